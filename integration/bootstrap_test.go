@@ -33,6 +33,16 @@ var bootstrapTestCases = []TableEntry{
 			false,
 			"",
 		}},
+	{
+		Description: "positive test: unix domain sockets",
+		Parameters: []interface{}{
+			"./integration/testdata/bootstrap_configuration_unix_sockets.yaml",
+			"./integration/testdata/keyer_configuration_complete_tech_spec.yaml",
+			"debug",
+			"validate",
+			false,
+			"",
+		}},
 	{
 		Description: "negative test: invalid mode",
 		Parameters: []interface{}{
@@ -154,6 +164,28 @@ var bootstrapTestCases = []TableEntry{
 			"invalid SocketAddress.Address: value must be a valid hostname, or ip address",
 		},
 	},
+	{
+		Description: "negative test: unix domain socket admin address",
+		Parameters: []interface{}{
+			"./integration/testdata/bootstrap_configuration_unix_socket_admin.yaml",
+			"./integration/testdata/keyer_configuration_complete_tech_spec.yaml",
+			"debug",
+			"serve",
+			true,
+			"invalid SocketAddress.Address: value must be a valid hostname, or ip address",
+		},
+	},
+	{
+		Description: "negative test: unix domain socket without path",
+		Parameters: []interface{}{
+			"./integration/testdata/bootstrap_configuration_unix_socket_missing_path.yaml",
+			"./integration/testdata/keyer_configuration_complete_tech_spec.yaml",
+			"debug",
+			"serve",
+			true,
+			"invalid SocketAddress.Address: unix:// must be followed by the path of a Unix domain socket",
+		},
+	},
 }
 
 var _ = Describe("Integration tests for bootstrapping xds-relay", func() {
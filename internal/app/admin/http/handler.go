@@ -1,12 +1,24 @@
 package handler
 
 import (
+	"archive/tar"
+	"compress/gzip"
+	"expvar"
 	"fmt"
 	"net/http"
+	"net/http/pprof" // #nosec
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/goroutines"
 	"github.com/envoyproxy/xds-relay/internal/pkg/util/stringify"
+	"github.com/envoyproxy/xds-relay/internal/pkg/version"
+	"github.com/golang/protobuf/proto"
 
 	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	"github.com/envoyproxy/xds-relay/internal/app/cache"
@@ -22,7 +34,13 @@ type Handler struct {
 	handler     http.HandlerFunc
 }
 
-func getHandlers(bootstrap *bootstrapv1.Bootstrap, orchestrator *orchestrator.Orchestrator) []Handler {
+func getHandlers(
+	bootstrap *bootstrapv1.Bootstrap,
+	orchestrator *orchestrator.Orchestrator,
+	recentLogs *log.Recent,
+	keyEvents *log.KeyEvents,
+	versionInfo version.Info,
+) []Handler {
 	handlers := []Handler{
 		{
 			"/",
@@ -31,23 +49,206 @@ func getHandlers(bootstrap *bootstrapv1.Bootstrap, orchestrator *orchestrator.Or
 		},
 		{
 			"/cache/",
-			"print cache entry for a given key. usage: `/cache/<key>`",
+			"print cache entry for a given key. usage: `/cache/<key>`, or `/cache/` for all entries",
 			cacheDumpHandler(orchestrator),
 		},
+		{
+			"/cache_keys",
+			"print the keys of the cache entries. usage: `/cache_keys` or `/cache_keys?prefix=<prefix>`",
+			cacheKeysHandler(orchestrator),
+		},
+		{
+			"/diff/",
+			"print the resources changed by the latest upstream response for a given key. usage: `/diff/<key>`",
+			lastDiffHandler(orchestrator),
+		},
+		{
+			"/shadow_diff/",
+			"print the differences between the shadow and origin server responses for a given key. " +
+				"usage: `/shadow_diff/<key>`",
+			shadowDiffHandler(orchestrator),
+		},
+		{
+			"/workers",
+			"print the state of the upstream worker of every aggregated key. " +
+				"usage: `/workers` or `/workers?tenant=<tenant>`",
+			workersHandler(orchestrator),
+		},
+		{
+			"/cache_stats",
+			"print when the cache entry of every aggregated key was populated and updated, and how often. " +
+				"usage: `/cache_stats`, `/cache_stats?stale=<duration>` for keys not updated within the duration, " +
+				"or `/cache_stats?min_rate=<updates>` for keys updated at least as many times per minute",
+			cacheStatsHandler(orchestrator),
+		},
+		{
+			"/alerts",
+			"print the active alerts raised by the alerting rules. usage: `/alerts` or `/alerts?key=<key>`",
+			alertsHandler(orchestrator),
+		},
+		{
+			"/lint",
+			"print the lint findings of the cached responses. usage: `/lint` or `/lint?key=<key>`",
+			lintHandler(orchestrator),
+		},
+		{
+			"/dependencies",
+			"print the aggregated keys referenced by the cached response of every aggregated key. " +
+				"usage: `/dependencies` or `/dependencies?key=<key>`",
+			dependenciesHandler(orchestrator),
+		},
+		{
+			"/simulation",
+			"print how the aggregated keys would change if the candidate aggregation rules were rolled out. " +
+				"usage: `/simulation` or `/simulation?key=<key>` for the nodes remapped from or to the key",
+			simulationHandler(orchestrator),
+		},
+		{
+			"/upstream_health",
+			"print the health of the upstream stream of every aggregated key. " +
+				"usage: `/upstream_health` or `/upstream_health?max_score=<score>` for streams scored at most as high",
+			upstreamHealthHandler(orchestrator),
+		},
+		{
+			"/watches",
+			"print the open downstream watches. usage: `/watches` or `/watches?key=<key>`",
+			watchesHandler(orchestrator),
+		},
+		{
+			"/watch_history",
+			"print the latest responses of each type sent on the open downstream streams, and whether their " +
+				"clients acknowledged them. usage: `/watch_history` or `/watch_history?node=<node ID>`",
+			watchHistoryHandler(orchestrator),
+		},
+		{
+			"/nodes",
+			"print the nodes connected to the relay. usage: `/nodes`, `/nodes?prefix=<node ID prefix>`, or " +
+				"`/nodes?cluster=<cluster>&region=<region>&zone=<zone>&user_agent=<name>&version=<version>` " +
+				"for the nodes matching every given filter",
+			nodesHandler(orchestrator),
+		},
+		{
+			"/node_versions",
+			"print the number of connected nodes of each user agent and version",
+			nodeVersionsHandler(orchestrator),
+		},
+		{
+			"/rollouts",
+			"print the rollouts of new versions that are only served to canary nodes",
+			rolloutsHandler(orchestrator),
+		},
+		{
+			"/rollouts/",
+			"serve the canary version of a given key to every node. usage: `POST /rollouts/<key>`",
+			promoteRolloutHandler(orchestrator),
+		},
+		{
+			"/keys/",
+			"pin the version served for a given key, roll it back to a version in its history, unpin it, " +
+				"resume its fanout paused by the change rate guard, print its latest logged events, or print its " +
+				"served and upstream versions, watches, ACK ratio, and last error. " +
+				"usage: `POST /keys/<key>/pin?version=<version>`, `POST /keys/<key>/rollback?to=<version>`, " +
+				"`POST /keys/<key>/unpin`, `POST /keys/<key>/resume`, `/keys/<key>/pin`, `/keys/<key>/rollback`, " +
+				"`/keys/<key>/events`, or `/keys/<key>/status`",
+			keysHandler(orchestrator, keyEvents),
+		},
+		{
+			"/rollbacks",
+			"print the rolled back keys, and how their upstream diverged from the rolled back version",
+			rollbacksHandler(orchestrator),
+		},
+		{
+			"/history/",
+			"print the versions held for a given key, one of them, or the differences between two of them. " +
+				"usage: `/history/<key>`, `/history/<key>?version=<version>`, " +
+				"or `/history/<key>?from=<version>&to=<version>`",
+			historyHandler(orchestrator),
+		},
+		{
+			"/debug/goroutines",
+			"print the number of goroutines of each subsystem, and the goroutines blocked for a minute or more",
+			goroutinesHandler(orchestrator),
+		},
+		{
+			"/debug/bundle",
+			"download a support bundle of the sanitized bootstrap configuration, cache stats, watches, " +
+				"upstream streams, goroutines, recent logs, and build info, to attach to bug reports",
+			bundleHandler(bootstrap, orchestrator, recentLogs, versionInfo),
+		},
 		{
 			"/server_info",
 			"print bootstrap configuration",
 			configDumpHandler(bootstrap),
 		},
+		{
+			"/version",
+			"print the version, git SHA, and build time of the relay, and the fingerprints of its configuration",
+			versionHandler(versionInfo),
+		},
+	}
+	if bootstrap.GetAdmin().GetEnableDebugEndpoints() {
+		handlers = append(handlers, getDebugHandlers()...)
 	}
 	// The default handler is defined later to avoid infinite recursion.
 	handlers[0].handler = defaultHandler(handlers)
 	return handlers
 }
 
-func RegisterHandlers(bootstrapConfig *bootstrapv1.Bootstrap, orchestrator *orchestrator.Orchestrator) {
-	for _, handler := range getHandlers(bootstrapConfig, orchestrator) {
-		http.Handle(handler.prefix, handler.handler)
+// getDebugHandlers returns the handlers of pprof, expvar, and runtime statistics.
+func getDebugHandlers() []Handler {
+	return []Handler{
+		{
+			"/debug/pprof/",
+			"serve pprof profiles. usage: `/debug/pprof/<profile>`",
+			pprof.Index,
+		},
+		{
+			"/debug/pprof/cmdline",
+			"print the command line of the relay",
+			pprof.Cmdline,
+		},
+		{
+			"/debug/pprof/profile",
+			"serve a CPU profile. usage: `/debug/pprof/profile?seconds=<seconds>`",
+			pprof.Profile,
+		},
+		{
+			"/debug/pprof/symbol",
+			"look up the functions of program counters",
+			pprof.Symbol,
+		},
+		{
+			"/debug/pprof/trace",
+			"serve an execution trace. usage: `/debug/pprof/trace?seconds=<seconds>`",
+			pprof.Trace,
+		},
+		{
+			"/debug/vars",
+			"print expvar variables",
+			expvar.Handler().ServeHTTP,
+		},
+		{
+			"/debug/runtime",
+			"print heap, garbage collection, and goroutine statistics",
+			runtimeStatsHandler,
+		},
+	}
+}
+
+// RegisterHandlers registers the admin handlers with the mux. Support bundles
+// include the messages kept by recentLogs, the events of keys are the messages
+// kept by keyEvents, both of which may be nil, and versionInfo is the build and
+// configuration reported by the relay.
+func RegisterHandlers(
+	mux *http.ServeMux,
+	bootstrapConfig *bootstrapv1.Bootstrap,
+	orchestrator *orchestrator.Orchestrator,
+	recentLogs *log.Recent,
+	keyEvents *log.KeyEvents,
+	versionInfo version.Info,
+) {
+	for _, handler := range getHandlers(bootstrapConfig, orchestrator, recentLogs, keyEvents, versionInfo) {
+		mux.Handle(handler.prefix, handler.handler)
 	}
 }
 
@@ -66,6 +267,18 @@ func defaultHandler(handlers []Handler) http.HandlerFunc {
 	}
 }
 
+func versionHandler(versionInfo version.Info) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		versionString, err := stringify.InterfaceToString(versionInfo)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert version to string.\n")
+			return
+		}
+		fmt.Fprint(w, versionString)
+	}
+}
+
 func configDumpHandler(bootstrapConfig *bootstrapv1.Bootstrap) http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		configString, err := stringify.InterfaceToString(bootstrapConfig)
@@ -76,7 +289,6 @@ func configDumpHandler(bootstrapConfig *bootstrapv1.Bootstrap) http.HandlerFunc
 	}
 }
 
-// TODO(lisalu): Support dump of entire cache when no key is provided.
 // TODO(lisalu): Support dump of matching resources when cache key regex is provided.
 func cacheDumpHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
@@ -85,8 +297,24 @@ func cacheDumpHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
 			w.WriteHeader(http.StatusBadRequest)
 			fmt.Fprintf(w, "unable to parse cache key from path: %s", err.Error())
 		}
-		cache := orchestrator.Orchestrator.GetReadOnlyCache(*o)
-		resource, err := cache.FetchReadOnly(cacheKey)
+		readOnlyCache := orchestrator.Orchestrator.GetReadOnlyCache(*o)
+		if cacheKey == "" {
+			// Dump the entire cache when no key is provided.
+			resources := make(map[string]*marshallableResource)
+			readOnlyCache.Range(func(key string, resource cache.Resource) bool {
+				resources[key] = newMarshallableResource(resource)
+				return true
+			})
+			resourcesString, err := stringify.InterfaceToString(resources)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "unable to convert resources to string.\n")
+				return
+			}
+			fmt.Fprint(w, resourcesString)
+			return
+		}
+		resource, err := readOnlyCache.FetchReadOnly(cacheKey)
 		if err != nil {
 			fmt.Fprintf(w, "no resource for key %s found in cache.\n", cacheKey)
 			return
@@ -101,29 +329,790 @@ func cacheDumpHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
 	}
 }
 
+func cacheKeysHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		readOnlyCache := orchestrator.Orchestrator.GetReadOnlyCache(*o)
+		keys := readOnlyCache.Keys(req.URL.Query().Get("prefix"))
+		keysString, err := stringify.InterfaceToString(keys)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert keys to string.\n")
+			return
+		}
+		fmt.Fprint(w, keysString)
+	}
+}
+
+func lastDiffHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		cacheKey, err := getCacheKeyParam(req.URL.Path)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "unable to parse cache key from path: %s", err.Error())
+			return
+		}
+		summary, ok := orchestrator.Orchestrator.GetLastDiff(*o, cacheKey)
+		if !ok {
+			fmt.Fprintf(w, "no diff for key %s found.\n", cacheKey)
+			return
+		}
+		summaryString, err := stringify.InterfaceToString(summary)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert diff to string.\n")
+			return
+		}
+		fmt.Fprint(w, summaryString)
+	}
+}
+
+func shadowDiffHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		cacheKey, err := getCacheKeyParam(req.URL.Path)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "unable to parse cache key from path: %s", err.Error())
+			return
+		}
+		summary, ok := orchestrator.Orchestrator.GetShadowDiff(*o, cacheKey)
+		if !ok {
+			fmt.Fprintf(w, "no shadow diff for key %s found.\n", cacheKey)
+			return
+		}
+		summaryString, err := stringify.InterfaceToString(summary)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert diff to string.\n")
+			return
+		}
+		fmt.Fprint(w, summaryString)
+	}
+}
+
+func workersHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		workers := orchestrator.Orchestrator.GetWorkers(*o)
+		if tenant := req.URL.Query().Get("tenant"); tenant != "" {
+			var tenantWorkers []orchestrator.WorkerStatus
+			for _, worker := range workers {
+				if worker.Tenant == tenant {
+					tenantWorkers = append(tenantWorkers, worker)
+				}
+			}
+			workers = tenantWorkers
+		}
+		workersString, err := stringify.InterfaceToString(workers)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert workers to string.\n")
+			return
+		}
+		fmt.Fprint(w, workersString)
+	}
+}
+
+func cacheStatsHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var stale time.Duration
+		if param := req.URL.Query().Get("stale"); param != "" {
+			var err error
+			if stale, err = time.ParseDuration(param); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "unable to parse stale duration: %s\n", err.Error())
+				return
+			}
+		}
+		var minRate float64
+		if param := req.URL.Query().Get("min_rate"); param != "" {
+			var err error
+			if minRate, err = strconv.ParseFloat(param, 64); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "unable to parse min rate: %s\n", err.Error())
+				return
+			}
+		}
+		var statuses []orchestrator.CacheStatus
+		for _, status := range orchestrator.Orchestrator.GetCacheStatuses(*o) {
+			if stale > 0 && time.Since(status.LastUpdated) < stale {
+				continue
+			}
+			if status.UpdateRate < minRate {
+				continue
+			}
+			statuses = append(statuses, status)
+		}
+		statusesString, err := stringify.InterfaceToString(statuses)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert cache stats to string.\n")
+			return
+		}
+		fmt.Fprint(w, statusesString)
+	}
+}
+
+func upstreamHealthHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		maxScore := 1.0
+		if param := req.URL.Query().Get("max_score"); param != "" {
+			var err error
+			if maxScore, err = strconv.ParseFloat(param, 64); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "unable to parse max score: %s\n", err.Error())
+				return
+			}
+		}
+		var statuses []orchestrator.UpstreamHealthStatus
+		for _, status := range orchestrator.Orchestrator.GetUpstreamHealth(*o) {
+			if status.Score <= maxScore {
+				statuses = append(statuses, status)
+			}
+		}
+		statusesString, err := stringify.InterfaceToString(statuses)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert upstream health to string.\n")
+			return
+		}
+		fmt.Fprint(w, statusesString)
+	}
+}
+
+func alertsHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		alerts := orchestrator.Orchestrator.GetAlerts(*o)
+		if key := req.URL.Query().Get("key"); key != "" {
+			var keyAlerts []orchestrator.Alert
+			for _, alert := range alerts {
+				if alert.Key == key {
+					keyAlerts = append(keyAlerts, alert)
+				}
+			}
+			alerts = keyAlerts
+		}
+		alertsString, err := stringify.InterfaceToString(alerts)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert alerts to string.\n")
+			return
+		}
+		fmt.Fprint(w, alertsString)
+	}
+}
+
+func lintHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		findings := orchestrator.Orchestrator.GetLintFindings(*o)
+		if key := req.URL.Query().Get("key"); key != "" {
+			var keyFindings []orchestrator.LintFinding
+			for _, finding := range findings {
+				if finding.Key == key {
+					keyFindings = append(keyFindings, finding)
+				}
+			}
+			findings = keyFindings
+		}
+		findingsString, err := stringify.InterfaceToString(findings)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert lint findings to string.\n")
+			return
+		}
+		fmt.Fprint(w, findingsString)
+	}
+}
+
+func dependenciesHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		dependencies := orchestrator.Orchestrator.GetDependencies(*o)
+		if key := req.URL.Query().Get("key"); key != "" {
+			var keyDependencies []orchestrator.KeyDependencies
+			for _, dependency := range dependencies {
+				if dependency.Key == key {
+					keyDependencies = append(keyDependencies, dependency)
+				}
+			}
+			dependencies = keyDependencies
+		}
+		dependenciesString, err := stringify.InterfaceToString(dependencies)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert dependencies to string.\n")
+			return
+		}
+		fmt.Fprint(w, dependenciesString)
+	}
+}
+
+func simulationHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		summary, ok := orchestrator.Orchestrator.GetSimulation(*o)
+		if !ok {
+			fmt.Fprintf(w, "no candidate aggregation rules are simulated.\n")
+			return
+		}
+		if key := req.URL.Query().Get("key"); key != "" {
+			var remapped []orchestrator.RemappedNode
+			for _, node := range summary.Remapped {
+				if node.ActiveKey == key || node.CandidateKey == key {
+					remapped = append(remapped, node)
+				}
+			}
+			summary.Remapped = remapped
+		}
+		summaryString, err := stringify.InterfaceToString(summary)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert simulation to string.\n")
+			return
+		}
+		fmt.Fprint(w, summaryString)
+	}
+}
+
+func watchesHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		watches := orchestrator.Orchestrator.GetWatches(*o)
+		if key := req.URL.Query().Get("key"); key != "" {
+			var keyWatches []orchestrator.WatchStatus
+			for _, watch := range watches {
+				if watch.Key == key {
+					keyWatches = append(keyWatches, watch)
+				}
+			}
+			watches = keyWatches
+		}
+		watchesString, err := stringify.InterfaceToString(watches)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert watches to string.\n")
+			return
+		}
+		fmt.Fprint(w, watchesString)
+	}
+}
+
+func watchHistoryHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		histories := orchestrator.Orchestrator.GetWatchHistories(*o)
+		if nodeID := req.URL.Query().Get("node"); nodeID != "" {
+			var nodeHistories []orchestrator.WatchHistory
+			for _, history := range histories {
+				if history.NodeID == nodeID {
+					nodeHistories = append(nodeHistories, history)
+				}
+			}
+			histories = nodeHistories
+		}
+		historiesString, err := stringify.InterfaceToString(histories)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert watch history to string.\n")
+			return
+		}
+		fmt.Fprint(w, historiesString)
+	}
+}
+
+func nodesHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		var nodes []orchestrator.NodeStatus
+		for _, node := range orchestrator.Orchestrator.GetNodes(*o) {
+			if !strings.HasPrefix(node.ID, query.Get("prefix")) {
+				continue
+			}
+			matches := true
+			for param, value := range map[string]string{
+				"cluster":    node.Cluster,
+				"region":     node.Region,
+				"zone":       node.Zone,
+				"user_agent": node.UserAgentName,
+				"version":    node.UserAgentVersion,
+			} {
+				if filter, ok := query[param]; ok && filter[0] != value {
+					matches = false
+				}
+			}
+			if matches {
+				nodes = append(nodes, node)
+			}
+		}
+		nodesString, err := stringify.InterfaceToString(nodes)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert nodes to string.\n")
+			return
+		}
+		fmt.Fprint(w, nodesString)
+	}
+}
+
+// nodeVersion is the number of connected nodes of a user agent and version.
+type nodeVersion struct {
+	UserAgentName    string
+	UserAgentVersion string
+	Nodes            int
+}
+
+func nodeVersionsHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		counts := make(map[nodeVersion]int)
+		for _, node := range orchestrator.Orchestrator.GetNodes(*o) {
+			counts[nodeVersion{UserAgentName: node.UserAgentName, UserAgentVersion: node.UserAgentVersion}]++
+		}
+		versions := make([]nodeVersion, 0, len(counts))
+		for version, count := range counts {
+			version.Nodes = count
+			versions = append(versions, version)
+		}
+		sort.Slice(versions, func(i, j int) bool {
+			if versions[i].UserAgentName != versions[j].UserAgentName {
+				return versions[i].UserAgentName < versions[j].UserAgentName
+			}
+			return versions[i].UserAgentVersion < versions[j].UserAgentVersion
+		})
+		versionsString, err := stringify.InterfaceToString(versions)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert node versions to string.\n")
+			return
+		}
+		fmt.Fprint(w, versionsString)
+	}
+}
+
+func rolloutsHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		rolloutsString, err := stringify.InterfaceToString(orchestrator.Orchestrator.GetRollouts(*o))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert rollouts to string.\n")
+			return
+		}
+		fmt.Fprint(w, rolloutsString)
+	}
+}
+
+func promoteRolloutHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			fmt.Fprintf(w, "rollouts are promoted with POST.\n")
+			return
+		}
+		cacheKey, err := getCacheKeyParam(req.URL.Path)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "unable to parse cache key from path: %s", err.Error())
+			return
+		}
+		if !orchestrator.Orchestrator.PromoteRollout(*o, cacheKey) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, "no rollout for key %s found.\n", cacheKey)
+			return
+		}
+		fmt.Fprintf(w, "rollout for key %s promoted.\n", cacheKey)
+	}
+}
+
+// keysHandler serves the endpoints of a given key. The endpoints that inspect
+// the key are served by keyStatusHandler and keyEventsHandler, and the
+// endpoints that change the version served for the key by pinHandler.
+func keysHandler(o *orchestrator.Orchestrator, keyEvents *log.KeyEvents) http.HandlerFunc {
+	pin := pinHandler(o)
+	status := keyStatusHandler(o)
+	events := keyEventsHandler(keyEvents)
+	return func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/status"):
+			status(w, req)
+		case strings.HasSuffix(req.URL.Path, "/events"):
+			events(w, req)
+		default:
+			pin(w, req)
+		}
+	}
+}
+
+func pinHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, "/keys/")
+		switch {
+		case strings.HasSuffix(path, "/pin") && req.Method == http.MethodPost:
+			cacheKey := strings.TrimSuffix(path, "/pin")
+			version := req.URL.Query().Get("version")
+			if err := orchestrator.Orchestrator.PinVersion(*o, cacheKey, version); err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintf(w, "unable to pin key %s: %s\n", cacheKey, err.Error())
+				return
+			}
+			version, _ = orchestrator.Orchestrator.GetPinnedVersion(*o, cacheKey)
+			fmt.Fprintf(w, "key %s pinned to version %s.\n", cacheKey, version)
+		case strings.HasSuffix(path, "/pin"):
+			cacheKey := strings.TrimSuffix(path, "/pin")
+			version, ok := orchestrator.Orchestrator.GetPinnedVersion(*o, cacheKey)
+			if !ok {
+				fmt.Fprintf(w, "key %s is not pinned.\n", cacheKey)
+				return
+			}
+			fmt.Fprintf(w, "key %s is pinned to version %s.\n", cacheKey, version)
+		case strings.HasSuffix(path, "/rollback") && req.Method == http.MethodPost:
+			cacheKey := strings.TrimSuffix(path, "/rollback")
+			version := req.URL.Query().Get("to")
+			if version == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "the version to roll back to is required. usage: `?to=<version>`\n")
+				return
+			}
+			if err := orchestrator.Orchestrator.RollBack(*o, cacheKey, version); err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintf(w, "unable to roll back key %s: %s\n", cacheKey, err.Error())
+				return
+			}
+			fmt.Fprintf(w, "key %s rolled back to version %s, and pinned until unpinned.\n", cacheKey, version)
+		case strings.HasSuffix(path, "/rollback"):
+			cacheKey := strings.TrimSuffix(path, "/rollback")
+			status, ok := orchestrator.Orchestrator.GetRollback(*o, cacheKey)
+			if !ok {
+				fmt.Fprintf(w, "key %s is not rolled back.\n", cacheKey)
+				return
+			}
+			statusString, err := stringify.InterfaceToString(status)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "unable to convert rollback to string.\n")
+				return
+			}
+			fmt.Fprint(w, statusString)
+		case strings.HasSuffix(path, "/unpin") && req.Method == http.MethodPost:
+			cacheKey := strings.TrimSuffix(path, "/unpin")
+			if !orchestrator.Orchestrator.UnpinVersion(*o, cacheKey) {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintf(w, "key %s is not pinned.\n", cacheKey)
+				return
+			}
+			fmt.Fprintf(w, "key %s unpinned.\n", cacheKey)
+		case strings.HasSuffix(path, "/unpin"):
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			fmt.Fprintf(w, "keys are unpinned with POST.\n")
+		case strings.HasSuffix(path, "/resume") && req.Method == http.MethodPost:
+			cacheKey := strings.TrimSuffix(path, "/resume")
+			if !orchestrator.Orchestrator.ResumeFanout(*o, cacheKey) {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintf(w, "fanout of key %s is not paused.\n", cacheKey)
+				return
+			}
+			fmt.Fprintf(w, "fanout of key %s resumed.\n", cacheKey)
+		case strings.HasSuffix(path, "/resume"):
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			fmt.Fprintf(w, "fanout is resumed with POST.\n")
+		default:
+			http.NotFound(w, req)
+		}
+	}
+}
+
+func keyStatusHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		cacheKey := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/keys/"), "/status")
+		status, ok := orchestrator.Orchestrator.GetKeyStatus(*o, cacheKey)
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, "key %s is not watched.\n", cacheKey)
+			return
+		}
+		statusString, err := stringify.InterfaceToString(status)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert status to string.\n")
+			return
+		}
+		fmt.Fprint(w, statusString)
+	}
+}
+
+func keyEventsHandler(keyEvents *log.KeyEvents) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if keyEvents == nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, "events of keys are not kept. set logging.key_events_size in the bootstrap to keep them.\n")
+			return
+		}
+		cacheKey := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/keys/"), "/events")
+		eventsString, err := stringify.InterfaceToString(keyEvents.Events(cacheKey))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert events to string.\n")
+			return
+		}
+		fmt.Fprint(w, eventsString)
+	}
+}
+
+func rollbacksHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		rollbacksString, err := stringify.InterfaceToString(orchestrator.Orchestrator.GetRollbacks(*o))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert rollbacks to string.\n")
+			return
+		}
+		fmt.Fprint(w, rollbacksString)
+	}
+}
+
+func historyHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		cacheKey, err := getCacheKeyParam(req.URL.Path)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "unable to parse cache key from path: %s", err.Error())
+			return
+		}
+		query := req.URL.Query()
+		var history interface{}
+		switch {
+		case query.Get("version") != "":
+			version := query.Get("version")
+			resp, ok := orchestrator.Orchestrator.GetHistoricalResponse(*o, cacheKey, version)
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintf(w, "version %s of key %s not found.\n", version, cacheKey)
+				return
+			}
+			history = resp
+		case query.Get("from") != "" || query.Get("to") != "":
+			summary, err := orchestrator.Orchestrator.DiffHistory(*o, cacheKey, query.Get("from"), query.Get("to"))
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintf(w, "unable to diff key %s: %s\n", cacheKey, err.Error())
+				return
+			}
+			history = summary
+		default:
+			history = orchestrator.Orchestrator.GetHistory(*o, cacheKey)
+		}
+		historyString, err := stringify.InterfaceToString(history)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert history to string.\n")
+			return
+		}
+		fmt.Fprint(w, historyString)
+	}
+}
+
+// goroutineSummary is the goroutine accounting of the orchestrator, along with
+// the goroutines of the process that have been blocked for long enough to be
+// leaked or deadlocked.
+type goroutineSummary struct {
+	orchestrator.GoroutineSummary
+	Blocked []goroutines.Blocked
+}
+
+func goroutinesHandler(o *orchestrator.Orchestrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		summary := goroutineSummary{
+			GoroutineSummary: orchestrator.Orchestrator.GetGoroutines(*o),
+			Blocked:          goroutines.FindBlocked(goroutines.Dump(), 1),
+		}
+		summaryString, err := stringify.InterfaceToString(summary)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "unable to convert goroutines to string.\n")
+			return
+		}
+		fmt.Fprint(w, summaryString)
+	}
+}
+
+// runtimeStats are the statistics printed by the `/debug/runtime` handler.
+type runtimeStats struct {
+	Goroutines   int
+	GOMAXPROCS   int
+	HeapAlloc    uint64
+	HeapInuse    uint64
+	HeapObjects  uint64
+	Sys          uint64
+	NumGC        uint32
+	PauseTotalNs uint64
+	LastGC       time.Time
+}
+
+func runtimeStatsHandler(w http.ResponseWriter, req *http.Request) {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	stats := runtimeStats{
+		Goroutines:   runtime.NumGoroutine(),
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+		HeapAlloc:    memStats.HeapAlloc,
+		HeapInuse:    memStats.HeapInuse,
+		HeapObjects:  memStats.HeapObjects,
+		Sys:          memStats.Sys,
+		NumGC:        memStats.NumGC,
+		PauseTotalNs: memStats.PauseTotalNs,
+		LastGC:       time.Unix(0, int64(memStats.LastGC)),
+	}
+	statsString, err := stringify.InterfaceToString(stats)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "unable to convert runtime stats to string.\n")
+		return
+	}
+	fmt.Fprint(w, statsString)
+}
+
+// redacted replaces the secrets of the bootstrap configuration in support
+// bundles.
+const redacted = "[redacted]"
+
+// buildInfo is the build of the relay in support bundles.
+type buildInfo struct {
+	version.Info
+	OS   string
+	Arch string
+	Deps []string
+}
+
+func newBuildInfo(versionInfo version.Info) buildInfo {
+	info := buildInfo{Info: versionInfo, OS: runtime.GOOS, Arch: runtime.GOARCH}
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range build.Deps {
+			info.Deps = append(info.Deps, dep.Path+"@"+dep.Version)
+		}
+	}
+	return info
+}
+
+// sanitizeBootstrap returns a copy of the bootstrap configuration without the
+// secrets it may hold inline: the values of the metadata of the upstream
+// streams, and the arguments and environment of credential commands. Paths
+// of files holding secrets are kept.
+func sanitizeBootstrap(bootstrapConfig *bootstrapv1.Bootstrap) *bootstrapv1.Bootstrap {
+	sanitized := proto.Clone(bootstrapConfig).(*bootstrapv1.Bootstrap)
+	for _, upstream := range []*bootstrapv1.Upstream{
+		sanitized.GetOriginServer(),
+		sanitized.GetShadowServer(),
+		sanitized.GetParentRelay().GetUpstream(),
+	} {
+		for _, metadata := range upstream.GetMetadata() {
+			switch metadata.GetValueSpecifier().(type) {
+			case *bootstrapv1.Upstream_Metadata_Value:
+				metadata.ValueSpecifier = &bootstrapv1.Upstream_Metadata_Value{Value: redacted}
+			case *bootstrapv1.Upstream_Metadata_Template:
+				metadata.ValueSpecifier = &bootstrapv1.Upstream_Metadata_Template{Template: redacted}
+			}
+		}
+		if exec := upstream.GetCredentials().GetExec(); exec != nil {
+			for i := range exec.Args {
+				exec.Args[i] = redacted
+			}
+			for name := range exec.Env {
+				exec.Env[name] = redacted
+			}
+		}
+	}
+	return sanitized
+}
+
+// bundleHandler serves a gzipped tarball of the state of the relay, with one
+// JSON file per section. Sections that fail to convert hold the error.
+func bundleHandler(
+	bootstrapConfig *bootstrapv1.Bootstrap,
+	o *orchestrator.Orchestrator,
+	recentLogs *log.Recent,
+	versionInfo version.Info,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			fmt.Fprintf(w, "support bundles are downloaded with GET.\n")
+			return
+		}
+		var logs []log.Entry
+		if recentLogs != nil {
+			logs = recentLogs.Entries()
+		}
+		sections := []struct {
+			name    string
+			content interface{}
+		}{
+			{"build_info.json", newBuildInfo(versionInfo)},
+			{"bootstrap.json", sanitizeBootstrap(bootstrapConfig)},
+			{"cache_stats.json", orchestrator.Orchestrator.GetCacheStatuses(*o)},
+			{"watches.json", orchestrator.Orchestrator.GetWatches(*o)},
+			{"workers.json", orchestrator.Orchestrator.GetWorkers(*o)},
+			{"upstream_health.json", orchestrator.Orchestrator.GetUpstreamHealth(*o)},
+			{"goroutines.json", goroutineSummary{
+				GoroutineSummary: orchestrator.Orchestrator.GetGoroutines(*o),
+				Blocked:          goroutines.FindBlocked(goroutines.Dump(), 1),
+			}},
+			{"logs.json", logs},
+		}
+
+		now := time.Now()
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition",
+			fmt.Sprintf("attachment; filename=\"xds-relay-bundle-%s.tar.gz\"", now.UTC().Format("20060102T150405Z")))
+		gzipWriter := gzip.NewWriter(w)
+		tarWriter := tar.NewWriter(gzipWriter)
+		for _, section := range sections {
+			content, err := stringify.InterfaceToString(section.content)
+			if err != nil {
+				content = fmt.Sprintf("unable to convert %s to string: %s\n", section.name, err.Error())
+			}
+			header := &tar.Header{Name: section.name, Mode: 0644, Size: int64(len(content)), ModTime: now}
+			if err := tarWriter.WriteHeader(header); err != nil {
+				return
+			}
+			if _, err := tarWriter.Write([]byte(content)); err != nil {
+				return
+			}
+		}
+		if err := tarWriter.Close(); err != nil {
+			return
+		}
+		_ = gzipWriter.Close()
+	}
+}
+
 type marshallableResource struct {
 	Resp           *v2.DiscoveryResponse
 	Requests       []*v2.DiscoveryRequest
 	ExpirationTime time.Time
+	FirstSeen      time.Time
+	LastUpdated    time.Time
+	UpdateCount    uint64
 }
 
 // In order to marshal a Resource from the cache to JSON to be printed,
-// the map of requests is converted to a slice of just the keys,
-// since the bool value is meaningless.
+// the map of requests is converted to a slice of just the values, ordered
+// by watch ID.
 // TODO(lisalu): More intelligent unmarshalling of DiscoveryResponse.
 func resourceToString(resource cache.Resource) (string, error) {
+	return stringify.InterfaceToString(newMarshallableResource(resource))
+}
+
+func newMarshallableResource(resource cache.Resource) *marshallableResource {
+	ids := make([]cache.WatchID, 0, len(resource.Requests))
+	for id := range resource.Requests {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
 	var requests []*v2.DiscoveryRequest
-	for request := range resource.Requests {
-		requests = append(requests, request)
+	for _, id := range ids {
+		requests = append(requests, resource.Requests[id])
 	}
 
-	resourceString := &marshallableResource{
+	return &marshallableResource{
 		Resp:           resource.Resp,
 		Requests:       requests,
 		ExpirationTime: resource.ExpirationTime,
+		FirstSeen:      resource.FirstSeen,
+		LastUpdated:    resource.LastUpdated,
+		UpdateCount:    resource.UpdateCount,
 	}
-
-	return stringify.InterfaceToString(resourceString)
 }
 
 func getCacheKeyParam(path string) (string, error) {
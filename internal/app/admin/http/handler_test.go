@@ -1,18 +1,27 @@
 package handler
 
 import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/envoyproxy/xds-relay/internal/app/mapper"
 	"github.com/envoyproxy/xds-relay/internal/app/orchestrator"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/version"
 
 	"github.com/uber-go/tally"
 
 	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
 	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/stretchr/testify/assert"
 )
@@ -79,6 +88,34 @@ func TestAdminServer_ConfigDumpHandler(t *testing.T) {
 		rr.Body.String())
 }
 
+func TestAdminServer_VersionHandler(t *testing.T) {
+	req, err := http.NewRequest("GET", "/version", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler := versionHandler(version.Info{
+		Version:                "v1.0.0",
+		GitSHA:                 "abc123",
+		BuildTime:              "2020-06-01T00:00:00Z",
+		GoVersion:              "go1.14.1",
+		BootstrapFingerprint:   "bootstrap",
+		AggregationFingerprint: "aggregation",
+	})
+
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t,
+		`{
+  "Version": "v1.0.0",
+  "GitSHA": "abc123",
+  "BuildTime": "2020-06-01T00:00:00Z",
+  "GoVersion": "go1.14.1",
+  "BootstrapFingerprint": "bootstrap",
+  "AggregationFingerprint": "aggregation"
+}`,
+		rr.Body.String())
+}
+
 type mockSimpleUpstreamClient struct {
 	responseChan <-chan *v2.DiscoveryResponse
 }
@@ -156,6 +193,71 @@ func TestAdminServer_CacheDumpHandler_NotFound(t *testing.T) {
 	assert.Equal(t, "no resource for key cds found in cache.\n", rr.Body.String())
 }
 
+func TestAdminServer_CacheDumpHandler_All(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	defer cancelWatch()
+	upstreamResponseChannel <- &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	<-respChannel
+
+	req, err := http.NewRequest("GET", "/cache/", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler := cacheDumpHandler(&orchestrator)
+
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `{
+  "lds": {
+    "Resp": {
+      "version_info": "1",
+      "type_url": "type.googleapis.com/envoy.api.v2.Listener"
+    },`)
+}
+
+func TestAdminServer_CacheKeysHandler(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	for _, typeURL := range []string{
+		"type.googleapis.com/envoy.api.v2.Listener",
+		"type.googleapis.com/envoy.api.v2.Cluster",
+	} {
+		_, cancelWatch := orchestrator.CreateWatch(gcp.Request{TypeUrl: typeURL})
+		defer cancelWatch()
+	}
+
+	handler := cacheKeysHandler(&orchestrator)
+	req, err := http.NewRequest("GET", "/cache_keys", nil)
+	assert.NoError(t, err)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "[\n  \"cds\",\n  \"lds\"\n]", rr.Body.String())
+
+	req, err = http.NewRequest("GET", "/cache_keys?prefix=l", nil)
+	assert.NoError(t, err)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, "[\n  \"lds\"\n]", rr.Body.String())
+}
+
 func TestGetCacheKeyParam(t *testing.T) {
 	path := "127.0.0.1:6070/cache/foo_production_*"
 	cacheKey, err := getCacheKeyParam(path)
@@ -176,3 +278,744 @@ func TestGetCacheKeyParam_Malformed(t *testing.T) {
 	assert.Error(t, err, "")
 	assert.Equal(t, "", cacheKey)
 }
+
+func TestAdminServer_LastDiffHandler(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	gcpReq := gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	respChannel, _ := orchestrator.CreateWatch(gcpReq)
+
+	listener, err := ptypes.MarshalAny(&v2.Listener{Name: "listener_A"})
+	assert.NoError(t, err)
+	resp := v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+		Resources:   []*any.Any{listener},
+	}
+	upstreamResponseChannel <- &resp
+	<-respChannel
+
+	req, err := http.NewRequest("GET", "/diff/lds", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler := lastDiffHandler(&orchestrator)
+
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `{
+  "TypeURL": "type.googleapis.com/envoy.api.v2.Listener",
+  "PreviousVersion": "",
+  "Version": "1",
+  "Added": [
+    "listener_A"
+  ],
+  "Removed": null,
+  "Modified": null,
+  "Time": "`)
+}
+
+func TestAdminServer_LastDiffHandler_NotFound(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	req, err := http.NewRequest("GET", "/diff/cds", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler := lastDiffHandler(&orchestrator)
+
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "no diff for key cds found.\n", rr.Body.String())
+}
+
+func TestAdminServer_ShadowDiffHandler_NotFound(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	req, err := http.NewRequest("GET", "/shadow_diff/cds", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler := shadowDiffHandler(&orchestrator)
+
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "no shadow diff for key cds found.\n", rr.Body.String())
+}
+
+func TestAdminServer_WorkersHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	_, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	defer cancelWatch()
+
+	req, err := http.NewRequest("GET", "/workers", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler := workersHandler(&orchestrator)
+
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"Key": "lds",
+    "Tenant": "",
+    "State": "running",
+    "Restarts": 0,
+    "LastError": "",`)
+}
+
+func TestAdminServer_WorkersHandler_Tenant(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	_, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	defer cancelWatch()
+
+	req, err := http.NewRequest("GET", "/workers?tenant=a", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler := workersHandler(&orchestrator)
+
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "null", rr.Body.String())
+}
+
+func TestAdminServer_CacheStatsHandler(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	defer cancelWatch()
+	upstreamResponseChannel <- &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	<-respChannel
+
+	handler := cacheStatsHandler(&orchestrator)
+	req, err := http.NewRequest("GET", "/cache_stats", nil)
+	assert.NoError(t, err)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"Key": "lds",`)
+	assert.Contains(t, rr.Body.String(), `"UpdateCount": 1,`)
+
+	// The key was just updated, so it is neither stale nor updated a hundred
+	// times per minute.
+	for _, query := range []string{"stale=1h", "min_rate=100"} {
+		req, err = http.NewRequest("GET", "/cache_stats?"+query, nil)
+		assert.NoError(t, err)
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "null", rr.Body.String())
+	}
+
+	req, err = http.NewRequest("GET", "/cache_stats?stale=soon", nil)
+	assert.NoError(t, err)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestAdminServer_AlertsHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	req, err := http.NewRequest("GET", "/alerts?key=lds", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler := alertsHandler(&orchestrator)
+
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "null", rr.Body.String())
+}
+
+func TestAdminServer_LintHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	req, err := http.NewRequest("GET", "/lint?key=lds", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler := lintHandler(&orchestrator)
+
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "null", rr.Body.String())
+}
+
+func TestAdminServer_DependenciesHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	req, err := http.NewRequest("GET", "/dependencies?key=rds", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler := dependenciesHandler(&orchestrator)
+
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "null", rr.Body.String())
+}
+
+func TestAdminServer_SimulationHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	req, err := http.NewRequest("GET", "/simulation?key=lds", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler := simulationHandler(&orchestrator)
+
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "no candidate aggregation rules are simulated.\n", rr.Body.String())
+}
+
+func TestAdminServer_UpstreamHealthHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	handler := upstreamHealthHandler(&orchestrator)
+	req, err := http.NewRequest("GET", "/upstream_health?max_score=0.5", nil)
+	assert.NoError(t, err)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "null", rr.Body.String())
+
+	req, err = http.NewRequest("GET", "/upstream_health?max_score=low", nil)
+	assert.NoError(t, err)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestAdminServer_WatchesHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	_, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+		VersionInfo: "1",
+		Node:        &core.Node{Id: "node"},
+	})
+	defer cancelWatch()
+
+	req, err := http.NewRequest("GET", "/watches?key=lds", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler := watchesHandler(&orchestrator)
+
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"ID": 1,
+    "Key": "lds",
+    "NodeID": "node",
+    "TypeURL": "type.googleapis.com/envoy.api.v2.Listener",
+    "ResourceNames": null,
+    "Version": "1",`)
+
+	req, err = http.NewRequest("GET", "/watches?key=cds", nil)
+	assert.NoError(t, err)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "null", rr.Body.String())
+}
+
+func TestAdminServer_WatchHistoryHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NoError(t, orchestrator.OnStreamOpen(context.Background(), 1, ""))
+	assert.NoError(t, orchestrator.OnStreamRequest(1, &v2.DiscoveryRequest{Node: &core.Node{Id: "node"}}))
+	resp := &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: "type.googleapis.com/envoy.api.v2.Listener"}
+	orchestrator.OnStreamResponse(1, nil, resp)
+	assert.NoError(t, orchestrator.OnStreamRequest(1, &v2.DiscoveryRequest{
+		Node:          &core.Node{Id: "node"},
+		TypeUrl:       "type.googleapis.com/envoy.api.v2.Listener",
+		VersionInfo:   "1",
+		ResponseNonce: resp.GetNonce(),
+	}))
+	serve := func(path string) string {
+		req, err := http.NewRequest("GET", path, nil)
+		assert.NoError(t, err)
+		rr := httptest.NewRecorder()
+		watchHistoryHandler(&orchestrator).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		return rr.Body.String()
+	}
+
+	body := serve("/watch_history?node=node")
+	assert.Contains(t, body, `"NodeID": "node"`)
+	assert.Contains(t, body, `"Version": "1"`)
+	assert.Contains(t, body, `"Outcome": "ACK"`)
+	assert.Equal(t, "null", serve("/watch_history?node=other"))
+}
+
+func TestAdminServer_NodesHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	for streamID, node := range map[int64]*core.Node{
+		1: {Id: "edge-1", Cluster: "edge", UserAgentName: "envoy",
+			UserAgentVersionType: &core.Node_UserAgentVersion{UserAgentVersion: "1.14.1"}},
+		2: {Id: "edge-2", Cluster: "edge", UserAgentName: "envoy",
+			UserAgentVersionType: &core.Node_UserAgentVersion{UserAgentVersion: "1.15.0"}},
+		3: {Id: "ingress-1", Cluster: "ingress", UserAgentName: "envoy",
+			UserAgentVersionType: &core.Node_UserAgentVersion{UserAgentVersion: "1.14.1"}},
+	} {
+		assert.NoError(t, orchestrator.OnStreamOpen(context.Background(), streamID, ""))
+		assert.NoError(t, orchestrator.OnStreamRequest(streamID, &v2.DiscoveryRequest{Node: node}))
+	}
+	serve := func(handler http.HandlerFunc, path string) string {
+		req, err := http.NewRequest("GET", path, nil)
+		assert.NoError(t, err)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		return rr.Body.String()
+	}
+
+	body := serve(nodesHandler(&orchestrator), "/nodes?cluster=edge&version=1.14.1")
+	assert.Contains(t, body, `"ID": "edge-1"`)
+	assert.NotContains(t, body, `"ID": "edge-2"`)
+	assert.NotContains(t, body, `"ID": "ingress-1"`)
+	body = serve(nodesHandler(&orchestrator), "/nodes?prefix=ingress")
+	assert.Contains(t, body, `"ID": "ingress-1"`)
+	assert.NotContains(t, body, `"ID": "edge-1"`)
+	assert.Equal(t, "null", serve(nodesHandler(&orchestrator), "/nodes?zone=us-east-1a"))
+
+	assert.Equal(t, `[
+  {
+    "UserAgentName": "envoy",
+    "UserAgentVersion": "1.14.1",
+    "Nodes": 2
+  },
+  {
+    "UserAgentName": "envoy",
+    "UserAgentVersion": "1.15.0",
+    "Nodes": 1
+  }
+]`, serve(nodeVersionsHandler(&orchestrator), "/node_versions"))
+}
+
+func TestAdminServer_RolloutsHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	req, err := http.NewRequest("GET", "/rollouts", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler := rolloutsHandler(&orchestrator)
+
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "null", rr.Body.String())
+}
+
+func TestAdminServer_PromoteRolloutHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NotNil(t, orchestrator)
+	handler := promoteRolloutHandler(&orchestrator)
+
+	req, err := http.NewRequest("GET", "/rollouts/lds", nil)
+	assert.NoError(t, err)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+
+	req, err = http.NewRequest("POST", "/rollouts/lds", nil)
+	assert.NoError(t, err)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "no rollout for key lds found.\n", rr.Body.String())
+}
+
+func TestAdminServer_PinHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel}, mockScope)
+	assert.NotNil(t, orchestrator)
+	handler := keysHandler(&orchestrator, nil)
+	serve := func(method string, path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, path, nil)
+		assert.NoError(t, err)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := serve("POST", "/keys/lds/pin")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "unable to pin key lds: no response cached for key lds\n", rr.Body.String())
+
+	respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	defer cancelWatch()
+	upstreamResponseChannel <- &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	<-respChannel
+
+	rr = serve("POST", "/keys/lds/pin?version=1")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "key lds pinned to version 1.\n", rr.Body.String())
+	rr = serve("GET", "/keys/lds/pin")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "key lds is pinned to version 1.\n", rr.Body.String())
+	rr = serve("GET", "/keys/lds/unpin")
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	rr = serve("POST", "/keys/lds/unpin")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "key lds unpinned.\n", rr.Body.String())
+	rr = serve("POST", "/keys/lds/unpin")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serve("GET", "/keys/lds")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serve("GET", "/keys/lds/resume")
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	rr = serve("POST", "/keys/lds/resume")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "fanout of key lds is not paused.\n", rr.Body.String())
+	rr = serve("GET", "/keys/lds/status")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"served_version": "1"`)
+	assert.Contains(t, rr.Body.String(), `"upstream_version": "1"`)
+	assert.Contains(t, rr.Body.String(), `"watches": 1`)
+	assert.Contains(t, rr.Body.String(), `"ack_ratio": null`)
+	rr = serve("GET", "/keys/cds/status")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "key cds is not watched.\n", rr.Body.String())
+}
+
+func TestAdminServer_KeyEventsHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	serve := func(handler http.HandlerFunc, path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", path, nil)
+		assert.NoError(t, err)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := serve(keysHandler(&orchestrator, nil), "/keys/lds/events")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), "logging.key_events_size")
+
+	keyEvents := log.NewKeyEvents(10)
+	keyEvents.Wrap(log.New("info")).With("version", "1").
+		Debug(log.WithAggregatedKey(context.Background(), "lds"), "response sent")
+	handler := keysHandler(&orchestrator, keyEvents)
+	rr = serve(handler, "/keys/lds/events")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"level": "debug"`)
+	assert.Contains(t, rr.Body.String(), `"message": "response sent"`)
+	assert.Contains(t, rr.Body.String(), `"version": "1"`)
+	rr = serve(handler, "/keys/cds/events")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "null", rr.Body.String())
+}
+
+func TestAdminServer_RollbackHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel}, mockScope)
+	assert.NotNil(t, orchestrator)
+	handler := pinHandler(&orchestrator)
+	serve := func(method string, path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, path, nil)
+		assert.NoError(t, err)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	defer cancelWatch()
+	upstreamResponseChannel <- &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	<-respChannel
+
+	rr := serve("POST", "/keys/lds/rollback")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	rr = serve("POST", "/keys/lds/rollback?to=7")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "unable to roll back key lds: version 7 of key lds is not held by the relay\n", rr.Body.String())
+	rr = serve("GET", "/keys/lds/rollback")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "key lds is not rolled back.\n", rr.Body.String())
+
+	rr = serve("POST", "/keys/lds/rollback?to=1")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "key lds rolled back to version 1, and pinned until unpinned.\n", rr.Body.String())
+	rr = serve("GET", "/keys/lds/rollback")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"RolledBackFrom": "1"`)
+	assert.Contains(t, rr.Body.String(), `"Diverged": false`)
+
+	req, err := http.NewRequest("GET", "/rollbacks", nil)
+	assert.NoError(t, err)
+	rr = httptest.NewRecorder()
+	rollbacksHandler(&orchestrator).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"Key": "lds"`)
+
+	rr = serve("POST", "/keys/lds/unpin")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	rr = serve("GET", "/keys/lds/rollback")
+	assert.Equal(t, "key lds is not rolled back.\n", rr.Body.String())
+}
+
+func TestAdminServer_HistoryHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NotNil(t, orchestrator)
+	handler := historyHandler(&orchestrator)
+	serve := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", path, nil)
+		assert.NoError(t, err)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := serve("/history/lds")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "null", rr.Body.String())
+	rr = serve("/history/lds?version=1")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "version 1 of key lds not found.\n", rr.Body.String())
+	rr = serve("/history/lds?from=1&to=2")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "unable to diff key lds: version 1 of key lds is not held by the relay\n", rr.Body.String())
+}
+
+func TestAdminServer_GoroutinesHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	assert.NotNil(t, orchestrator)
+
+	_, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	defer cancelWatch()
+
+	req, err := http.NewRequest("GET", "/debug/goroutines", nil)
+	assert.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler := goroutinesHandler(&orchestrator)
+
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"Subsystems": {
+    "upstream_receiver": 1,
+    "watch": 1
+  },
+  "Blocked": []`)
+}
+
+func TestAdminServer_DebugHandlers(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+
+	mux := http.NewServeMux()
+	RegisterHandlers(mux, &bootstrapv1.Bootstrap{
+		Admin: &bootstrapv1.Admin{EnableDebugEndpoints: true},
+	}, &orchestrator, nil, nil, version.Get())
+	for path, expected := range map[string]string{
+		"/":                      "/debug/pprof/: serve pprof profiles",
+		"/debug/pprof/":          "goroutine",
+		"/debug/pprof/goroutine": "",
+		"/debug/pprof/cmdline":   "",
+		"/debug/vars":            `"memstats"`,
+		"/debug/runtime":         `"Goroutines"`,
+	} {
+		req, err := http.NewRequest("GET", path, nil)
+		assert.NoError(t, err)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code, path)
+		assert.Contains(t, rr.Body.String(), expected, path)
+	}
+}
+
+func TestAdminServer_DebugHandlers_Disabled(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+
+	mux := http.NewServeMux()
+	RegisterHandlers(mux, &bootstrapv1.Bootstrap{Admin: &bootstrapv1.Admin{}}, &orchestrator, nil, nil, version.Get())
+	for _, path := range []string{"/debug/pprof/", "/debug/vars", "/debug/runtime"} {
+		req, err := http.NewRequest("GET", path, nil)
+		assert.NoError(t, err)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Code, path)
+	}
+}
+
+func TestAdminServer_BundleHandler(t *testing.T) {
+	mapper := mapper.NewMock(t)
+	mockScope := tally.NewTestScope("mock_orchestrator", make(map[string]string))
+	orchestrator := orchestrator.NewMock(t, mapper,
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)}, mockScope)
+	recentLogs := log.NewRecent(10)
+	recentLogs.Wrap(log.New("panic")).Warn(context.Background(), "upstream stream closed")
+	bootstrapConfig := &bootstrapv1.Bootstrap{
+		OriginServer: &bootstrapv1.Upstream{
+			Address: &bootstrapv1.SocketAddress{Address: "origin", PortValue: 18000},
+			Metadata: []*bootstrapv1.Upstream_Metadata{
+				{
+					Key:            "authorization",
+					ValueSpecifier: &bootstrapv1.Upstream_Metadata_Value{Value: "Bearer secret-token"},
+				},
+			},
+			Credentials: &bootstrapv1.Upstream_Credentials{
+				SourceSpecifier: &bootstrapv1.Upstream_Credentials_Exec{
+					Exec: &bootstrapv1.Upstream_Credentials_ExecPlugin{
+						Command: "get-token",
+						Args:    []string{"--password=secret-arg"},
+						Env:     map[string]string{"TOKEN": "secret-env"},
+					},
+				},
+			},
+		},
+	}
+
+	handler := bundleHandler(bootstrapConfig, &orchestrator, recentLogs, version.Get())
+	req, err := http.NewRequest("GET", "/debug/bundle", nil)
+	assert.NoError(t, err)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/gzip", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "xds-relay-bundle-")
+
+	gzipReader, err := gzip.NewReader(rr.Body)
+	assert.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+	files := make(map[string]string)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		content, err := ioutil.ReadAll(tarReader)
+		assert.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	for _, name := range []string{"build_info.json", "cache_stats.json", "watches.json", "workers.json",
+		"upstream_health.json", "goroutines.json"} {
+		assert.Contains(t, files, name)
+	}
+	assert.Contains(t, files["build_info.json"], "GoVersion")
+	assert.Contains(t, files["logs.json"], "upstream stream closed")
+
+	// Secrets held in the configuration are redacted, and the configuration
+	// served is left as is.
+	assert.Contains(t, files["bootstrap.json"], "get-token")
+	assert.Contains(t, files["bootstrap.json"], redacted)
+	assert.NotContains(t, files["bootstrap.json"], "secret")
+	assert.Equal(t, "Bearer secret-token", bootstrapConfig.GetOriginServer().GetMetadata()[0].GetValue())
+
+	req, err = http.NewRequest("POST", "/debug/bundle", nil)
+	assert.NoError(t, err)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
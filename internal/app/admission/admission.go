@@ -0,0 +1,126 @@
+// Package admission limits the rate at which new downstream xDS streams are accepted, so that clients reconnecting
+// all at once are spread over time instead of overwhelming the cache and the origin server.
+package admission
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RetryPushbackTrailer is the trailer that hints rejected clients when to retry, in milliseconds.
+	RetryPushbackTrailer = "grpc-retry-pushback-ms"
+
+	// xdsMethodPrefix is the prefix of the methods of the xDS services. Streams of other services, e.g. replication,
+	// are not admission controlled.
+	xdsMethodPrefix = "/envoy."
+
+	metricAdmitted = "admitted"
+	metricRejected = "rejected"
+)
+
+// Controller is a token bucket that admits new streams at a limited rate.
+type Controller struct {
+	rate      float64
+	burst     float64
+	maxJitter time.Duration
+	// until is zero if streams are always admission controlled.
+	until  time.Time
+	now    func() time.Time
+	jitter func(max time.Duration) time.Duration
+	logger log.Logger
+	scope  tally.Scope
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// New returns a controller whose bucket starts full.
+func New(config *bootstrapv1.Admission, logger log.Logger, scope tally.Scope) (*Controller, error) {
+	c := &Controller{
+		rate:   config.GetStreamsPerSecond(),
+		burst:  float64(config.GetBurst()),
+		now:    time.Now,
+		jitter: func(max time.Duration) time.Duration { return time.Duration(rand.Int63n(int64(max))) }, // #nosec
+		logger: logger.Named("admission"),
+		scope:  scope,
+	}
+	if c.burst == 0 {
+		c.burst = math.Ceil(c.rate)
+	}
+	if config.GetMaxRetryJitter() != nil {
+		maxJitter, err := ptypes.Duration(config.GetMaxRetryJitter())
+		if err != nil {
+			return nil, err
+		}
+		c.maxJitter = maxJitter
+	}
+	if config.GetStartupDuration() != nil {
+		startupDuration, err := ptypes.Duration(config.GetStartupDuration())
+		if err != nil {
+			return nil, err
+		}
+		if startupDuration > 0 {
+			c.until = c.now().Add(startupDuration)
+		}
+	}
+	c.tokens, c.last = c.burst, c.now()
+	return c, nil
+}
+
+// Admit takes a token from the bucket. If the bucket is empty, it returns false and the time after which the stream
+// should be retried.
+func (c *Controller) Admit() (bool, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if !c.until.IsZero() && now.After(c.until) {
+		return true, 0
+	}
+	c.tokens = math.Min(c.burst, c.tokens+now.Sub(c.last).Seconds()*c.rate)
+	c.last = now
+	if c.tokens >= 1 {
+		c.tokens--
+		return true, 0
+	}
+	retryAfter := time.Duration((1 - c.tokens) / c.rate * float64(time.Second))
+	if c.maxJitter > 0 {
+		retryAfter += c.jitter(c.maxJitter)
+	}
+	return false, retryAfter
+}
+
+// StreamServerInterceptor closes new xDS streams that are not admitted with a RESOURCE_EXHAUSTED status and a retry
+// hint.
+func (c *Controller) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !strings.HasPrefix(info.FullMethod, xdsMethodPrefix) {
+			return handler(srv, ss)
+		}
+		admitted, retryAfter := c.Admit()
+		if admitted {
+			c.scope.Counter(metricAdmitted).Inc(1)
+			return handler(srv, ss)
+		}
+		c.scope.Counter(metricRejected).Inc(1)
+		c.logger.With("method", info.FullMethod).With("retry after", retryAfter).
+			Debug(context.Background(), "rejected stream")
+		ss.SetTrailer(metadata.Pairs(RetryPushbackTrailer, strconv.FormatInt(retryAfter.Milliseconds(), 10)))
+		return status.Errorf(codes.ResourceExhausted, "too many new streams, retry after %s", retryAfter)
+	}
+}
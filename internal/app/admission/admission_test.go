@@ -0,0 +1,132 @@
+package admission
+
+import (
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// newController returns a controller whose clock only advances when the returned function is called.
+func newController(t *testing.T, config *bootstrapv1.Admission, scope tally.Scope) (*Controller, func(time.Duration)) {
+	c, err := New(config, log.New("info"), scope)
+	assert.NoError(t, err)
+	now := c.last
+	c.now = func() time.Time { return now }
+	c.jitter = func(max time.Duration) time.Duration { return max }
+	if !c.until.IsZero() {
+		c.until = now.Add(c.until.Sub(c.last))
+	}
+	return c, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestAdmit(t *testing.T) {
+	c, advance := newController(t, &bootstrapv1.Admission{StreamsPerSecond: 2, Burst: 3},
+		tally.NewTestScope("admission", make(map[string]string)))
+
+	// The burst is admitted at once.
+	for i := 0; i < 3; i++ {
+		admitted, _ := c.Admit()
+		assert.True(t, admitted)
+	}
+	admitted, retryAfter := c.Admit()
+	assert.False(t, admitted)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	// Tokens are refilled at the rate.
+	advance(250 * time.Millisecond)
+	admitted, retryAfter = c.Admit()
+	assert.False(t, admitted)
+	assert.Equal(t, 250*time.Millisecond, retryAfter)
+	advance(250 * time.Millisecond)
+	admitted, _ = c.Admit()
+	assert.True(t, admitted)
+
+	// The bucket never holds more than the burst.
+	advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		admitted, _ = c.Admit()
+		assert.True(t, admitted)
+	}
+	admitted, _ = c.Admit()
+	assert.False(t, admitted)
+}
+
+func TestAdmitDefaults(t *testing.T) {
+	c, _ := newController(t, &bootstrapv1.Admission{StreamsPerSecond: 1.5},
+		tally.NewTestScope("admission", make(map[string]string)))
+	assert.Equal(t, float64(2), c.burst)
+}
+
+func TestAdmitJitter(t *testing.T) {
+	c, _ := newController(t, &bootstrapv1.Admission{
+		StreamsPerSecond: 1,
+		MaxRetryJitter:   ptypes.DurationProto(time.Second),
+	}, tally.NewTestScope("admission", make(map[string]string)))
+	c.Admit()
+	admitted, retryAfter := c.Admit()
+	assert.False(t, admitted)
+	assert.Equal(t, 2*time.Second, retryAfter)
+}
+
+func TestAdmitStartupDuration(t *testing.T) {
+	c, advance := newController(t, &bootstrapv1.Admission{
+		StreamsPerSecond: 1,
+		StartupDuration:  ptypes.DurationProto(time.Minute),
+	}, tally.NewTestScope("admission", make(map[string]string)))
+	c.Admit()
+	admitted, _ := c.Admit()
+	assert.False(t, admitted)
+
+	// Every stream is admitted once startup is over.
+	advance(2 * time.Minute)
+	for i := 0; i < 10; i++ {
+		admitted, _ = c.Admit()
+		assert.True(t, admitted)
+	}
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	trailer metadata.MD
+}
+
+func (m *mockServerStream) SetTrailer(md metadata.MD) {
+	m.trailer = metadata.Join(m.trailer, md)
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	scope := tally.NewTestScope("admission", make(map[string]string))
+	c, _ := newController(t, &bootstrapv1.Admission{StreamsPerSecond: 1}, scope)
+	interceptor := c.StreamServerInterceptor()
+	handled := 0
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		handled++
+		return nil
+	}
+	xds := &grpc.StreamServerInfo{FullMethod: "/envoy.api.v2.ClusterDiscoveryService/StreamClusters"}
+
+	assert.NoError(t, interceptor(nil, &mockServerStream{}, xds, handler))
+	stream := &mockServerStream{}
+	err := interceptor(nil, stream, xds, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"1000"}, stream.trailer.Get(RetryPushbackTrailer))
+
+	// Other services are not admission controlled.
+	other := &grpc.StreamServerInfo{FullMethod: "/replication.Replication/StreamUpdates"}
+	assert.NoError(t, interceptor(nil, &mockServerStream{}, other, handler))
+	assert.Equal(t, 2, handled)
+
+	counters := scope.Snapshot().Counters()
+	testutils.AssertCounterValue(t, counters, "admission.admitted", 1)
+	testutils.AssertCounterValue(t, counters, "admission.rejected", 1)
+}
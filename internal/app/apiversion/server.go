@@ -0,0 +1,183 @@
+package apiversion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	clusterservice "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	endpointservice "github.com/envoyproxy/go-control-plane/envoy/service/endpoint/v3"
+	listenerservice "github.com/envoyproxy/go-control-plane/envoy/service/listener/v3"
+	routeservice "github.com/envoyproxy/go-control-plane/envoy/service/route/v3"
+	runtimeservice "github.com/envoyproxy/go-control-plane/envoy/service/runtime/v3"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/server/v2"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+// Server is the v3 CDS, EDS, LDS, RDS and RTDS services.
+type Server interface {
+	clusterservice.ClusterDiscoveryServiceServer
+	endpointservice.EndpointDiscoveryServiceServer
+	listenerservice.ListenerDiscoveryServiceServer
+	routeservice.RouteDiscoveryServiceServer
+	runtimeservice.RuntimeDiscoveryServiceServer
+}
+
+type server struct {
+	server gcp.Server
+}
+
+// NewServer returns the v3 services of the v2 xDS server. Requests are translated to v2 before they are served, and
+// responses back to v3.
+func NewServer(v2Server gcp.Server) Server {
+	return &server{server: v2Server}
+}
+
+// Register registers the v3 services with the gRPC server.
+func Register(grpcServer *grpc.Server, v3Server Server) {
+	clusterservice.RegisterClusterDiscoveryServiceServer(grpcServer, v3Server)
+	endpointservice.RegisterEndpointDiscoveryServiceServer(grpcServer, v3Server)
+	listenerservice.RegisterListenerDiscoveryServiceServer(grpcServer, v3Server)
+	routeservice.RegisterRouteDiscoveryServiceServer(grpcServer, v3Server)
+	runtimeservice.RegisterRuntimeDiscoveryServiceServer(grpcServer, v3Server)
+}
+
+func (s *server) StreamClusters(stream clusterservice.ClusterDiscoveryService_StreamClustersServer) error {
+	return s.server.StreamClusters(&v3Stream{ServerStream: stream})
+}
+
+func (s *server) StreamEndpoints(stream endpointservice.EndpointDiscoveryService_StreamEndpointsServer) error {
+	return s.server.StreamEndpoints(&v3Stream{ServerStream: stream})
+}
+
+func (s *server) StreamListeners(stream listenerservice.ListenerDiscoveryService_StreamListenersServer) error {
+	return s.server.StreamListeners(&v3Stream{ServerStream: stream})
+}
+
+func (s *server) StreamRoutes(stream routeservice.RouteDiscoveryService_StreamRoutesServer) error {
+	return s.server.StreamRoutes(&v3Stream{ServerStream: stream})
+}
+
+func (s *server) StreamRuntime(stream runtimeservice.RuntimeDiscoveryService_StreamRuntimeServer) error {
+	return s.server.StreamRuntime(&v3Stream{ServerStream: stream})
+}
+
+func (s *server) FetchClusters(
+	ctx context.Context,
+	req *discoveryv3.DiscoveryRequest,
+) (*discoveryv3.DiscoveryResponse, error) {
+	return fetch(ctx, req, s.server.FetchClusters)
+}
+
+func (s *server) FetchEndpoints(
+	ctx context.Context,
+	req *discoveryv3.DiscoveryRequest,
+) (*discoveryv3.DiscoveryResponse, error) {
+	return fetch(ctx, req, s.server.FetchEndpoints)
+}
+
+func (s *server) FetchListeners(
+	ctx context.Context,
+	req *discoveryv3.DiscoveryRequest,
+) (*discoveryv3.DiscoveryResponse, error) {
+	return fetch(ctx, req, s.server.FetchListeners)
+}
+
+func (s *server) FetchRoutes(
+	ctx context.Context,
+	req *discoveryv3.DiscoveryRequest,
+) (*discoveryv3.DiscoveryResponse, error) {
+	return fetch(ctx, req, s.server.FetchRoutes)
+}
+
+func (s *server) FetchRuntime(
+	ctx context.Context,
+	req *discoveryv3.DiscoveryRequest,
+) (*discoveryv3.DiscoveryResponse, error) {
+	return fetch(ctx, req, s.server.FetchRuntime)
+}
+
+// The incremental services are not implemented by the v2 server either.
+
+func (s *server) DeltaClusters(clusterservice.ClusterDiscoveryService_DeltaClustersServer) error {
+	return errors.New("not implemented")
+}
+
+func (s *server) DeltaEndpoints(endpointservice.EndpointDiscoveryService_DeltaEndpointsServer) error {
+	return errors.New("not implemented")
+}
+
+func (s *server) DeltaListeners(listenerservice.ListenerDiscoveryService_DeltaListenersServer) error {
+	return errors.New("not implemented")
+}
+
+func (s *server) DeltaRoutes(routeservice.RouteDiscoveryService_DeltaRoutesServer) error {
+	return errors.New("not implemented")
+}
+
+func (s *server) DeltaRuntime(runtimeservice.RuntimeDiscoveryService_DeltaRuntimeServer) error {
+	return errors.New("not implemented")
+}
+
+// fetch serves the v3 request with the fetch function of the v2 server.
+func fetch(
+	ctx context.Context,
+	req *discoveryv3.DiscoveryRequest,
+	fetchV2 func(context.Context, *discovery.DiscoveryRequest) (*discovery.DiscoveryResponse, error),
+) (*discoveryv3.DiscoveryResponse, error) {
+	var reqV2 discovery.DiscoveryRequest
+	if err := Convert(req, &reqV2); err != nil {
+		return nil, err
+	}
+	Request(&reqV2, V2)
+	resp, err := fetchV2(ctx, &reqV2)
+	if err != nil {
+		return nil, err
+	}
+	if resp, err = Response(resp, V3); err != nil {
+		return nil, err
+	}
+	var respV3 discoveryv3.DiscoveryResponse
+	if err := Convert(resp, &respV3); err != nil {
+		return nil, err
+	}
+	return &respV3, nil
+}
+
+// v3Stream serves a v3 xDS stream as a v2 stream. The v2 messages are sent and received as is, since they are wire
+// compatible with the v3 messages, with their type URLs translated.
+type v3Stream struct {
+	grpc.ServerStream
+}
+
+func (s *v3Stream) Send(resp *discovery.DiscoveryResponse) error {
+	translated, err := Response(resp, V3)
+	if err != nil {
+		return err
+	}
+	return s.ServerStream.SendMsg(translated)
+}
+
+func (s *v3Stream) Recv() (*discovery.DiscoveryRequest, error) {
+	req := &discovery.DiscoveryRequest{}
+	if err := s.ServerStream.RecvMsg(req); err != nil {
+		return nil, err
+	}
+	Request(req, V2)
+	return req, nil
+}
+
+// Convert translates between wire-compatible messages of different API versions. Type URLs are not translated.
+func Convert(from proto.Message, to proto.Message) error {
+	serialized, err := proto.Marshal(from)
+	if err != nil {
+		return fmt.Errorf("failed to convert %T: %s", from, err.Error())
+	}
+	if err := proto.Unmarshal(serialized, to); err != nil {
+		return fmt.Errorf("failed to convert %T: %s", from, err.Error())
+	}
+	return nil
+}
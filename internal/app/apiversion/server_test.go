@@ -0,0 +1,108 @@
+package apiversion
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	listenerservice "github.com/envoyproxy/go-control-plane/envoy/service/listener/v3"
+	resourcev2 "github.com/envoyproxy/go-control-plane/pkg/resource/v2"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/server/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockV2Server struct {
+	gcp.Server
+	requests []*v2.DiscoveryRequest
+	response *v2.DiscoveryResponse
+}
+
+func (s *mockV2Server) FetchListeners(_ context.Context, req *v2.DiscoveryRequest) (*v2.DiscoveryResponse, error) {
+	s.requests = append(s.requests, req)
+	return s.response, nil
+}
+
+func (s *mockV2Server) StreamListeners(stream v2.ListenerDiscoveryService_StreamListenersServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.requests = append(s.requests, req)
+		if err := stream.Send(s.response); err != nil {
+			return err
+		}
+	}
+}
+
+// mockStream is a v3 stream whose messages are only sent and received with
+// SendMsg and RecvMsg.
+type mockStream struct {
+	listenerservice.ListenerDiscoveryService_StreamListenersServer
+	requests []proto.Message
+	sent     []interface{}
+}
+
+func (s *mockStream) RecvMsg(m interface{}) error {
+	if len(s.requests) == 0 {
+		return io.EOF
+	}
+	if err := Convert(s.requests[0], m.(proto.Message)); err != nil {
+		return err
+	}
+	s.requests = s.requests[1:]
+	return nil
+}
+
+func (s *mockStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func newV2ListenerResponse(t *testing.T) *v2.DiscoveryResponse {
+	resource, err := ptypes.MarshalAny(&v2.Listener{Name: "listener"})
+	assert.NoError(t, err)
+	return &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: resourcev2.ListenerType, Resources: []*any.Any{resource}}
+}
+
+func TestServerFetch(t *testing.T) {
+	v2Server := &mockV2Server{response: newV2ListenerResponse(t)}
+	resp, err := NewServer(v2Server).FetchListeners(context.Background(), &discoveryv3.DiscoveryRequest{
+		TypeUrl:       resourcev3.ListenerType,
+		ResourceNames: []string{"listener"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, resourcev2.ListenerType, v2Server.requests[0].GetTypeUrl())
+	assert.Equal(t, []string{"listener"}, v2Server.requests[0].GetResourceNames())
+	assert.Equal(t, "1", resp.GetVersionInfo())
+	assert.Equal(t, resourcev3.ListenerType, resp.GetTypeUrl())
+	assert.Equal(t, resourcev3.ListenerType, resp.GetResources()[0].GetTypeUrl())
+	// The cached response is not modified.
+	assert.Equal(t, resourcev2.ListenerType, v2Server.response.GetResources()[0].GetTypeUrl())
+}
+
+func TestServerStream(t *testing.T) {
+	v2Server := &mockV2Server{response: newV2ListenerResponse(t)}
+	stream := &mockStream{requests: []proto.Message{
+		&discoveryv3.DiscoveryRequest{TypeUrl: resourcev3.ListenerType},
+		&discoveryv3.DiscoveryRequest{TypeUrl: resourcev3.ListenerType, VersionInfo: "1"},
+	}}
+	assert.NoError(t, NewServer(v2Server).StreamListeners(stream))
+
+	assert.Equal(t, 2, len(v2Server.requests))
+	assert.Equal(t, resourcev2.ListenerType, v2Server.requests[1].GetTypeUrl())
+	assert.Equal(t, "1", v2Server.requests[1].GetVersionInfo())
+	assert.Equal(t, 2, len(stream.sent))
+	resp := stream.sent[0].(*v2.DiscoveryResponse)
+	assert.Equal(t, resourcev3.ListenerType, resp.GetTypeUrl())
+	assert.Equal(t, resourcev3.ListenerType, resp.GetResources()[0].GetTypeUrl())
+}
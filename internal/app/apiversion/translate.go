@@ -0,0 +1,263 @@
+// Package apiversion translates xDS messages between the v2 and v3 APIs, so that v2 and v3 Envoys can be served
+// during a migration regardless of the API version of the origin server.
+//
+// The messages of both versions are wire compatible, so only the type URLs are translated: those of the discovery
+// messages, and those of the `Any` payloads nested in their resources. Payloads of types that are not linked into
+// the relay are passed through untranslated.
+package apiversion
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	// Register the v3 resource types, and the v3 types of the filters they most commonly nest, so that they can be
+	// translated.
+	_ "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	_ "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	_ "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	_ "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	_ "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	_ "github.com/envoyproxy/go-control-plane/envoy/service/runtime/v3"
+)
+
+// Version is an xDS API version.
+type Version int
+
+const (
+	// V2 is the v2 xDS API, which the relay caches responses of.
+	V2 Version = iota
+	// V3 is the v3 xDS API.
+	V3
+)
+
+const (
+	// versioningExtension annotates each v3 message with the v2 message it replaces.
+	versioningExtension = "udpa.annotations.versioning"
+	// previousMessageTypeField is the field of the versioning annotation that names the v2 message.
+	previousMessageTypeField = "previous_message_type"
+
+	anyFullName = "google.protobuf.Any"
+)
+
+var (
+	typesOnce sync.Once
+	// typeNames maps the full name of each translatable message to its counterpart in the other API version.
+	typeNames map[Version]map[string]string
+)
+
+func (v Version) String() string {
+	switch v {
+	case V2:
+		return "v2"
+	case V3:
+		return "v3"
+	default:
+		return fmt.Sprintf("Version(%d)", int(v))
+	}
+}
+
+// TypeURL returns the type URL translated to the API version. Type URLs that cannot be translated are returned
+// unchanged.
+func TypeURL(typeURL string, to Version) string {
+	i := strings.LastIndex(typeURL, "/")
+	if name, ok := typeNamesTo(to)[typeURL[i+1:]]; ok {
+		return typeURL[:i+1] + name
+	}
+	return typeURL
+}
+
+// Response returns a copy of the response translated to the API version. The response is not modified, so that
+// cached responses can be translated.
+func Response(resp *discovery.DiscoveryResponse, to Version) (*discovery.DiscoveryResponse, error) {
+	translated := *resp
+	translated.TypeUrl = TypeURL(resp.GetTypeUrl(), to)
+	translated.Resources = make([]*any.Any, len(resp.GetResources()))
+	for i, resource := range resp.GetResources() {
+		var err error
+		if translated.Resources[i], err = Any(resource, to); err != nil {
+			return nil, fmt.Errorf("failed to translate resource %d to %s: %s", i, to, err.Error())
+		}
+	}
+	return &translated, nil
+}
+
+// Request translates the type URL of the request to the API version in place.
+func Request(req *discovery.DiscoveryRequest, to Version) {
+	req.TypeUrl = TypeURL(req.GetTypeUrl(), to)
+}
+
+// DeltaResponse returns a copy of the incremental response translated to the API version. The response is not
+// modified, so that cached resources can be translated.
+func DeltaResponse(resp *discovery.DeltaDiscoveryResponse, to Version) (*discovery.DeltaDiscoveryResponse, error) {
+	translated := *resp
+	translated.TypeUrl = TypeURL(resp.GetTypeUrl(), to)
+	translated.Resources = make([]*discovery.Resource, len(resp.GetResources()))
+	for i, resource := range resp.GetResources() {
+		translatedResource := *resource
+		if resource.GetResource() != nil {
+			var err error
+			if translatedResource.Resource, err = Any(resource.GetResource(), to); err != nil {
+				return nil, fmt.Errorf("failed to translate resource %s to %s: %s", resource.GetName(), to, err.Error())
+			}
+		}
+		translated.Resources[i] = &translatedResource
+	}
+	return &translated, nil
+}
+
+// DeltaRequest translates the type URL of the incremental request to the API version in place.
+func DeltaRequest(req *discovery.DeltaDiscoveryRequest, to Version) {
+	req.TypeUrl = TypeURL(req.GetTypeUrl(), to)
+}
+
+// Any returns the payload translated to the API version, along with the payloads nested in it. The payload is
+// returned unchanged if nothing in it is translated.
+func Any(payload *any.Any, to Version) (*any.Any, error) {
+	typeURL := TypeURL(payload.GetTypeUrl(), to)
+	message := newMessage(payload.GetTypeUrl(), typeURL)
+	if message == nil {
+		if typeURL == payload.GetTypeUrl() {
+			return payload, nil
+		}
+		return &any.Any{TypeUrl: typeURL, Value: payload.GetValue()}, nil
+	}
+	if err := proto.Unmarshal(payload.GetValue(), message.Interface()); err != nil {
+		return nil, err
+	}
+	changed, err := translateMessage(message, to)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		if typeURL == payload.GetTypeUrl() {
+			return payload, nil
+		}
+		return &any.Any{TypeUrl: typeURL, Value: payload.GetValue()}, nil
+	}
+	value, err := proto.MarshalOptions{Deterministic: true}.Marshal(message.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return &any.Any{TypeUrl: typeURL, Value: value}, nil
+}
+
+// newMessage returns an empty message of either type URL, whose messages are wire compatible, or nil if neither is
+// linked into the relay.
+func newMessage(typeURLs ...string) protoreflect.Message {
+	for _, typeURL := range typeURLs {
+		if messageType, err := protoregistry.GlobalTypes.FindMessageByURL(typeURL); err == nil {
+			return messageType.New()
+		}
+	}
+	return nil
+}
+
+// translateMessage translates the `Any` payloads nested in the message in place, and reports whether any was
+// translated.
+func translateMessage(message protoreflect.Message, to Version) (bool, error) {
+	if message.Descriptor().FullName() == anyFullName {
+		return translateAnyMessage(message, to)
+	}
+	changed := false
+	var err error
+	visit := func(nested protoreflect.Message) bool {
+		var nestedChanged bool
+		nestedChanged, err = translateMessage(nested, to)
+		changed = changed || nestedChanged
+		return err == nil
+	}
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.IsList():
+			if field.Message() == nil {
+				return true
+			}
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				if !visit(list.Get(i).Message()) {
+					return false
+				}
+			}
+			return true
+		case field.IsMap():
+			if field.MapValue().Message() == nil {
+				return true
+			}
+			value.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+				return visit(value.Message())
+			})
+			return err == nil
+		case field.Message() != nil:
+			return visit(value.Message())
+		default:
+			return true
+		}
+	})
+	return changed, err
+}
+
+// translateAnyMessage translates the `Any` message in place, and reports whether it was translated.
+func translateAnyMessage(message protoreflect.Message, to Version) (bool, error) {
+	fields := message.Descriptor().Fields()
+	typeURLField, valueField := fields.ByName("type_url"), fields.ByName("value")
+	payload := &any.Any{TypeUrl: message.Get(typeURLField).String(), Value: message.Get(valueField).Bytes()}
+	translated, err := Any(payload, to)
+	if err != nil || translated == payload {
+		return false, err
+	}
+	message.Set(typeURLField, protoreflect.ValueOfString(translated.GetTypeUrl()))
+	message.Set(valueField, protoreflect.ValueOfBytes(translated.GetValue()))
+	return true, nil
+}
+
+// typeNamesTo returns the translations of message names to the API version.
+func typeNamesTo(to Version) map[string]string {
+	typesOnce.Do(loadTypeNames)
+	return typeNames[to]
+}
+
+// loadTypeNames maps the v3 messages linked into the relay to the v2 messages they replace, as recorded by their
+// versioning annotations.
+func loadTypeNames() {
+	typeNames = map[Version]map[string]string{V2: {}, V3: {}}
+	extension, err := protoregistry.GlobalTypes.FindExtensionByName(versioningExtension)
+	if err != nil {
+		return
+	}
+	var rangeMessages func(messages protoreflect.MessageDescriptors)
+	rangeMessages = func(messages protoreflect.MessageDescriptors) {
+		for i := 0; i < messages.Len(); i++ {
+			message := messages.Get(i)
+			rangeMessages(message.Messages())
+			// Later API versions are annotated with the v3 messages they replace.
+			if !strings.HasSuffix(string(message.ParentFile().Package()), ".v3") {
+				continue
+			}
+			options := message.Options().ProtoReflect()
+			if !options.Has(extension.TypeDescriptor()) {
+				continue
+			}
+			annotation := options.Get(extension.TypeDescriptor()).Message()
+			previous := annotation.Get(annotation.Descriptor().Fields().ByName(previousMessageTypeField)).String()
+			if previous == "" {
+				continue
+			}
+			name := string(message.FullName())
+			typeNames[V2][name] = previous
+			if _, ok := typeNames[V3][previous]; !ok {
+				typeNames[V3][previous] = name
+			}
+		}
+	}
+	protoregistry.GlobalFiles.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		rangeMessages(file.Messages())
+		return true
+	})
+}
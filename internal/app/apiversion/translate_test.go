@@ -0,0 +1,148 @@
+package apiversion
+
+import (
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	routev2 "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	resourcev2 "github.com/envoyproxy/go-control-plane/pkg/resource/v2"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	hcmTypeURLV2 = "type.googleapis.com/envoy.config.filter.network.http_connection_manager.v2.HttpConnectionManager"
+	hcmTypeURLV3 = "type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager"
+
+	vhostTypeURLV2 = "type.googleapis.com/envoy.api.v2.route.VirtualHost"
+	vhostTypeURLV3 = "type.googleapis.com/envoy.config.route.v3.VirtualHost"
+)
+
+func newListenerV3(t *testing.T) *listenerv3.Listener {
+	hcm, err := ptypes.MarshalAny(&hcmv3.HttpConnectionManager{
+		StatPrefix: "ingress",
+		RouteSpecifier: &hcmv3.HttpConnectionManager_RouteConfig{
+			RouteConfig: &routev3.RouteConfiguration{Name: "local"},
+		},
+	})
+	assert.NoError(t, err)
+	return &listenerv3.Listener{
+		Name: "listener",
+		FilterChains: []*listenerv3.FilterChain{
+			{
+				Filters: []*listenerv3.Filter{
+					{Name: "envoy.filters.network.http_connection_manager", ConfigType: &listenerv3.Filter_TypedConfig{
+						TypedConfig: hcm,
+					}},
+					// Payloads of types that are not linked into the relay are passed through.
+					{Name: "unknown", ConfigType: &listenerv3.Filter_TypedConfig{
+						TypedConfig: &any.Any{TypeUrl: "type.googleapis.com/unknown.v3.Filter", Value: []byte{0x0a, 0x01, 'x'}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestTypeURL(t *testing.T) {
+	assert.Equal(t, resourcev2.ListenerType, TypeURL(resourcev3.ListenerType, V2))
+	assert.Equal(t, resourcev3.ListenerType, TypeURL(resourcev2.ListenerType, V3))
+	assert.Equal(t, resourcev2.ClusterType, TypeURL(resourcev3.ClusterType, V2))
+	assert.Equal(t, resourcev3.EndpointType, TypeURL(resourcev2.EndpointType, V3))
+	assert.Equal(t, resourcev3.RouteType, TypeURL(resourcev2.RouteType, V3))
+	assert.Equal(t, resourcev3.RuntimeType, TypeURL(resourcev2.RuntimeType, V3))
+	assert.Equal(t, resourcev2.RuntimeType, TypeURL(resourcev3.RuntimeType, V2))
+	assert.Equal(t, hcmTypeURLV2, TypeURL(hcmTypeURLV3, V2))
+
+	// Type URLs of the target version, and of unknown types, are not translated.
+	assert.Equal(t, resourcev2.ListenerType, TypeURL(resourcev2.ListenerType, V2))
+	assert.Equal(t, "type.googleapis.com/unknown.v3.Filter", TypeURL("type.googleapis.com/unknown.v3.Filter", V2))
+	assert.Equal(t, "", TypeURL("", V2))
+}
+
+func TestResponse(t *testing.T) {
+	listener := newListenerV3(t)
+	resource, err := ptypes.MarshalAny(listener)
+	assert.NoError(t, err)
+	resp := &v2.DiscoveryResponse{VersionInfo: "1", Nonce: "a", TypeUrl: resourcev3.ListenerType}
+	resp.Resources = []*any.Any{resource}
+
+	translated, err := Response(resp, V2)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", translated.GetVersionInfo())
+	assert.Equal(t, "a", translated.GetNonce())
+	assert.Equal(t, resourcev2.ListenerType, translated.GetTypeUrl())
+	// The response is not modified.
+	assert.Equal(t, resourcev3.ListenerType, resp.GetTypeUrl())
+	assert.Equal(t, resource, resp.Resources[0])
+
+	var listenerV2 v2.Listener
+	assert.NoError(t, ptypes.UnmarshalAny(translated.Resources[0], &listenerV2))
+	assert.Equal(t, "listener", listenerV2.GetName())
+	filters := listenerV2.GetFilterChains()[0].GetFilters()
+	assert.Equal(t, hcmTypeURLV2, filters[0].GetTypedConfig().GetTypeUrl())
+	assert.Equal(t, "type.googleapis.com/unknown.v3.Filter", filters[1].GetTypedConfig().GetTypeUrl())
+
+	// Translating back restores the response.
+	restored, err := Response(translated, V3)
+	assert.NoError(t, err)
+	assert.Equal(t, resourcev3.ListenerType, restored.GetTypeUrl())
+	var listenerV3 listenerv3.Listener
+	assert.NoError(t, ptypes.UnmarshalAny(restored.Resources[0], &listenerV3))
+	assert.True(t, proto.Equal(listener, &listenerV3))
+}
+
+func TestAny(t *testing.T) {
+	// Payloads without anything to translate are returned unchanged.
+	payload := &any.Any{TypeUrl: "type.googleapis.com/unknown.v3.Filter", Value: []byte{0x0a}}
+	translated, err := Any(payload, V2)
+	assert.NoError(t, err)
+	assert.True(t, payload == translated)
+
+	_, err = Any(&any.Any{TypeUrl: resourcev3.ListenerType, Value: []byte{0x0a}}, V2)
+	assert.Error(t, err)
+}
+
+func TestRequest(t *testing.T) {
+	req := &v2.DiscoveryRequest{TypeUrl: resourcev2.ClusterType}
+	Request(req, V3)
+	assert.Equal(t, resourcev3.ClusterType, req.GetTypeUrl())
+	Request(req, V2)
+	assert.Equal(t, resourcev2.ClusterType, req.GetTypeUrl())
+}
+
+func TestDeltaResponse(t *testing.T) {
+	resource, err := ptypes.MarshalAny(&routev2.VirtualHost{Name: "local/a"})
+	assert.NoError(t, err)
+	resp := &v2.DeltaDiscoveryResponse{
+		SystemVersionInfo: "1",
+		TypeUrl:           vhostTypeURLV2,
+		Resources: []*v2.Resource{
+			{Name: "local/a", Aliases: []string{"local/a.com"}, Version: "1", Resource: resource},
+			// Resources without a payload answer on-demand requests for unknown aliases.
+			{Aliases: []string{"local/b.com"}},
+		},
+		RemovedResources: []string{"local/c"},
+	}
+
+	translated, err := DeltaResponse(resp, V3)
+	assert.NoError(t, err)
+	assert.Equal(t, vhostTypeURLV3, translated.GetTypeUrl())
+	assert.Equal(t, vhostTypeURLV3, translated.GetResources()[0].GetResource().GetTypeUrl())
+	assert.Equal(t, []string{"local/a.com"}, translated.GetResources()[0].GetAliases())
+	assert.Nil(t, translated.GetResources()[1].GetResource())
+	assert.Equal(t, []string{"local/c"}, translated.GetRemovedResources())
+	// The response is not modified.
+	assert.Equal(t, vhostTypeURLV2, resp.GetTypeUrl())
+	assert.Equal(t, vhostTypeURLV2, resp.GetResources()[0].GetResource().GetTypeUrl())
+
+	req := &v2.DeltaDiscoveryRequest{TypeUrl: vhostTypeURLV3}
+	DeltaRequest(req, V2)
+	assert.Equal(t, vhostTypeURLV2, req.GetTypeUrl())
+}
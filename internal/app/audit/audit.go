@@ -0,0 +1,163 @@
+// Package audit appends an entry for every response served to a downstream client to an append-only log, either a
+// rotated file or syslog, to keep a record of which configuration each node was served.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/syslog"
+	"sync"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/uber-go/tally"
+)
+
+const (
+	defaultSyslogTag = "xds-relay"
+
+	metricAudited    = "audited"
+	metricAuditError = "audit_error"
+)
+
+// Entry is a single entry of the audit log.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Key       string    `json:"key"`
+	NodeID    string    `json:"node_id"`
+	TypeURL   string    `json:"type_url"`
+	Version   string    `json:"version"`
+	// PayloadSHA256 is empty unless payloads are hashed.
+	PayloadSHA256 string `json:"payload_sha256,omitempty"`
+}
+
+type payloadHash struct {
+	resp *discovery.DiscoveryResponse
+	hash string
+}
+
+// Logger appends an entry to the audit log for every served response.
+type Logger struct {
+	hashPayloads bool
+	logger       log.Logger
+	scope        tally.Scope
+
+	mu   sync.Mutex
+	sink io.WriteCloser
+	// hashes holds the hash of the last response served for each aggregated key, so that a response fanned out to
+	// many nodes is only hashed once.
+	hashes map[string]payloadHash
+}
+
+// New opens the sink of the audit log.
+func New(config *bootstrapv1.AuditLog, logger log.Logger, scope tally.Scope) (*Logger, error) {
+	var sink io.WriteCloser
+	var err error
+	switch {
+	case config.GetFile() != nil:
+		sink, err = newRotatingFile(config.GetFile())
+	case config.GetSyslog() != nil:
+		sink, err = newSyslog(config.GetSyslog())
+	default:
+		err = fmt.Errorf("audit log has no sink")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Logger{
+		hashPayloads: config.GetHashPayloads(),
+		logger:       logger.Named("audit"),
+		scope:        scope,
+		sink:         sink,
+		hashes:       make(map[string]payloadHash),
+	}, nil
+}
+
+func newSyslog(config *bootstrapv1.AuditLogSyslog) (io.WriteCloser, error) {
+	if config.GetNetwork() != "" && config.GetAddress() == "" {
+		return nil, fmt.Errorf("audit log syslog network %s requires an address", config.GetNetwork())
+	}
+	tag := config.GetTag()
+	if tag == "" {
+		tag = defaultSyslogTag
+	}
+	return syslog.Dial(config.GetNetwork(), config.GetAddress(), syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+}
+
+// RecordServed records that the response of the aggregated key was served to the node.
+func (l *Logger) RecordServed(aggregatedKey string, nodeID string, resp *discovery.DiscoveryResponse) {
+	if err := l.write(aggregatedKey, nodeID, resp); err != nil {
+		l.scope.Counter(metricAuditError).Inc(1)
+		l.logger.With("err", err).With("key", aggregatedKey).With("node ID", nodeID).
+			Error(context.Background(), "failed to audit served response")
+		return
+	}
+	l.scope.Counter(metricAudited).Inc(1)
+}
+
+func (l *Logger) write(aggregatedKey string, nodeID string, resp *discovery.DiscoveryResponse) error {
+	entry := Entry{
+		Timestamp: time.Now(),
+		Key:       aggregatedKey,
+		NodeID:    nodeID,
+		TypeURL:   resp.GetTypeUrl(),
+		Version:   resp.GetVersionInfo(),
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.hashPayloads {
+		hash, err := l.hash(aggregatedKey, resp)
+		if err != nil {
+			return err
+		}
+		entry.PayloadSHA256 = hash
+	}
+	line, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+	_, err = l.sink.Write(append(line, '\n'))
+	return err
+}
+
+// hash returns the SHA-256 of the deterministically marshaled resources of the response.
+func (l *Logger) hash(aggregatedKey string, resp *discovery.DiscoveryResponse) (string, error) {
+	if cached, ok := l.hashes[aggregatedKey]; ok && cached.resp == resp {
+		return cached.hash, nil
+	}
+	hasher := sha256.New()
+	buffer := proto.NewBuffer(nil)
+	buffer.SetDeterministic(true)
+	for _, resource := range resp.GetResources() {
+		buffer.Reset()
+		if err := buffer.Marshal(resource); err != nil {
+			return "", err
+		}
+		_, _ = hasher.Write(buffer.Bytes())
+	}
+	hash := hex.EncodeToString(hasher.Sum(nil))
+	l.hashes[aggregatedKey] = payloadHash{resp: resp, hash: hash}
+	return hash, nil
+}
+
+// Forget drops the cached payload hash of the aggregated key.
+func (l *Logger) Forget(aggregatedKey string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.hashes, aggregatedKey)
+}
+
+// Close closes the sink of the audit log.
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.sink.Close()
+}
@@ -0,0 +1,164 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+)
+
+func newDirectory(t *testing.T) string {
+	directory, err := ioutil.TempDir("", "audit")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(directory) })
+	return directory
+}
+
+func newListenerResponse(t *testing.T, version string) *v2.DiscoveryResponse {
+	listener, err := ptypes.MarshalAny(&v2.Listener{Name: "listener_" + version})
+	assert.NoError(t, err)
+	return &v2.DiscoveryResponse{
+		VersionInfo: version,
+		TypeUrl:     upstream.ListenerTypeURL,
+		Resources:   []*any.Any{listener},
+	}
+}
+
+func readEntries(t *testing.T, path string) []Entry {
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(newDirectory(t), "audit.log")
+	scope := tally.NewTestScope("audit", make(map[string]string))
+	auditLog, err := New(&bootstrapv1.AuditLog{
+		Sink: &bootstrapv1.AuditLog_File{File: &bootstrapv1.AuditLogFile{Path: path}},
+	}, log.New("info"), scope)
+	assert.NoError(t, err)
+
+	start := time.Now()
+	auditLog.RecordServed("lds", "node_a", newListenerResponse(t, "1"))
+	auditLog.RecordServed("lds", "node_b", newListenerResponse(t, "1"))
+	assert.NoError(t, auditLog.Close())
+	testutils.AssertCounterValue(t, scope.Snapshot().Counters(), "audit.audited", 2)
+
+	entries := readEntries(t, path)
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "lds", entries[0].Key)
+	assert.Equal(t, "node_a", entries[0].NodeID)
+	assert.Equal(t, upstream.ListenerTypeURL, entries[0].TypeURL)
+	assert.Equal(t, "1", entries[0].Version)
+	assert.Empty(t, entries[0].PayloadSHA256)
+	assert.False(t, entries[0].Timestamp.Before(start.Truncate(time.Second)))
+	assert.Equal(t, "node_b", entries[1].NodeID)
+
+	// Entries are appended to an existing log.
+	auditLog, err = New(&bootstrapv1.AuditLog{
+		Sink: &bootstrapv1.AuditLog_File{File: &bootstrapv1.AuditLogFile{Path: path}},
+	}, log.New("info"), scope)
+	assert.NoError(t, err)
+	auditLog.RecordServed("cds", "node_a", newListenerResponse(t, "2"))
+	assert.NoError(t, auditLog.Close())
+	assert.Equal(t, 3, len(readEntries(t, path)))
+}
+
+func TestHashPayloads(t *testing.T) {
+	path := filepath.Join(newDirectory(t), "audit.log")
+	auditLog, err := New(&bootstrapv1.AuditLog{
+		Sink:         &bootstrapv1.AuditLog_File{File: &bootstrapv1.AuditLogFile{Path: path}},
+		HashPayloads: true,
+	}, log.New("info"), tally.NewTestScope("audit", make(map[string]string)))
+	assert.NoError(t, err)
+
+	resp := newListenerResponse(t, "1")
+	auditLog.RecordServed("lds", "node_a", resp)
+	auditLog.RecordServed("lds", "node_b", resp)
+	auditLog.RecordServed("lds", "node_a", newListenerResponse(t, "1"))
+	auditLog.RecordServed("lds", "node_a", newListenerResponse(t, "2"))
+	assert.NoError(t, auditLog.Close())
+
+	entries := readEntries(t, path)
+	assert.Equal(t, 4, len(entries))
+	assert.Len(t, entries[0].PayloadSHA256, 64)
+	assert.Equal(t, entries[0].PayloadSHA256, entries[1].PayloadSHA256)
+	assert.Equal(t, entries[0].PayloadSHA256, entries[2].PayloadSHA256)
+	assert.NotEqual(t, entries[0].PayloadSHA256, entries[3].PayloadSHA256)
+}
+
+func TestRotation(t *testing.T) {
+	path := filepath.Join(newDirectory(t), "audit.log")
+	auditLog, err := New(&bootstrapv1.AuditLog{
+		Sink: &bootstrapv1.AuditLog_File{File: &bootstrapv1.AuditLogFile{
+			Path:         path,
+			MaxSizeBytes: &wrappers.UInt64Value{Value: 1},
+			MaxBackups:   &wrappers.UInt32Value{Value: 2},
+		}},
+	}, log.New("info"), tally.NewTestScope("audit", make(map[string]string)))
+	assert.NoError(t, err)
+
+	// Every entry exceeds the maximum size, so each is written to a new file and only the latest two are kept.
+	for _, version := range []string{"1", "2", "3", "4"} {
+		auditLog.RecordServed("lds", "node", newListenerResponse(t, version))
+	}
+	assert.NoError(t, auditLog.Close())
+
+	for path, version := range map[string]string{path: "4", path + ".1": "3", path + ".2": "2"} {
+		entries := readEntries(t, path)
+		assert.Equal(t, 1, len(entries))
+		assert.Equal(t, version, entries[0].Version)
+	}
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSyslog(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer conn.Close()
+	auditLog, err := New(&bootstrapv1.AuditLog{
+		Sink: &bootstrapv1.AuditLog_Syslog{Syslog: &bootstrapv1.AuditLogSyslog{
+			Network: "udp",
+			Address: conn.LocalAddr().String(),
+		}},
+	}, log.New("info"), tally.NewTestScope("audit", make(map[string]string)))
+	assert.NoError(t, err)
+	defer auditLog.Close()
+
+	auditLog.RecordServed("lds", "node", newListenerResponse(t, "1"))
+	buffer := make([]byte, 4096)
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	n, _, err := conn.ReadFrom(buffer)
+	assert.NoError(t, err)
+	message := string(buffer[:n])
+	assert.Contains(t, message, defaultSyslogTag)
+	var entry Entry
+	assert.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(message[strings.Index(message, "{"):])), &entry))
+	assert.Equal(t, "node", entry.NodeID)
+	assert.Equal(t, "1", entry.Version)
+}
@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+)
+
+const (
+	defaultMaxSizeBytes = 100 * 1024 * 1024
+	defaultMaxBackups   = 5
+)
+
+// rotatingFile appends to a file, and renames it to a backup once it reaches the maximum size. It is not safe for
+// concurrent use.
+type rotatingFile struct {
+	path         string
+	maxSizeBytes uint64
+	maxBackups   uint32
+
+	file *os.File
+	size uint64
+}
+
+func newRotatingFile(config *bootstrapv1.AuditLogFile) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:         config.GetPath(),
+		maxSizeBytes: defaultMaxSizeBytes,
+		maxBackups:   defaultMaxBackups,
+	}
+	if config.GetMaxSizeBytes() != nil {
+		f.maxSizeBytes = config.GetMaxSizeBytes().GetValue()
+	}
+	if config.GetMaxBackups() != nil {
+		f.maxBackups = config.GetMaxBackups().GetValue()
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// backupPath returns the path of the nth most recent backup.
+func backupPath(path string, n uint32) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	f.file, f.size = file, uint64(info.Size())
+	return nil
+}
+
+// Write rotates the file first if p would grow it past the maximum size. Entries are never split across files.
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	if f.size > 0 && f.size+uint64(len(p)) > f.maxSizeBytes {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += uint64(n)
+	return n, err
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	if f.maxBackups == 0 {
+		if err := os.Remove(f.path); err != nil {
+			return err
+		}
+		return f.open()
+	}
+	for n := f.maxBackups - 1; n > 0; n-- {
+		if err := os.Rename(backupPath(f.path, n), backupPath(f.path, n+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(f.path, backupPath(f.path, 1)); err != nil {
+		return err
+	}
+	return f.open()
+}
+
+func (f *rotatingFile) Close() error {
+	return f.file.Close()
+}
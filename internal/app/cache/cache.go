@@ -3,75 +3,177 @@
 package cache
 
 import (
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
-	"github.com/golang/groupcache/lru"
+)
+
+var (
+	// ErrKeyNotFound is returned by Fetch for keys that are not cached.
+	ErrKeyNotFound = errors.New("no value found for key")
+	// ErrCacheCastFailure is returned for keys whose cached value is not a Resource.
+	ErrCacheCastFailure = errors.New("unable to cast cache value to type resource for key")
 )
 
 type Cache interface {
 	// Fetch returns the cached resource if it exists.
 	Fetch(key string) (*Resource, error)
 
+	// FetchAll returns the cached resources of the keys that exist, locking each shard once.
+	FetchAll(keys []string) (map[string]*Resource, error)
+
 	// SetResponse sets the cache response and returns the list of requests.
-	SetResponse(key string, resp v2.DiscoveryResponse) (map[*v2.DiscoveryRequest]bool, error)
+	SetResponse(key string, resp v2.DiscoveryResponse) (map[WatchID]*v2.DiscoveryRequest, error)
+
+	// SetResponseIfNewer atomically sets the cache response only if isNewer reports it as newer than the cached
+	// response, so that concurrent writers cannot regress the key to an older version.
+	SetResponseIfNewer(key string, resp v2.DiscoveryResponse, isNewer Comparator) (SetResult, error)
+
+	// SetResponses sets the cache responses of the keys, locking each shard once, and returns the list of requests of
+	// each key that was already cached.
+	SetResponses(responses map[string]v2.DiscoveryResponse) (map[string]map[WatchID]*v2.DiscoveryRequest, error)
 
-	// AddRequest adds the request to the cache.
-	AddRequest(key string, req *v2.DiscoveryRequest) error
+	// Range calls f for each unexpired entry of the cache until f returns false.
+	Range(f func(key string, resource Resource) bool)
 
-	// DeleteRequest removes the given request from any cache entries it's present in.
-	DeleteRequest(key string, req *v2.DiscoveryRequest) error
+	// AddRequest adds the request of the watch to the cache.
+	AddRequest(key string, id WatchID, req *v2.DiscoveryRequest) error
+
+	// DeleteRequest removes the request of the watch from the cache entry of the key.
+	DeleteRequest(key string, id WatchID) error
+
+	// EvictOldest evicts up to n keys chosen by the eviction strategy, least recently used by default, and returns the
+	// number of keys evicted.
+	EvictOldest(n int) int
+
+	// RemoveExpired removes the expired keys, and returns the number of keys removed. Expired keys are otherwise only
+	// removed when they are fetched.
+	RemoveExpired() int
 
 	// GetReadOnlyCache returns a copy of the cache that only exposes read-only methods in its interface.
 	GetReadOnlyCache() ReadOnlyCache
 }
 
 type ReadOnlyCache interface {
-	// Fetch returns the cached resource if it exists.
+	// FetchReadOnly returns the cached resource if it exists. Unlike Fetch, it does not record a use of the key, so
+	// reading the cache does not change which keys are evicted.
 	FetchReadOnly(key string) (Resource, error)
+
+	// Range calls f for each unexpired entry of the cache until f returns false.
+	Range(f func(key string, resource Resource) bool)
+
+	// Keys returns the sorted keys of the unexpired entries of the cache that start with prefix. An empty prefix
+	// matches every key.
+	Keys(prefix string) []string
 }
 
+const (
+	// defaultNumShards is the upper bound on the number of independently locked partitions of the cache.
+	defaultNumShards = 32
+	// minEntriesPerShard is the minimum capacity of a shard when max entries is bounded. Small caches are kept in
+	// fewer shards so that LRU eviction stays close to exact.
+	minEntriesPerShard = 64
+)
+
+// cache partitions its keys across a fixed set of shards, each with its own lock and LRU, so that operations on
+// different aggregated keys do not contend on a single mutex.
 type cache struct {
-	cacheMu sync.RWMutex
-	cache   lru.Cache
-	ttl     time.Duration
+	shards []*shard
+	ttl    time.Duration
+}
+
+// shard is a single partition of the cache. The underlying store records the use of its entries on every Get, so all
+// operations, including reads, take the exclusive lock. The store cannot be iterated, so its entries are mirrored in
+// entries, which Range reads without affecting the eviction order of the keys. Shared holds the keys whose requests map
+// was returned since it was last copied, and must be copied before it is modified.
+type shard struct {
+	mu      sync.Mutex
+	cache   store
+	entries map[string]Resource
+	shared  map[string]struct{}
 }
 
+// WatchID identifies a downstream watch. Watch IDs are never reused within a process, so that requests stay
+// identifiable when they are copied or serialized.
+type WatchID uint64
+
 type Resource struct {
-	Resp           *v2.DiscoveryResponse
-	Requests       map[*v2.DiscoveryRequest]bool
+	Resp *v2.DiscoveryResponse
+	// Requests are the requests of the open watches of the key, by watch ID. Once returned by the cache, the map is
+	// copied rather than modified when watches are added or deleted, so it is safe to iterate without the lock of the
+	// cache, but must not be modified.
+	Requests       map[WatchID]*v2.DiscoveryRequest
 	ExpirationTime time.Time
+	// FirstSeen is the time the key was first populated with a response, and LastUpdated is the time of its latest
+	// response. Both are zero until a response is cached for the key.
+	FirstSeen   time.Time
+	LastUpdated time.Time
+	// UpdateCount is the number of responses cached for the key since it was first populated.
+	UpdateCount uint64
+}
+
+// Comparator reports whether response is newer than the previous response of a key. Previous is nil if no response
+// is cached for the key.
+type Comparator func(previous *v2.DiscoveryResponse, response *v2.DiscoveryResponse) bool
+
+// SetResult is the outcome of SetResponseIfNewer.
+type SetResult struct {
+	// Won is true if the response was cached.
+	Won bool
+	// PreviousVersion is the version of the response cached before the write, or empty if there was none.
+	PreviousVersion string
+	// Requests are the requests of the open watches of the key if the write won.
+	Requests map[WatchID]*v2.DiscoveryRequest
 }
 
 // OnEvictFunc is a callback function for each eviction. Receives the key and cache value when called.
 type OnEvictFunc func(key string, value Resource)
 
-func NewCache(maxEntries int, onEvicted OnEvictFunc, ttl time.Duration) (Cache, error) {
+// Factory creates a cache that calls onEvicted for each eviction. It allows the orchestrator to be constructed with
+// a cache implementation other than the one returned by NewCache.
+type Factory func(onEvicted OnEvictFunc) (Cache, error)
+
+func NewCache(maxEntries int, onEvicted OnEvictFunc, ttl time.Duration, opts ...Option) (Cache, error) {
 	if ttl < 0 {
 		return nil, fmt.Errorf("ttl must be nonnegative but was set to %v", ttl)
 	}
-	return &cache{
-		cache: lru.Cache{
-			// Max number of cache entries before an item is evicted. Zero means no limit.
-			MaxEntries: maxEntries,
-			// OnEvict is called for each eviction.
-			OnEvicted: func(cacheKey lru.Key, cacheValue interface{}) {
-				key, ok := cacheKey.(string)
-				if !ok {
-					panic(fmt.Sprintf("Unable to cast key %v to string upon eviction", cacheKey))
-				}
-				value, ok := cacheValue.(Resource)
-				if !ok {
-					panic(fmt.Sprintf("Unable to cast value %v to resource upon eviction", cacheValue))
-				}
-				onEvicted(key, value)
-			},
-		},
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
+	numShards := getNumShards(maxEntries)
+	c := &cache{
+		shards: make([]*shard, numShards),
 		// Duration before which an item is evicted for expiring. Zero means no expiration time.
 		ttl: ttl,
-	}, nil
+	}
+	for i := range c.shards {
+		s := &shard{
+			entries: make(map[string]Resource),
+			shared:  make(map[string]struct{}),
+		}
+		// Max number of shard entries before an item is evicted. Zero means no limit.
+		shardMaxEntries := getShardMaxEntries(maxEntries, numShards, i)
+		// OnEvict is called for each eviction.
+		s.cache = newStore(options.strategy, shardMaxEntries, func(key string, cacheValue interface{}) {
+			value, ok := cacheValue.(Resource)
+			if !ok {
+				panic(fmt.Sprintf("Unable to cast value %v to resource upon eviction", cacheValue))
+			}
+			delete(s.entries, key)
+			delete(s.shared, key)
+			onEvicted(key, value)
+		})
+		c.shards[i] = s
+	}
+	return c, nil
 }
 
 func (c *cache) GetReadOnlyCache() ReadOnlyCache {
@@ -79,108 +181,352 @@ func (c *cache) GetReadOnlyCache() ReadOnlyCache {
 }
 
 func (c *cache) FetchReadOnly(key string) (Resource, error) {
-	resource, err := c.Fetch(key)
-	if resource == nil {
-		return Resource{}, err
-	}
-	return *resource, err
+	return c.getShard(key).peek(key, time.Now())
 }
 
 func (c *cache) Fetch(key string) (*Resource, error) {
-	c.cacheMu.RLock()
-	value, found := c.cache.Get(key)
-	c.cacheMu.RUnlock()
-	if !found {
-		return nil, fmt.Errorf("no value found for key: %s", key)
+	s := c.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetch(key, time.Now())
+}
+
+func (c *cache) FetchAll(keys []string) (map[string]*Resource, error) {
+	currentTime := time.Now()
+	resources := make(map[string]*Resource, len(keys))
+	for s, shardKeys := range c.groupByShard(keys) {
+		err := func() error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			for _, key := range shardKeys {
+				resource, err := s.fetch(key, currentTime)
+				if errors.Is(err, ErrKeyNotFound) || (err == nil && resource == nil) {
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				resources[key] = resource
+			}
+			return nil
+		}()
+		if err != nil {
+			return nil, err
+		}
 	}
-	resource, ok := value.(Resource)
-	if !ok {
-		return nil, fmt.Errorf("unable to cast cache value to type resource for key: %s", key)
+	return resources, nil
+}
+
+func (c *cache) SetResponse(key string, response v2.DiscoveryResponse) (map[WatchID]*v2.DiscoveryRequest, error) {
+	s := c.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return c.setResponse(s, key, response, time.Now())
+}
+
+func (c *cache) SetResponses(
+	responses map[string]v2.DiscoveryResponse,
+) (map[string]map[WatchID]*v2.DiscoveryRequest, error) {
+	keys := make([]string, 0, len(responses))
+	for key := range responses {
+		keys = append(keys, key)
 	}
-	// Lazy eviction based on TTL occurs here. Fetch does not increase the lifespan of the key.
-	if resource.isExpired(time.Now()) {
-		c.cacheMu.Lock()
-		defer c.cacheMu.Unlock()
-		value, found = c.cache.Get(key)
-		if !found {
-			// The entry was already evicted.
-			return nil, nil
-		}
-		resource, ok = value.(Resource)
-		if !ok {
-			return nil, fmt.Errorf("unable to cast cache value to type resource for key: %s", key)
-		}
-		// This second check for expiration is required in case a recent SetResponse call was made to the same key
-		// from another goroutine, extending the deadline for eviction. Without it, a key that was recently refreshed
-		// may be prematurely removed by the goroutine calling Fetch.
-		if resource.isExpired(time.Now()) {
-			c.cache.Remove(key)
-			return nil, nil
+	currentTime := time.Now()
+	requests := make(map[string]map[WatchID]*v2.DiscoveryRequest, len(responses))
+	for s, shardKeys := range c.groupByShard(keys) {
+		err := func() error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			for _, key := range shardKeys {
+				keyRequests, err := c.setResponse(s, key, responses[key], currentTime)
+				if err != nil {
+					return err
+				}
+				if keyRequests != nil {
+					requests[key] = keyRequests
+				}
+			}
+			return nil
+		}()
+		if err != nil {
+			return nil, err
 		}
 	}
-	return &resource, nil
+	return requests, nil
 }
 
-func (c *cache) SetResponse(key string, response v2.DiscoveryResponse) (map[*v2.DiscoveryRequest]bool, error) {
-	c.cacheMu.Lock()
-	defer c.cacheMu.Unlock()
-	value, found := c.cache.Get(key)
-	if !found {
-		resource := Resource{
-			Resp:           &response,
-			ExpirationTime: c.getExpirationTime(time.Now()),
-			Requests:       make(map[*v2.DiscoveryRequest]bool),
+// Range visits the shards one at a time, so each shard is only locked while its entries are copied, and the entries
+// of a shard reflect the time it was visited.
+func (c *cache) Range(f func(key string, resource Resource) bool) {
+	for _, s := range c.shards {
+		entries := s.list(time.Now())
+		for key, resource := range entries {
+			if !f(key, resource) {
+				return
+			}
 		}
-		c.cache.Add(key, resource)
-		return nil, nil
 	}
-	resource, ok := value.(Resource)
-	if !ok {
-		return nil, fmt.Errorf("unable to cast cache value to type resource for key: %s", key)
+}
+
+func (c *cache) SetResponseIfNewer(
+	key string,
+	response v2.DiscoveryResponse,
+	isNewer Comparator,
+) (SetResult, error) {
+	s := c.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	currentTime := time.Now()
+	var previous *v2.DiscoveryResponse
+	// Expired responses are not served, so they cannot be regressed.
+	if resource, found := s.entries[key]; found && !resource.isExpired(currentTime) {
+		previous = resource.Resp
 	}
-	resource.Resp = &response
-	resource.ExpirationTime = c.getExpirationTime(time.Now())
-	c.cache.Add(key, resource)
-	return resource.Requests, nil
+	result := SetResult{PreviousVersion: previous.GetVersionInfo()}
+	if !isNewer(previous, &response) {
+		return result, nil
+	}
+	requests, err := c.setResponse(s, key, response, currentTime)
+	if err != nil {
+		return SetResult{}, err
+	}
+	result.Won = true
+	result.Requests = requests
+	return result, nil
 }
 
-func (c *cache) AddRequest(key string, req *v2.DiscoveryRequest) error {
-	c.cacheMu.Lock()
-	defer c.cacheMu.Unlock()
-	value, found := c.cache.Get(key)
+// IsNewerVersion is a Comparator that orders responses by their version. Versions that are both unsigned integers are
+// compared numerically. Other versions are opaque and cannot be ordered, so any version other than the cached one is
+// considered newer.
+func IsNewerVersion(previous *v2.DiscoveryResponse, response *v2.DiscoveryResponse) bool {
+	if previous == nil {
+		return true
+	}
+	previousVersion, err := strconv.ParseUint(previous.GetVersionInfo(), 10, 64)
+	if err != nil {
+		return previous.GetVersionInfo() != response.GetVersionInfo()
+	}
+	version, err := strconv.ParseUint(response.GetVersionInfo(), 10, 64)
+	if err != nil {
+		return previous.GetVersionInfo() != response.GetVersionInfo()
+	}
+	return version > previousVersion
+}
+
+func (c *cache) AddRequest(key string, id WatchID, req *v2.DiscoveryRequest) error {
+	s := c.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resource, found := s.entries[key]
 	if !found {
-		requests := make(map[*v2.DiscoveryRequest]bool)
-		requests[req] = true
+		requests := make(map[WatchID]*v2.DiscoveryRequest)
+		requests[id] = req
 		resource := Resource{
 			Requests:       requests,
 			ExpirationTime: c.getExpirationTime(time.Now()),
 		}
-		c.cache.Add(key, resource)
+		s.add(key, resource)
 		return nil
 	}
-	resource, ok := value.(Resource)
-	if !ok {
-		return fmt.Errorf("unable to cast cache value to type resource for key: %s", key)
-	}
-	resource.Requests[req] = true
-	c.cache.Add(key, resource)
+	resource.Requests = s.own(key, resource.Requests)
+	resource.Requests[id] = req
+	s.add(key, resource)
 	return nil
 }
 
-func (c *cache) DeleteRequest(key string, req *v2.DiscoveryRequest) error {
-	c.cacheMu.Lock()
-	defer c.cacheMu.Unlock()
-	value, found := c.cache.Get(key)
+func (c *cache) DeleteRequest(key string, id WatchID) error {
+	s := c.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resource, found := s.entries[key]
 	if !found {
 		return nil
 	}
+	if _, ok := resource.Requests[id]; !ok {
+		return nil
+	}
+	resource.Requests = s.own(key, resource.Requests)
+	delete(resource.Requests, id)
+	s.add(key, resource)
+	return nil
+}
+
+// EvictOldest approximates a global eviction order across the shards by evicting the key chosen by the strategy of the
+// largest shard, one key at a time.
+func (c *cache) EvictOldest(n int) int {
+	evicted := 0
+	for ; evicted < n; evicted++ {
+		var largest *shard
+		largestLen := 0
+		for _, s := range c.shards {
+			s.mu.Lock()
+			if length := s.cache.Len(); length > largestLen {
+				largest, largestLen = s, length
+			}
+			s.mu.Unlock()
+		}
+		if largest == nil {
+			break
+		}
+		largest.mu.Lock()
+		ok := largest.cache.Evict()
+		largest.mu.Unlock()
+		if !ok {
+			break
+		}
+	}
+	return evicted
+}
+
+func (c *cache) RemoveExpired() int {
+	currentTime := time.Now()
+	removed := 0
+	for _, s := range c.shards {
+		removed += s.removeExpired(currentTime)
+	}
+	return removed
+}
+
+func (c *cache) Keys(prefix string) []string {
+	currentTime := time.Now()
+	var keys []string
+	for _, s := range c.shards {
+		keys = append(keys, s.keys(prefix, currentTime)...)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fetch returns the cached resource of the key. The shard lock must be held.
+func (s *shard) fetch(key string, currentTime time.Time) (*Resource, error) {
+	value, found := s.cache.Get(key)
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
 	resource, ok := value.(Resource)
 	if !ok {
-		return fmt.Errorf("unable to cast cache value to type resource for key: %s", key)
+		return nil, fmt.Errorf("%w: %s", ErrCacheCastFailure, key)
 	}
-	delete(resource.Requests, req)
-	c.cache.Add(key, resource)
-	return nil
+	// Lazy eviction based on TTL occurs here. Fetch does not increase the lifespan of the key.
+	if resource.isExpired(currentTime) {
+		s.cache.Remove(key)
+		return nil, nil
+	}
+	resource.Requests = s.share(key, resource.Requests)
+	return &resource, nil
+}
+
+// peek returns the cached resource of the key from the mirrored entries, so that the use of the key is not recorded
+// by the store. Expired resources are returned empty, and are left to be removed by fetch.
+func (s *shard) peek(key string, currentTime time.Time) (Resource, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resource, found := s.entries[key]
+	if !found {
+		return Resource{}, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+	if resource.isExpired(currentTime) {
+		return Resource{}, nil
+	}
+	resource.Requests = s.share(key, resource.Requests)
+	return resource, nil
+}
+
+// setResponse sets the cache response of the key. The shard lock must be held.
+func (c *cache) setResponse(
+	s *shard,
+	key string,
+	response v2.DiscoveryResponse,
+	currentTime time.Time,
+) (map[WatchID]*v2.DiscoveryRequest, error) {
+	resource, found := s.entries[key]
+	if !found {
+		resource := Resource{
+			Resp:           &response,
+			ExpirationTime: c.getExpirationTime(currentTime),
+			Requests:       make(map[WatchID]*v2.DiscoveryRequest),
+		}
+		resource.recordUpdate(currentTime)
+		s.add(key, resource)
+		return nil, nil
+	}
+	resource.Resp = &response
+	resource.ExpirationTime = c.getExpirationTime(currentTime)
+	resource.recordUpdate(currentTime)
+	s.add(key, resource)
+	return s.share(key, resource.Requests), nil
+}
+
+// add caches the resource of the key. Writes read the resource of the key from the mirrored entries rather than from
+// the store, so that only fetches count as uses of the key. The shard lock must be held.
+func (s *shard) add(key string, resource Resource) {
+	s.entries[key] = resource
+	s.cache.Add(key, resource)
+}
+
+// share marks the requests map of the key as returned, so that it is copied before it is next modified, and returns it.
+// The shard lock must be held.
+func (s *shard) share(key string, requests map[WatchID]*v2.DiscoveryRequest) map[WatchID]*v2.DiscoveryRequest {
+	s.shared[key] = struct{}{}
+	return requests
+}
+
+// own returns the requests map of the key for modification, copying it if it was returned since it was last copied.
+// The shard lock must be held.
+func (s *shard) own(key string, requests map[WatchID]*v2.DiscoveryRequest) map[WatchID]*v2.DiscoveryRequest {
+	if _, ok := s.shared[key]; !ok {
+		return requests
+	}
+	delete(s.shared, key)
+	copied := make(map[WatchID]*v2.DiscoveryRequest, len(requests)+1)
+	for id, request := range requests {
+		copied[id] = request
+	}
+	return copied
+}
+
+// list returns a copy of the unexpired entries of the shard.
+func (s *shard) list(currentTime time.Time) map[string]Resource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make(map[string]Resource, len(s.entries))
+	for key, resource := range s.entries {
+		if resource.isExpired(currentTime) {
+			continue
+		}
+		resource.Requests = s.share(key, resource.Requests)
+		entries[key] = resource
+	}
+	return entries
+}
+
+// removeExpired removes the expired entries of the shard, and returns the number of entries removed.
+func (s *shard) removeExpired(currentTime time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var expired []string
+	for key, resource := range s.entries {
+		if resource.isExpired(currentTime) {
+			expired = append(expired, key)
+		}
+	}
+	for _, key := range expired {
+		s.cache.Remove(key)
+	}
+	return len(expired)
+}
+
+// keys returns the keys of the unexpired entries of the shard that start with prefix.
+func (s *shard) keys(prefix string, currentTime time.Time) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for key, resource := range s.entries {
+		if strings.HasPrefix(key, prefix) && !resource.isExpired(currentTime) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
 }
 
 func (r *Resource) isExpired(currentTime time.Time) bool {
@@ -190,9 +536,80 @@ func (r *Resource) isExpired(currentTime time.Time) bool {
 	return r.ExpirationTime.Before(currentTime)
 }
 
+// recordUpdate records that a response was cached for the key at the current time.
+func (r *Resource) recordUpdate(currentTime time.Time) {
+	if r.FirstSeen.IsZero() {
+		r.FirstSeen = currentTime
+	}
+	r.LastUpdated = currentTime
+	r.UpdateCount++
+}
+
+// UpdateRate returns the average number of responses cached for the key per minute since it was first populated. Keys
+// populated less than a minute ago are averaged over a minute, so that their first response does not read as flapping.
+func (r *Resource) UpdateRate(currentTime time.Time) float64 {
+	if r.FirstSeen.IsZero() {
+		return 0
+	}
+	elapsed := currentTime.Sub(r.FirstSeen)
+	if elapsed < time.Minute {
+		elapsed = time.Minute
+	}
+	return float64(r.UpdateCount) / elapsed.Minutes()
+}
+
 func (c *cache) getExpirationTime(currentTime time.Time) time.Time {
 	if c.ttl > 0 {
 		return currentTime.Add(c.ttl)
 	}
 	return time.Time{}
 }
+
+// getShard returns the shard responsible for the given key.
+func (c *cache) getShard(key string) *shard {
+	if len(c.shards) == 1 {
+		return c.shards[0]
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return c.shards[h.Sum32()%uint32(len(c.shards))]
+}
+
+// groupByShard groups the keys by the shard responsible for them.
+func (c *cache) groupByShard(keys []string) map[*shard][]string {
+	grouped := make(map[*shard][]string)
+	for _, key := range keys {
+		s := c.getShard(key)
+		grouped[s] = append(grouped[s], key)
+	}
+	return grouped
+}
+
+// getNumShards returns the number of shards to partition the cache into. When the cache is bounded, the shard count
+// is reduced such that each shard holds at least minEntriesPerShard entries.
+func getNumShards(maxEntries int) int {
+	if maxEntries <= 0 {
+		return defaultNumShards
+	}
+	numShards := maxEntries / minEntriesPerShard
+	if numShards < 1 {
+		return 1
+	}
+	if numShards > defaultNumShards {
+		return defaultNumShards
+	}
+	return numShards
+}
+
+// getShardMaxEntries divides the max entries evenly across shards, and gives the remainder to the first shards, so that
+// the shards never hold more than max entries in total. Zero means no limit.
+func getShardMaxEntries(maxEntries int, numShards int, shard int) int {
+	if maxEntries <= 0 {
+		return 0
+	}
+	shardMaxEntries := maxEntries / numShards
+	if shard < maxEntries%numShards {
+		shardMaxEntries++
+	}
+	return shardMaxEntries
+}
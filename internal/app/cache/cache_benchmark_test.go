@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+)
+
+const (
+	benchmarkNumKeys     = 1000
+	benchmarkMaxEntries  = 10000
+	benchmarkWatchesEach = 10
+)
+
+func benchmarkKeys() []string {
+	keys := make([]string, benchmarkNumKeys)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key_%d", i)
+	}
+	return keys
+}
+
+// newBenchmarkCache returns a cache pre-populated with a response and a set of
+// downstream watches for each key, mimicking a relay in steady state.
+func newBenchmarkCache(b *testing.B, keys []string) Cache {
+	c, err := NewCache(benchmarkMaxEntries, func(string, Resource) {}, time.Minute)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, key := range keys {
+		if _, err := c.SetResponse(key, testDiscoveryResponse); err != nil {
+			b.Fatal(err)
+		}
+		for i := 0; i < benchmarkWatchesEach; i++ {
+			req := &v2.DiscoveryRequest{
+				Node:    &core.Node{Id: fmt.Sprintf("%s_node_%d", key, i)},
+				TypeUrl: testRequestA.TypeUrl,
+			}
+			if err := c.AddRequest(key, nextBenchmarkWatchID(), req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	return c
+}
+
+// benchmarkWatchIDs is the last watch ID handed out to a benchmark watch.
+var benchmarkWatchIDs uint64
+
+func nextBenchmarkWatchID() WatchID {
+	return WatchID(atomic.AddUint64(&benchmarkWatchIDs, 1))
+}
+
+func BenchmarkFetch(b *testing.B) {
+	keys := benchmarkKeys()
+	c := newBenchmarkCache(b, keys)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for pb.Next() {
+			if _, err := c.Fetch(keys[r.Intn(len(keys))]); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSetResponse(b *testing.B) {
+	keys := benchmarkKeys()
+	c := newBenchmarkCache(b, keys)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for pb.Next() {
+			if _, err := c.SetResponse(keys[r.Intn(len(keys))], testDiscoveryResponse); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
+func BenchmarkAddDeleteRequest(b *testing.B) {
+	keys := benchmarkKeys()
+	c := newBenchmarkCache(b, keys)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		id, req := nextBenchmarkWatchID(), &v2.DiscoveryRequest{TypeUrl: testRequestA.TypeUrl}
+		for pb.Next() {
+			key := keys[r.Intn(len(keys))]
+			if err := c.AddRequest(key, id, req); err != nil {
+				b.Error(err)
+			}
+			if err := c.DeleteRequest(key, id); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
+// BenchmarkFanoutLoad simulates the access pattern of the orchestrator under
+// load: the majority of operations are downstream watches being registered and
+// served from the cache, interleaved with less frequent upstream responses
+// that trigger a fanout read of the watchers.
+func BenchmarkFanoutLoad(b *testing.B) {
+	keys := benchmarkKeys()
+	c := newBenchmarkCache(b, keys)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		id, req := nextBenchmarkWatchID(), &v2.DiscoveryRequest{TypeUrl: testRequestA.TypeUrl}
+		for pb.Next() {
+			key := keys[r.Intn(len(keys))]
+			switch n := r.Intn(10); {
+			case n == 0:
+				// Upstream response followed by a fanout read.
+				if _, err := c.SetResponse(key, testDiscoveryResponse); err != nil {
+					b.Error(err)
+				}
+				if _, err := c.Fetch(key); err != nil {
+					b.Error(err)
+				}
+			case n < 4:
+				// Downstream watch churn.
+				if err := c.AddRequest(key, id, req); err != nil {
+					b.Error(err)
+				}
+				if err := c.DeleteRequest(key, id); err != nil {
+					b.Error(err)
+				}
+			default:
+				// Downstream watch served from cache.
+				if _, err := c.Fetch(key); err != nil {
+					b.Error(err)
+				}
+			}
+		}
+	})
+}
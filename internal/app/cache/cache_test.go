@@ -1,6 +1,8 @@
 package cache
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -16,6 +18,10 @@ const testKeyA = "key_A"
 
 const testKeyB = "key_B"
 
+const testWatchA WatchID = 1
+
+const testWatchB WatchID = 2
+
 type panicValues struct {
 	key    lru.Key
 	reason string
@@ -68,7 +74,7 @@ var testDiscoveryResponse = v2.DiscoveryResponse{
 
 var testResource = Resource{
 	Resp:     &testDiscoveryResponse,
-	Requests: make(map[*v2.DiscoveryRequest]bool),
+	Requests: make(map[WatchID]*v2.DiscoveryRequest),
 }
 
 func TestAddRequestAndFetch(t *testing.T) {
@@ -77,9 +83,10 @@ func TestAddRequestAndFetch(t *testing.T) {
 
 	resource, err := cache.Fetch(testKeyA)
 	assert.EqualError(t, err, "no value found for key: key_A")
+	assert.True(t, errors.Is(err, ErrKeyNotFound))
 	assert.Nil(t, resource)
 
-	err = cache.AddRequest(testKeyA, &testRequestA)
+	err = cache.AddRequest(testKeyA, testWatchA, &testRequestA)
 	assert.NoError(t, err)
 
 	resource, err = cache.Fetch(testKeyA)
@@ -109,17 +116,17 @@ func TestAddRequestAndSetResponse(t *testing.T) {
 	cache, err := NewCache(2, testOnEvict, time.Second*60)
 	assert.NoError(t, err)
 
-	err = cache.AddRequest(testKeyA, &testRequestA)
+	err = cache.AddRequest(testKeyA, testWatchA, &testRequestA)
 	assert.NoError(t, err)
 
-	err = cache.AddRequest(testKeyA, &testRequestB)
+	err = cache.AddRequest(testKeyA, testWatchB, &testRequestB)
 	assert.NoError(t, err)
 
 	requests, err := cache.SetResponse(testKeyA, testDiscoveryResponse)
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(requests))
-	assert.Equal(t, true, requests[&testRequestA])
-	assert.Equal(t, true, requests[&testRequestB])
+	assert.Equal(t, &testRequestA, requests[testWatchA])
+	assert.Equal(t, &testRequestB, requests[testWatchB])
 
 	resource, err := cache.Fetch(testKeyA)
 	assert.NoError(t, err)
@@ -141,7 +148,7 @@ func TestMaxEntries(t *testing.T) {
 		key:    testKeyA,
 		reason: "testOnEvict called",
 	}, func() {
-		err = cache.AddRequest(testKeyB, &testRequestB)
+		err = cache.AddRequest(testKeyB, testWatchB, &testRequestB)
 		assert.NoError(t, err)
 	})
 
@@ -192,9 +199,13 @@ func TestTTL_Disabled(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, testDiscoveryResponse, *resource.Resp)
 
+	expected := testResource
+	expected.FirstSeen = resource.FirstSeen
+	expected.LastUpdated = resource.LastUpdated
+	expected.UpdateCount = 1
 	gomega.Consistently(func() (*Resource, error) {
 		return cache.Fetch(testKeyA)
-	}).Should(gomega.Equal(&testResource))
+	}).Should(gomega.Equal(&expected))
 }
 
 func TestTTL_Negative(t *testing.T) {
@@ -203,6 +214,233 @@ func TestTTL_Negative(t *testing.T) {
 	assert.Nil(t, cache)
 }
 
+func TestSetResponse_UpdateMetadata(t *testing.T) {
+	cache, err := NewCache(1, testOnEvict, time.Second*60)
+	assert.NoError(t, err)
+
+	// Watches populate the key without a response.
+	err = cache.AddRequest(testKeyA, testWatchA, &testRequestA)
+	assert.NoError(t, err)
+	resource, err := cache.Fetch(testKeyA)
+	assert.NoError(t, err)
+	assert.True(t, resource.FirstSeen.IsZero())
+	assert.True(t, resource.LastUpdated.IsZero())
+	assert.Equal(t, uint64(0), resource.UpdateCount)
+
+	_, err = cache.SetResponse(testKeyA, testDiscoveryResponse)
+	assert.NoError(t, err)
+	resource, err = cache.Fetch(testKeyA)
+	assert.NoError(t, err)
+	firstSeen := resource.FirstSeen
+	assert.False(t, firstSeen.IsZero())
+	assert.Equal(t, firstSeen, resource.LastUpdated)
+	assert.Equal(t, uint64(1), resource.UpdateCount)
+
+	_, err = cache.SetResponse(testKeyA, testDiscoveryResponse)
+	assert.NoError(t, err)
+	resource, err = cache.Fetch(testKeyA)
+	assert.NoError(t, err)
+	assert.Equal(t, firstSeen, resource.FirstSeen)
+	assert.False(t, resource.LastUpdated.Before(firstSeen))
+	assert.Equal(t, uint64(2), resource.UpdateCount)
+}
+
+func TestSetResponseIfNewer(t *testing.T) {
+	cache, err := NewCache(1, testOnEvict, 0)
+	assert.NoError(t, err)
+	err = cache.AddRequest(testKeyA, testWatchA, &testRequestA)
+	assert.NoError(t, err)
+
+	result, err := cache.SetResponseIfNewer(testKeyA, v2.DiscoveryResponse{VersionInfo: "2"}, IsNewerVersion)
+	assert.NoError(t, err)
+	assert.True(t, result.Won)
+	assert.Equal(t, "", result.PreviousVersion)
+	assert.Equal(t, map[WatchID]*v2.DiscoveryRequest{testWatchA: &testRequestA}, result.Requests)
+
+	// Older and retried versions are rejected.
+	for _, version := range []string{"1", "2"} {
+		result, err = cache.SetResponseIfNewer(testKeyA, v2.DiscoveryResponse{VersionInfo: version}, IsNewerVersion)
+		assert.NoError(t, err)
+		assert.False(t, result.Won)
+		assert.Equal(t, "2", result.PreviousVersion)
+		assert.Nil(t, result.Requests)
+	}
+	resource, err := cache.Fetch(testKeyA)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", resource.Resp.GetVersionInfo())
+	assert.Equal(t, uint64(1), resource.UpdateCount)
+
+	result, err = cache.SetResponseIfNewer(testKeyA, v2.DiscoveryResponse{VersionInfo: "10"}, IsNewerVersion)
+	assert.NoError(t, err)
+	assert.True(t, result.Won)
+	assert.Equal(t, "2", result.PreviousVersion)
+	resource, err = cache.Fetch(testKeyA)
+	assert.NoError(t, err)
+	assert.Equal(t, "10", resource.Resp.GetVersionInfo())
+}
+
+func TestSetResponseIfNewer_Expired(t *testing.T) {
+	cache, err := NewCache(1, testOnEvict, time.Millisecond)
+	assert.NoError(t, err)
+	result, err := cache.SetResponseIfNewer(testKeyA, v2.DiscoveryResponse{VersionInfo: "2"}, IsNewerVersion)
+	assert.NoError(t, err)
+	assert.True(t, result.Won)
+
+	// Expired responses do not prevent older versions from being cached.
+	time.Sleep(10 * time.Millisecond)
+	result, err = cache.SetResponseIfNewer(testKeyA, v2.DiscoveryResponse{VersionInfo: "1"}, IsNewerVersion)
+	assert.NoError(t, err)
+	assert.True(t, result.Won)
+	assert.Equal(t, "", result.PreviousVersion)
+}
+
+func TestFetchAll(t *testing.T) {
+	cache, err := NewCache(0, testOnEvict, 0)
+	assert.NoError(t, err)
+	_, err = cache.SetResponse(testKeyA, testDiscoveryResponse)
+	assert.NoError(t, err)
+	err = cache.AddRequest(testKeyB, testWatchB, &testRequestB)
+	assert.NoError(t, err)
+
+	resources, err := cache.FetchAll([]string{testKeyA, testKeyB, "key_C"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(resources))
+	assert.Equal(t, &testDiscoveryResponse, resources[testKeyA].Resp)
+	assert.Equal(t, map[WatchID]*v2.DiscoveryRequest{testWatchB: &testRequestB}, resources[testKeyB].Requests)
+}
+
+func TestFetchAll_CastFailure(t *testing.T) {
+	c, err := NewCache(0, testOnEvict, 0)
+	assert.NoError(t, err)
+	_, err = c.SetResponse(testKeyA, testDiscoveryResponse)
+	assert.NoError(t, err)
+	c.(*cache).getShard(testKeyB).cache.Add(testKeyB, "not a resource")
+
+	resources, err := c.FetchAll([]string{testKeyA, testKeyB})
+	assert.True(t, errors.Is(err, ErrCacheCastFailure))
+	assert.Nil(t, resources)
+}
+
+func TestSetResponses(t *testing.T) {
+	cache, err := NewCache(0, testOnEvict, 0)
+	assert.NoError(t, err)
+	err = cache.AddRequest(testKeyA, testWatchA, &testRequestA)
+	assert.NoError(t, err)
+
+	requests, err := cache.SetResponses(map[string]v2.DiscoveryResponse{
+		testKeyA: {VersionInfo: "1"},
+		testKeyB: {VersionInfo: "2"},
+	})
+	assert.NoError(t, err)
+	// Only keys that were already cached have requests.
+	assert.Equal(t, map[string]map[WatchID]*v2.DiscoveryRequest{
+		testKeyA: {testWatchA: &testRequestA},
+	}, requests)
+
+	resources, err := cache.FetchAll([]string{testKeyA, testKeyB})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", resources[testKeyA].Resp.GetVersionInfo())
+	assert.Equal(t, "2", resources[testKeyB].Resp.GetVersionInfo())
+}
+
+func TestRange(t *testing.T) {
+	cache, err := NewCache(0, func(string, Resource) {}, 0)
+	assert.NoError(t, err)
+	keys := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("key_%d", i)
+		_, err = cache.SetResponse(key, testDiscoveryResponse)
+		assert.NoError(t, err)
+		keys[key] = true
+	}
+	assert.Equal(t, 1, cache.EvictOldest(1))
+
+	visited := make(map[string]bool)
+	cache.Range(func(key string, resource Resource) bool {
+		assert.True(t, keys[key])
+		assert.Equal(t, &testDiscoveryResponse, resource.Resp)
+		visited[key] = true
+		return true
+	})
+	assert.Equal(t, 99, len(visited))
+
+	count := 0
+	cache.Range(func(key string, resource Resource) bool {
+		count++
+		return count < 10
+	})
+	assert.Equal(t, 10, count)
+}
+
+func TestRange_Expired(t *testing.T) {
+	cache, err := NewCache(0, func(string, Resource) {}, time.Millisecond)
+	assert.NoError(t, err)
+	_, err = cache.SetResponse(testKeyA, testDiscoveryResponse)
+	assert.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+	cache.Range(func(key string, resource Resource) bool {
+		assert.Fail(t, "expired entries are not visited")
+		return true
+	})
+}
+
+func TestRemoveExpired(t *testing.T) {
+	var evicted []string
+	cache, err := NewCache(0, func(key string, value Resource) {
+		evicted = append(evicted, key)
+	}, 10*time.Millisecond)
+	assert.NoError(t, err)
+	_, err = cache.SetResponse(testKeyA, testDiscoveryResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cache.RemoveExpired())
+
+	time.Sleep(10 * time.Millisecond)
+	_, err = cache.SetResponse(testKeyB, testDiscoveryResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cache.RemoveExpired())
+	assert.Equal(t, []string{testKeyA}, evicted)
+	assert.Equal(t, []string{testKeyB}, cache.GetReadOnlyCache().Keys(""))
+}
+
+func TestKeys(t *testing.T) {
+	cache, err := NewCache(0, testOnEvict, 0)
+	assert.NoError(t, err)
+	for _, key := range []string{"eds_zone_b", "cds_zone_a", "eds_zone_a"} {
+		_, err = cache.SetResponse(key, testDiscoveryResponse)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"cds_zone_a", "eds_zone_a", "eds_zone_b"}, cache.GetReadOnlyCache().Keys(""))
+	assert.Equal(t, []string{"eds_zone_a", "eds_zone_b"}, cache.GetReadOnlyCache().Keys("eds_"))
+	assert.Empty(t, cache.GetReadOnlyCache().Keys("rds_"))
+}
+
+func TestIsNewerVersion(t *testing.T) {
+	response := func(version string) *v2.DiscoveryResponse {
+		return &v2.DiscoveryResponse{VersionInfo: version}
+	}
+	assert.True(t, IsNewerVersion(nil, response("1")))
+	assert.True(t, IsNewerVersion(response("9"), response("10")))
+	assert.False(t, IsNewerVersion(response("10"), response("9")))
+	assert.False(t, IsNewerVersion(response("10"), response("10")))
+	assert.True(t, IsNewerVersion(response("abc"), response("def")))
+	assert.False(t, IsNewerVersion(response("abc"), response("abc")))
+	assert.True(t, IsNewerVersion(response("1"), response("abc")))
+}
+
+func TestUpdateRate(t *testing.T) {
+	var resource Resource
+	currentTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, float64(0), resource.UpdateRate(currentTime))
+
+	resource.FirstSeen = currentTime.Add(-10 * time.Minute)
+	resource.UpdateCount = 5
+	assert.Equal(t, 0.5, resource.UpdateRate(currentTime))
+
+	// Keys populated within the last minute are averaged over a minute.
+	resource.FirstSeen = currentTime.Add(-time.Second)
+	assert.Equal(t, float64(5), resource.UpdateRate(currentTime))
+}
+
 func TestIsExpired(t *testing.T) {
 	var resource Resource
 
@@ -233,19 +471,164 @@ func TestDeleteRequest(t *testing.T) {
 	cache, err := NewCache(1, testOnEvict, time.Second*60)
 	assert.NoError(t, err)
 
-	err = cache.AddRequest(testKeyA, &testRequestA)
+	err = cache.AddRequest(testKeyA, testWatchA, &testRequestA)
 	assert.NoError(t, err)
 
-	err = cache.AddRequest(testKeyA, &testRequestA)
+	err = cache.AddRequest(testKeyA, testWatchA, &testRequestA)
 	assert.NoError(t, err)
 
-	err = cache.DeleteRequest(testKeyA, &testRequestA)
+	err = cache.DeleteRequest(testKeyA, testWatchA)
 	assert.NoError(t, err)
 
 	requests, err := cache.SetResponse(testKeyA, testDiscoveryResponse)
 	assert.NoError(t, err)
 	assert.Equal(t, 0, len(requests))
 
-	err = cache.DeleteRequest(testKeyB, &testRequestB)
+	err = cache.DeleteRequest(testKeyB, testWatchB)
+	assert.NoError(t, err)
+
+	// Watches with equal requests are deleted independently.
+	err = cache.AddRequest(testKeyA, testWatchA, &testRequestA)
+	assert.NoError(t, err)
+	requestCopy := testRequestA
+	err = cache.AddRequest(testKeyA, testWatchB, &requestCopy)
+	assert.NoError(t, err)
+	err = cache.DeleteRequest(testKeyA, testWatchA)
+	assert.NoError(t, err)
+	resource, err := cache.Fetch(testKeyA)
+	assert.NoError(t, err)
+	assert.Equal(t, map[WatchID]*v2.DiscoveryRequest{testWatchB: &requestCopy}, resource.Requests)
+}
+
+func TestFetchRequestsAreNotModified(t *testing.T) {
+	cache, err := NewCache(0, testOnEvict, time.Second*60)
+	assert.NoError(t, err)
+	err = cache.AddRequest(testKeyA, testWatchA, &testRequestA)
+	assert.NoError(t, err)
+
+	// The requests returned by the cache are not modified by later watches.
+	resource, err := cache.Fetch(testKeyA)
+	assert.NoError(t, err)
+	requests, err := cache.SetResponse(testKeyA, testDiscoveryResponse)
+	assert.NoError(t, err)
+	err = cache.AddRequest(testKeyA, testWatchB, &testRequestB)
+	assert.NoError(t, err)
+	err = cache.DeleteRequest(testKeyA, testWatchA)
+	assert.NoError(t, err)
+	assert.Equal(t, map[WatchID]*v2.DiscoveryRequest{testWatchA: &testRequestA}, resource.Requests)
+	assert.Equal(t, map[WatchID]*v2.DiscoveryRequest{testWatchA: &testRequestA}, requests)
+
+	resource, err = cache.Fetch(testKeyA)
+	assert.NoError(t, err)
+	assert.Equal(t, map[WatchID]*v2.DiscoveryRequest{testWatchB: &testRequestB}, resource.Requests)
+}
+
+func TestFetchReadOnly(t *testing.T) {
+	var evicted []string
+	cache, err := NewCache(2, func(key string, value Resource) {
+		evicted = append(evicted, key)
+	}, time.Second*60)
+	assert.NoError(t, err)
+
+	_, err = cache.GetReadOnlyCache().FetchReadOnly(testKeyA)
+	assert.True(t, errors.Is(err, ErrKeyNotFound))
+	for _, key := range []string{testKeyA, testKeyB} {
+		_, err = cache.SetResponse(key, testDiscoveryResponse)
+		assert.NoError(t, err)
+	}
+	err = cache.AddRequest(testKeyA, testWatchA, &testRequestA)
+	assert.NoError(t, err)
+
+	// Unlike Fetch, reading the key does not make it the most recently used.
+	resource, err := cache.GetReadOnlyCache().FetchReadOnly(testKeyB)
+	assert.NoError(t, err)
+	assert.Equal(t, testDiscoveryResponse, *resource.Resp)
+	assert.Equal(t, 1, cache.EvictOldest(1))
+	assert.Equal(t, []string{testKeyB}, evicted)
+}
+
+func TestGetNumShards(t *testing.T) {
+	assert.Equal(t, defaultNumShards, getNumShards(0))
+	assert.Equal(t, 1, getNumShards(1))
+	assert.Equal(t, 1, getNumShards(minEntriesPerShard*2-1))
+	assert.Equal(t, 2, getNumShards(minEntriesPerShard*2))
+	assert.Equal(t, defaultNumShards, getNumShards(minEntriesPerShard*defaultNumShards*10))
+}
+
+func TestGetShardMaxEntries(t *testing.T) {
+	assert.Equal(t, 0, getShardMaxEntries(0, defaultNumShards, 0))
+	assert.Equal(t, 1, getShardMaxEntries(1, 1, 0))
+	assert.Equal(t, 50, getShardMaxEntries(100, 2, 1))
+	assert.Equal(t, 34, getShardMaxEntries(100, 3, 0))
+	assert.Equal(t, 33, getShardMaxEntries(100, 3, 1))
+	assert.Equal(t, 33, getShardMaxEntries(100, 3, 2))
+
+	// The shards hold exactly max entries in total.
+	for _, maxEntries := range []int{minEntriesPerShard * 2, 1000, 4099, 100000} {
+		numShards := getNumShards(maxEntries)
+		total := 0
+		for shard := 0; shard < numShards; shard++ {
+			total += getShardMaxEntries(maxEntries, numShards, shard)
+		}
+		assert.Equal(t, maxEntries, total)
+	}
+}
+
+func TestShardedKeysAreIsolated(t *testing.T) {
+	cache, err := NewCache(0, testOnEvict, time.Second*60)
+	assert.NoError(t, err)
+
+	err = cache.AddRequest(testKeyA, testWatchA, &testRequestA)
+	assert.NoError(t, err)
+	err = cache.AddRequest(testKeyB, testWatchB, &testRequestB)
+	assert.NoError(t, err)
+
+	requests, err := cache.SetResponse(testKeyA, testDiscoveryResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(requests))
+	assert.Equal(t, &testRequestA, requests[testWatchA])
+
+	resource, err := cache.Fetch(testKeyB)
+	assert.NoError(t, err)
+	assert.Nil(t, resource.Resp)
+	assert.Equal(t, &testRequestB, resource.Requests[testWatchB])
+}
+
+func TestEvictOldest(t *testing.T) {
+	var evicted []string
+	cache, err := NewCache(0, func(key string, value Resource) {
+		evicted = append(evicted, key)
+	}, time.Second*60)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, cache.EvictOldest(1))
+	for _, key := range []string{testKeyA, testKeyB} {
+		_, err = cache.SetResponse(key, testDiscoveryResponse)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, cache.EvictOldest(1))
+	assert.Equal(t, 1, cache.EvictOldest(2))
+	assert.ElementsMatch(t, []string{testKeyA, testKeyB}, evicted)
+	resource, err := cache.Fetch(testKeyA)
+	assert.Error(t, err)
+	assert.Nil(t, resource)
+}
+
+func TestEvictOldestWithinShard(t *testing.T) {
+	var evicted []string
+	// Small caches are kept in a single shard.
+	cache, err := NewCache(2, func(key string, value Resource) {
+		evicted = append(evicted, key)
+	}, time.Second*60)
+	assert.NoError(t, err)
+
+	// Fetching a key makes it the most recently used.
+	for _, key := range []string{testKeyA, testKeyB} {
+		_, err = cache.SetResponse(key, testDiscoveryResponse)
+		assert.NoError(t, err)
+	}
+	_, err = cache.Fetch(testKeyA)
 	assert.NoError(t, err)
+	assert.Equal(t, 1, cache.EvictOldest(1))
+	assert.Equal(t, []string{testKeyB}, evicted)
 }
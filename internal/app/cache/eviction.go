@@ -0,0 +1,364 @@
+package cache
+
+import (
+	"container/heap"
+	"fmt"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+)
+
+// Strategy decides which keys are evicted when a shard of the cache is full, or when EvictOldest is called.
+type Strategy int
+
+const (
+	// StrategyLRU evicts the least recently used keys.
+	StrategyLRU Strategy = iota
+	// StrategyLFU evicts the least frequently used keys, so that a single scan of rarely requested keys does not evict
+	// frequently requested ones. Ties are broken by evicting the least recently used key.
+	StrategyLFU
+	// StrategyTTLOnly evicts the keys closest to expiry, so that keys are removed in the order they expire regardless of
+	// how they are used. Keys that never expire are evicted last, in the order they were added.
+	StrategyTTLOnly
+)
+
+func (s Strategy) String() string {
+	switch s {
+	case StrategyLRU:
+		return "lru"
+	case StrategyLFU:
+		return "lfu"
+	case StrategyTTLOnly:
+		return "ttl_only"
+	default:
+		return fmt.Sprintf("Strategy(%d)", int(s))
+	}
+}
+
+// Option configures the cache returned by NewCache.
+type Option func(*options)
+
+type options struct {
+	strategy Strategy
+}
+
+// WithStrategy sets the eviction strategy of the cache. The default is StrategyLRU.
+func WithStrategy(strategy Strategy) Option {
+	return func(o *options) {
+		o.strategy = strategy
+	}
+}
+
+// lfuAgingFactor is the number of uses per entry an lfuStore records before it halves the use counts of its entries.
+const lfuAgingFactor = 10
+
+// store holds the entries of a shard, and chooses the entry evicted when the shard is full. Every removal, including
+// explicit ones, is reported to the onEvicted callback of the store. Stores are not safe for concurrent use.
+type store interface {
+	// Get returns the value of the key, and records a use of the key.
+	Get(key string) (interface{}, bool)
+	// Add sets the value of the key. Replacing the value of a key is not a use of the key, except in an lruStore,
+	// whose order does not tell the two apart.
+	Add(key string, value interface{})
+	Remove(key string)
+	// Evict removes the entry chosen by the strategy, and returns false if no entry was removed.
+	Evict() bool
+	Len() int
+}
+
+// newStore returns the store of the strategy that holds up to maxEntries entries. Zero means no limit.
+func newStore(strategy Strategy, maxEntries int, onEvicted func(key string, value interface{})) store {
+	switch strategy {
+	case StrategyLFU:
+		return &lfuStore{
+			maxEntries: maxEntries,
+			onEvicted:  onEvicted,
+			entries:    make(map[string]*lfuEntry),
+		}
+	case StrategyTTLOnly:
+		return &ttlOnlyStore{
+			maxEntries: maxEntries,
+			onEvicted:  onEvicted,
+			entries:    make(map[string]*ttlEntry),
+		}
+	default:
+		s := &lruStore{cache: lru.Cache{MaxEntries: maxEntries}}
+		s.cache.OnEvicted = func(cacheKey lru.Key, cacheValue interface{}) {
+			key, ok := cacheKey.(string)
+			if !ok {
+				panic(fmt.Sprintf("Unable to cast key %v to string upon eviction", cacheKey))
+			}
+			onEvicted(key, cacheValue)
+		}
+		return s
+	}
+}
+
+// lruStore evicts the least recently used entry.
+type lruStore struct {
+	cache lru.Cache
+}
+
+func (s *lruStore) Get(key string) (interface{}, bool) {
+	return s.cache.Get(key)
+}
+
+func (s *lruStore) Add(key string, value interface{}) {
+	s.cache.Add(key, value)
+}
+
+func (s *lruStore) Remove(key string) {
+	s.cache.Remove(key)
+}
+
+func (s *lruStore) Evict() bool {
+	if s.cache.Len() == 0 {
+		return false
+	}
+	s.cache.RemoveOldest()
+	return true
+}
+
+func (s *lruStore) Len() int {
+	return s.cache.Len()
+}
+
+// lfuStore evicts the least frequently used entry. The entries are kept in a min-heap ordered by the number of times
+// they were read, and then by the time they were last used. The use counts are halved every lfuAgingFactor uses per
+// entry, so that keys that were used often long ago become evictable once they are no longer used.
+type lfuStore struct {
+	maxEntries int
+	onEvicted  func(key string, value interface{})
+	entries    map[string]*lfuEntry
+	heap       lfuHeap
+	// clock is incremented on every use, and orders entries that were used as many times.
+	clock uint64
+	// usesSinceAging is the number of uses recorded since the use counts were last halved.
+	usesSinceAging uint64
+}
+
+type lfuEntry struct {
+	key      string
+	value    interface{}
+	uses     uint64
+	lastUsed uint64
+	index    int
+}
+
+func (s *lfuStore) Get(key string) (interface{}, bool) {
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	s.use(entry)
+	return entry.value, true
+}
+
+func (s *lfuStore) Add(key string, value interface{}) {
+	if entry, ok := s.entries[key]; ok {
+		entry.value = value
+		return
+	}
+	// Evict before adding, so that a new entry, which is used the least, is not evicted right away.
+	if s.maxEntries > 0 && len(s.entries) >= s.maxEntries {
+		s.Evict()
+	}
+	s.clock++
+	entry := &lfuEntry{key: key, value: value, lastUsed: s.clock}
+	s.entries[key] = entry
+	heap.Push(&s.heap, entry)
+}
+
+func (s *lfuStore) Remove(key string) {
+	if entry, ok := s.entries[key]; ok {
+		s.remove(entry)
+	}
+}
+
+func (s *lfuStore) Evict() bool {
+	if len(s.heap) == 0 {
+		return false
+	}
+	s.remove(s.heap[0])
+	return true
+}
+
+func (s *lfuStore) Len() int {
+	return len(s.entries)
+}
+
+func (s *lfuStore) use(entry *lfuEntry) {
+	s.clock++
+	entry.uses++
+	entry.lastUsed = s.clock
+	heap.Fix(&s.heap, entry.index)
+	s.usesSinceAging++
+	if s.usesSinceAging >= lfuAgingFactor*uint64(len(s.entries)) {
+		s.age()
+	}
+}
+
+// age halves the use counts of the entries.
+func (s *lfuStore) age() {
+	for _, entry := range s.heap {
+		entry.uses /= 2
+	}
+	heap.Init(&s.heap)
+	s.usesSinceAging = 0
+}
+
+func (s *lfuStore) remove(entry *lfuEntry) {
+	heap.Remove(&s.heap, entry.index)
+	delete(s.entries, entry.key)
+	s.onEvicted(entry.key, entry.value)
+}
+
+// lfuHeap implements heap.Interface for the entries of an lfuStore.
+type lfuHeap []*lfuEntry
+
+func (h lfuHeap) Len() int {
+	return len(h)
+}
+
+func (h lfuHeap) Less(i, j int) bool {
+	if h[i].uses != h[j].uses {
+		return h[i].uses < h[j].uses
+	}
+	return h[i].lastUsed < h[j].lastUsed
+}
+
+func (h lfuHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *lfuHeap) Push(x interface{}) {
+	entry := x.(*lfuEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *lfuHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return entry
+}
+
+// ttlOnlyStore evicts the entry closest to expiry. The entries are kept in a min-heap ordered by their expiration time,
+// and then by the time they were added. Using an entry does not change its order.
+type ttlOnlyStore struct {
+	maxEntries int
+	onEvicted  func(key string, value interface{})
+	entries    map[string]*ttlEntry
+	heap       ttlHeap
+	// clock is incremented on every addition, and orders entries that expire at the same time.
+	clock uint64
+}
+
+type ttlEntry struct {
+	key            string
+	value          interface{}
+	expirationTime time.Time
+	added          uint64
+	index          int
+}
+
+func (s *ttlOnlyStore) Get(key string) (interface{}, bool) {
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (s *ttlOnlyStore) Add(key string, value interface{}) {
+	if entry, ok := s.entries[key]; ok {
+		entry.value = value
+		entry.expirationTime = expirationTime(value)
+		heap.Fix(&s.heap, entry.index)
+		return
+	}
+	if s.maxEntries > 0 && len(s.entries) >= s.maxEntries {
+		s.Evict()
+	}
+	s.clock++
+	entry := &ttlEntry{key: key, value: value, expirationTime: expirationTime(value), added: s.clock}
+	s.entries[key] = entry
+	heap.Push(&s.heap, entry)
+}
+
+func (s *ttlOnlyStore) Remove(key string) {
+	if entry, ok := s.entries[key]; ok {
+		s.remove(entry)
+	}
+}
+
+func (s *ttlOnlyStore) Evict() bool {
+	if len(s.heap) == 0 {
+		return false
+	}
+	s.remove(s.heap[0])
+	return true
+}
+
+func (s *ttlOnlyStore) Len() int {
+	return len(s.entries)
+}
+
+func (s *ttlOnlyStore) remove(entry *ttlEntry) {
+	heap.Remove(&s.heap, entry.index)
+	delete(s.entries, entry.key)
+	s.onEvicted(entry.key, entry.value)
+}
+
+// expirationTime returns the expiration time of a cached value. Values other than resources never expire.
+func expirationTime(value interface{}) time.Time {
+	if resource, ok := value.(Resource); ok {
+		return resource.ExpirationTime
+	}
+	return time.Time{}
+}
+
+// ttlHeap implements heap.Interface for the entries of a ttlOnlyStore. Entries that never expire have a zero
+// expiration time, and are ordered after the entries that do.
+type ttlHeap []*ttlEntry
+
+func (h ttlHeap) Len() int {
+	return len(h)
+}
+
+func (h ttlHeap) Less(i, j int) bool {
+	iExpires, jExpires := !h[i].expirationTime.IsZero(), !h[j].expirationTime.IsZero()
+	if iExpires != jExpires {
+		return iExpires
+	}
+	if !h[i].expirationTime.Equal(h[j].expirationTime) {
+		return h[i].expirationTime.Before(h[j].expirationTime)
+	}
+	return h[i].added < h[j].added
+}
+
+func (h ttlHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *ttlHeap) Push(x interface{}) {
+	entry := x.(*ttlEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *ttlHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return entry
+}
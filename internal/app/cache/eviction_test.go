@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLFUStore(t *testing.T) {
+	var evicted []string
+	s := newStore(StrategyLFU, 3, func(key string, value interface{}) {
+		evicted = append(evicted, key)
+	})
+	s.Add("hot", 1)
+	s.Add("warm", 2)
+	for i := 0; i < 5; i++ {
+		_, ok := s.Get("hot")
+		assert.True(t, ok)
+	}
+	_, _ = s.Get("warm")
+
+	// A scan of rarely used keys only evicts the keys used the least.
+	for i := 0; i < 3; i++ {
+		s.Add(fmt.Sprintf("rare_%d", i), i)
+	}
+	assert.Equal(t, []string{"rare_0", "rare_1"}, evicted)
+	assert.Equal(t, 3, s.Len())
+	value, ok := s.Get("hot")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	// Keys used as many times are evicted in the order they were last used.
+	assert.True(t, s.Evict())
+	assert.Equal(t, "rare_2", evicted[2])
+	s.Remove("hot")
+	assert.Equal(t, "hot", evicted[3])
+	assert.True(t, s.Evict())
+	assert.False(t, s.Evict())
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestLFUStore_WritesAreNotUses(t *testing.T) {
+	var evicted []string
+	s := newStore(StrategyLFU, 2, func(key string, value interface{}) {
+		evicted = append(evicted, key)
+	})
+	s.Add("written", 1)
+	s.Add("read", 1)
+	_, _ = s.Get("read")
+	for i := 0; i < 5; i++ {
+		s.Add("written", i)
+	}
+
+	s.Add("new", 1)
+	assert.Equal(t, []string{"written"}, evicted)
+}
+
+func TestLFUStore_Aging(t *testing.T) {
+	var evicted []string
+	s := newStore(StrategyLFU, 2, func(key string, value interface{}) {
+		evicted = append(evicted, key)
+	})
+	s.Add("once_hot", 1)
+	s.Add("hot", 1)
+	for i := 0; i < 100; i++ {
+		_, _ = s.Get("once_hot")
+	}
+	// Without aging, the key used the most long ago would be kept until the
+	// other key is used as many times.
+	for i := 0; i < 2*lfuAgingFactor; i++ {
+		_, _ = s.Get("hot")
+	}
+
+	s.Add("new", 1)
+	assert.Equal(t, []string{"once_hot"}, evicted)
+}
+
+func TestTTLOnlyStore(t *testing.T) {
+	var evicted []string
+	s := newStore(StrategyTTLOnly, 3, func(key string, value interface{}) {
+		evicted = append(evicted, key)
+	})
+	now := time.Now()
+	s.Add("never", Resource{})
+	s.Add("late", Resource{ExpirationTime: now.Add(2 * time.Minute)})
+	s.Add("soon", Resource{ExpirationTime: now.Add(time.Minute)})
+
+	// The entry closest to expiry is evicted when the store is full, however
+	// recently it was used.
+	_, ok := s.Get("soon")
+	assert.True(t, ok)
+	s.Add("later", Resource{ExpirationTime: now.Add(3 * time.Minute)})
+	assert.Equal(t, []string{"soon"}, evicted)
+
+	// Updating an entry moves it to its new expiration time.
+	s.Add("late", Resource{ExpirationTime: now.Add(4 * time.Minute)})
+	assert.True(t, s.Evict())
+	assert.Equal(t, "later", evicted[1])
+
+	// Entries that never expire are evicted last.
+	assert.True(t, s.Evict())
+	assert.Equal(t, "late", evicted[2])
+	s.Remove("never")
+	assert.Equal(t, "never", evicted[3])
+	assert.False(t, s.Evict())
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestNewCache_LFU(t *testing.T) {
+	var evicted []string
+	cache, err := NewCache(2, func(key string, value Resource) {
+		evicted = append(evicted, key)
+	}, 0, WithStrategy(StrategyLFU))
+	assert.NoError(t, err)
+	_, err = cache.SetResponse(testKeyA, testDiscoveryResponse)
+	assert.NoError(t, err)
+	_, err = cache.SetResponse(testKeyB, testDiscoveryResponse)
+	assert.NoError(t, err)
+	_, err = cache.Fetch(testKeyA)
+	assert.NoError(t, err)
+
+	_, err = cache.SetResponse("key_C", testDiscoveryResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{testKeyB}, evicted)
+	assert.Equal(t, []string{testKeyA, "key_C"}, cache.GetReadOnlyCache().Keys(""))
+}
+
+func TestNewCache_TTLOnly(t *testing.T) {
+	var evicted []string
+	cache, err := NewCache(2, func(key string, value Resource) {
+		evicted = append(evicted, key)
+	}, time.Minute, WithStrategy(StrategyTTLOnly))
+	assert.NoError(t, err)
+	_, err = cache.SetResponse(testKeyA, testDiscoveryResponse)
+	assert.NoError(t, err)
+	_, err = cache.SetResponse(testKeyB, testDiscoveryResponse)
+	assert.NoError(t, err)
+	// Fetches do not keep a key from being evicted.
+	_, err = cache.Fetch(testKeyA)
+	assert.NoError(t, err)
+
+	_, err = cache.SetResponse("key_C", testDiscoveryResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{testKeyA}, evicted)
+	assert.Equal(t, 1, cache.EvictOldest(1))
+	assert.Equal(t, []string{testKeyA, testKeyB}, evicted)
+}
@@ -0,0 +1,114 @@
+// Package client queries an xDS server, such as a deployed xds-relay, for a single discovery response. It is used to
+// smoke test a server without a real Envoy.
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+// typeURLs maps the resource types accepted on the command line to their type URLs.
+var typeURLs = map[string]string{
+	"listener": upstream.ListenerTypeURL,
+	"cluster":  upstream.ClusterTypeURL,
+	"route":    upstream.RouteTypeURL,
+	"endpoint": upstream.EndpointTypeURL,
+	"runtime":  upstream.RuntimeTypeURL,
+}
+
+// FetchOptions describes the discovery request to send.
+type FetchOptions struct {
+	ServerAddress string
+	NodeID        string
+	NodeCluster   string
+	// ResourceType is a type URL, or one of "listener", "cluster", "route", "endpoint", or "runtime".
+	ResourceType  string
+	ResourceNames []string
+	Timeout       time.Duration
+}
+
+// GetTypeURL resolves a resource type to its type URL.
+func GetTypeURL(resourceType string) (string, error) {
+	for _, typeURL := range typeURLs {
+		if resourceType == typeURL {
+			return typeURL, nil
+		}
+	}
+	if typeURL, ok := typeURLs[strings.TrimSuffix(strings.ToLower(resourceType), "s")]; ok {
+		return typeURL, nil
+	}
+	return "", fmt.Errorf("unsupported resource type %q, expected one of listener, cluster, route, endpoint, runtime",
+		resourceType)
+}
+
+// Fetch opens a stream to the server, sends the discovery request, and returns the first response.
+func Fetch(ctx context.Context, logger log.Logger, options FetchOptions) (*v2.DiscoveryResponse, error) {
+	typeURL, err := GetTypeURL(options.ResourceType)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	client, err := upstream.New(ctx, options.ServerAddress, upstream.CallOptions{Timeout: options.Timeout}, logger)
+	if err != nil {
+		return nil, err
+	}
+	responses, shutdown, err := client.OpenStream(v2.DiscoveryRequest{
+		Node: &core.Node{
+			Id:      options.NodeID,
+			Cluster: options.NodeCluster,
+		},
+		ResourceNames: options.ResourceNames,
+		TypeUrl:       typeURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer shutdown()
+
+	select {
+	case resp, ok := <-responses:
+		if !ok {
+			return nil, fmt.Errorf("stream to %s closed before a response was received", options.ServerAddress)
+		}
+		return resp, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("no response received from %s: %s", options.ServerAddress, ctx.Err().Error())
+	}
+}
+
+// Format renders the response as "json" or "yaml".
+func Format(resp *v2.DiscoveryResponse, format string) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := (&jsonpb.Marshaler{OrigName: true, Indent: "  "}).Marshal(buf, resp); err != nil {
+		return "", err
+	}
+	return formatJSON(buf.Bytes(), format)
+}
+
+// formatJSON renders the JSON document as "json" or "yaml".
+func formatJSON(js []byte, format string) (string, error) {
+	switch format {
+	case "json":
+		return string(js), nil
+	case "yaml":
+		yamlBytes, err := yaml.JSONToYAML(js)
+		if err != nil {
+			return "", err
+		}
+		return string(yamlBytes), nil
+	default:
+		return "", fmt.Errorf("unsupported output format %q, expected json or yaml", format)
+	}
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev2 "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/server/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+const testNodeID = "node"
+
+// startServer serves a snapshot with a single cluster on a local port and returns its address.
+func startServer(t *testing.T) string {
+	snapshotCache := cachev2.NewSnapshotCache(false, cachev2.IDHash{}, nil)
+	err := snapshotCache.SetSnapshot(testNodeID, cachev2.NewSnapshot("1", nil,
+		[]types.Resource{&v2.Cluster{Name: "cluster"}}, nil, nil, nil))
+	assert.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	v2.RegisterClusterDiscoveryServiceServer(server, gcp.NewServer(context.Background(), snapshotCache, nil))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestGetTypeURL(t *testing.T) {
+	for resourceType, expected := range map[string]string{
+		"cluster":                  upstream.ClusterTypeURL,
+		"Clusters":                 upstream.ClusterTypeURL,
+		"listener":                 upstream.ListenerTypeURL,
+		"routes":                   upstream.RouteTypeURL,
+		"endpoint":                 upstream.EndpointTypeURL,
+		upstream.EndpointTypeURL:   upstream.EndpointTypeURL,
+		"runtime":                  upstream.RuntimeTypeURL,
+		"":                         "",
+		"secret":                   "",
+		"type.googleapis.com/ping": "",
+	} {
+		typeURL, err := GetTypeURL(resourceType)
+		assert.Equal(t, expected, typeURL, resourceType)
+		assert.Equal(t, expected == "", err != nil, resourceType)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	address := startServer(t)
+	resp, err := Fetch(context.Background(), log.New("info"), FetchOptions{
+		ServerAddress: address,
+		NodeID:        testNodeID,
+		ResourceType:  "cluster",
+		Timeout:       time.Second,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", resp.GetVersionInfo())
+	assert.Equal(t, upstream.ClusterTypeURL, resp.GetTypeUrl())
+	assert.Equal(t, 1, len(resp.GetResources()))
+
+	output, err := Format(resp, "json")
+	assert.NoError(t, err)
+	assert.Contains(t, output, `"version_info": "1"`)
+	assert.Contains(t, output, `"name": "cluster"`)
+
+	output, err = Format(resp, "yaml")
+	assert.NoError(t, err)
+	assert.Contains(t, output, `version_info: "1"`)
+	assert.Contains(t, output, "name: cluster")
+
+	_, err = Format(resp, "xml")
+	assert.Error(t, err)
+}
+
+func TestFetchTimeout(t *testing.T) {
+	address := startServer(t)
+	_, err := Fetch(context.Background(), log.New("info"), FetchOptions{
+		ServerAddress: address,
+		NodeID:        "unknown",
+		ResourceType:  "cluster",
+		Timeout:       100 * time.Millisecond,
+	})
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), address))
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	// Register the filter configurations most often embedded in resources, so that their typed configs are decoded
+	// as well.
+	_ "github.com/envoyproxy/go-control-plane/envoy/config/filter/http/router/v2"
+	_ "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	_ "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/tcp_proxy/v2"
+)
+
+// InspectOptions describes the cache entry to inspect. Either File, or AdminAddress and Key, must be set.
+type InspectOptions struct {
+	// AdminAddress is the address of the admin server of the relay.
+	AdminAddress string
+	// Key is the aggregated key of the cache entry.
+	Key string
+	// File holds a cache entry previously dumped by the /cache/ endpoint of the admin server.
+	File    string
+	Timeout time.Duration
+}
+
+// CacheEntry is a cache entry as dumped by the /cache/ endpoint of the admin server.
+type CacheEntry struct {
+	Resp           *v2.DiscoveryResponse
+	Requests       []*v2.DiscoveryRequest
+	ExpirationTime time.Time
+	FirstSeen      time.Time
+	LastUpdated    time.Time
+	UpdateCount    uint64
+}
+
+// Inspect reads the cache entry from the file, or from the admin server if no file is set.
+func Inspect(ctx context.Context, options InspectOptions) (*CacheEntry, error) {
+	var contents []byte
+	var err error
+	if options.File != "" {
+		contents, err = ioutil.ReadFile(options.File)
+	} else {
+		contents, err = fetchCacheEntry(ctx, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The admin server responds with a plain text message when the key is not cached.
+	if !strings.HasPrefix(strings.TrimSpace(string(contents)), "{") {
+		return nil, fmt.Errorf("no cache entry: %s", strings.TrimSpace(string(contents)))
+	}
+	var entry CacheEntry
+	if err := json.Unmarshal(contents, &entry); err != nil {
+		return nil, fmt.Errorf("invalid cache entry: %s", err.Error())
+	}
+	return &entry, nil
+}
+
+func fetchCacheEntry(ctx context.Context, options InspectOptions) ([]byte, error) {
+	if options.AdminAddress == "" || options.Key == "" {
+		return nil, fmt.Errorf("either a file, or an admin address and key, are required")
+	}
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+	address := options.AdminAddress
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(address, "/")+"/cache/"+url.PathEscape(options.Key), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("admin server responded with %s: %s", resp.Status, strings.TrimSpace(string(contents)))
+	}
+	return contents, nil
+}
+
+// FormatCacheEntry renders the cache entry as "json" or "yaml". Resources are decoded into their concrete types by
+// type URL, rather than shown as serialized bytes.
+func FormatCacheEntry(entry *CacheEntry, format string) (string, error) {
+	// Watches are cached before the first response is received.
+	response := json.RawMessage("null")
+	if entry.Resp != nil {
+		var err error
+		if response, err = marshalJSON(entry.Resp); err != nil {
+			return "", fmt.Errorf("failed to decode response: %s", err.Error())
+		}
+	}
+	requests := make([]json.RawMessage, 0, len(entry.Requests))
+	for _, request := range entry.Requests {
+		serialized, err := marshalJSON(request)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode request: %s", err.Error())
+		}
+		requests = append(requests, serialized)
+	}
+	js, err := json.MarshalIndent(struct {
+		Response       json.RawMessage   `json:"response"`
+		Requests       []json.RawMessage `json:"requests"`
+		ExpirationTime time.Time         `json:"expiration_time"`
+		FirstSeen      time.Time         `json:"first_seen"`
+		LastUpdated    time.Time         `json:"last_updated"`
+		UpdateCount    uint64            `json:"update_count"`
+	}{response, requests, entry.ExpirationTime, entry.FirstSeen, entry.LastUpdated, entry.UpdateCount}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return formatJSON(js, format)
+}
+
+func marshalJSON(msg proto.Message) (json.RawMessage, error) {
+	serialized, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(msg)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(serialized), nil
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	listener "github.com/envoyproxy/go-control-plane/envoy/api/v2/listener"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/stringify"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCacheEntry returns a cache entry as dumped by the admin server.
+func newCacheEntry(t *testing.T) string {
+	config, err := ptypes.MarshalAny(&hcm.HttpConnectionManager{StatPrefix: "ingress"})
+	assert.NoError(t, err)
+	resource, err := ptypes.MarshalAny(&v2.Listener{
+		Name: "listener_A",
+		FilterChains: []*listener.FilterChain{{
+			Filters: []*listener.Filter{{
+				Name:       "envoy.http_connection_manager",
+				ConfigType: &listener.Filter_TypedConfig{TypedConfig: config},
+			}},
+		}},
+	})
+	assert.NoError(t, err)
+	entry, err := stringify.InterfaceToString(&CacheEntry{
+		Resp: &v2.DiscoveryResponse{
+			VersionInfo: "1",
+			TypeUrl:     upstream.ListenerTypeURL,
+			Resources:   []*any.Any{resource},
+		},
+		Requests:       []*v2.DiscoveryRequest{{TypeUrl: upstream.ListenerTypeURL}},
+		ExpirationTime: time.Unix(0, 0).UTC(),
+		UpdateCount:    3,
+	})
+	assert.NoError(t, err)
+	return entry
+}
+
+func TestInspect(t *testing.T) {
+	entry := newCacheEntry(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/cache/lds" {
+			fmt.Fprint(w, entry)
+			return
+		}
+		fmt.Fprintf(w, "no resource for key %s found in cache.\n", strings.TrimPrefix(req.URL.Path, "/cache/"))
+	}))
+	defer server.Close()
+
+	cacheEntry, err := Inspect(context.Background(), InspectOptions{
+		AdminAddress: server.URL,
+		Key:          "lds",
+		Timeout:      time.Second,
+	})
+	assert.NoError(t, err)
+	output, err := FormatCacheEntry(cacheEntry, "yaml")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "name: listener_A")
+	assert.Contains(t, output, "'@type': type.googleapis.com/envoy.config.filter.network.http_connection_manager.v2")
+	assert.Contains(t, output, "stat_prefix: ingress")
+	assert.Contains(t, output, "expiration_time: \"1970-01-01T00:00:00Z\"")
+	assert.Contains(t, output, "update_count: 3")
+
+	_, err = Inspect(context.Background(), InspectOptions{
+		AdminAddress: strings.TrimPrefix(server.URL, "http://"),
+		Key:          "cds",
+		Timeout:      time.Second,
+	})
+	assert.EqualError(t, err, "no cache entry: no resource for key cds found in cache.")
+}
+
+func TestInspectFile(t *testing.T) {
+	directory, err := ioutil.TempDir("", "inspect")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+	path := filepath.Join(directory, "lds.json")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(newCacheEntry(t)), 0600))
+
+	cacheEntry, err := Inspect(context.Background(), InspectOptions{File: path})
+	assert.NoError(t, err)
+	output, err := FormatCacheEntry(cacheEntry, "json")
+	assert.NoError(t, err)
+	assert.Contains(t, output, `"name": "listener_A"`)
+
+	_, err = FormatCacheEntry(cacheEntry, "xml")
+	assert.Error(t, err)
+
+	// Entries without a response are formatted too.
+	output, err = FormatCacheEntry(&CacheEntry{}, "yaml")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "response: null")
+
+	_, err = Inspect(context.Background(), InspectOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,180 @@
+// Package codec provides a gRPC codec for the downstream server that serializes each aggregated key's discovery
+// response once per version and reuses the serialized bytes for every downstream send.
+package codec
+
+import (
+	"fmt"
+	"sync"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/uber-go/tally"
+)
+
+const (
+	// Name matches the name of gRPC's default codec so that the content-subtype seen by clients is unchanged.
+	Name = "proto"
+
+	metricMarshal        = "marshal"
+	metricMarshalAvoided = "marshal_avoided"
+
+	// nonceFieldTag is the wire tag of DiscoveryResponse.nonce, field number 5 with the length-delimited wire type.
+	nonceFieldTag = 5<<3 | 2
+)
+
+// Codec marshals and unmarshals gRPC messages using protobuf. Discovery responses registered through Register are
+// serialized once, without a nonce, and subsequent sends of any response sharing the same resources reuse those
+// bytes with the per-stream nonce appended.
+//
+// go-control-plane assigns a nonce to each response right before sending it, so responses handed to the server
+// must be shallow copies of the registered response that share its resources slice.
+type Codec struct {
+	mu sync.RWMutex
+	// byKey holds the latest registered response for each aggregated key.
+	byKey map[string]*marshaledResponse
+	// byResource indexes registered responses by their first resource, which identifies the decoded response it
+	// belongs to. Keys that share resources share an entry, which belongs to the key that registered it last.
+	byResource map[*any.Any]*marshaledResponse
+
+	scope tally.Scope
+}
+
+type marshaledResponse struct {
+	response *discovery.DiscoveryResponse
+	bytes    []byte
+}
+
+// New returns a Codec that reports marshal statistics to the provided scope.
+func New(scope tally.Scope) *Codec {
+	return &Codec{
+		byKey:      make(map[string]*marshaledResponse),
+		byResource: make(map[*any.Any]*marshaledResponse),
+		scope:      scope,
+	}
+}
+
+// Register serializes the response for the aggregated key, replacing any response previously registered for the
+// key. Responses without resources are not registered since they are trivial to marshal.
+func (c *Codec) Register(aggregatedKey string, resp *discovery.DiscoveryResponse) error {
+	if resp == nil || len(resp.GetResources()) == 0 {
+		c.Unregister(aggregatedKey)
+		return nil
+	}
+	bytes, err := proto.Marshal(&discovery.DiscoveryResponse{
+		VersionInfo:  resp.GetVersionInfo(),
+		Resources:    resp.GetResources(),
+		Canary:       resp.GetCanary(),
+		TypeUrl:      resp.GetTypeUrl(),
+		ControlPlane: resp.GetControlPlane(),
+	})
+	if err != nil {
+		return err
+	}
+	c.scope.Counter(metricMarshal).Inc(1)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unregister(aggregatedKey)
+	marshaled := &marshaledResponse{response: resp, bytes: bytes}
+	c.byKey[aggregatedKey] = marshaled
+	c.byResource[resp.GetResources()[0]] = marshaled
+	return nil
+}
+
+// Unregister releases the serialized response held for the aggregated key.
+func (c *Codec) Unregister(aggregatedKey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unregister(aggregatedKey)
+}
+
+func (c *Codec) unregister(aggregatedKey string) {
+	marshaled, ok := c.byKey[aggregatedKey]
+	if !ok {
+		return
+	}
+	delete(c.byKey, aggregatedKey)
+	// The entry of the resource is only removed if it still belongs to the key, since a key registered later with
+	// the same resources takes it over.
+	resource := marshaled.response.GetResources()[0]
+	if c.byResource[resource] == marshaled {
+		delete(c.byResource, resource)
+	}
+}
+
+// Marshal returns the wire format of v, reusing registered serialized bytes for discovery responses.
+func (c *Codec) Marshal(v interface{}) ([]byte, error) {
+	if resp, ok := v.(*discovery.DiscoveryResponse); ok {
+		if bytes, ok := c.load(resp); ok {
+			c.scope.Counter(metricMarshalAvoided).Inc(1)
+			return appendNonce(bytes, resp.GetNonce()), nil
+		}
+	}
+	message, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("failed to marshal, message is %T, want proto.Message", v)
+	}
+	c.scope.Counter(metricMarshal).Inc(1)
+	return proto.Marshal(message)
+}
+
+// Unmarshal parses the wire format into v.
+func (c *Codec) Unmarshal(data []byte, v interface{}) error {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal, message is %T, want proto.Message", v)
+	}
+	return proto.Unmarshal(data, message)
+}
+
+// String returns the name of the codec.
+func (c *Codec) String() string {
+	return Name
+}
+
+// load returns the registered serialized bytes for resp if resp carries the same content as the registered
+// response, ignoring the nonce.
+func (c *Codec) load(resp *discovery.DiscoveryResponse) ([]byte, bool) {
+	resources := resp.GetResources()
+	if len(resources) == 0 {
+		return nil, false
+	}
+	c.mu.RLock()
+	marshaled, ok := c.byResource[resources[0]]
+	c.mu.RUnlock()
+	if !ok || !isSameContent(marshaled.response, resp) {
+		return nil, false
+	}
+	return marshaled.bytes, true
+}
+
+func isSameContent(registered *discovery.DiscoveryResponse, resp *discovery.DiscoveryResponse) bool {
+	if registered.GetVersionInfo() != resp.GetVersionInfo() ||
+		registered.GetTypeUrl() != resp.GetTypeUrl() ||
+		registered.GetCanary() != resp.GetCanary() ||
+		registered.GetControlPlane() != resp.GetControlPlane() ||
+		len(registered.GetResources()) != len(resp.GetResources()) {
+		return false
+	}
+	for i, resource := range registered.GetResources() {
+		if resource != resp.GetResources()[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// appendNonce returns a copy of the serialized response with the nonce field appended. Protobuf parsers accept
+// fields in any order, so the result is equivalent to marshaling the response with the nonce set.
+func appendNonce(bytes []byte, nonce string) []byte {
+	if nonce == "" {
+		return bytes
+	}
+	length := proto.EncodeVarint(uint64(len(nonce)))
+	out := make([]byte, 0, len(bytes)+1+len(length)+len(nonce))
+	out = append(out, bytes...)
+	out = append(out, nonceFieldTag)
+	out = append(out, length...)
+	return append(out, nonce...)
+}
@@ -0,0 +1,160 @@
+package codec
+
+import (
+	"testing"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+)
+
+const testKey = "lds"
+
+func newTestResponse(version string) *discovery.DiscoveryResponse {
+	return &discovery.DiscoveryResponse{
+		VersionInfo: version,
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+		Resources: []*any.Any{
+			{
+				TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+				Value:   []byte("lds resource"),
+			},
+		},
+		Nonce: "upstream nonce",
+	}
+}
+
+// newDownstreamResponse mimics the per-watch shallow copy sent by the orchestrator, with the nonce
+// set by go-control-plane.
+func newDownstreamResponse(resp *discovery.DiscoveryResponse, nonce string) *discovery.DiscoveryResponse {
+	return &discovery.DiscoveryResponse{
+		VersionInfo: resp.GetVersionInfo(),
+		Resources:   resp.GetResources(),
+		TypeUrl:     resp.GetTypeUrl(),
+		Nonce:       nonce,
+	}
+}
+
+func assertUnmarshalsTo(t *testing.T, c *Codec, bytes []byte, expected *discovery.DiscoveryResponse) {
+	var got discovery.DiscoveryResponse
+	assert.NoError(t, c.Unmarshal(bytes, &got))
+	assert.True(t, proto.Equal(expected, &got))
+}
+
+func TestMarshalRegisteredResponse(t *testing.T) {
+	scope := tally.NewTestScope("codec", make(map[string]string))
+	c := New(scope)
+	resp := newTestResponse("1")
+	assert.NoError(t, c.Register(testKey, resp))
+
+	downstream := newDownstreamResponse(resp, "1")
+	bytes, err := c.Marshal(downstream)
+	assert.NoError(t, err)
+	assertUnmarshalsTo(t, c, bytes, downstream)
+
+	downstream = newDownstreamResponse(resp, "12345")
+	bytes, err = c.Marshal(downstream)
+	assert.NoError(t, err)
+	assertUnmarshalsTo(t, c, bytes, downstream)
+
+	counters := scope.Snapshot().Counters()
+	testutils.AssertCounterValue(t, counters, "codec.marshal", 1)
+	testutils.AssertCounterValue(t, counters, "codec.marshal_avoided", 2)
+}
+
+func TestMarshalUnregisteredResponse(t *testing.T) {
+	scope := tally.NewTestScope("codec", make(map[string]string))
+	c := New(scope)
+	resp := newTestResponse("1")
+
+	downstream := newDownstreamResponse(resp, "1")
+	bytes, err := c.Marshal(downstream)
+	assert.NoError(t, err)
+	assertUnmarshalsTo(t, c, bytes, downstream)
+
+	counters := scope.Snapshot().Counters()
+	testutils.AssertCounterValue(t, counters, "codec.marshal", 1)
+	assert.NotContains(t, counters, "codec.marshal_avoided+")
+}
+
+func TestMarshalReplacedResponse(t *testing.T) {
+	scope := tally.NewTestScope("codec", make(map[string]string))
+	c := New(scope)
+	oldResp := newTestResponse("1")
+	newResp := newTestResponse("2")
+	assert.NoError(t, c.Register(testKey, oldResp))
+	assert.NoError(t, c.Register(testKey, newResp))
+	assert.Equal(t, 1, len(c.byKey))
+	assert.Equal(t, 1, len(c.byResource))
+
+	// A stale response still in flight falls back to a regular marshal.
+	stale := newDownstreamResponse(oldResp, "1")
+	bytes, err := c.Marshal(stale)
+	assert.NoError(t, err)
+	assertUnmarshalsTo(t, c, bytes, stale)
+
+	counters := scope.Snapshot().Counters()
+	testutils.AssertCounterValue(t, counters, "codec.marshal", 3)
+	assert.NotContains(t, counters, "codec.marshal_avoided+")
+}
+
+func TestMarshalModifiedResponse(t *testing.T) {
+	c := New(tally.NoopScope)
+	resp := newTestResponse("1")
+	assert.NoError(t, c.Register(testKey, resp))
+
+	// A response sharing resources but with different content must not reuse the registered bytes.
+	modified := newDownstreamResponse(resp, "1")
+	modified.VersionInfo = "2"
+	bytes, err := c.Marshal(modified)
+	assert.NoError(t, err)
+	assertUnmarshalsTo(t, c, bytes, modified)
+}
+
+func TestUnregister(t *testing.T) {
+	c := New(tally.NoopScope)
+	assert.NoError(t, c.Register(testKey, newTestResponse("1")))
+	c.Unregister(testKey)
+	assert.Equal(t, 0, len(c.byKey))
+	assert.Equal(t, 0, len(c.byResource))
+
+	// Unregistering an unknown key is a no-op.
+	c.Unregister(testKey)
+}
+
+func TestUnregisterSharedResources(t *testing.T) {
+	scope := tally.NewTestScope("codec", make(map[string]string))
+	c := New(scope)
+	resp := newTestResponse("1")
+	assert.NoError(t, c.Register(testKey, resp))
+	assert.NoError(t, c.Register("cds", resp))
+	c.Unregister(testKey)
+
+	downstream := newDownstreamResponse(resp, "1")
+	bytes, err := c.Marshal(downstream)
+	assert.NoError(t, err)
+	assertUnmarshalsTo(t, c, bytes, downstream)
+	testutils.AssertCounterValue(t, scope.Snapshot().Counters(), "codec.marshal_avoided", 1)
+
+	c.Unregister("cds")
+	assert.Equal(t, 0, len(c.byResource))
+}
+
+func TestRegisterEmptyResponse(t *testing.T) {
+	c := New(tally.NoopScope)
+	assert.NoError(t, c.Register(testKey, newTestResponse("1")))
+	assert.NoError(t, c.Register(testKey, &discovery.DiscoveryResponse{VersionInfo: "2"}))
+	assert.Equal(t, 0, len(c.byKey))
+	assert.Equal(t, 0, len(c.byResource))
+}
+
+func TestMarshalNonProtoMessage(t *testing.T) {
+	c := New(tally.NoopScope)
+	_, err := c.Marshal("not a proto")
+	assert.EqualError(t, err, "failed to marshal, message is string, want proto.Message")
+	err = c.Unmarshal([]byte{}, "not a proto")
+	assert.EqualError(t, err, "failed to unmarshal, message is string, want proto.Message")
+}
@@ -0,0 +1,113 @@
+// Package diff computes summaries of the resources that changed between two discovery responses for the same
+// aggregated key.
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+// Summary describes the resources added, removed, and modified by a response, identified by resource name.
+type Summary struct {
+	TypeURL         string
+	PreviousVersion string
+	Version         string
+	Added           []string
+	Removed         []string
+	Modified        []string
+	Time            time.Time
+}
+
+// IsEmpty returns true if no resources changed.
+func (s *Summary) IsEmpty() bool {
+	return len(s.Added) == 0 && len(s.Removed) == 0 && len(s.Modified) == 0
+}
+
+// Compute returns the changes introduced by current relative to previous. A nil previous response is treated as
+// having no resources. A resource is considered modified if its serialized value changed.
+func Compute(previous *v2.DiscoveryResponse, current *v2.DiscoveryResponse) Summary {
+	summary := Summary{
+		TypeURL:         current.GetTypeUrl(),
+		PreviousVersion: previous.GetVersionInfo(),
+		Version:         current.GetVersionInfo(),
+		Time:            time.Now(),
+	}
+	previousResources := ResourcesByName(previous.GetResources())
+	currentResources := ResourcesByName(current.GetResources())
+	for name, resource := range currentResources {
+		previousResource, ok := previousResources[name]
+		if !ok {
+			summary.Added = append(summary.Added, name)
+		} else if !bytes.Equal(previousResource.GetValue(), resource.GetValue()) {
+			summary.Modified = append(summary.Modified, name)
+		}
+	}
+	for name := range previousResources {
+		if _, ok := currentResources[name]; !ok {
+			summary.Removed = append(summary.Removed, name)
+		}
+	}
+	sort.Strings(summary.Added)
+	sort.Strings(summary.Removed)
+	sort.Strings(summary.Modified)
+	return summary
+}
+
+// ResourcesByName indexes resources by name. Resources whose name cannot be determined are keyed by their
+// position in the response.
+func ResourcesByName(resources []*any.Any) map[string]*any.Any {
+	byName := make(map[string]*any.Any, len(resources))
+	for i, resource := range resources {
+		name, err := GetResourceName(resource)
+		if err != nil || name == "" {
+			name = fmt.Sprintf("resources[%d]", i)
+		}
+		byName[name] = resource
+	}
+	return byName
+}
+
+// GetResourceName returns the name of the serialized resource. All xDS resource types relayed by xds-relay hold
+// their name in field number 1 (e.g. Listener.name, ClusterLoadAssignment.cluster_name), so the name is read
+// directly from the wire format without unmarshaling the resource.
+func GetResourceName(resource *any.Any) (string, error) {
+	buf := resource.GetValue()
+	for len(buf) > 0 {
+		key, n := proto.DecodeVarint(buf)
+		if n == 0 {
+			return "", fmt.Errorf("malformed field key in resource of type %s", resource.GetTypeUrl())
+		}
+		buf = buf[n:]
+		fieldNumber, wireType := key>>3, key&0x7
+		switch wireType {
+		case proto.WireVarint:
+			_, n = proto.DecodeVarint(buf)
+		case proto.WireFixed64:
+			n = 8
+		case proto.WireFixed32:
+			n = 4
+		case proto.WireBytes:
+			length, m := proto.DecodeVarint(buf)
+			if m == 0 || uint64(len(buf)-m) < length {
+				return "", fmt.Errorf("malformed field %d in resource of type %s", fieldNumber, resource.GetTypeUrl())
+			}
+			if fieldNumber == 1 {
+				return string(buf[m : m+int(length)]), nil
+			}
+			n = m + int(length)
+		default:
+			return "", fmt.Errorf("unsupported wire type %d in resource of type %s", wireType, resource.GetTypeUrl())
+		}
+		if n == 0 || n > len(buf) {
+			return "", fmt.Errorf("malformed field %d in resource of type %s", fieldNumber, resource.GetTypeUrl())
+		}
+		buf = buf[n:]
+	}
+	return "", nil
+}
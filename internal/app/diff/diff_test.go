@@ -0,0 +1,102 @@
+package diff
+
+import (
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+)
+
+func marshalAny(t *testing.T, pb proto.Message) *any.Any {
+	resource, err := ptypes.MarshalAny(pb)
+	assert.NoError(t, err)
+	return resource
+}
+
+func TestGetResourceName(t *testing.T) {
+	name, err := GetResourceName(marshalAny(t, &v2.Cluster{
+		Name:           "cluster_A",
+		ConnectTimeout: ptypes.DurationProto(1),
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "cluster_A", name)
+
+	name, err = GetResourceName(marshalAny(t, &v2.ClusterLoadAssignment{ClusterName: "cluster_A"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "cluster_A", name)
+
+	// The name is found when it is not the first field on the wire.
+	resource := marshalAny(t, &v2.RouteConfiguration{
+		Name:             "route_A",
+		ValidateClusters: &wrappers.BoolValue{Value: true},
+	})
+	field := proto.EncodeVarint(7<<3 | proto.WireVarint)
+	resource.Value = append(append(field, 1), resource.Value...)
+	name, err = GetResourceName(resource)
+	assert.NoError(t, err)
+	assert.Equal(t, "route_A", name)
+
+	name, err = GetResourceName(&any.Any{})
+	assert.NoError(t, err)
+	assert.Equal(t, "", name)
+
+	_, err = GetResourceName(&any.Any{TypeUrl: "foo", Value: []byte{0x0a, 0x05, 'a'}})
+	assert.EqualError(t, err, "malformed field 1 in resource of type foo")
+
+	_, err = GetResourceName(&any.Any{TypeUrl: "foo", Value: []byte{0x0b}})
+	assert.EqualError(t, err, "unsupported wire type 3 in resource of type foo")
+}
+
+func TestCompute(t *testing.T) {
+	previous := &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+		Resources: []*any.Any{
+			marshalAny(t, &v2.Listener{Name: "unchanged"}),
+			marshalAny(t, &v2.Listener{Name: "modified"}),
+			marshalAny(t, &v2.Listener{Name: "removed"}),
+		},
+	}
+	current := &v2.DiscoveryResponse{
+		VersionInfo: "2",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+		Resources: []*any.Any{
+			marshalAny(t, &v2.Listener{Name: "added"}),
+			marshalAny(t, &v2.Listener{Name: "unchanged"}),
+			marshalAny(t, &v2.Listener{Name: "modified", UseOriginalDst: &wrappers.BoolValue{Value: true}}),
+		},
+	}
+
+	summary := Compute(previous, current)
+	assert.Equal(t, "type.googleapis.com/envoy.api.v2.Listener", summary.TypeURL)
+	assert.Equal(t, "1", summary.PreviousVersion)
+	assert.Equal(t, "2", summary.Version)
+	assert.Equal(t, []string{"added"}, summary.Added)
+	assert.Equal(t, []string{"removed"}, summary.Removed)
+	assert.Equal(t, []string{"modified"}, summary.Modified)
+	assert.False(t, summary.IsEmpty())
+
+	summary = Compute(current, current)
+	assert.True(t, summary.IsEmpty())
+}
+
+func TestCompute_NoPrevious(t *testing.T) {
+	current := &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		Resources: []*any.Any{
+			marshalAny(t, &v2.Listener{Name: "b"}),
+			marshalAny(t, &v2.Listener{Name: "a"}),
+			{},
+		},
+	}
+
+	summary := Compute(nil, current)
+	assert.Equal(t, "", summary.PreviousVersion)
+	assert.Equal(t, []string{"a", "b", "resources[2]"}, summary.Added)
+	assert.Empty(t, summary.Removed)
+	assert.Empty(t, summary.Modified)
+}
@@ -0,0 +1,87 @@
+// Package dryrun subscribes to the origin server through the orchestrator on behalf of configured downstream clients,
+// so that the relay populates its cache without serving downstream.
+package dryrun
+
+import (
+	"context"
+	"sync"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/uber-go/tally"
+)
+
+const (
+	metricResponse       = "response"
+	metricWatchCancelled = "watch_cancelled"
+)
+
+// Run opens a watch for each subscription and blocks until ctx is done. Like the xDS server, every response is
+// acknowledged by replacing the watch with one for the received version.
+func Run(
+	ctx context.Context,
+	cache gcp.Cache,
+	subscriptions []*bootstrapv1.DryRunSubscription,
+	logger log.Logger,
+	scope tally.Scope,
+) {
+	logger = logger.Named("dry_run")
+	var wg sync.WaitGroup
+	for _, subscription := range subscriptions {
+		wg.Add(1)
+		go func(subscription *bootstrapv1.DryRunSubscription) {
+			defer wg.Done()
+			watch(ctx, cache, newRequest(subscription), logger, scope)
+		}(subscription)
+	}
+	<-ctx.Done()
+	wg.Wait()
+}
+
+func newRequest(subscription *bootstrapv1.DryRunSubscription) v2.DiscoveryRequest {
+	return v2.DiscoveryRequest{
+		Node: &core.Node{
+			Id:      subscription.GetNodeId(),
+			Cluster: subscription.GetNodeCluster(),
+		},
+		ResourceNames: subscription.GetResourceNames(),
+		TypeUrl:       subscription.GetTypeUrl(),
+	}
+}
+
+func watch(ctx context.Context, cache gcp.Cache, req v2.DiscoveryRequest, logger log.Logger, scope tally.Scope) {
+	logger = logger.With("node ID", req.GetNode().GetId()).With("type", req.GetTypeUrl())
+	for {
+		responses, cancel := cache.CreateWatch(req)
+		select {
+		case <-ctx.Done():
+			if cancel != nil {
+				cancel()
+			}
+			return
+		case resp, ok := <-responses:
+			if cancel != nil {
+				cancel()
+			}
+			if !ok {
+				// The orchestrator closes the channel if the watch cannot be registered.
+				scope.Counter(metricWatchCancelled).Inc(1)
+				logger.Error(ctx, "watch cancelled")
+				return
+			}
+			discoveryResponse, err := resp.GetDiscoveryResponse()
+			if err != nil {
+				logger.With("err", err).Error(ctx, "failed to read response")
+				return
+			}
+			scope.Counter(metricResponse).Inc(1)
+			logger.With("version", discoveryResponse.GetVersionInfo()).
+				With("resources", len(discoveryResponse.GetResources())).Info(ctx, "received response")
+			req.VersionInfo = discoveryResponse.GetVersionInfo()
+			req.ResponseNonce = discoveryResponse.GetNonce()
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package dryrun
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev2 "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+)
+
+func responseCount(scope tally.TestScope) int64 {
+	if counter, ok := scope.Snapshot().Counters()["dry_run.response+"]; ok {
+		return counter.Value()
+	}
+	return 0
+}
+
+func TestRun(t *testing.T) {
+	snapshotCache := cachev2.NewSnapshotCache(false, cachev2.IDHash{}, nil)
+	setClusters := func(version string) {
+		err := snapshotCache.SetSnapshot("node", cachev2.NewSnapshot(version, nil,
+			[]types.Resource{&v2.Cluster{Name: "cluster"}}, nil, nil, nil))
+		assert.NoError(t, err)
+	}
+	setClusters("1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scope := tally.NewTestScope("dry_run", make(map[string]string))
+	done := make(chan struct{})
+	go func() {
+		Run(ctx, snapshotCache, []*bootstrapv1.DryRunSubscription{
+			{TypeUrl: upstream.ClusterTypeURL, NodeId: "node"},
+		}, log.New("info"), scope)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return responseCount(scope) == 1
+	}, time.Second, time.Millisecond)
+
+	// The received version is acknowledged, so only new versions are delivered.
+	setClusters("2")
+	assert.Eventually(t, func() bool {
+		return responseCount(scope) == 2
+	}, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int64(2), responseCount(scope))
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		assert.Fail(t, "Run did not return after the context was cancelled")
+	}
+}
+
+func TestRunWithoutSubscriptions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		Run(ctx, cachev2.NewSnapshotCache(false, cachev2.IDHash{}, nil), nil, log.New("info"),
+			tally.NewTestScope("dry_run", make(map[string]string)))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		assert.Fail(t, "Run returned before the context was cancelled")
+	case <-time.After(10 * time.Millisecond):
+	}
+	cancel()
+	<-done
+}
@@ -0,0 +1,160 @@
+// Package election elects a single leader among xds-relay replicas so that only one replica holds streams to the
+// origin server at a time.
+package election
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/uber-go/tally"
+)
+
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+
+	metricIsLeader       = "is_leader"
+	metricLeaderAcquired = "leader_acquired"
+	metricLeaderLost     = "leader_lost"
+	metricLockError      = "lock_error"
+)
+
+// Lock is a lease shared by all candidates.
+type Lock interface {
+	// TryAcquireOrRenew takes the lease for identity if it is free or expired, or renews it if identity already
+	// holds it. It returns true if identity holds the lease after the call.
+	TryAcquireOrRenew(ctx context.Context, identity string, leaseDuration time.Duration) (bool, error)
+
+	// Release gives up the lease if identity holds it, so that another candidate can take over without waiting for
+	// the lease to expire.
+	Release(ctx context.Context, identity string) error
+}
+
+// Elector campaigns for the lease on behalf of this replica and tracks whether it is the leader.
+type Elector struct {
+	lock          Lock
+	identity      string
+	leaseDuration time.Duration
+	retryPeriod   time.Duration
+	logger        log.Logger
+	scope         tally.Scope
+
+	mu        sync.RWMutex
+	isLeader  bool
+	callbacks []func(isLeader bool)
+}
+
+// New returns an Elector for the configured identity and timings. Run must be called to start campaigning.
+func New(config *bootstrapv1.LeaderElection, lock Lock, logger log.Logger, scope tally.Scope) *Elector {
+	identity := config.GetIdentity()
+	if identity == "" {
+		identity, _ = os.Hostname()
+	}
+	return &Elector{
+		lock:          lock,
+		identity:      identity,
+		leaseDuration: durationOrDefault(config.GetLeaseDuration(), defaultLeaseDuration),
+		retryPeriod:   durationOrDefault(config.GetRetryPeriod(), defaultRetryPeriod),
+		logger:        logger.Named("election").With("identity", identity),
+		scope:         scope,
+	}
+}
+
+// NewLock returns the lock for the configured backend.
+func NewLock(config *bootstrapv1.LeaderElection) (Lock, error) {
+	return NewKubernetesLease(config.GetKubernetesLease())
+}
+
+// OnLeaderChange registers a callback invoked each time this replica gains or loses leadership. Callbacks are
+// invoked sequentially from the Run goroutine and must be registered before Run is called.
+func (e *Elector) OnLeaderChange(callback func(isLeader bool)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.callbacks = append(e.callbacks, callback)
+}
+
+// IsLeader returns true if this replica currently holds the lease.
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.isLeader
+}
+
+// Run campaigns for the lease until ctx is done, at which point the lease is released if held.
+func (e *Elector) Run(ctx context.Context) {
+	e.scope.Gauge(metricIsLeader).Update(0)
+	ticker := time.NewTicker(e.retryPeriod)
+	defer ticker.Stop()
+
+	var lastRenewal time.Time
+	for {
+		acquired, err := e.lock.TryAcquireOrRenew(ctx, e.identity, e.leaseDuration)
+		if err != nil {
+			e.scope.Counter(metricLockError).Inc(1)
+			e.logger.With("err", err).Warn(ctx, "failed to acquire or renew lease")
+		}
+		switch {
+		case acquired:
+			lastRenewal = time.Now()
+			e.setLeader(ctx, true)
+		case err == nil:
+			// Another candidate holds the lease.
+			e.setLeader(ctx, false)
+		case time.Since(lastRenewal) >= e.leaseDuration-e.retryPeriod:
+			// Step down before followers may consider the lease expired.
+			e.setLeader(ctx, false)
+		}
+
+		select {
+		case <-ctx.Done():
+			if e.IsLeader() {
+				e.setLeader(ctx, false)
+				// ctx is already done, so the release uses a fresh deadline.
+				releaseCtx, cancel := context.WithTimeout(context.Background(), e.retryPeriod)
+				if err := e.lock.Release(releaseCtx, e.identity); err != nil {
+					e.logger.With("err", err).Warn(ctx, "failed to release lease")
+				}
+				cancel()
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Elector) setLeader(ctx context.Context, isLeader bool) {
+	e.mu.Lock()
+	if e.isLeader == isLeader {
+		e.mu.Unlock()
+		return
+	}
+	e.isLeader = isLeader
+	callbacks := e.callbacks
+	e.mu.Unlock()
+
+	if isLeader {
+		e.scope.Counter(metricLeaderAcquired).Inc(1)
+		e.scope.Gauge(metricIsLeader).Update(1)
+		e.logger.Info(ctx, "acquired leadership")
+	} else {
+		e.scope.Counter(metricLeaderLost).Inc(1)
+		e.scope.Gauge(metricIsLeader).Update(0)
+		e.logger.Info(ctx, "lost leadership")
+	}
+	for _, callback := range callbacks {
+		callback(isLeader)
+	}
+}
+
+func durationOrDefault(d *duration.Duration, defaultDuration time.Duration) time.Duration {
+	if converted, err := ptypes.Duration(d); err == nil && converted > 0 {
+		return converted
+	}
+	return defaultDuration
+}
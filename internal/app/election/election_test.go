@@ -0,0 +1,111 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+)
+
+type mockLock struct {
+	mu       sync.Mutex
+	acquired bool
+	err      error
+	released bool
+}
+
+func (m *mockLock) TryAcquireOrRenew(context.Context, string, time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.acquired, m.err
+}
+
+func (m *mockLock) Release(context.Context, string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.released = true
+	return nil
+}
+
+func (m *mockLock) set(acquired bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.acquired = acquired
+	m.err = err
+}
+
+func newTestElector(lock Lock, scope tally.Scope) *Elector {
+	return New(&bootstrapv1.LeaderElection{
+		Identity:      "relay-0",
+		LeaseDuration: ptypes.DurationProto(50 * time.Millisecond),
+		RetryPeriod:   ptypes.DurationProto(5 * time.Millisecond),
+	}, lock, log.New("info"), scope)
+}
+
+func TestElector(t *testing.T) {
+	lock := &mockLock{acquired: true}
+	scope := tally.NewTestScope("election", make(map[string]string))
+	elector := newTestElector(lock, scope)
+	changes := make(chan bool, 10)
+	elector.OnLeaderChange(func(isLeader bool) { changes <- isLeader })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		elector.Run(ctx)
+		close(done)
+	}()
+	assert.True(t, <-changes)
+	assert.True(t, elector.IsLeader())
+
+	// Another candidate took over the lease.
+	lock.set(false, nil)
+	assert.False(t, <-changes)
+	assert.False(t, elector.IsLeader())
+
+	lock.set(true, nil)
+	assert.True(t, <-changes)
+	cancel()
+	<-done
+	assert.False(t, <-changes)
+	assert.True(t, lock.released)
+
+	counters := scope.Snapshot().Counters()
+	testutils.AssertCounterValue(t, counters, "election.leader_acquired", 2)
+	testutils.AssertCounterValue(t, counters, "election.leader_lost", 2)
+}
+
+func TestElectorStepsDownOnRenewalErrors(t *testing.T) {
+	lock := &mockLock{acquired: true}
+	scope := tally.NewTestScope("election", make(map[string]string))
+	elector := newTestElector(lock, scope)
+	changes := make(chan bool, 10)
+	elector.OnLeaderChange(func(isLeader bool) { changes <- isLeader })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go elector.Run(ctx)
+	assert.True(t, <-changes)
+
+	// Leadership is kept through transient errors until the renew deadline passes.
+	start := time.Now()
+	lock.set(false, errors.New("unavailable"))
+	assert.False(t, <-changes)
+	assert.True(t, time.Since(start) >= 40*time.Millisecond)
+	assert.Contains(t, scope.Snapshot().Counters(), "election.lock_error+")
+}
+
+func TestNewDefaults(t *testing.T) {
+	elector := New(&bootstrapv1.LeaderElection{}, &mockLock{}, log.New("info"), tally.NoopScope)
+	assert.NotEmpty(t, elector.identity)
+	assert.Equal(t, defaultLeaseDuration, elector.leaseDuration)
+	assert.Equal(t, defaultRetryPeriod, elector.retryPeriod)
+}
@@ -0,0 +1,180 @@
+package election
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/kubernetes"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+)
+
+const (
+	// microTimeFormat is the serialization of metav1.MicroTime.
+	microTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// lease is the subset of the coordination.k8s.io/v1 Lease object used for election.
+type lease struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Metadata   leaseMetadata `json:"metadata"`
+	Spec       leaseSpec     `json:"spec"`
+}
+
+type leaseMetadata struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type leaseSpec struct {
+	HolderIdentity       string `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int32  `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          string `json:"acquireTime,omitempty"`
+	RenewTime            string `json:"renewTime,omitempty"`
+	LeaseTransitions     int32  `json:"leaseTransitions"`
+}
+
+// kubernetesLease is a Lock backed by a Lease object, written with optimistic concurrency on its resourceVersion.
+//
+// Expiry is judged by when this candidate last observed the lease change rather than by the renewTime written by
+// the holder, so that clock skew between replicas does not cause a premature takeover.
+type kubernetesLease struct {
+	namespace string
+	name      string
+	// collectionURL is the path of the namespace's Leases, to which new Leases are posted.
+	collectionURL string
+	url           string
+	client        *kubernetes.Client
+
+	mu           sync.Mutex
+	observedSpec leaseSpec
+	observedTime time.Time
+}
+
+// NewKubernetesLease returns a Lock backed by the configured Lease object.
+func NewKubernetesLease(config *bootstrapv1.KubernetesLease) (Lock, error) {
+	client, err := kubernetes.NewClient(config.GetApiServer(), config.GetTokenFile(), config.GetCaFile())
+	if err != nil {
+		return nil, err
+	}
+	collectionURL := fmt.Sprintf("/apis/coordination.k8s.io/v1/namespaces/%s/leases", config.GetNamespace())
+	return &kubernetesLease{
+		namespace:     config.GetNamespace(),
+		name:          config.GetName(),
+		collectionURL: collectionURL,
+		url:           collectionURL + "/" + config.GetName(),
+		client:        client,
+	}, nil
+}
+
+func (k *kubernetesLease) TryAcquireOrRenew(
+	ctx context.Context,
+	identity string,
+	leaseDuration time.Duration,
+) (bool, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := time.Now()
+	current, found, err := k.get(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		created := &lease{
+			APIVersion: "coordination.k8s.io/v1",
+			Kind:       "Lease",
+			Metadata:   leaseMetadata{Name: k.name, Namespace: k.namespace},
+			Spec: leaseSpec{
+				HolderIdentity:       identity,
+				LeaseDurationSeconds: int32(leaseDuration / time.Second),
+				AcquireTime:          now.Format(microTimeFormat),
+				RenewTime:            now.Format(microTimeFormat),
+			},
+		}
+		return k.write(ctx, http.MethodPost, k.collectionURL, created, now)
+	}
+
+	if current.Spec != k.observedSpec {
+		k.observedSpec = current.Spec
+		k.observedTime = now
+	}
+	holder := current.Spec.HolderIdentity
+	if holder != "" && holder != identity && now.Before(k.observedTime.Add(leaseDuration)) {
+		return false, nil
+	}
+
+	updated := *current
+	updated.Spec.LeaseDurationSeconds = int32(leaseDuration / time.Second)
+	updated.Spec.RenewTime = now.Format(microTimeFormat)
+	if holder != identity {
+		updated.Spec.HolderIdentity = identity
+		updated.Spec.AcquireTime = now.Format(microTimeFormat)
+		updated.Spec.LeaseTransitions++
+	}
+	return k.write(ctx, http.MethodPut, k.url, &updated, now)
+}
+
+func (k *kubernetesLease) Release(ctx context.Context, identity string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	current, found, err := k.get(ctx)
+	if err != nil || !found || current.Spec.HolderIdentity != identity {
+		return err
+	}
+	released := *current
+	released.Spec.HolderIdentity = ""
+	released.Spec.RenewTime = time.Now().Format(microTimeFormat)
+	_, err = k.write(ctx, http.MethodPut, k.url, &released, time.Now())
+	return err
+}
+
+// get fetches the Lease, returning false if it does not exist.
+func (k *kubernetesLease) get(ctx context.Context) (*lease, bool, error) {
+	resp, err := k.client.Do(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var current lease
+		if err := json.NewDecoder(resp.Body).Decode(&current); err != nil {
+			return nil, false, err
+		}
+		return &current, true, nil
+	case http.StatusNotFound:
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("unexpected status code %d fetching lease", resp.StatusCode)
+	}
+}
+
+// write creates or updates the Lease. It returns false without error if another candidate wrote the Lease first.
+func (k *kubernetesLease) write(ctx context.Context, method string, url string, l *lease, now time.Time) (bool, error) {
+	body, err := json.Marshal(l)
+	if err != nil {
+		return false, err
+	}
+	resp, err := k.client.Do(ctx, method, url, body)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		k.observedSpec = l.Spec
+		k.observedTime = now
+		return true, nil
+	case http.StatusConflict:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status code %d writing lease", resp.StatusCode)
+	}
+}
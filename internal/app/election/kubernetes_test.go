@@ -0,0 +1,185 @@
+package election
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testLeasePath     = "/apis/coordination.k8s.io/v1/namespaces/xds-relay/leases"
+	testLeaseName     = "xds-relay-leader"
+	testLeaseDuration = 50 * time.Millisecond
+	testToken         = "token"
+)
+
+// fakeLeaseServer implements the Lease endpoints of the Kubernetes API server for a single Lease.
+type fakeLeaseServer struct {
+	t       *testing.T
+	mu      sync.Mutex
+	current *lease
+	version int
+}
+
+func (f *fakeLeaseServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	assert.Equal(f.t, "Bearer "+testToken, r.Header.Get("Authorization"))
+
+	var body lease
+	if r.Method != http.MethodGet {
+		assert.NoError(f.t, json.NewDecoder(r.Body).Decode(&body))
+	}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == testLeasePath+"/"+testLeaseName:
+		if f.current == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NoError(f.t, json.NewEncoder(w).Encode(f.current))
+	case r.Method == http.MethodPost && r.URL.Path == testLeasePath:
+		if f.current != nil {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.store(&body)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && r.URL.Path == testLeasePath+"/"+testLeaseName:
+		if f.current == nil || body.Metadata.ResourceVersion != f.current.Metadata.ResourceVersion {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.store(&body)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (f *fakeLeaseServer) store(l *lease) {
+	f.version++
+	l.Metadata.ResourceVersion = strconv.Itoa(f.version)
+	f.current = l
+}
+
+func (f *fakeLeaseServer) holder() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.current.Spec.HolderIdentity
+}
+
+func newTestKubernetesLease(t *testing.T, url string) Lock {
+	dir, err := ioutil.TempDir("", "election")
+	assert.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	tokenFile := filepath.Join(dir, "token")
+	assert.NoError(t, ioutil.WriteFile(tokenFile, []byte(testToken+"\n"), 0600))
+
+	lock, err := NewKubernetesLease(&bootstrapv1.KubernetesLease{
+		Namespace: "xds-relay",
+		Name:      testLeaseName,
+		ApiServer: url,
+		TokenFile: tokenFile,
+	})
+	assert.NoError(t, err)
+	return lock
+}
+
+func TestKubernetesLease(t *testing.T) {
+	fake := &fakeLeaseServer{t: t}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	ctx := context.Background()
+	a := newTestKubernetesLease(t, server.URL)
+	b := newTestKubernetesLease(t, server.URL)
+
+	// The first candidate creates the Lease.
+	acquired, err := a.TryAcquireOrRenew(ctx, "a", testLeaseDuration)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	assert.Equal(t, "a", fake.holder())
+
+	// The second candidate must wait for the lease to expire.
+	acquired, err = b.TryAcquireOrRenew(ctx, "b", testLeaseDuration)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+
+	// Renewals by the holder keep the lease.
+	acquired, err = a.TryAcquireOrRenew(ctx, "a", testLeaseDuration)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	acquired, err = b.TryAcquireOrRenew(ctx, "b", testLeaseDuration)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+
+	// Once the holder stops renewing, the lease is taken over.
+	time.Sleep(testLeaseDuration)
+	acquired, err = b.TryAcquireOrRenew(ctx, "b", testLeaseDuration)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	assert.Equal(t, "b", fake.holder())
+	assert.Equal(t, int32(1), fake.current.Spec.LeaseTransitions)
+
+	acquired, err = a.TryAcquireOrRenew(ctx, "a", testLeaseDuration)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+}
+
+func TestKubernetesLeaseRelease(t *testing.T) {
+	fake := &fakeLeaseServer{t: t}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	ctx := context.Background()
+	a := newTestKubernetesLease(t, server.URL)
+	b := newTestKubernetesLease(t, server.URL)
+
+	acquired, err := a.TryAcquireOrRenew(ctx, "a", testLeaseDuration)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Releasing a lease held by another candidate is a no-op.
+	assert.NoError(t, b.Release(ctx, "b"))
+	assert.Equal(t, "a", fake.holder())
+
+	// A released lease can be taken over immediately.
+	assert.NoError(t, a.Release(ctx, "a"))
+	assert.Equal(t, "", fake.holder())
+	acquired, err = b.TryAcquireOrRenew(ctx, "b", testLeaseDuration)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+}
+
+func TestKubernetesLeaseConflict(t *testing.T) {
+	fake := &fakeLeaseServer{t: t}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	ctx := context.Background()
+	a := newTestKubernetesLease(t, server.URL)
+
+	acquired, err := a.TryAcquireOrRenew(ctx, "a", testLeaseDuration)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	// A write based on a stale resourceVersion loses the race without error.
+	stale := *fake.current
+	stale.Metadata.ResourceVersion = "0"
+	acquired, err = a.(*kubernetesLease).write(ctx, http.MethodPut, a.(*kubernetesLease).url, &stale, time.Now())
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+}
+
+func TestNewKubernetesLeaseOutsideCluster(t *testing.T) {
+	os.Unsetenv("KUBERNETES_SERVICE_HOST")
+	_, err := NewKubernetesLease(&bootstrapv1.KubernetesLease{Namespace: "xds-relay", Name: testLeaseName})
+	assert.EqualError(t, err, "api_server is unset and xds-relay is not running in a Kubernetes cluster")
+}
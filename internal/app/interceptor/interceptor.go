@@ -0,0 +1,105 @@
+// Package interceptor provides the built-in interceptors of the downstream gRPC server, and chains them with the
+// interceptors registered by embedders, so that the serving path is extensible without forking.
+package interceptor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+)
+
+// Interceptors are interceptors of the streams and calls of a gRPC server, in the order in which they run.
+type Interceptors struct {
+	Stream []grpc.StreamServerInterceptor
+	Unary  []grpc.UnaryServerInterceptor
+}
+
+// New returns the built-in interceptors of the configs, in order.
+func New(configs []*bootstrapv1.Interceptor, logger log.Logger, scope tally.Scope) (Interceptors, error) {
+	var interceptors Interceptors
+	for _, config := range configs {
+		switch {
+		case config.GetRecovery() != nil:
+			interceptors.Append(Recovery(logger, scope))
+		case config.GetRequestId() != nil:
+			interceptors.Append(RequestID(config.GetRequestId().GetHeader()))
+		default:
+			return Interceptors{}, fmt.Errorf("unknown interceptor %v", config)
+		}
+	}
+	return interceptors, nil
+}
+
+// Append adds the interceptors after the existing ones.
+func (i *Interceptors) Append(interceptors Interceptors) {
+	i.Stream = append(i.Stream, interceptors.Stream...)
+	i.Unary = append(i.Unary, interceptors.Unary...)
+}
+
+// ServerOptions returns the server options that install the interceptors. The gRPC server accepts a single
+// interceptor of each kind, so the interceptors are chained.
+func (i Interceptors) ServerOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if len(i.Stream) > 0 {
+		opts = append(opts, grpc.StreamInterceptor(ChainStream(i.Stream...)))
+	}
+	if len(i.Unary) > 0 {
+		opts = append(opts, grpc.UnaryInterceptor(ChainUnary(i.Unary...)))
+	}
+	return opts
+}
+
+// ChainStream returns a stream interceptor that runs the interceptors in order, the first one outermost.
+func ChainStream(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return chainStreamHandler(interceptors, info, handler)(srv, ss)
+	}
+}
+
+func chainStreamHandler(
+	interceptors []grpc.StreamServerInterceptor,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) grpc.StreamHandler {
+	if len(interceptors) == 0 {
+		return handler
+	}
+	return func(srv interface{}, ss grpc.ServerStream) error {
+		return interceptors[0](srv, ss, info, chainStreamHandler(interceptors[1:], info, handler))
+	}
+}
+
+// ChainUnary returns a unary interceptor that runs the interceptors in order, the first one outermost.
+func ChainUnary(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+		interface{}, error) {
+		return chainUnaryHandler(interceptors, info, handler)(ctx, req)
+	}
+}
+
+func chainUnaryHandler(
+	interceptors []grpc.UnaryServerInterceptor,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) grpc.UnaryHandler {
+	if len(interceptors) == 0 {
+		return handler
+	}
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return interceptors[0](ctx, req, info, chainUnaryHandler(interceptors[1:], info, handler))
+	}
+}
+
+// contextStream overrides the context of a server stream, so that interceptors can pass values to the handler.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,143 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const method = "/envoy.api.v2.ClusterDiscoveryService/StreamClusters"
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func recordingInterceptors(name string, calls *[]string) Interceptors {
+	return Interceptors{
+		Stream: []grpc.StreamServerInterceptor{func(
+			srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			*calls = append(*calls, name)
+			return handler(srv, ss)
+		}},
+		Unary: []grpc.UnaryServerInterceptor{func(
+			ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+			interface{}, error) {
+			*calls = append(*calls, name)
+			return handler(ctx, req)
+		}},
+	}
+}
+
+func TestChain(t *testing.T) {
+	var calls []string
+	var interceptors Interceptors
+	interceptors.Append(recordingInterceptors("first", &calls))
+	interceptors.Append(recordingInterceptors("second", &calls))
+
+	err := ChainStream(interceptors.Stream...)(nil, &mockServerStream{ctx: context.Background()},
+		&grpc.StreamServerInfo{FullMethod: method}, func(srv interface{}, ss grpc.ServerStream) error {
+			calls = append(calls, "handler")
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "handler"}, calls)
+
+	calls = nil
+	resp, err := ChainUnary(interceptors.Unary...)(context.Background(), "request",
+		&grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls = append(calls, "handler")
+			return req, nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, "request", resp)
+	assert.Equal(t, []string{"first", "second", "handler"}, calls)
+}
+
+func TestRecovery(t *testing.T) {
+	scope := tally.NewTestScope("interceptor", nil)
+	interceptors := Recovery(log.New("error"), scope)
+
+	err := interceptors.Stream[0](nil, &mockServerStream{ctx: context.Background()},
+		&grpc.StreamServerInfo{FullMethod: method}, func(srv interface{}, ss grpc.ServerStream) error {
+			panic("stream handler")
+		})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	_, err = interceptors.Unary[0](context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			panic("unary handler")
+		})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	testutils.AssertCounterValue(t, scope.Snapshot().Counters(), "interceptor.panic_recovered", 2)
+
+	err = interceptors.Stream[0](nil, &mockServerStream{ctx: context.Background()},
+		&grpc.StreamServerInfo{FullMethod: method}, func(srv interface{}, ss grpc.ServerStream) error {
+			return nil
+		})
+	assert.NoError(t, err)
+}
+
+func TestRequestID(t *testing.T) {
+	interceptors := RequestID("X-Trace-ID")
+	var handlerCtx context.Context
+	ss := &mockServerStream{
+		ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-trace-id", "abc")),
+	}
+	err := interceptors.Stream[0](nil, ss, &grpc.StreamServerInfo{FullMethod: method},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			handlerCtx = ss.Context()
+			return nil
+		})
+	assert.NoError(t, err)
+	id, ok := RequestIDFromContext(handlerCtx)
+	assert.True(t, ok)
+	assert.Equal(t, "abc", id)
+	assert.Equal(t, []string{"abc"}, ss.header.Get("x-trace-id"))
+	assert.Equal(t, []interface{}{log.FieldRequestID, "abc"}, log.FieldsFromContext(handlerCtx))
+
+	ss = &mockServerStream{ctx: context.Background()}
+	err = interceptors.Stream[0](nil, ss, &grpc.StreamServerInfo{FullMethod: method},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			handlerCtx = ss.Context()
+			return nil
+		})
+	assert.NoError(t, err)
+	id, ok = RequestIDFromContext(handlerCtx)
+	assert.True(t, ok)
+	assert.Len(t, id, 32)
+	assert.Equal(t, []string{id}, ss.header.Get("x-trace-id"))
+}
+
+func TestNew(t *testing.T) {
+	interceptors, err := New([]*bootstrapv1.Interceptor{
+		{Type: &bootstrapv1.Interceptor_Recovery_{Recovery: &bootstrapv1.Interceptor_Recovery{}}},
+		{Type: &bootstrapv1.Interceptor_RequestId{RequestId: &bootstrapv1.Interceptor_RequestID{}}},
+	}, log.New("error"), tally.NoopScope)
+	assert.NoError(t, err)
+	assert.Len(t, interceptors.Stream, 2)
+	assert.Len(t, interceptors.Unary, 2)
+	assert.Len(t, interceptors.ServerOptions(), 2)
+
+	_, err = New([]*bootstrapv1.Interceptor{{}}, log.New("error"), tally.NoopScope)
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package interceptor
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const metricPanicRecovered = "panic_recovered"
+
+// Recovery returns interceptors that recover from panics in the handlers of streams and calls. The stream or call
+// fails with an INTERNAL status instead of crashing the relay.
+func Recovery(logger log.Logger, scope tally.Scope) Interceptors {
+	logger = logger.Named("recovery")
+	recoverPanic := func(ctx context.Context, method string, err *error) {
+		if r := recover(); r != nil {
+			scope.Counter(metricPanicRecovered).Inc(1)
+			logger.With("method", method).With("panic", r).With("stack", string(debug.Stack())).
+				Error(ctx, "recovered from panic in handler")
+			*err = status.Errorf(codes.Internal, "panic in handler: %v", r)
+		}
+	}
+	return Interceptors{
+		Stream: []grpc.StreamServerInterceptor{func(
+			srv interface{},
+			ss grpc.ServerStream,
+			info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) (err error) {
+			defer recoverPanic(ss.Context(), info.FullMethod, &err)
+			return handler(srv, ss)
+		}},
+		Unary: []grpc.UnaryServerInterceptor{func(
+			ctx context.Context,
+			req interface{},
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (resp interface{}, err error) {
+			defer recoverPanic(ctx, info.FullMethod, &err)
+			return handler(ctx, req)
+		}},
+	}
+}
@@ -0,0 +1,81 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// DefaultRequestIDHeader is the header of the request ID if none is configured.
+const DefaultRequestIDHeader = "x-request-id"
+
+type requestIDKey struct{}
+
+// RequestID returns interceptors that identify each stream and call by the request ID in the header of the request,
+// or by a generated ID if the header is missing. The request ID is sent back in the same response header, and is
+// carried by the context of the handler, which annotates the log messages logged with the context.
+func RequestID(header string) Interceptors {
+	if header == "" {
+		header = DefaultRequestIDHeader
+	}
+	// Metadata keys are lowercase.
+	header = strings.ToLower(header)
+	return Interceptors{
+		Stream: []grpc.StreamServerInterceptor{func(
+			srv interface{},
+			ss grpc.ServerStream,
+			info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) error {
+			ctx, id := withRequestID(ss.Context(), header)
+			if err := ss.SetHeader(metadata.Pairs(header, id)); err != nil {
+				return err
+			}
+			return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		}},
+		Unary: []grpc.UnaryServerInterceptor{func(
+			ctx context.Context,
+			req interface{},
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (interface{}, error) {
+			ctx, id := withRequestID(ctx, header)
+			if err := grpc.SetHeader(ctx, metadata.Pairs(header, id)); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}},
+	}
+}
+
+// RequestIDFromContext returns the request ID of the stream or call of ctx, if it was identified by the RequestID
+// interceptors.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+func withRequestID(ctx context.Context, header string) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var id string
+	if values := md.Get(header); len(values) > 0 && values[0] != "" {
+		id = values[0]
+	} else {
+		id = NewRequestID()
+	}
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return log.WithFields(ctx, log.FieldRequestID, id), id
+}
+
+// NewRequestID generates a random request ID.
+func NewRequestID() string {
+	id := make([]byte, 16)
+	// crypto/rand only fails if the system's source of randomness is unavailable.
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,209 @@
+package loadreport
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/api/v2/endpoint"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// clusterKey identifies the load of a cluster, or of one of its EDS services.
+type clusterKey struct {
+	name        string
+	serviceName string
+}
+
+// localityKey identifies the load of a cluster in a locality at a priority.
+type localityKey struct {
+	region   string
+	zone     string
+	subZone  string
+	priority uint32
+}
+
+// clusterLoad is the load of a cluster summed across the reports of every
+// downstream stream since the last flush.
+type clusterLoad struct {
+	localities        map[localityKey]*endpoint.UpstreamLocalityStats
+	droppedRequests   uint64
+	droppedByCategory map[string]uint64
+}
+
+// aggregator sums the load reported by downstream streams until it is
+// flushed. Request counts are deltas, so they are summed across every report.
+// Requests in progress are gauges, so only the latest report of each stream
+// is summed.
+type aggregator struct {
+	mu       sync.Mutex
+	clusters map[clusterKey]*clusterLoad
+	// inProgress is the number of requests in progress of each cluster and
+	// locality in the latest report of each stream.
+	inProgress map[int64]map[clusterKey]map[localityKey]uint64
+	lastFlush  time.Time
+}
+
+func newAggregator(now time.Time) *aggregator {
+	return &aggregator{
+		clusters:   make(map[clusterKey]*clusterLoad),
+		inProgress: make(map[int64]map[clusterKey]map[localityKey]uint64),
+		lastFlush:  now,
+	}
+}
+
+// add sums the load reported by the stream.
+func (a *aggregator) add(streamID int64, stats []*endpoint.ClusterStats) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, clusterStats := range stats {
+		key := clusterKey{name: clusterStats.GetClusterName(), serviceName: clusterStats.GetClusterServiceName()}
+		load, ok := a.clusters[key]
+		if !ok {
+			load = &clusterLoad{
+				localities:        make(map[localityKey]*endpoint.UpstreamLocalityStats),
+				droppedByCategory: make(map[string]uint64),
+			}
+			a.clusters[key] = load
+		}
+		load.droppedRequests += clusterStats.GetTotalDroppedRequests()
+		for _, dropped := range clusterStats.GetDroppedRequests() {
+			load.droppedByCategory[dropped.GetCategory()] += dropped.GetDroppedCount()
+		}
+		inProgress := make(map[localityKey]uint64)
+		for _, localityStats := range clusterStats.GetUpstreamLocalityStats() {
+			locality := newLocalityKey(localityStats)
+			addLocalityStats(load.locality(locality), localityStats)
+			inProgress[locality] += localityStats.GetTotalRequestsInProgress()
+		}
+		if a.inProgress[streamID] == nil {
+			a.inProgress[streamID] = make(map[clusterKey]map[localityKey]uint64)
+		}
+		a.inProgress[streamID][key] = inProgress
+	}
+}
+
+// close forgets the requests in progress of the stream.
+func (a *aggregator) close(streamID int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.inProgress, streamID)
+}
+
+// flush returns the load of the clusters that are reported, summed since the
+// last flush, ordered by cluster and locality, and starts summing anew. The
+// load of the other clusters is discarded.
+func (a *aggregator) flush(now time.Time, reported func(cluster string) bool) []*endpoint.ClusterStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	interval := ptypes.DurationProto(now.Sub(a.lastFlush))
+	for _, clusters := range a.inProgress {
+		for key, localities := range clusters {
+			load, ok := a.clusters[key]
+			if !ok {
+				load = &clusterLoad{localities: make(map[localityKey]*endpoint.UpstreamLocalityStats)}
+				a.clusters[key] = load
+			}
+			for locality, inProgress := range localities {
+				load.locality(locality).TotalRequestsInProgress += inProgress
+			}
+		}
+	}
+	var stats []*endpoint.ClusterStats
+	for key, load := range a.clusters {
+		if !reported(key.name) {
+			continue
+		}
+		clusterStats := &endpoint.ClusterStats{
+			ClusterName:          key.name,
+			ClusterServiceName:   key.serviceName,
+			TotalDroppedRequests: load.droppedRequests,
+			LoadReportInterval:   interval,
+		}
+		for category, count := range load.droppedByCategory {
+			clusterStats.DroppedRequests = append(clusterStats.DroppedRequests,
+				&endpoint.ClusterStats_DroppedRequests{Category: category, DroppedCount: count})
+		}
+		sort.Slice(clusterStats.DroppedRequests, func(i, j int) bool {
+			return clusterStats.DroppedRequests[i].GetCategory() < clusterStats.DroppedRequests[j].GetCategory()
+		})
+		for _, localityStats := range load.localities {
+			clusterStats.UpstreamLocalityStats = append(clusterStats.UpstreamLocalityStats, localityStats)
+		}
+		sort.Slice(clusterStats.UpstreamLocalityStats, func(i, j int) bool {
+			return newLocalityKey(clusterStats.UpstreamLocalityStats[i]).
+				less(newLocalityKey(clusterStats.UpstreamLocalityStats[j]))
+		})
+		stats = append(stats, clusterStats)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].GetClusterName() != stats[j].GetClusterName() {
+			return stats[i].GetClusterName() < stats[j].GetClusterName()
+		}
+		return stats[i].GetClusterServiceName() < stats[j].GetClusterServiceName()
+	})
+	a.clusters = make(map[clusterKey]*clusterLoad)
+	a.lastFlush = now
+	return stats
+}
+
+// locality returns the load of the cluster in the locality, which is created
+// if the cluster has none yet.
+func (c *clusterLoad) locality(key localityKey) *endpoint.UpstreamLocalityStats {
+	localityStats, ok := c.localities[key]
+	if !ok {
+		localityStats = &endpoint.UpstreamLocalityStats{
+			Locality: &core.Locality{Region: key.region, Zone: key.zone, SubZone: key.subZone},
+			Priority: key.priority,
+		}
+		c.localities[key] = localityStats
+	}
+	return localityStats
+}
+
+// addLocalityStats sums the request counts and load metrics of the reported
+// locality stats into the stats of the same locality. Requests in progress
+// are summed separately.
+func addLocalityStats(sum *endpoint.UpstreamLocalityStats, reported *endpoint.UpstreamLocalityStats) {
+	sum.TotalSuccessfulRequests += reported.GetTotalSuccessfulRequests()
+	sum.TotalErrorRequests += reported.GetTotalErrorRequests()
+	sum.TotalIssuedRequests += reported.GetTotalIssuedRequests()
+	for _, reportedMetric := range reported.GetLoadMetricStats() {
+		var metric *endpoint.EndpointLoadMetricStats
+		for _, m := range sum.LoadMetricStats {
+			if m.GetMetricName() == reportedMetric.GetMetricName() {
+				metric = m
+				break
+			}
+		}
+		if metric == nil {
+			metric = &endpoint.EndpointLoadMetricStats{MetricName: reportedMetric.GetMetricName()}
+			sum.LoadMetricStats = append(sum.LoadMetricStats, metric)
+		}
+		metric.NumRequestsFinishedWithMetric += reportedMetric.GetNumRequestsFinishedWithMetric()
+		metric.TotalMetricValue += reportedMetric.GetTotalMetricValue()
+	}
+}
+
+func newLocalityKey(stats *endpoint.UpstreamLocalityStats) localityKey {
+	return localityKey{
+		region:   stats.GetLocality().GetRegion(),
+		zone:     stats.GetLocality().GetZone(),
+		subZone:  stats.GetLocality().GetSubZone(),
+		priority: stats.GetPriority(),
+	}
+}
+
+func (l localityKey) less(other localityKey) bool {
+	if l.priority != other.priority {
+		return l.priority < other.priority
+	}
+	if l.region != other.region {
+		return l.region < other.region
+	}
+	if l.zone != other.zone {
+		return l.zone < other.zone
+	}
+	return l.subZone < other.subZone
+}
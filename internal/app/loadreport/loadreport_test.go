@@ -0,0 +1,178 @@
+package loadreport
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/api/v2/endpoint"
+	lrs "github.com/envoyproxy/go-control-plane/envoy/service/load_stats/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// mockOrigin responds to the first request of every stream with response, and records the metadata of every stream and
+// the requests that report load.
+type mockOrigin struct {
+	response *lrs.LoadStatsResponse
+	metadata chan metadata.MD
+	reports  chan *lrs.LoadStatsRequest
+}
+
+func (m *mockOrigin) StreamLoadStats(stream lrs.LoadReportingService_StreamLoadStatsServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	m.metadata <- md
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	if err := stream.Send(m.response); err != nil {
+		return err
+	}
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		m.reports <- req
+	}
+}
+
+// startServer serves s on a local port and returns its address.
+func startServer(t *testing.T, s lrs.LoadReportingServiceServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	lrs.RegisterLoadReportingServiceServer(server, s)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func newClusterStats(cluster string, zone string, successful uint64, inProgress uint64) *endpoint.ClusterStats {
+	return &endpoint.ClusterStats{
+		ClusterName: cluster,
+		UpstreamLocalityStats: []*endpoint.UpstreamLocalityStats{
+			{
+				Locality:                &core.Locality{Region: "region", Zone: zone},
+				TotalSuccessfulRequests: successful,
+				TotalIssuedRequests:     successful,
+				TotalRequestsInProgress: inProgress,
+				LoadMetricStats: []*endpoint.EndpointLoadMetricStats{
+					{MetricName: "cpu", NumRequestsFinishedWithMetric: successful, TotalMetricValue: 0.5},
+				},
+			},
+		},
+		TotalDroppedRequests: 1,
+		DroppedRequests:      []*endpoint.ClusterStats_DroppedRequests{{Category: "overload", DroppedCount: 1}},
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	start := time.Now()
+	a := newAggregator(start)
+	a.add(1, []*endpoint.ClusterStats{newClusterStats("a", "zone-1", 2, 5), newClusterStats("b", "zone-1", 1, 0)})
+	a.add(1, []*endpoint.ClusterStats{newClusterStats("a", "zone-1", 3, 1)})
+	a.add(2, []*endpoint.ClusterStats{newClusterStats("a", "zone-2", 4, 2)})
+
+	stats := a.flush(start.Add(time.Second), func(cluster string) bool { return cluster == "a" })
+	assert.Equal(t, 1, len(stats))
+	assert.Equal(t, "a", stats[0].GetClusterName())
+	assert.Equal(t, uint64(3), stats[0].GetTotalDroppedRequests())
+	assert.Equal(t, uint64(3), stats[0].GetDroppedRequests()[0].GetDroppedCount())
+	assert.Equal(t, ptypes.DurationProto(time.Second), stats[0].GetLoadReportInterval())
+	localities := stats[0].GetUpstreamLocalityStats()
+	assert.Equal(t, 2, len(localities))
+	assert.Equal(t, "zone-1", localities[0].GetLocality().GetZone())
+	assert.Equal(t, uint64(5), localities[0].GetTotalSuccessfulRequests())
+	assert.Equal(t, uint64(5), localities[0].GetTotalIssuedRequests())
+	// Only the latest requests in progress of each stream are summed.
+	assert.Equal(t, uint64(1), localities[0].GetTotalRequestsInProgress())
+	assert.Equal(t, uint64(5), localities[0].GetLoadMetricStats()[0].GetNumRequestsFinishedWithMetric())
+	assert.Equal(t, 1.0, localities[0].GetLoadMetricStats()[0].GetTotalMetricValue())
+	assert.Equal(t, "zone-2", localities[1].GetLocality().GetZone())
+	assert.Equal(t, uint64(2), localities[1].GetTotalRequestsInProgress())
+
+	// Request counts are reset by the flush, but requests in progress are
+	// reported until their stream closes.
+	stats = a.flush(start.Add(2*time.Second), func(string) bool { return true })
+	assert.Equal(t, 2, len(stats))
+	assert.Equal(t, uint64(0), stats[0].GetUpstreamLocalityStats()[0].GetTotalSuccessfulRequests())
+	assert.Equal(t, uint64(1), stats[0].GetUpstreamLocalityStats()[0].GetTotalRequestsInProgress())
+	a.close(1)
+	a.close(2)
+	assert.Empty(t, a.flush(start.Add(3*time.Second), func(string) bool { return true }))
+}
+
+func TestRelay(t *testing.T) {
+	origin := &mockOrigin{
+		response: &lrs.LoadStatsResponse{
+			Clusters:                  []string{"a"},
+			LoadReportingInterval:     ptypes.DurationProto(50 * time.Millisecond),
+			ReportEndpointGranularity: true,
+		},
+		metadata: make(chan metadata.MD, 10),
+		reports:  make(chan *lrs.LoadStatsRequest, 10),
+	}
+	originAddress := startServer(t, origin)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scope := tally.NewTestScope("", make(map[string]string))
+	nodeValue, err := upstream.NewTemplateValue("{{.NodeID}}")
+	assert.NoError(t, err)
+	callOptions := upstream.CallOptions{Metadata: []upstream.Metadata{
+		{Key: "authorization", Value: upstream.StaticValue("Bearer token")},
+		{Key: "x-node", Value: nodeValue},
+	}}
+	relay, err := New(ctx, originAddress, callOptions, &bootstrapv1.LoadReporting{NodeId: "relay"}, log.New("info"),
+		scope)
+	assert.NoError(t, err)
+	go relay.Run(ctx)
+	relayAddress := startServer(t, relay)
+
+	conn, err := grpc.Dial(relayAddress, grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+	stream, err := lrs.NewLoadReportingServiceClient(conn).StreamLoadStats(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Send(&lrs.LoadStatsRequest{Node: &core.Node{Id: "envoy"}}))
+
+	// The response of the origin server is relayed, without asking for
+	// endpoint granularity.
+	resp, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, resp.GetClusters())
+	// The stream with the origin server carries the metadata of the
+	// upstream client.
+	md := <-origin.metadata
+	assert.Equal(t, []string{"Bearer token"}, md.Get("authorization"))
+	assert.Equal(t, []string{"relay"}, md.Get("x-node"))
+	assert.False(t, resp.GetReportEndpointGranularity())
+
+	assert.NoError(t, stream.Send(&lrs.LoadStatsRequest{
+		Node:         &core.Node{Id: "envoy"},
+		ClusterStats: []*endpoint.ClusterStats{newClusterStats("a", "zone", 2, 0), newClusterStats("b", "zone", 1, 0)},
+	}))
+
+	// Only the clusters the origin server asks for are reported, as the
+	// relay's node.
+	select {
+	case report := <-origin.reports:
+		assert.Equal(t, "relay", report.GetNode().GetId())
+		assert.Equal(t, 1, len(report.GetClusterStats()))
+		assert.Equal(t, "a", report.GetClusterStats()[0].GetClusterName())
+		assert.Equal(t, uint64(2),
+			report.GetClusterStats()[0].GetUpstreamLocalityStats()[0].GetTotalSuccessfulRequests())
+	case <-time.After(5 * time.Second):
+		t.Fatal("no load report received by the origin server")
+	}
+	assert.Equal(t, int64(1), scope.Snapshot().Counters()["reports_received+"].Value())
+}
@@ -0,0 +1,290 @@
+// Package loadreport relays the load reports of downstream Envoys to the origin server, so that the origin server does
+// not need a load reporting stream with every Envoy.
+//
+// Envoys stream their load to the relay with the load reporting service (LRS). The relay sums the load of each cluster
+// across Envoys by locality and priority, and reports it to the origin server on a single stream as one node. The
+// origin server's responses, which select the clusters to report and the reporting interval, are relayed to every
+// Envoy.
+package loadreport
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	lrs "github.com/envoyproxy/go-control-plane/envoy/service/load_stats/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+)
+
+const (
+	// reconnectDelay is the wait between attempts to open the load reporting stream with the origin server.
+	reconnectDelay = time.Second
+
+	metricDownstreamStreams = "downstream_streams"
+	metricReportsReceived   = "reports_received"
+	metricReportsSent       = "reports_sent"
+	metricStreamError       = "stream_error"
+)
+
+// Relay serves the load reporting service to downstream Envoys, and reports their load to the origin server.
+type Relay struct {
+	client lrs.LoadReportingServiceClient
+	// metadata is attached to every stream with the origin server, as it is to the xDS streams.
+	metadata   []upstream.Metadata
+	hostname   string
+	node       *core.Node
+	aggregator *aggregator
+	logger     log.Logger
+	scope      tally.Scope
+
+	mu sync.Mutex
+	// response is the latest response of the origin server, or nil until the origin server responds.
+	response *lrs.LoadStatsResponse
+	// streams are the channels that the latest response is relayed to each downstream stream on, by stream ID.
+	streams      map[int64]chan *lrs.LoadStatsResponse
+	nextStreamID int64
+}
+
+// New creates a connection to the origin server at address, which is established in the background and closed when ctx
+// is done. The connection egresses through the proxy of the call options of the upstream client, and its streams carry
+// their metadata. Run must be called to report load.
+func New(
+	ctx context.Context,
+	address string,
+	callOptions upstream.CallOptions,
+	config *bootstrapv1.LoadReporting,
+	logger log.Logger,
+	scope tally.Scope,
+) (*Relay, error) {
+	conn, err := grpc.Dial(address, upstream.DialOptions(address, callOptions)...)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+	hostname, _ := os.Hostname()
+	nodeID := config.GetNodeId()
+	if nodeID == "" {
+		nodeID = hostname
+	}
+	return &Relay{
+		client:     lrs.NewLoadReportingServiceClient(conn),
+		metadata:   callOptions.Metadata,
+		hostname:   hostname,
+		node:       &core.Node{Id: nodeID, Cluster: config.GetNodeCluster()},
+		aggregator: newAggregator(time.Now()),
+		logger:     logger.Named("load_reporting"),
+		scope:      scope,
+		streams:    make(map[int64]chan *lrs.LoadStatsResponse),
+	}, nil
+}
+
+// StreamLoadStats receives the load reports of a downstream Envoy, and sends it the responses of the origin server.
+func (r *Relay) StreamLoadStats(stream lrs.LoadReportingService_StreamLoadStatsServer) error {
+	streamID, responses := r.openStream()
+	defer r.closeStream(streamID)
+
+	received := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				received <- err
+				return
+			}
+			// The first request of a stream only identifies the node.
+			if len(req.GetClusterStats()) == 0 {
+				continue
+			}
+			r.scope.Counter(metricReportsReceived).Inc(1)
+			r.aggregator.add(streamID, req.GetClusterStats())
+		}
+	}()
+	for {
+		select {
+		case resp := <-responses:
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		case err := <-received:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// Run reports the load of downstream Envoys to the origin server until ctx is done, reopening the stream whenever it
+// ends.
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		if err := r.report(ctx); err != nil && ctx.Err() == nil {
+			r.scope.Counter(metricStreamError).Inc(1)
+			r.logger.With("err", err).Warn(ctx, "load reporting stream ended")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// report opens a load reporting stream with the origin server, and reports the load summed since the last report at
+// the interval of the latest response, until the stream ends.
+func (r *Relay) report(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	streamCtx, err := upstream.AppendMetadata(ctx, r.metadata, upstream.StreamInfo{
+		NodeID:      r.node.GetId(),
+		NodeCluster: r.node.GetCluster(),
+		Hostname:    r.hostname,
+	})
+	if err != nil {
+		return err
+	}
+	stream, err := r.client.StreamLoadStats(streamCtx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&lrs.LoadStatsRequest{Node: r.node}); err != nil {
+		return err
+	}
+
+	responses := make(chan *lrs.LoadStatsResponse)
+	received := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				received <- err
+				return
+			}
+			select {
+			case responses <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var ticker *time.Ticker
+	var tick <-chan time.Time
+	defer func() {
+		if ticker != nil {
+			ticker.Stop()
+		}
+	}()
+	var resp *lrs.LoadStatsResponse
+	for {
+		select {
+		case resp = <-responses:
+			r.relay(resp)
+			interval, err := ptypes.Duration(resp.GetLoadReportingInterval())
+			if err != nil || interval <= 0 {
+				r.logger.With("interval", resp.GetLoadReportingInterval()).
+					Warn(ctx, "origin server responded with an invalid load reporting interval")
+				continue
+			}
+			if ticker != nil {
+				ticker.Stop()
+			}
+			ticker = time.NewTicker(interval)
+			tick = ticker.C
+		case <-tick:
+			reported := func(cluster string) bool { return isReported(resp, cluster) }
+			stats := r.aggregator.flush(time.Now(), reported)
+			if len(stats) == 0 {
+				continue
+			}
+			if err := stream.Send(&lrs.LoadStatsRequest{Node: r.node, ClusterStats: stats}); err != nil {
+				return err
+			}
+			r.scope.Counter(metricReportsSent).Inc(1)
+		case err := <-received:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// relay sends the response of the origin server to every downstream stream, in place of the previous response that
+// a stream has yet to send.
+func (r *Relay) relay(resp *lrs.LoadStatsResponse) {
+	resp = downstreamResponse(resp)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.response = resp
+	for _, responses := range r.streams {
+		send(responses, resp)
+	}
+}
+
+func (r *Relay) openStream() (int64, chan *lrs.LoadStatsResponse) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextStreamID++
+	responses := make(chan *lrs.LoadStatsResponse, 1)
+	if r.response != nil {
+		responses <- r.response
+	}
+	r.streams[r.nextStreamID] = responses
+	r.scope.Gauge(metricDownstreamStreams).Update(float64(len(r.streams)))
+	return r.nextStreamID, responses
+}
+
+func (r *Relay) closeStream(streamID int64) {
+	r.aggregator.close(streamID)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.streams, streamID)
+	r.scope.Gauge(metricDownstreamStreams).Update(float64(len(r.streams)))
+}
+
+// send replaces the response buffered in the channel, if any, with resp.
+func send(responses chan *lrs.LoadStatsResponse, resp *lrs.LoadStatsResponse) {
+	select {
+	case <-responses:
+	default:
+	}
+	responses <- resp
+}
+
+// downstreamResponse returns the response of the origin server as relayed to downstream Envoys, which are not asked
+// for endpoint granularity since the relay only reports the load of localities.
+func downstreamResponse(resp *lrs.LoadStatsResponse) *lrs.LoadStatsResponse {
+	relayed := proto.Clone(resp).(*lrs.LoadStatsResponse)
+	relayed.ReportEndpointGranularity = false
+	return relayed
+}
+
+// isReported returns true if the response of the origin server asks for the load of the cluster.
+func isReported(resp *lrs.LoadStatsResponse, cluster string) bool {
+	if resp.GetSendAllClusters() {
+		return true
+	}
+	for _, c := range resp.GetClusters() {
+		if c == cluster {
+			return true
+		}
+	}
+	return false
+}
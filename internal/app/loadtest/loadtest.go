@@ -0,0 +1,165 @@
+// Package loadtest simulates many Envoy clients streaming from an xDS server, such as a deployed xds-relay, and
+// measures how quickly responses reach them.
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	rtds "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/client"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// How simulated clients respond to the responses they receive.
+const (
+	// AckModeAck acknowledges every response, like a healthy Envoy.
+	AckModeAck = "ack"
+	// AckModeNack rejects every response.
+	AckModeNack = "nack"
+	// AckModeNone never responds, like an Envoy whose stream has stalled.
+	AckModeNone = "none"
+)
+
+// Options describes the simulated clients.
+type Options struct {
+	ServerAddress string
+	// Clients is the number of clients, each of which opens its own connection and stream.
+	Clients int
+	// Client i has the node ID NodeIDPrefix followed by i modulo DistinctNodes. Every client has its own node ID if
+	// DistinctNodes is zero.
+	NodeIDPrefix  string
+	DistinctNodes int
+	NodeCluster   string
+	// ResourceType is a type URL, or one of "listener", "cluster", "route", "endpoint", or "runtime".
+	ResourceType  string
+	ResourceNames []string
+	// ConnectRate is the number of clients that connect per second. All clients connect at once if it is zero.
+	ConnectRate float64
+	// AckMode is one of "ack", "nack", or "none".
+	AckMode string
+	// Duration is the time the clients stay connected, counted from the start of the run.
+	Duration time.Duration
+}
+
+// discoveryStream is the stream of any of the resource type specific discovery services.
+type discoveryStream interface {
+	Send(*v2.DiscoveryRequest) error
+	Recv() (*v2.DiscoveryResponse, error)
+}
+
+// Run connects the clients to the server, keeps them connected for the duration of the run, and reports the latency
+// of the responses they received.
+func Run(ctx context.Context, logger log.Logger, options Options) (*Report, error) {
+	typeURL, err := client.GetTypeURL(options.ResourceType)
+	if err != nil {
+		return nil, err
+	}
+	switch options.AckMode {
+	case AckModeAck, AckModeNack, AckModeNone:
+	default:
+		return nil, fmt.Errorf("unsupported ack mode %q, expected one of ack, nack, none", options.AckMode)
+	}
+	if options.Clients <= 0 {
+		return nil, fmt.Errorf("the number of clients must be positive but was %d", options.Clients)
+	}
+	ctx, cancel := context.WithTimeout(ctx, options.Duration)
+	defer cancel()
+
+	recorder := newRecorder(options.Clients)
+	var wg sync.WaitGroup
+	for i := 0; i < options.Clients; i++ {
+		if i > 0 && options.ConnectRate > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Duration(float64(time.Second) / options.ConnectRate)):
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		req := &v2.DiscoveryRequest{
+			Node:          &core.Node{Id: getNodeID(options, i), Cluster: options.NodeCluster},
+			ResourceNames: options.ResourceNames,
+			TypeUrl:       typeURL,
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := runClient(ctx, options, req, recorder); err != nil && ctx.Err() == nil {
+				recorder.fail()
+				logger.With("node ID", req.GetNode().GetId()).With("err", err).Debug(ctx, "client failed")
+			}
+		}()
+	}
+	wg.Wait()
+	return recorder.report(), nil
+}
+
+func getNodeID(options Options, i int) string {
+	if options.DistinctNodes > 0 {
+		i %= options.DistinctNodes
+	}
+	return fmt.Sprintf("%s%d", options.NodeIDPrefix, i)
+}
+
+// runClient streams from the server with the request until ctx is done, recording every response it receives.
+func runClient(ctx context.Context, options Options, req *v2.DiscoveryRequest, recorder *recorder) error {
+	start := time.Now()
+	conn, err := grpc.DialContext(ctx, options.ServerAddress, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	stream, err := openStream(ctx, conn, req.GetTypeUrl())
+	if err != nil {
+		return err
+	}
+	recorder.connect()
+	if err := stream.Send(req); err != nil {
+		return err
+	}
+	for first := true; ; first = false {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		recorder.receive(resp.GetVersionInfo(), time.Since(start), first)
+		switch options.AckMode {
+		case AckModeAck:
+			req.VersionInfo, req.ResponseNonce, req.ErrorDetail = resp.GetVersionInfo(), resp.GetNonce(), nil
+		case AckModeNack:
+			req.ResponseNonce = resp.GetNonce()
+			req.ErrorDetail = status.New(codes.InvalidArgument, "rejected by load test").Proto()
+		default:
+			continue
+		}
+		if err := stream.Send(req); err != nil {
+			return err
+		}
+	}
+}
+
+func openStream(ctx context.Context, conn *grpc.ClientConn, typeURL string) (discoveryStream, error) {
+	switch typeURL {
+	case upstream.ListenerTypeURL:
+		return v2.NewListenerDiscoveryServiceClient(conn).StreamListeners(ctx)
+	case upstream.ClusterTypeURL:
+		return v2.NewClusterDiscoveryServiceClient(conn).StreamClusters(ctx)
+	case upstream.RouteTypeURL:
+		return v2.NewRouteDiscoveryServiceClient(conn).StreamRoutes(ctx)
+	case upstream.EndpointTypeURL:
+		return v2.NewEndpointDiscoveryServiceClient(conn).StreamEndpoints(ctx)
+	case upstream.RuntimeTypeURL:
+		return rtds.NewRuntimeDiscoveryServiceClient(conn).StreamRuntime(ctx)
+	}
+	return nil, &upstream.UnsupportedResourceError{TypeURL: typeURL}
+}
@@ -0,0 +1,115 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev2 "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/server/v2"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+const testClients = 3
+
+// startServer serves clusters to the nodes of the test clients on a local port, and returns its address along with
+// the function that sets the version of the clusters.
+func startServer(t *testing.T) (string, func(string)) {
+	snapshotCache := cachev2.NewSnapshotCache(false, cachev2.IDHash{}, nil)
+	setVersion := func(version string) {
+		for i := 0; i < testClients; i++ {
+			err := snapshotCache.SetSnapshot(fmt.Sprintf("node-%d", i), cachev2.NewSnapshot(version, nil,
+				[]types.Resource{&v2.Cluster{Name: "cluster"}}, nil, nil, nil))
+			assert.NoError(t, err)
+		}
+	}
+	setVersion("1")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	v2.RegisterClusterDiscoveryServiceServer(server, gcp.NewServer(context.Background(), snapshotCache, nil))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String(), setVersion
+}
+
+func newTestOptions(address string, ackMode string) Options {
+	return Options{
+		ServerAddress: address,
+		Clients:       testClients,
+		NodeIDPrefix:  "node-",
+		ResourceType:  "cluster",
+		AckMode:       ackMode,
+		Duration:      500 * time.Millisecond,
+	}
+}
+
+func TestRun(t *testing.T) {
+	address, setVersion := startServer(t)
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		setVersion("2")
+	}()
+
+	report, err := Run(context.Background(), log.New("info"), newTestOptions(address, AckModeAck))
+	assert.NoError(t, err)
+	assert.Equal(t, testClients, report.Connected)
+	assert.Equal(t, 0, report.Failed)
+	assert.Equal(t, 2*testClients, report.Responses)
+	assert.Equal(t, testClients, report.TimeToFirstResponse.Count)
+	assert.Equal(t, testClients, report.FanoutLatency.Count)
+	assert.Contains(t, report.String(), "connected:              3\n")
+}
+
+func TestRunWithoutAcks(t *testing.T) {
+	address, setVersion := startServer(t)
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		setVersion("2")
+	}()
+
+	// Clients that never acknowledge a response are not sent new versions.
+	report, err := Run(context.Background(), log.New("info"), newTestOptions(address, AckModeNone))
+	assert.NoError(t, err)
+	assert.Equal(t, testClients, report.Connected)
+	assert.Equal(t, testClients, report.Responses)
+	assert.Equal(t, 0, report.FanoutLatency.Count)
+}
+
+func TestRunInvalidOptions(t *testing.T) {
+	_, err := Run(context.Background(), log.New("info"), newTestOptions("localhost:0", "maybe"))
+	assert.EqualError(t, err, `unsupported ack mode "maybe", expected one of ack, nack, none`)
+
+	options := newTestOptions("localhost:0", AckModeAck)
+	options.Clients = 0
+	_, err = Run(context.Background(), log.New("info"), options)
+	assert.EqualError(t, err, "the number of clients must be positive but was 0")
+}
+
+func TestGetNodeID(t *testing.T) {
+	options := Options{NodeIDPrefix: "node-", DistinctNodes: 2}
+	assert.Equal(t, "node-1", getNodeID(options, 3))
+	options.DistinctNodes = 0
+	assert.Equal(t, "node-3", getNodeID(options, 3))
+}
+
+func TestNewLatencies(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, Latencies{
+		Count: 100,
+		P50:   50 * time.Millisecond,
+		P99:   99 * time.Millisecond,
+		Max:   100 * time.Millisecond,
+	}, newLatencies(latencies))
+	assert.Equal(t, Latencies{}, newLatencies(nil))
+}
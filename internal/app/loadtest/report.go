@@ -0,0 +1,136 @@
+package loadtest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Latencies summarizes a set of latencies.
+type Latencies struct {
+	Count int
+	P50   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+func newLatencies(latencies []time.Duration) Latencies {
+	if len(latencies) == 0 {
+		return Latencies{}
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return Latencies{
+		Count: len(sorted),
+		P50:   percentile(sorted, 50),
+		P99:   percentile(sorted, 99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func (l Latencies) String() string {
+	return fmt.Sprintf("count=%d p50=%s p99=%s max=%s", l.Count, l.P50, l.P99, l.Max)
+}
+
+// Report is the outcome of a load test run.
+type Report struct {
+	Clients int
+	// Connected is the number of clients that opened a stream.
+	Connected int
+	// Failed is the number of clients whose stream failed before the end of the run.
+	Failed    int
+	Responses int
+	// TimeToFirstResponse is the time from when each client started connecting to when it received its first
+	// response.
+	TimeToFirstResponse Latencies
+	// FanoutLatency is the time from when the first client received a new version to when each other client
+	// received it. The first response of each client is not counted, since it is served when the client connects
+	// rather than fanned out.
+	FanoutLatency Latencies
+}
+
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "clients:                %d\n", r.Clients)
+	fmt.Fprintf(&b, "connected:              %d\n", r.Connected)
+	fmt.Fprintf(&b, "failed:                 %d\n", r.Failed)
+	fmt.Fprintf(&b, "responses:              %d\n", r.Responses)
+	fmt.Fprintf(&b, "time to first response: %s\n", r.TimeToFirstResponse)
+	fmt.Fprintf(&b, "fanout latency:         %s\n", r.FanoutLatency)
+	return b.String()
+}
+
+// recorder collects the responses received by the clients of a run.
+type recorder struct {
+	clients int
+
+	mu                  sync.Mutex
+	connected           int
+	failed              int
+	responses           int
+	timeToFirstResponse []time.Duration
+	// updates holds the times at which clients received each version, other than in their first response, in the
+	// order they were received.
+	updates map[string][]time.Time
+}
+
+func newRecorder(clients int) *recorder {
+	return &recorder{
+		clients: clients,
+		updates: make(map[string][]time.Time),
+	}
+}
+
+func (r *recorder) connect() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.connected++
+}
+
+func (r *recorder) fail() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failed++
+}
+
+// receive records a response of the version, received elapsed after the client started connecting.
+func (r *recorder) receive(version string, elapsed time.Duration, first bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses++
+	if first {
+		r.timeToFirstResponse = append(r.timeToFirstResponse, elapsed)
+		return
+	}
+	r.updates[version] = append(r.updates[version], time.Now())
+}
+
+func (r *recorder) report() *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var fanoutLatencies []time.Duration
+	for _, times := range r.updates {
+		for _, received := range times {
+			fanoutLatencies = append(fanoutLatencies, received.Sub(times[0]))
+		}
+	}
+	return &Report{
+		Clients:             r.clients,
+		Connected:           r.connected,
+		Failed:              r.failed,
+		Responses:           r.responses,
+		TimeToFirstResponse: newLatencies(r.timeToFirstResponse),
+		FanoutLatency:       newLatencies(fanoutLatencies),
+	}
+}
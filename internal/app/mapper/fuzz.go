@@ -0,0 +1,139 @@
+// +build gofuzz
+
+package mapper
+
+import (
+	"strings"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/yamlproto"
+	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+)
+
+// fuzzConfig exercises every kind of rule and result with the node fields and
+// resource names of the requests of FuzzGetKey.
+const fuzzConfig = `
+tenant:
+  rules:
+    - match:
+        request_node_match:
+          field: NODE_CLUSTER
+          regex_match: "^[a-z]+-"
+      result:
+        request_node_fragment:
+          field: NODE_CLUSTER
+          action:
+            split_action:
+              delimiter: "-"
+              index: 0
+    - match:
+        any_match: true
+      result:
+        string_fragment: "default"
+fragments:
+  - rules:
+    - match:
+        or_match:
+          rules:
+            - request_node_match:
+                field: NODE_ID
+                exact_match: "passthrough"
+            - not_match:
+                request_type_match:
+                  types:
+                    - "type.googleapis.com/envoy.api.v2.Listener"
+                    - "type.googleapis.com/envoy.api.v2.Cluster"
+      result:
+        passthrough: true
+  - rules:
+    - match:
+        and_match:
+          rules:
+            - request_node_match:
+                field: NODE_LOCALITY_REGION
+                regex_match: "(us|eu)-.*"
+            - request_node_match:
+                field: NODE_LOCALITY_ZONE
+                regex_match: ".+"
+      result:
+        and_result:
+          result_predicates:
+            - request_node_fragment:
+                field: NODE_LOCALITY_REGION
+                action:
+                  regex_action:
+                    pattern: "^(us|eu)-(.*)$"
+                    replace: "$2-$1"
+            - string_fragment: "_"
+            - request_node_fragment:
+                field: NODE_LOCALITY_SUBZONE
+                action:
+                  to_lower: true
+    - match:
+        any_match: true
+      result:
+        resource_names_fragment:
+          element: 0
+          action:
+            trim_prefix: "resource/"
+fallback:
+  default_key: "fallback"
+`
+
+// fuzzTypeURLs are the type URLs of the requests of the fuzz targets.
+var fuzzTypeURLs = []string{
+	"type.googleapis.com/envoy.api.v2.Listener",
+	"type.googleapis.com/envoy.api.v2.Cluster",
+	"type.googleapis.com/envoy.api.v2.RouteConfiguration",
+}
+
+// FuzzKeyerConfiguration is a go-fuzz target that loads the data as a keyer
+// configuration, and maps requests with the configurations that are valid.
+// Malformed configurations must be rejected with an error when they are
+// loaded, rather than panic or fail every request.
+func FuzzKeyerConfiguration(data []byte) int {
+	var config aggregationv1.KeyerConfiguration
+	if err := yamlproto.FromYAMLToKeyerConfiguration(string(data), &config); err != nil {
+		return 0
+	}
+	mapper := New(&config)
+	node := &core.Node{
+		Id:       "node",
+		Cluster:  "cluster",
+		Locality: &core.Locality{Region: "region", Zone: "zone", SubZone: "subzone"},
+	}
+	for _, typeURL := range fuzzTypeURLs {
+		_, _ = mapper.GetKey(v2.DiscoveryRequest{TypeUrl: typeURL, Node: node, ResourceNames: []string{"resource"}})
+		_, _ = mapper.GetKey(v2.DiscoveryRequest{TypeUrl: typeURL})
+	}
+	return 1
+}
+
+// FuzzGetKey is a go-fuzz target that maps requests whose node fields and
+// resource names are read from the lines of the data, in the order of node
+// ID, cluster, region, zone, subzone and resource names. The node fields are
+// controlled by downstream clients, so no value must panic the mapper.
+func FuzzGetKey(data []byte) int {
+	var config aggregationv1.KeyerConfiguration
+	if err := yamlproto.FromYAMLToKeyerConfiguration(fuzzConfig, &config); err != nil {
+		panic(err)
+	}
+	fields := strings.Split(string(data), "\n")
+	for len(fields) < 5 {
+		fields = append(fields, "")
+	}
+	node := &core.Node{
+		Id:       fields[0],
+		Cluster:  fields[1],
+		Locality: &core.Locality{Region: fields[2], Zone: fields[3], SubZone: fields[4]},
+	}
+	mapper := New(&config)
+	mapped := 0
+	for _, typeURL := range fuzzTypeURLs {
+		if _, err := mapper.GetKey(v2.DiscoveryRequest{TypeUrl: typeURL, Node: node, ResourceNames: fields[5:]}); err == nil {
+			mapped = 1
+		}
+	}
+	return mapped
+}
@@ -1,13 +1,18 @@
 package mapper
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
 	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	"github.com/golang/groupcache/lru"
 )
 
 type matchPredicate = aggregationv1.MatchPredicate
@@ -25,54 +30,271 @@ type Mapper interface {
 	GetKey(request v2.DiscoveryRequest) (string, error)
 }
 
+// TenantMapper is implemented by mappers that prefix aggregated keys with the
+// tenant of the request.
+type TenantMapper interface {
+	Mapper
+
+	// GetTenant returns the tenant of the aggregated key, or an empty string
+	// if the key has none.
+	GetTenant(aggregatedKey string) string
+}
+
+// Transport is the transport-level identity of the downstream stream of a
+// request, such as the gRPC metadata headers sent by the client and the SANs
+// of its TLS client certificate, by transport key.
+type Transport map[string][]string
+
+const (
+	// TransportURISAN is the transport key of the URI SANs of the TLS client
+	// certificate of the downstream stream.
+	TransportURISAN = ":uri_san"
+	// TransportDNSSAN is the transport key of the DNS SANs of the TLS client
+	// certificate of the downstream stream.
+	TransportDNSSAN = ":dns_san"
+)
+
+// TransportMapper is a Mapper whose rules can match on and generate fragments
+// from the transport of the downstream stream of requests.
+type TransportMapper interface {
+	Mapper
+
+	// TransportKeys returns the transport keys that the rules refer to,
+	// which are the only keys that need to be captured from downstream
+	// streams. Header names are lowercased.
+	TransportKeys() []string
+
+	// GetKeyWithTransport converts a request into an aggregated key like
+	// GetKey, with the transport of its downstream stream.
+	GetKeyWithTransport(request v2.DiscoveryRequest, transport Transport) (string, error)
+}
+
+// GetKeyWithTransport converts a request into an aggregated key with the
+// transport of its downstream stream, if the mapper is a TransportMapper. The
+// transport is ignored otherwise.
+func GetKeyWithTransport(m Mapper, request v2.DiscoveryRequest, transport Transport) (string, error) {
+	if transportMapper, ok := m.(TransportMapper); ok {
+		return transportMapper.GetKeyWithTransport(request, transport)
+	}
+	return m.GetKey(request)
+}
+
+// ErrKeyNotMapped is wrapped by the errors of requests that cannot be mapped to
+// an aggregated key.
+var ErrKeyNotMapped = errors.New("request cannot be mapped to an aggregated key")
+
+// UnmatchedRequestError is returned by GetKey for requests that match no rule,
+// unless the fallback of the keyer configuration maps them to a default key.
+// Reject is set if the fallback rejects such requests, rather than passing
+// them through without aggregation.
+type UnmatchedRequestError struct {
+	Reject bool
+}
+
+func (e *UnmatchedRequestError) Error() string {
+	return "Cannot map the input to a key"
+}
+
+// Unwrap returns ErrKeyNotMapped.
+func (e *UnmatchedRequestError) Unwrap() error {
+	return ErrKeyNotMapped
+}
+
 type mapper struct {
 	config *aggregationv1.KeyerConfiguration
+	// transportKeys are the transport keys that the rules refer to, sorted.
+	transportKeys []string
+
+	// keys memoizes the results of GetKey by the fields of the request that
+	// rules can match on, so that regexes are not evaluated again for every
+	// acknowledgement. The memoized results are only valid for the config,
+	// so a reloaded config requires a new mapper.
+	mu   sync.Mutex
+	keys *lru.Cache
+}
+
+// keyResult is a memoized result of GetKey.
+type keyResult struct {
+	key string
+	err error
 }
 
 const (
 	separator = "_"
+
+	// TenantSeparator separates the tenant prefix of an aggregated key from
+	// the rest of the key.
+	TenantSeparator = "/"
+
+	// UnaggregatedPrefix prefixes the keys of requests that are not
+	// aggregated. Such keys are unique to the node and type of the request.
+	UnaggregatedPrefix = "unaggregated_"
+
+	// maxMemoizedKeys bounds the number of results of GetKey that are
+	// memoized. The least recently used result is evicted first.
+	maxMemoizedKeys = 10000
 )
 
+// UnaggregatedKey returns the key of the request when it is not aggregated.
+func UnaggregatedKey(request v2.DiscoveryRequest) string {
+	return fmt.Sprintf("%s%s_%s", UnaggregatedPrefix, request.GetNode().GetId(), request.GetTypeUrl())
+}
+
 // New constructs a concrete implementation for the Mapper interface
 func New(config *aggregationv1.KeyerConfiguration) Mapper {
 	return &mapper{
-		config: config,
+		config:        config,
+		transportKeys: getTransportKeys(config),
+		keys:          lru.New(maxMemoizedKeys),
 	}
 }
 
 // GetKey converts a request into an aggregated key
 func (mapper *mapper) GetKey(request v2.DiscoveryRequest) (string, error) {
+	return mapper.GetKeyWithTransport(request, nil)
+}
+
+// TransportKeys returns the transport keys that the rules refer to.
+func (mapper *mapper) TransportKeys() []string {
+	return mapper.transportKeys
+}
+
+// GetKeyWithTransport converts a request into an aggregated key, with the
+// transport of its downstream stream.
+func (mapper *mapper) GetKeyWithTransport(request v2.DiscoveryRequest, transport Transport) (string, error) {
+	memoKey := getMemoKey(request, mapper.transportKeys, transport)
+	mapper.mu.Lock()
+	memoized, ok := mapper.keys.Get(memoKey)
+	mapper.mu.Unlock()
+	if ok {
+		result := memoized.(keyResult)
+		return result.key, result.err
+	}
+
+	key, err := mapper.getKey(request, transport)
+	mapper.mu.Lock()
+	mapper.keys.Add(memoKey, keyResult{key: key, err: err})
+	mapper.mu.Unlock()
+	return key, err
+}
+
+// getMemoKey identifies the request by the fields and transport keys that
+// rules can match on or generate fragments from.
+func getMemoKey(request v2.DiscoveryRequest, transportKeys []string, transport Transport) string {
+	node := request.GetNode()
+	fields := []string{
+		request.GetTypeUrl(),
+		node.GetId(),
+		node.GetCluster(),
+		node.GetLocality().GetRegion(),
+		node.GetLocality().GetZone(),
+		node.GetLocality().GetSubZone(),
+	}
+	// Lists are preceded by their number of values so that the values cannot
+	// be confused with those of the next list.
+	for _, key := range transportKeys {
+		fields = append(fields, strconv.Itoa(len(transport[key])))
+		fields = append(fields, transport[key]...)
+	}
+	fields = append(fields, strconv.Itoa(len(request.GetResourceNames())))
+	fields = append(fields, request.GetResourceNames()...)
+
+	// Fields are prefixed with their length rather than separated, so that
+	// fields containing a separator cannot be confused with other fields.
+	var memoKey strings.Builder
+	for _, field := range fields {
+		memoKey.WriteString(strconv.Itoa(len(field)))
+		memoKey.WriteByte(':')
+		memoKey.WriteString(field)
+	}
+	return memoKey.String()
+}
+
+func (mapper *mapper) getKey(request v2.DiscoveryRequest, transport Transport) (string, error) {
 	if request.GetTypeUrl() == "" {
 		return "", fmt.Errorf("typeURL is empty")
 	}
 
 	var resultFragments []string
 	for _, fragment := range mapper.config.GetFragments() {
-		fragmentRules := fragment.GetRules()
-		for _, fragmentRule := range fragmentRules {
-			matchPredicate := fragmentRule.GetMatch()
-			isMatch, err := isMatch(matchPredicate, request.GetTypeUrl(), request.GetNode())
-			if err != nil {
-				return "", err
-			}
-			if isMatch {
-				result, err := getResult(fragmentRule, request.GetNode(), request.GetResourceNames())
-				if err != nil {
-					return "", err
-				}
-				resultFragments = append(resultFragments, result)
-			}
+		results, passthrough, err := getFragmentResults(fragment, request, transport)
+		if err != nil {
+			return "", err
+		}
+		if passthrough {
+			return UnaggregatedKey(request), nil
 		}
+		resultFragments = append(resultFragments, results...)
 	}
 
 	if len(resultFragments) == 0 {
-		return "", fmt.Errorf("Cannot map the input to a key")
+		fallback := mapper.config.GetFallback()
+		if fallback.GetDefaultKey() == "" {
+			return "", &UnmatchedRequestError{Reject: fallback.GetReject()}
+		}
+		typeURL := request.GetTypeUrl()
+		resultFragments = []string{fallback.GetDefaultKey(), typeURL[strings.LastIndex(typeURL, "/")+1:]}
 	}
 
-	return strings.Join(resultFragments, separator), nil
+	key := strings.Join(resultFragments, separator)
+	if mapper.config.GetTenant() == nil {
+		return key, nil
+	}
+	tenants, _, err := getFragmentResults(mapper.config.GetTenant(), request, transport)
+	if err != nil {
+		return "", err
+	}
+	if len(tenants) == 0 {
+		return "", fmt.Errorf("Cannot map the input to a tenant")
+	}
+	if tenants[0] == "" || strings.Contains(tenants[0], TenantSeparator) {
+		return "", fmt.Errorf("invalid tenant %q", tenants[0])
+	}
+	return tenants[0] + TenantSeparator + key, nil
+}
+
+// GetTenant returns the tenant prefix of the aggregated key, or an empty
+// string if no tenant is configured.
+func (mapper *mapper) GetTenant(aggregatedKey string) string {
+	if mapper.config.GetTenant() == nil {
+		return ""
+	}
+	if i := strings.Index(aggregatedKey, TenantSeparator); i > 0 {
+		return aggregatedKey[:i]
+	}
+	return ""
+}
+
+// getFragmentResults returns the results of the rules of the fragment that
+// match the request, in order. It returns true instead if a matching rule
+// marks the request as not aggregated.
+func getFragmentResults(
+	fragment *aggregationv1.KeyerConfiguration_Fragment,
+	request v2.DiscoveryRequest,
+	transport Transport,
+) ([]string, bool, error) {
+	var results []string
+	for _, fragmentRule := range fragment.GetRules() {
+		matchPredicate := fragmentRule.GetMatch()
+		isMatch, err := isMatch(matchPredicate, request.GetTypeUrl(), request.GetNode(), transport)
+		if err != nil {
+			return nil, false, err
+		}
+		if isMatch {
+			if fragmentRule.GetResult().GetPassthrough() {
+				return nil, true, nil
+			}
+			result, err := getResult(fragmentRule, request.GetNode(), request.GetResourceNames(), transport)
+			if err != nil {
+				return nil, false, err
+			}
+			results = append(results, result)
+		}
+	}
+	return results, false, nil
 }
 
-func isMatch(matchPredicate *matchPredicate, typeURL string, node *core.Node) (bool, error) {
+func isMatch(matchPredicate *matchPredicate, typeURL string, node *core.Node, transport Transport) (bool, error) {
 	isNodeMatch, err := isNodeMatch(matchPredicate, node)
 	if err != nil {
 		return false, err
@@ -81,7 +303,15 @@ func isMatch(matchPredicate *matchPredicate, typeURL string, node *core.Node) (b
 		return true, nil
 	}
 
-	isAndMatch, err := isAndMatch(matchPredicate, typeURL, node)
+	isTransportMatch, err := isTransportMatch(matchPredicate, transport)
+	if err != nil {
+		return false, err
+	}
+	if isTransportMatch {
+		return true, nil
+	}
+
+	isAndMatch, err := isAndMatch(matchPredicate, typeURL, node, transport)
 	if err != nil {
 		return false, err
 	}
@@ -89,7 +319,7 @@ func isMatch(matchPredicate *matchPredicate, typeURL string, node *core.Node) (b
 		return true, nil
 	}
 
-	isOrMatch, err := isOrMatch(matchPredicate, typeURL, node)
+	isOrMatch, err := isOrMatch(matchPredicate, typeURL, node, transport)
 	if err != nil {
 		return false, err
 	}
@@ -97,7 +327,7 @@ func isMatch(matchPredicate *matchPredicate, typeURL string, node *core.Node) (b
 		return true, nil
 	}
 
-	isNotMatch, err := isNotMatch(matchPredicate, typeURL, node)
+	isNotMatch, err := isNotMatch(matchPredicate, typeURL, node, transport)
 	if err != nil {
 		return false, err
 	}
@@ -129,6 +359,28 @@ func isNodeMatch(matchPredicate *matchPredicate, node *core.Node) (bool, error)
 	}
 }
 
+// isTransportMatch returns true if any value of the transport key of the
+// predicate matches. Keys without values do not match.
+func isTransportMatch(matchPredicate *matchPredicate, transport Transport) (bool, error) {
+	predicate := matchPredicate.GetTransportMatch()
+	if predicate == nil {
+		return false, nil
+	}
+	for _, value := range transport[transportKey(predicate.GetKey())] {
+		var match bool
+		var err error
+		if regexMatch := predicate.GetRegexMatch(); regexMatch != "" {
+			match, err = regexp.MatchString(regexMatch, value)
+		} else {
+			match = value == predicate.GetExactMatch()
+		}
+		if err != nil || match {
+			return match, err
+		}
+	}
+	return false, nil
+}
+
 func isRequestTypeMatch(matchPredicate *matchPredicate, typeURL string) bool {
 	predicate := matchPredicate.GetRequestTypeMatch()
 	if predicate == nil {
@@ -147,14 +399,14 @@ func isAnyMatch(matchPredicate *matchPredicate) bool {
 	return matchPredicate.GetAnyMatch()
 }
 
-func isAndMatch(matchPredicate *matchPredicate, typeURL string, node *core.Node) (bool, error) {
+func isAndMatch(matchPredicate *matchPredicate, typeURL string, node *core.Node, transport Transport) (bool, error) {
 	matchset := matchPredicate.GetAndMatch()
 	if matchset == nil {
 		return false, nil
 	}
 
 	for _, rule := range matchset.GetRules() {
-		isMatch, err := isMatch(rule, typeURL, node)
+		isMatch, err := isMatch(rule, typeURL, node, transport)
 		if err != nil {
 			return false, err
 		}
@@ -165,14 +417,14 @@ func isAndMatch(matchPredicate *matchPredicate, typeURL string, node *core.Node)
 	return true, nil
 }
 
-func isOrMatch(matchPredicate *matchPredicate, typeURL string, node *core.Node) (bool, error) {
+func isOrMatch(matchPredicate *matchPredicate, typeURL string, node *core.Node, transport Transport) (bool, error) {
 	matchset := matchPredicate.GetOrMatch()
 	if matchset == nil {
 		return false, nil
 	}
 
 	for _, rule := range matchset.GetRules() {
-		isMatch, err := isMatch(rule, typeURL, node)
+		isMatch, err := isMatch(rule, typeURL, node, transport)
 		if err != nil {
 			return false, err
 		}
@@ -183,20 +435,20 @@ func isOrMatch(matchPredicate *matchPredicate, typeURL string, node *core.Node)
 	return false, nil
 }
 
-func isNotMatch(matchPredicate *matchPredicate, typeURL string, node *core.Node) (bool, error) {
+func isNotMatch(matchPredicate *matchPredicate, typeURL string, node *core.Node, transport Transport) (bool, error) {
 	predicate := matchPredicate.GetNotMatch()
 	if predicate == nil {
 		return false, nil
 	}
 
-	isMatch, err := isMatch(predicate, typeURL, node)
+	isMatch, err := isMatch(predicate, typeURL, node, transport)
 	if err != nil {
 		return false, err
 	}
 	return !isMatch, nil
 }
 
-func getResult(fragmentRule *rule, node *core.Node, resourceNames []string) (string, error) {
+func getResult(fragmentRule *rule, node *core.Node, resourceNames []string, transport Transport) (string, error) {
 	found, result, err := getResultFromRequestNodeFragmentRule(fragmentRule, node)
 	if err != nil {
 		return "", err
@@ -205,7 +457,15 @@ func getResult(fragmentRule *rule, node *core.Node, resourceNames []string) (str
 		return result, nil
 	}
 
-	found, result, err = getResultFromAndResultFragmentRule(fragmentRule, node, resourceNames)
+	found, result, err = getResultFromTransportPredicate(fragmentRule.GetResult(), transport)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		return result, nil
+	}
+
+	found, result, err = getResultFromAndResultFragmentRule(fragmentRule, node, resourceNames, transport)
 	if err != nil {
 		return "", err
 	}
@@ -236,12 +496,13 @@ func getResultFromRequestNodeFragmentRule(fragmentRule *rule, node *core.Node) (
 func getResultFromAndResultFragmentRule(
 	fragmentRule *rule,
 	node *core.Node,
-	resourceNames []string) (bool, string, error) {
+	resourceNames []string,
+	transport Transport) (bool, string, error) {
 	resultPredicate := fragmentRule.GetResult()
 	if resultPredicate == nil {
 		return false, "", nil
 	}
-	return getResultFromAndResultPredicate(resultPredicate, node, resourceNames)
+	return getResultFromAndResultPredicate(resultPredicate, node, resourceNames, transport)
 }
 
 func getResultFromRequestNodePredicate(predicate *resultPredicate, node *core.Node) (bool, string, error) {
@@ -265,7 +526,8 @@ func getResultFromRequestNodePredicate(predicate *resultPredicate, node *core.No
 func getResultFromAndResultPredicate(
 	resultPredicate *resultPredicate,
 	node *core.Node,
-	resourceNames []string) (bool, string, error) {
+	resourceNames []string,
+	transport Transport) (bool, string, error) {
 	if resultPredicate == nil {
 		return false, "", nil
 	}
@@ -288,6 +550,14 @@ func getResultFromAndResultPredicate(
 			resultfragments.WriteString(fragment)
 		}
 
+		found, fragment, err = getResultFromTransportPredicate(result, transport)
+		if err != nil {
+			return false, "", err
+		}
+		if found {
+			resultfragments.WriteString(fragment)
+		}
+
 		found, fragment, err = getResultFromResourceNamesPredicate(result, resourceNames)
 		if err != nil {
 			return false, "", err
@@ -296,7 +566,7 @@ func getResultFromAndResultPredicate(
 			resultfragments.WriteString(fragment)
 		}
 
-		found, fragment, err = getResultFromAndResultPredicate(result, node, resourceNames)
+		found, fragment, err = getResultFromAndResultPredicate(result, node, resourceNames, transport)
 		if err != nil {
 			return false, "", err
 		}
@@ -344,6 +614,24 @@ func getResultFromResourceNamesPredicate(
 	return true, result, nil
 }
 
+// getResultFromTransportPredicate generates the fragment from the first value
+// of the transport key of the predicate.
+func getResultFromTransportPredicate(predicate *resultPredicate, transport Transport) (bool, string, error) {
+	transportFragment := predicate.GetTransportFragment()
+	if transportFragment == nil {
+		return false, "", nil
+	}
+	values := transport[transportKey(transportFragment.GetKey())]
+	if len(values) == 0 {
+		return false, "", fmt.Errorf("TransportFragment key %s has no value", transportFragment.GetKey())
+	}
+	result, err := getResultFragmentFromAction(values[0], transportFragment.GetAction())
+	if err != nil {
+		return false, "", err
+	}
+	return true, result, nil
+}
+
 func getNodeValue(nodeField aggregationv1.NodeFieldType, node *core.Node) (string, error) {
 	var nodeValue string
 	switch nodeField {
@@ -374,6 +662,22 @@ func getResultFragmentFromAction(
 		return nodeValue, nil
 	}
 
+	switch action.GetAction().(type) {
+	case *aggregationv1.ResultPredicate_ResultAction_ToLower:
+		return getNonEmptyFragment(strings.ToLower(nodeValue), "to_lower")
+	case *aggregationv1.ResultPredicate_ResultAction_TrimPrefix:
+		return getNonEmptyFragment(strings.TrimPrefix(nodeValue, action.GetTrimPrefix()), "trim_prefix")
+	case *aggregationv1.ResultPredicate_ResultAction_SplitAction_:
+		splitAction := action.GetSplitAction()
+		elements := strings.Split(nodeValue, splitAction.GetDelimiter())
+		index := splitAction.GetIndex()
+		if index < 0 || index >= int32(len(elements)) {
+			return "", fmt.Errorf("RequestNodeFragment split_action index %d is out of range of %d elements",
+				index, len(elements))
+		}
+		return getNonEmptyFragment(elements[index], "split_action")
+	}
+
 	regexAction := action.GetRegexAction()
 	pattern := regexAction.GetPattern()
 	replace := regexAction.GetReplace()
@@ -391,6 +695,13 @@ func getResultFragmentFromAction(
 	return replacedFragment, nil
 }
 
+func getNonEmptyFragment(fragment string, action string) (string, error) {
+	if fragment == "" {
+		return "", fmt.Errorf("RequestNodeFragment %s resulted in an empty fragment", action)
+	}
+	return fragment, nil
+}
+
 func compare(requestNodeMatch *aggregationv1.MatchPredicate_RequestNodeMatch, nodeValue string) (bool, error) {
 	if nodeValue == "" {
 		return false, fmt.Errorf("MatchPredicate Node field cannot be empty")
@@ -411,3 +722,53 @@ func compare(requestNodeMatch *aggregationv1.MatchPredicate_RequestNodeMatch, no
 
 	return false, nil
 }
+
+// transportKey normalizes the transport key. gRPC metadata header names are
+// case insensitive.
+func transportKey(key string) string {
+	return strings.ToLower(key)
+}
+
+// getTransportKeys returns the transport keys that the rules of the fragments
+// and the tenant of the configuration refer to, sorted.
+func getTransportKeys(config *aggregationv1.KeyerConfiguration) []string {
+	keys := make(map[string]bool)
+	var addMatchKeys func(predicate *matchPredicate)
+	addMatchKeys = func(predicate *matchPredicate) {
+		if predicate == nil {
+			return
+		}
+		if transportMatch := predicate.GetTransportMatch(); transportMatch != nil {
+			keys[transportKey(transportMatch.GetKey())] = true
+		}
+		addMatchKeys(predicate.GetNotMatch())
+		for _, rule := range predicate.GetAndMatch().GetRules() {
+			addMatchKeys(rule)
+		}
+		for _, rule := range predicate.GetOrMatch().GetRules() {
+			addMatchKeys(rule)
+		}
+	}
+	var addResultKeys func(predicate *resultPredicate)
+	addResultKeys = func(predicate *resultPredicate) {
+		if transportFragment := predicate.GetTransportFragment(); transportFragment != nil {
+			keys[transportKey(transportFragment.GetKey())] = true
+		}
+		for _, result := range predicate.GetAndResult().GetResultPredicates() {
+			addResultKeys(result)
+		}
+	}
+	fragments := append([]*aggregationv1.KeyerConfiguration_Fragment{config.GetTenant()}, config.GetFragments()...)
+	for _, fragment := range fragments {
+		for _, rule := range fragment.GetRules() {
+			addMatchKeys(rule.GetMatch())
+			addResultKeys(rule.GetResult())
+		}
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,84 @@
+package mapper
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+)
+
+const benchmarkNumNodes = 1000
+
+// newBenchmarkMapper returns a mapper whose rules evaluate regexes on the node
+// and resource names of every request, mimicking a typical keyer configuration.
+func newBenchmarkMapper() *mapper {
+	return New(&KeyerConfiguration{
+		Fragments: []*Fragment{
+			{
+				Rules: []*FragmentRule{
+					{
+						Match:  getRequestTypeMatch([]string{listenerTypeURL}),
+						Result: getResultStringFragment(),
+					},
+					{
+						Match:  getRequestTypeMatch([]string{clusterTypeURL}),
+						Result: getResultRequestNodeFragment(nodeClusterField, getRegexAction("^(.*)-[0-9]+$", "$1")),
+					},
+				},
+			},
+			{
+				Rules: []*FragmentRule{
+					{
+						Match: getRequestNodeAndMatch([]*MatchPredicate{
+							getRequestNodeRegexMatch(nodeIDField, "^node-"),
+							getRequestNodeRegexMatch(nodeRegionField, "^us-"),
+						}),
+						Result: getResourceNameFragment(0, getRegexAction("^resource-(.*)$", "$1")),
+					},
+				},
+			},
+		},
+	}).(*mapper)
+}
+
+func benchmarkRequests() []v2.DiscoveryRequest {
+	requests := make([]v2.DiscoveryRequest, benchmarkNumNodes)
+	for i := range requests {
+		requests[i] = v2.DiscoveryRequest{
+			Node:          getNode(fmt.Sprintf("node-%d", i), fmt.Sprintf("cluster-%d", i%10), "us-east", "", ""),
+			ResourceNames: []string{fmt.Sprintf("resource-%d", i%100)},
+			TypeUrl:       clusterTypeURL,
+		}
+	}
+	return requests
+}
+
+func benchmarkGetKey(b *testing.B, getKey func(v2.DiscoveryRequest) (string, error)) {
+	requests := benchmarkRequests()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for pb.Next() {
+			if _, err := getKey(requests[r.Intn(len(requests))]); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
+// BenchmarkGetKey measures GetKey when the keys of repeated requests, such as
+// acknowledgements, are memoized.
+func BenchmarkGetKey(b *testing.B) {
+	benchmarkGetKey(b, newBenchmarkMapper().GetKey)
+}
+
+// BenchmarkGetKeyUnmemoized measures the evaluation of the rules for every
+// request.
+func BenchmarkGetKeyUnmemoized(b *testing.B) {
+	mapper := newBenchmarkMapper()
+	benchmarkGetKey(b, func(request v2.DiscoveryRequest) (string, error) {
+		return mapper.getKey(request, nil)
+	})
+}
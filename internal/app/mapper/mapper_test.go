@@ -353,6 +353,51 @@ var positiveTests = []TableEntry{
 			"replace",
 		},
 	},
+	{
+		Description: "AnyMatch With to_lower Node Id result",
+		Parameters: []interface{}{
+			getAnyMatch(true),
+			getResultRequestNodeFragment(nodeIDField, getToLowerAction()),
+			clusterTypeURL,
+			nodeid,
+		},
+	},
+	{
+		Description: "AnyMatch With trim_prefix Node Id result",
+		Parameters: []interface{}{
+			getAnyMatch(true),
+			getResultRequestNodeFragment(nodeIDField, getTrimPrefixAction("node")),
+			clusterTypeURL,
+			"id",
+		},
+	},
+	{
+		Description: "AnyMatch With trim_prefix not matching Node Id result",
+		Parameters: []interface{}{
+			getAnyMatch(true),
+			getResultRequestNodeFragment(nodeIDField, getTrimPrefixAction("id")),
+			clusterTypeURL,
+			nodeid,
+		},
+	},
+	{
+		Description: "AnyMatch With split_action Node Region result",
+		Parameters: []interface{}{
+			getAnyMatch(true),
+			getResultRequestNodeFragment(nodeRegionField, getSplitAction("g", 1)),
+			clusterTypeURL,
+			"ion",
+		},
+	},
+	{
+		Description: "AnyMatch With split_action resource name result",
+		Parameters: []interface{}{
+			getAnyMatch(true),
+			getResourceNameFragment(1, getSplitAction("resource", 1)),
+			clusterTypeURL,
+			"2",
+		},
+	},
 	{
 		Description: "AnyMatch With exact Node Cluster match",
 		Parameters: []interface{}{
@@ -1086,6 +1131,42 @@ var emptyFragmentErrorCases = []TableEntry{
 			"ResourceNamesFragment.Element cannot be negative or larger than length",
 		},
 	},
+	{
+		Description: "empty node id in to_lower result",
+		Parameters: []interface{}{
+			getAnyMatch(true),
+			getResultRequestNodeFragment(nodeIDField, getToLowerAction()),
+			getDiscoveryRequestWithNode(getNode("", nodecluster, noderegion, nodezone, nodesubzone)),
+			"RequestNodeFragment to_lower resulted in an empty fragment",
+		},
+	},
+	{
+		Description: "trim_prefix of the whole node id",
+		Parameters: []interface{}{
+			getAnyMatch(true),
+			getResultRequestNodeFragment(nodeIDField, getTrimPrefixAction(nodeid)),
+			getDiscoveryRequest(),
+			"RequestNodeFragment trim_prefix resulted in an empty fragment",
+		},
+	},
+	{
+		Description: "split_action index above range",
+		Parameters: []interface{}{
+			getAnyMatch(true),
+			getResultRequestNodeFragment(nodeRegionField, getSplitAction("g", 2)),
+			getDiscoveryRequest(),
+			"RequestNodeFragment split_action index 2 is out of range of 2 elements",
+		},
+	},
+	{
+		Description: "split_action resulting in an empty element",
+		Parameters: []interface{}{
+			getAnyMatch(true),
+			getResultRequestNodeFragment(nodeRegionField, getSplitAction("r", 0)),
+			getDiscoveryRequest(),
+			"RequestNodeFragment split_action resulted in an empty fragment",
+		},
+	},
 }
 
 var _ = Describe("GetKey", func() {
@@ -1128,7 +1209,7 @@ var _ = Describe("GetKey", func() {
 			mapper := New(&protoConfig)
 			key, err := mapper.GetKey(request)
 			Expect(key).To(Equal(""))
-			Expect(err).Should(Equal(fmt.Errorf("Cannot map the input to a key")))
+			Expect(err).Should(Equal(&UnmatchedRequestError{}))
 		},
 		negativeTests...)
 
@@ -1190,7 +1271,7 @@ var _ = Describe("GetKey", func() {
 			mapper := New(&protoConfig)
 			key, err := mapper.GetKey(getDiscoveryRequest())
 			Expect(key).To(Equal(""))
-			Expect(err).Should(Equal(fmt.Errorf("Cannot map the input to a key")))
+			Expect(err).Should(Equal(&UnmatchedRequestError{}))
 		},
 		multiFragmentNegativeTests...)
 
@@ -1265,6 +1346,24 @@ var _ = Describe("GetKey", func() {
 		},
 		emptyFragmentErrorCases...)
 
+	It("to_lower should lowercase the fragment", func() {
+		mapper := New(&KeyerConfiguration{
+			Fragments: []*Fragment{
+				{
+					Rules: []*FragmentRule{
+						{
+							Match:  getAnyMatch(true),
+							Result: getResultRequestNodeFragment(nodeClusterField, getToLowerAction()),
+						},
+					},
+				},
+			},
+		})
+		key, err := mapper.GetKey(getDiscoveryRequestWithNode(getNode(nodeid, "Cluster-A", "", "", "")))
+		Expect(err).Should(BeNil())
+		Expect(key).To(Equal("cluster-a"))
+	})
+
 	It("TypeUrl should not be empty", func() {
 		mapper := New(&KeyerConfiguration{})
 		request := getDiscoveryRequest()
@@ -1273,6 +1372,262 @@ var _ = Describe("GetKey", func() {
 		Expect(key).To(Equal(""))
 		Expect(err).Should(Equal(fmt.Errorf("typeURL is empty")))
 	})
+
+	It("should evaluate nested compound match predicates", func() {
+		// Matches cluster requests of the cluster, except from canary nodes.
+		mapper := New(&KeyerConfiguration{
+			Fragments: []*Fragment{
+				{
+					Rules: []*FragmentRule{
+						{
+							Match: getRequestNodeAndMatch([]*MatchPredicate{
+								getRequestTypeMatch([]string{clusterTypeURL}),
+								getRequestNodeRegexMatch(nodeClusterField, "^clus"),
+								{
+									Type: &aggregationv1.MatchPredicate_NotMatch{
+										NotMatch: getRequestNodeRegexMatch(nodeIDField, "^canary-"),
+									},
+								},
+							}),
+							Result: getResultStringFragment(),
+						},
+					},
+				},
+			},
+		})
+		key, err := mapper.GetKey(getDiscoveryRequest())
+		Expect(err).Should(BeNil())
+		Expect(key).To(Equal(stringFragment))
+
+		request := getDiscoveryRequestWithNode(getNode("canary-1", nodecluster, noderegion, nodezone, nodesubzone))
+		key, err = mapper.GetKey(request)
+		Expect(key).To(Equal(""))
+		Expect(err).Should(Equal(&UnmatchedRequestError{}))
+
+		request = getDiscoveryRequest()
+		request.TypeUrl = listenerTypeURL
+		key, err = mapper.GetKey(request)
+		Expect(key).To(Equal(""))
+		Expect(err).Should(Equal(&UnmatchedRequestError{}))
+	})
+
+	Describe("with a tenant", func() {
+		newTenantMapper := func(tenantMatch *MatchPredicate) Mapper {
+			return New(&KeyerConfiguration{
+				Fragments: []*Fragment{
+					{
+						Rules: []*FragmentRule{
+							{
+								Match:  getAnyMatch(true),
+								Result: getResultStringFragment(),
+							},
+						},
+					},
+				},
+				Tenant: &Fragment{
+					Rules: []*FragmentRule{
+						{
+							Match:  tenantMatch,
+							Result: getResultRequestNodeFragment(nodeClusterField, getExactAction()),
+						},
+						{
+							Match:  getAnyMatch(true),
+							Result: getResultRequestNodeFragment(nodeRegionField, getExactAction()),
+						},
+					},
+				},
+			})
+		}
+
+		It("should prefix the key with the first matching tenant", func() {
+			key, err := newTenantMapper(getAnyMatch(true)).GetKey(getDiscoveryRequest())
+			Expect(err).Should(BeNil())
+			Expect(key).To(Equal(nodecluster + TenantSeparator + stringFragment))
+			Expect(newTenantMapper(getAnyMatch(true)).(TenantMapper).GetTenant(key)).To(Equal(nodecluster))
+
+			key, err = newTenantMapper(getRequestTypeMatch([]string{listenerTypeURL})).GetKey(getDiscoveryRequest())
+			Expect(err).Should(BeNil())
+			Expect(key).To(Equal(noderegion + TenantSeparator + stringFragment))
+		})
+
+		It("should return error for invalid tenants", func() {
+			request := getDiscoveryRequestWithNode(getNode(nodeid, "a/b", noderegion, nodezone, nodesubzone))
+			key, err := newTenantMapper(getAnyMatch(true)).GetKey(request)
+			Expect(key).To(Equal(""))
+			Expect(err).Should(Equal(fmt.Errorf("invalid tenant %q", "a/b")))
+		})
+
+		It("should return error if no tenant matches", func() {
+			mapper := New(&KeyerConfiguration{
+				Fragments: []*Fragment{
+					{
+						Rules: []*FragmentRule{
+							{
+								Match:  getAnyMatch(true),
+								Result: getResultStringFragment(),
+							},
+						},
+					},
+				},
+				Tenant: &Fragment{
+					Rules: []*FragmentRule{
+						{
+							Match:  getRequestTypeMatch([]string{listenerTypeURL}),
+							Result: getResultStringFragment(),
+						},
+					},
+				},
+			})
+			key, err := mapper.GetKey(getDiscoveryRequest())
+			Expect(key).To(Equal(""))
+			Expect(err).Should(Equal(fmt.Errorf("Cannot map the input to a tenant")))
+		})
+	})
+
+	It("should memoize keys by the fields of the request that rules use", func() {
+		m := New(&KeyerConfiguration{
+			Fragments: []*Fragment{
+				{
+					Rules: []*FragmentRule{
+						{
+							Match:  getRequestNodeRegexMatch(nodeIDField, "^node"),
+							Result: getResourceNameFragment(0, getRegexAction("resource", "r")),
+						},
+					},
+				},
+			},
+		}).(*mapper)
+		request := getDiscoveryRequest()
+		for i := 0; i < 2; i++ {
+			key, err := m.GetKey(request)
+			Expect(err).Should(BeNil())
+			Expect(key).To(Equal("r1"))
+			Expect(m.keys.Len()).To(Equal(1))
+		}
+
+		request.ResourceNames = []string{resource2}
+		key, err := m.GetKey(request)
+		Expect(err).Should(BeNil())
+		Expect(key).To(Equal("r2"))
+
+		request.Node = getNode("other", nodecluster, noderegion, nodezone, nodesubzone)
+		key, err = m.GetKey(request)
+		Expect(key).To(Equal(""))
+		Expect(err).Should(Equal(&UnmatchedRequestError{}))
+		_, err = m.GetKey(request)
+		Expect(err).Should(Equal(&UnmatchedRequestError{}))
+		Expect(m.keys.Len()).To(Equal(3))
+	})
+
+	It("should not memoize keys of different requests together", func() {
+		request := getDiscoveryRequest()
+		request.ResourceNames = []string{"a\x00b"}
+		other := getDiscoveryRequest()
+		other.ResourceNames = []string{"a", "b"}
+		Expect(getMemoKey(request, nil, nil)).NotTo(Equal(getMemoKey(other, nil, nil)))
+
+		request = getDiscoveryRequest()
+		request.Node = getNode("node\x00cluster", "", noderegion, nodezone, nodesubzone)
+		other = getDiscoveryRequest()
+		other.Node = getNode("node", "cluster", noderegion, nodezone, nodesubzone)
+		Expect(getMemoKey(request, nil, nil)).NotTo(Equal(getMemoKey(other, nil, nil)))
+
+		transportKeys := []string{TransportURISAN, "x-tenant"}
+		Expect(getMemoKey(request, transportKeys, Transport{TransportURISAN: {"a", "\x01"}})).
+			NotTo(Equal(getMemoKey(request, transportKeys, Transport{TransportURISAN: {"a"}, "x-tenant": {}})))
+		Expect(getMemoKey(request, transportKeys, Transport{TransportURISAN: {"a"}})).
+			NotTo(Equal(getMemoKey(request, transportKeys, Transport{"x-tenant": {"a"}})))
+	})
+
+	It("should not aggregate requests that match a passthrough rule", func() {
+		mapper := New(&KeyerConfiguration{
+			Fragments: []*Fragment{
+				{
+					Rules: []*FragmentRule{
+						{
+							Match:  getAnyMatch(true),
+							Result: getResultStringFragment(),
+						},
+					},
+				},
+				{
+					Rules: []*FragmentRule{
+						{
+							Match: getRequestTypeMatch([]string{clusterTypeURL}),
+							Result: &ResultPredicate{
+								Type: &aggregationv1.ResultPredicate_Passthrough{Passthrough: true},
+							},
+						},
+					},
+				},
+			},
+		})
+		key, err := mapper.GetKey(getDiscoveryRequest())
+		Expect(err).Should(BeNil())
+		Expect(key).To(Equal(UnaggregatedPrefix + nodeid + "_" + clusterTypeURL))
+
+		request := getDiscoveryRequest()
+		request.TypeUrl = listenerTypeURL
+		key, err = mapper.GetKey(request)
+		Expect(err).Should(BeNil())
+		Expect(key).To(Equal(stringFragment))
+	})
+
+	Describe("with a fallback", func() {
+		newFallbackMapper := func(fallback *aggregationv1.KeyerConfiguration_Fallback) Mapper {
+			return New(&KeyerConfiguration{
+				Fragments: []*Fragment{
+					{
+						Rules: []*FragmentRule{
+							{
+								Match:  getRequestTypeMatch([]string{listenerTypeURL}),
+								Result: getResultStringFragment(),
+							},
+						},
+					},
+				},
+				Fallback: fallback,
+			})
+		}
+
+		It("should map unmatched requests to the default key of their type", func() {
+			mapper := newFallbackMapper(&aggregationv1.KeyerConfiguration_Fallback{
+				Behavior: &aggregationv1.KeyerConfiguration_Fallback_DefaultKey{DefaultKey: "default"},
+			})
+			key, err := mapper.GetKey(getDiscoveryRequest())
+			Expect(err).Should(BeNil())
+			Expect(key).To(Equal("default_envoy.api.v2.Cluster"))
+
+			request := getDiscoveryRequest()
+			request.TypeUrl = listenerTypeURL
+			key, err = mapper.GetKey(request)
+			Expect(err).Should(BeNil())
+			Expect(key).To(Equal(stringFragment))
+		})
+
+		It("should return an error that passes unmatched requests through", func() {
+			mapper := newFallbackMapper(&aggregationv1.KeyerConfiguration_Fallback{
+				Behavior: &aggregationv1.KeyerConfiguration_Fallback_Passthrough{Passthrough: true},
+			})
+			key, err := mapper.GetKey(getDiscoveryRequest())
+			Expect(key).To(Equal(""))
+			Expect(err).Should(Equal(&UnmatchedRequestError{}))
+		})
+
+		It("should return an error that rejects unmatched requests", func() {
+			mapper := newFallbackMapper(&aggregationv1.KeyerConfiguration_Fallback{
+				Behavior: &aggregationv1.KeyerConfiguration_Fallback_Reject{Reject: true},
+			})
+			key, err := mapper.GetKey(getDiscoveryRequest())
+			Expect(key).To(Equal(""))
+			Expect(err).Should(Equal(&UnmatchedRequestError{Reject: true}))
+		})
+	})
+
+	It("GetTenant should return empty if no tenant is configured", func() {
+		mapper := New(&KeyerConfiguration{}).(TenantMapper)
+		Expect(mapper.GetTenant(nodecluster + TenantSeparator + stringFragment)).To(Equal(""))
+	})
 })
 
 func getAnyMatch(any bool) *MatchPredicate {
@@ -1468,6 +1823,33 @@ func getRegexAction(pattern string, replace string) *aggregationv1.ResultPredica
 	}
 }
 
+func getToLowerAction() *aggregationv1.ResultPredicate_ResultAction {
+	return &aggregationv1.ResultPredicate_ResultAction{
+		Action: &aggregationv1.ResultPredicate_ResultAction_ToLower{
+			ToLower: true,
+		},
+	}
+}
+
+func getTrimPrefixAction(prefix string) *aggregationv1.ResultPredicate_ResultAction {
+	return &aggregationv1.ResultPredicate_ResultAction{
+		Action: &aggregationv1.ResultPredicate_ResultAction_TrimPrefix{
+			TrimPrefix: prefix,
+		},
+	}
+}
+
+func getSplitAction(delimiter string, index int32) *aggregationv1.ResultPredicate_ResultAction {
+	return &aggregationv1.ResultPredicate_ResultAction{
+		Action: &aggregationv1.ResultPredicate_ResultAction_SplitAction_{
+			SplitAction: &aggregationv1.ResultPredicate_ResultAction_SplitAction{
+				Delimiter: delimiter,
+				Index:     index,
+			},
+		},
+	}
+}
+
 func getDiscoveryRequest() v2.DiscoveryRequest {
 	return getDiscoveryRequestWithNode(getNode(nodeid, nodecluster, noderegion, nodezone, nodesubzone))
 }
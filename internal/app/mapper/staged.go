@@ -0,0 +1,165 @@
+package mapper
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+)
+
+const (
+	// RevisionSeparator separates the aggregated keys of a staged revision
+	// from the name of the revision.
+	RevisionSeparator = "@"
+
+	// revisionBuckets is the number of buckets that node IDs are hashed into,
+	// which allows revision percentages with two decimals.
+	revisionBuckets = 10000
+)
+
+// Revision is a revision of the aggregation rules staged on a share of the
+// downstream nodes.
+type Revision struct {
+	// Name suffixes the aggregated keys of the revision.
+	Name   string
+	Mapper Mapper
+	// Percentage of the nodes, selected by a hash of their node ID, that are
+	// mapped with the revision.
+	Percentage float64
+	// NodeMetadata selects the nodes whose metadata has all of its string
+	// fields, in addition to the percentage.
+	NodeMetadata map[string]string
+}
+
+type stagedMapper struct {
+	stable    Mapper
+	revisions []Revision
+}
+
+// NewStaged returns a mapper that maps the requests of the nodes selected by a
+// revision with the revision, and the requests of the other nodes with the
+// stable mapper. Nodes are mapped with the first revision that selects them.
+// The percentages of the revisions select distinct nodes, so they must add up
+// to at most 100.
+func NewStaged(stable Mapper, revisions []Revision) (Mapper, error) {
+	names := make(map[string]bool)
+	total := 0.0
+	for _, revision := range revisions {
+		if revision.Name == "" || strings.Contains(revision.Name, RevisionSeparator) {
+			return nil, fmt.Errorf("invalid revision name %q", revision.Name)
+		}
+		if names[revision.Name] {
+			return nil, fmt.Errorf("duplicate revision name %q", revision.Name)
+		}
+		names[revision.Name] = true
+		total += revision.Percentage
+	}
+	if total > 100 {
+		return nil, fmt.Errorf("revision percentages add up to %v, more than 100", total)
+	}
+	return &stagedMapper{stable: stable, revisions: revisions}, nil
+}
+
+// GetKey maps the request with the revision that selects its node, and
+// suffixes the aggregated key with the name of the revision.
+func (s *stagedMapper) GetKey(request v2.DiscoveryRequest) (string, error) {
+	return s.GetKeyWithTransport(request, nil)
+}
+
+// TransportKeys returns the transport keys that the stable mapper and the
+// revisions refer to.
+func (s *stagedMapper) TransportKeys() []string {
+	keys := append([]string(nil), transportKeysOf(s.stable)...)
+	for _, revision := range s.revisions {
+		for _, key := range transportKeysOf(revision.Mapper) {
+			if !containsString(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// GetKeyWithTransport maps the request like GetKey, with the transport of its
+// downstream stream.
+func (s *stagedMapper) GetKeyWithTransport(request v2.DiscoveryRequest, transport Transport) (string, error) {
+	revision := s.revisionOf(request.GetNode())
+	if revision == nil {
+		return GetKeyWithTransport(s.stable, request, transport)
+	}
+	key, err := GetKeyWithTransport(revision.Mapper, request, transport)
+	if err != nil || strings.HasPrefix(key, UnaggregatedPrefix) {
+		return key, err
+	}
+	return key + RevisionSeparator + revision.Name, nil
+}
+
+// GetTenant returns the tenant of the aggregated key from the mapper of its
+// revision.
+func (s *stagedMapper) GetTenant(aggregatedKey string) string {
+	mapper := s.stable
+	if i := strings.LastIndex(aggregatedKey, RevisionSeparator); i >= 0 {
+		for _, revision := range s.revisions {
+			if revision.Name == aggregatedKey[i+len(RevisionSeparator):] {
+				mapper, aggregatedKey = revision.Mapper, aggregatedKey[:i]
+				break
+			}
+		}
+	}
+	tenantMapper, ok := mapper.(TenantMapper)
+	if !ok {
+		return ""
+	}
+	return tenantMapper.GetTenant(aggregatedKey)
+}
+
+// revisionOf returns the revision that selects the node, or nil if none
+// does.
+func (s *stagedMapper) revisionOf(node *core.Node) *Revision {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(node.GetId()))
+	bucket := float64(hash.Sum32() % revisionBuckets)
+	lower := 0.0
+	for i := range s.revisions {
+		revision := &s.revisions[i]
+		upper := lower + revision.Percentage*revisionBuckets/100
+		if bucket >= lower && bucket < upper {
+			return revision
+		}
+		lower = upper
+		if len(revision.NodeMetadata) > 0 && isNodeMetadataMatch(revision.NodeMetadata, node) {
+			return revision
+		}
+	}
+	return nil
+}
+
+func isNodeMetadataMatch(metadata map[string]string, node *core.Node) bool {
+	fields := node.GetMetadata().GetFields()
+	for name, value := range metadata {
+		if fields[name].GetStringValue() != value {
+			return false
+		}
+	}
+	return true
+}
+
+// transportKeysOf returns the transport keys of the mapper, if it is a
+// TransportMapper.
+func transportKeysOf(m Mapper) []string {
+	if transportMapper, ok := m.(TransportMapper); ok {
+		return transportMapper.TransportKeys()
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package mapper
+
+import (
+	"fmt"
+
+	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Staged", func() {
+	newStringMapper := func(fragment string, tenant *Fragment) Mapper {
+		return New(&KeyerConfiguration{
+			Fragments: []*Fragment{
+				{
+					Rules: []*FragmentRule{
+						{
+							Match: getAnyMatch(true),
+							Result: &ResultPredicate{
+								Type: &aggregationv1.ResultPredicate_StringFragment{StringFragment: fragment},
+							},
+						},
+					},
+				},
+			},
+			Tenant: tenant,
+		})
+	}
+	tenant := &Fragment{
+		Rules: []*FragmentRule{
+			{
+				Match:  getAnyMatch(true),
+				Result: getResultRequestNodeFragment(nodeClusterField, getExactAction()),
+			},
+		},
+	}
+
+	It("should map the nodes selected by a revision with the revision", func() {
+		mapper, err := NewStaged(newStringMapper("stable", nil), []Revision{
+			{
+				Name:         "labeled",
+				Mapper:       newStringMapper("labeled", nil),
+				NodeMetadata: map[string]string{"canary": "true"},
+			},
+			{Name: "everyone", Mapper: newStringMapper("everyone", tenant), Percentage: 100},
+		})
+		Expect(err).Should(BeNil())
+
+		node := getNode(nodeid, nodecluster, noderegion, nodezone, nodesubzone)
+		key, err := mapper.GetKey(getDiscoveryRequestWithNode(node))
+		Expect(err).Should(BeNil())
+		Expect(key).To(Equal(nodecluster + TenantSeparator + "everyone" + RevisionSeparator + "everyone"))
+		Expect(mapper.(TenantMapper).GetTenant(key)).To(Equal(nodecluster))
+
+		// Nodes are mapped with the first revision that selects them.
+		node.Metadata = &structpb.Struct{Fields: map[string]*structpb.Value{
+			"canary": {Kind: &structpb.Value_StringValue{StringValue: "true"}},
+		}}
+		key, err = mapper.GetKey(getDiscoveryRequestWithNode(node))
+		Expect(err).Should(BeNil())
+		Expect(key).To(Equal("labeled" + RevisionSeparator + "labeled"))
+		Expect(mapper.(TenantMapper).GetTenant(key)).To(Equal(""))
+	})
+
+	It("should map the nodes selected by no revision with the stable mapper", func() {
+		mapper, err := NewStaged(newStringMapper("stable", tenant), []Revision{
+			{Name: "canary", Mapper: newStringMapper("canary", nil), Percentage: 0},
+		})
+		Expect(err).Should(BeNil())
+		key, err := mapper.GetKey(getDiscoveryRequest())
+		Expect(err).Should(BeNil())
+		Expect(key).To(Equal(nodecluster + TenantSeparator + "stable"))
+		Expect(mapper.(TenantMapper).GetTenant(key)).To(Equal(nodecluster))
+	})
+
+	It("should select distinct nodes by percentage", func() {
+		mapper, err := NewStaged(newStringMapper("stable", nil), []Revision{
+			{Name: "a", Mapper: newStringMapper("a", nil), Percentage: 25},
+			{Name: "b", Mapper: newStringMapper("b", nil), Percentage: 25},
+		})
+		Expect(err).Should(BeNil())
+		counts := make(map[string]int)
+		for i := 0; i < 1000; i++ {
+			node := getNode(fmt.Sprintf("node-%d", i), nodecluster, noderegion, nodezone, nodesubzone)
+			request := getDiscoveryRequestWithNode(node)
+			key, err := mapper.GetKey(request)
+			Expect(err).Should(BeNil())
+			counts[key]++
+			// Every request of a node is mapped with the same revision.
+			request.TypeUrl = listenerTypeURL
+			Expect(mapper.GetKey(request)).To(Equal(key))
+		}
+		Expect(counts).To(HaveLen(3))
+		Expect(counts["a@a"]).To(BeNumerically("~", 250, 50))
+		Expect(counts["b@b"]).To(BeNumerically("~", 250, 50))
+		Expect(counts["stable"]).To(BeNumerically("~", 500, 50))
+	})
+
+	It("should not suffix the keys of requests that are not aggregated", func() {
+		mapper, err := NewStaged(newStringMapper("stable", nil), []Revision{
+			{
+				Name: "canary",
+				Mapper: New(&KeyerConfiguration{
+					Fragments: []*Fragment{
+						{
+							Rules: []*FragmentRule{
+								{
+									Match:  getAnyMatch(true),
+									Result: &ResultPredicate{Type: &aggregationv1.ResultPredicate_Passthrough{Passthrough: true}},
+								},
+							},
+						},
+					},
+				}),
+				Percentage: 100,
+			},
+		})
+		Expect(err).Should(BeNil())
+		request := getDiscoveryRequest()
+		Expect(mapper.GetKey(request)).To(Equal(UnaggregatedKey(request)))
+	})
+
+	It("should return error for invalid revisions", func() {
+		stable := newStringMapper("stable", nil)
+		_, err := NewStaged(stable, []Revision{{Name: "a@b", Mapper: stable}})
+		Expect(err).Should(Equal(fmt.Errorf("invalid revision name %q", "a@b")))
+		_, err = NewStaged(stable, []Revision{{Name: "a", Mapper: stable}, {Name: "a", Mapper: stable}})
+		Expect(err).Should(Equal(fmt.Errorf("duplicate revision name %q", "a")))
+		_, err = NewStaged(stable, []Revision{
+			{Name: "a", Mapper: stable, Percentage: 60},
+			{Name: "b", Mapper: stable, Percentage: 50},
+		})
+		Expect(err).Should(Equal(fmt.Errorf("revision percentages add up to %v, more than 100", 110.0)))
+	})
+})
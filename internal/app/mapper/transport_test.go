@@ -0,0 +1,86 @@
+package mapper
+
+import (
+	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Transport", func() {
+	newTransportMapper := func(match *MatchPredicate, result *ResultPredicate) Mapper {
+		return New(&KeyerConfiguration{
+			Fragments: []*Fragment{
+				{Rules: []*FragmentRule{{Match: match, Result: result}}},
+			},
+		})
+	}
+	tenantMatch := &MatchPredicate{
+		Type: &aggregationv1.MatchPredicate_TransportMatch_{
+			TransportMatch: &aggregationv1.MatchPredicate_TransportMatch{
+				Key:  "X-Tenant",
+				Type: &aggregationv1.MatchPredicate_TransportMatch_RegexMatch{RegexMatch: "^team-"},
+			},
+		},
+	}
+	sanFragment := &ResultPredicate{
+		Type: &aggregationv1.ResultPredicate_TransportFragment_{
+			TransportFragment: &aggregationv1.ResultPredicate_TransportFragment{
+				Key:    TransportURISAN,
+				Action: getRegexAction("^spiffe://cluster.local/ns/", ""),
+			},
+		},
+	}
+
+	It("should report the transport keys that the rules refer to", func() {
+		mapper := newTransportMapper(tenantMatch, sanFragment)
+		Expect(mapper.(TransportMapper).TransportKeys()).To(Equal([]string{TransportURISAN, "x-tenant"}))
+		nodeMapper := newTransportMapper(getAnyMatch(true), getResultRequestNodeFragment(nodeIDField, getExactAction()))
+		Expect(nodeMapper.(TransportMapper).TransportKeys()).To(BeEmpty())
+	})
+
+	It("should map requests with the transport of their downstream stream", func() {
+		mapper := newTransportMapper(tenantMatch, sanFragment)
+		key, err := GetKeyWithTransport(mapper, getDiscoveryRequest(), Transport{
+			"x-tenant":      {"other", "team-a"},
+			TransportURISAN: {"spiffe://cluster.local/ns/default/sa/envoy", "spiffe://other"},
+		})
+		Expect(err).Should(BeNil())
+		Expect(key).To(Equal("default/sa/envoy"))
+
+		// Results are memoized by the transport values.
+		key, err = GetKeyWithTransport(mapper, getDiscoveryRequest(), Transport{
+			"x-tenant":      {"team-b"},
+			TransportURISAN: {"spiffe://cluster.local/ns/payments/sa/envoy"},
+		})
+		Expect(err).Should(BeNil())
+		Expect(key).To(Equal("payments/sa/envoy"))
+	})
+
+	It("should not match requests whose transport key has no value", func() {
+		mapper := newTransportMapper(tenantMatch, sanFragment)
+		_, err := GetKeyWithTransport(mapper, getDiscoveryRequest(), Transport{"x-tenant": {"other"}})
+		Expect(err).ShouldNot(BeNil())
+		_, err = mapper.GetKey(getDiscoveryRequest())
+		Expect(err).ShouldNot(BeNil())
+	})
+
+	It("should fail to map requests whose fragment key has no value", func() {
+		mapper := newTransportMapper(getAnyMatch(true), sanFragment)
+		_, err := GetKeyWithTransport(mapper, getDiscoveryRequest(), Transport{"x-tenant": {"team-a"}})
+		Expect(err).Should(MatchError("TransportFragment key :uri_san has no value"))
+	})
+
+	It("should map requests with the transport of the staged mappers", func() {
+		staged, err := NewStaged(newTransportMapper(getAnyMatch(true), sanFragment), []Revision{
+			{Name: "everyone", Mapper: newTransportMapper(tenantMatch, sanFragment), Percentage: 100},
+		})
+		Expect(err).Should(BeNil())
+		Expect(staged.(TransportMapper).TransportKeys()).To(Equal([]string{TransportURISAN, "x-tenant"}))
+		key, err := GetKeyWithTransport(staged, getDiscoveryRequest(), Transport{
+			"x-tenant":      {"team-a"},
+			TransportURISAN: {"spiffe://cluster.local/ns/default/sa/envoy"},
+		})
+		Expect(err).Should(BeNil())
+		Expect(key).To(Equal("default/sa/envoy@everyone"))
+	})
+})
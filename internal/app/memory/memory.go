@@ -0,0 +1,153 @@
+// Package memory tunes the garbage collector, and evicts the least recently used aggregated keys from the cache when
+// the resident memory of the process exceeds a high watermark, so that the relay degrades gracefully instead of being
+// killed for running out of memory.
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/uber-go/tally"
+)
+
+const (
+	defaultCheckInterval     = 5 * time.Second
+	defaultEvictionBatchSize = 10
+
+	statmPath     = "/proc/self/statm"
+	statmRSSField = 1
+
+	metricRSSBytes = "rss_bytes"
+	metricRSSError = "rss_error"
+	metricEvicted  = "watermark_evicted"
+)
+
+// ballast is never read or written. It is kept reachable so that the garbage collector counts it towards the heap.
+var ballast []byte
+
+// Tune applies the garbage collection percentage and allocates the ballast of the config.
+func Tune(config *bootstrapv1.Memory) {
+	if config.GetGcPercent() != nil {
+		debug.SetGCPercent(int(config.GetGcPercent().GetValue()))
+	}
+	if config.GetBallastBytes() > 0 {
+		ballast = make([]byte, config.GetBallastBytes())
+	}
+}
+
+// Evictor evicts the least recently used aggregated keys.
+type Evictor interface {
+	EvictLeastRecentlyUsed(n int) int
+}
+
+// Monitor periodically checks the resident memory of the process against the high watermark.
+type Monitor struct {
+	highWatermark uint64
+	interval      time.Duration
+	batchSize     int
+	evictor       Evictor
+	readRSS       func() (uint64, error)
+	logger        log.Logger
+	scope         tally.Scope
+}
+
+// NewMonitor returns a monitor of the high watermark of the config, or nil if no high watermark is set.
+func NewMonitor(config *bootstrapv1.Memory, evictor Evictor, logger log.Logger, scope tally.Scope) (*Monitor, error) {
+	if config.GetHighWatermarkBytes() == 0 {
+		return nil, nil
+	}
+	m := &Monitor{
+		highWatermark: config.GetHighWatermarkBytes(),
+		interval:      defaultCheckInterval,
+		batchSize:     defaultEvictionBatchSize,
+		evictor:       evictor,
+		readRSS:       readRSS,
+		logger:        logger.Named("memory").With("high watermark", config.GetHighWatermarkBytes()),
+		scope:         scope,
+	}
+	if config.GetCheckInterval() != nil {
+		interval, err := ptypes.Duration(config.GetCheckInterval())
+		if err != nil {
+			return nil, err
+		}
+		m.interval = interval
+	}
+	if config.GetEvictionBatchSize() > 0 {
+		m.batchSize = int(config.GetEvictionBatchSize())
+	}
+	return m, nil
+}
+
+// Run checks the resident memory at every interval until ctx is done.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.check(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check evicts a batch of keys if the resident memory is above the high watermark. Memory freed by the eviction is
+// returned to the operating system right away, so that the next check observes it.
+func (m *Monitor) check(ctx context.Context) {
+	rss, err := m.readRSS()
+	if err != nil {
+		m.scope.Counter(metricRSSError).Inc(1)
+		m.logger.With("err", err).Error(ctx, "failed to read resident memory")
+		return
+	}
+	m.scope.Gauge(metricRSSBytes).Update(float64(rss))
+	if rss <= m.highWatermark {
+		return
+	}
+	evicted := m.evictor.EvictLeastRecentlyUsed(m.batchSize)
+	m.scope.Counter(metricEvicted).Inc(int64(evicted))
+	m.logger.With("rss", rss).With("evicted", evicted).Warn(ctx, "resident memory above high watermark")
+	if evicted > 0 {
+		debug.FreeOSMemory()
+	}
+}
+
+// readRSS returns the resident memory of the process. Outside of Linux, the memory obtained from the operating system
+// by the Go runtime is returned instead.
+func readRSS() (uint64, error) {
+	contents, err := ioutil.ReadFile(statmPath)
+	if os.IsNotExist(err) {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		return stats.Sys - stats.HeapReleased, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return parseStatm(contents, uint64(os.Getpagesize()))
+}
+
+// parseStatm returns the resident memory in bytes from the contents of /proc/self/statm, whose second field is the
+// number of resident pages.
+func parseStatm(contents []byte, pageSize uint64) (uint64, error) {
+	fields := bytes.Fields(contents)
+	if len(fields) <= statmRSSField {
+		return 0, fmt.Errorf("malformed %s: %q", statmPath, contents)
+	}
+	pages, err := strconv.ParseUint(string(fields[statmRSSField]), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return pages * pageSize, nil
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+)
+
+type mockEvictor struct {
+	keys int
+}
+
+func (m *mockEvictor) EvictLeastRecentlyUsed(n int) int {
+	if n > m.keys {
+		n = m.keys
+	}
+	m.keys -= n
+	return n
+}
+
+func TestTune(t *testing.T) {
+	previous := debug.SetGCPercent(100)
+	defer debug.SetGCPercent(previous)
+	defer func() { ballast = nil }()
+
+	Tune(&bootstrapv1.Memory{GcPercent: &wrappers.Int32Value{Value: 200}, BallastBytes: 1024})
+	assert.Equal(t, 200, debug.SetGCPercent(100))
+	assert.Equal(t, 1024, len(ballast))
+
+	// The GC percentage is left alone if unset.
+	Tune(&bootstrapv1.Memory{})
+	assert.Equal(t, 100, debug.SetGCPercent(100))
+}
+
+func TestNewMonitor(t *testing.T) {
+	scope := tally.NewTestScope("memory", make(map[string]string))
+	monitor, err := NewMonitor(&bootstrapv1.Memory{}, &mockEvictor{}, log.New("info"), scope)
+	assert.NoError(t, err)
+	assert.Nil(t, monitor)
+
+	monitor, err = NewMonitor(&bootstrapv1.Memory{HighWatermarkBytes: 1}, &mockEvictor{}, log.New("info"), scope)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultCheckInterval, monitor.interval)
+	assert.Equal(t, defaultEvictionBatchSize, monitor.batchSize)
+
+	monitor, err = NewMonitor(&bootstrapv1.Memory{
+		HighWatermarkBytes: 1,
+		CheckInterval:      ptypes.DurationProto(time.Second),
+		EvictionBatchSize:  3,
+	}, &mockEvictor{}, log.New("info"), scope)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, monitor.interval)
+	assert.Equal(t, 3, monitor.batchSize)
+}
+
+func TestCheck(t *testing.T) {
+	scope := tally.NewTestScope("memory", make(map[string]string))
+	evictor := &mockEvictor{keys: 5}
+	monitor, err := NewMonitor(&bootstrapv1.Memory{HighWatermarkBytes: 100, EvictionBatchSize: 3}, evictor,
+		log.New("info"), scope)
+	assert.NoError(t, err)
+	rss := uint64(100)
+	monitor.readRSS = func() (uint64, error) { return rss, nil }
+	ctx := context.Background()
+
+	// Nothing is evicted at the watermark.
+	monitor.check(ctx)
+	assert.Equal(t, 5, evictor.keys)
+
+	// A batch is evicted per check above the watermark.
+	rss = 101
+	monitor.check(ctx)
+	assert.Equal(t, 2, evictor.keys)
+	monitor.check(ctx)
+	monitor.check(ctx)
+	assert.Equal(t, 0, evictor.keys)
+	testutils.AssertCounterValue(t, scope.Snapshot().Counters(), "memory.watermark_evicted", 5)
+	testutils.AssertGaugeValue(t, scope.Snapshot().Gauges(), "memory.rss_bytes", 101)
+
+	monitor.readRSS = func() (uint64, error) { return 0, fmt.Errorf("unreadable") }
+	monitor.check(ctx)
+	testutils.AssertCounterValue(t, scope.Snapshot().Counters(), "memory.rss_error", 1)
+}
+
+func TestReadRSS(t *testing.T) {
+	rss, err := readRSS()
+	assert.NoError(t, err)
+	assert.True(t, rss > 0)
+}
+
+func TestParseStatm(t *testing.T) {
+	rss, err := parseStatm([]byte("1000 250 100 10 0 500 0\n"), 4096)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(250*4096), rss)
+
+	_, err = parseStatm([]byte("1000"), 4096)
+	assert.Error(t, err)
+	_, err = parseStatm([]byte("1000 abc"), 4096)
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+// Package notifier publishes events describing updates to the cached response for an aggregated key, for
+// integration with deploy-tracking and alerting systems.
+package notifier
+
+import (
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/diff"
+)
+
+// Event describes an update to the cached response for an aggregated key.
+type Event struct {
+	AggregatedKey   string    `json:"aggregated_key"`
+	TypeURL         string    `json:"type_url"`
+	PreviousVersion string    `json:"previous_version"`
+	Version         string    `json:"version"`
+	Added           []string  `json:"added"`
+	Removed         []string  `json:"removed"`
+	Modified        []string  `json:"modified"`
+	Time            time.Time `json:"time"`
+}
+
+// NewEvent constructs the event for the resource changes made to the aggregated key.
+func NewEvent(aggregatedKey string, summary diff.Summary) Event {
+	return Event{
+		AggregatedKey:   aggregatedKey,
+		TypeURL:         summary.TypeURL,
+		PreviousVersion: summary.PreviousVersion,
+		Version:         summary.Version,
+		Added:           summary.Added,
+		Removed:         summary.Removed,
+		Modified:        summary.Modified,
+		Time:            summary.Time,
+	}
+}
+
+// Notifier delivers events. Notify must not block the caller.
+type Notifier interface {
+	Notify(event Event)
+}
+
+type multiNotifier []Notifier
+
+// NewMulti returns a Notifier that delivers each event to all of the provided notifiers.
+func NewMulti(notifiers ...Notifier) Notifier {
+	return multiNotifier(notifiers)
+}
+
+func (m multiNotifier) Notify(event Event) {
+	for _, n := range m {
+		n.Notify(event)
+	}
+}
+
+// Channel is a Notifier that publishes events to a buffered channel. Events are dropped while the channel is full.
+type Channel struct {
+	events chan Event
+}
+
+// NewChannel returns a Channel notifier buffering up to size events.
+func NewChannel(size int) *Channel {
+	return &Channel{events: make(chan Event, size)}
+}
+
+// Notify publishes the event if the channel has capacity.
+func (c *Channel) Notify(event Event) {
+	select {
+	case c.events <- event:
+	default:
+	}
+}
+
+// Events returns the channel events are published to.
+func (c *Channel) Events() <-chan Event {
+	return c.events
+}
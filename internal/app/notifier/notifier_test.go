@@ -0,0 +1,109 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/diff"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+)
+
+var testEvent = NewEvent("lds", diff.Summary{
+	TypeURL:         "type.googleapis.com/envoy.api.v2.Listener",
+	PreviousVersion: "1",
+	Version:         "2",
+	Added:           []string{"listener-b"},
+	Modified:        []string{"listener-a"},
+	Time:            time.Unix(0, 0).UTC(),
+})
+
+func TestWebhook(t *testing.T) {
+	received := make(chan Event, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var event Event
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		received <- event
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scope := tally.NewTestScope("notifier", make(map[string]string))
+	w := NewWebhook(ctx, &bootstrapv1.Webhook{Url: server.URL}, log.New("info"), scope)
+	w.Notify(testEvent)
+
+	assert.Equal(t, testEvent, <-received)
+	assert.Eventually(t, func() bool {
+		_, ok := scope.Snapshot().Counters()["notifier.webhook_sent+"]
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	testutils.AssertCounterValue(t, scope.Snapshot().Counters(), "notifier.webhook_sent", 1)
+}
+
+func TestWebhookFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scope := tally.NewTestScope("notifier", make(map[string]string))
+	w := NewWebhook(ctx, &bootstrapv1.Webhook{Url: server.URL}, log.New("info"), scope)
+	w.Notify(testEvent)
+
+	assert.Eventually(t, func() bool {
+		_, ok := scope.Snapshot().Counters()["notifier.webhook_failed+"]
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	assert.NotContains(t, scope.Snapshot().Counters(), "notifier.webhook_sent+")
+}
+
+func TestWebhookQueueFull(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scope := tally.NewTestScope("notifier", make(map[string]string))
+	w := NewWebhook(ctx, &bootstrapv1.Webhook{Url: server.URL, QueueSize: 1}, log.New("info"), scope)
+
+	// The first event is held by the blocked request, the second fills the queue, and the rest are dropped.
+	w.Notify(testEvent)
+	assert.Eventually(t, func() bool {
+		return len(w.(*webhook).queue) == 0
+	}, time.Second, 10*time.Millisecond)
+	w.Notify(testEvent)
+	w.Notify(testEvent)
+	w.Notify(testEvent)
+
+	testutils.AssertCounterValue(t, scope.Snapshot().Counters(), "notifier.webhook_dropped", 2)
+}
+
+func TestMultiAndChannel(t *testing.T) {
+	a := NewChannel(1)
+	b := NewChannel(1)
+	m := NewMulti(a, b)
+	m.Notify(testEvent)
+	assert.Equal(t, testEvent, <-a.Events())
+	assert.Equal(t, testEvent, <-b.Events())
+
+	// A full channel drops events rather than blocking.
+	m.Notify(testEvent)
+	m.Notify(testEvent)
+	assert.Equal(t, 1, len(a.Events()))
+}
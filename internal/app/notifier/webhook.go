@@ -0,0 +1,105 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/uber-go/tally"
+)
+
+const (
+	defaultWebhookTimeout   = 5 * time.Second
+	defaultWebhookQueueSize = 100
+
+	metricWebhookSent    = "webhook_sent"
+	metricWebhookFailed  = "webhook_failed"
+	metricWebhookDropped = "webhook_dropped"
+)
+
+// webhook POSTs events as JSON to a URL. Events are queued and delivered in order by a single goroutine so that
+// a slow endpoint never blocks the caller.
+type webhook struct {
+	url    string
+	client *http.Client
+	queue  chan Event
+	logger log.Logger
+	scope  tally.Scope
+}
+
+// NewWebhook returns a Notifier that delivers events to the configured webhook until ctx is done.
+func NewWebhook(ctx context.Context, config *bootstrapv1.Webhook, logger log.Logger, scope tally.Scope) Notifier {
+	timeout := defaultWebhookTimeout
+	if config.GetTimeout() != nil {
+		if d, err := ptypes.Duration(config.GetTimeout()); err == nil && d > 0 {
+			timeout = d
+		}
+	}
+	queueSize := defaultWebhookQueueSize
+	if config.GetQueueSize() > 0 {
+		queueSize = int(config.GetQueueSize())
+	}
+	w := &webhook{
+		url:    config.GetUrl(),
+		client: &http.Client{Timeout: timeout},
+		queue:  make(chan Event, queueSize),
+		logger: logger.Named("webhook").With("url", config.GetUrl()),
+		scope:  scope,
+	}
+	go w.run(ctx)
+	return w
+}
+
+func (w *webhook) Notify(event Event) {
+	select {
+	case w.queue <- event:
+	default:
+		w.scope.Counter(metricWebhookDropped).Inc(1)
+		w.logger.With("key", event.AggregatedKey).With("version", event.Version).
+			Warn(context.Background(), "webhook queue full, dropping event")
+	}
+}
+
+func (w *webhook) run(ctx context.Context) {
+	for {
+		select {
+		case event := <-w.queue:
+			if err := w.post(ctx, event); err != nil {
+				w.scope.Counter(metricWebhookFailed).Inc(1)
+				w.logger.With("err", err).With("key", event.AggregatedKey).
+					Error(ctx, "failed to deliver webhook event")
+				continue
+			}
+			w.scope.Counter(metricWebhookSent).Inc(1)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *webhook) post(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,315 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file raises alerts when the configuration of an aggregated key stops
+// being updated, loses a large share of its resources in one response, or is
+// frequently rejected by downstream nodes. The contents of this file are
+// intended to only be used within the orchestrator module and should not be
+// exported.
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	defaultAlertEvaluationInterval = 30 * time.Second
+
+	// metricAlertPrefix prefixes the rule of the alerts counted when raised,
+	// e.g. alert_stale_key.
+	metricAlertPrefix  = "alert_"
+	metricAlertsActive = "alerts_active"
+)
+
+// AlertRule is the rule that raised an alert.
+type AlertRule string
+
+const (
+	// AlertStaleKey is raised for aggregated keys that were not updated
+	// within the stale duration.
+	AlertStaleKey AlertRule = "stale_key"
+	// AlertResourceDrop is raised for aggregated keys whose latest response
+	// dropped too many of the resources of the previous one.
+	AlertResourceDrop AlertRule = "resource_drop"
+	// AlertNackRate is raised for aggregated keys whose responses were
+	// rejected too often within the last evaluation interval.
+	AlertNackRate AlertRule = "nack_rate"
+	// AlertChangeRate is raised for aggregated keys whose fanout is paused
+	// because their versions changed too often.
+	AlertChangeRate AlertRule = "change_rate"
+)
+
+// Alert describes an active alert of an aggregated key.
+type Alert struct {
+	Key     string
+	Tenant  string
+	Rule    AlertRule
+	Message string
+	// Since is the time the alert was raised.
+	Since time.Time
+}
+
+type alertID struct {
+	aggregatedKey string
+	rule          AlertRule
+}
+
+// alertRules holds the thresholds of the alerting rules, the active alerts,
+// and the acknowledgements and rejections of each aggregated key since the
+// rules were last evaluated.
+type alertRules struct {
+	staleAfter             time.Duration
+	maxResourceDropPercent float64
+	maxNackPercent         float64
+	evaluationInterval     time.Duration
+
+	mu     sync.Mutex
+	active map[alertID]*Alert
+	acks   map[string]int
+	nacks  map[string]int
+}
+
+// newAlertRules returns alerting rules with the thresholds of the config.
+func newAlertRules(config *bootstrapv1.Alerting) *alertRules {
+	a := &alertRules{
+		maxResourceDropPercent: config.GetMaxResourceDropPercent(),
+		maxNackPercent:         config.GetMaxNackPercent(),
+		evaluationInterval:     defaultAlertEvaluationInterval,
+		active:                 make(map[alertID]*Alert),
+		acks:                   make(map[string]int),
+		nacks:                  make(map[string]int),
+	}
+	if config.GetStaleAfter() != nil {
+		if staleAfter, err := ptypes.Duration(config.GetStaleAfter()); err == nil {
+			a.staleAfter = staleAfter
+		}
+	}
+	if config.GetEvaluationInterval() != nil {
+		if interval, err := ptypes.Duration(config.GetEvaluationInterval()); err == nil {
+			a.evaluationInterval = interval
+		}
+	}
+	return a
+}
+
+// raise activates the alert. It returns false if the alert was already
+// active.
+func (a *alertRules) raise(alert Alert) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	id := alertID{aggregatedKey: alert.Key, rule: alert.Rule}
+	if _, ok := a.active[id]; ok {
+		return false
+	}
+	a.active[id] = &alert
+	return true
+}
+
+// clear deactivates the alert. It returns false if the alert was not active.
+func (a *alertRules) clear(aggregatedKey string, rule AlertRule) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	id := alertID{aggregatedKey: aggregatedKey, rule: rule}
+	if _, ok := a.active[id]; !ok {
+		return false
+	}
+	delete(a.active, id)
+	return true
+}
+
+// count returns the number of active alerts.
+func (a *alertRules) count() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.active)
+}
+
+// activeKeys returns the aggregated keys with an active alert of the rule.
+func (a *alertRules) activeKeys(rule AlertRule) []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var keys []string
+	for id := range a.active {
+		if id.rule == rule {
+			keys = append(keys, id.aggregatedKey)
+		}
+	}
+	return keys
+}
+
+// list returns the active alerts, ordered by aggregated key and rule.
+func (a *alertRules) list() []Alert {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	alerts := make([]Alert, 0, len(a.active))
+	for _, alert := range a.active {
+		alerts = append(alerts, *alert)
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Key != alerts[j].Key {
+			return alerts[i].Key < alerts[j].Key
+		}
+		return alerts[i].Rule < alerts[j].Rule
+	})
+	return alerts
+}
+
+// recordResponseStatus counts the acknowledgement or rejection of a response
+// of the aggregated key.
+func (a *alertRules) recordResponseStatus(aggregatedKey string, isNack bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if isNack {
+		a.nacks[aggregatedKey]++
+	} else {
+		a.acks[aggregatedKey]++
+	}
+}
+
+// takeNackPercents returns the percentage of the acknowledgements and
+// rejections of each aggregated key that were rejections, and resets the
+// counts for the next evaluation interval.
+func (a *alertRules) takeNackPercents() map[string]float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	percents := make(map[string]float64, len(a.acks)+len(a.nacks))
+	for aggregatedKey, nacks := range a.nacks {
+		percents[aggregatedKey] = 100 * float64(nacks) / float64(nacks+a.acks[aggregatedKey])
+	}
+	for aggregatedKey := range a.acks {
+		if _, ok := percents[aggregatedKey]; !ok {
+			percents[aggregatedKey] = 0
+		}
+	}
+	a.acks = make(map[string]int)
+	a.nacks = make(map[string]int)
+	return percents
+}
+
+// GetAlerts returns the active alerts, ordered by aggregated key and rule.
+func (o *orchestrator) GetAlerts() []Alert {
+	if o.alertRules == nil {
+		return nil
+	}
+	return o.alertRules.list()
+}
+
+// evaluateAlerts evaluates the stale key and NACK rate rules every
+// evaluation interval until ctx is done.
+func (o *orchestrator) evaluateAlerts(ctx context.Context) {
+	ticker := time.NewTicker(o.alertRules.evaluationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			o.evaluateAlertRules(ctx, time.Now())
+		}
+	}
+}
+
+// evaluateAlertRules raises and clears the stale key and NACK rate alerts of
+// every aggregated key as of now.
+func (o *orchestrator) evaluateAlertRules(ctx context.Context, now time.Time) {
+	if o.alertRules.staleAfter > 0 {
+		stale := make(map[string]bool)
+		workers := o.supervisor.list()
+		resources, _ := o.cache.FetchAll(workerKeys(workers))
+		for _, worker := range workers {
+			// Keys that were never updated are stale once their worker has
+			// waited for a response for as long.
+			lastUpdated := worker.StartTime
+			if resource, ok := resources[worker.Key]; ok && !resource.LastUpdated.IsZero() {
+				lastUpdated = resource.LastUpdated
+			}
+			if since := now.Sub(lastUpdated); since > o.alertRules.staleAfter {
+				stale[worker.Key] = true
+				o.raiseAlert(ctx, worker.Key, AlertStaleKey, fmt.Sprintf("not updated for %s", since.Round(time.Second)))
+			}
+		}
+		for _, aggregatedKey := range o.alertRules.activeKeys(AlertStaleKey) {
+			if !stale[aggregatedKey] {
+				o.clearAlert(ctx, aggregatedKey, AlertStaleKey)
+			}
+		}
+	}
+	if o.alertRules.maxNackPercent > 0 {
+		percents := o.alertRules.takeNackPercents()
+		for aggregatedKey, percent := range percents {
+			if percent > o.alertRules.maxNackPercent {
+				o.raiseAlert(ctx, aggregatedKey, AlertNackRate,
+					fmt.Sprintf("%.1f%% of responses rejected within %s", percent, o.alertRules.evaluationInterval))
+			}
+		}
+		for _, aggregatedKey := range o.alertRules.activeKeys(AlertNackRate) {
+			if percents[aggregatedKey] <= o.alertRules.maxNackPercent {
+				o.clearAlert(ctx, aggregatedKey, AlertNackRate)
+			}
+		}
+	}
+}
+
+// checkResourceDrop raises a resource drop alert if the response has too
+// many fewer resources than the previous response of the aggregated key, and
+// clears it otherwise.
+func (o *orchestrator) checkResourceDrop(
+	ctx context.Context,
+	aggregatedKey string,
+	previous *discovery.DiscoveryResponse,
+	resp *discovery.DiscoveryResponse,
+) {
+	if o.alertRules.maxResourceDropPercent <= 0 {
+		return
+	}
+	before, after := len(previous.GetResources()), len(resp.GetResources())
+	if before > 0 && after < before {
+		percent := 100 * float64(before-after) / float64(before)
+		if percent > o.alertRules.maxResourceDropPercent {
+			o.raiseAlert(ctx, aggregatedKey, AlertResourceDrop, fmt.Sprintf(
+				"version %s dropped %d of %d resources of version %s",
+				resp.GetVersionInfo(), before-after, before, previous.GetVersionInfo()))
+			return
+		}
+	}
+	o.clearAlert(ctx, aggregatedKey, AlertResourceDrop)
+}
+
+// raiseAlert activates the alert of the rule for the aggregated key, and
+// logs and counts it unless it is already active.
+func (o *orchestrator) raiseAlert(ctx context.Context, aggregatedKey string, rule AlertRule, message string) {
+	if !o.alertRules.raise(Alert{
+		Key:     aggregatedKey,
+		Tenant:  o.getTenant(aggregatedKey),
+		Rule:    rule,
+		Message: message,
+		Since:   time.Now(),
+	}) {
+		return
+	}
+	o.keyScope(aggregatedKey).Counter(metricAlertPrefix + string(rule)).Inc(1)
+	o.scope.Gauge(metricAlertsActive).Update(float64(o.alertRules.count()))
+	o.logger.With("key", aggregatedKey).With("rule", rule).With("alert", message).Warn(ctx, "alert raised")
+}
+
+// clearAlert deactivates the alert of the rule for the aggregated key, if it
+// is active.
+func (o *orchestrator) clearAlert(ctx context.Context, aggregatedKey string, rule AlertRule) {
+	if !o.alertRules.clear(aggregatedKey, rule) {
+		return
+	}
+	o.scope.Gauge(metricAlertsActive).Update(float64(o.alertRules.count()))
+	o.logger.With("key", aggregatedKey).With("rule", rule).Info(ctx, "alert cleared")
+}
@@ -0,0 +1,181 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewAlertRules(t *testing.T) {
+	rules := newAlertRules(&bootstrapv1.Alerting{})
+	assert.Equal(t, time.Duration(0), rules.staleAfter)
+	assert.Equal(t, defaultAlertEvaluationInterval, rules.evaluationInterval)
+
+	rules = newAlertRules(&bootstrapv1.Alerting{
+		StaleAfter:             ptypes.DurationProto(time.Hour),
+		MaxResourceDropPercent: 50,
+		MaxNackPercent:         10,
+		EvaluationInterval:     ptypes.DurationProto(time.Minute),
+	})
+	assert.Equal(t, time.Hour, rules.staleAfter)
+	assert.Equal(t, float64(50), rules.maxResourceDropPercent)
+	assert.Equal(t, float64(10), rules.maxNackPercent)
+	assert.Equal(t, time.Minute, rules.evaluationInterval)
+}
+
+func TestAlertResourceDrop(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	orchestrator.alertRules = newAlertRules(&bootstrapv1.Alerting{MaxResourceDropPercent: 50})
+	newResponse := func(version string, count int) *v2.DiscoveryResponse {
+		resources := make([]*any.Any, count)
+		for i := range resources {
+			resources[i] = &any.Any{Value: []byte{byte(i)}}
+		}
+		return &v2.DiscoveryResponse{
+			VersionInfo: version,
+			TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+			Resources:   resources,
+		}
+	}
+
+	respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	upstreamResponseChannel <- newResponse("1", 4)
+	<-respChannel
+	cancelWatch()
+	assert.Empty(t, orchestrator.GetAlerts())
+
+	// Dropping half of the resources is within the threshold.
+	respChannel, cancelWatch = orchestrator.CreateWatch(gcp.Request{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	upstreamResponseChannel <- newResponse("2", 2)
+	<-respChannel
+	cancelWatch()
+	assert.Empty(t, orchestrator.GetAlerts())
+
+	respChannel, cancelWatch = orchestrator.CreateWatch(gcp.Request{
+		VersionInfo: "2",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	upstreamResponseChannel <- newResponse("3", 0)
+	<-respChannel
+	cancelWatch()
+	alerts := orchestrator.GetAlerts()
+	assert.Equal(t, 1, len(alerts))
+	assert.Equal(t, "lds", alerts[0].Key)
+	assert.Equal(t, AlertResourceDrop, alerts[0].Rule)
+	assert.Equal(t, "version 3 dropped 2 of 2 resources of version 2", alerts[0].Message)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.alert_resource_drop", 1)
+
+	respChannel, cancelWatch = orchestrator.CreateWatch(gcp.Request{
+		VersionInfo: "3",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	defer cancelWatch()
+	upstreamResponseChannel <- newResponse("4", 1)
+	<-respChannel
+	assert.Empty(t, orchestrator.GetAlerts())
+}
+
+func TestAlertStaleKey(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	orchestrator.alertRules = newAlertRules(&bootstrapv1.Alerting{StaleAfter: ptypes.DurationProto(time.Hour)})
+	ctx := context.Background()
+
+	respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	defer cancelWatch()
+
+	// Keys that never received a response are stale once their worker has
+	// waited for as long.
+	orchestrator.evaluateAlertRules(ctx, time.Now())
+	assert.Empty(t, orchestrator.GetAlerts())
+	orchestrator.evaluateAlertRules(ctx, time.Now().Add(2*time.Hour))
+	alerts := orchestrator.GetAlerts()
+	assert.Equal(t, 1, len(alerts))
+	assert.Equal(t, AlertStaleKey, alerts[0].Rule)
+
+	upstreamResponseChannel <- &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	<-respChannel
+	orchestrator.evaluateAlertRules(ctx, time.Now())
+	assert.Empty(t, orchestrator.GetAlerts())
+
+	orchestrator.evaluateAlertRules(ctx, time.Now().Add(2*time.Hour))
+	orchestrator.evaluateAlertRules(ctx, time.Now().Add(3*time.Hour))
+	assert.Equal(t, 1, len(orchestrator.GetAlerts()))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.alert_stale_key", 2)
+	assert.Equal(t, float64(1), mockScope.Snapshot().Gauges()["prefix.alerts_active+"].Value())
+}
+
+func TestAlertNackRate(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)})
+	orchestrator.alertRules = newAlertRules(&bootstrapv1.Alerting{MaxNackPercent: 25})
+	ctx := context.Background()
+
+	orchestrator.alertRules.recordResponseStatus("lds", false)
+	orchestrator.alertRules.recordResponseStatus("lds", false)
+	orchestrator.alertRules.recordResponseStatus("lds", true)
+	orchestrator.alertRules.recordResponseStatus("cds", false)
+	orchestrator.alertRules.recordResponseStatus("cds", false)
+	orchestrator.alertRules.recordResponseStatus("cds", false)
+	orchestrator.alertRules.recordResponseStatus("cds", true)
+	orchestrator.evaluateAlertRules(ctx, time.Now())
+	alerts := orchestrator.GetAlerts()
+	assert.Equal(t, 1, len(alerts))
+	assert.Equal(t, "lds", alerts[0].Key)
+	assert.Equal(t, AlertNackRate, alerts[0].Rule)
+
+	// The counts are reset every evaluation interval.
+	orchestrator.alertRules.recordResponseStatus("lds", false)
+	orchestrator.evaluateAlertRules(ctx, time.Now())
+	assert.Empty(t, orchestrator.GetAlerts())
+}
+
+func TestAlertNackRateUnknownNonce(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)})
+	orchestrator.alertRules = newAlertRules(&bootstrapv1.Alerting{MaxNackPercent: 25})
+	assert.NoError(t, orchestrator.OnStreamOpen(context.Background(), 1, ""))
+
+	// The rejected response was not sent on the stream of the client, but the
+	// rejection still counts.
+	_, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl:       upstream.ListenerTypeURL,
+		Node:          &v2_core.Node{Id: "node"},
+		VersionInfo:   "1",
+		ResponseNonce: "unknown",
+		ErrorDetail:   status.New(codes.InvalidArgument, "invalid listener").Proto(),
+	})
+	defer cancelWatch()
+	orchestrator.evaluateAlertRules(context.Background(), time.Now())
+	alerts := orchestrator.GetAlerts()
+	assert.Equal(t, 1, len(alerts))
+	assert.Equal(t, AlertNackRate, alerts[0].Rule)
+}
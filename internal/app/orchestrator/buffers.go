@@ -0,0 +1,212 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file sizes the response channels of downstream watches and upstream
+// streams, and applies their overflow policies. The contents of this file are
+// intended to only be used within the orchestrator module and should not be
+// exported.
+package orchestrator
+
+import (
+	"sync"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/uber-go/tally"
+)
+
+const (
+	metricBufferOccupancy = "buffer_occupancy"
+	metricBufferDropped   = "buffer_dropped"
+	metricBufferCoalesced = "buffer_coalesced"
+	metricBufferBlocked   = "buffer_blocked"
+
+	metricSubscopeUpstream = "upstream"
+
+	// defaultDownstreamBufferSize is the number of responses the channel of a
+	// downstream watch holds by default.
+	defaultDownstreamBufferSize = 1
+)
+
+// bufferConfig is the size and overflow policy of a response channel.
+type bufferConfig struct {
+	size   int
+	policy bootstrapv1.Buffers_OverflowPolicy
+}
+
+// responseBuffers holds the buffers of the response channels by direction
+// and type URL.
+type responseBuffers struct {
+	downstream bufferConfig
+	upstream   bufferConfig
+	// downstreamTypes and upstreamTypes are keyed by type URL.
+	downstreamTypes map[string]bufferConfig
+	upstreamTypes   map[string]bufferConfig
+}
+
+func newResponseBuffers(config *bootstrapv1.Buffers) *responseBuffers {
+	b := &responseBuffers{
+		downstream:      newDownstreamBufferConfig(config.GetDownstream()),
+		upstream:        newUpstreamBufferConfig(config.GetUpstream()),
+		downstreamTypes: make(map[string]bufferConfig),
+		upstreamTypes:   make(map[string]bufferConfig),
+	}
+	for _, typeBuffers := range config.GetTypeUrls() {
+		if typeBuffers.GetDownstream() != nil {
+			b.downstreamTypes[typeBuffers.GetTypeUrl()] = newDownstreamBufferConfig(typeBuffers.GetDownstream())
+		}
+		if typeBuffers.GetUpstream() != nil {
+			b.upstreamTypes[typeBuffers.GetTypeUrl()] = newUpstreamBufferConfig(typeBuffers.GetUpstream())
+		}
+	}
+	return b
+}
+
+func newDownstreamBufferConfig(config *bootstrapv1.Buffers_Buffer) bufferConfig {
+	size := int(config.GetSize())
+	if size < defaultDownstreamBufferSize {
+		// A new watch is sent the cached response before its channel is
+		// read, so the channel holds at least one response.
+		size = defaultDownstreamBufferSize
+	}
+	return bufferConfig{size: size, policy: config.GetOverflowPolicy()}
+}
+
+func newUpstreamBufferConfig(config *bootstrapv1.Buffers_Buffer) bufferConfig {
+	return bufferConfig{size: int(config.GetSize()), policy: config.GetOverflowPolicy()}
+}
+
+// downstreamFor returns the buffer of the downstream watches of the type URL.
+func (b *responseBuffers) downstreamFor(typeURL string) bufferConfig {
+	if b == nil {
+		return bufferConfig{size: defaultDownstreamBufferSize}
+	}
+	if config, ok := b.downstreamTypes[typeURL]; ok {
+		return config
+	}
+	return b.downstream
+}
+
+// upstreamFor returns the buffer of the upstream streams of the type URL.
+func (b *responseBuffers) upstreamFor(typeURL string) bufferConfig {
+	if b == nil {
+		return bufferConfig{}
+	}
+	if config, ok := b.upstreamTypes[typeURL]; ok {
+		return config
+	}
+	return b.upstream
+}
+
+// blocks returns true if the buffer waits for room when it is full.
+func (c bufferConfig) blocks() bool {
+	return c.policy == bootstrapv1.Buffers_DEFAULT || c.policy == bootstrapv1.Buffers_BLOCK
+}
+
+// bufferUpstream returns a channel that buffers the responses of the upstream
+// stream of the aggregated key according to the buffer of its type URL, and
+// the shutdown function that stops the buffer along with the stream. The
+// stream is passed through as is if it is unbuffered.
+func (o *orchestrator) bufferUpstream(
+	aggregatedKey string,
+	typeURL string,
+	responseChannel <-chan *discovery.DiscoveryResponse,
+	shutdown func(),
+) (<-chan *discovery.DiscoveryResponse, func()) {
+	config := o.buffers.upstreamFor(typeURL)
+	if config.size == 0 && config.blocks() {
+		return responseChannel, shutdown
+	}
+	if config.size == 0 {
+		// Responses are dropped or coalesced as they are received, so the
+		// channel holds at least one.
+		config.size = 1
+	}
+	buffered := make(chan *discovery.DiscoveryResponse)
+	stop := make(chan struct{})
+	var once sync.Once
+	scope := o.keyScope(aggregatedKey).SubScope(metricSubscopeUpstream)
+	o.goroutines.goroutine(subsystemUpstreamReceiver, func() {
+		runUpstreamBuffer(config, scope, responseChannel, buffered, stop)
+	})
+	return buffered, func() {
+		once.Do(func() { close(stop) })
+		shutdown()
+	}
+}
+
+// runUpstreamBuffer receives the responses of the upstream stream into a queue
+// of the size of the buffer, and passes them on to the buffered channel in
+// order. The buffered channel is closed once the queue is drained after the
+// stream is closed, or once stop is closed.
+func runUpstreamBuffer(
+	config bufferConfig,
+	scope tally.Scope,
+	responseChannel <-chan *discovery.DiscoveryResponse,
+	buffered chan<- *discovery.DiscoveryResponse,
+	stop <-chan struct{},
+) {
+	defer close(buffered)
+	var queue []*discovery.DiscoveryResponse
+	blocked := false
+	for {
+		if responseChannel == nil && len(queue) == 0 {
+			return
+		}
+		receive := responseChannel
+		if len(queue) >= config.size && config.blocks() {
+			if !blocked {
+				scope.Counter(metricBufferBlocked).Inc(1)
+			}
+			receive = nil
+		}
+		blocked = receive == nil
+		var send chan<- *discovery.DiscoveryResponse
+		var next *discovery.DiscoveryResponse
+		if len(queue) > 0 {
+			send, next = buffered, queue[0]
+		}
+		select {
+		case resp, ok := <-receive:
+			if !ok {
+				responseChannel = nil
+				continue
+			}
+			queue = overflow(config, scope, queue)
+			queue = append(queue, resp)
+		case send <- next:
+			queue[0] = nil
+			queue = queue[1:]
+		case <-stop:
+			return
+		}
+		scope.Gauge(metricBufferOccupancy).Update(float64(len(queue)))
+	}
+}
+
+// overflow makes room for a response in the full queue of the buffer, as the
+// overflow policy of the buffer requires. It returns the queue as is if it is
+// not full.
+func overflow(
+	config bufferConfig,
+	scope tally.Scope,
+	queue []*discovery.DiscoveryResponse,
+) []*discovery.DiscoveryResponse {
+	if len(queue) < config.size {
+		return queue
+	}
+	switch config.policy {
+	case bootstrapv1.Buffers_DROP_OLDEST:
+		scope.Counter(metricBufferDropped).Inc(1)
+		return queue[1:]
+	case bootstrapv1.Buffers_COALESCE_LATEST:
+		scope.Counter(metricBufferCoalesced).Inc(int64(len(queue)))
+		return queue[:0]
+	default:
+		return queue
+	}
+}
@@ -0,0 +1,192 @@
+package orchestrator
+
+import (
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+)
+
+func newBufferedResponse(version string) gcp.Response {
+	return gcp.PassthroughResponse{DiscoveryResponse: &v2.DiscoveryResponse{VersionInfo: version}}
+}
+
+func receiveVersion(t *testing.T, channel chan gcp.Response) string {
+	resp, err := (<-channel).GetDiscoveryResponse()
+	assert.NoError(t, err)
+	return resp.GetVersionInfo()
+}
+
+func waitForBlockedSends(t *testing.T, mockScope tally.TestScope, count int64) {
+	assert.Eventually(t, func() bool {
+		counter, ok := mockScope.Snapshot().Counters()["prefix."+metricBufferBlocked+"+"]
+		return ok && counter.Value() == count
+	}, time.Second, time.Millisecond)
+}
+
+func TestResponseBuffers(t *testing.T) {
+	var b *responseBuffers
+	assert.Equal(t, bufferConfig{size: 1}, b.downstreamFor(upstream.ListenerTypeURL))
+	assert.Equal(t, bufferConfig{}, b.upstreamFor(upstream.ListenerTypeURL))
+
+	b = newResponseBuffers(&bootstrapv1.Buffers{
+		Downstream: &bootstrapv1.Buffers_Buffer{Size: 0, OverflowPolicy: bootstrapv1.Buffers_DROP_OLDEST},
+		Upstream:   &bootstrapv1.Buffers_Buffer{Size: 4},
+		TypeUrls: []*bootstrapv1.Buffers_TypeURLBuffers{
+			{
+				TypeUrl:  upstream.EndpointTypeURL,
+				Upstream: &bootstrapv1.Buffers_Buffer{Size: 2, OverflowPolicy: bootstrapv1.Buffers_COALESCE_LATEST},
+			},
+		},
+	})
+	// Downstream channels hold at least one response.
+	assert.Equal(t, bufferConfig{size: 1, policy: bootstrapv1.Buffers_DROP_OLDEST},
+		b.downstreamFor(upstream.EndpointTypeURL))
+	assert.Equal(t, bufferConfig{size: 4}, b.upstreamFor(upstream.ListenerTypeURL))
+	assert.Equal(t, bufferConfig{size: 2, policy: bootstrapv1.Buffers_COALESCE_LATEST},
+		b.upstreamFor(upstream.EndpointTypeURL))
+}
+
+func TestDownstreamBufferOverflow(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	d := newDownstreamResponseMap(mockScope)
+	d.buffers = newResponseBuffers(&bootstrapv1.Buffers{
+		Downstream: &bootstrapv1.Buffers_Buffer{Size: 2, OverflowPolicy: bootstrapv1.Buffers_DROP_OLDEST},
+		TypeUrls: []*bootstrapv1.Buffers_TypeURLBuffers{
+			{
+				TypeUrl:    upstream.ClusterTypeURL,
+				Downstream: &bootstrapv1.Buffers_Buffer{Size: 2, OverflowPolicy: bootstrapv1.Buffers_COALESCE_LATEST},
+			},
+			{
+				TypeUrl:    upstream.RouteTypeURL,
+				Downstream: &bootstrapv1.Buffers_Buffer{},
+			},
+		},
+	})
+
+	id, channel := d.createChannel("lds", &gcp.Request{TypeUrl: upstream.ListenerTypeURL}, 0, "")
+	for _, version := range []string{"1", "2", "3"} {
+		sent, found := d.send(id, newBufferedResponse(version))
+		assert.True(t, sent)
+		assert.True(t, found)
+	}
+	assert.Equal(t, "2", receiveVersion(t, channel))
+	assert.Equal(t, "3", receiveVersion(t, channel))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.buffer_dropped", 1)
+
+	id, channel = d.createChannel("cds", &gcp.Request{TypeUrl: upstream.ClusterTypeURL}, 0, "")
+	for _, version := range []string{"1", "2", "3"} {
+		sent, _ := d.send(id, newBufferedResponse(version))
+		assert.True(t, sent)
+	}
+	assert.Equal(t, 1, len(channel))
+	assert.Equal(t, "3", receiveVersion(t, channel))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.buffer_coalesced", 2)
+
+	// The default overflow policy drops the new response.
+	id, channel = d.createChannel("rds", &gcp.Request{TypeUrl: upstream.RouteTypeURL}, 0, "")
+	sent, _ := d.send(id, newBufferedResponse("1"))
+	assert.True(t, sent)
+	sent, found := d.send(id, newBufferedResponse("2"))
+	assert.False(t, sent)
+	assert.True(t, found)
+	assert.Equal(t, "1", receiveVersion(t, channel))
+}
+
+func TestDownstreamBufferBlock(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	d := newDownstreamResponseMap(mockScope)
+	d.buffers = newResponseBuffers(&bootstrapv1.Buffers{
+		Downstream: &bootstrapv1.Buffers_Buffer{Size: 1, OverflowPolicy: bootstrapv1.Buffers_BLOCK},
+	})
+	req := &gcp.Request{TypeUrl: upstream.ListenerTypeURL}
+
+	id, channel := d.createChannel("lds", req, 0, "")
+	sent, _ := d.send(id, newBufferedResponse("1"))
+	assert.True(t, sent)
+	blocked := make(chan bool)
+	go func() {
+		sent, _ := d.send(id, newBufferedResponse("2"))
+		blocked <- sent
+	}()
+	waitForBlockedSends(t, mockScope, 1)
+	// The blocked send completes once there is room.
+	assert.Equal(t, "1", receiveVersion(t, channel))
+	assert.True(t, <-blocked)
+	assert.Equal(t, "2", receiveVersion(t, channel))
+
+	// Terminating the watch releases the blocked send.
+	id, channel = d.createChannel("lds", req, 0, "")
+	sent, _ = d.send(id, newBufferedResponse("1"))
+	assert.True(t, sent)
+	go func() {
+		sent, _ := d.send(id, newBufferedResponse("2"))
+		blocked <- sent
+	}()
+	waitForBlockedSends(t, mockScope, 2)
+	assert.Equal(t, 1, d.terminate(map[cache.WatchID]*gcp.Request{id: req}, nil))
+	assert.False(t, <-blocked)
+	assert.Equal(t, "1", receiveVersion(t, channel))
+	_, ok := <-channel
+	assert.False(t, ok)
+}
+
+func TestUpstreamBuffer(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		policy   bootstrapv1.Buffers_OverflowPolicy
+		expected []string
+		counter  string
+		count    int
+	}{
+		{name: "drop oldest", policy: bootstrapv1.Buffers_DROP_OLDEST, expected: []string{"2", "3"},
+			counter: metricBufferDropped, count: 1},
+		{name: "coalesce latest", policy: bootstrapv1.Buffers_COALESCE_LATEST, expected: []string{"3"},
+			counter: metricBufferCoalesced, count: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockScope := newMockScope("prefix")
+			responseChannel := make(chan *v2.DiscoveryResponse)
+			buffered := make(chan *v2.DiscoveryResponse)
+			go runUpstreamBuffer(bufferConfig{size: 2, policy: tc.policy}, mockScope, responseChannel, buffered,
+				make(chan struct{}))
+			for _, version := range []string{"1", "2", "3"} {
+				responseChannel <- &v2.DiscoveryResponse{VersionInfo: version}
+			}
+			close(responseChannel)
+			var versions []string
+			for resp := range buffered {
+				versions = append(versions, resp.GetVersionInfo())
+			}
+			assert.Equal(t, tc.expected, versions)
+			testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix."+tc.counter, tc.count)
+		})
+	}
+
+	t.Run("block", func(t *testing.T) {
+		mockScope := newMockScope("prefix")
+		responseChannel := make(chan *v2.DiscoveryResponse)
+		buffered := make(chan *v2.DiscoveryResponse)
+		stop := make(chan struct{})
+		go runUpstreamBuffer(bufferConfig{size: 1}, mockScope, responseChannel, buffered, stop)
+		responseChannel <- &v2.DiscoveryResponse{VersionInfo: "1"}
+		select {
+		case responseChannel <- &v2.DiscoveryResponse{VersionInfo: "2"}:
+			t.Fatal("the full buffer received a response")
+		case <-time.After(50 * time.Millisecond):
+		}
+		testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix."+metricBufferBlocked, 1)
+		assert.Equal(t, "1", (<-buffered).GetVersionInfo())
+		// Stopping the buffer closes the buffered channel.
+		close(stop)
+		_, ok := <-buffered
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,106 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file chains relays, where the origin server of a relay is another
+// relay. The hops of a request are the identities of the relays it passed
+// through, which each relay appends to the node of the requests it sends
+// upstream. The contents of this file are intended to only be used within the
+// orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/proto"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+const (
+	// HopsMetadataKey is the key of the list of hops in the node metadata.
+	HopsMetadataKey = "xds_relay_hops"
+
+	defaultMaxHops = 8
+
+	metricRelayLoop    = "relay_loop"
+	metricRelayMaxHops = "relay_max_hops"
+)
+
+// relayChain appends the identity of this relay to the hops of upstream
+// requests, and rejects the downstream requests that loop or passed through
+// too many relays.
+type relayChain struct {
+	identity string
+	maxHops  int
+}
+
+func newRelayChain(config *bootstrapv1.RelayChain) *relayChain {
+	c := &relayChain{identity: config.GetIdentity(), maxHops: defaultMaxHops}
+	if c.identity == "" {
+		c.identity, _ = os.Hostname()
+	}
+	if config.GetMaxHops() != nil {
+		c.maxHops = int(config.GetMaxHops().GetValue())
+	}
+	return c
+}
+
+// hops returns the identities of the relays that the request of the node
+// passed through, in order.
+func hops(node *core.Node) []string {
+	value, ok := node.GetMetadata().GetFields()[HopsMetadataKey]
+	if !ok {
+		return nil
+	}
+	var identities []string
+	for _, hop := range value.GetListValue().GetValues() {
+		identities = append(identities, hop.GetStringValue())
+	}
+	return identities
+}
+
+// check returns an error if the request of the node already passed through
+// this relay, or through the maximum number of relays.
+func (c *relayChain) check(node *core.Node) (string, error) {
+	identities := hops(node)
+	for _, identity := range identities {
+		if identity == c.identity {
+			return metricRelayLoop, fmt.Errorf("%w: hops %v", ErrRelayLoop, identities)
+		}
+	}
+	if len(identities) > c.maxHops {
+		return metricRelayMaxHops, fmt.Errorf("%w: %d hops, at most %d allowed", ErrTooManyHops, len(identities),
+			c.maxHops)
+	}
+	return "", nil
+}
+
+// forward returns a copy of the request whose node has the identity of this
+// relay appended to its hops. The node of the request is left untouched, as
+// it is shared with the downstream stream of the request.
+func (c *relayChain) forward(req gcp.Request) gcp.Request {
+	node := &core.Node{}
+	if req.GetNode() != nil {
+		node = proto.Clone(req.GetNode()).(*core.Node)
+	}
+	if node.Metadata == nil {
+		node.Metadata = &structpb.Struct{}
+	}
+	if node.Metadata.Fields == nil {
+		node.Metadata.Fields = make(map[string]*structpb.Value)
+	}
+	values := node.Metadata.Fields[HopsMetadataKey].GetListValue().GetValues()
+	values = append(values, &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: c.identity}})
+	node.Metadata.Fields[HopsMetadataKey] = &structpb.Value{
+		Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: values}},
+	}
+	req.Node = node
+	return req
+}
@@ -0,0 +1,79 @@
+package orchestrator
+
+import (
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockRecordingUpstreamClient hands the request of every stream that it
+// opens to the test.
+type mockRecordingUpstreamClient struct {
+	requests chan v2.DiscoveryRequest
+}
+
+func (m mockRecordingUpstreamClient) OpenStream(req v2.DiscoveryRequest) (<-chan *v2.DiscoveryResponse, func(), error) {
+	m.requests <- req
+	return make(chan *v2.DiscoveryResponse), func() {}, nil
+}
+
+func newHopsRequest(hops ...string) gcp.Request {
+	values := make([]*structpb.Value, 0, len(hops))
+	for _, hop := range hops {
+		values = append(values, &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: hop}})
+	}
+	return gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+		Node: &core.Node{
+			Id: "node",
+			Metadata: &structpb.Struct{Fields: map[string]*structpb.Value{
+				HopsMetadataKey: {Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: values}}},
+			}},
+		},
+	}
+}
+
+func TestRelayChainForward(t *testing.T) {
+	upstreamClient := mockRecordingUpstreamClient{requests: make(chan v2.DiscoveryRequest, 1)}
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), upstreamClient)
+	WithRelayChain(&bootstrapv1.RelayChain{Identity: "regional"})(orchestrator)
+
+	req := newHopsRequest("edge")
+	_, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	upstreamReq := <-upstreamClient.requests
+	assert.Equal(t, []string{"edge", "regional"}, hops(upstreamReq.GetNode()))
+	assert.Equal(t, "node", upstreamReq.GetNode().GetId())
+	// The node of the downstream request is left untouched.
+	assert.Equal(t, []string{"edge"}, hops(req.GetNode()))
+}
+
+func TestRelayChainForwardWithoutHops(t *testing.T) {
+	relayChain := newRelayChain(&bootstrapv1.RelayChain{Identity: "edge"})
+	assert.Equal(t, []string{"edge"}, hops(relayChain.forward(gcp.Request{}).Node))
+	assert.Equal(t, []string{"edge"}, hops(relayChain.forward(gcp.Request{Node: &core.Node{Id: "node"}}).Node))
+}
+
+func TestRelayChainRejects(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockUnusedUpstreamClient{t: t})
+	WithRelayChain(&bootstrapv1.RelayChain{Identity: "regional", MaxHops: &wrappers.UInt32Value{Value: 2}})(orchestrator)
+
+	respChannel, _ := orchestrator.CreateWatch(newHopsRequest("edge", "regional", "edge"))
+	_, more := <-respChannel
+	assert.False(t, more)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.relay_loop", 1)
+
+	respChannel, _ = orchestrator.CreateWatch(newHopsRequest("a", "b", "c"))
+	_, more = <-respChannel
+	assert.False(t, more)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.relay_max_hops", 1)
+}
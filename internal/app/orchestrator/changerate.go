@@ -0,0 +1,221 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file guards downstream nodes against aggregated keys whose upstream
+// versions change too often, by collapsing their versions or pausing their
+// fanout. The contents of this file are intended to only be used within the
+// orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	metricChangeRateCollapsed = "change_rate_collapsed"
+	metricChangeRatePaused    = "change_rate_paused"
+)
+
+// changeRateDecision is what the guard decides for a new version.
+type changeRateDecision int
+
+const (
+	// changeRateFanout fans out the version.
+	changeRateFanout changeRateDecision = iota
+	// changeRateCollapse withholds the version until the end of the interval,
+	// when the latest version is fanned out.
+	changeRateCollapse
+	// changeRatePause withholds the version until the key is resumed.
+	changeRatePause
+)
+
+// keyChanges holds the times of the recent versions of an aggregated key.
+type keyChanges struct {
+	times []time.Time
+	// timer fans out the latest version at the end of the interval, while
+	// versions are collapsed.
+	timer  *time.Timer
+	paused bool
+}
+
+// changeRateGuard counts the new versions of every aggregated key within the
+// interval.
+type changeRateGuard struct {
+	maxVersions int
+	interval    time.Duration
+	pause       bool
+
+	mu sync.Mutex
+	// keys is keyed by aggregated key.
+	keys map[string]*keyChanges
+}
+
+func newChangeRateGuard(config *bootstrapv1.ChangeRateGuard) *changeRateGuard {
+	g := &changeRateGuard{
+		maxVersions: int(config.GetMaxVersions()),
+		pause:       config.GetPause(),
+		keys:        make(map[string]*keyChanges),
+	}
+	if config.GetInterval() != nil {
+		if interval, err := ptypes.Duration(config.GetInterval()); err == nil {
+			g.interval = interval
+		}
+	}
+	return g
+}
+
+// record counts the version of the aggregated key received at the time if it
+// changed, and decides whether to fan it out. release is called at the end of
+// the interval if the versions of the key are collapsed.
+func (g *changeRateGuard) record(
+	aggregatedKey string,
+	changed bool,
+	now time.Time,
+	release func(),
+) changeRateDecision {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	changes, ok := g.keys[aggregatedKey]
+	if !ok {
+		changes = &keyChanges{}
+		g.keys[aggregatedKey] = changes
+	}
+	recent := changes.times[:0]
+	for _, t := range changes.times {
+		if now.Sub(t) < g.interval {
+			recent = append(recent, t)
+		}
+	}
+	changes.times = recent
+	if changed {
+		changes.times = append(changes.times, now)
+	}
+	switch {
+	case changes.paused:
+		return changeRatePause
+	case changes.timer != nil:
+		return changeRateCollapse
+	case len(changes.times) <= g.maxVersions:
+		return changeRateFanout
+	case g.pause:
+		changes.paused = true
+		return changeRatePause
+	default:
+		changes.timer = time.AfterFunc(g.interval, release)
+		return changeRateCollapse
+	}
+}
+
+// released ends the interval over which the versions of the aggregated key
+// were collapsed.
+func (g *changeRateGuard) released(aggregatedKey string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if changes, ok := g.keys[aggregatedKey]; ok {
+		changes.timer = nil
+	}
+}
+
+// resume forgets the versions of the aggregated key. It returns false if the
+// key is not paused.
+func (g *changeRateGuard) resume(aggregatedKey string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	changes, ok := g.keys[aggregatedKey]
+	if !ok || !changes.paused {
+		return false
+	}
+	delete(g.keys, aggregatedKey)
+	return true
+}
+
+func (g *changeRateGuard) remove(aggregatedKey string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if changes, ok := g.keys[aggregatedKey]; ok && changes.timer != nil {
+		changes.timer.Stop()
+	}
+	delete(g.keys, aggregatedKey)
+}
+
+// isChangeRateWithheld returns true if the fanout of the response of the
+// aggregated key is withheld because the key changes too often.
+func (o *orchestrator) isChangeRateWithheld(
+	ctx context.Context,
+	aggregatedKey string,
+	previous string,
+	version string,
+) bool {
+	if o.changeRateGuard == nil {
+		return false
+	}
+	release := func() { o.releaseChangeRate(aggregatedKey) }
+	switch o.changeRateGuard.record(aggregatedKey, previous != version, time.Now(), release) {
+	case changeRateCollapse:
+		o.keyScope(aggregatedKey).Counter(metricChangeRateCollapsed).Inc(1)
+		o.logger.With("key", aggregatedKey).With("version", version).
+			Info(ctx, "key changes too often, collapsing versions")
+		return true
+	case changeRatePause:
+		o.keyScope(aggregatedKey).Counter(metricChangeRatePaused).Inc(1)
+		o.logger.With("key", aggregatedKey).With("version", version).
+			Warn(ctx, "key changes too often, fanout paused")
+		if o.alertRules != nil {
+			o.raiseAlert(ctx, aggregatedKey, AlertChangeRate, fmt.Sprintf(
+				"more than %d versions within %s, fanout paused", o.changeRateGuard.maxVersions,
+				o.changeRateGuard.interval))
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// releaseChangeRate fans out the latest version of the aggregated key at the
+// end of the interval over which its versions were collapsed.
+func (o *orchestrator) releaseChangeRate(aggregatedKey string) {
+	o.changeRateGuard.released(aggregatedKey)
+	o.fanoutLatest(context.Background(), aggregatedKey)
+}
+
+// ResumeFanout fans out the latest version of the aggregated key whose fanout
+// was paused because it changed too often. It returns false if the fanout of
+// the aggregated key is not paused.
+func (o *orchestrator) ResumeFanout(aggregatedKey string) bool {
+	if o.changeRateGuard == nil || !o.changeRateGuard.resume(aggregatedKey) {
+		return false
+	}
+	ctx := context.Background()
+	if o.alertRules != nil {
+		o.clearAlert(ctx, aggregatedKey, AlertChangeRate)
+	}
+	o.logger.With("key", aggregatedKey).Info(ctx, "fanout resumed")
+	o.fanoutLatest(ctx, aggregatedKey)
+	return true
+}
+
+// fanoutLatest fans out the cached response of the aggregated key, unless it
+// is pinned or a maintenance window is in progress.
+func (o *orchestrator) fanoutLatest(ctx context.Context, aggregatedKey string) {
+	if _, ok := o.pinnedResponse(aggregatedKey); ok {
+		return
+	}
+	cached, err := o.cache.Fetch(aggregatedKey)
+	if err != nil || cached == nil || cached.Resp == nil {
+		return
+	}
+	if o.isMaintenanceWithheld(ctx, aggregatedKey) {
+		return
+	}
+	o.fanoutServed(aggregatedKey, cached)
+}
@@ -0,0 +1,100 @@
+package orchestrator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeRateGuardRecord(t *testing.T) {
+	g := newChangeRateGuard(&bootstrapv1.ChangeRateGuard{MaxVersions: 2, Interval: ptypes.DurationProto(time.Minute)})
+	defer g.remove("lds")
+	now := time.Now()
+	release := func() {}
+	assert.Equal(t, changeRateFanout, g.record("lds", true, now, release))
+	assert.Equal(t, changeRateFanout, g.record("lds", true, now.Add(time.Second), release))
+	assert.Equal(t, changeRateFanout, g.record("lds", false, now.Add(2*time.Second), release))
+	assert.Equal(t, changeRateCollapse, g.record("lds", true, now.Add(3*time.Second), release))
+
+	// Versions older than the interval are not counted.
+	g.released("lds")
+	assert.Equal(t, changeRateFanout, g.record("lds", true, now.Add(62*time.Second), release))
+
+	g.pause = true
+	assert.Equal(t, changeRateFanout, g.record("cds", true, now, release))
+	assert.Equal(t, changeRateFanout, g.record("cds", true, now, release))
+	assert.Equal(t, changeRatePause, g.record("cds", true, now, release))
+	assert.Equal(t, changeRatePause, g.record("cds", false, now.Add(time.Hour), release))
+	assert.True(t, g.resume("cds"))
+	assert.False(t, g.resume("cds"))
+}
+
+func TestChangeRateCollapse(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithChangeRateGuard(&bootstrapv1.ChangeRateGuard{
+		MaxVersions: 1,
+		Interval:    ptypes.DurationProto(200 * time.Millisecond),
+	})(orchestrator)
+
+	req := newRolloutRequest("node", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	resp1 := newRolloutResponse("1")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp1))
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+
+	// Only the latest of the versions collapsed within the interval is
+	// fanned out, at the end of the interval.
+	req.VersionInfo = "1"
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", newRolloutResponse("2")))
+	resp3 := newRolloutResponse("3")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp3))
+	assert.Equal(t, 0, len(respChannel))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.change_rate_collapsed", 2)
+	assertEqualResponse(t, <-respChannel, *resp3, req)
+}
+
+func TestChangeRatePause(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithAlerting(&bootstrapv1.Alerting{})(orchestrator)
+	WithChangeRateGuard(&bootstrapv1.ChangeRateGuard{
+		MaxVersions: 1,
+		Interval:    ptypes.DurationProto(time.Minute),
+		Pause:       true,
+	})(orchestrator)
+	assert.False(t, orchestrator.ResumeFanout("lds"))
+
+	req := newRolloutRequest("node", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	resp1 := newRolloutResponse("1")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp1))
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+
+	req.VersionInfo = "1"
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	resp2 := newRolloutResponse("2")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp2))
+	assert.Equal(t, 0, len(respChannel))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.change_rate_paused", 1)
+	alerts := orchestrator.GetAlerts()
+	assert.Equal(t, 1, len(alerts))
+	assert.Equal(t, AlertChangeRate, alerts[0].Rule)
+	assert.Equal(t, "more than 1 versions within 1m0s, fanout paused", alerts[0].Message)
+
+	// Resuming fans out the latest version and clears the alert.
+	assert.True(t, orchestrator.ResumeFanout("lds"))
+	assertEqualResponse(t, <-respChannel, *resp2, req)
+	assert.Empty(t, orchestrator.GetAlerts())
+	assert.False(t, orchestrator.ResumeFanout("lds"))
+}
@@ -0,0 +1,276 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file breaks the circuit of aggregated keys whose upstream streams keep
+// failing. The contents of this file are intended to only be used within the
+// orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultFailureWindow    = time.Minute
+	defaultCoolDown         = 30 * time.Second
+
+	metricCircuitFailure  = "circuit_failure"
+	metricCircuitOpened   = "circuit_opened"
+	metricCircuitClosed   = "circuit_closed"
+	metricCircuitRejected = "circuit_rejected"
+	metricCircuitsOpen    = "circuits_open"
+)
+
+// circuitState is the state of the circuit of an aggregated key.
+type circuitState int
+
+const (
+	// circuitClosed circuits open upstream streams as usual.
+	circuitClosed circuitState = iota
+	// circuitOpen circuits do not open upstream streams until the cool down
+	// has elapsed.
+	circuitOpen
+	// circuitHalfOpen circuits have opened a probe stream, and are closed
+	// once it delivers a response.
+	circuitHalfOpen
+)
+
+// circuit holds the recent failures of the upstream streams of an aggregated
+// key.
+type circuit struct {
+	state    circuitState
+	failures []time.Time
+	// nackedVersion is the last version rejected by a downstream node, which
+	// is only counted as a failure once.
+	nackedVersion string
+	// timer opens the probe stream once the cool down elapses.
+	timer *time.Timer
+}
+
+// circuitBreakers tracks the circuit of every aggregated key that had a
+// failure recently.
+type circuitBreakers struct {
+	failureThreshold int
+	failureWindow    time.Duration
+	coolDown         time.Duration
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// newCircuitBreakers returns circuit breakers with the thresholds of the
+// config.
+func newCircuitBreakers(config *bootstrapv1.CircuitBreaker) *circuitBreakers {
+	c := &circuitBreakers{
+		failureThreshold: defaultFailureThreshold,
+		failureWindow:    defaultFailureWindow,
+		coolDown:         defaultCoolDown,
+		circuits:         make(map[string]*circuit),
+	}
+	if config.GetFailureThreshold() != nil {
+		c.failureThreshold = int(config.GetFailureThreshold().GetValue())
+	}
+	if config.GetFailureWindow() != nil {
+		if window, err := ptypes.Duration(config.GetFailureWindow()); err == nil {
+			c.failureWindow = window
+		}
+	}
+	if config.GetCoolDown() != nil {
+		if coolDown, err := ptypes.Duration(config.GetCoolDown()); err == nil {
+			c.coolDown = coolDown
+		}
+	}
+	return c
+}
+
+// recordFailure counts a failure of the aggregated key at now. It returns
+// true if the failure opened the circuit.
+func (c *circuitBreakers) recordFailure(aggregatedKey string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cb, ok := c.circuits[aggregatedKey]
+	if !ok {
+		cb = &circuit{}
+		c.circuits[aggregatedKey] = cb
+	}
+	if cb.state == circuitOpen {
+		return false
+	}
+	recent := cb.failures[:0]
+	for _, failure := range cb.failures {
+		if now.Sub(failure) < c.failureWindow {
+			recent = append(recent, failure)
+		}
+	}
+	cb.failures = append(recent, now)
+	if cb.state == circuitClosed && len(cb.failures) < c.failureThreshold {
+		return false
+	}
+	cb.state = circuitOpen
+	cb.failures = nil
+	return true
+}
+
+// recordNack counts the rejection of the version as a failure of the
+// aggregated key, unless the version was rejected before. It returns true if
+// the rejection opened the circuit.
+func (c *circuitBreakers) recordNack(aggregatedKey string, version string, now time.Time) (counted bool, opened bool) {
+	c.mu.Lock()
+	cb, ok := c.circuits[aggregatedKey]
+	if ok && cb.nackedVersion == version {
+		c.mu.Unlock()
+		return false, false
+	}
+	if !ok {
+		c.circuits[aggregatedKey] = &circuit{nackedVersion: version}
+	} else {
+		cb.nackedVersion = version
+	}
+	c.mu.Unlock()
+	return true, c.recordFailure(aggregatedKey, now)
+}
+
+// recordSuccess closes the circuit of the aggregated key if it is half open.
+// It returns true if the circuit was closed.
+func (c *circuitBreakers) recordSuccess(aggregatedKey string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cb, ok := c.circuits[aggregatedKey]
+	if !ok || cb.state != circuitHalfOpen {
+		return false
+	}
+	delete(c.circuits, aggregatedKey)
+	return true
+}
+
+// allows returns false if the circuit of the aggregated key is open.
+func (c *circuitBreakers) allows(aggregatedKey string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cb, ok := c.circuits[aggregatedKey]
+	return !ok || cb.state != circuitOpen
+}
+
+// halfOpen moves the open circuit of the aggregated key to half open. It
+// returns false if the circuit is not open.
+func (c *circuitBreakers) halfOpen(aggregatedKey string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cb, ok := c.circuits[aggregatedKey]
+	if !ok || cb.state != circuitOpen {
+		return false
+	}
+	cb.state = circuitHalfOpen
+	cb.timer = nil
+	return true
+}
+
+// setTimer sets the timer that probes the open circuit of the aggregated
+// key.
+func (c *circuitBreakers) setTimer(aggregatedKey string, timer *time.Timer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cb, ok := c.circuits[aggregatedKey]; ok {
+		cb.timer = timer
+	}
+}
+
+// remove forgets the circuit of the aggregated key.
+func (c *circuitBreakers) remove(aggregatedKey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cb, ok := c.circuits[aggregatedKey]; ok && cb.timer != nil {
+		cb.timer.Stop()
+	}
+	delete(c.circuits, aggregatedKey)
+}
+
+// openCount returns the number of circuits that are open or half open.
+func (c *circuitBreakers) openCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	open := 0
+	for _, cb := range c.circuits {
+		if cb.state != circuitClosed {
+			open++
+		}
+	}
+	return open
+}
+
+// onUpstreamReset counts the reset of the upstream stream of the aggregated
+// key as a failure, and reopens the stream unless the circuit opened.
+func (o *orchestrator) onUpstreamReset(ctx context.Context, aggregatedKey string, done chan bool) {
+	o.deleteUpstream(aggregatedKey, done)
+	opened := o.circuitBreakers.recordFailure(aggregatedKey, time.Now())
+	o.recordUpstreamFailure(ctx, aggregatedKey, opened)
+	if !opened {
+		o.reopenUpstream(ctx, aggregatedKey)
+	}
+}
+
+// onDownstreamNack counts the first rejection of a version of the aggregated
+// key by a downstream node as a failure.
+func (o *orchestrator) onDownstreamNack(ctx context.Context, aggregatedKey string, version string) {
+	counted, opened := o.circuitBreakers.recordNack(aggregatedKey, version, time.Now())
+	if counted {
+		o.recordUpstreamFailure(ctx, aggregatedKey, opened)
+	}
+}
+
+// onUpstreamResponse closes the circuit of the aggregated key if the
+// response was delivered by a probe stream.
+func (o *orchestrator) onUpstreamResponse(ctx context.Context, aggregatedKey string) {
+	if !o.circuitBreakers.recordSuccess(aggregatedKey) {
+		return
+	}
+	o.keyScope(aggregatedKey).Counter(metricCircuitClosed).Inc(1)
+	o.scope.Gauge(metricCircuitsOpen).Update(float64(o.circuitBreakers.openCount()))
+	o.logger.With("key", aggregatedKey).Info(ctx, "circuit closed")
+}
+
+// recordUpstreamFailure reports a failure of the aggregated key. If the
+// failure opened the circuit, the upstream stream is closed, and a probe
+// stream is opened once the cool down elapses.
+func (o *orchestrator) recordUpstreamFailure(ctx context.Context, aggregatedKey string, opened bool) {
+	o.keyScope(aggregatedKey).Counter(metricCircuitFailure).Inc(1)
+	if !opened {
+		return
+	}
+	o.keyScope(aggregatedKey).Counter(metricCircuitOpened).Inc(1)
+	o.scope.Gauge(metricCircuitsOpen).Update(float64(o.circuitBreakers.openCount()))
+	o.logger.With("key", aggregatedKey).With("cool down", o.circuitBreakers.coolDown).
+		Error(ctx, "circuit opened, serving the cached response")
+	o.deleteUpstream(aggregatedKey, nil)
+	o.circuitBreakers.setTimer(aggregatedKey, time.AfterFunc(o.circuitBreakers.coolDown, func() {
+		if o.circuitBreakers.halfOpen(aggregatedKey) {
+			o.logger.With("key", aggregatedKey).Info(context.Background(), "circuit half open, probing upstream")
+			o.reopenUpstream(context.Background(), aggregatedKey)
+		}
+	}))
+}
+
+// reopenUpstream opens the upstream stream of the aggregated key with its
+// representative request, if this replica opens upstream streams.
+func (o *orchestrator) reopenUpstream(ctx context.Context, aggregatedKey string) {
+	representative, ok := o.representativeRequests.Load(aggregatedKey)
+	if !ok {
+		return
+	}
+	o.upstreamMu.RLock()
+	defer o.upstreamMu.RUnlock()
+	if o.isLeader() {
+		o.openUpstream(ctx, aggregatedKey, representative.(gcp.Request))
+	}
+}
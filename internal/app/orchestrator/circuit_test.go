@@ -0,0 +1,103 @@
+package orchestrator
+
+import (
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockFlakyUpstreamClient hands the response channel of every stream that it
+// opens to the test, which may close it to reset the stream.
+type mockFlakyUpstreamClient struct {
+	streams chan chan *v2.DiscoveryResponse
+}
+
+func (m mockFlakyUpstreamClient) OpenStream(req v2.DiscoveryRequest) (<-chan *v2.DiscoveryResponse, func(), error) {
+	stream := make(chan *v2.DiscoveryResponse)
+	m.streams <- stream
+	return stream, func() {}, nil
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	upstreamClient := mockFlakyUpstreamClient{streams: make(chan chan *v2.DiscoveryResponse, 10)}
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), upstreamClient)
+	WithCircuitBreaker(&bootstrapv1.CircuitBreaker{
+		FailureThreshold: &wrappers.UInt32Value{Value: 2},
+		CoolDown:         ptypes.DurationProto(100 * time.Millisecond),
+	})(orchestrator)
+	req := newRolloutRequest("node", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	stream := <-upstreamClient.streams
+	resp1 := newRolloutResponse("1")
+	stream <- resp1
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+
+	// Reset streams are reopened until the circuit opens.
+	close(stream)
+	stream = <-upstreamClient.streams
+	close(stream)
+	assert.Eventually(t, func() bool {
+		return !orchestrator.circuitBreakers.allows("lds")
+	}, time.Second, time.Millisecond)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.circuit_failure", 2)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.circuit_opened", 1)
+	testutils.AssertGaugeValue(t, mockScope.Snapshot().Gauges(), "prefix.circuits_open", 1)
+
+	// The cached response is served while the circuit is open.
+	otherReq := newRolloutRequest("other", false)
+	otherChannel, cancelOther := orchestrator.CreateWatch(otherReq)
+	defer cancelOther()
+	assertEqualResponse(t, <-otherChannel, *resp1, otherReq)
+	assert.Equal(t, 0, len(upstreamClient.streams))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.circuit_rejected", 1)
+
+	// The probe stream closes the circuit once it delivers a response.
+	stream = <-upstreamClient.streams
+	resp2 := newRolloutResponse("2")
+	stream <- resp2
+	assertEqualResponse(t, <-otherChannel, *resp2, otherReq)
+	assert.Eventually(t, func() bool {
+		return orchestrator.circuitBreakers.openCount() == 0
+	}, time.Second, time.Millisecond)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.circuit_closed", 1)
+	testutils.AssertGaugeValue(t, mockScope.Snapshot().Gauges(), "prefix.circuits_open", 0)
+}
+
+func TestCircuitBreakerFailures(t *testing.T) {
+	breakers := newCircuitBreakers(&bootstrapv1.CircuitBreaker{
+		FailureThreshold: &wrappers.UInt32Value{Value: 2},
+		FailureWindow:    ptypes.DurationProto(time.Minute),
+	})
+	now := time.Now()
+
+	// Failures outside of the window are forgotten.
+	assert.False(t, breakers.recordFailure("lds", now))
+	assert.False(t, breakers.recordFailure("lds", now.Add(2*time.Minute)))
+	assert.True(t, breakers.allows("lds"))
+
+	// Each rejected version is counted once.
+	counted, opened := breakers.recordNack("lds", "1", now.Add(2*time.Minute))
+	assert.True(t, counted)
+	assert.True(t, opened)
+	assert.False(t, breakers.allows("lds"))
+	counted, _ = breakers.recordNack("lds", "1", now.Add(2*time.Minute))
+	assert.False(t, counted)
+
+	// A failure of the probe opens the circuit again.
+	assert.False(t, breakers.recordSuccess("lds"))
+	assert.True(t, breakers.halfOpen("lds"))
+	assert.True(t, breakers.allows("lds"))
+	assert.True(t, breakers.recordFailure("lds", now.Add(3*time.Minute)))
+	assert.True(t, breakers.halfOpen("lds"))
+	assert.True(t, breakers.recordSuccess("lds"))
+	assert.Equal(t, 0, breakers.openCount())
+}
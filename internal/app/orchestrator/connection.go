@@ -0,0 +1,91 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file multiplexes the downstream sends of fanouts over one send loop per
+// downstream connection. The contents of this file are intended to only be
+// used within the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"sync"
+
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"google.golang.org/grpc/peer"
+)
+
+// connectionID identifies the downstream connection of a watch. Envoy opens
+// its per-type streams on a single connection, whose streams share the peer
+// address. The connection of a watch is recorded when the watch is created,
+// since later requests of its stream may replace the node that attributes the
+// watch to the stream. Watches whose stream has no known peer are each given a
+// connection of their own.
+type connectionID struct {
+	peer  string
+	watch cache.WatchID
+}
+
+// contextPeer returns the address of the peer of a downstream stream, or an
+// empty string if it is unknown.
+func contextPeer(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	return p.Addr.String()
+}
+
+// connectionSenders runs the sends to downstream watches. Each connection
+// with pending sends has a single send loop, which runs its sends in order and
+// exits once there are none left, so that the number of send goroutines is
+// bounded by the number of connections rather than by the number of watches.
+// A send that blocks holds up the other sends of its connection only.
+type connectionSenders struct {
+	goroutines *goroutineTracker
+
+	mu sync.Mutex
+	// pending holds the sends of the connections whose loop is running.
+	pending map[connectionID][]func()
+}
+
+func newConnectionSenders(goroutines *goroutineTracker) *connectionSenders {
+	return &connectionSenders{
+		goroutines: goroutines,
+		pending:    make(map[connectionID][]func()),
+	}
+}
+
+// enqueue queues the send on the loop of the connection, and starts the loop
+// if it is not running.
+func (c *connectionSenders) enqueue(connection connectionID, send func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if sends, ok := c.pending[connection]; ok {
+		c.pending[connection] = append(sends, send)
+		return
+	}
+	c.pending[connection] = []func(){send}
+	c.goroutines.goroutine(subsystemConnection, func() { c.run(connection) })
+}
+
+// run runs the pending sends of the connection until there are none left.
+func (c *connectionSenders) run(connection connectionID) {
+	for {
+		c.mu.Lock()
+		sends := c.pending[connection]
+		if len(sends) == 0 {
+			delete(c.pending, connection)
+			c.mu.Unlock()
+			return
+		}
+		send := sends[0]
+		sends[0] = nil
+		c.pending[connection] = sends[1:]
+		c.mu.Unlock()
+		send()
+	}
+}
@@ -0,0 +1,146 @@
+package orchestrator
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/peer"
+)
+
+func peerContext(address string) context.Context {
+	addr, _ := net.ResolveTCPAddr("tcp", address)
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+}
+
+func TestConnectionSenders(t *testing.T) {
+	goroutines := newGoroutineTracker(newMockScope("prefix"))
+	senders := newConnectionSenders(goroutines)
+	blocked := connectionID{peer: "10.0.0.1:1000"}
+	other := connectionID{peer: "10.0.0.2:1000"}
+
+	// The sends of a connection run in order, on a single loop.
+	release := make(chan struct{})
+	var order []int
+	done := make(chan struct{})
+	senders.enqueue(blocked, func() { <-release })
+	for i := 0; i < 3; i++ {
+		i := i
+		senders.enqueue(blocked, func() { order = append(order, i) })
+	}
+	senders.enqueue(blocked, func() { close(done) })
+	assert.Equal(t, 1, goroutines.summary()[subsystemConnection])
+
+	// A blocked send holds up the sends of its connection only.
+	sent := make(chan struct{})
+	senders.enqueue(other, func() { close(sent) })
+	<-sent
+
+	close(release)
+	<-done
+	assert.Equal(t, []int{0, 1, 2}, order)
+	assert.Eventually(t, func() bool {
+		return goroutines.summary()[subsystemConnection] == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestWatchConnection(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), typeURLMapper{},
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)})
+	assert.NoError(t, orchestrator.OnStreamOpen(peerContext("10.0.0.1:1000"), 1, ""))
+	defer orchestrator.OnStreamClosed(1)
+	assert.NoError(t, orchestrator.OnStreamOpen(context.Background(), 2, ""))
+	defer orchestrator.OnStreamClosed(2)
+
+	// Envoy sends its node on every request, so every request of the stream
+	// has a node message of its own.
+	for _, streamRequest := range []struct {
+		streamID int64
+		typeURL  string
+	}{
+		{streamID: 1, typeURL: upstream.ListenerTypeURL},
+		{streamID: 1, typeURL: upstream.ClusterTypeURL},
+		{streamID: 2, typeURL: upstream.ListenerTypeURL},
+	} {
+		req := &v2.DiscoveryRequest{TypeUrl: streamRequest.typeURL, Node: &v2_core.Node{Id: "node"}}
+		assert.NoError(t, orchestrator.OnStreamRequest(streamRequest.streamID, req))
+		_, cancelWatch := orchestrator.CreateWatch(*req)
+		defer cancelWatch()
+	}
+
+	var connections []connectionID
+	for _, watch := range orchestrator.downstreamResponseMap.list() {
+		connections = append(connections, orchestrator.downstreamResponseMap.connection(watch.ID))
+	}
+	assert.Equal(t, []connectionID{
+		// The watches of the stream share the connection of its peer, even
+		// though the first node of the stream was replaced.
+		{peer: "10.0.0.1:1000"},
+		{peer: "10.0.0.1:1000"},
+		// The watch of the stream without a known peer has a connection of
+		// its own.
+		{watch: 3},
+	}, connections)
+}
+
+func TestFanoutSendsPerConnection(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), typeURLMapper{},
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	orchestrator.buffers = newResponseBuffers(&bootstrapv1.Buffers{
+		Downstream: &bootstrapv1.Buffers_Buffer{Size: 1, OverflowPolicy: bootstrapv1.Buffers_BLOCK},
+	})
+	orchestrator.downstreamResponseMap.buffers = orchestrator.buffers
+
+	// Two Envoys, each of which opens its stream from its own connection.
+	var channels []<-chan gcp.Response
+	for i, address := range []string{"10.0.0.1:1000", "10.0.0.2:1000"} {
+		streamID := int64(i + 1)
+		req := &v2.DiscoveryRequest{TypeUrl: upstream.ListenerTypeURL, Node: &v2_core.Node{Id: address}}
+		assert.NoError(t, orchestrator.OnStreamOpen(peerContext(address), streamID, ""))
+		defer orchestrator.OnStreamClosed(streamID)
+		assert.NoError(t, orchestrator.OnStreamRequest(streamID, req))
+		respChannel, cancelWatch := orchestrator.CreateWatch(*req)
+		defer cancelWatch()
+		channels = append(channels, respChannel)
+	}
+	blocked, other := channels[0], channels[1]
+
+	upstreamResponseChannel <- &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ListenerTypeURL}
+	gcpResp := <-other
+	resp, err := gcpResp.GetDiscoveryResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", resp.GetVersionInfo())
+
+	// The buffer of the first watch is full, so its send blocks, which does
+	// not hold up the send to the watch of the other connection.
+	upstreamResponseChannel <- &v2.DiscoveryResponse{VersionInfo: "2", TypeUrl: upstream.ListenerTypeURL}
+	gcpResp = <-other
+	resp, err = gcpResp.GetDiscoveryResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, "2", resp.GetVersionInfo())
+	assert.Eventually(t, func() bool {
+		return orchestrator.GetGoroutines().Subsystems[subsystemConnection] == 1
+	}, time.Second, time.Millisecond)
+
+	for _, version := range []string{"1", "2"} {
+		gcpResp = <-blocked
+		resp, err = gcpResp.GetDiscoveryResponse()
+		assert.NoError(t, err)
+		assert.Equal(t, version, resp.GetVersionInfo())
+	}
+	assert.Eventually(t, func() bool {
+		return orchestrator.GetGoroutines().Subsystems[subsystemConnection] == 0
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	orchestrator.shutdown(ctx)
+}
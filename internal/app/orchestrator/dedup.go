@@ -0,0 +1,273 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file tracks the responses sent to each downstream node, so that
+// responses the node already holds are not sent again, nor, with differential
+// fanout, responses that leave the resources the node subscribes to
+// unchanged. It also detects responses that bump the version without
+// changing the resources. The contents of this file are intended to only be used within
+// the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"sync"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/diff"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+const (
+	metricSuppressedDuplicate = "suppressed_duplicate_response"
+	metricSuppressedUnchanged = "suppressed_unchanged_subset"
+	metricSuppressedIdentical = "suppressed_identical_content"
+	// metricIdenticalVersionBump counts the responses of the origin server
+	// that bump the version without changing the resources.
+	metricIdenticalVersionBump = "identical_content_version_bump"
+)
+
+// sentResponse identifies a response by its version and the contents of its
+// resources. Responses with the same version can differ, e.g. when overrides
+// are reapplied to the latest upstream response.
+type sentResponse struct {
+	version     string
+	fingerprint [sha256.Size]byte
+}
+
+func newSentResponse(resp *discovery.DiscoveryResponse) sentResponse {
+	hash := sha256.New()
+	for _, resource := range resp.GetResources() {
+		_, _ = hash.Write([]byte(resource.GetTypeUrl()))
+		_, _ = hash.Write(resource.GetValue())
+	}
+	sent := sentResponse{version: resp.GetVersionInfo()}
+	copy(sent.fingerprint[:], hash.Sum(nil))
+	return sent
+}
+
+// subsetFingerprint identifies the resources that a watch subscribes to, by
+// their names and the contents of those of them that are in the response.
+type subsetFingerprint [sha256.Size]byte
+
+func newSubsetFingerprint(resources map[string]*any.Any, names []string) subsetFingerprint {
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	hash := sha256.New()
+	write := func(b []byte) {
+		var length [binary.MaxVarintLen64]byte
+		_, _ = hash.Write(length[:binary.PutUvarint(length[:], uint64(len(b)))])
+		_, _ = hash.Write(b)
+	}
+	for _, name := range sorted {
+		write([]byte(name))
+		if resource, ok := resources[name]; ok {
+			write([]byte{1})
+			write(resource.GetValue())
+		} else {
+			write([]byte{0})
+		}
+	}
+	var fingerprint subsetFingerprint
+	copy(fingerprint[:], hash.Sum(nil))
+	return fingerprint
+}
+
+// sentRecord is the response last sent to a node, and the fingerprint of the
+// resources that the node subscribed to in it, if recorded.
+type sentRecord struct {
+	response  sentResponse
+	subset    subsetFingerprint
+	hasSubset bool
+}
+
+// sentResponseMap is the map of aggregated keys to downstream node IDs to the
+// response last sent to the node.
+type sentResponseMap struct {
+	mu        sync.Mutex
+	responses map[string]map[string]sentRecord
+}
+
+func newSentResponseMap() *sentResponseMap {
+	return &sentResponseMap{
+		responses: make(map[string]map[string]sentRecord),
+	}
+}
+
+// isDuplicate returns true if the watch already holds the response: the watch
+// acknowledged the version of the response, and the last response sent to its
+// node with that version had the same resources. Reconnecting nodes that
+// acknowledge the version are taken to hold the response, even though the
+// nonce of their stream differs.
+func (s *sentResponseMap) isDuplicate(aggregatedKey string, watch *gcp.Request, resp sentResponse) bool {
+	if watch.GetVersionInfo() != resp.version {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sent, ok := s.responses[aggregatedKey][watch.GetNode().GetId()]
+	return !ok || sent.response == resp
+}
+
+// holdsSubset returns true if the watch already holds the resources that it
+// subscribes to: the watch acknowledged the last response sent to its node,
+// which had the same subset of resources.
+func (s *sentResponseMap) holdsSubset(aggregatedKey string, watch *gcp.Request, subset subsetFingerprint) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sent, ok := s.responses[aggregatedKey][watch.GetNode().GetId()]
+	return ok && sent.hasSubset && sent.response.version == watch.GetVersionInfo() && sent.subset == subset
+}
+
+// record remembers the response as the last sent to the node of the watch,
+// along with the fingerprint of the resources the watch subscribes to in it,
+// if not nil.
+func (s *sentResponseMap) record(
+	aggregatedKey string,
+	watch *gcp.Request,
+	resp sentResponse,
+	subset *subsetFingerprint,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.responses[aggregatedKey] == nil {
+		s.responses[aggregatedKey] = make(map[string]sentRecord)
+	}
+	sent := sentRecord{response: resp}
+	if subset != nil {
+		sent.subset, sent.hasSubset = *subset, true
+	}
+	s.responses[aggregatedKey][watch.GetNode().GetId()] = sent
+}
+
+// restore remembers the response as the last sent to the node of the watch,
+// unless a response sent to the node is already remembered. It returns true
+// if the response was restored.
+func (s *sentResponseMap) restore(aggregatedKey string, watch *gcp.Request, sent sentRecord) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.responses[aggregatedKey] == nil {
+		s.responses[aggregatedKey] = make(map[string]sentRecord)
+	}
+	if _, ok := s.responses[aggregatedKey][watch.GetNode().GetId()]; ok {
+		return false
+	}
+	s.responses[aggregatedKey][watch.GetNode().GetId()] = sent
+	return true
+}
+
+// delete forgets the responses sent for the aggregated key.
+func (s *sentResponseMap) delete(aggregatedKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.responses, aggregatedKey)
+}
+
+// typeURLSet is a set of type URLs. The empty set contains every type URL.
+type typeURLSet map[string]bool
+
+func newTypeURLSet(typeURLs []string) typeURLSet {
+	set := make(typeURLSet, len(typeURLs))
+	for _, typeURL := range typeURLs {
+		set[typeURL] = true
+	}
+	return set
+}
+
+func (s typeURLSet) contains(typeURL string) bool {
+	return len(s) == 0 || s[typeURL]
+}
+
+// differentialFanout decides the responses that are fanned out
+// differentially.
+type differentialFanout struct {
+	typeURLs typeURLSet
+}
+
+func newDifferentialFanout(config *bootstrapv1.DifferentialFanout) *differentialFanout {
+	return &differentialFanout{typeURLs: newTypeURLSet(config.GetTypeUrls())}
+}
+
+// resources indexes the resources of the response by name if the response is
+// fanned out differentially, and returns nil otherwise.
+func (d *differentialFanout) resources(resp *discovery.DiscoveryResponse) map[string]*any.Any {
+	if d == nil || !d.typeURLs.contains(resp.GetTypeUrl()) {
+		return nil
+	}
+	return diff.ResourcesByName(resp.GetResources())
+}
+
+// watchSubset returns the fingerprint of the resources that the watch subscribes
+// to in the indexed resources of a response, or nil if the response is not
+// fanned out differentially or the watch subscribes to every resource.
+func watchSubset(resources map[string]*any.Any, watch *gcp.Request) *subsetFingerprint {
+	if resources == nil || len(watch.GetResourceNames()) == 0 {
+		return nil
+	}
+	fingerprint := newSubsetFingerprint(resources, watch.GetResourceNames())
+	return &fingerprint
+}
+
+// contentFingerprint identifies the resources of a response independently of
+// their order and of the version of the response.
+func contentFingerprint(resp *discovery.DiscoveryResponse) [sha256.Size]byte {
+	hashes := make([][sha256.Size]byte, 0, len(resp.GetResources()))
+	for _, resource := range resp.GetResources() {
+		hash := sha256.New()
+		_, _ = hash.Write([]byte(resource.GetTypeUrl()))
+		_, _ = hash.Write(resource.GetValue())
+		var sum [sha256.Size]byte
+		copy(sum[:], hash.Sum(nil))
+		hashes = append(hashes, sum)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i][:], hashes[j][:]) < 0
+	})
+	hash := sha256.New()
+	for _, sum := range hashes {
+		_, _ = hash.Write(sum[:])
+	}
+	var fingerprint [sha256.Size]byte
+	copy(fingerprint[:], hash.Sum(nil))
+	return fingerprint
+}
+
+// isIdenticalVersionBump returns true if the response bumps the version of
+// the previous response without changing its resources.
+func isIdenticalVersionBump(previous *discovery.DiscoveryResponse, resp *discovery.DiscoveryResponse) bool {
+	return previous != nil && previous.GetVersionInfo() != resp.GetVersionInfo() &&
+		len(previous.GetResources()) == len(resp.GetResources()) &&
+		contentFingerprint(previous) == contentFingerprint(resp)
+}
+
+// suppressIdenticalVersionBump counts the response if it bumps the version
+// of the previous response without changing its resources, and returns true
+// if its fanout is suppressed.
+func (o *orchestrator) suppressIdenticalVersionBump(
+	ctx context.Context,
+	aggregatedKey string,
+	previous *discovery.DiscoveryResponse,
+	resp *discovery.DiscoveryResponse,
+) bool {
+	if !isIdenticalVersionBump(previous, resp) {
+		return false
+	}
+	o.keyScope(aggregatedKey).Counter(metricIdenticalVersionBump).Inc(1)
+	if o.contentDeduplication == nil || !o.contentDeduplication.contains(resp.GetTypeUrl()) {
+		return false
+	}
+	o.keyScope(aggregatedKey).Counter(metricSuppressedIdentical).Inc(1)
+	o.logger.With("key", aggregatedKey).With("previous version", previous.GetVersionInfo()).
+		With("version", resp.GetVersionInfo()).Debug(ctx, "resources unchanged, suppressing fanout")
+	return true
+}
@@ -0,0 +1,239 @@
+package orchestrator
+
+import (
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/api/v2/endpoint"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanoutSuppressesDuplicates(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	node := &v2_core.Node{Id: "node"}
+	assertNoResponse := func(respChannel chan gcp.Response) {
+		select {
+		case <-respChannel:
+			assert.Fail(t, "unexpected response")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	listener, err := ptypes.MarshalAny(&v2.Listener{Name: "listener_A"})
+	assert.NoError(t, err)
+	newResponse := func(version string, resources ...*any.Any) *v2.DiscoveryResponse {
+		return &v2.DiscoveryResponse{
+			VersionInfo: version,
+			TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+			Resources:   resources,
+		}
+	}
+
+	// A node that reconnects with the version the origin server sends
+	// already holds the response.
+	respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+		Node:        node,
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	upstreamResponseChannel <- newResponse("1")
+	assertNoResponse(respChannel)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.suppressed_duplicate_response", 1)
+
+	upstreamResponseChannel <- newResponse("2")
+	resp, err := (<-respChannel).GetDiscoveryResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, "2", resp.GetVersionInfo())
+	cancelWatch()
+
+	// The acknowledging watch does not receive the same response again.
+	respChannel, cancelWatch = orchestrator.CreateWatch(gcp.Request{
+		Node:        node,
+		VersionInfo: "2",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	defer cancelWatch()
+	upstreamResponseChannel <- newResponse("2")
+	assertNoResponse(respChannel)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.suppressed_duplicate_response", 2)
+
+	// Responses with the same version but different resources are sent.
+	upstreamResponseChannel <- newResponse("2", listener)
+	resp, err = (<-respChannel).GetDiscoveryResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp.GetResources()))
+}
+
+func TestFanoutSuppressesUnchangedSubsets(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t),
+		mockTracedUpstreamClient{responseChan: upstreamResponseChannel})
+	orchestrator.differentialFanout = newDifferentialFanout(&bootstrapv1.DifferentialFanout{})
+	typeURL := "type.googleapis.com/envoy.api.v2.Endpoint"
+	newAssignment := func(name string, port uint32) *any.Any {
+		assignment, err := ptypes.MarshalAny(&v2.ClusterLoadAssignment{
+			ClusterName: name,
+			Endpoints: []*endpoint.LocalityLbEndpoints{{
+				LbEndpoints: []*endpoint.LbEndpoint{{
+					HostIdentifier: &endpoint.LbEndpoint_Endpoint{
+						Endpoint: &endpoint.Endpoint{Address: &v2_core.Address{
+							Address: &v2_core.Address_SocketAddress{SocketAddress: &v2_core.SocketAddress{
+								PortSpecifier: &v2_core.SocketAddress_PortValue{PortValue: port},
+							}},
+						}},
+					},
+				}},
+			}},
+		})
+		assert.NoError(t, err)
+		return assignment
+	}
+	newResponse := func(version string, resources ...*any.Any) *v2.DiscoveryResponse {
+		return &v2.DiscoveryResponse{VersionInfo: version, TypeUrl: typeURL, Resources: resources}
+	}
+	watch := func(nodeID string, version string, name string) (chan gcp.Response, func()) {
+		return orchestrator.CreateWatch(gcp.Request{
+			Node:          &v2_core.Node{Id: nodeID},
+			VersionInfo:   version,
+			TypeUrl:       typeURL,
+			ResourceNames: []string{name},
+		})
+	}
+	assertResponse := func(respChannel chan gcp.Response, version string) {
+		resp, err := (<-respChannel).GetDiscoveryResponse()
+		assert.NoError(t, err)
+		assert.Equal(t, version, resp.GetVersionInfo())
+	}
+	assertNoResponse := func(respChannel chan gcp.Response) {
+		select {
+		case <-respChannel:
+			assert.Fail(t, "unexpected response")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	respChannelA, cancelWatchA := watch("a", "", "cluster_a")
+	respChannelB, cancelWatchB := watch("b", "", "cluster_b")
+	upstreamResponseChannel <- newResponse("1", newAssignment("cluster_a", 1), newAssignment("cluster_b", 1))
+	assertResponse(respChannelA, "1")
+	assertResponse(respChannelB, "1")
+
+	// Both nodes acknowledge the first version. Only the node whose cluster
+	// changed is sent the second.
+	cancelWatchA()
+	cancelWatchB()
+	respChannelA, cancelWatchA = watch("a", "1", "cluster_a")
+	respChannelB, cancelWatchB = watch("b", "1", "cluster_b")
+	defer func() { cancelWatchB() }()
+	upstreamResponseChannel <- newResponse("2", newAssignment("cluster_a", 2), newAssignment("cluster_b", 1))
+	assertResponse(respChannelA, "2")
+	assertNoResponse(respChannelB)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.suppressed_unchanged_subset", 1)
+
+	// The watch that was skipped stays open for the next change of its
+	// cluster, and is sent the latest version.
+	cancelWatchA()
+	respChannelA, cancelWatchA = watch("a", "2", "cluster_a")
+	defer cancelWatchA()
+	upstreamResponseChannel <- newResponse("3", newAssignment("cluster_a", 2), newAssignment("cluster_b", 2))
+	assertResponse(respChannelB, "3")
+	assertNoResponse(respChannelA)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.suppressed_unchanged_subset", 2)
+
+	// A removed cluster is a change.
+	upstreamResponseChannel <- newResponse("4", newAssignment("cluster_b", 2))
+	assertResponse(respChannelA, "4")
+}
+
+func TestContentFingerprint(t *testing.T) {
+	listenerA, err := ptypes.MarshalAny(&v2.Listener{Name: "listener_A"})
+	assert.NoError(t, err)
+	listenerB, err := ptypes.MarshalAny(&v2.Listener{Name: "listener_B"})
+	assert.NoError(t, err)
+
+	previous := &v2.DiscoveryResponse{VersionInfo: "1", Resources: []*any.Any{listenerA, listenerB}}
+	assert.False(t, isIdenticalVersionBump(nil, previous))
+	assert.False(t, isIdenticalVersionBump(previous, previous))
+	// The order of the resources does not matter.
+	assert.True(t, isIdenticalVersionBump(previous,
+		&v2.DiscoveryResponse{VersionInfo: "2", Resources: []*any.Any{listenerB, listenerA}}))
+	assert.False(t, isIdenticalVersionBump(previous,
+		&v2.DiscoveryResponse{VersionInfo: "2", Resources: []*any.Any{listenerA}}))
+	assert.False(t, isIdenticalVersionBump(previous,
+		&v2.DiscoveryResponse{VersionInfo: "2", Resources: []*any.Any{listenerA, listenerA}}))
+}
+
+func TestFanoutSuppressesIdenticalVersionBumps(t *testing.T) {
+	listener, err := ptypes.MarshalAny(&v2.Listener{Name: "listener_A"})
+	assert.NoError(t, err)
+	newResponse := func(version string, resources ...*any.Any) *v2.DiscoveryResponse {
+		return &v2.DiscoveryResponse{
+			VersionInfo: version,
+			TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+			Resources:   resources,
+		}
+	}
+	for _, deduplicate := range []bool{false, true} {
+		upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+		mockScope := newMockScope("prefix")
+		orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t),
+			mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+		if deduplicate {
+			orchestrator.contentDeduplication = newTypeURLSet(nil)
+		}
+		watch := func(version string) (chan gcp.Response, func()) {
+			return orchestrator.CreateWatch(gcp.Request{
+				Node:        &v2_core.Node{Id: "node"},
+				VersionInfo: version,
+				TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+			})
+		}
+
+		respChannel, cancelWatch := watch("")
+		upstreamResponseChannel <- newResponse("1", listener)
+		resp, err := (<-respChannel).GetDiscoveryResponse()
+		assert.NoError(t, err)
+		assert.Equal(t, "1", resp.GetVersionInfo())
+		cancelWatch()
+
+		respChannel, cancelWatch = watch("1")
+		upstreamResponseChannel <- newResponse("2", listener)
+		if deduplicate {
+			select {
+			case <-respChannel:
+				assert.Fail(t, "unexpected response")
+			case <-time.After(10 * time.Millisecond):
+			}
+			testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.suppressed_identical_content", 1)
+
+			// The version bump is cached for new watches.
+			cached, err := orchestrator.cache.Fetch("lds")
+			assert.NoError(t, err)
+			assert.Equal(t, "2", cached.Resp.GetVersionInfo())
+		} else {
+			resp, err = (<-respChannel).GetDiscoveryResponse()
+			assert.NoError(t, err)
+			assert.Equal(t, "2", resp.GetVersionInfo())
+			cancelWatch()
+			respChannel, cancelWatch = watch("2")
+		}
+		testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.identical_content_version_bump", 1)
+
+		upstreamResponseChannel <- newResponse("3")
+		resp, err = (<-respChannel).GetDiscoveryResponse()
+		assert.NoError(t, err)
+		assert.Equal(t, "3", resp.GetVersionInfo())
+		cancelWatch()
+	}
+}
@@ -0,0 +1,293 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file tracks the references between the resources of aggregated keys,
+// and fans out the responses of keys that update together in the order in
+// which Envoy expects related resources. The contents of this file are
+// intended to only be used within the orchestrator module and should not be
+// exported, except for the dependencies shown by the admin server.
+package orchestrator
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"github.com/envoyproxy/xds-relay/internal/app/diff"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	defaultDependencyWindow = 100 * time.Millisecond
+
+	metricDependencyWaves    = "dependency_waves"
+	metricDependencyDeferred = "dependency_deferred"
+)
+
+// fanoutStages ranks the resource types in the order in which Envoy expects
+// related resources to be sent. Other types are ranked last.
+var fanoutStages = map[string]int{
+	upstream.ClusterTypeURL:  0,
+	upstream.EndpointTypeURL: 1,
+	upstream.ListenerTypeURL: 2,
+	upstream.RouteTypeURL:    3,
+}
+
+func fanoutStage(typeURL string) int {
+	if stage, ok := fanoutStages[typeURL]; ok {
+		return stage
+	}
+	return len(fanoutStages)
+}
+
+// KeyDependencies lists the aggregated keys whose cached responses hold the
+// resources referenced by the cached response of an aggregated key.
+type KeyDependencies struct {
+	Key        string
+	Tenant     string
+	References []string
+}
+
+// keyIndex holds the aggregated keys of the resources of responses, by type
+// URL and resource name.
+type keyIndex map[string]map[string][]string
+
+func (i keyIndex) add(aggregatedKey string, resp *discovery.DiscoveryResponse) {
+	names, ok := i[resp.GetTypeUrl()]
+	if !ok {
+		names = make(map[string][]string)
+		i[resp.GetTypeUrl()] = names
+	}
+	for name := range diff.ResourcesByName(resp.GetResources()) {
+		names[name] = append(names[name], aggregatedKey)
+	}
+}
+
+// referencedKeys returns the aggregated keys, other than the aggregated key
+// of the response, that hold the resources referenced by the response,
+// ordered by aggregated key.
+func (i keyIndex) referencedKeys(aggregatedKey string, resp *discovery.DiscoveryResponse) []string {
+	set := make(map[string]bool)
+	for _, resource := range resp.GetResources() {
+		references, err := resourceReferences(resource)
+		if err != nil {
+			continue
+		}
+		for _, ref := range references {
+			for _, key := range i[ref.typeURL][ref.name] {
+				if key != aggregatedKey {
+					set[key] = true
+				}
+			}
+		}
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fanoutWaves orders the aggregated keys of the responses into waves. A key
+// is in a later wave than the keys it references or that reference it and
+// whose type comes first in the fanout stages. Keys without such references
+// are in the first wave.
+func fanoutWaves(responses map[string]*discovery.DiscoveryResponse) [][]string {
+	index := make(keyIndex)
+	keys := make([]string, 0, len(responses))
+	for key, resp := range responses {
+		index.add(key, resp)
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		si, sj := fanoutStage(responses[keys[i]].GetTypeUrl()), fanoutStage(responses[keys[j]].GetTypeUrl())
+		if si != sj {
+			return si < sj
+		}
+		return keys[i] < keys[j]
+	})
+
+	// after is keyed by aggregated key, and holds the keys fanned out before
+	// it.
+	after := make(map[string][]string)
+	for _, key := range keys {
+		stage := fanoutStage(responses[key].GetTypeUrl())
+		for _, referenced := range index.referencedKeys(key, responses[key]) {
+			switch referencedStage := fanoutStage(responses[referenced].GetTypeUrl()); {
+			case referencedStage < stage:
+				after[key] = append(after[key], referenced)
+			case referencedStage > stage:
+				after[referenced] = append(after[referenced], key)
+			}
+		}
+	}
+
+	// Keys are visited by stage, so the keys fanned out before a key have
+	// their wave assigned by the time it is visited.
+	waveOf := make(map[string]int)
+	var waves [][]string
+	for _, key := range keys {
+		wave := 0
+		for _, before := range after[key] {
+			if waveOf[before]+1 > wave {
+				wave = waveOf[before] + 1
+			}
+		}
+		waveOf[key] = wave
+		for len(waves) <= wave {
+			waves = append(waves, nil)
+		}
+		waves[wave] = append(waves[wave], key)
+	}
+	for _, wave := range waves {
+		sort.Strings(wave)
+	}
+	return waves
+}
+
+// pendingResponse is an upstream response held for the responses of related
+// keys.
+type pendingResponse struct {
+	ctx  context.Context
+	resp *discovery.DiscoveryResponse
+}
+
+// dependencyOrdering holds the upstream responses received within a window.
+type dependencyOrdering struct {
+	window time.Duration
+	pacing time.Duration
+
+	mu sync.Mutex
+	// pending is keyed by aggregated key.
+	pending map[string]*pendingResponse
+	timer   *time.Timer
+
+	// flushMu serializes the fanout of windows, so that a window is fanned
+	// out after the window before it.
+	flushMu sync.Mutex
+}
+
+func newDependencyOrdering(config *bootstrapv1.DependencyOrdering) *dependencyOrdering {
+	d := &dependencyOrdering{window: defaultDependencyWindow, pending: make(map[string]*pendingResponse)}
+	if config.GetWindow() != nil {
+		if window, err := ptypes.Duration(config.GetWindow()); err == nil {
+			d.window = window
+		}
+	}
+	if config.GetPacing() != nil {
+		if pacing, err := ptypes.Duration(config.GetPacing()); err == nil {
+			d.pacing = pacing
+		}
+	}
+	return d
+}
+
+// add holds the response of the aggregated key in place of any response of
+// the key held before, and calls flush when the window of the first response
+// held ends.
+func (d *dependencyOrdering) add(aggregatedKey string, pending *pendingResponse, flush func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pending[aggregatedKey] = pending
+	if d.timer == nil {
+		d.timer = time.AfterFunc(d.window, flush)
+	}
+}
+
+// take returns the responses held, and starts a new window.
+func (d *dependencyOrdering) take() map[string]*pendingResponse {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	pending := d.pending
+	d.pending, d.timer = make(map[string]*pendingResponse), nil
+	return pending
+}
+
+// remove drops the response held for the aggregated key.
+func (d *dependencyOrdering) remove(aggregatedKey string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.pending, aggregatedKey)
+}
+
+// holdUpstreamResponse holds the upstream response of the aggregated key
+// until the end of the window.
+func (o *orchestrator) holdUpstreamResponse(
+	ctx context.Context,
+	aggregatedKey string,
+	resp *discovery.DiscoveryResponse,
+) {
+	o.dependencyOrdering.add(aggregatedKey, &pendingResponse{ctx: ctx, resp: resp}, o.flushDependencyOrdering)
+}
+
+// flushDependencyOrdering caches and fans out the responses held in the
+// window, wave by wave.
+func (o *orchestrator) flushDependencyOrdering() {
+	o.dependencyOrdering.flushMu.Lock()
+	defer o.dependencyOrdering.flushMu.Unlock()
+	pending := o.dependencyOrdering.take()
+	responses := make(map[string]*discovery.DiscoveryResponse, len(pending))
+	for key, p := range pending {
+		responses[key] = p.resp
+	}
+	waves := fanoutWaves(responses)
+	o.scope.Counter(metricDependencyWaves).Inc(int64(len(waves)))
+	for i, wave := range waves {
+		if i > 0 && o.dependencyOrdering.pacing > 0 {
+			time.Sleep(o.dependencyOrdering.pacing)
+		}
+		for _, key := range wave {
+			if i > 0 {
+				o.keyScope(key).Counter(metricDependencyDeferred).Inc(1)
+			}
+			var previous *discovery.DiscoveryResponse
+			if cached, err := o.cache.Fetch(key); err == nil && cached != nil {
+				previous = cached.Resp
+			}
+			o.applyUpstreamResponse(pending[key].ctx, key, previous, pending[key].resp)
+		}
+	}
+}
+
+// GetDependencies returns the aggregated keys referenced by the cached
+// response of every aggregated key that references any, ordered by
+// aggregated key. It returns nil if dependency ordering is not enabled.
+func (o *orchestrator) GetDependencies() []KeyDependencies {
+	if o.dependencyOrdering == nil {
+		return nil
+	}
+	index := make(keyIndex)
+	responses := make(map[string]*discovery.DiscoveryResponse)
+	o.cache.Range(func(key string, resource cache.Resource) bool {
+		if resource.Resp != nil {
+			index.add(key, resource.Resp)
+			responses[key] = resource.Resp
+		}
+		return true
+	})
+	var dependencies []KeyDependencies
+	for key, resp := range responses {
+		if references := index.referencedKeys(key, resp); len(references) > 0 {
+			dependencies = append(dependencies, KeyDependencies{
+				Key:        key,
+				Tenant:     o.getTenant(key),
+				References: references,
+			})
+		}
+	}
+	sort.Slice(dependencies, func(i, j int) bool { return dependencies[i].Key < dependencies[j].Key })
+	return dependencies
+}
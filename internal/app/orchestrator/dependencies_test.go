@@ -0,0 +1,108 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	route "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRouteConfiguration(name string, cluster string) *v2.RouteConfiguration {
+	return &v2.RouteConfiguration{
+		Name: name,
+		VirtualHosts: []*route.VirtualHost{{Routes: []*route.Route{{
+			Action: &route.Route_Route{Route: &route.RouteAction{
+				ClusterSpecifier: &route.RouteAction_Cluster{Cluster: cluster},
+			}},
+		}}}},
+	}
+}
+
+func newDependentResponses(t *testing.T) map[string]*v2.DiscoveryResponse {
+	return map[string]*v2.DiscoveryResponse{
+		"cds": newResponse(t, upstream.ClusterTypeURL, "1",
+			&v2.Cluster{Name: "a", ClusterDiscoveryType: &v2.Cluster_Type{Type: v2.Cluster_EDS}}),
+		"eds":   newResponse(t, upstream.EndpointTypeURL, "1", &v2.ClusterLoadAssignment{ClusterName: "a"}),
+		"lds":   newResponse(t, upstream.ListenerTypeURL, "1", newRDSListener(t, "listener", "route")),
+		"rds":   newResponse(t, upstream.RouteTypeURL, "1", newRouteConfiguration("route", "a")),
+		"other": newResponse(t, upstream.RouteTypeURL, "1", newRouteConfiguration("other", "z")),
+	}
+}
+
+func TestFanoutWaves(t *testing.T) {
+	// Endpoints follow their cluster, and the route configuration follows
+	// both its listener and the cluster it routes to.
+	assert.Equal(t, [][]string{{"cds", "lds", "other"}, {"eds", "rds"}}, fanoutWaves(newDependentResponses(t)))
+
+	// A route configuration whose listener is not pending follows its
+	// cluster only, and unrelated keys are fanned out together.
+	responses := newDependentResponses(t)
+	delete(responses, "cds")
+	assert.Equal(t, [][]string{{"eds", "lds", "other"}, {"rds"}}, fanoutWaves(responses))
+
+	assert.Empty(t, fanoutWaves(nil))
+}
+
+func TestGetDependencies(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockUnusedUpstreamClient{t: t})
+	assert.Nil(t, orchestrator.GetDependencies())
+
+	WithDependencyOrdering(&bootstrapv1.DependencyOrdering{})(orchestrator)
+	for key, resp := range newDependentResponses(t) {
+		_, err := orchestrator.cache.SetResponse(key, *resp)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []KeyDependencies{
+		{Key: "cds", References: []string{"eds"}},
+		{Key: "lds", References: []string{"rds"}},
+		{Key: "rds", References: []string{"cds"}},
+	}, orchestrator.GetDependencies())
+}
+
+func TestDependencyOrdering(t *testing.T) {
+	upstreamClient := mockFlakyUpstreamClient{streams: make(chan chan *v2.DiscoveryResponse, 10)}
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), upstreamClient)
+	WithDependencyOrdering(&bootstrapv1.DependencyOrdering{Window: ptypes.DurationProto(100 * time.Millisecond)})(
+		orchestrator)
+
+	req := newRolloutRequest("node", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	stream := <-upstreamClient.streams
+	resp1 := newRolloutResponse("1")
+	stream <- resp1
+
+	// The response is held until the end of the window.
+	select {
+	case <-respChannel:
+		t.Fatal("response fanned out before the end of the window")
+	case <-time.After(20 * time.Millisecond):
+	}
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.dependency_waves", 1)
+}
+
+func TestFlushDependencyOrdering(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockUnusedUpstreamClient{t: t})
+	WithDependencyOrdering(&bootstrapv1.DependencyOrdering{Window: ptypes.DurationProto(time.Hour)})(orchestrator)
+	for key, resp := range newDependentResponses(t) {
+		orchestrator.holdUpstreamResponse(context.Background(), key, resp)
+	}
+	orchestrator.flushDependencyOrdering()
+
+	for key := range newDependentResponses(t) {
+		assert.True(t, orchestrator.hasCachedResponse(key), key)
+	}
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.dependency_waves", 2)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.dependency_deferred", 2)
+}
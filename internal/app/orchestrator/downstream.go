@@ -12,77 +12,300 @@
 package orchestrator
 
 import (
+	"sort"
 	"sync"
+	"time"
 
 	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
 	"github.com/uber-go/tally"
 )
 
 const (
 	metricCreateChannel = "create_channel"
+	metricOpenWatches   = "open_watches"
 )
 
-// downstreamResponseMap is a map of downstream xDS client requests to response
-// channels.
+// WatchStatus describes an open downstream watch.
+type WatchStatus struct {
+	ID            cache.WatchID
+	Key           string
+	NodeID        string
+	TypeURL       string
+	ResourceNames []string
+	// Version is the version the client last acknowledged.
+	Version    string
+	CreateTime time.Time
+	// SentTime is the time the watch was sent a response, or the zero time
+	// if it is still waiting for one.
+	SentTime time.Time
+}
+
+func (o *orchestrator) GetWatches() []WatchStatus {
+	return o.downstreamResponseMap.list()
+}
+
+// downstreamWatch is an open downstream watch.
+type downstreamWatch struct {
+	aggregatedKey string
+	req           *gcp.Request
+	channel       chan gcp.Response
+	buffer        bufferConfig
+	createTime    time.Time
+	sentTime      time.Time
+	// streamID is the ID of the downstream stream of the watch, or zero if
+	// the watch is of a fetch.
+	streamID int64
+	// connection is the downstream connection whose send loop the responses
+	// of the watch are sent from.
+	connection connectionID
+	// done is closed once the watch is removed, which releases the sends
+	// blocked on its channel. senders counts the blocked sends, which must
+	// complete before the channel is closed.
+	done    chan struct{}
+	senders sync.WaitGroup
+}
+
+// downstreamResponseMap is the index of open downstream watches by watch ID.
+// Watches are identified by ID rather than by their request, so that the
+// requests of watches can be copied and serialized.
 type downstreamResponseMap struct {
-	mu               sync.RWMutex
-	responseChannels map[*gcp.Request]chan gcp.Response
-	scope            tally.Scope
+	mu      sync.RWMutex
+	lastID  cache.WatchID
+	watches map[cache.WatchID]*downstreamWatch
+	scope   tally.Scope
+	// buffers sizes the channels of the watches. The default buffer applies
+	// to every watch if it is nil.
+	buffers *responseBuffers
 }
 
 func newDownstreamResponseMap(scope tally.Scope) downstreamResponseMap {
 	return downstreamResponseMap{
-		responseChannels: make(map[*gcp.Request]chan gcp.Response),
-		scope:            scope,
+		watches: make(map[cache.WatchID]*downstreamWatch),
+		scope:   scope,
 	}
 }
 
-// createChannel initializes a new channel for a request if it doesn't already
-// exist.
-func (d *downstreamResponseMap) createChannel(req *gcp.Request) chan gcp.Response {
+// createChannel registers a new watch for the request, which was received on
+// the stream with the ID, if any, from the peer with the address, if known,
+// and returns its ID along with the channel where its responses are sent.
+func (d *downstreamResponseMap) createChannel(
+	aggregatedKey string,
+	req *gcp.Request,
+	streamID int64,
+	peer string,
+) (cache.WatchID, chan gcp.Response) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	if _, ok := d.responseChannels[req]; !ok {
-		d.responseChannels[req] = make(chan gcp.Response, 1)
+	d.lastID++
+	buffer := d.buffers.downstreamFor(req.GetTypeUrl())
+	watch := &downstreamWatch{
+		aggregatedKey: aggregatedKey,
+		req:           req,
+		channel:       make(chan gcp.Response, buffer.size),
+		buffer:        buffer,
+		createTime:    time.Now(),
+		done:          make(chan struct{}),
+		streamID:      streamID,
+		connection:    connectionID{peer: peer},
+	}
+	if peer == "" {
+		watch.connection = connectionID{watch: d.lastID}
 	}
+	d.watches[d.lastID] = watch
+	d.scope.Gauge(metricOpenWatches).Update(float64(len(d.watches)))
 	d.scope.Counter(metricCreateChannel).Inc(1)
-	return d.responseChannels[req]
+	return d.lastID, watch.channel
 }
 
-// get retrieves the channel where responses are set for the specified request.
-func (d *downstreamResponseMap) get(req *gcp.Request) (chan gcp.Response, bool) {
+// get retrieves the channel where responses are set for the specified watch.
+func (d *downstreamResponseMap) get(id cache.WatchID) (chan gcp.Response, bool) {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
-	channel, ok := d.responseChannels[req]
-	return channel, ok
+	watch, ok := d.watches[id]
+	if !ok {
+		return nil, false
+	}
+	return watch.channel, true
 }
 
-// delete removes the response channel and request entry from the map.
-// Note: We don't close the response channel prior to deletion because there
-// can be separate go routines that are still attempting to write to the
-// channel. We rely on garbage collection to clean up and close outstanding
-// response channels once the go routines finish writing to them.
-func (d *downstreamResponseMap) delete(req *gcp.Request) chan gcp.Response {
+// streamID returns the ID of the downstream stream of the watch, or zero if
+// the watch is of a fetch or is not open.
+func (d *downstreamResponseMap) streamID(id cache.WatchID) int64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if watch, ok := d.watches[id]; ok {
+		return watch.streamID
+	}
+	return 0
+}
+
+// connection returns the downstream connection of the watch. A watch that is
+// not open is given a connection of its own.
+func (d *downstreamResponseMap) connection(id cache.WatchID) connectionID {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if watch, ok := d.watches[id]; ok {
+		return watch.connection
+	}
+	return connectionID{watch: id}
+}
+
+// send pushes the response to the channel of the watch. It returns false if
+// the watch is not open, or if its channel is full and the overflow policy of
+// its buffer drops the response. The send holds the lock, so that it never
+// races with terminate closing the channel, unless the overflow policy blocks
+// on a full channel. Blocked sends wait without the lock until there is room,
+// or until the watch is removed.
+func (d *downstreamResponseMap) send(id cache.WatchID, resp gcp.Response) (sent bool, found bool) {
+	d.mu.Lock()
+	watch, ok := d.watches[id]
+	if !ok {
+		d.mu.Unlock()
+		return false, false
+	}
+	if len(watch.channel) == cap(watch.channel) {
+		d.overflow(watch)
+	}
+	select {
+	case watch.channel <- resp:
+		watch.sentTime = time.Now()
+		d.scope.Gauge(metricBufferOccupancy).Update(float64(len(watch.channel)))
+		d.mu.Unlock()
+		return true, true
+	default:
+	}
+	if watch.buffer.policy != bootstrapv1.Buffers_BLOCK {
+		d.mu.Unlock()
+		return false, true
+	}
+	d.scope.Counter(metricBufferBlocked).Inc(1)
+	watch.senders.Add(1)
+	d.mu.Unlock()
+	select {
+	case watch.channel <- resp:
+		sent = true
+	case <-watch.done:
+	}
+	watch.senders.Done()
+	if sent {
+		d.mu.Lock()
+		watch.sentTime = time.Now()
+		d.mu.Unlock()
+	}
+	return sent, true
+}
+
+// overflow makes room in the full channel of the watch, as the overflow
+// policy of its buffer requires. The responses in the channel are only ever
+// superseded by newer responses.
+func (d *downstreamResponseMap) overflow(watch *downstreamWatch) {
+	switch watch.buffer.policy {
+	case bootstrapv1.Buffers_DROP_OLDEST:
+		select {
+		case <-watch.channel:
+			d.scope.Counter(metricBufferDropped).Inc(1)
+		default:
+		}
+	case bootstrapv1.Buffers_COALESCE_LATEST:
+		for {
+			select {
+			case <-watch.channel:
+				d.scope.Counter(metricBufferCoalesced).Inc(1)
+				continue
+			default:
+			}
+			return
+		}
+	}
+}
+
+// idle returns the aggregated keys of the watches that were last sent a
+// response more than timeout ago, by watch ID. go-control-plane cancels a
+// watch once its client acknowledges or rejects the response, so these
+// clients have not done either.
+func (d *downstreamResponseMap) idle(timeout time.Duration) map[cache.WatchID]string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	deadline := time.Now().Add(-timeout)
+	idle := make(map[cache.WatchID]string)
+	for id, watch := range d.watches {
+		if !watch.sentTime.IsZero() && watch.sentTime.Before(deadline) {
+			idle[id] = watch.aggregatedKey
+		}
+	}
+	return idle
+}
+
+// terminate closes the response channels of the watches and removes them
+// from the map. go-control-plane closes the stream of a watch whose channel is
+// closed with a retryable status. If set, onTerminate is called with the
+// stream ID of each open watch before its channel is closed.
+func (d *downstreamResponseMap) terminate(
+	watches map[cache.WatchID]*gcp.Request,
+	onTerminate func(streamID int64),
+) int {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	if channel, ok := d.responseChannels[req]; ok {
-		delete(d.responseChannels, req)
-		return channel
+	terminated := 0
+	for id := range watches {
+		if watch, ok := d.watches[id]; ok {
+			if onTerminate != nil {
+				onTerminate(watch.streamID)
+			}
+			close(watch.done)
+			watch.senders.Wait()
+			close(watch.channel)
+			delete(d.watches, id)
+			terminated++
+		}
 	}
-	return nil
+	d.scope.Gauge(metricOpenWatches).Update(float64(len(d.watches)))
+	return terminated
 }
 
-// deleteAll removes all response channels and request entries from the map.
+// len returns the number of open watches.
+func (d *downstreamResponseMap) len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.watches)
+}
+
+// list returns the status of the open watches, ordered by ID.
+func (d *downstreamResponseMap) list() []WatchStatus {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	statuses := make([]WatchStatus, 0, len(d.watches))
+	for id, watch := range d.watches {
+		statuses = append(statuses, WatchStatus{
+			ID:            id,
+			Key:           watch.aggregatedKey,
+			NodeID:        watch.req.GetNode().GetId(),
+			TypeURL:       watch.req.GetTypeUrl(),
+			ResourceNames: watch.req.GetResourceNames(),
+			Version:       watch.req.GetVersionInfo(),
+			CreateTime:    watch.createTime,
+			SentTime:      watch.sentTime,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ID < statuses[j].ID })
+	return statuses
+}
+
+// delete removes the watch from the map and returns its response channel.
 // Note: We don't close the response channel prior to deletion because there
 // can be separate go routines that are still attempting to write to the
 // channel. We rely on garbage collection to clean up and close outstanding
 // response channels once the go routines finish writing to them.
-func (d *downstreamResponseMap) deleteAll(watchers map[*gcp.Request]bool) {
+func (d *downstreamResponseMap) delete(id cache.WatchID) chan gcp.Response {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	for watch := range watchers {
-		if d.responseChannels[watch] != nil {
-			delete(d.responseChannels, watch)
-		}
+	if watch, ok := d.watches[id]; ok {
+		delete(d.watches, id)
+		close(watch.done)
+		d.scope.Gauge(metricOpenWatches).Update(float64(len(d.watches)))
+		return watch.channel
 	}
+	return nil
 }
@@ -0,0 +1,204 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file defines the errors of the orchestrator, and maps the errors that
+// fail downstream streams and fetches to gRPC statuses.
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	statusv1 "github.com/envoyproxy/xds-relay/pkg/api/status/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrWatchFailed is returned by Fetch if the watch of the request is
+	// closed before a response is received.
+	ErrWatchFailed = errors.New("watch failed")
+	// ErrFetchTimeout is returned by Fetch if no response is received for the
+	// request in time.
+	ErrFetchTimeout = errors.New("timed out waiting for a response")
+	// ErrKeyNotCached is returned by PinVersion for aggregated keys without a
+	// cached response.
+	ErrKeyNotCached = errors.New("no response cached")
+	// ErrVersionNotHeld is returned by PinVersion for versions that are
+	// neither cached, held by a rollout, nor in the history.
+	ErrVersionNotHeld = errors.New("not held by the relay")
+	// ErrKeyEvicted closes the watches of aggregated keys that are evicted
+	// from the cache.
+	ErrKeyEvicted = errors.New("evicted from the cache")
+	// ErrWatchIdle closes the watches whose clients neither acknowledged nor
+	// rejected the response sent to them within the idle timeout.
+	ErrWatchIdle = errors.New("watch idle")
+	// ErrRelayLoop rejects the requests that already passed through the
+	// relay.
+	ErrRelayLoop = errors.New("request already passed through this relay")
+	// ErrTooManyHops rejects the requests that passed through more relays
+	// than allowed.
+	ErrTooManyHops = errors.New("request passed through too many relays")
+)
+
+// KeyError is an error of a request for an aggregated key.
+type KeyError struct {
+	Key string
+	Err error
+}
+
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("%s for aggregated key %s", e.Err.Error(), e.Key)
+}
+
+// Unwrap returns the error of the aggregated key.
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// Status returns the gRPC status of a downstream stream or fetch that failed
+// with err. The status carries a statusv1.ErrorInfo detail with the reason
+// of the failure and the aggregated key, if any. Errors that are gRPC
+// statuses already are returned as is.
+func Status(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	code, reason := classify(err)
+	st := status.New(code, err.Error())
+	info := &statusv1.ErrorInfo{Reason: reason}
+	var keyErr *KeyError
+	if errors.As(err, &keyErr) {
+		info.Key = keyErr.Key
+	}
+	if detailed, detailErr := st.WithDetails(info); detailErr == nil {
+		return detailed
+	}
+	return st
+}
+
+// classify returns the status code and the reason of the error.
+func classify(err error) (codes.Code, string) {
+	switch {
+	case errors.Is(err, mapper.ErrKeyNotMapped):
+		return codes.InvalidArgument, "KEY_NOT_MAPPED"
+	case errors.Is(err, upstream.ErrUnsupportedResource):
+		return codes.Unimplemented, "UNSUPPORTED_RESOURCE"
+	case errors.Is(err, upstream.ErrUpstreamUnavailable):
+		return codes.Unavailable, "UPSTREAM_UNAVAILABLE"
+	case errors.Is(err, cache.ErrKeyNotFound), errors.Is(err, ErrKeyNotCached):
+		return codes.NotFound, "KEY_NOT_CACHED"
+	case errors.Is(err, ErrVersionNotHeld):
+		return codes.NotFound, "VERSION_NOT_HELD"
+	case errors.Is(err, cache.ErrCacheCastFailure):
+		return codes.Internal, "CACHE_CAST_FAILURE"
+	case errors.Is(err, ErrKeyEvicted):
+		return codes.Unavailable, "KEY_EVICTED"
+	case errors.Is(err, ErrWatchIdle):
+		return codes.Unavailable, "WATCH_IDLE"
+	case errors.Is(err, ErrRelayLoop):
+		return codes.FailedPrecondition, "RELAY_LOOP"
+	case errors.Is(err, ErrTooManyHops):
+		return codes.FailedPrecondition, "TOO_MANY_HOPS"
+	case errors.Is(err, ErrWatchFailed):
+		return codes.Unavailable, "WATCH_FAILED"
+	case errors.Is(err, ErrFetchTimeout), errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded, "TIMEOUT"
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled, "CANCELED"
+	default:
+		return codes.Unknown, "UNKNOWN"
+	}
+}
+
+// statusError returns err as a gRPC status error.
+func statusError(err error) error {
+	return Status(err).Err()
+}
+
+// streamFailure holds the error that the relay closed a watch of a
+// downstream stream with. go-control-plane fails the stream of a closed watch
+// with a generic status, which StreamServerInterceptor replaces with the
+// status of the error.
+type streamFailure struct {
+	mu  sync.Mutex
+	err error
+}
+
+// set records err, unless an earlier error was recorded.
+func (f *streamFailure) set(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err == nil {
+		f.err = err
+	}
+}
+
+func (f *streamFailure) get() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+type streamFailureKey struct{}
+
+func streamFailureFromContext(ctx context.Context) *streamFailure {
+	failure, _ := ctx.Value(streamFailureKey{}).(*streamFailure)
+	return failure
+}
+
+// failureStream is a server stream whose context carries a stream failure.
+type failureStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *failureStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamServerInterceptor fails the downstream streams whose watches the
+// relay closed with the status of the error that closed them, rather than
+// the generic status of go-control-plane.
+func (o *orchestrator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		failure := &streamFailure{}
+		err := handler(srv, &failureStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), streamFailureKey{}, failure),
+		})
+		if err == nil {
+			return nil
+		}
+		if cause := failure.get(); cause != nil {
+			return statusError(cause)
+		}
+		return err
+	}
+}
+
+// terminateWatches closes the open watches, and records err as the reason
+// they were closed. It returns the number of watches that were closed.
+func (o *orchestrator) terminateWatches(watches map[cache.WatchID]*gcp.Request, err error) int {
+	return o.downstreamResponseMap.terminate(watches, func(streamID int64) {
+		o.failWatch(streamID, err)
+	})
+}
+
+// failWatch records err as the reason a watch of the downstream stream was
+// closed, so that the stream fails with the status of err. It is ignored for
+// the watches of fetches, whose stream ID is zero.
+func (o *orchestrator) failWatch(streamID int64, err error) {
+	o.requestIDs.fail(streamID, err)
+}
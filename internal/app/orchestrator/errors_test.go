@@ -0,0 +1,157 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	statusv1 "github.com/envoyproxy/xds-relay/pkg/api/status/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func errorInfo(t *testing.T, st *status.Status) *statusv1.ErrorInfo {
+	details := st.Details()
+	assert.Equal(t, 1, len(details))
+	info, ok := details[0].(*statusv1.ErrorInfo)
+	assert.True(t, ok)
+	return info
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{&mapper.UnmatchedRequestError{Reject: true}, codes.InvalidArgument, "KEY_NOT_MAPPED"},
+		{&upstream.UnsupportedResourceError{}, codes.Unimplemented, "UNSUPPORTED_RESOURCE"},
+		{fmt.Errorf("%w: connection refused", upstream.ErrUpstreamUnavailable), codes.Unavailable, "UPSTREAM_UNAVAILABLE"},
+		{fmt.Errorf("%w: lds", cache.ErrKeyNotFound), codes.NotFound, "KEY_NOT_CACHED"},
+		{fmt.Errorf("%w: lds", cache.ErrCacheCastFailure), codes.Internal, "CACHE_CAST_FAILURE"},
+		{ErrVersionNotHeld, codes.NotFound, "VERSION_NOT_HELD"},
+		{ErrWatchIdle, codes.Unavailable, "WATCH_IDLE"},
+		{fmt.Errorf("%w: hops [a]", ErrRelayLoop), codes.FailedPrecondition, "RELAY_LOOP"},
+		{ErrTooManyHops, codes.FailedPrecondition, "TOO_MANY_HOPS"},
+		{context.Canceled, codes.Canceled, "CANCELED"},
+		{context.DeadlineExceeded, codes.DeadlineExceeded, "TIMEOUT"},
+		{errors.New("error"), codes.Unknown, "UNKNOWN"},
+	}
+	for _, tt := range tests {
+		st := Status(tt.err)
+		assert.Equal(t, tt.code, st.Code())
+		assert.Equal(t, tt.err.Error(), st.Message())
+		info := errorInfo(t, st)
+		assert.Equal(t, tt.reason, info.GetReason())
+		assert.Equal(t, "", info.GetKey())
+	}
+}
+
+func TestStatus_KeyError(t *testing.T) {
+	st := Status(&KeyError{Key: "lds", Err: ErrKeyEvicted})
+	assert.Equal(t, codes.Unavailable, st.Code())
+	assert.Equal(t, "evicted from the cache for aggregated key lds", st.Message())
+	info := errorInfo(t, st)
+	assert.Equal(t, "KEY_EVICTED", info.GetReason())
+	assert.Equal(t, "lds", info.GetKey())
+
+	st = Status(&KeyError{Key: "lds", Err: ErrFetchTimeout})
+	assert.Equal(t, codes.DeadlineExceeded, st.Code())
+	assert.Equal(t, "lds", errorInfo(t, st).GetKey())
+}
+
+func TestStatus_GRPCStatus(t *testing.T) {
+	err := status.Error(codes.PermissionDenied, "denied")
+	st := Status(err)
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+	assert.Empty(t, st.Details())
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)})
+	interceptor := orchestrator.StreamServerInterceptor()
+	ss := &mockServerStream{ctx: context.Background()}
+	req := &v2.DiscoveryRequest{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &v2_core.Node{Id: "node"},
+	}
+
+	// Streams whose watches were closed by the relay fail with the status of
+	// the error that closed them.
+	err := interceptor(nil, ss, &grpc.StreamServerInfo{}, func(srv interface{}, ss grpc.ServerStream) error {
+		assert.NoError(t, orchestrator.OnStreamOpen(ss.Context(), 1, ""))
+		defer orchestrator.OnStreamClosed(1)
+		assert.NoError(t, orchestrator.OnStreamRequest(1, req))
+		orchestrator.failWatch(1, &KeyError{Key: "lds", Err: ErrKeyEvicted})
+		return status.Error(codes.Unavailable, "listeners watch failed")
+	})
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Unavailable, st.Code())
+	assert.Equal(t, "evicted from the cache for aggregated key lds", st.Message())
+	assert.Equal(t, "KEY_EVICTED", errorInfo(t, st).GetReason())
+
+	// Other errors are returned as is.
+	err = interceptor(nil, ss, &grpc.StreamServerInfo{}, func(srv interface{}, ss grpc.ServerStream) error {
+		assert.NoError(t, orchestrator.OnStreamOpen(ss.Context(), 2, ""))
+		defer orchestrator.OnStreamClosed(2)
+		assert.NoError(t, orchestrator.OnStreamRequest(2, req))
+		return status.Error(codes.Canceled, "canceled")
+	})
+	assert.Equal(t, codes.Canceled, status.Code(err))
+}
+
+func TestFailWatch(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)})
+	failure := &streamFailure{}
+	ctx := context.WithValue(context.Background(), streamFailureKey{}, failure)
+	assert.NoError(t, orchestrator.OnStreamOpen(ctx, 1, ""))
+	defer orchestrator.OnStreamClosed(1)
+
+	req := &v2.DiscoveryRequest{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &v2_core.Node{Id: "node"},
+	}
+	assert.NoError(t, orchestrator.OnStreamRequest(1, req))
+	rejected := &mapper.UnmatchedRequestError{Reject: true}
+	orchestrator.failWatch(1, rejected)
+	// Only the first failure of the stream is kept.
+	orchestrator.failWatch(1, ErrWatchIdle)
+	assert.Equal(t, rejected, failure.get())
+	assert.Equal(t, codes.InvalidArgument, Status(failure.get()).Code())
+}
+
+func TestTerminateWatchesWithNewNodes(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), typeURLMapper{},
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)})
+	failure := &streamFailure{}
+	ctx := context.WithValue(context.Background(), streamFailureKey{}, failure)
+	assert.NoError(t, orchestrator.OnStreamOpen(ctx, 1, ""))
+	defer orchestrator.OnStreamClosed(1)
+
+	// Envoy sends its node on every request, so the watch of the first
+	// request is no longer attributed to the stream by its node.
+	for _, typeURL := range []string{upstream.ListenerTypeURL, upstream.ClusterTypeURL} {
+		req := &v2.DiscoveryRequest{TypeUrl: typeURL, Node: &v2_core.Node{Id: "node"}}
+		assert.NoError(t, orchestrator.OnStreamRequest(1, req))
+		_, cancelWatch := orchestrator.CreateWatch(*req)
+		defer cancelWatch()
+	}
+
+	evicted := &KeyError{Key: upstream.ListenerTypeURL, Err: ErrKeyEvicted}
+	first := orchestrator.downstreamResponseMap.list()[0]
+	assert.Equal(t, upstream.ListenerTypeURL, first.TypeURL)
+	assert.Equal(t, 1, orchestrator.terminateWatches(map[cache.WatchID]*gcp.Request{first.ID: nil}, evicted))
+	assert.Equal(t, evicted, failure.get())
+}
@@ -0,0 +1,100 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file evicts keys on demand and once they expire, and resubscribes the
+// downstream watchers of evicted keys. The contents of this file are intended
+// to only be used within the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+)
+
+const (
+	metricCacheEvict       = "cache_evict"
+	metricEvictTerminate   = "cache_evict_terminate"
+	metricEvictResubscribe = "cache_evict_resubscribe"
+)
+
+func (o *orchestrator) EvictLeastRecentlyUsed(n int) int {
+	return o.cache.EvictOldest(n)
+}
+
+// removeExpiredPeriodically removes the expired keys of the cache every
+// interval until ctx is done, so that keys that are no longer requested do not
+// stay cached until they are evicted by size.
+func (o *orchestrator) removeExpiredPeriodically(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if removed := o.cache.RemoveExpired(); removed > 0 {
+				o.logger.With("keys", removed).Debug(ctx, "removed expired keys")
+			}
+		}
+	}
+}
+
+// cacheStrategy returns the cache eviction strategy of the configured one.
+func cacheStrategy(strategy bootstrapv1.Cache_EvictionStrategy) cache.Strategy {
+	switch strategy {
+	case bootstrapv1.Cache_LFU:
+		return cache.StrategyLFU
+	case bootstrapv1.Cache_TTL_ONLY:
+		return cache.StrategyTTLOnly
+	default:
+		return cache.StrategyLRU
+	}
+}
+
+// resubscribeEvicted adds the watchers of the evicted key back to the cache,
+// and opens a new upstream stream with the representative request to serve
+// them. Watchers that were cancelled in the meantime are skipped.
+func (o *orchestrator) resubscribeEvicted(
+	aggregatedKey string,
+	req gcp.Request,
+	watchers map[cache.WatchID]*gcp.Request,
+) {
+	ctx := context.Background()
+	resubscribed := 0
+	for id, watch := range watchers {
+		if _, ok := o.downstreamResponseMap.get(id); !ok {
+			continue
+		}
+		if err := o.cache.AddRequest(aggregatedKey, id, watch); err != nil {
+			o.logger.With("err", err).With("key", aggregatedKey).Error(ctx, "failed to resubscribe watch")
+			o.terminateWatches(map[cache.WatchID]*gcp.Request{id: watch}, &KeyError{Key: aggregatedKey, Err: err})
+			continue
+		}
+		// The watch may have been cancelled before it was added back.
+		if _, ok := o.downstreamResponseMap.get(id); !ok {
+			_ = o.cache.DeleteRequest(aggregatedKey, id)
+			continue
+		}
+		resubscribed++
+	}
+	o.keyScope(aggregatedKey).Counter(metricEvictResubscribe).Inc(int64(resubscribed))
+	if resubscribed == 0 {
+		return
+	}
+	o.logger.With("key", aggregatedKey).With("watches", resubscribed).Info(ctx, "resubscribing evicted key")
+
+	o.setRepresentative(aggregatedKey, req)
+	o.upstreamMu.RLock()
+	defer o.upstreamMu.RUnlock()
+	if o.isLeader() {
+		o.openUpstream(ctx, aggregatedKey, req)
+	}
+}
@@ -0,0 +1,136 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+)
+
+const evictionTTL = 20 * time.Millisecond
+
+// newEvictingOrchestrator returns an orchestrator whose cache entries expire
+// after evictionTTL.
+func newEvictingOrchestrator(
+	t *testing.T,
+	mockScope tally.Scope,
+	upstreamClient upstream.Client,
+	policy bootstrapv1.Cache_EvictionPolicy,
+) *orchestrator {
+	orchestrator := newMockOrchestrator(t, mockScope, typeURLMapper{}, upstreamClient)
+	orchestrator.evictionPolicy = policy
+	evictingCache, err := cache.NewCache(1000, orchestrator.onCacheEvicted, evictionTTL)
+	assert.NoError(t, err)
+	orchestrator.cache = evictingCache
+	return orchestrator
+}
+
+func TestEvictionTerminate(t *testing.T) {
+	baseline := testutils.CurrentGoroutines()
+	upstreamClient := newMockSubscriptionUpstreamClient()
+	mockScope := newMockScope("prefix")
+	orchestrator := newEvictingOrchestrator(t, mockScope, upstreamClient, bootstrapv1.Cache_TERMINATE)
+
+	respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{TypeUrl: upstream.ListenerTypeURL})
+	defer cancelWatch()
+	<-upstreamClient.requests
+
+	// Fetching the expired key evicts it.
+	time.Sleep(2 * evictionTTL)
+	_, _ = orchestrator.cache.Fetch(upstream.ListenerTypeURL)
+
+	_, more := <-respChannel
+	assert.False(t, more)
+	<-upstreamClient.shutdown
+	_, ok := orchestrator.representativeRequests.Load(upstream.ListenerTypeURL)
+	assert.False(t, ok)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.cache_evict", 1)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.cache_evict_terminate", 1)
+	testutils.AssertNoLeakedGoroutines(t, baseline)
+}
+
+func TestEvictionResubscribe(t *testing.T) {
+	upstreamClient := newMockSubscriptionUpstreamClient()
+	mockScope := newMockScope("prefix")
+	orchestrator := newEvictingOrchestrator(t, mockScope, upstreamClient, bootstrapv1.Cache_RESUBSCRIBE)
+
+	req := gcp.Request{TypeUrl: upstream.EndpointTypeURL, ResourceNames: []string{"a"}}
+	_, cancelWatch := orchestrator.CreateWatch(req)
+	// Cancelled watches are not resubscribed.
+	_, cancelCancelled := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl:       upstream.EndpointTypeURL,
+		ResourceNames: []string{"b"},
+	})
+	<-upstreamClient.requests
+	<-upstreamClient.shutdown
+	<-upstreamClient.requests
+	cancelCancelled()
+	<-upstreamClient.shutdown
+	<-upstreamClient.requests
+
+	time.Sleep(2 * evictionTTL)
+	_, _ = orchestrator.cache.Fetch(upstream.EndpointTypeURL)
+
+	// The upstream stream is reopened for the remaining watch.
+	<-upstreamClient.shutdown
+	assert.Equal(t, req.ResourceNames, (<-upstreamClient.requests).ResourceNames)
+	resource, err := orchestrator.cache.Fetch(upstream.EndpointTypeURL)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resource.Requests))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.cache_evict_resubscribe", 1)
+
+	cancelWatch()
+	resource, err = orchestrator.cache.Fetch(upstream.EndpointTypeURL)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(resource.Requests))
+}
+
+func TestRemoveExpiredPeriodically(t *testing.T) {
+	upstreamClient := newMockSubscriptionUpstreamClient()
+	mockScope := newMockScope("prefix")
+	orchestrator := newEvictingOrchestrator(t, mockScope, upstreamClient, bootstrapv1.Cache_TERMINATE)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go orchestrator.removeExpiredPeriodically(ctx, evictionTTL)
+
+	respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{TypeUrl: upstream.ListenerTypeURL})
+	defer cancelWatch()
+	<-upstreamClient.requests
+
+	// The expired key is evicted without being fetched.
+	_, more := <-respChannel
+	assert.False(t, more)
+	<-upstreamClient.shutdown
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.cache_evict", 1)
+}
+
+func TestEvictLeastRecentlyUsed(t *testing.T) {
+	upstreamClient := newMockSubscriptionUpstreamClient()
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, typeURLMapper{}, upstreamClient)
+
+	respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{TypeUrl: upstream.ListenerTypeURL})
+	defer cancelWatch()
+	<-upstreamClient.requests
+
+	// Keys evicted on demand are cleaned up like expired keys.
+	assert.Equal(t, 1, orchestrator.EvictLeastRecentlyUsed(2))
+	_, more := <-respChannel
+	assert.False(t, more)
+	<-upstreamClient.shutdown
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.cache_evict", 1)
+	assert.Equal(t, 0, orchestrator.EvictLeastRecentlyUsed(1))
+}
+
+func TestCacheStrategy(t *testing.T) {
+	assert.Equal(t, cache.StrategyLRU, cacheStrategy(bootstrapv1.Cache_LRU))
+	assert.Equal(t, cache.StrategyLFU, cacheStrategy(bootstrapv1.Cache_LFU))
+	assert.Equal(t, cache.StrategyTTLOnly, cacheStrategy(bootstrapv1.Cache_TTL_ONLY))
+}
@@ -0,0 +1,113 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file fetches the responses of aggregated keys that are not cached with
+// a single shot upstream stream, for unary Fetch requests. The contents of
+// this file are intended to only be used within the orchestrator module and
+// should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	metricFetchSingleShot = "fetch_single_shot"
+)
+
+// newFetchTimeout returns the fetch timeout of the config, or zero if the
+// config leaves it to the default.
+func newFetchTimeout(config *bootstrapv1.Fetch) (time.Duration, error) {
+	if config.GetTimeout() == nil {
+		return 0, nil
+	}
+	return ptypes.Duration(config.GetTimeout())
+}
+
+// getFetchTimeout returns the maximum time Fetch waits for the upstream
+// response of an aggregated key that is not cached.
+func (o *orchestrator) getFetchTimeout() time.Duration {
+	if o.fetchTimeout > 0 {
+		return o.fetchTimeout
+	}
+	return fetchTimeout
+}
+
+// isSingleShotFetch returns true if the upstream response of the aggregated
+// key is fetched with a single shot stream, rather than by opening a stream
+// that is kept open like the streams of streaming watches.
+func (o *orchestrator) isSingleShotFetch(aggregatedKey string) bool {
+	return o.singleShotFetch && o.isLeader() && !o.upstreamResponseMap.exists(aggregatedKey)
+}
+
+// fetchOnce opens an upstream stream for the aggregated key, caches its first
+// accepted response, and closes the stream. The response is cached and fanned
+// out like the responses of streams that are kept open.
+func (o *orchestrator) fetchOnce(
+	ctx context.Context,
+	aggregatedKey string,
+	req gcp.Request,
+) (gcp.Response, error) {
+	upstreamReq := req
+	if o.relayChain != nil {
+		upstreamReq = o.relayChain.forward(upstreamReq)
+	}
+	upstreamClient, _, err := o.upstreamClientFor(aggregatedKey)
+	if err != nil {
+		return nil, statusError(&KeyError{Key: aggregatedKey, Err: err})
+	}
+	responseChannel, _, shutdown, err := openStream(upstreamClient, aggregatedKey, upstreamReq, requestIDFromContext(ctx))
+	if err != nil {
+		o.logger.With("err", err).With("key", aggregatedKey).
+			Error(logRequestID(ctx), "Failed to open single shot stream to origin server")
+		return nil, statusError(&KeyError{Key: aggregatedKey, Err: err})
+	}
+	defer shutdown()
+	o.keyScope(aggregatedKey).Counter(metricFetchSingleShot).Inc(1)
+
+	timer := time.NewTimer(o.getFetchTimeout())
+	defer timer.Stop()
+	for {
+		select {
+		case x, ok := <-responseChannel:
+			if !ok {
+				return nil, statusError(&KeyError{Key: aggregatedKey, Err: ErrWatchFailed})
+			}
+			if !o.isVerified(ctx, aggregatedKey, x) || !o.isValid(ctx, aggregatedKey, x) {
+				continue
+			}
+			x, ok = o.transform(ctx, aggregatedKey, x)
+			if !ok {
+				continue
+			}
+			var previous *discovery.DiscoveryResponse
+			if cached, err := o.cache.Fetch(aggregatedKey); err == nil && cached != nil {
+				previous = cached.Resp
+			}
+			o.applyResponse(ctx, aggregatedKey, previous, x)
+			cached, err := o.cache.Fetch(aggregatedKey)
+			if err != nil || cached == nil || cached.Resp == nil {
+				return nil, statusError(&KeyError{Key: aggregatedKey, Err: ErrWatchFailed})
+			}
+			served := o.servedResponse(aggregatedKey, req.GetNode(), cached)
+			if o.auditLog != nil {
+				o.auditLog.RecordServed(aggregatedKey, req.GetNode().GetId(), served)
+			}
+			return convertToGcpResponse(served, req), nil
+		case <-ctx.Done():
+			return nil, statusError(ctx.Err())
+		case <-timer.C:
+			return nil, statusError(&KeyError{Key: aggregatedKey, Err: ErrFetchTimeout})
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package orchestrator
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockCountingUpstreamClient struct {
+	responseChan <-chan *v2.DiscoveryResponse
+	opened       *int32
+	shutdown     *int32
+}
+
+func (m mockCountingUpstreamClient) OpenStream(req v2.DiscoveryRequest) (<-chan *v2.DiscoveryResponse, func(), error) {
+	atomic.AddInt32(m.opened, 1)
+	return m.responseChan, func() { atomic.AddInt32(m.shutdown, 1) }, nil
+}
+
+func TestFetchSingleShot(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse, 1)
+	var opened, shutdown int32
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockCountingUpstreamClient{
+		responseChan: upstreamResponseChannel,
+		opened:       &opened,
+		shutdown:     &shutdown,
+	})
+	WithFetch(&bootstrapv1.Fetch{SingleShot: true})(orchestrator)
+
+	req := v2.DiscoveryRequest{TypeUrl: upstream.ListenerTypeURL}
+	resp := newResponse(t, upstream.ListenerTypeURL, "1", &v2.Listener{Name: "listener"})
+	upstreamResponseChannel <- resp
+	got, err := orchestrator.Fetch(context.Background(), req)
+	assert.NoError(t, err)
+	assertEqualResponse(t, got, *resp, req)
+	// The upstream stream is closed after the first response.
+	assert.Equal(t, int32(1), atomic.LoadInt32(&opened))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&shutdown))
+	assert.False(t, orchestrator.upstreamResponseMap.exists("lds"))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.fetch_single_shot", 1)
+
+	// Subsequent fetches are served from the cache.
+	got, err = orchestrator.Fetch(context.Background(), req)
+	assert.NoError(t, err)
+	assertEqualResponse(t, got, *resp, req)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&opened))
+}
+
+func TestFetchTimeout(t *testing.T) {
+	var opened, shutdown int32
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockCountingUpstreamClient{
+		responseChan: make(chan *v2.DiscoveryResponse),
+		opened:       &opened,
+		shutdown:     &shutdown,
+	})
+	WithFetch(&bootstrapv1.Fetch{Timeout: ptypes.DurationProto(50 * time.Millisecond), SingleShot: true})(orchestrator)
+
+	fetched := make(chan error)
+	go func() {
+		got, err := orchestrator.Fetch(context.Background(), v2.DiscoveryRequest{TypeUrl: upstream.ListenerTypeURL})
+		assert.Nil(t, got)
+		fetched <- err
+	}()
+	select {
+	case err := <-fetched:
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), ErrFetchTimeout.Error())
+	case <-time.After(time.Second):
+		t.Fatal("fetch did not time out")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&shutdown))
+}
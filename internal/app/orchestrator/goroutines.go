@@ -0,0 +1,96 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file accounts for the goroutines of each orchestrator subsystem, so
+// that goroutines that leak show up in metrics. The contents of this file are
+// intended to only be used within the orchestrator module and should not be
+// exported, except for the summary shown by the admin server.
+package orchestrator
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/uber-go/tally"
+)
+
+// The subsystems whose goroutines are accounted for.
+const (
+	subsystemUpstreamReceiver = "upstream_receiver"
+	subsystemShadowReceiver   = "shadow_receiver"
+	subsystemFanout           = "fanout"
+	subsystemEviction         = "eviction"
+	subsystemIdleReaper       = "idle_reaper"
+	subsystemAlerting         = "alerting"
+	subsystemUpstreamHealth   = "upstream_health"
+	subsystemConnection       = "connection"
+	subsystemExpiry           = "expiry"
+	// subsystemWatch counts the open downstream watches, each of which is
+	// served by a go-control-plane stream goroutine.
+	subsystemWatch = "watch"
+
+	metricSubscopeGoroutines = "goroutines"
+)
+
+// GoroutineSummary is the number of goroutines of each subsystem, along with
+// the total number of goroutines of the process.
+type GoroutineSummary struct {
+	Total      int
+	Subsystems map[string]int
+}
+
+// goroutineTracker counts the running goroutines of each subsystem, and
+// reports them as gauges.
+type goroutineTracker struct {
+	scope tally.Scope
+
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newGoroutineTracker(scope tally.Scope) *goroutineTracker {
+	return &goroutineTracker{
+		scope:  scope,
+		counts: make(map[string]int),
+	}
+}
+
+// add adds delta to the number of goroutines of the subsystem.
+func (g *goroutineTracker) add(subsystem string, delta int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.counts[subsystem] += delta
+	g.scope.Gauge(subsystem).Update(float64(g.counts[subsystem]))
+}
+
+// goroutine runs f in a new goroutine of the subsystem.
+func (g *goroutineTracker) goroutine(subsystem string, f func()) {
+	g.add(subsystem, 1)
+	go func() {
+		defer g.add(subsystem, -1)
+		f()
+	}()
+}
+
+func (g *goroutineTracker) summary() map[string]int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	counts := make(map[string]int, len(g.counts))
+	for subsystem, count := range g.counts {
+		counts[subsystem] = count
+	}
+	return counts
+}
+
+func (o *orchestrator) GetGoroutines() GoroutineSummary {
+	subsystems := o.goroutines.summary()
+	subsystems[subsystemWatch] = o.downstreamResponseMap.len()
+	return GoroutineSummary{
+		Total:      runtime.NumGoroutine(),
+		Subsystems: subsystems,
+	}
+}
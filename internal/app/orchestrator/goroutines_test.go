@@ -0,0 +1,50 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoroutineAccounting(t *testing.T) {
+	baseline := testutils.CurrentGoroutines()
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, typeURLMapper{},
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+
+	respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{TypeUrl: upstream.ListenerTypeURL})
+	upstreamResponseChannel <- &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ListenerTypeURL}
+	<-respChannel
+
+	// The send loop of the connection exits once the response is sent.
+	assert.Eventually(t, func() bool {
+		return orchestrator.GetGoroutines().Subsystems[subsystemConnection] == 0
+	}, time.Second, time.Millisecond)
+	summary := orchestrator.GetGoroutines()
+	assert.Equal(t, map[string]int{
+		subsystemUpstreamReceiver: 1,
+		subsystemConnection:       0,
+		subsystemWatch:            1,
+	}, summary.Subsystems)
+	assert.True(t, summary.Total > 0)
+	testutils.AssertGaugeValue(t, mockScope.Snapshot().Gauges(), "prefix.goroutines.upstream_receiver", 1)
+
+	cancelWatch()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	orchestrator.shutdown(ctx)
+
+	testutils.AssertNoLeakedGoroutines(t, baseline)
+	assert.Equal(t, map[string]int{
+		subsystemUpstreamReceiver: 0,
+		subsystemConnection:       0,
+		subsystemWatch:            0,
+	}, orchestrator.GetGoroutines().Subsystems)
+}
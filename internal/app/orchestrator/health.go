@@ -0,0 +1,263 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file scores the health of the upstream stream of each aggregated key by
+// the time since its last response, and restarts the streams that fall silent
+// while resources of their type are changing. The contents of this file are
+// intended to only be used within the orchestrator module and should not be
+// exported, except for the health statuses shown by the admin server.
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	defaultUpstreamTimeout          = 5 * time.Minute
+	defaultHealthEvaluationInterval = 30 * time.Second
+
+	metricUpstreamSilentRestart = "upstream_silent_restart"
+)
+
+// UpstreamHealthStatus describes the health of the upstream stream of an
+// aggregated key.
+type UpstreamHealthStatus struct {
+	Key     string
+	Tenant  string
+	TypeURL string
+	// LastResponse is the time of the last response of any upstream stream
+	// of the key, and is zero until the first response.
+	LastResponse time.Time
+	// Silence is the time since the last response, or since the stream was
+	// opened if it was opened since.
+	Silence time.Duration
+	Timeout time.Duration
+	// MissingChanges is true if a response of another key of the same type
+	// URL changed version during the silence.
+	MissingChanges bool
+	// Restarts is the number of times the stream was restarted for being
+	// silent.
+	Restarts int
+	// Score is 1 while the stream is within its timeout, and decays as
+	// timeout/silence beyond it. It is halved while the stream is missing
+	// changes.
+	Score float64
+}
+
+// keyTimeout is the upstream timeout of the aggregated keys that match the
+// regex.
+type keyTimeout struct {
+	keyRegex *regexp.Regexp
+	timeout  time.Duration
+}
+
+// keyHealth records the upstream responses of an aggregated key.
+type keyHealth struct {
+	lastResponse time.Time
+	version      string
+	restarts     int
+}
+
+// upstreamHealth records the last upstream response of each aggregated key,
+// and the last time a response of each type URL changed version.
+type upstreamHealth struct {
+	timeout            time.Duration
+	keyTimeouts        []keyTimeout
+	evaluationInterval time.Duration
+
+	mu sync.Mutex
+	// keys is keyed by aggregated key.
+	keys map[string]*keyHealth
+	// typeChanged is keyed by type URL.
+	typeChanged map[string]time.Time
+}
+
+// newUpstreamHealth returns the upstream health of the config. It returns an
+// error if a key regex does not compile.
+func newUpstreamHealth(config *bootstrapv1.UpstreamHealth) (*upstreamHealth, error) {
+	h := &upstreamHealth{
+		timeout:            defaultUpstreamTimeout,
+		evaluationInterval: defaultHealthEvaluationInterval,
+		keys:               make(map[string]*keyHealth),
+		typeChanged:        make(map[string]time.Time),
+	}
+	if config.GetTimeout() != nil {
+		if timeout, err := ptypes.Duration(config.GetTimeout()); err == nil {
+			h.timeout = timeout
+		}
+	}
+	if config.GetEvaluationInterval() != nil {
+		if interval, err := ptypes.Duration(config.GetEvaluationInterval()); err == nil {
+			h.evaluationInterval = interval
+		}
+	}
+	for _, config := range config.GetKeyTimeouts() {
+		keyRegex, err := regexp.Compile(config.GetKeyRegex())
+		if err != nil {
+			return nil, fmt.Errorf("invalid key regex %q: %w", config.GetKeyRegex(), err)
+		}
+		timeout, err := ptypes.Duration(config.GetTimeout())
+		if err != nil {
+			return nil, err
+		}
+		h.keyTimeouts = append(h.keyTimeouts, keyTimeout{keyRegex: keyRegex, timeout: timeout})
+	}
+	return h, nil
+}
+
+// keyTimeout returns the timeout of the aggregated key.
+func (h *upstreamHealth) keyTimeout(aggregatedKey string) time.Duration {
+	for _, keyTimeout := range h.keyTimeouts {
+		if keyTimeout.keyRegex.MatchString(aggregatedKey) {
+			return keyTimeout.timeout
+		}
+	}
+	return h.timeout
+}
+
+// recordResponse records the upstream response of the aggregated key at now.
+// A response that changes the version of the key also marks its type URL as
+// changed.
+func (h *upstreamHealth) recordResponse(aggregatedKey string, resp *discovery.DiscoveryResponse, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	kh, ok := h.keys[aggregatedKey]
+	if !ok {
+		kh = &keyHealth{}
+		h.keys[aggregatedKey] = kh
+	}
+	if kh.version != "" && kh.version != resp.GetVersionInfo() {
+		h.typeChanged[resp.GetTypeUrl()] = now
+	}
+	kh.lastResponse, kh.version = now, resp.GetVersionInfo()
+}
+
+// recordRestart counts a restart of the upstream stream of the aggregated key.
+func (h *upstreamHealth) recordRestart(aggregatedKey string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	kh, ok := h.keys[aggregatedKey]
+	if !ok {
+		kh = &keyHealth{}
+		h.keys[aggregatedKey] = kh
+	}
+	kh.restarts++
+}
+
+// remove forgets the aggregated key.
+func (h *upstreamHealth) remove(aggregatedKey string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.keys, aggregatedKey)
+}
+
+// status returns the health of the upstream stream of the aggregated key of
+// the type URL, opened at the time, as of now.
+func (h *upstreamHealth) status(
+	aggregatedKey string,
+	typeURL string,
+	opened time.Time,
+	now time.Time,
+) UpstreamHealthStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	status := UpstreamHealthStatus{
+		Key:     aggregatedKey,
+		TypeURL: typeURL,
+		Timeout: h.keyTimeout(aggregatedKey),
+		Score:   1,
+	}
+	lastActivity := opened
+	if kh, ok := h.keys[aggregatedKey]; ok {
+		status.LastResponse, status.Restarts = kh.lastResponse, kh.restarts
+		if kh.lastResponse.After(lastActivity) {
+			lastActivity = kh.lastResponse
+		}
+	}
+	status.Silence = now.Sub(lastActivity)
+	status.MissingChanges = h.typeChanged[typeURL].After(lastActivity)
+	if status.Silence > status.Timeout {
+		status.Score = float64(status.Timeout) / float64(status.Silence)
+		if status.MissingChanges {
+			status.Score /= 2
+		}
+	}
+	return status
+}
+
+// GetUpstreamHealth returns the health of the upstream stream of every
+// aggregated key with a running worker, ordered by aggregated key. It returns
+// nil if upstream health is not enabled.
+func (o *orchestrator) GetUpstreamHealth() []UpstreamHealthStatus {
+	if o.upstreamHealth == nil {
+		return nil
+	}
+	return o.upstreamHealthStatuses(time.Now())
+}
+
+// upstreamHealthStatuses returns the health of the upstream stream of every
+// aggregated key with a running worker as of now.
+func (o *orchestrator) upstreamHealthStatuses(now time.Time) []UpstreamHealthStatus {
+	var statuses []UpstreamHealthStatus
+	for _, worker := range o.supervisor.list() {
+		if worker.State != WorkerRunning {
+			continue
+		}
+		var typeURL string
+		if representative, ok := o.representativeRequests.Load(worker.Key); ok {
+			typeURL = representative.(gcp.Request).TypeUrl
+		}
+		status := o.upstreamHealth.status(worker.Key, typeURL, worker.StartTime, now)
+		status.Tenant = worker.Tenant
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// checkUpstreamHealthPeriodically restarts silent upstream streams every
+// evaluation interval until ctx is done.
+func (o *orchestrator) checkUpstreamHealthPeriodically(ctx context.Context) {
+	ticker := time.NewTicker(o.upstreamHealth.evaluationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			o.checkUpstreamHealth(ctx, time.Now())
+		}
+	}
+}
+
+// checkUpstreamHealth restarts the upstream streams that are silent beyond
+// their timeout and missing changes as of now. It returns the number of
+// streams that were restarted.
+func (o *orchestrator) checkUpstreamHealth(ctx context.Context, now time.Time) int {
+	restarted := 0
+	for _, status := range o.upstreamHealthStatuses(now) {
+		if status.Silence <= status.Timeout || !status.MissingChanges {
+			continue
+		}
+		o.upstreamHealth.recordRestart(status.Key)
+		o.keyScope(status.Key).Counter(metricUpstreamSilentRestart).Inc(1)
+		o.logger.With("key", status.Key).With("silence", status.Silence.Round(time.Second)).
+			Warn(ctx, "upstream stream is silent while resources of its type are changing, restarting it")
+		o.deleteUpstream(status.Key, nil)
+		o.reopenUpstream(ctx, status.Key)
+		restarted++
+	}
+	return restarted
+}
@@ -0,0 +1,124 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUpstreamHealth(t *testing.T) {
+	health, err := newUpstreamHealth(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultUpstreamTimeout, health.timeout)
+	assert.Equal(t, defaultHealthEvaluationInterval, health.evaluationInterval)
+
+	health, err = newUpstreamHealth(&bootstrapv1.UpstreamHealth{
+		Timeout: ptypes.DurationProto(time.Minute),
+		KeyTimeouts: []*bootstrapv1.UpstreamHealth_KeyTimeout{
+			{KeyRegex: "^eds", Timeout: ptypes.DurationProto(10 * time.Minute)},
+			{KeyRegex: "eds", Timeout: ptypes.DurationProto(time.Hour)},
+		},
+		EvaluationInterval: ptypes.DurationProto(time.Second),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, health.evaluationInterval)
+	assert.Equal(t, 10*time.Minute, health.keyTimeout("eds_cluster"))
+	assert.Equal(t, time.Hour, health.keyTimeout("cluster_eds"))
+	assert.Equal(t, time.Minute, health.keyTimeout("lds"))
+
+	_, err = newUpstreamHealth(&bootstrapv1.UpstreamHealth{
+		KeyTimeouts: []*bootstrapv1.UpstreamHealth_KeyTimeout{
+			{KeyRegex: "(", Timeout: ptypes.DurationProto(time.Minute)},
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestUpstreamHealthStatus(t *testing.T) {
+	health, err := newUpstreamHealth(&bootstrapv1.UpstreamHealth{Timeout: ptypes.DurationProto(time.Minute)})
+	assert.NoError(t, err)
+	opened := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	resp := &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ListenerTypeURL}
+
+	// Streams without a response are scored by the time since they opened.
+	status := health.status("lds", upstream.ListenerTypeURL, opened, opened.Add(30*time.Second))
+	assert.Equal(t, 30*time.Second, status.Silence)
+	assert.True(t, status.LastResponse.IsZero())
+	assert.Equal(t, 1.0, status.Score)
+
+	health.recordResponse("lds", resp, opened.Add(time.Minute))
+	status = health.status("lds", upstream.ListenerTypeURL, opened, opened.Add(3*time.Minute))
+	assert.Equal(t, opened.Add(time.Minute), status.LastResponse)
+	assert.Equal(t, 2*time.Minute, status.Silence)
+	assert.False(t, status.MissingChanges)
+	assert.Equal(t, 0.5, status.Score)
+
+	// A response of another key that changes its version marks the type URL
+	// as changed, unlike a response that does not.
+	health.recordResponse("other", resp, opened.Add(time.Minute))
+	health.recordResponse("other", resp, opened.Add(2*time.Minute))
+	status = health.status("lds", upstream.ListenerTypeURL, opened, opened.Add(3*time.Minute))
+	assert.False(t, status.MissingChanges)
+	health.recordResponse("other", &v2.DiscoveryResponse{VersionInfo: "2", TypeUrl: upstream.ListenerTypeURL},
+		opened.Add(2*time.Minute))
+	status = health.status("lds", upstream.ListenerTypeURL, opened, opened.Add(3*time.Minute))
+	assert.True(t, status.MissingChanges)
+	assert.Equal(t, 0.25, status.Score)
+	assert.False(t, health.status("cds", upstream.ClusterTypeURL, opened, opened.Add(3*time.Minute)).MissingChanges)
+
+	health.remove("lds")
+	status = health.status("lds", upstream.ListenerTypeURL, opened, opened.Add(3*time.Minute))
+	assert.True(t, status.LastResponse.IsZero())
+}
+
+func TestCheckUpstreamHealth(t *testing.T) {
+	upstreamClient := mockFlakyUpstreamClient{streams: make(chan chan *v2.DiscoveryResponse, 10)}
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), upstreamClient)
+	WithUpstreamHealth(&bootstrapv1.UpstreamHealth{Timeout: ptypes.DurationProto(time.Minute)})(orchestrator)
+	respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{TypeUrl: upstream.ListenerTypeURL})
+	defer cancelWatch()
+	stream := <-upstreamClient.streams
+	stream <- &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ListenerTypeURL}
+	<-respChannel
+
+	statuses := orchestrator.GetUpstreamHealth()
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, "lds", statuses[0].Key)
+	assert.Equal(t, upstream.ListenerTypeURL, statuses[0].TypeURL)
+	assert.Equal(t, 1.0, statuses[0].Score)
+
+	// Silent streams are left open while resources of their type are not
+	// known to be changing.
+	ctx := context.Background()
+	assert.Equal(t, 0, orchestrator.checkUpstreamHealth(ctx, time.Now().Add(time.Hour)))
+
+	changed := time.Now().Add(time.Second)
+	for _, version := range []string{"1", "2"} {
+		other := &v2.DiscoveryResponse{VersionInfo: version, TypeUrl: upstream.ListenerTypeURL}
+		orchestrator.upstreamHealth.recordResponse("other", other, changed)
+	}
+	assert.Equal(t, 0, orchestrator.checkUpstreamHealth(ctx, changed.Add(time.Second)))
+	assert.Equal(t, 1, orchestrator.checkUpstreamHealth(ctx, changed.Add(time.Hour)))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.upstream_silent_restart", 1)
+
+	// The restarted stream serves the watch.
+	stream = <-upstreamClient.streams
+	stream <- &v2.DiscoveryResponse{VersionInfo: "2", TypeUrl: upstream.ListenerTypeURL}
+	resp, err := (<-respChannel).GetDiscoveryResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, "2", resp.VersionInfo)
+	statuses = orchestrator.GetUpstreamHealth()
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, 1, statuses[0].Restarts)
+	assert.Equal(t, 0, orchestrator.checkUpstreamHealth(ctx, changed.Add(time.Minute)))
+}
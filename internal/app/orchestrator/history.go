@@ -0,0 +1,175 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file keeps the latest versions cached for every aggregated key, so
+// that they can be diffed and pinned after newer versions replace them. The
+// contents of this file are intended to only be used within the orchestrator
+// module and should not be exported, except for the versions shown by the
+// admin server.
+package orchestrator
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/diff"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+)
+
+const (
+	defaultHistoryMaxVersions = 10
+)
+
+// HistoricalVersion is a version cached for an aggregated key.
+type HistoricalVersion struct {
+	Version   string
+	TypeURL   string
+	Resources int
+	CachedAt  time.Time
+}
+
+// historyEntry is a response cached for an aggregated key.
+type historyEntry struct {
+	resp     *discovery.DiscoveryResponse
+	cachedAt time.Time
+}
+
+// versionRing is a ring buffer of the latest responses cached for an
+// aggregated key.
+type versionRing struct {
+	entries []historyEntry
+	// next is the index of the entry overwritten by the next response.
+	next int
+	size int
+}
+
+// add records the response in place of the oldest response once the ring is
+// full.
+func (r *versionRing) add(entry historyEntry) {
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % len(r.entries)
+	if r.size < len(r.entries) {
+		r.size++
+	}
+}
+
+// latest returns the entries, the most recently cached first.
+func (r *versionRing) latest() []historyEntry {
+	entries := make([]historyEntry, 0, r.size)
+	for i := 1; i <= r.size; i++ {
+		entries = append(entries, r.entries[(r.next-i+len(r.entries))%len(r.entries)])
+	}
+	return entries
+}
+
+// history holds a ring of responses per aggregated key.
+type history struct {
+	maxVersions int
+
+	mu sync.RWMutex
+	// rings is keyed by aggregated key.
+	rings map[string]*versionRing
+}
+
+func newHistory(config *bootstrapv1.History) *history {
+	h := &history{maxVersions: defaultHistoryMaxVersions, rings: make(map[string]*versionRing)}
+	if config.GetMaxVersions() != nil && config.GetMaxVersions().GetValue() > 0 {
+		h.maxVersions = int(config.GetMaxVersions().GetValue())
+	}
+	return h
+}
+
+// record adds the response cached for the aggregated key, unless its version
+// is the version recorded last.
+func (h *history) record(aggregatedKey string, resp *discovery.DiscoveryResponse, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ring, ok := h.rings[aggregatedKey]
+	if !ok {
+		ring = &versionRing{entries: make([]historyEntry, h.maxVersions)}
+		h.rings[aggregatedKey] = ring
+	}
+	if latest := ring.latest(); len(latest) > 0 && latest[0].resp.GetVersionInfo() == resp.GetVersionInfo() {
+		return
+	}
+	ring.add(historyEntry{resp: resp, cachedAt: now})
+}
+
+// versions returns the responses recorded for the aggregated key, the most
+// recently cached first.
+func (h *history) versions(aggregatedKey string) []historyEntry {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	ring, ok := h.rings[aggregatedKey]
+	if !ok {
+		return nil
+	}
+	return ring.latest()
+}
+
+// response returns the most recently cached response of the aggregated key
+// with the version.
+func (h *history) response(aggregatedKey string, version string) (*discovery.DiscoveryResponse, bool) {
+	for _, entry := range h.versions(aggregatedKey) {
+		if entry.resp.GetVersionInfo() == version {
+			return entry.resp, true
+		}
+	}
+	return nil, false
+}
+
+func (h *history) remove(aggregatedKey string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.rings, aggregatedKey)
+}
+
+// GetHistory returns the versions held for the aggregated key, the most
+// recently cached first.
+func (o *orchestrator) GetHistory(aggregatedKey string) []HistoricalVersion {
+	if o.history == nil {
+		return nil
+	}
+	var versions []HistoricalVersion
+	for _, entry := range o.history.versions(aggregatedKey) {
+		versions = append(versions, HistoricalVersion{
+			Version:   entry.resp.GetVersionInfo(),
+			TypeURL:   entry.resp.GetTypeUrl(),
+			Resources: len(entry.resp.GetResources()),
+			CachedAt:  entry.cachedAt,
+		})
+	}
+	return versions
+}
+
+// GetHistoricalResponse returns the response held for the aggregated key with
+// the version.
+func (o *orchestrator) GetHistoricalResponse(
+	aggregatedKey string,
+	version string,
+) (*discovery.DiscoveryResponse, bool) {
+	if o.history == nil {
+		return nil, false
+	}
+	return o.history.response(aggregatedKey, version)
+}
+
+// DiffHistory returns the resource changes between two versions held for the
+// aggregated key.
+func (o *orchestrator) DiffHistory(aggregatedKey string, from string, to string) (diff.Summary, error) {
+	previous, ok := o.GetHistoricalResponse(aggregatedKey, from)
+	if !ok {
+		return diff.Summary{}, fmt.Errorf("version %s of key %s is %w", from, aggregatedKey, ErrVersionNotHeld)
+	}
+	current, ok := o.GetHistoricalResponse(aggregatedKey, to)
+	if !ok {
+		return diff.Summary{}, fmt.Errorf("version %s of key %s is %w", to, aggregatedKey, ErrVersionNotHeld)
+	}
+	return diff.Compute(previous, current), nil
+}
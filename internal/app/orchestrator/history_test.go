@@ -0,0 +1,73 @@
+package orchestrator
+
+import (
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryRing(t *testing.T) {
+	h := newHistory(&bootstrapv1.History{MaxVersions: &wrappers.UInt32Value{Value: 2}})
+	now := time.Now()
+	h.record("lds", newRolloutResponse("1"), now)
+	h.record("lds", newRolloutResponse("1"), now)
+	assert.Equal(t, 1, len(h.versions("lds")))
+
+	// The oldest version is overwritten once the ring is full.
+	h.record("lds", newRolloutResponse("2"), now)
+	h.record("lds", newRolloutResponse("3"), now)
+	versions := h.versions("lds")
+	assert.Equal(t, 2, len(versions))
+	assert.Equal(t, "3", versions[0].resp.GetVersionInfo())
+	assert.Equal(t, "2", versions[1].resp.GetVersionInfo())
+	_, ok := h.response("lds", "1")
+	assert.False(t, ok)
+
+	h.remove("lds")
+	assert.Nil(t, h.versions("lds"))
+	assert.Equal(t, defaultHistoryMaxVersions, newHistory(&bootstrapv1.History{}).maxVersions)
+}
+
+func TestHistory(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	assert.Nil(t, orchestrator.GetHistory("lds"))
+	WithHistory(&bootstrapv1.History{MaxVersions: &wrappers.UInt32Value{Value: 2}})(orchestrator)
+
+	req := newRolloutRequest("node", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	resp1 := newResponse(t, upstream.ListenerTypeURL, "1", &v2.Listener{Name: "a"})
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp1))
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+	resp2 := newResponse(t, upstream.ListenerTypeURL, "2", &v2.Listener{Name: "a"}, &v2.Listener{Name: "b"})
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp2))
+
+	versions := orchestrator.GetHistory("lds")
+	assert.Equal(t, 2, len(versions))
+	assert.Equal(t, "2", versions[0].Version)
+	assert.Equal(t, 2, versions[0].Resources)
+	assert.Equal(t, "1", versions[1].Version)
+	assert.Equal(t, upstream.ListenerTypeURL, versions[1].TypeURL)
+
+	summary, err := orchestrator.DiffHistory("lds", "1", "2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, summary.Added)
+	_, err = orchestrator.DiffHistory("lds", "1", "7")
+	assert.EqualError(t, err, "version 7 of key lds is not held by the relay")
+
+	// Pinning a version in the history rolls the served version back.
+	req.VersionInfo = "2"
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	assert.NoError(t, orchestrator.PinVersion("lds", "1"))
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+
+	assert.Equal(t, 1, orchestrator.EvictLeastRecentlyUsed(1))
+	assert.Nil(t, orchestrator.GetHistory("lds"))
+}
@@ -0,0 +1,50 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file stamps the control plane identifier of the responses served by
+// the relay. The contents of this file are intended to only be used within
+// the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+)
+
+const defaultControlPlaneIdentifierPrefix = "xds-relay"
+
+// controlPlaneIdentifierPrefix returns the identifier of this replica, which
+// prefixes the version in the identifiers that it stamps.
+func controlPlaneIdentifierPrefix(config *bootstrapv1.ControlPlaneIdentifier) string {
+	prefix := config.GetPrefix()
+	if prefix == "" {
+		prefix = defaultControlPlaneIdentifierPrefix
+	}
+	replica := config.GetReplica()
+	if replica == "" {
+		replica, _ = os.Hostname()
+	}
+	return fmt.Sprintf("%s/%s", prefix, replica)
+}
+
+// stampIdentifier returns a copy of the response whose control plane
+// identifier names this replica and the version of the response. The
+// response is returned unchanged if stamping is disabled.
+func (o *orchestrator) stampIdentifier(resp *discovery.DiscoveryResponse) *discovery.DiscoveryResponse {
+	if o.controlPlaneIdentifier == "" {
+		return resp
+	}
+	stamped := *resp
+	stamped.ControlPlane = &core.ControlPlane{
+		Identifier: fmt.Sprintf("%s/%s", o.controlPlaneIdentifier, resp.GetVersionInfo()),
+	}
+	return &stamped
+}
@@ -0,0 +1,58 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file closes downstream watches whose clients never acknowledge or
+// reject the responses sent to them. The contents of this file are intended
+// to only be used within the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+)
+
+const (
+	metricWatchIdleReaped = "watch_idle_reaped"
+)
+
+// reapIdleWatches closes idle watches every half of the idle timeout until ctx
+// is done.
+func (o *orchestrator) reapIdleWatches(ctx context.Context) {
+	ticker := time.NewTicker(o.watchIdleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			o.reapIdle(ctx)
+		}
+	}
+}
+
+// reapIdle closes the response channels of the watches that have been idle
+// for longer than the idle timeout, so that go-control-plane closes their
+// streams, and removes them from the cache. It returns the number of watches
+// that were closed.
+func (o *orchestrator) reapIdle(ctx context.Context) int {
+	reaped := 0
+	for id, aggregatedKey := range o.downstreamResponseMap.idle(o.watchIdleTimeout) {
+		// The watch may have been cancelled since it was found idle.
+		idleErr := &KeyError{Key: aggregatedKey, Err: ErrWatchIdle}
+		if o.terminateWatches(map[cache.WatchID]*gcp.Request{id: nil}, idleErr) == 0 {
+			continue
+		}
+		o.onCancelWatch(aggregatedKey, id)()
+		o.keyScope(aggregatedKey).Counter(metricWatchIdleReaped).Inc(1)
+		o.logger.With("key", aggregatedKey).With("watch ID", id).Info(ctx, "closed idle watch")
+		reaped++
+	}
+	return reaped
+}
@@ -0,0 +1,54 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReapIdle(t *testing.T) {
+	upstreamClient := newMockSubscriptionUpstreamClient()
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, typeURLMapper{}, upstreamClient)
+	orchestrator.watchIdleTimeout = time.Millisecond
+
+	_, err := orchestrator.cache.SetResponse(upstream.ListenerTypeURL, v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     upstream.ListenerTypeURL,
+	})
+	assert.NoError(t, err)
+	sentChannel, cancelSent := orchestrator.CreateWatch(gcp.Request{TypeUrl: upstream.ListenerTypeURL})
+	defer cancelSent()
+	// Watches that are waiting for a response never become idle.
+	waitingChannel, cancelWaiting := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl:     upstream.ListenerTypeURL,
+		VersionInfo: "1",
+	})
+	defer cancelWaiting()
+	<-upstreamClient.requests
+
+	time.Sleep(2 * orchestrator.watchIdleTimeout)
+	assert.Equal(t, 1, orchestrator.reapIdle(context.Background()))
+
+	_, more := <-sentChannel
+	assert.True(t, more)
+	_, more = <-sentChannel
+	assert.False(t, more)
+	assert.Equal(t, 1, orchestrator.downstreamResponseMap.len())
+	cached, err := orchestrator.cache.Fetch(upstream.ListenerTypeURL)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(cached.Requests))
+	assert.Equal(t, 0, len(waitingChannel))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.watch_idle_reaped", 1)
+
+	// Cancelling a closed watch is a no-op.
+	cancelSent()
+	assert.Equal(t, 0, orchestrator.reapIdle(context.Background()))
+	assert.Equal(t, 1, orchestrator.downstreamResponseMap.len())
+}
@@ -0,0 +1,94 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file lists the nodes connected to the relay from the nodes of their
+// downstream streams, so that operators can tell which clients and Envoy
+// versions are served by the relay. The contents of this file are intended to
+// only be used within the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+)
+
+// NodeStatus describes a node connected to the relay.
+type NodeStatus struct {
+	ID      string
+	Cluster string
+	Region  string
+	Zone    string
+	SubZone string
+	// UserAgentName and UserAgentVersion identify the client of the node,
+	// e.g. "envoy" and "1.14.1". They are empty for clients that do not set
+	// them, such as Envoys older than 1.14.
+	UserAgentName    string
+	UserAgentVersion string
+	// Streams is the number of open downstream streams of the node, and
+	// ConnectedSince is when the oldest of them was opened.
+	Streams        int
+	ConnectedSince time.Time
+}
+
+func (o *orchestrator) GetNodes() []NodeStatus {
+	return o.requestIDs.nodeStatuses()
+}
+
+// nodeStatuses returns the nodes of the open streams that received a request,
+// ordered by node ID. The streams of nodes with the same ID are counted
+// together, and described by the node of the most recently opened stream.
+func (r *requestIDMap) nodeStatuses() []NodeStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	nodes := make(map[string]*NodeStatus)
+	// latest is the open time of the stream that describes each node.
+	latest := make(map[string]time.Time)
+	for _, stream := range r.streams {
+		if stream.node == nil {
+			continue
+		}
+		id := stream.node.GetId()
+		status, ok := nodes[id]
+		if !ok {
+			status = &NodeStatus{ConnectedSince: stream.openTime}
+			nodes[id] = status
+		}
+		status.Streams++
+		if stream.openTime.Before(status.ConnectedSince) {
+			status.ConnectedSince = stream.openTime
+		}
+		if !ok || !stream.openTime.Before(latest[id]) {
+			latest[id] = stream.openTime
+			describeNode(status, stream.node)
+		}
+	}
+	statuses := make([]NodeStatus, 0, len(nodes))
+	for _, status := range nodes {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ID < statuses[j].ID })
+	return statuses
+}
+
+// describeNode sets the identity, locality, and user agent of the status from
+// the node.
+func describeNode(status *NodeStatus, node *core.Node) {
+	status.ID = node.GetId()
+	status.Cluster = node.GetCluster()
+	status.Region = node.GetLocality().GetRegion()
+	status.Zone = node.GetLocality().GetZone()
+	status.SubZone = node.GetLocality().GetSubZone()
+	status.UserAgentName = node.GetUserAgentName()
+	status.UserAgentVersion = node.GetUserAgentVersion()
+	if version := node.GetUserAgentBuildVersion().GetVersion(); version != nil {
+		status.UserAgentVersion = fmt.Sprintf("%d.%d.%d",
+			version.GetMajorNumber(), version.GetMinorNumber(), version.GetPatch())
+	}
+}
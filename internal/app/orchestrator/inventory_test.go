@@ -0,0 +1,57 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	envoy_type "github.com/envoyproxy/go-control-plane/envoy/type"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNodes(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	edge := &v2_core.Node{
+		Id:            "edge-1",
+		Cluster:       "edge",
+		Locality:      &v2_core.Locality{Region: "us-east-1", Zone: "us-east-1a"},
+		UserAgentName: "envoy",
+		UserAgentVersionType: &v2_core.Node_UserAgentBuildVersion{UserAgentBuildVersion: &v2_core.BuildVersion{
+			Version: &envoy_type.SemanticVersion{MajorNumber: 1, MinorNumber: 14, Patch: 1},
+		}},
+	}
+	grpcClient := &v2_core.Node{
+		Id:                   "client-1",
+		UserAgentName:        "grpc-go",
+		UserAgentVersionType: &v2_core.Node_UserAgentVersion{UserAgentVersion: "1.30.0"},
+	}
+	for streamID, node := range map[int64]*v2_core.Node{1: edge, 2: edge, 3: grpcClient, 4: nil} {
+		assert.NoError(t, orchestrator.OnStreamOpen(context.Background(), streamID, ""))
+		assert.NoError(t, orchestrator.OnStreamRequest(streamID, &v2.DiscoveryRequest{Node: node}))
+	}
+
+	// Streams are counted per node, and streams without a request are not
+	// listed.
+	nodes := orchestrator.GetNodes()
+	assert.Equal(t, 2, len(nodes))
+	assert.Equal(t, "client-1", nodes[0].ID)
+	assert.Equal(t, "grpc-go", nodes[0].UserAgentName)
+	assert.Equal(t, "1.30.0", nodes[0].UserAgentVersion)
+	assert.Equal(t, 1, nodes[0].Streams)
+	assert.Equal(t, "edge-1", nodes[1].ID)
+	assert.Equal(t, "edge", nodes[1].Cluster)
+	assert.Equal(t, "us-east-1", nodes[1].Region)
+	assert.Equal(t, "us-east-1a", nodes[1].Zone)
+	assert.Equal(t, "envoy", nodes[1].UserAgentName)
+	assert.Equal(t, "1.14.1", nodes[1].UserAgentVersion)
+	assert.Equal(t, 2, nodes[1].Streams)
+	assert.False(t, nodes[1].ConnectedSince.IsZero())
+
+	orchestrator.OnStreamClosed(1)
+	orchestrator.OnStreamClosed(3)
+	nodes = orchestrator.GetNodes()
+	assert.Equal(t, 1, len(nodes))
+	assert.Equal(t, 1, nodes[0].Streams)
+}
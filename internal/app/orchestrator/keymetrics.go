@@ -0,0 +1,100 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file tags the metrics of aggregated keys with the key, and bounds the
+// number of distinct tag values. The contents of this file are intended to
+// only be used within the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+)
+
+const (
+	metricTagKey = "key"
+
+	metricKeyMetricsOverflow = "key_metrics_overflow"
+
+	// keyMetricsOverflowBucket is the tag value of the metrics of the keys
+	// beyond the maximum number of keys.
+	keyMetricsOverflowBucket = "other"
+)
+
+type keyMetricsRule struct {
+	key *regexp.Regexp
+	// bucket is the tag value of the keys of the rule. Keys are tagged with
+	// themselves if it is empty.
+	bucket string
+}
+
+// keyMetrics resolves the value of the key tag of the metrics of aggregated
+// keys.
+type keyMetrics struct {
+	rules   []keyMetricsRule
+	maxKeys int
+
+	mu sync.Mutex
+	// tagged holds the keys tagged with themselves. Keys keep their slot once
+	// they are evicted, since their metrics were already reported.
+	tagged map[string]bool
+	// overflowed holds the keys tagged with the overflow bucket, so that
+	// every key is counted once.
+	overflowed map[string]bool
+}
+
+func newKeyMetrics(config *bootstrapv1.KeyMetrics) (*keyMetrics, error) {
+	k := &keyMetrics{
+		maxKeys:    int(config.GetMaxKeys()),
+		tagged:     make(map[string]bool),
+		overflowed: make(map[string]bool),
+	}
+	for _, rule := range config.GetRules() {
+		key, err := regexp.Compile(rule.GetKeyRegex())
+		if err != nil {
+			return nil, fmt.Errorf("invalid key metrics regex %s: %s", rule.GetKeyRegex(), err.Error())
+		}
+		k.rules = append(k.rules, keyMetricsRule{key: key, bucket: rule.GetBucket()})
+	}
+	return k, nil
+}
+
+// tag returns the value of the key tag of the aggregated key, which is empty
+// if the metrics of the key are not tagged with the key. overflow is true the
+// first time the key is tagged with the overflow bucket.
+func (k *keyMetrics) tag(aggregatedKey string) (tag string, overflow bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.tagged[aggregatedKey] {
+		return aggregatedKey, false
+	}
+	for _, rule := range k.rules {
+		if !rule.key.MatchString(aggregatedKey) {
+			continue
+		}
+		if rule.bucket != "" {
+			return rule.bucket, false
+		}
+		if k.maxKeys > 0 && len(k.tagged) >= k.maxKeys {
+			overflow = !k.overflowed[aggregatedKey]
+			k.overflowed[aggregatedKey] = true
+			return keyMetricsOverflowBucket, overflow
+		}
+		k.tagged[aggregatedKey] = true
+		return aggregatedKey, false
+	}
+	return "", false
+}
+
+func (k *keyMetrics) remove(aggregatedKey string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	delete(k.overflowed, aggregatedKey)
+}
@@ -0,0 +1,80 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyMetricsTag(t *testing.T) {
+	_, err := newKeyMetrics(&bootstrapv1.KeyMetrics{
+		Rules: []*bootstrapv1.KeyMetrics_Rule{{KeyRegex: "("}},
+	})
+	assert.Error(t, err)
+
+	k, err := newKeyMetrics(&bootstrapv1.KeyMetrics{
+		Rules: []*bootstrapv1.KeyMetrics_Rule{
+			{KeyRegex: "^canary_", Bucket: "canary"},
+			{KeyRegex: "^lds_"},
+		},
+		MaxKeys: 2,
+	})
+	assert.NoError(t, err)
+
+	tag, overflow := k.tag("canary_lds")
+	assert.Equal(t, "canary", tag)
+	assert.False(t, overflow)
+	tag, _ = k.tag("cds_a")
+	assert.Equal(t, "", tag)
+	tag, _ = k.tag("lds_a")
+	assert.Equal(t, "lds_a", tag)
+	tag, _ = k.tag("lds_b")
+	assert.Equal(t, "lds_b", tag)
+
+	// Keys beyond the maximum are tagged with the overflow bucket, and counted
+	// once.
+	tag, overflow = k.tag("lds_c")
+	assert.Equal(t, keyMetricsOverflowBucket, tag)
+	assert.True(t, overflow)
+	tag, overflow = k.tag("lds_c")
+	assert.Equal(t, keyMetricsOverflowBucket, tag)
+	assert.False(t, overflow)
+	tag, _ = k.tag("lds_a")
+	assert.Equal(t, "lds_a", tag)
+
+	// Evicted keys keep their slot.
+	k.remove("lds_a")
+	k.remove("lds_c")
+	tag, _ = k.tag("lds_a")
+	assert.Equal(t, "lds_a", tag)
+	tag, overflow = k.tag("lds_c")
+	assert.Equal(t, keyMetricsOverflowBucket, tag)
+	assert.True(t, overflow)
+}
+
+func TestKeyMetricsScope(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, newTenantMapper(), mockSimpleUpstreamClient{})
+	WithKeyMetrics(&bootstrapv1.KeyMetrics{
+		Rules:   []*bootstrapv1.KeyMetrics_Rule{{KeyRegex: "lds$"}},
+		MaxKeys: 1,
+	})(orchestrator)
+
+	orchestrator.keyScope("tenant-a/lds").Counter("test").Inc(1)
+	orchestrator.keyScope("tenant-b/lds").Counter("test").Inc(1)
+	orchestrator.keyScope("tenant-b/cds").Counter("test").Inc(1)
+
+	counters := mockScope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counterValue(counters, "prefix.test+key=tenant-a/lds,tenant=tenant-a"))
+	assert.Equal(t, int64(1), counterValue(counters, "prefix.test+key=other,tenant=tenant-b"))
+	assert.Equal(t, int64(1), counterValue(counters, "prefix.test+tenant=tenant-b"))
+	assert.Equal(t, int64(1), counterValue(counters, "prefix."+metricKeyMetricsOverflow+"+"))
+
+	// Metrics are not tagged with the key without key metrics.
+	mockScope = newMockScope("prefix")
+	orchestrator = newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	orchestrator.keyScope("lds").Counter("test").Inc(1)
+	assert.Equal(t, int64(1), counterValue(mockScope.Snapshot().Counters(), "prefix.test+"))
+}
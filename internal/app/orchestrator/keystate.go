@@ -0,0 +1,285 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file tracks the lifecycle of every aggregated key as an explicit state
+// machine. The contents of this file are intended to only be used within the
+// orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+)
+
+const (
+	metricKeyStateInvalidTransition = "key_state_invalid_transition"
+)
+
+// keyState is the lifecycle state of an aggregated key. The events that move
+// a key between the states are:
+//
+//	             watched                 stream added
+//	  (none) -------------> INIT ------------------------> SUBSCRIBED
+//	    |                    |   <------------------------     |
+//	    |                    |        stream deleted           |
+//	    |   response cached  |  response cached                | response cached
+//	    +--------------------+---------------------> SERVING <-+
+//	                                                    |
+//	                          evicted, from any state   |
+//	                                                    v
+//	  (none) <---------------------------------------- DRAINING
+//	                        drained
+//
+// A key keeps serving its cached response while its upstream stream is
+// reopened, so streams only move keys that have no cached response.
+type keyState int
+
+const (
+	// keyStateInit is the state of keys that are watched, but have neither an
+	// upstream stream nor a cached response.
+	keyStateInit keyState = iota
+	// keyStateSubscribed is the state of keys with an upstream stream, but no
+	// cached response yet.
+	keyStateSubscribed
+	// keyStateServing is the state of keys with a cached response.
+	keyStateServing
+	// keyStateDraining is the state of evicted keys while their state is
+	// cleaned up. The key is forgotten once it is drained.
+	keyStateDraining
+)
+
+func (s keyState) String() string {
+	switch s {
+	case keyStateInit:
+		return "INIT"
+	case keyStateSubscribed:
+		return "SUBSCRIBED"
+	case keyStateServing:
+		return "SERVING"
+	case keyStateDraining:
+		return "DRAINING"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// keyStateTransitions are the transitions allowed from each state.
+var keyStateTransitions = map[keyState]map[keyState]bool{
+	keyStateInit:       {keyStateSubscribed: true, keyStateServing: true, keyStateDraining: true},
+	keyStateSubscribed: {keyStateInit: true, keyStateServing: true, keyStateDraining: true},
+	keyStateServing:    {keyStateDraining: true},
+	keyStateDraining:   {},
+}
+
+// keyLifecycle is the state of an aggregated key. The mutex guards the state
+// and serializes the events of the key. The representative request and the
+// upstream stream of the key, held in representativeRequests and
+// upstreamResponseMap so that they can be read without the lock, are only
+// changed while holding it, along with the state they move the key to. The
+// subscription of the key is owned by its lifecycle, so that it is forgotten
+// along with the key.
+type keyLifecycle struct {
+	mu    sync.Mutex
+	state keyState
+	since time.Time
+	// subscription is nil until the upstream stream of the key is first
+	// opened.
+	subscription *subscription
+	// acks and nacks count the responses of the key acknowledged and rejected
+	// by downstream clients, and lastError is the latest rejection or error of
+	// the upstream stream. See KeyStatus.
+	acks          uint64
+	nacks         uint64
+	lastError     string
+	lastErrorTime time.Time
+	// removed is set once the key is drained. Later events of the key apply
+	// to a new lifecycle.
+	removed bool
+}
+
+// transition moves the lifecycle to the state. It returns false, leaving the
+// state as is, if the transition is not allowed.
+func (l *keyLifecycle) transition(to keyState) bool {
+	if !keyStateTransitions[l.state][to] {
+		return false
+	}
+	l.state, l.since = to, time.Now()
+	return true
+}
+
+// keyStates holds the lifecycles of the aggregated keys.
+type keyStates struct {
+	mu sync.Mutex
+	// keys is keyed by aggregated key.
+	keys map[string]*keyLifecycle
+}
+
+func newKeyStates() *keyStates {
+	return &keyStates{keys: make(map[string]*keyLifecycle)}
+}
+
+// lock returns the locked lifecycle of the aggregated key. The lifecycle is
+// created in INIT if the key has none and create is set, and nil is returned
+// otherwise.
+func (k *keyStates) lock(aggregatedKey string, create bool) *keyLifecycle {
+	for {
+		k.mu.Lock()
+		l, ok := k.keys[aggregatedKey]
+		if !ok {
+			if !create {
+				k.mu.Unlock()
+				return nil
+			}
+			l = &keyLifecycle{state: keyStateInit, since: time.Now()}
+			k.keys[aggregatedKey] = l
+		}
+		k.mu.Unlock()
+		l.mu.Lock()
+		if !l.removed {
+			return l
+		}
+		// The key was drained while waiting for its lock.
+		l.mu.Unlock()
+	}
+}
+
+// state returns the state of the aggregated key, and false if the key has
+// none.
+func (k *keyStates) state(aggregatedKey string) (keyState, bool) {
+	l := k.lock(aggregatedKey, false)
+	if l == nil {
+		return keyStateInit, false
+	}
+	defer l.mu.Unlock()
+	return l.state, true
+}
+
+// onKeyWatched records a watch of the aggregated key, which moves keys
+// without a state to INIT.
+func (o *orchestrator) onKeyWatched(aggregatedKey string) {
+	l := o.keyStates.lock(aggregatedKey, true)
+	l.mu.Unlock()
+}
+
+// setRepresentative remembers the request as the representative request of
+// the aggregated key, unless the key already has one.
+func (o *orchestrator) setRepresentative(aggregatedKey string, req gcp.Request) {
+	l := o.keyStates.lock(aggregatedKey, true)
+	defer l.mu.Unlock()
+	o.representativeRequests.LoadOrStore(aggregatedKey, req)
+}
+
+// getSubscription returns the subscription of the aggregated key, creating it
+// if it does not exist.
+func (o *orchestrator) getSubscription(aggregatedKey string) *subscription {
+	l := o.keyStates.lock(aggregatedKey, true)
+	defer l.mu.Unlock()
+	if l.subscription == nil {
+		l.subscription = &subscription{}
+	}
+	return l.subscription
+}
+
+// addUpstream records the upstream stream opened for the aggregated key with
+// the subscription, which moves keys in INIT to SUBSCRIBED. It returns false
+// if the key already has a stream, or if the key was drained since the
+// subscription was returned, in which case the stream must be shut down.
+func (o *orchestrator) addUpstream(
+	aggregatedKey string,
+	s *subscription,
+	responseChannel <-chan *discovery.DiscoveryResponse,
+) (upstreamResponseChannel, bool) {
+	l := o.keyStates.lock(aggregatedKey, false)
+	if l == nil {
+		return upstreamResponseChannel{}, false
+	}
+	defer l.mu.Unlock()
+	if l.subscription != s {
+		return upstreamResponseChannel{}, false
+	}
+	channel, exists := o.upstreamResponseMap.add(aggregatedKey, responseChannel)
+	if exists {
+		return upstreamResponseChannel{}, false
+	}
+	if l.state == keyStateInit {
+		o.transitionKey(aggregatedKey, l, keyStateSubscribed)
+	}
+	return channel, true
+}
+
+// deleteUpstream closes the upstream stream of the aggregated key, which moves
+// keys in SUBSCRIBED back to INIT. If done is not nil, the stream is only
+// closed if done is its done channel, so that a stream that was replaced in
+// the meantime is left open.
+func (o *orchestrator) deleteUpstream(aggregatedKey string, done chan bool) {
+	l := o.keyStates.lock(aggregatedKey, false)
+	if l == nil {
+		return
+	}
+	defer l.mu.Unlock()
+	if done == nil {
+		o.upstreamResponseMap.delete(aggregatedKey)
+	} else {
+		o.upstreamResponseMap.deleteStream(aggregatedKey, done)
+	}
+	if l.state == keyStateSubscribed && !o.upstreamResponseMap.exists(aggregatedKey) {
+		o.transitionKey(aggregatedKey, l, keyStateInit)
+	}
+}
+
+// deleteAllUpstreams closes the upstream streams of every aggregated key. This
+// is called during server shutdown, and when this replica steps down.
+func (o *orchestrator) deleteAllUpstreams() {
+	o.upstreamResponseMap.internal.Range(func(aggregatedKey, _ interface{}) bool {
+		o.deleteUpstream(aggregatedKey.(string), nil)
+		return true
+	})
+}
+
+// onKeyServed records that a response of the aggregated key was cached, which
+// moves the key to SERVING.
+func (o *orchestrator) onKeyServed(aggregatedKey string) {
+	l := o.keyStates.lock(aggregatedKey, true)
+	defer l.mu.Unlock()
+	if l.state != keyStateServing {
+		o.transitionKey(aggregatedKey, l, keyStateServing)
+	}
+}
+
+// drainKey moves the aggregated key to DRAINING, cleans it up, and forgets
+// it. The lock of the key is held while it is cleaned up, so the events of
+// the key that race with the cleanup apply to a new lifecycle once it is
+// done. cleanup must not wait for the events of the key, and changes the
+// representative request and upstream stream of the key directly.
+func (o *orchestrator) drainKey(aggregatedKey string, cleanup func()) {
+	l := o.keyStates.lock(aggregatedKey, true)
+	defer l.mu.Unlock()
+	o.transitionKey(aggregatedKey, l, keyStateDraining)
+	cleanup()
+	l.removed = true
+	o.keyStates.mu.Lock()
+	delete(o.keyStates.keys, aggregatedKey)
+	o.keyStates.mu.Unlock()
+}
+
+// transitionKey moves the locked lifecycle of the aggregated key to the
+// state, and counts the transitions that are not allowed.
+func (o *orchestrator) transitionKey(aggregatedKey string, l *keyLifecycle, to keyState) {
+	from := l.state
+	if !l.transition(to) {
+		o.keyScope(aggregatedKey).Counter(metricKeyStateInvalidTransition).Inc(1)
+		o.logger.With("key", aggregatedKey).With("from", from.String()).With("to", to.String()).
+			Warn(context.Background(), "invalid key state transition")
+		return
+	}
+	o.logger.With("key", aggregatedKey).With("from", from.String()).With("to", to.String()).
+		Debug(context.Background(), "key state changed")
+}
@@ -0,0 +1,176 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/stretchr/testify/assert"
+)
+
+func assertKeyState(t *testing.T, o *orchestrator, aggregatedKey string, expected keyState) {
+	state, ok := o.keyStates.state(aggregatedKey)
+	assert.True(t, ok)
+	assert.Equal(t, expected.String(), state.String())
+}
+
+func TestKeyStateTransitions(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	_, ok := orchestrator.keyStates.state("lds")
+	assert.False(t, ok)
+
+	orchestrator.onKeyWatched("lds")
+	assertKeyState(t, orchestrator, "lds", keyStateInit)
+	subscription := orchestrator.getSubscription("lds")
+	stream, added := orchestrator.addUpstream("lds", subscription, make(chan *v2.DiscoveryResponse))
+	assert.True(t, added)
+	_, added = orchestrator.addUpstream("lds", subscription, make(chan *v2.DiscoveryResponse))
+	assert.False(t, added)
+	assertKeyState(t, orchestrator, "lds", keyStateSubscribed)
+	// Keys without a cached response move back to INIT once their stream is
+	// deleted, but not for the deletion of a stream that was replaced.
+	orchestrator.deleteUpstream("lds", make(chan bool))
+	assertKeyState(t, orchestrator, "lds", keyStateSubscribed)
+	orchestrator.deleteUpstream("lds", stream.done)
+	assertKeyState(t, orchestrator, "lds", keyStateInit)
+	assert.False(t, orchestrator.upstreamResponseMap.exists("lds"))
+
+	_, added = orchestrator.addUpstream("lds", subscription, make(chan *v2.DiscoveryResponse))
+	assert.True(t, added)
+	orchestrator.onKeyServed("lds")
+	assertKeyState(t, orchestrator, "lds", keyStateServing)
+	// Keys keep serving their cached response without a stream.
+	orchestrator.deleteUpstream("lds", nil)
+	assertKeyState(t, orchestrator, "lds", keyStateServing)
+
+	orchestrator.setRepresentative("lds", gcp.Request{TypeUrl: upstream.ListenerTypeURL})
+	_, added = orchestrator.addUpstream("lds", subscription, make(chan *v2.DiscoveryResponse))
+	assert.True(t, added)
+	orchestrator.drainKey("lds", func() {
+		// The lock of the key is held while it is cleaned up.
+		assert.Equal(t, keyStateDraining, orchestrator.keyStates.keys["lds"].state)
+		orchestrator.cleanUpEvicted("lds", cache.Resource{})
+	})
+	_, ok = orchestrator.keyStates.state("lds")
+	assert.False(t, ok)
+	assert.False(t, orchestrator.upstreamResponseMap.exists("lds"))
+	_, ok = orchestrator.representativeRequests.Load("lds")
+	assert.False(t, ok)
+	// The subscription of the drained lifecycle cannot add a stream to the
+	// new lifecycle.
+	orchestrator.onKeyWatched("lds")
+	_, added = orchestrator.addUpstream("lds", subscription, make(chan *v2.DiscoveryResponse))
+	assert.False(t, added)
+	assert.NotSame(t, subscription, orchestrator.getSubscription("lds"))
+	assertKeyState(t, orchestrator, "lds", keyStateInit)
+	_, ok = mockScope.Snapshot().Counters()["prefix."+metricKeyStateInvalidTransition+"+"]
+	assert.False(t, ok)
+
+	l := &keyLifecycle{state: keyStateServing}
+	assert.False(t, l.transition(keyStateInit))
+	assert.Equal(t, keyStateServing, l.state)
+	assert.True(t, l.transition(keyStateDraining))
+	assert.False(t, l.transition(keyStateServing))
+}
+
+func TestKeyStateLifecycle(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{
+		responseChan: upstreamResponseChannel,
+	})
+
+	req := v2.DiscoveryRequest{TypeUrl: upstream.ListenerTypeURL}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	assertKeyState(t, orchestrator, "lds", keyStateSubscribed)
+
+	resp := newResponse(t, upstream.ListenerTypeURL, "1", &v2.Listener{Name: "listener"})
+	upstreamResponseChannel <- resp
+	assertEqualResponse(t, <-respChannel, *resp, req)
+	assertKeyState(t, orchestrator, "lds", keyStateServing)
+
+	assert.Equal(t, 1, orchestrator.EvictLeastRecentlyUsed(1))
+	_, ok := orchestrator.keyStates.state("lds")
+	assert.False(t, ok)
+}
+
+// TestKeyStateStress races watches, responses and evictions of the same keys,
+// and checks that every transition is allowed, that keys are SUBSCRIBED only
+// while they have an upstream stream, and that no key is left draining or
+// subscribed once the upstream streams are closed.
+func TestKeyStateStress(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{
+		responseChan: make(chan *v2.DiscoveryResponse),
+	})
+	typeURLs := map[string]string{"lds": upstream.ListenerTypeURL, "cds": upstream.ClusterTypeURL}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				for key, typeURL := range typeURLs {
+					switch (i + j) % 3 {
+					case 0:
+						_, cancelWatch := orchestrator.CreateWatch(v2.DiscoveryRequest{
+							TypeUrl:     typeURL,
+							VersionInfo: fmt.Sprint(j),
+						})
+						cancelWatch()
+					case 1:
+						orchestrator.ApplyReplicatedResponse(key, newResponse(t, typeURL, fmt.Sprint(j)))
+					default:
+						orchestrator.EvictLeastRecentlyUsed(1)
+					}
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	orchestrator.keyStates.mu.Lock()
+	for key, l := range orchestrator.keyStates.keys {
+		l.mu.Lock()
+		hasStream := orchestrator.upstreamResponseMap.exists(key)
+		if l.state == keyStateSubscribed {
+			assert.True(t, hasStream)
+		}
+		if hasStream {
+			assert.NotEqual(t, keyStateInit, l.state)
+		}
+		l.mu.Unlock()
+	}
+	orchestrator.keyStates.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	orchestrator.shutdown(ctx)
+	assert.Eventually(t, func() bool {
+		orchestrator.keyStates.mu.Lock()
+		lifecycles := make([]*keyLifecycle, 0, len(orchestrator.keyStates.keys))
+		for _, l := range orchestrator.keyStates.keys {
+			lifecycles = append(lifecycles, l)
+		}
+		orchestrator.keyStates.mu.Unlock()
+		for _, l := range lifecycles {
+			l.mu.Lock()
+			state := l.state
+			l.mu.Unlock()
+			if state == keyStateDraining || state == keyStateSubscribed {
+				return false
+			}
+		}
+		return true
+	}, time.Second, 10*time.Millisecond)
+	_, ok := mockScope.Snapshot().Counters()["prefix."+metricKeyStateInvalidTransition+"+"]
+	assert.False(t, ok)
+}
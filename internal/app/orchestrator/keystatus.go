@@ -0,0 +1,136 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file summarizes the propagation of an aggregated key for deploy
+// tooling, which polls it to gate rollouts on the versions acknowledged by
+// downstream clients. The contents of this file are intended to only be used
+// within the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"fmt"
+	"time"
+
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+)
+
+// KeyStatus is the propagation of an aggregated key. Its JSON schema is
+// polled by deploy tooling, so fields may be added but not renamed or
+// removed.
+type KeyStatus struct {
+	Key string `json:"key"`
+	// State is the lifecycle state of the key: INIT, SUBSCRIBED, SERVING, or
+	// DRAINING.
+	State string `json:"state"`
+	// ServedVersion is the version served to every node, which lags behind
+	// UpstreamVersion while the key is pinned or a rollout is in progress.
+	// Both are empty until a response is cached.
+	ServedVersion   string `json:"served_version"`
+	UpstreamVersion string `json:"upstream_version"`
+	// Watches is the number of open downstream watches, of which
+	// WatchesAtServedVersion acknowledged the served version.
+	Watches                int `json:"watches"`
+	WatchesAtServedVersion int `json:"watches_at_served_version"`
+	// Acks and Nacks count the responses acknowledged and rejected by
+	// downstream clients. AckRatio is the ratio of the responses that were
+	// acknowledged, and is null until a response is either.
+	Acks     uint64   `json:"acks"`
+	Nacks    uint64   `json:"nacks"`
+	AckRatio *float64 `json:"ack_ratio"`
+	// LastError is the latest rejection of a response or error of the
+	// upstream stream, and LastErrorTime is null until one occurs.
+	LastError     string     `json:"last_error"`
+	LastErrorTime *time.Time `json:"last_error_time"`
+}
+
+// GetKeyStatus returns the propagation of the aggregated key. It returns
+// false if the key is not watched.
+func (o *orchestrator) GetKeyStatus(aggregatedKey string) (KeyStatus, bool) {
+	l := o.keyStates.lock(aggregatedKey, false)
+	if l == nil {
+		return KeyStatus{}, false
+	}
+	status := KeyStatus{
+		Key:       aggregatedKey,
+		State:     l.state.String(),
+		Acks:      l.acks,
+		Nacks:     l.nacks,
+		LastError: l.lastError,
+	}
+	if !l.lastErrorTime.IsZero() {
+		lastErrorTime := l.lastErrorTime
+		status.LastErrorTime = &lastErrorTime
+	}
+	l.mu.Unlock()
+	if answered := status.Acks + status.Nacks; answered > 0 {
+		ackRatio := float64(status.Acks) / float64(answered)
+		status.AckRatio = &ackRatio
+	}
+
+	// The read-only cache does not record the use of the key, so that polling
+	// the status does not keep the key from being evicted.
+	cached, err := o.cache.GetReadOnlyCache().FetchReadOnly(aggregatedKey)
+	if err == nil && cached.Resp != nil {
+		status.UpstreamVersion = cached.Resp.GetVersionInfo()
+		status.ServedVersion = status.UpstreamVersion
+		if pinned, ok := o.pinnedResponse(aggregatedKey); ok {
+			status.ServedVersion = pinned.GetVersionInfo()
+		} else if o.rollouts != nil {
+			if stable, ok := o.rollouts.stableResponse(aggregatedKey); ok {
+				status.ServedVersion = stable.GetVersionInfo()
+			}
+		}
+	}
+	for _, watch := range o.downstreamResponseMap.list() {
+		if watch.Key != aggregatedKey {
+			continue
+		}
+		status.Watches++
+		if status.ServedVersion != "" && watch.Version == status.ServedVersion {
+			status.WatchesAtServedVersion++
+		}
+	}
+	return status, true
+}
+
+// recordKeyResponseStatus counts the acknowledgement or rejection of a
+// response of the aggregated key by the request, and records the error
+// detail of rejections. Requests with an error detail are rejections even if
+// their nonce is unknown, e.g. after the stream was reopened, in which case
+// the rejected version is empty and omitted from the error.
+func (o *orchestrator) recordKeyResponseStatus(aggregatedKey string, req *gcp.Request, rejectedVersion string) {
+	l := o.keyStates.lock(aggregatedKey, false)
+	if l == nil {
+		return
+	}
+	defer l.mu.Unlock()
+	if req.GetErrorDetail() == nil {
+		l.acks++
+		return
+	}
+	l.nacks++
+	if rejectedVersion == "" {
+		l.lastError = fmt.Sprintf("response rejected by node %s: %s",
+			req.GetNode().GetId(), req.GetErrorDetail().GetMessage())
+	} else {
+		l.lastError = fmt.Sprintf("version %s rejected by node %s: %s",
+			rejectedVersion, req.GetNode().GetId(), req.GetErrorDetail().GetMessage())
+	}
+	l.lastErrorTime = time.Now()
+}
+
+// recordKeyError records the error of the upstream stream of the aggregated
+// key.
+func (o *orchestrator) recordKeyError(aggregatedKey string, err error) {
+	l := o.keyStates.lock(aggregatedKey, false)
+	if l == nil {
+		return
+	}
+	defer l.mu.Unlock()
+	l.lastError = err.Error()
+	l.lastErrorTime = time.Now()
+}
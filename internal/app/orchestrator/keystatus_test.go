@@ -0,0 +1,120 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetKeyStatus(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	_, ok := orchestrator.GetKeyStatus("lds")
+	assert.False(t, ok)
+
+	assert.NoError(t, orchestrator.OnStreamOpen(context.Background(), 1, ""))
+	req := gcp.Request{TypeUrl: upstream.ListenerTypeURL, Node: &v2_core.Node{Id: "node"}}
+	assert.NoError(t, orchestrator.OnStreamRequest(1, &req))
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	status1, ok := orchestrator.GetKeyStatus("lds")
+	assert.True(t, ok)
+	assert.Equal(t, KeyStatus{Key: "lds", State: "SUBSCRIBED", Watches: 1}, status1)
+
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", newRolloutResponse("1")))
+	gotResponse, err := (<-respChannel).GetDiscoveryResponse()
+	assert.NoError(t, err)
+	orchestrator.OnStreamResponse(1, &req, gotResponse)
+	cancelWatch()
+
+	// The client acknowledges the response.
+	req.VersionInfo = "1"
+	req.ResponseNonce = gotResponse.GetNonce()
+	assert.NoError(t, orchestrator.OnStreamRequest(1, &req))
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	status1, _ = orchestrator.GetKeyStatus("lds")
+	assert.Equal(t, "SERVING", status1.State)
+	assert.Equal(t, "1", status1.ServedVersion)
+	assert.Equal(t, "1", status1.UpstreamVersion)
+	assert.Equal(t, 1, status1.Watches)
+	assert.Equal(t, 1, status1.WatchesAtServedVersion)
+	assert.Equal(t, uint64(1), status1.Acks)
+	assert.Equal(t, 1.0, *status1.AckRatio)
+	assert.Nil(t, status1.LastErrorTime)
+
+	// The client rejects the next response, while the previous version stays
+	// pinned for the other nodes.
+	assert.NoError(t, orchestrator.PinVersion("lds", "1"))
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", newRolloutResponse("2")))
+	assert.True(t, orchestrator.UnpinVersion("lds"))
+	gotResponse, err = (<-respChannel).GetDiscoveryResponse()
+	assert.NoError(t, err)
+	orchestrator.OnStreamResponse(1, &req, gotResponse)
+	cancelWatch()
+	req.ResponseNonce = gotResponse.GetNonce()
+	req.ErrorDetail = status.New(codes.InvalidArgument, "invalid listener").Proto()
+	assert.NoError(t, orchestrator.OnStreamRequest(1, &req))
+	_, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	status2, _ := orchestrator.GetKeyStatus("lds")
+	assert.Equal(t, "2", status2.ServedVersion)
+	assert.Equal(t, 0, status2.WatchesAtServedVersion)
+	assert.Equal(t, uint64(1), status2.Nacks)
+	assert.Equal(t, 0.5, *status2.AckRatio)
+	assert.Equal(t, "version 2 rejected by node node: invalid listener", status2.LastError)
+	assert.NotNil(t, status2.LastErrorTime)
+
+	// Errors of the upstream stream replace the last error.
+	orchestrator.recordKeyError("lds", errors.New("upstream stream for aggregated key lds closed"))
+	status2, _ = orchestrator.GetKeyStatus("lds")
+	assert.Equal(t, "upstream stream for aggregated key lds closed", status2.LastError)
+}
+
+func TestGetKeyStatusUnknownNonce(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	assert.NoError(t, orchestrator.OnStreamOpen(context.Background(), 1, ""))
+
+	// The client rejects a response that was not sent on its stream, e.g.
+	// before it reconnected, so the rejected version is unknown.
+	req := gcp.Request{
+		TypeUrl:       upstream.ListenerTypeURL,
+		Node:          &v2_core.Node{Id: "node"},
+		VersionInfo:   "1",
+		ResponseNonce: "unknown",
+		ErrorDetail:   status.New(codes.InvalidArgument, "invalid listener").Proto(),
+	}
+	_, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	keyStatus, ok := orchestrator.GetKeyStatus("lds")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0), keyStatus.Acks)
+	assert.Equal(t, uint64(1), keyStatus.Nacks)
+	assert.Equal(t, 0.0, *keyStatus.AckRatio)
+	assert.Equal(t, "response rejected by node node: invalid listener", keyStatus.LastError)
+	assert.NotNil(t, keyStatus.LastErrorTime)
+}
+
+func TestGetKeyStatusPinned(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	respChannel, cancelWatch := orchestrator.CreateWatch(newRolloutRequest("node", false))
+	defer cancelWatch()
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", newRolloutResponse("1")))
+	<-respChannel
+	assert.NoError(t, orchestrator.PinVersion("lds", ""))
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", &v2.DiscoveryResponse{
+		VersionInfo: "2",
+		TypeUrl:     upstream.ListenerTypeURL,
+	}))
+	status, ok := orchestrator.GetKeyStatus("lds")
+	assert.True(t, ok)
+	assert.Equal(t, "1", status.ServedVersion)
+	assert.Equal(t, "2", status.UpstreamVersion)
+	assert.Nil(t, status.AckRatio)
+}
@@ -0,0 +1,39 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file opens and closes upstream streams as this replica gains and loses
+// leadership. The contents of this file are intended to only be used within
+// the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+)
+
+// isLeader returns true if this replica should hold upstream streams.
+func (o *orchestrator) isLeader() bool {
+	return o.elector == nil || o.elector.IsLeader()
+}
+
+// onLeaderChange opens an upstream stream for every known aggregated key when
+// this replica becomes the leader, and closes all upstream streams when it
+// steps down. Cached responses and downstream watches are left in place.
+func (o *orchestrator) onLeaderChange(isLeader bool) {
+	ctx := context.Background()
+	o.upstreamMu.Lock()
+	defer o.upstreamMu.Unlock()
+	if !isLeader {
+		o.deleteAllUpstreams()
+		return
+	}
+	o.representativeRequests.Range(func(aggregatedKey, req interface{}) bool {
+		o.openUpstream(ctx, aggregatedKey.(string), req.(gcp.Request))
+		return true
+	})
+}
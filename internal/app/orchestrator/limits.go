@@ -0,0 +1,81 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file checks upstream responses against the configured size limits.
+// The contents of this file are intended to only be used within the
+// orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	metricResponseLimitExceeded = "response_limit_exceeded"
+	metricResponseLimitRejected = "response_limit_rejected"
+)
+
+// responseLimits holds the response limit of each type URL, where the empty
+// type URL holds the limit of the types without a limit of their own.
+type responseLimits map[string]*bootstrapv1.ResponseLimit
+
+// newResponseLimits returns the limits described by the config, or nil if
+// responses are not limited.
+func newResponseLimits(config []*bootstrapv1.ResponseLimit) responseLimits {
+	if len(config) == 0 {
+		return nil
+	}
+	limits := make(responseLimits, len(config))
+	for _, limit := range config {
+		limits[limit.GetTypeUrl()] = limit
+	}
+	return limits
+}
+
+func (l responseLimits) get(typeURL string) *bootstrapv1.ResponseLimit {
+	if limit, ok := l[typeURL]; ok {
+		return limit
+	}
+	return l[""]
+}
+
+// isOverLimit checks the upstream response against the limit of its type,
+// reporting responses over the limit. It returns true if the response should
+// be dropped.
+func (o *orchestrator) isOverLimit(ctx context.Context, aggregatedKey string, resp *discovery.DiscoveryResponse) bool {
+	limit := o.responseLimits.get(resp.GetTypeUrl())
+	if limit == nil {
+		return false
+	}
+	logger := o.logger.With("key", aggregatedKey).With("version", resp.GetVersionInfo())
+	exceeded := false
+	if maxResources := limit.GetMaxResources(); maxResources > 0 && len(resp.GetResources()) > int(maxResources) {
+		exceeded = true
+		logger = logger.With("resources", len(resp.GetResources())).With("max resources", maxResources)
+	}
+	if maxBytes := limit.GetMaxBytes(); maxBytes > 0 {
+		if size := proto.Size(resp); uint64(size) > maxBytes {
+			exceeded = true
+			logger = logger.With("bytes", size).With("max bytes", maxBytes)
+		}
+	}
+	if !exceeded {
+		return false
+	}
+	o.keyScope(aggregatedKey).Counter(metricResponseLimitExceeded).Inc(1)
+	if limit.GetAction() == bootstrapv1.ResponseLimit_WARN {
+		logger.Warn(ctx, "upstream response exceeds the response limit, serving")
+		return false
+	}
+	o.keyScope(aggregatedKey).Counter(metricResponseLimitRejected).Inc(1)
+	logger.Error(ctx, "upstream response exceeds the response limit, dropping")
+	return true
+}
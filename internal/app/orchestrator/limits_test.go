@@ -0,0 +1,80 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSizedResponse(typeURL string, resources int) *v2.DiscoveryResponse {
+	resp := &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: typeURL}
+	for i := 0; i < resources; i++ {
+		resp.Resources = append(resp.Resources, &any.Any{Value: make([]byte, 100)})
+	}
+	return resp
+}
+
+func TestNewResponseLimits_Disabled(t *testing.T) {
+	assert.Nil(t, newResponseLimits(nil))
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	assert.False(t, orchestrator.isOverLimit(context.Background(), "lds",
+		newSizedResponse(upstream.ListenerTypeURL, 100)))
+}
+
+func TestIsOverLimit(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithResponseLimits([]*bootstrapv1.ResponseLimit{
+		{MaxResources: 2},
+		{TypeUrl: upstream.ClusterTypeURL, MaxBytes: 1000},
+		{TypeUrl: upstream.EndpointTypeURL, MaxResources: 2, Action: bootstrapv1.ResponseLimit_WARN},
+	})(orchestrator)
+	ctx := context.Background()
+
+	// Types without a limit of their own fall back to the limit without a type URL.
+	assert.False(t, orchestrator.isOverLimit(ctx, "lds", newSizedResponse(upstream.ListenerTypeURL, 2)))
+	assert.True(t, orchestrator.isOverLimit(ctx, "lds", newSizedResponse(upstream.ListenerTypeURL, 3)))
+
+	// Only the limit of the type applies.
+	assert.False(t, orchestrator.isOverLimit(ctx, "cds", newSizedResponse(upstream.ClusterTypeURL, 5)))
+	assert.True(t, orchestrator.isOverLimit(ctx, "cds", newSizedResponse(upstream.ClusterTypeURL, 10)))
+
+	// Responses over a limit that warns are served.
+	assert.False(t, orchestrator.isOverLimit(ctx, "eds", newSizedResponse(upstream.EndpointTypeURL, 3)))
+
+	counters := mockScope.Snapshot().Counters()
+	testutils.AssertCounterValue(t, counters, "prefix.response_limit_exceeded", 3)
+	testutils.AssertCounterValue(t, counters, "prefix.response_limit_rejected", 2)
+}
+
+func TestResponseLimitKeepsCachedResponse(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	WithResponseLimits([]*bootstrapv1.ResponseLimit{{MaxResources: 1}})(orchestrator)
+	req := v2.DiscoveryRequest{TypeUrl: upstream.ListenerTypeURL}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+
+	served := newSizedResponse(upstream.ListenerTypeURL, 1)
+	upstreamResponseChannel <- served
+	assertEqualResponse(t, <-respChannel, *served, req)
+	oversized := newSizedResponse(upstream.ListenerTypeURL, 2)
+	oversized.VersionInfo = "2"
+	upstreamResponseChannel <- oversized
+	// The worker has handled the oversized response once it receives the
+	// next one, which is diffed against the response cached before.
+	upstreamResponseChannel <- served
+	assert.Eventually(t, func() bool {
+		summary, ok := orchestrator.GetLastDiff("lds")
+		return ok && summary.PreviousVersion == "1" && summary.Version == "1"
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,270 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file lints the references between the resources of upstream
+// responses, such as the clusters routed to by a route configuration, against
+// the resources in the cache. The contents of this file are intended to only
+// be used within the orchestrator module and should not be exported, except
+// for the findings shown by the admin server.
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"github.com/envoyproxy/xds-relay/internal/app/diff"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+const (
+	// metricLintPrefix prefixes the check of the findings counted when an
+	// upstream response is linted, e.g. lint_route_cluster_missing.
+	metricLintPrefix  = "lint_"
+	metricLintBlocked = "lint_blocked"
+)
+
+// LintCheck is the check that reported a finding.
+type LintCheck string
+
+const (
+	// LintRouteClusterMissing is reported for the clusters routed to by a
+	// route configuration that are in no cached CDS response.
+	LintRouteClusterMissing LintCheck = "route_cluster_missing"
+	// LintListenerRouteMissing is reported for the route configurations of a
+	// listener that are in no cached RDS response.
+	LintListenerRouteMissing LintCheck = "listener_route_missing"
+	// LintClusterEndpointsMissing is reported for the EDS clusters whose
+	// endpoints are in no cached EDS response.
+	LintClusterEndpointsMissing LintCheck = "cluster_endpoints_missing"
+)
+
+// lintChecks maps the checks of the config to the checks of the findings.
+var lintChecks = map[bootstrapv1.Linting_Check]LintCheck{
+	bootstrapv1.Linting_ROUTE_CLUSTER_MISSING:     LintRouteClusterMissing,
+	bootstrapv1.Linting_LISTENER_ROUTE_MISSING:    LintListenerRouteMissing,
+	bootstrapv1.Linting_CLUSTER_ENDPOINTS_MISSING: LintClusterEndpointsMissing,
+}
+
+// LintFinding describes a reference of a resource of the cached response of
+// an aggregated key to a resource that is missing from the cache.
+type LintFinding struct {
+	Key      string
+	Tenant   string
+	Check    LintCheck
+	Resource string
+	Message  string
+}
+
+// reference is a reference of a resource to another resource.
+type reference struct {
+	typeURL string
+	name    string
+}
+
+// resourceReferences returns the references of the resource to resources of
+// other types.
+func resourceReferences(resource *any.Any) ([]reference, error) {
+	var references []reference
+	switch resource.GetTypeUrl() {
+	case upstream.RouteTypeURL:
+		var routeConfiguration discovery.RouteConfiguration
+		if err := ptypes.UnmarshalAny(resource, &routeConfiguration); err != nil {
+			return nil, err
+		}
+		for _, virtualHost := range routeConfiguration.GetVirtualHosts() {
+			for _, route := range virtualHost.GetRoutes() {
+				if cluster := route.GetRoute().GetCluster(); cluster != "" {
+					references = append(references, reference{typeURL: upstream.ClusterTypeURL, name: cluster})
+				}
+				for _, cluster := range route.GetRoute().GetWeightedClusters().GetClusters() {
+					references = append(references, reference{typeURL: upstream.ClusterTypeURL, name: cluster.GetName()})
+				}
+			}
+		}
+	case upstream.ListenerTypeURL:
+		var listener discovery.Listener
+		if err := ptypes.UnmarshalAny(resource, &listener); err != nil {
+			return nil, err
+		}
+		for _, filterChain := range listener.GetFilterChains() {
+			for _, filter := range filterChain.GetFilters() {
+				var manager hcm.HttpConnectionManager
+				if !ptypes.Is(filter.GetTypedConfig(), &manager) {
+					continue
+				}
+				if err := ptypes.UnmarshalAny(filter.GetTypedConfig(), &manager); err != nil {
+					return nil, err
+				}
+				if name := manager.GetRds().GetRouteConfigName(); name != "" {
+					references = append(references, reference{typeURL: upstream.RouteTypeURL, name: name})
+				}
+			}
+		}
+	case upstream.ClusterTypeURL:
+		var cluster discovery.Cluster
+		if err := ptypes.UnmarshalAny(resource, &cluster); err != nil {
+			return nil, err
+		}
+		if cluster.GetType() == discovery.Cluster_EDS {
+			name := cluster.GetEdsClusterConfig().GetServiceName()
+			if name == "" {
+				name = cluster.GetName()
+			}
+			references = append(references, reference{typeURL: upstream.EndpointTypeURL, name: name})
+		}
+	}
+	return references, nil
+}
+
+// referenceChecks maps the type URL of a referencing resource to the check
+// of its references.
+var referenceChecks = map[string]LintCheck{
+	upstream.RouteTypeURL:    LintRouteClusterMissing,
+	upstream.ListenerTypeURL: LintListenerRouteMissing,
+	upstream.ClusterTypeURL:  LintClusterEndpointsMissing,
+}
+
+// resourceIndex holds the names of the resources in the cache, by type URL.
+type resourceIndex map[string]map[string]bool
+
+// newResourceIndex indexes the resources of the cached responses, with the
+// response of the aggregated key in place of its cached response, if any.
+func newResourceIndex(c cache.Cache, aggregatedKey string, resp *discovery.DiscoveryResponse) resourceIndex {
+	index := make(resourceIndex)
+	add := func(resp *discovery.DiscoveryResponse) {
+		names, ok := index[resp.GetTypeUrl()]
+		if !ok {
+			names = make(map[string]bool)
+			index[resp.GetTypeUrl()] = names
+		}
+		for name := range diff.ResourcesByName(resp.GetResources()) {
+			names[name] = true
+		}
+	}
+	c.Range(func(key string, resource cache.Resource) bool {
+		if resource.Resp != nil && key != aggregatedKey {
+			add(resource.Resp)
+		}
+		return true
+	})
+	if resp != nil {
+		add(resp)
+	}
+	return index
+}
+
+// linter runs the checks of the config.
+type linter struct {
+	checks map[LintCheck]bool
+	block  bool
+}
+
+func newLinter(config *bootstrapv1.Linting) *linter {
+	l := &linter{checks: make(map[LintCheck]bool), block: config.GetBlock()}
+	for _, check := range config.GetChecks() {
+		l.checks[lintChecks[check]] = true
+	}
+	if len(l.checks) == 0 {
+		for _, check := range lintChecks {
+			l.checks[check] = true
+		}
+	}
+	return l
+}
+
+// lint returns the findings of the response of the aggregated key. The
+// references to types without a cached response are not checked.
+func (l *linter) lint(aggregatedKey string, resp *discovery.DiscoveryResponse, index resourceIndex) []LintFinding {
+	check, ok := referenceChecks[resp.GetTypeUrl()]
+	if !ok || !l.checks[check] {
+		return nil
+	}
+	var findings []LintFinding
+	for name, resource := range diff.ResourcesByName(resp.GetResources()) {
+		references, err := resourceReferences(resource)
+		if err != nil {
+			continue
+		}
+		for _, ref := range references {
+			names, ok := index[ref.typeURL]
+			if !ok || names[ref.name] {
+				continue
+			}
+			findings = append(findings, LintFinding{
+				Key:      aggregatedKey,
+				Check:    check,
+				Resource: name,
+				Message:  fmt.Sprintf("%s references %s, which is in no cached response of type %s", name, ref.name, ref.typeURL),
+			})
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Resource != findings[j].Resource {
+			return findings[i].Resource < findings[j].Resource
+		}
+		return findings[i].Message < findings[j].Message
+	})
+	return findings
+}
+
+// isLintBlocked lints the upstream response, counting its findings. It
+// returns true if the response has findings and should be dropped.
+func (o *orchestrator) isLintBlocked(
+	ctx context.Context,
+	aggregatedKey string,
+	resp *discovery.DiscoveryResponse,
+) bool {
+	if o.linter == nil {
+		return false
+	}
+	findings := o.linter.lint(aggregatedKey, resp, newResourceIndex(o.cache, aggregatedKey, resp))
+	if len(findings) == 0 {
+		return false
+	}
+	for _, finding := range findings {
+		o.keyScope(aggregatedKey).Counter(metricLintPrefix + string(finding.Check)).Inc(1)
+	}
+	logger := o.logger.With("key", aggregatedKey).With("version", resp.GetVersionInfo()).
+		With("findings", len(findings)).With("first", findings[0].Message)
+	if !o.linter.block {
+		logger.Warn(ctx, "upstream response has lint findings, serving")
+		return false
+	}
+	o.keyScope(aggregatedKey).Counter(metricLintBlocked).Inc(1)
+	logger.Error(ctx, "upstream response has lint findings, dropping")
+	return true
+}
+
+// GetLintFindings lints the cached response of every aggregated key against
+// the cache, and returns the findings ordered by aggregated key. It returns
+// nil if linting is not enabled.
+func (o *orchestrator) GetLintFindings() []LintFinding {
+	if o.linter == nil {
+		return nil
+	}
+	index := newResourceIndex(o.cache, "", nil)
+	var findings []LintFinding
+	o.cache.Range(func(key string, resource cache.Resource) bool {
+		if resource.Resp == nil {
+			return true
+		}
+		for _, finding := range o.linter.lint(key, resource.Resp, index) {
+			finding.Tenant = o.getTenant(key)
+			findings = append(findings, finding)
+		}
+		return true
+	})
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].Key < findings[j].Key })
+	return findings
+}
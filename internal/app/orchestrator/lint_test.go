@@ -0,0 +1,172 @@
+package orchestrator
+
+import (
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	listener "github.com/envoyproxy/go-control-plane/envoy/api/v2/listener"
+	route "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+)
+
+func newResponse(t *testing.T, typeURL string, version string, resources ...proto.Message) *v2.DiscoveryResponse {
+	resp := &v2.DiscoveryResponse{VersionInfo: version, TypeUrl: typeURL}
+	for _, resource := range resources {
+		packed, err := ptypes.MarshalAny(resource)
+		assert.NoError(t, err)
+		resp.Resources = append(resp.Resources, packed)
+	}
+	return resp
+}
+
+func newRDSListener(t *testing.T, name string, routeConfigName string) *v2.Listener {
+	manager, err := ptypes.MarshalAny(&hcm.HttpConnectionManager{
+		RouteSpecifier: &hcm.HttpConnectionManager_Rds{Rds: &hcm.Rds{RouteConfigName: routeConfigName}},
+	})
+	assert.NoError(t, err)
+	return &v2.Listener{
+		Name: name,
+		FilterChains: []*listener.FilterChain{{Filters: []*listener.Filter{{
+			Name:       "envoy.http_connection_manager",
+			ConfigType: &listener.Filter_TypedConfig{TypedConfig: manager},
+		}}}},
+	}
+}
+
+func TestResourceReferences(t *testing.T) {
+	pack := func(resource proto.Message) *any.Any {
+		packed, err := ptypes.MarshalAny(resource)
+		assert.NoError(t, err)
+		return packed
+	}
+
+	references, err := resourceReferences(pack(&v2.RouteConfiguration{
+		Name: "route",
+		VirtualHosts: []*route.VirtualHost{{Routes: []*route.Route{
+			{Action: &route.Route_Route{Route: &route.RouteAction{
+				ClusterSpecifier: &route.RouteAction_Cluster{Cluster: "a"},
+			}}},
+			{Action: &route.Route_Route{Route: &route.RouteAction{
+				ClusterSpecifier: &route.RouteAction_WeightedClusters{WeightedClusters: &route.WeightedCluster{
+					Clusters: []*route.WeightedCluster_ClusterWeight{{Name: "b"}, {Name: "c"}},
+				}},
+			}}},
+		}}},
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []reference{
+		{typeURL: upstream.ClusterTypeURL, name: "a"},
+		{typeURL: upstream.ClusterTypeURL, name: "b"},
+		{typeURL: upstream.ClusterTypeURL, name: "c"},
+	}, references)
+
+	references, err = resourceReferences(pack(newRDSListener(t, "listener", "route")))
+	assert.NoError(t, err)
+	assert.Equal(t, []reference{{typeURL: upstream.RouteTypeURL, name: "route"}}, references)
+
+	references, err = resourceReferences(pack(&v2.Cluster{
+		Name:                 "cluster",
+		ClusterDiscoveryType: &v2.Cluster_Type{Type: v2.Cluster_EDS},
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []reference{{typeURL: upstream.EndpointTypeURL, name: "cluster"}}, references)
+
+	references, err = resourceReferences(pack(&v2.Cluster{
+		Name:                 "cluster",
+		ClusterDiscoveryType: &v2.Cluster_Type{Type: v2.Cluster_EDS},
+		EdsClusterConfig:     &v2.Cluster_EdsClusterConfig{ServiceName: "service"},
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []reference{{typeURL: upstream.EndpointTypeURL, name: "service"}}, references)
+
+	references, err = resourceReferences(pack(&v2.Cluster{Name: "cluster"}))
+	assert.NoError(t, err)
+	assert.Empty(t, references)
+}
+
+func TestLinterLint(t *testing.T) {
+	linter := newLinter(&bootstrapv1.Linting{})
+	resp := newResponse(t, upstream.ListenerTypeURL, "1", newRDSListener(t, "listener", "route"))
+
+	// References to types without a cached response are not checked.
+	assert.Empty(t, linter.lint("lds", resp, resourceIndex{}))
+
+	index := resourceIndex{upstream.RouteTypeURL: {"other": true}}
+	assert.Equal(t, []LintFinding{{
+		Key:      "lds",
+		Check:    LintListenerRouteMissing,
+		Resource: "listener",
+		Message:  "listener references route, which is in no cached response of type " + upstream.RouteTypeURL,
+	}}, linter.lint("lds", resp, index))
+
+	index[upstream.RouteTypeURL]["route"] = true
+	assert.Empty(t, linter.lint("lds", resp, index))
+
+	// Checks that are not configured do not run.
+	linter = newLinter(&bootstrapv1.Linting{Checks: []bootstrapv1.Linting_Check{
+		bootstrapv1.Linting_ROUTE_CLUSTER_MISSING,
+	}})
+	assert.Empty(t, linter.lint("lds", resp, resourceIndex{upstream.RouteTypeURL: {}}))
+}
+
+func TestLinting(t *testing.T) {
+	for _, block := range []bool{true, false} {
+		upstreamClient := mockFlakyUpstreamClient{streams: make(chan chan *v2.DiscoveryResponse, 10)}
+		mockScope := newMockScope("prefix")
+		orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), upstreamClient)
+		WithLinting(&bootstrapv1.Linting{Block: block})(orchestrator)
+		_, err := orchestrator.cache.SetResponse("rds",
+			*newResponse(t, upstream.RouteTypeURL, "1", &v2.RouteConfiguration{Name: "route"}))
+		assert.NoError(t, err)
+
+		req := newRolloutRequest("node", false)
+		respChannel, cancelWatch := orchestrator.CreateWatch(req)
+		stream := <-upstreamClient.streams
+		missing := newResponse(t, upstream.ListenerTypeURL, "1", newRDSListener(t, "listener", "missing"))
+		stream <- missing
+		consistent := newResponse(t, upstream.ListenerTypeURL, "2", newRDSListener(t, "listener", "route"))
+		stream <- consistent
+
+		// A watch is sent a single response, which is the response with
+		// findings unless it was blocked.
+		if block {
+			assertEqualResponse(t, <-respChannel, *consistent, req)
+			testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.lint_blocked", 1)
+			assert.Empty(t, orchestrator.GetLintFindings())
+		} else {
+			assertEqualResponse(t, <-respChannel, *missing, req)
+		}
+		testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.lint_listener_route_missing", 1)
+		cancelWatch()
+	}
+}
+
+func TestGetLintFindings(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockUnusedUpstreamClient{t: t})
+	assert.Nil(t, orchestrator.GetLintFindings())
+
+	WithLinting(&bootstrapv1.Linting{})(orchestrator)
+	_, err := orchestrator.cache.SetResponse("cds", *newResponse(t, upstream.ClusterTypeURL, "1",
+		&v2.Cluster{Name: "a", ClusterDiscoveryType: &v2.Cluster_Type{Type: v2.Cluster_EDS}},
+		&v2.Cluster{Name: "b", ClusterDiscoveryType: &v2.Cluster_Type{Type: v2.Cluster_EDS}},
+	))
+	assert.NoError(t, err)
+	_, err = orchestrator.cache.SetResponse("eds", *newResponse(t, upstream.EndpointTypeURL, "1",
+		&v2.ClusterLoadAssignment{ClusterName: "a"},
+	))
+	assert.NoError(t, err)
+
+	findings := orchestrator.GetLintFindings()
+	assert.Len(t, findings, 1)
+	assert.Equal(t, "cds", findings[0].Key)
+	assert.Equal(t, LintClusterEndpointsMissing, findings[0].Check)
+	assert.Equal(t, "b", findings[0].Resource)
+}
@@ -0,0 +1,194 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file freezes the fanout of new upstream versions during scheduled
+// maintenance windows, and releases the withheld keys gradually once the
+// windows end. The contents of this file are intended to only be used within
+// the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/cron"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	defaultMaintenanceReleaseInterval = time.Second
+
+	metricMaintenanceWithheld = "maintenance_withheld"
+	metricMaintenanceReleased = "maintenance_released"
+)
+
+type maintenanceWindow struct {
+	schedule *cron.Schedule
+	duration time.Duration
+}
+
+// maintenance tracks the keys whose fanout is withheld during maintenance
+// windows.
+type maintenance struct {
+	windows         []maintenanceWindow
+	allowedKeys     []*regexp.Regexp
+	releaseInterval time.Duration
+	now             func() time.Time
+
+	mu sync.Mutex
+	// withheld holds the aggregated keys with a version that was not fanned
+	// out.
+	withheld map[string]bool
+	// timer releases the withheld keys at the end of the window.
+	timer *time.Timer
+}
+
+func newMaintenance(config *bootstrapv1.Maintenance) (*maintenance, error) {
+	m := &maintenance{
+		releaseInterval: defaultMaintenanceReleaseInterval,
+		now:             time.Now,
+		withheld:        make(map[string]bool),
+	}
+	for _, window := range config.GetWindows() {
+		schedule, err := cron.Parse(window.GetSchedule())
+		if err != nil {
+			return nil, err
+		}
+		duration, err := ptypes.Duration(window.GetDuration())
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window duration: %s", err.Error())
+		}
+		m.windows = append(m.windows, maintenanceWindow{schedule: schedule, duration: duration})
+	}
+	for _, keyRegex := range config.GetAllowedKeyRegexes() {
+		allowedKey, err := regexp.Compile(keyRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance allowed key regex %s: %s", keyRegex, err.Error())
+		}
+		m.allowedKeys = append(m.allowedKeys, allowedKey)
+	}
+	if config.GetReleaseInterval() != nil {
+		if releaseInterval, err := ptypes.Duration(config.GetReleaseInterval()); err == nil {
+			m.releaseInterval = releaseInterval
+		}
+	}
+	return m, nil
+}
+
+// windowEnd returns when the last of the windows in progress at the time
+// ends. It returns false if no window is in progress.
+func (m *maintenance) windowEnd(t time.Time) (time.Time, bool) {
+	var end time.Time
+	for _, window := range m.windows {
+		start, ok := window.schedule.Last(t, window.duration)
+		if ok && start.Add(window.duration).After(t) && start.Add(window.duration).After(end) {
+			end = start.Add(window.duration)
+		}
+	}
+	return end, !end.IsZero()
+}
+
+func (m *maintenance) isAllowed(aggregatedKey string) bool {
+	for _, allowedKey := range m.allowedKeys {
+		if allowedKey.MatchString(aggregatedKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// withhold records the aggregated key as withheld if a window is in progress,
+// and calls release when the window ends. It returns false if no window is in
+// progress.
+func (m *maintenance) withhold(aggregatedKey string, release func()) bool {
+	end, ok := m.windowEnd(m.now())
+	if !ok {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.withheld[aggregatedKey] = true
+	if m.timer == nil {
+		m.timer = time.AfterFunc(end.Sub(m.now()), release)
+	}
+	return true
+}
+
+// take returns the withheld keys, ordered by aggregated key, unless a window
+// is still in progress, in which case release is called when it ends.
+func (m *maintenance) take(release func()) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if end, ok := m.windowEnd(m.now()); ok {
+		m.timer = time.AfterFunc(end.Sub(m.now()), release)
+		return nil
+	}
+	m.timer = nil
+	keys := make([]string, 0, len(m.withheld))
+	for key := range m.withheld {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	m.withheld = make(map[string]bool)
+	return keys
+}
+
+// remove forgets the aggregated key, so that it is not released.
+func (m *maintenance) remove(aggregatedKey string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.withheld, aggregatedKey)
+}
+
+// isMaintenanceWithheld returns true if the fanout of the aggregated key is
+// withheld because a maintenance window is in progress.
+func (o *orchestrator) isMaintenanceWithheld(ctx context.Context, aggregatedKey string) bool {
+	if o.maintenance == nil || o.maintenance.isAllowed(aggregatedKey) {
+		return false
+	}
+	if !o.maintenance.withhold(aggregatedKey, o.releaseMaintenance) {
+		return false
+	}
+	o.keyScope(aggregatedKey).Counter(metricMaintenanceWithheld).Inc(1)
+	o.logger.With("key", aggregatedKey).Info(ctx, "maintenance window in progress, withholding response")
+	return true
+}
+
+// releaseMaintenance fans out the latest version of every withheld key, one
+// key per release interval.
+func (o *orchestrator) releaseMaintenance() {
+	keys := o.maintenance.take(o.releaseMaintenance)
+	for i, key := range keys {
+		if i > 0 && o.maintenance.releaseInterval > 0 {
+			time.Sleep(o.maintenance.releaseInterval)
+		}
+		// Keys not released yet when another window starts are withheld
+		// until it ends.
+		if o.maintenance.withhold(key, o.releaseMaintenance) {
+			for _, remaining := range keys[i+1:] {
+				o.maintenance.withhold(remaining, o.releaseMaintenance)
+			}
+			return
+		}
+		if _, ok := o.pinnedResponse(key); ok {
+			continue
+		}
+		cached, err := o.cache.Fetch(key)
+		if err != nil || cached == nil || cached.Resp == nil {
+			continue
+		}
+		o.keyScope(key).Counter(metricMaintenanceReleased).Inc(1)
+		o.logger.With("key", key).With("version", cached.Resp.GetVersionInfo()).
+			Info(context.Background(), "maintenance window ended, releasing response")
+		o.fanoutServed(key, cached)
+	}
+}
@@ -0,0 +1,120 @@
+package orchestrator
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockClock is a clock that only moves when it is set.
+type mockClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *mockClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *mockClock) set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+func newMaintenanceConfig(allowedKeyRegexes ...string) *bootstrapv1.Maintenance {
+	return &bootstrapv1.Maintenance{
+		Windows: []*bootstrapv1.Maintenance_Window{{
+			Schedule: "0 9 * * *",
+			Duration: ptypes.DurationProto(time.Hour),
+		}},
+		AllowedKeyRegexes: allowedKeyRegexes,
+		ReleaseInterval:   ptypes.DurationProto(0),
+	}
+}
+
+func TestMaintenanceWindowEnd(t *testing.T) {
+	m, err := newMaintenance(&bootstrapv1.Maintenance{
+		Windows: []*bootstrapv1.Maintenance_Window{
+			{Schedule: "0 9 * * *", Duration: ptypes.DurationProto(time.Hour)},
+			{Schedule: "30 9 * * *", Duration: ptypes.DurationProto(time.Hour)},
+		},
+	})
+	assert.NoError(t, err)
+	nine := time.Date(2020, time.June, 1, 9, 0, 0, 0, time.UTC)
+
+	end, ok := m.windowEnd(nine.Add(10 * time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, nine.Add(time.Hour), end)
+	end, ok = m.windowEnd(nine.Add(40 * time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, nine.Add(90*time.Minute), end)
+	_, ok = m.windowEnd(nine.Add(90 * time.Minute))
+	assert.False(t, ok)
+	_, ok = m.windowEnd(nine.Add(-time.Second))
+	assert.False(t, ok)
+
+	_, err = newMaintenance(&bootstrapv1.Maintenance{
+		Windows: []*bootstrapv1.Maintenance_Window{{Schedule: "0 9 * *", Duration: ptypes.DurationProto(time.Hour)}},
+	})
+	assert.EqualError(t, err, `cron expression "0 9 * *" has 4 fields, expected 5`)
+	_, err = newMaintenance(&bootstrapv1.Maintenance{AllowedKeyRegexes: []string{"("}})
+	assert.Error(t, err)
+}
+
+func TestMaintenance(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithMaintenance(newMaintenanceConfig())(orchestrator)
+	nine := time.Date(2020, time.June, 1, 9, 0, 0, 0, time.UTC)
+	clock := &mockClock{now: nine.Add(-time.Minute)}
+	orchestrator.maintenance.now = clock.Now
+
+	req := newRolloutRequest("node", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	resp1 := newRolloutResponse("1")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp1))
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+
+	// New versions are cached but withheld during the window.
+	clock.set(nine.Add(time.Hour - 50*time.Millisecond))
+	req.VersionInfo = "1"
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	resp2 := newRolloutResponse("2")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp2))
+	assert.Equal(t, 0, len(respChannel))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.maintenance_withheld", 1)
+	cached, err := orchestrator.cache.Fetch("lds")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", cached.Resp.GetVersionInfo())
+
+	// The withheld version is released once the window ends.
+	clock.set(nine.Add(time.Hour))
+	assertEqualResponse(t, <-respChannel, *resp2, req)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.maintenance_released", 1)
+}
+
+func TestMaintenanceAllowedKey(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithMaintenance(newMaintenanceConfig("^lds$"))(orchestrator)
+	orchestrator.maintenance.now = func() time.Time {
+		return time.Date(2020, time.June, 1, 9, 30, 0, 0, time.UTC)
+	}
+
+	req := newRolloutRequest("node", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	resp := newRolloutResponse("1")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp))
+	assertEqualResponse(t, <-respChannel, *resp, req)
+}
@@ -0,0 +1,168 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file remembers requests that cannot be mapped and aggregated keys
+// whose upstream stream failed to open, so that identical requests fail fast
+// for a short window rather than retrying the mapper and the origin server.
+// The contents of this file are intended to only be used within the
+// orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	metricNegativeCached = "negative_cached"
+	metricNegativeHit    = "negative_hit"
+
+	defaultNegativeCacheMaxEntries = 10000
+)
+
+// negativeFailure is a remembered failure.
+type negativeFailure struct {
+	err       error
+	expiresAt time.Time
+	// element is the key of the failure in the record order.
+	element *list.Element
+}
+
+// negativeCache remembers failures by key until their TTL expires, or until
+// it holds max entries and they are the oldest. The keys are aggregated keys,
+// or the unaggregated keys of requests that cannot be mapped.
+type negativeCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu       sync.Mutex
+	failures map[string]negativeFailure
+	// order holds the keys of the failures from the oldest to the newest
+	// record. As all failures share the TTL, this is also the order in which
+	// they expire.
+	order *list.List
+}
+
+// newNegativeCache returns a negative cache with the TTL and max entries of
+// the config.
+func newNegativeCache(config *bootstrapv1.NegativeCache) *negativeCache {
+	n := &negativeCache{
+		maxEntries: defaultNegativeCacheMaxEntries,
+		failures:   make(map[string]negativeFailure),
+		order:      list.New(),
+	}
+	if ttl, err := ptypes.Duration(config.GetTtl()); err == nil {
+		n.ttl = ttl
+	}
+	if config.GetMaxEntries() > 0 {
+		n.maxEntries = int(config.GetMaxEntries())
+	}
+	return n
+}
+
+// record remembers the failure of the key as of now. The failures that
+// expired are forgotten, along with the oldest ones while the cache holds
+// more than max entries.
+func (n *negativeCache) record(key string, err error, now time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if failure, ok := n.failures[key]; ok {
+		n.order.Remove(failure.element)
+	}
+	n.failures[key] = negativeFailure{err: err, expiresAt: now.Add(n.ttl), element: n.order.PushBack(key)}
+	for front := n.order.Front(); front != nil; front = n.order.Front() {
+		oldest := front.Value.(string)
+		if len(n.failures) <= n.maxEntries && now.Before(n.failures[oldest].expiresAt) {
+			break
+		}
+		n.forget(oldest)
+	}
+}
+
+// get returns the failure remembered for the key, or nil if there is none or
+// it expired as of now.
+func (n *negativeCache) get(key string, now time.Time) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	failure, ok := n.failures[key]
+	if !ok {
+		return nil
+	}
+	if !now.Before(failure.expiresAt) {
+		n.forget(key)
+		return nil
+	}
+	return failure.err
+}
+
+// remove forgets the failure of the key.
+func (n *negativeCache) remove(key string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.forget(key)
+}
+
+// forget drops the failure of the key. The caller must hold the lock.
+func (n *negativeCache) forget(key string) {
+	if failure, ok := n.failures[key]; ok {
+		n.order.Remove(failure.element)
+		delete(n.failures, key)
+	}
+}
+
+// negativeCached returns the failure remembered for the key, and counts the
+// hit. It returns nil if negative caching is disabled.
+func (o *orchestrator) negativeCached(key string) error {
+	if o.negativeCache == nil {
+		return nil
+	}
+	err := o.negativeCache.get(key, time.Now())
+	if err != nil {
+		o.keyScope(key).Counter(metricNegativeHit).Inc(1)
+	}
+	return err
+}
+
+// recordNegative remembers the failure of the key, if negative caching is
+// enabled.
+func (o *orchestrator) recordNegative(ctx context.Context, key string, err error) {
+	if o.negativeCache == nil {
+		return
+	}
+	o.negativeCache.record(key, err, time.Now())
+	o.keyScope(key).Counter(metricNegativeCached).Inc(1)
+	o.logger.With("key", key).With("err", err).With("ttl", o.negativeCache.ttl).
+		Debug(ctx, "failure cached")
+}
+
+// hasCachedResponse returns true if a response is cached for the aggregated
+// key.
+func (o *orchestrator) hasCachedResponse(aggregatedKey string) bool {
+	cached, err := o.cache.Fetch(aggregatedKey)
+	return err == nil && cached != nil && cached.Resp != nil
+}
+
+// onUpstreamOpenFailure remembers that the upstream stream of the aggregated
+// key failed to open. Unless a response is cached for the key, its open
+// watches are closed, so that their clients retry and fail fast until the
+// failure expires.
+func (o *orchestrator) onUpstreamOpenFailure(ctx context.Context, aggregatedKey string, err error) {
+	if o.negativeCache == nil {
+		return
+	}
+	o.recordNegative(ctx, aggregatedKey, err)
+	cached, fetchErr := o.cache.Fetch(aggregatedKey)
+	if fetchErr != nil || cached == nil || cached.Resp != nil {
+		return
+	}
+	o.terminateWatches(cached.Requests, &KeyError{Key: aggregatedKey, Err: err})
+}
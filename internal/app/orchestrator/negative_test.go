@@ -0,0 +1,173 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// mockFailingUpstreamClient fails to open streams while failing is set, and
+// counts the streams it was asked to open.
+type mockFailingUpstreamClient struct {
+	failing      *int32
+	opens        *int32
+	responseChan chan *v2.DiscoveryResponse
+}
+
+func (m mockFailingUpstreamClient) OpenStream(req v2.DiscoveryRequest) (<-chan *v2.DiscoveryResponse, func(), error) {
+	atomic.AddInt32(m.opens, 1)
+	if atomic.LoadInt32(m.failing) == 1 {
+		return nil, nil, fmt.Errorf("%w: connection refused", upstream.ErrUpstreamUnavailable)
+	}
+	return m.responseChan, func() {}, nil
+}
+
+func TestNegativeCache(t *testing.T) {
+	n := newNegativeCache(&bootstrapv1.NegativeCache{Ttl: ptypes.DurationProto(time.Minute)})
+	now := time.Now()
+	err := errors.New("error")
+	assert.NoError(t, n.get("lds", now))
+
+	n.record("lds", err, now)
+	assert.Equal(t, err, n.get("lds", now.Add(time.Second)))
+	assert.NoError(t, n.get("cds", now))
+	assert.NoError(t, n.get("lds", now.Add(time.Minute)))
+	assert.Empty(t, n.failures)
+
+	n.record("lds", err, now)
+	n.remove("lds")
+	assert.NoError(t, n.get("lds", now))
+}
+
+func TestNegativeCacheBounded(t *testing.T) {
+	n := newNegativeCache(&bootstrapv1.NegativeCache{Ttl: ptypes.DurationProto(time.Minute), MaxEntries: 2})
+	now := time.Now()
+	err := errors.New("error")
+
+	// The oldest failure is forgotten once the cache holds max entries.
+	n.record("node-1", err, now)
+	n.record("node-2", err, now.Add(time.Second))
+	n.record("node-1", err, now.Add(2*time.Second))
+	n.record("node-3", err, now.Add(3*time.Second))
+	assert.Len(t, n.failures, 2)
+	assert.NoError(t, n.get("node-2", now.Add(3*time.Second)))
+	assert.Equal(t, err, n.get("node-1", now.Add(3*time.Second)))
+	assert.Equal(t, err, n.get("node-3", now.Add(3*time.Second)))
+
+	// The failures that expired are forgotten even if they are never read.
+	n.record("node-4", err, now.Add(2*time.Second+time.Minute))
+	assert.Len(t, n.failures, 2)
+	assert.Contains(t, n.failures, "node-3")
+	assert.Contains(t, n.failures, "node-4")
+	assert.Equal(t, 2, n.order.Len())
+}
+
+func TestNegativeCache_UpstreamOpenFailure(t *testing.T) {
+	failing, opens := int32(1), int32(0)
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	client := mockFailingUpstreamClient{failing: &failing, opens: &opens, responseChan: upstreamResponseChannel}
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), client)
+	WithNegativeCache(&bootstrapv1.NegativeCache{Ttl: ptypes.DurationProto(time.Hour)})(orchestrator)
+	req := gcp.Request{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &v2_core.Node{Id: "node"},
+	}
+
+	// The watch that failed to open the upstream stream is closed.
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	_, ok := <-respChannel
+	assert.False(t, ok)
+	cancelWatch()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&opens))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.negative_cached", 1)
+
+	// Identical requests fail fast without opening the upstream stream.
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	assert.Nil(t, cancelWatch)
+	_, ok = <-respChannel
+	assert.False(t, ok)
+	_, err := orchestrator.Fetch(context.Background(), req)
+	assert.Equal(t, codes.Unavailable, grpcstatus.Code(err))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&opens))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.negative_hit", 2)
+
+	// The upstream stream is opened again once the failure expires.
+	atomic.StoreInt32(&failing, 0)
+	orchestrator.negativeCache.remove("lds")
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	upstreamResponseChannel <- &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ListenerTypeURL}
+	resp, err := (<-respChannel).GetDiscoveryResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", resp.GetVersionInfo())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&opens))
+}
+
+func TestNegativeCache_ServesCachedResponse(t *testing.T) {
+	failing, opens := int32(0), int32(0)
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	client := mockFailingUpstreamClient{failing: &failing, opens: &opens, responseChan: upstreamResponseChannel}
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), client)
+	WithNegativeCache(&bootstrapv1.NegativeCache{Ttl: ptypes.DurationProto(time.Hour)})(orchestrator)
+	req := gcp.Request{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &v2_core.Node{Id: "node"},
+	}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	upstreamResponseChannel <- &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ListenerTypeURL}
+	<-respChannel
+	cancelWatch()
+
+	// Watches of keys with a cached response are served from the cache, and
+	// are not closed when the upstream stream fails to open.
+	orchestrator.deleteUpstream("lds", nil)
+	atomic.StoreInt32(&failing, 1)
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	got, ok := <-respChannel
+	assert.True(t, ok)
+	resp, err := got.GetDiscoveryResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", resp.GetVersionInfo())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&opens))
+	assert.Error(t, orchestrator.negativeCache.get("lds", time.Now()))
+
+	_, cancelOther := orchestrator.CreateWatch(req)
+	defer cancelOther()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&opens))
+}
+
+func TestNegativeCache_UnmappedRequest(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.New(&aggregationv1.KeyerConfiguration{
+		Fallback: &aggregationv1.KeyerConfiguration_Fallback{
+			Behavior: &aggregationv1.KeyerConfiguration_Fallback_Reject{Reject: true},
+		},
+	}), mockSimpleUpstreamClient{})
+	WithNegativeCache(&bootstrapv1.NegativeCache{Ttl: ptypes.DurationProto(time.Hour)})(orchestrator)
+	req := gcp.Request{TypeUrl: upstream.ListenerTypeURL, Node: &v2_core.Node{Id: "node"}}
+
+	_, err := orchestrator.getAggregatedKey(context.Background(), req)
+	assert.Error(t, err)
+	_, err = orchestrator.getAggregatedKey(context.Background(), req)
+	assert.Equal(t, &mapper.UnmatchedRequestError{Reject: true}, err)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.unmatched_rejected", 1)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.negative_hit", 1)
+}
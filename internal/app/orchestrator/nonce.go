@@ -0,0 +1,197 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file generates the nonces of the responses sent on downstream streams
+// and validates the nonces of the requests received on them, by implementing
+// go-control-plane's server Callbacks. The contents of this file are intended
+// to only be used within the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+)
+
+const (
+	metricStaleNonce     = "stale_nonce"
+	metricNackSuppressed = "nack_suppressed"
+
+	nonceSeparator = "-"
+)
+
+// sentNonce is the nonce and version of the last response of a type sent on a
+// stream.
+type sentNonce struct {
+	nonce   string
+	version string
+}
+
+// streamNonces holds the nonces sent on a stream.
+type streamNonces struct {
+	// sequence is the number of responses sent on the stream.
+	sequence uint64
+	// types is the map of type URLs to the last response of the type sent.
+	types map[string]sentNonce
+	// history is the map of type URLs to the latest responses of the type
+	// sent, oldest first.
+	history map[string][]SentResponse
+}
+
+func newStreamNonces() *streamNonces {
+	return &streamNonces{types: make(map[string]sentNonce), history: make(map[string][]SentResponse)}
+}
+
+// nonceMap is the map of downstream stream IDs to the nonces sent on them.
+type nonceMap struct {
+	mu      sync.Mutex
+	streams map[int64]*streamNonces
+}
+
+func newNonceMap() *nonceMap {
+	return &nonceMap{
+		streams: make(map[int64]*streamNonces),
+	}
+}
+
+// newNonce returns the nonce of the nth response sent on the stream. Nonces
+// are unique within the process, and the stream of a nonce can be recovered
+// with parseNonceStream.
+func newNonce(streamID int64, sequence uint64) string {
+	return fmt.Sprintf("%d%s%d", streamID, nonceSeparator, sequence)
+}
+
+func parseNonceStream(nonce string) (int64, bool) {
+	i := strings.Index(nonce, nonceSeparator)
+	if i < 0 {
+		return 0, false
+	}
+	streamID, err := strconv.ParseInt(nonce[:i], 10, 64)
+	return streamID, err == nil
+}
+
+func (n *nonceMap) open(streamID int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.streams[streamID] = newStreamNonces()
+}
+
+func (n *nonceMap) close(streamID int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.streams, streamID)
+}
+
+// next generates the nonce of a response sent on the stream and records it as
+// the last of its type.
+// The resumption token, if any, is appended to the nonce.
+func (n *nonceMap) next(streamID int64, resp *discovery.DiscoveryResponse, token string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	stream, ok := n.streams[streamID]
+	if !ok {
+		stream = newStreamNonces()
+		n.streams[streamID] = stream
+	}
+	stream.sequence++
+	nonce := newNonce(streamID, stream.sequence)
+	if token != "" {
+		nonce += resumptionSeparator + token
+	}
+	stream.types[resp.GetTypeUrl()] = sentNonce{nonce: nonce, version: resp.GetVersionInfo()}
+	stream.recordSent(resp, nonce)
+	return nonce
+}
+
+// get returns the last response of the type sent on the stream. It returns
+// false if the stream is not open, or sent no response of the type.
+func (n *nonceMap) get(streamID int64, typeURL string) (sentNonce, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	stream, ok := n.streams[streamID]
+	if !ok {
+		return sentNonce{}, false
+	}
+	sent, ok := stream.types[typeURL]
+	return sent, ok
+}
+
+// isStale returns true if the request received on the stream responds to a
+// response of its type other than the last sent on the stream. Nonces of
+// other streams are never the last sent on the stream, so they are stale
+// too. Stale requests are only counted: go-control-plane ignores them itself,
+// since it only replaces the watch of a type for requests with the nonce of
+// the last response of the type.
+func (n *nonceMap) isStale(streamID int64, req *gcp.Request) bool {
+	if req.GetResponseNonce() == "" {
+		return false
+	}
+	sent, ok := n.get(streamID, req.GetTypeUrl())
+	return ok && sent.nonce != req.GetResponseNonce()
+}
+
+// rejectedVersion returns the version of the response that the request
+// rejects, if the request is a NACK of the last response of its type sent on
+// the stream it was received on.
+func (n *nonceMap) rejectedVersion(streamID int64, req *gcp.Request) (string, bool) {
+	if req.GetErrorDetail() == nil {
+		return "", false
+	}
+	sent, ok := n.get(streamID, req.GetTypeUrl())
+	if !ok || sent.nonce != req.GetResponseNonce() {
+		return "", false
+	}
+	return sent.version, true
+}
+
+func (o *orchestrator) OnStreamOpen(ctx context.Context, streamID int64, typeURL string) error {
+	o.nonces.open(streamID)
+	o.requestIDs.open(streamID, contextRequestID(ctx), streamFailureFromContext(ctx), o.captureTransport(ctx),
+		contextPeer(ctx))
+	return nil
+}
+
+func (o *orchestrator) OnStreamClosed(streamID int64) {
+	o.nonces.close(streamID)
+	o.requestIDs.close(streamID)
+}
+
+func (o *orchestrator) OnStreamRequest(streamID int64, req *discovery.DiscoveryRequest) error {
+	o.requestIDs.observe(streamID, req.GetNode())
+	o.nonces.recordAnswer(streamID, req)
+	if o.nonces.isStale(streamID, req) {
+		o.scope.Counter(metricStaleNonce).Inc(1)
+		o.logger.With("node ID", req.GetNode().GetId()).With("type", req.GetTypeUrl()).
+			With("nonce", req.GetResponseNonce()).Debug(context.Background(), "received request with stale nonce")
+	}
+	return nil
+}
+
+// OnStreamResponse replaces the nonce that go-control-plane generated for the
+// response with one that identifies the stream, so that the requests
+// responding to it can be validated, and that carries the resumption token of
+// the response if resumption tokens are enabled.
+func (o *orchestrator) OnStreamResponse(streamID int64, req *discovery.DiscoveryRequest,
+	resp *discovery.DiscoveryResponse) {
+	var token string
+	if o.resumptionTokens {
+		token = o.newResumptionToken(resp, req)
+	}
+	resp.Nonce = o.nonces.next(streamID, resp, token)
+}
+
+func (o *orchestrator) OnFetchRequest(ctx context.Context, req *discovery.DiscoveryRequest) error {
+	return nil
+}
+
+func (o *orchestrator) OnFetchResponse(req *discovery.DiscoveryRequest, resp *discovery.DiscoveryResponse) {
+}
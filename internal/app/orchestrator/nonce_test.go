@@ -0,0 +1,182 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNonces(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	assert.NoError(t, orchestrator.OnStreamOpen(context.Background(), 7, ""))
+
+	// Nonces identify the stream and the sequence of the response on it.
+	lds := &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ListenerTypeURL}
+	orchestrator.OnStreamResponse(7, nil, lds)
+	assert.Equal(t, "7-1", lds.GetNonce())
+	cds := &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ClusterTypeURL}
+	orchestrator.OnStreamResponse(7, nil, cds)
+	assert.Equal(t, "7-2", cds.GetNonce())
+	lds = &v2.DiscoveryResponse{VersionInfo: "2", TypeUrl: upstream.ListenerTypeURL}
+	orchestrator.OnStreamResponse(7, nil, lds)
+	assert.Equal(t, "7-3", lds.GetNonce())
+
+	// Requests responding to an older response of their type are stale.
+	stale := &v2.DiscoveryRequest{TypeUrl: upstream.ListenerTypeURL, ResponseNonce: "7-1"}
+	assert.True(t, orchestrator.nonces.isStale(7, stale))
+	assert.NoError(t, orchestrator.OnStreamRequest(7, stale))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.stale_nonce", 1)
+	for _, nonce := range []string{"", "7-3"} {
+		assert.False(t, orchestrator.nonces.isStale(7, &v2.DiscoveryRequest{
+			TypeUrl:       upstream.ListenerTypeURL,
+			ResponseNonce: nonce,
+		}), nonce)
+	}
+	assert.False(t, orchestrator.nonces.isStale(7, &v2.DiscoveryRequest{
+		TypeUrl:       upstream.ClusterTypeURL,
+		ResponseNonce: "7-2",
+	}))
+
+	// Nonces are checked against the stream the request is received on, so
+	// nonces of other streams are stale.
+	for _, nonce := range []string{"unknown", "8-3"} {
+		assert.True(t, orchestrator.nonces.isStale(7, &v2.DiscoveryRequest{
+			TypeUrl:       upstream.ListenerTypeURL,
+			ResponseNonce: nonce,
+		}), nonce)
+	}
+	// Streams that sent no response of the type have no stale nonces, such
+	// as those of clients that resume on a new stream.
+	assert.NoError(t, orchestrator.OnStreamOpen(context.Background(), 8, ""))
+	defer orchestrator.OnStreamClosed(8)
+	assert.False(t, orchestrator.nonces.isStale(8, stale))
+
+	// NACKs of the last response identify the rejected version.
+	nack := &v2.DiscoveryRequest{
+		TypeUrl:       upstream.ListenerTypeURL,
+		VersionInfo:   "1",
+		ResponseNonce: "7-3",
+		ErrorDetail:   status.New(codes.InvalidArgument, "invalid listener").Proto(),
+	}
+	version, ok := orchestrator.nonces.rejectedVersion(7, nack)
+	assert.True(t, ok)
+	assert.Equal(t, "2", version)
+	// A nonce copied from another stream does not reject the responses of
+	// the stream it is received on.
+	orchestrator.OnStreamResponse(8, nil, &v2.DiscoveryResponse{VersionInfo: "3", TypeUrl: upstream.ListenerTypeURL})
+	_, ok = orchestrator.nonces.rejectedVersion(8, nack)
+	assert.False(t, ok)
+	nack.ResponseNonce = "7-1"
+	_, ok = orchestrator.nonces.rejectedVersion(7, nack)
+	assert.False(t, ok)
+
+	orchestrator.OnStreamClosed(7)
+	assert.False(t, orchestrator.nonces.isStale(7, stale))
+}
+
+func TestNoncesFanout(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	orchestrator.resumptionTokens = true
+	var reqs []gcp.Request
+	var respChannels []chan gcp.Response
+	for _, streamID := range []int64{1, 2} {
+		assert.NoError(t, orchestrator.OnStreamOpen(context.Background(), streamID, ""))
+		req := gcp.Request{
+			TypeUrl: upstream.ListenerTypeURL,
+			Node:    &v2_core.Node{Id: fmt.Sprintf("node-%d", streamID)},
+		}
+		respChannel, cancelWatch := orchestrator.CreateWatch(req)
+		defer cancelWatch()
+		reqs = append(reqs, req)
+		respChannels = append(respChannels, respChannel)
+	}
+
+	// The streams of a fanout set the nonces of their responses concurrently,
+	// which the race detector flags if the response is shared.
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", newRolloutResponse("1")))
+	responses := make([]*v2.DiscoveryResponse, len(respChannels))
+	var wg sync.WaitGroup
+	for i := range respChannels {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := (<-respChannels[i]).GetDiscoveryResponse()
+			assert.NoError(t, err)
+			orchestrator.OnStreamResponse(int64(i+1), &reqs[i], resp)
+			responses[i] = resp
+		}()
+	}
+	wg.Wait()
+
+	// Each stream recorded the nonce it sent, so NACKs are attributed to it.
+	for i, resp := range responses {
+		streamID, ok := parseNonceStream(resp.GetNonce())
+		assert.True(t, ok)
+		assert.Equal(t, int64(i+1), streamID)
+		nack := &v2.DiscoveryRequest{
+			TypeUrl:       upstream.ListenerTypeURL,
+			ResponseNonce: resp.GetNonce(),
+			ErrorDetail:   status.New(codes.InvalidArgument, "invalid listener").Proto(),
+		}
+		version, ok := orchestrator.nonces.rejectedVersion(int64(i+1), nack)
+		assert.True(t, ok)
+		assert.Equal(t, "1", version)
+	}
+	cached, err := orchestrator.cache.Fetch("lds")
+	assert.NoError(t, err)
+	assert.Equal(t, "", cached.Resp.GetNonce())
+}
+
+func TestNackSuppressesResend(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	assert.NoError(t, orchestrator.OnStreamOpen(context.Background(), 1, ""))
+	req := gcp.Request{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &v2_core.Node{Id: "node"},
+	}
+	assert.NoError(t, orchestrator.OnStreamRequest(1, &req))
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	resp := &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     upstream.ListenerTypeURL,
+		Resources:   []*any.Any{{Value: []byte("lds resource")}},
+	}
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp))
+	gotResponse, err := (<-respChannel).GetDiscoveryResponse()
+	assert.NoError(t, err)
+	orchestrator.OnStreamResponse(1, &req, gotResponse)
+	cancelWatch()
+
+	// The client rejects the response, which is not sent again.
+	req.ResponseNonce = gotResponse.GetNonce()
+	req.ErrorDetail = status.New(codes.InvalidArgument, "invalid listener").Proto()
+	assert.NoError(t, orchestrator.OnStreamRequest(1, &req))
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	assert.Equal(t, 0, len(respChannel))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.nack_suppressed", 1)
+
+	// The next response is sent to the watch.
+	resp2 := &v2.DiscoveryResponse{
+		VersionInfo: "2",
+		TypeUrl:     upstream.ListenerTypeURL,
+		Resources:   []*any.Any{{Value: []byte("fixed lds resource")}},
+	}
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp2))
+	assertEqualResponse(t, <-respChannel, *resp2, req)
+}
@@ -8,28 +8,49 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
 
+	"github.com/envoyproxy/xds-relay/internal/app/audit"
 	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"github.com/envoyproxy/xds-relay/internal/app/codec"
+	"github.com/envoyproxy/xds-relay/internal/app/diff"
+	"github.com/envoyproxy/xds-relay/internal/app/election"
 	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/notifier"
+	"github.com/envoyproxy/xds-relay/internal/app/recording"
+	"github.com/envoyproxy/xds-relay/internal/app/replication"
+	"github.com/envoyproxy/xds-relay/internal/app/ring"
+	"github.com/envoyproxy/xds-relay/internal/app/signature"
+	"github.com/envoyproxy/xds-relay/internal/app/static"
+	"github.com/envoyproxy/xds-relay/internal/app/status"
+	"github.com/envoyproxy/xds-relay/internal/app/transform"
 	"github.com/envoyproxy/xds-relay/internal/app/upstream"
 	"github.com/envoyproxy/xds-relay/internal/pkg/log"
 
 	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	gcpserver "github.com/envoyproxy/go-control-plane/pkg/server/v2"
 	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
 )
 
 const (
 	component = "orchestrator"
 
-	// unaggregatedPrefix is the prefix used to label discovery requests that
-	// could not be successfully mapped to an aggregation rule.
-	unaggregatedPrefix = "unaggregated_"
+	// fetchTimeout is the maximum time Fetch waits for the first upstream
+	// response for an aggregated key.
+	fetchTimeout = 10 * time.Second
+
+	metricTagTenant = "tenant"
+
+	metricUnmatchedRejected = "unmatched_rejected"
 )
 
 // Orchestrator has the following responsibilities:
@@ -55,55 +76,814 @@ const (
 type Orchestrator interface {
 	gcp.Cache
 
+	// Callbacks generate the nonces of the responses sent on downstream
+	// streams and validate the nonces of the requests received on them.
+	gcpserver.Callbacks
+
 	// This is called by the main shutdown handler and tests to clean up
 	// open channels.
 	shutdown(ctx context.Context)
 
 	GetReadOnlyCache() cache.ReadOnlyCache
+
+	// GetLastDiff returns the resource changes introduced by the most recent
+	// upstream response for the aggregated key.
+	GetLastDiff(aggregatedKey string) (diff.Summary, bool)
+
+	// GetShadowDiff returns the differences between the latest shadow server
+	// response and the cached origin server response for the aggregated key.
+	GetShadowDiff(aggregatedKey string) (diff.Summary, bool)
+
+	// GetWorkers returns the status of the worker of every aggregated key
+	// with an upstream stream.
+	GetWorkers() []WorkerStatus
+
+	// GetWatches returns the status of the open downstream watches, ordered
+	// by watch ID.
+	GetWatches() []WatchStatus
+
+	// StreamServerInterceptor fails the downstream streams whose watches
+	// the orchestrator closed with the status of the error that closed them.
+	// It must run right before the handler of the xDS server.
+	StreamServerInterceptor() grpc.StreamServerInterceptor
+
+	// GetAlerts returns the active alerts, ordered by aggregated key and
+	// rule.
+	GetAlerts() []Alert
+
+	// GetCacheStatuses returns when the cache entry of every aggregated key
+	// with an upstream stream was populated and updated, and how often.
+	GetCacheStatuses() []CacheStatus
+
+	// GetUpstreamHealth returns the health of the upstream stream of every
+	// aggregated key, ordered by aggregated key.
+	GetUpstreamHealth() []UpstreamHealthStatus
+
+	// GetLintFindings lints the cached responses against the cache, and
+	// returns the findings ordered by aggregated key.
+	GetLintFindings() []LintFinding
+
+	// GetDependencies returns the aggregated keys referenced by the cached
+	// response of every aggregated key, ordered by aggregated key.
+	GetDependencies() []KeyDependencies
+
+	// GetGoroutines returns the number of goroutines of each subsystem.
+	GetGoroutines() GoroutineSummary
+
+	// ApplyReplicatedResponse caches a response replicated from a peer relay
+	// and fans it out to downstream watchers. It returns false if the response
+	// is not newer than the locally cached response.
+	ApplyReplicatedResponse(aggregatedKey string, resp *discovery.DiscoveryResponse) bool
+
+	// GetRollouts returns the rollouts of new versions that are only served
+	// to canary nodes, ordered by aggregated key.
+	GetRollouts() []RolloutStatus
+
+	// PromoteRollout serves the canary version of the aggregated key to every
+	// node. It returns false if no rollout of the aggregated key is in
+	// progress.
+	PromoteRollout(aggregatedKey string) bool
+
+	// PinVersion freezes the response served for the aggregated key to the
+	// held response with the version, or to the cached response if the
+	// version is empty. Pinning a version held in the history rolls the
+	// served version back. Newer upstream responses are cached but withheld
+	// until UnpinVersion is called.
+	PinVersion(aggregatedKey string, version string) error
+
+	// UnpinVersion serves the latest response of the aggregated key again. It
+	// returns false if the aggregated key is not pinned.
+	UnpinVersion(aggregatedKey string) bool
+
+	// GetPinnedVersion returns the version pinned for the aggregated key.
+	GetPinnedVersion(aggregatedKey string) (string, bool)
+
+	// RollBack pins the held response with the version for the aggregated
+	// key, and reports the divergence of the upstream from it until
+	// UnpinVersion is called.
+	RollBack(aggregatedKey string, version string) error
+
+	// GetRollbacks returns the rollbacks of the aggregated keys that are still
+	// pinned, ordered by aggregated key.
+	GetRollbacks() []RollbackStatus
+
+	// GetRollback returns the rollback of the aggregated key.
+	GetRollback(aggregatedKey string) (RollbackStatus, bool)
+
+	// ResumeFanout fans out the latest version of the aggregated key whose
+	// fanout was paused because it changed too often. It returns false if the
+	// fanout of the aggregated key is not paused.
+	ResumeFanout(aggregatedKey string) bool
+
+	// GetHistory returns the versions held for the aggregated key, the most
+	// recently cached first.
+	GetHistory(aggregatedKey string) []HistoricalVersion
+
+	// GetHistoricalResponse returns the response held for the aggregated key
+	// with the version.
+	GetHistoricalResponse(aggregatedKey string, version string) (*discovery.DiscoveryResponse, bool)
+
+	// DiffHistory returns the resource changes between two versions held for
+	// the aggregated key.
+	DiffHistory(aggregatedKey string, from string, to string) (diff.Summary, error)
+
+	// EvictLeastRecentlyUsed evicts up to n aggregated keys from the cache, as
+	// if their TTL had expired. The keys are chosen by the eviction strategy
+	// of the cache, least recently used by default. It returns the number of
+	// keys evicted.
+	EvictLeastRecentlyUsed(n int) int
+
+	// GetSimulation returns how the aggregated keys would change if the
+	// candidate aggregation rules replaced the serving rules. It returns
+	// false if no candidate rules are simulated.
+	GetSimulation() (SimulationSummary, bool)
+
+	// GetKeyStatus returns the served and upstream versions of the aggregated
+	// key, and how downstream clients received them. It returns false if the
+	// key is not watched.
+	GetKeyStatus(aggregatedKey string) (KeyStatus, bool)
+
+	// GetNodes returns the nodes with open downstream streams, ordered by
+	// node ID.
+	GetNodes() []NodeStatus
+
+	// GetWatchHistories returns the latest responses of each type sent on
+	// the open downstream streams and how their clients answered them,
+	// ordered by stream ID and type URL.
+	GetWatchHistories() []WatchHistory
 }
 
 type orchestrator struct {
 	mapper         mapper.Mapper
 	cache          cache.Cache
 	upstreamClient upstream.Client
+	codec          *codec.Codec
 
 	logger log.Logger
 	scope  tally.Scope
 
 	downstreamResponseMap downstreamResponseMap
 	upstreamResponseMap   upstreamResponseMap
+	sentResponseMap       *sentResponseMap
+	nonces                *nonceMap
+	requestIDs            *requestIDMap
+
+	// lastDiffs is of type *sync.Map[string]diff.Summary, where the key is the
+	// xds-relay aggregated key.
+	lastDiffs *sync.Map
+
+	// versionGuard is nil when upstream versions are not checked.
+	versionGuard *versionGuard
+
+	// responseLimits is nil when upstream responses are not limited.
+	responseLimits responseLimits
+
+	// notifier is nil when cache updates are not published.
+	notifier notifier.Notifier
+
+	// elector is nil when leader election is disabled, in which case upstream
+	// streams are always opened.
+	elector *election.Elector
+	// upstreamMu serializes leadership changes with the opening and closing of
+	// upstream streams.
+	upstreamMu sync.RWMutex
+	// representativeRequests is of type *sync.Map[string]gcp.Request, where
+	// the key is the xds-relay aggregated key and the value is the request
+	// used to open the upstream stream. It is only written while holding the
+	// lock of the key. See keyLifecycle.
+	representativeRequests *sync.Map
+	// keyStates holds the lifecycle state of every aggregated key.
+	keyStates *keyStates
+
+	// replicationServer is nil when cache updates are not served to peers.
+	replicationServer *replication.Server
+
+	// statusServer is nil when key status events are not streamed.
+	statusServer *status.Server
+
+	// auditLog is nil when served responses are not audited.
+	auditLog *audit.Logger
+
+	// verifier is nil when the signatures of upstream responses are not
+	// verified.
+	verifier *signature.Verifier
+
+	// controlPlaneIdentifier prefixes the control plane identifier stamped on
+	// cached responses. Responses are not stamped if it is empty.
+	controlPlaneIdentifier string
+
+	// pins holds the response pinned for each aggregated key, which is served
+	// in place of the cached response.
+	pins *sync.Map
+	// rollbacks is of type *sync.Map[string]rollback, where the key is the
+	// xds-relay aggregated key and the value is the version the pinned
+	// response was rolled back from.
+	rollbacks *sync.Map
+
+	// circuitBreakers stop opening the upstream streams of aggregated keys
+	// that keep failing. Closed upstream streams are not reopened if it is
+	// nil.
+	circuitBreakers *circuitBreakers
+
+	// alertRules raise alerts on anomalies of the configuration of aggregated
+	// keys. No alerts are raised if it is nil.
+	alertRules *alertRules
+
+	// negativeCache remembers requests that cannot be mapped and aggregated
+	// keys whose upstream stream failed to open, so that identical requests
+	// fail fast. Failures are not remembered if it is nil.
+	negativeCache *negativeCache
+
+	// hashRing opens the upstream streams of the aggregated keys owned by
+	// other replicas with those replicas. Every upstream stream is opened
+	// with the origin server if it is nil.
+	hashRing *hashRing
+
+	// upstreamHealth scores the upstream streams of aggregated keys and
+	// restarts silent ones. Silent streams are left open if it is nil.
+	upstreamHealth *upstreamHealth
+
+	// relayChain appends this relay to the hops of upstream requests, and
+	// rejects looping downstream requests. Hops are ignored if it is nil.
+	relayChain *relayChain
+
+	// parentRelay opens upstream streams with a parent relay before falling
+	// back to the origin server. Every upstream stream is opened with the
+	// origin server if it is nil.
+	parentRelay *parentRelay
+
+	// responseValidation validates upstream responses against their Envoy
+	// proto schemas. Responses are not validated if it is nil.
+	responseValidation *responseValidation
+
+	// linter checks the references between the resources of upstream
+	// responses. References are not checked if it is nil.
+	linter *linter
+
+	// dependencyOrdering holds upstream responses to fan them out in the
+	// order of the references between their resources. Responses are fanned
+	// out as soon as they are received if it is nil.
+	dependencyOrdering *dependencyOrdering
+
+	// history keeps the latest versions cached for every aggregated key. Only
+	// the cached version is held if it is nil.
+	history *history
+
+	// maintenance withholds the fanout of new upstream versions during
+	// maintenance windows. New versions are always fanned out if it is nil.
+	maintenance *maintenance
+
+	// changeRateGuard collapses the versions of aggregated keys that change
+	// too often, or pauses their fanout. Every version is fanned out if it is
+	// nil.
+	changeRateGuard *changeRateGuard
+
+	// fanoutPacing spreads the downstream sends of large fanouts across a
+	// duration. Every send of a fanout runs at once if it is nil.
+	fanoutPacing *fanoutPacing
+
+	// fetchTimeout is the maximum time Fetch waits for the upstream response
+	// of a key that is not cached. The fetchTimeout constant applies if it is
+	// zero.
+	fetchTimeout time.Duration
+	// singleShotFetch is true when Fetch closes the upstream stream it opens
+	// for a key that is not cached after the first response.
+	singleShotFetch bool
+
+	// buffers sizes the response channels of downstream watches and upstream
+	// streams. The channels are sized by default if it is nil.
+	buffers *responseBuffers
+
+	// keyMetrics tags the metrics of aggregated keys with the key. Metrics
+	// are not tagged with the key if it is nil.
+	keyMetrics *keyMetrics
+
+	// keySimulation maps requests with candidate aggregation rules alongside
+	// the mapper. Only the mapper maps requests if it is nil.
+	keySimulation *keySimulation
+
+	// resumptionTokens is true when the nonces of the responses sent
+	// downstream carry resumption tokens, and the tokens of reconnecting
+	// clients are accepted.
+	resumptionTokens bool
+
+	// rollouts stages new versions to canary nodes. New versions are fanned
+	// out to every node at once if it is nil.
+	rollouts *rolloutController
+
+	// shadowClient is nil when no shadow origin server is configured.
+	shadowClient upstream.Client
+	// shadowResponses is of type *sync.Map[string]*discovery.DiscoveryResponse,
+	// where the key is the xds-relay aggregated key and the value is the latest
+	// shadow server response.
+	shadowResponses *sync.Map
+	// shadowDiffs is of type *sync.Map[string]diff.Summary, where the key is
+	// the xds-relay aggregated key.
+	shadowDiffs *sync.Map
+
+	// transformer is nil when upstream responses are served unmodified.
+	transformer transform.Transformer
+
+	// overrides is nil when upstream resources are not patched.
+	overrides *transform.Overrides
+	// overriddenResponses is of type *sync.Map[string]*overriddenResponse,
+	// where the key is the xds-relay aggregated key.
+	overriddenResponses *sync.Map
+
+	// staticResponses is nil when every request is forwarded upstream.
+	staticResponses *static.Responses
+
+	// recorder is nil when nothing is recorded.
+	recorder *recording.Recorder
+
+	// differentialFanout is nil if every watch is sent every new response.
+	differentialFanout *differentialFanout
+
+	// contentDeduplication is the set of type URLs whose responses are not
+	// fanned out if they only bump the version. It is nil if no responses
+	// are deduplicated by content.
+	contentDeduplication typeURLSet
+
+	// cacheFactory creates the cache in place of cache.NewCache when set.
+	cacheFactory cache.Factory
+
+	// evictionPolicy decides what happens to the watchers of evicted keys.
+	evictionPolicy bootstrapv1.Cache_EvictionPolicy
+
+	// supervisor tracks and restarts the upstream worker of each aggregated
+	// key.
+	supervisor *supervisor
+
+	goroutines *goroutineTracker
+
+	// connectionSenders runs the downstream sends of fanouts on the send loop
+	// of the connection of their watch, unless fanouts are paced or scheduled.
+	connectionSenders *connectionSenders
+
+	// fanoutScheduler is nil when the downstream sends of a fanout run on the
+	// send loops of their connections.
+	fanoutScheduler *fanoutScheduler
+
+	// watchIdleTimeout is zero when watches never become idle.
+	watchIdleTimeout time.Duration
+}
+
+// Opts allows configuring optional orchestrator behavior.
+type Opts func(*orchestrator)
+
+// WithVersionGuard enables detection of upstream responses whose version
+// moves backwards for an aggregated key.
+func WithVersionGuard(config *bootstrapv1.VersionGuard) Opts {
+	return func(o *orchestrator) {
+		o.versionGuard = newVersionGuard(config)
+	}
+}
+
+// WithResponseLimits drops or warns about upstream responses over the size
+// limits of their type.
+func WithResponseLimits(config []*bootstrapv1.ResponseLimit) Opts {
+	return func(o *orchestrator) {
+		o.responseLimits = newResponseLimits(config)
+	}
+}
+
+// WithSupervision restarts the upstream workers of aggregated keys that panic
+// according to the restart policy of the config.
+func WithSupervision(config *bootstrapv1.Supervision) Opts {
+	return func(o *orchestrator) {
+		o.supervisor = newSupervisor(config)
+	}
+}
+
+// WithFanoutScheduling shares a fixed number of concurrent downstream sends
+// between the aggregated keys that are fanning out, by the priority class of
+// their resource type and fairly within each class.
+func WithFanoutScheduling(config *bootstrapv1.FanoutScheduling) Opts {
+	return func(o *orchestrator) {
+		scheduler, err := newFanoutScheduler(config, o.scope)
+		if err != nil {
+			o.logger.With("error", err).Panic(context.Background(), "failed to initialize fanout scheduler")
+		}
+		o.fanoutScheduler = scheduler
+	}
+}
+
+// WithWatchIdleTimeout closes the downstream watches whose clients have
+// neither acknowledged nor rejected the response sent to them within the
+// timeout.
+func WithWatchIdleTimeout(timeout time.Duration) Opts {
+	return func(o *orchestrator) {
+		o.watchIdleTimeout = timeout
+	}
+}
+
+// WithNotifier publishes an event for every update to the cached response of
+// an aggregated key.
+func WithNotifier(n notifier.Notifier) Opts {
+	return func(o *orchestrator) {
+		o.notifier = n
+	}
+}
+
+// WithLeaderElection opens upstream streams only while the elector holds the
+// lease. Followers serve downstream clients from the cache.
+func WithLeaderElection(elector *election.Elector) Opts {
+	return func(o *orchestrator) {
+		o.elector = elector
+		elector.OnLeaderChange(o.onLeaderChange)
+	}
+}
+
+// WithReplicationServer publishes every update to the cached response of an
+// aggregated key to peers replicating from this relay.
+func WithReplicationServer(server *replication.Server) Opts {
+	return func(o *orchestrator) {
+		o.replicationServer = server
+	}
+}
+
+// WithStatusServer streams changes to the cached version of each aggregated
+// key, and the acknowledgements and rejections of downstream nodes, to
+// subscribers of the status server.
+func WithStatusServer(server *status.Server) Opts {
+	return func(o *orchestrator) {
+		o.statusServer = server
+	}
+}
+
+// WithAuditLog records every response served to a downstream client in the
+// audit log.
+func WithAuditLog(auditLog *audit.Logger) Opts {
+	return func(o *orchestrator) {
+		o.auditLog = auditLog
+	}
+}
+
+// WithSignatureVerifier drops upstream responses that are unsigned or whose
+// signature is invalid, rather than caching and serving them.
+func WithSignatureVerifier(verifier *signature.Verifier) Opts {
+	return func(o *orchestrator) {
+		o.verifier = verifier
+	}
+}
+
+// WithControlPlaneIdentifier stamps the control plane identifier of cached
+// responses with this replica and the response version.
+func WithControlPlaneIdentifier(config *bootstrapv1.ControlPlaneIdentifier) Opts {
+	return func(o *orchestrator) {
+		o.controlPlaneIdentifier = controlPlaneIdentifierPrefix(config)
+	}
+}
+
+// WithRollout fans out new versions to canary nodes first, and serves the
+// previous version to the other nodes until the rollout is promoted.
+func WithRollout(config *bootstrapv1.Rollout) Opts {
+	return func(o *orchestrator) {
+		o.rollouts = newRolloutController(config)
+	}
+}
+
+// WithCircuitBreaker reopens failed upstream streams, and stops doing so for
+// the cool down once an aggregated key fails too often.
+func WithCircuitBreaker(config *bootstrapv1.CircuitBreaker) Opts {
+	return func(o *orchestrator) {
+		o.circuitBreakers = newCircuitBreakers(config)
+	}
+}
+
+// WithDifferentialFanout skips sending responses to the watches of specific
+// resources whose resources did not change since the response their node
+// last acknowledged.
+func WithDifferentialFanout(config *bootstrapv1.DifferentialFanout) Opts {
+	return func(o *orchestrator) {
+		o.differentialFanout = newDifferentialFanout(config)
+	}
+}
+
+// WithContentDeduplication caches but does not fan out responses that bump
+// the version without changing the resources.
+func WithContentDeduplication(config *bootstrapv1.ContentDeduplication) Opts {
+	return func(o *orchestrator) {
+		o.contentDeduplication = newTypeURLSet(config.GetTypeUrls())
+	}
+}
+
+// WithAlerting raises alerts when aggregated keys are not updated, lose
+// resources, or are rejected by downstream nodes beyond the thresholds of the
+// config.
+func WithAlerting(config *bootstrapv1.Alerting) Opts {
+	return func(o *orchestrator) {
+		o.alertRules = newAlertRules(config)
+	}
+}
+
+// WithNegativeCache fails requests that cannot be mapped, and watches of
+// aggregated keys whose upstream stream failed to open, fast for the TTL of
+// the config.
+func WithNegativeCache(config *bootstrapv1.NegativeCache) Opts {
+	return func(o *orchestrator) {
+		o.negativeCache = newNegativeCache(config)
+	}
+}
+
+// WithHashRing opens the upstream streams of the aggregated keys owned by
+// other replicas of the ring with the xDS servers of those replicas, dialed
+// with dialPeer, and moves upstream streams to the new owner of their
+// aggregated key when the membership of the ring changes.
+func WithHashRing(hashRing *ring.Ring, dialPeer PeerDialer) Opts {
+	return func(o *orchestrator) {
+		o.hashRing = newHashRing(hashRing, dialPeer)
+		hashRing.OnChange(o.onRingChange)
+	}
+}
+
+// WithUpstreamHealth scores the upstream stream of every aggregated key by the
+// time since its last response, and restarts the streams that stay silent
+// beyond their timeout while resources of their type are changing.
+func WithUpstreamHealth(config *bootstrapv1.UpstreamHealth) Opts {
+	return func(o *orchestrator) {
+		health, err := newUpstreamHealth(config)
+		if err != nil {
+			o.logger.With("error", err).Panic(context.Background(), "failed to initialize upstream health")
+		}
+		o.upstreamHealth = health
+	}
+}
+
+// WithRelayChain appends the identity of this relay to the hops of the
+// requests sent upstream, and rejects the requests that already passed
+// through this relay or through too many relays.
+func WithRelayChain(config *bootstrapv1.RelayChain) Opts {
+	return func(o *orchestrator) {
+		o.relayChain = newRelayChain(config)
+	}
+}
+
+// WithParentRelay opens the upstream streams of aggregated keys with the
+// parent relay's client, and falls back to the origin server for the keys
+// that the parent relay fails to serve.
+func WithParentRelay(config *bootstrapv1.ParentRelay, client upstream.Client) Opts {
+	return func(o *orchestrator) {
+		o.parentRelay = newParentRelay(config, client)
+	}
+}
+
+// WithResponseValidation validates the resources of upstream responses
+// against their Envoy proto schemas, and drops or warns about invalid
+// responses per the action of the config.
+func WithResponseValidation(config *bootstrapv1.ResponseValidation) Opts {
+	return func(o *orchestrator) {
+		o.responseValidation = newResponseValidation(config)
+	}
+}
+
+// WithLinting lints the references of the resources of upstream responses
+// against the cache, and drops the responses with findings if the config
+// blocks them.
+func WithLinting(config *bootstrapv1.Linting) Opts {
+	return func(o *orchestrator) {
+		o.linter = newLinter(config)
+	}
+}
+
+// WithDependencyOrdering holds the upstream responses received within the
+// window of the config, and fans them out in the order of the references
+// between their resources.
+func WithDependencyOrdering(config *bootstrapv1.DependencyOrdering) Opts {
+	return func(o *orchestrator) {
+		o.dependencyOrdering = newDependencyOrdering(config)
+	}
+}
+
+// WithHistory keeps as many of the latest versions cached for every
+// aggregated key as the config allows, to be listed, diffed, and pinned.
+func WithHistory(config *bootstrapv1.History) Opts {
+	return func(o *orchestrator) {
+		o.history = newHistory(config)
+	}
+}
+
+// WithMaintenance withholds the fanout of new upstream versions of the keys
+// that are not allow-listed during the windows of the config, and releases
+// them gradually once the windows end.
+func WithMaintenance(config *bootstrapv1.Maintenance) Opts {
+	return func(o *orchestrator) {
+		maintenance, err := newMaintenance(config)
+		if err != nil {
+			o.logger.With("error", err).Panic(context.Background(), "failed to initialize maintenance windows")
+		}
+		o.maintenance = maintenance
+	}
+}
+
+// WithChangeRateGuard collapses the versions of aggregated keys that change
+// more often than the config allows, fanning out only the latest at the end
+// of the interval, or pauses their fanout until ResumeFanout is called if the
+// config pauses them.
+func WithChangeRateGuard(config *bootstrapv1.ChangeRateGuard) Opts {
+	return func(o *orchestrator) {
+		o.changeRateGuard = newChangeRateGuard(config)
+	}
+}
+
+// WithFanoutPacing spreads the downstream sends of fanouts to at least as
+// many watches as the config requires evenly across the duration of the
+// config, in a random order.
+func WithFanoutPacing(config *bootstrapv1.FanoutPacing) Opts {
+	return func(o *orchestrator) {
+		o.fanoutPacing = newFanoutPacing(config)
+	}
+}
+
+// WithFetch configures the unary Fetch requests for keys that are not cached.
+func WithFetch(config *bootstrapv1.Fetch) Opts {
+	return func(o *orchestrator) {
+		timeout, err := newFetchTimeout(config)
+		if err != nil {
+			o.logger.With("error", err).Panic(context.Background(), "failed to initialize fetch")
+		}
+		o.fetchTimeout = timeout
+		o.singleShotFetch = config.GetSingleShot()
+	}
+}
+
+// WithBuffers sizes the response channels of downstream watches and upstream
+// streams, and applies the overflow policies of the config.
+func WithBuffers(config *bootstrapv1.Buffers) Opts {
+	return func(o *orchestrator) {
+		o.buffers = newResponseBuffers(config)
+		o.downstreamResponseMap.buffers = o.buffers
+	}
+}
+
+// WithKeyMetrics tags the metrics of the aggregated keys matching the rules of
+// the config with the key, or with the bucket of their rule.
+func WithKeyMetrics(config *bootstrapv1.KeyMetrics) Opts {
+	return func(o *orchestrator) {
+		keyMetrics, err := newKeyMetrics(config)
+		if err != nil {
+			o.logger.With("error", err).Panic(context.Background(), "failed to initialize key metrics")
+		}
+		o.keyMetrics = keyMetrics
+	}
+}
+
+// WithAggregationSimulation maps every request with the candidate mapper
+// alongside the mapper, and reports how the aggregated keys would change if
+// the candidate mapper replaced it. Requests are always served with the keys
+// of the mapper.
+func WithAggregationSimulation(config *bootstrapv1.AggregationSimulation, candidate mapper.Mapper) Opts {
+	return func(o *orchestrator) {
+		o.keySimulation = newKeySimulation(config, candidate, o.scope.SubScope(metricSubscopeSimulation))
+	}
+}
+
+// WithResumptionTokens issues a resumption token in the nonce of every
+// response sent downstream, and restores the response recorded in the token
+// of a reconnecting client's request, so that a restarted relay does not
+// resend the configuration the client already holds.
+func WithResumptionTokens() Opts {
+	return func(o *orchestrator) {
+		o.resumptionTokens = true
+	}
+}
+
+// WithCacheFactory creates the cache with the factory instead of
+// configuring the default cache from the cache config. The TTL and max
+// entries of the cache config are then up to the factory.
+func WithCacheFactory(factory cache.Factory) Opts {
+	return func(o *orchestrator) {
+		o.cacheFactory = factory
+	}
+}
+
+// WithShadowUpstream sends every representative request to a shadow origin
+// server as well, and compares its responses with the origin server's. Shadow
+// responses are never served downstream.
+func WithShadowUpstream(shadowClient upstream.Client) Opts {
+	return func(o *orchestrator) {
+		o.shadowClient = shadowClient
+	}
+}
+
+// WithTransformer applies the transformer to upstream responses before they
+// are cached and fanned out.
+func WithTransformer(transformer transform.Transformer) Opts {
+	return func(o *orchestrator) {
+		o.transformer = transformer
+	}
+}
+
+// WithOverrides patches the resources of upstream responses with the
+// overrides for their aggregated key before they are cached and fanned out.
+// Cached responses are patched again whenever the overrides change.
+func WithOverrides(overrides *transform.Overrides) Opts {
+	return func(o *orchestrator) {
+		o.overrides = overrides
+		overrides.OnChange(o.reapplyOverrides)
+	}
+}
+
+// WithStaticResponses serves the static responses to matching requests
+// instead of forwarding them upstream.
+func WithStaticResponses(responses *static.Responses) Opts {
+	return func(o *orchestrator) {
+		o.staticResponses = responses
+	}
+}
+
+// WithRecorder records the upstream responses, and the downstream requests if
+// configured, of each aggregated key.
+func WithRecorder(recorder *recording.Recorder) Opts {
+	return func(o *orchestrator) {
+		o.recorder = recorder
+	}
 }
 
 // New instantiates the mapper, cache, upstream client components necessary for
 // the orchestrator to operate and returns an instance of the instantiated
-// orchestrator.
+// orchestrator. Responses are registered with the provided codec so that the
+// downstream server serializes each response once per aggregated key.
 func New(
 	ctx context.Context,
 	l log.Logger,
 	scope tally.Scope,
 	mapper mapper.Mapper,
 	upstreamClient upstream.Client,
+	responseCodec *codec.Codec,
 	cacheConfig *bootstrapv1.Cache,
+	opts ...Opts,
 ) Orchestrator {
 	orchestrator := &orchestrator{
-		logger:                l.Named(component),
-		scope:                 scope,
-		mapper:                mapper,
-		upstreamClient:        upstreamClient,
-		downstreamResponseMap: newDownstreamResponseMap(scope.SubScope("downstream")),
-		upstreamResponseMap:   newUpstreamResponseMap(),
+		logger:                 l.Named(component),
+		scope:                  scope,
+		mapper:                 mapper,
+		upstreamClient:         upstreamClient,
+		codec:                  responseCodec,
+		downstreamResponseMap:  newDownstreamResponseMap(scope.SubScope("downstream")),
+		upstreamResponseMap:    newUpstreamResponseMap(),
+		sentResponseMap:        newSentResponseMap(),
+		nonces:                 newNonceMap(),
+		requestIDs:             newRequestIDMap(),
+		lastDiffs:              &sync.Map{},
+		representativeRequests: &sync.Map{},
+		keyStates:              newKeyStates(),
+		shadowResponses:        &sync.Map{},
+		shadowDiffs:            &sync.Map{},
+		overriddenResponses:    &sync.Map{},
+		pins:                   &sync.Map{},
+		rollbacks:              &sync.Map{},
+		evictionPolicy:         cacheConfig.GetEvictionPolicy(),
+		supervisor:             newSupervisor(nil),
+		goroutines:             newGoroutineTracker(scope.SubScope(metricSubscopeGoroutines)),
+	}
+	orchestrator.connectionSenders = newConnectionSenders(orchestrator.goroutines)
+	for _, opt := range opts {
+		opt(orchestrator)
+	}
+	if orchestrator.fanoutScheduler != nil {
+		orchestrator.fanoutScheduler.start(orchestrator.goroutines)
+	}
+	if orchestrator.watchIdleTimeout > 0 {
+		orchestrator.goroutines.goroutine(subsystemIdleReaper, func() {
+			orchestrator.reapIdleWatches(ctx)
+		})
 	}
 
 	// Initialize cache.
-	cache, err := cache.NewCache(
-		int(cacheConfig.MaxEntries),
-		orchestrator.onCacheEvicted,
-		time.Duration(cacheConfig.Ttl.Nanos)*time.Nanosecond,
-	)
+	ttl := time.Duration(cacheConfig.Ttl.Nanos) * time.Nanosecond
+	cacheFactory := orchestrator.cacheFactory
+	if cacheFactory == nil {
+		cacheFactory = func(onEvicted cache.OnEvictFunc) (cache.Cache, error) {
+			return cache.NewCache(
+				int(cacheConfig.MaxEntries),
+				onEvicted,
+				ttl,
+				cache.WithStrategy(cacheStrategy(cacheConfig.GetEvictionStrategy())),
+			)
+		}
+	}
+	cache, err := cacheFactory(orchestrator.onCacheEvicted)
 	if err != nil {
 		orchestrator.logger.With("error", err).Panic(ctx, "failed to initialize cache")
 	}
 	orchestrator.cache = cache
+	if ttl > 0 {
+		orchestrator.goroutines.goroutine(subsystemExpiry, func() {
+			orchestrator.removeExpiredPeriodically(ctx, ttl)
+		})
+	}
+
+	if orchestrator.alertRules != nil {
+		orchestrator.goroutines.goroutine(subsystemAlerting, func() {
+			orchestrator.evaluateAlerts(ctx)
+		})
+	}
+	if orchestrator.upstreamHealth != nil {
+		orchestrator.goroutines.goroutine(subsystemUpstreamHealth, func() {
+			orchestrator.checkUpstreamHealthPeriodically(ctx)
+		})
+	}
 
 	go orchestrator.shutdown(ctx)
 
@@ -122,94 +902,386 @@ func New(
 // Cancel is an optional function to release resources in the producer. If
 // provided, the consumer may call this function multiple times.
 func (o *orchestrator) CreateWatch(req gcp.Request) (chan gcp.Response, func()) {
-	ctx := context.Background()
-	o.logger.With("node ID", req.GetNode().GetId()).With("type", req.GetTypeUrl()).Debug(ctx, "creating watch")
+	streamID := o.requestIDs.stream(req.GetNode())
+	return o.createWatch(req, streamID, o.requestIDs.get(streamID), o.requestIDs.getTransport(streamID),
+		o.requestIDs.peer(streamID))
+}
 
-	// If this is the first time we're seeing the request from the
-	// downstream client, initialize a channel to feed future responses.
-	responseChannel := o.downstreamResponseMap.createChannel(&req)
+// createWatch creates the watch of the request of the downstream stream with
+// the ID, or of a fetch if the ID is zero. The stream or fetch has the request
+// ID and the transport, and was received from the peer with the address, if
+// known.
+func (o *orchestrator) createWatch(
+	req gcp.Request,
+	streamID int64,
+	requestID string,
+	transport mapper.Transport,
+	peer string,
+) (chan gcp.Response, func()) {
+	ctx := withTransport(withRequestID(context.Background(), requestID), transport)
+	// watchCtx annotates the messages logged about this watch. It is not
+	// passed on to the upstream stream, which is shared by other watches.
+	watchCtx := log.WithNodeID(logRequestID(ctx), req.GetNode().GetId())
+	o.logger.With("type", req.GetTypeUrl()).Debug(watchCtx, "creating watch")
 
-	aggregatedKey, err := o.mapper.GetKey(req)
+	aggregatedKey, err := o.getAggregatedKey(ctx, req)
 	if err != nil {
-		// Can't map the request to an aggregated key. Log and continue to
-		// propagate the response upstream without aggregation.
-		o.logger.With("err", err).With("req node", req.GetNode()).Warn(ctx, "failed to map to aggregated key")
-		// Mimic the aggregated key.
-		// TODO (https://github.com/envoyproxy/xds-relay/issues/56). This key
-		// needs to be made more granular to uniquely identify a request.
-		aggregatedKey = fmt.Sprintf("%s%s_%s", unaggregatedPrefix, req.GetNode().GetId(), req.GetTypeUrl())
+		// Close the stream of the rejected request.
+		o.failWatch(streamID, err)
+		closedChannel := make(chan gcp.Response)
+		close(closedChannel)
+		return closedChannel, nil
+	}
+	watchCtx = log.WithAggregatedKey(watchCtx, aggregatedKey)
+	if o.negativeCache != nil && !o.hasCachedResponse(aggregatedKey) {
+		if err := o.negativeCached(aggregatedKey); err != nil {
+			// Close the stream of the key that recently failed.
+			o.failWatch(streamID, &KeyError{Key: aggregatedKey, Err: err})
+			closedChannel := make(chan gcp.Response)
+			close(closedChannel)
+			return closedChannel, nil
+		}
+	}
+	if o.resumptionTokens {
+		o.resume(watchCtx, aggregatedKey, &req)
+	}
+	if o.statusServer != nil {
+		o.publishRequestStatus(aggregatedKey, req)
 	}
 
+	// Initialize a channel to feed future responses to the watch.
+	id, responseChannel := o.downstreamResponseMap.createChannel(aggregatedKey, &req, streamID, peer)
+
+	if o.recorder != nil {
+		o.recorder.RecordRequest(aggregatedKey, &req)
+	}
+
+	// Static responses are cached before the watch is registered, so that the
+	// watch is served from the cache below.
+	isStatic := o.cacheStaticResponse(ctx, aggregatedKey, req)
+
 	// Register the watch for future responses.
-	err = o.cache.AddRequest(aggregatedKey, &req)
+	err = o.cache.AddRequest(aggregatedKey, id, &req)
 	if err != nil {
 		// If we fail to register the watch, we need to kill this stream by
 		// closing the response channel.
-		o.logger.With("err", err).With("key", aggregatedKey).With(
-			"req node", req.GetNode()).Error(ctx, "failed to add watch")
-		closedChannel := o.downstreamResponseMap.delete(&req)
+		o.logger.With("err", err).With("req node", req.GetNode()).Error(watchCtx, "failed to add watch")
+		o.failWatch(streamID, &KeyError{Key: aggregatedKey, Err: err})
+		closedChannel := o.downstreamResponseMap.delete(id)
 		return closedChannel, nil
 	}
+	o.onKeyWatched(aggregatedKey)
 
 	// Check if we have a cached response first.
 	cached, err := o.cache.Fetch(aggregatedKey)
 	if err != nil {
 		// Log, and continue to propagate the response upstream.
-		o.logger.With("err", err).With("key", aggregatedKey).Warn(ctx, "failed to fetch aggregated key")
+		o.logger.With("err", err).Warn(watchCtx, "failed to fetch aggregated key")
 	}
 
-	if cached != nil && cached.Resp != nil && cached.Resp.GetVersionInfo() != req.GetVersionInfo() {
+	// A client that rejects the cached response is not sent it again. The
+	// watch waits for the next response instead.
+	served := o.servedResponse(aggregatedKey, req.GetNode(), cached)
+	rejectedVersion, isNack := o.nonces.rejectedVersion(streamID, &req)
+	if req.GetResponseNonce() != "" {
+		o.recordKeyResponseStatus(aggregatedKey, &req, rejectedVersion)
+		if o.alertRules != nil {
+			// Rejections count towards the NACK rate even if the nonce of the
+			// rejected response is unknown.
+			o.alertRules.recordResponseStatus(aggregatedKey, req.GetErrorDetail() != nil)
+		}
+	}
+	if isNack && o.circuitBreakers != nil {
+		o.onDownstreamNack(ctx, aggregatedKey, rejectedVersion)
+	}
+	if isNack && served != nil && served.GetVersionInfo() == rejectedVersion {
+		o.keyScope(aggregatedKey).Counter(metricNackSuppressed).Inc(1)
+	} else if served != nil && served.GetVersionInfo() != req.GetVersionInfo() {
 		// If we have a cached response and the version is different,
 		// immediately push the result to the response channel.
-		go func() { responseChannel <- convertToGcpResponse(cached.Resp, req) }()
+		o.sentResponseMap.record(aggregatedKey, &req, newSentResponse(served),
+			watchSubset(o.differentialFanout.resources(served), &req))
+		if sent, _ := o.downstreamResponseMap.send(id, convertToGcpResponse(served, req)); !sent {
+			o.logger.Error(watchCtx, "channel blocked while sending the cached response")
+		} else if o.auditLog != nil {
+			o.auditLog.RecordServed(aggregatedKey, req.GetNode().GetId(), served)
+		}
+	}
+
+	if isStatic {
+		return responseChannel, o.onCancelWatch(aggregatedKey, id)
 	}
 
+	// Remember the first request for the aggregated key so that a replica
+	// promoted to leader can open the upstream stream on its behalf.
+	o.setRepresentative(aggregatedKey, req)
+
 	// Check if we have a upstream stream open for this aggregated key. If not,
-	// open a stream with the representative request.
-	if !o.upstreamResponseMap.exists(aggregatedKey) {
-		upstreamResponseChan, shutdown, err := o.upstreamClient.OpenStream(req)
-		if err != nil {
-			// TODO implement retry/back-off logic on error scenario.
-			// https://github.com/envoyproxy/xds-relay/issues/68
-			o.logger.With("err", err).With("key", aggregatedKey).Error(ctx, "Failed to open stream to origin server")
-		} else {
-			respChannel, upstreamOpenedPreviously := o.upstreamResponseMap.add(aggregatedKey, upstreamResponseChan)
-			if upstreamOpenedPreviously {
-				// A stream was opened previously due to a race between
-				// concurrent downstreams for the same aggregated key, between
-				// exists and add operations. In this event, simply close the
-				// slower stream and return the existing one.
-				shutdown()
-			} else {
-				// Spin up a go routine to watch for upstream responses.
-				// One routine is opened per aggregate key.
-				go o.watchUpstream(ctx, aggregatedKey, respChannel.response, respChannel.done, shutdown)
+	// open a stream with the representative request. Followers leave upstream
+	// streams to the leader and serve from the cache.
+	o.upstreamMu.RLock()
+	if o.isLeader() {
+		o.openUpstream(ctx, aggregatedKey, req)
+	}
+	o.upstreamMu.RUnlock()
+
+	return responseChannel, o.onCancelWatch(aggregatedKey, id)
+}
+
+// openUpstream opens a stream to the origin server with the representative
+// request if one is not already open for the aggregated key. The stream
+// subscribes to the union of the resource names of the open watches, and is
+// resubscribed whenever the union changes. Streams of clients that cannot
+// resubscribe in place are reopened instead.
+func (o *orchestrator) openUpstream(ctx context.Context, aggregatedKey string, req gcp.Request) {
+	s := o.getSubscription(aggregatedKey)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wildcard, resourceNames := o.subscribedResourceNames(aggregatedKey, req)
+	if o.upstreamResponseMap.exists(aggregatedKey) {
+		if s.matches(wildcard, resourceNames) {
+			return
+		}
+		o.keyScope(aggregatedKey).Counter(metricSubscriptionUpdate).Inc(1)
+		o.logger.With("key", aggregatedKey).With("resource names", resourceNames).
+			Info(logRequestID(ctx), "resubscribing upstream")
+		if s.resubscribe != nil {
+			s.resubscribe(resourceNames)
+			if s.resubscribeShadow != nil {
+				s.resubscribeShadow(resourceNames)
 			}
+			s.wildcard, s.resourceNames = wildcard, resourceNames
+			return
 		}
+		o.deleteUpstream(aggregatedKey, nil)
+	}
+	if o.circuitBreakers != nil && !o.circuitBreakers.allows(aggregatedKey) {
+		o.keyScope(aggregatedKey).Counter(metricCircuitRejected).Inc(1)
+		return
+	}
+	if o.negativeCached(aggregatedKey) != nil {
+		return
+	}
+	req.ResourceNames = resourceNames
+	if o.relayChain != nil {
+		req = o.relayChain.forward(req)
+	}
+	upstreamClient, owner, err := o.upstreamClientFor(aggregatedKey)
+	if err != nil {
+		o.logger.With("err", err).With("key", aggregatedKey).With("owner", owner).
+			Error(logRequestID(ctx), "Failed to dial the replica that owns the key")
+		o.onUpstreamOpenFailure(ctx, aggregatedKey, err)
+		return
 	}
+	fromParent := owner == "" && o.fetchesFromParent(aggregatedKey)
+	if fromParent {
+		upstreamClient = o.parentRelay.client
+	}
+	upstreamResponseChan, resubscribe, shutdown, err := openStream(upstreamClient, aggregatedKey, req, requestIDFromContext(ctx))
+	if err != nil && fromParent {
+		o.fallBackToOrigin(ctx, aggregatedKey, err.Error())
+		fromParent = false
+		upstreamResponseChan, resubscribe, shutdown, err = openStream(o.upstreamClient, aggregatedKey, req, requestIDFromContext(ctx))
+	}
+	if err != nil {
+		// TODO implement retry/back-off logic on error scenario.
+		// https://github.com/envoyproxy/xds-relay/issues/68
+		o.logger.With("err", err).With("key", aggregatedKey).
+			Error(logRequestID(ctx), "Failed to open stream to origin server")
+		o.onUpstreamOpenFailure(ctx, aggregatedKey, err)
+		return
+	}
+	upstreamResponseChan, shutdown = o.bufferUpstream(aggregatedKey, req.GetTypeUrl(), upstreamResponseChan, shutdown)
+	respChannel, added := o.addUpstream(aggregatedKey, s, upstreamResponseChan)
+	if !added {
+		// Either a stream was opened previously due to a race between
+		// concurrent downstreams for the same aggregated key, or the key
+		// was evicted while the stream was opened. In this event, simply
+		// close the slower stream.
+		shutdown()
+		return
+	}
+	s.wildcard, s.resourceNames = wildcard, resourceNames
+	s.resubscribe, s.resubscribeShadow = resubscribe, nil
+	if o.hashRing != nil {
+		o.hashRing.setOwner(aggregatedKey, owner)
+		if owner != "" {
+			o.keyScope(aggregatedKey).Counter(metricUpstreamForwarded).Inc(1)
+		}
+	}
+	if fromParent {
+		o.awaitParent(ctx, aggregatedKey)
+	}
+	// Spin up a go routine to watch for upstream responses.
+	// One routine is opened per aggregate key.
+	worker := o.supervisor.start(aggregatedKey, o.getTenant(aggregatedKey))
+	o.goroutines.goroutine(subsystemUpstreamReceiver, func() {
+		o.superviseUpstream(ctx, worker, respChannel.response, respChannel.done, shutdown)
+	})
+	// Responses forwarded by other replicas are compared with the shadow
+	// server by the replica that owns the key.
+	if o.shadowClient != nil && owner == "" {
+		s.resubscribeShadow = o.openShadow(ctx, aggregatedKey, req, respChannel.done)
+	}
+}
 
-	return responseChannel, o.onCancelWatch(aggregatedKey, &req)
+// getAggregatedKey maps the request to its aggregated key. It returns an
+// error if the request matches no aggregation rule and the keyer
+// configuration rejects such requests.
+func (o *orchestrator) getAggregatedKey(ctx context.Context, req gcp.Request) (string, error) {
+	if o.relayChain != nil {
+		if metric, err := o.relayChain.check(req.GetNode()); err != nil {
+			o.scope.Counter(metric).Inc(1)
+			o.logger.With("err", err).With("req node", req.GetNode().GetId()).Warn(ctx, "rejected request")
+			return "", err
+		}
+	}
+	if o.negativeCache != nil {
+		if err := o.negativeCached(mapper.UnaggregatedKey(req)); err != nil {
+			return "", err
+		}
+	}
+	aggregatedKey, err := mapper.GetKeyWithTransport(o.mapper, req, transportFromContext(ctx))
+	var unmatched *mapper.UnmatchedRequestError
+	if errors.As(err, &unmatched) && unmatched.Reject {
+		o.scope.Counter(metricUnmatchedRejected).Inc(1)
+		o.logger.With("req node", req.GetNode()).With("type", req.GetTypeUrl()).
+			Warn(ctx, "rejected request that matches no aggregation rule")
+		o.recordNegative(ctx, mapper.UnaggregatedKey(req), err)
+		return "", err
+	}
+	if err != nil {
+		// Can't map the request to an aggregated key. Log and continue to
+		// propagate the response upstream without aggregation.
+		o.logger.With("err", err).With("req node", req.GetNode()).Warn(ctx, "failed to map to aggregated key")
+		// Mimic the aggregated key.
+		// TODO (https://github.com/envoyproxy/xds-relay/issues/56). This key
+		// needs to be made more granular to uniquely identify a request.
+		aggregatedKey = mapper.UnaggregatedKey(req)
+	}
+	if o.keySimulation != nil {
+		o.simulate(ctx, req, aggregatedKey)
+	}
+	return aggregatedKey, nil
 }
 
-// Fetch implements the polling method of the config cache using a non-empty request.
-func (o *orchestrator) Fetch(context.Context, discovery.DiscoveryRequest) (gcp.Response, error) {
-	return nil, fmt.Errorf("Not implemented")
+// getTenant returns the tenant of the aggregated key, or an empty string if
+// the key has none. Keys of requests that are not aggregated have no tenant.
+func (o *orchestrator) getTenant(aggregatedKey string) string {
+	tenantMapper, ok := o.mapper.(mapper.TenantMapper)
+	if !ok || strings.HasPrefix(aggregatedKey, mapper.UnaggregatedPrefix) {
+		return ""
+	}
+	return tenantMapper.GetTenant(aggregatedKey)
+}
+
+// keyScope returns the scope of the metrics of the aggregated key. Metrics of
+// keys with a tenant are tagged with the tenant, and metrics of keys matching
+// the key metrics rules are tagged with the key.
+func (o *orchestrator) keyScope(aggregatedKey string) tally.Scope {
+	tags := make(map[string]string)
+	if tenant := o.getTenant(aggregatedKey); tenant != "" {
+		tags[metricTagTenant] = tenant
+	}
+	if o.keyMetrics != nil {
+		tag, overflow := o.keyMetrics.tag(aggregatedKey)
+		if overflow {
+			o.scope.Counter(metricKeyMetricsOverflow).Inc(1)
+		}
+		if tag != "" {
+			tags[metricTagKey] = tag
+		}
+	}
+	if len(tags) == 0 {
+		return o.scope
+	}
+	return o.scope.Tagged(tags)
+}
+
+// Fetch implements the polling method of the config cache using a non-empty
+// request. It is used to serve xDS over REST.
+//
+// The cached response is returned if its version differs from the request's.
+// If nothing is cached for the aggregated key yet, a watch is created so that
+// the upstream stream is opened, and Fetch waits for the first response.
+//
+// Errors are returned as gRPC statuses, see Status.
+func (o *orchestrator) Fetch(ctx context.Context, req discovery.DiscoveryRequest) (gcp.Response, error) {
+	transport := o.captureTransport(ctx)
+	aggregatedKey, err := o.getAggregatedKey(withTransport(ctx, transport), req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	cached, err := o.cache.Fetch(aggregatedKey)
+	if err == nil && cached != nil && cached.Resp != nil {
+		served := o.servedResponse(aggregatedKey, req.GetNode(), cached)
+		if served.GetVersionInfo() == req.GetVersionInfo() {
+			return nil, &types.SkipFetchError{}
+		}
+		if o.auditLog != nil {
+			o.auditLog.RecordServed(aggregatedKey, req.GetNode().GetId(), served)
+		}
+		return convertToGcpResponse(served, req), nil
+	}
+
+	if err := o.negativeCached(aggregatedKey); err != nil {
+		return nil, statusError(&KeyError{Key: aggregatedKey, Err: err})
+	}
+
+	if o.isSingleShotFetch(aggregatedKey) {
+		return o.fetchOnce(withRequestID(ctx, contextRequestID(ctx)), aggregatedKey, req)
+	}
+	responseChannel, cancelWatch := o.createWatch(req, 0, contextRequestID(ctx), transport, contextPeer(ctx))
+	if cancelWatch != nil {
+		defer cancelWatch()
+	}
+	timer := time.NewTimer(o.getFetchTimeout())
+	defer timer.Stop()
+	select {
+	case resp, ok := <-responseChannel:
+		if !ok {
+			return nil, statusError(&KeyError{Key: aggregatedKey, Err: ErrWatchFailed})
+		}
+		return resp, nil
+	case <-ctx.Done():
+		return nil, statusError(ctx.Err())
+	case <-timer.C:
+		return nil, statusError(&KeyError{Key: aggregatedKey, Err: ErrFetchTimeout})
+	}
 }
 
 func (o *orchestrator) GetReadOnlyCache() cache.ReadOnlyCache {
 	return o.cache.GetReadOnlyCache()
 }
 
-// watchUpstream is intended to be called in a go routine, to receive incoming
-// responses, cache the response, and fan out to downstream clients or
-// "watchers". There is a corresponding go routine for each aggregated key.
+func (o *orchestrator) GetLastDiff(aggregatedKey string) (diff.Summary, bool) {
+	summary, ok := o.lastDiffs.Load(aggregatedKey)
+	if !ok {
+		return diff.Summary{}, false
+	}
+	return summary.(diff.Summary), true
+}
+
+// watchUpstream is intended to be called by the worker of an aggregated key,
+// to receive incoming responses, cache the response, and fan out to downstream
+// clients or "watchers". There is a corresponding worker for each aggregated
+// key, see `superviseUpstream`.
 //
 // This goroutine continually listens for upstream responses from the passed
 // `responseChannel`. For each response, we will:
+// - drop the response if signatures are verified and its signature is
+//   missing or invalid.
+// - drop the response if it exceeds the response limit of its type and the
+//   limit rejects such responses.
+// - apply the configured transformations, dropping the response on failure.
+// - drop the response if its version regresses and regressions are rejected.
+// - apply the overrides for the aggregated key, if any.
 // - cache this latest response, replacing the previous stale response.
+// - record the resources changed relative to the previous response.
 // - retrieve the downstream watchers from the cache for this `aggregated key`.
 // - trigger the fanout process to downstream watchers by pushing to the
-//   individual downstream response channels in separate go routines.
+//   individual downstream response channels from the send loops of their
+//   downstream connections.
 //
 // Additionally this function tracks a `done` channel and a `shutdownUpstream`
 // function. `done` is a channel that gets closed in two places:
@@ -221,13 +1293,18 @@ func (o *orchestrator) GetReadOnlyCache() cache.ReadOnlyCache {
 // function. This will signify to the upstream client that we no longer require
 // responses from this stream because the downstream connections have been
 // terminated. The upstream client will clean up the stream accordingly.
+// watchUpstream returns nil once `done` is closed, and an error if the
+// upstream stream was closed instead.
 func (o *orchestrator) watchUpstream(
 	ctx context.Context,
 	aggregatedKey string,
 	responseChannel <-chan *discovery.DiscoveryResponse,
 	done <-chan bool,
 	shutdownUpstream func(),
-) {
+) error {
+	// streamPrevious is the previous response of the stream that passed the
+	// version guard.
+	var streamPrevious *discovery.DiscoveryResponse
 	for {
 		select {
 		case x, more := <-responseChannel:
@@ -236,109 +1313,403 @@ func (o *orchestrator) watchUpstream(
 				// TODO implement retry/back-off logic on error scenario.
 				// https://github.com/envoyproxy/xds-relay/issues/68
 				o.logger.With("key", aggregatedKey).Error(ctx, "upstream error")
-				return
+				return fmt.Errorf("upstream stream for aggregated key %s closed", aggregatedKey)
 			}
-			// Cache the response.
-			_, err := o.cache.SetResponse(aggregatedKey, *x)
-			if err != nil {
-				// TODO if set fails, we may need to retry upstream as well.
-				// Currently the fallback is to rely on a future response, but
-				// that probably isn't ideal.
-				// https://github.com/envoyproxy/xds-relay/issues/70
-				//
-				// If we fail to cache the new response, log and return the old one.
-				o.logger.With("err", err).With("key", aggregatedKey).
-					Error(ctx, "Failed to cache the response")
+			if o.upstreamHealth != nil {
+				o.upstreamHealth.recordResponse(aggregatedKey, x, time.Now())
 			}
-
-			// Get downstream watchers and fan out.
-			// We retrieve from cache rather than directly fanning out the
-			// newly received response because the cache does additional
-			// resource serialization.
-			cached, err := o.cache.Fetch(aggregatedKey)
-			if err != nil {
-				o.logger.With("err", err).With("key", aggregatedKey).Error(ctx, "cache fetch failed")
-				// Can't do anything because we don't know who the watchers
-				// are. Drop the response.
+			if o.parentRelay != nil {
+				o.parentRelay.responded(aggregatedKey)
+			}
+			// Responses are recorded as received, so that replaying them
+			// reproduces transformations and overrides as well.
+			if o.recorder != nil {
+				o.recorder.RecordResponse(aggregatedKey, x)
+			}
+			if !o.isVerified(ctx, aggregatedKey, x) || !o.isValid(ctx, aggregatedKey, x) ||
+				o.isOverLimit(ctx, aggregatedKey, x) {
+				continue
+			}
+			x, ok := o.transform(ctx, aggregatedKey, x)
+			if !ok || o.isLintBlocked(ctx, aggregatedKey, x) {
+				continue
+			}
+			var previous *discovery.DiscoveryResponse
+			if cached, err := o.cache.Fetch(aggregatedKey); err == nil && cached != nil {
+				previous = cached.Resp
+			}
+			if o.isRejectedVersion(ctx, aggregatedKey, previous, streamPrevious, x) {
+				continue
+			}
+			// Only the fields that are compared are kept, since the response
+			// is stamped and sent downstream once applied.
+			streamPrevious = &discovery.DiscoveryResponse{VersionInfo: x.GetVersionInfo(), Nonce: x.GetNonce()}
+			if o.dependencyOrdering != nil {
+				o.holdUpstreamResponse(ctx, aggregatedKey, x)
 			} else {
-				if cached == nil || cached.Resp == nil {
-					// If cache is empty, there is nothing to fan out.
-					// Error. Sanity check. Shouldn't ever reach this since we
-					// just set the response, but it's a rare scenario that can
-					// happen if the cache TTL is set very short.
-					o.logger.With("key", aggregatedKey).Error(ctx, "attempted to fan out with no cached response")
-				} else {
-					// Goldenpath.
-					o.logger.With("key", aggregatedKey).With("response", cached.Resp).Debug(ctx, "response fanout initiated")
-					o.fanout(cached.Resp, cached.Requests, aggregatedKey)
-				}
+				o.applyUpstreamResponse(ctx, aggregatedKey, previous, x)
+			}
+			if o.circuitBreakers != nil {
+				o.onUpstreamResponse(ctx, aggregatedKey)
 			}
 		case <-done:
 			// Exit when signaled that the stream has closed.
 			shutdownUpstream()
-			return
+			return nil
 		}
 	}
 }
 
+// applyResponse stamps and caches the response, records the resources it
+// changed relative to the previous response if it was cached, and fans it out
+// to downstream watchers.
+func (o *orchestrator) applyResponse(
+	ctx context.Context,
+	aggregatedKey string,
+	previous *discovery.DiscoveryResponse,
+	resp *discovery.DiscoveryResponse,
+) {
+	resp = o.stampIdentifier(resp)
+	// Cache the response.
+	_, err := o.cache.SetResponse(aggregatedKey, *resp)
+	o.onResponseSet(ctx, aggregatedKey, previous, resp, err)
+}
+
+// onResponseSet records the outcome of caching the response, and fans out the
+// cached response to downstream watchers.
+func (o *orchestrator) onResponseSet(
+	ctx context.Context,
+	aggregatedKey string,
+	previous *discovery.DiscoveryResponse,
+	resp *discovery.DiscoveryResponse,
+	err error,
+) {
+	if err != nil {
+		// TODO if set fails, we may need to retry upstream as well.
+		// Currently the fallback is to rely on a future response, but
+		// that probably isn't ideal.
+		// https://github.com/envoyproxy/xds-relay/issues/70
+		//
+		// If we fail to cache the new response, log and return the old one.
+		o.logger.With("err", err).With("key", aggregatedKey).
+			Error(ctx, "Failed to cache the response")
+	} else {
+		o.keyScope(aggregatedKey).Counter(metricCacheUpdate).Inc(1)
+		o.onKeyServed(aggregatedKey)
+		if o.history != nil {
+			o.history.record(aggregatedKey, resp, time.Now())
+		}
+		// Changes are only recorded and published once cached, so that
+		// subscribers are not told about a version that is not served.
+		o.recordDiff(ctx, aggregatedKey, previous, resp)
+	}
+	if o.alertRules != nil {
+		o.checkResourceDrop(ctx, aggregatedKey, previous, resp)
+	}
+	if o.shadowClient != nil {
+		o.compareShadow(ctx, aggregatedKey)
+	}
+
+	// Get downstream watchers and fan out.
+	// We retrieve from cache rather than directly fanning out the
+	// newly received response because the cache does additional
+	// resource serialization.
+	cached, err := o.cache.Fetch(aggregatedKey)
+	if err != nil {
+		o.logger.With("err", err).With("key", aggregatedKey).Error(ctx, "cache fetch failed")
+		// Can't do anything because we don't know who the watchers
+		// are. Drop the response.
+		return
+	}
+	if cached == nil || cached.Resp == nil {
+		// If cache is empty, there is nothing to fan out.
+		// Error. Sanity check. Shouldn't ever reach this since we
+		// just set the response, but it's a rare scenario that can
+		// happen if the cache TTL is set very short.
+		o.logger.With("key", aggregatedKey).Error(ctx, "attempted to fan out with no cached response")
+		return
+	}
+	// Goldenpath.
+	if o.replicationServer != nil {
+		o.replicationServer.Publish(aggregatedKey, cached.Resp)
+	}
+	if o.statusServer != nil && previous.GetVersionInfo() != cached.Resp.GetVersionInfo() {
+		o.statusServer.PublishVersion(aggregatedKey, cached.Resp.GetVersionInfo())
+	}
+	if err := o.codec.Register(aggregatedKey, cached.Resp); err != nil {
+		// Downstream sends fall back to marshaling the response
+		// individually.
+		o.logger.With("err", err).With("key", aggregatedKey).Warn(ctx, "failed to pre-marshal response")
+	}
+	if o.suppressIdenticalVersionBump(ctx, aggregatedKey, previous, cached.Resp) {
+		return
+	}
+	isStaged := o.rollouts != nil && o.stageRollout(ctx, aggregatedKey, previous, cached.Resp)
+	if _, ok := o.pinnedResponse(aggregatedKey); ok {
+		o.keyScope(aggregatedKey).Counter(metricPinWithheld).Inc(1)
+		o.logger.With("key", aggregatedKey).With("version", cached.Resp.GetVersionInfo()).
+			Info(ctx, "key is pinned, withholding response")
+		if o.isRolledBack(aggregatedKey) && previous.GetVersionInfo() != cached.Resp.GetVersionInfo() {
+			o.keyScope(aggregatedKey).Counter(metricRollbackDiverged).Inc(1)
+			o.logger.With("key", aggregatedKey).With("version", cached.Resp.GetVersionInfo()).
+				Warn(ctx, "upstream diverged from the rolled back version")
+		}
+		return
+	}
+	if o.isChangeRateWithheld(ctx, aggregatedKey, previous.GetVersionInfo(), cached.Resp.GetVersionInfo()) ||
+		o.isMaintenanceWithheld(ctx, aggregatedKey) {
+		return
+	}
+	o.logger.With("key", aggregatedKey).With("response", cached.Resp).Debug(ctx, "response fanout initiated")
+	watchers := cached.Requests
+	if isStaged {
+		watchers = o.rollouts.canaryWatchers(watchers)
+	}
+	o.fanout(cached.Resp, watchers, aggregatedKey)
+}
+
+// publishRequestStatus publishes whether the downstream request acknowledges
+// or rejects the last response sent to its node. Requests without a response
+// nonce are initial requests, which neither acknowledge nor reject.
+func (o *orchestrator) publishRequestStatus(aggregatedKey string, req gcp.Request) {
+	if req.GetResponseNonce() == "" {
+		return
+	}
+	if req.GetErrorDetail() != nil {
+		o.statusServer.PublishNack(aggregatedKey, req.GetNode().GetId(), req.GetVersionInfo(),
+			req.GetErrorDetail().GetMessage())
+		return
+	}
+	o.statusServer.PublishAck(aggregatedKey, req.GetNode().GetId(), req.GetVersionInfo())
+}
+
 // fanout pushes the response to the response channels of all open downstream
-// watchers in parallel.
-func (o *orchestrator) fanout(resp *discovery.DiscoveryResponse, watchers map[*gcp.Request]bool, aggregatedKey string) {
+// watchers in parallel. Watchers that already hold the response are skipped.
+// If fanout scheduling is configured, the sends are run by the scheduler.
+func (o *orchestrator) fanout(
+	resp *discovery.DiscoveryResponse,
+	watchers map[cache.WatchID]*gcp.Request,
+	aggregatedKey string,
+) {
+	sent := newSentResponse(resp)
+	resources := o.differentialFanout.resources(resp)
+	var ids []cache.WatchID
+	var connections []connectionID
+	var sends []func()
+	for id, watch := range watchers {
+		if o.sentResponseMap.isDuplicate(aggregatedKey, watch, sent) {
+			o.keyScope(aggregatedKey).Counter(metricSuppressedDuplicate).Inc(1)
+			continue
+		}
+		subset := watchSubset(resources, watch)
+		if subset != nil && o.sentResponseMap.holdsSubset(aggregatedKey, watch, *subset) {
+			o.keyScope(aggregatedKey).Counter(metricSuppressedUnchanged).Inc(1)
+			continue
+		}
+		id, watch := id, watch
+		ids = append(ids, id)
+		connections = append(connections, o.downstreamResponseMap.connection(id))
+		sends = append(sends, func() { o.send(aggregatedKey, id, watch, resp, sent, subset) })
+	}
+	if o.fanoutPacing != nil {
+		if o.fanoutPacing.paces(len(sends)) {
+			o.keyScope(aggregatedKey).Counter(metricFanoutPaced).Inc(int64(len(sends)))
+			o.fanoutPacing.schedule(ids, sends)
+			return
+		}
+		o.fanoutPacing.cancel(ids)
+	}
+	if o.fanoutScheduler != nil {
+		o.fanoutScheduler.submit(aggregatedKey, resp.GetTypeUrl(), sends)
+		return
+	}
 	var wg sync.WaitGroup
-	for watch := range watchers {
-		wg.Add(1)
-		go func(watch *gcp.Request) {
+	wg.Add(len(sends))
+	for i, send := range sends {
+		send := send
+		o.connectionSenders.enqueue(connections[i], func() {
 			defer wg.Done()
-			if channel, ok := o.downstreamResponseMap.get(watch); ok {
-				select {
-				case channel <- convertToGcpResponse(resp, *watch):
-					o.logger.With("key", aggregatedKey).With("node ID", watch.GetNode().GetId()).
-						Debug(context.Background(), "response sent")
-				default:
-					// If the channel is blocked, we simply drop subsequent requests and error.
-					// Alternative possibilities are discussed here:
-					// https://github.com/envoyproxy/xds-relay/pull/53#discussion_r420325553
-					o.logger.With("key", aggregatedKey).With("node ID", watch.GetNode().GetId()).
-						Error(context.Background(), "channel blocked during fanout")
-				}
-			}
-		}(watch)
+			send()
+		})
 	}
 	// Wait for all fanouts to complete.
 	wg.Wait()
 }
 
+// send pushes the response to the response channel of the watch, if it is
+// still open.
+func (o *orchestrator) send(
+	aggregatedKey string,
+	id cache.WatchID,
+	watch *gcp.Request,
+	resp *discovery.DiscoveryResponse,
+	sent sentResponse,
+	subset *subsetFingerprint,
+) {
+	ok, found := o.downstreamResponseMap.send(id, convertToGcpResponse(resp, *watch))
+	ctx := context.Background()
+	if requestID := o.requestIDs.get(o.downstreamResponseMap.streamID(id)); requestID != "" {
+		ctx = log.WithFields(ctx, log.FieldRequestID, requestID)
+	}
+	switch {
+	case ok:
+		o.sentResponseMap.record(aggregatedKey, watch, sent, subset)
+		if o.auditLog != nil {
+			o.auditLog.RecordServed(aggregatedKey, watch.GetNode().GetId(), resp)
+		}
+		o.logger.With("key", aggregatedKey).With("node ID", watch.GetNode().GetId()).
+			With("version", resp.GetVersionInfo()).Debug(ctx, "response sent")
+	case found:
+		// If the channel is blocked, we simply drop subsequent requests and error.
+		// Alternative possibilities are discussed here:
+		// https://github.com/envoyproxy/xds-relay/pull/53#discussion_r420325553
+		o.logger.With("key", aggregatedKey).With("node ID", watch.GetNode().GetId()).
+			Error(ctx, "channel blocked during fanout")
+	}
+}
+
 // onCacheEvicted is called when the cache evicts a response due to TTL or
 // other reasons. When this happens, we need to clean up open streams.
-// We shut down both the downstream watchers and the upstream stream.
+// We shut down the upstream stream, and either terminate or resubscribe the
+// downstream watchers according to the eviction policy. The key is DRAINING
+// until it is cleaned up.
 func (o *orchestrator) onCacheEvicted(key string, resource cache.Resource) {
+	o.keyScope(key).Counter(metricCacheEvict).Inc(1)
+	o.drainKey(key, func() { o.cleanUpEvicted(key, resource) })
+}
+
+// cleanUpEvicted forgets the state of the evicted aggregated key, and either
+// terminates its watches or resubscribes them, according to the eviction
+// policy.
+func (o *orchestrator) cleanUpEvicted(key string, resource cache.Resource) {
+	// The lock of the key is held, so its upstream stream and representative
+	// request are deleted directly.
+	representative, hasRepresentative := o.representativeRequests.Load(key)
+	o.upstreamResponseMap.delete(key)
+	o.codec.Unregister(key)
+	o.lastDiffs.Delete(key)
+	o.representativeRequests.Delete(key)
+	o.sentResponseMap.delete(key)
+	o.shadowResponses.Delete(key)
+	o.shadowDiffs.Delete(key)
+	o.overriddenResponses.Delete(key)
+	o.pins.Delete(key)
+	o.rollbacks.Delete(key)
+	if o.circuitBreakers != nil {
+		o.circuitBreakers.remove(key)
+	}
+	if o.negativeCache != nil {
+		o.negativeCache.remove(key)
+	}
+	if o.upstreamHealth != nil {
+		o.upstreamHealth.remove(key)
+	}
+	if o.hashRing != nil {
+		o.hashRing.remove(key)
+	}
+	if o.parentRelay != nil {
+		o.parentRelay.remove(key)
+	}
+	if o.dependencyOrdering != nil {
+		o.dependencyOrdering.remove(key)
+	}
+	if o.history != nil {
+		o.history.remove(key)
+	}
+	if o.maintenance != nil {
+		o.maintenance.remove(key)
+	}
+	if o.keyMetrics != nil {
+		o.keyMetrics.remove(key)
+	}
+	if o.changeRateGuard != nil {
+		o.changeRateGuard.remove(key)
+	}
+	if o.replicationServer != nil {
+		o.replicationServer.Evict(key)
+	}
+	if o.auditLog != nil {
+		o.auditLog.Forget(key)
+	}
+	if o.rollouts != nil {
+		o.rollouts.remove(key, nil)
+	}
+
+	if o.evictionPolicy == bootstrapv1.Cache_RESUBSCRIBE && hasRepresentative && len(resource.Requests) > 0 {
+		// The cache calls onCacheEvicted while holding the lock of the key,
+		// so the watchers are added back once it is released.
+		o.goroutines.goroutine(subsystemEviction, func() {
+			o.resubscribeEvicted(key, representative.(gcp.Request), resource.Requests)
+		})
+		return
+	}
 	// TODO Potential for improvements here to handle the thundering herd
 	// problem: https://github.com/envoyproxy/xds-relay/issues/71
-	o.downstreamResponseMap.deleteAll(resource.Requests)
-	o.upstreamResponseMap.delete(key)
+	terminated := o.terminateWatches(resource.Requests, &KeyError{Key: key, Err: ErrKeyEvicted})
+	o.keyScope(key).Counter(metricEvictTerminate).Inc(int64(terminated))
+}
+
+// recordDiff computes the resources changed by the response, stores the
+// summary as the latest diff for the aggregated key, and publishes it to the
+// notifier if one is configured.
+func (o *orchestrator) recordDiff(
+	ctx context.Context,
+	aggregatedKey string,
+	previous *discovery.DiscoveryResponse,
+	resp *discovery.DiscoveryResponse,
+) {
+	summary := diff.Compute(previous, resp)
+	o.lastDiffs.Store(aggregatedKey, summary)
+	o.logger.With("key", aggregatedKey).
+		With("previous version", summary.PreviousVersion).With("version", summary.Version).
+		With("added", summary.Added).With("removed", summary.Removed).With("modified", summary.Modified).
+		Debug(ctx, "resources changed")
+	if o.notifier != nil {
+		o.notifier.Notify(notifier.NewEvent(aggregatedKey, summary))
+	}
 }
 
 // onCancelWatch cleans up the cached watch when called.
-func (o *orchestrator) onCancelWatch(aggregatedKey string, req *gcp.Request) func() {
+func (o *orchestrator) onCancelWatch(aggregatedKey string, id cache.WatchID) func() {
 	return func() {
-		o.downstreamResponseMap.delete(req)
-		if err := o.cache.DeleteRequest(aggregatedKey, req); err != nil {
+		o.downstreamResponseMap.delete(id)
+		if err := o.cache.DeleteRequest(aggregatedKey, id); err != nil {
 			o.logger.With("key", aggregatedKey).With("err", err).Warn(context.Background(), "Failed to delete from cache")
+			return
 		}
+		o.shrinkSubscription(aggregatedKey)
 	}
 }
 
 // shutdown closes all upstream connections when ctx.Done is called.
 func (o *orchestrator) shutdown(ctx context.Context) {
 	<-ctx.Done()
-	o.upstreamResponseMap.deleteAll()
+	o.upstreamMu.Lock()
+	defer o.upstreamMu.Unlock()
+	o.deleteAllUpstreams()
+	if o.fanoutScheduler != nil {
+		o.fanoutScheduler.stop()
+	}
+	if o.fanoutPacing != nil {
+		o.fanoutPacing.stop()
+	}
 }
 
 // convertToGcpResponse constructs the go-control-plane response from the
-// cached response.
+// cached response. go-control-plane and OnStreamResponse set a per-stream
+// nonce on the response before sending, so each watch receives its own
+// shallow copy that shares the cached resources rather than the cached
+// response itself. The copy must be made for every watch, since the streams
+// of a fanout set their nonces concurrently.
 func convertToGcpResponse(resp *discovery.DiscoveryResponse, req gcp.Request) gcp.PassthroughResponse {
 	return gcp.PassthroughResponse{
-		Request:           req,
-		DiscoveryResponse: resp,
+		Request: req,
+		DiscoveryResponse: &discovery.DiscoveryResponse{
+			VersionInfo:  resp.GetVersionInfo(),
+			Resources:    resp.GetResources(),
+			Canary:       resp.GetCanary(),
+			TypeUrl:      resp.GetTypeUrl(),
+			ControlPlane: resp.GetControlPlane(),
+		},
 	}
 }
@@ -1,12 +1,14 @@
 package orchestrator
 
 import (
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/uber-go/tally"
 
 	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"github.com/envoyproxy/xds-relay/internal/app/codec"
 	"github.com/envoyproxy/xds-relay/internal/app/mapper"
 	"github.com/envoyproxy/xds-relay/internal/app/upstream"
 	"github.com/envoyproxy/xds-relay/internal/pkg/log"
@@ -25,12 +27,28 @@ func NewMockOrchestrator(t *testing.T,
 	upstreamClient upstream.Client,
 	scope tally.Scope) Orchestrator {
 	orchestrator := &orchestrator{
-		logger:                log.New("info"),
-		mapper:                mapper,
-		upstreamClient:        upstreamClient,
-		downstreamResponseMap: newDownstreamResponseMap(scope),
-		upstreamResponseMap:   newUpstreamResponseMap(),
+		logger:                 log.New("info"),
+		scope:                  scope,
+		mapper:                 mapper,
+		upstreamClient:         upstreamClient,
+		codec:                  codec.New(scope.SubScope("codec")),
+		downstreamResponseMap:  newDownstreamResponseMap(scope),
+		upstreamResponseMap:    newUpstreamResponseMap(),
+		sentResponseMap:        newSentResponseMap(),
+		nonces:                 newNonceMap(),
+		requestIDs:             newRequestIDMap(),
+		lastDiffs:              &sync.Map{},
+		representativeRequests: &sync.Map{},
+		keyStates:              newKeyStates(),
+		shadowResponses:        &sync.Map{},
+		shadowDiffs:            &sync.Map{},
+		pins:                   &sync.Map{},
+		rollbacks:              &sync.Map{},
+		overriddenResponses:    &sync.Map{},
+		supervisor:             newSupervisor(nil),
+		goroutines:             newGoroutineTracker(scope.SubScope(metricSubscopeGoroutines)),
 	}
+	orchestrator.connectionSenders = newConnectionSenders(orchestrator.goroutines)
 
 	cache, err := cache.NewCache(1000, orchestrator.onCacheEvicted, 10*time.Second)
 	assert.NoError(t, err)
@@ -2,23 +2,46 @@ package orchestrator
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/audit"
 	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"github.com/envoyproxy/xds-relay/internal/app/codec"
+	"github.com/envoyproxy/xds-relay/internal/app/election"
 	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/notifier"
+	"github.com/envoyproxy/xds-relay/internal/app/recording"
+	"github.com/envoyproxy/xds-relay/internal/app/signature"
+	"github.com/envoyproxy/xds-relay/internal/app/static"
+	"github.com/envoyproxy/xds-relay/internal/app/status"
+	"github.com/envoyproxy/xds-relay/internal/app/transform"
 	"github.com/envoyproxy/xds-relay/internal/app/upstream"
 	"github.com/envoyproxy/xds-relay/internal/pkg/log"
 	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
 	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
 	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	statusv1 "github.com/envoyproxy/xds-relay/pkg/api/status/v1"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/stretchr/testify/assert"
 	"github.com/uber-go/tally"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
 )
 
 type mockSimpleUpstreamClient struct {
@@ -56,13 +79,28 @@ func (m mockMultiStreamUpstreamClient) OpenStream(
 func newMockOrchestrator(t *testing.T, mockScope tally.Scope, mapper mapper.Mapper,
 	upstreamClient upstream.Client) *orchestrator {
 	orchestrator := &orchestrator{
-		logger:                log.New("info"),
-		scope:                 mockScope,
-		mapper:                mapper,
-		upstreamClient:        upstreamClient,
-		downstreamResponseMap: newDownstreamResponseMap(mockScope.SubScope("downstream")),
-		upstreamResponseMap:   newUpstreamResponseMap(),
+		logger:                 log.New("info"),
+		scope:                  mockScope,
+		mapper:                 mapper,
+		upstreamClient:         upstreamClient,
+		codec:                  codec.New(mockScope.SubScope("codec")),
+		downstreamResponseMap:  newDownstreamResponseMap(mockScope.SubScope("downstream")),
+		upstreamResponseMap:    newUpstreamResponseMap(),
+		sentResponseMap:        newSentResponseMap(),
+		nonces:                 newNonceMap(),
+		requestIDs:             newRequestIDMap(),
+		lastDiffs:              &sync.Map{},
+		representativeRequests: &sync.Map{},
+		keyStates:              newKeyStates(),
+		shadowResponses:        &sync.Map{},
+		shadowDiffs:            &sync.Map{},
+		pins:                   &sync.Map{},
+		rollbacks:              &sync.Map{},
+		overriddenResponses:    &sync.Map{},
+		supervisor:             newSupervisor(nil),
+		goroutines:             newGoroutineTracker(mockScope.SubScope(metricSubscopeGoroutines)),
 	}
+	orchestrator.connectionSenders = newConnectionSenders(orchestrator.goroutines)
 
 	cache, err := cache.NewCache(1000, orchestrator.onCacheEvicted, 10*time.Second)
 	assert.NoError(t, err)
@@ -109,7 +147,7 @@ func TestNew(t *testing.T) {
 	}
 
 	orchestrator := New(context.Background(), log.New("info"), tally.NewTestScope("prefix",
-		make(map[string]string)), requestMapper, upstreamClient, &cacheConfig)
+		make(map[string]string)), requestMapper, upstreamClient, codec.New(tally.NoopScope), &cacheConfig)
 	assert.NotNil(t, orchestrator)
 }
 
@@ -136,7 +174,7 @@ func TestGoldenPath(t *testing.T) {
 	counters := snap.Counters()
 	testutils.AssertCounterValue(t, counters, "mock_orchestrator.downstream.create_channel", 1)
 	assert.NotNil(t, respChannel)
-	assert.Equal(t, 1, len(orchestrator.downstreamResponseMap.responseChannels))
+	assert.Equal(t, 1, len(orchestrator.downstreamResponseMap.watches))
 	testutils.AssertSyncMapLen(t, 1, orchestrator.upstreamResponseMap.internal)
 	orchestrator.upstreamResponseMap.internal.Range(func(key, val interface{}) bool {
 		assert.Equal(t, "lds", key.(string))
@@ -163,7 +201,7 @@ func TestGoldenPath(t *testing.T) {
 	testutils.AssertSyncMapLen(t, 0, orchestrator.upstreamResponseMap.internal)
 
 	cancelWatch()
-	assert.Equal(t, 0, len(orchestrator.downstreamResponseMap.responseChannels))
+	assert.Equal(t, 0, len(orchestrator.downstreamResponseMap.watches))
 }
 
 func TestCachedResponse(t *testing.T) {
@@ -204,7 +242,7 @@ func TestCachedResponse(t *testing.T) {
 
 	respChannel, cancelWatch := orchestrator.CreateWatch(req)
 	assert.NotNil(t, respChannel)
-	assert.Equal(t, 1, len(orchestrator.downstreamResponseMap.responseChannels))
+	assert.Equal(t, 1, len(orchestrator.downstreamResponseMap.watches))
 	testutils.AssertSyncMapLen(t, 1, orchestrator.upstreamResponseMap.internal)
 	orchestrator.upstreamResponseMap.internal.Range(func(key, val interface{}) bool {
 		assert.Equal(t, "lds", key.(string))
@@ -243,7 +281,7 @@ func TestCachedResponse(t *testing.T) {
 
 	respChannel2, cancelWatch2 := orchestrator.CreateWatch(req2)
 	assert.NotNil(t, respChannel2)
-	assert.Equal(t, 2, len(orchestrator.downstreamResponseMap.responseChannels))
+	assert.Equal(t, 2, len(orchestrator.downstreamResponseMap.watches))
 	testutils.AssertSyncMapLen(t, 1, orchestrator.upstreamResponseMap.internal)
 	orchestrator.upstreamResponseMap.internal.Range(func(key, val interface{}) bool {
 		assert.Contains(t, "lds", key.(string))
@@ -258,9 +296,9 @@ func TestCachedResponse(t *testing.T) {
 	testutils.AssertSyncMapLen(t, 0, orchestrator.upstreamResponseMap.internal)
 
 	cancelWatch()
-	assert.Equal(t, 1, len(orchestrator.downstreamResponseMap.responseChannels))
+	assert.Equal(t, 1, len(orchestrator.downstreamResponseMap.watches))
 	cancelWatch2()
-	assert.Equal(t, 0, len(orchestrator.downstreamResponseMap.responseChannels))
+	assert.Equal(t, 0, len(orchestrator.downstreamResponseMap.watches))
 }
 
 func TestMultipleWatchersAndUpstreams(t *testing.T) {
@@ -333,7 +371,7 @@ func TestMultipleWatchersAndUpstreams(t *testing.T) {
 	gotResponseFromChannel2 := <-respChannel2
 	gotResponseFromChannel3 := <-respChannel3
 
-	assert.Equal(t, 3, len(orchestrator.downstreamResponseMap.responseChannels))
+	assert.Equal(t, 3, len(orchestrator.downstreamResponseMap.watches))
 	testutils.AssertSyncMapLen(t, 2, orchestrator.upstreamResponseMap.internal)
 	orchestrator.upstreamResponseMap.internal.Range(func(key, val interface{}) bool {
 		assert.Contains(t, []string{"lds", "cds"}, key.(string))
@@ -352,5 +390,907 @@ func TestMultipleWatchersAndUpstreams(t *testing.T) {
 	cancelWatch1()
 	cancelWatch2()
 	cancelWatch3()
-	assert.Equal(t, 0, len(orchestrator.downstreamResponseMap.responseChannels))
+	assert.Equal(t, 0, len(orchestrator.downstreamResponseMap.watches))
+}
+
+func TestWatchIDs(t *testing.T) {
+	upstreamClient := newMockSubscriptionUpstreamClient()
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), typeURLMapper{}, upstreamClient)
+
+	// Watches with equal requests are tracked separately.
+	req := gcp.Request{TypeUrl: upstream.ListenerTypeURL, Node: &v2_core.Node{Id: "node"}}
+	_, cancelFirst := orchestrator.CreateWatch(req)
+	respChannel, cancelSecond := orchestrator.CreateWatch(req)
+	defer cancelSecond()
+	<-upstreamClient.requests
+	watches := orchestrator.GetWatches()
+	assert.Equal(t, 2, len(watches))
+	assert.Equal(t, cache.WatchID(1), watches[0].ID)
+	assert.Equal(t, cache.WatchID(2), watches[1].ID)
+	assert.Equal(t, upstream.ListenerTypeURL, watches[1].Key)
+	assert.Equal(t, "node", watches[1].NodeID)
+	assert.True(t, watches[1].SentTime.IsZero())
+
+	cancelFirst()
+	watches = orchestrator.GetWatches()
+	assert.Equal(t, 1, len(watches))
+	assert.Equal(t, cache.WatchID(2), watches[0].ID)
+	cached, err := orchestrator.cache.Fetch(upstream.ListenerTypeURL)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(cached.Requests))
+	assert.Equal(t, "node", cached.Requests[2].GetNode().GetId())
+
+	orchestrator.fanout(&v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ListenerTypeURL},
+		cached.Requests, upstream.ListenerTypeURL)
+	<-respChannel
+	assert.False(t, orchestrator.GetWatches()[0].SentTime.IsZero())
+}
+
+func TestVersionRegression(t *testing.T) {
+	for _, reject := range []bool{true, false} {
+		upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+		mapper := mapper.NewMock(t)
+		mockScope := newMockScope("prefix")
+		orchestrator := newMockOrchestrator(
+			t,
+			mockScope,
+			mapper,
+			mockSimpleUpstreamClient{
+				responseChan: upstreamResponseChannel,
+			},
+		)
+		WithVersionGuard(&bootstrapv1.VersionGuard{
+			Comparator:        bootstrapv1.VersionGuard_NUMERIC,
+			RejectRegressions: reject,
+		})(orchestrator)
+
+		req := gcp.Request{
+			TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+		}
+		respChannel, cancelWatch := orchestrator.CreateWatch(req)
+
+		newResponse := func(version string) v2.DiscoveryResponse {
+			return v2.DiscoveryResponse{
+				VersionInfo: version,
+				TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+				Resources: []*any.Any{
+					{
+						Value: []byte("lds resource " + version),
+					},
+				},
+			}
+		}
+
+		resp2 := newResponse("2")
+		upstreamResponseChannel <- &resp2
+		assertEqualResponse(t, <-respChannel, resp2, req)
+
+		resp1 := newResponse("1")
+		upstreamResponseChannel <- &resp1
+		resp3 := newResponse("3")
+		if !reject {
+			assertEqualResponse(t, <-respChannel, resp1, req)
+		}
+		upstreamResponseChannel <- &resp3
+		assertEqualResponse(t, <-respChannel, resp3, req)
+
+		testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.version_regression", 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		orchestrator.shutdown(ctx)
+		cancelWatch()
+	}
+}
+
+func TestVersionRegressionStreamReopened(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithVersionGuard(&bootstrapv1.VersionGuard{
+		Comparator:        bootstrapv1.VersionGuard_OPAQUE_WITH_NONCE,
+		RejectRegressions: true,
+	})(orchestrator)
+	newResponse := func(version string, nonce string) *v2.DiscoveryResponse {
+		resp := newRolloutResponse(version)
+		resp.Nonce = nonce
+		return resp
+	}
+	// watchStream receives the responses on a new upstream stream, which is
+	// closed once they are received.
+	watchStream := func(responses ...*v2.DiscoveryResponse) {
+		responseChannel := make(chan *v2.DiscoveryResponse, len(responses))
+		for _, resp := range responses {
+			responseChannel <- resp
+		}
+		close(responseChannel)
+		err := orchestrator.watchUpstream(context.Background(), "lds", responseChannel, make(chan bool), func() {})
+		assert.Error(t, err)
+	}
+	cachedVersion := func() string {
+		cached, err := orchestrator.cache.Fetch("lds")
+		assert.NoError(t, err)
+		return cached.Resp.GetVersionInfo()
+	}
+
+	watchStream(newResponse("a", "1"), newResponse("b", "2"), newResponse("c", "1"))
+	assert.Equal(t, "b", cachedVersion())
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.version_regression", 1)
+
+	// The nonces of the reopened stream restart, and are not compared with
+	// those of the previous stream.
+	watchStream(newResponse("d", "1"))
+	assert.Equal(t, "d", cachedVersion())
+	watchStream(newResponse("e", "1"), newResponse("f", "2"))
+	assert.Equal(t, "f", cachedVersion())
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.version_regression", 1)
+
+	// Replicated responses are applied if their version differs, whatever
+	// their nonce.
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", newResponse("g", "0")))
+	assert.False(t, orchestrator.ApplyReplicatedResponse("lds", newResponse("g", "9")))
+	assert.Equal(t, "g", cachedVersion())
+}
+
+func TestNotifier(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mapper := mapper.NewMock(t)
+	orchestrator := newMockOrchestrator(
+		t,
+		newMockScope("prefix"),
+		mapper,
+		mockSimpleUpstreamClient{
+			responseChan: upstreamResponseChannel,
+		},
+	)
+	events := notifier.NewChannel(2)
+	WithNotifier(events)(orchestrator)
+
+	req := gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+
+	for _, version := range []string{"1", "2"} {
+		resp := v2.DiscoveryResponse{
+			VersionInfo: version,
+			TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+			Resources: []*any.Any{
+				{
+					Value: []byte("lds resource " + version),
+				},
+			},
+		}
+		upstreamResponseChannel <- &resp
+		assertEqualResponse(t, <-respChannel, resp, req)
+	}
+
+	event := <-events.Events()
+	assert.Equal(t, "lds", event.AggregatedKey)
+	assert.Equal(t, "", event.PreviousVersion)
+	assert.Equal(t, "1", event.Version)
+	assert.Equal(t, []string{"resources[0]"}, event.Added)
+
+	event = <-events.Events()
+	assert.Equal(t, "1", event.PreviousVersion)
+	assert.Equal(t, "2", event.Version)
+	assert.Equal(t, []string{"resources[0]"}, event.Modified)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	orchestrator.shutdown(ctx)
+	cancelWatch()
+}
+
+// failingSetCache is a cache that fails to set responses.
+type failingSetCache struct {
+	cache.Cache
+}
+
+func (failingSetCache) SetResponse(string, v2.DiscoveryResponse) (map[cache.WatchID]*v2.DiscoveryRequest, error) {
+	return nil, cache.ErrCacheCastFailure
+}
+
+func TestNotifierCacheFailure(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	orchestrator.cache = failingSetCache{orchestrator.cache}
+	events := notifier.NewChannel(1)
+	WithNotifier(events)(orchestrator)
+
+	orchestrator.applyResponse(context.Background(), "lds", nil, newRolloutResponse("1"))
+	assert.Equal(t, 0, len(events.Events()))
+	_, ok := orchestrator.GetLastDiff("lds")
+	assert.False(t, ok)
+}
+
+type mockElectionLock struct {
+	mu       sync.Mutex
+	acquired bool
+}
+
+func (m *mockElectionLock) TryAcquireOrRenew(context.Context, string, time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.acquired, nil
+}
+
+func (m *mockElectionLock) Release(context.Context, string) error {
+	return nil
+}
+
+func (m *mockElectionLock) set(acquired bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.acquired = acquired
+}
+
+func TestLeaderElection(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mapper := mapper.NewMock(t)
+	orchestrator := newMockOrchestrator(
+		t,
+		newMockScope("prefix"),
+		mapper,
+		mockSimpleUpstreamClient{
+			responseChan: upstreamResponseChannel,
+		},
+	)
+	lock := &mockElectionLock{}
+	elector := election.New(&bootstrapv1.LeaderElection{
+		RetryPeriod: &duration.Duration{Nanos: int32(time.Millisecond)},
+	}, lock, log.New("info"), tally.NoopScope)
+	WithLeaderElection(elector)(orchestrator)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go elector.Run(ctx)
+
+	req := gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+
+	// Followers do not open upstream streams.
+	assert.False(t, orchestrator.upstreamResponseMap.exists("lds"))
+
+	// The stream is opened once this replica becomes the leader.
+	lock.set(true)
+	assert.Eventually(t, func() bool {
+		return orchestrator.upstreamResponseMap.exists("lds")
+	}, time.Second, time.Millisecond)
+	resp := v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+		Resources: []*any.Any{
+			{
+				Value: []byte("lds resource"),
+			},
+		},
+	}
+	upstreamResponseChannel <- &resp
+	assertEqualResponse(t, <-respChannel, resp, req)
+
+	// The stream is closed when leadership is lost, but the cached response is kept.
+	lock.set(false)
+	assert.Eventually(t, func() bool {
+		return !orchestrator.upstreamResponseMap.exists("lds")
+	}, time.Second, time.Millisecond)
+	cached, err := orchestrator.cache.Fetch("lds")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", cached.Resp.GetVersionInfo())
+}
+
+func TestApplyReplicatedResponse(t *testing.T) {
+	newResponse := func(version string) *v2.DiscoveryResponse {
+		return &v2.DiscoveryResponse{
+			VersionInfo: version,
+			TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+			Resources: []*any.Any{
+				{
+					Value: []byte("lds resource " + version),
+				},
+			},
+		}
+	}
+
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	req := gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+
+	resp1, resp2, resp3 := newResponse("1"), newResponse("2"), newResponse("3")
+
+	// Replicated responses are fanned out to downstream watchers.
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp2))
+	assertEqualResponse(t, <-respChannel, *resp2, req)
+	_, ok := orchestrator.GetLastDiff("lds")
+	assert.True(t, ok)
+
+	// Without a version guard, only responses matching the cached version are discarded.
+	assert.False(t, orchestrator.ApplyReplicatedResponse("lds", newResponse("2")))
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp1))
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+
+	// With a version guard, older responses are discarded.
+	WithVersionGuard(&bootstrapv1.VersionGuard{Comparator: bootstrapv1.VersionGuard_NUMERIC})(orchestrator)
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp3))
+	assertEqualResponse(t, <-respChannel, *resp3, req)
+	assert.False(t, orchestrator.ApplyReplicatedResponse("lds", newResponse("2")))
+	assert.False(t, orchestrator.ApplyReplicatedResponse("lds", newResponse("3")))
+	// Responses whose versions cannot be compared are discarded too.
+	assert.False(t, orchestrator.ApplyReplicatedResponse("lds", newResponse("abc")))
+	cached, err := orchestrator.cache.Fetch("lds")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", cached.Resp.GetVersionInfo())
+}
+
+func TestStatusServer(t *testing.T) {
+	newResponse := func(version string) *v2.DiscoveryResponse {
+		return &v2.DiscoveryResponse{
+			VersionInfo: version,
+			TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+			Resources: []*any.Any{
+				{
+					Value: []byte("lds resource " + version),
+				},
+			},
+		}
+	}
+
+	statusServer := status.NewServer(log.New("info"), newMockScope("status"))
+	events, unsubscribe := statusServer.Subscribe(nil)
+	defer unsubscribe()
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithStatusServer(statusServer)(orchestrator)
+
+	// Initial requests neither acknowledge nor reject.
+	req := gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+		Node:    &v2_core.Node{Id: "node"},
+	}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", newResponse("1")))
+	<-respChannel
+	cancelWatch()
+	event := <-events
+	assert.Equal(t, statusv1.KeyStatusEvent_VERSION_CHANGED, event.GetType())
+	assert.Equal(t, "lds", event.GetKey())
+	assert.Equal(t, "1", event.GetVersion())
+
+	req.VersionInfo, req.ResponseNonce = "1", "nonce"
+	_, cancelWatch = orchestrator.CreateWatch(req)
+	cancelWatch()
+	event = <-events
+	assert.Equal(t, statusv1.KeyStatusEvent_ACKED, event.GetType())
+	assert.Equal(t, "node", event.GetNodeId())
+	assert.Equal(t, "1", event.GetVersion())
+
+	req.ErrorDetail = grpcstatus.New(codes.InvalidArgument, "invalid listener").Proto()
+	_, cancelWatch = orchestrator.CreateWatch(req)
+	cancelWatch()
+	event = <-events
+	assert.Equal(t, statusv1.KeyStatusEvent_NACKED, event.GetType())
+	assert.Equal(t, "node", event.GetNodeId())
+	assert.Equal(t, "1", event.GetVersion())
+	assert.Equal(t, "invalid listener", event.GetErrorDetail())
+	assert.Equal(t, 0, len(events))
+}
+
+func TestAuditLog(t *testing.T) {
+	directory, err := ioutil.TempDir("", "audit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+	path := filepath.Join(directory, "audit.log")
+	auditLog, err := audit.New(&bootstrapv1.AuditLog{
+		Sink: &bootstrapv1.AuditLog_File{File: &bootstrapv1.AuditLogFile{Path: path}},
+	}, log.New("info"), newMockScope("audit"))
+	assert.NoError(t, err)
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithAuditLog(auditLog)(orchestrator)
+
+	// Responses are audited when fanned out, when served from the cache to a
+	// new watch, and when fetched.
+	req := gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+		Node:    &v2_core.Node{Id: "node_a"},
+	}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+	}))
+	<-respChannel
+	req.Node = &v2_core.Node{Id: "node_b"}
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	<-respChannel
+	req.Node = &v2_core.Node{Id: "node_c"}
+	_, err = orchestrator.Fetch(context.Background(), req)
+	assert.NoError(t, err)
+	assert.NoError(t, auditLog.Close())
+
+	contents, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	for _, node := range []string{"node_a", "node_b", "node_c"} {
+		assert.Contains(t, string(contents), `"key":"lds","node_id":"`+node+`"`)
+	}
+}
+
+func TestSignatureVerification(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	assert.NoError(t, err)
+	directory, err := ioutil.TempDir("", "signature")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+	path := filepath.Join(directory, "public.pem")
+	assert.NoError(t, ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+	verifier, err := signature.NewVerifier(&bootstrapv1.SignatureVerification{PublicKeyPath: path})
+	assert.NoError(t, err)
+
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	WithSignatureVerifier(verifier)(orchestrator)
+	req := gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+
+	// Unsigned and tampered responses are dropped.
+	newResponse := func(version string) *v2.DiscoveryResponse {
+		return &v2.DiscoveryResponse{
+			VersionInfo: version,
+			TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+			Resources: []*any.Any{
+				{
+					Value: []byte("lds resource " + version),
+				},
+			},
+		}
+	}
+	upstreamResponseChannel <- newResponse("1")
+	tampered := newResponse("2")
+	assert.NoError(t, signature.Sign(key, tampered))
+	tampered.Resources[0].Value = []byte("injected")
+	upstreamResponseChannel <- tampered
+	signed := newResponse("3")
+	assert.NoError(t, signature.Sign(key, signed))
+	upstreamResponseChannel <- signed
+
+	assertEqualResponse(t, <-respChannel, *signed, req)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.signature_invalid", 2)
+}
+
+func TestControlPlaneIdentifier(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	WithControlPlaneIdentifier(&bootstrapv1.ControlPlaneIdentifier{Replica: "relay-0"})(orchestrator)
+	req := gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+
+	resp := &v2.DiscoveryResponse{
+		VersionInfo:  "1",
+		TypeUrl:      "type.googleapis.com/envoy.api.v2.Listener",
+		Resources:    []*any.Any{{Value: []byte("lds resource")}},
+		ControlPlane: &v2_core.ControlPlane{Identifier: "origin"},
+	}
+	upstreamResponseChannel <- resp
+	gotResponse, err := (<-respChannel).GetDiscoveryResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, "xds-relay/relay-0/1", gotResponse.GetControlPlane().GetIdentifier())
+	// The upstream response is not modified.
+	assert.Equal(t, "origin", resp.GetControlPlane().GetIdentifier())
+}
+
+func TestFetch(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	orchestrator := newMockOrchestrator(
+		t,
+		newMockScope("prefix"),
+		mapper.NewMock(t),
+		mockSimpleUpstreamClient{
+			responseChan: upstreamResponseChannel,
+		},
+	)
+	req := v2.DiscoveryRequest{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	resp := v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+		Resources: []*any.Any{
+			{
+				Value: []byte("lds resource"),
+			},
+		},
+	}
+
+	// With nothing cached, Fetch opens the upstream stream and waits for the first response.
+	fetched := make(chan gcp.Response)
+	go func() {
+		got, err := orchestrator.Fetch(context.Background(), req)
+		assert.NoError(t, err)
+		fetched <- got
+	}()
+	upstreamResponseChannel <- &resp
+	assertEqualResponse(t, <-fetched, resp, req)
+
+	// Subsequent fetches are served from the cache.
+	got, err := orchestrator.Fetch(context.Background(), req)
+	assert.NoError(t, err)
+	assertEqualResponse(t, got, resp, req)
+
+	req.VersionInfo = "1"
+	_, err = orchestrator.Fetch(context.Background(), req)
+	assert.IsType(t, &types.SkipFetchError{}, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	orchestrator.shutdown(ctx)
+}
+
+func TestFetchCanceled(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := orchestrator.Fetch(ctx, v2.DiscoveryRequest{TypeUrl: "type.googleapis.com/envoy.api.v2.Listener"})
+	assert.Equal(t, codes.Canceled, grpcstatus.Code(err))
+}
+
+func TestUnmatchedRequestFallback(t *testing.T) {
+	newFallbackMapper := func(fallback *aggregationv1.KeyerConfiguration_Fallback) mapper.Mapper {
+		return mapper.New(&aggregationv1.KeyerConfiguration{
+			Fragments: []*aggregationv1.KeyerConfiguration_Fragment{
+				{
+					Rules: []*aggregationv1.KeyerConfiguration_Fragment_Rule{
+						{
+							Match: &aggregationv1.MatchPredicate{
+								Type: &aggregationv1.MatchPredicate_RequestTypeMatch_{
+									RequestTypeMatch: &aggregationv1.MatchPredicate_RequestTypeMatch{
+										Types: []string{upstream.ClusterTypeURL},
+									},
+								},
+							},
+							Result: &aggregationv1.ResultPredicate{
+								Type: &aggregationv1.ResultPredicate_StringFragment{StringFragment: "cds"},
+							},
+						},
+					},
+				},
+			},
+			Fallback: fallback,
+		})
+	}
+	req := gcp.Request{TypeUrl: upstream.ListenerTypeURL, Node: &v2_core.Node{Id: "node"}}
+
+	// Unmatched requests are passed through by default.
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), newFallbackMapper(nil), mockSimpleUpstreamClient{})
+	key, err := orchestrator.getAggregatedKey(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, mapper.UnaggregatedPrefix+"node_"+upstream.ListenerTypeURL, key)
+
+	scope := newMockScope("prefix")
+	orchestrator = newMockOrchestrator(t, scope, newFallbackMapper(&aggregationv1.KeyerConfiguration_Fallback{
+		Behavior: &aggregationv1.KeyerConfiguration_Fallback_Reject{Reject: true},
+	}), mockSimpleUpstreamClient{})
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	assert.Nil(t, cancelWatch)
+	_, ok := <-respChannel
+	assert.False(t, ok)
+	assert.Equal(t, 0, len(orchestrator.GetWatches()))
+	testutils.AssertCounterValue(t, scope.Snapshot().Counters(), "prefix.unmatched_rejected", 1)
+	_, err = orchestrator.Fetch(context.Background(), req)
+	assert.Equal(t, codes.InvalidArgument, grpcstatus.Code(err))
+}
+
+func TestPassthroughRule(t *testing.T) {
+	type rule = aggregationv1.KeyerConfiguration_Fragment_Rule
+	newRule := func(typeURL string, result *aggregationv1.ResultPredicate) *rule {
+		return &rule{
+			Match: &aggregationv1.MatchPredicate{
+				Type: &aggregationv1.MatchPredicate_RequestTypeMatch_{
+					RequestTypeMatch: &aggregationv1.MatchPredicate_RequestTypeMatch{Types: []string{typeURL}},
+				},
+			},
+			Result: result,
+		}
+	}
+	requestMapper := mapper.New(&aggregationv1.KeyerConfiguration{
+		Fragments: []*aggregationv1.KeyerConfiguration_Fragment{
+			{
+				Rules: []*rule{
+					newRule(upstream.ClusterTypeURL, &aggregationv1.ResultPredicate{
+						Type: &aggregationv1.ResultPredicate_StringFragment{StringFragment: "cds"},
+					}),
+					newRule(upstream.EndpointTypeURL, &aggregationv1.ResultPredicate{
+						Type: &aggregationv1.ResultPredicate_Passthrough{Passthrough: true},
+					}),
+				},
+			},
+		},
+	})
+	upstreamClient := newMockSubscriptionUpstreamClient()
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), requestMapper, upstreamClient)
+
+	// Requests of passthrough rules open a dedicated upstream stream per node.
+	for _, node := range []string{"a", "b"} {
+		for _, typeURL := range []string{upstream.EndpointTypeURL, upstream.ClusterTypeURL} {
+			_, cancelWatch := orchestrator.CreateWatch(gcp.Request{TypeUrl: typeURL, Node: &v2_core.Node{Id: node}})
+			defer cancelWatch()
+		}
+	}
+	var upstreamRequests []string
+	for i := 0; i < 3; i++ {
+		req := <-upstreamClient.requests
+		upstreamRequests = append(upstreamRequests, req.Node.Id+" "+req.TypeUrl)
+	}
+	assert.ElementsMatch(t, []string{
+		"a " + upstream.EndpointTypeURL,
+		"a " + upstream.ClusterTypeURL,
+		"b " + upstream.EndpointTypeURL,
+	}, upstreamRequests)
+	assert.Equal(t, 0, len(upstreamClient.requests))
+
+	keys := make(map[string]int)
+	for _, watch := range orchestrator.GetWatches() {
+		keys[watch.Key]++
+	}
+	assert.Equal(t, map[string]int{
+		"cds": 2,
+		mapper.UnaggregatedPrefix + "a_" + upstream.EndpointTypeURL: 1,
+		mapper.UnaggregatedPrefix + "b_" + upstream.EndpointTypeURL: 1,
+	}, keys)
+}
+
+func TestShadowUpstream(t *testing.T) {
+	newResponse := func(version string, value string) *v2.DiscoveryResponse {
+		return &v2.DiscoveryResponse{
+			VersionInfo: version,
+			TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+			Resources: []*any.Any{
+				{
+					Value: []byte(value),
+				},
+			},
+		}
+	}
+
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	shadowResponseChannel := make(chan *v2.DiscoveryResponse)
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	WithShadowUpstream(mockSimpleUpstreamClient{responseChan: shadowResponseChannel})(orchestrator)
+
+	req := gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+
+	resp := newResponse("1", "lds resource")
+	upstreamResponseChannel <- resp
+	assertEqualResponse(t, <-respChannel, *resp, req)
+	_, ok := orchestrator.GetShadowDiff("lds")
+	assert.False(t, ok)
+
+	shadowResponseChannel <- newResponse("1", "lds resource")
+	assert.Eventually(t, func() bool {
+		_, ok := orchestrator.GetShadowDiff("lds")
+		return ok
+	}, time.Second, time.Millisecond)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.shadow_match", 1)
+
+	// Shadow responses are compared but never served downstream.
+	shadowResponseChannel <- newResponse("2", "modified lds resource")
+	assert.Eventually(t, func() bool {
+		summary, _ := orchestrator.GetShadowDiff("lds")
+		return summary.Version == "2"
+	}, time.Second, time.Millisecond)
+	summary, _ := orchestrator.GetShadowDiff("lds")
+	assert.Equal(t, "1", summary.PreviousVersion)
+	assert.Equal(t, []string{"resources[0]"}, summary.Modified)
+	counters := mockScope.Snapshot().Counters()
+	testutils.AssertCounterValue(t, counters, "prefix.shadow_mismatch", 1)
+	testutils.AssertCounterValue(t, counters, "prefix.shadow_version_skew", 1)
+	assert.Equal(t, 0, len(respChannel))
+
+	// Origin server responses are compared with the latest shadow response.
+	resp = newResponse("2", "modified lds resource")
+	upstreamResponseChannel <- resp
+	assertEqualResponse(t, <-respChannel, *resp, req)
+	summary, _ = orchestrator.GetShadowDiff("lds")
+	assert.True(t, summary.IsEmpty())
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.shadow_match", 2)
+}
+
+func TestTransformer(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	WithTransformer(transform.Func(func(resp *v2.DiscoveryResponse) (*v2.DiscoveryResponse, error) {
+		if resp.GetVersionInfo() == "invalid" {
+			return nil, fmt.Errorf("failed")
+		}
+		resp.Resources = resp.Resources[:1]
+		return resp, nil
+	}))(orchestrator)
+
+	req := gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+
+	// Responses that fail to transform are dropped.
+	upstreamResponseChannel <- &v2.DiscoveryResponse{
+		VersionInfo: "invalid",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	resource := &any.Any{Value: []byte("lds resource")}
+	upstreamResponseChannel <- &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+		Resources:   []*any.Any{resource, {Value: []byte("stripped lds resource")}},
+	}
+	assertEqualResponse(t, <-respChannel, v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+		Resources:   []*any.Any{resource},
+	}, req)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.transform_error", 1)
+}
+
+func TestOverrides(t *testing.T) {
+	directory, err := ioutil.TempDir("", "overrides")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+	writeOverrides := func(bufferLimit string) {
+		path := filepath.Join(directory, "overrides.yaml")
+		assert.NoError(t, ioutil.WriteFile(path+".tmp", []byte(`
+overrides:
+- key: lds
+  resource_name: listener_A
+  merge_patch:
+    per_connection_buffer_limit_bytes: `+bufferLimit), 0600))
+		assert.NoError(t, os.Rename(path+".tmp", path))
+	}
+	writeOverrides("1024")
+	overrides, err := transform.NewOverrides(&bootstrapv1.OverrideFiles{
+		Directory:      directory,
+		ReloadInterval: &duration.Duration{Nanos: int32(time.Millisecond)},
+	}, log.New("info"), tally.NoopScope)
+	assert.NoError(t, err)
+
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	WithOverrides(overrides)(orchestrator)
+
+	req := gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+
+	listener, err := ptypes.MarshalAny(&v2.Listener{Name: "listener_A"})
+	assert.NoError(t, err)
+	upstreamResponseChannel <- &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+		Resources:   []*any.Any{listener},
+	}
+	getBufferLimit := func(resp gcp.Response) uint32 {
+		discoveryResponse, err := resp.GetDiscoveryResponse()
+		assert.NoError(t, err)
+		assert.Equal(t, "1", discoveryResponse.GetVersionInfo())
+		var patched v2.Listener
+		assert.NoError(t, ptypes.UnmarshalAny(discoveryResponse.GetResources()[0], &patched))
+		return patched.GetPerConnectionBufferLimitBytes().GetValue()
+	}
+	assert.Equal(t, uint32(1024), getBufferLimit(<-respChannel))
+
+	// Changed overrides are applied to the latest upstream response.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go overrides.Run(ctx)
+	writeOverrides("2048")
+	assert.Equal(t, uint32(2048), getBufferLimit(<-respChannel))
+}
+
+type mockUnusedUpstreamClient struct {
+	t *testing.T
+}
+
+func (m mockUnusedUpstreamClient) OpenStream(req v2.DiscoveryRequest) (<-chan *v2.DiscoveryResponse, func(), error) {
+	m.t.Errorf("Unexpected upstream stream for %s", req.GetTypeUrl())
+	return nil, func() {}, fmt.Errorf("unexpected upstream stream")
+}
+
+func TestStaticResponses(t *testing.T) {
+	directory, err := ioutil.TempDir("", "static")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+	path := filepath.Join(directory, "lds.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`
+version_info: "1"
+type_url: type.googleapis.com/envoy.api.v2.Listener
+resources:
+- "@type": type.googleapis.com/envoy.api.v2.Listener
+  name: listener_A
+`), 0600))
+	staticResponses, err := static.New([]*bootstrapv1.StaticResponse{
+		{Match: &bootstrapv1.StaticResponse_Key{Key: "lds"}, Path: path},
+	})
+	assert.NoError(t, err)
+
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockUnusedUpstreamClient{t: t})
+	WithStaticResponses(staticResponses)(orchestrator)
+
+	req := gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	resp, err := (<-respChannel).GetDiscoveryResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", resp.GetVersionInfo())
+	var listener v2.Listener
+	assert.NoError(t, ptypes.UnmarshalAny(resp.GetResources()[0], &listener))
+	assert.Equal(t, "listener_A", listener.GetName())
+
+	// Watches that are up to date with the static response receive nothing.
+	req.VersionInfo = "1"
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	select {
+	case <-respChannel:
+		assert.Fail(t, "unexpected response")
+	case <-time.After(10 * time.Millisecond):
+	}
+	_, ok := orchestrator.representativeRequests.Load("lds")
+	assert.False(t, ok)
+}
+
+func TestRecorder(t *testing.T) {
+	directory, err := ioutil.TempDir("", "recording")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+	recorder, err := recording.NewRecorder(&bootstrapv1.Recording{Directory: directory, RecordRequests: true},
+		log.New("info"), tally.NoopScope)
+	assert.NoError(t, err)
+
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	WithRecorder(recorder)(orchestrator)
+
+	req := gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	upstreamResponseChannel <- &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	<-respChannel
+	assert.NoError(t, recorder.Close())
+
+	records, err := recording.Load(recording.Path(directory, "lds"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(records))
+	assert.NotNil(t, records[0].Request)
+	assert.NotNil(t, records[1].Response)
 }
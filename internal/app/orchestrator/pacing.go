@@ -0,0 +1,110 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file paces the downstream sends of large fanouts across a duration, so
+// that downstream nodes do not reload their configuration all at once. The
+// contents of this file are intended to only be used within the orchestrator
+// module and should not be exported.
+package orchestrator
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	metricFanoutPaced = "fanout_paced"
+)
+
+// fanoutPacing holds the pending sends of paced fanouts.
+type fanoutPacing struct {
+	duration   time.Duration
+	minWatches int
+
+	mu sync.Mutex
+	// pending is keyed by the watch ID of the pending sends.
+	pending map[cache.WatchID]*time.Timer
+}
+
+func newFanoutPacing(config *bootstrapv1.FanoutPacing) *fanoutPacing {
+	p := &fanoutPacing{
+		minWatches: int(config.GetMinWatches()),
+		pending:    make(map[cache.WatchID]*time.Timer),
+	}
+	if config.GetDuration() != nil {
+		if duration, err := ptypes.Duration(config.GetDuration()); err == nil {
+			p.duration = duration
+		}
+	}
+	return p
+}
+
+// paces returns true if a fanout to as many watches is paced.
+func (p *fanoutPacing) paces(watches int) bool {
+	return watches > 0 && watches >= p.minWatches
+}
+
+// schedule spreads the sends evenly across the duration, in a random order.
+// The pending send of a watch is replaced by the send scheduled for it.
+func (p *fanoutPacing) schedule(ids []cache.WatchID, sends []func()) {
+	order := rand.Perm(len(sends))
+	interval := p.duration / time.Duration(len(sends))
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, j := range order {
+		id, send := ids[j], sends[j]
+		if pending, ok := p.pending[id]; ok {
+			pending.Stop()
+		}
+		var timer *time.Timer
+		timer = time.AfterFunc(time.Duration(i)*interval, func() {
+			p.mu.Lock()
+			if p.pending[id] != timer {
+				p.mu.Unlock()
+				return
+			}
+			delete(p.pending, id)
+			p.mu.Unlock()
+			send()
+		})
+		p.pending[id] = timer
+	}
+}
+
+// cancel cancels the pending sends of the watches.
+func (p *fanoutPacing) cancel(ids []cache.WatchID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, id := range ids {
+		if pending, ok := p.pending[id]; ok {
+			pending.Stop()
+			delete(p.pending, id)
+		}
+	}
+}
+
+// pendingCount returns the number of pending sends.
+func (p *fanoutPacing) pendingCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.pending)
+}
+
+// stop cancels the pending sends.
+func (p *fanoutPacing) stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for id, pending := range p.pending {
+		pending.Stop()
+		delete(p.pending, id)
+	}
+}
@@ -0,0 +1,77 @@
+package orchestrator
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanoutPacingSchedule(t *testing.T) {
+	p := newFanoutPacing(&bootstrapv1.FanoutPacing{Duration: ptypes.DurationProto(100 * time.Millisecond)})
+	assert.False(t, p.paces(0))
+	assert.True(t, p.paces(1))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sent := make(map[string]int)
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			defer mu.Unlock()
+			sent[name]++
+			wg.Done()
+		}
+	}
+	start := time.Now()
+	wg.Add(4)
+	p.schedule([]cache.WatchID{1, 2, 3}, []func(){record("old"), record("b"), record("c")})
+	// The pending send of a watch is replaced by the newer one.
+	p.schedule([]cache.WatchID{1, 4}, []func(){record("a"), record("d")})
+	wg.Wait()
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Equal(t, map[string]int{"a": 1, "b": 1, "c": 1, "d": 1}, sent)
+	assert.Equal(t, 0, p.pendingCount())
+
+	p.schedule([]cache.WatchID{1, 2}, []func(){record("a"), record("b")})
+	p.cancel([]cache.WatchID{1, 2})
+	assert.Equal(t, 0, p.pendingCount())
+}
+
+func TestFanoutPacing(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithFanoutPacing(&bootstrapv1.FanoutPacing{
+		Duration:   ptypes.DurationProto(50 * time.Millisecond),
+		MinWatches: 2,
+	})(orchestrator)
+
+	req := newRolloutRequest("a", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	resp1 := newRolloutResponse("1")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp1))
+	// Fanouts to fewer watches than the minimum are sent at once.
+	assert.Equal(t, 1, len(respChannel))
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+	cancelWatch()
+
+	req.VersionInfo = "1"
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	otherReq := newRolloutRequest("b", false)
+	otherReq.VersionInfo = "1"
+	otherChannel, cancelOther := orchestrator.CreateWatch(otherReq)
+	defer cancelOther()
+	resp2 := newRolloutResponse("2")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp2))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.fanout_paced", 2)
+	assertEqualResponse(t, <-respChannel, *resp2, req)
+	assertEqualResponse(t, <-otherChannel, *resp2, otherReq)
+}
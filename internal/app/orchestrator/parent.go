@@ -0,0 +1,180 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file opens upstream streams with a parent relay, which shields the
+// origin server, and falls back to the origin server when the parent relay
+// fails to serve an aggregated key. The contents of this file are intended to
+// only be used within the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	defaultParentTimeout       = 5 * time.Second
+	defaultParentRetryInterval = time.Minute
+
+	metricParentFetch    = "parent_fetch"
+	metricParentFallback = "parent_fallback"
+)
+
+// parentFetch is an upstream stream opened with the parent relay that was not
+// sent a response yet.
+type parentFetch struct {
+	timer *time.Timer
+}
+
+// parentRelay tracks the aggregated keys whose upstream streams are opened
+// with the parent relay.
+type parentRelay struct {
+	client        upstream.Client
+	timeout       time.Duration
+	retryInterval time.Duration
+
+	mu sync.Mutex
+	// fetches is keyed by aggregated key.
+	fetches map[string]*parentFetch
+	// fallbacks is keyed by aggregated key, and holds the time the key fell
+	// back to the origin server.
+	fallbacks map[string]time.Time
+}
+
+func newParentRelay(config *bootstrapv1.ParentRelay, client upstream.Client) *parentRelay {
+	p := &parentRelay{
+		client:        client,
+		timeout:       defaultParentTimeout,
+		retryInterval: defaultParentRetryInterval,
+		fetches:       make(map[string]*parentFetch),
+		fallbacks:     make(map[string]time.Time),
+	}
+	if config.GetTimeout() != nil {
+		if timeout, err := ptypes.Duration(config.GetTimeout()); err == nil {
+			p.timeout = timeout
+		}
+	}
+	if config.GetRetryInterval() != nil {
+		if interval, err := ptypes.Duration(config.GetRetryInterval()); err == nil {
+			p.retryInterval = interval
+		}
+	}
+	return p
+}
+
+// uses returns true if the upstream stream of the aggregated key is opened
+// with the parent relay as of now, which it is unless the key fell back to the
+// origin server within the retry interval.
+func (p *parentRelay) uses(aggregatedKey string, now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fellBack, ok := p.fallbacks[aggregatedKey]
+	if !ok {
+		return true
+	}
+	if now.Sub(fellBack) < p.retryInterval {
+		return false
+	}
+	delete(p.fallbacks, aggregatedKey)
+	return true
+}
+
+// await starts the timeout of the upstream stream of the aggregated key that
+// was opened with the parent relay. onTimeout is called with the fetch if no
+// response is received within the timeout.
+func (p *parentRelay) await(aggregatedKey string, onTimeout func(fetch *parentFetch)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if previous, ok := p.fetches[aggregatedKey]; ok {
+		previous.timer.Stop()
+	}
+	fetch := &parentFetch{}
+	fetch.timer = time.AfterFunc(p.timeout, func() { onTimeout(fetch) })
+	p.fetches[aggregatedKey] = fetch
+}
+
+// responded stops the timeout of the aggregated key.
+func (p *parentRelay) responded(aggregatedKey string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if fetch, ok := p.fetches[aggregatedKey]; ok {
+		fetch.timer.Stop()
+		delete(p.fetches, aggregatedKey)
+	}
+}
+
+// pending returns true if the fetch is still awaiting the first response of
+// the aggregated key.
+func (p *parentRelay) pending(aggregatedKey string, fetch *parentFetch) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.fetches[aggregatedKey] == fetch
+}
+
+// fallBack records that the aggregated key fell back to the origin server at
+// now.
+func (p *parentRelay) fallBack(aggregatedKey string, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if fetch, ok := p.fetches[aggregatedKey]; ok {
+		fetch.timer.Stop()
+		delete(p.fetches, aggregatedKey)
+	}
+	p.fallbacks[aggregatedKey] = now
+}
+
+// remove forgets the aggregated key.
+func (p *parentRelay) remove(aggregatedKey string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if fetch, ok := p.fetches[aggregatedKey]; ok {
+		fetch.timer.Stop()
+		delete(p.fetches, aggregatedKey)
+	}
+	delete(p.fallbacks, aggregatedKey)
+}
+
+// fetchesFromParent returns true if the upstream stream of the aggregated key
+// is opened with the parent relay.
+func (o *orchestrator) fetchesFromParent(aggregatedKey string) bool {
+	return o.parentRelay != nil && o.parentRelay.uses(aggregatedKey, time.Now())
+}
+
+// fallBackToOrigin makes the aggregated key fall back to the origin server,
+// after its upstream stream with the parent relay failed.
+func (o *orchestrator) fallBackToOrigin(ctx context.Context, aggregatedKey string, reason string) {
+	o.parentRelay.fallBack(aggregatedKey, time.Now())
+	o.keyScope(aggregatedKey).Counter(metricParentFallback).Inc(1)
+	o.logger.With("key", aggregatedKey).With("reason", reason).
+		Warn(ctx, "parent relay failed to serve key, falling back to origin server")
+}
+
+// awaitParent reopens the upstream stream of the aggregated key with the
+// origin server if the parent relay sends no response within the timeout.
+func (o *orchestrator) awaitParent(ctx context.Context, aggregatedKey string) {
+	o.keyScope(aggregatedKey).Counter(metricParentFetch).Inc(1)
+	o.parentRelay.await(aggregatedKey, func(fetch *parentFetch) {
+		representative, ok := o.representativeRequests.Load(aggregatedKey)
+		if !ok {
+			return
+		}
+		o.upstreamMu.Lock()
+		defer o.upstreamMu.Unlock()
+		if !o.isLeader() || !o.parentRelay.pending(aggregatedKey, fetch) {
+			return
+		}
+		o.fallBackToOrigin(ctx, aggregatedKey, "timeout")
+		o.deleteUpstream(aggregatedKey, nil)
+		o.openUpstream(ctx, aggregatedKey, representative.(gcp.Request))
+	})
+}
@@ -0,0 +1,92 @@
+package orchestrator
+
+import (
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParentRelay(t *testing.T) {
+	originClient := mockFlakyUpstreamClient{streams: make(chan chan *v2.DiscoveryResponse, 10)}
+	parentClient := mockFlakyUpstreamClient{streams: make(chan chan *v2.DiscoveryResponse, 10)}
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), originClient)
+	WithParentRelay(&bootstrapv1.ParentRelay{Timeout: ptypes.DurationProto(50 * time.Millisecond)},
+		parentClient)(orchestrator)
+
+	req := newRolloutRequest("node", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	stream := <-parentClient.streams
+	resp1 := newRolloutResponse("1")
+	stream <- resp1
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.parent_fetch", 1)
+
+	// The stream stays with the parent relay once it responded.
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, originClient.streams, 0)
+	_, ok := mockScope.Snapshot().Counters()["prefix.parent_fallback+"]
+	assert.False(t, ok)
+}
+
+func TestParentRelayTimeout(t *testing.T) {
+	originClient := mockFlakyUpstreamClient{streams: make(chan chan *v2.DiscoveryResponse, 10)}
+	parentClient := mockFlakyUpstreamClient{streams: make(chan chan *v2.DiscoveryResponse, 10)}
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), originClient)
+	WithParentRelay(&bootstrapv1.ParentRelay{Timeout: ptypes.DurationProto(50 * time.Millisecond)},
+		parentClient)(orchestrator)
+
+	req := newRolloutRequest("node", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	<-parentClient.streams
+
+	// The parent relay sends no response, so the stream falls back to the
+	// origin server.
+	stream := <-originClient.streams
+	resp1 := newRolloutResponse("1")
+	stream <- resp1
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.parent_fallback", 1)
+
+	// The key stays with the origin server within the retry interval.
+	assert.False(t, orchestrator.fetchesFromParent("lds"))
+	assert.Len(t, parentClient.streams, 0)
+}
+
+func TestParentRelayOpenFailure(t *testing.T) {
+	originClient := mockFlakyUpstreamClient{streams: make(chan chan *v2.DiscoveryResponse, 10)}
+	failing, opens := int32(1), int32(0)
+	parentClient := mockFailingUpstreamClient{failing: &failing, opens: &opens}
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), originClient)
+	WithParentRelay(&bootstrapv1.ParentRelay{}, parentClient)(orchestrator)
+
+	req := newRolloutRequest("node", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	stream := <-originClient.streams
+	resp1 := newRolloutResponse("1")
+	stream <- resp1
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+	assert.Equal(t, int32(1), opens)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.parent_fallback", 1)
+}
+
+func TestParentRelayRetryInterval(t *testing.T) {
+	parentRelay := newParentRelay(&bootstrapv1.ParentRelay{RetryInterval: ptypes.DurationProto(time.Minute)}, nil)
+	now := time.Now()
+	assert.True(t, parentRelay.uses("lds", now))
+	parentRelay.fallBack("lds", now)
+	assert.False(t, parentRelay.uses("lds", now.Add(30*time.Second)))
+	assert.True(t, parentRelay.uses("lds", now.Add(time.Minute)))
+	assert.True(t, parentRelay.uses("lds", now.Add(30*time.Second)))
+}
@@ -0,0 +1,116 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file pins the version served for an aggregated key, so that newer
+// upstream responses are cached but withheld from downstream nodes. The
+// contents of this file are intended to only be used within the orchestrator
+// module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+)
+
+const (
+	metricPinWithheld = "pin_withheld"
+)
+
+// PinVersion serves the response with the version to every node of the
+// aggregated key, until UnpinVersion is called. The version must be held by
+// the relay, either as the cached response, as the stable response of a
+// rollout, or in the history of the aggregated key. If the version is empty,
+// the cached response is pinned.
+func (o *orchestrator) PinVersion(aggregatedKey string, version string) error {
+	cached, err := o.cache.Fetch(aggregatedKey)
+	if err != nil || cached == nil || cached.Resp == nil {
+		return fmt.Errorf("%w for key %s", ErrKeyNotCached, aggregatedKey)
+	}
+	pinned := cached.Resp
+	if version != "" && pinned.GetVersionInfo() != version {
+		held, ok := o.heldResponse(aggregatedKey, version)
+		if !ok {
+			return fmt.Errorf("version %s of key %s is %w", version, aggregatedKey, ErrVersionNotHeld)
+		}
+		pinned = held
+	}
+	o.pins.Store(aggregatedKey, pinned)
+	o.rollbacks.Delete(aggregatedKey)
+	o.logger.With("key", aggregatedKey).With("version", pinned.GetVersionInfo()).
+		Warn(context.Background(), "version pinned")
+	o.fanout(pinned, cached.Requests, aggregatedKey)
+	return nil
+}
+
+// UnpinVersion resumes serving the latest response of the aggregated key. It
+// returns false if the aggregated key is not pinned.
+func (o *orchestrator) UnpinVersion(aggregatedKey string) bool {
+	if _, ok := o.pins.Load(aggregatedKey); !ok {
+		return false
+	}
+	o.pins.Delete(aggregatedKey)
+	o.rollbacks.Delete(aggregatedKey)
+	o.logger.With("key", aggregatedKey).Warn(context.Background(), "version unpinned")
+	cached, err := o.cache.Fetch(aggregatedKey)
+	if err == nil && cached != nil && cached.Resp != nil {
+		o.fanoutServed(aggregatedKey, cached)
+	}
+	return true
+}
+
+// GetPinnedVersion returns the version pinned for the aggregated key.
+func (o *orchestrator) GetPinnedVersion(aggregatedKey string) (string, bool) {
+	pinned, ok := o.pinnedResponse(aggregatedKey)
+	return pinned.GetVersionInfo(), ok
+}
+
+// pinnedResponse returns the response pinned for the aggregated key.
+func (o *orchestrator) pinnedResponse(aggregatedKey string) (*discovery.DiscoveryResponse, bool) {
+	pinned, ok := o.pins.Load(aggregatedKey)
+	if !ok {
+		return nil, false
+	}
+	return pinned.(*discovery.DiscoveryResponse), true
+}
+
+// heldResponse returns the response with the version held for the
+// aggregated key by a rollout or in the history.
+func (o *orchestrator) heldResponse(aggregatedKey string, version string) (*discovery.DiscoveryResponse, bool) {
+	if stable, ok := o.heldStableResponse(aggregatedKey); ok && stable.GetVersionInfo() == version {
+		return stable, true
+	}
+	return o.GetHistoricalResponse(aggregatedKey, version)
+}
+
+// heldStableResponse returns the stable response of the rollout of the
+// aggregated key, if one is in progress.
+func (o *orchestrator) heldStableResponse(aggregatedKey string) (*discovery.DiscoveryResponse, bool) {
+	if o.rollouts == nil {
+		return nil, false
+	}
+	return o.rollouts.stableResponse(aggregatedKey)
+}
+
+// fanoutServed fans out to each watcher of the cached resource the response
+// that is served to its node.
+func (o *orchestrator) fanoutServed(aggregatedKey string, cached *cache.Resource) {
+	byResponse := make(map[*discovery.DiscoveryResponse]map[cache.WatchID]*gcp.Request)
+	for id, watch := range cached.Requests {
+		resp := o.servedResponse(aggregatedKey, watch.GetNode(), cached)
+		if byResponse[resp] == nil {
+			byResponse[resp] = make(map[cache.WatchID]*gcp.Request)
+		}
+		byResponse[resp][id] = watch
+	}
+	for resp, watchers := range byResponse {
+		o.fanout(resp, watchers, aggregatedKey)
+	}
+}
@@ -0,0 +1,80 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinVersion(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	assert.EqualError(t, orchestrator.PinVersion("lds", ""), "no response cached for key lds")
+
+	req := newRolloutRequest("node", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	resp1 := newRolloutResponse("1")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp1))
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+	cancelWatch()
+	req.VersionInfo = "1"
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+
+	assert.EqualError(t, orchestrator.PinVersion("lds", "7"), "version 7 of key lds is not held by the relay")
+	assert.NoError(t, orchestrator.PinVersion("lds", ""))
+	version, ok := orchestrator.GetPinnedVersion("lds")
+	assert.True(t, ok)
+	assert.Equal(t, "1", version)
+	assert.Equal(t, 0, len(respChannel))
+
+	// Newer responses are cached but withheld.
+	resp2 := newRolloutResponse("2")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp2))
+	assert.Equal(t, 0, len(respChannel))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.pin_withheld", 1)
+	cached, err := orchestrator.cache.Fetch("lds")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", cached.Resp.GetVersionInfo())
+	otherReq := newRolloutRequest("other", false)
+	otherChannel, cancelOther := orchestrator.CreateWatch(otherReq)
+	defer cancelOther()
+	assertEqualResponse(t, <-otherChannel, *resp1, otherReq)
+
+	// Unpinning serves the latest response.
+	assert.True(t, orchestrator.UnpinVersion("lds"))
+	assertEqualResponse(t, <-respChannel, *resp2, req)
+	_, ok = orchestrator.GetPinnedVersion("lds")
+	assert.False(t, ok)
+	assert.False(t, orchestrator.UnpinVersion("lds"))
+}
+
+func TestPinVersionRollout(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithRollout(&bootstrapv1.Rollout{CanaryPercentage: 100})(orchestrator)
+	req := newRolloutRequest("canary", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	resp1 := newRolloutResponse("1")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp1))
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+	resp2 := newRolloutResponse("2")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp2))
+	assertEqualResponse(t, <-respChannel, *resp2, req)
+
+	// Pinning the stable version of a rollout rolls back the canary nodes.
+	assert.NoError(t, orchestrator.PinVersion("lds", "1"))
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+
+	// Promotion does not override the pin.
+	cancelWatch()
+	req.VersionInfo = "1"
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	assert.True(t, orchestrator.PromoteRollout("lds"))
+	assert.Equal(t, 0, len(respChannel))
+}
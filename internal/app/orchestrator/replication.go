@@ -0,0 +1,53 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file applies responses replicated from peer relays to the cache. The
+// contents of this file are intended to only be used within the orchestrator
+// module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+)
+
+func (o *orchestrator) ApplyReplicatedResponse(aggregatedKey string, resp *discovery.DiscoveryResponse) bool {
+	resp = o.stampIdentifier(resp)
+	// The response is compared and cached atomically, so that a response
+	// received upstream after failing over is not regressed by a replicated
+	// response that was compared with the response cached before it.
+	var previous *discovery.DiscoveryResponse
+	result, err := o.cache.SetResponseIfNewer(aggregatedKey, *resp,
+		func(cached *discovery.DiscoveryResponse, resp *discovery.DiscoveryResponse) bool {
+			previous = cached
+			return o.isNewerReplicatedResponse(cached, resp)
+		})
+	if err == nil && !result.Won {
+		return false
+	}
+	o.onResponseSet(context.Background(), aggregatedKey, previous, resp, err)
+	return err == nil
+}
+
+// isNewerReplicatedResponse resolves conflicts between a replicated response
+// and the locally cached response. Versions are ordered by the version guard
+// comparator if one is configured, and are otherwise only checked for
+// equality so that the latest response received wins. Nonces of different
+// relays are unrelated, so stream scoped guards also only check for equality.
+// Responses whose versions cannot be ordered by the comparator are discarded,
+// since they could regress the key to an older version.
+func (o *orchestrator) isNewerReplicatedResponse(previous, resp *discovery.DiscoveryResponse) bool {
+	if previous == nil {
+		return true
+	}
+	if o.versionGuard == nil || o.versionGuard.streamScoped {
+		return previous.GetVersionInfo() != resp.GetVersionInfo()
+	}
+	result, err := o.versionGuard.comparator(previous, resp)
+	return err == nil && result > 0
+}
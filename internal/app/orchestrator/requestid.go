@@ -0,0 +1,203 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file tracks the request IDs of downstream streams, so that the
+// watches of a stream, the upstream streams they open, and the responses
+// fanned out to them can be traced in the logs of the relay and of the
+// origin server. The contents of this file are intended to only be used
+// within the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/envoyproxy/xds-relay/internal/app/interceptor"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+)
+
+// streamRequestID is the request ID of a downstream stream, the node of the
+// last request received on it, the failure of its watches, its transport, the
+// address of its peer, and when it was opened.
+type streamRequestID struct {
+	id        string
+	node      *core.Node
+	failure   *streamFailure
+	transport mapper.Transport
+	peer      string
+	openTime  time.Time
+}
+
+// requestIDMap maps downstream streams to their request IDs, and records why
+// the relay closed their watches.
+//
+// go-control-plane passes the context of a stream to OnStreamOpen only, and
+// creates the watches of the stream without its stream ID. Watches are
+// attributed to their stream by the node of their request when they are
+// created instead: go-control-plane passes every request of a stream to
+// OnStreamRequest, which observes its node, right before it creates the watch
+// of the request. Clients such as Envoy send a new node message with every
+// request, so the node only identifies the stream until its next request. The
+// stream ID is recorded on the watch when it is created, and the stream of the
+// watch is looked up by stream ID afterwards.
+type requestIDMap struct {
+	mu      sync.Mutex
+	streams map[int64]*streamRequestID
+	// nodes maps the node of the last request received on each open stream
+	// to the stream ID.
+	nodes map[*core.Node]int64
+}
+
+func newRequestIDMap() *requestIDMap {
+	return &requestIDMap{
+		streams: make(map[int64]*streamRequestID),
+		nodes:   make(map[*core.Node]int64),
+	}
+}
+
+// open registers the stream with its request ID, the failure that the
+// failures of its watches are recorded to, if any, the transport that its
+// requests are mapped with, and the address of its peer, if known.
+func (r *requestIDMap) open(streamID int64, id string, failure *streamFailure, transport mapper.Transport,
+	peer string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.streams[streamID] = &streamRequestID{
+		id:        id,
+		failure:   failure,
+		transport: transport,
+		peer:      peer,
+		openTime:  time.Now(),
+	}
+}
+
+// observe attributes the node of a request received on the stream to the
+// stream.
+func (r *requestIDMap) observe(streamID int64, node *core.Node) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stream, ok := r.streams[streamID]
+	if !ok || node == nil || stream.node == node {
+		return
+	}
+	if stream.node != nil {
+		delete(r.nodes, stream.node)
+	}
+	stream.node = node
+	r.nodes[node] = streamID
+}
+
+func (r *requestIDMap) close(streamID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stream, ok := r.streams[streamID]; ok && stream.node != nil {
+		delete(r.nodes, stream.node)
+	}
+	delete(r.streams, streamID)
+}
+
+// stream returns the ID of the open stream whose last request is of the node,
+// or zero if there is none. go-control-plane numbers streams from one.
+func (r *requestIDMap) stream(node *core.Node) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.nodes[node]
+}
+
+// get returns the request ID of the open stream, or an empty string if the
+// stream is not open.
+func (r *requestIDMap) get(streamID int64) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stream, ok := r.streams[streamID]; ok {
+		return stream.id
+	}
+	return ""
+}
+
+// nodeID returns the node ID of the last request received on the open stream,
+// or an empty string if the stream is not open or received no request.
+func (r *requestIDMap) nodeID(streamID int64) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stream, ok := r.streams[streamID]; ok {
+		return stream.node.GetId()
+	}
+	return ""
+}
+
+// peer returns the address of the peer of the open stream, or an empty string
+// if the stream is not open or its peer is unknown.
+func (r *requestIDMap) peer(streamID int64) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stream, ok := r.streams[streamID]; ok {
+		return stream.peer
+	}
+	return ""
+}
+
+// getTransport returns the transport of the open stream, or nil if the stream
+// is not open.
+func (r *requestIDMap) getTransport(streamID int64) mapper.Transport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stream, ok := r.streams[streamID]; ok {
+		return stream.transport
+	}
+	return nil
+}
+
+// fail records err as the reason the relay closed a watch of the open stream.
+// It is ignored if the stream is not open.
+func (r *requestIDMap) fail(streamID int64, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stream, ok := r.streams[streamID]; ok && stream.failure != nil {
+		stream.failure.set(err)
+	}
+}
+
+type requestIDKey struct{}
+
+// withRequestID returns a copy of ctx that carries the request ID of the
+// downstream request on whose behalf the orchestrator acts. Unlike
+// log.WithFields, it does not annotate the messages logged with ctx, since
+// ctx is passed on to upstream workers that outlive the request.
+func withRequestID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// logRequestID returns a copy of ctx that annotates the messages logged with
+// it with the request ID that ctx carries, if any.
+func logRequestID(ctx context.Context) context.Context {
+	if id := requestIDFromContext(ctx); id != "" {
+		return log.WithFields(ctx, log.FieldRequestID, id)
+	}
+	return ctx
+}
+
+// contextRequestID returns the request ID of the context of a downstream
+// stream or fetch, as identified by the request ID interceptors. A request ID
+// is generated if the server does not run the interceptors.
+func contextRequestID(ctx context.Context) string {
+	if id, ok := interceptor.RequestIDFromContext(ctx); ok {
+		return id
+	}
+	return interceptor.NewRequestID()
+}
@@ -0,0 +1,154 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/envoyproxy/xds-relay/internal/app/interceptor"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type mockTracedUpstreamClient struct {
+	responseChan <-chan *v2.DiscoveryResponse
+	requestIDs   chan string
+}
+
+func (m mockTracedUpstreamClient) OpenStream(req v2.DiscoveryRequest) (<-chan *v2.DiscoveryResponse, func(), error) {
+	return m.responseChan, func() {}, nil
+}
+
+func (m mockTracedUpstreamClient) OpenResubscribableStream(
+	req v2.DiscoveryRequest,
+) (<-chan *v2.DiscoveryResponse, func([]string), func(), error) {
+	return m.responseChan, func([]string) {}, func() {}, nil
+}
+
+func (m mockTracedUpstreamClient) OpenTracedStream(
+	req v2.DiscoveryRequest,
+	requestID string,
+) (<-chan *v2.DiscoveryResponse, func([]string), func(), error) {
+	m.requestIDs <- requestID
+	return m.OpenResubscribableStream(req)
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockServerStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+// streamContext returns the context of a downstream stream whose request ID
+// header is set, as seen by the orchestrator behind the request ID
+// interceptors.
+func streamContext(t *testing.T, requestID string) context.Context {
+	var ctx context.Context
+	ss := &mockServerStream{
+		ctx: metadata.NewIncomingContext(context.Background(),
+			metadata.Pairs(interceptor.DefaultRequestIDHeader, requestID)),
+	}
+	err := interceptor.RequestID("").Stream[0](nil, ss, &grpc.StreamServerInfo{},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			ctx = ss.Context()
+			return nil
+		})
+	assert.NoError(t, err)
+	return ctx
+}
+
+func TestRequestIDMap(t *testing.T) {
+	requestIDs := newRequestIDMap()
+	node := &v2_core.Node{Id: "node"}
+	requestIDs.open(1, "abc", nil, nil, "")
+	assert.Equal(t, "abc", requestIDs.get(1))
+	assert.Equal(t, int64(0), requestIDs.stream(node))
+
+	requestIDs.observe(1, node)
+	assert.Equal(t, int64(1), requestIDs.stream(node))
+
+	// Nodes of other streams are not attributed to the stream, even if they
+	// are equal.
+	assert.Equal(t, int64(0), requestIDs.stream(&v2_core.Node{Id: "node"}))
+
+	// A new node of the stream replaces the previous one.
+	newNode := &v2_core.Node{Id: "node"}
+	requestIDs.observe(1, newNode)
+	assert.Equal(t, int64(1), requestIDs.stream(newNode))
+	assert.Equal(t, int64(0), requestIDs.stream(node))
+
+	requestIDs.close(1)
+	assert.Equal(t, "", requestIDs.get(1))
+	assert.Equal(t, int64(0), requestIDs.stream(newNode))
+	assert.Empty(t, requestIDs.nodes)
+	assert.Empty(t, requestIDs.streams)
+
+	// Nodes of unknown streams are ignored.
+	requestIDs.observe(2, node)
+	assert.Equal(t, int64(0), requestIDs.stream(node))
+}
+
+func TestRequestIDOfWatchesWithNewNodes(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), typeURLMapper{},
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)})
+	assert.NoError(t, orchestrator.OnStreamOpen(streamContext(t, "abc"), 1, ""))
+	defer orchestrator.OnStreamClosed(1)
+
+	// Envoy sends its node on every request, so every request of the stream
+	// has a node message of its own.
+	for _, typeURL := range []string{upstream.ListenerTypeURL, upstream.ClusterTypeURL, upstream.RouteTypeURL} {
+		req := &v2.DiscoveryRequest{TypeUrl: typeURL, Node: &v2_core.Node{Id: "node"}}
+		assert.NoError(t, orchestrator.OnStreamRequest(1, req))
+		_, cancelWatch := orchestrator.CreateWatch(*req)
+		defer cancelWatch()
+	}
+
+	// The earlier watches remain attributed to the stream.
+	watches := orchestrator.downstreamResponseMap.list()
+	assert.Len(t, watches, 3)
+	for _, watch := range watches {
+		streamID := orchestrator.downstreamResponseMap.streamID(watch.ID)
+		assert.Equal(t, int64(1), streamID)
+		assert.Equal(t, "abc", orchestrator.requestIDs.get(streamID))
+	}
+}
+
+func TestRequestIDPropagatesUpstream(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	client := mockTracedUpstreamClient{responseChan: upstreamResponseChannel, requestIDs: make(chan string, 1)}
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), client)
+
+	assert.NoError(t, orchestrator.OnStreamOpen(streamContext(t, "abc"), 1, ""))
+	req := &v2.DiscoveryRequest{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &v2_core.Node{Id: "node"},
+	}
+	assert.NoError(t, orchestrator.OnStreamRequest(1, req))
+	_, cancelWatch := orchestrator.CreateWatch(*req)
+	defer cancelWatch()
+	assert.Equal(t, "abc", <-client.requestIDs)
+
+	// Streams without a request ID header are assigned a generated one.
+	assert.NoError(t, orchestrator.OnStreamOpen(context.Background(), 2, ""))
+	other := &v2.DiscoveryRequest{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &v2_core.Node{Id: "other"},
+	}
+	assert.NoError(t, orchestrator.OnStreamRequest(2, other))
+	assert.Len(t, orchestrator.requestIDs.get(orchestrator.requestIDs.stream(other.GetNode())), 32)
+
+	orchestrator.OnStreamClosed(1)
+	orchestrator.OnStreamClosed(2)
+	assert.Empty(t, orchestrator.requestIDs.nodes)
+}
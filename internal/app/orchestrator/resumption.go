@@ -0,0 +1,115 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file issues resumption tokens with the responses sent downstream, and
+// accepts them from reconnecting clients, so that a restarted relay knows
+// the responses its clients already hold and does not send them again. The
+// contents of this file are intended to only be used within the orchestrator
+// module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+)
+
+const (
+	metricResumed = "resumed"
+
+	// resumptionSeparator separates the resumption token from the rest of
+	// the nonce. It is neither a digit nor in the URL-safe base64 alphabet.
+	resumptionSeparator = "."
+
+	// resumptionFormat is the first byte of every token, so that the format
+	// can evolve without misreading the tokens of older relays.
+	resumptionFormat    byte = 1
+	resumptionHasSubset byte = 1
+)
+
+// newResumptionToken returns the token of the response sent to the watch. It
+// encodes the version and fingerprint of the response, and the fingerprint of
+// the resources the watch subscribes to if the response is fanned out
+// differentially. Tokens are opaque to clients.
+func (o *orchestrator) newResumptionToken(resp *discovery.DiscoveryResponse, watch *gcp.Request) string {
+	sent := sentRecord{response: newSentResponse(resp)}
+	if subset := watchSubset(o.differentialFanout.resources(resp), watch); subset != nil {
+		sent.subset, sent.hasSubset = *subset, true
+	}
+	b := make([]byte, 0, 2+2*sha256.Size+len(sent.response.version))
+	var flags byte
+	if sent.hasSubset {
+		flags |= resumptionHasSubset
+	}
+	b = append(b, resumptionFormat, flags)
+	b = append(b, sent.response.fingerprint[:]...)
+	if sent.hasSubset {
+		b = append(b, sent.subset[:]...)
+	}
+	b = append(b, sent.response.version...)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// parseResumptionToken returns the response recorded in the token of the
+// nonce. It returns false if the nonce has no token, or the token is not
+// valid.
+func parseResumptionToken(nonce string) (sentRecord, bool) {
+	i := strings.LastIndex(nonce, resumptionSeparator)
+	if i < 0 {
+		return sentRecord{}, false
+	}
+	b, err := base64.RawURLEncoding.DecodeString(nonce[i+len(resumptionSeparator):])
+	if err != nil || len(b) < 2+sha256.Size || b[0] != resumptionFormat {
+		return sentRecord{}, false
+	}
+	var sent sentRecord
+	sent.hasSubset = b[1]&resumptionHasSubset != 0
+	b = b[2:]
+	copy(sent.response.fingerprint[:], b)
+	b = b[sha256.Size:]
+	if sent.hasSubset {
+		if len(b) < sha256.Size {
+			return sentRecord{}, false
+		}
+		copy(sent.subset[:], b)
+		b = b[sha256.Size:]
+	}
+	sent.response.version = string(b)
+	return sent, true
+}
+
+// resume restores the response recorded in the resumption token of the
+// request as the last sent to its node, unless the relay already knows the
+// last response sent to the node. Requests without a version adopt the
+// version of the token, so that the response they hold is not sent again.
+// Tokens of rejected responses and of versions other than the one the client
+// holds are ignored.
+func (o *orchestrator) resume(ctx context.Context, aggregatedKey string, req *gcp.Request) {
+	if req.GetErrorDetail() != nil {
+		return
+	}
+	sent, ok := parseResumptionToken(req.GetResponseNonce())
+	if !ok {
+		return
+	}
+	if req.GetVersionInfo() != "" && req.GetVersionInfo() != sent.response.version {
+		return
+	}
+	restored := o.sentResponseMap.restore(aggregatedKey, req, sent)
+	if req.GetVersionInfo() == "" {
+		req.VersionInfo = sent.response.version
+	}
+	if restored {
+		o.keyScope(aggregatedKey).Counter(metricResumed).Inc(1)
+		o.logger.With("key", aggregatedKey).With("node ID", req.GetNode().GetId()).
+			With("version", sent.response.version).Debug(ctx, "watch resumed")
+	}
+}
@@ -0,0 +1,103 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResumptionToken(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	resp := &v2.DiscoveryResponse{
+		VersionInfo: "1.2-3",
+		TypeUrl:     upstream.ListenerTypeURL,
+		Resources:   []*any.Any{{Value: []byte("lds resource")}},
+	}
+	watch := &gcp.Request{ResourceNames: []string{"resources[0]"}}
+
+	sent, ok := parseResumptionToken("7-1" + resumptionSeparator + orchestrator.newResumptionToken(resp, watch))
+	assert.True(t, ok)
+	assert.Equal(t, sentRecord{response: newSentResponse(resp)}, sent)
+
+	WithDifferentialFanout(&bootstrapv1.DifferentialFanout{})(orchestrator)
+	sent, ok = parseResumptionToken("7-1" + resumptionSeparator + orchestrator.newResumptionToken(resp, watch))
+	assert.True(t, ok)
+	assert.Equal(t, *watchSubset(orchestrator.differentialFanout.resources(resp), watch), sent.subset)
+	assert.True(t, sent.hasSubset)
+	assert.Equal(t, "1.2-3", sent.response.version)
+
+	for _, nonce := range []string{"", "7-1", "7-1.", "7-1.!", "7-1.AQA", "7-1.AgAA"} {
+		_, ok := parseResumptionToken(nonce)
+		assert.False(t, ok, nonce)
+	}
+}
+
+func TestResumption_RestartedRelay(t *testing.T) {
+	resp := &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     upstream.ListenerTypeURL,
+		Resources:   []*any.Any{{Value: []byte("lds resource")}},
+	}
+	req := gcp.Request{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &v2_core.Node{Id: "node"},
+	}
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithResumptionTokens()(orchestrator)
+	assert.NoError(t, orchestrator.OnStreamOpen(context.Background(), 1, ""))
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp))
+	gotResponse, err := (<-respChannel).GetDiscoveryResponse()
+	assert.NoError(t, err)
+	orchestrator.OnStreamResponse(1, &req, gotResponse)
+	cancelWatch()
+	nonce := gotResponse.GetNonce()
+	assert.True(t, strings.HasPrefix(nonce, "1-1"+resumptionSeparator))
+
+	// The restarted relay does not resend the response held by the client
+	// that presents its token, whether or not the client sent its version.
+	mockScope := newMockScope("prefix")
+	restarted := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithResumptionTokens()(restarted)
+	assert.True(t, restarted.ApplyReplicatedResponse("lds", resp))
+	for _, version := range []string{"", "1"} {
+		resumed := req
+		resumed.Node = &v2_core.Node{Id: "node-" + version}
+		resumed.VersionInfo = version
+		resumed.ResponseNonce = nonce
+		respChannel, cancelWatch := restarted.CreateWatch(resumed)
+		assert.Equal(t, 0, len(respChannel), version)
+		cancelWatch()
+	}
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.resumed", 2)
+
+	// Clients that do not hold the response of the token are sent the
+	// response.
+	for _, resumed := range []gcp.Request{
+		{TypeUrl: upstream.ListenerTypeURL, Node: &v2_core.Node{Id: "other"}},
+		{TypeUrl: upstream.ListenerTypeURL, Node: &v2_core.Node{Id: "other"}, VersionInfo: "0", ResponseNonce: nonce},
+		{
+			TypeUrl:       upstream.ListenerTypeURL,
+			Node:          &v2_core.Node{Id: "other"},
+			ResponseNonce: nonce,
+			ErrorDetail:   status.New(codes.InvalidArgument, "invalid listener").Proto(),
+		},
+	} {
+		respChannel, cancelWatch := restarted.CreateWatch(resumed)
+		assert.Equal(t, 1, len(respChannel))
+		cancelWatch()
+	}
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.resumed", 2)
+}
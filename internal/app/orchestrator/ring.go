@@ -0,0 +1,148 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file opens the upstream streams of aggregated keys owned by other
+// replicas of the hash ring with those replicas, and moves upstream streams
+// to the new owner of their aggregated key when the membership changes. The
+// contents of this file are intended to only be used within the orchestrator
+// module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"sync"
+
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/ring"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+)
+
+const (
+	metricUpstreamForwarded = "upstream_forwarded"
+	metricUpstreamMoved     = "upstream_moved"
+)
+
+// PeerDialer returns the client of the xDS server of a replica at the
+// address.
+type PeerDialer func(address string) (upstream.Client, error)
+
+// hashRing tracks the replica that the upstream stream of each aggregated key
+// is opened with.
+type hashRing struct {
+	ring     *ring.Ring
+	dialPeer PeerDialer
+
+	mu sync.Mutex
+	// peers is keyed by the address of the replica.
+	peers map[string]upstream.Client
+	// owners is keyed by aggregated key, and holds the address of the
+	// replica that the upstream stream was opened with, or an empty string
+	// for the origin server.
+	owners map[string]string
+}
+
+func newHashRing(r *ring.Ring, dialPeer PeerDialer) *hashRing {
+	return &hashRing{
+		ring:     r,
+		dialPeer: dialPeer,
+		peers:    make(map[string]upstream.Client),
+		owners:   make(map[string]string),
+	}
+}
+
+// ownerAddress returns the address of the replica that owns the aggregated
+// key, or an empty string if this replica owns it.
+func (h *hashRing) ownerAddress(aggregatedKey string) string {
+	owner, ok := h.ring.Owner(aggregatedKey)
+	if !ok || owner.ID == h.ring.Identity() {
+		return ""
+	}
+	return owner.Address
+}
+
+// peer returns the client of the replica at the address, dialing it if it
+// was not dialed before.
+func (h *hashRing) peer(address string) (upstream.Client, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if client, ok := h.peers[address]; ok {
+		return client, nil
+	}
+	client, err := h.dialPeer(address)
+	if err != nil {
+		return nil, err
+	}
+	h.peers[address] = client
+	return client, nil
+}
+
+// setOwner records the address of the replica that the upstream stream of
+// the aggregated key was opened with.
+func (h *hashRing) setOwner(aggregatedKey string, address string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.owners[aggregatedKey] = address
+}
+
+// owner returns the address of the replica that the upstream stream of the
+// aggregated key was opened with.
+func (h *hashRing) owner(aggregatedKey string) (string, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	address, ok := h.owners[aggregatedKey]
+	return address, ok
+}
+
+// remove forgets the aggregated key.
+func (h *hashRing) remove(aggregatedKey string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.owners, aggregatedKey)
+}
+
+// upstreamClientFor returns the client that the upstream stream of the
+// aggregated key is opened with, which is the origin server unless another
+// replica of the hash ring owns the key, along with the address of that
+// replica.
+func (o *orchestrator) upstreamClientFor(aggregatedKey string) (upstream.Client, string, error) {
+	if o.hashRing == nil {
+		return o.upstreamClient, "", nil
+	}
+	address := o.hashRing.ownerAddress(aggregatedKey)
+	if address == "" {
+		return o.upstreamClient, "", nil
+	}
+	client, err := o.hashRing.peer(address)
+	return client, address, err
+}
+
+// onRingChange reopens the upstream streams of the aggregated keys whose
+// owner changed with their new owner. Cached responses and downstream watches
+// are left in place.
+func (o *orchestrator) onRingChange() {
+	ctx := context.Background()
+	o.upstreamMu.Lock()
+	defer o.upstreamMu.Unlock()
+	if !o.isLeader() {
+		return
+	}
+	o.representativeRequests.Range(func(key, req interface{}) bool {
+		aggregatedKey := key.(string)
+		previous, ok := o.hashRing.owner(aggregatedKey)
+		if !ok || !o.upstreamResponseMap.exists(aggregatedKey) {
+			return true
+		}
+		if previous == o.hashRing.ownerAddress(aggregatedKey) {
+			return true
+		}
+		o.keyScope(aggregatedKey).Counter(metricUpstreamMoved).Inc(1)
+		o.logger.With("key", aggregatedKey).Info(ctx, "owner of key changed, moving upstream stream")
+		o.deleteUpstream(aggregatedKey, nil)
+		o.openUpstream(ctx, aggregatedKey, req.(gcp.Request))
+		return true
+	})
+}
@@ -0,0 +1,94 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/ring"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+)
+
+// mockMembership lists the members set by the test.
+type mockMembership struct {
+	mu      sync.Mutex
+	members []ring.Member
+}
+
+func (m *mockMembership) Members(context.Context) ([]ring.Member, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.members, nil
+}
+
+func (m *mockMembership) set(members ...ring.Member) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.members = members
+}
+
+func TestHashRing(t *testing.T) {
+	originClient := mockFlakyUpstreamClient{streams: make(chan chan *v2.DiscoveryResponse, 10)}
+	peerClient := mockFlakyUpstreamClient{streams: make(chan chan *v2.DiscoveryResponse, 10)}
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), originClient)
+
+	// The peer is the only member, so it owns every key.
+	membership := &mockMembership{}
+	membership.set(ring.Member{ID: "peer", Address: "peer:9991"})
+	hashRing := ring.New(&bootstrapv1.HashRing{Identity: "self"}, membership, log.New("panic"),
+		tally.NewTestScope("", nil))
+	assert.NoError(t, hashRing.Refresh(context.Background()))
+	var dialed []string
+	WithHashRing(hashRing, func(address string) (upstream.Client, error) {
+		dialed = append(dialed, address)
+		return peerClient, nil
+	})(orchestrator)
+
+	req := newRolloutRequest("node", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	stream := <-peerClient.streams
+	resp1 := newRolloutResponse("1")
+	stream <- resp1
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+	assert.Equal(t, []string{"peer:9991"}, dialed)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.upstream_forwarded", 1)
+
+	// The stream moves to the origin server once this replica owns the key.
+	membership.set(ring.Member{ID: "self", Address: "self:9991"})
+	assert.NoError(t, hashRing.Refresh(context.Background()))
+	stream = <-originClient.streams
+	resp2 := newRolloutResponse("2")
+	stream <- resp2
+	assertEqualResponse(t, <-respChannel, *resp2, req)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.upstream_moved", 1)
+
+	// Refreshing the same members leaves the stream in place.
+	assert.NoError(t, hashRing.Refresh(context.Background()))
+	assert.Len(t, originClient.streams, 0)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.upstream_moved", 1)
+}
+
+func TestHashRingDialFailure(t *testing.T) {
+	originClient := mockFlakyUpstreamClient{streams: make(chan chan *v2.DiscoveryResponse, 10)}
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), originClient)
+	hashRing := ring.New(&bootstrapv1.HashRing{Identity: "self"},
+		ring.StaticMembership{{ID: "peer", Address: "peer:9991"}}, log.New("panic"), tally.NewTestScope("", nil))
+	assert.NoError(t, hashRing.Refresh(context.Background()))
+	WithHashRing(hashRing, func(address string) (upstream.Client, error) {
+		return nil, errors.New("unreachable")
+	})(orchestrator)
+
+	_, cancelWatch := orchestrator.CreateWatch(newRolloutRequest("node", false))
+	defer cancelWatch()
+	assert.Len(t, originClient.streams, 0)
+}
@@ -0,0 +1,125 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file rolls the version served for an aggregated key back to a held
+// version, and reports how the upstream diverges from it until the key is
+// unpinned. The contents of this file are intended to only be used within the
+// orchestrator module and should not be exported, except for the rollbacks
+// shown by the admin server.
+package orchestrator
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/diff"
+)
+
+const (
+	metricRollback         = "rollback"
+	metricRollbackDiverged = "rollback_diverged"
+)
+
+// RollbackStatus is the rollback of an aggregated key. The key diverges from
+// the upstream once the upstream version differs from the rolled back version,
+// and Diff holds the changes the upstream would introduce once the key is
+// unpinned.
+type RollbackStatus struct {
+	Key             string
+	Tenant          string
+	Version         string
+	RolledBackFrom  string
+	RolledBackAt    time.Time
+	UpstreamVersion string
+	Diverged        bool
+	Diff            *diff.Summary
+}
+
+// rollback records the version an aggregated key was rolled back from.
+type rollback struct {
+	from string
+	at   time.Time
+}
+
+// RollBack pins the held response with the version for the aggregated key and
+// fans it out to the existing watches, like PinVersion, and reports the
+// divergence of the upstream from the version until UnpinVersion is called.
+func (o *orchestrator) RollBack(aggregatedKey string, version string) error {
+	cached, err := o.cache.Fetch(aggregatedKey)
+	var from string
+	if err == nil && cached != nil {
+		from = cached.Resp.GetVersionInfo()
+	}
+	if served, ok := o.pinnedResponse(aggregatedKey); ok {
+		from = served.GetVersionInfo()
+	}
+	if err := o.PinVersion(aggregatedKey, version); err != nil {
+		return err
+	}
+	o.rollbacks.Store(aggregatedKey, rollback{from: from, at: time.Now()})
+	o.keyScope(aggregatedKey).Counter(metricRollback).Inc(1)
+	o.logger.With("key", aggregatedKey).With("version", version).With("from", from).
+		Warn(context.Background(), "version rolled back")
+	return nil
+}
+
+// GetRollbacks returns the rollbacks of the aggregated keys that are still
+// pinned, ordered by aggregated key.
+func (o *orchestrator) GetRollbacks() []RollbackStatus {
+	var statuses []RollbackStatus
+	o.rollbacks.Range(func(key, value interface{}) bool {
+		if status, ok := o.rollbackStatus(key.(string), value.(rollback)); ok {
+			statuses = append(statuses, status)
+		}
+		return true
+	})
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Key < statuses[j].Key })
+	return statuses
+}
+
+// GetRollback returns the rollback of the aggregated key.
+func (o *orchestrator) GetRollback(aggregatedKey string) (RollbackStatus, bool) {
+	value, ok := o.rollbacks.Load(aggregatedKey)
+	if !ok {
+		return RollbackStatus{}, false
+	}
+	return o.rollbackStatus(aggregatedKey, value.(rollback))
+}
+
+// rollbackStatus compares the rolled back response of the aggregated key with
+// the cached upstream response.
+func (o *orchestrator) rollbackStatus(aggregatedKey string, r rollback) (RollbackStatus, bool) {
+	pinned, ok := o.pinnedResponse(aggregatedKey)
+	if !ok {
+		return RollbackStatus{}, false
+	}
+	status := RollbackStatus{
+		Key:            aggregatedKey,
+		Tenant:         o.getTenant(aggregatedKey),
+		Version:        pinned.GetVersionInfo(),
+		RolledBackFrom: r.from,
+		RolledBackAt:   r.at,
+	}
+	cached, err := o.cache.Fetch(aggregatedKey)
+	if err != nil || cached == nil || cached.Resp == nil {
+		return status, true
+	}
+	status.UpstreamVersion = cached.Resp.GetVersionInfo()
+	if cached.Resp.GetVersionInfo() != pinned.GetVersionInfo() {
+		summary := diff.Compute(pinned, cached.Resp)
+		status.Diverged = true
+		status.Diff = &summary
+	}
+	return status, true
+}
+
+// isRolledBack returns true if the aggregated key is rolled back.
+func (o *orchestrator) isRolledBack(aggregatedKey string) bool {
+	_, ok := o.rollbacks.Load(aggregatedKey)
+	return ok
+}
@@ -0,0 +1,89 @@
+package orchestrator
+
+import (
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollBack(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithHistory(&bootstrapv1.History{})(orchestrator)
+	assert.EqualError(t, orchestrator.RollBack("lds", "1"), "no response cached for key lds")
+
+	req := newRolloutRequest("node", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	resp1 := newResponse(t, upstream.ListenerTypeURL, "1", &v2.Listener{Name: "a"})
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp1))
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+	resp2 := newResponse(t, upstream.ListenerTypeURL, "2", &v2.Listener{Name: "a"}, &v2.Listener{Name: "b"})
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp2))
+
+	// The rolled back version is fanned out to the existing watches.
+	req.VersionInfo = "2"
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	assert.NoError(t, orchestrator.RollBack("lds", "1"))
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.rollback", 1)
+	version, ok := orchestrator.GetPinnedVersion("lds")
+	assert.True(t, ok)
+	assert.Equal(t, "1", version)
+
+	status, ok := orchestrator.GetRollback("lds")
+	assert.True(t, ok)
+	assert.Equal(t, "1", status.Version)
+	assert.Equal(t, "2", status.RolledBackFrom)
+	assert.Equal(t, "2", status.UpstreamVersion)
+	assert.True(t, status.Diverged)
+	assert.Equal(t, []string{"b"}, status.Diff.Added)
+
+	// Newer upstream versions are withheld and reported as divergence.
+	req.VersionInfo = "1"
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	resp3 := newResponse(t, upstream.ListenerTypeURL, "3", &v2.Listener{Name: "c"})
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp3))
+	assert.Equal(t, 0, len(respChannel))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.rollback_diverged", 1)
+	rollbacks := orchestrator.GetRollbacks()
+	assert.Equal(t, 1, len(rollbacks))
+	assert.Equal(t, "3", rollbacks[0].UpstreamVersion)
+	assert.Equal(t, []string{"c"}, rollbacks[0].Diff.Added)
+	assert.Equal(t, []string{"a"}, rollbacks[0].Diff.Removed)
+
+	// Unpinning ends the rollback and serves the upstream version.
+	assert.True(t, orchestrator.UnpinVersion("lds"))
+	assertEqualResponse(t, <-respChannel, *resp3, req)
+	_, ok = orchestrator.GetRollback("lds")
+	assert.False(t, ok)
+	assert.Empty(t, orchestrator.GetRollbacks())
+}
+
+func TestRollBackPinned(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithHistory(&bootstrapv1.History{})(orchestrator)
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", newRolloutResponse("1")))
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", newRolloutResponse("2")))
+	assert.NoError(t, orchestrator.RollBack("lds", "1"))
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", newRolloutResponse("3")))
+
+	// Rolling back a rolled back key records the version that was served.
+	assert.NoError(t, orchestrator.RollBack("lds", "2"))
+	status, ok := orchestrator.GetRollback("lds")
+	assert.True(t, ok)
+	assert.Equal(t, "1", status.RolledBackFrom)
+	assert.Equal(t, "3", status.UpstreamVersion)
+
+	// Pinning a version ends the rollback.
+	assert.NoError(t, orchestrator.PinVersion("lds", "3"))
+	_, ok = orchestrator.GetRollback("lds")
+	assert.False(t, ok)
+}
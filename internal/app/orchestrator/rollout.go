@@ -0,0 +1,263 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file stages the fanout of new versions to canary nodes before the rest
+// of the downstream nodes. The contents of this file are intended to only be
+// used within the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	metricRolloutStarted  = "rollout_started"
+	metricRolloutPromoted = "rollout_promoted"
+
+	// canaryBuckets is the number of buckets that node IDs are hashed into,
+	// which allows canary percentages with two decimals.
+	canaryBuckets = 10000
+)
+
+// RolloutStatus describes the rollout of a new version of an aggregated key
+// that is only served to canary nodes.
+type RolloutStatus struct {
+	Key           string
+	StableVersion string
+	CanaryVersion string
+	StartTime     time.Time
+}
+
+// rollout holds the version of an aggregated key served to nodes that are
+// not canaries while the new version soaks on canary nodes.
+type rollout struct {
+	stable    *discovery.DiscoveryResponse
+	canary    *discovery.DiscoveryResponse
+	startTime time.Time
+	// timer promotes the rollout after the soak duration. It is nil if
+	// rollouts are only promoted through the admin API.
+	timer *time.Timer
+}
+
+// rolloutController tracks the rollouts in progress and selects the canary
+// nodes.
+type rolloutController struct {
+	canaryPercentage   float64
+	canaryNodeMetadata map[string]string
+	soakDuration       time.Duration
+
+	mu       sync.Mutex
+	rollouts map[string]*rollout
+}
+
+// newRolloutController returns a rollout controller with the canary
+// selection and soak duration of the config.
+func newRolloutController(config *bootstrapv1.Rollout) *rolloutController {
+	c := &rolloutController{
+		canaryPercentage:   config.GetCanaryPercentage(),
+		canaryNodeMetadata: config.GetCanaryNodeMetadata(),
+		rollouts:           make(map[string]*rollout),
+	}
+	if config.GetSoakDuration() != nil {
+		c.soakDuration, _ = ptypes.Duration(config.GetSoakDuration())
+	}
+	return c
+}
+
+// isCanary returns true if the node ID hashes into the canary percentage, or
+// if the node metadata matches the canary node metadata.
+func (c *rolloutController) isCanary(node *core.Node) bool {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(node.GetId()))
+	if float64(hash.Sum32()%canaryBuckets) < c.canaryPercentage*canaryBuckets/100 {
+		return true
+	}
+	if len(c.canaryNodeMetadata) == 0 {
+		return false
+	}
+	fields := node.GetMetadata().GetFields()
+	for name, value := range c.canaryNodeMetadata {
+		if fields[name].GetStringValue() != value {
+			return false
+		}
+	}
+	return true
+}
+
+// canaryWatchers returns the watchers whose nodes are canaries.
+func (c *rolloutController) canaryWatchers(
+	watchers map[cache.WatchID]*gcp.Request,
+) map[cache.WatchID]*gcp.Request {
+	canaries := make(map[cache.WatchID]*gcp.Request)
+	for id, watch := range watchers {
+		if c.isCanary(watch.GetNode()) {
+			canaries[id] = watch
+		}
+	}
+	return canaries
+}
+
+// stableResponse returns the response served to nodes that are not canaries
+// while a rollout of the aggregated key is in progress.
+func (c *rolloutController) stableResponse(aggregatedKey string) (*discovery.DiscoveryResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.rollouts[aggregatedKey]
+	if !ok {
+		return nil, false
+	}
+	return r.stable, true
+}
+
+// remove ends the rollout of the aggregated key, if it is the expected
+// rollout or if expected is nil. It returns false if no rollout was removed.
+func (c *rolloutController) remove(aggregatedKey string, expected *rollout) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.rollouts[aggregatedKey]
+	if !ok || (expected != nil && r != expected) {
+		return false
+	}
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	delete(c.rollouts, aggregatedKey)
+	return true
+}
+
+// list returns the rollouts in progress, ordered by aggregated key.
+func (c *rolloutController) list() []RolloutStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rollouts := make([]RolloutStatus, 0, len(c.rollouts))
+	for key, r := range c.rollouts {
+		rollouts = append(rollouts, RolloutStatus{
+			Key:           key,
+			StableVersion: r.stable.GetVersionInfo(),
+			CanaryVersion: r.canary.GetVersionInfo(),
+			StartTime:     r.startTime,
+		})
+	}
+	sort.Slice(rollouts, func(i, j int) bool { return rollouts[i].Key < rollouts[j].Key })
+	return rollouts
+}
+
+func (o *orchestrator) GetRollouts() []RolloutStatus {
+	if o.rollouts == nil {
+		return nil
+	}
+	return o.rollouts.list()
+}
+
+// PromoteRollout ends the rollout of the aggregated key and fans out the
+// canary version to every node. It returns false if no rollout of the
+// aggregated key is in progress.
+func (o *orchestrator) PromoteRollout(aggregatedKey string) bool {
+	return o.promoteRollout(context.Background(), aggregatedKey, nil)
+}
+
+// promoteRollout promotes the rollout of the aggregated key, if it is the
+// expected rollout or if expected is nil.
+func (o *orchestrator) promoteRollout(ctx context.Context, aggregatedKey string, expected *rollout) bool {
+	if o.rollouts == nil || !o.rollouts.remove(aggregatedKey, expected) {
+		return false
+	}
+	o.keyScope(aggregatedKey).Counter(metricRolloutPromoted).Inc(1)
+	cached, err := o.cache.Fetch(aggregatedKey)
+	if err != nil || cached == nil || cached.Resp == nil {
+		return true
+	}
+	o.logger.With("key", aggregatedKey).With("version", cached.Resp.GetVersionInfo()).Info(ctx, "rollout promoted")
+	o.fanoutServed(aggregatedKey, cached)
+	return true
+}
+
+// stageRollout starts or restarts the rollout of the aggregated key if the
+// response is a new version, and returns true if the response must only be
+// fanned out to canary watchers. The first version of an aggregated key, and
+// a version that returns to the stable version, are served to every node.
+func (o *orchestrator) stageRollout(
+	ctx context.Context,
+	aggregatedKey string,
+	previous *discovery.DiscoveryResponse,
+	resp *discovery.DiscoveryResponse,
+) bool {
+	c := o.rollouts
+	c.mu.Lock()
+	r, inProgress := c.rollouts[aggregatedKey]
+	stable := previous
+	if inProgress {
+		stable = r.stable
+	}
+	if stable == nil || stable.GetVersionInfo() == resp.GetVersionInfo() {
+		if inProgress {
+			if r.timer != nil {
+				r.timer.Stop()
+			}
+			delete(c.rollouts, aggregatedKey)
+		}
+		c.mu.Unlock()
+		return false
+	}
+	if inProgress && r.canary.GetVersionInfo() == resp.GetVersionInfo() {
+		// The canary version was patched again, e.g. by overrides, which does
+		// not restart the soak.
+		r.canary = resp
+	} else {
+		if inProgress && r.timer != nil {
+			r.timer.Stop()
+		}
+		r = &rollout{stable: stable, canary: resp, startTime: time.Now()}
+		if c.soakDuration > 0 {
+			started := r
+			r.timer = time.AfterFunc(c.soakDuration, func() {
+				o.promoteRollout(context.Background(), aggregatedKey, started)
+			})
+		}
+		c.rollouts[aggregatedKey] = r
+		o.keyScope(aggregatedKey).Counter(metricRolloutStarted).Inc(1)
+		o.logger.With("key", aggregatedKey).With("stable version", stable.GetVersionInfo()).
+			With("canary version", resp.GetVersionInfo()).Info(ctx, "rollout started")
+	}
+	c.mu.Unlock()
+	return true
+}
+
+// servedResponse returns the response served to the node for the cached
+// resource, which is the pinned response if the aggregated key is pinned, or
+// the stable response if a rollout of the aggregated key is in progress and
+// the node is not a canary.
+func (o *orchestrator) servedResponse(
+	aggregatedKey string,
+	node *core.Node,
+	cached *cache.Resource,
+) *discovery.DiscoveryResponse {
+	if cached == nil || cached.Resp == nil {
+		return nil
+	}
+	if pinned, ok := o.pinnedResponse(aggregatedKey); ok {
+		return pinned
+	}
+	if o.rollouts == nil {
+		return cached.Resp
+	}
+	if stable, ok := o.rollouts.stableResponse(aggregatedKey); ok && !o.rollouts.isCanary(node) {
+		return stable
+	}
+	return cached.Resp
+}
@@ -0,0 +1,154 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRolloutResponse(version string) *v2.DiscoveryResponse {
+	return &v2.DiscoveryResponse{
+		VersionInfo: version,
+		TypeUrl:     upstream.ListenerTypeURL,
+		Resources:   []*any.Any{{Value: []byte("lds resource " + version)}},
+	}
+}
+
+func newRolloutRequest(nodeID string, canary bool) gcp.Request {
+	node := &v2_core.Node{Id: nodeID}
+	if canary {
+		node.Metadata = &structpb.Struct{Fields: map[string]*structpb.Value{
+			"canary": {Kind: &structpb.Value_StringValue{StringValue: "true"}},
+		}}
+	}
+	return gcp.Request{TypeUrl: upstream.ListenerTypeURL, Node: node}
+}
+
+func TestRollout(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithRollout(&bootstrapv1.Rollout{CanaryNodeMetadata: map[string]string{"canary": "true"}})(orchestrator)
+	canaryReq := newRolloutRequest("canary", true)
+	canaryChannel, cancelCanary := orchestrator.CreateWatch(canaryReq)
+	defer cancelCanary()
+	stableReq := newRolloutRequest("stable", false)
+	stableChannel, cancelStable := orchestrator.CreateWatch(stableReq)
+	defer cancelStable()
+
+	// The first version is fanned out to every node.
+	resp1 := newRolloutResponse("1")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp1))
+	assertEqualResponse(t, <-canaryChannel, *resp1, canaryReq)
+	assertEqualResponse(t, <-stableChannel, *resp1, stableReq)
+	assert.Empty(t, orchestrator.GetRollouts())
+
+	// New versions are only fanned out to canary nodes.
+	resp2 := newRolloutResponse("2")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp2))
+	assertEqualResponse(t, <-canaryChannel, *resp2, canaryReq)
+	assert.Equal(t, 0, len(stableChannel))
+	rollouts := orchestrator.GetRollouts()
+	assert.Equal(t, 1, len(rollouts))
+	assert.Equal(t, "lds", rollouts[0].Key)
+	assert.Equal(t, "1", rollouts[0].StableVersion)
+	assert.Equal(t, "2", rollouts[0].CanaryVersion)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.rollout_started", 1)
+
+	// Nodes that are not canaries are served the stable version.
+	newStableReq := newRolloutRequest("other", false)
+	newStableChannel, cancelNewStable := orchestrator.CreateWatch(newStableReq)
+	defer cancelNewStable()
+	assertEqualResponse(t, <-newStableChannel, *resp1, newStableReq)
+	gotResponse, err := orchestrator.Fetch(context.Background(), newStableReq)
+	assert.NoError(t, err)
+	assertEqualResponse(t, gotResponse, *resp1, newStableReq)
+
+	// Promotion fans out the canary version to the other nodes. The canary
+	// node, which acknowledged it, is not sent it again.
+	cancelCanary()
+	canaryReq.VersionInfo = "2"
+	canaryChannel, cancelCanary = orchestrator.CreateWatch(canaryReq)
+	defer cancelCanary()
+	assert.True(t, orchestrator.PromoteRollout("lds"))
+	assertEqualResponse(t, <-stableChannel, *resp2, stableReq)
+	assertEqualResponse(t, <-newStableChannel, *resp2, newStableReq)
+	assert.Equal(t, 0, len(canaryChannel))
+	assert.Empty(t, orchestrator.GetRollouts())
+	assert.False(t, orchestrator.PromoteRollout("lds"))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.rollout_promoted", 1)
+}
+
+func TestRolloutReturnToStable(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithRollout(&bootstrapv1.Rollout{})(orchestrator)
+	req := newRolloutRequest("stable", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+
+	resp1 := newRolloutResponse("1")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp1))
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+	cancelWatch()
+	req.VersionInfo = "1"
+	respChannel, cancelWatch = orchestrator.CreateWatch(req)
+	defer cancelWatch()
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", newRolloutResponse("2")))
+	assert.Equal(t, 1, len(orchestrator.GetRollouts()))
+
+	// Returning to the stable version ends the rollout.
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp1))
+	assert.Empty(t, orchestrator.GetRollouts())
+	assert.Equal(t, 0, len(respChannel))
+}
+
+func TestRolloutSoak(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	WithRollout(&bootstrapv1.Rollout{SoakDuration: ptypes.DurationProto(10 * time.Millisecond)})(orchestrator)
+	req := newRolloutRequest("stable", false)
+	respChannel, cancelWatch := orchestrator.CreateWatch(req)
+	defer cancelWatch()
+
+	resp1 := newRolloutResponse("1")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp1))
+	assertEqualResponse(t, <-respChannel, *resp1, req)
+	resp2 := newRolloutResponse("2")
+	assert.True(t, orchestrator.ApplyReplicatedResponse("lds", resp2))
+	assertEqualResponse(t, <-respChannel, *resp2, req)
+	assert.Empty(t, orchestrator.GetRollouts())
+}
+
+func TestIsCanary(t *testing.T) {
+	all := newRolloutController(&bootstrapv1.Rollout{CanaryPercentage: 100})
+	none := newRolloutController(&bootstrapv1.Rollout{})
+	half := newRolloutController(&bootstrapv1.Rollout{CanaryPercentage: 50})
+	canaries := 0
+	for i := 0; i < 1000; i++ {
+		node := &v2_core.Node{Id: fmt.Sprintf("node-%d", i)}
+		assert.True(t, all.isCanary(node))
+		assert.False(t, none.isCanary(node))
+		if half.isCanary(node) {
+			canaries++
+			// Canaries are selected deterministically.
+			assert.True(t, half.isCanary(node))
+		}
+	}
+	assert.InDelta(t, 500, canaries, 100)
+
+	labeled := newRolloutController(&bootstrapv1.Rollout{CanaryNodeMetadata: map[string]string{"canary": "true"}})
+	canaryReq, stableReq := newRolloutRequest("node", true), newRolloutRequest("node", false)
+	assert.True(t, labeled.isCanary(canaryReq.GetNode()))
+	assert.False(t, labeled.isCanary(stableReq.GetNode()))
+}
@@ -0,0 +1,227 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file schedules the downstream sends of concurrent fanouts by the
+// priority class of their resource type, and fairly across aggregated keys.
+// The contents of this file are intended to only be used within the
+// orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/uber-go/tally"
+)
+
+const (
+	metricFanoutPending = "fanout_pending"
+)
+
+type keyWeight struct {
+	keyRegex *regexp.Regexp
+	weight   int
+}
+
+// fanoutQueue holds the pending sends of an aggregated key.
+type fanoutQueue struct {
+	key    string
+	weight int
+	class  *fanoutClass
+	sends  []func()
+}
+
+// fanoutClass holds the keys of a priority class with pending sends, in the
+// order of their turns.
+type fanoutClass struct {
+	priority int32
+	ring     []*fanoutQueue
+	// next is the index in ring of the key whose turn it is, and credit the
+	// number of sends left in its turn.
+	next   int
+	credit int
+}
+
+// fanoutScheduler runs the downstream sends of fanouts on a fixed number of
+// senders. Sends of higher priority classes run first. Within a priority
+// class, keys with pending sends take turns in round-robin order, and each key
+// runs up to its weight many sends per turn, so that the sends of a large
+// fanout are interleaved with those of other keys rather than delaying them.
+type fanoutScheduler struct {
+	concurrency    int
+	weights        []keyWeight
+	typePriorities map[string]int32
+	scope          tally.Scope
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queues  map[string]*fanoutQueue
+	classes map[int32]*fanoutClass
+	// priorities are the priorities of the classes with pending sends, from
+	// the highest.
+	priorities []int32
+	pending    int
+	closed     bool
+}
+
+func newFanoutScheduler(config *bootstrapv1.FanoutScheduling, scope tally.Scope) (*fanoutScheduler, error) {
+	s := &fanoutScheduler{
+		concurrency:    int(config.GetConcurrency()),
+		typePriorities: make(map[string]int32),
+		scope:          scope,
+		queues:         make(map[string]*fanoutQueue),
+		classes:        make(map[int32]*fanoutClass),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	for _, typePriority := range config.GetTypePriorities() {
+		s.typePriorities[typePriority.GetTypeUrl()] = typePriority.GetPriority()
+	}
+	for _, weight := range config.GetWeights() {
+		keyRegex, err := regexp.Compile(weight.GetKeyRegex())
+		if err != nil {
+			return nil, fmt.Errorf("invalid fanout weight key regex %s: %s", weight.GetKeyRegex(), err.Error())
+		}
+		s.weights = append(s.weights, keyWeight{keyRegex: keyRegex, weight: int(weight.GetWeight())})
+	}
+	return s, nil
+}
+
+// start runs the senders until stop is called.
+func (s *fanoutScheduler) start(goroutines *goroutineTracker) {
+	for i := 0; i < s.concurrency; i++ {
+		goroutines.goroutine(subsystemFanout, s.runSender)
+	}
+}
+
+// stop lets the senders exit once the pending sends have run. Sends that are
+// submitted afterwards run immediately.
+func (s *fanoutScheduler) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.cond.Broadcast()
+}
+
+// getWeight returns the number of sends per turn of the aggregated key.
+func (s *fanoutScheduler) getWeight(aggregatedKey string) int {
+	for _, weight := range s.weights {
+		if weight.keyRegex.MatchString(aggregatedKey) {
+			return weight.weight
+		}
+	}
+	return 1
+}
+
+// submit schedules the sends of a fanout of the aggregated key, whose
+// responses are of the type URL, and waits for all of them to run.
+func (s *fanoutScheduler) submit(aggregatedKey string, typeURL string, sends []func()) {
+	var wg sync.WaitGroup
+	wg.Add(len(sends))
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		for _, send := range sends {
+			send()
+			wg.Done()
+		}
+		return
+	}
+	queue, ok := s.queues[aggregatedKey]
+	if !ok {
+		queue = &fanoutQueue{
+			key:    aggregatedKey,
+			weight: s.getWeight(aggregatedKey),
+			class:  s.getClass(s.typePriorities[typeURL]),
+		}
+		s.queues[aggregatedKey] = queue
+		queue.class.ring = append(queue.class.ring, queue)
+		if len(queue.class.ring) == 1 {
+			queue.class.next, queue.class.credit = 0, queue.weight
+		}
+	}
+	for _, send := range sends {
+		send := send
+		queue.sends = append(queue.sends, func() {
+			defer wg.Done()
+			send()
+		})
+	}
+	s.pending += len(sends)
+	s.scope.Gauge(metricFanoutPending).Update(float64(s.pending))
+	s.cond.Broadcast()
+	s.mu.Unlock()
+	wg.Wait()
+}
+
+func (s *fanoutScheduler) runSender() {
+	for {
+		send, ok := s.dequeue()
+		if !ok {
+			return
+		}
+		send()
+	}
+}
+
+// getClass returns the priority class of the priority, creating it if no
+// sends of the priority are pending.
+func (s *fanoutScheduler) getClass(priority int32) *fanoutClass {
+	class, ok := s.classes[priority]
+	if !ok {
+		class = &fanoutClass{priority: priority}
+		s.classes[priority] = class
+		s.priorities = append(s.priorities, priority)
+		sort.Slice(s.priorities, func(i, j int) bool { return s.priorities[i] > s.priorities[j] })
+	}
+	return class
+}
+
+// dequeue waits for the next send in turn. It returns false once the
+// scheduler is stopped and no sends are pending.
+func (s *fanoutScheduler) dequeue() (func(), bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.priorities) == 0 {
+		if s.closed {
+			return nil, false
+		}
+		s.cond.Wait()
+	}
+	class := s.classes[s.priorities[0]]
+	queue := class.ring[class.next]
+	send := queue.sends[0]
+	queue.sends = queue.sends[1:]
+	s.pending--
+	s.scope.Gauge(metricFanoutPending).Update(float64(s.pending))
+	class.credit--
+	if len(queue.sends) == 0 {
+		// The key leaves the ring, and the turn passes to the key after it.
+		delete(s.queues, queue.key)
+		class.ring = append(class.ring[:class.next], class.ring[class.next+1:]...)
+		class.advance(class.next)
+		if len(class.ring) == 0 {
+			delete(s.classes, class.priority)
+			s.priorities = s.priorities[1:]
+		}
+	} else if class.credit == 0 {
+		class.advance(class.next + 1)
+	}
+	return send, true
+}
+
+// advance passes the turn to the key at index next of the ring.
+func (c *fanoutClass) advance(next int) {
+	if len(c.ring) == 0 {
+		c.next, c.credit = 0, 0
+		return
+	}
+	c.next = next % len(c.ring)
+	c.credit = c.ring[c.next].weight
+}
@@ -0,0 +1,164 @@
+package orchestrator
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// scheduleFanouts submits a blocked fanout to the scheduler, followed by the
+// fanouts of the keys with responses of the type URLs, and returns the order in which the sends of the keys
+// ran once the blocked fanout is released.
+func scheduleFanouts(
+	t *testing.T,
+	scheduler *fanoutScheduler,
+	fanouts []string,
+	typeURLs []string,
+	sizes []int,
+) []string {
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	running, release := make(chan struct{}), make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		scheduler.submit("blocked", "", []func(){func() {
+			close(running)
+			<-release
+		}})
+	}()
+	<-running
+
+	pending := 0
+	for i, key := range fanouts {
+		key := key
+		var sends []func()
+		for j := 0; j < sizes[i]; j++ {
+			sends = append(sends, func() {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, key)
+			})
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scheduler.submit(key, typeURLs[i], sends)
+		}()
+		// Wait for the sends to be queued, so that keys are queued in order.
+		pending += sizes[i]
+		assert.Eventually(t, func() bool {
+			scheduler.mu.Lock()
+			defer scheduler.mu.Unlock()
+			return scheduler.pending == pending
+		}, time.Second, time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	return order
+}
+
+func TestFanoutSchedulerRoundRobin(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	scheduler, err := newFanoutScheduler(&bootstrapv1.FanoutScheduling{Concurrency: 1}, mockScope)
+	assert.NoError(t, err)
+	scheduler.start(newGoroutineTracker(mockScope))
+	defer scheduler.stop()
+
+	order := scheduleFanouts(t, scheduler, []string{"eds", "lds"}, make([]string, 2), []int{4, 1})
+	assert.Equal(t, []string{"eds", "lds", "eds", "eds", "eds"}, order)
+	testutils.AssertGaugeValue(t, mockScope.Snapshot().Gauges(), "prefix.fanout_pending", 0)
+}
+
+func TestFanoutSchedulerWeights(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	scheduler, err := newFanoutScheduler(&bootstrapv1.FanoutScheduling{
+		Concurrency: 1,
+		Weights: []*bootstrapv1.KeyWeight{
+			{KeyRegex: "^cds", Weight: 3},
+			{KeyRegex: "ds$", Weight: 2},
+		},
+	}, mockScope)
+	assert.NoError(t, err)
+	scheduler.start(newGoroutineTracker(mockScope))
+	defer scheduler.stop()
+
+	order := scheduleFanouts(t, scheduler, []string{"eds", "cds", "rds_a"}, make([]string, 3), []int{3, 4, 2})
+	assert.Equal(t, []string{"eds", "eds", "cds", "cds", "cds", "rds_a", "eds", "cds", "rds_a"}, order)
+
+	_, err = newFanoutScheduler(&bootstrapv1.FanoutScheduling{
+		Concurrency: 1,
+		Weights:     []*bootstrapv1.KeyWeight{{KeyRegex: "(", Weight: 1}},
+	}, mockScope)
+	assert.Error(t, err)
+}
+
+func TestFanoutSchedulerPriorities(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	scheduler, err := newFanoutScheduler(&bootstrapv1.FanoutScheduling{
+		Concurrency: 1,
+		TypePriorities: []*bootstrapv1.TypePriority{
+			{TypeUrl: upstream.ListenerTypeURL, Priority: 2},
+			{TypeUrl: upstream.ClusterTypeURL, Priority: 2},
+			{TypeUrl: upstream.RouteTypeURL, Priority: 1},
+		},
+	}, mockScope)
+	assert.NoError(t, err)
+	scheduler.start(newGoroutineTracker(mockScope))
+	defer scheduler.stop()
+
+	order := scheduleFanouts(t, scheduler,
+		[]string{"eds", "rds", "lds", "cds"},
+		[]string{upstream.EndpointTypeURL, upstream.RouteTypeURL, upstream.ListenerTypeURL, upstream.ClusterTypeURL},
+		[]int{2, 2, 2, 1})
+	assert.Equal(t, []string{"lds", "cds", "lds", "rds", "rds", "eds", "eds"}, order)
+}
+
+func TestFanoutSchedulerStop(t *testing.T) {
+	baseline := testutils.CurrentGoroutines()
+	mockScope := newMockScope("prefix")
+	scheduler, err := newFanoutScheduler(&bootstrapv1.FanoutScheduling{Concurrency: 2}, mockScope)
+	assert.NoError(t, err)
+	scheduler.start(newGoroutineTracker(mockScope))
+	scheduler.stop()
+	testutils.AssertNoLeakedGoroutines(t, baseline)
+
+	// Sends submitted after the scheduler stopped run immediately.
+	sent := false
+	scheduler.submit("lds", upstream.ListenerTypeURL, []func(){func() { sent = true }})
+	assert.True(t, sent)
+}
+
+func TestFanoutScheduling(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, typeURLMapper{},
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	WithFanoutScheduling(&bootstrapv1.FanoutScheduling{Concurrency: 1})(orchestrator)
+	orchestrator.fanoutScheduler.start(orchestrator.goroutines)
+	defer orchestrator.fanoutScheduler.stop()
+
+	var respChannels []chan gcp.Response
+	for _, id := range []string{"a", "b"} {
+		respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+			TypeUrl:       upstream.ListenerTypeURL,
+			ResourceNames: []string{id},
+		})
+		defer cancelWatch()
+		respChannels = append(respChannels, respChannel)
+	}
+	upstreamResponseChannel <- &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ListenerTypeURL}
+	for _, respChannel := range respChannels {
+		resp, err := (<-respChannel).GetDiscoveryResponse()
+		assert.NoError(t, err)
+		assert.Equal(t, "1", resp.GetVersionInfo())
+	}
+}
@@ -0,0 +1,121 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file mirrors representative requests to a shadow origin server and
+// compares its responses with those of the origin server. The contents of
+// this file are intended to only be used within the orchestrator module and
+// should not be exported.
+package orchestrator
+
+import (
+	"context"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/diff"
+)
+
+const (
+	metricShadowMatch       = "shadow_match"
+	metricShadowMismatch    = "shadow_mismatch"
+	metricShadowVersionSkew = "shadow_version_skew"
+	metricShadowError       = "shadow_error"
+)
+
+func (o *orchestrator) GetShadowDiff(aggregatedKey string) (diff.Summary, bool) {
+	summary, ok := o.shadowDiffs.Load(aggregatedKey)
+	if !ok {
+		return diff.Summary{}, false
+	}
+	return summary.(diff.Summary), true
+}
+
+// openShadow opens a stream to the shadow origin server with the
+// representative request. The stream is closed along with the origin server
+// stream when done is closed. It returns the function that resubscribes the
+// stream, which is nil if the stream cannot be resubscribed.
+func (o *orchestrator) openShadow(
+	ctx context.Context,
+	aggregatedKey string,
+	req gcp.Request,
+	done <-chan bool,
+) func([]string) {
+	responseChannel, resubscribe, shutdown, err := openStream(o.shadowClient, aggregatedKey, req, requestIDFromContext(ctx))
+	if err != nil {
+		o.keyScope(aggregatedKey).Counter(metricShadowError).Inc(1)
+		o.logger.With("err", err).With("key", aggregatedKey).Error(ctx, "Failed to open stream to shadow server")
+		return nil
+	}
+	o.goroutines.goroutine(subsystemShadowReceiver, func() {
+		o.watchShadow(ctx, aggregatedKey, responseChannel, done, shutdown)
+	})
+	return resubscribe
+}
+
+// watchShadow records each shadow response for the aggregated key and
+// compares it with the cached origin server response. Shadow responses are
+// never cached or served downstream.
+func (o *orchestrator) watchShadow(
+	ctx context.Context,
+	aggregatedKey string,
+	responseChannel <-chan *discovery.DiscoveryResponse,
+	done <-chan bool,
+	shutdownShadow func(),
+) {
+	for {
+		select {
+		case resp, more := <-responseChannel:
+			if !more {
+				o.keyScope(aggregatedKey).Counter(metricShadowError).Inc(1)
+				o.logger.With("key", aggregatedKey).Error(ctx, "shadow upstream error")
+				return
+			}
+			// Shadow responses are transformed like origin server responses
+			// so that the two are comparable.
+			resp, ok := o.transform(ctx, aggregatedKey, resp)
+			if !ok {
+				continue
+			}
+			o.shadowResponses.Store(aggregatedKey, resp)
+			o.compareShadow(ctx, aggregatedKey)
+		case <-done:
+			shutdownShadow()
+			return
+		}
+	}
+}
+
+// compareShadow diffs the latest shadow response for the aggregated key
+// against the cached origin server response, if both have been received. The
+// summary lists resources that only the shadow server returned as added,
+// resources that it omitted as removed, and resources whose value differs as
+// modified.
+func (o *orchestrator) compareShadow(ctx context.Context, aggregatedKey string) {
+	shadow, ok := o.shadowResponses.Load(aggregatedKey)
+	if !ok {
+		return
+	}
+	cached, err := o.cache.Fetch(aggregatedKey)
+	if err != nil || cached == nil || cached.Resp == nil {
+		return
+	}
+	summary := diff.Compute(cached.Resp, shadow.(*discovery.DiscoveryResponse))
+	o.shadowDiffs.Store(aggregatedKey, summary)
+
+	if summary.PreviousVersion != summary.Version {
+		o.keyScope(aggregatedKey).Counter(metricShadowVersionSkew).Inc(1)
+	}
+	if summary.IsEmpty() {
+		o.keyScope(aggregatedKey).Counter(metricShadowMatch).Inc(1)
+		return
+	}
+	o.keyScope(aggregatedKey).Counter(metricShadowMismatch).Inc(1)
+	o.logger.With("key", aggregatedKey).
+		With("version", summary.PreviousVersion).With("shadow version", summary.Version).
+		With("added", summary.Added).With("removed", summary.Removed).With("modified", summary.Modified).
+		Warn(ctx, "shadow response differs")
+}
@@ -0,0 +1,36 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file verifies the signatures of upstream responses. The contents of
+// this file are intended to only be used within the orchestrator module and
+// should not be exported.
+package orchestrator
+
+import (
+	"context"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+)
+
+const (
+	metricSignatureInvalid = "signature_invalid"
+)
+
+// isVerified returns false if the upstream response is unsigned or its
+// signature is invalid, in which case the response must be dropped rather
+// than cached and served.
+func (o *orchestrator) isVerified(ctx context.Context, aggregatedKey string, resp *discovery.DiscoveryResponse) bool {
+	if o.verifier == nil {
+		return true
+	}
+	if err := o.verifier.Verify(resp); err != nil {
+		o.keyScope(aggregatedKey).Counter(metricSignatureInvalid).Inc(1)
+		o.logger.With("err", err).With("key", aggregatedKey).Error(ctx, "failed to verify response, dropping")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,262 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file maps downstream requests with candidate aggregation rules
+// alongside the serving rules, and reports how the aggregated keys would
+// change if the candidate rules were rolled out. The contents of this file are
+// intended to only be used within the orchestrator module and should not be
+// exported, except for the summary shown by the admin server.
+package orchestrator
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/uber-go/tally"
+)
+
+const (
+	// defaultSimulatedNodes is the number of nodes and type URLs tracked if
+	// the config leaves it unset.
+	defaultSimulatedNodes = 10000
+
+	metricSubscopeSimulation = "simulation"
+
+	metricSimulationRemapped       = "remapped"
+	metricSimulationCandidateError = "candidate_error"
+	metricSimulationActiveChurn    = "active_churn"
+	metricSimulationCandidateChurn = "candidate_churn"
+	metricSimulationUntracked      = "untracked"
+	metricSimulationActiveKeys     = "active_keys"
+	metricSimulationCandidateKeys  = "candidate_keys"
+	metricSimulationRemappedNodes  = "remapped_nodes"
+)
+
+// SimulationSummary is how the aggregated keys of the latest requests of the
+// tracked nodes would change if the candidate aggregation rules replaced the
+// serving rules.
+type SimulationSummary struct {
+	// Nodes is the number of tracked nodes and type URLs.
+	Nodes int
+	// ActiveKeys and CandidateKeys are the numbers of distinct keys that the
+	// tracked nodes are mapped to by the serving and the candidate rules.
+	ActiveKeys    int
+	CandidateKeys int
+	// ActiveChurn and CandidateChurn are the numbers of requests whose key
+	// differs from the key of the previous request of the node and type URL,
+	// with the serving and the candidate rules.
+	ActiveChurn    int64
+	CandidateChurn int64
+	// SplitKeys are the keys of the serving rules whose nodes the candidate
+	// rules spread across several keys, and MergedKeys the keys of the
+	// candidate rules that collect the nodes of several serving keys.
+	SplitKeys  []string
+	MergedKeys []string
+	// Remapped are the tracked nodes and type URLs whose candidate key differs
+	// from their serving key, ordered by node ID and type URL.
+	Remapped []RemappedNode
+}
+
+// RemappedNode is a node whose requests of the type URL the candidate rules
+// map to a different key than the serving rules.
+type RemappedNode struct {
+	NodeID       string
+	TypeURL      string
+	ActiveKey    string
+	CandidateKey string
+	// Error is why the candidate rules failed to map the request, in which
+	// case the candidate key is empty.
+	Error string
+}
+
+// simulatedNode identifies the requests of a node of a type URL.
+type simulatedNode struct {
+	nodeID  string
+	typeURL string
+}
+
+// simulatedKeys are the keys of the latest request of a node and type URL.
+type simulatedKeys struct {
+	active    string
+	candidate string
+	err       string
+}
+
+// keySimulation maps requests with the candidate mapper, and tracks the keys
+// of the latest request of every node and type URL with both mappers.
+type keySimulation struct {
+	candidate mapper.Mapper
+	maxNodes  int
+	scope     tally.Scope
+
+	mu             sync.Mutex
+	nodes          map[simulatedNode]simulatedKeys
+	activeKeys     map[string]int
+	candidateKeys  map[string]int
+	remapped       int
+	activeChurn    int64
+	candidateChurn int64
+}
+
+func newKeySimulation(
+	config *bootstrapv1.AggregationSimulation,
+	candidate mapper.Mapper,
+	scope tally.Scope,
+) *keySimulation {
+	maxNodes := int(config.GetMaxNodes())
+	if maxNodes == 0 {
+		maxNodes = defaultSimulatedNodes
+	}
+	return &keySimulation{
+		candidate:     candidate,
+		maxNodes:      maxNodes,
+		scope:         scope,
+		nodes:         make(map[simulatedNode]simulatedKeys),
+		activeKeys:    make(map[string]int),
+		candidateKeys: make(map[string]int),
+	}
+}
+
+// simulate maps the request with the candidate mapper, and records its key
+// alongside the key the serving mapper mapped it to.
+func (o *orchestrator) simulate(ctx context.Context, req gcp.Request, activeKey string) {
+	s := o.keySimulation
+	keys := simulatedKeys{active: activeKey}
+	candidateKey, err := mapper.GetKeyWithTransport(s.candidate, req, transportFromContext(ctx))
+	if err != nil {
+		s.scope.Counter(metricSimulationCandidateError).Inc(1)
+		o.logger.With("err", err).With("req node", req.GetNode().GetId()).
+			Debug(ctx, "candidate aggregation rules failed to map request")
+		keys.err = err.Error()
+	} else {
+		keys.candidate = candidateKey
+	}
+	if keys.candidate != keys.active {
+		s.scope.Counter(metricSimulationRemapped).Inc(1)
+	}
+	s.record(simulatedNode{nodeID: req.GetNode().GetId(), typeURL: req.GetTypeUrl()}, keys)
+}
+
+// record tracks the keys of the latest request of the node, unless the
+// maximum number of nodes are already tracked.
+func (s *keySimulation) record(node simulatedNode, keys simulatedKeys) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, ok := s.nodes[node]
+	if !ok && len(s.nodes) >= s.maxNodes {
+		s.scope.Counter(metricSimulationUntracked).Inc(1)
+		return
+	}
+	if ok {
+		if previous.active != keys.active {
+			s.activeChurn++
+			s.scope.Counter(metricSimulationActiveChurn).Inc(1)
+		}
+		if previous.candidate != keys.candidate {
+			s.candidateChurn++
+			s.scope.Counter(metricSimulationCandidateChurn).Inc(1)
+		}
+		s.untrack(previous)
+	}
+	s.nodes[node] = keys
+	s.activeKeys[keys.active]++
+	if keys.candidate != "" {
+		s.candidateKeys[keys.candidate]++
+	}
+	if keys.candidate != keys.active {
+		s.remapped++
+	}
+	s.scope.Gauge(metricSimulationActiveKeys).Update(float64(len(s.activeKeys)))
+	s.scope.Gauge(metricSimulationCandidateKeys).Update(float64(len(s.candidateKeys)))
+	s.scope.Gauge(metricSimulationRemappedNodes).Update(float64(s.remapped))
+}
+
+// untrack removes the keys of a node that are replaced from the counts of
+// distinct and remapped keys.
+func (s *keySimulation) untrack(keys simulatedKeys) {
+	if s.activeKeys[keys.active]--; s.activeKeys[keys.active] == 0 {
+		delete(s.activeKeys, keys.active)
+	}
+	if keys.candidate != "" {
+		if s.candidateKeys[keys.candidate]--; s.candidateKeys[keys.candidate] == 0 {
+			delete(s.candidateKeys, keys.candidate)
+		}
+	}
+	if keys.candidate != keys.active {
+		s.remapped--
+	}
+}
+
+func (s *keySimulation) summary() SimulationSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	summary := SimulationSummary{
+		Nodes:          len(s.nodes),
+		ActiveKeys:     len(s.activeKeys),
+		CandidateKeys:  len(s.candidateKeys),
+		ActiveChurn:    s.activeChurn,
+		CandidateChurn: s.candidateChurn,
+	}
+	candidatesOf := make(map[string]map[string]bool)
+	activesOf := make(map[string]map[string]bool)
+	for node, keys := range s.nodes {
+		if keys.candidate != keys.active {
+			summary.Remapped = append(summary.Remapped, RemappedNode{
+				NodeID:       node.nodeID,
+				TypeURL:      node.typeURL,
+				ActiveKey:    keys.active,
+				CandidateKey: keys.candidate,
+				Error:        keys.err,
+			})
+		}
+		if keys.candidate == "" {
+			continue
+		}
+		addToSet(candidatesOf, keys.active, keys.candidate)
+		addToSet(activesOf, keys.candidate, keys.active)
+	}
+	for key, candidates := range candidatesOf {
+		if len(candidates) > 1 {
+			summary.SplitKeys = append(summary.SplitKeys, key)
+		}
+	}
+	for key, actives := range activesOf {
+		if len(actives) > 1 {
+			summary.MergedKeys = append(summary.MergedKeys, key)
+		}
+	}
+	sort.Strings(summary.SplitKeys)
+	sort.Strings(summary.MergedKeys)
+	sort.Slice(summary.Remapped, func(i, j int) bool {
+		if summary.Remapped[i].NodeID != summary.Remapped[j].NodeID {
+			return summary.Remapped[i].NodeID < summary.Remapped[j].NodeID
+		}
+		return summary.Remapped[i].TypeURL < summary.Remapped[j].TypeURL
+	})
+	return summary
+}
+
+func addToSet(sets map[string]map[string]bool, key string, value string) {
+	if sets[key] == nil {
+		sets[key] = make(map[string]bool)
+	}
+	sets[key][value] = true
+}
+
+// GetSimulation returns how the aggregated keys would change if the candidate
+// aggregation rules replaced the serving rules. It returns false if no
+// candidate rules are simulated.
+func (o *orchestrator) GetSimulation() (SimulationSummary, bool) {
+	if o.keySimulation == nil {
+		return SimulationSummary{}, false
+	}
+	return o.keySimulation.summary(), true
+}
@@ -0,0 +1,82 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulation(t *testing.T) {
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), mockSimpleUpstreamClient{})
+	_, ok := orchestrator.GetSimulation()
+	assert.False(t, ok)
+
+	WithAggregationSimulation(&bootstrapv1.AggregationSimulation{MaxNodes: 2}, newTenantMapper())(orchestrator)
+	getKey := func(nodeID string, cluster string) string {
+		key, err := orchestrator.getAggregatedKey(context.Background(), gcp.Request{
+			TypeUrl: upstream.ListenerTypeURL,
+			Node:    &v2_core.Node{Id: nodeID, Cluster: cluster},
+		})
+		assert.NoError(t, err)
+		return key
+	}
+
+	// Requests are served with the keys of the mapper.
+	assert.Equal(t, "lds", getKey("a", "tenant-a"))
+	assert.Equal(t, "lds", getKey("b", "tenant-b"))
+	summary, ok := orchestrator.GetSimulation()
+	assert.True(t, ok)
+	assert.Equal(t, SimulationSummary{
+		Nodes:         2,
+		ActiveKeys:    1,
+		CandidateKeys: 2,
+		SplitKeys:     []string{"lds"},
+		Remapped: []RemappedNode{
+			{NodeID: "a", TypeURL: upstream.ListenerTypeURL, ActiveKey: "lds", CandidateKey: "tenant-a/lds"},
+			{NodeID: "b", TypeURL: upstream.ListenerTypeURL, ActiveKey: "lds", CandidateKey: "tenant-b/lds"},
+		},
+	}, summary)
+
+	// The keys of the latest request of every node are tracked.
+	getKey("a", "tenant-b")
+	summary, _ = orchestrator.GetSimulation()
+	assert.Equal(t, 2, summary.Nodes)
+	assert.Equal(t, 1, summary.CandidateKeys)
+	assert.Equal(t, int64(0), summary.ActiveChurn)
+	assert.Equal(t, int64(1), summary.CandidateChurn)
+	assert.Empty(t, summary.SplitKeys)
+	assert.Equal(t, "tenant-b/lds", summary.Remapped[0].CandidateKey)
+
+	// Further nodes are not tracked.
+	getKey("c", "tenant-c")
+	summary, _ = orchestrator.GetSimulation()
+	assert.Equal(t, 2, summary.Nodes)
+	counters := mockScope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counterValue(counters, "prefix.simulation.untracked+"))
+	assert.Equal(t, int64(4), counterValue(counters, "prefix.simulation.remapped+"))
+	assert.Equal(t, float64(1), mockScope.Snapshot().Gauges()["prefix.simulation.candidate_keys+"].Value())
+}
+
+func TestSimulationMergedKeys(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), newTenantMapper(), mockSimpleUpstreamClient{})
+	WithAggregationSimulation(&bootstrapv1.AggregationSimulation{}, mapper.NewMock(t))(orchestrator)
+	for _, node := range []*v2_core.Node{{Id: "a", Cluster: "tenant-a"}, {Id: "b", Cluster: "tenant-b"}} {
+		_, err := orchestrator.getAggregatedKey(context.Background(), gcp.Request{
+			TypeUrl: upstream.ListenerTypeURL,
+			Node:    node,
+		})
+		assert.NoError(t, err)
+	}
+	summary, _ := orchestrator.GetSimulation()
+	assert.Equal(t, []string{"lds"}, summary.MergedKeys)
+	assert.Empty(t, summary.SplitKeys)
+	assert.Equal(t, 2, summary.ActiveKeys)
+	assert.Equal(t, 1, summary.CandidateKeys)
+}
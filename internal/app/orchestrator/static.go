@@ -0,0 +1,41 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file serves static responses in place of the origin server. The
+// contents of this file are intended to only be used within the orchestrator
+// module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+)
+
+// cacheStaticResponse caches the static response for the request, unless it
+// is already cached. It returns false if the request has no static response,
+// in which case it must be forwarded upstream.
+func (o *orchestrator) cacheStaticResponse(ctx context.Context, aggregatedKey string, req gcp.Request) bool {
+	if o.staticResponses == nil {
+		return false
+	}
+	resp, ok := o.staticResponses.Get(aggregatedKey, req.GetTypeUrl())
+	if !ok {
+		return false
+	}
+	var previous *discovery.DiscoveryResponse
+	if cached, err := o.cache.Fetch(aggregatedKey); err == nil && cached != nil {
+		previous = cached.Resp
+	}
+	if previous == nil || previous.GetVersionInfo() != resp.GetVersionInfo() {
+		o.logger.With("key", aggregatedKey).With("version", resp.GetVersionInfo()).
+			Debug(ctx, "caching static response")
+		o.applyResponse(ctx, aggregatedKey, previous, resp)
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file tracks the resource names subscribed to upstream for each
+// aggregated key. The contents of this file are intended to only be used
+// within the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+)
+
+const (
+	metricSubscriptionUpdate = "subscription_update"
+)
+
+// wildcardTypeURLs are the types whose subscriptions always receive every
+// resource, regardless of the resource names of the request. Resources of
+// other types are subscribed to by name.
+var wildcardTypeURLs = map[string]bool{
+	upstream.ListenerTypeURL: true,
+	upstream.ClusterTypeURL:  true,
+}
+
+// subscription is the set of resource names subscribed to by the upstream
+// stream of an aggregated key, and is owned by the lifecycle of the key. The
+// mutex serializes opening and updating the stream.
+type subscription struct {
+	mu sync.Mutex
+	// resourceNames is sorted, and nil if wildcard is set.
+	resourceNames []string
+	wildcard      bool
+	// resubscribe and resubscribeShadow update the resource names of the
+	// origin and shadow server streams. They are nil if the streams cannot be
+	// resubscribed in place.
+	resubscribe       func([]string)
+	resubscribeShadow func([]string)
+}
+
+// matches returns true if the subscription holds exactly the resource names.
+func (s *subscription) matches(wildcard bool, resourceNames []string) bool {
+	if s.wildcard || wildcard {
+		return s.wildcard == wildcard
+	}
+	if len(s.resourceNames) != len(resourceNames) {
+		return false
+	}
+	for i := range resourceNames {
+		if s.resourceNames[i] != resourceNames[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// unionResourceNames returns the resource names to subscribe to upstream on
+// behalf of the watches. The subscription is a wildcard if the type is always
+// subscribed to by wildcard, or if any watch subscribes to every resource by
+// requesting no names. Otherwise it is the sorted union of the names of the
+// watches.
+func unionResourceNames(typeURL string, watches []*gcp.Request) (bool, []string) {
+	if wildcardTypeURLs[typeURL] {
+		return true, nil
+	}
+	union := make(map[string]bool)
+	for _, watch := range watches {
+		if len(watch.GetResourceNames()) == 0 {
+			return true, nil
+		}
+		for _, name := range watch.GetResourceNames() {
+			union[name] = true
+		}
+	}
+	resourceNames := make([]string, 0, len(union))
+	for name := range union {
+		resourceNames = append(resourceNames, name)
+	}
+	sort.Strings(resourceNames)
+	return false, resourceNames
+}
+
+// subscribedResourceNames returns the resource names to subscribe to upstream
+// for the open watches of the aggregated key. If none are cached, the request
+// is taken to be the only watch.
+func (o *orchestrator) subscribedResourceNames(aggregatedKey string, req gcp.Request) (bool, []string) {
+	if wildcardTypeURLs[req.GetTypeUrl()] {
+		return true, nil
+	}
+	return unionResourceNames(req.GetTypeUrl(), o.getWatches(aggregatedKey, req))
+}
+
+func (o *orchestrator) getWatches(aggregatedKey string, req gcp.Request) []*gcp.Request {
+	cached, err := o.cache.Fetch(aggregatedKey)
+	if err != nil || cached == nil || len(cached.Requests) == 0 {
+		return []*gcp.Request{&req}
+	}
+	watches := make([]*gcp.Request, 0, len(cached.Requests))
+	for _, watch := range cached.Requests {
+		watches = append(watches, watch)
+	}
+	return watches
+}
+
+// shrinkSubscription resubscribes upstream after a watch of the aggregated key
+// is cancelled, so that names no other watch requested are dropped. The
+// subscription is kept as is once no watches remain, until the aggregated key
+// is evicted.
+func (o *orchestrator) shrinkSubscription(aggregatedKey string) {
+	req, ok := o.representativeRequests.Load(aggregatedKey)
+	if !ok {
+		return
+	}
+	cached, err := o.cache.Fetch(aggregatedKey)
+	if err != nil || cached == nil || len(cached.Requests) == 0 {
+		return
+	}
+	o.upstreamMu.RLock()
+	defer o.upstreamMu.RUnlock()
+	if o.isLeader() && o.upstreamResponseMap.exists(aggregatedKey) {
+		o.openUpstream(context.Background(), aggregatedKey, req.(gcp.Request))
+	}
+}
+
+// openStream opens a stream with the client for the aggregated key, along
+// with the function that resubscribes it if the client supports resubscribing
+// in place. The stream carries the request ID of the downstream request that
+// opened it, if any and if the client supports it.
+func openStream(
+	client upstream.Client,
+	aggregatedKey string,
+	req gcp.Request,
+	requestID string,
+) (<-chan *discovery.DiscoveryResponse, func([]string), func(), error) {
+	if keyedClient, ok := client.(upstream.KeyedClient); ok {
+		responseChannel, shutdown, err := keyedClient.OpenKeyedStream(aggregatedKey, req)
+		return responseChannel, nil, shutdown, err
+	}
+	if tracedClient, ok := client.(upstream.TracedClient); ok && requestID != "" {
+		return tracedClient.OpenTracedStream(req, requestID)
+	}
+	if resubscribableClient, ok := client.(upstream.ResubscribableClient); ok {
+		return resubscribableClient.OpenResubscribableStream(req)
+	}
+	responseChannel, shutdown, err := client.OpenStream(req)
+	return responseChannel, nil, shutdown, err
+}
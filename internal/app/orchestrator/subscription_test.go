@@ -0,0 +1,170 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// typeURLMapper maps requests to their type URL.
+type typeURLMapper struct{}
+
+func (typeURLMapper) GetKey(req v2.DiscoveryRequest) (string, error) {
+	return req.GetTypeUrl(), nil
+}
+
+// mockSubscriptionUpstreamClient records the requests that streams are opened
+// with, and the number of streams that were shut down.
+type mockSubscriptionUpstreamClient struct {
+	requests chan v2.DiscoveryRequest
+	shutdown chan struct{}
+}
+
+func newMockSubscriptionUpstreamClient() mockSubscriptionUpstreamClient {
+	return mockSubscriptionUpstreamClient{
+		requests: make(chan v2.DiscoveryRequest, 10),
+		shutdown: make(chan struct{}, 10),
+	}
+}
+
+func (m mockSubscriptionUpstreamClient) OpenStream(
+	req v2.DiscoveryRequest,
+) (<-chan *v2.DiscoveryResponse, func(), error) {
+	m.requests <- req
+	return make(chan *v2.DiscoveryResponse), func() { m.shutdown <- struct{}{} }, nil
+}
+
+func TestUnionResourceNames(t *testing.T) {
+	wildcard, names := unionResourceNames(upstream.ClusterTypeURL, []*gcp.Request{
+		{TypeUrl: upstream.ClusterTypeURL, ResourceNames: []string{"a"}},
+	})
+	assert.True(t, wildcard)
+	assert.Nil(t, names)
+
+	wildcard, names = unionResourceNames(upstream.EndpointTypeURL, []*gcp.Request{
+		{TypeUrl: upstream.EndpointTypeURL, ResourceNames: []string{"c", "a"}},
+		{TypeUrl: upstream.EndpointTypeURL, ResourceNames: []string{"b", "a"}},
+	})
+	assert.False(t, wildcard)
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+
+	wildcard, names = unionResourceNames(upstream.RouteTypeURL, []*gcp.Request{
+		{TypeUrl: upstream.RouteTypeURL, ResourceNames: []string{"a"}},
+		{TypeUrl: upstream.RouteTypeURL},
+	})
+	assert.True(t, wildcard)
+	assert.Nil(t, names)
+}
+
+func TestSubscriptionUnion(t *testing.T) {
+	upstreamClient := newMockSubscriptionUpstreamClient()
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, typeURLMapper{}, upstreamClient)
+
+	_, cancelFirst := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl:       upstream.EndpointTypeURL,
+		ResourceNames: []string{"b"},
+	})
+	assert.Equal(t, []string{"b"}, (<-upstreamClient.requests).ResourceNames)
+
+	// A watch for additional names reopens the stream with the union, since
+	// the client cannot resubscribe in place.
+	_, cancelSecond := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl:       upstream.EndpointTypeURL,
+		ResourceNames: []string{"c", "a"},
+	})
+	<-upstreamClient.shutdown
+	assert.Equal(t, []string{"a", "b", "c"}, (<-upstreamClient.requests).ResourceNames)
+
+	// A watch for names that are already subscribed to does not.
+	_, cancelThird := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl:       upstream.EndpointTypeURL,
+		ResourceNames: []string{"a"},
+	})
+	assert.Equal(t, 0, len(upstreamClient.requests))
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.subscription_update", 1)
+
+	// Cancelling watches drops the names no other watch requested.
+	cancelSecond()
+	<-upstreamClient.shutdown
+	assert.Equal(t, []string{"a", "b"}, (<-upstreamClient.requests).ResourceNames)
+	cancelThird()
+	<-upstreamClient.shutdown
+	assert.Equal(t, []string{"b"}, (<-upstreamClient.requests).ResourceNames)
+
+	// The subscription is kept once the last watch is cancelled.
+	cancelFirst()
+	assert.Equal(t, 0, len(upstreamClient.requests))
+	assert.Equal(t, 0, len(upstreamClient.shutdown))
+}
+
+func TestSubscriptionWildcard(t *testing.T) {
+	upstreamClient := newMockSubscriptionUpstreamClient()
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), typeURLMapper{}, upstreamClient)
+
+	// Clusters are subscribed to by wildcard regardless of resource names.
+	_, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl:       upstream.ClusterTypeURL,
+		ResourceNames: []string{"a"},
+	})
+	defer cancelWatch()
+	assert.Nil(t, (<-upstreamClient.requests).ResourceNames)
+
+	_, cancelNamed := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl:       upstream.RouteTypeURL,
+		ResourceNames: []string{"a"},
+	})
+	defer cancelNamed()
+	assert.Equal(t, []string{"a"}, (<-upstreamClient.requests).ResourceNames)
+
+	// A watch without names subscribes to every route.
+	_, cancelWildcard := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl: upstream.RouteTypeURL,
+	})
+	defer cancelWildcard()
+	<-upstreamClient.shutdown
+	assert.Nil(t, (<-upstreamClient.requests).ResourceNames)
+}
+
+func TestSubscriptionResubscribe(t *testing.T) {
+	requests := make(chan v2.DiscoveryRequest, 10)
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	upstreamClient := upstream.NewMock(context.Background(), upstream.CallOptions{Timeout: time.Second}, nil,
+		upstreamResponseChannel, func(m interface{}) error {
+			requests <- *m.(*v2.DiscoveryRequest)
+			return nil
+		})
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), typeURLMapper{}, upstreamClient)
+
+	respChannel, cancelFirst := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl:       upstream.EndpointTypeURL,
+		ResourceNames: []string{"b"},
+	})
+	defer cancelFirst()
+	assert.Equal(t, []string{"b"}, (<-requests).ResourceNames)
+	upstreamResponseChannel <- &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.EndpointTypeURL}
+	<-respChannel
+	assert.Equal(t, "1", (<-requests).VersionInfo)
+
+	// Additional names are requested on the open stream, which carries the
+	// version of the latest response.
+	_, cancelSecond := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl:       upstream.EndpointTypeURL,
+		ResourceNames: []string{"a"},
+	})
+	request := <-requests
+	assert.Equal(t, []string{"a", "b"}, request.ResourceNames)
+	assert.Equal(t, "1", request.VersionInfo)
+
+	// Names are dropped from the open stream when their watches are cancelled.
+	cancelSecond()
+	request = <-requests
+	assert.Equal(t, []string{"b"}, request.ResourceNames)
+	assert.Equal(t, "1", request.VersionInfo)
+}
@@ -0,0 +1,103 @@
+package orchestrator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/transform"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+)
+
+// newTenantMapper maps requests to their type URL, prefixed with the node
+// cluster as the tenant.
+func newTenantMapper() mapper.Mapper {
+	return mapper.New(&aggregationv1.KeyerConfiguration{
+		Fragments: []*aggregationv1.KeyerConfiguration_Fragment{
+			{
+				Rules: []*aggregationv1.KeyerConfiguration_Fragment_Rule{
+					{
+						Match: &aggregationv1.MatchPredicate{
+							Type: &aggregationv1.MatchPredicate_AnyMatch{AnyMatch: true},
+						},
+						Result: &aggregationv1.ResultPredicate{
+							Type: &aggregationv1.ResultPredicate_StringFragment{StringFragment: "lds"},
+						},
+					},
+				},
+			},
+		},
+		Tenant: &aggregationv1.KeyerConfiguration_Fragment{
+			Rules: []*aggregationv1.KeyerConfiguration_Fragment_Rule{
+				{
+					Match: &aggregationv1.MatchPredicate{
+						Type: &aggregationv1.MatchPredicate_AnyMatch{AnyMatch: true},
+					},
+					Result: &aggregationv1.ResultPredicate{
+						Type: &aggregationv1.ResultPredicate_RequestNodeFragment_{
+							RequestNodeFragment: &aggregationv1.ResultPredicate_RequestNodeFragment{
+								Field: aggregationv1.NodeFieldType_NODE_CLUSTER,
+								Action: &aggregationv1.ResultPredicate_ResultAction{
+									Action: &aggregationv1.ResultPredicate_ResultAction_Exact{Exact: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestTenantIsolation(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, newTenantMapper(),
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	orchestrator.transformer = transform.Func(func(resp *v2.DiscoveryResponse) (*v2.DiscoveryResponse, error) {
+		return nil, fmt.Errorf("failed")
+	})
+
+	_, cancelA := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &v2_core.Node{Id: "a", Cluster: "tenant-a"},
+	})
+	defer cancelA()
+	_, cancelB := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &v2_core.Node{Id: "b", Cluster: "tenant-b"},
+	})
+	defer cancelB()
+
+	// Requests of different tenants never share an aggregated key.
+	workers := orchestrator.GetWorkers()
+	assert.Equal(t, 2, len(workers))
+	assert.Equal(t, "tenant-a/lds", workers[0].Key)
+	assert.Equal(t, "tenant-a", workers[0].Tenant)
+	assert.Equal(t, "tenant-b/lds", workers[1].Key)
+	assert.Equal(t, "tenant-b", workers[1].Tenant)
+
+	// Metrics of aggregated keys are tagged with their tenant.
+	upstreamResponseChannel <- &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ListenerTypeURL}
+	upstreamResponseChannel <- &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ListenerTypeURL}
+	assert.Eventually(t, func() bool {
+		counters := mockScope.Snapshot().Counters()
+		return counterValue(counters, "prefix.transform_error+tenant=tenant-a")+
+			counterValue(counters, "prefix.transform_error+tenant=tenant-b") == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int64(0), counterValue(mockScope.Snapshot().Counters(), "prefix.transform_error+"))
+}
+
+func counterValue(counters map[string]tally.CounterSnapshot, name string) int64 {
+	if counter, ok := counters[name]; ok {
+		return counter.Value()
+	}
+	return 0
+}
@@ -0,0 +1,113 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file applies the configured transformations and overrides to upstream
+// responses. The contents of this file are intended to only be used within
+// the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"sync"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+)
+
+const (
+	metricTransformError = "transform_error"
+	metricOverrideError  = "override_error"
+)
+
+// overriddenResponse holds the latest upstream response for an aggregated key
+// before overrides are applied, so that it can be patched again when the
+// overrides change.
+type overriddenResponse struct {
+	// mu serializes caching the patched response between upstream responses
+	// and override changes.
+	mu   sync.Mutex
+	resp *discovery.DiscoveryResponse
+}
+
+// transform applies the transformer to an upstream response. It returns false
+// if the transformation failed, in which case the response must be dropped
+// rather than served partially transformed.
+func (o *orchestrator) transform(
+	ctx context.Context,
+	aggregatedKey string,
+	resp *discovery.DiscoveryResponse,
+) (*discovery.DiscoveryResponse, bool) {
+	if o.transformer == nil {
+		return resp, true
+	}
+	transformed, err := o.transformer.Transform(resp)
+	if err != nil {
+		o.keyScope(aggregatedKey).Counter(metricTransformError).Inc(1)
+		o.logger.With("err", err).With("key", aggregatedKey).With("version", resp.GetVersionInfo()).
+			Error(ctx, "failed to transform response, dropping")
+		return nil, false
+	}
+	return transformed, true
+}
+
+// applyUpstreamResponse applies the overrides for the aggregated key to the
+// upstream response, if overrides are configured, and caches and fans out the
+// result.
+func (o *orchestrator) applyUpstreamResponse(
+	ctx context.Context,
+	aggregatedKey string,
+	previous *discovery.DiscoveryResponse,
+	resp *discovery.DiscoveryResponse,
+) {
+	if o.overrides == nil {
+		o.applyResponse(ctx, aggregatedKey, previous, resp)
+		return
+	}
+	value, _ := o.overriddenResponses.LoadOrStore(aggregatedKey, &overriddenResponse{})
+	overridden := value.(*overriddenResponse)
+	overridden.mu.Lock()
+	defer overridden.mu.Unlock()
+	overridden.resp = resp
+	o.applyResponse(ctx, aggregatedKey, previous, o.override(ctx, aggregatedKey, resp))
+}
+
+// reapplyOverrides patches the latest upstream response of every aggregated
+// key with the current overrides, and caches and fans out the result.
+func (o *orchestrator) reapplyOverrides() {
+	ctx := context.Background()
+	o.overriddenResponses.Range(func(key, value interface{}) bool {
+		aggregatedKey, overridden := key.(string), value.(*overriddenResponse)
+		overridden.mu.Lock()
+		defer overridden.mu.Unlock()
+		if overridden.resp == nil {
+			return true
+		}
+		var previous *discovery.DiscoveryResponse
+		if cached, err := o.cache.Fetch(aggregatedKey); err == nil && cached != nil {
+			previous = cached.Resp
+		}
+		o.applyResponse(ctx, aggregatedKey, previous, o.override(ctx, aggregatedKey, overridden.resp))
+		return true
+	})
+}
+
+// override returns the response with the overrides for the aggregated key
+// applied. If the overrides fail to apply, the upstream response is returned
+// so that a broken override does not block upstream updates.
+func (o *orchestrator) override(
+	ctx context.Context,
+	aggregatedKey string,
+	resp *discovery.DiscoveryResponse,
+) *discovery.DiscoveryResponse {
+	patched, err := o.overrides.Apply(aggregatedKey, resp)
+	if err != nil {
+		o.keyScope(aggregatedKey).Counter(metricOverrideError).Inc(1)
+		o.logger.With("err", err).With("key", aggregatedKey).With("version", resp.GetVersionInfo()).
+			Error(ctx, "failed to apply overrides, serving the upstream response")
+		return resp
+	}
+	return patched
+}
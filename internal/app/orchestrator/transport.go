@@ -0,0 +1,112 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file captures the transport of downstream streams, such as their gRPC
+// metadata headers and the SANs of their client certificates, so that the
+// aggregation rules can key on the identity that clients authenticated with.
+// The contents of this file are intended to only be used within the
+// orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+type transportKey struct{}
+
+// withTransport returns a copy of ctx that carries the transport that the
+// request on whose behalf the orchestrator acts is mapped with.
+func withTransport(ctx context.Context, transport mapper.Transport) context.Context {
+	if transport == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, transportKey{}, transport)
+}
+
+func transportFromContext(ctx context.Context) mapper.Transport {
+	transport, _ := ctx.Value(transportKey{}).(mapper.Transport)
+	return transport
+}
+
+// captureTransport returns the values of the transport keys that the mapper,
+// and the candidate mapper of the simulation, refer to, from the context of a
+// downstream stream or fetch. It returns nil if they refer to no transport
+// key.
+func (o *orchestrator) captureTransport(ctx context.Context) mapper.Transport {
+	keys := o.transportKeys()
+	if len(keys) == 0 {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	certificate := peerCertificate(ctx)
+	transport := make(mapper.Transport)
+	for _, key := range keys {
+		var values []string
+		switch key {
+		case mapper.TransportURISAN:
+			if certificate != nil {
+				for _, uri := range certificate.URIs {
+					values = append(values, uri.String())
+				}
+			}
+		case mapper.TransportDNSSAN:
+			if certificate != nil {
+				values = certificate.DNSNames
+			}
+		default:
+			values = md.Get(key)
+		}
+		if len(values) > 0 {
+			transport[key] = values
+		}
+	}
+	return transport
+}
+
+// transportKeys returns the transport keys that the mapper and the candidate
+// mapper of the simulation refer to.
+func (o *orchestrator) transportKeys() []string {
+	mappers := []mapper.Mapper{o.mapper}
+	if o.keySimulation != nil {
+		mappers = append(mappers, o.keySimulation.candidate)
+	}
+	var keys []string
+	seen := make(map[string]bool)
+	for _, m := range mappers {
+		transportMapper, ok := m.(mapper.TransportMapper)
+		if !ok {
+			continue
+		}
+		for _, key := range transportMapper.TransportKeys() {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// peerCertificate returns the verified client certificate of the downstream
+// stream or fetch, or nil if it was not authenticated with TLS.
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return nil
+	}
+	return tlsInfo.State.PeerCertificates[0]
+}
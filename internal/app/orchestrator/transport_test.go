@@ -0,0 +1,178 @@
+package orchestrator
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// newTransportMapper maps requests to the first value of the transport key.
+func newTransportMapper(key string) mapper.Mapper {
+	return mapper.New(&aggregationv1.KeyerConfiguration{
+		Fragments: []*aggregationv1.KeyerConfiguration_Fragment{
+			{
+				Rules: []*aggregationv1.KeyerConfiguration_Fragment_Rule{
+					{
+						Match: &aggregationv1.MatchPredicate{
+							Type: &aggregationv1.MatchPredicate_AnyMatch{AnyMatch: true},
+						},
+						Result: transportResult(key),
+					},
+				},
+			},
+		},
+	})
+}
+
+// transportResult generates the first value of the transport key as is.
+func transportResult(key string) *aggregationv1.ResultPredicate {
+	return &aggregationv1.ResultPredicate{
+		Type: &aggregationv1.ResultPredicate_TransportFragment_{
+			TransportFragment: &aggregationv1.ResultPredicate_TransportFragment{
+				Key: key,
+				Action: &aggregationv1.ResultPredicate_ResultAction{
+					Action: &aggregationv1.ResultPredicate_ResultAction_Exact{Exact: true},
+				},
+			},
+		},
+	}
+}
+
+func TestCaptureTransport(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", "a", "x-other", "b"))
+	ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{
+			{
+				URIs:     []*url.URL{{Scheme: "spiffe", Host: "cluster.local", Path: "/ns/default/sa/envoy"}},
+				DNSNames: []string{"envoy.default.svc"},
+			},
+		},
+	}}})
+
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.New(&aggregationv1.KeyerConfiguration{
+		Fragments: []*aggregationv1.KeyerConfiguration_Fragment{
+			{
+				Rules: []*aggregationv1.KeyerConfiguration_Fragment_Rule{
+					{
+						Match: &aggregationv1.MatchPredicate{
+							Type: &aggregationv1.MatchPredicate_TransportMatch_{
+								TransportMatch: &aggregationv1.MatchPredicate_TransportMatch{
+									Key:  mapper.TransportDNSSAN,
+									Type: &aggregationv1.MatchPredicate_TransportMatch_ExactMatch{ExactMatch: "envoy.default.svc"},
+								},
+							},
+						},
+						Result: transportResult("X-Tenant"),
+					},
+					{
+						Match:  &aggregationv1.MatchPredicate{Type: &aggregationv1.MatchPredicate_AnyMatch{AnyMatch: true}},
+						Result: transportResult(mapper.TransportURISAN),
+					},
+				},
+			},
+		},
+	}), mockSimpleUpstreamClient{})
+
+	// Only the transport keys that the rules refer to are captured.
+	assert.Equal(t, mapper.Transport{
+		"x-tenant":             {"a"},
+		mapper.TransportURISAN: {"spiffe://cluster.local/ns/default/sa/envoy"},
+		mapper.TransportDNSSAN: {"envoy.default.svc"},
+	}, orchestrator.captureTransport(ctx))
+
+	// Streams without metadata or a client certificate have no transport
+	// values.
+	assert.Empty(t, orchestrator.captureTransport(context.Background()))
+
+	// Nothing is captured for mappers that refer to no transport key.
+	orchestrator.mapper = newTenantMapper()
+	assert.Nil(t, orchestrator.captureTransport(ctx))
+}
+
+func TestWatchesAreMappedWithTheTransportOfTheirStream(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), newTransportMapper("x-tenant"),
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", "tenant-a"))
+	assert.NoError(t, orchestrator.OnStreamOpen(ctx, 1, ""))
+	req := &v2.DiscoveryRequest{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &v2_core.Node{Id: "node"},
+	}
+	assert.NoError(t, orchestrator.OnStreamRequest(1, req))
+	_, cancelWatch := orchestrator.CreateWatch(*req)
+	defer cancelWatch()
+
+	workers := orchestrator.GetWorkers()
+	assert.Equal(t, 1, len(workers))
+	assert.Equal(t, "tenant-a", workers[0].Key)
+
+	// Requests of unknown streams have no transport values, so they cannot be
+	// mapped and are not aggregated.
+	other := gcp.Request{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &v2_core.Node{Id: "other"},
+	}
+	_, cancelOther := orchestrator.CreateWatch(other)
+	defer cancelOther()
+	workers = orchestrator.GetWorkers()
+	assert.Equal(t, 2, len(workers))
+	assert.Contains(t, []string{workers[0].Key, workers[1].Key}, mapper.UnaggregatedKey(other))
+
+	orchestrator.OnStreamClosed(1)
+}
+
+// mockKeyedUpstreamClient records the aggregated keys that streams are opened
+// for.
+type mockKeyedUpstreamClient struct {
+	mockSimpleUpstreamClient
+	keys chan string
+}
+
+func (m mockKeyedUpstreamClient) OpenKeyedStream(
+	aggregatedKey string,
+	req v2.DiscoveryRequest,
+) (<-chan *v2.DiscoveryResponse, func(), error) {
+	m.keys <- aggregatedKey
+	return m.OpenStream(req)
+}
+
+func TestKeyedClientStreamsAreOpenedForTheAggregatedKey(t *testing.T) {
+	upstreamClient := mockKeyedUpstreamClient{keys: make(chan string, 2)}
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), newTransportMapper("x-tenant"), upstreamClient)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", "tenant-a"))
+	assert.NoError(t, orchestrator.OnStreamOpen(ctx, 1, ""))
+	defer orchestrator.OnStreamClosed(1)
+	req := &v2.DiscoveryRequest{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &v2_core.Node{Id: "node"},
+	}
+	assert.NoError(t, orchestrator.OnStreamRequest(1, req))
+	_, cancelWatch := orchestrator.CreateWatch(*req)
+	defer cancelWatch()
+	assert.Equal(t, "tenant-a", <-upstreamClient.keys)
+
+	// Requests that are not aggregated are opened for their unaggregated key.
+	other := gcp.Request{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &v2_core.Node{Id: "other"},
+	}
+	_, cancelOther := orchestrator.CreateWatch(other)
+	defer cancelOther()
+	assert.Equal(t, mapper.UnaggregatedKey(other), <-upstreamClient.keys)
+}
@@ -0,0 +1,65 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file reports when each aggregated key was populated and updated, and
+// how often, so that keys that stopped receiving updates and keys that are
+// updated excessively can be detected. The contents of this file are intended
+// to only be used within the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+)
+
+const (
+	// metricCacheUpdate counts the responses cached for the aggregated keys,
+	// whose rate is the update rate of the keys of each tenant.
+	metricCacheUpdate = "cache_update"
+)
+
+// CacheStatus describes the updates of the cache entry of an aggregated key.
+type CacheStatus struct {
+	Key         string
+	Tenant      string
+	FirstSeen   time.Time
+	LastUpdated time.Time
+	UpdateCount uint64
+	// UpdateRate is the average number of updates per minute since the key
+	// was first populated.
+	UpdateRate float64
+}
+
+// GetCacheStatuses returns the status of the cache entry of every aggregated
+// key with an upstream stream, ordered by aggregated key.
+func (o *orchestrator) GetCacheStatuses() []CacheStatus {
+	workers := o.supervisor.list()
+	resources, err := o.cache.FetchAll(workerKeys(workers))
+	if err != nil {
+		return nil
+	}
+	currentTime := time.Now()
+	var statuses []CacheStatus
+	for _, worker := range workers {
+		if resource, ok := resources[worker.Key]; ok {
+			statuses = append(statuses, newCacheStatus(worker.Key, worker.Tenant, *resource, currentTime))
+		}
+	}
+	return statuses
+}
+
+func newCacheStatus(aggregatedKey string, tenant string, resource cache.Resource, currentTime time.Time) CacheStatus {
+	return CacheStatus{
+		Key:         aggregatedKey,
+		Tenant:      tenant,
+		FirstSeen:   resource.FirstSeen,
+		LastUpdated: resource.LastUpdated,
+		UpdateCount: resource.UpdateCount,
+		UpdateRate:  resource.UpdateRate(currentTime),
+	}
+}
@@ -0,0 +1,60 @@
+package orchestrator
+
+import (
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCacheStatuses(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t),
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+
+	respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{
+		TypeUrl: "type.googleapis.com/envoy.api.v2.Listener",
+	})
+	defer cancelWatch()
+	// The key is not populated until the first response is cached.
+	statuses := orchestrator.GetCacheStatuses()
+	assert.Equal(t, 1, len(statuses))
+	assert.Equal(t, "lds", statuses[0].Key)
+	assert.True(t, statuses[0].FirstSeen.IsZero())
+	assert.Equal(t, uint64(0), statuses[0].UpdateCount)
+
+	upstreamResponseChannel <- &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+	}
+	<-respChannel
+	statuses = orchestrator.GetCacheStatuses()
+	assert.Equal(t, 1, len(statuses))
+	assert.False(t, statuses[0].FirstSeen.IsZero())
+	assert.Equal(t, statuses[0].FirstSeen, statuses[0].LastUpdated)
+	assert.Equal(t, uint64(1), statuses[0].UpdateCount)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.cache_update", 1)
+}
+
+func TestNewCacheStatus(t *testing.T) {
+	currentTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	status := newCacheStatus("lds", "tenant", cache.Resource{
+		FirstSeen:   currentTime.Add(-2 * time.Minute),
+		LastUpdated: currentTime.Add(-time.Minute),
+		UpdateCount: 4,
+	}, currentTime)
+	assert.Equal(t, CacheStatus{
+		Key:         "lds",
+		Tenant:      "tenant",
+		FirstSeen:   currentTime.Add(-2 * time.Minute),
+		LastUpdated: currentTime.Add(-time.Minute),
+		UpdateCount: 4,
+		UpdateRate:  2,
+	}, status)
+}
@@ -24,6 +24,9 @@ import (
 // - support for concurrent locks on a per-key basis
 // - stable keys (when a given key is written once but read many times)
 // The main drawback is the lack of type support.
+//
+// Entries are only added and deleted while holding the lock of their key, so
+// that the key state agrees with them. See addUpstream and deleteUpstream.
 type upstreamResponseMap struct {
 	// This is of type *sync.Map[string]upstreamResponseChannel, where the key
 	// is the xds-relay aggregated key.
@@ -73,12 +76,12 @@ func (u *upstreamResponseMap) delete(aggregatedKey string) {
 	}
 }
 
-// deleteAll signifies closure of all upstream streams and removes the map
-// entries. This is called during server shutdown.
-func (u *upstreamResponseMap) deleteAll() {
-	u.internal.Range(func(aggregatedKey, channel interface{}) bool {
-		close(channel.(upstreamResponseChannel).done)
+// deleteStream signifies closure of the upstream stream with the done channel
+// and removes its map entry, unless the aggregated key has since been mapped
+// to another stream.
+func (u *upstreamResponseMap) deleteStream(aggregatedKey string, done chan bool) {
+	if channel, ok := u.internal.Load(aggregatedKey); ok && channel.(upstreamResponseChannel).done == done {
+		close(done)
 		u.internal.Delete(aggregatedKey)
-		return true
-	})
+	}
 }
@@ -0,0 +1,84 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file validates the resources of upstream responses against their Envoy
+// proto schemas. The contents of this file are intended to only be used
+// within the orchestrator module and should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	metricResponseInvalid         = "response_invalid"
+	metricResponseInvalidRejected = "response_invalid_rejected"
+)
+
+// validator is implemented by the messages generated with
+// protoc-gen-validate, which includes every Envoy API message.
+type validator interface {
+	Validate() error
+}
+
+// responseValidation validates the responses of its type URLs.
+type responseValidation struct {
+	action   bootstrapv1.ResponseValidation_Action
+	typeURLs typeURLSet
+}
+
+func newResponseValidation(config *bootstrapv1.ResponseValidation) *responseValidation {
+	return &responseValidation{action: config.GetAction(), typeURLs: newTypeURLSet(config.GetTypeUrls())}
+}
+
+// validateResponse returns an error for the first resource of the response
+// that is of another type URL than the response, of an unknown type URL, or
+// that fails the validation rules of its type.
+func validateResponse(resp *discovery.DiscoveryResponse) error {
+	for i, resource := range resp.GetResources() {
+		if resource.GetTypeUrl() != resp.GetTypeUrl() {
+			return fmt.Errorf("resource %d is of type %s in a response of type %s", i, resource.GetTypeUrl(),
+				resp.GetTypeUrl())
+		}
+		var unpacked ptypes.DynamicAny
+		if err := ptypes.UnmarshalAny(resource, &unpacked); err != nil {
+			return fmt.Errorf("resource %d: %w", i, err)
+		}
+		if v, ok := unpacked.Message.(validator); ok {
+			if err := v.Validate(); err != nil {
+				return fmt.Errorf("resource %d: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// isValid validates the upstream response, reporting invalid responses. It
+// returns false if the response should be dropped.
+func (o *orchestrator) isValid(ctx context.Context, aggregatedKey string, resp *discovery.DiscoveryResponse) bool {
+	if o.responseValidation == nil || !o.responseValidation.typeURLs.contains(resp.GetTypeUrl()) {
+		return true
+	}
+	err := validateResponse(resp)
+	if err == nil {
+		return true
+	}
+	logger := o.logger.With("err", err).With("key", aggregatedKey).With("version", resp.GetVersionInfo())
+	o.keyScope(aggregatedKey).Counter(metricResponseInvalid).Inc(1)
+	if o.responseValidation.action == bootstrapv1.ResponseValidation_WARN {
+		logger.Warn(ctx, "upstream response is invalid, serving")
+		return true
+	}
+	o.keyScope(aggregatedKey).Counter(metricResponseInvalidRejected).Inc(1)
+	logger.Error(ctx, "upstream response is invalid, dropping")
+	return false
+}
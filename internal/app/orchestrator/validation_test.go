@@ -0,0 +1,102 @@
+package orchestrator
+
+import (
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+)
+
+func newListenerResponse(t *testing.T, version string, listener *v2.Listener) *v2.DiscoveryResponse {
+	resource, err := ptypes.MarshalAny(listener)
+	assert.NoError(t, err)
+	return &v2.DiscoveryResponse{
+		VersionInfo: version,
+		TypeUrl:     upstream.ListenerTypeURL,
+		Resources:   []*any.Any{resource},
+	}
+}
+
+func validListener() *v2.Listener {
+	return &v2.Listener{
+		Name: "listener",
+		Address: &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+			Address:       "0.0.0.0",
+			PortSpecifier: &core.SocketAddress_PortValue{PortValue: 8080},
+		}}},
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	assert.NoError(t, validateResponse(newListenerResponse(t, "1", validListener())))
+
+	// The address of a listener is required.
+	assert.Error(t, validateResponse(newListenerResponse(t, "1", &v2.Listener{Name: "listener"})))
+
+	cluster, err := ptypes.MarshalAny(&v2.Cluster{Name: "cluster"})
+	assert.NoError(t, err)
+	mismatched := newListenerResponse(t, "1", validListener())
+	mismatched.Resources = append(mismatched.Resources, cluster)
+	assert.EqualError(t, validateResponse(mismatched), "resource 1 is of type "+upstream.ClusterTypeURL+
+		" in a response of type "+upstream.ListenerTypeURL)
+
+	unknown := &v2.DiscoveryResponse{
+		TypeUrl:   "type.googleapis.com/unknown.Type",
+		Resources: []*any.Any{{TypeUrl: "type.googleapis.com/unknown.Type"}},
+	}
+	assert.Error(t, validateResponse(unknown))
+
+	value, err := proto.Marshal(validListener())
+	assert.NoError(t, err)
+	corrupt := newListenerResponse(t, "1", validListener())
+	corrupt.Resources[0].Value = value[:len(value)-1]
+	assert.Error(t, validateResponse(corrupt))
+}
+
+func TestResponseValidation(t *testing.T) {
+	for _, action := range []bootstrapv1.ResponseValidation_Action{
+		bootstrapv1.ResponseValidation_REJECT,
+		bootstrapv1.ResponseValidation_WARN,
+	} {
+		t.Run(action.String(), func(t *testing.T) {
+			upstreamClient := mockFlakyUpstreamClient{streams: make(chan chan *v2.DiscoveryResponse, 10)}
+			mockScope := newMockScope("prefix")
+			orchestrator := newMockOrchestrator(t, mockScope, mapper.NewMock(t), upstreamClient)
+			WithResponseValidation(&bootstrapv1.ResponseValidation{Action: action})(orchestrator)
+
+			req := newRolloutRequest("node", false)
+			respChannel, cancelWatch := orchestrator.CreateWatch(req)
+			defer cancelWatch()
+			stream := <-upstreamClient.streams
+			invalid := newListenerResponse(t, "1", &v2.Listener{Name: "listener"})
+			stream <- invalid
+			valid := newListenerResponse(t, "2", validListener())
+			stream <- valid
+
+			// A watch is sent a single response, which is the invalid
+			// response unless it was dropped.
+			if action == bootstrapv1.ResponseValidation_WARN {
+				assertEqualResponse(t, <-respChannel, *invalid, req)
+			} else {
+				assertEqualResponse(t, <-respChannel, *valid, req)
+			}
+			testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.response_invalid", 1)
+			_, rejected := mockScope.Snapshot().Counters()["prefix.response_invalid_rejected+"]
+			assert.Equal(t, action == bootstrapv1.ResponseValidation_REJECT, rejected)
+		})
+	}
+}
+
+func TestResponseValidationTypeURLs(t *testing.T) {
+	validation := newResponseValidation(&bootstrapv1.ResponseValidation{TypeUrls: []string{upstream.ClusterTypeURL}})
+	assert.True(t, validation.typeURLs.contains(upstream.ClusterTypeURL))
+	assert.False(t, validation.typeURLs.contains(upstream.ListenerTypeURL))
+}
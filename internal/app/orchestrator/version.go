@@ -0,0 +1,247 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file detects upstream responses whose version moves backwards relative
+// to the response currently cached for the aggregated key. The contents of
+// this file are intended to only be used within the orchestrator module and
+// should not be exported.
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+)
+
+const (
+	metricVersionRegression  = "version_regression"
+	metricVersionUnparseable = "version_unparseable"
+)
+
+// versionComparator returns a negative number if current is ordered before
+// previous, zero if they are equivalent, and a positive number otherwise.
+type versionComparator func(previous, current *discovery.DiscoveryResponse) (int, error)
+
+// versionGuard checks each upstream response against the previously cached
+// response for the same aggregated key.
+type versionGuard struct {
+	comparator versionComparator
+	// streamScoped is true if the comparator orders responses by their nonce.
+	// Nonces restart with every upstream stream and differ between relays, so
+	// responses are then only compared with the previous response of their
+	// upstream stream.
+	streamScoped      bool
+	rejectRegressions bool
+}
+
+// newVersionGuard returns the guard described by the config, or nil if
+// version checking is disabled.
+func newVersionGuard(config *bootstrapv1.VersionGuard) *versionGuard {
+	var comparator versionComparator
+	streamScoped := false
+	switch config.GetComparator() {
+	case bootstrapv1.VersionGuard_NUMERIC:
+		comparator = compareNumericVersions
+	case bootstrapv1.VersionGuard_SEMVER:
+		comparator = compareSemanticVersions
+	case bootstrapv1.VersionGuard_OPAQUE_WITH_NONCE:
+		comparator = compareNonces
+		streamScoped = true
+	default:
+		return nil
+	}
+	return &versionGuard{
+		comparator:        comparator,
+		streamScoped:      streamScoped,
+		rejectRegressions: config.GetRejectRegressions(),
+	}
+}
+
+// isRejectedVersion checks the upstream response against the previously
+// cached response for the aggregated key, or against the previous response of
+// its upstream stream if the guard is stream scoped, reporting any regression.
+// It returns true if the response should be dropped.
+func (o *orchestrator) isRejectedVersion(
+	ctx context.Context,
+	aggregatedKey string,
+	previous *discovery.DiscoveryResponse,
+	streamPrevious *discovery.DiscoveryResponse,
+	resp *discovery.DiscoveryResponse,
+) bool {
+	if o.versionGuard == nil {
+		return false
+	}
+	if o.versionGuard.streamScoped {
+		previous = streamPrevious
+	}
+	regression, err := o.versionGuard.isRegression(previous, resp)
+	if err != nil {
+		o.keyScope(aggregatedKey).Counter(metricVersionUnparseable).Inc(1)
+		o.logger.With("err", err).With("key", aggregatedKey).Warn(ctx, "unable to compare upstream versions")
+		return false
+	}
+	if !regression {
+		return false
+	}
+	o.keyScope(aggregatedKey).Counter(metricVersionRegression).Inc(1)
+	o.logger.With("key", aggregatedKey).
+		With("previous version", previous.GetVersionInfo()).With("previous nonce", previous.GetNonce()).
+		With("version", resp.GetVersionInfo()).With("nonce", resp.GetNonce()).
+		With("rejected", o.versionGuard.rejectRegressions).
+		Warn(ctx, "upstream version regressed")
+	return o.versionGuard.rejectRegressions
+}
+
+// isRegression returns true if current is ordered before previous.
+func (g *versionGuard) isRegression(previous, current *discovery.DiscoveryResponse) (bool, error) {
+	if previous == nil {
+		return false, nil
+	}
+	result, err := g.comparator(previous, current)
+	if err != nil {
+		return false, err
+	}
+	return result < 0, nil
+}
+
+func compareNumericVersions(previous, current *discovery.DiscoveryResponse) (int, error) {
+	return compareUints(previous.GetVersionInfo(), current.GetVersionInfo())
+}
+
+func compareNonces(previous, current *discovery.DiscoveryResponse) (int, error) {
+	return compareUints(nonceSequence(previous.GetNonce()), nonceSequence(current.GetNonce()))
+}
+
+// nonceSequence returns the sequence number of the nonce within its stream.
+// Responses relayed from a parent relay carry nonces generated by newNonce,
+// optionally followed by a resumption token, whose sequence follows the
+// stream ID. Other nonces are returned as is.
+func nonceSequence(nonce string) string {
+	if _, ok := parseNonceStream(nonce); !ok {
+		return nonce
+	}
+	sequence := nonce[strings.Index(nonce, nonceSeparator)+len(nonceSeparator):]
+	if i := strings.Index(sequence, resumptionSeparator); i >= 0 {
+		sequence = sequence[:i]
+	}
+	return sequence
+}
+
+func compareUints(previous, current string) (int, error) {
+	p, err := strconv.ParseUint(previous, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	c, err := strconv.ParseUint(current, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case c < p:
+		return -1, nil
+	case c > p:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// semanticVersion is a parsed MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD] version.
+// Build metadata is ignored for ordering.
+type semanticVersion struct {
+	core       [3]uint64
+	prerelease []string
+}
+
+func compareSemanticVersions(previous, current *discovery.DiscoveryResponse) (int, error) {
+	p, err := parseSemanticVersion(previous.GetVersionInfo())
+	if err != nil {
+		return 0, err
+	}
+	c, err := parseSemanticVersion(current.GetVersionInfo())
+	if err != nil {
+		return 0, err
+	}
+	return c.compare(p), nil
+}
+
+func parseSemanticVersion(version string) (semanticVersion, error) {
+	var parsed semanticVersion
+	v := strings.TrimPrefix(version, "v")
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i]
+	}
+	if i := strings.IndexByte(v, '-'); i >= 0 {
+		parsed.prerelease = strings.Split(v[i+1:], ".")
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) != len(parsed.core) {
+		return semanticVersion{}, fmt.Errorf("invalid semantic version: %s", version)
+	}
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return semanticVersion{}, fmt.Errorf("invalid semantic version: %s", version)
+		}
+		parsed.core[i] = n
+	}
+	return parsed, nil
+}
+
+// compare orders two semantic versions according to https://semver.org/#spec-item-11.
+func (v semanticVersion) compare(other semanticVersion) int {
+	for i := range v.core {
+		if v.core[i] != other.core[i] {
+			if v.core[i] < other.core[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	// A version without a pre-release has higher precedence than one with.
+	switch {
+	case len(v.prerelease) == 0 && len(other.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(other.prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.prerelease) && i < len(other.prerelease); i++ {
+		if result := comparePrereleaseIdentifiers(v.prerelease[i], other.prerelease[i]); result != 0 {
+			return result
+		}
+	}
+	return len(v.prerelease) - len(other.prerelease)
+}
+
+// comparePrereleaseIdentifiers compares numeric identifiers numerically and
+// all other identifiers lexically. Numeric identifiers sort first.
+func comparePrereleaseIdentifiers(a, b string) int {
+	aNum, aErr := strconv.ParseUint(a, 10, 64)
+	bNum, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		if aNum < bNum {
+			return -1
+		} else if aNum > bNum {
+			return 1
+		}
+		return 0
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
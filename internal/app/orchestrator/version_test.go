@@ -0,0 +1,115 @@
+package orchestrator
+
+import (
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVersionGuard_Disabled(t *testing.T) {
+	assert.Nil(t, newVersionGuard(nil))
+	assert.Nil(t, newVersionGuard(&bootstrapv1.VersionGuard{}))
+}
+
+func TestIsRegression_Numeric(t *testing.T) {
+	guard := newVersionGuard(&bootstrapv1.VersionGuard{Comparator: bootstrapv1.VersionGuard_NUMERIC})
+
+	regression, err := guard.isRegression(nil, &v2.DiscoveryResponse{VersionInfo: "1"})
+	assert.NoError(t, err)
+	assert.False(t, regression)
+
+	regression, err = guard.isRegression(&v2.DiscoveryResponse{VersionInfo: "9"}, &v2.DiscoveryResponse{VersionInfo: "10"})
+	assert.NoError(t, err)
+	assert.False(t, regression)
+
+	regression, err = guard.isRegression(
+		&v2.DiscoveryResponse{VersionInfo: "10"}, &v2.DiscoveryResponse{VersionInfo: "10"})
+	assert.NoError(t, err)
+	assert.False(t, regression)
+
+	regression, err = guard.isRegression(&v2.DiscoveryResponse{VersionInfo: "10"}, &v2.DiscoveryResponse{VersionInfo: "9"})
+	assert.NoError(t, err)
+	assert.True(t, regression)
+
+	_, err = guard.isRegression(&v2.DiscoveryResponse{VersionInfo: "10"}, &v2.DiscoveryResponse{VersionInfo: "abc"})
+	assert.Error(t, err)
+}
+
+func TestIsRegression_OpaqueWithNonce(t *testing.T) {
+	guard := newVersionGuard(&bootstrapv1.VersionGuard{Comparator: bootstrapv1.VersionGuard_OPAQUE_WITH_NONCE})
+
+	regression, err := guard.isRegression(
+		&v2.DiscoveryResponse{VersionInfo: "b", Nonce: "2"},
+		&v2.DiscoveryResponse{VersionInfo: "a", Nonce: "3"},
+	)
+	assert.NoError(t, err)
+	assert.False(t, regression)
+
+	regression, err = guard.isRegression(
+		&v2.DiscoveryResponse{VersionInfo: "a", Nonce: "3"},
+		&v2.DiscoveryResponse{VersionInfo: "b", Nonce: "2"},
+	)
+	assert.NoError(t, err)
+	assert.True(t, regression)
+
+	// Nonces of responses relayed from a parent relay are ordered by their
+	// sequence within the stream.
+	regression, err = guard.isRegression(
+		&v2.DiscoveryResponse{VersionInfo: "a", Nonce: "7-9"},
+		&v2.DiscoveryResponse{VersionInfo: "b", Nonce: "7-10.token"},
+	)
+	assert.NoError(t, err)
+	assert.False(t, regression)
+	regression, err = guard.isRegression(
+		&v2.DiscoveryResponse{VersionInfo: "a", Nonce: "7-10"},
+		&v2.DiscoveryResponse{VersionInfo: "b", Nonce: "7-9"},
+	)
+	assert.NoError(t, err)
+	assert.True(t, regression)
+}
+
+func TestCompareSemanticVersions(t *testing.T) {
+	// Each version is ordered strictly after the previous one.
+	ordered := []string{
+		"0.9.9",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"v1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1+build.5",
+		"1.2.0",
+		"v10.0.0",
+	}
+	for i := 1; i < len(ordered); i++ {
+		previous := &v2.DiscoveryResponse{VersionInfo: ordered[i-1]}
+		current := &v2.DiscoveryResponse{VersionInfo: ordered[i]}
+
+		result, err := compareSemanticVersions(previous, current)
+		assert.NoError(t, err)
+		assert.True(t, result > 0, "%s should be after %s", ordered[i], ordered[i-1])
+
+		result, err = compareSemanticVersions(current, previous)
+		assert.NoError(t, err)
+		assert.True(t, result < 0, "%s should be before %s", ordered[i-1], ordered[i])
+	}
+
+	result, err := compareSemanticVersions(
+		&v2.DiscoveryResponse{VersionInfo: "1.0.0+a"},
+		&v2.DiscoveryResponse{VersionInfo: "v1.0.0+b"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result)
+}
+
+func TestParseSemanticVersion_Invalid(t *testing.T) {
+	for _, version := range []string{"", "1", "1.0", "1.0.0.0", "a.b.c", "1.0.x"} {
+		_, err := parseSemanticVersion(version)
+		assert.EqualError(t, err, "invalid semantic version: "+version)
+	}
+}
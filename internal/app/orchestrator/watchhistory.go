@@ -0,0 +1,137 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file keeps the latest responses of each type sent on a downstream
+// stream and whether the client acknowledged them, so that the configuration
+// a specific client was sent can be traced when it reports bad config. The
+// contents of this file are intended to only be used within the orchestrator
+// module and should not be exported.
+package orchestrator
+
+import (
+	"sort"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+)
+
+const (
+	// watchHistorySize is the number of responses of each type kept for each
+	// downstream stream.
+	watchHistorySize = 10
+)
+
+// DeliveryOutcome is how the client answered a response sent to it.
+type DeliveryOutcome string
+
+const (
+	// DeliveryPending is the outcome of responses the client has not
+	// answered yet.
+	DeliveryPending DeliveryOutcome = "PENDING"
+	// DeliveryACK is the outcome of responses the client acknowledged.
+	DeliveryACK DeliveryOutcome = "ACK"
+	// DeliveryNACK is the outcome of responses the client rejected.
+	DeliveryNACK DeliveryOutcome = "NACK"
+)
+
+// SentResponse is a response sent on a downstream stream, and how the client
+// answered it.
+type SentResponse struct {
+	Version  string
+	Nonce    string
+	SentTime time.Time
+	Outcome  DeliveryOutcome
+	// AnswerTime is the time of the request answering the response, and
+	// ErrorDetail is the error detail of the rejections.
+	AnswerTime  time.Time
+	ErrorDetail string
+}
+
+// WatchHistory is the latest responses of a type sent on a downstream stream,
+// oldest first.
+type WatchHistory struct {
+	StreamID  int64
+	NodeID    string
+	TypeURL   string
+	Responses []SentResponse
+}
+
+func (o *orchestrator) GetWatchHistories() []WatchHistory {
+	histories := o.nonces.histories()
+	for i := range histories {
+		histories[i].NodeID = o.requestIDs.nodeID(histories[i].StreamID)
+	}
+	return histories
+}
+
+// recordSent adds the response sent with the nonce to the history of its type
+// on the stream, dropping the oldest response beyond watchHistorySize. The
+// lock of the nonce map must be held.
+func (s *streamNonces) recordSent(resp *discovery.DiscoveryResponse, nonce string) {
+	history := append(s.history[resp.GetTypeUrl()], SentResponse{
+		Version:  resp.GetVersionInfo(),
+		Nonce:    nonce,
+		SentTime: time.Now(),
+		Outcome:  DeliveryPending,
+	})
+	if len(history) > watchHistorySize {
+		history = append([]SentResponse{}, history[len(history)-watchHistorySize:]...)
+	}
+	s.history[resp.GetTypeUrl()] = history
+}
+
+// recordAnswer records the outcome of the response of the stream that the
+// request answers. Requests answering responses that were already answered,
+// or that are no longer kept, are ignored.
+func (n *nonceMap) recordAnswer(streamID int64, req *discovery.DiscoveryRequest) {
+	if req.GetResponseNonce() == "" {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	stream, ok := n.streams[streamID]
+	if !ok {
+		return
+	}
+	history := stream.history[req.GetTypeUrl()]
+	for i := range history {
+		if history[i].Nonce != req.GetResponseNonce() || history[i].Outcome != DeliveryPending {
+			continue
+		}
+		history[i].AnswerTime = time.Now()
+		history[i].Outcome = DeliveryACK
+		if req.GetErrorDetail() != nil {
+			history[i].Outcome = DeliveryNACK
+			history[i].ErrorDetail = req.GetErrorDetail().GetMessage()
+		}
+		return
+	}
+}
+
+// histories returns the histories of the open streams, ordered by stream ID
+// and type URL.
+func (n *nonceMap) histories() []WatchHistory {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var histories []WatchHistory
+	for streamID, stream := range n.streams {
+		for typeURL, responses := range stream.history {
+			histories = append(histories, WatchHistory{
+				StreamID:  streamID,
+				TypeURL:   typeURL,
+				Responses: append([]SentResponse{}, responses...),
+			})
+		}
+	}
+	sort.Slice(histories, func(i, j int) bool {
+		if histories[i].StreamID != histories[j].StreamID {
+			return histories[i].StreamID < histories[j].StreamID
+		}
+		return histories[i].TypeURL < histories[j].TypeURL
+	})
+	return histories
+}
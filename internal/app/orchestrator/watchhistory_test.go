@@ -0,0 +1,79 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	v2_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetWatchHistories(t *testing.T) {
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), mapper.NewMock(t), mockSimpleUpstreamClient{})
+	assert.NoError(t, orchestrator.OnStreamOpen(context.Background(), 7, ""))
+	node := &v2_core.Node{Id: "node"}
+	assert.NoError(t, orchestrator.OnStreamRequest(7, &v2.DiscoveryRequest{Node: node, TypeUrl: upstream.ListenerTypeURL}))
+
+	lds1 := &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ListenerTypeURL}
+	orchestrator.OnStreamResponse(7, nil, lds1)
+	cds := &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ClusterTypeURL}
+	orchestrator.OnStreamResponse(7, nil, cds)
+	lds2 := &v2.DiscoveryResponse{VersionInfo: "2", TypeUrl: upstream.ListenerTypeURL}
+	orchestrator.OnStreamResponse(7, nil, lds2)
+
+	// The client acknowledges the first listeners, and rejects the next ones.
+	assert.NoError(t, orchestrator.OnStreamRequest(7, &v2.DiscoveryRequest{
+		Node:          node,
+		TypeUrl:       upstream.ListenerTypeURL,
+		VersionInfo:   "1",
+		ResponseNonce: lds1.GetNonce(),
+	}))
+	nack := &v2.DiscoveryRequest{
+		Node:          node,
+		TypeUrl:       upstream.ListenerTypeURL,
+		VersionInfo:   "1",
+		ResponseNonce: lds2.GetNonce(),
+		ErrorDetail:   status.New(codes.InvalidArgument, "invalid listener").Proto(),
+	}
+	assert.NoError(t, orchestrator.OnStreamRequest(7, nack))
+
+	histories := orchestrator.GetWatchHistories()
+	assert.Equal(t, 2, len(histories))
+	assert.Equal(t, upstream.ClusterTypeURL, histories[0].TypeURL)
+	assert.Equal(t, DeliveryPending, histories[0].Responses[0].Outcome)
+	lds := histories[1]
+	assert.Equal(t, int64(7), lds.StreamID)
+	assert.Equal(t, "node", lds.NodeID)
+	assert.Equal(t, upstream.ListenerTypeURL, lds.TypeURL)
+	assert.Equal(t, 2, len(lds.Responses))
+	assert.Equal(t, "1", lds.Responses[0].Version)
+	assert.Equal(t, DeliveryACK, lds.Responses[0].Outcome)
+	assert.False(t, lds.Responses[0].AnswerTime.IsZero())
+	assert.Equal(t, "2", lds.Responses[1].Version)
+	assert.Equal(t, DeliveryNACK, lds.Responses[1].Outcome)
+	assert.Equal(t, "invalid listener", lds.Responses[1].ErrorDetail)
+
+	// Answers to answered responses are ignored, and only the latest
+	// responses are kept.
+	nack.ErrorDetail = nil
+	assert.NoError(t, orchestrator.OnStreamRequest(7, nack))
+	assert.Equal(t, DeliveryNACK, orchestrator.GetWatchHistories()[1].Responses[1].Outcome)
+	for i := 3; i < 3+watchHistorySize; i++ {
+		orchestrator.OnStreamResponse(7, nil, &v2.DiscoveryResponse{
+			VersionInfo: fmt.Sprint(i),
+			TypeUrl:     upstream.ListenerTypeURL,
+		})
+	}
+	lds = orchestrator.GetWatchHistories()[1]
+	assert.Equal(t, watchHistorySize, len(lds.Responses))
+	assert.Equal(t, "3", lds.Responses[0].Version)
+
+	orchestrator.OnStreamClosed(7)
+	assert.Empty(t, orchestrator.GetWatchHistories())
+}
@@ -0,0 +1,231 @@
+// Package orchestrator is responsible for instrumenting inbound xDS client
+// requests to the correct aggregated key, forwarding a representative request
+// to the upstream origin server, and managing the lifecycle of downstream and
+// upstream connections and associates streams. It implements
+// go-control-plane's Cache interface in order to receive xDS-based requests,
+// send responses, and handle gRPC streams.
+//
+// This file supervises the per aggregated key workers that receive upstream
+// responses, cache them, and fan them out. The contents of this file are
+// intended to only be used within the orchestrator module and should not be
+// exported, except for the worker states shown by the admin server.
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	defaultMaxRestarts    = 3
+	defaultRestartBackoff = time.Second
+
+	metricWorkerPanic   = "worker_panic"
+	metricWorkerRestart = "worker_restart"
+	metricWorkerFailed  = "worker_failed"
+)
+
+// WorkerState is the state of the worker of an aggregated key.
+type WorkerState string
+
+const (
+	// WorkerRunning is the state of workers receiving upstream responses.
+	WorkerRunning WorkerState = "running"
+	// WorkerRestarting is the state of workers waiting to be restarted after
+	// a panic.
+	WorkerRestarting WorkerState = "restarting"
+	// WorkerStopped is the state of workers whose upstream stream was closed
+	// by the origin server.
+	WorkerStopped WorkerState = "stopped"
+	// WorkerFailed is the state of workers that panicked more times than the
+	// restart policy allows.
+	WorkerFailed WorkerState = "failed"
+)
+
+// WorkerStatus describes the worker of an aggregated key.
+type WorkerStatus struct {
+	Key       string
+	Tenant    string
+	State     WorkerState
+	Restarts  int
+	LastError string
+	StartTime time.Time
+}
+
+// supervisor tracks the worker of each aggregated key. Workers are removed
+// once their upstream stream is shut down by the relay, while stopped and
+// failed workers are kept until another worker replaces them.
+type supervisor struct {
+	maxRestarts    int
+	restartBackoff time.Duration
+
+	mu      sync.Mutex
+	workers map[string]*WorkerStatus
+}
+
+// newSupervisor returns a supervisor with the restart policy of the config.
+func newSupervisor(config *bootstrapv1.Supervision) *supervisor {
+	s := &supervisor{
+		maxRestarts:    defaultMaxRestarts,
+		restartBackoff: defaultRestartBackoff,
+		workers:        make(map[string]*WorkerStatus),
+	}
+	if config.GetMaxRestarts() != nil {
+		s.maxRestarts = int(config.GetMaxRestarts().GetValue())
+	}
+	if config.GetRestartBackoff() != nil {
+		if backoff, err := ptypes.Duration(config.GetRestartBackoff()); err == nil {
+			s.restartBackoff = backoff
+		}
+	}
+	return s
+}
+
+// start registers a running worker for the aggregated key of the tenant,
+// replacing any previous worker.
+func (s *supervisor) start(aggregatedKey string, tenant string) *WorkerStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	worker := &WorkerStatus{
+		Key:       aggregatedKey,
+		Tenant:    tenant,
+		State:     WorkerRunning,
+		StartTime: time.Now(),
+	}
+	s.workers[aggregatedKey] = worker
+	return worker
+}
+
+// remove unregisters the worker, unless it was already replaced.
+func (s *supervisor) remove(worker *WorkerStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.workers[worker.Key] == worker {
+		delete(s.workers, worker.Key)
+	}
+}
+
+// setState records the state of the worker, and the error it stopped with if
+// any.
+func (s *supervisor) setState(worker *WorkerStatus, state WorkerState, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	worker.State = state
+	if err != nil {
+		worker.LastError = err.Error()
+	}
+}
+
+// fail records that the worker panicked with err. It returns false if the
+// worker exceeded its restarts and failed.
+func (s *supervisor) fail(worker *WorkerStatus, err error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	worker.LastError = err.Error()
+	if worker.Restarts >= s.maxRestarts {
+		worker.State = WorkerFailed
+		return false
+	}
+	worker.Restarts++
+	worker.State = WorkerRestarting
+	return true
+}
+
+// list returns the status of every worker, sorted by aggregated key.
+func (s *supervisor) list() []WorkerStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	workers := make([]WorkerStatus, 0, len(s.workers))
+	for _, worker := range s.workers {
+		workers = append(workers, *worker)
+	}
+	sort.Slice(workers, func(i, j int) bool { return workers[i].Key < workers[j].Key })
+	return workers
+}
+
+// workerKeys returns the aggregated keys of the workers.
+func workerKeys(workers []WorkerStatus) []string {
+	keys := make([]string, 0, len(workers))
+	for _, worker := range workers {
+		keys = append(keys, worker.Key)
+	}
+	return keys
+}
+
+func (o *orchestrator) GetWorkers() []WorkerStatus {
+	return o.supervisor.list()
+}
+
+// superviseUpstream runs watchUpstream as the worker of its aggregated key.
+// A worker that panics is restarted on the same upstream stream after the
+// restart backoff. Once it exceeds its restarts, the upstream stream is
+// closed, so that the next watch for the aggregated key opens a new one.
+func (o *orchestrator) superviseUpstream(
+	ctx context.Context,
+	worker *WorkerStatus,
+	responseChannel <-chan *discovery.DiscoveryResponse,
+	done chan bool,
+	shutdownUpstream func(),
+) {
+	aggregatedKey := worker.Key
+	for {
+		panicked, err := o.runWorker(ctx, aggregatedKey, func() error {
+			return o.watchUpstream(ctx, aggregatedKey, responseChannel, done, shutdownUpstream)
+		})
+		if err == nil {
+			o.supervisor.remove(worker)
+			return
+		}
+		o.recordKeyError(aggregatedKey, err)
+		if !panicked {
+			o.supervisor.setState(worker, WorkerStopped, err)
+			if o.circuitBreakers != nil {
+				o.onUpstreamReset(ctx, aggregatedKey, done)
+			}
+			return
+		}
+		if !o.supervisor.fail(worker, err) {
+			o.keyScope(aggregatedKey).Counter(metricWorkerFailed).Inc(1)
+			o.logger.With("key", aggregatedKey).With("restarts", o.supervisor.maxRestarts).
+				Error(ctx, "upstream worker failed")
+			shutdownUpstream()
+			o.deleteUpstream(aggregatedKey, done)
+			return
+		}
+		select {
+		case <-time.After(o.supervisor.restartBackoff):
+		case <-done:
+			shutdownUpstream()
+			o.supervisor.remove(worker)
+			return
+		}
+		o.keyScope(aggregatedKey).Counter(metricWorkerRestart).Inc(1)
+		o.supervisor.setState(worker, WorkerRunning, nil)
+	}
+}
+
+// runWorker calls run, and recovers from any panic. It returns the error run
+// returned, or true along with the panic if run panicked.
+func (o *orchestrator) runWorker(
+	ctx context.Context,
+	aggregatedKey string,
+	run func() error,
+) (panicked bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			o.keyScope(aggregatedKey).Counter(metricWorkerPanic).Inc(1)
+			o.logger.With("key", aggregatedKey).With("panic", r).With("stack", string(debug.Stack())).
+				Error(ctx, "upstream worker panicked")
+			panicked, err = true, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return false, run()
+}
@@ -0,0 +1,118 @@
+package orchestrator
+
+import (
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/transform"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+)
+
+// panickingTransformer panics on responses with version "panic".
+var panickingTransformer = transform.Func(func(resp *v2.DiscoveryResponse) (*v2.DiscoveryResponse, error) {
+	if resp.GetVersionInfo() == "panic" {
+		panic("transform failed")
+	}
+	return resp, nil
+})
+
+func TestSuperviseUpstreamRestart(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, typeURLMapper{},
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	orchestrator.transformer = panickingTransformer
+	orchestrator.supervisor = newSupervisor(&bootstrapv1.Supervision{RestartBackoff: ptypes.DurationProto(0)})
+
+	respChannel, cancelWatch := orchestrator.CreateWatch(gcp.Request{TypeUrl: upstream.ListenerTypeURL})
+	defer cancelWatch()
+	upstreamResponseChannel <- &v2.DiscoveryResponse{VersionInfo: "panic", TypeUrl: upstream.ListenerTypeURL}
+
+	// The restarted worker keeps receiving from the same stream.
+	upstreamResponseChannel <- &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ListenerTypeURL}
+	resp, err := (<-respChannel).GetDiscoveryResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", resp.GetVersionInfo())
+
+	workers := orchestrator.GetWorkers()
+	assert.Equal(t, 1, len(workers))
+	assert.Equal(t, upstream.ListenerTypeURL, workers[0].Key)
+	assert.Equal(t, WorkerRunning, workers[0].State)
+	assert.Equal(t, 1, workers[0].Restarts)
+	assert.Equal(t, "panic: transform failed", workers[0].LastError)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.worker_panic", 1)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.worker_restart", 1)
+}
+
+func TestSuperviseUpstreamFailed(t *testing.T) {
+	upstreamClient := newMockSubscriptionUpstreamClient()
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	mockScope := newMockScope("prefix")
+	orchestrator := newMockOrchestrator(t, mockScope, typeURLMapper{},
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+	orchestrator.transformer = panickingTransformer
+	orchestrator.supervisor = newSupervisor(&bootstrapv1.Supervision{MaxRestarts: &wrappers.UInt32Value{Value: 0}})
+
+	_, cancelWatch := orchestrator.CreateWatch(gcp.Request{TypeUrl: upstream.ListenerTypeURL})
+	defer cancelWatch()
+	upstreamResponseChannel <- &v2.DiscoveryResponse{VersionInfo: "panic", TypeUrl: upstream.ListenerTypeURL}
+
+	assert.Eventually(t, func() bool {
+		return !orchestrator.upstreamResponseMap.exists(upstream.ListenerTypeURL)
+	}, time.Second, time.Millisecond)
+	workers := orchestrator.GetWorkers()
+	assert.Equal(t, 1, len(workers))
+	assert.Equal(t, WorkerFailed, workers[0].State)
+	assert.Equal(t, 0, workers[0].Restarts)
+	testutils.AssertCounterValue(t, mockScope.Snapshot().Counters(), "prefix.worker_failed", 1)
+
+	// The next watch opens a new stream with a new worker.
+	orchestrator.upstreamClient = upstreamClient
+	_, cancelNext := orchestrator.CreateWatch(gcp.Request{TypeUrl: upstream.ListenerTypeURL})
+	defer cancelNext()
+	<-upstreamClient.requests
+	workers = orchestrator.GetWorkers()
+	assert.Equal(t, 1, len(workers))
+	assert.Equal(t, WorkerRunning, workers[0].State)
+}
+
+func TestSuperviseUpstreamStopped(t *testing.T) {
+	upstreamResponseChannel := make(chan *v2.DiscoveryResponse)
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), typeURLMapper{},
+		mockSimpleUpstreamClient{responseChan: upstreamResponseChannel})
+
+	_, cancelWatch := orchestrator.CreateWatch(gcp.Request{TypeUrl: upstream.ListenerTypeURL})
+	defer cancelWatch()
+	close(upstreamResponseChannel)
+
+	assert.Eventually(t, func() bool {
+		workers := orchestrator.GetWorkers()
+		return len(workers) == 1 && workers[0].State == WorkerStopped
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, "upstream stream for aggregated key "+upstream.ListenerTypeURL+" closed",
+		orchestrator.GetWorkers()[0].LastError)
+}
+
+func TestSuperviseUpstreamRemoved(t *testing.T) {
+	baseline := testutils.CurrentGoroutines()
+	orchestrator := newMockOrchestrator(t, newMockScope("prefix"), typeURLMapper{},
+		mockSimpleUpstreamClient{responseChan: make(chan *v2.DiscoveryResponse)})
+
+	_, cancelWatch := orchestrator.CreateWatch(gcp.Request{TypeUrl: upstream.ListenerTypeURL})
+	defer cancelWatch()
+	assert.Equal(t, 1, len(orchestrator.GetWorkers()))
+
+	// Workers are removed once the relay shuts their stream down.
+	orchestrator.deleteUpstream(upstream.ListenerTypeURL, nil)
+	assert.Eventually(t, func() bool {
+		return len(orchestrator.GetWorkers()) == 0
+	}, time.Second, time.Millisecond)
+	testutils.AssertNoLeakedGoroutines(t, baseline)
+}
@@ -0,0 +1,162 @@
+// Package recording records the upstream responses and downstream requests of each aggregated key to files, and
+// replays recorded responses in place of the origin server.
+package recording
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/uber-go/tally"
+)
+
+const (
+	fileExtension = ".jsonl"
+
+	metricRecorded    = "recorded"
+	metricRecordError = "record_error"
+)
+
+// Record is a single line of a recording file. Exactly one of Request and Response is set, to the JSON
+// representation of the discovery message.
+type Record struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Request   json.RawMessage `json:"request,omitempty"`
+	Response  json.RawMessage `json:"response,omitempty"`
+}
+
+// Path returns the path of the recording file of the aggregated key in the directory.
+func Path(directory string, aggregatedKey string) string {
+	return filepath.Join(directory, url.PathEscape(aggregatedKey)+fileExtension)
+}
+
+// Recorder appends the responses and requests of each aggregated key to its recording file.
+type Recorder struct {
+	directory      string
+	recordRequests bool
+	logger         log.Logger
+	scope          tally.Scope
+
+	mu    sync.Mutex
+	files map[string]*os.File
+}
+
+// NewRecorder creates the recording directory if it does not exist.
+func NewRecorder(config *bootstrapv1.Recording, logger log.Logger, scope tally.Scope) (*Recorder, error) {
+	if err := os.MkdirAll(config.GetDirectory(), 0750); err != nil {
+		return nil, err
+	}
+	return &Recorder{
+		directory:      config.GetDirectory(),
+		recordRequests: config.GetRecordRequests(),
+		logger:         logger.Named("recorder").With("directory", config.GetDirectory()),
+		scope:          scope,
+		files:          make(map[string]*os.File),
+	}, nil
+}
+
+// RecordResponse records an upstream response of the aggregated key.
+func (r *Recorder) RecordResponse(aggregatedKey string, resp *discovery.DiscoveryResponse) {
+	r.record(aggregatedKey, resp, func(record *Record, serialized json.RawMessage) {
+		record.Response = serialized
+	})
+}
+
+// RecordRequest records a downstream request of the aggregated key, if requests are recorded.
+func (r *Recorder) RecordRequest(aggregatedKey string, req *discovery.DiscoveryRequest) {
+	if !r.recordRequests {
+		return
+	}
+	r.record(aggregatedKey, req, func(record *Record, serialized json.RawMessage) {
+		record.Request = serialized
+	})
+}
+
+func (r *Recorder) record(aggregatedKey string, msg proto.Message, set func(*Record, json.RawMessage)) {
+	if err := r.write(aggregatedKey, msg, set); err != nil {
+		r.scope.Counter(metricRecordError).Inc(1)
+		r.logger.With("err", err).With("key", aggregatedKey).Error(context.Background(), "failed to record")
+		return
+	}
+	r.scope.Counter(metricRecorded).Inc(1)
+}
+
+func (r *Recorder) write(aggregatedKey string, msg proto.Message, set func(*Record, json.RawMessage)) error {
+	serialized, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(msg)
+	if err != nil {
+		return err
+	}
+	record := Record{Timestamp: time.Now()}
+	set(&record, json.RawMessage(serialized))
+	line, err := json.Marshal(&record)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	file, ok := r.files[aggregatedKey]
+	if !ok {
+		file, err = os.OpenFile(Path(r.directory, aggregatedKey), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		r.files[aggregatedKey] = file
+	}
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the recording files.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var closeErr error
+	for aggregatedKey, file := range r.files {
+		if err := file.Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+		delete(r.files, aggregatedKey)
+	}
+	return closeErr
+}
+
+// Load reads the records of a recording file, in the order they were recorded.
+func Load(path string) ([]Record, error) {
+	file, err := os.Open(path) // #nosec
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []Record
+	reader := bufio.NewReader(file)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var record Record
+			if err := json.Unmarshal(line, &record); err != nil {
+				return nil, fmt.Errorf("invalid record on line %d of %s: %s", lineNumber, path, err.Error())
+			}
+			records = append(records, record)
+		}
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package recording
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+)
+
+func newDirectory(t *testing.T) string {
+	directory, err := ioutil.TempDir("", "recording")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(directory) })
+	return directory
+}
+
+func newListenerResponse(t *testing.T, version string) *v2.DiscoveryResponse {
+	listener, err := ptypes.MarshalAny(&v2.Listener{Name: "listener_" + version})
+	assert.NoError(t, err)
+	return &v2.DiscoveryResponse{
+		VersionInfo: version,
+		TypeUrl:     upstream.ListenerTypeURL,
+		Resources:   []*any.Any{listener},
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	directory := newDirectory(t)
+	scope := tally.NewTestScope("recording", make(map[string]string))
+	recorder, err := NewRecorder(&bootstrapv1.Recording{Directory: directory, RecordRequests: true},
+		log.New("info"), scope)
+	assert.NoError(t, err)
+
+	req := &v2.DiscoveryRequest{TypeUrl: upstream.ListenerTypeURL}
+	resp := newListenerResponse(t, "1")
+	recorder.RecordRequest("a/b", req)
+	recorder.RecordResponse("a/b", resp)
+	recorder.RecordResponse("c", newListenerResponse(t, "2"))
+	assert.NoError(t, recorder.Close())
+	testutils.AssertCounterValue(t, scope.Snapshot().Counters(), "recording.recorded", 3)
+
+	records, err := Load(Path(directory, "a/b"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(records))
+	assert.Nil(t, records[0].Response)
+	assert.Nil(t, records[1].Request)
+	assert.False(t, records[1].Timestamp.Before(records[0].Timestamp))
+
+	client := NewReplayClient(&bootstrapv1.Replay{Directory: directory}, log.New("info"))
+	responses, err := client.load("a/b")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(responses))
+	assert.True(t, proto.Equal(resp, responses[0].resp))
+}
+
+func TestRecorderWithoutRequests(t *testing.T) {
+	directory := newDirectory(t)
+	recorder, err := NewRecorder(&bootstrapv1.Recording{Directory: directory}, log.New("info"),
+		tally.NewTestScope("recording", make(map[string]string)))
+	assert.NoError(t, err)
+
+	recorder.RecordRequest("a", &v2.DiscoveryRequest{TypeUrl: upstream.ListenerTypeURL})
+	assert.NoError(t, recorder.Close())
+	_, err = Load(Path(directory, "a"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestReplayClient(t *testing.T) {
+	directory := newDirectory(t)
+	recorder, err := NewRecorder(&bootstrapv1.Recording{Directory: directory}, log.New("info"),
+		tally.NewTestScope("recording", make(map[string]string)))
+	assert.NoError(t, err)
+	recorder.RecordResponse(upstream.ListenerTypeURL, newListenerResponse(t, "1"))
+	time.Sleep(10 * time.Millisecond)
+	recorder.RecordResponse(upstream.ListenerTypeURL, newListenerResponse(t, "2"))
+	assert.NoError(t, recorder.Close())
+
+	client := NewReplayClient(&bootstrapv1.Replay{Directory: directory, PreserveTiming: true}, log.New("info"))
+	var _ upstream.KeyedClient = client
+	responseChannel, shutdown, err := client.OpenKeyedStream(upstream.ListenerTypeURL,
+		v2.DiscoveryRequest{TypeUrl: upstream.ListenerTypeURL})
+	assert.NoError(t, err)
+	defer shutdown()
+	start := time.Now()
+	assert.Equal(t, "1", (<-responseChannel).GetVersionInfo())
+	assert.Equal(t, "2", (<-responseChannel).GetVersionInfo())
+	assert.True(t, time.Since(start) >= 10*time.Millisecond)
+
+	// The stream stays open once the recording is exhausted.
+	select {
+	case <-responseChannel:
+		assert.Fail(t, "unexpected response")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	_, _, err = client.OpenKeyedStream(upstream.ClusterTypeURL, v2.DiscoveryRequest{TypeUrl: upstream.ClusterTypeURL})
+	assert.Error(t, err)
+	_, _, err = client.OpenStream(v2.DiscoveryRequest{TypeUrl: upstream.ListenerTypeURL})
+	assert.Equal(t, errNoAggregatedKey, err)
+}
+
+func TestLoadInvalid(t *testing.T) {
+	directory := newDirectory(t)
+	path := Path(directory, "a")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("{\"timestamp\": \"invalid\"}\n"), 0600))
+	_, err := Load(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,102 @@
+package recording
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+// errNoAggregatedKey is returned when a stream is opened without the aggregated key whose recording it replays.
+var errNoAggregatedKey = errors.New("replayed streams must be opened for an aggregated key")
+
+// ReplayClient is an upstream client that serves the recorded responses of each aggregated key instead of
+// connecting to the origin server. Its streams are opened for the aggregated key computed by the orchestrator, so
+// that keys recorded without aggregation rules, or with rules that match the transport, are replayed as recorded.
+type ReplayClient struct {
+	directory      string
+	preserveTiming bool
+	logger         log.Logger
+}
+
+// NewReplayClient creates a client that replays the recording in the configured directory.
+func NewReplayClient(config *bootstrapv1.Replay, logger log.Logger) *ReplayClient {
+	return &ReplayClient{
+		directory:      config.GetDirectory(),
+		preserveTiming: config.GetPreserveTiming(),
+		logger:         logger.Named("replay").With("directory", config.GetDirectory()),
+	}
+}
+
+type timedResponse struct {
+	timestamp time.Time
+	resp      *discovery.DiscoveryResponse
+}
+
+// OpenStream fails, since the aggregated key of the request is needed to find its recording. Streams are opened
+// with OpenKeyedStream instead.
+func (c *ReplayClient) OpenStream(discovery.DiscoveryRequest) (<-chan *discovery.DiscoveryResponse, func(), error) {
+	return nil, nil, errNoAggregatedKey
+}
+
+// OpenKeyedStream sends the recorded responses of the aggregated key in order. Once every response has been sent,
+// the stream stays open without sending further responses until it is shut down, so that the last recorded response
+// keeps being served.
+func (c *ReplayClient) OpenKeyedStream(
+	aggregatedKey string,
+	_ discovery.DiscoveryRequest,
+) (<-chan *discovery.DiscoveryResponse, func(), error) {
+	responses, err := c.load(aggregatedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.logger.With("key", aggregatedKey).With("responses", len(responses)).
+		Info(context.Background(), "replaying recorded responses")
+
+	responseChannel := make(chan *discovery.DiscoveryResponse)
+	done := make(chan struct{})
+	var once sync.Once
+	shutdown := func() { once.Do(func() { close(done) }) }
+	go func() {
+		for i, response := range responses {
+			if c.preserveTiming && i > 0 {
+				select {
+				case <-time.After(response.timestamp.Sub(responses[i-1].timestamp)):
+				case <-done:
+					return
+				}
+			}
+			select {
+			case responseChannel <- response.resp:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return responseChannel, shutdown, nil
+}
+
+func (c *ReplayClient) load(aggregatedKey string) ([]timedResponse, error) {
+	records, err := Load(Path(c.directory, aggregatedKey))
+	if err != nil {
+		return nil, err
+	}
+	var responses []timedResponse
+	for i, record := range records {
+		if record.Response == nil {
+			continue
+		}
+		var resp discovery.DiscoveryResponse
+		if err := jsonpb.UnmarshalString(string(record.Response), &resp); err != nil {
+			return nil, fmt.Errorf("invalid response in record %d of %s: %s", i, aggregatedKey, err.Error())
+		}
+		responses = append(responses, timedResponse{timestamp: record.Timestamp, resp: &resp})
+	}
+	return responses, nil
+}
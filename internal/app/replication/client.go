@@ -0,0 +1,126 @@
+package replication
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	replicationv1 "github.com/envoyproxy/xds-relay/pkg/api/replication/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	// reconnectDelay is the wait between attempts to stream updates from the source relay.
+	reconnectDelay = time.Second
+
+	metricLag           = "lag"
+	metricApplied       = "applied"
+	metricDiscarded     = "discarded"
+	metricInvalidUpdate = "invalid_update"
+	metricStreamError   = "stream_error"
+)
+
+// Applier applies responses replicated from a peer to the local cache.
+type Applier interface {
+	// ApplyReplicatedResponse caches the response for the aggregated key and returns true, or returns false if the
+	// response conflicts with a newer locally cached response.
+	ApplyReplicatedResponse(aggregatedKey string, resp *discovery.DiscoveryResponse) bool
+}
+
+// Client replicates the cache of a source relay into the local cache.
+type Client struct {
+	client  replicationv1.ReplicationClient
+	applier Applier
+	peerID  string
+	logger  log.Logger
+	scope   tally.Scope
+}
+
+// NewClient creates a connection to the source relay at address, over TLS if creds is not nil. The connection is
+// established in the background, and closed when ctx is done. Run must be called to start replicating.
+func NewClient(
+	ctx context.Context,
+	address string,
+	creds credentials.TransportCredentials,
+	applier Applier,
+	logger log.Logger,
+	scope tally.Scope,
+) (*Client, error) {
+	dialOpt := grpc.WithInsecure()
+	if creds != nil {
+		dialOpt = grpc.WithTransportCredentials(creds)
+	}
+	conn, err := grpc.Dial(address, dialOpt)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+	peerID, _ := os.Hostname()
+	return &Client{
+		client:  replicationv1.NewReplicationClient(conn),
+		applier: applier,
+		peerID:  peerID,
+		logger:  logger.Named("replication_client").With("source", address),
+		scope:   scope,
+	}, nil
+}
+
+// Run streams updates from the source relay until ctx is done, reconnecting whenever the stream ends.
+func (c *Client) Run(ctx context.Context) {
+	for {
+		if err := c.replicate(ctx); err != nil && ctx.Err() == nil {
+			c.scope.Counter(metricStreamError).Inc(1)
+			c.logger.With("err", err).Warn(ctx, "replication stream ended")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (c *Client) replicate(ctx context.Context) error {
+	stream, err := c.client.StreamUpdates(ctx, &replicationv1.StreamUpdatesRequest{PeerId: c.peerID})
+	if err != nil {
+		return err
+	}
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		c.apply(ctx, update)
+	}
+}
+
+func (c *Client) apply(ctx context.Context, update *replicationv1.CacheUpdate) {
+	if updateTime, err := ptypes.Timestamp(update.GetUpdateTime()); err == nil {
+		c.scope.Timer(metricLag).Record(time.Since(updateTime))
+	}
+	var resp discovery.DiscoveryResponse
+	if err := ptypes.UnmarshalAny(update.GetResponse(), &resp); err != nil {
+		c.scope.Counter(metricInvalidUpdate).Inc(1)
+		c.logger.With("err", err).With("key", update.GetKey()).Error(ctx, "failed to unpack replicated response")
+		return
+	}
+	if !c.applier.ApplyReplicatedResponse(update.GetKey(), &resp) {
+		c.scope.Counter(metricDiscarded).Inc(1)
+		c.logger.With("key", update.GetKey()).With("version", update.GetVersion()).
+			Debug(ctx, "discarded replicated response")
+		return
+	}
+	c.scope.Counter(metricApplied).Inc(1)
+}
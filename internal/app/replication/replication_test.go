@@ -0,0 +1,111 @@
+package replication
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	replicationv1 "github.com/envoyproxy/xds-relay/pkg/api/replication/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+)
+
+type mockApplier struct {
+	mu      sync.Mutex
+	applied map[string]*discovery.DiscoveryResponse
+	// reject is the version of responses that conflict with the local cache.
+	reject string
+}
+
+func (m *mockApplier) ApplyReplicatedResponse(aggregatedKey string, resp *discovery.DiscoveryResponse) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if resp.GetVersionInfo() == m.reject {
+		return false
+	}
+	m.applied[aggregatedKey] = resp
+	return true
+}
+
+func (m *mockApplier) get(aggregatedKey string) *discovery.DiscoveryResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.applied[aggregatedKey]
+}
+
+func newResponse(version string) *discovery.DiscoveryResponse {
+	return &discovery.DiscoveryResponse{
+		VersionInfo: version,
+		TypeUrl:     "type.googleapis.com/envoy.api.v2.Listener",
+		Nonce:       version,
+	}
+}
+
+// startServer serves s on a local port and returns its address.
+func startServer(t *testing.T, s *Server) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	replicationv1.RegisterReplicationServer(server, s)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestReplication(t *testing.T) {
+	serverScope := tally.NewTestScope("server", make(map[string]string))
+	s := NewServer(log.New("info"), serverScope)
+	address := startServer(t, s)
+
+	// Updates published before the peer connects are sent as a snapshot.
+	s.Publish("lds", newResponse("1"))
+	s.Publish("cds", newResponse("1"))
+	s.Evict("cds")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	applier := &mockApplier{applied: make(map[string]*discovery.DiscoveryResponse), reject: "2"}
+	clientScope := tally.NewTestScope("client", make(map[string]string))
+	client, err := NewClient(ctx, address, nil, applier, log.New("info"), clientScope)
+	assert.NoError(t, err)
+	go client.Run(ctx)
+
+	assert.Eventually(t, func() bool {
+		return applier.get("lds") != nil
+	}, time.Second, time.Millisecond)
+	assert.True(t, proto.Equal(newResponse("1"), applier.get("lds")))
+	assert.Nil(t, applier.get("cds"))
+
+	// Subsequent updates are streamed as they are published.
+	s.Publish("lds", newResponse("2"))
+	s.Publish("rds", newResponse("3"))
+	assert.Eventually(t, func() bool {
+		return applier.get("rds") != nil
+	}, time.Second, time.Millisecond)
+	assert.True(t, proto.Equal(newResponse("1"), applier.get("lds")))
+
+	counters := clientScope.Snapshot().Counters()
+	testutils.AssertCounterValue(t, counters, "client.applied", 2)
+	testutils.AssertCounterValue(t, counters, "client.discarded", 1)
+	assert.Contains(t, clientScope.Snapshot().Timers(), "client.lag+")
+}
+
+func TestPublishDropsSlowPeer(t *testing.T) {
+	scope := tally.NewTestScope("server", make(map[string]string))
+	s := NewServer(log.New("info"), scope)
+	peer := make(chan *replicationv1.CacheUpdate)
+	s.peers[peer] = struct{}{}
+
+	s.Publish("lds", newResponse("1"))
+	_, ok := <-peer
+	assert.False(t, ok)
+	assert.Equal(t, 0, len(s.peers))
+	testutils.AssertCounterValue(t, scope.Snapshot().Counters(), "server.peer_dropped", 1)
+}
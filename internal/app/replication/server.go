@@ -0,0 +1,137 @@
+// Package replication streams cached responses between xds-relay peers so that standby or regional replicas keep a
+// warm cache without opening their own streams to the origin server.
+package replication
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	replicationv1 "github.com/envoyproxy/xds-relay/pkg/api/replication/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// peerBufferSize is the number of updates buffered for each peer. A peer that falls further behind is
+	// disconnected and resynchronizes from a fresh snapshot when it reconnects.
+	peerBufferSize = 1000
+
+	metricPeers       = "peers"
+	metricPeerDropped = "peer_dropped"
+	metricPublishErr  = "publish_error"
+)
+
+// Server streams this relay's cache updates to replicating peers.
+type Server struct {
+	logger log.Logger
+	scope  tally.Scope
+
+	mu sync.Mutex
+	// latest holds the most recent update for each aggregated key, sent to peers when they connect.
+	latest map[string]*replicationv1.CacheUpdate
+	peers  map[chan *replicationv1.CacheUpdate]struct{}
+}
+
+// NewServer returns a Server with no cached updates.
+func NewServer(logger log.Logger, scope tally.Scope) *Server {
+	return &Server{
+		logger: logger.Named("replication_server"),
+		scope:  scope,
+		latest: make(map[string]*replicationv1.CacheUpdate),
+		peers:  make(map[chan *replicationv1.CacheUpdate]struct{}),
+	}
+}
+
+// Publish records the response as the latest for the aggregated key and sends it to all connected peers.
+func (s *Server) Publish(aggregatedKey string, resp *discovery.DiscoveryResponse) {
+	packed, err := ptypes.MarshalAny(resp)
+	if err != nil {
+		s.scope.Counter(metricPublishErr).Inc(1)
+		s.logger.With("err", err).With("key", aggregatedKey).Error(context.Background(), "failed to pack update")
+		return
+	}
+	updateTime, _ := ptypes.TimestampProto(time.Now())
+	update := &replicationv1.CacheUpdate{
+		Key:        aggregatedKey,
+		Version:    resp.GetVersionInfo(),
+		Response:   packed,
+		UpdateTime: updateTime,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latest[aggregatedKey] = update
+	for peer := range s.peers {
+		select {
+		case peer <- update:
+		default:
+			// Closing the channel ends the peer's stream.
+			close(peer)
+			delete(s.peers, peer)
+			s.scope.Counter(metricPeerDropped).Inc(1)
+		}
+	}
+	s.scope.Gauge(metricPeers).Update(float64(len(s.peers)))
+}
+
+// Evict forgets the aggregated key so that it is not sent to peers that connect later.
+func (s *Server) Evict(aggregatedKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.latest, aggregatedKey)
+}
+
+// StreamUpdates sends the latest update for every aggregated key, followed by each subsequent update, until the
+// peer disconnects or falls too far behind.
+func (s *Server) StreamUpdates(
+	req *replicationv1.StreamUpdatesRequest,
+	stream replicationv1.Replication_StreamUpdatesServer,
+) error {
+	logger := s.logger.With("peer", req.GetPeerId())
+	peer := make(chan *replicationv1.CacheUpdate, peerBufferSize)
+
+	// The snapshot and subscription are taken together so that no update is missed in between.
+	s.mu.Lock()
+	snapshot := make([]*replicationv1.CacheUpdate, 0, len(s.latest))
+	for _, update := range s.latest {
+		snapshot = append(snapshot, update)
+	}
+	s.peers[peer] = struct{}{}
+	s.scope.Gauge(metricPeers).Update(float64(len(s.peers)))
+	s.mu.Unlock()
+	defer s.unsubscribe(peer)
+
+	logger.With("keys", len(snapshot)).Info(stream.Context(), "peer connected")
+	for _, update := range snapshot {
+		if err := stream.Send(update); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case update, ok := <-peer:
+			if !ok {
+				logger.Warn(stream.Context(), "peer fell behind, disconnecting")
+				return status.Error(codes.ResourceExhausted, "peer fell behind replication updates")
+			}
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			logger.Info(stream.Context(), "peer disconnected")
+			return nil
+		}
+	}
+}
+
+func (s *Server) unsubscribe(peer chan *replicationv1.CacheUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.peers, peer)
+	s.scope.Gauge(metricPeers).Update(float64(len(s.peers)))
+}
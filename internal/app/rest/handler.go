@@ -0,0 +1,127 @@
+// Package rest serves xDS over REST-JSON from the relay's cache, for Envoy clients configured with the REST API type
+// and for debugging tools that cannot speak gRPC.
+package rest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resourcev2 "github.com/envoyproxy/go-control-plane/pkg/resource/v2"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/server/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/apiversion"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/golang/protobuf/jsonpb"
+
+	// Register the v3 resource types so that they can be rendered as JSON.
+	_ "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	_ "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	_ "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	_ "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	_ "github.com/envoyproxy/go-control-plane/envoy/service/runtime/v3"
+)
+
+// v3TypeURLs maps each v3 fetch path to the v2 type URL that the relay caches.
+var v3TypeURLs = map[string]string{
+	resourcev3.FetchListeners: resourcev2.ListenerType,
+	resourcev3.FetchClusters:  resourcev2.ClusterType,
+	resourcev3.FetchRoutes:    resourcev2.RouteType,
+	resourcev3.FetchEndpoints: resourcev2.EndpointType,
+	resourcev3.FetchRuntimes:  resourcev2.RuntimeType,
+}
+
+type handler struct {
+	server  gcp.Server
+	gateway *gcp.HTTPGateway
+	logger  log.Logger
+}
+
+// New returns a handler serving `/v2/discovery:{type}` and `/v3/discovery:{type}` from the xDS server.
+//
+// The relay caches v2 responses, so v3 requests are translated to v2 and the responses back to v3, including the type
+// URLs of the payloads nested in their resources. The v3 resources are decoded from the v2 bytes, which relies on the
+// wire compatibility between the two API versions.
+func New(server gcp.Server, logger log.Logger) http.Handler {
+	return &handler{
+		server:  server,
+		gateway: &gcp.HTTPGateway{Server: server},
+		logger:  logger.Named("rest"),
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	var body []byte
+	var status int
+	var err error
+	if strings.HasPrefix(path.Clean(req.URL.Path), "/v3/") {
+		body, status, err = h.serveV3(req)
+	} else {
+		body, status, err = h.gateway.ServeHTTP(req)
+	}
+	if err != nil {
+		h.logger.With("err", err).With("path", req.URL.Path).Debug(req.Context(), "failed to serve REST request")
+		http.Error(w, err.Error(), status)
+		return
+	}
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(body); err != nil {
+		h.logger.With("err", err).Warn(req.Context(), "failed to write REST response")
+	}
+}
+
+func (h *handler) serveV3(req *http.Request) ([]byte, int, error) {
+	typeURL, ok := v3TypeURLs[path.Clean(req.URL.Path)]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("no endpoint")
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("cannot read body")
+	}
+	var requestV3 discoveryv3.DiscoveryRequest
+	if err := jsonpb.Unmarshal(bytes.NewReader(body), &requestV3); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("cannot parse JSON body: %s", err.Error())
+	}
+	var requestV2 discovery.DiscoveryRequest
+	if err := apiversion.Convert(&requestV3, &requestV2); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	requestV2.TypeUrl = typeURL
+
+	responseV2, err := h.server.Fetch(req.Context(), &requestV2)
+	if err != nil {
+		if _, ok := err.(*types.SkipFetchError); ok {
+			return nil, http.StatusNotModified, nil
+		}
+		return nil, http.StatusInternalServerError, fmt.Errorf("fetch error: %s", err.Error())
+	}
+
+	if responseV2, err = apiversion.Response(responseV2, apiversion.V3); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	var responseV3 discoveryv3.DiscoveryResponse
+	if err := apiversion.Convert(responseV2, &responseV3); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	buf := &bytes.Buffer{}
+	if err := (&jsonpb.Marshaler{OrigName: true}).Marshal(buf, &responseV3); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("marshal error: %s", err.Error())
+	}
+	return buf.Bytes(), http.StatusOK, nil
+}
@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev2 "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/server/v2"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+const testNodeID = "node"
+
+func newTestHandler(t *testing.T) http.Handler {
+	snapshotCache := cachev2.NewSnapshotCache(false, cachev2.IDHash{}, nil)
+	err := snapshotCache.SetSnapshot(testNodeID, cachev2.NewSnapshot("1", nil, nil, nil,
+		[]types.Resource{&v2.Listener{Name: "listener"}}, nil))
+	assert.NoError(t, err)
+	return New(gcp.NewServer(context.Background(), snapshotCache, nil), log.New("info"))
+}
+
+func serve(handler http.Handler, method string, path string, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return recorder
+}
+
+// decodeResponse returns the version, response type URL, and resource type URLs and names of a JSON response.
+func decodeResponse(t *testing.T, recorder *httptest.ResponseRecorder) (string, string, []string, []string) {
+	var resp struct {
+		VersionInfo string `json:"version_info"`
+		TypeURL     string `json:"type_url"`
+		Resources   []struct {
+			Type string `json:"@type"`
+			Name string `json:"name"`
+		} `json:"resources"`
+	}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	var resourceTypes, names []string
+	for _, resource := range resp.Resources {
+		resourceTypes = append(resourceTypes, resource.Type)
+		names = append(names, resource.Name)
+	}
+	return resp.VersionInfo, resp.TypeURL, resourceTypes, names
+}
+
+func TestServeV2(t *testing.T) {
+	handler := newTestHandler(t)
+	recorder := serve(handler, http.MethodPost, "/v2/discovery:listeners", `{"node": {"id": "node"}}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	version, typeURL, resourceTypes, names := decodeResponse(t, recorder)
+	assert.Equal(t, "1", version)
+	assert.Equal(t, "type.googleapis.com/envoy.api.v2.Listener", typeURL)
+	assert.Equal(t, []string{"type.googleapis.com/envoy.api.v2.Listener"}, resourceTypes)
+	assert.Equal(t, []string{"listener"}, names)
+
+	recorder = serve(handler, http.MethodPost, "/v2/discovery:listeners",
+		`{"node": {"id": "node"}, "version_info": "1"}`)
+	assert.Equal(t, http.StatusNotModified, recorder.Code)
+}
+
+func TestServeV3(t *testing.T) {
+	handler := newTestHandler(t)
+	recorder := serve(handler, http.MethodPost, "/v3/discovery:listeners", `{"node": {"id": "node"}}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	version, typeURL, resourceTypes, names := decodeResponse(t, recorder)
+	assert.Equal(t, "1", version)
+	assert.Equal(t, "type.googleapis.com/envoy.config.listener.v3.Listener", typeURL)
+	assert.Equal(t, []string{"type.googleapis.com/envoy.config.listener.v3.Listener"}, resourceTypes)
+	assert.Equal(t, []string{"listener"}, names)
+
+	recorder = serve(handler, http.MethodPost, "/v3/discovery:listeners",
+		`{"node": {"id": "node"}, "version_info": "1"}`)
+	assert.Equal(t, http.StatusNotModified, recorder.Code)
+}
+
+func TestServeErrors(t *testing.T) {
+	handler := newTestHandler(t)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(handler, http.MethodGet, "/v2/discovery:listeners", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(handler, http.MethodPost, "/v2/discovery:unknown", "{}").Code)
+	assert.Equal(t, http.StatusNotFound, serve(handler, http.MethodPost, "/v3/discovery:unknown", "{}").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(handler, http.MethodPost, "/v3/discovery:listeners", "{").Code)
+	assert.Equal(t, http.StatusInternalServerError,
+		serve(handler, http.MethodPost, "/v3/discovery:listeners", `{"node": {"id": "unknown"}}`).Code)
+}
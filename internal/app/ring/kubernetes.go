@@ -0,0 +1,98 @@
+package ring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/kubernetes"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+)
+
+// endpoints is the subset of the v1 Endpoints object used for membership.
+type endpoints struct {
+	Subsets []endpointSubset `json:"subsets"`
+}
+
+type endpointSubset struct {
+	Addresses []endpointAddress `json:"addresses"`
+	Ports     []endpointPort    `json:"ports"`
+}
+
+type endpointAddress struct {
+	IP        string `json:"ip"`
+	TargetRef *struct {
+		Name string `json:"name"`
+	} `json:"targetRef"`
+}
+
+type endpointPort struct {
+	Name string `json:"name"`
+	Port int32  `json:"port"`
+}
+
+// kubernetesEndpoints is a Membership of the ready addresses of an Endpoints object.
+type kubernetesEndpoints struct {
+	url      string
+	portName string
+	client   *kubernetes.Client
+}
+
+// NewKubernetesEndpoints returns the membership of the configured Endpoints object.
+func NewKubernetesEndpoints(config *bootstrapv1.HashRing_KubernetesEndpoints) (Membership, error) {
+	client, err := kubernetes.NewClient(config.GetApiServer(), config.GetTokenFile(), config.GetCaFile())
+	if err != nil {
+		return nil, err
+	}
+	return &kubernetesEndpoints{
+		url:      fmt.Sprintf("/api/v1/namespaces/%s/endpoints/%s", config.GetNamespace(), config.GetName()),
+		portName: config.GetPortName(),
+		client:   client,
+	}, nil
+}
+
+// Members returns a member for every ready address of the Endpoints object, identified by the name of its pod.
+// Subsets without the port are skipped.
+func (k *kubernetesEndpoints) Members(ctx context.Context) ([]Member, error) {
+	resp, err := k.client.Do(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d fetching endpoints", resp.StatusCode)
+	}
+	var current endpoints
+	if err := json.NewDecoder(resp.Body).Decode(&current); err != nil {
+		return nil, err
+	}
+
+	var members []Member
+	for _, subset := range current.Subsets {
+		port, ok := k.port(subset.Ports)
+		if !ok {
+			continue
+		}
+		for _, address := range subset.Addresses {
+			member := Member{ID: address.IP, Address: net.JoinHostPort(address.IP, strconv.Itoa(int(port)))}
+			if address.TargetRef != nil && address.TargetRef.Name != "" {
+				member.ID = address.TargetRef.Name
+			}
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+// port returns the port with the port name, or the first port if the port name is empty.
+func (k *kubernetesEndpoints) port(ports []endpointPort) (int32, bool) {
+	for _, port := range ports {
+		if k.portName == "" || port.Name == k.portName {
+			return port.Port, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,70 @@
+package ring
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+const testEndpoints = `{
+  "subsets": [
+    {
+      "addresses": [
+        {"ip": "10.0.0.1", "targetRef": {"name": "xds-relay-0"}},
+        {"ip": "10.0.0.2"}
+      ],
+      "ports": [{"name": "admin", "port": 6070}, {"name": "xds", "port": 9991}]
+    },
+    {
+      "addresses": [{"ip": "10.0.0.3"}],
+      "ports": [{"name": "admin", "port": 6070}]
+    }
+  ]
+}`
+
+func TestKubernetesEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		if r.URL.Path != "/api/v1/namespaces/xds-relay/endpoints/xds-relay" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testEndpoints))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "ring")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	tokenFile := filepath.Join(dir, "token")
+	assert.NoError(t, ioutil.WriteFile(tokenFile, []byte("token\n"), 0600))
+
+	config := &bootstrapv1.HashRing_KubernetesEndpoints{
+		Namespace: "xds-relay",
+		Name:      "xds-relay",
+		PortName:  "xds",
+		ApiServer: server.URL,
+		TokenFile: tokenFile,
+	}
+	membership, err := NewKubernetesEndpoints(config)
+	assert.NoError(t, err)
+	members, err := membership.Members(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []Member{
+		{ID: "xds-relay-0", Address: "10.0.0.1:9991"},
+		{ID: "10.0.0.2", Address: "10.0.0.2:9991"},
+	}, members)
+
+	config.Name = "missing"
+	membership, err = NewKubernetesEndpoints(config)
+	assert.NoError(t, err)
+	_, err = membership.Members(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,220 @@
+// Package ring assigns aggregated keys to xds-relay replicas with consistent hashing, so that a single replica holds
+// the stream of each aggregated key with the origin server.
+package ring
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/socket"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/uber-go/tally"
+)
+
+const (
+	defaultVirtualNodes    = 100
+	defaultRefreshInterval = 10 * time.Second
+
+	metricMembers          = "members"
+	metricMembershipChange = "membership_change"
+	metricMembershipError  = "membership_error"
+)
+
+// Member is a replica of the ring.
+type Member struct {
+	ID string
+	// Address is the address of the xDS server of the replica.
+	Address string
+}
+
+// Membership lists the members of the ring.
+type Membership interface {
+	Members(ctx context.Context) ([]Member, error)
+}
+
+// StaticMembership is a fixed list of members.
+type StaticMembership []Member
+
+// Members returns the members of the list.
+func (s StaticMembership) Members(context.Context) ([]Member, error) {
+	return s, nil
+}
+
+// NewMembership returns the membership of the config.
+func NewMembership(config *bootstrapv1.HashRing) (Membership, error) {
+	if config.GetKubernetesEndpoints() != nil {
+		return NewKubernetesEndpoints(config.GetKubernetesEndpoints())
+	}
+	members := make(StaticMembership, 0, len(config.GetStaticMembers().GetMembers()))
+	for _, member := range config.GetStaticMembers().GetMembers() {
+		members = append(members, Member{ID: member.GetId(), Address: socket.Target(member.GetAddress())})
+	}
+	return members, nil
+}
+
+// point is a virtual node of a member on the ring.
+type point struct {
+	hash   uint64
+	member int
+}
+
+// Ring maps aggregated keys to the member that owns them. Each member has a number of virtual nodes on the ring, and
+// a key is owned by the member of the first virtual node that follows the hash of the key.
+type Ring struct {
+	identity        string
+	virtualNodes    int
+	refreshInterval time.Duration
+	membership      Membership
+	logger          log.Logger
+	scope           tally.Scope
+
+	mu        sync.RWMutex
+	members   []Member
+	points    []point
+	callbacks []func()
+}
+
+// New returns the ring of the config, whose members are listed by the membership. Run must be called to populate
+// the ring. Until then, every aggregated key is owned by this replica.
+func New(config *bootstrapv1.HashRing, membership Membership, logger log.Logger, scope tally.Scope) *Ring {
+	identity := config.GetIdentity()
+	if identity == "" {
+		identity, _ = os.Hostname()
+	}
+	r := &Ring{
+		identity:        identity,
+		virtualNodes:    defaultVirtualNodes,
+		refreshInterval: defaultRefreshInterval,
+		membership:      membership,
+		logger:          logger.Named("ring").With("identity", identity),
+		scope:           scope,
+	}
+	if config.GetVirtualNodes() != nil {
+		r.virtualNodes = int(config.GetVirtualNodes().GetValue())
+	}
+	if config.GetRefreshInterval() != nil {
+		if interval, err := ptypes.Duration(config.GetRefreshInterval()); err == nil {
+			r.refreshInterval = interval
+		}
+	}
+	return r
+}
+
+// Identity returns the member ID of this replica.
+func (r *Ring) Identity() string {
+	return r.identity
+}
+
+// OnChange registers a callback invoked each time the membership changes. Callbacks are invoked sequentially from
+// the Run goroutine and must be registered before Run is called.
+func (r *Ring) OnChange(callback func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.callbacks = append(r.callbacks, callback)
+}
+
+// Run refreshes the membership every refresh interval until ctx is done.
+func (r *Ring) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.refreshInterval)
+	defer ticker.Stop()
+	for {
+		if err := r.Refresh(ctx); err != nil {
+			r.scope.Counter(metricMembershipError).Inc(1)
+			r.logger.With("error", err).Warn(ctx, "failed to refresh the membership of the ring")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh lists the members and rebuilds the ring if they changed, after which the callbacks are invoked. The ring
+// is left as is if the members cannot be listed.
+func (r *Ring) Refresh(ctx context.Context) error {
+	members, err := r.membership.Members(ctx)
+	if err != nil {
+		return err
+	}
+	members = append([]Member(nil), members...)
+	sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+
+	r.mu.Lock()
+	if equal(members, r.members) {
+		r.mu.Unlock()
+		return nil
+	}
+	r.members = members
+	r.points = make([]point, 0, len(members)*r.virtualNodes)
+	for i, member := range members {
+		for v := 0; v < r.virtualNodes; v++ {
+			r.points = append(r.points, point{hash: hash(member.ID + "#" + strconv.Itoa(v)), member: i})
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i].hash < r.points[j].hash })
+	callbacks := r.callbacks
+	r.mu.Unlock()
+
+	r.scope.Gauge(metricMembers).Update(float64(len(members)))
+	r.scope.Counter(metricMembershipChange).Inc(1)
+	r.logger.With("members", members).Info(ctx, "ring membership changed")
+	for _, callback := range callbacks {
+		callback()
+	}
+	return nil
+}
+
+// Owner returns the member that owns the aggregated key. It returns false if the ring has no members.
+func (r *Ring) Owner(aggregatedKey string) (Member, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.points) == 0 {
+		return Member{}, false
+	}
+	h := hash(aggregatedKey)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.members[r.points[i].member], true
+}
+
+// Owns returns true if this replica owns the aggregated key, which it does for every key while the ring has no
+// members.
+func (r *Ring) Owns(aggregatedKey string) bool {
+	owner, ok := r.Owner(aggregatedKey)
+	return !ok || owner.ID == r.identity
+}
+
+// Members returns the members of the ring, ordered by ID.
+func (r *Ring) Members() []Member {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Member(nil), r.members...)
+}
+
+func equal(a []Member, b []Member) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func hash(s string) uint64 {
+	sum := sha256.Sum256([]byte(s))
+	return binary.BigEndian.Uint64(sum[:8])
+}
@@ -0,0 +1,111 @@
+package ring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+)
+
+// fakeMembership lists the members set by the test.
+type fakeMembership struct {
+	mu      sync.Mutex
+	members []Member
+	err     error
+}
+
+func (f *fakeMembership) Members(context.Context) ([]Member, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.members, f.err
+}
+
+func (f *fakeMembership) set(members []Member, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.members, f.err = members, err
+}
+
+func newTestRing(membership Membership) (*Ring, tally.TestScope) {
+	scope := tally.NewTestScope("ring", nil)
+	return New(&bootstrapv1.HashRing{Identity: "a"}, membership, log.New("panic"), scope), scope
+}
+
+func TestRingOwner(t *testing.T) {
+	membership := &fakeMembership{}
+	r, scope := newTestRing(membership)
+	changes := 0
+	r.OnChange(func() { changes++ })
+
+	// Every key is owned by this replica until the ring has members.
+	_, ok := r.Owner("lds")
+	assert.False(t, ok)
+	assert.True(t, r.Owns("lds"))
+
+	membership.set([]Member{{ID: "c", Address: "c:9991"}, {ID: "b", Address: "b:9991"}, {ID: "a", Address: "a:9991"}},
+		nil)
+	assert.NoError(t, r.Refresh(context.Background()))
+	assert.Equal(t, 1, changes)
+	assert.Equal(t, []Member{{ID: "a", Address: "a:9991"}, {ID: "b", Address: "b:9991"}, {ID: "c", Address: "c:9991"}},
+		r.Members())
+	assert.Equal(t, float64(3), scope.Snapshot().Gauges()["ring.members+"].Value())
+
+	owners := make(map[string]Member)
+	counts := make(map[string]int)
+	for i := 0; i < 3000; i++ {
+		key := fmt.Sprintf("key_%d", i)
+		owner, ok := r.Owner(key)
+		assert.True(t, ok)
+		assert.Equal(t, owner.ID == "a", r.Owns(key))
+		owners[key] = owner
+		counts[owner.ID]++
+	}
+	// Keys are spread evenly across the members.
+	for _, id := range []string{"a", "b", "c"} {
+		assert.InDelta(t, 1000, counts[id], 300, id)
+	}
+
+	// Refreshing the same members does not change the ring.
+	assert.NoError(t, r.Refresh(context.Background()))
+	assert.Equal(t, 1, changes)
+
+	// Only the keys of a member that leaves change owner.
+	membership.set([]Member{{ID: "a", Address: "a:9991"}, {ID: "c", Address: "c:9991"}}, nil)
+	assert.NoError(t, r.Refresh(context.Background()))
+	assert.Equal(t, 2, changes)
+	for key, previous := range owners {
+		owner, _ := r.Owner(key)
+		if previous.ID != "b" {
+			assert.Equal(t, previous, owner)
+		} else {
+			assert.NotEqual(t, "b", owner.ID)
+		}
+	}
+
+	// The ring is left as is if the members cannot be listed.
+	membership.set(nil, errors.New("unavailable"))
+	assert.Error(t, r.Refresh(context.Background()))
+	assert.Len(t, r.Members(), 2)
+}
+
+func TestNewMembership(t *testing.T) {
+	membership, err := NewMembership(&bootstrapv1.HashRing{
+		Membership: &bootstrapv1.HashRing_StaticMembers_{StaticMembers: &bootstrapv1.HashRing_StaticMembers{
+			Members: []*bootstrapv1.HashRing_Member{
+				{Id: "a", Address: &bootstrapv1.SocketAddress{Address: "10.0.0.1", PortValue: 9991}},
+			},
+		}},
+		VirtualNodes: &wrappers.UInt32Value{Value: 10},
+	})
+	assert.NoError(t, err)
+	members, err := membership.Members(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []Member{{ID: "a", Address: "10.0.0.1:9991"}}, members)
+}
@@ -0,0 +1,217 @@
+// Package sandbox generates the configuration of the local sandbox, in which an Envoy is served by xds-relay, which
+// is in turn served by the management server simulator, and validates that resources flow through the pipeline.
+package sandbox
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/api/v2/endpoint"
+	envoybootstrap "github.com/envoyproxy/go-control-plane/envoy/config/bootstrap/v2"
+	resourcev2 "github.com/envoyproxy/go-control-plane/pkg/test/resource/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	// RelayBootstrapFile, AggregationRulesFile, and EnvoyBootstrapFile are the names of the generated files.
+	RelayBootstrapFile   = "xds-relay-bootstrap.yaml"
+	AggregationRulesFile = "aggregation-rules.yaml"
+	EnvoyBootstrapFile   = "envoy-bootstrap.yaml"
+
+	// EnvoyNodeID is the node ID of the sandbox Envoy.
+	EnvoyNodeID = "sandbox-envoy"
+
+	generatedHeader = "# Generated by `make sandbox-config`. Do not edit.\n"
+)
+
+// ConfigOptions describes the hosts and ports of the sandbox services, as they address each other.
+type ConfigOptions struct {
+	RelayHost      string
+	RelayPort      uint32
+	RelayAdminPort uint32
+	SimulatorHost  string
+	SimulatorPort  uint32
+	EnvoyAdminPort uint32
+}
+
+// DefaultConfigOptions are the options of the docker-compose sandbox, in which services are addressed by their
+// service names.
+var DefaultConfigOptions = ConfigOptions{
+	RelayHost:      "relay",
+	RelayPort:      9991,
+	RelayAdminPort: 6070,
+	SimulatorHost:  "simulator",
+	SimulatorPort:  18000,
+	EnvoyAdminPort: 9901,
+}
+
+// aggregatedKeys are the aggregated keys of the resource types. The sandbox has a single Envoy, so requests are
+// aggregated by type only.
+var aggregatedKeys = []struct {
+	typeURL string
+	key     string
+}{
+	{upstream.ListenerTypeURL, "lds"},
+	{upstream.ClusterTypeURL, "cds"},
+	{upstream.RouteTypeURL, "rds"},
+	{upstream.EndpointTypeURL, "eds"},
+}
+
+// RelayBootstrap returns the bootstrap configuration of xds-relay, which listens on all interfaces and forwards
+// requests to the simulator.
+func RelayBootstrap(options ConfigOptions) *bootstrapv1.Bootstrap {
+	return &bootstrapv1.Bootstrap{
+		Server: &bootstrapv1.Server{
+			Address: &bootstrapv1.SocketAddress{Address: "0.0.0.0", PortValue: options.RelayPort},
+		},
+		OriginServer: &bootstrapv1.Upstream{
+			Address: &bootstrapv1.SocketAddress{Address: options.SimulatorHost, PortValue: options.SimulatorPort},
+		},
+		Logging: &bootstrapv1.Logging{Path: "/dev/stdout", Level: bootstrapv1.Logging_INFO},
+		Cache:   &bootstrapv1.Cache{Ttl: ptypes.DurationProto(60 * time.Second), MaxEntries: 10},
+		Admin: &bootstrapv1.Admin{
+			Address: &bootstrapv1.SocketAddress{Address: "0.0.0.0", PortValue: options.RelayAdminPort},
+		},
+		MetricsSink: &bootstrapv1.MetricsSink{
+			Type: &bootstrapv1.MetricsSink_Statsd{
+				Statsd: &bootstrapv1.Statsd{
+					Address:       &bootstrapv1.SocketAddress{Address: "127.0.0.1", PortValue: 8125},
+					RootPrefix:    "xdsrelay",
+					FlushInterval: ptypes.DurationProto(time.Second),
+				},
+			},
+		},
+	}
+}
+
+// AggregationRules returns the aggregation rules of xds-relay, which map requests of each resource type to a single
+// aggregated key, e.g. "cds".
+func AggregationRules() *aggregationv1.KeyerConfiguration {
+	var rules []*aggregationv1.KeyerConfiguration_Fragment_Rule
+	for _, aggregatedKey := range aggregatedKeys {
+		rules = append(rules, &aggregationv1.KeyerConfiguration_Fragment_Rule{
+			Match: &aggregationv1.MatchPredicate{
+				Type: &aggregationv1.MatchPredicate_RequestTypeMatch_{
+					RequestTypeMatch: &aggregationv1.MatchPredicate_RequestTypeMatch{
+						Types: []string{aggregatedKey.typeURL},
+					},
+				},
+			},
+			Result: &aggregationv1.ResultPredicate{
+				Type: &aggregationv1.ResultPredicate_StringFragment{StringFragment: aggregatedKey.key},
+			},
+		})
+	}
+	return &aggregationv1.KeyerConfiguration{
+		Fragments: []*aggregationv1.KeyerConfiguration_Fragment{{Rules: rules}},
+	}
+}
+
+// EnvoyBootstrap returns the bootstrap configuration of Envoy, which discovers listeners and clusters from
+// xds-relay. The clusters served by the simulator discover their endpoints from the same cluster, which must be
+// named resourcev2.XdsCluster.
+func EnvoyBootstrap(options ConfigOptions) *envoybootstrap.Bootstrap {
+	configSource := &core.ConfigSource{
+		ConfigSourceSpecifier: &core.ConfigSource_ApiConfigSource{
+			ApiConfigSource: &core.ApiConfigSource{
+				ApiType: core.ApiConfigSource_GRPC,
+				GrpcServices: []*core.GrpcService{{
+					TargetSpecifier: &core.GrpcService_EnvoyGrpc_{
+						EnvoyGrpc: &core.GrpcService_EnvoyGrpc{ClusterName: resourcev2.XdsCluster},
+					},
+				}},
+				SetNodeOnFirstMessageOnly: true,
+			},
+		},
+	}
+	return &envoybootstrap.Bootstrap{
+		Node: &core.Node{Id: EnvoyNodeID, Cluster: "sandbox"},
+		Admin: &envoybootstrap.Admin{
+			AccessLogPath: "/dev/null",
+			Address:       socketAddress("0.0.0.0", options.EnvoyAdminPort),
+		},
+		DynamicResources: &envoybootstrap.Bootstrap_DynamicResources{
+			LdsConfig: configSource,
+			CdsConfig: configSource,
+		},
+		StaticResources: &envoybootstrap.Bootstrap_StaticResources{
+			Clusters: []*v2.Cluster{{
+				Name:                 resourcev2.XdsCluster,
+				ConnectTimeout:       ptypes.DurationProto(time.Second),
+				ClusterDiscoveryType: &v2.Cluster_Type{Type: v2.Cluster_STRICT_DNS},
+				Http2ProtocolOptions: &core.Http2ProtocolOptions{},
+				LoadAssignment: &v2.ClusterLoadAssignment{
+					ClusterName: resourcev2.XdsCluster,
+					Endpoints: []*endpoint.LocalityLbEndpoints{{
+						LbEndpoints: []*endpoint.LbEndpoint{{
+							HostIdentifier: &endpoint.LbEndpoint_Endpoint{
+								Endpoint: &endpoint.Endpoint{Address: socketAddress(options.RelayHost, options.RelayPort)},
+							},
+						}},
+					}},
+				},
+			}},
+		},
+	}
+}
+
+func socketAddress(address string, port uint32) *core.Address {
+	return &core.Address{
+		Address: &core.Address_SocketAddress{
+			SocketAddress: &core.SocketAddress{
+				Address:       address,
+				PortSpecifier: &core.SocketAddress_PortValue{PortValue: port},
+			},
+		},
+	}
+}
+
+// Generate validates the configurations and writes them as YAML to the directory.
+func Generate(options ConfigOptions, directory string) error {
+	files := []struct {
+		name string
+		msg  interface {
+			proto.Message
+			Validate() error
+		}
+	}{
+		{RelayBootstrapFile, RelayBootstrap(options)},
+		{AggregationRulesFile, AggregationRules()},
+		{EnvoyBootstrapFile, EnvoyBootstrap(options)},
+	}
+	for _, file := range files {
+		if err := file.msg.Validate(); err != nil {
+			return fmt.Errorf("invalid %s: %s", file.name, err.Error())
+		}
+		contents, err := toYAML(file.msg)
+		if err != nil {
+			return fmt.Errorf("failed to generate %s: %s", file.name, err.Error())
+		}
+		if err := ioutil.WriteFile(filepath.Join(directory, file.name), contents, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toYAML(msg proto.Message) ([]byte, error) {
+	js, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(msg)
+	if err != nil {
+		return nil, err
+	}
+	yml, err := yaml.JSONToYAML([]byte(js))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(generatedHeader), yml...), nil
+}
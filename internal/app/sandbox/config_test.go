@@ -0,0 +1,53 @@
+package sandbox
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/yamlproto"
+	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+// sandboxConfigDirectory holds the generated configuration of the docker-compose sandbox.
+const sandboxConfigDirectory = "../../../sandbox/config"
+
+func TestGenerate(t *testing.T) {
+	directory, err := ioutil.TempDir("", "sandbox")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+	assert.NoError(t, Generate(DefaultConfigOptions, directory))
+
+	contents, err := ioutil.ReadFile(filepath.Join(directory, RelayBootstrapFile))
+	assert.NoError(t, err)
+	var bootstrap bootstrapv1.Bootstrap
+	assert.NoError(t, yamlproto.FromYAMLToBootstrapConfiguration(string(contents), &bootstrap))
+	assert.True(t, proto.Equal(RelayBootstrap(DefaultConfigOptions), &bootstrap))
+
+	contents, err = ioutil.ReadFile(filepath.Join(directory, AggregationRulesFile))
+	assert.NoError(t, err)
+	var rules aggregationv1.KeyerConfiguration
+	assert.NoError(t, yamlproto.FromYAMLToKeyerConfiguration(string(contents), &rules))
+	assert.True(t, proto.Equal(AggregationRules(), &rules))
+}
+
+// TestSandboxConfigUpToDate fails if the configuration of the docker-compose sandbox was not regenerated after the
+// generator changed. Run `make sandbox-config` to regenerate it.
+func TestSandboxConfigUpToDate(t *testing.T) {
+	directory, err := ioutil.TempDir("", "sandbox")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+	assert.NoError(t, Generate(DefaultConfigOptions, directory))
+
+	for _, name := range []string{RelayBootstrapFile, AggregationRulesFile, EnvoyBootstrapFile} {
+		generated, err := ioutil.ReadFile(filepath.Join(directory, name))
+		assert.NoError(t, err)
+		committed, err := ioutil.ReadFile(filepath.Join(sandboxConfigDirectory, name))
+		assert.NoError(t, err)
+		assert.Equal(t, string(generated), string(committed), name)
+	}
+}
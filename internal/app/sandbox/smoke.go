@@ -0,0 +1,166 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/client"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+)
+
+const smokeTestNodeID = "sandbox-smoke-test"
+
+// SmokeTestOptions describes the addresses at which the smoke test reaches the sandbox services.
+type SmokeTestOptions struct {
+	RelayAddress      string
+	RelayAdminAddress string
+	EnvoyAdminAddress string
+	// ListenerAddress is the address of an HTTP listener that the simulator serves to Envoy. It is only reachable
+	// from the network namespace of Envoy, since the simulator binds listeners to localhost. Traffic is not sent
+	// through Envoy if it is empty.
+	ListenerAddress string
+	// Timeout bounds the time that the sandbox has to become healthy. Checks are retried until then.
+	Timeout time.Duration
+	// RetryInterval is the time between attempts of a failing check.
+	RetryInterval time.Duration
+}
+
+type check struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// SmokeTest validates that resources flow from the simulator through xds-relay to Envoy, and, if a listener address
+// is set, that Envoy routes traffic with them. Each check is retried until it passes or the timeout elapses, so that
+// the smoke test can be run while the sandbox is starting.
+func SmokeTest(ctx context.Context, logger log.Logger, options SmokeTestOptions) error {
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+	logger = logger.Named("smoke_test")
+
+	checks := []check{
+		{"relay serves clusters", func(ctx context.Context) error { return checkRelay(ctx, logger, options) }},
+		{"relay caches the requests of envoy", func(ctx context.Context) error { return checkCache(ctx, options) }},
+		{"envoy accepts listeners and clusters", func(ctx context.Context) error { return checkEnvoy(ctx, options) }},
+	}
+	if options.ListenerAddress != "" {
+		checks = append(checks, check{"envoy routes traffic", func(ctx context.Context) error {
+			_, err := get(ctx, options.ListenerAddress, "/")
+			return err
+		}})
+	}
+	for _, check := range checks {
+		if err := retry(ctx, options.RetryInterval, check.run); err != nil {
+			return fmt.Errorf("%s: %s", check.name, err.Error())
+		}
+		logger.With("check", check.name).Info(ctx, "passed")
+	}
+	return nil
+}
+
+// retry runs the check until it succeeds, and returns its last error if ctx is done first. Each attempt is bounded
+// by the retry interval.
+func retry(ctx context.Context, interval time.Duration, run func(ctx context.Context) error) error {
+	for {
+		attemptCtx, cancel := context.WithTimeout(ctx, interval)
+		err := run(attemptCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(interval):
+		}
+	}
+}
+
+func checkRelay(ctx context.Context, logger log.Logger, options SmokeTestOptions) error {
+	resp, err := client.Fetch(ctx, logger, client.FetchOptions{
+		ServerAddress: options.RelayAddress,
+		NodeID:        smokeTestNodeID,
+		ResourceType:  "cluster",
+		Timeout:       options.RetryInterval,
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.GetResources()) == 0 {
+		return fmt.Errorf("response version %s has no clusters", resp.GetVersionInfo())
+	}
+	return nil
+}
+
+func checkCache(ctx context.Context, options SmokeTestOptions) error {
+	entry, err := client.Inspect(ctx, client.InspectOptions{
+		AdminAddress: options.RelayAdminAddress,
+		Key:          "cds",
+		Timeout:      options.RetryInterval,
+	})
+	if err != nil {
+		return err
+	}
+	if entry.Resp == nil {
+		return fmt.Errorf("no response cached")
+	}
+	for _, request := range entry.Requests {
+		if request.GetNode().GetId() == EnvoyNodeID {
+			return nil
+		}
+	}
+	return fmt.Errorf("no request from node %s cached", EnvoyNodeID)
+}
+
+// checkEnvoy checks that Envoy applied at least one update of listeners and of clusters, according to its stats.
+func checkEnvoy(ctx context.Context, options SmokeTestOptions) error {
+	stats, err := get(ctx, options.EnvoyAdminAddress, "/stats?filter=update_success")
+	if err != nil {
+		return err
+	}
+	for _, stat := range []string{"cluster_manager.cds.update_success", "listener_manager.lds.update_success"} {
+		value, err := getStat(stats, stat)
+		if err != nil {
+			return err
+		}
+		if value == 0 {
+			return fmt.Errorf("%s is 0", stat)
+		}
+	}
+	return nil
+}
+
+// getStat parses the value of the stat from the plain text output of the /stats endpoint of the Envoy admin.
+func getStat(stats string, name string) (int, error) {
+	for _, line := range strings.Split(stats, "\n") {
+		if value := strings.TrimPrefix(line, name+": "); value != line {
+			return strconv.Atoi(strings.TrimSpace(value))
+		}
+	}
+	return 0, fmt.Errorf("no stat %s", name)
+}
+
+func get(ctx context.Context, address string, path string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+address+path, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s%s responded with %s", address, path, resp.Status)
+	}
+	return string(contents), nil
+}
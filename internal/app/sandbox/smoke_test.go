@@ -0,0 +1,80 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/envoyproxy/xds-relay/internal/app/client"
+	"github.com/envoyproxy/xds-relay/internal/app/simulator"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/stringify"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSandbox serves the simulator in place of the relay, and fakes the admin servers of the relay and Envoy. It
+// returns the smoke test options that reach them.
+func newSandbox(t *testing.T, envoyStats string) SmokeTestOptions {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go func() {
+		_ = simulator.Serve(ctx, listener, log.New("error"), simulator.Options{Clusters: 1, HTTPListeners: 1})
+	}()
+
+	entry, err := stringify.InterfaceToString(&client.CacheEntry{
+		Resp: &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ClusterTypeURL},
+		Requests: []*v2.DiscoveryRequest{
+			{Node: &core.Node{Id: EnvoyNodeID}, TypeUrl: upstream.ClusterTypeURL},
+		},
+	})
+	assert.NoError(t, err)
+	relayAdmin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, entry)
+	}))
+	t.Cleanup(relayAdmin.Close)
+	envoyAdmin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, envoyStats)
+	}))
+	t.Cleanup(envoyAdmin.Close)
+
+	return SmokeTestOptions{
+		RelayAddress:      listener.Addr().String(),
+		RelayAdminAddress: strings.TrimPrefix(relayAdmin.URL, "http://"),
+		EnvoyAdminAddress: strings.TrimPrefix(envoyAdmin.URL, "http://"),
+		ListenerAddress:   strings.TrimPrefix(envoyAdmin.URL, "http://"),
+		Timeout:           5 * time.Second,
+		RetryInterval:     100 * time.Millisecond,
+	}
+}
+
+func TestSmokeTest(t *testing.T) {
+	options := newSandbox(t, "cluster_manager.cds.update_success: 2\nlistener_manager.lds.update_success: 1\n")
+	assert.NoError(t, SmokeTest(context.Background(), log.New("error"), options))
+}
+
+func TestSmokeTestFailure(t *testing.T) {
+	options := newSandbox(t, "cluster_manager.cds.update_success: 2\nlistener_manager.lds.update_success: 0\n")
+	options.Timeout = 500 * time.Millisecond
+	assert.EqualError(t, SmokeTest(context.Background(), log.New("error"), options),
+		"envoy accepts listeners and clusters: listener_manager.lds.update_success is 0")
+}
+
+func TestGetStat(t *testing.T) {
+	stats := "cluster_manager.cds.update_success: 3\ncluster_manager.cds.update_success_count: 1\n"
+	value, err := getStat(stats, "cluster_manager.cds.update_success")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, value)
+
+	_, err = getStat(stats, "listener_manager.lds.update_success")
+	assert.EqualError(t, err, "no stat listener_manager.lds.update_success")
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/server/v2"
+)
+
+// chainedCallbacks calls each of the callbacks in order. The first error
+// returned by a callback ends the chain and is returned, and the callbacks
+// after it are not called.
+type chainedCallbacks []gcp.Callbacks
+
+func (c chainedCallbacks) OnStreamOpen(ctx context.Context, streamID int64, typeURL string) error {
+	for _, callbacks := range c {
+		if err := callbacks.OnStreamOpen(ctx, streamID, typeURL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c chainedCallbacks) OnStreamClosed(streamID int64) {
+	for _, callbacks := range c {
+		callbacks.OnStreamClosed(streamID)
+	}
+}
+
+func (c chainedCallbacks) OnStreamRequest(streamID int64, req *discovery.DiscoveryRequest) error {
+	for _, callbacks := range c {
+		if err := callbacks.OnStreamRequest(streamID, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c chainedCallbacks) OnStreamResponse(
+	streamID int64,
+	req *discovery.DiscoveryRequest,
+	resp *discovery.DiscoveryResponse,
+) {
+	for _, callbacks := range c {
+		callbacks.OnStreamResponse(streamID, req, resp)
+	}
+}
+
+func (c chainedCallbacks) OnFetchRequest(ctx context.Context, req *discovery.DiscoveryRequest) error {
+	for _, callbacks := range c {
+		if err := callbacks.OnFetchRequest(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c chainedCallbacks) OnFetchResponse(req *discovery.DiscoveryRequest, resp *discovery.DiscoveryResponse) {
+	for _, callbacks := range c {
+		callbacks.OnFetchResponse(req, resp)
+	}
+}
+
+// CallbackFuncs implements the callbacks of the xDS server with functions, so
+// that embedders only provide the callbacks they need. Callbacks whose
+// function is nil do nothing.
+type CallbackFuncs struct {
+	StreamOpenFunc     func(ctx context.Context, streamID int64, typeURL string) error
+	StreamClosedFunc   func(streamID int64)
+	StreamRequestFunc  func(streamID int64, req *discovery.DiscoveryRequest) error
+	StreamResponseFunc func(streamID int64, req *discovery.DiscoveryRequest, resp *discovery.DiscoveryResponse)
+	FetchRequestFunc   func(ctx context.Context, req *discovery.DiscoveryRequest) error
+	FetchResponseFunc  func(req *discovery.DiscoveryRequest, resp *discovery.DiscoveryResponse)
+}
+
+func (c CallbackFuncs) OnStreamOpen(ctx context.Context, streamID int64, typeURL string) error {
+	if c.StreamOpenFunc == nil {
+		return nil
+	}
+	return c.StreamOpenFunc(ctx, streamID, typeURL)
+}
+
+func (c CallbackFuncs) OnStreamClosed(streamID int64) {
+	if c.StreamClosedFunc != nil {
+		c.StreamClosedFunc(streamID)
+	}
+}
+
+func (c CallbackFuncs) OnStreamRequest(streamID int64, req *discovery.DiscoveryRequest) error {
+	if c.StreamRequestFunc == nil {
+		return nil
+	}
+	return c.StreamRequestFunc(streamID, req)
+}
+
+func (c CallbackFuncs) OnStreamResponse(
+	streamID int64,
+	req *discovery.DiscoveryRequest,
+	resp *discovery.DiscoveryResponse,
+) {
+	if c.StreamResponseFunc != nil {
+		c.StreamResponseFunc(streamID, req, resp)
+	}
+}
+
+func (c CallbackFuncs) OnFetchRequest(ctx context.Context, req *discovery.DiscoveryRequest) error {
+	if c.FetchRequestFunc == nil {
+		return nil
+	}
+	return c.FetchRequestFunc(ctx, req)
+}
+
+func (c CallbackFuncs) OnFetchResponse(req *discovery.DiscoveryRequest, resp *discovery.DiscoveryResponse) {
+	if c.FetchResponseFunc != nil {
+		c.FetchResponseFunc(req, resp)
+	}
+}
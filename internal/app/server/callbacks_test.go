@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainedCallbacks(t *testing.T) {
+	var calls []string
+	record := func(name string, err error) CallbackFuncs {
+		return CallbackFuncs{
+			StreamOpenFunc: func(ctx context.Context, streamID int64, typeURL string) error {
+				calls = append(calls, name+" open")
+				return err
+			},
+			StreamClosedFunc: func(streamID int64) {
+				calls = append(calls, name+" closed")
+			},
+			StreamRequestFunc: func(streamID int64, req *discovery.DiscoveryRequest) error {
+				calls = append(calls, name+" request")
+				return err
+			},
+			StreamResponseFunc: func(streamID int64, req *discovery.DiscoveryRequest, resp *discovery.DiscoveryResponse) {
+				calls = append(calls, name+" response")
+			},
+		}
+	}
+	failure := errors.New("quota exceeded")
+	callbacks := chainedCallbacks{record("first", nil), record("second", failure), record("third", nil)}
+
+	assert.Equal(t, failure, callbacks.OnStreamOpen(context.Background(), 1, ""))
+	assert.Equal(t, failure, callbacks.OnStreamRequest(1, &discovery.DiscoveryRequest{}))
+	callbacks.OnStreamResponse(1, &discovery.DiscoveryRequest{}, &discovery.DiscoveryResponse{})
+	callbacks.OnStreamClosed(1)
+	assert.Equal(t, []string{
+		"first open", "second open",
+		"first request", "second request",
+		"first response", "second response", "third response",
+		"first closed", "second closed", "third closed",
+	}, calls)
+
+	// Callbacks without a function do nothing.
+	assert.NoError(t, callbacks.OnFetchRequest(context.Background(), &discovery.DiscoveryRequest{}))
+	callbacks.OnFetchResponse(&discovery.DiscoveryRequest{}, &discovery.DiscoveryResponse{})
+	assert.Equal(t, 10, len(calls))
+}
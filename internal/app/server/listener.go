@@ -0,0 +1,160 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/envoyproxy/xds-relay/internal/app/admission"
+	"github.com/envoyproxy/xds-relay/internal/app/interceptor"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	replicationv1 "github.com/envoyproxy/xds-relay/pkg/api/replication/v1"
+
+	api "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/server/v2"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const metricTagListener = "listener"
+
+// registerListenerService registers the gRPC services of the xDS service of a listener on the gRPC server.
+func registerListenerService(server *grpc.Server, gcpServer gcp.Server, service bootstrapv1.Listener_Service) {
+	switch service {
+	case bootstrapv1.Listener_ADS:
+		discovery.RegisterAggregatedDiscoveryServiceServer(server, gcpServer)
+	case bootstrapv1.Listener_CDS_EDS:
+		api.RegisterClusterDiscoveryServiceServer(server, gcpServer)
+		api.RegisterEndpointDiscoveryServiceServer(server, gcpServer)
+	case bootstrapv1.Listener_LDS_RDS:
+		api.RegisterListenerDiscoveryServiceServer(server, gcpServer)
+		api.RegisterRouteDiscoveryServiceServer(server, gcpServer)
+	case bootstrapv1.Listener_RTDS:
+		discovery.RegisterRuntimeDiscoveryServiceServer(server, gcpServer)
+	}
+}
+
+// serverInterceptors returns the interceptors of an xDS server: the built-in interceptors, the admission control of
+// the admission config, if any, and then the other interceptors, in order.
+func serverInterceptors(builtIn interceptor.Interceptors, admissionConfig *bootstrapv1.Admission,
+	others interceptor.Interceptors, logger log.Logger, scope tally.Scope) (interceptor.Interceptors, error) {
+	var interceptors interceptor.Interceptors
+	interceptors.Append(builtIn)
+	if admissionConfig != nil {
+		admissionController, err := admission.New(admissionConfig, logger, scope)
+		if err != nil {
+			return interceptor.Interceptors{}, err
+		}
+		interceptors.Stream = append(interceptors.Stream, admissionController.StreamServerInterceptor())
+	}
+	interceptors.Append(others)
+	return interceptors, nil
+}
+
+// newListenerServer returns the gRPC server of the listener config, which serves the xDS services of the listener
+// over its own TLS and admission control settings. The admission control of the listener runs after the built-in
+// interceptors and before the others.
+func newListenerServer(config *bootstrapv1.Listener, gcpServer gcp.Server, opts []grpc.ServerOption,
+	builtIn interceptor.Interceptors, others interceptor.Interceptors, logger log.Logger,
+	scope tally.Scope) (*grpc.Server, error) {
+	admissionScope := scope.SubScope(metricSubscopeAdmission).Tagged(map[string]string{
+		metricTagListener: config.GetName(),
+	})
+	interceptors, err := serverInterceptors(builtIn, config.GetAdmission(), others, logger, admissionScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize admission control of listener %s: %w", config.GetName(), err)
+	}
+	serverOpts := append(append([]grpc.ServerOption(nil), opts...), interceptors.ServerOptions()...)
+	if tlsConfig := config.GetTls(); tlsConfig != nil {
+		creds, err := newTLSCredentials(tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize TLS of listener %s: %w", config.GetName(), err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+	}
+	server := grpc.NewServer(serverOpts...)
+	registered := make(map[bootstrapv1.Listener_Service]bool)
+	for _, service := range config.GetServices() {
+		if !registered[service] {
+			registerListenerService(server, gcpServer, service)
+			registered[service] = true
+		}
+	}
+	return server, nil
+}
+
+// newTLSCredentials returns the server credentials of the TLS config. Client certificates are required and verified
+// if the config has client CAs.
+func newTLSCredentials(config *bootstrapv1.TLS) (credentials.TransportCredentials, error) {
+	certificate, err := tls.LoadX509KeyPair(config.GetCertFile(), config.GetKeyFile())
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if caFile := config.GetClientCaFile(); caFile != "" {
+		caPEM, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates in %s", caFile)
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// newReplicationListenerServer returns the gRPC server that serves cache updates to peers over the TLS settings of the
+// listener config. Only the built-in interceptors run, since the admission control of the xDS server does not apply to
+// peers.
+func newReplicationListenerServer(config *bootstrapv1.ReplicationListener,
+	replicationServer replicationv1.ReplicationServer, builtIn interceptor.Interceptors) (*grpc.Server, error) {
+	serverOpts := builtIn.ServerOptions()
+	if tlsConfig := config.GetTls(); tlsConfig != nil {
+		creds, err := newTLSCredentials(tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize TLS of replication listener: %w", err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+	}
+	server := grpc.NewServer(serverOpts...)
+	replicationv1.RegisterReplicationServer(server, replicationServer)
+	return server, nil
+}
+
+// newClientTLSCredentials returns the client credentials of the TLS config. The client certificate, if any, is
+// presented to the server, and the server certificate is verified against the CAs of the config, or of the host if
+// the config has none.
+func newClientTLSCredentials(config *bootstrapv1.ClientTLS) (credentials.TransportCredentials, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if certFile := config.GetCertFile(); certFile != "" {
+		certificate, err := tls.LoadX509KeyPair(certFile, config.GetKeyFile())
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	if caFile := config.GetCaFile(); caFile != "" {
+		caPEM, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates in %s", caFile)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/interceptor"
+	"github.com/envoyproxy/xds-relay/internal/app/replication"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+
+	gcp "github.com/envoyproxy/go-control-plane/pkg/server/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+)
+
+func TestNewListenerServer(t *testing.T) {
+	var tests = []struct {
+		name     string
+		services []bootstrapv1.Listener_Service
+		want     []string
+	}{
+		{
+			name:     "ads",
+			services: []bootstrapv1.Listener_Service{bootstrapv1.Listener_ADS},
+			want:     []string{"envoy.service.discovery.v2.AggregatedDiscoveryService"},
+		},
+		{
+			name:     "cds and eds",
+			services: []bootstrapv1.Listener_Service{bootstrapv1.Listener_CDS_EDS, bootstrapv1.Listener_CDS_EDS},
+			want:     []string{"envoy.api.v2.ClusterDiscoveryService", "envoy.api.v2.EndpointDiscoveryService"},
+		},
+		{
+			name:     "lds and rds",
+			services: []bootstrapv1.Listener_Service{bootstrapv1.Listener_LDS_RDS},
+			want:     []string{"envoy.api.v2.ListenerDiscoveryService", "envoy.api.v2.RouteDiscoveryService"},
+		},
+		{
+			name:     "rtds",
+			services: []bootstrapv1.Listener_Service{bootstrapv1.Listener_RTDS},
+			want:     []string{"envoy.service.discovery.v2.RuntimeDiscoveryService"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server, err := newListenerServer(&bootstrapv1.Listener{
+				Name:      tc.name,
+				Services:  tc.services,
+				Admission: &bootstrapv1.Admission{StreamsPerSecond: 1},
+			}, gcp.NewServer(context.Background(), nil, nil), nil, interceptor.Interceptors{}, interceptor.Interceptors{},
+				&logger{}, tally.NoopScope)
+			assert.NoError(t, err)
+			var services []string
+			for service := range server.GetServiceInfo() {
+				services = append(services, service)
+			}
+			sort.Strings(services)
+			assert.Equal(t, tc.want, services)
+		})
+	}
+}
+
+func TestServerInterceptors(t *testing.T) {
+	builtIn := interceptor.Interceptors{Stream: []grpc.StreamServerInterceptor{nil}}
+	others := interceptor.Interceptors{Stream: []grpc.StreamServerInterceptor{nil}}
+	interceptors, err := serverInterceptors(builtIn, nil, others, &logger{}, tally.NoopScope)
+	assert.NoError(t, err)
+	assert.Len(t, interceptors.Stream, 2)
+	interceptors, err = serverInterceptors(builtIn, &bootstrapv1.Admission{StreamsPerSecond: 1}, others, &logger{},
+		tally.NoopScope)
+	assert.NoError(t, err)
+	assert.Len(t, interceptors.Stream, 3)
+	assert.NotNil(t, interceptors.Stream[1])
+	assert.Len(t, builtIn.Stream, 1)
+}
+
+func TestNewTLSCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeCertificate(t, dir)
+
+	creds, err := newTLSCredentials(&bootstrapv1.TLS{CertFile: certFile, KeyFile: keyFile})
+	assert.NoError(t, err)
+	assert.Equal(t, "tls", creds.Info().SecurityProtocol)
+	_, err = newTLSCredentials(&bootstrapv1.TLS{CertFile: certFile, KeyFile: keyFile, ClientCaFile: certFile})
+	assert.NoError(t, err)
+
+	_, err = newTLSCredentials(&bootstrapv1.TLS{CertFile: filepath.Join(dir, "missing"), KeyFile: keyFile})
+	assert.Error(t, err)
+	_, err = newTLSCredentials(&bootstrapv1.TLS{CertFile: certFile, KeyFile: keyFile, ClientCaFile: keyFile})
+	assert.EqualError(t, err, "no certificates in "+keyFile)
+
+	_, err = newListenerServer(&bootstrapv1.Listener{
+		Name:     "tls",
+		Services: []bootstrapv1.Listener_Service{bootstrapv1.Listener_ADS},
+		Tls:      &bootstrapv1.TLS{CertFile: certFile, KeyFile: filepath.Join(dir, "missing")},
+	}, gcp.NewServer(context.Background(), nil, nil), nil, interceptor.Interceptors{}, interceptor.Interceptors{},
+		&logger{}, tally.NoopScope)
+	assert.Error(t, err)
+}
+
+func TestNewReplicationListenerServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeCertificate(t, dir)
+	replicationServer := replication.NewServer(log.New("info"), tally.NoopScope)
+
+	server, err := newReplicationListenerServer(&bootstrapv1.ReplicationListener{
+		Tls: &bootstrapv1.TLS{CertFile: certFile, KeyFile: keyFile, ClientCaFile: certFile},
+	}, replicationServer, interceptor.Interceptors{})
+	assert.NoError(t, err)
+	var services []string
+	for service := range server.GetServiceInfo() {
+		services = append(services, service)
+	}
+	assert.Equal(t, []string{"replication.Replication"}, services)
+
+	_, err = newReplicationListenerServer(&bootstrapv1.ReplicationListener{
+		Tls: &bootstrapv1.TLS{CertFile: certFile, KeyFile: filepath.Join(dir, "missing")},
+	}, replicationServer, interceptor.Interceptors{})
+	assert.Error(t, err)
+}
+
+func TestNewClientTLSCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeCertificate(t, dir)
+
+	creds, err := newClientTLSCredentials(&bootstrapv1.ClientTLS{})
+	assert.NoError(t, err)
+	assert.Equal(t, "tls", creds.Info().SecurityProtocol)
+	_, err = newClientTLSCredentials(&bootstrapv1.ClientTLS{CaFile: certFile, CertFile: certFile, KeyFile: keyFile})
+	assert.NoError(t, err)
+
+	_, err = newClientTLSCredentials(&bootstrapv1.ClientTLS{CertFile: certFile})
+	assert.Error(t, err)
+	_, err = newClientTLSCredentials(&bootstrapv1.ClientTLS{CaFile: keyFile})
+	assert.EqualError(t, err, "no certificates in "+keyFile)
+}
+
+// writeCertificate writes a self-signed certificate and its key to the directory, and returns their paths.
+func writeCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "xds-relay"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	assert.NoError(t, ioutil.WriteFile(certFile, certPEM, 0600))
+	assert.NoError(t, ioutil.WriteFile(keyFile, keyPEM, 0600))
+	return certFile, keyFile
+}
@@ -2,37 +2,111 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	handler "github.com/envoyproxy/xds-relay/internal/app/admin/http"
+	"github.com/envoyproxy/xds-relay/internal/app/apiversion"
+	"github.com/envoyproxy/xds-relay/internal/app/audit"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"github.com/envoyproxy/xds-relay/internal/app/codec"
+	"github.com/envoyproxy/xds-relay/internal/app/dryrun"
 	"github.com/envoyproxy/xds-relay/internal/pkg/stats"
 
+	"github.com/envoyproxy/xds-relay/internal/app/election"
+	"github.com/envoyproxy/xds-relay/internal/app/interceptor"
+	"github.com/envoyproxy/xds-relay/internal/app/loadreport"
 	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/memory"
+	"github.com/envoyproxy/xds-relay/internal/app/notifier"
 	"github.com/envoyproxy/xds-relay/internal/app/orchestrator"
+	"github.com/envoyproxy/xds-relay/internal/app/recording"
+	"github.com/envoyproxy/xds-relay/internal/app/replication"
+	"github.com/envoyproxy/xds-relay/internal/app/rest"
+	"github.com/envoyproxy/xds-relay/internal/app/ring"
+	"github.com/envoyproxy/xds-relay/internal/app/signature"
+	"github.com/envoyproxy/xds-relay/internal/app/static"
+	"github.com/envoyproxy/xds-relay/internal/app/status"
+	"github.com/envoyproxy/xds-relay/internal/app/transform"
 	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/app/vhds"
 	"github.com/envoyproxy/xds-relay/internal/pkg/log"
 	"github.com/envoyproxy/xds-relay/internal/pkg/util"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/socket"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/yamlproto"
+	"github.com/envoyproxy/xds-relay/internal/pkg/version"
 
 	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
 	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	statusv1 "github.com/envoyproxy/xds-relay/pkg/api/status/v1"
 
 	api "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
+	lrs "github.com/envoyproxy/go-control-plane/envoy/service/load_stats/v2"
+	routeservice "github.com/envoyproxy/go-control-plane/envoy/service/route/v3"
 	gcp "github.com/envoyproxy/go-control-plane/pkg/server/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/uber-go/tally"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
 	metricSubscope             = "server"
 	metricSubscopeOrchestrator = "orchestrator"
+	metricSubscopeCodec        = "codec"
+	metricSubscopeNotifier     = "notifier"
+	metricSubscopeElection     = "election"
+	metricSubscopeRing         = "ring"
+	metricSubscopeReplication  = "replication"
+	metricSubscopeDryRun       = "dry_run"
+	metricSubscopeOverrides    = "overrides"
+	metricSubscopeRecording    = "recording"
+	metricSubscopeStatus       = "status"
+	metricSubscopeAudit        = "audit"
+	metricSubscopeMemory       = "memory"
+	metricSubscopeAdmission    = "admission"
+	metricSubscopeInterceptor  = "interceptor"
+	metricSubscopeLoadReport   = "load_reporting"
+	metricSubscopeVHDS         = "vhds"
 	metricServerAlive          = "alive"
+
+	// recentLogsSize is the number of recent messages included in support bundles.
+	recentLogsSize = 1000
 )
 
+// Components are the components of the server that embedders may provide in place of the ones configured from the
+// bootstrap. Each component that is nil is configured from the bootstrap.
+type Components struct {
+	Logger log.Logger
+	// Scope is the root scope of the metrics of the server. It is not closed when the server stops.
+	Scope  tally.Scope
+	Mapper mapper.Mapper
+	// UpstreamDialer creates the clients of the origin server and of the shadow origin server.
+	UpstreamDialer upstream.Dialer
+	// UpstreamMetadata is attached to the streams with the origin server and the shadow origin server after the
+	// metadata of the bootstrap, e.g. to authenticate with tokens from a custom upstream.TokenSource.
+	UpstreamMetadata []upstream.Metadata
+	// CacheFactory creates the cache of responses, whose TTL and max entries are then up to the factory.
+	CacheFactory cache.Factory
+	// Interceptors run after the built-in interceptors and admission control of the xDS server.
+	Interceptors interceptor.Interceptors
+	// Callbacks are called at the hook points of the xDS protocol, in order, after the callbacks of the relay, so
+	// that they observe the nonces the relay sets on responses. The first error returned by a request callback fails
+	// the stream or call.
+	Callbacks []gcp.Callbacks
+}
+
 // Run instantiates a running gRPC server for accepting incoming xDS-based requests.
 func Run(bootstrapConfig *bootstrapv1.Bootstrap,
 	aggregationRulesConfig *aggregationv1.KeyerConfiguration,
@@ -50,6 +124,13 @@ func RunAdminServer(ctx context.Context, adminServer *http.Server, logger log.Lo
 	}
 }
 
+func RunRESTServer(ctx context.Context, restServer *http.Server, logger log.Logger) {
+	logger.With("address", restServer.Addr).Info(ctx, "Starting REST server")
+	if err := restServer.ListenAndServe(); err != http.ErrServerClosed {
+		logger.Fatal(ctx, "Failed to start REST server with ListenAndServe: %v", err)
+	}
+}
+
 func RunWithContext(ctx context.Context, cancel context.CancelFunc, bootstrapConfig *bootstrapv1.Bootstrap,
 	aggregationRulesConfig *aggregationv1.KeyerConfiguration, logLevel string, mode string) {
 	// Initialize logger. The command line input for the log level overrides the log level set in the bootstrap config.
@@ -60,61 +141,437 @@ func RunWithContext(ctx context.Context, cancel context.CancelFunc, bootstrapCon
 	} else {
 		logger = log.New(bootstrapConfig.Logging.Level.String())
 	}
+	RunWithLogger(ctx, cancel, bootstrapConfig, aggregationRulesConfig, logger, mode)
+}
 
-	// Initialize metrics sink. For now we default to statsd.
-	statsdPort := strconv.FormatUint(uint64(bootstrapConfig.MetricsSink.GetStatsd().Address.PortValue), 10)
-	statsdAddress := net.JoinHostPort(bootstrapConfig.MetricsSink.GetStatsd().Address.Address, statsdPort)
-	scope, scopeCloser, err := stats.NewScope(stats.Config{
-		StatsdAddress: statsdAddress,
-		RootPrefix:    bootstrapConfig.MetricsSink.GetStatsd().RootPrefix,
-		FlushInterval: time.Duration(bootstrapConfig.MetricsSink.GetStatsd().FlushInterval.Nanos),
-	})
-	defer func() {
-		if err := scopeCloser.Close(); err != nil {
-			panic(err)
-		}
-	}()
+// RunWithLogger runs the server like RunWithContext, logging to the provided logger instead of a logger configured
+// from the bootstrap, so that embedders can integrate their logging stack.
+func RunWithLogger(ctx context.Context, cancel context.CancelFunc, bootstrapConfig *bootstrapv1.Bootstrap,
+	aggregationRulesConfig *aggregationv1.KeyerConfiguration, logger log.Logger, mode string) {
+	RunWithComponents(ctx, cancel, bootstrapConfig, aggregationRulesConfig, Components{Logger: logger}, mode)
+}
 
+// RunWithComponents runs the server like RunWithContext, with the provided components in place of the ones
+// configured from the bootstrap. Unlike the standalone server, it stops serving once ctx is done, so that embedders
+// control its lifetime.
+func RunWithComponents(ctx context.Context, cancel context.CancelFunc, bootstrapConfig *bootstrapv1.Bootstrap,
+	aggregationRulesConfig *aggregationv1.KeyerConfiguration, components Components, mode string) {
+	logger := components.Logger
+	if logger == nil {
+		logger = log.New(bootstrapConfig.GetLogging().GetLevel().String())
+	}
+	recentLogs := log.NewRecent(recentLogsSize)
+	logger = recentLogs.Wrap(logger)
+	var keyEvents *log.KeyEvents
+	if size := bootstrapConfig.GetLogging().GetKeyEventsSize(); size > 0 {
+		keyEvents = log.NewKeyEvents(int(size))
+		logger = keyEvents.Wrap(logger)
+	}
+	versionInfo, err := version.WithConfigs(bootstrapConfig, aggregationRulesConfig)
 	if err != nil {
-		logger.With("error", err).Panic(ctx, "failed to configure stats client")
-	}
-
-	// Initialize upstream client.
-	upstreamPort := strconv.FormatUint(uint64(bootstrapConfig.OriginServer.Address.PortValue), 10)
-	upstreamAddress := net.JoinHostPort(bootstrapConfig.OriginServer.Address.Address, upstreamPort)
-	// TODO: configure timeout param from bootstrap config.
-	// https://github.com/envoyproxy/xds-relay/issues/55
-	upstreamClient, err := upstream.New(
-		ctx,
-		upstreamAddress,
-		upstream.CallOptions{Timeout: time.Minute},
-		logger,
-	)
-	if err != nil {
-		logger.With("error", err).Panic(ctx, "failed to initialize upstream client")
+		logger.With("error", err).Panic(ctx, "failed to fingerprint configuration")
+	}
+	logger.With("version", versionInfo.Version).With("git SHA", versionInfo.GitSHA).
+		With("build time", versionInfo.BuildTime).
+		With("bootstrap fingerprint", versionInfo.BootstrapFingerprint).
+		With("aggregation fingerprint", versionInfo.AggregationFingerprint).
+		Info(ctx, "Starting xds-relay")
+	memory.Tune(bootstrapConfig.GetMemory())
+
+	// Initialize metrics sink. For now we default to statsd.
+	scope := components.Scope
+	if scope == nil {
+		statsdPort := strconv.FormatUint(uint64(bootstrapConfig.MetricsSink.GetStatsd().Address.PortValue), 10)
+		statsdAddress := net.JoinHostPort(bootstrapConfig.MetricsSink.GetStatsd().Address.Address, statsdPort)
+		statsdScope, scopeCloser, err := stats.NewScope(stats.Config{
+			StatsdAddress: statsdAddress,
+			RootPrefix:    bootstrapConfig.MetricsSink.GetStatsd().RootPrefix,
+			FlushInterval: time.Duration(bootstrapConfig.MetricsSink.GetStatsd().FlushInterval.Nanos),
+		})
+		defer func() {
+			if err := scopeCloser.Close(); err != nil {
+				panic(err)
+			}
+		}()
+
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to configure stats client")
+		}
+		scope = statsdScope
 	}
 
 	// Initialize request aggregation mapper component.
-	requestMapper := mapper.New(aggregationRulesConfig)
+	requestMapper := components.Mapper
+	if requestMapper == nil {
+		requestMapper = mapper.New(aggregationRulesConfig)
+	}
+	if rolloutConfig := bootstrapConfig.GetAggregationRollout(); rolloutConfig != nil {
+		requestMapper, err = newStagedMapper(requestMapper, rolloutConfig, logger)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize aggregation rollout")
+		}
+	}
+	dialUpstream := components.UpstreamDialer
+	if dialUpstream == nil {
+		dialUpstream = upstream.New
+	}
+
+	// Initialize upstream client. A replayed recording takes the place of the origin server.
+	var upstreamClient upstream.Client
+	var upstreamCallOptions upstream.CallOptions
+	if replayConfig := bootstrapConfig.GetReplay(); replayConfig != nil {
+		upstreamClient = recording.NewReplayClient(replayConfig, logger)
+	} else {
+		upstreamAddress := socket.Target(bootstrapConfig.OriginServer.Address)
+		upstreamProxy, err := newUpstreamProxy(bootstrapConfig.OriginServer.GetProxy())
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize upstream proxy")
+		}
+		upstreamMetadata, err := newUpstreamMetadata(bootstrapConfig.OriginServer)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize upstream metadata")
+		}
+		// TODO: configure timeout param from bootstrap config.
+		// https://github.com/envoyproxy/xds-relay/issues/55
+		upstreamCallOptions = upstream.CallOptions{
+			Timeout:    time.Minute,
+			Proxy:      upstreamProxy,
+			Discovery:  newUpstreamDiscovery(bootstrapConfig.OriginServer.GetDiscovery()),
+			Metadata:   append(upstreamMetadata, components.UpstreamMetadata...),
+			APIVersion: newUpstreamAPIVersion(bootstrapConfig.OriginServer),
+		}
+		upstreamClient, err = dialUpstream(ctx, upstreamAddress, upstreamCallOptions, logger)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize upstream client")
+		}
+	}
+
+	// Initialize the downstream codec, which reuses serialized responses across downstream sends.
+	responseCodec := codec.New(scope.SubScope(metricSubscopeCodec))
 
 	// Initialize orchestrator.
+	orchestratorOpts := []orchestrator.Opts{
+		orchestrator.WithVersionGuard(bootstrapConfig.VersionGuard),
+		orchestrator.WithResponseLimits(bootstrapConfig.ResponseLimits),
+		orchestrator.WithSupervision(bootstrapConfig.Supervision),
+	}
+	if bootstrapConfig.GetServer().GetWatchIdleTimeout() != nil {
+		watchIdleTimeout, err := ptypes.Duration(bootstrapConfig.GetServer().GetWatchIdleTimeout())
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "invalid watch idle timeout")
+		}
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithWatchIdleTimeout(watchIdleTimeout))
+	}
+	if bootstrapConfig.GetServer().GetResumptionTokens() {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithResumptionTokens())
+	}
+	if bootstrapConfig.GetFanoutScheduling() != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithFanoutScheduling(bootstrapConfig.FanoutScheduling))
+	}
+	if webhooks := bootstrapConfig.GetNotifications().GetWebhooks(); len(webhooks) > 0 {
+		notifierScope := scope.SubScope(metricSubscopeNotifier)
+		var notifiers []notifier.Notifier
+		for _, webhook := range webhooks {
+			notifiers = append(notifiers, notifier.NewWebhook(ctx, webhook, logger, notifierScope))
+		}
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithNotifier(notifier.NewMulti(notifiers...)))
+	}
+	var elector *election.Elector
+	if electionConfig := bootstrapConfig.GetLeaderElection(); electionConfig != nil {
+		lock, err := election.NewLock(electionConfig)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize leader election lock")
+		}
+		elector = election.New(electionConfig, lock, logger, scope.SubScope(metricSubscopeElection))
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithLeaderElection(elector))
+	}
+	// The ring is only run when serving, so that every key is owned by a dry-run relay.
+	var hashRing *ring.Ring
+	if ringConfig := bootstrapConfig.GetHashRing(); ringConfig != nil {
+		membership, err := ring.NewMembership(ringConfig)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize hash ring membership")
+		}
+		hashRing = ring.New(ringConfig, membership, logger, scope.SubScope(metricSubscopeRing))
+		dialPeer := func(address string) (upstream.Client, error) {
+			return dialUpstream(ctx, address, upstream.CallOptions{Timeout: time.Minute}, logger)
+		}
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithHashRing(hashRing, dialPeer))
+	}
+	if shadowServer := bootstrapConfig.GetShadowServer(); shadowServer != nil {
+		shadowProxy, err := newUpstreamProxy(shadowServer.GetProxy())
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize shadow upstream proxy")
+		}
+		shadowMetadata, err := newUpstreamMetadata(shadowServer)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize shadow upstream metadata")
+		}
+		shadowClient, err := dialUpstream(
+			ctx,
+			socket.Target(shadowServer.Address),
+			upstream.CallOptions{
+				Timeout:    time.Minute,
+				Proxy:      shadowProxy,
+				Discovery:  newUpstreamDiscovery(shadowServer.GetDiscovery()),
+				Metadata:   append(shadowMetadata, components.UpstreamMetadata...),
+				APIVersion: newUpstreamAPIVersion(shadowServer),
+			},
+			logger,
+		)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize shadow upstream client")
+		}
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithShadowUpstream(shadowClient))
+	}
+	if parentRelayConfig := bootstrapConfig.GetParentRelay(); parentRelayConfig != nil {
+		parentServer := parentRelayConfig.GetUpstream()
+		parentProxy, err := newUpstreamProxy(parentServer.GetProxy())
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize parent relay proxy")
+		}
+		parentMetadata, err := newUpstreamMetadata(parentServer)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize parent relay metadata")
+		}
+		parentClient, err := dialUpstream(
+			ctx,
+			socket.Target(parentServer.Address),
+			upstream.CallOptions{
+				Timeout:    time.Minute,
+				Proxy:      parentProxy,
+				Discovery:  newUpstreamDiscovery(parentServer.GetDiscovery()),
+				Metadata:   append(parentMetadata, components.UpstreamMetadata...),
+				APIVersion: newUpstreamAPIVersion(parentServer),
+			},
+			logger,
+		)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize parent relay client")
+		}
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithParentRelay(parentRelayConfig, parentClient))
+	}
+	if transformations := bootstrapConfig.GetTransformations(); len(transformations) > 0 {
+		transformer, err := transform.New(transformations)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize transformations")
+		}
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithTransformer(transformer))
+	}
+	var overrides *transform.Overrides
+	if overrideFiles := bootstrapConfig.GetOverrideFiles(); overrideFiles != nil {
+		overrides, err = transform.NewOverrides(overrideFiles, logger, scope.SubScope(metricSubscopeOverrides))
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to load override files")
+		}
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithOverrides(overrides))
+	}
+	if staticConfigs := bootstrapConfig.GetStaticResponses(); len(staticConfigs) > 0 {
+		staticResponses, err := static.New(staticConfigs)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to load static responses")
+		}
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithStaticResponses(staticResponses))
+	}
+	if recordingConfig := bootstrapConfig.GetRecording(); recordingConfig != nil {
+		recorder, err := recording.NewRecorder(recordingConfig, logger, scope.SubScope(metricSubscopeRecording))
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize recorder")
+		}
+		defer func() {
+			if err := recorder.Close(); err != nil {
+				logger.With("error", err).Error(ctx, "failed to close recording files")
+			}
+		}()
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithRecorder(recorder))
+	}
+	if auditLogConfig := bootstrapConfig.GetAuditLog(); auditLogConfig != nil {
+		auditLog, err := audit.New(auditLogConfig, logger, scope.SubScope(metricSubscopeAudit))
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize audit log")
+		}
+		defer func() {
+			if err := auditLog.Close(); err != nil {
+				logger.With("error", err).Error(ctx, "failed to close audit log")
+			}
+		}()
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithAuditLog(auditLog))
+	}
+	if verificationConfig := bootstrapConfig.GetSignatureVerification(); verificationConfig != nil {
+		verifier, err := signature.NewVerifier(verificationConfig)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize signature verification")
+		}
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithSignatureVerifier(verifier))
+	}
+	if identifierConfig := bootstrapConfig.GetControlPlaneIdentifier(); identifierConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithControlPlaneIdentifier(identifierConfig))
+	}
+	if circuitBreakerConfig := bootstrapConfig.GetCircuitBreaker(); circuitBreakerConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithCircuitBreaker(circuitBreakerConfig))
+	}
+	if alertingConfig := bootstrapConfig.GetAlerting(); alertingConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithAlerting(alertingConfig))
+	}
+	if negativeCacheConfig := bootstrapConfig.GetNegativeCache(); negativeCacheConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithNegativeCache(negativeCacheConfig))
+	}
+	if upstreamHealthConfig := bootstrapConfig.GetUpstreamHealth(); upstreamHealthConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithUpstreamHealth(upstreamHealthConfig))
+	}
+	if validationConfig := bootstrapConfig.GetResponseValidation(); validationConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithResponseValidation(validationConfig))
+	}
+	if lintingConfig := bootstrapConfig.GetLinting(); lintingConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithLinting(lintingConfig))
+	}
+	if orderingConfig := bootstrapConfig.GetDependencyOrdering(); orderingConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithDependencyOrdering(orderingConfig))
+	}
+	if historyConfig := bootstrapConfig.GetHistory(); historyConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithHistory(historyConfig))
+	}
+	if maintenanceConfig := bootstrapConfig.GetMaintenance(); maintenanceConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithMaintenance(maintenanceConfig))
+	}
+	if changeRateConfig := bootstrapConfig.GetChangeRateGuard(); changeRateConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithChangeRateGuard(changeRateConfig))
+	}
+	if pacingConfig := bootstrapConfig.GetFanoutPacing(); pacingConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithFanoutPacing(pacingConfig))
+	}
+	if fetchConfig := bootstrapConfig.GetFetch(); fetchConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithFetch(fetchConfig))
+	}
+	if buffersConfig := bootstrapConfig.GetBuffers(); buffersConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithBuffers(buffersConfig))
+	}
+	if keyMetricsConfig := bootstrapConfig.GetKeyMetrics(); keyMetricsConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithKeyMetrics(keyMetricsConfig))
+	}
+	if simulationConfig := bootstrapConfig.GetAggregationSimulation(); simulationConfig != nil {
+		candidateMapper, err := newCandidateMapper(simulationConfig, logger)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize aggregation simulation")
+		}
+		orchestratorOpts = append(orchestratorOpts,
+			orchestrator.WithAggregationSimulation(simulationConfig, candidateMapper))
+	}
+	if relayChainConfig := bootstrapConfig.GetRelayChain(); relayChainConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithRelayChain(relayChainConfig))
+	}
+	if differentialFanoutConfig := bootstrapConfig.GetDifferentialFanout(); differentialFanoutConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithDifferentialFanout(differentialFanoutConfig))
+	}
+	if contentDeduplicationConfig := bootstrapConfig.GetContentDeduplication(); contentDeduplicationConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithContentDeduplication(contentDeduplicationConfig))
+	}
+	if rolloutConfig := bootstrapConfig.GetRollout(); rolloutConfig != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithRollout(rolloutConfig))
+	}
+	if components.CacheFactory != nil {
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithCacheFactory(components.CacheFactory))
+	}
+	var replicationServer *replication.Server
+	if bootstrapConfig.GetReplication().GetListener() != nil {
+		replicationServer = replication.NewServer(logger, scope.SubScope(metricSubscopeReplication))
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithReplicationServer(replicationServer))
+	}
+	var statusServer *status.Server
+	if bootstrapConfig.Server.GetStatusService() {
+		statusServer = status.NewServer(logger, scope.SubScope(metricSubscopeStatus))
+		orchestratorOpts = append(orchestratorOpts, orchestrator.WithStatusServer(statusServer))
+	}
 	orchestrator := orchestrator.New(ctx, logger, scope.SubScope(metricSubscopeOrchestrator), requestMapper,
-		upstreamClient, bootstrapConfig.Cache)
+		upstreamClient, responseCodec, bootstrapConfig.Cache, orchestratorOpts...)
+
+	memoryMonitor, err := memory.NewMonitor(bootstrapConfig.GetMemory(), orchestrator, logger,
+		scope.SubScope(metricSubscopeMemory))
+	if err != nil {
+		logger.With("error", err).Panic(ctx, "failed to initialize memory monitor")
+	}
+
+	// Initialize replication from the source peer.
+	var replicationClient *replication.Client
+	if source := bootstrapConfig.GetReplication().GetSource(); source != nil {
+		sourceAddress := net.JoinHostPort(source.Address, strconv.FormatUint(uint64(source.PortValue), 10))
+		var sourceCreds credentials.TransportCredentials
+		if sourceTLS := bootstrapConfig.GetReplication().GetSourceTls(); sourceTLS != nil {
+			sourceCreds, err = newClientTLSCredentials(sourceTLS)
+			if err != nil {
+				logger.With("error", err).Panic(ctx, "failed to initialize TLS of replication client")
+			}
+		}
+		replicationClient, err = replication.NewClient(ctx, sourceAddress, sourceCreds, orchestrator, logger,
+			scope.SubScope(metricSubscopeReplication))
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize replication client")
+		}
+	}
+
+	// Initialize the relaying of downstream load reports to the origin server, which it reaches the same way as the
+	// upstream client.
+	var loadReportRelay *loadreport.Relay
+	if loadReportingConfig := bootstrapConfig.GetLoadReporting(); loadReportingConfig != nil {
+		loadReportRelay, err = loadreport.New(ctx, socket.Target(bootstrapConfig.OriginServer.Address),
+			upstreamCallOptions, loadReportingConfig, logger, scope.SubScope(metricSubscopeLoadReport))
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize load reporting")
+		}
+	}
+
+	// Initialize the relaying of the virtual host discovery service, which is only served incrementally.
+	vhdsRelay := newVHDSRelay(ctx, bootstrapConfig.Server, mode, upstreamClient, requestMapper, logger,
+		scope.SubScope(metricSubscopeVHDS))
 
 	// Configure admin server.
 	adminPort := strconv.FormatUint(uint64(bootstrapConfig.Admin.Address.PortValue), 10)
 	adminAddress := net.JoinHostPort(bootstrapConfig.Admin.Address.Address, adminPort)
+	adminMux := http.NewServeMux()
+	handler.RegisterHandlers(adminMux, bootstrapConfig, &orchestrator, recentLogs, keyEvents, versionInfo)
 	adminServer := &http.Server{
-		Addr: adminAddress,
+		Addr:    adminAddress,
+		Handler: adminMux,
+	}
+
+	// In dry-run mode, subscribe upstream on behalf of the configured clients instead of serving downstream. Only the
+	// admin server is started, so that the cache can be inspected.
+	if mode == "dry-run" {
+		subscriptions := bootstrapConfig.GetDryRun().GetSubscriptions()
+		if len(subscriptions) == 0 {
+			logger.Warn(ctx, "no dry run subscriptions configured")
+		}
+		go RunAdminServer(ctx, adminServer, logger)
+		if elector != nil {
+			go elector.Run(ctx)
+		}
+		if overrides != nil {
+			go overrides.Run(ctx)
+		}
+		registerShutdownHandler(ctx, cancel, func() {}, adminServer.Shutdown, logger, time.Second*30)
+		logger.With("subscriptions", len(subscriptions)).Info(ctx, "Starting dry run")
+		dryrun.Run(ctx, orchestrator, subscriptions, logger, scope.SubScope(metricSubscopeDryRun))
+		return
 	}
-	handler.RegisterHandlers(bootstrapConfig, &orchestrator)
 
 	// Start server.
-	gcpServer := gcp.NewServer(ctx, orchestrator, nil)
-	server := grpc.NewServer()
-	serverPort := strconv.FormatUint(uint64(bootstrapConfig.Server.Address.PortValue), 10)
-	serverAddress := net.JoinHostPort(bootstrapConfig.Server.Address.Address, serverPort)
-	listener, err := net.Listen("tcp", serverAddress) // #nosec
+	gcpServer := gcp.NewServer(ctx, orchestrator, append(chainedCallbacks{orchestrator}, components.Callbacks...))
+	builtInInterceptors, err := interceptor.New(bootstrapConfig.Server.GetInterceptors(), logger,
+		scope.SubScope(metricSubscopeInterceptor))
+	if err != nil {
+		logger.With("error", err).Panic(ctx, "failed to initialize interceptors")
+	}
+	var otherInterceptors interceptor.Interceptors
+	otherInterceptors.Append(components.Interceptors)
+	otherInterceptors.Stream = append(otherInterceptors.Stream, orchestrator.StreamServerInterceptor())
+	interceptors, err := serverInterceptors(builtInInterceptors, bootstrapConfig.Server.GetAdmission(),
+		otherInterceptors, logger, scope.SubScope(metricSubscopeAdmission))
+	if err != nil {
+		logger.With("error", err).Panic(ctx, "failed to initialize admission control")
+	}
+	codecOpts := []grpc.ServerOption{grpc.CustomCodec(responseCodec)}
+	server := grpc.NewServer(append(codecOpts, interceptors.ServerOptions()...)...)
+	listener, err := socket.Listen(bootstrapConfig.Server.Address)
 	if err != nil {
 		logger.With("err", err).Fatal(ctx, "failed to bind server to listener")
 	}
@@ -123,14 +580,143 @@ func RunWithContext(ctx context.Context, cancel context.CancelFunc, bootstrapCon
 	api.RegisterClusterDiscoveryServiceServer(server, gcpServer)
 	api.RegisterRouteDiscoveryServiceServer(server, gcpServer)
 	api.RegisterListenerDiscoveryServiceServer(server, gcpServer)
+	discovery.RegisterRuntimeDiscoveryServiceServer(server, gcpServer)
+	if bootstrapConfig.Server.GetV3Services() {
+		apiversion.Register(server, apiversion.NewServer(gcpServer))
+	}
+	if statusServer != nil {
+		statusv1.RegisterStatusServiceServer(server, statusServer)
+	}
+	if loadReportRelay != nil {
+		lrs.RegisterLoadReportingServiceServer(server, loadReportRelay)
+	}
+	if vhdsRelay != nil {
+		api.RegisterVirtualHostDiscoveryServiceServer(server, vhdsRelay)
+		if bootstrapConfig.Server.GetV3Services() {
+			routeservice.RegisterVirtualHostDiscoveryServiceServer(server, vhdsRelay.V3())
+		}
+	}
+
+	// Configure the additional listeners, each of which serves a subset of the xDS services.
+	listenerConfigs := bootstrapConfig.Server.GetListeners()
+	listenerServers := make([]*grpc.Server, 0, len(listenerConfigs))
+	for _, listenerConfig := range listenerConfigs {
+		listenerServer, err := newListenerServer(listenerConfig, gcpServer, codecOpts, builtInInterceptors,
+			otherInterceptors, logger, scope)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize listener")
+		}
+		listenerServers = append(listenerServers, listenerServer)
+	}
+
+	// Configure the replication listener, which serves cache updates to peers apart from the xDS server.
+	var replicationListenerServer *grpc.Server
+	if replicationServer != nil {
+		replicationListenerServer, err = newReplicationListenerServer(bootstrapConfig.GetReplication().GetListener(),
+			replicationServer, builtInInterceptors)
+		if err != nil {
+			logger.With("error", err).Panic(ctx, "failed to initialize replication listener")
+		}
+	}
+
+	// Configure REST server.
+	var restServer *http.Server
+	if restAddress := bootstrapConfig.Server.GetRestAddress(); restAddress != nil {
+		restPort := strconv.FormatUint(uint64(restAddress.PortValue), 10)
+		restServer = &http.Server{
+			Addr:    net.JoinHostPort(restAddress.Address, restPort),
+			Handler: rest.New(gcpServer, logger),
+		}
+	}
 
 	if mode != "serve" {
 		return
 	}
 
 	go RunAdminServer(ctx, adminServer, logger)
+	httpShutdown := adminServer.Shutdown
+	if restServer != nil {
+		go RunRESTServer(ctx, restServer, logger)
+		httpShutdown = func(ctx context.Context) error {
+			if err := restServer.Shutdown(ctx); err != nil {
+				logger.With("err", err).Error(ctx, "REST server shutdown error")
+			}
+			return adminServer.Shutdown(ctx)
+		}
+	}
+	if elector != nil {
+		go elector.Run(ctx)
+	}
+	if hashRing != nil {
+		go hashRing.Run(ctx)
+	}
+	if replicationClient != nil {
+		go replicationClient.Run(ctx)
+	}
+	if loadReportRelay != nil {
+		go loadReportRelay.Run(ctx)
+	}
+	if overrides != nil {
+		go overrides.Run(ctx)
+	}
+	if memoryMonitor != nil {
+		go memoryMonitor.Run(ctx)
+	}
+
+	for i, listenerServer := range listenerServers {
+		listenerConfig := listenerConfigs[i]
+		listenerListener, err := socket.Listen(listenerConfig.Address)
+		if err != nil {
+			logger.With("err", err).With("listener", listenerConfig.GetName()).
+				Fatal(ctx, "failed to bind listener")
+		}
+		logger.With("address", listenerListener.Addr()).With("listener", listenerConfig.GetName()).
+			Info(ctx, "Initializing listener")
+		go func(listenerServer *grpc.Server, name string) {
+			if err := listenerServer.Serve(listenerListener); err != nil {
+				logger.With("err", err).With("listener", name).Fatal(ctx, "failed to serve listener")
+			}
+		}(listenerServer, listenerConfig.GetName())
+	}
 
-	registerShutdownHandler(ctx, cancel, server.GracefulStop, adminServer.Shutdown, logger, time.Second*30)
+	if replicationListenerServer != nil {
+		replicationAddress := bootstrapConfig.GetReplication().GetListener().GetAddress()
+		replicationListener, err := socket.Listen(replicationAddress)
+		if err != nil {
+			logger.With("err", err).Fatal(ctx, "failed to bind replication listener")
+		}
+		logger.With("address", replicationListener.Addr()).Info(ctx, "Initializing replication listener")
+		go func() {
+			if err := replicationListenerServer.Serve(replicationListener); err != nil {
+				logger.With("err", err).Fatal(ctx, "failed to serve replication listener")
+			}
+		}()
+		listenerServers = append(listenerServers, replicationListenerServer)
+	}
+
+	// The servers stop gracefully at once, so that none accepts new streams while waiting on the others.
+	gracefulStop := func() {
+		var wg sync.WaitGroup
+		for _, s := range append([]*grpc.Server{server}, listenerServers...) {
+			wg.Add(1)
+			go func(s *grpc.Server) {
+				defer wg.Done()
+				s.GracefulStop()
+			}(s)
+		}
+		wg.Wait()
+	}
+	registerShutdownHandler(ctx, cancel, gracefulStop, httpShutdown, logger, time.Second*30)
+	go func() {
+		<-ctx.Done()
+		if err := httpShutdown(context.Background()); err != nil {
+			logger.With("err", err).Error(ctx, "admin server shutdown error")
+		}
+		for _, listenerServer := range listenerServers {
+			listenerServer.Stop()
+		}
+		server.Stop()
+	}()
 	logger.With("address", listener.Addr()).Info(ctx, "Initializing server")
 	serverScope := scope.SubScope(metricSubscope)
 	serverScope.Counter(metricServerAlive).Inc(1)
@@ -140,6 +726,191 @@ func RunWithContext(ctx context.Context, cancel context.CancelFunc, bootstrapCon
 	}
 }
 
+// newStagedMapper returns a mapper that maps the nodes selected by the revisions of the config with the aggregation
+// rules read from the files of the revisions, and the other nodes with the stable mapper.
+func newStagedMapper(
+	stable mapper.Mapper,
+	config *bootstrapv1.AggregationRollout,
+	logger log.Logger,
+) (mapper.Mapper, error) {
+	var revisions []mapper.Revision
+	for _, revisionConfig := range config.GetRevisions() {
+		rules, err := ioutil.ReadFile(revisionConfig.GetPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read aggregation rules of revision %s: %w", revisionConfig.GetName(), err)
+		}
+		var aggregationRulesConfig aggregationv1.KeyerConfiguration
+		if err := yamlproto.FromYAMLToKeyerConfiguration(string(rules), &aggregationRulesConfig); err != nil {
+			return nil, fmt.Errorf("failed to translate aggregation rules of revision %s: %w",
+				revisionConfig.GetName(), err)
+		}
+		fingerprint, err := version.Fingerprint(&aggregationRulesConfig)
+		if err != nil {
+			return nil, err
+		}
+		logger.With("revision", revisionConfig.GetName()).With("percentage", revisionConfig.GetPercentage()).
+			With("aggregation fingerprint", fingerprint).Info(context.Background(), "Staging aggregation rules")
+		revisions = append(revisions, mapper.Revision{
+			Name:         revisionConfig.GetName(),
+			Mapper:       mapper.New(&aggregationRulesConfig),
+			Percentage:   revisionConfig.GetPercentage(),
+			NodeMetadata: revisionConfig.GetNodeMetadata(),
+		})
+	}
+	return mapper.NewStaged(stable, revisions)
+}
+
+// newCandidateMapper returns a mapper of the candidate aggregation rules read from the file of the simulation config.
+func newCandidateMapper(config *bootstrapv1.AggregationSimulation, logger log.Logger) (mapper.Mapper, error) {
+	rules, err := ioutil.ReadFile(config.GetPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read candidate aggregation rules: %w", err)
+	}
+	var aggregationRulesConfig aggregationv1.KeyerConfiguration
+	if err := yamlproto.FromYAMLToKeyerConfiguration(string(rules), &aggregationRulesConfig); err != nil {
+		return nil, fmt.Errorf("failed to translate candidate aggregation rules: %w", err)
+	}
+	fingerprint, err := version.Fingerprint(&aggregationRulesConfig)
+	if err != nil {
+		return nil, err
+	}
+	logger.With("path", config.GetPath()).With("aggregation fingerprint", fingerprint).
+		Info(context.Background(), "Simulating candidate aggregation rules")
+	return mapper.New(&aggregationRulesConfig), nil
+}
+
+// newVHDSRelay returns the relay of the virtual host discovery service. It returns nil unless the config enables it
+// and the server is started in serve mode, or if the upstream client does not open incremental streams, as replayed
+// recordings do not.
+func newVHDSRelay(
+	ctx context.Context,
+	config *bootstrapv1.Server,
+	mode string,
+	upstreamClient upstream.Client,
+	requestMapper mapper.Mapper,
+	logger log.Logger,
+	scope tally.Scope,
+) *vhds.Relay {
+	if !config.GetVhdsService() || mode != "serve" {
+		return nil
+	}
+	deltaClient, ok := upstreamClient.(upstream.DeltaClient)
+	if !ok {
+		logger.Warn(ctx, "the virtual host discovery service is not served when a recording is replayed")
+		return nil
+	}
+	return vhds.New(ctx, deltaClient, requestMapper, logger, scope)
+}
+
+// newUpstreamProxy returns the upstream proxy of the config, reading its password from the password file. It returns
+// nil if the config is nil.
+func newUpstreamProxy(config *bootstrapv1.Proxy) (*upstream.Proxy, error) {
+	if config == nil {
+		return nil, nil
+	}
+	proxy := &upstream.Proxy{
+		Address:  socket.Target(config.GetAddress()),
+		Username: config.GetUsername(),
+	}
+	if config.GetType() == bootstrapv1.Proxy_SOCKS5 {
+		proxy.Type = upstream.ProxySOCKS5
+	}
+	if passwordFile := config.GetPasswordFile(); passwordFile != "" {
+		password, err := ioutil.ReadFile(passwordFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read proxy password: %w", err)
+		}
+		proxy.Password = strings.TrimRight(string(password), "\r\n")
+	}
+	return proxy, nil
+}
+
+// newUpstreamDiscovery returns the upstream discovery of the config. It returns nil if the config is nil.
+func newUpstreamDiscovery(config *bootstrapv1.Upstream_Discovery) *upstream.Discovery {
+	if config == nil {
+		return nil
+	}
+	discovery := &upstream.Discovery{LoadBalancingPolicy: upstream.PickFirst}
+	if config.GetLoadBalancingPolicy() == bootstrapv1.Upstream_Discovery_ROUND_ROBIN {
+		discovery.LoadBalancingPolicy = upstream.RoundRobin
+	}
+	if refreshInterval, err := ptypes.Duration(config.GetRefreshInterval()); err == nil {
+		discovery.RefreshInterval = refreshInterval
+	}
+	return discovery
+}
+
+// newUpstreamAPIVersion returns the xDS API version spoken with the upstream of the config.
+func newUpstreamAPIVersion(upstreamConfig *bootstrapv1.Upstream) apiversion.Version {
+	if upstreamConfig.GetApiVersion() == bootstrapv1.Upstream_V3 {
+		return apiversion.V3
+	}
+	return apiversion.V2
+}
+
+// newUpstreamMetadata returns the upstream metadata of the config, followed by the authorization header of its
+// credentials if any.
+func newUpstreamMetadata(upstreamConfig *bootstrapv1.Upstream) ([]upstream.Metadata, error) {
+	headers := make([]upstream.Metadata, 0, len(upstreamConfig.GetMetadata())+1)
+	for _, config := range upstreamConfig.GetMetadata() {
+		header := upstream.Metadata{Key: strings.ToLower(config.GetKey())}
+		switch {
+		case config.GetTemplate() != "":
+			value, err := upstream.NewTemplateValue(config.GetTemplate())
+			if err != nil {
+				return nil, fmt.Errorf("invalid template of metadata %s: %w", config.GetKey(), err)
+			}
+			header.Value = value
+		case config.GetTokenFile() != nil:
+			tokenFile := config.GetTokenFile()
+			refreshInterval, _ := ptypes.Duration(tokenFile.GetRefreshInterval())
+			header.Value = &upstream.TokenValue{
+				Source: upstream.NewFileTokenSource(tokenFile.GetPath(), refreshInterval),
+				Prefix: tokenFile.GetPrefix(),
+			}
+		default:
+			header.Value = upstream.StaticValue(config.GetValue())
+		}
+		headers = append(headers, header)
+	}
+	if upstreamConfig.GetCredentials() != nil {
+		source, err := newUpstreamTokenSource(upstreamConfig.GetCredentials())
+		if err != nil {
+			return nil, fmt.Errorf("invalid upstream credentials: %w", err)
+		}
+		headers = append(headers, upstream.Metadata{
+			Key:   "authorization",
+			Value: &upstream.TokenValue{Source: source, Prefix: "Bearer "},
+		})
+	}
+	return headers, nil
+}
+
+// newUpstreamTokenSource returns the token source of the upstream credentials.
+func newUpstreamTokenSource(config *bootstrapv1.Upstream_Credentials) (upstream.TokenSource, error) {
+	switch {
+	case config.GetServiceAccount() != nil:
+		serviceAccount := config.GetServiceAccount()
+		keyFile, err := ioutil.ReadFile(serviceAccount.GetKeyFile())
+		if err != nil {
+			return nil, err
+		}
+		return upstream.NewServiceAccountTokenSource(keyFile, serviceAccount.GetScopes(), serviceAccount.GetAudience())
+	case config.GetWorkloadIdentity() != nil:
+		workloadIdentity := config.GetWorkloadIdentity()
+		return upstream.NewWorkloadIdentityTokenSource(
+			workloadIdentity.GetMetadataServer(), workloadIdentity.GetAudience()), nil
+	default:
+		execPlugin := config.GetExec()
+		env := make([]string, 0, len(execPlugin.GetEnv()))
+		for key, value := range execPlugin.GetEnv() {
+			env = append(env, key+"="+value)
+		}
+		sort.Strings(env)
+		return upstream.NewExecTokenSource(execPlugin.GetCommand(), execPlugin.GetArgs(), env), nil
+	}
+}
+
 func registerShutdownHandler(
 	ctx context.Context,
 	cancel context.CancelFunc,
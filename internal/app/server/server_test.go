@@ -3,13 +3,22 @@ package server
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"syscall"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
 	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/uber-go/tally"
 )
 
 func TestShutdown(t *testing.T) {
@@ -40,6 +49,178 @@ func TestShutdownTimeout(t *testing.T) {
 	assert.Equal(t, "shutdown error: context deadline exceeded", l.lastErr)
 }
 
+func TestNewUpstreamProxy(t *testing.T) {
+	proxy, err := newUpstreamProxy(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, proxy)
+
+	passwordFile, err := ioutil.TempFile("", "password")
+	assert.NoError(t, err)
+	defer os.Remove(passwordFile.Name())
+	_, err = passwordFile.WriteString("password\n")
+	assert.NoError(t, err)
+	assert.NoError(t, passwordFile.Close())
+
+	proxy, err = newUpstreamProxy(&bootstrapv1.Proxy{
+		Type:         bootstrapv1.Proxy_SOCKS5,
+		Address:      &bootstrapv1.SocketAddress{Address: "127.0.0.1", PortValue: 1080},
+		Username:     "user",
+		PasswordFile: passwordFile.Name(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &upstream.Proxy{
+		Type:     upstream.ProxySOCKS5,
+		Address:  "127.0.0.1:1080",
+		Username: "user",
+		Password: "password",
+	}, proxy)
+
+	_, err = newUpstreamProxy(&bootstrapv1.Proxy{
+		Address:      &bootstrapv1.SocketAddress{Address: "127.0.0.1", PortValue: 3128},
+		PasswordFile: passwordFile.Name() + ".missing",
+	})
+	assert.Error(t, err)
+}
+
+// mockDeltaClient is an upstream client that opens incremental streams.
+type mockDeltaClient struct {
+	upstream.Client
+}
+
+func (mockDeltaClient) OpenDeltaStream(context.Context, *core.Node, string) (upstream.DeltaStream, error) {
+	return nil, nil
+}
+
+func TestNewVHDSRelay(t *testing.T) {
+	ctx := context.Background()
+	requestMapper := mapper.New(&aggregationv1.KeyerConfiguration{})
+	enabled := &bootstrapv1.Server{VhdsService: true}
+	newRelay := func(config *bootstrapv1.Server, mode string, upstreamClient upstream.Client) bool {
+		return newVHDSRelay(ctx, config, mode, upstreamClient, requestMapper, log.New("info"), tally.NoopScope) != nil
+	}
+
+	assert.True(t, newRelay(enabled, "serve", mockDeltaClient{}))
+	assert.False(t, newRelay(&bootstrapv1.Server{}, "serve", mockDeltaClient{}))
+	assert.False(t, newRelay(enabled, "validate", mockDeltaClient{}))
+	// Replayed recordings do not open incremental streams.
+	assert.False(t, newRelay(enabled, "serve", struct{ upstream.Client }{}))
+}
+
+func TestNewStagedMapper(t *testing.T) {
+	rulesFile, err := ioutil.TempFile("", "aggregation_rules")
+	assert.NoError(t, err)
+	defer os.Remove(rulesFile.Name())
+	_, err = rulesFile.WriteString(`
+fragments:
+  - rules:
+    - match:
+        any_match: true
+      result:
+        string_fragment: "canary"
+`)
+	assert.NoError(t, err)
+	assert.NoError(t, rulesFile.Close())
+
+	stable := mapper.New(&aggregationv1.KeyerConfiguration{})
+	stagedMapper, err := newStagedMapper(stable, &bootstrapv1.AggregationRollout{
+		Revisions: []*bootstrapv1.AggregationRollout_Revision{
+			{Name: "v2", Path: rulesFile.Name(), Percentage: 100},
+		},
+	}, log.New("panic"))
+	assert.NoError(t, err)
+	key, err := stagedMapper.GetKey(v2.DiscoveryRequest{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &core.Node{Id: "node"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "canary@v2", key)
+
+	_, err = newStagedMapper(stable, &bootstrapv1.AggregationRollout{
+		Revisions: []*bootstrapv1.AggregationRollout_Revision{
+			{Name: "v2", Path: rulesFile.Name() + ".missing", Percentage: 100},
+		},
+	}, log.New("panic"))
+	assert.Error(t, err)
+}
+
+func TestNewCandidateMapper(t *testing.T) {
+	rulesFile, err := ioutil.TempFile("", "aggregation_rules")
+	assert.NoError(t, err)
+	defer os.Remove(rulesFile.Name())
+	_, err = rulesFile.WriteString(`
+fragments:
+  - rules:
+    - match:
+        any_match: true
+      result:
+        string_fragment: "candidate"
+`)
+	assert.NoError(t, err)
+	assert.NoError(t, rulesFile.Close())
+
+	candidateMapper, err := newCandidateMapper(&bootstrapv1.AggregationSimulation{Path: rulesFile.Name()},
+		log.New("panic"))
+	assert.NoError(t, err)
+	key, err := candidateMapper.GetKey(v2.DiscoveryRequest{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &core.Node{Id: "node"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "candidate", key)
+
+	_, err = newCandidateMapper(&bootstrapv1.AggregationSimulation{Path: rulesFile.Name() + ".missing"},
+		log.New("panic"))
+	assert.Error(t, err)
+}
+
+func TestNewUpstreamMetadata(t *testing.T) {
+	metadata, err := newUpstreamMetadata(&bootstrapv1.Upstream{Metadata: []*bootstrapv1.Upstream_Metadata{
+		{Key: "X-Tenant", ValueSpecifier: &bootstrapv1.Upstream_Metadata_Value{Value: "tenant"}},
+		{Key: "x-node", ValueSpecifier: &bootstrapv1.Upstream_Metadata_Template{Template: "{{.NodeID}}"}},
+		{Key: "authorization", ValueSpecifier: &bootstrapv1.Upstream_Metadata_TokenFile{
+			TokenFile: &bootstrapv1.Upstream_TokenFile{Path: "/var/run/token", Prefix: "Bearer "},
+		}},
+	}})
+	assert.NoError(t, err)
+	assert.Len(t, metadata, 3)
+	assert.Equal(t, upstream.Metadata{Key: "x-tenant", Value: upstream.StaticValue("tenant")}, metadata[0])
+	value, err := metadata[1].Value.Value(context.Background(), upstream.StreamInfo{NodeID: "node"})
+	assert.NoError(t, err)
+	assert.Equal(t, "node", value)
+	assert.IsType(t, &upstream.TokenValue{}, metadata[2].Value)
+	assert.Equal(t, "Bearer ", metadata[2].Value.(*upstream.TokenValue).Prefix)
+
+	_, err = newUpstreamMetadata(&bootstrapv1.Upstream{Metadata: []*bootstrapv1.Upstream_Metadata{
+		{Key: "x-node", ValueSpecifier: &bootstrapv1.Upstream_Metadata_Template{Template: "{{.NodeID"}},
+	}})
+	assert.Error(t, err)
+
+	metadata, err = newUpstreamMetadata(&bootstrapv1.Upstream{
+		Credentials: &bootstrapv1.Upstream_Credentials{
+			SourceSpecifier: &bootstrapv1.Upstream_Credentials_Exec{Exec: &bootstrapv1.Upstream_Credentials_ExecPlugin{
+				Command: "sh",
+				Args:    []string{"-c", `echo "{\"kind\": \"ExecCredential\", \"status\": {\"token\": \"$TOKEN\"}}"`},
+				Env:     map[string]string{"TOKEN": "exec"},
+			}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, metadata, 1)
+	assert.Equal(t, "authorization", metadata[0].Key)
+	value, err = metadata[0].Value.Value(context.Background(), upstream.StreamInfo{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer exec", value)
+
+	_, err = newUpstreamMetadata(&bootstrapv1.Upstream{
+		Credentials: &bootstrapv1.Upstream_Credentials{
+			SourceSpecifier: &bootstrapv1.Upstream_Credentials_ServiceAccount_{
+				ServiceAccount: &bootstrapv1.Upstream_Credentials_ServiceAccount{KeyFile: "/missing/key.json"},
+			},
+		},
+	})
+	assert.Error(t, err)
+}
+
 type logger struct {
 	blockedCh chan bool
 	lastErr   string
@@ -0,0 +1,113 @@
+// Package signature verifies that upstream responses are signed by the control plane with a detached signature,
+// carried in the control plane identifier of the response.
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+)
+
+// Verifier verifies the signatures of responses with the public key of the control plane.
+type Verifier struct {
+	publicKey crypto.PublicKey
+}
+
+// NewVerifier reads the public key of the control plane.
+func NewVerifier(config *bootstrapv1.SignatureVerification) (*Verifier, error) {
+	contents, err := ioutil.ReadFile(config.GetPublicKeyPath())
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded public key in %s", config.GetPublicKeyPath())
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch publicKey.(type) {
+	case ed25519.PublicKey, *ecdsa.PublicKey, *rsa.PublicKey:
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+	return &Verifier{publicKey: publicKey}, nil
+}
+
+// Verify returns an error if the response is unsigned or its signature is invalid.
+func (v *Verifier) Verify(resp *discovery.DiscoveryResponse) error {
+	encoded := resp.GetControlPlane().GetIdentifier()
+	if encoded == "" {
+		return fmt.Errorf("response version %s is unsigned", resp.GetVersionInfo())
+	}
+	sig, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("response version %s has a malformed signature: %s", resp.GetVersionInfo(), err.Error())
+	}
+	digest := Digest(resp)
+	valid := false
+	switch publicKey := v.publicKey.(type) {
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(publicKey, digest, sig)
+	case *ecdsa.PublicKey:
+		var ecdsaSig struct{ R, S *big.Int }
+		if rest, err := asn1.Unmarshal(sig, &ecdsaSig); err == nil && len(rest) == 0 {
+			valid = ecdsa.Verify(publicKey, digest, ecdsaSig.R, ecdsaSig.S)
+		}
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest, sig) == nil
+	}
+	if !valid {
+		return fmt.Errorf("response version %s has an invalid signature", resp.GetVersionInfo())
+	}
+	return nil
+}
+
+// Digest returns the SHA-256 digest of the signed contents of the response: its type URL, version info, and the
+// type URL and serialized value of each resource, each prefixed by its length.
+func Digest(resp *discovery.DiscoveryResponse) []byte {
+	hasher := sha256.New()
+	write := func(field []byte) {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(field)))
+		_, _ = hasher.Write(length[:])
+		_, _ = hasher.Write(field)
+	}
+	write([]byte(resp.GetTypeUrl()))
+	write([]byte(resp.GetVersionInfo()))
+	for _, resource := range resp.GetResources() {
+		write([]byte(resource.GetTypeUrl()))
+		write(resource.GetValue())
+	}
+	return hasher.Sum(nil)
+}
+
+// Sign sets the signature of the response, as a control plane would. Ed25519, ECDSA, and RSA keys are supported.
+func Sign(signer crypto.Signer, resp *discovery.DiscoveryResponse) error {
+	var opts crypto.SignerOpts = crypto.SHA256
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		opts = crypto.Hash(0)
+	}
+	sig, err := signer.Sign(rand.Reader, Digest(resp), opts)
+	if err != nil {
+		return err
+	}
+	resp.ControlPlane = &core.ControlPlane{Identifier: base64.StdEncoding.EncodeToString(sig)}
+	return nil
+}
@@ -0,0 +1,107 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDirectory(t *testing.T) string {
+	directory, err := ioutil.TempDir("", "signature")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(directory) })
+	return directory
+}
+
+// writePublicKey writes the PEM encoded public key of the signer to a file and returns its path.
+func writePublicKey(t *testing.T, signer crypto.Signer) string {
+	directory := newDirectory(t)
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	assert.NoError(t, err)
+	path := filepath.Join(directory, "public.pem")
+	assert.NoError(t, ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+	return path
+}
+
+func newListenerResponse(t *testing.T, version string) *v2.DiscoveryResponse {
+	listener, err := ptypes.MarshalAny(&v2.Listener{Name: "listener_" + version})
+	assert.NoError(t, err)
+	return &v2.DiscoveryResponse{
+		VersionInfo: version,
+		TypeUrl:     upstream.ListenerTypeURL,
+		Resources:   []*any.Any{listener},
+	}
+}
+
+func TestVerify(t *testing.T) {
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	for name, signer := range map[string]crypto.Signer{"ed25519": ed25519Key, "ecdsa": ecdsaKey, "rsa": rsaKey} {
+		t.Run(name, func(t *testing.T) {
+			verifier, err := NewVerifier(&bootstrapv1.SignatureVerification{PublicKeyPath: writePublicKey(t, signer)})
+			assert.NoError(t, err)
+
+			resp := newListenerResponse(t, "1")
+			assert.EqualError(t, verifier.Verify(resp), "response version 1 is unsigned")
+			assert.NoError(t, Sign(signer, resp))
+			assert.NoError(t, verifier.Verify(resp))
+
+			// The signature covers the version and the resources.
+			tampered := *resp
+			tampered.VersionInfo = "2"
+			assert.EqualError(t, verifier.Verify(&tampered), "response version 2 has an invalid signature")
+			tampered = *resp
+			tampered.Resources = newListenerResponse(t, "2").Resources
+			assert.EqualError(t, verifier.Verify(&tampered), "response version 1 has an invalid signature")
+
+			// Responses signed with another key are invalid.
+			_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+			assert.NoError(t, err)
+			assert.NoError(t, Sign(otherKey, resp))
+			assert.Error(t, verifier.Verify(resp))
+		})
+	}
+}
+
+func TestVerifyMalformedSignature(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	verifier, err := NewVerifier(&bootstrapv1.SignatureVerification{PublicKeyPath: writePublicKey(t, key)})
+	assert.NoError(t, err)
+	resp := newListenerResponse(t, "1")
+	assert.NoError(t, Sign(key, resp))
+	resp.ControlPlane.Identifier = "not base64!"
+	assert.Error(t, verifier.Verify(resp))
+}
+
+func TestNewVerifierInvalidKey(t *testing.T) {
+	directory := newDirectory(t)
+	path := filepath.Join(directory, "public.pem")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("not a key"), 0600))
+	_, err := NewVerifier(&bootstrapv1.SignatureVerification{PublicKeyPath: path})
+	assert.EqualError(t, err, "no PEM encoded public key in "+path)
+
+	_, err = NewVerifier(&bootstrapv1.SignatureVerification{PublicKeyPath: filepath.Join(directory, "missing.pem")})
+	assert.Error(t, err)
+}
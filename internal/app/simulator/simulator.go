@@ -0,0 +1,119 @@
+// Package simulator serves synthetic xDS snapshots that change periodically, in place of a real management server.
+// It is used to run xds-relay end to end during local development.
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
+	cachev2 "github.com/envoyproxy/go-control-plane/pkg/cache/v2"
+	gcp "github.com/envoyproxy/go-control-plane/pkg/server/v2"
+	resourcev2 "github.com/envoyproxy/go-control-plane/pkg/test/resource/v2"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"google.golang.org/grpc"
+)
+
+// Options describes the snapshots served by the simulator.
+type Options struct {
+	// Address is the TCP address that the simulator listens on.
+	Address string
+	// UpstreamPort is the port of the single endpoint of every cluster, on localhost.
+	UpstreamPort uint32
+	// BasePort is the port of the first listener. Listeners are assigned consecutive ports.
+	BasePort uint32
+	// Clusters must be positive. Listeners are assigned clusters in round-robin order.
+	Clusters      int
+	HTTPListeners int
+	TCPListeners  int
+	// UpdateInterval is the time between snapshot versions. The first version is served forever if it is zero.
+	UpdateInterval time.Duration
+}
+
+// allNodes serves the same snapshot to every node.
+type allNodes struct{}
+
+func (allNodes) ID(*core.Node) string {
+	return ""
+}
+
+// Run listens on the configured address and serves the snapshots until ctx is done.
+func Run(ctx context.Context, logger log.Logger, options Options) error {
+	listener, err := net.Listen("tcp", options.Address)
+	if err != nil {
+		return err
+	}
+	return Serve(ctx, listener, logger, options)
+}
+
+// Serve serves the snapshots on the listener until ctx is done. A new snapshot version replaces the previous one
+// every update interval. Cluster and route names include the version, so every version changes the resources of
+// all types.
+func Serve(ctx context.Context, listener net.Listener, logger log.Logger, options Options) error {
+	if options.Clusters <= 0 {
+		return fmt.Errorf("the number of clusters must be positive but was %d", options.Clusters)
+	}
+	logger = logger.Named("simulator")
+	snapshotCache := cachev2.NewSnapshotCache(false, allNodes{}, nil)
+	version := 1
+	if err := setSnapshot(snapshotCache, options, version); err != nil {
+		return err
+	}
+
+	server := grpc.NewServer()
+	xdsServer := gcp.NewServer(ctx, snapshotCache, nil)
+	discovery.RegisterAggregatedDiscoveryServiceServer(server, xdsServer)
+	v2.RegisterEndpointDiscoveryServiceServer(server, xdsServer)
+	v2.RegisterClusterDiscoveryServiceServer(server, xdsServer)
+	v2.RegisterRouteDiscoveryServiceServer(server, xdsServer)
+	v2.RegisterListenerDiscoveryServiceServer(server, xdsServer)
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+	logger.With("address", listener.Addr().String()).Info(ctx, "serving snapshots")
+
+	var updates <-chan time.Time
+	if options.UpdateInterval > 0 {
+		ticker := time.NewTicker(options.UpdateInterval)
+		defer ticker.Stop()
+		updates = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			server.GracefulStop()
+			return nil
+		case err := <-serveErr:
+			return err
+		case <-updates:
+			version++
+			if err := setSnapshot(snapshotCache, options, version); err != nil {
+				server.Stop()
+				return err
+			}
+			logger.With("version", version).Info(ctx, "snapshot updated")
+		}
+	}
+}
+
+func setSnapshot(snapshotCache cachev2.SnapshotCache, options Options, version int) error {
+	snapshot := resourcev2.TestSnapshot{
+		Xds:              resourcev2.Xds,
+		Version:          strconv.Itoa(version),
+		UpstreamPort:     options.UpstreamPort,
+		BasePort:         options.BasePort,
+		NumClusters:      options.Clusters,
+		NumHTTPListeners: options.HTTPListeners,
+		NumTCPListeners:  options.TCPListeners,
+	}.Generate()
+	if err := snapshot.Consistent(); err != nil {
+		return fmt.Errorf("inconsistent snapshot: %s", err.Error())
+	}
+	return snapshotCache.SetSnapshot(allNodes{}.ID(nil), snapshot)
+}
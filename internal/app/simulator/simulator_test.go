@@ -0,0 +1,66 @@
+package simulator
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/client"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- Serve(ctx, listener, log.New("info"), Options{
+			UpstreamPort:   18080,
+			BasePort:       10000,
+			Clusters:       2,
+			HTTPListeners:  1,
+			TCPListeners:   1,
+			UpdateInterval: 50 * time.Millisecond,
+		})
+	}()
+
+	fetch := func(resourceType string) *v2.DiscoveryResponse {
+		resp, err := client.Fetch(context.Background(), log.New("info"), client.FetchOptions{
+			ServerAddress: listener.Addr().String(),
+			NodeID:        "any",
+			ResourceType:  resourceType,
+			Timeout:       time.Second,
+		})
+		assert.NoError(t, err)
+		return resp
+	}
+	for resourceType, count := range map[string]int{"listener": 2, "cluster": 2, "route": 1} {
+		assert.Equal(t, count, len(fetch(resourceType).GetResources()), resourceType)
+	}
+
+	// New versions are served every update interval.
+	version := fetch("cluster").GetVersionInfo()
+	assert.Eventually(t, func() bool {
+		return fetch("cluster").GetVersionInfo() != version
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		assert.Fail(t, "Serve did not return after the context was cancelled")
+	}
+}
+
+func TestServeWithoutClusters(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	err = Serve(context.Background(), listener, log.New("info"), Options{HTTPListeners: 1})
+	assert.EqualError(t, err, "the number of clusters must be positive but was 0")
+}
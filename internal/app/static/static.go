@@ -0,0 +1,89 @@
+// Package static loads discovery responses that the relay serves from files instead of forwarding requests to the
+// origin server.
+package static
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+// defaultVersion is the version of static responses that do not set one. Downstream clients only receive responses
+// whose version differs from the version they last received, so the version must not be empty.
+const defaultVersion = "static"
+
+// Responses holds the static responses by aggregated key and by type URL.
+type Responses struct {
+	byKey     map[string]*discovery.DiscoveryResponse
+	byTypeURL map[string]*discovery.DiscoveryResponse
+}
+
+// New loads the configured static responses.
+func New(configs []*bootstrapv1.StaticResponse) (*Responses, error) {
+	r := &Responses{
+		byKey:     make(map[string]*discovery.DiscoveryResponse),
+		byTypeURL: make(map[string]*discovery.DiscoveryResponse),
+	}
+	for _, config := range configs {
+		resp, err := load(config.GetPath(), config.GetTypeUrl())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load static response %s: %s", config.GetPath(), err.Error())
+		}
+		switch config.GetMatch().(type) {
+		case *bootstrapv1.StaticResponse_Key:
+			r.byKey[config.GetKey()] = resp
+		case *bootstrapv1.StaticResponse_TypeUrl:
+			r.byTypeURL[config.GetTypeUrl()] = resp
+		default:
+			return nil, fmt.Errorf("static response %s does not match any requests", config.GetPath())
+		}
+	}
+	return r, nil
+}
+
+func load(path string, typeURL string) (*discovery.DiscoveryResponse, error) {
+	contents, err := ioutil.ReadFile(path) // #nosec
+	if err != nil {
+		return nil, err
+	}
+	js, err := yaml.YAMLToJSON(contents)
+	if err != nil {
+		return nil, err
+	}
+	var resp discovery.DiscoveryResponse
+	if err := jsonpb.UnmarshalString(string(js), &resp); err != nil {
+		return nil, err
+	}
+	if resp.GetTypeUrl() == "" {
+		resp.TypeUrl = typeURL
+	}
+	if typeURL != "" && resp.GetTypeUrl() != typeURL {
+		return nil, fmt.Errorf("type URL %s does not match %s", resp.GetTypeUrl(), typeURL)
+	}
+	if resp.GetTypeUrl() == "" {
+		return nil, fmt.Errorf("type URL is not set")
+	}
+	for _, resource := range resp.GetResources() {
+		if resource.GetTypeUrl() != resp.GetTypeUrl() {
+			return nil, fmt.Errorf("resource type %s does not match %s", resource.GetTypeUrl(), resp.GetTypeUrl())
+		}
+	}
+	if resp.GetVersionInfo() == "" {
+		resp.VersionInfo = defaultVersion
+	}
+	return &resp, nil
+}
+
+// Get returns the static response for requests of the type URL that map to the aggregated key. The response must not
+// be modified.
+func (r *Responses) Get(aggregatedKey string, typeURL string) (*discovery.DiscoveryResponse, bool) {
+	if resp, ok := r.byKey[aggregatedKey]; ok && resp.GetTypeUrl() == typeURL {
+		return resp, true
+	}
+	resp, ok := r.byTypeURL[typeURL]
+	return resp, ok
+}
@@ -0,0 +1,91 @@
+package static
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, contents string) string {
+	directory, err := ioutil.TempDir("", "static")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(directory) })
+	path := filepath.Join(directory, "response.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+	return path
+}
+
+func TestNew(t *testing.T) {
+	clusters := writeFile(t, `
+resources:
+- "@type": type.googleapis.com/envoy.api.v2.Cluster
+  name: cluster_A
+`)
+	listeners := writeFile(t, `{
+  "version_info": "2",
+  "type_url": "type.googleapis.com/envoy.api.v2.Listener",
+  "resources": [{"@type": "type.googleapis.com/envoy.api.v2.Listener", "name": "listener_A"}]
+}`)
+	responses, err := New([]*bootstrapv1.StaticResponse{
+		{Match: &bootstrapv1.StaticResponse_TypeUrl{TypeUrl: upstream.ClusterTypeURL}, Path: clusters},
+		{Match: &bootstrapv1.StaticResponse_TypeUrl{TypeUrl: upstream.ListenerTypeURL}, Path: listeners},
+		{Match: &bootstrapv1.StaticResponse_Key{Key: "cds"}, Path: listeners},
+	})
+	assert.NoError(t, err)
+
+	resp, ok := responses.Get("any", upstream.ClusterTypeURL)
+	assert.True(t, ok)
+	assert.Equal(t, defaultVersion, resp.GetVersionInfo())
+	assert.Equal(t, upstream.ClusterTypeURL, resp.GetTypeUrl())
+	assert.Equal(t, 1, len(resp.GetResources()))
+
+	// Responses for the aggregated key take precedence over those for the type URL.
+	resp, ok = responses.Get("cds", upstream.ListenerTypeURL)
+	assert.True(t, ok)
+	assert.Equal(t, "2", resp.GetVersionInfo())
+
+	// Responses for the aggregated key are only served to requests of their type URL.
+	resp, ok = responses.Get("cds", upstream.ClusterTypeURL)
+	assert.True(t, ok)
+	assert.Equal(t, defaultVersion, resp.GetVersionInfo())
+
+	_, ok = responses.Get("cds", upstream.RouteTypeURL)
+	assert.False(t, ok)
+}
+
+func TestNewErrors(t *testing.T) {
+	for name, config := range map[string]*bootstrapv1.StaticResponse{
+		"nonexistent file": {
+			Match: &bootstrapv1.StaticResponse_Key{Key: "cds"},
+			Path:  filepath.Join(os.TempDir(), "nonexistent", "response.yaml"),
+		},
+		"invalid response": {
+			Match: &bootstrapv1.StaticResponse_Key{Key: "cds"},
+			Path:  writeFile(t, "unknown: field"),
+		},
+		"missing type URL": {
+			Match: &bootstrapv1.StaticResponse_Key{Key: "cds"},
+			Path:  writeFile(t, "version_info: \"1\""),
+		},
+		"mismatched type URL": {
+			Match: &bootstrapv1.StaticResponse_TypeUrl{TypeUrl: upstream.ClusterTypeURL},
+			Path:  writeFile(t, "type_url: type.googleapis.com/envoy.api.v2.Listener"),
+		},
+		"mismatched resource type URL": {
+			Match: &bootstrapv1.StaticResponse_TypeUrl{TypeUrl: upstream.ClusterTypeURL},
+			Path: writeFile(t, `
+resources:
+- "@type": type.googleapis.com/envoy.api.v2.Listener
+  name: listener_A
+`),
+		},
+	} {
+		_, err := New([]*bootstrapv1.StaticResponse{config})
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,160 @@
+// Package status streams the propagation of configuration through xds-relay: changes to the cached version of each
+// aggregated key, and the acknowledgements and rejections of the nodes that are served the key.
+package status
+
+import (
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	statusv1 "github.com/envoyproxy/xds-relay/pkg/api/status/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+const (
+	// subscriberBufferSize is the number of events buffered for each subscriber. A subscriber that falls further
+	// behind is disconnected.
+	subscriberBufferSize = 1000
+
+	metricSubscribers       = "subscribers"
+	metricSubscriberDropped = "subscriber_dropped"
+)
+
+type subscriber struct {
+	// keys is empty if the subscriber is sent the events of all keys.
+	keys   map[string]struct{}
+	events chan *statusv1.KeyStatusEvent
+}
+
+func (s *subscriber) wants(aggregatedKey string) bool {
+	if len(s.keys) == 0 {
+		return true
+	}
+	_, ok := s.keys[aggregatedKey]
+	return ok
+}
+
+// Server streams key status events to subscribers.
+type Server struct {
+	logger log.Logger
+	scope  tally.Scope
+
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+// NewServer returns a Server with no subscribers.
+func NewServer(logger log.Logger, scope tally.Scope) *Server {
+	return &Server{
+		logger:      logger.Named("status_server"),
+		scope:       scope,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// PublishVersion sends a VERSION_CHANGED event for the aggregated key.
+func (s *Server) PublishVersion(aggregatedKey string, version string) {
+	s.publish(&statusv1.KeyStatusEvent{
+		Type:    statusv1.KeyStatusEvent_VERSION_CHANGED,
+		Key:     aggregatedKey,
+		Version: version,
+	})
+}
+
+// PublishAck sends an ACKED event for the node that acknowledged the version of the aggregated key.
+func (s *Server) PublishAck(aggregatedKey string, nodeID string, version string) {
+	s.publish(&statusv1.KeyStatusEvent{
+		Type:    statusv1.KeyStatusEvent_ACKED,
+		Key:     aggregatedKey,
+		Version: version,
+		NodeId:  nodeID,
+	})
+}
+
+// PublishNack sends a NACKED event for the node that rejected a response of the aggregated key. The version is the
+// last version that the node acknowledged.
+func (s *Server) PublishNack(aggregatedKey string, nodeID string, version string, errorDetail string) {
+	s.publish(&statusv1.KeyStatusEvent{
+		Type:        statusv1.KeyStatusEvent_NACKED,
+		Key:         aggregatedKey,
+		Version:     version,
+		NodeId:      nodeID,
+		ErrorDetail: errorDetail,
+	})
+}
+
+func (s *Server) publish(event *statusv1.KeyStatusEvent) {
+	event.Time, _ = ptypes.TimestampProto(time.Now())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subscribers {
+		if !sub.wants(event.GetKey()) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			// Closing the channel ends the subscriber's stream.
+			close(sub.events)
+			delete(s.subscribers, sub)
+			s.scope.Counter(metricSubscriberDropped).Inc(1)
+		}
+	}
+	s.scope.Gauge(metricSubscribers).Update(float64(len(s.subscribers)))
+}
+
+// Subscribe returns a channel of the subsequent events of the keys, or of all keys if none are given, and the
+// function that unsubscribes from them. The channel is closed if the subscriber falls too far behind.
+func (s *Server) Subscribe(keys []string) (<-chan *statusv1.KeyStatusEvent, func()) {
+	sub := &subscriber{
+		keys:   make(map[string]struct{}, len(keys)),
+		events: make(chan *statusv1.KeyStatusEvent, subscriberBufferSize),
+	}
+	for _, key := range keys {
+		sub.keys[key] = struct{}{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers[sub] = struct{}{}
+	s.scope.Gauge(metricSubscribers).Update(float64(len(s.subscribers)))
+	return sub.events, func() { s.unsubscribe(sub) }
+}
+
+// StreamKeyStatus sends each subsequent event of the requested keys until the client disconnects or falls too far
+// behind.
+func (s *Server) StreamKeyStatus(
+	req *statusv1.StreamKeyStatusRequest,
+	stream statusv1.StatusService_StreamKeyStatusServer,
+) error {
+	events, unsubscribe := s.Subscribe(req.GetKeys())
+	defer unsubscribe()
+
+	logger := s.logger.With("keys", req.GetKeys())
+	logger.Info(stream.Context(), "subscriber connected")
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				logger.Warn(stream.Context(), "subscriber fell behind, disconnecting")
+				return grpcstatus.Error(codes.ResourceExhausted, "subscriber fell behind key status events")
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			logger.Info(stream.Context(), "subscriber disconnected")
+			return nil
+		}
+	}
+}
+
+func (s *Server) unsubscribe(sub *subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscribers, sub)
+	s.scope.Gauge(metricSubscribers).Update(float64(len(s.subscribers)))
+}
@@ -0,0 +1,111 @@
+package status
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	statusv1 "github.com/envoyproxy/xds-relay/pkg/api/status/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// startServer serves s on a local port and returns a client of it.
+func startServer(t *testing.T, s *Server) statusv1.StatusServiceClient {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	statusv1.RegisterStatusServiceServer(server, s)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return statusv1.NewStatusServiceClient(conn)
+}
+
+func (s *Server) numSubscribers() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers)
+}
+
+func TestStreamKeyStatus(t *testing.T) {
+	s := NewServer(log.New("info"), tally.NewTestScope("status", make(map[string]string)))
+	client := startServer(t, s)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	all, err := client.StreamKeyStatus(ctx, &statusv1.StreamKeyStatusRequest{})
+	assert.NoError(t, err)
+	lds, err := client.StreamKeyStatus(ctx, &statusv1.StreamKeyStatusRequest{Keys: []string{"lds"}})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return s.numSubscribers() == 2 }, time.Second, 10*time.Millisecond)
+
+	s.PublishVersion("cds", "1")
+	s.PublishVersion("lds", "1")
+	s.PublishAck("lds", "node", "1")
+	s.PublishNack("lds", "node", "1", "invalid listener")
+
+	event, err := all.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, statusv1.KeyStatusEvent_VERSION_CHANGED, event.GetType())
+	assert.Equal(t, "cds", event.GetKey())
+	assert.NotNil(t, event.GetTime())
+
+	// Events of other keys are filtered out.
+	event, err = lds.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, statusv1.KeyStatusEvent_VERSION_CHANGED, event.GetType())
+	assert.Equal(t, "lds", event.GetKey())
+	assert.Equal(t, "1", event.GetVersion())
+
+	event, err = lds.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, statusv1.KeyStatusEvent_ACKED, event.GetType())
+	assert.Equal(t, "node", event.GetNodeId())
+	assert.Equal(t, "1", event.GetVersion())
+
+	event, err = lds.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, statusv1.KeyStatusEvent_NACKED, event.GetType())
+	assert.Equal(t, "node", event.GetNodeId())
+	assert.Equal(t, "invalid listener", event.GetErrorDetail())
+
+	cancel()
+	assert.Eventually(t, func() bool { return s.numSubscribers() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestStreamKeyStatusSlowSubscriber(t *testing.T) {
+	scope := tally.NewTestScope("status", make(map[string]string))
+	s := NewServer(log.New("info"), scope)
+	client := startServer(t, s)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.StreamKeyStatus(ctx, &statusv1.StreamKeyStatusRequest{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return s.numSubscribers() == 1 }, time.Second, 10*time.Millisecond)
+
+	// The client never receives, so sends block once the flow control window fills, followed by the subscriber's
+	// buffer.
+	for i := 0; s.numSubscribers() > 0 && i < 1000000; i++ {
+		s.PublishVersion("lds", "1")
+	}
+	assert.Equal(t, 0, s.numSubscribers())
+	testutils.AssertCounterValue(t, scope.Snapshot().Counters(), "status.subscriber_dropped", 1)
+
+	for {
+		if _, err = stream.Recv(); err != nil {
+			break
+		}
+	}
+	assert.Equal(t, codes.ResourceExhausted, grpcstatus.Code(err))
+}
@@ -0,0 +1,186 @@
+package transform
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxExactInteger is the largest integer that JSON numbers, which are doubles, represent exactly.
+const maxExactInteger = 1 << 53
+
+// NewStripFields returns a transformer that clears the fields at the dot-separated paths of every resource.
+func NewStripFields(paths []string) Transformer {
+	return Func(func(resp *discovery.DiscoveryResponse) (*discovery.DiscoveryResponse, error) {
+		return resp, transformResources(resp, func(resource protoreflect.Message) error {
+			for _, path := range paths {
+				msg, field, err := resolve(resource, path, false)
+				if err != nil {
+					return err
+				}
+				if msg != nil {
+					msg.Clear(field)
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// NewSetFields returns a transformer that sets the fields at the dot-separated paths of every resource to the
+// values.
+func NewSetFields(values map[string]*structpb.Value) Transformer {
+	// Sort the paths so that overlapping paths are set in a deterministic order.
+	paths := make([]string, 0, len(values))
+	for path := range values {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return Func(func(resp *discovery.DiscoveryResponse) (*discovery.DiscoveryResponse, error) {
+		return resp, transformResources(resp, func(resource protoreflect.Message) error {
+			for _, path := range paths {
+				msg, field, err := resolve(resource, path, true)
+				if err != nil {
+					return err
+				}
+				value, err := toProtoValue(msg, field, values[path])
+				if err != nil {
+					return fmt.Errorf("cannot set %s: %s", path, err.Error())
+				}
+				msg.Set(field, value)
+			}
+			return nil
+		})
+	})
+}
+
+// transformResources unpacks each resource of the response, applies fn to it, and packs it back into the response.
+func transformResources(resp *discovery.DiscoveryResponse, fn func(resource protoreflect.Message) error) error {
+	for i, resource := range resp.GetResources() {
+		var unpacked ptypes.DynamicAny
+		if err := ptypes.UnmarshalAny(resource, &unpacked); err != nil {
+			return err
+		}
+		if err := fn(proto.MessageReflect(unpacked.Message)); err != nil {
+			return err
+		}
+		value, err := proto.Marshal(unpacked.Message)
+		if err != nil {
+			return err
+		}
+		resp.Resources[i] = &any.Any{TypeUrl: resource.GetTypeUrl(), Value: value}
+	}
+	return nil
+}
+
+// resolve walks the dot-separated path of field names from msg, and returns the message holding the last field and
+// the field's descriptor. Unset intermediate messages are created if create is true. Otherwise a nil message is
+// returned, as the field is already unset.
+func resolve(
+	msg protoreflect.Message,
+	path string,
+	create bool,
+) (protoreflect.Message, protoreflect.FieldDescriptor, error) {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		field := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return nil, nil, fmt.Errorf("%s has no field %q", msg.Descriptor().FullName(), name)
+		}
+		if i == len(names)-1 {
+			return msg, field, nil
+		}
+		if field.Kind() != protoreflect.MessageKind || field.IsList() || field.IsMap() {
+			return nil, nil, fmt.Errorf("field %q of %s is not a message", name, msg.Descriptor().FullName())
+		}
+		if !create && !msg.Has(field) {
+			return nil, field, nil
+		}
+		msg = msg.Mutable(field).Message()
+	}
+	return nil, nil, fmt.Errorf("empty path")
+}
+
+// toProtoValue converts value to the type of the field.
+func toProtoValue(
+	msg protoreflect.Message,
+	field protoreflect.FieldDescriptor,
+	value *structpb.Value,
+) (protoreflect.Value, error) {
+	if field.IsList() || field.IsMap() {
+		return protoreflect.Value{}, fmt.Errorf("repeated and map fields are not supported")
+	}
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		if v, ok := value.GetKind().(*structpb.Value_BoolValue); ok {
+			return protoreflect.ValueOfBool(v.BoolValue), nil
+		}
+	case protoreflect.StringKind:
+		if v, ok := value.GetKind().(*structpb.Value_StringValue); ok {
+			return protoreflect.ValueOfString(v.StringValue), nil
+		}
+	case protoreflect.EnumKind:
+		if v, ok := value.GetKind().(*structpb.Value_StringValue); ok {
+			enumValue := field.Enum().Values().ByName(protoreflect.Name(v.StringValue))
+			if enumValue == nil {
+				return protoreflect.Value{}, fmt.Errorf("%s has no value %q", field.Enum().FullName(), v.StringValue)
+			}
+			return protoreflect.ValueOfEnum(enumValue.Number()), nil
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if n, ok := toInteger(value, math.MinInt32, math.MaxInt32); ok {
+			return protoreflect.ValueOfInt32(int32(n)), nil
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if n, ok := toInteger(value, -maxExactInteger, maxExactInteger); ok {
+			return protoreflect.ValueOfInt64(int64(n)), nil
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if n, ok := toInteger(value, 0, math.MaxUint32); ok {
+			return protoreflect.ValueOfUint32(uint32(n)), nil
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if n, ok := toInteger(value, 0, maxExactInteger); ok {
+			return protoreflect.ValueOfUint64(uint64(n)), nil
+		}
+	case protoreflect.FloatKind:
+		if v, ok := value.GetKind().(*structpb.Value_NumberValue); ok {
+			return protoreflect.ValueOfFloat32(float32(v.NumberValue)), nil
+		}
+	case protoreflect.DoubleKind:
+		if v, ok := value.GetKind().(*structpb.Value_NumberValue); ok {
+			return protoreflect.ValueOfFloat64(v.NumberValue), nil
+		}
+	case protoreflect.MessageKind:
+		// Wrapper types, e.g. google.protobuf.BoolValue, are set through their value field.
+		wrapperField := field.Message().Fields().ByName("value")
+		if field.Message().FullName().Parent() == "google.protobuf" && wrapperField != nil {
+			wrapper := msg.NewField(field).Message()
+			wrapperValue, err := toProtoValue(wrapper, wrapperField, value)
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+			wrapper.Set(wrapperField, wrapperValue)
+			return protoreflect.ValueOfMessage(wrapper), nil
+		}
+		return protoreflect.Value{}, fmt.Errorf("messages of type %s are not supported", field.Message().FullName())
+	}
+	return protoreflect.Value{}, fmt.Errorf("value %s does not match field of kind %s", value.String(), field.Kind())
+}
+
+// toInteger returns the number value if it is an integer within [min, max].
+func toInteger(value *structpb.Value, min float64, max float64) (float64, bool) {
+	v, ok := value.GetKind().(*structpb.Value_NumberValue)
+	if !ok || v.NumberValue != math.Trunc(v.NumberValue) || v.NumberValue < min || v.NumberValue > max {
+		return 0, false
+	}
+	return v.NumberValue, true
+}
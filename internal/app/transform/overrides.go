@@ -0,0 +1,363 @@
+package transform
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	rtds "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/diff"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/yamlproto"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	overridev1 "github.com/envoyproxy/xds-relay/pkg/api/override/v1"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/uber-go/tally"
+)
+
+const (
+	defaultReloadInterval = 10 * time.Second
+
+	metricOverrideApplied = "override_applied"
+	metricReload          = "reload"
+	metricReloadError     = "reload_error"
+)
+
+// overrideFileExtensions are the extensions of the files loaded from the override directory.
+var overrideFileExtensions = map[string]bool{
+	".yaml": true,
+	".yml":  true,
+	".json": true,
+}
+
+// Overrides patches resources by aggregated key and resource name, as configured by a directory of override files.
+type Overrides struct {
+	directory      string
+	reloadInterval time.Duration
+	logger         log.Logger
+	scope          tally.Scope
+
+	mu sync.RWMutex
+	// overrides maps aggregated keys to resource names to the patches of the resource, in the order they are
+	// applied. The map is replaced rather than modified on reload.
+	overrides map[string]map[string][]*overridev1.Override
+	// checksum identifies the contents of the override files that were last loaded.
+	checksum [sha256.Size]byte
+	onChange []func()
+}
+
+// NewOverrides loads the override files from the configured directory.
+func NewOverrides(config *bootstrapv1.OverrideFiles, logger log.Logger, scope tally.Scope) (*Overrides, error) {
+	o := &Overrides{
+		directory:      config.GetDirectory(),
+		reloadInterval: defaultReloadInterval,
+		logger:         logger.Named("overrides").With("directory", config.GetDirectory()),
+		scope:          scope,
+	}
+	if config.GetReloadInterval() != nil {
+		reloadInterval, err := ptypes.Duration(config.GetReloadInterval())
+		if err != nil {
+			return nil, err
+		}
+		o.reloadInterval = reloadInterval
+	}
+	if _, err := o.reload(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// OnChange registers a callback that is called whenever reloading changes the overrides.
+func (o *Overrides) OnChange(callback func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.onChange = append(o.onChange, callback)
+}
+
+// Run reloads the override files every reload interval until ctx is done. If the files fail to load, the previous
+// overrides are kept.
+func (o *Overrides) Run(ctx context.Context) {
+	ticker := time.NewTicker(o.reloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		changed, err := o.reload()
+		if err != nil {
+			o.scope.Counter(metricReloadError).Inc(1)
+			o.logger.With("err", err).Error(ctx, "failed to reload overrides")
+			continue
+		}
+		if !changed {
+			continue
+		}
+		o.scope.Counter(metricReload).Inc(1)
+		o.logger.Info(ctx, "overrides changed")
+		o.mu.RLock()
+		callbacks := o.onChange
+		o.mu.RUnlock()
+		for _, callback := range callbacks {
+			callback()
+		}
+	}
+}
+
+// reload reads the override files, and returns true if their contents changed since they were last loaded.
+func (o *Overrides) reload() (bool, error) {
+	files, err := ioutil.ReadDir(o.directory)
+	if err != nil {
+		return false, err
+	}
+	hash := sha256.New()
+	overrides := make(map[string]map[string][]*overridev1.Override)
+	for _, file := range files {
+		if file.IsDir() || !overrideFileExtensions[filepath.Ext(file.Name())] {
+			continue
+		}
+		path := filepath.Join(o.directory, file.Name())
+		contents, err := ioutil.ReadFile(path) // #nosec
+		if err != nil {
+			return false, err
+		}
+		_, _ = hash.Write([]byte(file.Name()))
+		_, _ = hash.Write(contents)
+		var parsed overridev1.Overrides
+		if err := yamlproto.FromYAMLToOverrides(string(contents), &parsed); err != nil {
+			return false, fmt.Errorf("invalid override file %s: %s", path, err.Error())
+		}
+		for _, override := range parsed.GetOverrides() {
+			if overrides[override.GetKey()] == nil {
+				overrides[override.GetKey()] = make(map[string][]*overridev1.Override)
+			}
+			resourceOverrides, name := overrides[override.GetKey()], override.GetResourceName()
+			resourceOverrides[name] = append(resourceOverrides[name], override)
+		}
+	}
+	var checksum [sha256.Size]byte
+	copy(checksum[:], hash.Sum(nil))
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.overrides != nil && checksum == o.checksum {
+		return false, nil
+	}
+	o.overrides = overrides
+	o.checksum = checksum
+	return true, nil
+}
+
+// Apply returns a copy of the response with the overrides of the aggregated key applied to its resources. The
+// response itself is returned if no overrides exist for the aggregated key.
+func (o *Overrides) Apply(
+	aggregatedKey string,
+	resp *discovery.DiscoveryResponse,
+) (*discovery.DiscoveryResponse, error) {
+	o.mu.RLock()
+	overrides := o.overrides[aggregatedKey]
+	o.mu.RUnlock()
+	if len(overrides) == 0 {
+		return resp, nil
+	}
+	patched := proto.Clone(resp).(*discovery.DiscoveryResponse)
+	for i, resource := range patched.GetResources() {
+		name, err := diff.GetResourceName(resource)
+		if err != nil {
+			continue
+		}
+		resourceOverrides, ok := overrides[name]
+		if !ok {
+			continue
+		}
+		patchedResource, err := patchResource(resource, resourceOverrides)
+		if err != nil {
+			return nil, fmt.Errorf("failed to override resource %s: %s", name, err.Error())
+		}
+		patched.Resources[i] = patchedResource
+		o.scope.Counter(metricOverrideApplied).Inc(1)
+	}
+	return patched, nil
+}
+
+func patchResource(resource *any.Any, overrides []*overridev1.Override) (*any.Any, error) {
+	var unpacked ptypes.DynamicAny
+	if err := ptypes.UnmarshalAny(resource, &unpacked); err != nil {
+		return nil, err
+	}
+	msg := unpacked.Message
+	for _, override := range overrides {
+		var err error
+		switch patch := override.GetPatch().(type) {
+		case *overridev1.Override_MergePatch:
+			msg, err = applyMergePatch(msg, patch.MergePatch)
+		case *overridev1.Override_FieldMaskPatch:
+			err = applyFieldMaskPatch(msg, patch.FieldMaskPatch)
+		case *overridev1.Override_RuntimeLayer:
+			err = applyRuntimeLayer(msg, patch.RuntimeLayer)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	value, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &any.Any{TypeUrl: resource.GetTypeUrl(), Value: value}, nil
+}
+
+// applyMergePatch applies the JSON merge patch to the JSON representation of the message, and returns the patched
+// message.
+func applyMergePatch(msg proto.Message, patch *structpb.Struct) (proto.Message, error) {
+	target, err := toJSONValue(msg)
+	if err != nil {
+		return nil, err
+	}
+	patchValue, err := toJSONValue(patch)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := json.Marshal(mergePatch(target, patchValue))
+	if err != nil {
+		return nil, err
+	}
+	patched := newMessage(msg)
+	if err := jsonpb.UnmarshalString(string(merged), patched); err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+// toJSONValue returns the JSON representation of the message, decoded into maps, slices, and primitive values.
+func toJSONValue(msg proto.Message) (interface{}, error) {
+	serialized, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(msg)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(serialized), &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// mergePatch implements the JSON merge patch algorithm of RFC 7386.
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+		} else {
+			targetObject[name] = mergePatch(targetObject[name], value)
+		}
+	}
+	return targetObject
+}
+
+// applyFieldMaskPatch replaces the fields of the message at the paths with those of the patch value.
+func applyFieldMaskPatch(msg proto.Message, patch *overridev1.FieldMaskPatch) error {
+	serialized, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(patch.GetValue())
+	if err != nil {
+		return err
+	}
+	value := newMessage(msg)
+	if err := jsonpb.UnmarshalString(serialized, value); err != nil {
+		return err
+	}
+	target, source := proto.MessageReflect(msg), proto.MessageReflect(value)
+	for _, path := range patch.GetPaths() {
+		sourceMsg, sourceField, err := resolve(source, path, false)
+		if err != nil {
+			return err
+		}
+		if sourceMsg == nil || !sourceMsg.Has(sourceField) {
+			targetMsg, targetField, err := resolve(target, path, false)
+			if err != nil {
+				return err
+			}
+			if targetMsg != nil {
+				targetMsg.Clear(targetField)
+			}
+			continue
+		}
+		targetMsg, targetField, err := resolve(target, path, true)
+		if err != nil {
+			return err
+		}
+		targetMsg.Set(targetField, sourceMsg.Get(sourceField))
+	}
+	return nil
+}
+
+// applyRuntimeLayer merges the runtime values into the layer of the Runtime resource. The layer is flattened into
+// dot-separated runtime keys, so that values set by nested and by dotted keys override each other.
+func applyRuntimeLayer(msg proto.Message, values *structpb.Struct) error {
+	runtime, ok := msg.(*rtds.Runtime)
+	if !ok {
+		return fmt.Errorf("runtime layer patches only apply to Runtime resources, not %s", proto.MessageName(msg))
+	}
+	layer := make(map[string]*structpb.Value)
+	flattenRuntimeLayer("", runtime.GetLayer(), layer)
+	patch := make(map[string]*structpb.Value)
+	flattenRuntimeLayer("", values, patch)
+	// Keys are removed before others are set, so that a patch can replace the keys nested under a key.
+	for key, value := range patch {
+		if _, ok := value.GetKind().(*structpb.Value_NullValue); !ok {
+			continue
+		}
+		for name := range layer {
+			if name == key || strings.HasPrefix(name, key+".") {
+				delete(layer, name)
+			}
+		}
+	}
+	for key, value := range patch {
+		if _, ok := value.GetKind().(*structpb.Value_NullValue); !ok {
+			layer[key] = value
+		}
+	}
+	runtime.Layer = &structpb.Struct{Fields: layer}
+	return nil
+}
+
+// flattenRuntimeLayer adds the values of the layer to the flattened layer, keyed by their dot-separated runtime keys
+// under the prefix.
+func flattenRuntimeLayer(prefix string, layer *structpb.Struct, flattened map[string]*structpb.Value) {
+	for name, value := range layer.GetFields() {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		if nested, ok := value.GetKind().(*structpb.Value_StructValue); ok {
+			flattenRuntimeLayer(key, nested.StructValue, flattened)
+			continue
+		}
+		flattened[key] = value
+	}
+}
+
+// newMessage returns an empty message of the same type as msg.
+func newMessage(msg proto.Message) proto.Message {
+	return reflect.New(reflect.TypeOf(msg).Elem()).Interface().(proto.Message)
+}
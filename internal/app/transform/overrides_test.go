@@ -0,0 +1,206 @@
+package transform
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	rtds "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
+	envoy_type "github.com/envoyproxy/go-control-plane/envoy/type"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/duration"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+)
+
+const testOverrides = `
+overrides:
+- key: cds
+  resource_name: a
+  merge_patch:
+    alt_stat_name: null
+    connect_timeout: 5s
+    common_lb_config:
+      healthy_panic_threshold:
+        value: 10
+- key: cds
+  resource_name: a
+  field_mask_patch:
+    paths:
+    - per_connection_buffer_limit_bytes
+    - respect_dns_ttl
+    value:
+      per_connection_buffer_limit_bytes: 1024
+- key: lds
+  resource_name: a
+  merge_patch:
+    alt_stat_name: lds
+`
+
+func newOverrideDirectory(t *testing.T) string {
+	directory, err := ioutil.TempDir("", "overrides")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(directory) })
+	return directory
+}
+
+// writeOverrideFile atomically replaces the override file, so that a concurrent reload does not read a partial file.
+func writeOverrideFile(t *testing.T, directory string, name string, contents string) {
+	path := filepath.Join(directory, name)
+	assert.NoError(t, ioutil.WriteFile(path+".tmp", []byte(contents), 0600))
+	assert.NoError(t, os.Rename(path+".tmp", path))
+}
+
+func TestOverridesApply(t *testing.T) {
+	directory := newOverrideDirectory(t)
+	writeOverrideFile(t, directory, "overrides.yaml", testOverrides)
+	// Files with other extensions are ignored.
+	writeOverrideFile(t, directory, "README.md", "not an override file")
+	scope := tally.NewTestScope("overrides", make(map[string]string))
+	overrides, err := NewOverrides(&bootstrapv1.OverrideFiles{Directory: directory}, log.New("info"), scope)
+	assert.NoError(t, err)
+
+	resp := newClusterResponse(t,
+		&v2.Cluster{Name: "a", AltStatName: "stat", RespectDnsTtl: true},
+		&v2.Cluster{Name: "b", AltStatName: "stat"},
+	)
+	original := proto.Clone(resp)
+	patched, err := overrides.Apply("cds", resp)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(original, resp))
+	assert.True(t, proto.Equal(&v2.Cluster{
+		Name:                          "a",
+		ConnectTimeout:                ptypes.DurationProto(5 * time.Second),
+		CommonLbConfig:                &v2.Cluster_CommonLbConfig{HealthyPanicThreshold: &envoy_type.Percent{Value: 10}},
+		PerConnectionBufferLimitBytes: &wrappers.UInt32Value{Value: 1024},
+	}, getCluster(t, patched.GetResources()[0])))
+	assert.True(t, proto.Equal(&v2.Cluster{Name: "b", AltStatName: "stat"}, getCluster(t, patched.GetResources()[1])))
+	testutils.AssertCounterValue(t, scope.Snapshot().Counters(), "overrides.override_applied", 1)
+
+	// Responses for keys without overrides are not copied.
+	unpatched, err := overrides.Apply("eds", resp)
+	assert.NoError(t, err)
+	assert.True(t, unpatched == resp)
+}
+
+func TestOverridesApplyRuntimeLayer(t *testing.T) {
+	directory := newOverrideDirectory(t)
+	writeOverrideFile(t, directory, "runtime.yaml", `
+overrides:
+- key: rtds
+  resource_name: layer
+  runtime_layer:
+    envoy.reloadable_features.a: false
+    health_check:
+      min_interval: 20
+    old: null
+    new.flag: "on"
+`)
+	overrides, err := NewOverrides(&bootstrapv1.OverrideFiles{Directory: directory}, log.New("info"),
+		tally.NewTestScope("overrides", make(map[string]string)))
+	assert.NoError(t, err)
+
+	var layer structpb.Struct
+	assert.NoError(t, jsonpb.UnmarshalString(
+		`{"envoy": {"reloadable_features": {"a": true, "b": true}}, "health_check.min_interval": 10, "old": {"x": 1}}`,
+		&layer))
+	resource, err := ptypes.MarshalAny(&rtds.Runtime{Name: "layer", Layer: &layer})
+	assert.NoError(t, err)
+	patched, err := overrides.Apply("rtds", &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     upstream.RuntimeTypeURL,
+		Resources:   []*any.Any{resource},
+	})
+	assert.NoError(t, err)
+
+	// The patched layer is flattened into runtime keys.
+	var runtime rtds.Runtime
+	assert.NoError(t, ptypes.UnmarshalAny(patched.GetResources()[0], &runtime))
+	assert.Equal(t, "layer", runtime.GetName())
+	patchedLayer, err := (&jsonpb.Marshaler{}).MarshalToString(runtime.GetLayer())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"envoy.reloadable_features.a": false,
+		"envoy.reloadable_features.b": true,
+		"health_check.min_interval": 20,
+		"new.flag": "on"
+	}`, patchedLayer)
+}
+
+func TestOverridesApplyError(t *testing.T) {
+	directory := newOverrideDirectory(t)
+	writeOverrideFile(t, directory, "overrides.json", `{"overrides": [
+		{"key": "cds", "resource_name": "a", "merge_patch": {"unknown": 1}},
+		{"key": "eds", "resource_name": "a", "runtime_layer": {"flag": true}}
+	]}`)
+	overrides, err := NewOverrides(&bootstrapv1.OverrideFiles{Directory: directory}, log.New("info"),
+		tally.NewTestScope("overrides", make(map[string]string)))
+	assert.NoError(t, err)
+
+	_, err = overrides.Apply("cds", newClusterResponse(t, &v2.Cluster{Name: "a"}))
+	assert.Error(t, err)
+	// Runtime layer patches of other resources fail.
+	_, err = overrides.Apply("eds", newClusterResponse(t, &v2.Cluster{Name: "a"}))
+	assert.Error(t, err)
+}
+
+func TestNewOverridesInvalid(t *testing.T) {
+	directory := newOverrideDirectory(t)
+	writeOverrideFile(t, directory, "overrides.yaml", "overrides:\n- key: cds\n")
+	_, err := NewOverrides(&bootstrapv1.OverrideFiles{Directory: directory}, log.New("info"),
+		tally.NewTestScope("overrides", make(map[string]string)))
+	assert.Error(t, err)
+
+	_, err = NewOverrides(&bootstrapv1.OverrideFiles{Directory: filepath.Join(directory, "nonexistent")},
+		log.New("info"), tally.NewTestScope("overrides", make(map[string]string)))
+	assert.Error(t, err)
+}
+
+func TestOverridesRun(t *testing.T) {
+	directory := newOverrideDirectory(t)
+	scope := tally.NewTestScope("overrides", make(map[string]string))
+	overrides, err := NewOverrides(&bootstrapv1.OverrideFiles{
+		Directory:      directory,
+		ReloadInterval: &duration.Duration{Nanos: int32(time.Millisecond)},
+	}, log.New("info"), scope)
+	assert.NoError(t, err)
+	changed := make(chan struct{}, 1)
+	overrides.OnChange(func() { changed <- struct{}{} })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go overrides.Run(ctx)
+
+	writeOverrideFile(t, directory, "overrides.yaml", testOverrides)
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		assert.Fail(t, "overrides were not reloaded")
+	}
+	patched, err := overrides.Apply("lds", newClusterResponse(t, &v2.Cluster{Name: "a"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "lds", getCluster(t, patched.GetResources()[0]).GetAltStatName())
+
+	// Invalid files keep the previous overrides.
+	writeOverrideFile(t, directory, "invalid.yaml", "overrides: invalid")
+	assert.Eventually(t, func() bool {
+		_, ok := scope.Snapshot().Counters()["overrides.reload_error+"]
+		return ok
+	}, time.Second, time.Millisecond)
+	patched, err = overrides.Apply("lds", newClusterResponse(t, &v2.Cluster{Name: "a"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "lds", getCluster(t, patched.GetResources()[0]).GetAltStatName())
+	assert.Equal(t, 0, len(changed))
+}
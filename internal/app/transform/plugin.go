@@ -0,0 +1,28 @@
+package transform
+
+import (
+	"fmt"
+	"plugin"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+)
+
+// pluginSymbol is the name of the function exported by transformation plugins.
+const pluginSymbol = "Transform"
+
+// OpenPlugin loads the Go plugin at path and returns a transformer calling its exported Transform function.
+func OpenPlugin(path string) (Transformer, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	symbol, err := p.Lookup(pluginSymbol)
+	if err != nil {
+		return nil, err
+	}
+	transform, ok := symbol.(func(*discovery.DiscoveryResponse) (*discovery.DiscoveryResponse, error))
+	if !ok {
+		return nil, fmt.Errorf("%s in plugin %s has unexpected type %T", pluginSymbol, path, symbol)
+	}
+	return Func(transform), nil
+}
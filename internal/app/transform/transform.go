@@ -0,0 +1,90 @@
+// Package transform mutates origin server responses before the relay caches them and fans them out to downstream
+// clients.
+package transform
+
+import (
+	"fmt"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+)
+
+// Transformer mutates a discovery response. The response may be modified in place.
+type Transformer interface {
+	Transform(resp *discovery.DiscoveryResponse) (*discovery.DiscoveryResponse, error)
+}
+
+// Func adapts a function to a Transformer.
+type Func func(resp *discovery.DiscoveryResponse) (*discovery.DiscoveryResponse, error)
+
+// Transform calls f(resp).
+func (f Func) Transform(resp *discovery.DiscoveryResponse) (*discovery.DiscoveryResponse, error) {
+	return f(resp)
+}
+
+type stage struct {
+	// typeURLs is empty if the transformer applies to every type.
+	typeURLs    map[string]bool
+	transformer Transformer
+}
+
+// Chain applies transformers in the order they were registered, each to responses of the type URLs it is
+// registered for.
+type Chain struct {
+	stages []stage
+}
+
+// New returns a chain of the configured transformations.
+func New(transformations []*bootstrapv1.Transformation) (*Chain, error) {
+	chain := &Chain{}
+	for i, transformation := range transformations {
+		transformer, err := newTransformer(transformation)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transformation %d: %s", i, err.Error())
+		}
+		chain.Register(transformation.GetTypeUrls(), transformer)
+	}
+	return chain, nil
+}
+
+func newTransformer(transformation *bootstrapv1.Transformation) (Transformer, error) {
+	switch transformer := transformation.GetTransformer().(type) {
+	case *bootstrapv1.Transformation_StripFields:
+		return NewStripFields(transformer.StripFields.GetPaths()), nil
+	case *bootstrapv1.Transformation_SetFields:
+		return NewSetFields(transformer.SetFields.GetValues()), nil
+	case *bootstrapv1.Transformation_GoPlugin:
+		return OpenPlugin(transformer.GoPlugin.GetPath())
+	default:
+		return nil, fmt.Errorf("no transformer configured")
+	}
+}
+
+// Register appends a transformer for responses of the type URLs. If typeURLs is empty, the transformer applies to
+// responses of every type.
+func (c *Chain) Register(typeURLs []string, transformer Transformer) {
+	s := stage{
+		typeURLs:    make(map[string]bool, len(typeURLs)),
+		transformer: transformer,
+	}
+	for _, typeURL := range typeURLs {
+		s.typeURLs[typeURL] = true
+	}
+	c.stages = append(c.stages, s)
+}
+
+// Transform applies each transformer registered for the type URL of the response. The first error aborts the
+// chain.
+func (c *Chain) Transform(resp *discovery.DiscoveryResponse) (*discovery.DiscoveryResponse, error) {
+	typeURL := resp.GetTypeUrl()
+	for _, s := range c.stages {
+		if len(s.typeURLs) > 0 && !s.typeURLs[typeURL] {
+			continue
+		}
+		var err error
+		if resp, err = s.transformer.Transform(resp); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,165 @@
+package transform
+
+import (
+	"fmt"
+	"testing"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_type "github.com/envoyproxy/go-control-plane/envoy/type"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+)
+
+func newClusterResponse(t *testing.T, clusters ...*v2.Cluster) *v2.DiscoveryResponse {
+	resp := &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ClusterTypeURL}
+	for _, cluster := range clusters {
+		resource, err := ptypes.MarshalAny(cluster)
+		assert.NoError(t, err)
+		resp.Resources = append(resp.Resources, resource)
+	}
+	return resp
+}
+
+func getCluster(t *testing.T, resource *any.Any) *v2.Cluster {
+	var cluster v2.Cluster
+	assert.NoError(t, ptypes.UnmarshalAny(resource, &cluster))
+	return &cluster
+}
+
+func TestChain(t *testing.T) {
+	var applied []string
+	newTransformer := func(name string) Transformer {
+		return Func(func(resp *v2.DiscoveryResponse) (*v2.DiscoveryResponse, error) {
+			applied = append(applied, name)
+			return resp, nil
+		})
+	}
+	chain := &Chain{}
+	chain.Register(nil, newTransformer("all"))
+	chain.Register([]string{upstream.ListenerTypeURL}, newTransformer("listener"))
+	chain.Register([]string{upstream.ClusterTypeURL, upstream.ListenerTypeURL}, newTransformer("cluster"))
+
+	_, err := chain.Transform(&v2.DiscoveryResponse{TypeUrl: upstream.ClusterTypeURL})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"all", "cluster"}, applied)
+
+	applied = nil
+	_, err = chain.Transform(&v2.DiscoveryResponse{TypeUrl: upstream.ListenerTypeURL})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"all", "listener", "cluster"}, applied)
+}
+
+func TestChainError(t *testing.T) {
+	chain := &Chain{}
+	chain.Register(nil, Func(func(resp *v2.DiscoveryResponse) (*v2.DiscoveryResponse, error) {
+		return nil, fmt.Errorf("failed")
+	}))
+	chain.Register(nil, Func(func(resp *v2.DiscoveryResponse) (*v2.DiscoveryResponse, error) {
+		assert.Fail(t, "transformers after a failure should not be applied")
+		return resp, nil
+	}))
+	resp, err := chain.Transform(&v2.DiscoveryResponse{})
+	assert.EqualError(t, err, "failed")
+	assert.Nil(t, resp)
+}
+
+func TestNew(t *testing.T) {
+	chain, err := New([]*bootstrapv1.Transformation{
+		{
+			TypeUrls: []string{upstream.ClusterTypeURL},
+			Transformer: &bootstrapv1.Transformation_StripFields{
+				StripFields: &bootstrapv1.StripFields{Paths: []string{"alt_stat_name"}},
+			},
+		},
+		{
+			TypeUrls: []string{upstream.ListenerTypeURL},
+			Transformer: &bootstrapv1.Transformation_StripFields{
+				StripFields: &bootstrapv1.StripFields{Paths: []string{"stat_prefix"}},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	resp, err := chain.Transform(newClusterResponse(t, &v2.Cluster{Name: "a", AltStatName: "b"}))
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&v2.Cluster{Name: "a"}, getCluster(t, resp.GetResources()[0])))
+
+	_, err = New([]*bootstrapv1.Transformation{{}})
+	assert.EqualError(t, err, "invalid transformation 0: no transformer configured")
+
+	_, err = New([]*bootstrapv1.Transformation{
+		{
+			Transformer: &bootstrapv1.Transformation_GoPlugin{
+				GoPlugin: &bootstrapv1.GoPlugin{Path: "testdata/nonexistent.so"},
+			},
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestStripFields(t *testing.T) {
+	transformer := NewStripFields([]string{"alt_stat_name", "common_lb_config.healthy_panic_threshold"})
+	resp, err := transformer.Transform(newClusterResponse(t,
+		&v2.Cluster{
+			Name:        "a",
+			AltStatName: "stat",
+			CommonLbConfig: &v2.Cluster_CommonLbConfig{
+				HealthyPanicThreshold: &envoy_type.Percent{Value: 50},
+				UpdateMergeWindow:     ptypes.DurationProto(0),
+			},
+		},
+		// Unset intermediate messages are left unset.
+		&v2.Cluster{Name: "b"},
+	))
+	assert.NoError(t, err)
+	assert.Equal(t, upstream.ClusterTypeURL, resp.GetResources()[0].GetTypeUrl())
+	assert.True(t, proto.Equal(&v2.Cluster{
+		Name:           "a",
+		CommonLbConfig: &v2.Cluster_CommonLbConfig{UpdateMergeWindow: ptypes.DurationProto(0)},
+	}, getCluster(t, resp.GetResources()[0])))
+	assert.True(t, proto.Equal(&v2.Cluster{Name: "b"}, getCluster(t, resp.GetResources()[1])))
+
+	_, err = NewStripFields([]string{"unknown"}).Transform(newClusterResponse(t, &v2.Cluster{Name: "a"}))
+	assert.EqualError(t, err, `envoy.api.v2.Cluster has no field "unknown"`)
+	_, err = NewStripFields([]string{"name.value"}).Transform(newClusterResponse(t, &v2.Cluster{Name: "a"}))
+	assert.EqualError(t, err, `field "name" of envoy.api.v2.Cluster is not a message`)
+}
+
+func TestSetFields(t *testing.T) {
+	transformer := NewSetFields(map[string]*structpb.Value{
+		"alt_stat_name":                     {Kind: &structpb.Value_StringValue{StringValue: "stat"}},
+		"lb_policy":                         {Kind: &structpb.Value_StringValue{StringValue: "RANDOM"}},
+		"respect_dns_ttl":                   {Kind: &structpb.Value_BoolValue{BoolValue: true}},
+		"per_connection_buffer_limit_bytes": {Kind: &structpb.Value_NumberValue{NumberValue: 1024}},
+		"common_lb_config.ignore_new_hosts_until_first_hc": {Kind: &structpb.Value_BoolValue{BoolValue: false}},
+	})
+	resp, err := transformer.Transform(newClusterResponse(t, &v2.Cluster{Name: "a"}))
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&v2.Cluster{
+		Name:                          "a",
+		AltStatName:                   "stat",
+		LbPolicy:                      v2.Cluster_RANDOM,
+		RespectDnsTtl:                 true,
+		PerConnectionBufferLimitBytes: &wrappers.UInt32Value{Value: 1024},
+		CommonLbConfig:                &v2.Cluster_CommonLbConfig{},
+	}, getCluster(t, resp.GetResources()[0])))
+}
+
+func TestSetFieldsErrors(t *testing.T) {
+	for path, value := range map[string]*structpb.Value{
+		"alt_stat_name":                     {Kind: &structpb.Value_BoolValue{BoolValue: true}},
+		"lb_policy":                         {Kind: &structpb.Value_StringValue{StringValue: "UNKNOWN"}},
+		"per_connection_buffer_limit_bytes": {Kind: &structpb.Value_NumberValue{NumberValue: -1}},
+		"connect_timeout":                   {Kind: &structpb.Value_NumberValue{NumberValue: 1}},
+		"hosts":                             {Kind: &structpb.Value_NumberValue{NumberValue: 1}},
+	} {
+		transformer := NewSetFields(map[string]*structpb.Value{path: value})
+		_, err := transformer.Transform(newClusterResponse(t, &v2.Cluster{Name: "a"}))
+		assert.Error(t, err, path)
+	}
+}
@@ -2,13 +2,24 @@ package upstream
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	clusterservice "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
+	rtds "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
+	endpointservice "github.com/envoyproxy/go-control-plane/envoy/service/endpoint/v3"
+	listenerservice "github.com/envoyproxy/go-control-plane/envoy/service/listener/v3"
+	routeservice "github.com/envoyproxy/go-control-plane/envoy/service/route/v3"
+	runtimeservice "github.com/envoyproxy/go-control-plane/envoy/service/runtime/v3"
+	"github.com/envoyproxy/xds-relay/internal/app/apiversion"
 	"github.com/envoyproxy/xds-relay/internal/pkg/log"
 	"github.com/envoyproxy/xds-relay/internal/pkg/util"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/socket"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
@@ -20,6 +31,17 @@ const (
 	EndpointTypeURL = "type.googleapis.com/envoy.api.v2.ClusterLoadAssignment"
 	// RouteTypeURL is the resource url for route
 	RouteTypeURL = "type.googleapis.com/envoy.api.v2.RouteConfiguration"
+	// VirtualHostTypeURL is the resource url for virtual hosts, which are only served incrementally
+	VirtualHostTypeURL = "type.googleapis.com/envoy.api.v2.route.VirtualHost"
+	// RuntimeTypeURL is the resource url for runtime layers
+	RuntimeTypeURL = "type.googleapis.com/envoy.service.discovery.v2.Runtime"
+)
+
+var (
+	// ErrUpstreamUnavailable is wrapped by the errors of streams that cannot be opened to the origin server.
+	ErrUpstreamUnavailable = errors.New("origin server unavailable")
+	// ErrUnsupportedResource is wrapped by UnsupportedResourceError.
+	ErrUnsupportedResource = errors.New("unsupported resource")
 )
 
 // UnsupportedResourceError is a custom error for unsupported typeURL
@@ -52,21 +74,92 @@ type Client interface {
 	OpenStream(v2.DiscoveryRequest) (<-chan *v2.DiscoveryResponse, func(), error)
 }
 
+// ResubscribableClient is a Client whose streams can change the resource names
+// they subscribe to without being reopened.
+type ResubscribableClient interface {
+	Client
+
+	// OpenResubscribableStream opens a stream like OpenStream. It additionally
+	// returns a resubscribe function, which sends a request with the resource
+	// names on the stream. The request carries the version and nonce of the
+	// latest response, so that the origin server only sends the resources
+	// that were added to the subscription.
+	OpenResubscribableStream(v2.DiscoveryRequest) (<-chan *v2.DiscoveryResponse, func([]string), func(), error)
+}
+
+// KeyedClient is a Client whose streams serve the responses of the aggregated
+// key of the request, such as recorded responses, rather than relaying the
+// request. The aggregated key is passed as computed by the orchestrator, so
+// that the client does not have to map the request again.
+type KeyedClient interface {
+	Client
+
+	// OpenKeyedStream opens a stream like OpenStream for the request of the
+	// aggregated key.
+	OpenKeyedStream(aggregatedKey string, req v2.DiscoveryRequest) (<-chan *v2.DiscoveryResponse, func(), error)
+}
+
+// RequestIDHeader is the metadata header of upstream streams that carries the
+// request ID of the downstream request that opened the stream.
+const RequestIDHeader = "x-request-id"
+
+// TracedClient is a ResubscribableClient whose streams carry the request ID of
+// the downstream request that opened them, so that the origin server can
+// correlate its logs with those of the relay.
+type TracedClient interface {
+	ResubscribableClient
+
+	// OpenTracedStream opens a stream like OpenResubscribableStream, with the
+	// request ID in the RequestIDHeader metadata header.
+	OpenTracedStream(req v2.DiscoveryRequest, requestID string) (
+		<-chan *v2.DiscoveryResponse, func([]string), func(), error)
+}
+
 type client struct {
 	ldsClient   v2.ListenerDiscoveryServiceClient
 	rdsClient   v2.RouteDiscoveryServiceClient
 	edsClient   v2.EndpointDiscoveryServiceClient
 	cdsClient   v2.ClusterDiscoveryServiceClient
+	rtdsClient  rtds.RuntimeDiscoveryServiceClient
+	vhdsClient  v2.VirtualHostDiscoveryServiceClient
 	callOptions CallOptions
 	logger      log.Logger
+	// endpoints is nil unless the endpoints of the origin server are discovered.
+	endpoints *endpointSet
+	// v3 is nil unless the origin server is spoken to with the v3 xDS API.
+	v3 *v3Clients
+}
+
+// v3Clients open the streams of the v3 xDS API.
+type v3Clients struct {
+	ldsClient  listenerservice.ListenerDiscoveryServiceClient
+	rdsClient  routeservice.RouteDiscoveryServiceClient
+	edsClient  endpointservice.EndpointDiscoveryServiceClient
+	cdsClient  clusterservice.ClusterDiscoveryServiceClient
+	rtdsClient runtimeservice.RuntimeDiscoveryServiceClient
+	vhdsClient routeservice.VirtualHostDiscoveryServiceClient
 }
 
 // CallOptions contains grpc client call options
 type CallOptions struct {
 	// Timeout is the time to wait on a blocking grpc SendMsg.
 	Timeout time.Duration
+	// Proxy, if set, is the proxy that the connection to the origin server egresses through. It is ignored for Unix
+	// domain sockets.
+	Proxy *Proxy
+	// Discovery, if set, discovers the endpoints of the origin server by resolving its hostname periodically. It is
+	// ignored for Unix domain sockets.
+	Discovery *Discovery
+	// Metadata is attached to every stream with the origin server.
+	Metadata []Metadata
+	// APIVersion is the xDS API version spoken with the origin server. The requests sent to v3 origin servers are
+	// translated from v2, and their responses back to v2, so that the relay keeps caching v2 responses.
+	APIVersion apiversion.Version
 }
 
+// Dialer creates a client of the origin server at the address. New is the default dialer.
+type Dialer func(ctx context.Context, address string, callOptions CallOptions, logger log.Logger) (Client, error)
+
 type version struct {
 	version string
 	nonce   string
@@ -78,11 +171,19 @@ type version struct {
 //
 // The method does not block until the underlying connection is up.
 // Returns immediately and connecting the server happens in background
+// Addresses prefixed with unix:// are dialed as Unix domain sockets.
 func New(ctx context.Context, url string, callOptions CallOptions, logger log.Logger) (Client, error) {
 	namedLogger := logger.Named("upstream_client")
 	namedLogger.With("address", url).Info(ctx, "Initiating upstream connection")
-	// TODO: configure grpc options.https://github.com/envoyproxy/xds-relay/issues/55
-	conn, err := grpc.Dial(url, grpc.WithInsecure())
+	dialOpts := DialOptions(url, callOptions)
+	target := url
+	var endpoints *endpointSet
+	if callOptions.Discovery != nil && !strings.HasPrefix(url, socket.UnixPrefix) {
+		endpoints = newEndpointSet()
+		target = dnsScheme + ":///" + url
+		dialOpts = append(dialOpts, callOptions.Discovery.dialOptions(endpoints)...)
+	}
+	conn, err := grpc.Dial(target, dialOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +192,20 @@ func New(ctx context.Context, url string, callOptions CallOptions, logger log.Lo
 	rdsClient := v2.NewRouteDiscoveryServiceClient(conn)
 	edsClient := v2.NewEndpointDiscoveryServiceClient(conn)
 	cdsClient := v2.NewClusterDiscoveryServiceClient(conn)
+	rtdsClient := rtds.NewRuntimeDiscoveryServiceClient(conn)
+	vhdsClient := v2.NewVirtualHostDiscoveryServiceClient(conn)
+
+	var v3 *v3Clients
+	if callOptions.APIVersion == apiversion.V3 {
+		v3 = &v3Clients{
+			ldsClient:  listenerservice.NewListenerDiscoveryServiceClient(conn),
+			rdsClient:  routeservice.NewRouteDiscoveryServiceClient(conn),
+			edsClient:  endpointservice.NewEndpointDiscoveryServiceClient(conn),
+			cdsClient:  clusterservice.NewClusterDiscoveryServiceClient(conn),
+			rtdsClient: runtimeservice.NewRuntimeDiscoveryServiceClient(conn),
+			vhdsClient: routeservice.NewVirtualHostDiscoveryServiceClient(conn),
+		}
+	}
 
 	go shutDown(ctx, conn)
 
@@ -99,51 +214,142 @@ func New(ctx context.Context, url string, callOptions CallOptions, logger log.Lo
 		rdsClient:   rdsClient,
 		edsClient:   edsClient,
 		cdsClient:   cdsClient,
+		rtdsClient:  rtdsClient,
+		vhdsClient:  vhdsClient,
 		callOptions: callOptions,
 		logger:      namedLogger,
+		endpoints:   endpoints,
+		v3:          v3,
 	}, nil
 }
 
+// DialOptions returns the options of a connection to the origin server at url, which egresses through the proxy of
+// the call options, if any. Other connections to the origin server, such as the load reporting one, use them so that
+// they reach it the same way as the xDS streams.
+func DialOptions(url string, callOptions CallOptions) []grpc.DialOption {
+	// TODO: configure grpc options.https://github.com/envoyproxy/xds-relay/issues/55
+	dialOpts := append([]grpc.DialOption{grpc.WithInsecure()}, socket.DialOptions(url)...)
+	if callOptions.Proxy != nil && !strings.HasPrefix(url, socket.UnixPrefix) {
+		dialOpts = append(dialOpts, grpc.WithContextDialer(callOptions.Proxy.Dial))
+	}
+	return dialOpts
+}
+
 func (m *client) OpenStream(request v2.DiscoveryRequest) (<-chan *v2.DiscoveryResponse, func(), error) {
+	response, _, shutdown, err := m.OpenResubscribableStream(request)
+	return response, shutdown, err
+}
+
+func (m *client) OpenResubscribableStream(
+	request v2.DiscoveryRequest,
+) (<-chan *v2.DiscoveryResponse, func([]string), func(), error) {
+	return m.OpenTracedStream(request, "")
+}
+
+func (m *client) OpenTracedStream(
+	request v2.DiscoveryRequest,
+	requestID string,
+) (<-chan *v2.DiscoveryResponse, func([]string), func(), error) {
 	ctx, cancel := context.WithCancel(context.Background())
+	if requestID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, RequestIDHeader, requestID)
+	}
+	ctx, err := withMetadata(ctx, m.callOptions.Metadata, &request, requestID)
+	if err != nil {
+		defer cancel()
+		return nil, nil, nil, fmt.Errorf("%w: %s", ErrUpstreamUnavailable, err.Error())
+	}
+	stream, err := m.newStream(ctx, request.GetTypeUrl())
+	if err != nil {
+		defer cancel()
+		return nil, nil, nil, err
+	}
+	if m.endpoints != nil {
+		response, resubscribe := m.startMigratingStream(ctx, cancel, request, stream)
+		return response, resubscribe, func() { cancel() }, nil
+	}
+
+	// The xds protocol https://www.envoyproxy.io/docs/envoy/latest/api-docs/xds_protocol#ack
+	// specifies that the first request be empty nonce and empty version.
+	// The origin server will respond with the latest version.
+	response, resubscribe := m.startStream(ctx, cancel, &request, stream, &version{nonce: "", version: ""})
+
+	// We use context cancellation over using a separate channel for signalling stream shutdown.
+	// The reason is cancelling a context tied with the stream is straightforward to signal closure.
+	// Also, the shutdown function could potentially be called more than once by a caller.
+	// Closing channels is not idempotent while cancelling context is idempotent.
+	return response, resubscribe, func() { cancel() }, nil
+}
+
+// newStream opens a gRPC stream of the type URL with the origin server.
+func (m *client) newStream(ctx context.Context, typeURL string) (grpc.ClientStream, error) {
 	var stream grpc.ClientStream
 	var err error
-	switch request.GetTypeUrl() {
+	switch typeURL {
 	case ListenerTypeURL:
-		stream, err = m.ldsClient.StreamListeners(ctx)
+		if m.v3 != nil {
+			stream, err = m.v3.ldsClient.StreamListeners(ctx)
+		} else {
+			stream, err = m.ldsClient.StreamListeners(ctx)
+		}
 	case ClusterTypeURL:
-		stream, err = m.cdsClient.StreamClusters(ctx)
+		if m.v3 != nil {
+			stream, err = m.v3.cdsClient.StreamClusters(ctx)
+		} else {
+			stream, err = m.cdsClient.StreamClusters(ctx)
+		}
 	case RouteTypeURL:
-		stream, err = m.rdsClient.StreamRoutes(ctx)
+		if m.v3 != nil {
+			stream, err = m.v3.rdsClient.StreamRoutes(ctx)
+		} else {
+			stream, err = m.rdsClient.StreamRoutes(ctx)
+		}
 	case EndpointTypeURL:
-		stream, err = m.edsClient.StreamEndpoints(ctx)
+		if m.v3 != nil {
+			stream, err = m.v3.edsClient.StreamEndpoints(ctx)
+		} else {
+			stream, err = m.edsClient.StreamEndpoints(ctx)
+		}
+	case RuntimeTypeURL:
+		if m.v3 != nil {
+			stream, err = m.v3.rtdsClient.StreamRuntime(ctx)
+		} else {
+			stream, err = m.rtdsClient.StreamRuntime(ctx)
+		}
 	default:
-		defer cancel()
-		m.logger.Error(ctx, "Unsupported Type Url %s", request.GetTypeUrl())
-		return nil, nil, &UnsupportedResourceError{TypeURL: request.GetTypeUrl()}
+		m.logger.Error(ctx, "Unsupported Type Url %s", typeURL)
+		return nil, &UnsupportedResourceError{TypeURL: typeURL}
 	}
-
 	if err != nil {
-		defer cancel()
-		return nil, nil, err
+		return nil, fmt.Errorf("%w: %s", ErrUpstreamUnavailable, err.Error())
 	}
+	return stream, nil
+}
 
+// startStream starts the send and recv goroutines of the stream, whose first request carries the initial version
+// and nonce. It returns the response channel and the resubscribe function of the stream.
+func (m *client) startStream(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	request *v2.DiscoveryRequest,
+	stream grpc.ClientStream,
+	initial *version,
+) (<-chan *v2.DiscoveryResponse, func([]string)) {
 	signal := make(chan *version, 1)
-	// The xds protocol https://www.envoyproxy.io/docs/envoy/latest/api-docs/xds_protocol#ack
-	// specifies that the first request be empty nonce and empty version.
-	// The origin server will respond with the latest version.
-	signal <- &version{nonce: "", version: ""}
+	signal <- initial
 
 	response := make(chan *v2.DiscoveryResponse)
+	resourceNames := make(chan []string)
+	resubscribe := func(names []string) {
+		select {
+		case resourceNames <- names:
+		case <-ctx.Done():
+		}
+	}
 
-	go send(ctx, m.logger, cancel, &request, stream, signal, m.callOptions)
-	go recv(ctx, cancel, m.logger, response, stream, signal)
-
-	// We use context cancellation over using a separate channel for signalling stream shutdown.
-	// The reason is cancelling a context tied with the stream is straightforward to signal closure.
-	// Also, the shutdown function could potentially be called more than once by a caller.
-	// Closing channels is not idempotent while cancelling context is idempotent.
-	return response, func() { cancel() }, nil
+	go send(ctx, m.logger, cancel, request, stream, signal, resourceNames, m.callOptions)
+	go recv(ctx, cancel, m.logger, response, stream, signal, m.callOptions.APIVersion)
+	return response, resubscribe
 }
 
 // It is safe to assume send goroutine will not leak as long as these conditions are true:
@@ -157,6 +363,7 @@ func send(
 	request *v2.DiscoveryRequest,
 	stream grpc.ClientStream,
 	signal chan *version,
+	resourceNames <-chan []string,
 	callOptions CallOptions) {
 	for {
 		select {
@@ -166,37 +373,56 @@ func send(
 			}
 			request.ResponseNonce = sig.nonce
 			request.VersionInfo = sig.version
-			// Ref: https://github.com/grpc/grpc-go/issues/1229#issuecomment-302755717
-			// Call SendMsg in a timeout because it can block in some cases.
-			err := util.DoWithTimeout(ctx, func() error {
-				return stream.SendMsg(request)
-			}, callOptions.Timeout)
-			if err != nil {
-				handleError(ctx, logger, "Error in SendMsg", cancelFunc, err)
-				return
-			}
+		case names := <-resourceNames:
+			// The version and nonce of the latest response are kept.
+			request.ResourceNames = names
 		case <-ctx.Done():
 			_ = stream.CloseSend()
 			return
 		}
+		message := request
+		if callOptions.APIVersion != apiversion.V2 {
+			translated := *request
+			apiversion.Request(&translated, callOptions.APIVersion)
+			message = &translated
+		}
+		// Ref: https://github.com/grpc/grpc-go/issues/1229#issuecomment-302755717
+		// Call SendMsg in a timeout because it can block in some cases.
+		err := util.DoWithTimeout(ctx, func() error {
+			return stream.SendMsg(message)
+		}, callOptions.Timeout)
+		if err != nil {
+			handleError(ctx, logger, "Error in SendMsg", cancelFunc, err)
+			return
+		}
 	}
 }
 
 // recv is an infinite loop which blocks on RecvMsg.
 // The only ways to exit the goroutine is by cancelling the context or when an error occurs.
+// Responses of other API versions are translated to v2.
 func recv(
 	ctx context.Context,
 	cancelFunc context.CancelFunc,
 	logger log.Logger,
 	response chan *v2.DiscoveryResponse,
 	stream grpc.ClientStream,
-	signal chan *version) {
+	signal chan *version,
+	apiVersion apiversion.Version) {
 	for {
 		resp := new(v2.DiscoveryResponse)
 		if err := stream.RecvMsg(resp); err != nil {
 			handleError(ctx, logger, "Error in RecvMsg", cancelFunc, err)
 			break
 		}
+		if apiVersion != apiversion.V2 {
+			translated, err := apiversion.Response(resp, apiversion.V2)
+			if err != nil {
+				handleError(ctx, logger, "Error translating response", cancelFunc, err)
+				break
+			}
+			resp = translated
+		}
 		select {
 		case <-ctx.Done():
 			break
@@ -237,3 +463,8 @@ func shutDown(ctx context.Context, conn *grpc.ClientConn) {
 func (e *UnsupportedResourceError) Error() string {
 	return fmt.Sprintf("Unsupported resource typeUrl: %s", e.TypeURL)
 }
+
+// Unwrap returns ErrUnsupportedResource.
+func (e *UnsupportedResourceError) Unwrap() error {
+	return ErrUnsupportedResource
+}
@@ -9,6 +9,7 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	rtds "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
 	"github.com/envoyproxy/xds-relay/internal/pkg/log"
 )
 
@@ -19,12 +20,14 @@ func NewMockClient(
 	rdsClient v2.RouteDiscoveryServiceClient,
 	edsClient v2.EndpointDiscoveryServiceClient,
 	cdsClient v2.ClusterDiscoveryServiceClient,
+	rtdsClient rtds.RuntimeDiscoveryServiceClient,
 	callOptions CallOptions) Client {
 	return &client{
 		ldsClient:   ldsClient,
 		rdsClient:   rdsClient,
 		edsClient:   edsClient,
 		cdsClient:   cdsClient,
+		rtdsClient:  rtdsClient,
 		callOptions: callOptions,
 		logger:      log.New("panic"),
 	}
@@ -43,6 +46,7 @@ func NewMock(
 		createMockRdsClient(errorOnCreate, receiveChan, sendCb),
 		createMockEdsClient(errorOnCreate, receiveChan, sendCb),
 		createMockCdsClient(errorOnCreate, receiveChan, sendCb),
+		createMockRtdsClient(errorOnCreate, receiveChan, sendCb),
 		callOptions,
 	)
 }
@@ -75,6 +79,13 @@ func createMockEdsClient(
 	return &mockClient{errorOnStreamCreate: errorOnCreate, receiveChan: receiveChan, sendCb: sendCb}
 }
 
+func createMockRtdsClient(
+	errorOnCreate error,
+	receiveChan chan *v2.DiscoveryResponse,
+	sendCb func(m interface{}) error) rtds.RuntimeDiscoveryServiceClient {
+	return &mockClient{errorOnStreamCreate: errorOnCreate, receiveChan: receiveChan, sendCb: sendCb}
+}
+
 type mockClient struct {
 	errorOnStreamCreate error
 	receiveChan         chan *v2.DiscoveryResponse
@@ -168,6 +179,15 @@ func (c *mockClient) StreamEndpoints(
 	return &mockGrpcStream{ctx: ctx, receiveChan: c.receiveChan, sendCb: c.sendCb}, nil
 }
 
+func (c *mockClient) StreamRuntime(
+	ctx context.Context,
+	opts ...grpc.CallOption) (rtds.RuntimeDiscoveryService_StreamRuntimeClient, error) {
+	if c.errorOnStreamCreate != nil {
+		return nil, c.errorOnStreamCreate
+	}
+	return &mockGrpcStream{ctx: ctx, receiveChan: c.receiveChan, sendCb: c.sendCb}, nil
+}
+
 func (c *mockClient) DeltaListeners(
 	ctx context.Context,
 	opts ...grpc.CallOption) (v2.ListenerDiscoveryService_DeltaListenersClient, error) {
@@ -192,6 +212,12 @@ func (c *mockClient) DeltaEndpoints(
 	return nil, fmt.Errorf("Not implemented")
 }
 
+func (c *mockClient) DeltaRuntime(
+	ctx context.Context,
+	opts ...grpc.CallOption) (rtds.RuntimeDiscoveryService_DeltaRuntimeClient, error) {
+	return nil, fmt.Errorf("Not implemented")
+}
+
 func (c *mockClient) FetchListeners(
 	ctx context.Context,
 	in *v2.DiscoveryRequest,
@@ -219,3 +245,10 @@ func (c *mockClient) FetchEndpoints(
 	opts ...grpc.CallOption) (*v2.DiscoveryResponse, error) {
 	return nil, fmt.Errorf("Not implemented")
 }
+
+func (c *mockClient) FetchRuntime(
+	ctx context.Context,
+	in *v2.DiscoveryRequest,
+	opts ...grpc.CallOption) (*v2.DiscoveryResponse, error) {
+	return nil, fmt.Errorf("Not implemented")
+}
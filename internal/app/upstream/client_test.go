@@ -9,7 +9,12 @@ import (
 
 	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/xds-relay/internal/app/apiversion"
 	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,6 +38,7 @@ func TestOpenStreamShouldResturnErrorOnStreamCreationFailure(t *testing.T) {
 		upstream.ClusterTypeURL,
 		upstream.RouteTypeURL,
 		upstream.EndpointTypeURL,
+		upstream.RuntimeTypeURL,
 	}
 	for _, typeURL := range typeURLs {
 		t.Run(typeURL, func(t *testing.T) {
@@ -180,6 +186,82 @@ func TestOpenStreamShouldSendErrorWhenSendMsgBlocks(t *testing.T) {
 	cancel()
 }
 
+func TestOpenResubscribableStreamShouldSendUpdatedResourceNames(t *testing.T) {
+	responseChan := make(chan *v2.DiscoveryResponse)
+	requests := make(chan v2.DiscoveryRequest, 1)
+	client := createMockClientWithReponse(time.Second, responseChan, func(m interface{}) error {
+		requests <- *m.(*v2.DiscoveryRequest)
+		return nil
+	})
+
+	resp, resubscribe, done, err := client.(upstream.ResubscribableClient).OpenResubscribableStream(
+		v2.DiscoveryRequest{
+			TypeUrl:       upstream.EndpointTypeURL,
+			ResourceNames: []string{"a"},
+			Node:          &core.Node{},
+		})
+	assert.Nil(t, err)
+	defer done()
+	request := <-requests
+	assert.Equal(t, []string{"a"}, request.GetResourceNames())
+
+	responseChan <- &v2.DiscoveryResponse{VersionInfo: "1", Nonce: "2", TypeUrl: upstream.EndpointTypeURL}
+	<-resp
+	request = <-requests
+	assert.Equal(t, "1", request.GetVersionInfo())
+
+	// The resubscription carries the version and nonce of the latest response.
+	resubscribe([]string{"a", "b"})
+	request = <-requests
+	assert.Equal(t, []string{"a", "b"}, request.GetResourceNames())
+	assert.Equal(t, "1", request.GetVersionInfo())
+	assert.Equal(t, "2", request.GetResponseNonce())
+
+	// Resubscribing a closed stream does not block.
+	done()
+	resubscribe([]string{"a"})
+}
+
+func TestOpenStreamShouldTranslateV3Messages(t *testing.T) {
+	responseChan := make(chan *v2.DiscoveryResponse)
+	requests := make(chan v2.DiscoveryRequest, 1)
+	client := upstream.NewMock(
+		context.Background(),
+		CallOptions{Timeout: time.Second, APIVersion: apiversion.V3},
+		nil,
+		responseChan,
+		func(m interface{}) error {
+			requests <- *m.(*v2.DiscoveryRequest)
+			return nil
+		})
+
+	request := v2.DiscoveryRequest{TypeUrl: upstream.ClusterTypeURL, Node: &core.Node{}}
+	resp, done, err := client.OpenStream(request)
+	assert.Nil(t, err)
+	defer done()
+	request = <-requests
+	assert.Equal(t, resourcev3.ClusterType, request.GetTypeUrl())
+
+	resource, err := ptypes.MarshalAny(&clusterv3.Cluster{Name: "cluster"})
+	assert.Nil(t, err)
+	responseChan <- &v2.DiscoveryResponse{
+		VersionInfo: "1",
+		TypeUrl:     resourcev3.ClusterType,
+		Resources:   []*any.Any{resource},
+	}
+	response := <-resp
+	assert.Equal(t, "1", response.GetVersionInfo())
+	assert.Equal(t, upstream.ClusterTypeURL, response.GetTypeUrl())
+	var cluster v2.Cluster
+	assert.Nil(t, ptypes.UnmarshalAny(response.GetResources()[0], &cluster))
+	assert.Equal(t, "cluster", cluster.GetName())
+
+	// The following requests are translated too.
+	request = <-requests
+	assert.Equal(t, resourcev3.ClusterType, request.GetTypeUrl())
+	assert.Equal(t, "1", request.GetVersionInfo())
+}
+
 func createMockClient() upstream.Client {
 	return upstream.NewMock(
 		context.Background(),
@@ -0,0 +1,310 @@
+package upstream
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTokenScope is the OAuth scope of service account access tokens when none is configured.
+	DefaultTokenScope = "https://www.googleapis.com/auth/cloud-platform"
+	// DefaultMetadataServer is the host of the metadata server that workload identities are obtained from.
+	DefaultMetadataServer = "metadata.google.internal"
+
+	// tokenExpiryDelta is how long before their expiry tokens are refreshed, so that streams are not opened with
+	// tokens that expire in flight.
+	tokenExpiryDelta = time.Minute
+	// serviceAccountTokenLifetime is the lifetime of the assertions exchanged for service account tokens.
+	serviceAccountTokenLifetime = time.Hour
+	defaultTokenURI             = "https://oauth2.googleapis.com/token"
+	jwtBearerGrantType          = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	execCredentialAPIVersion    = "client.authentication.k8s.io/v1beta1"
+	maxTokenResponseSize        = 1 << 20
+)
+
+// credentialsHTTPClient fetches the tokens of service accounts and workload identities.
+var credentialsHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// refreshingTokenSource is a TokenSource that caches the token it fetches until shortly before it expires. Tokens
+// without an expiry are fetched again after DefaultTokenRefreshInterval.
+type refreshingTokenSource struct {
+	fetch func(ctx context.Context) (token string, expiry time.Time, err error)
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (s *refreshingTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if s.token != "" && now.Add(tokenExpiryDelta).Before(s.expiry) {
+		return s.token, nil
+	}
+	token, expiry, err := s.fetch(ctx)
+	if err != nil {
+		// The current token is used until it expires if it cannot be refreshed.
+		if s.token != "" && now.Before(s.expiry) {
+			return s.token, nil
+		}
+		return "", err
+	}
+	if token == "" {
+		return "", errors.New("empty token")
+	}
+	if expiry.IsZero() {
+		expiry = now.Add(DefaultTokenRefreshInterval + tokenExpiryDelta)
+	}
+	s.token, s.expiry = token, expiry
+	return s.token, nil
+}
+
+// serviceAccountKey is the JSON key file of a Google service account.
+type serviceAccountKey struct {
+	Type         string `json:"type"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// NewServiceAccountTokenSource returns the token source of the JSON key file of a Google service account. Its
+// tokens are OAuth access tokens of the scopes, DefaultTokenScope if none, unless the audience is set, in which case
+// they are OpenID Connect ID tokens for the audience.
+func NewServiceAccountTokenSource(keyFile []byte, scopes []string, audience string) (TokenSource, error) {
+	var key serviceAccountKey
+	if err := json.Unmarshal(keyFile, &key); err != nil {
+		return nil, fmt.Errorf("invalid service account key file: %w", err)
+	}
+	if key.Type != "service_account" {
+		return nil, fmt.Errorf("unexpected credentials type %q, expected service_account", key.Type)
+	}
+	privateKey, err := parseRSAPrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = defaultTokenURI
+	}
+	if len(scopes) == 0 {
+		scopes = []string{DefaultTokenScope}
+	}
+
+	return &refreshingTokenSource{fetch: func(ctx context.Context) (string, time.Time, error) {
+		now := time.Now()
+		claims := map[string]interface{}{
+			"iss": key.ClientEmail,
+			"aud": key.TokenURI,
+			"iat": now.Unix(),
+			"exp": now.Add(serviceAccountTokenLifetime).Unix(),
+		}
+		if audience != "" {
+			claims["target_audience"] = audience
+		} else {
+			claims["scope"] = strings.Join(scopes, " ")
+		}
+		assertion, err := signJWT(privateKey, key.PrivateKeyID, claims)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		form := url.Values{"grant_type": {jwtBearerGrantType}, "assertion": {assertion}}
+		request, err := http.NewRequestWithContext(ctx, http.MethodPost, key.TokenURI, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		body, err := doTokenRequest(request)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+
+		var response struct {
+			AccessToken string `json:"access_token"`
+			ExpiresIn   int64  `json:"expires_in"`
+			IDToken     string `json:"id_token"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return "", time.Time{}, fmt.Errorf("invalid token response: %w", err)
+		}
+		if audience != "" {
+			expiry, err := jwtExpiry(response.IDToken)
+			return response.IDToken, expiry, err
+		}
+		return response.AccessToken, now.Add(time.Duration(response.ExpiresIn) * time.Second), nil
+	}}, nil
+}
+
+// NewWorkloadIdentityTokenSource returns the token source of the default service account of the GCE instance or GKE
+// workload, obtained from its metadata server, DefaultMetadataServer if empty. Its tokens are OAuth access tokens
+// unless the audience is set, in which case they are OpenID Connect ID tokens for the audience.
+func NewWorkloadIdentityTokenSource(metadataServer string, audience string) TokenSource {
+	if metadataServer == "" {
+		metadataServer = DefaultMetadataServer
+	}
+	baseURL := "http://" + metadataServer + "/computeMetadata/v1/instance/service-accounts/default/"
+
+	return &refreshingTokenSource{fetch: func(ctx context.Context) (string, time.Time, error) {
+		now := time.Now()
+		tokenURL := baseURL + "token"
+		if audience != "" {
+			tokenURL = baseURL + "identity?" + url.Values{"audience": {audience}, "format": {"full"}}.Encode()
+		}
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		request.Header.Set("Metadata-Flavor", "Google")
+		body, err := doTokenRequest(request)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+
+		if audience != "" {
+			token := strings.TrimSpace(string(body))
+			expiry, err := jwtExpiry(token)
+			return token, expiry, err
+		}
+		var response struct {
+			AccessToken string `json:"access_token"`
+			ExpiresIn   int64  `json:"expires_in"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return "", time.Time{}, fmt.Errorf("invalid token response: %w", err)
+		}
+		return response.AccessToken, now.Add(time.Duration(response.ExpiresIn) * time.Second), nil
+	}}
+}
+
+// NewExecTokenSource returns the token source of a command that prints an ExecCredential, like the credential
+// plugins of Kubernetes client-go. The command runs with the environment of the relay and the extra variables, in
+// `KEY=value` form, and runs again when the token expires.
+func NewExecTokenSource(command string, args []string, env []string) TokenSource {
+	execInfo := fmt.Sprintf(`{"apiVersion":%q,"kind":"ExecCredential","spec":{}}`, execCredentialAPIVersion)
+
+	return &refreshingTokenSource{fetch: func(ctx context.Context) (string, time.Time, error) {
+		cmd := exec.CommandContext(ctx, command, args...)
+		cmd.Env = append(append(os.Environ(), env...), "KUBERNETES_EXEC_INFO="+execInfo)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		if err := cmd.Run(); err != nil {
+			return "", time.Time{}, fmt.Errorf("credential command %s failed: %w: %s", command, err,
+				strings.TrimSpace(stderr.String()))
+		}
+
+		var credential struct {
+			Kind   string `json:"kind"`
+			Status struct {
+				Token               string    `json:"token"`
+				ExpirationTimestamp time.Time `json:"expirationTimestamp"`
+			} `json:"status"`
+		}
+		if err := json.Unmarshal(stdout.Bytes(), &credential); err != nil {
+			return "", time.Time{}, fmt.Errorf("invalid output of credential command %s: %w", command, err)
+		}
+		if credential.Kind != "ExecCredential" {
+			return "", time.Time{}, fmt.Errorf("unexpected kind %q of credential command %s, expected ExecCredential",
+				credential.Kind, command)
+		}
+		return credential.Status.Token, credential.Status.ExpirationTimestamp, nil
+	}}
+}
+
+// doTokenRequest returns the body of the successful response to the token request.
+func doTokenRequest(request *http.Request) ([]byte, error) {
+	response, err := credentialsHTTPClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(&io.LimitedReader{R: response.Body, N: maxTokenResponseSize})
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token request to %s failed with status %d: %s", request.URL.Host,
+			response.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// parseRSAPrivateKey parses the PEM encoded PKCS #8 or PKCS #1 RSA private key.
+func parseRSAPrivateKey(key string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, errors.New("invalid service account private key: no PEM data")
+	}
+	if parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("invalid service account private key: not an RSA key")
+		}
+		return rsaKey, nil
+	}
+	rsaKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service account private key: %w", err)
+	}
+	return rsaKey, nil
+}
+
+// signJWT returns the JWT of the claims, signed with RS256.
+func signJWT(key *rsa.PrivateKey, keyID string, claims map[string]interface{}) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": keyID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// jwtExpiry returns the expiry of the JWT. Its signature is not verified, as the token comes straight from its
+// issuer.
+func jwtExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.New("invalid ID token: not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid ID token: %w", err)
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("invalid ID token: %w", err)
+	}
+	if claims.Exp == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(claims.Exp, 0), nil
+}
@@ -0,0 +1,160 @@
+package upstream_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/stretchr/testify/assert"
+)
+
+func newJWT(t *testing.T, claims map[string]interface{}) string {
+	payload, err := json.Marshal(claims)
+	assert.NoError(t, err)
+	return "e30." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+// verifyAssertion verifies the signature of the JWT assertion with the key and returns its claims.
+func verifyAssertion(t *testing.T, key *rsa.PrivateKey, assertion string) map[string]interface{} {
+	parts := strings.Split(assertion, ".")
+	assert.Len(t, parts, 3)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	assert.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	assert.NoError(t, err)
+	var claims map[string]interface{}
+	assert.NoError(t, json.Unmarshal(payload, &claims))
+	return claims
+}
+
+func TestServiceAccountTokenSource(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+
+	var requests int32
+	idToken := newJWT(t, map[string]interface{}{"exp": time.Now().Add(time.Hour).Unix()})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.Form.Get("grant_type"))
+		claims := verifyAssertion(t, key, r.Form.Get("assertion"))
+		assert.Equal(t, "relay@project.iam.gserviceaccount.com", claims["iss"])
+		if audience, ok := claims["target_audience"]; ok {
+			assert.Equal(t, "https://xds.example.com", audience)
+			fmt.Fprintf(w, `{"id_token": %q}`, idToken)
+			return
+		}
+		assert.Equal(t, upstream.DefaultTokenScope, claims["scope"])
+		fmt.Fprint(w, `{"access_token": "access", "expires_in": 3600}`)
+	}))
+	defer server.Close()
+
+	keyFile, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "relay@project.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":    server.URL,
+	})
+	assert.NoError(t, err)
+
+	source, err := upstream.NewServiceAccountTokenSource(keyFile, nil, "")
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		token, err := source.Token(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "access", token)
+	}
+	// Tokens are cached until shortly before they expire.
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	source, err = upstream.NewServiceAccountTokenSource(keyFile, nil, "https://xds.example.com")
+	assert.NoError(t, err)
+	token, err := source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, idToken, token)
+
+	_, err = upstream.NewServiceAccountTokenSource([]byte(`{"type": "authorized_user"}`), nil, "")
+	assert.Error(t, err)
+	_, err = upstream.NewServiceAccountTokenSource([]byte(`{"type": "service_account"}`), nil, "")
+	assert.Error(t, err)
+}
+
+func TestWorkloadIdentityTokenSource(t *testing.T) {
+	var requests int32
+	idToken := newJWT(t, map[string]interface{}{"exp": time.Now().Add(time.Hour).Unix()})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/computeMetadata/v1/instance/service-accounts/default/token":
+			// Tokens that expire within a minute are fetched again.
+			fmt.Fprint(w, `{"access_token": "access", "expires_in": 30}`)
+		case "/computeMetadata/v1/instance/service-accounts/default/identity":
+			assert.Equal(t, "https://xds.example.com", r.URL.Query().Get("audience"))
+			fmt.Fprint(w, idToken)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	source := upstream.NewWorkloadIdentityTokenSource(host, "")
+	for i := 0; i < 2; i++ {
+		token, err := source.Token(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "access", token)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	token, err := upstream.NewWorkloadIdentityTokenSource(host, "https://xds.example.com").Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, idToken, token)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	// The current token is used until it expires if it cannot be refreshed.
+	server.Close()
+	token, err = source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "access", token)
+	_, err = upstream.NewWorkloadIdentityTokenSource(host, "").Token(context.Background())
+	assert.Error(t, err)
+}
+
+func TestExecTokenSource(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	source := upstream.NewExecTokenSource("sh", []string{"-c", `printf '{"kind": "ExecCredential", ` +
+		`"status": {"token": "%s", "expirationTimestamp": "` + expiry + `"}}' "$TOKEN"`}, []string{"TOKEN=exec"})
+	token, err := source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "exec", token)
+
+	_, err = upstream.NewExecTokenSource("sh", []string{"-c", "echo denied >&2; exit 1"}, nil).
+		Token(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "denied")
+	_, err = upstream.NewExecTokenSource("sh", []string{"-c", `echo '{"kind": "Config"}'`}, nil).
+		Token(context.Background())
+	assert.Error(t, err)
+}
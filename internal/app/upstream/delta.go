@@ -0,0 +1,77 @@
+package upstream
+
+import (
+	"context"
+	"fmt"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/envoyproxy/xds-relay/internal/app/apiversion"
+	"google.golang.org/grpc"
+)
+
+// DeltaClient is a Client that opens incremental xDS streams with the origin server, for the types that are only
+// served incrementally, such as virtual hosts.
+type DeltaClient interface {
+	Client
+
+	// OpenDeltaStream opens an incremental stream of the type URL with the origin server, on behalf of the node. The
+	// stream is closed when ctx is done.
+	OpenDeltaStream(ctx context.Context, node *core.Node, typeURL string) (DeltaStream, error)
+}
+
+// DeltaStream is an incremental xDS stream with the origin server. Its requests and responses are translated to and
+// from the API version of the origin server. Send must not be called concurrently.
+type DeltaStream interface {
+	Send(*v2.DeltaDiscoveryRequest) error
+	Recv() (*v2.DeltaDiscoveryResponse, error)
+}
+
+func (m *client) OpenDeltaStream(ctx context.Context, node *core.Node, typeURL string) (DeltaStream, error) {
+	ctx, err := withMetadata(ctx, m.callOptions.Metadata, &v2.DiscoveryRequest{Node: node, TypeUrl: typeURL}, "")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUpstreamUnavailable, err.Error())
+	}
+	var stream grpc.ClientStream
+	switch typeURL {
+	case VirtualHostTypeURL:
+		if m.v3 != nil {
+			stream, err = m.v3.vhdsClient.DeltaVirtualHosts(ctx)
+		} else {
+			stream, err = m.vhdsClient.DeltaVirtualHosts(ctx)
+		}
+	default:
+		return nil, &UnsupportedResourceError{TypeURL: typeURL}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUpstreamUnavailable, err.Error())
+	}
+	return &deltaStream{stream: stream, apiVersion: m.callOptions.APIVersion}, nil
+}
+
+// deltaStream sends and receives the v2 messages of an incremental stream as is, since they are wire compatible with
+// the v3 messages, with their type URLs translated.
+type deltaStream struct {
+	stream     grpc.ClientStream
+	apiVersion apiversion.Version
+}
+
+func (s *deltaStream) Send(req *v2.DeltaDiscoveryRequest) error {
+	if s.apiVersion != apiversion.V2 {
+		translated := *req
+		apiversion.DeltaRequest(&translated, s.apiVersion)
+		req = &translated
+	}
+	return s.stream.SendMsg(req)
+}
+
+func (s *deltaStream) Recv() (*v2.DeltaDiscoveryResponse, error) {
+	resp := &v2.DeltaDiscoveryResponse{}
+	if err := s.stream.RecvMsg(resp); err != nil {
+		return nil, err
+	}
+	if s.apiVersion == apiversion.V2 {
+		return resp, nil
+	}
+	return apiversion.DeltaResponse(resp, apiversion.V2)
+}
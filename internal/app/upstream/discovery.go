@@ -0,0 +1,378 @@
+package upstream
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/resolver"
+)
+
+const (
+	// PickFirst opens every stream to the first endpoint that accepts connections.
+	PickFirst = "pick_first"
+	// RoundRobin opens streams to the endpoints in turn.
+	RoundRobin = "round_robin"
+
+	// DefaultRefreshInterval is the interval between resolutions of Discovery when unset.
+	DefaultRefreshInterval = 30 * time.Second
+
+	dnsScheme = "xds-relay-dns"
+	// minResolveInterval rate limits the resolutions that gRPC requests when connections fail.
+	minResolveInterval = time.Second
+	// migrationRetryInterval is the time after which a stream that failed to migrate tries again.
+	migrationRetryInterval = 100 * time.Millisecond
+)
+
+// Discovery discovers the endpoints of the origin server by resolving its hostname periodically. Streams to
+// endpoints that are no longer resolved migrate to the remaining ones.
+type Discovery struct {
+	// LoadBalancingPolicy is the gRPC load balancing policy across the endpoints, PickFirst or RoundRobin.
+	LoadBalancingPolicy string
+	// RefreshInterval is the interval between resolutions. DefaultRefreshInterval is used if it is zero.
+	RefreshInterval time.Duration
+	// LookupHost resolves the hostname into addresses. net.DefaultResolver is used if it is nil.
+	LookupHost func(ctx context.Context, host string) ([]string, error)
+}
+
+// dialOptions returns the options of the gRPC client that resolves its target with the discovery, and records the
+// resolved endpoints in the endpoint set.
+func (d *Discovery) dialOptions(endpoints *endpointSet) []grpc.DialOption {
+	builder := &dnsBuilder{
+		interval:   d.RefreshInterval,
+		lookupHost: d.LookupHost,
+		onUpdate:   endpoints.update,
+	}
+	if builder.interval <= 0 {
+		builder.interval = DefaultRefreshInterval
+	}
+	if builder.lookupHost == nil {
+		builder.lookupHost = net.DefaultResolver.LookupHost
+	}
+	policy := d.LoadBalancingPolicy
+	if policy == "" {
+		policy = PickFirst
+	}
+	return []grpc.DialOption{
+		grpc.WithResolvers(builder),
+		grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingPolicy":%q}`, policy)),
+	}
+}
+
+// dnsBuilder builds resolvers that resolve the hostname of their target periodically.
+type dnsBuilder struct {
+	interval   time.Duration
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+	onUpdate   func(addresses []string)
+}
+
+func (b *dnsBuilder) Build(target resolver.Target, cc resolver.ClientConn,
+	opts resolver.BuildOptions) (resolver.Resolver, error) {
+	host, port, err := net.SplitHostPort(target.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &dnsResolver{
+		builder:    b,
+		host:       host,
+		port:       port,
+		cc:         cc,
+		ctx:        ctx,
+		cancel:     cancel,
+		resolveNow: make(chan struct{}, 1),
+	}
+	go r.watch()
+	return r, nil
+}
+
+func (b *dnsBuilder) Scheme() string {
+	return dnsScheme
+}
+
+// dnsResolver resolves the hostname of its target periodically, and whenever gRPC requests it.
+type dnsResolver struct {
+	builder    *dnsBuilder
+	host       string
+	port       string
+	cc         resolver.ClientConn
+	ctx        context.Context
+	cancel     context.CancelFunc
+	resolveNow chan struct{}
+}
+
+func (r *dnsResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+func (r *dnsResolver) Close() {
+	r.cancel()
+}
+
+func (r *dnsResolver) watch() {
+	ticker := time.NewTicker(r.builder.interval)
+	defer ticker.Stop()
+	for {
+		r.resolve()
+		select {
+		case <-time.After(minResolveInterval):
+		case <-r.ctx.Done():
+			return
+		}
+		select {
+		case <-ticker.C:
+		case <-r.resolveNow:
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *dnsResolver) resolve() {
+	hosts, err := r.builder.lookupHost(r.ctx, r.host)
+	if err == nil && len(hosts) == 0 {
+		err = fmt.Errorf("no addresses for %s", r.host)
+	}
+	if err != nil {
+		r.cc.ReportError(err)
+		return
+	}
+	addresses := make([]string, 0, len(hosts))
+	state := resolver.State{Addresses: make([]resolver.Address, 0, len(hosts))}
+	for _, host := range hosts {
+		address := net.JoinHostPort(host, r.port)
+		addresses = append(addresses, address)
+		state.Addresses = append(state.Addresses, resolver.Address{Addr: address})
+	}
+	r.cc.UpdateState(state)
+	r.builder.onUpdate(addresses)
+}
+
+// endpointSet holds the resolved endpoints of the origin server, and the streams that migrate when their endpoint
+// is no longer resolved.
+type endpointSet struct {
+	mu        sync.Mutex
+	addresses map[string]bool
+	streams   map[*migratingStream]bool
+}
+
+func newEndpointSet() *endpointSet {
+	return &endpointSet{
+		addresses: make(map[string]bool),
+		streams:   make(map[*migratingStream]bool),
+	}
+}
+
+// update replaces the resolved endpoints, and migrates the streams to endpoints that are no longer resolved.
+func (e *endpointSet) update(addresses []string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.addresses = make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		e.addresses[address] = true
+	}
+	for s := range e.streams {
+		if !e.addresses[s.getPeer()] {
+			s.requestMigration()
+		}
+	}
+}
+
+// contains returns true if the endpoint is resolved.
+func (e *endpointSet) contains(address string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.addresses[address]
+}
+
+// list returns the resolved endpoints, sorted.
+func (e *endpointSet) list() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	addresses := make([]string, 0, len(e.addresses))
+	for address := range e.addresses {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+func (e *endpointSet) add(s *migratingStream) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.streams[s] = true
+}
+
+func (e *endpointSet) remove(s *migratingStream) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.streams, s)
+}
+
+// streamPeer returns the address of the endpoint that the stream is open to.
+func streamPeer(stream grpc.ClientStream) string {
+	if p, ok := peer.FromContext(stream.Context()); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// migratingStream is a stream with the origin server that moves to another endpoint when its endpoint is no longer
+// resolved. Its responses are forwarded from the gRPC stream to the current endpoint, which is replaced on
+// migration by a stream whose first request carries the version of the last response.
+type migratingStream struct {
+	client *client
+	ctx    context.Context
+	cancel context.CancelFunc
+	// request carries the resource names of the latest subscription. It is only accessed by forward.
+	request    v2.DiscoveryRequest
+	migrations chan struct{}
+
+	mu   sync.Mutex
+	peer string
+}
+
+// innerStream is the gRPC stream to an endpoint of a migratingStream.
+type innerStream struct {
+	response    <-chan *v2.DiscoveryResponse
+	resubscribe func([]string)
+	cancel      context.CancelFunc
+}
+
+// startMigratingStream starts the migrating stream of the gRPC stream, and returns its response channel and
+// resubscribe function. Both are closed or stop once ctx is done.
+func (m *client) startMigratingStream(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	request v2.DiscoveryRequest,
+	stream grpc.ClientStream,
+) (<-chan *v2.DiscoveryResponse, func([]string)) {
+	s := &migratingStream{
+		client:     m,
+		ctx:        ctx,
+		cancel:     cancel,
+		request:    request,
+		migrations: make(chan struct{}, 1),
+	}
+	streamCtx, streamCancel := context.WithCancel(ctx)
+	inner := s.start(streamCtx, streamCancel, stream, &version{})
+
+	response := make(chan *v2.DiscoveryResponse)
+	resourceNames := make(chan []string)
+	m.endpoints.add(s)
+	go s.forward(inner, response, resourceNames)
+	return response, func(names []string) {
+		select {
+		case resourceNames <- names:
+		case <-ctx.Done():
+		}
+	}
+}
+
+func (s *migratingStream) getPeer() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.peer
+}
+
+// requestMigration migrates the stream, unless a migration is already pending.
+func (s *migratingStream) requestMigration() {
+	select {
+	case s.migrations <- struct{}{}:
+	default:
+	}
+}
+
+// start starts the gRPC stream with a copy of the request, so that the streams of the migrating stream do not share
+// it.
+func (s *migratingStream) start(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	stream grpc.ClientStream,
+	initial *version,
+) innerStream {
+	s.mu.Lock()
+	s.peer = streamPeer(stream)
+	s.mu.Unlock()
+	request := s.request
+	response, resubscribe := s.client.startStream(ctx, cancel, &request, stream, initial)
+	return innerStream{response: response, resubscribe: resubscribe, cancel: cancel}
+}
+
+// forward forwards the responses of the current gRPC stream until the migrating stream is shut down or the gRPC
+// stream fails, and migrates it on request.
+func (s *migratingStream) forward(
+	inner innerStream,
+	response chan<- *v2.DiscoveryResponse,
+	resourceNames <-chan []string,
+) {
+	defer func() {
+		s.client.endpoints.remove(s)
+		s.cancel()
+		go drain(inner.response)
+		close(response)
+	}()
+	var lastVersion string
+	for {
+		select {
+		case resp, ok := <-inner.response:
+			if !ok {
+				return
+			}
+			lastVersion = resp.GetVersionInfo()
+			select {
+			case response <- resp:
+			case <-s.ctx.Done():
+				return
+			}
+		case names := <-resourceNames:
+			s.request.ResourceNames = names
+			inner.resubscribe(names)
+		case <-s.migrations:
+			if migrated, ok := s.migrate(lastVersion); ok {
+				inner.cancel()
+				go drain(inner.response)
+				inner = migrated
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// migrate opens a gRPC stream to a resolved endpoint, whose first request carries the version of the last
+// response. It tries again later if the stream cannot be opened, or gRPC has yet to stop picking the endpoints that
+// are no longer resolved.
+func (s *migratingStream) migrate(lastVersion string) (innerStream, bool) {
+	from := s.getPeer()
+	ctx, cancel := context.WithCancel(s.ctx)
+	stream, err := s.client.newStream(ctx, s.request.GetTypeUrl())
+	if err == nil && !s.client.endpoints.contains(streamPeer(stream)) {
+		err = fmt.Errorf("endpoint %s is no longer resolved", streamPeer(stream))
+	}
+	if err != nil {
+		cancel()
+		s.client.logger.With("error", err).With("from", from).Debug(ctx, "upstream stream migration delayed")
+		time.AfterFunc(migrationRetryInterval, s.requestMigration)
+		return innerStream{}, false
+	}
+	inner := s.start(ctx, cancel, stream, &version{version: lastVersion})
+	s.client.logger.With("type", s.request.GetTypeUrl()).With("from", from).With("to", s.getPeer()).
+		With("endpoints", s.client.endpoints.list()).Info(ctx, "upstream stream migrated")
+	return inner, true
+}
+
+// drain reads the channel until it is closed, so that its sender does not block.
+func drain(response <-chan *v2.DiscoveryResponse) {
+	for range response {
+	}
+}
@@ -0,0 +1,98 @@
+package upstream_test
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// mockListenerServer responds to the first request of every LDS stream with its version, and records the version
+// of the first request.
+type mockListenerServer struct {
+	v2.UnimplementedListenerDiscoveryServiceServer
+	version  string
+	requests chan string
+}
+
+func (s *mockListenerServer) StreamListeners(stream v2.ListenerDiscoveryService_StreamListenersServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	s.requests <- req.GetVersionInfo()
+	if err := stream.Send(&v2.DiscoveryResponse{VersionInfo: s.version, TypeUrl: upstream.ListenerTypeURL}); err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+	}
+}
+
+func serveListeners(t *testing.T, address string, server *mockListenerServer) func() {
+	listener, err := net.Listen("tcp", address)
+	assert.NoError(t, err)
+	s := grpc.NewServer()
+	v2.RegisterListenerDiscoveryServiceServer(s, server)
+	go func() { _ = s.Serve(listener) }()
+	return s.Stop
+}
+
+func TestDiscovery_Migration(t *testing.T) {
+	// The endpoints share a port and differ by loopback address.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	port := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+	assert.NoError(t, listener.Close())
+	first := &mockListenerServer{version: "1", requests: make(chan string, 1)}
+	defer serveListeners(t, net.JoinHostPort("127.0.0.1", port), first)()
+	second := &mockListenerServer{version: "2", requests: make(chan string, 1)}
+	defer serveListeners(t, net.JoinHostPort("127.0.0.2", port), second)()
+
+	var mu sync.Mutex
+	hosts := []string{"127.0.0.1"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := upstream.New(ctx, net.JoinHostPort("origin.example", port), upstream.CallOptions{
+		Timeout: time.Second,
+		Discovery: &upstream.Discovery{
+			LoadBalancingPolicy: upstream.RoundRobin,
+			RefreshInterval:     10 * time.Millisecond,
+			LookupHost: func(ctx context.Context, host string) ([]string, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				return hosts, nil
+			},
+		},
+	}, log.New("panic"))
+	assert.NoError(t, err)
+
+	respCh, shutdown, err := client.OpenStream(v2.DiscoveryRequest{TypeUrl: upstream.ListenerTypeURL})
+	assert.NoError(t, err)
+	defer shutdown()
+	assert.Equal(t, "", <-first.requests)
+	assert.Equal(t, "1", (<-respCh).GetVersionInfo())
+
+	// The stream migrates to the remaining endpoint once the first one is no longer resolved, and resumes from the
+	// version of its last response.
+	mu.Lock()
+	hosts = []string{"127.0.0.2"}
+	mu.Unlock()
+	select {
+	case version := <-second.requests:
+		assert.Equal(t, "1", version)
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream did not migrate")
+	}
+	assert.Equal(t, "2", (<-respCh).GetVersionInfo())
+}
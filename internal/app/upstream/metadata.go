@@ -0,0 +1,169 @@
+package upstream
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"google.golang.org/grpc/metadata"
+)
+
+// DefaultTokenRefreshInterval is the interval after which a FileTokenSource reads its file again when unset.
+const DefaultTokenRefreshInterval = time.Minute
+
+// Metadata is a gRPC metadata header attached to every upstream stream.
+type Metadata struct {
+	Key   string
+	Value MetadataValue
+}
+
+// StreamInfo describes an upstream stream to the values of its metadata.
+type StreamInfo struct {
+	// NodeID and NodeCluster are those of the node of the request that opened the stream.
+	NodeID      string
+	NodeCluster string
+	TypeURL     string
+	// RequestID is the request ID of the downstream request that opened the stream, if any.
+	RequestID string
+	// Hostname is the host name of the relay.
+	Hostname string
+}
+
+// MetadataValue returns the value of a metadata header of an upstream stream.
+type MetadataValue interface {
+	Value(ctx context.Context, info StreamInfo) (string, error)
+}
+
+// StaticValue is a metadata value that is the same for every stream.
+type StaticValue string
+
+// Value returns the static value.
+func (v StaticValue) Value(context.Context, StreamInfo) (string, error) {
+	return string(v), nil
+}
+
+// templateValue is a metadata value executed from a template for every stream.
+type templateValue struct {
+	template *template.Template
+}
+
+// NewTemplateValue returns the metadata value of the Go text/template, which is executed with the StreamInfo of
+// every stream.
+func NewTemplateValue(text string) (MetadataValue, error) {
+	t, err := template.New("metadata").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &templateValue{template: t}, nil
+}
+
+func (v *templateValue) Value(_ context.Context, info StreamInfo) (string, error) {
+	var b bytes.Buffer
+	if err := v.template.Execute(&b, info); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// TokenSource returns the current token of credentials that rotate, such as OAuth or JWT credentials. It is called
+// for every new stream, so implementations cache tokens until they need refreshing.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenValue is a metadata value that is the token of the source, preceded by the prefix, e.g. `Bearer `.
+type TokenValue struct {
+	Source TokenSource
+	Prefix string
+}
+
+// Value returns the current token of the source.
+func (v *TokenValue) Value(ctx context.Context, _ StreamInfo) (string, error) {
+	token, err := v.Source.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	return v.Prefix + token, nil
+}
+
+// FileTokenSource is a TokenSource that reads the token from a file, and reads it again once the refresh interval
+// has elapsed, so that tokens rotated by another process are picked up.
+type FileTokenSource struct {
+	path            string
+	refreshInterval time.Duration
+
+	mu     sync.Mutex
+	token  string
+	readAt time.Time
+}
+
+// NewFileTokenSource returns the token source of the file. DefaultTokenRefreshInterval is used if the refresh
+// interval is zero.
+func NewFileTokenSource(path string, refreshInterval time.Duration) *FileTokenSource {
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultTokenRefreshInterval
+	}
+	return &FileTokenSource{path: path, refreshInterval: refreshInterval}
+}
+
+// Token returns the token of the file, without trailing newlines. The last token read is returned if the file
+// cannot be read again.
+func (s *FileTokenSource) Token(context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if !s.readAt.IsZero() && now.Sub(s.readAt) < s.refreshInterval {
+		return s.token, nil
+	}
+	b, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		if s.readAt.IsZero() {
+			return "", err
+		}
+		return s.token, nil
+	}
+	s.token, s.readAt = strings.TrimRight(string(b), "\r\n"), now
+	return s.token, nil
+}
+
+// hostname is the host name of the relay in the StreamInfo of its streams.
+var hostname, _ = os.Hostname()
+
+// withMetadata returns ctx with the metadata of the stream of the request appended to its outgoing metadata.
+func withMetadata(
+	ctx context.Context,
+	headers []Metadata,
+	request *v2.DiscoveryRequest,
+	requestID string,
+) (context.Context, error) {
+	return AppendMetadata(ctx, headers, StreamInfo{
+		NodeID:      request.GetNode().GetId(),
+		NodeCluster: request.GetNode().GetCluster(),
+		TypeURL:     request.GetTypeUrl(),
+		RequestID:   requestID,
+		Hostname:    hostname,
+	})
+}
+
+// AppendMetadata returns ctx with the metadata of the stream that info describes appended to its outgoing metadata.
+func AppendMetadata(ctx context.Context, headers []Metadata, info StreamInfo) (context.Context, error) {
+	if len(headers) == 0 {
+		return ctx, nil
+	}
+	kv := make([]string, 0, 2*len(headers))
+	for _, header := range headers {
+		value, err := header.Value.Value(ctx, info)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the value of metadata %s: %w", header.Key, err)
+		}
+		kv = append(kv, header.Key, value)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...), nil
+}
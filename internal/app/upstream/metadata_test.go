@@ -0,0 +1,131 @@
+package upstream_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// metadataListenerServer records the metadata of every LDS stream.
+type metadataListenerServer struct {
+	v2.UnimplementedListenerDiscoveryServiceServer
+	metadata chan metadata.MD
+}
+
+func (s *metadataListenerServer) StreamListeners(stream v2.ListenerDiscoveryService_StreamListenersServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	s.metadata <- md
+	<-stream.Context().Done()
+	return nil
+}
+
+type errorTokenSource struct{}
+
+func (errorTokenSource) Token(context.Context) (string, error) {
+	return "", errors.New("token unavailable")
+}
+
+func TestMetadata(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := &metadataListenerServer{metadata: make(chan metadata.MD, 1)}
+	s := grpc.NewServer()
+	v2.RegisterListenerDiscoveryServiceServer(s, server)
+	go func() { _ = s.Serve(listener) }()
+	defer s.Stop()
+
+	template, err := upstream.NewTemplateValue("{{.NodeCluster}}/{{.NodeID}}")
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := upstream.New(ctx, listener.Addr().String(), upstream.CallOptions{
+		Timeout: time.Second,
+		Metadata: []upstream.Metadata{
+			{Key: "x-tenant", Value: upstream.StaticValue("tenant")},
+			{Key: "x-node", Value: template},
+		},
+	}, log.New("panic"))
+	assert.NoError(t, err)
+
+	_, _, shutdown, err := client.(upstream.TracedClient).OpenTracedStream(v2.DiscoveryRequest{
+		TypeUrl: upstream.ListenerTypeURL,
+		Node:    &core.Node{Id: "node", Cluster: "cluster"},
+	}, "request")
+	assert.NoError(t, err)
+	defer shutdown()
+	md := <-server.metadata
+	assert.Equal(t, []string{"tenant"}, md.Get("x-tenant"))
+	assert.Equal(t, []string{"cluster/node"}, md.Get("x-node"))
+	assert.Equal(t, []string{"request"}, md.Get(upstream.RequestIDHeader))
+
+	// Streams are not opened without the value of their metadata.
+	client, err = upstream.New(ctx, listener.Addr().String(), upstream.CallOptions{
+		Timeout:  time.Second,
+		Metadata: []upstream.Metadata{{Key: "authorization", Value: &upstream.TokenValue{Source: errorTokenSource{}}}},
+	}, log.New("panic"))
+	assert.NoError(t, err)
+	_, _, err = client.OpenStream(v2.DiscoveryRequest{TypeUrl: upstream.ListenerTypeURL})
+	assert.True(t, errors.Is(err, upstream.ErrUpstreamUnavailable))
+}
+
+func TestTemplateValue(t *testing.T) {
+	value, err := upstream.NewTemplateValue("{{.Hostname}}:{{.TypeURL}}:{{.RequestID}}")
+	assert.NoError(t, err)
+	got, err := value.Value(context.Background(), upstream.StreamInfo{
+		TypeURL:   upstream.ClusterTypeURL,
+		RequestID: "request",
+		Hostname:  "relay",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "relay:"+upstream.ClusterTypeURL+":request", got)
+
+	_, err = upstream.NewTemplateValue("{{.NodeID")
+	assert.Error(t, err)
+	value, err = upstream.NewTemplateValue("{{.Unknown}}")
+	assert.NoError(t, err)
+	_, err = value.Value(context.Background(), upstream.StreamInfo{})
+	assert.Error(t, err)
+}
+
+func TestFileTokenSource(t *testing.T) {
+	file, err := ioutil.TempFile("", "token")
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+	assert.NoError(t, ioutil.WriteFile(file.Name(), []byte("first\n"), 0600))
+
+	value := &upstream.TokenValue{Source: upstream.NewFileTokenSource(file.Name(), time.Hour), Prefix: "Bearer "}
+	got, err := value.Value(context.Background(), upstream.StreamInfo{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer first", got)
+
+	// The token is cached until the refresh interval elapses.
+	assert.NoError(t, ioutil.WriteFile(file.Name(), []byte("second\n"), 0600))
+	got, err = value.Value(context.Background(), upstream.StreamInfo{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer first", got)
+
+	source := upstream.NewFileTokenSource(file.Name(), time.Nanosecond)
+	token, err := source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "second", token)
+
+	// The last token is kept if the file cannot be read again.
+	assert.NoError(t, os.Remove(file.Name()))
+	token, err = source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "second", token)
+	_, err = upstream.NewFileTokenSource(file.Name(), time.Hour).Token(context.Background())
+	assert.Error(t, err)
+}
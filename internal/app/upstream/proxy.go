@@ -0,0 +1,238 @@
+package upstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ProxyType is the protocol of a proxy.
+type ProxyType int
+
+const (
+	// ProxyHTTPConnect tunnels connections through an HTTP proxy with the CONNECT method.
+	ProxyHTTPConnect ProxyType = iota
+	// ProxySOCKS5 tunnels connections through a SOCKS5 proxy.
+	ProxySOCKS5
+)
+
+const (
+	socksVersion          = 5
+	socksAuthNone         = 0
+	socksAuthPassword     = 2
+	socksAuthNoAcceptable = 0xff
+	socksAuthVersion      = 1
+	socksCommandConnect   = 1
+	socksAddressIPv4      = 1
+	socksAddressDomain    = 3
+	socksAddressIPv6      = 4
+)
+
+// Proxy is a proxy that connections to the origin server egress through.
+type Proxy struct {
+	Type ProxyType
+	// Address is the host and port of the proxy.
+	Address string
+	// Username authenticates to the proxy along with Password. The relay does not authenticate if it is empty.
+	Username string
+	Password string
+}
+
+// Dial connects to the address through the proxy. The handshake with the proxy is bounded by the deadline of ctx.
+func (p *Proxy) Dial(ctx context.Context, address string) (net.Conn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", p.Address)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	var tunnel net.Conn
+	switch p.Type {
+	case ProxySOCKS5:
+		tunnel, err = p.socks5Connect(conn, address)
+	default:
+		tunnel, err = p.httpConnect(conn, address)
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to connect to %s through proxy %s: %w", address, p.Address, err)
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return &tunnelConn{Conn: tunnel, remote: tunnelAddr(address)}, nil
+}
+
+// tunnelConn is a connection tunneled through a proxy, whose remote address is the address at the other end of the
+// tunnel rather than the proxy.
+type tunnelConn struct {
+	net.Conn
+	remote net.Addr
+}
+
+func (c *tunnelConn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+// tunnelAddr is the TCP address at the other end of a tunnel.
+type tunnelAddr string
+
+func (a tunnelAddr) Network() string {
+	return "tcp"
+}
+
+func (a tunnelAddr) String() string {
+	return string(a)
+}
+
+// bufferedConn is a connection whose first bytes were read into a buffer.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// httpConnect tunnels the connection to the HTTP proxy to the address.
+func (p *Proxy) httpConnect(conn net.Conn, address string) (net.Conn, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Host: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if p.Username != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(p.Username + ":" + p.Password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("proxy responded %s", resp.Status)
+	}
+	return &bufferedConn{Conn: conn, r: r}, nil
+}
+
+// socks5Connect tunnels the connection to the SOCKS5 proxy to the address, per RFC 1928 and RFC 1929.
+func (p *Proxy) socks5Connect(conn net.Conn, address string) (net.Conn, error) {
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.ParseUint(portString, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %s", portString)
+	}
+
+	methods := []byte{socksAuthNone}
+	if p.Username != "" {
+		methods = []byte{socksAuthPassword}
+	}
+	if _, err := conn.Write(append([]byte{socksVersion, byte(len(methods))}, methods...)); err != nil {
+		return nil, err
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return nil, err
+	}
+	if reply[0] != socksVersion {
+		return nil, fmt.Errorf("unexpected SOCKS version %d", reply[0])
+	}
+	switch reply[1] {
+	case socksAuthNone:
+	case socksAuthPassword:
+		if err := p.socks5Authenticate(conn); err != nil {
+			return nil, err
+		}
+	case socksAuthNoAcceptable:
+		return nil, errors.New("no acceptable SOCKS authentication method")
+	default:
+		return nil, fmt.Errorf("unexpected SOCKS authentication method %d", reply[1])
+	}
+
+	req := []byte{socksVersion, socksCommandConnect, 0}
+	if ip := net.ParseIP(host); ip == nil {
+		if len(host) > 255 {
+			return nil, fmt.Errorf("host %s is too long", host)
+		}
+		req = append(req, socksAddressDomain, byte(len(host)))
+		req = append(req, host...)
+	} else if ip4 := ip.To4(); ip4 != nil {
+		req = append(req, socksAddressIPv4)
+		req = append(req, ip4...)
+	} else {
+		req = append(req, socksAddressIPv6)
+		req = append(req, ip.To16()...)
+	}
+	req = append(req, byte(port>>8), byte(port))
+	if _, err := conn.Write(req); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, err
+	}
+	if header[1] != 0 {
+		return nil, fmt.Errorf("SOCKS connect failed with reply %d", header[1])
+	}
+	// Skip the address that the proxy bound.
+	var length int
+	switch header[3] {
+	case socksAddressIPv4:
+		length = net.IPv4len
+	case socksAddressIPv6:
+		length = net.IPv6len
+	case socksAddressDomain:
+		size := make([]byte, 1)
+		if _, err := io.ReadFull(conn, size); err != nil {
+			return nil, err
+		}
+		length = int(size[0])
+	default:
+		return nil, fmt.Errorf("unexpected SOCKS address type %d", header[3])
+	}
+	if _, err := io.ReadFull(conn, make([]byte, length+2)); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// socks5Authenticate authenticates to the SOCKS5 proxy with the username and password of the proxy.
+func (p *Proxy) socks5Authenticate(conn net.Conn) error {
+	if len(p.Username) > 255 || len(p.Password) > 255 {
+		return errors.New("SOCKS username and password must be at most 255 bytes")
+	}
+	req := []byte{socksAuthVersion, byte(len(p.Username))}
+	req = append(req, p.Username...)
+	req = append(req, byte(len(p.Password)))
+	req = append(req, p.Password...)
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[1] != 0 {
+		return errors.New("SOCKS authentication failed")
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package upstream_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/stretchr/testify/assert"
+)
+
+// serveProxy accepts a single connection on a local listener and serves it with the handler, and returns the
+// address of the listener.
+func serveProxy(t *testing.T, handle func(conn net.Conn)) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handle(conn)
+	}()
+	return listener.Addr().String()
+}
+
+func dialProxy(t *testing.T, proxy *upstream.Proxy) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := proxy.Dial(ctx, "origin.example:9000")
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	assert.Equal(t, "origin.example:9000", conn.RemoteAddr().String())
+	b, err := ioutil.ReadAll(conn)
+	return string(b), err
+}
+
+func TestProxy_HTTPConnect(t *testing.T) {
+	address := serveProxy(t, func(conn net.Conn) {
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil {
+			return
+		}
+		credentials := base64.StdEncoding.EncodeToString([]byte("user:password"))
+		if req.Method != http.MethodConnect || req.Host != "origin.example:9000" ||
+			req.Header.Get("Proxy-Authorization") != "Basic "+credentials {
+			_, _ = io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+			return
+		}
+		// The tunneled bytes may arrive along with the response.
+		_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\ntunneled")
+	})
+	got, err := dialProxy(t, &upstream.Proxy{Address: address, Username: "user", Password: "password"})
+	assert.NoError(t, err)
+	assert.Equal(t, "tunneled", got)
+}
+
+func TestProxy_HTTPConnectRejected(t *testing.T) {
+	address := serveProxy(t, func(conn net.Conn) {
+		_, _ = http.ReadRequest(bufio.NewReader(conn))
+		_, _ = io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+	})
+	_, err := dialProxy(t, &upstream.Proxy{Address: address})
+	assert.EqualError(t, err, "failed to connect to origin.example:9000 through proxy "+address+
+		": proxy responded 407 Proxy Authentication Required")
+}
+
+// serveSOCKS5 serves a SOCKS5 handshake that requires the username and password, and then writes to the tunnel.
+func serveSOCKS5(conn net.Conn) {
+	read := func(n int) []byte {
+		b := make([]byte, n)
+		_, _ = io.ReadFull(conn, b)
+		return b
+	}
+	greeting := read(2)
+	read(int(greeting[1]))
+	_, _ = conn.Write([]byte{5, 2})
+	auth := read(2)
+	username := string(read(int(auth[1])))
+	password := string(read(int(read(1)[0])))
+	if username != "user" || password != "password" {
+		_, _ = conn.Write([]byte{1, 1})
+		return
+	}
+	_, _ = conn.Write([]byte{1, 0})
+	req := read(5)
+	host := string(read(int(req[4])))
+	port := read(2)
+	if req[1] != 1 || req[3] != 3 || host != "origin.example" || int(port[0])<<8|int(port[1]) != 9000 {
+		_, _ = conn.Write([]byte{5, 4, 0, 1, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	_, _ = conn.Write([]byte{5, 0, 0, 1, 127, 0, 0, 1, 0x23, 0x28})
+	_, _ = io.WriteString(conn, "tunneled")
+}
+
+func TestProxy_SOCKS5(t *testing.T) {
+	address := serveProxy(t, serveSOCKS5)
+	got, err := dialProxy(t, &upstream.Proxy{
+		Type:     upstream.ProxySOCKS5,
+		Address:  address,
+		Username: "user",
+		Password: "password",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "tunneled", got)
+}
+
+func TestProxy_SOCKS5AuthenticationFailed(t *testing.T) {
+	address := serveProxy(t, serveSOCKS5)
+	_, err := dialProxy(t, &upstream.Proxy{
+		Type:     upstream.ProxySOCKS5,
+		Address:  address,
+		Username: "user",
+		Password: "wrong",
+	})
+	assert.EqualError(t, err, "failed to connect to origin.example:9000 through proxy "+address+
+		": SOCKS authentication failed")
+}
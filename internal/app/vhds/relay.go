@@ -0,0 +1,618 @@
+// Package vhds relays the virtual host discovery service (VHDS) to downstream Envoys, which discover the virtual hosts
+// of their route configurations incrementally and on demand.
+//
+// VHDS is only served over incremental xDS streams, whose state is held per stream, so it is relayed apart from the
+// cached state-of-the-world responses. Envoy opens a VHDS stream for each route configuration, subscribes to the name
+// of the route configuration on its first request, and to `<route configuration>/<host>` aliases on demand. The
+// downstream streams of the same aggregated key whose first requests subscribe to the same names form a group, which
+// shares one stream with the origin server subscribed to the union of their names. Every stream of a group is sent
+// the virtual hosts of the group. The virtual hosts already received are sent to new streams, and answer on-demand
+// requests without a round trip to the origin server.
+package vhds
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	routeservice "github.com/envoyproxy/go-control-plane/envoy/service/route/v3"
+	"github.com/envoyproxy/xds-relay/internal/app/apiversion"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+)
+
+const (
+	// reconnectDelay is the wait between attempts to open the stream of a group with the origin server.
+	reconnectDelay = time.Second
+
+	metricDownstreamStreams = "downstream_streams"
+	metricUpstreamStreams   = "upstream_streams"
+	metricResponsesReceived = "responses_received"
+	metricResponsesSent     = "responses_sent"
+	metricOnDemandHit       = "on_demand_hit"
+	metricNack              = "nack"
+	metricStreamError       = "stream_error"
+)
+
+// Relay serves VHDS to downstream Envoys from incremental streams with the origin server.
+type Relay struct {
+	ctx    context.Context
+	client upstream.DeltaClient
+	mapper mapper.Mapper
+	logger log.Logger
+	scope  tally.Scope
+
+	mu           sync.Mutex
+	groups       map[groupKey]*group
+	nextStreamID int64
+}
+
+// groupKey identifies the downstream streams that share a stream with the origin server.
+type groupKey struct {
+	aggregatedKey string
+	// names are the sorted resource names subscribed to by the first request of the streams, joined by commas.
+	names string
+}
+
+// group is the state of the downstream streams that share a stream with the origin server.
+type group struct {
+	key groupKey
+	// node is the node of the stream that opened the group, which the stream with the origin server is opened on
+	// behalf of.
+	node   *core.Node
+	scope  tally.Scope
+	cancel context.CancelFunc
+	// changed signals the stream with the origin server that the subscribed names changed.
+	changed chan struct{}
+
+	mu sync.Mutex
+	// resources are the virtual hosts received from the origin server, by name.
+	resources map[string]*v2.Resource
+	// notFound are the answers of the origin server to on-demand requests of unknown aliases, by alias.
+	notFound map[string]*v2.Resource
+	version  string
+	// subscribers counts the downstream streams subscribed to each name.
+	subscribers map[string]int
+	streams     map[int64]*downstream
+}
+
+// downstream is the state of a downstream stream of a group.
+type downstream struct {
+	// names are the names that the stream is subscribed to.
+	names map[string]bool
+	// pending are the responses that the stream has yet to send, in order.
+	pending []*v2.DeltaDiscoveryResponse
+	ready   chan struct{}
+}
+
+// deltaStream is a downstream VHDS stream of either API version.
+type deltaStream interface {
+	Send(*v2.DeltaDiscoveryResponse) error
+	Recv() (*v2.DeltaDiscoveryRequest, error)
+	Context() context.Context
+}
+
+// New returns a relay whose streams with the origin server are opened with the client, and closed when ctx is done.
+func New(
+	ctx context.Context,
+	client upstream.DeltaClient,
+	requestMapper mapper.Mapper,
+	logger log.Logger,
+	scope tally.Scope,
+) *Relay {
+	return &Relay{
+		ctx:    ctx,
+		client: client,
+		mapper: requestMapper,
+		logger: logger.Named("vhds"),
+		scope:  scope,
+		groups: make(map[groupKey]*group),
+	}
+}
+
+// DeltaVirtualHosts serves the v2 VHDS stream of a downstream Envoy.
+func (r *Relay) DeltaVirtualHosts(stream v2.VirtualHostDiscoveryService_DeltaVirtualHostsServer) error {
+	return r.serve(stream)
+}
+
+// V3 returns the v3 VHDS service of the relay. Requests are translated to v2 before they are served, and responses
+// back to v3.
+func (r *Relay) V3() routeservice.VirtualHostDiscoveryServiceServer {
+	return &v3Server{relay: r}
+}
+
+func (r *Relay) serve(stream deltaStream) error {
+	ctx := stream.Context()
+	req, err := stream.Recv()
+	if err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+	names := sortedNames(req.GetResourceNamesSubscribe())
+	mappedReq := v2.DiscoveryRequest{Node: req.GetNode(), TypeUrl: upstream.VirtualHostTypeURL, ResourceNames: names}
+	aggregatedKey, err := r.mapper.GetKey(mappedReq)
+	if err != nil {
+		// Like the other types, requests that no aggregation rule matches are not aggregated.
+		aggregatedKey = mapper.UnaggregatedKey(mappedReq)
+	}
+	g, streamID, d := r.join(groupKey{aggregatedKey: aggregatedKey, names: strings.Join(names, ",")}, req)
+	defer r.leave(g, streamID)
+
+	received := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				received <- err
+				return
+			}
+			if req.GetErrorDetail() != nil {
+				r.scope.Counter(metricNack).Inc(1)
+				r.logger.With("aggregated_key", aggregatedKey).With("node_id", req.GetNode().GetId()).
+					With("error_detail", req.GetErrorDetail().GetMessage()).
+					Warn(ctx, "downstream rejected virtual hosts")
+			}
+			g.update(streamID, req.GetResourceNamesSubscribe(), req.GetResourceNamesUnsubscribe())
+		}
+	}()
+	var nonce int64
+	for {
+		select {
+		case <-d.ready:
+			for _, resp := range g.takePending(streamID) {
+				nonce++
+				sent := *resp
+				sent.Nonce = strconv.FormatInt(nonce, 10)
+				if err := stream.Send(&sent); err != nil {
+					return err
+				}
+				r.scope.Counter(metricResponsesSent).Inc(1)
+			}
+		case err := <-received:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// join adds the downstream stream of the first request to its group, which is created and starts streaming from the
+// origin server if it does not exist yet.
+func (r *Relay) join(key groupKey, req *v2.DeltaDiscoveryRequest) (*group, int64, *downstream) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextStreamID++
+	g, ok := r.groups[key]
+	var ctx context.Context
+	if !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(r.ctx)
+		g = &group{
+			key:         key,
+			node:        req.GetNode(),
+			scope:       r.scope,
+			cancel:      cancel,
+			changed:     make(chan struct{}, 1),
+			resources:   make(map[string]*v2.Resource),
+			notFound:    make(map[string]*v2.Resource),
+			subscribers: make(map[string]int),
+			streams:     make(map[int64]*downstream),
+		}
+		r.groups[key] = g
+		r.scope.Gauge(metricUpstreamStreams).Update(float64(len(r.groups)))
+	}
+	d := g.open(r.nextStreamID, req)
+	if !ok {
+		// The stream with the origin server is opened once the group is subscribed to the names of the first
+		// request.
+		go r.run(ctx, g)
+	}
+	r.scope.Gauge(metricDownstreamStreams).Update(float64(r.streamCount()))
+	return g, r.nextStreamID, d
+}
+
+// leave removes the downstream stream from its group, which is closed once it has no streams left.
+func (r *Relay) leave(g *group, streamID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if g.close(streamID) {
+		g.cancel()
+		delete(r.groups, g.key)
+		r.scope.Gauge(metricUpstreamStreams).Update(float64(len(r.groups)))
+	}
+	r.scope.Gauge(metricDownstreamStreams).Update(float64(r.streamCount()))
+}
+
+func (r *Relay) streamCount() int {
+	count := 0
+	for _, g := range r.groups {
+		g.mu.Lock()
+		count += len(g.streams)
+		g.mu.Unlock()
+	}
+	return count
+}
+
+// run streams the virtual hosts of the group from the origin server until ctx is done, reopening the stream whenever
+// it ends.
+func (r *Relay) run(ctx context.Context, g *group) {
+	for {
+		if err := r.stream(ctx, g); err != nil && ctx.Err() == nil {
+			r.scope.Counter(metricStreamError).Inc(1)
+			r.logger.With("err", err).With("aggregated_key", g.key.aggregatedKey).
+				Warn(ctx, "virtual host discovery stream ended")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// stream opens a stream with the origin server subscribed to the names of the group, applies its responses to the
+// group, and updates its subscription whenever the names of the group change, until the stream ends.
+func (r *Relay) stream(ctx context.Context, g *group) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := r.client.OpenDeltaStream(ctx, g.node, upstream.VirtualHostTypeURL)
+	if err != nil {
+		return err
+	}
+	subscribed, versions := g.subscription()
+	if err := stream.Send(&v2.DeltaDiscoveryRequest{
+		Node:                    g.node,
+		TypeUrl:                 upstream.VirtualHostTypeURL,
+		ResourceNamesSubscribe:  subscribed,
+		InitialResourceVersions: versions,
+	}); err != nil {
+		return err
+	}
+
+	responses := make(chan *v2.DeltaDiscoveryResponse)
+	received := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				received <- err
+				return
+			}
+			select {
+			case responses <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case resp := <-responses:
+			r.scope.Counter(metricResponsesReceived).Inc(1)
+			g.apply(resp)
+			if err := stream.Send(&v2.DeltaDiscoveryRequest{
+				TypeUrl:       upstream.VirtualHostTypeURL,
+				ResponseNonce: resp.GetNonce(),
+			}); err != nil {
+				return err
+			}
+		case <-g.changed:
+			names, _ := g.subscription()
+			subscribe, unsubscribe := diffNames(subscribed, names)
+			subscribed = names
+			if len(subscribe) == 0 && len(unsubscribe) == 0 {
+				continue
+			}
+			if err := stream.Send(&v2.DeltaDiscoveryRequest{
+				TypeUrl:                  upstream.VirtualHostTypeURL,
+				ResourceNamesSubscribe:   subscribe,
+				ResourceNamesUnsubscribe: unsubscribe,
+			}); err != nil {
+				return err
+			}
+		case err := <-received:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// open adds the downstream stream of the first request to the group. The virtual hosts of the group are sent to the
+// stream first, except those whose version the stream already has.
+func (g *group) open(streamID int64, req *v2.DeltaDiscoveryRequest) *downstream {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	d := &downstream{names: make(map[string]bool), ready: make(chan struct{}, 1)}
+	g.streams[streamID] = d
+	g.subscribe(d, req.GetResourceNamesSubscribe())
+	var resources []*v2.Resource
+	for name, resource := range g.resources {
+		if req.GetInitialResourceVersions()[name] != resource.GetVersion() {
+			resources = append(resources, resource)
+		}
+	}
+	if len(resources) > 0 {
+		sort.Slice(resources, func(i, j int) bool { return resources[i].GetName() < resources[j].GetName() })
+		d.enqueue(g.response(resources, nil))
+	}
+	return d
+}
+
+// update subscribes the downstream stream to names, and unsubscribes it from others. The virtual hosts and
+// answers of the group that the new names refer to are sent to the stream right away.
+func (g *group) update(streamID int64, subscribe []string, unsubscribe []string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	d, ok := g.streams[streamID]
+	if !ok {
+		return
+	}
+	var resources []*v2.Resource
+	for _, name := range subscribe {
+		if d.names[name] {
+			continue
+		}
+		if resource := g.find(name); resource != nil {
+			g.scope.Counter(metricOnDemandHit).Inc(1)
+			resources = append(resources, resource)
+		}
+	}
+	g.subscribe(d, subscribe)
+	g.unsubscribe(d, unsubscribe)
+	if len(resources) > 0 {
+		d.enqueue(g.response(resources, nil))
+	}
+}
+
+// close removes the downstream stream from the group, and returns true if the group has no streams left.
+func (g *group) close(streamID int64) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if d, ok := g.streams[streamID]; ok {
+		names := make([]string, 0, len(d.names))
+		for name := range d.names {
+			names = append(names, name)
+		}
+		g.unsubscribe(d, names)
+		delete(g.streams, streamID)
+	}
+	return len(g.streams) == 0
+}
+
+// apply updates the virtual hosts of the group with the response of the origin server, and sends the changes to
+// every stream of the group. Answers to on-demand requests of unknown aliases are only sent to the streams
+// subscribed to them.
+func (g *group) apply(resp *v2.DeltaDiscoveryResponse) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.version = resp.GetSystemVersionInfo()
+	var resources, answers []*v2.Resource
+	for _, resource := range resp.GetResources() {
+		if resource.GetResource() == nil {
+			for _, alias := range resource.GetAliases() {
+				g.notFound[alias] = resource
+			}
+			answers = append(answers, resource)
+			continue
+		}
+		for _, alias := range resource.GetAliases() {
+			delete(g.notFound, alias)
+		}
+		g.resources[resource.GetName()] = resource
+		resources = append(resources, resource)
+	}
+	for _, name := range resp.GetRemovedResources() {
+		delete(g.resources, name)
+	}
+	for _, d := range g.streams {
+		streamResources := resources
+		if len(answers) > 0 {
+			streamResources = append([]*v2.Resource(nil), resources...)
+			for _, answer := range answers {
+				if d.subscribedTo(answer) {
+					streamResources = append(streamResources, answer)
+				}
+			}
+		}
+		if len(streamResources) == 0 && len(resp.GetRemovedResources()) == 0 {
+			continue
+		}
+		d.enqueue(g.response(streamResources, resp.GetRemovedResources()))
+	}
+}
+
+// subscription returns the sorted names that the streams of the group are subscribed to, and the versions of the
+// virtual hosts of the group by name.
+func (g *group) subscription() ([]string, map[string]string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	names := make([]string, 0, len(g.subscribers))
+	for name := range g.subscribers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	versions := make(map[string]string, len(g.resources))
+	for name, resource := range g.resources {
+		versions[name] = resource.GetVersion()
+	}
+	return names, versions
+}
+
+// takePending returns the responses that the downstream stream has yet to send.
+func (g *group) takePending(streamID int64) []*v2.DeltaDiscoveryResponse {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	d, ok := g.streams[streamID]
+	if !ok {
+		return nil
+	}
+	pending := d.pending
+	d.pending = nil
+	return pending
+}
+
+// subscribe counts the new names of the stream, and signals the stream with the origin server if the group was not
+// subscribed to them yet.
+func (g *group) subscribe(d *downstream, names []string) {
+	changed := false
+	for _, name := range names {
+		if d.names[name] {
+			continue
+		}
+		d.names[name] = true
+		if g.subscribers[name]++; g.subscribers[name] == 1 {
+			changed = true
+		}
+	}
+	if changed {
+		g.signal()
+	}
+}
+
+// unsubscribe forgets the names of the stream, and signals the stream with the origin server if no stream of the
+// group is subscribed to them anymore.
+func (g *group) unsubscribe(d *downstream, names []string) {
+	changed := false
+	for _, name := range names {
+		if !d.names[name] {
+			continue
+		}
+		delete(d.names, name)
+		if g.subscribers[name]--; g.subscribers[name] == 0 {
+			delete(g.subscribers, name)
+			changed = true
+		}
+	}
+	if changed {
+		g.signal()
+	}
+}
+
+func (g *group) signal() {
+	select {
+	case g.changed <- struct{}{}:
+	default:
+	}
+}
+
+// find returns the virtual host or the answer of the group named or aliased by name, or nil if there is none.
+func (g *group) find(name string) *v2.Resource {
+	if resource, ok := g.resources[name]; ok {
+		return resource
+	}
+	for _, resource := range g.resources {
+		for _, alias := range resource.GetAliases() {
+			if alias == name {
+				return resource
+			}
+		}
+	}
+	return g.notFound[name]
+}
+
+func (g *group) response(resources []*v2.Resource, removed []string) *v2.DeltaDiscoveryResponse {
+	return &v2.DeltaDiscoveryResponse{
+		SystemVersionInfo: g.version,
+		TypeUrl:           upstream.VirtualHostTypeURL,
+		Resources:         resources,
+		RemovedResources:  removed,
+	}
+}
+
+// enqueue appends the response to the pending responses of the stream, and wakes the stream up.
+func (d *downstream) enqueue(resp *v2.DeltaDiscoveryResponse) {
+	d.pending = append(d.pending, resp)
+	select {
+	case d.ready <- struct{}{}:
+	default:
+	}
+}
+
+// subscribedTo returns true if the stream is subscribed to the name or one of the aliases of the resource.
+func (d *downstream) subscribedTo(resource *v2.Resource) bool {
+	if d.names[resource.GetName()] {
+		return true
+	}
+	for _, alias := range resource.GetAliases() {
+		if d.names[alias] {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedNames(names []string) []string {
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// diffNames returns the names of to that are not in from, and the names of from that are not in to.
+func diffNames(from []string, to []string) ([]string, []string) {
+	inFrom := make(map[string]bool, len(from))
+	for _, name := range from {
+		inFrom[name] = true
+	}
+	var added, removed []string
+	for _, name := range to {
+		if !inFrom[name] {
+			added = append(added, name)
+		}
+		delete(inFrom, name)
+	}
+	for _, name := range from {
+		if inFrom[name] {
+			removed = append(removed, name)
+		}
+	}
+	return added, removed
+}
+
+type v3Server struct {
+	relay *Relay
+}
+
+func (s *v3Server) DeltaVirtualHosts(stream routeservice.VirtualHostDiscoveryService_DeltaVirtualHostsServer) error {
+	return s.relay.serve(&v3Stream{ServerStream: stream})
+}
+
+// v3Stream serves a v3 VHDS stream as a v2 stream. The v2 messages are sent and received as is, since they are wire
+// compatible with the v3 messages, with their type URLs translated.
+type v3Stream struct {
+	grpc.ServerStream
+}
+
+func (s *v3Stream) Send(resp *v2.DeltaDiscoveryResponse) error {
+	translated, err := apiversion.DeltaResponse(resp, apiversion.V3)
+	if err != nil {
+		return err
+	}
+	return s.ServerStream.SendMsg(translated)
+}
+
+func (s *v3Stream) Recv() (*v2.DeltaDiscoveryRequest, error) {
+	req := &v2.DeltaDiscoveryRequest{}
+	if err := s.ServerStream.RecvMsg(req); err != nil {
+		return nil, err
+	}
+	apiversion.DeltaRequest(req, apiversion.V2)
+	return req, nil
+}
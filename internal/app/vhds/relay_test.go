@@ -0,0 +1,269 @@
+package vhds
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	routev2 "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	routeservice "github.com/envoyproxy/go-control-plane/envoy/service/route/v3"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+)
+
+const vhostTypeURLV3 = "type.googleapis.com/envoy.config.route.v3.VirtualHost"
+
+// mockOrigin records the requests of its VHDS streams, and sends the responses pushed to it on the latest stream.
+type mockOrigin struct {
+	streams   chan struct{}
+	requests  chan *v2.DeltaDiscoveryRequest
+	responses chan *v2.DeltaDiscoveryResponse
+}
+
+func newMockOrigin() *mockOrigin {
+	return &mockOrigin{
+		streams:   make(chan struct{}, 10),
+		requests:  make(chan *v2.DeltaDiscoveryRequest, 10),
+		responses: make(chan *v2.DeltaDiscoveryResponse),
+	}
+}
+
+func (m *mockOrigin) DeltaVirtualHosts(stream v2.VirtualHostDiscoveryService_DeltaVirtualHostsServer) error {
+	m.streams <- struct{}{}
+	received := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				received <- err
+				return
+			}
+			m.requests <- req
+		}
+	}()
+	for {
+		select {
+		case resp := <-m.responses:
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		case err := <-received:
+			return err
+		}
+	}
+}
+
+// expectRequest returns the next request received by the origin server.
+func (m *mockOrigin) expectRequest(t *testing.T) *v2.DeltaDiscoveryRequest {
+	select {
+	case req := <-m.requests:
+		return req
+	case <-time.After(5 * time.Second):
+		t.Fatal("no request received by the origin server")
+		return nil
+	}
+}
+
+func newVirtualHost(t *testing.T, name string, version string, aliases ...string) *v2.Resource {
+	resource, err := ptypes.MarshalAny(&routev2.VirtualHost{Name: name})
+	assert.NoError(t, err)
+	return &v2.Resource{Name: name, Version: version, Aliases: aliases, Resource: resource}
+}
+
+func resourceNames(resp *v2.DeltaDiscoveryResponse) []string {
+	var names []string
+	for _, resource := range resp.GetResources() {
+		names = append(names, resource.GetName())
+	}
+	return names
+}
+
+// newRelay serves a relay of the origin server, whose requests are all mapped to the same aggregated key, and returns
+// a connection to it.
+func newRelay(t *testing.T, ctx context.Context, origin *mockOrigin, scope tally.Scope) *grpc.ClientConn {
+	originListener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	originServer := grpc.NewServer()
+	v2.RegisterVirtualHostDiscoveryServiceServer(originServer, origin)
+	go func() { _ = originServer.Serve(originListener) }()
+	t.Cleanup(originServer.Stop)
+
+	client, err := upstream.New(ctx, originListener.Addr().String(), upstream.CallOptions{Timeout: time.Second},
+		log.New("info"))
+	assert.NoError(t, err)
+	relay := New(ctx, client.(upstream.DeltaClient), mapper.New(&aggregationv1.KeyerConfiguration{
+		Fragments: []*aggregationv1.KeyerConfiguration_Fragment{
+			{
+				Rules: []*aggregationv1.KeyerConfiguration_Fragment_Rule{
+					{
+						Match: &aggregationv1.MatchPredicate{
+							Type: &aggregationv1.MatchPredicate_AnyMatch{AnyMatch: true},
+						},
+						Result: &aggregationv1.ResultPredicate{
+							Type: &aggregationv1.ResultPredicate_StringFragment{StringFragment: "vhds"},
+						},
+					},
+				},
+			},
+		},
+	}), log.New("info"), scope)
+
+	relayListener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	relayServer := grpc.NewServer()
+	v2.RegisterVirtualHostDiscoveryServiceServer(relayServer, relay)
+	routeservice.RegisterVirtualHostDiscoveryServiceServer(relayServer, relay.V3())
+	go func() { _ = relayServer.Serve(relayListener) }()
+	t.Cleanup(relayServer.Stop)
+
+	conn, err := grpc.Dial(relayListener.Addr().String(), grpc.WithInsecure())
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestRelay(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	origin := newMockOrigin()
+	scope := tally.NewTestScope("vhds", make(map[string]string))
+	conn := newRelay(t, ctx, origin, scope)
+	client := v2.NewVirtualHostDiscoveryServiceClient(conn)
+
+	envoyA, err := client.DeltaVirtualHosts(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, envoyA.Send(&v2.DeltaDiscoveryRequest{
+		Node:                   &core.Node{Id: "a"},
+		TypeUrl:                upstream.VirtualHostTypeURL,
+		ResourceNamesSubscribe: []string{"local"},
+	}))
+	req := origin.expectRequest(t)
+	assert.Equal(t, "a", req.GetNode().GetId())
+	assert.Equal(t, []string{"local"}, req.GetResourceNamesSubscribe())
+
+	origin.responses <- &v2.DeltaDiscoveryResponse{
+		SystemVersionInfo: "1",
+		Nonce:             "origin-1",
+		TypeUrl:           upstream.VirtualHostTypeURL,
+		Resources:         []*v2.Resource{newVirtualHost(t, "local/a", "1")},
+	}
+	resp, err := envoyA.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"local/a"}, resourceNames(resp))
+	assert.Equal(t, "1", resp.GetSystemVersionInfo())
+	assert.NotEmpty(t, resp.GetNonce())
+	assert.Equal(t, "origin-1", origin.expectRequest(t).GetResponseNonce())
+
+	// Envoys of the same aggregated key and route configuration share the stream with the origin server, and are
+	// sent the virtual hosts already received.
+	envoyB, err := client.DeltaVirtualHosts(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, envoyB.Send(&v2.DeltaDiscoveryRequest{
+		Node:                   &core.Node{Id: "b"},
+		TypeUrl:                upstream.VirtualHostTypeURL,
+		ResourceNamesSubscribe: []string{"local"},
+	}))
+	resp, err = envoyB.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"local/a"}, resourceNames(resp))
+
+	// On-demand requests are subscribed to by the stream with the origin server, and their virtual hosts are sent
+	// to every Envoy of the group.
+	assert.NoError(t, envoyB.Send(&v2.DeltaDiscoveryRequest{
+		TypeUrl:                upstream.VirtualHostTypeURL,
+		ResourceNamesSubscribe: []string{"local/b.com"},
+	}))
+	assert.Equal(t, []string{"local/b.com"}, origin.expectRequest(t).GetResourceNamesSubscribe())
+	origin.responses <- &v2.DeltaDiscoveryResponse{
+		SystemVersionInfo: "2",
+		Nonce:             "origin-2",
+		TypeUrl:           upstream.VirtualHostTypeURL,
+		Resources:         []*v2.Resource{newVirtualHost(t, "local/b", "1", "local/b.com")},
+	}
+	for _, envoy := range []v2.VirtualHostDiscoveryService_DeltaVirtualHostsClient{envoyA, envoyB} {
+		resp, err = envoy.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"local/b"}, resourceNames(resp))
+	}
+	assert.Equal(t, "origin-2", origin.expectRequest(t).GetResponseNonce())
+
+	// On-demand requests of virtual hosts that were already received are answered by the relay.
+	assert.NoError(t, envoyA.Send(&v2.DeltaDiscoveryRequest{
+		TypeUrl:                upstream.VirtualHostTypeURL,
+		ResourceNamesSubscribe: []string{"local/b.com"},
+	}))
+	resp, err = envoyA.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"local/b"}, resourceNames(resp))
+	assert.Equal(t, []string{"local/b.com"}, resp.GetResources()[0].GetAliases())
+	testutils.AssertCounterValue(t, scope.Snapshot().Counters(), "vhds.on_demand_hit", 1)
+
+	// Answers for unknown aliases are only sent to the Envoys that requested them.
+	assert.NoError(t, envoyB.Send(&v2.DeltaDiscoveryRequest{
+		TypeUrl:                upstream.VirtualHostTypeURL,
+		ResourceNamesSubscribe: []string{"local/c.com"},
+	}))
+	assert.Equal(t, []string{"local/c.com"}, origin.expectRequest(t).GetResourceNamesSubscribe())
+	origin.responses <- &v2.DeltaDiscoveryResponse{
+		SystemVersionInfo: "3",
+		Nonce:             "origin-3",
+		TypeUrl:           upstream.VirtualHostTypeURL,
+		Resources:         []*v2.Resource{{Aliases: []string{"local/c.com"}}},
+	}
+	resp, err = envoyB.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"local/c.com"}, resp.GetResources()[0].GetAliases())
+	assert.Nil(t, resp.GetResources()[0].GetResource())
+	origin.expectRequest(t)
+	origin.responses <- &v2.DeltaDiscoveryResponse{
+		SystemVersionInfo: "4",
+		Nonce:             "origin-4",
+		TypeUrl:           upstream.VirtualHostTypeURL,
+		RemovedResources:  []string{"local/a"},
+	}
+	resp, err = envoyA.Recv()
+	assert.NoError(t, err)
+	assert.Empty(t, resp.GetResources())
+	assert.Equal(t, []string{"local/a"}, resp.GetRemovedResources())
+	origin.expectRequest(t)
+
+	// Names are unsubscribed from once no Envoy of the group is subscribed to them.
+	assert.NoError(t, envoyB.CloseSend())
+	assert.Equal(t, []string{"local/c.com"}, origin.expectRequest(t).GetResourceNamesUnsubscribe())
+	assert.Equal(t, 1, len(origin.streams))
+}
+
+func TestRelayV3(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	origin := newMockOrigin()
+	conn := newRelay(t, ctx, origin, tally.NewTestScope("vhds", make(map[string]string)))
+
+	envoy, err := routeservice.NewVirtualHostDiscoveryServiceClient(conn).DeltaVirtualHosts(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, envoy.Send(&discoveryv3.DeltaDiscoveryRequest{
+		TypeUrl:                vhostTypeURLV3,
+		ResourceNamesSubscribe: []string{"local"},
+	}))
+	assert.Equal(t, upstream.VirtualHostTypeURL, origin.expectRequest(t).GetTypeUrl())
+	origin.responses <- &v2.DeltaDiscoveryResponse{
+		SystemVersionInfo: "1",
+		TypeUrl:           upstream.VirtualHostTypeURL,
+		Resources:         []*v2.Resource{newVirtualHost(t, "local/a", "1")},
+	}
+	resp, err := envoy.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, vhostTypeURLV3, resp.GetTypeUrl())
+	assert.Equal(t, vhostTypeURLV3, resp.GetResources()[0].GetResource().GetTypeUrl())
+}
@@ -0,0 +1,50 @@
+package log
+
+import (
+	"context"
+)
+
+const (
+	// FieldAggregatedKey and FieldNodeID are the names of the fields attached
+	// by WithAggregatedKey and WithNodeID.
+	FieldAggregatedKey = "key"
+	FieldNodeID        = "node ID"
+	// FieldRequestID is the name of the field of the ID of a downstream
+	// stream or call.
+	FieldRequestID = "request ID"
+)
+
+type fieldsKey struct{}
+
+// WithFields returns a copy of ctx that carries the fields, in addition to
+// the fields already carried by ctx. Loggers annotate each message logged
+// with the context with its fields. Fields are pairs of keys and values, as
+// in Logger.With.
+func WithFields(ctx context.Context, args ...interface{}) context.Context {
+	parent := FieldsFromContext(ctx)
+	fields := make([]interface{}, 0, len(parent)+len(args))
+	fields = append(fields, parent...)
+	fields = append(fields, args...)
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// WithAggregatedKey returns a copy of ctx that carries the aggregated key.
+func WithAggregatedKey(ctx context.Context, aggregatedKey string) context.Context {
+	return WithFields(ctx, FieldAggregatedKey, aggregatedKey)
+}
+
+// WithNodeID returns a copy of ctx that carries the node ID of a downstream
+// client.
+func WithNodeID(ctx context.Context, nodeID string) context.Context {
+	return WithFields(ctx, FieldNodeID, nodeID)
+}
+
+// FieldsFromContext returns the fields carried by ctx, for Logger
+// implementations to attach to messages.
+func FieldsFromContext(ctx context.Context) []interface{} {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).([]interface{})
+	return fields
+}
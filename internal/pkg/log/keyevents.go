@@ -0,0 +1,126 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// KeyEvents keeps the latest messages logged with each aggregated key through
+// the loggers it wraps, debug messages included, so that the interactions of
+// a key can be inspected without logging debug messages for every key.
+// Messages are attributed to the key of their FieldAggregatedKey field, set
+// with With or WithAggregatedKey.
+type KeyEvents struct {
+	mu   sync.Mutex
+	keys map[string]*ring
+	size int
+}
+
+// NewKeyEvents returns a KeyEvents that keeps the latest size messages of each
+// aggregated key.
+func NewKeyEvents(size int) *KeyEvents {
+	return &KeyEvents{keys: make(map[string]*ring), size: size}
+}
+
+// Wrap returns a logger that keeps the messages logged through it with an
+// aggregated key, and logs them to the logger. Unlike the messages of other
+// levels, debug messages are only logged to the logger if its level allows.
+func (k *KeyEvents) Wrap(logger Logger) Logger {
+	return &keyEventsLogger{Logger: logger, events: k}
+}
+
+// Events returns the kept messages of the aggregated key, oldest first.
+func (k *KeyEvents) Events(aggregatedKey string) []Entry {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	entries, ok := k.keys[aggregatedKey]
+	if !ok {
+		return nil
+	}
+	return entries.list()
+}
+
+func (k *KeyEvents) add(aggregatedKey string, entry Entry) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	entries, ok := k.keys[aggregatedKey]
+	if !ok {
+		entries = &ring{size: k.size}
+		k.keys[aggregatedKey] = entries
+	}
+	entries.add(entry)
+}
+
+type keyEventsLogger struct {
+	Logger
+	events *KeyEvents
+	name   string
+	fields []interface{}
+}
+
+func (l *keyEventsLogger) Named(name string) Logger {
+	fullName := name
+	if l.name != "" {
+		fullName = l.name + "." + name
+	}
+	return &keyEventsLogger{Logger: l.Logger.Named(name), events: l.events, name: fullName, fields: l.fields}
+}
+
+func (l *keyEventsLogger) With(args ...interface{}) Logger {
+	fields := make([]interface{}, 0, len(l.fields)+len(args))
+	fields = append(fields, l.fields...)
+	fields = append(fields, args...)
+	return &keyEventsLogger{Logger: l.Logger.With(args...), events: l.events, name: l.name, fields: fields}
+}
+
+func (l *keyEventsLogger) Debug(ctx context.Context, template string, args ...interface{}) {
+	l.keep(ctx, "debug", template, args)
+	l.Logger.Debug(ctx, template, args...)
+}
+
+func (l *keyEventsLogger) Info(ctx context.Context, template string, args ...interface{}) {
+	l.keep(ctx, "info", template, args)
+	l.Logger.Info(ctx, template, args...)
+}
+
+func (l *keyEventsLogger) Warn(ctx context.Context, template string, args ...interface{}) {
+	l.keep(ctx, "warn", template, args)
+	l.Logger.Warn(ctx, template, args...)
+}
+
+func (l *keyEventsLogger) Error(ctx context.Context, template string, args ...interface{}) {
+	l.keep(ctx, "error", template, args)
+	l.Logger.Error(ctx, template, args...)
+}
+
+func (l *keyEventsLogger) Panic(ctx context.Context, template string, args ...interface{}) {
+	l.keep(ctx, "panic", template, args)
+	l.Logger.Panic(ctx, template, args...)
+}
+
+func (l *keyEventsLogger) Fatal(ctx context.Context, template string, args ...interface{}) {
+	l.keep(ctx, "fatal", template, args)
+	l.Logger.Fatal(ctx, template, args...)
+}
+
+// keep adds the message to the events of its aggregated key. Messages without
+// one are not formatted, so that the messages of other components cost
+// nothing.
+func (l *keyEventsLogger) keep(ctx context.Context, level string, template string, args []interface{}) {
+	if l.events.size <= 0 {
+		return
+	}
+	fields := append(append([]interface{}{}, l.fields...), FieldsFromContext(ctx)...)
+	aggregatedKey, ok := "", false
+	for i := 0; i+1 < len(fields); i += 2 {
+		// The latest field wins, as it does in the formatted fields.
+		if fmt.Sprint(fields[i]) == FieldAggregatedKey {
+			aggregatedKey, ok = fmt.Sprint(fields[i+1]), true
+		}
+	}
+	if !ok {
+		return
+	}
+	l.events.add(aggregatedKey, newEntry(level, l.name, template, args, fields))
+}
@@ -0,0 +1,45 @@
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	z "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestKeyEvents(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	events := NewKeyEvents(2)
+	logger := events.Wrap(NewZap(z.New(core)))
+
+	ctx := WithAggregatedKey(context.Background(), "lds")
+	logger.Info(context.Background(), "no key")
+	logger.Named("orchestrator").Debug(ctx, "creating watch")
+	logger.With(FieldAggregatedKey, "cds").Debug(context.Background(), "response sent")
+	logger.With("version", "1").Info(ctx, "response %d sent", 1)
+	logger.Warn(ctx, "upstream stream closed")
+
+	// Debug messages are kept even though the level of the logger drops them,
+	// and the oldest messages of a key beyond the size are not kept.
+	assert.Equal(t, 3, logs.Len())
+	lds := events.Events("lds")
+	assert.Equal(t, 2, len(lds))
+	assert.Equal(t, "info", lds[0].Level)
+	assert.Equal(t, "response 1 sent", lds[0].Message)
+	assert.Equal(t, map[string]string{"version": "1", FieldAggregatedKey: "lds"}, lds[0].Fields)
+	assert.Equal(t, "warn", lds[1].Level)
+	cds := events.Events("cds")
+	assert.Equal(t, 1, len(cds))
+	assert.Equal(t, "debug", cds[0].Level)
+	assert.Equal(t, "response sent", cds[0].Message)
+	assert.Nil(t, events.Events("eds"))
+}
+
+func TestKeyEventsDisabled(t *testing.T) {
+	events := NewKeyEvents(0)
+	events.Wrap(New("panic")).Debug(WithAggregatedKey(context.Background(), "lds"), "creating watch")
+	assert.Nil(t, events.Events("lds"))
+}
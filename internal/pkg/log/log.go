@@ -1,6 +1,7 @@
 // Package log defines the contract for the xds-relay logger.
-// It also contains an implementation of the contract using the Zap logging
-// framework.
+// It also contains implementations of the contract using the Zap logging
+// framework and logr, and helpers that attach fields, such as the aggregated
+// key and node ID, to the context that messages are logged with.
 package log
 
 import (
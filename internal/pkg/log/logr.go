@@ -0,0 +1,88 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// LogrSink is the subset of the logr.Logger methods used to write messages,
+// which allows embedders to log through logr without xds-relay depending on
+// it.
+type LogrSink interface {
+	Info(msg string, keysAndValues ...interface{})
+	Error(err error, msg string, keysAndValues ...interface{})
+}
+
+type logrLogger struct {
+	sink      LogrSink
+	debugSink LogrSink
+	name      string
+	fields    []interface{}
+}
+
+// NewLogr returns an instance of Logger that writes to logr. Debug messages
+// are written to debugSink, which is typically sink.V(1), and other messages
+// to sink. Warnings are written as info messages, since logr has no warning
+// level, and errors are written as errors.
+func NewLogr(sink LogrSink, debugSink LogrSink) Logger {
+	return &logrLogger{sink: sink, debugSink: debugSink}
+}
+
+func (l *logrLogger) Named(name string) Logger {
+	named := *l
+	if l.name != "" {
+		named.name = l.name + "." + name
+	} else {
+		named.name = name
+	}
+	return &named
+}
+
+func (l *logrLogger) With(args ...interface{}) Logger {
+	with := *l
+	with.fields = make([]interface{}, 0, len(l.fields)+len(args))
+	with.fields = append(with.fields, l.fields...)
+	with.fields = append(with.fields, args...)
+	return &with
+}
+
+func (l *logrLogger) Sync() error { return nil }
+
+// keysAndValues returns the fields of the logger and of ctx.
+func (l *logrLogger) keysAndValues(ctx context.Context) []interface{} {
+	fields := FieldsFromContext(ctx)
+	keysAndValues := make([]interface{}, 0, len(l.fields)+len(fields)+2)
+	if l.name != "" {
+		keysAndValues = append(keysAndValues, "logger", l.name)
+	}
+	keysAndValues = append(keysAndValues, l.fields...)
+	return append(keysAndValues, fields...)
+}
+
+func (l *logrLogger) Debug(ctx context.Context, template string, args ...interface{}) {
+	l.debugSink.Info(fmt.Sprintf(template, args...), l.keysAndValues(ctx)...)
+}
+
+func (l *logrLogger) Info(ctx context.Context, template string, args ...interface{}) {
+	l.sink.Info(fmt.Sprintf(template, args...), l.keysAndValues(ctx)...)
+}
+
+func (l *logrLogger) Warn(ctx context.Context, template string, args ...interface{}) {
+	l.sink.Info(fmt.Sprintf(template, args...), append(l.keysAndValues(ctx), "level", "warn")...)
+}
+
+func (l *logrLogger) Error(ctx context.Context, template string, args ...interface{}) {
+	l.sink.Error(nil, fmt.Sprintf(template, args...), l.keysAndValues(ctx)...)
+}
+
+func (l *logrLogger) Panic(ctx context.Context, template string, args ...interface{}) {
+	msg := fmt.Sprintf(template, args...)
+	l.sink.Error(nil, msg, l.keysAndValues(ctx)...)
+	panic(msg)
+}
+
+func (l *logrLogger) Fatal(ctx context.Context, template string, args ...interface{}) {
+	l.sink.Error(nil, fmt.Sprintf(template, args...), l.keysAndValues(ctx)...)
+	os.Exit(1)
+}
@@ -0,0 +1,60 @@
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type logrEntry struct {
+	msg           string
+	isError       bool
+	keysAndValues []interface{}
+}
+
+// mockLogrSink records the messages written to it.
+type mockLogrSink struct {
+	entries *[]logrEntry
+}
+
+func (s mockLogrSink) Info(msg string, keysAndValues ...interface{}) {
+	*s.entries = append(*s.entries, logrEntry{msg: msg, keysAndValues: keysAndValues})
+}
+
+func (s mockLogrSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	*s.entries = append(*s.entries, logrEntry{msg: msg, isError: true, keysAndValues: keysAndValues})
+}
+
+func TestNewLogr(t *testing.T) {
+	var entries, debugEntries []logrEntry
+	logger := NewLogr(mockLogrSink{&entries}, mockLogrSink{&debugEntries}).
+		Named("relay").Named("orchestrator").With("type", "lds")
+	ctx := WithAggregatedKey(context.Background(), "lds")
+
+	logger.Debug(ctx, "debug %d", 1)
+	logger.Info(ctx, "info")
+	logger.Warn(context.Background(), "warn")
+	logger.Error(ctx, "error")
+	assert.Panics(t, func() { logger.Panic(ctx, "panic") })
+
+	assert.Equal(t, []logrEntry{
+		{msg: "debug 1", keysAndValues: []interface{}{"logger", "relay.orchestrator", "type", "lds", "key", "lds"}},
+	}, debugEntries)
+	assert.Equal(t, []logrEntry{
+		{msg: "info", keysAndValues: []interface{}{"logger", "relay.orchestrator", "type", "lds", "key", "lds"}},
+		{msg: "warn", keysAndValues: []interface{}{"logger", "relay.orchestrator", "type", "lds", "level", "warn"}},
+		{msg: "error", isError: true,
+			keysAndValues: []interface{}{"logger", "relay.orchestrator", "type", "lds", "key", "lds"}},
+		{msg: "panic", isError: true,
+			keysAndValues: []interface{}{"logger", "relay.orchestrator", "type", "lds", "key", "lds"}},
+	}, entries)
+}
+
+func TestWithFields(t *testing.T) {
+	assert.Nil(t, FieldsFromContext(context.Background()))
+	parent := WithFields(context.Background(), "a", 1)
+	child := WithNodeID(parent, "node")
+	assert.Equal(t, []interface{}{"a", 1}, FieldsFromContext(parent))
+	assert.Equal(t, []interface{}{"a", 1, "node ID", "node"}, FieldsFromContext(child))
+}
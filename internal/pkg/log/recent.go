@@ -0,0 +1,145 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Entry is a message kept by Recent.
+type Entry struct {
+	Time    time.Time         `json:"time"`
+	Level   string            `json:"level"`
+	Name    string            `json:"name,omitempty"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// Recent keeps the latest messages logged through the loggers it wraps, e.g.
+// to attach them to support bundles. Debug messages are not kept, so that
+// wrapped loggers do not format the messages that their level drops.
+type Recent struct {
+	mu      sync.Mutex
+	entries ring
+}
+
+// NewRecent returns a Recent that keeps the latest size messages.
+func NewRecent(size int) *Recent {
+	return &Recent{entries: ring{size: size}}
+}
+
+// Wrap returns a logger that keeps the messages logged through it, and logs
+// them to the logger.
+func (r *Recent) Wrap(logger Logger) Logger {
+	return &recentLogger{Logger: logger, recent: r}
+}
+
+// Entries returns the kept messages, oldest first.
+func (r *Recent) Entries() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.entries.list()
+}
+
+func (r *Recent) add(entry Entry) {
+	if r.entries.size <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries.add(entry)
+}
+
+// ring holds the latest size entries.
+type ring struct {
+	entries []Entry
+	// next is the index of the slot of the next entry, which holds the
+	// oldest entry once entries is full.
+	next int
+	size int
+}
+
+func (r *ring) add(entry Entry) {
+	if len(r.entries) < r.size {
+		r.entries = append(r.entries, entry)
+		return
+	}
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % r.size
+}
+
+// list returns the entries, oldest first.
+func (r *ring) list() []Entry {
+	entries := make([]Entry, 0, len(r.entries))
+	entries = append(entries, r.entries[r.next:]...)
+	return append(entries, r.entries[:r.next]...)
+}
+
+type recentLogger struct {
+	Logger
+	recent *Recent
+	name   string
+	fields []interface{}
+}
+
+func (l *recentLogger) Named(name string) Logger {
+	fullName := name
+	if l.name != "" {
+		fullName = l.name + "." + name
+	}
+	return &recentLogger{Logger: l.Logger.Named(name), recent: l.recent, name: fullName, fields: l.fields}
+}
+
+func (l *recentLogger) With(args ...interface{}) Logger {
+	fields := make([]interface{}, 0, len(l.fields)+len(args))
+	fields = append(fields, l.fields...)
+	fields = append(fields, args...)
+	return &recentLogger{Logger: l.Logger.With(args...), recent: l.recent, name: l.name, fields: fields}
+}
+
+func (l *recentLogger) Info(ctx context.Context, template string, args ...interface{}) {
+	l.keep(ctx, "info", template, args)
+	l.Logger.Info(ctx, template, args...)
+}
+
+func (l *recentLogger) Warn(ctx context.Context, template string, args ...interface{}) {
+	l.keep(ctx, "warn", template, args)
+	l.Logger.Warn(ctx, template, args...)
+}
+
+func (l *recentLogger) Error(ctx context.Context, template string, args ...interface{}) {
+	l.keep(ctx, "error", template, args)
+	l.Logger.Error(ctx, template, args...)
+}
+
+func (l *recentLogger) Panic(ctx context.Context, template string, args ...interface{}) {
+	l.keep(ctx, "panic", template, args)
+	l.Logger.Panic(ctx, template, args...)
+}
+
+func (l *recentLogger) Fatal(ctx context.Context, template string, args ...interface{}) {
+	l.keep(ctx, "fatal", template, args)
+	l.Logger.Fatal(ctx, template, args...)
+}
+
+func (l *recentLogger) keep(ctx context.Context, level string, template string, args []interface{}) {
+	fields := append(append([]interface{}{}, l.fields...), FieldsFromContext(ctx)...)
+	l.recent.add(newEntry(level, l.name, template, args, fields))
+}
+
+// newEntry formats a message logged with the fields.
+func newEntry(level string, name string, template string, args []interface{}, fields []interface{}) Entry {
+	message := template
+	if len(args) > 0 {
+		message = fmt.Sprintf(template, args...)
+	}
+	entry := Entry{Time: time.Now(), Level: level, Name: name, Message: message}
+	if len(fields) > 0 {
+		entry.Fields = make(map[string]string, len(fields)/2)
+		for i := 0; i+1 < len(fields); i += 2 {
+			entry.Fields[fmt.Sprint(fields[i])] = fmt.Sprint(fields[i+1])
+		}
+	}
+	return entry
+}
@@ -0,0 +1,37 @@
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	z "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRecent(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	recent := NewRecent(2)
+	logger := recent.Wrap(NewZap(z.New(core)))
+
+	logger.Debug(context.Background(), "debug")
+	logger.Info(context.Background(), "first")
+	named := logger.Named("orchestrator").Named("fanout").With("count", 3)
+	named.Warn(WithAggregatedKey(context.Background(), "lds"), "second %d", 2)
+	logger.Error(context.Background(), "third")
+
+	// Every message is logged, but debug messages and the oldest messages
+	// beyond the size are not kept.
+	assert.Equal(t, 4, logs.Len())
+	assert.Equal(t, "orchestrator.fanout", logs.All()[2].LoggerName)
+	entries := recent.Entries()
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "warn", entries[0].Level)
+	assert.Equal(t, "orchestrator.fanout", entries[0].Name)
+	assert.Equal(t, "second 2", entries[0].Message)
+	assert.Equal(t, map[string]string{"count": "3", FieldAggregatedKey: "lds"}, entries[0].Fields)
+	assert.Equal(t, "error", entries[1].Level)
+	assert.Equal(t, "third", entries[1].Message)
+	assert.Nil(t, entries[1].Fields)
+}
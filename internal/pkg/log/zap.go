@@ -29,6 +29,12 @@ func New(logLevel string) Logger {
 	return &logger{zap: log.Sugar()}
 }
 
+// NewZap returns an instance of Logger that writes to an existing zap
+// logger, so that embedders can share their logging configuration.
+func NewZap(log *z.Logger) Logger {
+	return &logger{zap: log.WithOptions(z.AddCallerSkip(1)).Sugar()}
+}
+
 func (l *logger) Named(name string) Logger {
 	return &logger{zap: l.zap.Named(name)}
 }
@@ -37,10 +43,13 @@ func (l *logger) With(args ...interface{}) Logger {
 	return &logger{zap: l.zap.With(args...)}
 }
 
+// WithContext returns the logger annotated with the fields carried by ctx.
 func (l *logger) WithContext(ctx context.Context) *logger {
-	// We can add origin xDS request context here later.
-	// For now, just return the logger.
-	return l
+	fields := FieldsFromContext(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return &logger{zap: l.zap.With(fields...)}
 }
 
 func (l *logger) Sync() error { return l.zap.Sync() }
@@ -1,9 +1,12 @@
 package log
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	z "go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestNew(t *testing.T) {
@@ -32,3 +35,20 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestNewZap_ContextFields(t *testing.T) {
+	core, logs := observer.New(z.DebugLevel)
+	logger := NewZap(z.New(core)).Named("orchestrator").With("type", "lds")
+	ctx := WithNodeID(WithAggregatedKey(context.Background(), "lds"), "node")
+
+	logger.Info(ctx, "created watch %d", 1)
+	logger.Info(context.Background(), "no context fields")
+
+	entries := logs.AllUntimed()
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "created watch 1", entries[0].Message)
+	assert.Equal(t, "orchestrator", entries[0].LoggerName)
+	assert.Equal(t, map[string]interface{}{"type": "lds", "key": "lds", "node ID": "node"},
+		entries[0].ContextMap())
+	assert.Equal(t, map[string]interface{}{"type": "lds"}, entries[1].ContextMap())
+}
@@ -0,0 +1,130 @@
+// Package cron parses cron expressions of five fields, minute, hour, day of month, month, and day of week, and
+// matches times against them. Each field is `*`, a value, a range `a-b`, or a comma-separated list of them, and a
+// wildcard or range may be stepped with `/n`. Days of week are 0 through 6, starting on Sunday.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// field is the range of values of a field of a cron expression.
+type field struct {
+	name string
+	min  int
+	max  int
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 6},
+}
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	// values holds the values matched by every field, in the order of fields.
+	values [][]bool
+	// domWildcard and dowWildcard are true if the day of month and day of
+	// week fields are `*`. As in cron, a time matches either day field if
+	// neither is a wildcard.
+	domWildcard bool
+	dowWildcard bool
+}
+
+// Parse parses a cron expression.
+func Parse(expression string) (*Schedule, error) {
+	parts := strings.Fields(expression)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("cron expression %q has %d fields, expected %d", expression, len(parts), len(fields))
+	}
+	s := &Schedule{domWildcard: parts[2] == "*", dowWildcard: parts[4] == "*"}
+	for i, part := range parts {
+		values, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %s", expression, err.Error())
+		}
+		s.values = append(s.values, values)
+	}
+	return s, nil
+}
+
+func parseField(part string, f field) ([]bool, error) {
+	values := make([]bool, f.max+1)
+	for _, item := range strings.Split(part, ",") {
+		rangePart, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			var err error
+			rangePart = item[:i]
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step %q of the %s field", item[i+1:], f.name)
+			}
+		}
+		low, high := f.min, f.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = parseValue(bounds[0], f); err != nil {
+				return nil, err
+			}
+			high = low
+			if len(bounds) == 2 {
+				if high, err = parseValue(bounds[1], f); err != nil {
+					return nil, err
+				}
+			} else if step > 1 {
+				high = f.max
+			}
+			if high < low {
+				return nil, fmt.Errorf("invalid range %q of the %s field", rangePart, f.name)
+			}
+		}
+		for value := low; value <= high; value += step {
+			values[value] = true
+		}
+	}
+	return values, nil
+}
+
+func parseValue(value string, f field) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid value %q of the %s field, expected %d-%d", value, f.name, f.min, f.max)
+	}
+	return n, nil
+}
+
+// Matches returns true if the minute of the time matches the schedule.
+func (s *Schedule) Matches(t time.Time) bool {
+	if !s.values[0][t.Minute()] || !s.values[1][t.Hour()] || !s.values[3][int(t.Month())] {
+		return false
+	}
+	dom, dow := s.values[2][t.Day()], s.values[4][int(t.Weekday())]
+	switch {
+	case s.domWildcard && s.dowWildcard:
+		return true
+	case s.domWildcard:
+		return dow
+	case s.dowWildcard:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// Last returns the latest minute matching the schedule at or before the time,
+// and not before the time minus the lookback. It returns false if no minute
+// within the lookback matches.
+func (s *Schedule) Last(t time.Time, lookback time.Duration) (time.Time, bool) {
+	minute := t.Truncate(time.Minute)
+	for earliest := t.Add(-lookback); !minute.Before(earliest); minute = minute.Add(-time.Minute) {
+		if s.Matches(minute) {
+			return minute, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	for _, expression := range []string{"* * * * *", "0 9 * * 1-5", "*/15 0-6,22-23 1,15 * *", "30 2 * 1-12/3 0"} {
+		_, err := Parse(expression)
+		assert.NoError(t, err, expression)
+	}
+
+	for expression, message := range map[string]string{
+		"* * * *":     `cron expression "* * * *" has 4 fields, expected 5`,
+		"60 * * * *":  `cron expression "60 * * * *": invalid value "60" of the minute field, expected 0-59`,
+		"* * 0 * *":   `cron expression "* * 0 * *": invalid value "0" of the day of month field, expected 1-31`,
+		"* 5-2 * * *": `cron expression "* 5-2 * * *": invalid range "5-2" of the hour field`,
+		"*/0 * * * *": `cron expression "*/0 * * * *": invalid step "0" of the minute field`,
+		"* * * * mon": `cron expression "* * * * mon": invalid value "mon" of the day of week field, expected 0-6`,
+	} {
+		_, err := Parse(expression)
+		assert.EqualError(t, err, message)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	// Monday, 1 June 2020.
+	monday := time.Date(2020, time.June, 1, 9, 0, 0, 0, time.UTC)
+
+	schedule, err := Parse("0 9 * * 1-5")
+	assert.NoError(t, err)
+	assert.True(t, schedule.Matches(monday))
+	assert.True(t, schedule.Matches(monday.Add(30*time.Second)))
+	assert.False(t, schedule.Matches(monday.Add(time.Minute)))
+	assert.False(t, schedule.Matches(monday.AddDate(0, 0, -1)))
+
+	schedule, err = Parse("*/15 * * * *")
+	assert.NoError(t, err)
+	assert.True(t, schedule.Matches(monday.Add(45*time.Minute)))
+	assert.False(t, schedule.Matches(monday.Add(50*time.Minute)))
+
+	// Either day field matches if neither is a wildcard.
+	schedule, err = Parse("0 9 15 * 1")
+	assert.NoError(t, err)
+	assert.True(t, schedule.Matches(monday))
+	assert.True(t, schedule.Matches(monday.AddDate(0, 0, 14)))
+	assert.False(t, schedule.Matches(monday.AddDate(0, 0, 1)))
+}
+
+func TestLast(t *testing.T) {
+	monday := time.Date(2020, time.June, 1, 9, 0, 0, 0, time.UTC)
+	schedule, err := Parse("0 9 * * 1-5")
+	assert.NoError(t, err)
+
+	start, ok := schedule.Last(monday.Add(90*time.Minute+time.Second), 2*time.Hour)
+	assert.True(t, ok)
+	assert.Equal(t, monday, start)
+
+	_, ok = schedule.Last(monday.Add(3*time.Hour), 2*time.Hour)
+	assert.False(t, ok)
+	_, ok = schedule.Last(monday.Add(-time.Second), 2*time.Hour)
+	assert.False(t, ok)
+}
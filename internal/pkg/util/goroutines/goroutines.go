@@ -0,0 +1,112 @@
+// Package goroutines parses the stacks of the running goroutines, to find
+// goroutines that leaked or are blocked, such as on a deadlock.
+package goroutines
+
+import (
+	"bytes"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+)
+
+// header matches the first line of the stack of a goroutine, such as
+// "goroutine 7 [chan receive, 3 minutes]:".
+var header = regexp.MustCompile(`^goroutine (\d+) \[([^,\]]+)(?:, (\d+) minutes)?[^\]]*\]:$`)
+
+// Goroutine is a running goroutine.
+type Goroutine struct {
+	ID string
+	// State is what the goroutine is doing, such as "running" or
+	// "chan receive".
+	State string
+	// WaitMinutes is how long the goroutine has been blocked for, in whole
+	// minutes. It is only reported by the runtime after a minute.
+	WaitMinutes int
+	// Function is the function the goroutine is executing.
+	Function string
+	Stack    string
+}
+
+// Dump returns every running goroutine.
+func Dump() []Goroutine {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return parse(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+func parse(stacks []byte) []Goroutine {
+	var goroutines []Goroutine
+	for _, stack := range bytes.Split(stacks, []byte("\n\n")) {
+		lines := bytes.Split(bytes.TrimSpace(stack), []byte("\n"))
+		match := header.FindSubmatch(lines[0])
+		if match == nil {
+			continue
+		}
+		goroutine := Goroutine{ID: string(match[1]), State: string(match[2]), Stack: string(stack)}
+		if len(match[3]) > 0 {
+			goroutine.WaitMinutes, _ = strconv.Atoi(string(match[3]))
+		}
+		if len(lines) > 1 {
+			goroutine.Function = functionName(lines[1])
+		}
+		goroutines = append(goroutines, goroutine)
+	}
+	return goroutines
+}
+
+// functionName strips the arguments from a stack frame such as
+// "main.run(0xc000010000)".
+func functionName(frame []byte) string {
+	if i := bytes.LastIndexByte(frame, '('); i > 0 {
+		frame = frame[:i]
+	}
+	return string(frame)
+}
+
+// Blocked is a group of goroutines blocked in the same state and function.
+type Blocked struct {
+	State    string
+	Function string
+	Count    int
+	// MaxWaitMinutes is the longest any of the goroutines has been blocked.
+	MaxWaitMinutes int
+}
+
+// FindBlocked groups the goroutines blocked for at least minWaitMinutes by
+// their state and function, the longest blocked first.
+func FindBlocked(goroutines []Goroutine, minWaitMinutes int) []Blocked {
+	type group struct{ state, function string }
+	groups := make(map[group]*Blocked)
+	for _, goroutine := range goroutines {
+		if goroutine.WaitMinutes == 0 || goroutine.WaitMinutes < minWaitMinutes {
+			continue
+		}
+		key := group{goroutine.State, goroutine.Function}
+		blocked, ok := groups[key]
+		if !ok {
+			blocked = &Blocked{State: goroutine.State, Function: goroutine.Function}
+			groups[key] = blocked
+		}
+		blocked.Count++
+		if goroutine.WaitMinutes > blocked.MaxWaitMinutes {
+			blocked.MaxWaitMinutes = goroutine.WaitMinutes
+		}
+	}
+	result := make([]Blocked, 0, len(groups))
+	for _, blocked := range groups {
+		result = append(result, *blocked)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].MaxWaitMinutes != result[j].MaxWaitMinutes {
+			return result[i].MaxWaitMinutes > result[j].MaxWaitMinutes
+		}
+		return result[i].Function < result[j].Function
+	})
+	return result
+}
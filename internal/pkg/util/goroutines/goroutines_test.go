@@ -0,0 +1,64 @@
+package goroutines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testStacks = `goroutine 1 [running]:
+main.main()
+	/src/main.go:10 +0x20
+
+goroutine 7 [chan receive, 3 minutes]:
+github.com/envoyproxy/xds-relay/internal/app/orchestrator.(*orchestrator).watchUpstream(0xc000010000)
+	/src/orchestrator.go:100 +0x40
+
+goroutine 8 [semacquire, 5 minutes]:
+sync.runtime_SemacquireMutex(0xc000020000, 0x0, 0x1)
+	/usr/local/go/src/runtime/sema.go:71 +0x47
+
+goroutine 9 [chan receive, 1 minutes, locked to thread]:
+github.com/envoyproxy/xds-relay/internal/app/orchestrator.(*orchestrator).watchUpstream(0xc000030000)
+	/src/orchestrator.go:100 +0x40
+
+goroutine 10 [select]:
+github.com/envoyproxy/xds-relay/internal/app/orchestrator.(*orchestrator).watchShadow(0xc000040000)
+	/src/shadow.go:60 +0x40
+`
+
+func TestParse(t *testing.T) {
+	goroutines := parse([]byte(testStacks))
+	assert.Equal(t, 5, len(goroutines))
+	assert.Equal(t, "1", goroutines[0].ID)
+	assert.Equal(t, "running", goroutines[0].State)
+	assert.Equal(t, "main.main", goroutines[0].Function)
+	assert.Equal(t, 0, goroutines[0].WaitMinutes)
+	assert.Equal(t, "chan receive", goroutines[1].State)
+	assert.Equal(t, 3, goroutines[1].WaitMinutes)
+	assert.Equal(t,
+		"github.com/envoyproxy/xds-relay/internal/app/orchestrator.(*orchestrator).watchUpstream",
+		goroutines[1].Function)
+	assert.Equal(t, 1, goroutines[3].WaitMinutes)
+}
+
+func TestFindBlocked(t *testing.T) {
+	blocked := FindBlocked(parse([]byte(testStacks)), 1)
+	assert.Equal(t, []Blocked{
+		{State: "semacquire", Function: "sync.runtime_SemacquireMutex", Count: 1, MaxWaitMinutes: 5},
+		{
+			State:          "chan receive",
+			Function:       "github.com/envoyproxy/xds-relay/internal/app/orchestrator.(*orchestrator).watchUpstream",
+			Count:          2,
+			MaxWaitMinutes: 3,
+		},
+	}, blocked)
+
+	assert.Equal(t, 1, len(FindBlocked(parse([]byte(testStacks)), 4)))
+}
+
+func TestDump(t *testing.T) {
+	goroutines := Dump()
+	assert.NotEmpty(t, goroutines)
+	assert.Equal(t, "running", goroutines[0].State)
+}
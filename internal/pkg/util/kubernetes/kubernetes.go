@@ -0,0 +1,109 @@
+// Package kubernetes is a minimal client of the Kubernetes API server, authenticated with a service account token.
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	inClusterTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	inClusterCAFile    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+// Client sends requests to the Kubernetes API server with a bearer token.
+type Client struct {
+	apiServer string
+	tokenFile string
+	client    *http.Client
+
+	mu sync.Mutex
+	// token is the token last read from the token file.
+	token string
+}
+
+// NewClient returns a client of the API server. The API server defaults to the in-cluster address from the
+// KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT environment variables, the token file to the in-cluster service
+// account token, and the CA file to the in-cluster service account CA if the API server is in-cluster.
+func NewClient(apiServer string, tokenFile string, caFile string) (*Client, error) {
+	inCluster := apiServer == ""
+	if inCluster {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" || port == "" {
+			return nil, fmt.Errorf("api_server is unset and xds-relay is not running in a Kubernetes cluster")
+		}
+		apiServer = "https://" + net.JoinHostPort(host, port)
+	}
+	if tokenFile == "" {
+		tokenFile = inClusterTokenFile
+	}
+	token, err := readToken(tokenFile)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caFile == "" && inCluster {
+		caFile = inClusterCAFile
+	}
+	if caFile != "" {
+		ca, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &Client{
+		apiServer: strings.TrimSuffix(apiServer, "/"),
+		tokenFile: tokenFile,
+		client:    &http.Client{Transport: transport, Timeout: 10 * time.Second},
+		token:     token,
+	}, nil
+}
+
+// readToken returns the token of the token file.
+func readToken(tokenFile string) (string, error) {
+	token, err := ioutil.ReadFile(tokenFile)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+// currentToken returns the token of the token file, which is read again for every request, as the kubelet rotates
+// projected service account tokens. The token last read is returned if the file cannot be read.
+func (c *Client) currentToken() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if token, err := readToken(c.tokenFile); err == nil {
+		c.token = token
+	}
+	return c.token
+}
+
+// Do sends the request to the path of the API server, with the JSON body if it is not nil.
+func (c *Client) Do(ctx context.Context, method string, path string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.apiServer+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.currentToken())
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.client.Do(req)
+}
@@ -0,0 +1,45 @@
+package kubernetes
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientRereadsToken(t *testing.T) {
+	authorizations := make(chan string, 3)
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations <- r.Header.Get("Authorization")
+	}))
+	defer apiServer.Close()
+
+	dir, err := ioutil.TempDir("", "kubernetes")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	tokenFile := filepath.Join(dir, "token")
+	assert.NoError(t, ioutil.WriteFile(tokenFile, []byte("token-1\n"), 0600))
+	client, err := NewClient(apiServer.URL, tokenFile, "")
+	assert.NoError(t, err)
+
+	do := func() string {
+		resp, err := client.Do(context.Background(), http.MethodGet, "/api", nil)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		return <-authorizations
+	}
+	assert.Equal(t, "Bearer token-1", do())
+
+	// A rotated token is sent with the next request.
+	assert.NoError(t, ioutil.WriteFile(tokenFile, []byte("token-2\n"), 0600))
+	assert.Equal(t, "Bearer token-2", do())
+
+	// The last token is sent if the file cannot be read.
+	assert.NoError(t, os.Remove(tokenFile))
+	assert.Equal(t, "Bearer token-2", do())
+}
@@ -0,0 +1,76 @@
+// Package socket binds listeners to and dials the socket addresses of the bootstrap config, which are either TCP
+// addresses or, when prefixed with unix://, paths of Unix domain sockets.
+package socket
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"google.golang.org/grpc"
+)
+
+// UnixPrefix prefixes the addresses of Unix domain sockets.
+const UnixPrefix = "unix://"
+
+// Target returns the address that clients dial to reach the socket address: the address itself for Unix domain
+// sockets, and the host and port otherwise.
+func Target(address *bootstrapv1.SocketAddress) string {
+	if strings.HasPrefix(address.GetAddress(), UnixPrefix) {
+		return address.GetAddress()
+	}
+	return net.JoinHostPort(address.GetAddress(), strconv.FormatUint(uint64(address.GetPortValue()), 10))
+}
+
+// split returns the network and the address within the network of the target.
+func split(target string) (string, string) {
+	if strings.HasPrefix(target, UnixPrefix) {
+		return "unix", strings.TrimPrefix(target, UnixPrefix)
+	}
+	return "tcp", target
+}
+
+// Listen binds a listener to the socket address. A stale Unix domain socket at the path of the address is removed
+// first, and the socket is created with the mode of the address, if set.
+func Listen(address *bootstrapv1.SocketAddress) (net.Listener, error) {
+	network, addr := split(Target(address))
+	if network == "unix" {
+		if info, err := os.Stat(addr); err == nil && info.Mode()&os.ModeSocket != 0 {
+			if err := os.Remove(addr); err != nil {
+				return nil, fmt.Errorf("failed to remove stale socket %s: %w", addr, err)
+			}
+		}
+	}
+	listener, err := net.Listen(network, addr) // #nosec
+	if err != nil {
+		return nil, err
+	}
+	if network == "unix" && address.GetMode() != 0 {
+		if err := os.Chmod(addr, os.FileMode(address.GetMode())); err != nil {
+			_ = listener.Close()
+			return nil, fmt.Errorf("failed to set the mode of socket %s: %w", addr, err)
+		}
+	}
+	return listener, nil
+}
+
+// Dial connects to the target, which is dialed as a Unix domain socket if prefixed with unix://, and over TCP
+// otherwise.
+func Dial(ctx context.Context, target string) (net.Conn, error) {
+	network, addr := split(target)
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, addr)
+}
+
+// DialOptions returns the options of gRPC clients that dial targets returned by Target. gRPC does not resolve
+// unix:// targets, so they are dialed by Dial, with the local host as authority.
+func DialOptions(target string) []grpc.DialOption {
+	if !strings.HasPrefix(target, UnixPrefix) {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithContextDialer(Dial), grpc.WithAuthority("localhost")}
+}
@@ -0,0 +1,90 @@
+package socket
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestTarget(t *testing.T) {
+	assert.Equal(t, "127.0.0.1:9991", Target(&bootstrapv1.SocketAddress{Address: "127.0.0.1", PortValue: 9991}))
+	assert.Equal(t, "[::1]:9991", Target(&bootstrapv1.SocketAddress{Address: "::1", PortValue: 9991}))
+	assert.Equal(t, "unix:///tmp/relay.sock",
+		Target(&bootstrapv1.SocketAddress{Address: "unix:///tmp/relay.sock", PortValue: 9991}))
+}
+
+func TestListen_Unix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "socket")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "relay.sock")
+	address := &bootstrapv1.SocketAddress{Address: UnixPrefix + path, Mode: 0600}
+
+	// A stale socket left behind by a previous process is replaced.
+	stale, err := net.Listen("unix", path)
+	assert.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	assert.NoError(t, stale.Close())
+
+	listener, err := Listen(address)
+	assert.NoError(t, err)
+	defer listener.Close()
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	accepted := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			err = conn.Close()
+		}
+		accepted <- err
+	}()
+	conn, err := Dial(context.Background(), Target(address))
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Close())
+	assert.NoError(t, <-accepted)
+}
+
+func TestListen_NotSocket(t *testing.T) {
+	file, err := ioutil.TempFile("", "socket")
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+	assert.NoError(t, file.Close())
+
+	// Files other than sockets are not removed.
+	_, err = Listen(&bootstrapv1.SocketAddress{Address: UnixPrefix + file.Name()})
+	assert.Error(t, err)
+	_, err = os.Stat(file.Name())
+	assert.NoError(t, err)
+}
+
+func TestDialOptions(t *testing.T) {
+	assert.Empty(t, DialOptions("127.0.0.1:9991"))
+
+	dir, err := ioutil.TempDir("", "socket")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	address := &bootstrapv1.SocketAddress{Address: UnixPrefix + filepath.Join(dir, "relay.sock")}
+	listener, err := Listen(address)
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	opts := append([]grpc.DialOption{grpc.WithInsecure(), grpc.WithBlock()}, DialOptions(Target(address))...)
+	conn, err := grpc.DialContext(ctx, Target(address), opts...)
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Close())
+}
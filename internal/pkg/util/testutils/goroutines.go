@@ -0,0 +1,41 @@
+package testutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/goroutines"
+	"github.com/stretchr/testify/assert"
+)
+
+// CurrentGoroutines returns the IDs of the running goroutines, as the baseline
+// for AssertNoLeakedGoroutines.
+func CurrentGoroutines() map[string]bool {
+	ids := make(map[string]bool)
+	for _, goroutine := range goroutines.Dump() {
+		ids[goroutine.ID] = true
+	}
+	return ids
+}
+
+// AssertNoLeakedGoroutines fails the test if goroutines that were not running
+// at the baseline are still running a second later, and prints their stacks.
+func AssertNoLeakedGoroutines(t *testing.T, baseline map[string]bool) {
+	var leaked []goroutines.Goroutine
+	deadline := time.Now().Add(time.Second)
+	for {
+		leaked = leaked[:0]
+		for _, goroutine := range goroutines.Dump() {
+			if !baseline[goroutine.ID] {
+				leaked = append(leaked, goroutine)
+			}
+		}
+		if len(leaked) == 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for _, goroutine := range leaked {
+		assert.Fail(t, "leaked goroutine", goroutine.Stack)
+	}
+}
@@ -1,8 +1,15 @@
 package yamlproto
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/socket"
 	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
 	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	overridev1 "github.com/envoyproxy/xds-relay/pkg/api/override/v1"
 	"github.com/ghodss/yaml"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -22,7 +29,8 @@ func fromYAMLToProto(yml string, pb proto.Message) error {
 }
 
 // FromYAMLToKeyerConfiguration unmarshals a YAML string into a KeyerConfiguration and uses the
-// protoc-gen-validate message validator to validate it.
+// protoc-gen-validate message validator to validate it. The regexes of the configuration are compiled as well, so
+// that a configuration with an invalid regex is rejected when it is loaded rather than when requests are mapped.
 func FromYAMLToKeyerConfiguration(yml string, pb *aggregationv1.KeyerConfiguration) error {
 	err := fromYAMLToProto(yml, pb)
 	if err != nil {
@@ -32,10 +40,132 @@ func FromYAMLToKeyerConfiguration(yml string, pb *aggregationv1.KeyerConfigurati
 	if err != nil {
 		return err
 	}
+	return validateKeyerRegexes(pb)
+}
+
+// validateKeyerRegexes compiles the regexes of the rules of the fragments and the tenant of the configuration.
+func validateKeyerRegexes(pb *aggregationv1.KeyerConfiguration) error {
+	fragments := append([]*aggregationv1.KeyerConfiguration_Fragment{pb.GetTenant()}, pb.GetFragments()...)
+	for _, fragment := range fragments {
+		for _, rule := range fragment.GetRules() {
+			if err := validateMatchRegexes(rule.GetMatch()); err != nil {
+				return err
+			}
+			if err := validateResultRegexes(rule.GetResult()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateMatchRegexes(predicate *aggregationv1.MatchPredicate) error {
+	if predicate == nil {
+		return nil
+	}
+	for _, pattern := range []string{
+		predicate.GetRequestNodeMatch().GetRegexMatch(),
+		predicate.GetTransportMatch().GetRegexMatch(),
+	} {
+		if pattern == "" {
+			continue
+		}
+		if err := compileRegex(pattern); err != nil {
+			return err
+		}
+	}
+	if err := validateMatchRegexes(predicate.GetNotMatch()); err != nil {
+		return err
+	}
+	for _, rule := range predicate.GetAndMatch().GetRules() {
+		if err := validateMatchRegexes(rule); err != nil {
+			return err
+		}
+	}
+	for _, rule := range predicate.GetOrMatch().GetRules() {
+		if err := validateMatchRegexes(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateResultRegexes(predicate *aggregationv1.ResultPredicate) error {
+	actions := []*aggregationv1.ResultPredicate_ResultAction{
+		predicate.GetRequestNodeFragment().GetAction(),
+		predicate.GetResourceNamesFragment().GetAction(),
+		predicate.GetTransportFragment().GetAction(),
+	}
+	for _, action := range actions {
+		if regexAction := action.GetRegexAction(); regexAction != nil {
+			if err := compileRegex(regexAction.GetPattern()); err != nil {
+				return err
+			}
+		}
+	}
+	for _, result := range predicate.GetAndResult().GetResultPredicates() {
+		if err := validateResultRegexes(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compileRegex(pattern string) error {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid regex %q: %w", pattern, err)
+	}
 	return nil
 }
 
+// FromYAMLToBootstrapConfiguration unmarshals a YAML or JSON string into a Bootstrap and validates it with
+// ValidateBootstrap.
 func FromYAMLToBootstrapConfiguration(yml string, pb *bootstrapv1.Bootstrap) error {
+	err := fromYAMLToProto(yml, pb)
+	if err != nil {
+		return err
+	}
+	return ValidateBootstrap(pb)
+}
+
+// ValidateBootstrap uses the protoc-gen-validate message validator to validate the bootstrap. Its rules only accept
+// hosts and IPs as socket addresses, so the addresses that may also be Unix domain sockets are validated here when
+// prefixed with unix://, and replaced by a host in the copy of the bootstrap that the message validator validates.
+func ValidateBootstrap(pb *bootstrapv1.Bootstrap) error {
+	validated := pb
+	for i, address := range unixSocketAddresses(pb) {
+		if !strings.HasPrefix(address.GetAddress(), socket.UnixPrefix) {
+			continue
+		}
+		if address.GetAddress() == socket.UnixPrefix {
+			return errors.New("invalid SocketAddress.Address: unix:// must be followed by the path of a Unix domain socket")
+		}
+		if validated == pb {
+			validated = proto.Clone(pb).(*bootstrapv1.Bootstrap)
+		}
+		unixSocketAddresses(validated)[i].Address = "localhost"
+	}
+	return validated.Validate()
+}
+
+// unixSocketAddresses returns the socket addresses of the bootstrap that may be Unix domain sockets: the addresses
+// of the xDS server and its listeners, and of the origin, shadow, and parent relay servers. Unset addresses are nil.
+func unixSocketAddresses(pb *bootstrapv1.Bootstrap) []*bootstrapv1.SocketAddress {
+	addresses := []*bootstrapv1.SocketAddress{
+		pb.GetServer().GetAddress(),
+		pb.GetOriginServer().GetAddress(),
+		pb.GetShadowServer().GetAddress(),
+		pb.GetParentRelay().GetUpstream().GetAddress(),
+	}
+	for _, listener := range pb.GetServer().GetListeners() {
+		addresses = append(addresses, listener.GetAddress())
+	}
+	return addresses
+}
+
+// FromYAMLToOverrides unmarshals a YAML or JSON string into Overrides and uses the protoc-gen-validate message
+// validator to validate it.
+func FromYAMLToOverrides(yml string, pb *overridev1.Overrides) error {
 	err := fromYAMLToProto(yml, pb)
 	if err != nil {
 		return err
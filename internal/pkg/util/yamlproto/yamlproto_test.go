@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 
 	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -79,6 +80,27 @@ var positiveTests = []TableEntry{
 			},
 		},
 	},
+	{
+		Description: "test result predicate containing split_action",
+		Parameters: []interface{}{
+			"split_action.yaml",
+			&ResultPredicate{
+				Type: &aggregationv1.ResultPredicate_RequestNodeFragment_{
+					RequestNodeFragment: &RequestNodeFragment{
+						Field: 1,
+						Action: &ResultAction{
+							Action: &aggregationv1.ResultPredicate_ResultAction_SplitAction_{
+								SplitAction: &aggregationv1.ResultPredicate_ResultAction_SplitAction{
+									Delimiter: "-",
+									Index:     1,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		Description: "test result predicate containing and_result",
 		Parameters: []interface{}{
@@ -224,6 +246,12 @@ var positiveTestsForKeyerConfigurationProto = []TableEntry{
 			"keyer_configuration_request_node_match_string_fragment.yaml",
 		},
 	},
+	{
+		Description: "should be able to load valid KeyerConfiguration containing nested and_match and not_match",
+		Parameters: []interface{}{
+			"keyer_configuration_nested_match.yaml",
+		},
+	},
 }
 
 var negativeTestsForKeyerConfigurationProto = []TableEntry{
@@ -311,6 +339,48 @@ var negativeTestsForKeyerConfigurationProto = []TableEntry{
 				"by: invalid MatchPredicate_RequestNodeMatch.Field: value must be one of the defined enum values",
 		},
 	},
+	{
+		Description: "request_node_match containing an invalid regex",
+		Parameters: []interface{}{
+			"keyer_configuration_invalid_regex_match.yaml",
+			"invalid regex \"cluster-(\": error parsing regexp: missing closing ): `cluster-(`",
+		},
+	},
+	{
+		Description: "regex_action in an and_result containing an invalid regex",
+		Parameters: []interface{}{
+			"keyer_configuration_invalid_regex_action.yaml",
+			"invalid regex \"[a-z\": error parsing regexp: missing closing ]: `[a-z`",
+		},
+	},
+}
+
+var positiveTestsForBootstrapProto = []TableEntry{
+	{
+		Description: "hosts and ips",
+		Parameters:  []interface{}{"bootstrap_complete.yaml"},
+	},
+	{
+		Description: "unix domain sockets for the server and origin server",
+		Parameters:  []interface{}{"bootstrap_unix_sockets.yaml"},
+	},
+}
+
+var negativeTestsForBootstrapProto = []TableEntry{
+	{
+		Description: "unix domain socket for the admin server",
+		Parameters: []interface{}{
+			"bootstrap_unix_socket_admin.yaml",
+			"invalid SocketAddress.Address: value must be a valid hostname, or ip address",
+		},
+	},
+	{
+		Description: "unix domain socket without a path",
+		Parameters: []interface{}{
+			"bootstrap_unix_socket_missing_path.yaml",
+			"invalid SocketAddress.Address: unix:// must be followed by the path of a Unix domain socket",
+		},
+	},
 }
 
 var _ = Describe("yamlproto tests", func() {
@@ -359,4 +429,37 @@ var _ = Describe("yamlproto tests", func() {
 			Expect(err.Error()).To(Equal(expectedErrorMessage))
 		},
 		negativeTestsForKeyerConfigurationProto...)
+
+	DescribeTable("should load and validate Bootstrap",
+		func(ymlFixtureFilename string) {
+			ymlBytes, err := ioutil.ReadFile(fmt.Sprintf("testdata/%s", ymlFixtureFilename))
+			Expect(err).To(BeNil())
+
+			var bootstrap bootstrapv1.Bootstrap
+			err = FromYAMLToBootstrapConfiguration(string(ymlBytes), &bootstrap)
+			Expect(err).To(BeNil())
+		},
+		positiveTestsForBootstrapProto...)
+
+	DescribeTable("should load yaml but validation for Bootstrap should fail",
+		func(ymlFixtureFilename string, expectedErrorMessage string) {
+			ymlBytes, err := ioutil.ReadFile(fmt.Sprintf("testdata/%s", ymlFixtureFilename))
+			Expect(err).To(BeNil())
+
+			var bootstrap bootstrapv1.Bootstrap
+			err = FromYAMLToBootstrapConfiguration(string(ymlBytes), &bootstrap)
+			Expect(err.Error()).Should(HaveSuffix(expectedErrorMessage))
+		},
+		negativeTestsForBootstrapProto...)
+
+	It("should not replace the unix domain sockets of the validated Bootstrap", func() {
+		ymlBytes, err := ioutil.ReadFile("testdata/bootstrap_unix_sockets.yaml")
+		Expect(err).To(BeNil())
+
+		var bootstrap bootstrapv1.Bootstrap
+		err = FromYAMLToBootstrapConfiguration(string(ymlBytes), &bootstrap)
+		Expect(err).To(BeNil())
+		Expect(bootstrap.GetServer().GetAddress().GetAddress()).To(Equal("unix:///var/run/xds-relay.sock"))
+		Expect(bootstrap.GetOriginServer().GetAddress().GetAddress()).To(Equal("unix:///var/run/control-plane.sock"))
+	})
 })
@@ -0,0 +1,73 @@
+// Package version reports the build of the relay, and fingerprints the configuration it runs with, so that operators
+// can verify which build and configuration generation each replica is running.
+//
+// The build is set at link time, e.g.
+//
+//	go build -ldflags "-X github.com/envoyproxy/xds-relay/internal/pkg/version.Version=v1.0.0"
+package version
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"runtime"
+
+	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/proto"
+)
+
+// Version, GitSHA and BuildTime describe the build, and are set at link time.
+var (
+	Version   = "dev"
+	GitSHA    = "unknown"
+	BuildTime = "unknown"
+)
+
+// Info is the build of the relay, and the fingerprints of its configuration.
+type Info struct {
+	Version   string
+	GitSHA    string
+	BuildTime string
+	GoVersion string
+	// BootstrapFingerprint and AggregationFingerprint are the SHA-256 of the
+	// deterministically marshaled bootstrap and aggregation configurations.
+	BootstrapFingerprint   string `json:",omitempty"`
+	AggregationFingerprint string `json:",omitempty"`
+}
+
+// Get returns the build of the relay.
+func Get() Info {
+	return Info{Version: Version, GitSHA: GitSHA, BuildTime: BuildTime, GoVersion: runtime.Version()}
+}
+
+// WithConfigs returns the build of the relay, with the fingerprints of the bootstrap and aggregation configurations.
+// The fingerprint of a nil configuration is empty.
+func WithConfigs(
+	bootstrapConfig *bootstrapv1.Bootstrap,
+	aggregationConfig *aggregationv1.KeyerConfiguration,
+) (Info, error) {
+	info := Get()
+	var err error
+	if bootstrapConfig != nil {
+		if info.BootstrapFingerprint, err = Fingerprint(bootstrapConfig); err != nil {
+			return info, err
+		}
+	}
+	if aggregationConfig != nil {
+		if info.AggregationFingerprint, err = Fingerprint(aggregationConfig); err != nil {
+			return info, err
+		}
+	}
+	return info, nil
+}
+
+// Fingerprint returns the SHA-256 of the deterministically marshaled configuration.
+func Fingerprint(config proto.Message) (string, error) {
+	buffer := proto.NewBuffer(nil)
+	buffer.SetDeterministic(true)
+	if err := buffer.Marshal(config); err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(buffer.Bytes())
+	return hex.EncodeToString(hash[:]), nil
+}
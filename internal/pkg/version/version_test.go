@@ -0,0 +1,44 @@
+package version
+
+import (
+	"testing"
+
+	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	newConfig := func(port uint32) *bootstrapv1.Bootstrap {
+		return &bootstrapv1.Bootstrap{
+			Server: &bootstrapv1.Server{Address: &bootstrapv1.SocketAddress{Address: "0.0.0.0", PortValue: port}},
+		}
+	}
+
+	fingerprint, err := Fingerprint(newConfig(9991))
+	assert.NoError(t, err)
+	assert.Equal(t, 64, len(fingerprint))
+	same, err := Fingerprint(newConfig(9991))
+	assert.NoError(t, err)
+	assert.Equal(t, fingerprint, same)
+	other, err := Fingerprint(newConfig(9992))
+	assert.NoError(t, err)
+	assert.NotEqual(t, fingerprint, other)
+}
+
+func TestWithConfigs(t *testing.T) {
+	info, err := WithConfigs(&bootstrapv1.Bootstrap{}, &aggregationv1.KeyerConfiguration{})
+	assert.NoError(t, err)
+	assert.Equal(t, Version, info.Version)
+	assert.Equal(t, GitSHA, info.GitSHA)
+	assert.Equal(t, BuildTime, info.BuildTime)
+	assert.NotEmpty(t, info.GoVersion)
+	// Empty configurations marshal to no bytes.
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", info.BootstrapFingerprint)
+	assert.Equal(t, info.BootstrapFingerprint, info.AggregationFingerprint)
+
+	// Embedders that provide their own mapper may run without aggregation rules.
+	info, err = WithConfigs(&bootstrapv1.Bootstrap{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "", info.AggregationFingerprint)
+}
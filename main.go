@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
 	"log"
+	"time"
 
+	"github.com/envoyproxy/xds-relay/internal/app/client"
 	"github.com/envoyproxy/xds-relay/internal/app/server"
+	relaylog "github.com/envoyproxy/xds-relay/internal/pkg/log"
 	yamlproto "github.com/envoyproxy/xds-relay/internal/pkg/util/yamlproto"
 	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
 	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
@@ -41,13 +46,62 @@ var (
 			}
 
 			switch mode {
-			case "serve", "validate":
+			case "serve", "validate", "dry-run":
 				server.Run(&bootstrapConfig, &aggregationRulesConfig, logLevel, mode)
 			default:
 				log.Fatal("unrecognized mode provided: ", mode)
 			}
 		},
 	}
+
+	fetchOptions  client.FetchOptions
+	fetchLogLevel string
+	outputFormat  string
+
+	clientCmd = &cobra.Command{
+		Use:   "client",
+		Short: "Query an xDS server",
+	}
+
+	fetchCmd = &cobra.Command{
+		Use:   "fetch",
+		Short: "Send a discovery request and print the first response",
+		Run: func(cmd *cobra.Command, args []string) {
+			resp, err := client.Fetch(context.Background(), relaylog.New(fetchLogLevel), fetchOptions)
+			if err != nil {
+				log.Fatal("failed to fetch: ", err)
+			}
+			output, err := client.Format(resp, outputFormat)
+			if err != nil {
+				log.Fatal("failed to format response: ", err)
+			}
+			fmt.Println(output)
+		},
+	}
+
+	inspectOptions      client.InspectOptions
+	inspectOutputFormat string
+
+	cacheCmd = &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect the cache of a relay",
+	}
+
+	inspectCmd = &cobra.Command{
+		Use:   "inspect",
+		Short: "Print a cache entry with its resources decoded",
+		Run: func(cmd *cobra.Command, args []string) {
+			entry, err := client.Inspect(context.Background(), inspectOptions)
+			if err != nil {
+				log.Fatal("failed to read cache entry: ", err)
+			}
+			output, err := client.FormatCacheEntry(entry, inspectOutputFormat)
+			if err != nil {
+				log.Fatal("failed to format cache entry: ", err)
+			}
+			fmt.Println(output)
+		},
+	}
 )
 
 func main() {
@@ -57,13 +111,46 @@ func main() {
 	bootstrapCmd.Flags().StringVarP(&logLevel, "log-level", "l", "", "the logging level")
 	bootstrapCmd.Flags().StringVarP(&mode, "mode", "m", "serve",
 		"operating mode. Set to 'serve' by default to validate user-entered configs and start the server. "+
-			"Set to 'validate' to only validate user-entered configs.")
+			"Set to 'validate' to only validate user-entered configs. "+
+			"Set to 'dry-run' to subscribe upstream for the configured dry run subscriptions without serving "+
+			"downstream.")
 	if err := bootstrapCmd.MarkFlagRequired("config-file"); err != nil {
 		log.Fatal("Could not mark the config-file flag as required: ", err)
 	}
 	if err := bootstrapCmd.MarkFlagRequired("aggregation-rules"); err != nil {
 		log.Fatal("Could not mark the aggregation-rules flag as required: ", err)
 	}
+
+	fetchCmd.Flags().StringVarP(&fetchOptions.ServerAddress, "server", "s", "localhost:9991",
+		"address of the xDS server")
+	fetchCmd.Flags().StringVarP(&fetchOptions.ResourceType, "type", "t", "",
+		"resource type: listener, cluster, route, endpoint, runtime, or a type URL")
+	fetchCmd.Flags().StringVarP(&fetchOptions.NodeID, "node-id", "n", "", "node ID sent in the discovery request")
+	fetchCmd.Flags().StringVar(&fetchOptions.NodeCluster, "node-cluster", "",
+		"node cluster sent in the discovery request")
+	fetchCmd.Flags().StringSliceVarP(&fetchOptions.ResourceNames, "resource-names", "r", nil,
+		"resource names to request. All resources are requested if unset")
+	fetchCmd.Flags().DurationVar(&fetchOptions.Timeout, "timeout", 10*time.Second,
+		"time to wait for a response")
+	fetchCmd.Flags().StringVarP(&outputFormat, "output", "o", "json", "output format: json or yaml")
+	fetchCmd.Flags().StringVarP(&fetchLogLevel, "log-level", "l", "error", "the logging level")
+	if err := fetchCmd.MarkFlagRequired("type"); err != nil {
+		log.Fatal("Could not mark the type flag as required: ", err)
+	}
+	clientCmd.AddCommand(fetchCmd)
+	bootstrapCmd.AddCommand(clientCmd)
+
+	inspectCmd.Flags().StringVarP(&inspectOptions.AdminAddress, "admin", "a", "localhost:6070",
+		"address of the admin server of the relay")
+	inspectCmd.Flags().StringVarP(&inspectOptions.Key, "key", "k", "", "aggregated key of the cache entry")
+	inspectCmd.Flags().StringVarP(&inspectOptions.File, "file", "f", "",
+		"file holding a cache entry dumped from the admin server. The admin server is not queried if set")
+	inspectCmd.Flags().DurationVar(&inspectOptions.Timeout, "timeout", 10*time.Second,
+		"time to wait for the admin server")
+	inspectCmd.Flags().StringVarP(&inspectOutputFormat, "output", "o", "yaml", "output format: json or yaml")
+	cacheCmd.AddCommand(inspectCmd)
+	bootstrapCmd.AddCommand(cacheCmd)
+
 	if err := bootstrapCmd.Execute(); err != nil {
 		log.Fatal("Issue parsing command line: ", err)
 	}
@@ -81,7 +81,7 @@ func (NodeFieldType) EnumDescriptor() ([]byte, []int) {
 	return file_aggregation_v1_aggregation_proto_rawDescGZIP(), []int{0}
 }
 
-// [#next-free-field: 2]
+// [#next-free-field: 4]
 type KeyerConfiguration struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -89,6 +89,15 @@ type KeyerConfiguration struct {
 
 	// Fragments are the pieces that form a cache key.
 	Fragments []*KeyerConfiguration_Fragment `protobuf:"bytes,1,rep,name=fragments,proto3" json:"fragments,omitempty"`
+	// The tenant of a request, for relays that serve multiple isolated fleets.
+	// If set, every cache key is prefixed with the tenant and a "/" separator,
+	// so that requests of different tenants never share a cache key. The first
+	// rule that matches determines the tenant, and requests that match no rule
+	// cannot be mapped to a key.
+	Tenant *KeyerConfiguration_Fragment `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// The behavior for requests that match no rule. Such requests are passed
+	// through without aggregation if unset.
+	Fallback *KeyerConfiguration_Fallback `protobuf:"bytes,3,opt,name=fallback,proto3" json:"fallback,omitempty"`
 }
 
 func (x *KeyerConfiguration) Reset() {
@@ -130,9 +139,23 @@ func (x *KeyerConfiguration) GetFragments() []*KeyerConfiguration_Fragment {
 	return nil
 }
 
+func (x *KeyerConfiguration) GetTenant() *KeyerConfiguration_Fragment {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+func (x *KeyerConfiguration) GetFallback() *KeyerConfiguration_Fallback {
+	if x != nil {
+		return x.Fallback
+	}
+	return nil
+}
+
 // This is a recursive structure which allows complex nested match
 // configurations to be built using various logical operators.
-// [#next-free-field: 7]
+// [#next-free-field: 8]
 type MatchPredicate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -145,6 +168,7 @@ type MatchPredicate struct {
 	//	*MatchPredicate_AnyMatch
 	//	*MatchPredicate_RequestTypeMatch_
 	//	*MatchPredicate_RequestNodeMatch_
+	//	*MatchPredicate_TransportMatch_
 	Type isMatchPredicate_Type `protobuf_oneof:"type"`
 }
 
@@ -229,25 +253,36 @@ func (x *MatchPredicate) GetRequestNodeMatch() *MatchPredicate_RequestNodeMatch
 	return nil
 }
 
+func (x *MatchPredicate) GetTransportMatch() *MatchPredicate_TransportMatch {
+	if x, ok := x.GetType().(*MatchPredicate_TransportMatch_); ok {
+		return x.TransportMatch
+	}
+	return nil
+}
+
 type isMatchPredicate_Type interface {
 	isMatchPredicate_Type()
 }
 
 type MatchPredicate_AndMatch struct {
-	// A set that describes a logical AND. If any member of the set
-	// matches, the match configuration matches.
+	// A set that describes a logical AND. If all members of the set
+	// match, the match configuration matches. Members are evaluated in
+	// order, and evaluation stops at the first member that does not match.
 	AndMatch *MatchPredicate_MatchSet `protobuf:"bytes,1,opt,name=and_match,json=andMatch,proto3,oneof"`
 }
 
 type MatchPredicate_OrMatch struct {
-	// A set that describes a logical OR. If all members of the set
-	// match, the match configuration matches.
+	// A set that describes a logical OR. If any member of the set
+	// matches, the match configuration matches. Members are evaluated in
+	// order, and evaluation stops at the first member that matches.
 	OrMatch *MatchPredicate_MatchSet `protobuf:"bytes,2,opt,name=or_match,json=orMatch,proto3,oneof"`
 }
 
 type MatchPredicate_NotMatch struct {
 	// A negation match. The match configuration will match if the
-	// negated match condition matches.
+	// negated match condition does not match. Sets and negations can be
+	// nested, e.g. to match EDS requests of a cluster except from canary
+	// nodes.
 	NotMatch *MatchPredicate `protobuf:"bytes,3,opt,name=not_match,json=notMatch,proto3,oneof"`
 }
 
@@ -267,6 +302,13 @@ type MatchPredicate_RequestNodeMatch_ struct {
 	RequestNodeMatch *MatchPredicate_RequestNodeMatch `protobuf:"bytes,6,opt,name=request_node_match,json=requestNodeMatch,proto3,oneof"`
 }
 
+type MatchPredicate_TransportMatch_ struct {
+	// Match on a transport attribute of the request's downstream stream,
+	// e.g. to aggregate on the identity that the client authenticated with
+	// rather than on the node it claims to be.
+	TransportMatch *MatchPredicate_TransportMatch `protobuf:"bytes,7,opt,name=transport_match,json=transportMatch,proto3,oneof"`
+}
+
 func (*MatchPredicate_AndMatch) isMatchPredicate_Type() {}
 
 func (*MatchPredicate_OrMatch) isMatchPredicate_Type() {}
@@ -279,9 +321,11 @@ func (*MatchPredicate_RequestTypeMatch_) isMatchPredicate_Type() {}
 
 func (*MatchPredicate_RequestNodeMatch_) isMatchPredicate_Type() {}
 
+func (*MatchPredicate_TransportMatch_) isMatchPredicate_Type() {}
+
 // Rules for how to generate the resulting fragment of the xDS Aggregator cache
 // key.
-// [#next-free-field: 5]
+// [#next-free-field: 7]
 type ResultPredicate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -292,6 +336,8 @@ type ResultPredicate struct {
 	//	*ResultPredicate_RequestNodeFragment_
 	//	*ResultPredicate_ResourceNamesFragment_
 	//	*ResultPredicate_StringFragment
+	//	*ResultPredicate_Passthrough
+	//	*ResultPredicate_TransportFragment_
 	Type isResultPredicate_Type `protobuf_oneof:"type"`
 }
 
@@ -362,6 +408,20 @@ func (x *ResultPredicate) GetStringFragment() string {
 	return ""
 }
 
+func (x *ResultPredicate) GetPassthrough() bool {
+	if x, ok := x.GetType().(*ResultPredicate_Passthrough); ok {
+		return x.Passthrough
+	}
+	return false
+}
+
+func (x *ResultPredicate) GetTransportFragment() *ResultPredicate_TransportFragment {
+	if x, ok := x.GetType().(*ResultPredicate_TransportFragment_); ok {
+		return x.TransportFragment
+	}
+	return nil
+}
+
 type isResultPredicate_Type interface {
 	isResultPredicate_Type()
 }
@@ -387,6 +447,21 @@ type ResultPredicate_StringFragment struct {
 	StringFragment string `protobuf:"bytes,4,opt,name=string_fragment,json=stringFragment,proto3,oneof"`
 }
 
+type ResultPredicate_Passthrough struct {
+	// Marks matching requests as not aggregated. Each node is served by a
+	// dedicated upstream stream and cache entry per resource type, under a
+	// key of the form "unaggregated_<node id>_<type URL>", which is useful
+	// for node scoped resources. Takes precedence over the results of all
+	// other rules, and only applies as the result of a rule.
+	Passthrough bool `protobuf:"varint,5,opt,name=passthrough,proto3,oneof"`
+}
+
+type ResultPredicate_TransportFragment_ struct {
+	// A fragment generated from a transport attribute of the request's
+	// downstream stream.
+	TransportFragment *ResultPredicate_TransportFragment `protobuf:"bytes,6,opt,name=transport_fragment,json=transportFragment,proto3,oneof"`
+}
+
 func (*ResultPredicate_AndResult_) isResultPredicate_Type() {}
 
 func (*ResultPredicate_RequestNodeFragment_) isResultPredicate_Type() {}
@@ -395,6 +470,10 @@ func (*ResultPredicate_ResourceNamesFragment_) isResultPredicate_Type() {}
 
 func (*ResultPredicate_StringFragment) isResultPredicate_Type() {}
 
+func (*ResultPredicate_Passthrough) isResultPredicate_Type() {}
+
+func (*ResultPredicate_TransportFragment_) isResultPredicate_Type() {}
+
 // [#next-free-field: 2]
 type KeyerConfiguration_Fragment struct {
 	state         protoimpl.MessageState
@@ -444,6 +523,110 @@ func (x *KeyerConfiguration_Fragment) GetRules() []*KeyerConfiguration_Fragment_
 	return nil
 }
 
+// The behavior for requests that match no rule of any fragment.
+// [#next-free-field: 4]
+type KeyerConfiguration_Fallback struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Behavior:
+	//	*KeyerConfiguration_Fallback_DefaultKey
+	//	*KeyerConfiguration_Fallback_Passthrough
+	//	*KeyerConfiguration_Fallback_Reject
+	Behavior isKeyerConfiguration_Fallback_Behavior `protobuf_oneof:"behavior"`
+}
+
+func (x *KeyerConfiguration_Fallback) Reset() {
+	*x = KeyerConfiguration_Fallback{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregation_v1_aggregation_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyerConfiguration_Fallback) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyerConfiguration_Fallback) ProtoMessage() {}
+
+func (x *KeyerConfiguration_Fallback) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregation_v1_aggregation_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyerConfiguration_Fallback.ProtoReflect.Descriptor instead.
+func (*KeyerConfiguration_Fallback) Descriptor() ([]byte, []int) {
+	return file_aggregation_v1_aggregation_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (m *KeyerConfiguration_Fallback) GetBehavior() isKeyerConfiguration_Fallback_Behavior {
+	if m != nil {
+		return m.Behavior
+	}
+	return nil
+}
+
+func (x *KeyerConfiguration_Fallback) GetDefaultKey() string {
+	if x, ok := x.GetBehavior().(*KeyerConfiguration_Fallback_DefaultKey); ok {
+		return x.DefaultKey
+	}
+	return ""
+}
+
+func (x *KeyerConfiguration_Fallback) GetPassthrough() bool {
+	if x, ok := x.GetBehavior().(*KeyerConfiguration_Fallback_Passthrough); ok {
+		return x.Passthrough
+	}
+	return false
+}
+
+func (x *KeyerConfiguration_Fallback) GetReject() bool {
+	if x, ok := x.GetBehavior().(*KeyerConfiguration_Fallback_Reject); ok {
+		return x.Reject
+	}
+	return false
+}
+
+type isKeyerConfiguration_Fallback_Behavior interface {
+	isKeyerConfiguration_Fallback_Behavior()
+}
+
+type KeyerConfiguration_Fallback_DefaultKey struct {
+	// Maps the request to the default key, followed by the "_" separator
+	// and the name of the requested resource type, e.g.
+	// "default_envoy.api.v2.Cluster", so that requests of different types
+	// never share a key. The key is prefixed with the tenant, if one is
+	// configured.
+	DefaultKey string `protobuf:"bytes,1,opt,name=default_key,json=defaultKey,proto3,oneof"`
+}
+
+type KeyerConfiguration_Fallback_Passthrough struct {
+	// Serves the request without aggregation, under a key that is unique
+	// to its node and type.
+	Passthrough bool `protobuf:"varint,2,opt,name=passthrough,proto3,oneof"`
+}
+
+type KeyerConfiguration_Fallback_Reject struct {
+	// Rejects the request, closing the stream of the client.
+	Reject bool `protobuf:"varint,3,opt,name=reject,proto3,oneof"`
+}
+
+func (*KeyerConfiguration_Fallback_DefaultKey) isKeyerConfiguration_Fallback_Behavior() {}
+
+func (*KeyerConfiguration_Fallback_Passthrough) isKeyerConfiguration_Fallback_Behavior() {}
+
+func (*KeyerConfiguration_Fallback_Reject) isKeyerConfiguration_Fallback_Behavior() {}
+
 // A rule defining how to match a Envoy request and what resulting
 // fragment to generate.
 // [#next-free-field: 3]
@@ -461,7 +644,7 @@ type KeyerConfiguration_Fragment_Rule struct {
 func (x *KeyerConfiguration_Fragment_Rule) Reset() {
 	*x = KeyerConfiguration_Fragment_Rule{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_aggregation_v1_aggregation_proto_msgTypes[4]
+		mi := &file_aggregation_v1_aggregation_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -474,7 +657,7 @@ func (x *KeyerConfiguration_Fragment_Rule) String() string {
 func (*KeyerConfiguration_Fragment_Rule) ProtoMessage() {}
 
 func (x *KeyerConfiguration_Fragment_Rule) ProtoReflect() protoreflect.Message {
-	mi := &file_aggregation_v1_aggregation_proto_msgTypes[4]
+	mi := &file_aggregation_v1_aggregation_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -519,7 +702,7 @@ type MatchPredicate_RequestTypeMatch struct {
 func (x *MatchPredicate_RequestTypeMatch) Reset() {
 	*x = MatchPredicate_RequestTypeMatch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_aggregation_v1_aggregation_proto_msgTypes[5]
+		mi := &file_aggregation_v1_aggregation_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -532,7 +715,7 @@ func (x *MatchPredicate_RequestTypeMatch) String() string {
 func (*MatchPredicate_RequestTypeMatch) ProtoMessage() {}
 
 func (x *MatchPredicate_RequestTypeMatch) ProtoReflect() protoreflect.Message {
-	mi := &file_aggregation_v1_aggregation_proto_msgTypes[5]
+	mi := &file_aggregation_v1_aggregation_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -573,7 +756,7 @@ type MatchPredicate_RequestNodeMatch struct {
 func (x *MatchPredicate_RequestNodeMatch) Reset() {
 	*x = MatchPredicate_RequestNodeMatch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_aggregation_v1_aggregation_proto_msgTypes[6]
+		mi := &file_aggregation_v1_aggregation_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -586,7 +769,7 @@ func (x *MatchPredicate_RequestNodeMatch) String() string {
 func (*MatchPredicate_RequestNodeMatch) ProtoMessage() {}
 
 func (x *MatchPredicate_RequestNodeMatch) ProtoReflect() protoreflect.Message {
-	mi := &file_aggregation_v1_aggregation_proto_msgTypes[6]
+	mi := &file_aggregation_v1_aggregation_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -650,6 +833,103 @@ func (*MatchPredicate_RequestNodeMatch_ExactMatch) isMatchPredicate_RequestNodeM
 
 func (*MatchPredicate_RequestNodeMatch_RegexMatch) isMatchPredicate_RequestNodeMatch_Type() {}
 
+// Match on a transport attribute of the request's downstream stream.
+// [#next-free-field: 4]
+type MatchPredicate_TransportMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The transport key: the name of a gRPC metadata header sent by the
+	// downstream client, e.g. "x-tenant", or ":uri_san" and ":dns_san" for the
+	// URI and DNS SANs of its TLS client certificate. Header names are case
+	// insensitive. The predicate matches if any value of the key matches, and
+	// does not match if the key has no value.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Types that are assignable to Type:
+	//	*MatchPredicate_TransportMatch_ExactMatch
+	//	*MatchPredicate_TransportMatch_RegexMatch
+	Type isMatchPredicate_TransportMatch_Type `protobuf_oneof:"type"`
+}
+
+func (x *MatchPredicate_TransportMatch) Reset() {
+	*x = MatchPredicate_TransportMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregation_v1_aggregation_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatchPredicate_TransportMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchPredicate_TransportMatch) ProtoMessage() {}
+
+func (x *MatchPredicate_TransportMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregation_v1_aggregation_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchPredicate_TransportMatch.ProtoReflect.Descriptor instead.
+func (*MatchPredicate_TransportMatch) Descriptor() ([]byte, []int) {
+	return file_aggregation_v1_aggregation_proto_rawDescGZIP(), []int{1, 2}
+}
+
+func (x *MatchPredicate_TransportMatch) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (m *MatchPredicate_TransportMatch) GetType() isMatchPredicate_TransportMatch_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (x *MatchPredicate_TransportMatch) GetExactMatch() string {
+	if x, ok := x.GetType().(*MatchPredicate_TransportMatch_ExactMatch); ok {
+		return x.ExactMatch
+	}
+	return ""
+}
+
+func (x *MatchPredicate_TransportMatch) GetRegexMatch() string {
+	if x, ok := x.GetType().(*MatchPredicate_TransportMatch_RegexMatch); ok {
+		return x.RegexMatch
+	}
+	return ""
+}
+
+type isMatchPredicate_TransportMatch_Type interface {
+	isMatchPredicate_TransportMatch_Type()
+}
+
+type MatchPredicate_TransportMatch_ExactMatch struct {
+	// Match on the exact string value.
+	ExactMatch string `protobuf:"bytes,2,opt,name=exact_match,json=exactMatch,proto3,oneof"`
+}
+
+type MatchPredicate_TransportMatch_RegexMatch struct {
+	// Match on a regex pattern.
+	RegexMatch string `protobuf:"bytes,3,opt,name=regex_match,json=regexMatch,proto3,oneof"`
+}
+
+func (*MatchPredicate_TransportMatch_ExactMatch) isMatchPredicate_TransportMatch_Type() {}
+
+func (*MatchPredicate_TransportMatch_RegexMatch) isMatchPredicate_TransportMatch_Type() {}
+
 // A set of match configurations used for logical operations.
 // [#next-free-field: 2]
 type MatchPredicate_MatchSet struct {
@@ -664,7 +944,7 @@ type MatchPredicate_MatchSet struct {
 func (x *MatchPredicate_MatchSet) Reset() {
 	*x = MatchPredicate_MatchSet{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_aggregation_v1_aggregation_proto_msgTypes[7]
+		mi := &file_aggregation_v1_aggregation_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -677,7 +957,7 @@ func (x *MatchPredicate_MatchSet) String() string {
 func (*MatchPredicate_MatchSet) ProtoMessage() {}
 
 func (x *MatchPredicate_MatchSet) ProtoReflect() protoreflect.Message {
-	mi := &file_aggregation_v1_aggregation_proto_msgTypes[7]
+	mi := &file_aggregation_v1_aggregation_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -690,7 +970,7 @@ func (x *MatchPredicate_MatchSet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchPredicate_MatchSet.ProtoReflect.Descriptor instead.
 func (*MatchPredicate_MatchSet) Descriptor() ([]byte, []int) {
-	return file_aggregation_v1_aggregation_proto_rawDescGZIP(), []int{1, 2}
+	return file_aggregation_v1_aggregation_proto_rawDescGZIP(), []int{1, 3}
 }
 
 func (x *MatchPredicate_MatchSet) GetRules() []*MatchPredicate {
@@ -708,13 +988,16 @@ type ResultPredicate_ResultAction struct {
 	// Types that are assignable to Action:
 	//	*ResultPredicate_ResultAction_Exact
 	//	*ResultPredicate_ResultAction_RegexAction_
+	//	*ResultPredicate_ResultAction_ToLower
+	//	*ResultPredicate_ResultAction_TrimPrefix
+	//	*ResultPredicate_ResultAction_SplitAction_
 	Action isResultPredicate_ResultAction_Action `protobuf_oneof:"action"`
 }
 
 func (x *ResultPredicate_ResultAction) Reset() {
 	*x = ResultPredicate_ResultAction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_aggregation_v1_aggregation_proto_msgTypes[8]
+		mi := &file_aggregation_v1_aggregation_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -727,7 +1010,7 @@ func (x *ResultPredicate_ResultAction) String() string {
 func (*ResultPredicate_ResultAction) ProtoMessage() {}
 
 func (x *ResultPredicate_ResultAction) ProtoReflect() protoreflect.Message {
-	mi := &file_aggregation_v1_aggregation_proto_msgTypes[8]
+	mi := &file_aggregation_v1_aggregation_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -764,6 +1047,27 @@ func (x *ResultPredicate_ResultAction) GetRegexAction() *ResultPredicate_ResultA
 	return nil
 }
 
+func (x *ResultPredicate_ResultAction) GetToLower() bool {
+	if x, ok := x.GetAction().(*ResultPredicate_ResultAction_ToLower); ok {
+		return x.ToLower
+	}
+	return false
+}
+
+func (x *ResultPredicate_ResultAction) GetTrimPrefix() string {
+	if x, ok := x.GetAction().(*ResultPredicate_ResultAction_TrimPrefix); ok {
+		return x.TrimPrefix
+	}
+	return ""
+}
+
+func (x *ResultPredicate_ResultAction) GetSplitAction() *ResultPredicate_ResultAction_SplitAction {
+	if x, ok := x.GetAction().(*ResultPredicate_ResultAction_SplitAction_); ok {
+		return x.SplitAction
+	}
+	return nil
+}
+
 type isResultPredicate_ResultAction_Action interface {
 	isResultPredicate_ResultAction_Action()
 }
@@ -778,10 +1082,32 @@ type ResultPredicate_ResultAction_RegexAction_ struct {
 	RegexAction *ResultPredicate_ResultAction_RegexAction `protobuf:"bytes,2,opt,name=regex_action,json=regexAction,proto3,oneof"`
 }
 
+type ResultPredicate_ResultAction_ToLower struct {
+	// Uses the lowercase value of the field.
+	ToLower bool `protobuf:"varint,3,opt,name=to_lower,json=toLower,proto3,oneof"`
+}
+
+type ResultPredicate_ResultAction_TrimPrefix struct {
+	// Uses the value of the field without the prefix. The value is used
+	// as is if it does not start with the prefix.
+	TrimPrefix string `protobuf:"bytes,4,opt,name=trim_prefix,json=trimPrefix,proto3,oneof"`
+}
+
+type ResultPredicate_ResultAction_SplitAction_ struct {
+	// Uses an element of the field split by a delimiter.
+	SplitAction *ResultPredicate_ResultAction_SplitAction `protobuf:"bytes,5,opt,name=split_action,json=splitAction,proto3,oneof"`
+}
+
 func (*ResultPredicate_ResultAction_Exact) isResultPredicate_ResultAction_Action() {}
 
 func (*ResultPredicate_ResultAction_RegexAction_) isResultPredicate_ResultAction_Action() {}
 
+func (*ResultPredicate_ResultAction_ToLower) isResultPredicate_ResultAction_Action() {}
+
+func (*ResultPredicate_ResultAction_TrimPrefix) isResultPredicate_ResultAction_Action() {}
+
+func (*ResultPredicate_ResultAction_SplitAction_) isResultPredicate_ResultAction_Action() {}
+
 type ResultPredicate_AndResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -793,7 +1119,7 @@ type ResultPredicate_AndResult struct {
 func (x *ResultPredicate_AndResult) Reset() {
 	*x = ResultPredicate_AndResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_aggregation_v1_aggregation_proto_msgTypes[9]
+		mi := &file_aggregation_v1_aggregation_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -806,7 +1132,7 @@ func (x *ResultPredicate_AndResult) String() string {
 func (*ResultPredicate_AndResult) ProtoMessage() {}
 
 func (x *ResultPredicate_AndResult) ProtoReflect() protoreflect.Message {
-	mi := &file_aggregation_v1_aggregation_proto_msgTypes[9]
+	mi := &file_aggregation_v1_aggregation_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -843,7 +1169,7 @@ type ResultPredicate_RequestNodeFragment struct {
 func (x *ResultPredicate_RequestNodeFragment) Reset() {
 	*x = ResultPredicate_RequestNodeFragment{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_aggregation_v1_aggregation_proto_msgTypes[10]
+		mi := &file_aggregation_v1_aggregation_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -856,7 +1182,7 @@ func (x *ResultPredicate_RequestNodeFragment) String() string {
 func (*ResultPredicate_RequestNodeFragment) ProtoMessage() {}
 
 func (x *ResultPredicate_RequestNodeFragment) ProtoReflect() protoreflect.Message {
-	mi := &file_aggregation_v1_aggregation_proto_msgTypes[10]
+	mi := &file_aggregation_v1_aggregation_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -902,7 +1228,7 @@ type ResultPredicate_ResourceNamesFragment struct {
 func (x *ResultPredicate_ResourceNamesFragment) Reset() {
 	*x = ResultPredicate_ResourceNamesFragment{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_aggregation_v1_aggregation_proto_msgTypes[11]
+		mi := &file_aggregation_v1_aggregation_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -915,7 +1241,7 @@ func (x *ResultPredicate_ResourceNamesFragment) String() string {
 func (*ResultPredicate_ResourceNamesFragment) ProtoMessage() {}
 
 func (x *ResultPredicate_ResourceNamesFragment) ProtoReflect() protoreflect.Message {
-	mi := &file_aggregation_v1_aggregation_proto_msgTypes[11]
+	mi := &file_aggregation_v1_aggregation_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -945,6 +1271,66 @@ func (x *ResultPredicate_ResourceNamesFragment) GetAction() *ResultPredicate_Res
 	return nil
 }
 
+// Rules for generating the resulting fragment from a transport attribute
+// of the request's downstream stream.
+// [#next-free-field: 3]
+type ResultPredicate_TransportFragment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The transport key, as in MatchPredicate.TransportMatch. The first value
+	// of the key is used. Requests whose key has no value cannot be mapped.
+	Key    string                        `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Action *ResultPredicate_ResultAction `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+}
+
+func (x *ResultPredicate_TransportFragment) Reset() {
+	*x = ResultPredicate_TransportFragment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregation_v1_aggregation_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResultPredicate_TransportFragment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultPredicate_TransportFragment) ProtoMessage() {}
+
+func (x *ResultPredicate_TransportFragment) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregation_v1_aggregation_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultPredicate_TransportFragment.ProtoReflect.Descriptor instead.
+func (*ResultPredicate_TransportFragment) Descriptor() ([]byte, []int) {
+	return file_aggregation_v1_aggregation_proto_rawDescGZIP(), []int{2, 4}
+}
+
+func (x *ResultPredicate_TransportFragment) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ResultPredicate_TransportFragment) GetAction() *ResultPredicate_ResultAction {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
 // TODO potentially use "safe regex"
 // https://github.com/envoyproxy/envoy/blob/10f756efa17e56c8d4d1033be7b4286410db4e01/api/envoy/type/matcher/v3/regex.proto
 // [#next-free-field: 3]
@@ -960,7 +1346,7 @@ type ResultPredicate_ResultAction_RegexAction struct {
 func (x *ResultPredicate_ResultAction_RegexAction) Reset() {
 	*x = ResultPredicate_ResultAction_RegexAction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_aggregation_v1_aggregation_proto_msgTypes[12]
+		mi := &file_aggregation_v1_aggregation_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -973,7 +1359,7 @@ func (x *ResultPredicate_ResultAction_RegexAction) String() string {
 func (*ResultPredicate_ResultAction_RegexAction) ProtoMessage() {}
 
 func (x *ResultPredicate_ResultAction_RegexAction) ProtoReflect() protoreflect.Message {
-	mi := &file_aggregation_v1_aggregation_proto_msgTypes[12]
+	mi := &file_aggregation_v1_aggregation_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1003,6 +1389,64 @@ func (x *ResultPredicate_ResultAction_RegexAction) GetReplace() string {
 	return ""
 }
 
+// Splits the field by the delimiter and uses the element at the index,
+// e.g. index 1 of "us-east-1" split by "-" is "east".
+// [#next-free-field: 3]
+type ResultPredicate_ResultAction_SplitAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Delimiter string `protobuf:"bytes,1,opt,name=delimiter,proto3" json:"delimiter,omitempty"`
+	Index     int32  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *ResultPredicate_ResultAction_SplitAction) Reset() {
+	*x = ResultPredicate_ResultAction_SplitAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregation_v1_aggregation_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResultPredicate_ResultAction_SplitAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultPredicate_ResultAction_SplitAction) ProtoMessage() {}
+
+func (x *ResultPredicate_ResultAction_SplitAction) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregation_v1_aggregation_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultPredicate_ResultAction_SplitAction.ProtoReflect.Descriptor instead.
+func (*ResultPredicate_ResultAction_SplitAction) Descriptor() ([]byte, []int) {
+	return file_aggregation_v1_aggregation_proto_rawDescGZIP(), []int{2, 0, 1}
+}
+
+func (x *ResultPredicate_ResultAction_SplitAction) GetDelimiter() string {
+	if x != nil {
+		return x.Delimiter
+	}
+	return ""
+}
+
+func (x *ResultPredicate_ResultAction_SplitAction) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
 var File_aggregation_v1_aggregation_proto protoreflect.FileDescriptor
 
 var file_aggregation_v1_aggregation_proto_rawDesc = []byte{
@@ -1010,96 +1454,137 @@ var file_aggregation_v1_aggregation_proto_rawDesc = []byte{
 	0x2f, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x12, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a,
 	0x17, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
-	0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc8, 0x02, 0x0a, 0x12, 0x4b, 0x65, 0x79,
+	0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf3, 0x04, 0x0a, 0x12, 0x4b, 0x65, 0x79,
 	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
 	0x50, 0x0a, 0x09, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
 	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
 	0x2e, 0x4b, 0x65, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74,
 	0x69, 0x6f, 0x6e, 0x2e, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x08, 0xfa, 0x42,
 	0x05, 0x92, 0x01, 0x02, 0x08, 0x01, 0x52, 0x09, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74,
-	0x73, 0x1a, 0xdf, 0x01, 0x0a, 0x08, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x4d,
-	0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e,
-	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4b, 0x65, 0x79, 0x65,
-	0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x46,
-	0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x42, 0x08, 0xfa, 0x42,
-	0x05, 0x92, 0x01, 0x02, 0x08, 0x01, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x1a, 0x83, 0x01,
-	0x0a, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x3b, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61,
-	0x74, 0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x05, 0x6d, 0x61,
-	0x74, 0x63, 0x68, 0x12, 0x3e, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74,
-	0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x06, 0x72, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x22, 0xe6, 0x05, 0x0a, 0x0e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x65,
-	0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x43, 0x0a, 0x09, 0x61, 0x6e, 0x64, 0x5f, 0x6d, 0x61,
-	0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x67, 0x67, 0x72,
-	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x65,
-	0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x48,
-	0x00, 0x52, 0x08, 0x61, 0x6e, 0x64, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x41, 0x0a, 0x08, 0x6f,
-	0x72, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x73, 0x12, 0x40, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x28, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x4b, 0x65, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x74, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x12, 0x44, 0x0a, 0x08, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x4b, 0x65, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x46, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x52,
+	0x08, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x1a, 0xdf, 0x01, 0x0a, 0x08, 0x46, 0x72,
+	0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x4d, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x4b, 0x65, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x52, 0x75, 0x6c, 0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x92, 0x01, 0x02, 0x08, 0x01, 0x52, 0x05,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x1a, 0x83, 0x01, 0x0a, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x3b,
+	0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
 	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x74, 0x63,
-	0x68, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68,
-	0x53, 0x65, 0x74, 0x48, 0x00, 0x52, 0x07, 0x6f, 0x72, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3a,
-	0x0a, 0x09, 0x6e, 0x6f, 0x74, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
-	0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x48, 0x00,
-	0x52, 0x08, 0x6e, 0x6f, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x26, 0x0a, 0x09, 0x61, 0x6e,
-	0x79, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x42, 0x07, 0xfa,
-	0x42, 0x04, 0x6a, 0x02, 0x08, 0x01, 0x48, 0x00, 0x52, 0x08, 0x61, 0x6e, 0x79, 0x4d, 0x61, 0x74,
-	0x63, 0x68, 0x12, 0x5c, 0x0a, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x79,
-	0x70, 0x65, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c,
-	0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x74,
-	0x63, 0x68, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x10,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68,
-	0x12, 0x5c, 0x0a, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x6e, 0x6f, 0x64, 0x65,
-	0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x61,
+	0x68, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a,
+	0x01, 0x02, 0x10, 0x01, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3e, 0x0a, 0x06, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01,
+	0x02, 0x10, 0x01, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x1a, 0xa0, 0x01, 0x0a, 0x08,
+	0x46, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x33, 0x0a, 0x0b, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x10, 0xfa,
+	0x42, 0x0d, 0x72, 0x0b, 0x10, 0x01, 0x32, 0x07, 0x5e, 0x5b, 0x5e, 0x2f, 0x5d, 0x2a, 0x24, 0x48,
+	0x00, 0x52, 0x0a, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x2b, 0x0a,
+	0x0b, 0x70, 0x61, 0x73, 0x73, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x6a, 0x02, 0x08, 0x01, 0x48, 0x00, 0x52, 0x0b, 0x70,
+	0x61, 0x73, 0x73, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x12, 0x21, 0x0a, 0x06, 0x72, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x6a,
+	0x02, 0x08, 0x01, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x42, 0x0f, 0x0a,
+	0x08, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x22, 0xbd,
+	0x07, 0x0a, 0x0e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x12, 0x43, 0x0a, 0x09, 0x61, 0x6e, 0x64, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x48, 0x00, 0x52, 0x08, 0x61, 0x6e,
+	0x64, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x41, 0x0a, 0x08, 0x6f, 0x72, 0x5f, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x65, 0x64,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x48, 0x00,
+	0x52, 0x07, 0x6f, 0x72, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3a, 0x0a, 0x09, 0x6e, 0x6f, 0x74,
+	0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61,
 	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68,
-	0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x10, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x1a, 0x32,
-	0x0a, 0x10, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x4d, 0x61, 0x74,
-	0x63, 0x68, 0x12, 0x1e, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x92, 0x01, 0x02, 0x08, 0x01, 0x52, 0x05, 0x74, 0x79, 0x70,
-	0x65, 0x73, 0x1a, 0xa1, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4e, 0x6f,
-	0x64, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3a, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79,
-	0x70, 0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x05, 0x66, 0x69,
-	0x65, 0x6c, 0x64, 0x12, 0x21, 0x0a, 0x0b, 0x65, 0x78, 0x61, 0x63, 0x74, 0x5f, 0x6d, 0x61, 0x74,
-	0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x61, 0x63,
-	0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x21, 0x0a, 0x0b, 0x72, 0x65, 0x67, 0x65, 0x78, 0x5f,
-	0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x72,
-	0x65, 0x67, 0x65, 0x78, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x0b, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x1a, 0x47, 0x0a, 0x08, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53,
-	0x65, 0x74, 0x12, 0x3b, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
-	0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x42, 0x08,
-	0xfa, 0x42, 0x05, 0x92, 0x01, 0x02, 0x08, 0x02, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x42,
-	0x0b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x22, 0xe7, 0x07, 0x0a,
-	0x0f, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65,
-	0x12, 0x47, 0x0a, 0x0a, 0x61, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x08, 0x6e, 0x6f, 0x74,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x26, 0x0a, 0x09, 0x61, 0x6e, 0x79, 0x5f, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x6a, 0x02, 0x08,
+	0x01, 0x48, 0x00, 0x52, 0x08, 0x61, 0x6e, 0x79, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x5c, 0x0a,
+	0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x61, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x65,
+	0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x10, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x5c, 0x0a, 0x12, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x65, 0x64, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x10, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x55, 0x0a, 0x0f, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00,
+	0x52, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x1a, 0x32, 0x0a, 0x10, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x1e, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x92, 0x01, 0x02, 0x08, 0x01, 0x52, 0x05, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x1a, 0xa1, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3a, 0x0a, 0x05, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x54, 0x79, 0x70, 0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x05,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x21, 0x0a, 0x0b, 0x65, 0x78, 0x61, 0x63, 0x74, 0x5f, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78,
+	0x61, 0x63, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x21, 0x0a, 0x0b, 0x72, 0x65, 0x67, 0x65,
+	0x78, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x0a, 0x72, 0x65, 0x67, 0x65, 0x78, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x0b, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x1a, 0x7e, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x19, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x0b, 0x65, 0x78, 0x61, 0x63, 0x74, 0x5f, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78,
+	0x61, 0x63, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x21, 0x0a, 0x0b, 0x72, 0x65, 0x67, 0x65,
+	0x78, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x0a, 0x72, 0x65, 0x67, 0x65, 0x78, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x0b, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x1a, 0x47, 0x0a, 0x08, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x53, 0x65, 0x74, 0x12, 0x3b, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0x92, 0x01, 0x02, 0x08, 0x02, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65,
+	0x73, 0x42, 0x0b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x22, 0xf5,
+	0x0b, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x12, 0x47, 0x0a, 0x0a, 0x61, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x2e, 0x41, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00,
+	0x52, 0x09, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x66, 0x0a, 0x15, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x66, 0x72, 0x61, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x61, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50,
+	0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x4e, 0x6f, 0x64, 0x65, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x13,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x46, 0x72, 0x61, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x6c, 0x0a, 0x17, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x5f, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
 	0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61,
-	0x74, 0x65, 0x2e, 0x41, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x09,
-	0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x66, 0x0a, 0x15, 0x72, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65,
-	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65,
-	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65,
-	0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4e, 0x6f,
-	0x64, 0x65, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x13, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e,
-	0x74, 0x12, 0x6c, 0x0a, 0x17, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x73, 0x5f, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x32, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65,
-	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x46, 0x72,
-	0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x15, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12,
-	0x29, 0x0a, 0x0f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65,
-	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0xef, 0x01, 0x0a, 0x0c, 0x52,
+	0x74, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x15, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x29, 0x0a, 0x0f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x72, 0x61, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x0b,
+	0x70, 0x61, 0x73, 0x73, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x6a, 0x02, 0x08, 0x01, 0x48, 0x00, 0x52, 0x0b, 0x70, 0x61,
+	0x73, 0x73, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x12, 0x5f, 0x0a, 0x12, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x72, 0x61,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f,
+	0x72, 0x74, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0xf2, 0x03, 0x0a, 0x0c, 0x52,
 	0x65, 0x73, 0x75, 0x6c, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x05, 0x65,
 	0x78, 0x61, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x6a,
 	0x02, 0x08, 0x01, 0x48, 0x00, 0x52, 0x05, 0x65, 0x78, 0x61, 0x63, 0x74, 0x12, 0x5a, 0x0a, 0x0c,
@@ -1108,33 +1593,57 @@ var file_aggregation_v1_aggregation_proto_rawDesc = []byte{
 	0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65,
 	0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65,
 	0x67, 0x65, 0x78, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x67,
-	0x65, 0x78, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x53, 0x0a, 0x0b, 0x52, 0x65, 0x67, 0x65,
-	0x78, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65,
-	0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10,
-	0x01, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x21, 0x0a, 0x07, 0x72, 0x65,
-	0x70, 0x6c, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04,
-	0x72, 0x02, 0x10, 0x00, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x42, 0x0d, 0x0a,
-	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x1a, 0x60, 0x0a, 0x09,
-	0x41, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x53, 0x0a, 0x11, 0x72, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x5f, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61,
-	0x74, 0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x92, 0x01, 0x02, 0x08, 0x02, 0x52, 0x10, 0x72, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x1a, 0x9e,
-	0x01, 0x0a, 0x13, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x46, 0x72,
-	0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70,
-	0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x05, 0x66, 0x69, 0x65,
-	0x6c, 0x64, 0x12, 0x4b, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65,
-	0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa,
-	0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a,
-	0x87, 0x01, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65,
-	0x73, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x07, 0x65, 0x6c, 0x65,
-	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x1a,
-	0x02, 0x28, 0x00, 0x52, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x4b, 0x0a, 0x06,
+	0x65, 0x78, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x08, 0x74, 0x6f, 0x5f, 0x6c,
+	0x6f, 0x77, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x6a,
+	0x02, 0x08, 0x01, 0x48, 0x00, 0x52, 0x07, 0x74, 0x6f, 0x4c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x2a,
+	0x0a, 0x0b, 0x74, 0x72, 0x69, 0x6d, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x48, 0x00, 0x52, 0x0a,
+	0x74, 0x72, 0x69, 0x6d, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x5a, 0x0a, 0x0c, 0x73, 0x70,
+	0x6c, 0x69, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x35, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x70, 0x6c, 0x69,
+	0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x70, 0x6c, 0x69, 0x74,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x53, 0x0a, 0x0b, 0x52, 0x65, 0x67, 0x65, 0x78, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52,
+	0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x21, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02,
+	0x10, 0x00, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x1a, 0x53, 0x0a, 0x0b, 0x53,
+	0x70, 0x6c, 0x69, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x09, 0x64, 0x65,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa,
+	0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65,
+	0x72, 0x12, 0x1d, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x42, 0x07, 0xfa, 0x42, 0x04, 0x1a, 0x02, 0x28, 0x00, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x42, 0x0d, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x1a,
+	0x60, 0x0a, 0x09, 0x41, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x53, 0x0a, 0x11,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x92, 0x01, 0x02, 0x08, 0x02, 0x52,
+	0x10, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x73, 0x1a, 0x9e, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4e, 0x6f, 0x64,
+	0x65, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x05, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x54, 0x79, 0x70, 0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x05,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x4b, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x1a, 0x87, 0x01, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x07,
+	0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x07, 0xfa,
+	0x42, 0x04, 0x1a, 0x02, 0x28, 0x00, 0x52, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x4b, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x29, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a,
+	0x01, 0x02, 0x10, 0x01, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x7b, 0x0a, 0x11,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x19, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07,
+	0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x4b, 0x0a, 0x06,
 	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x61,
 	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c,
 	0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c,
@@ -1166,48 +1675,58 @@ func file_aggregation_v1_aggregation_proto_rawDescGZIP() []byte {
 }
 
 var file_aggregation_v1_aggregation_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_aggregation_v1_aggregation_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_aggregation_v1_aggregation_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_aggregation_v1_aggregation_proto_goTypes = []interface{}{
 	(NodeFieldType)(0),                               // 0: aggregation.NodeFieldType
 	(*KeyerConfiguration)(nil),                       // 1: aggregation.KeyerConfiguration
 	(*MatchPredicate)(nil),                           // 2: aggregation.MatchPredicate
 	(*ResultPredicate)(nil),                          // 3: aggregation.ResultPredicate
 	(*KeyerConfiguration_Fragment)(nil),              // 4: aggregation.KeyerConfiguration.Fragment
-	(*KeyerConfiguration_Fragment_Rule)(nil),         // 5: aggregation.KeyerConfiguration.Fragment.Rule
-	(*MatchPredicate_RequestTypeMatch)(nil),          // 6: aggregation.MatchPredicate.RequestTypeMatch
-	(*MatchPredicate_RequestNodeMatch)(nil),          // 7: aggregation.MatchPredicate.RequestNodeMatch
-	(*MatchPredicate_MatchSet)(nil),                  // 8: aggregation.MatchPredicate.MatchSet
-	(*ResultPredicate_ResultAction)(nil),             // 9: aggregation.ResultPredicate.ResultAction
-	(*ResultPredicate_AndResult)(nil),                // 10: aggregation.ResultPredicate.AndResult
-	(*ResultPredicate_RequestNodeFragment)(nil),      // 11: aggregation.ResultPredicate.RequestNodeFragment
-	(*ResultPredicate_ResourceNamesFragment)(nil),    // 12: aggregation.ResultPredicate.ResourceNamesFragment
-	(*ResultPredicate_ResultAction_RegexAction)(nil), // 13: aggregation.ResultPredicate.ResultAction.RegexAction
+	(*KeyerConfiguration_Fallback)(nil),              // 5: aggregation.KeyerConfiguration.Fallback
+	(*KeyerConfiguration_Fragment_Rule)(nil),         // 6: aggregation.KeyerConfiguration.Fragment.Rule
+	(*MatchPredicate_RequestTypeMatch)(nil),          // 7: aggregation.MatchPredicate.RequestTypeMatch
+	(*MatchPredicate_RequestNodeMatch)(nil),          // 8: aggregation.MatchPredicate.RequestNodeMatch
+	(*MatchPredicate_TransportMatch)(nil),            // 9: aggregation.MatchPredicate.TransportMatch
+	(*MatchPredicate_MatchSet)(nil),                  // 10: aggregation.MatchPredicate.MatchSet
+	(*ResultPredicate_ResultAction)(nil),             // 11: aggregation.ResultPredicate.ResultAction
+	(*ResultPredicate_AndResult)(nil),                // 12: aggregation.ResultPredicate.AndResult
+	(*ResultPredicate_RequestNodeFragment)(nil),      // 13: aggregation.ResultPredicate.RequestNodeFragment
+	(*ResultPredicate_ResourceNamesFragment)(nil),    // 14: aggregation.ResultPredicate.ResourceNamesFragment
+	(*ResultPredicate_TransportFragment)(nil),        // 15: aggregation.ResultPredicate.TransportFragment
+	(*ResultPredicate_ResultAction_RegexAction)(nil), // 16: aggregation.ResultPredicate.ResultAction.RegexAction
+	(*ResultPredicate_ResultAction_SplitAction)(nil), // 17: aggregation.ResultPredicate.ResultAction.SplitAction
 }
 var file_aggregation_v1_aggregation_proto_depIdxs = []int32{
 	4,  // 0: aggregation.KeyerConfiguration.fragments:type_name -> aggregation.KeyerConfiguration.Fragment
-	8,  // 1: aggregation.MatchPredicate.and_match:type_name -> aggregation.MatchPredicate.MatchSet
-	8,  // 2: aggregation.MatchPredicate.or_match:type_name -> aggregation.MatchPredicate.MatchSet
-	2,  // 3: aggregation.MatchPredicate.not_match:type_name -> aggregation.MatchPredicate
-	6,  // 4: aggregation.MatchPredicate.request_type_match:type_name -> aggregation.MatchPredicate.RequestTypeMatch
-	7,  // 5: aggregation.MatchPredicate.request_node_match:type_name -> aggregation.MatchPredicate.RequestNodeMatch
-	10, // 6: aggregation.ResultPredicate.and_result:type_name -> aggregation.ResultPredicate.AndResult
-	11, // 7: aggregation.ResultPredicate.request_node_fragment:type_name -> aggregation.ResultPredicate.RequestNodeFragment
-	12, // 8: aggregation.ResultPredicate.resource_names_fragment:type_name -> aggregation.ResultPredicate.ResourceNamesFragment
-	5,  // 9: aggregation.KeyerConfiguration.Fragment.rules:type_name -> aggregation.KeyerConfiguration.Fragment.Rule
-	2,  // 10: aggregation.KeyerConfiguration.Fragment.Rule.match:type_name -> aggregation.MatchPredicate
-	3,  // 11: aggregation.KeyerConfiguration.Fragment.Rule.result:type_name -> aggregation.ResultPredicate
-	0,  // 12: aggregation.MatchPredicate.RequestNodeMatch.field:type_name -> aggregation.NodeFieldType
-	2,  // 13: aggregation.MatchPredicate.MatchSet.rules:type_name -> aggregation.MatchPredicate
-	13, // 14: aggregation.ResultPredicate.ResultAction.regex_action:type_name -> aggregation.ResultPredicate.ResultAction.RegexAction
-	3,  // 15: aggregation.ResultPredicate.AndResult.result_predicates:type_name -> aggregation.ResultPredicate
-	0,  // 16: aggregation.ResultPredicate.RequestNodeFragment.field:type_name -> aggregation.NodeFieldType
-	9,  // 17: aggregation.ResultPredicate.RequestNodeFragment.action:type_name -> aggregation.ResultPredicate.ResultAction
-	9,  // 18: aggregation.ResultPredicate.ResourceNamesFragment.action:type_name -> aggregation.ResultPredicate.ResultAction
-	19, // [19:19] is the sub-list for method output_type
-	19, // [19:19] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	4,  // 1: aggregation.KeyerConfiguration.tenant:type_name -> aggregation.KeyerConfiguration.Fragment
+	5,  // 2: aggregation.KeyerConfiguration.fallback:type_name -> aggregation.KeyerConfiguration.Fallback
+	10, // 3: aggregation.MatchPredicate.and_match:type_name -> aggregation.MatchPredicate.MatchSet
+	10, // 4: aggregation.MatchPredicate.or_match:type_name -> aggregation.MatchPredicate.MatchSet
+	2,  // 5: aggregation.MatchPredicate.not_match:type_name -> aggregation.MatchPredicate
+	7,  // 6: aggregation.MatchPredicate.request_type_match:type_name -> aggregation.MatchPredicate.RequestTypeMatch
+	8,  // 7: aggregation.MatchPredicate.request_node_match:type_name -> aggregation.MatchPredicate.RequestNodeMatch
+	9,  // 8: aggregation.MatchPredicate.transport_match:type_name -> aggregation.MatchPredicate.TransportMatch
+	12, // 9: aggregation.ResultPredicate.and_result:type_name -> aggregation.ResultPredicate.AndResult
+	13, // 10: aggregation.ResultPredicate.request_node_fragment:type_name -> aggregation.ResultPredicate.RequestNodeFragment
+	14, // 11: aggregation.ResultPredicate.resource_names_fragment:type_name -> aggregation.ResultPredicate.ResourceNamesFragment
+	15, // 12: aggregation.ResultPredicate.transport_fragment:type_name -> aggregation.ResultPredicate.TransportFragment
+	6,  // 13: aggregation.KeyerConfiguration.Fragment.rules:type_name -> aggregation.KeyerConfiguration.Fragment.Rule
+	2,  // 14: aggregation.KeyerConfiguration.Fragment.Rule.match:type_name -> aggregation.MatchPredicate
+	3,  // 15: aggregation.KeyerConfiguration.Fragment.Rule.result:type_name -> aggregation.ResultPredicate
+	0,  // 16: aggregation.MatchPredicate.RequestNodeMatch.field:type_name -> aggregation.NodeFieldType
+	2,  // 17: aggregation.MatchPredicate.MatchSet.rules:type_name -> aggregation.MatchPredicate
+	16, // 18: aggregation.ResultPredicate.ResultAction.regex_action:type_name -> aggregation.ResultPredicate.ResultAction.RegexAction
+	17, // 19: aggregation.ResultPredicate.ResultAction.split_action:type_name -> aggregation.ResultPredicate.ResultAction.SplitAction
+	3,  // 20: aggregation.ResultPredicate.AndResult.result_predicates:type_name -> aggregation.ResultPredicate
+	0,  // 21: aggregation.ResultPredicate.RequestNodeFragment.field:type_name -> aggregation.NodeFieldType
+	11, // 22: aggregation.ResultPredicate.RequestNodeFragment.action:type_name -> aggregation.ResultPredicate.ResultAction
+	11, // 23: aggregation.ResultPredicate.ResourceNamesFragment.action:type_name -> aggregation.ResultPredicate.ResultAction
+	11, // 24: aggregation.ResultPredicate.TransportFragment.action:type_name -> aggregation.ResultPredicate.ResultAction
+	25, // [25:25] is the sub-list for method output_type
+	25, // [25:25] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_aggregation_v1_aggregation_proto_init() }
@@ -1265,7 +1784,7 @@ func file_aggregation_v1_aggregation_proto_init() {
 			}
 		}
 		file_aggregation_v1_aggregation_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyerConfiguration_Fragment_Rule); i {
+			switch v := v.(*KeyerConfiguration_Fallback); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1277,7 +1796,7 @@ func file_aggregation_v1_aggregation_proto_init() {
 			}
 		}
 		file_aggregation_v1_aggregation_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MatchPredicate_RequestTypeMatch); i {
+			switch v := v.(*KeyerConfiguration_Fragment_Rule); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1289,7 +1808,7 @@ func file_aggregation_v1_aggregation_proto_init() {
 			}
 		}
 		file_aggregation_v1_aggregation_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MatchPredicate_RequestNodeMatch); i {
+			switch v := v.(*MatchPredicate_RequestTypeMatch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1301,7 +1820,7 @@ func file_aggregation_v1_aggregation_proto_init() {
 			}
 		}
 		file_aggregation_v1_aggregation_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MatchPredicate_MatchSet); i {
+			switch v := v.(*MatchPredicate_RequestNodeMatch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1313,7 +1832,7 @@ func file_aggregation_v1_aggregation_proto_init() {
 			}
 		}
 		file_aggregation_v1_aggregation_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResultPredicate_ResultAction); i {
+			switch v := v.(*MatchPredicate_TransportMatch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1325,7 +1844,7 @@ func file_aggregation_v1_aggregation_proto_init() {
 			}
 		}
 		file_aggregation_v1_aggregation_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResultPredicate_AndResult); i {
+			switch v := v.(*MatchPredicate_MatchSet); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1337,7 +1856,7 @@ func file_aggregation_v1_aggregation_proto_init() {
 			}
 		}
 		file_aggregation_v1_aggregation_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResultPredicate_RequestNodeFragment); i {
+			switch v := v.(*ResultPredicate_ResultAction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1349,7 +1868,7 @@ func file_aggregation_v1_aggregation_proto_init() {
 			}
 		}
 		file_aggregation_v1_aggregation_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResultPredicate_ResourceNamesFragment); i {
+			switch v := v.(*ResultPredicate_AndResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1361,6 +1880,42 @@ func file_aggregation_v1_aggregation_proto_init() {
 			}
 		}
 		file_aggregation_v1_aggregation_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResultPredicate_RequestNodeFragment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregation_v1_aggregation_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResultPredicate_ResourceNamesFragment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregation_v1_aggregation_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResultPredicate_TransportFragment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregation_v1_aggregation_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ResultPredicate_ResultAction_RegexAction); i {
 			case 0:
 				return &v.state
@@ -1372,6 +1927,18 @@ func file_aggregation_v1_aggregation_proto_init() {
 				return nil
 			}
 		}
+		file_aggregation_v1_aggregation_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResultPredicate_ResultAction_SplitAction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_aggregation_v1_aggregation_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*MatchPredicate_AndMatch)(nil),
@@ -1380,20 +1947,35 @@ func file_aggregation_v1_aggregation_proto_init() {
 		(*MatchPredicate_AnyMatch)(nil),
 		(*MatchPredicate_RequestTypeMatch_)(nil),
 		(*MatchPredicate_RequestNodeMatch_)(nil),
+		(*MatchPredicate_TransportMatch_)(nil),
 	}
 	file_aggregation_v1_aggregation_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*ResultPredicate_AndResult_)(nil),
 		(*ResultPredicate_RequestNodeFragment_)(nil),
 		(*ResultPredicate_ResourceNamesFragment_)(nil),
 		(*ResultPredicate_StringFragment)(nil),
+		(*ResultPredicate_Passthrough)(nil),
+		(*ResultPredicate_TransportFragment_)(nil),
 	}
-	file_aggregation_v1_aggregation_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_aggregation_v1_aggregation_proto_msgTypes[4].OneofWrappers = []interface{}{
+		(*KeyerConfiguration_Fallback_DefaultKey)(nil),
+		(*KeyerConfiguration_Fallback_Passthrough)(nil),
+		(*KeyerConfiguration_Fallback_Reject)(nil),
+	}
+	file_aggregation_v1_aggregation_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*MatchPredicate_RequestNodeMatch_ExactMatch)(nil),
 		(*MatchPredicate_RequestNodeMatch_RegexMatch)(nil),
 	}
 	file_aggregation_v1_aggregation_proto_msgTypes[8].OneofWrappers = []interface{}{
+		(*MatchPredicate_TransportMatch_ExactMatch)(nil),
+		(*MatchPredicate_TransportMatch_RegexMatch)(nil),
+	}
+	file_aggregation_v1_aggregation_proto_msgTypes[10].OneofWrappers = []interface{}{
 		(*ResultPredicate_ResultAction_Exact)(nil),
 		(*ResultPredicate_ResultAction_RegexAction_)(nil),
+		(*ResultPredicate_ResultAction_ToLower)(nil),
+		(*ResultPredicate_ResultAction_TrimPrefix)(nil),
+		(*ResultPredicate_ResultAction_SplitAction_)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1401,7 +1983,7 @@ func file_aggregation_v1_aggregation_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_aggregation_v1_aggregation_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   13,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -66,6 +66,26 @@ func (m *KeyerConfiguration) Validate() error {
 
 	}
 
+	if v, ok := interface{}(m.GetTenant()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KeyerConfigurationValidationError{
+				field:  "Tenant",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetFallback()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KeyerConfigurationValidationError{
+				field:  "Fallback",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -204,6 +224,18 @@ func (m *MatchPredicate) Validate() error {
 			}
 		}
 
+	case *MatchPredicate_TransportMatch_:
+
+		if v, ok := interface{}(m.GetTransportMatch()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MatchPredicateValidationError{
+					field:  "TransportMatch",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	default:
 		return MatchPredicateValidationError{
 			field:  "Type",
@@ -318,6 +350,27 @@ func (m *ResultPredicate) Validate() error {
 	case *ResultPredicate_StringFragment:
 		// no validation rules for StringFragment
 
+	case *ResultPredicate_Passthrough:
+
+		if m.GetPassthrough() != true {
+			return ResultPredicateValidationError{
+				field:  "Passthrough",
+				reason: "value must equal true",
+			}
+		}
+
+	case *ResultPredicate_TransportFragment_:
+
+		if v, ok := interface{}(m.GetTransportFragment()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ResultPredicateValidationError{
+					field:  "TransportFragment",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	default:
 		return ResultPredicateValidationError{
 			field:  "Type",
@@ -473,6 +526,120 @@ var _ interface {
 	ErrorName() string
 } = KeyerConfiguration_FragmentValidationError{}
 
+// Validate checks the field values on KeyerConfiguration_Fallback with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *KeyerConfiguration_Fallback) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	switch m.Behavior.(type) {
+
+	case *KeyerConfiguration_Fallback_DefaultKey:
+
+		if utf8.RuneCountInString(m.GetDefaultKey()) < 1 {
+			return KeyerConfiguration_FallbackValidationError{
+				field:  "DefaultKey",
+				reason: "value length must be at least 1 runes",
+			}
+		}
+
+		if !_KeyerConfiguration_Fallback_DefaultKey_Pattern.MatchString(m.GetDefaultKey()) {
+			return KeyerConfiguration_FallbackValidationError{
+				field:  "DefaultKey",
+				reason: "value does not match regex pattern \"^[^/]*$\"",
+			}
+		}
+
+	case *KeyerConfiguration_Fallback_Passthrough:
+
+		if m.GetPassthrough() != true {
+			return KeyerConfiguration_FallbackValidationError{
+				field:  "Passthrough",
+				reason: "value must equal true",
+			}
+		}
+
+	case *KeyerConfiguration_Fallback_Reject:
+
+		if m.GetReject() != true {
+			return KeyerConfiguration_FallbackValidationError{
+				field:  "Reject",
+				reason: "value must equal true",
+			}
+		}
+
+	default:
+		return KeyerConfiguration_FallbackValidationError{
+			field:  "Behavior",
+			reason: "value is required",
+		}
+
+	}
+
+	return nil
+}
+
+// KeyerConfiguration_FallbackValidationError is the validation error returned
+// by KeyerConfiguration_Fallback.Validate if the designated constraints
+// aren't met.
+type KeyerConfiguration_FallbackValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KeyerConfiguration_FallbackValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KeyerConfiguration_FallbackValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KeyerConfiguration_FallbackValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KeyerConfiguration_FallbackValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KeyerConfiguration_FallbackValidationError) ErrorName() string {
+	return "KeyerConfiguration_FallbackValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KeyerConfiguration_FallbackValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKeyerConfiguration_Fallback.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KeyerConfiguration_FallbackValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KeyerConfiguration_FallbackValidationError{}
+
+var _KeyerConfiguration_Fallback_DefaultKey_Pattern = regexp.MustCompile("^[^/]*$")
+
 // Validate checks the field values on KeyerConfiguration_Fragment_Rule with
 // the rules defined in the proto definition for this message. If any rules
 // are violated, an error is returned.
@@ -741,6 +908,97 @@ var _ interface {
 	ErrorName() string
 } = MatchPredicate_RequestNodeMatchValidationError{}
 
+// Validate checks the field values on MatchPredicate_TransportMatch with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *MatchPredicate_TransportMatch) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetKey()) < 1 {
+		return MatchPredicate_TransportMatchValidationError{
+			field:  "Key",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	switch m.Type.(type) {
+
+	case *MatchPredicate_TransportMatch_ExactMatch:
+		// no validation rules for ExactMatch
+
+	case *MatchPredicate_TransportMatch_RegexMatch:
+		// no validation rules for RegexMatch
+
+	default:
+		return MatchPredicate_TransportMatchValidationError{
+			field:  "Type",
+			reason: "value is required",
+		}
+
+	}
+
+	return nil
+}
+
+// MatchPredicate_TransportMatchValidationError is the validation error
+// returned by MatchPredicate_TransportMatch.Validate if the designated
+// constraints aren't met.
+type MatchPredicate_TransportMatchValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MatchPredicate_TransportMatchValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MatchPredicate_TransportMatchValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MatchPredicate_TransportMatchValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MatchPredicate_TransportMatchValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MatchPredicate_TransportMatchValidationError) ErrorName() string {
+	return "MatchPredicate_TransportMatchValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e MatchPredicate_TransportMatchValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMatchPredicate_TransportMatch.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MatchPredicate_TransportMatchValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MatchPredicate_TransportMatchValidationError{}
+
 // Validate checks the field values on MatchPredicate_MatchSet with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, an error is returned.
@@ -861,6 +1119,36 @@ func (m *ResultPredicate_ResultAction) Validate() error {
 			}
 		}
 
+	case *ResultPredicate_ResultAction_ToLower:
+
+		if m.GetToLower() != true {
+			return ResultPredicate_ResultActionValidationError{
+				field:  "ToLower",
+				reason: "value must equal true",
+			}
+		}
+
+	case *ResultPredicate_ResultAction_TrimPrefix:
+
+		if utf8.RuneCountInString(m.GetTrimPrefix()) < 1 {
+			return ResultPredicate_ResultActionValidationError{
+				field:  "TrimPrefix",
+				reason: "value length must be at least 1 runes",
+			}
+		}
+
+	case *ResultPredicate_ResultAction_SplitAction_:
+
+		if v, ok := interface{}(m.GetSplitAction()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ResultPredicate_ResultActionValidationError{
+					field:  "SplitAction",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	default:
 		return ResultPredicate_ResultActionValidationError{
 			field:  "Action",
@@ -1202,6 +1490,98 @@ var _ interface {
 	ErrorName() string
 } = ResultPredicate_ResourceNamesFragmentValidationError{}
 
+// Validate checks the field values on ResultPredicate_TransportFragment with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, an error is returned.
+func (m *ResultPredicate_TransportFragment) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetKey()) < 1 {
+		return ResultPredicate_TransportFragmentValidationError{
+			field:  "Key",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	if m.GetAction() == nil {
+		return ResultPredicate_TransportFragmentValidationError{
+			field:  "Action",
+			reason: "value is required",
+		}
+	}
+
+	if v, ok := interface{}(m.GetAction()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ResultPredicate_TransportFragmentValidationError{
+				field:  "Action",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// ResultPredicate_TransportFragmentValidationError is the validation error
+// returned by ResultPredicate_TransportFragment.Validate if the designated
+// constraints aren't met.
+type ResultPredicate_TransportFragmentValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResultPredicate_TransportFragmentValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResultPredicate_TransportFragmentValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResultPredicate_TransportFragmentValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResultPredicate_TransportFragmentValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResultPredicate_TransportFragmentValidationError) ErrorName() string {
+	return "ResultPredicate_TransportFragmentValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ResultPredicate_TransportFragmentValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResultPredicate_TransportFragment.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResultPredicate_TransportFragmentValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResultPredicate_TransportFragmentValidationError{}
+
 // Validate checks the field values on ResultPredicate_ResultAction_RegexAction
 // with the rules defined in the proto definition for this message. If any
 // rules are violated, an error is returned.
@@ -1283,3 +1663,85 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = ResultPredicate_ResultAction_RegexActionValidationError{}
+
+// Validate checks the field values on ResultPredicate_ResultAction_SplitAction
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, an error is returned.
+func (m *ResultPredicate_ResultAction_SplitAction) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetDelimiter()) < 1 {
+		return ResultPredicate_ResultAction_SplitActionValidationError{
+			field:  "Delimiter",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	if m.GetIndex() < 0 {
+		return ResultPredicate_ResultAction_SplitActionValidationError{
+			field:  "Index",
+			reason: "value must be greater than or equal to 0",
+		}
+	}
+
+	return nil
+}
+
+// ResultPredicate_ResultAction_SplitActionValidationError is the validation
+// error returned by ResultPredicate_ResultAction_SplitAction.Validate if the
+// designated constraints aren't met.
+type ResultPredicate_ResultAction_SplitActionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResultPredicate_ResultAction_SplitActionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResultPredicate_ResultAction_SplitActionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResultPredicate_ResultAction_SplitActionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResultPredicate_ResultAction_SplitActionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResultPredicate_ResultAction_SplitActionValidationError) ErrorName() string {
+	return "ResultPredicate_ResultAction_SplitActionValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ResultPredicate_ResultAction_SplitActionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResultPredicate_ResultAction_SplitAction.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResultPredicate_ResultAction_SplitActionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResultPredicate_ResultAction_SplitActionValidationError{}
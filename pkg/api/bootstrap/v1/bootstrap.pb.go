@@ -10,7 +10,8 @@ import (
 	_ "github.com/envoyproxy/protoc-gen-validate/validate"
 	proto "github.com/golang/protobuf/proto"
 	duration "github.com/golang/protobuf/ptypes/duration"
-	_ "github.com/golang/protobuf/ptypes/wrappers"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -28,6 +29,209 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+// The xDS services of the listener.
+type Listener_Service int32
+
+const (
+	// The aggregated discovery service, which serves every type over a single stream.
+	Listener_ADS Listener_Service = 0
+	// The cluster and endpoint discovery services.
+	Listener_CDS_EDS Listener_Service = 1
+	// The listener and route discovery services.
+	Listener_LDS_RDS Listener_Service = 2
+	// The runtime discovery service.
+	Listener_RTDS Listener_Service = 3
+)
+
+// Enum value maps for Listener_Service.
+var (
+	Listener_Service_name = map[int32]string{
+		0: "ADS",
+		1: "CDS_EDS",
+		2: "LDS_RDS",
+		3: "RTDS",
+	}
+	Listener_Service_value = map[string]int32{
+		"ADS":     0,
+		"CDS_EDS": 1,
+		"LDS_RDS": 2,
+		"RTDS":    3,
+	}
+)
+
+func (x Listener_Service) Enum() *Listener_Service {
+	p := new(Listener_Service)
+	*p = x
+	return p
+}
+
+func (x Listener_Service) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Listener_Service) Descriptor() protoreflect.EnumDescriptor {
+	return file_bootstrap_v1_bootstrap_proto_enumTypes[0].Descriptor()
+}
+
+func (Listener_Service) Type() protoreflect.EnumType {
+	return &file_bootstrap_v1_bootstrap_proto_enumTypes[0]
+}
+
+func (x Listener_Service) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Listener_Service.Descriptor instead.
+func (Listener_Service) EnumDescriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{2, 0}
+}
+
+// The xDS API version spoken with the upstream cluster. The responses of v3 upstream clusters are translated to
+// v2 before they are cached, including the type URLs of nested `Any` payloads, so that v2 downstream clients can
+// be served during a migration.
+type Upstream_ApiVersion int32
+
+const (
+	Upstream_V2 Upstream_ApiVersion = 0
+	Upstream_V3 Upstream_ApiVersion = 1
+)
+
+// Enum value maps for Upstream_ApiVersion.
+var (
+	Upstream_ApiVersion_name = map[int32]string{
+		0: "V2",
+		1: "V3",
+	}
+	Upstream_ApiVersion_value = map[string]int32{
+		"V2": 0,
+		"V3": 1,
+	}
+)
+
+func (x Upstream_ApiVersion) Enum() *Upstream_ApiVersion {
+	p := new(Upstream_ApiVersion)
+	*p = x
+	return p
+}
+
+func (x Upstream_ApiVersion) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Upstream_ApiVersion) Descriptor() protoreflect.EnumDescriptor {
+	return file_bootstrap_v1_bootstrap_proto_enumTypes[1].Descriptor()
+}
+
+func (Upstream_ApiVersion) Type() protoreflect.EnumType {
+	return &file_bootstrap_v1_bootstrap_proto_enumTypes[1]
+}
+
+func (x Upstream_ApiVersion) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Upstream_ApiVersion.Descriptor instead.
+func (Upstream_ApiVersion) EnumDescriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{6, 0}
+}
+
+// How upstream streams are spread across the endpoints.
+type Upstream_Discovery_LoadBalancingPolicy int32
+
+const (
+	// Every stream is opened to the first endpoint that accepts connections.
+	Upstream_Discovery_PICK_FIRST Upstream_Discovery_LoadBalancingPolicy = 0
+	// Streams are opened to the endpoints in turn.
+	Upstream_Discovery_ROUND_ROBIN Upstream_Discovery_LoadBalancingPolicy = 1
+)
+
+// Enum value maps for Upstream_Discovery_LoadBalancingPolicy.
+var (
+	Upstream_Discovery_LoadBalancingPolicy_name = map[int32]string{
+		0: "PICK_FIRST",
+		1: "ROUND_ROBIN",
+	}
+	Upstream_Discovery_LoadBalancingPolicy_value = map[string]int32{
+		"PICK_FIRST":  0,
+		"ROUND_ROBIN": 1,
+	}
+)
+
+func (x Upstream_Discovery_LoadBalancingPolicy) Enum() *Upstream_Discovery_LoadBalancingPolicy {
+	p := new(Upstream_Discovery_LoadBalancingPolicy)
+	*p = x
+	return p
+}
+
+func (x Upstream_Discovery_LoadBalancingPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Upstream_Discovery_LoadBalancingPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_bootstrap_v1_bootstrap_proto_enumTypes[2].Descriptor()
+}
+
+func (Upstream_Discovery_LoadBalancingPolicy) Type() protoreflect.EnumType {
+	return &file_bootstrap_v1_bootstrap_proto_enumTypes[2]
+}
+
+func (x Upstream_Discovery_LoadBalancingPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Upstream_Discovery_LoadBalancingPolicy.Descriptor instead.
+func (Upstream_Discovery_LoadBalancingPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{6, 3, 0}
+}
+
+type Proxy_Type int32
+
+const (
+	// An HTTP proxy that tunnels connections with the CONNECT method.
+	Proxy_HTTP_CONNECT Proxy_Type = 0
+	// A SOCKS5 proxy.
+	Proxy_SOCKS5 Proxy_Type = 1
+)
+
+// Enum value maps for Proxy_Type.
+var (
+	Proxy_Type_name = map[int32]string{
+		0: "HTTP_CONNECT",
+		1: "SOCKS5",
+	}
+	Proxy_Type_value = map[string]int32{
+		"HTTP_CONNECT": 0,
+		"SOCKS5":       1,
+	}
+)
+
+func (x Proxy_Type) Enum() *Proxy_Type {
+	p := new(Proxy_Type)
+	*p = x
+	return p
+}
+
+func (x Proxy_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Proxy_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_bootstrap_v1_bootstrap_proto_enumTypes[3].Descriptor()
+}
+
+func (Proxy_Type) Type() protoreflect.EnumType {
+	return &file_bootstrap_v1_bootstrap_proto_enumTypes[3]
+}
+
+func (x Proxy_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Proxy_Type.Descriptor instead.
+func (Proxy_Type) EnumDescriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{7, 0}
+}
+
 // The logging level. If no logging level is set, the default is INFO.
 type Logging_Level int32
 
@@ -65,11 +269,11 @@ func (x Logging_Level) String() string {
 }
 
 func (Logging_Level) Descriptor() protoreflect.EnumDescriptor {
-	return file_bootstrap_v1_bootstrap_proto_enumTypes[0].Descriptor()
+	return file_bootstrap_v1_bootstrap_proto_enumTypes[4].Descriptor()
 }
 
 func (Logging_Level) Type() protoreflect.EnumType {
-	return &file_bootstrap_v1_bootstrap_proto_enumTypes[0]
+	return &file_bootstrap_v1_bootstrap_proto_enumTypes[4]
 }
 
 func (x Logging_Level) Number() protoreflect.EnumNumber {
@@ -78,287 +282,528 @@ func (x Logging_Level) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Logging_Level.Descriptor instead.
 func (Logging_Level) EnumDescriptor() ([]byte, []int) {
-	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{3, 0}
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{8, 0}
 }
 
-// [#next-free-field: 7]
-type Bootstrap struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+// What happens to the open downstream watches of an evicted key. The upstream stream of the key is closed either
+// way.
+type Cache_EvictionPolicy int32
 
-	// xds-relay server configuration.
-	Server *Server `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
-	// Configuration information about the origin server.
-	OriginServer *Upstream `protobuf:"bytes,2,opt,name=origin_server,json=originServer,proto3" json:"origin_server,omitempty"`
-	// Logging settings.
-	Logging *Logging `protobuf:"bytes,3,opt,name=logging,proto3" json:"logging,omitempty"`
-	// Request/response cache settings.
-	Cache *Cache `protobuf:"bytes,4,opt,name=cache,proto3" json:"cache,omitempty"`
-	// Metrics sink settings
-	MetricsSink *MetricsSink `protobuf:"bytes,5,opt,name=metrics_sink,json=metricsSink,proto3" json:"metrics_sink,omitempty"`
-	// Admin server configuration.
-	Admin *Admin `protobuf:"bytes,6,opt,name=admin,proto3" json:"admin,omitempty"`
-}
+const (
+	// The streams of the watches are closed with an UNAVAILABLE status, so that clients reconnect.
+	Cache_TERMINATE Cache_EvictionPolicy = 0
+	// The watches are kept, and a new upstream stream is opened to serve them.
+	Cache_RESUBSCRIBE Cache_EvictionPolicy = 1
+)
 
-func (x *Bootstrap) Reset() {
-	*x = Bootstrap{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+// Enum value maps for Cache_EvictionPolicy.
+var (
+	Cache_EvictionPolicy_name = map[int32]string{
+		0: "TERMINATE",
+		1: "RESUBSCRIBE",
 	}
-}
+	Cache_EvictionPolicy_value = map[string]int32{
+		"TERMINATE":   0,
+		"RESUBSCRIBE": 1,
+	}
+)
 
-func (x *Bootstrap) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x Cache_EvictionPolicy) Enum() *Cache_EvictionPolicy {
+	p := new(Cache_EvictionPolicy)
+	*p = x
+	return p
 }
 
-func (*Bootstrap) ProtoMessage() {}
+func (x Cache_EvictionPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
 
-func (x *Bootstrap) ProtoReflect() protoreflect.Message {
-	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (Cache_EvictionPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_bootstrap_v1_bootstrap_proto_enumTypes[5].Descriptor()
 }
 
-// Deprecated: Use Bootstrap.ProtoReflect.Descriptor instead.
-func (*Bootstrap) Descriptor() ([]byte, []int) {
-	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{0}
+func (Cache_EvictionPolicy) Type() protoreflect.EnumType {
+	return &file_bootstrap_v1_bootstrap_proto_enumTypes[5]
 }
 
-func (x *Bootstrap) GetServer() *Server {
-	if x != nil {
-		return x.Server
-	}
-	return nil
+func (x Cache_EvictionPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *Bootstrap) GetOriginServer() *Upstream {
-	if x != nil {
-		return x.OriginServer
-	}
-	return nil
+// Deprecated: Use Cache_EvictionPolicy.Descriptor instead.
+func (Cache_EvictionPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{9, 0}
 }
 
-func (x *Bootstrap) GetLogging() *Logging {
-	if x != nil {
-		return x.Logging
+// Which keys are evicted when the cache holds max_entries keys, or when keys are shed under memory pressure.
+type Cache_EvictionStrategy int32
+
+const (
+	// The least recently used keys are evicted.
+	Cache_LRU Cache_EvictionStrategy = 0
+	// The least frequently used keys are evicted, so that a single scan of rarely requested keys does not evict
+	// frequently requested ones. Suits relays that serve many EDS keys.
+	Cache_LFU Cache_EvictionStrategy = 1
+	// The keys closest to the expiry of their ttl are evicted, however often they are requested. Keys that never
+	// expire are evicted last.
+	Cache_TTL_ONLY Cache_EvictionStrategy = 2
+)
+
+// Enum value maps for Cache_EvictionStrategy.
+var (
+	Cache_EvictionStrategy_name = map[int32]string{
+		0: "LRU",
+		1: "LFU",
+		2: "TTL_ONLY",
 	}
-	return nil
+	Cache_EvictionStrategy_value = map[string]int32{
+		"LRU":      0,
+		"LFU":      1,
+		"TTL_ONLY": 2,
+	}
+)
+
+func (x Cache_EvictionStrategy) Enum() *Cache_EvictionStrategy {
+	p := new(Cache_EvictionStrategy)
+	*p = x
+	return p
 }
 
-func (x *Bootstrap) GetCache() *Cache {
-	if x != nil {
-		return x.Cache
-	}
-	return nil
+func (x Cache_EvictionStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *Bootstrap) GetMetricsSink() *MetricsSink {
-	if x != nil {
-		return x.MetricsSink
-	}
-	return nil
+func (Cache_EvictionStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_bootstrap_v1_bootstrap_proto_enumTypes[6].Descriptor()
 }
 
-func (x *Bootstrap) GetAdmin() *Admin {
-	if x != nil {
-		return x.Admin
-	}
-	return nil
+func (Cache_EvictionStrategy) Type() protoreflect.EnumType {
+	return &file_bootstrap_v1_bootstrap_proto_enumTypes[6]
 }
 
-// [#next-free-field: 2]
-type Server struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x Cache_EvictionStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
 
-	// The TCP address that the xds-relay server will listen on.
-	Address *SocketAddress `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+// Deprecated: Use Cache_EvictionStrategy.Descriptor instead.
+func (Cache_EvictionStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{9, 1}
 }
 
-func (x *Server) Reset() {
-	*x = Server{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+// How two versions are ordered.
+type VersionGuard_Comparator int32
+
+const (
+	// Versions are not compared.
+	VersionGuard_DISABLED VersionGuard_Comparator = 0
+	// Versions are compared as unsigned integers.
+	VersionGuard_NUMERIC VersionGuard_Comparator = 1
+	// Versions are compared as semantic versions, with an optional "v" prefix.
+	VersionGuard_SEMVER VersionGuard_Comparator = 2
+	// Versions are treated as opaque and responses are ordered by their nonce, compared as unsigned integers.
+	// Nonces restart with every upstream stream, so a response is only compared with the previous response of
+	// its stream, and responses replicated from peer relays are only checked for a different version.
+	VersionGuard_OPAQUE_WITH_NONCE VersionGuard_Comparator = 3
+)
+
+// Enum value maps for VersionGuard_Comparator.
+var (
+	VersionGuard_Comparator_name = map[int32]string{
+		0: "DISABLED",
+		1: "NUMERIC",
+		2: "SEMVER",
+		3: "OPAQUE_WITH_NONCE",
+	}
+	VersionGuard_Comparator_value = map[string]int32{
+		"DISABLED":          0,
+		"NUMERIC":           1,
+		"SEMVER":            2,
+		"OPAQUE_WITH_NONCE": 3,
 	}
+)
+
+func (x VersionGuard_Comparator) Enum() *VersionGuard_Comparator {
+	p := new(VersionGuard_Comparator)
+	*p = x
+	return p
 }
 
-func (x *Server) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x VersionGuard_Comparator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (*Server) ProtoMessage() {}
+func (VersionGuard_Comparator) Descriptor() protoreflect.EnumDescriptor {
+	return file_bootstrap_v1_bootstrap_proto_enumTypes[7].Descriptor()
+}
 
-func (x *Server) ProtoReflect() protoreflect.Message {
-	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (VersionGuard_Comparator) Type() protoreflect.EnumType {
+	return &file_bootstrap_v1_bootstrap_proto_enumTypes[7]
 }
 
-// Deprecated: Use Server.ProtoReflect.Descriptor instead.
-func (*Server) Descriptor() ([]byte, []int) {
-	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{1}
+func (x VersionGuard_Comparator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *Server) GetAddress() *SocketAddress {
-	if x != nil {
-		return x.Address
-	}
-	return nil
+// Deprecated: Use VersionGuard_Comparator.Descriptor instead.
+func (VersionGuard_Comparator) EnumDescriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{14, 0}
 }
 
-// [#next-free-field: 2]
-type Upstream struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+// What to do with responses over the limit.
+type ResponseLimit_Action int32
 
-	// The address for the upstream cluster.
-	Address *SocketAddress `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-}
+const (
+	// Drop the response, and keep serving the previously cached response.
+	ResponseLimit_REJECT ResponseLimit_Action = 0
+	// Log a warning and serve the response.
+	ResponseLimit_WARN ResponseLimit_Action = 1
+)
 
-func (x *Upstream) Reset() {
-	*x = Upstream{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+// Enum value maps for ResponseLimit_Action.
+var (
+	ResponseLimit_Action_name = map[int32]string{
+		0: "REJECT",
+		1: "WARN",
+	}
+	ResponseLimit_Action_value = map[string]int32{
+		"REJECT": 0,
+		"WARN":   1,
 	}
+)
+
+func (x ResponseLimit_Action) Enum() *ResponseLimit_Action {
+	p := new(ResponseLimit_Action)
+	*p = x
+	return p
 }
 
-func (x *Upstream) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x ResponseLimit_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (*Upstream) ProtoMessage() {}
+func (ResponseLimit_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_bootstrap_v1_bootstrap_proto_enumTypes[8].Descriptor()
+}
 
-func (x *Upstream) ProtoReflect() protoreflect.Message {
-	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (ResponseLimit_Action) Type() protoreflect.EnumType {
+	return &file_bootstrap_v1_bootstrap_proto_enumTypes[8]
 }
 
-// Deprecated: Use Upstream.ProtoReflect.Descriptor instead.
-func (*Upstream) Descriptor() ([]byte, []int) {
-	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{2}
+func (x ResponseLimit_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *Upstream) GetAddress() *SocketAddress {
-	if x != nil {
-		return x.Address
+// Deprecated: Use ResponseLimit_Action.Descriptor instead.
+func (ResponseLimit_Action) EnumDescriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{42, 0}
+}
+
+// What to do with invalid responses.
+type ResponseValidation_Action int32
+
+const (
+	// Drop the response, and keep serving the previously cached response.
+	ResponseValidation_REJECT ResponseValidation_Action = 0
+	// Log a warning and serve the response.
+	ResponseValidation_WARN ResponseValidation_Action = 1
+)
+
+// Enum value maps for ResponseValidation_Action.
+var (
+	ResponseValidation_Action_name = map[int32]string{
+		0: "REJECT",
+		1: "WARN",
 	}
-	return nil
+	ResponseValidation_Action_value = map[string]int32{
+		"REJECT": 0,
+		"WARN":   1,
+	}
+)
+
+func (x ResponseValidation_Action) Enum() *ResponseValidation_Action {
+	p := new(ResponseValidation_Action)
+	*p = x
+	return p
 }
 
-// [#next-free-field: 3]
-type Logging struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x ResponseValidation_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
 
-	// Filepath where logs are emitted. If no filepath is specified, logs will be written to `/dev/null`.
-	Path  string        `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	Level Logging_Level `protobuf:"varint,2,opt,name=level,proto3,enum=bootstrap.Logging_Level" json:"level,omitempty"`
+func (ResponseValidation_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_bootstrap_v1_bootstrap_proto_enumTypes[9].Descriptor()
 }
 
-func (x *Logging) Reset() {
-	*x = Logging{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (ResponseValidation_Action) Type() protoreflect.EnumType {
+	return &file_bootstrap_v1_bootstrap_proto_enumTypes[9]
 }
 
-func (x *Logging) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x ResponseValidation_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (*Logging) ProtoMessage() {}
+// Deprecated: Use ResponseValidation_Action.Descriptor instead.
+func (ResponseValidation_Action) EnumDescriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{53, 0}
+}
 
-func (x *Logging) ProtoReflect() protoreflect.Message {
-	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+type Linting_Check int32
+
+const (
+	// The clusters routed to by the routes of a route configuration must be in a CDS response.
+	Linting_ROUTE_CLUSTER_MISSING Linting_Check = 0
+	// The route configurations of the HTTP connection managers of a listener must be in an RDS response.
+	Linting_LISTENER_ROUTE_MISSING Linting_Check = 1
+	// The endpoints of an EDS cluster must be in an EDS response.
+	Linting_CLUSTER_ENDPOINTS_MISSING Linting_Check = 2
+)
+
+// Enum value maps for Linting_Check.
+var (
+	Linting_Check_name = map[int32]string{
+		0: "ROUTE_CLUSTER_MISSING",
+		1: "LISTENER_ROUTE_MISSING",
+		2: "CLUSTER_ENDPOINTS_MISSING",
 	}
-	return mi.MessageOf(x)
+	Linting_Check_value = map[string]int32{
+		"ROUTE_CLUSTER_MISSING":     0,
+		"LISTENER_ROUTE_MISSING":    1,
+		"CLUSTER_ENDPOINTS_MISSING": 2,
+	}
+)
+
+func (x Linting_Check) Enum() *Linting_Check {
+	p := new(Linting_Check)
+	*p = x
+	return p
 }
 
-// Deprecated: Use Logging.ProtoReflect.Descriptor instead.
-func (*Logging) Descriptor() ([]byte, []int) {
-	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{3}
+func (x Linting_Check) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *Logging) GetPath() string {
-	if x != nil {
-		return x.Path
-	}
-	return ""
+func (Linting_Check) Descriptor() protoreflect.EnumDescriptor {
+	return file_bootstrap_v1_bootstrap_proto_enumTypes[10].Descriptor()
 }
 
-func (x *Logging) GetLevel() Logging_Level {
-	if x != nil {
-		return x.Level
+func (Linting_Check) Type() protoreflect.EnumType {
+	return &file_bootstrap_v1_bootstrap_proto_enumTypes[10]
+}
+
+func (x Linting_Check) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Linting_Check.Descriptor instead.
+func (Linting_Check) EnumDescriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{54, 0}
+}
+
+// What to do with a response when the channel is full.
+type Buffers_OverflowPolicy int32
+
+const (
+	// Downstream channels drop the new response, and upstream channels wait for room.
+	Buffers_DEFAULT Buffers_OverflowPolicy = 0
+	// Wait for room in the channel. A blocked downstream channel holds up the fanout of its key, and a blocked
+	// upstream channel holds up the stream.
+	Buffers_BLOCK Buffers_OverflowPolicy = 1
+	// Drop the oldest response in the channel to make room for the new response.
+	Buffers_DROP_OLDEST Buffers_OverflowPolicy = 2
+	// Replace every response in the channel with the new response, since it supersedes them.
+	Buffers_COALESCE_LATEST Buffers_OverflowPolicy = 3
+)
+
+// Enum value maps for Buffers_OverflowPolicy.
+var (
+	Buffers_OverflowPolicy_name = map[int32]string{
+		0: "DEFAULT",
+		1: "BLOCK",
+		2: "DROP_OLDEST",
+		3: "COALESCE_LATEST",
 	}
-	return Logging_INFO
+	Buffers_OverflowPolicy_value = map[string]int32{
+		"DEFAULT":         0,
+		"BLOCK":           1,
+		"DROP_OLDEST":     2,
+		"COALESCE_LATEST": 3,
+	}
+)
+
+func (x Buffers_OverflowPolicy) Enum() *Buffers_OverflowPolicy {
+	p := new(Buffers_OverflowPolicy)
+	*p = x
+	return p
 }
 
-// [#next-free-field: 3]
-type Cache struct {
+func (x Buffers_OverflowPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Buffers_OverflowPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_bootstrap_v1_bootstrap_proto_enumTypes[11].Descriptor()
+}
+
+func (Buffers_OverflowPolicy) Type() protoreflect.EnumType {
+	return &file_bootstrap_v1_bootstrap_proto_enumTypes[11]
+}
+
+func (x Buffers_OverflowPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Buffers_OverflowPolicy.Descriptor instead.
+func (Buffers_OverflowPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{61, 0}
+}
+
+// [#next-free-field: 48]
+type Bootstrap struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Duration before which a key is evicted from the request/response cache. Zero means no expiration time.
-	Ttl *duration.Duration `protobuf:"bytes,1,opt,name=ttl,proto3" json:"ttl,omitempty"`
-	// The maximum number of keys allowed in the request/response cache. If unset, no maximum number will be enforced.
-	MaxEntries int32 `protobuf:"varint,2,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
+	// xds-relay server configuration.
+	Server *Server `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	// Configuration information about the origin server.
+	OriginServer *Upstream `protobuf:"bytes,2,opt,name=origin_server,json=originServer,proto3" json:"origin_server,omitempty"`
+	// Logging settings.
+	Logging *Logging `protobuf:"bytes,3,opt,name=logging,proto3" json:"logging,omitempty"`
+	// Request/response cache settings.
+	Cache *Cache `protobuf:"bytes,4,opt,name=cache,proto3" json:"cache,omitempty"`
+	// Metrics sink settings
+	MetricsSink *MetricsSink `protobuf:"bytes,5,opt,name=metrics_sink,json=metricsSink,proto3" json:"metrics_sink,omitempty"`
+	// Admin server configuration.
+	Admin *Admin `protobuf:"bytes,6,opt,name=admin,proto3" json:"admin,omitempty"`
+	// Upstream response version monotonicity settings. If unset, versions are not checked.
+	VersionGuard *VersionGuard `protobuf:"bytes,7,opt,name=version_guard,json=versionGuard,proto3" json:"version_guard,omitempty"`
+	// Notifications sent when the cached response for an aggregated key is updated.
+	Notifications *Notifications `protobuf:"bytes,8,opt,name=notifications,proto3" json:"notifications,omitempty"`
+	// Leader election between relay replicas. If unset, every replica opens streams to the origin server.
+	LeaderElection *LeaderElection `protobuf:"bytes,9,opt,name=leader_election,json=leaderElection,proto3" json:"leader_election,omitempty"`
+	// Cache replication between relay peers. If unset, the cache is neither served to nor replicated from peers.
+	Replication *Replication `protobuf:"bytes,10,opt,name=replication,proto3" json:"replication,omitempty"`
+	// Upstream subscriptions opened in dry-run mode, in place of downstream clients. Ignored in other modes.
+	DryRun *DryRun `protobuf:"bytes,11,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// A shadow origin server that is sent the same requests as the origin server. Its responses are compared with the
+	// origin server's responses and are never served. If unset, no shadow requests are sent.
+	ShadowServer *Upstream `protobuf:"bytes,12,opt,name=shadow_server,json=shadowServer,proto3" json:"shadow_server,omitempty"`
+	// Transformations applied in order to origin server responses before they are cached and served.
+	Transformations []*Transformation `protobuf:"bytes,13,rep,name=transformations,proto3" json:"transformations,omitempty"`
+	// Override files that patch upstream resources by aggregated key and resource name. Overrides are applied after
+	// transformations. If unset, resources are not patched.
+	OverrideFiles *OverrideFiles `protobuf:"bytes,14,opt,name=override_files,json=overrideFiles,proto3" json:"override_files,omitempty"`
+	// Responses served by the relay instead of the origin server. Requests matching a static response are never
+	// forwarded upstream.
+	StaticResponses []*StaticResponse `protobuf:"bytes,15,rep,name=static_responses,json=staticResponses,proto3" json:"static_responses,omitempty"`
+	// Recording of upstream responses to files, for replaying them later. If unset, nothing is recorded.
+	Recording *Recording `protobuf:"bytes,16,opt,name=recording,proto3" json:"recording,omitempty"`
+	// Replay of a recorded session in place of the origin server. If set, the relay never connects to the origin
+	// server.
+	Replay *Replay `protobuf:"bytes,17,opt,name=replay,proto3" json:"replay,omitempty"`
+	// Supervision of the per aggregated key workers that handle upstream responses. If unset, the defaults apply.
+	Supervision *Supervision `protobuf:"bytes,18,opt,name=supervision,proto3" json:"supervision,omitempty"`
+	// Scheduling of downstream sends across aggregated keys during fanout. If unset, every downstream send of a
+	// fanout runs in its own goroutine.
+	FanoutScheduling *FanoutScheduling `protobuf:"bytes,19,opt,name=fanout_scheduling,json=fanoutScheduling,proto3" json:"fanout_scheduling,omitempty"`
+	// Audit log of the responses served to downstream clients. If unset, served responses are not audited.
+	AuditLog *AuditLog `protobuf:"bytes,20,opt,name=audit_log,json=auditLog,proto3" json:"audit_log,omitempty"`
+	// Verification of the signatures of upstream responses. If unset, upstream responses are not verified.
+	SignatureVerification *SignatureVerification `protobuf:"bytes,21,opt,name=signature_verification,json=signatureVerification,proto3" json:"signature_verification,omitempty"`
+	// Limits on the size of upstream responses. The limit whose type URL matches the response applies, or else the
+	// limit without a type URL, if any.
+	ResponseLimits []*ResponseLimit `protobuf:"bytes,22,rep,name=response_limits,json=responseLimits,proto3" json:"response_limits,omitempty"`
+	// Tuning of the garbage collector and memory usage of the process. If unset, the Go runtime defaults apply and
+	// the cache is only bounded by max_entries.
+	Memory *Memory `protobuf:"bytes,23,opt,name=memory,proto3" json:"memory,omitempty"`
+	// Stamping of the control plane identifier of the responses served by the relay. If unset, responses keep the
+	// control plane identifier set by the origin server.
+	ControlPlaneIdentifier *ControlPlaneIdentifier `protobuf:"bytes,24,opt,name=control_plane_identifier,json=controlPlaneIdentifier,proto3" json:"control_plane_identifier,omitempty"`
+	// Staged rollout of new upstream versions to a canary subset of downstream nodes. If unset, new versions are
+	// fanned out to every node at once.
+	Rollout *Rollout `protobuf:"bytes,25,opt,name=rollout,proto3" json:"rollout,omitempty"`
+	// Circuit breaking of the upstream streams of aggregated keys that keep failing. If unset, closed upstream
+	// streams are not reopened until a new watch is created for the aggregated key.
+	CircuitBreaker *CircuitBreaker `protobuf:"bytes,26,opt,name=circuit_breaker,json=circuitBreaker,proto3" json:"circuit_breaker,omitempty"`
+	// Differential fanout, which skips the downstream sends of responses that leave the resources subscribed to by a
+	// watch unchanged. If unset, every watch is sent every new response.
+	DifferentialFanout *DifferentialFanout `protobuf:"bytes,27,opt,name=differential_fanout,json=differentialFanout,proto3" json:"differential_fanout,omitempty"`
+	// Suppression of the fanout of responses that bump the version without changing the resources. If unset, such
+	// responses are fanned out like any other, and only counted.
+	ContentDeduplication *ContentDeduplication `protobuf:"bytes,28,opt,name=content_deduplication,json=contentDeduplication,proto3" json:"content_deduplication,omitempty"`
+	// Rules that raise alerts on anomalies of the configuration of aggregated keys. If unset, no alerts are raised.
+	Alerting *Alerting `protobuf:"bytes,29,opt,name=alerting,proto3" json:"alerting,omitempty"`
+	// Negative caching of requests that cannot be mapped to an aggregated key, and of aggregated keys whose upstream
+	// stream fails to open. If unset, every such request is mapped and opens an upstream stream again.
+	NegativeCache *NegativeCache `protobuf:"bytes,30,opt,name=negative_cache,json=negativeCache,proto3" json:"negative_cache,omitempty"`
+	// Health scoring of the upstream streams of aggregated keys by the time since their last response, and restarts
+	// of the streams that fall silent. If unset, silent upstream streams are left open.
+	UpstreamHealth *UpstreamHealth `protobuf:"bytes,31,opt,name=upstream_health,json=upstreamHealth,proto3" json:"upstream_health,omitempty"`
+	// A consistent hash ring of relay replicas, each of which holds the upstream streams of the aggregated keys it
+	// owns. If unset, every replica holds an upstream stream for every aggregated key it serves.
+	HashRing *HashRing `protobuf:"bytes,32,opt,name=hash_ring,json=hashRing,proto3" json:"hash_ring,omitempty"`
+	// Chaining of relays, where the origin server of this relay is another relay. If unset, requests that already
+	// passed through this relay are not detected, and the requests sent upstream do not carry the hops of the chain.
+	RelayChain *RelayChain `protobuf:"bytes,33,opt,name=relay_chain,json=relayChain,proto3" json:"relay_chain,omitempty"`
+	// A parent relay that the upstream streams of aggregated keys are opened with before falling back to the origin
+	// server. If unset, every upstream stream is opened with the origin server.
+	ParentRelay *ParentRelay `protobuf:"bytes,34,opt,name=parent_relay,json=parentRelay,proto3" json:"parent_relay,omitempty"`
+	// Validation of the resources of upstream responses against their Envoy proto schemas before caching. If unset,
+	// responses are cached and served as received.
+	ResponseValidation *ResponseValidation `protobuf:"bytes,35,opt,name=response_validation,json=responseValidation,proto3" json:"response_validation,omitempty"`
+	// Lint checks of the references between the resources of upstream responses. If unset, references are not
+	// checked.
+	Linting *Linting `protobuf:"bytes,36,opt,name=linting,proto3" json:"linting,omitempty"`
+	// Fanout of the responses of aggregated keys that update together in the order of the references between their
+	// resources. If unset, every response is fanned out as soon as it is received.
+	DependencyOrdering *DependencyOrdering `protobuf:"bytes,37,opt,name=dependency_ordering,json=dependencyOrdering,proto3" json:"dependency_ordering,omitempty"`
+	// Retention of the previous versions of every aggregated key, to view, diff, and roll back to. If unset, only the
+	// cached version is held.
+	History *History `protobuf:"bytes,38,opt,name=history,proto3" json:"history,omitempty"`
+	// Windows during which new upstream versions are cached but not fanned out. If unset, new versions are always
+	// fanned out.
+	Maintenance *Maintenance `protobuf:"bytes,39,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
+	// Guard against aggregated keys whose upstream versions change too often. If unset, every new version is fanned
+	// out.
+	ChangeRateGuard *ChangeRateGuard `protobuf:"bytes,40,opt,name=change_rate_guard,json=changeRateGuard,proto3" json:"change_rate_guard,omitempty"`
+	// Pacing of the downstream sends of large fanouts. If unset, the sends of a fanout run at once.
+	FanoutPacing *FanoutPacing `protobuf:"bytes,41,opt,name=fanout_pacing,json=fanoutPacing,proto3" json:"fanout_pacing,omitempty"`
+	// Unary Fetch requests, over REST or gRPC. If unset, requests for keys that are not cached wait up to 10s for the
+	// upstream response, and the upstream stream is kept open.
+	Fetch *Fetch `protobuf:"bytes,42,opt,name=fetch,proto3" json:"fetch,omitempty"`
+	// Buffer sizes and overflow policies of the response channels. If unset, every downstream watch buffers one
+	// response and drops the responses that do not fit, and upstream streams wait for the relay to take each response.
+	Buffers *Buffers `protobuf:"bytes,43,opt,name=buffers,proto3" json:"buffers,omitempty"`
+	// Which aggregated keys tag their metrics with the key. If unset, metrics are not tagged with the key.
+	KeyMetrics *KeyMetrics `protobuf:"bytes,44,opt,name=key_metrics,json=keyMetrics,proto3" json:"key_metrics,omitempty"`
+	// Revisions of the aggregation rules staged on a share of the downstream nodes. If unset, every node is mapped
+	// with the aggregation rules the relay is started with.
+	AggregationRollout *AggregationRollout `protobuf:"bytes,45,opt,name=aggregation_rollout,json=aggregationRollout,proto3" json:"aggregation_rollout,omitempty"`
+	// Candidate aggregation rules evaluated alongside the serving rules for every request. If unset, requests are only
+	// mapped with the serving rules.
+	AggregationSimulation *AggregationSimulation `protobuf:"bytes,46,opt,name=aggregation_simulation,json=aggregationSimulation,proto3" json:"aggregation_simulation,omitempty"`
+	// Relaying of the load reports of downstream Envoys to the origin server. If unset, the load reporting service is
+	// not served.
+	LoadReporting *LoadReporting `protobuf:"bytes,47,opt,name=load_reporting,json=loadReporting,proto3" json:"load_reporting,omitempty"`
 }
 
-func (x *Cache) Reset() {
-	*x = Cache{}
+func (x *Bootstrap) Reset() {
+	*x = Bootstrap{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[4]
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Cache) String() string {
+func (x *Bootstrap) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Cache) ProtoMessage() {}
+func (*Bootstrap) ProtoMessage() {}
 
-func (x *Cache) ProtoReflect() protoreflect.Message {
-	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[4]
+func (x *Bootstrap) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -369,408 +814,8340 @@ func (x *Cache) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Cache.ProtoReflect.Descriptor instead.
-func (*Cache) Descriptor() ([]byte, []int) {
-	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Bootstrap.ProtoReflect.Descriptor instead.
+func (*Bootstrap) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *Cache) GetTtl() *duration.Duration {
+func (x *Bootstrap) GetServer() *Server {
 	if x != nil {
-		return x.Ttl
+		return x.Server
 	}
 	return nil
 }
 
-func (x *Cache) GetMaxEntries() int32 {
+func (x *Bootstrap) GetOriginServer() *Upstream {
 	if x != nil {
-		return x.MaxEntries
+		return x.OriginServer
 	}
-	return 0
+	return nil
 }
 
-// [#next-free-field: 3]
-type SocketAddress struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	// The address for this socket. Listeners will bind to the address.
-	Address   string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	PortValue uint32 `protobuf:"varint,2,opt,name=port_value,json=portValue,proto3" json:"port_value,omitempty"`
+func (x *Bootstrap) GetLogging() *Logging {
+	if x != nil {
+		return x.Logging
+	}
+	return nil
 }
 
-func (x *SocketAddress) Reset() {
-	*x = SocketAddress{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Bootstrap) GetCache() *Cache {
+	if x != nil {
+		return x.Cache
 	}
+	return nil
 }
 
-func (x *SocketAddress) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Bootstrap) GetMetricsSink() *MetricsSink {
+	if x != nil {
+		return x.MetricsSink
+	}
+	return nil
 }
 
-func (*SocketAddress) ProtoMessage() {}
-
-func (x *SocketAddress) ProtoReflect() protoreflect.Message {
-	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Bootstrap) GetAdmin() *Admin {
+	if x != nil {
+		return x.Admin
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use SocketAddress.ProtoReflect.Descriptor instead.
-func (*SocketAddress) Descriptor() ([]byte, []int) {
-	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{5}
+func (x *Bootstrap) GetVersionGuard() *VersionGuard {
+	if x != nil {
+		return x.VersionGuard
+	}
+	return nil
 }
 
-func (x *SocketAddress) GetAddress() string {
+func (x *Bootstrap) GetNotifications() *Notifications {
 	if x != nil {
-		return x.Address
+		return x.Notifications
 	}
-	return ""
+	return nil
 }
 
-func (x *SocketAddress) GetPortValue() uint32 {
+func (x *Bootstrap) GetLeaderElection() *LeaderElection {
 	if x != nil {
-		return x.PortValue
+		return x.LeaderElection
 	}
-	return 0
+	return nil
 }
 
-// [#next-free-field: 2]
-type Admin struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	// The TCP address that the admin server will listen on.
-	Address *SocketAddress `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+func (x *Bootstrap) GetReplication() *Replication {
+	if x != nil {
+		return x.Replication
+	}
+	return nil
 }
 
-func (x *Admin) Reset() {
-	*x = Admin{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Bootstrap) GetDryRun() *DryRun {
+	if x != nil {
+		return x.DryRun
 	}
+	return nil
 }
 
-func (x *Admin) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Bootstrap) GetShadowServer() *Upstream {
+	if x != nil {
+		return x.ShadowServer
+	}
+	return nil
 }
 
-func (*Admin) ProtoMessage() {}
+func (x *Bootstrap) GetTransformations() []*Transformation {
+	if x != nil {
+		return x.Transformations
+	}
+	return nil
+}
 
-func (x *Admin) ProtoReflect() protoreflect.Message {
-	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[6]
+func (x *Bootstrap) GetOverrideFiles() *OverrideFiles {
+	if x != nil {
+		return x.OverrideFiles
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetStaticResponses() []*StaticResponse {
+	if x != nil {
+		return x.StaticResponses
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetRecording() *Recording {
+	if x != nil {
+		return x.Recording
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetReplay() *Replay {
+	if x != nil {
+		return x.Replay
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetSupervision() *Supervision {
+	if x != nil {
+		return x.Supervision
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetFanoutScheduling() *FanoutScheduling {
+	if x != nil {
+		return x.FanoutScheduling
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetAuditLog() *AuditLog {
+	if x != nil {
+		return x.AuditLog
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetSignatureVerification() *SignatureVerification {
+	if x != nil {
+		return x.SignatureVerification
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetResponseLimits() []*ResponseLimit {
+	if x != nil {
+		return x.ResponseLimits
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetMemory() *Memory {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetControlPlaneIdentifier() *ControlPlaneIdentifier {
+	if x != nil {
+		return x.ControlPlaneIdentifier
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetRollout() *Rollout {
+	if x != nil {
+		return x.Rollout
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetCircuitBreaker() *CircuitBreaker {
+	if x != nil {
+		return x.CircuitBreaker
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetDifferentialFanout() *DifferentialFanout {
+	if x != nil {
+		return x.DifferentialFanout
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetContentDeduplication() *ContentDeduplication {
+	if x != nil {
+		return x.ContentDeduplication
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetAlerting() *Alerting {
+	if x != nil {
+		return x.Alerting
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetNegativeCache() *NegativeCache {
+	if x != nil {
+		return x.NegativeCache
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetUpstreamHealth() *UpstreamHealth {
+	if x != nil {
+		return x.UpstreamHealth
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetHashRing() *HashRing {
+	if x != nil {
+		return x.HashRing
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetRelayChain() *RelayChain {
+	if x != nil {
+		return x.RelayChain
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetParentRelay() *ParentRelay {
+	if x != nil {
+		return x.ParentRelay
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetResponseValidation() *ResponseValidation {
+	if x != nil {
+		return x.ResponseValidation
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetLinting() *Linting {
+	if x != nil {
+		return x.Linting
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetDependencyOrdering() *DependencyOrdering {
+	if x != nil {
+		return x.DependencyOrdering
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetHistory() *History {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetMaintenance() *Maintenance {
+	if x != nil {
+		return x.Maintenance
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetChangeRateGuard() *ChangeRateGuard {
+	if x != nil {
+		return x.ChangeRateGuard
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetFanoutPacing() *FanoutPacing {
+	if x != nil {
+		return x.FanoutPacing
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetFetch() *Fetch {
+	if x != nil {
+		return x.Fetch
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetBuffers() *Buffers {
+	if x != nil {
+		return x.Buffers
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetKeyMetrics() *KeyMetrics {
+	if x != nil {
+		return x.KeyMetrics
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetAggregationRollout() *AggregationRollout {
+	if x != nil {
+		return x.AggregationRollout
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetAggregationSimulation() *AggregationSimulation {
+	if x != nil {
+		return x.AggregationSimulation
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetLoadReporting() *LoadReporting {
+	if x != nil {
+		return x.LoadReporting
+	}
+	return nil
+}
+
+// [#next-free-field: 11]
+type Server struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The TCP address that the xds-relay server will listen on.
+	Address *SocketAddress `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// The TCP address that serves xDS over REST-JSON at `/v2/discovery:{type}` and `/v3/discovery:{type}`. If unset,
+	// xDS is only served over gRPC.
+	RestAddress *SocketAddress `protobuf:"bytes,2,opt,name=rest_address,json=restAddress,proto3" json:"rest_address,omitempty"`
+	// Time after which a downstream watch that was sent a response is considered idle if its client has neither
+	// acknowledged nor rejected the response. The streams of idle watches are closed with an UNAVAILABLE status, so
+	// that clients reconnect. Watches that are waiting for a response never become idle. If unset, watches never
+	// become idle.
+	WatchIdleTimeout *duration.Duration `protobuf:"bytes,3,opt,name=watch_idle_timeout,json=watchIdleTimeout,proto3" json:"watch_idle_timeout,omitempty"`
+	// If true, the xDS server address also serves `status.StatusService`, which streams changes to the cached version
+	// of each aggregated key and the acknowledgements and rejections of the nodes that are served the key.
+	StatusService bool `protobuf:"varint,4,opt,name=status_service,json=statusService,proto3" json:"status_service,omitempty"`
+	// Admission control of new downstream xDS streams. If unset, every stream is accepted.
+	Admission *Admission `protobuf:"bytes,5,opt,name=admission,proto3" json:"admission,omitempty"`
+	// Built-in interceptors of the xDS server, e.g. panic recovery. Interceptors run in the order in which they are
+	// listed, the first one outermost, before admission control and the interceptors registered through the library
+	// API.
+	Interceptors []*Interceptor `protobuf:"bytes,6,rep,name=interceptors,proto3" json:"interceptors,omitempty"`
+	// If true, the nonce of every response sent downstream carries an opaque resumption token that records the
+	// response. Clients that echo the nonce of the last response of a type in their first request of the type after
+	// reconnecting, e.g. to a restarted relay, are taken to hold that response, and it is not sent to them again unless
+	// it changed. Tokens are not authenticated, and only affect the responses sent to the client that presents them.
+	ResumptionTokens bool `protobuf:"varint,7,opt,name=resumption_tokens,json=resumptionTokens,proto3" json:"resumption_tokens,omitempty"`
+	// Additional gRPC listeners, each of which serves a subset of the xDS services with its own TLS and admission
+	// control settings, for deployments where different fleets of clients may only reach certain services. The address
+	// above keeps serving CDS, EDS, LDS and RDS without TLS.
+	Listeners []*Listener `protobuf:"bytes,8,rep,name=listeners,proto3" json:"listeners,omitempty"`
+	// If true, the xDS server address also serves the v3 CDS, EDS, LDS and RDS services, so that Envoys migrating to
+	// the v3 API can be served from the v2 responses that the relay caches. Type URLs, including those of nested
+	// `Any` payloads, are translated between the API versions; the messages of both versions are wire compatible.
+	V3Services bool `protobuf:"varint,9,opt,name=v3_services,json=v3Services,proto3" json:"v3_services,omitempty"`
+	// If true, the xDS server address also serves the virtual host discovery service (VHDS), which is only served
+	// incrementally. Each downstream VHDS stream is relayed on its own stream with the origin server. It is not served
+	// when a recording is replayed, nor in validate mode.
+	VhdsService bool `protobuf:"varint,10,opt,name=vhds_service,json=vhdsService,proto3" json:"vhds_service,omitempty"`
+}
+
+func (x *Server) Reset() {
+	*x = Server{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Server) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server) ProtoMessage() {}
+
+func (x *Server) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Admin.ProtoReflect.Descriptor instead.
-func (*Admin) Descriptor() ([]byte, []int) {
-	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *Admin) GetAddress() *SocketAddress {
-	if x != nil {
-		return x.Address
-	}
-	return nil
-}
-
-// The type of metrics sink, i.e. statsd, prometheus, etc.
-type MetricsSink struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	// Types that are assignable to Type:
-	//	*MetricsSink_Statsd
-	Type isMetricsSink_Type `protobuf_oneof:"type"`
-}
-
-func (x *MetricsSink) Reset() {
-	*x = MetricsSink{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *MetricsSink) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*MetricsSink) ProtoMessage() {}
-
-func (x *MetricsSink) ProtoReflect() protoreflect.Message {
-	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server.ProtoReflect.Descriptor instead.
+func (*Server) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Server) GetAddress() *SocketAddress {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Server) GetRestAddress() *SocketAddress {
+	if x != nil {
+		return x.RestAddress
+	}
+	return nil
+}
+
+func (x *Server) GetWatchIdleTimeout() *duration.Duration {
+	if x != nil {
+		return x.WatchIdleTimeout
+	}
+	return nil
+}
+
+func (x *Server) GetStatusService() bool {
+	if x != nil {
+		return x.StatusService
+	}
+	return false
+}
+
+func (x *Server) GetAdmission() *Admission {
+	if x != nil {
+		return x.Admission
+	}
+	return nil
+}
+
+func (x *Server) GetInterceptors() []*Interceptor {
+	if x != nil {
+		return x.Interceptors
+	}
+	return nil
+}
+
+func (x *Server) GetResumptionTokens() bool {
+	if x != nil {
+		return x.ResumptionTokens
+	}
+	return false
+}
+
+func (x *Server) GetListeners() []*Listener {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+func (x *Server) GetV3Services() bool {
+	if x != nil {
+		return x.V3Services
+	}
+	return false
+}
+
+func (x *Server) GetVhdsService() bool {
+	if x != nil {
+		return x.VhdsService
+	}
+	return false
+}
+
+// A gRPC listener that serves a subset of the xDS services.
+// [#next-free-field: 6]
+type Listener struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the listener, which tags its metrics.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The TCP address that the listener binds to.
+	Address  *SocketAddress     `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Services []Listener_Service `protobuf:"varint,3,rep,packed,name=services,proto3,enum=bootstrap.Listener_Service" json:"services,omitempty"`
+	// Serves the listener over TLS. If unset, the listener serves plaintext.
+	Tls *TLS `protobuf:"bytes,4,opt,name=tls,proto3" json:"tls,omitempty"`
+	// Admission control of the new streams of the listener, independently of the other listeners. If unset, every
+	// stream is accepted.
+	Admission *Admission `protobuf:"bytes,5,opt,name=admission,proto3" json:"admission,omitempty"`
+}
+
+func (x *Listener) Reset() {
+	*x = Listener{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Listener) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Listener) ProtoMessage() {}
+
+func (x *Listener) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Listener.ProtoReflect.Descriptor instead.
+func (*Listener) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Listener) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Listener) GetAddress() *SocketAddress {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Listener) GetServices() []Listener_Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *Listener) GetTls() *TLS {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+func (x *Listener) GetAdmission() *Admission {
+	if x != nil {
+		return x.Admission
+	}
+	return nil
+}
+
+// [#next-free-field: 4]
+type TLS struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path of the PEM-encoded certificate chain of the server.
+	CertFile string `protobuf:"bytes,1,opt,name=cert_file,json=certFile,proto3" json:"cert_file,omitempty"`
+	// Path of the PEM-encoded private key of the server.
+	KeyFile string `protobuf:"bytes,2,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	// Path of the PEM-encoded certificates of the CAs that sign client certificates. If set, clients must present a
+	// certificate signed by one of them. If unset, client certificates are not requested.
+	ClientCaFile string `protobuf:"bytes,3,opt,name=client_ca_file,json=clientCaFile,proto3" json:"client_ca_file,omitempty"`
+}
+
+func (x *TLS) Reset() {
+	*x = TLS{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TLS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TLS) ProtoMessage() {}
+
+func (x *TLS) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TLS.ProtoReflect.Descriptor instead.
+func (*TLS) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TLS) GetCertFile() string {
+	if x != nil {
+		return x.CertFile
+	}
+	return ""
+}
+
+func (x *TLS) GetKeyFile() string {
+	if x != nil {
+		return x.KeyFile
+	}
+	return ""
+}
+
+func (x *TLS) GetClientCaFile() string {
+	if x != nil {
+		return x.ClientCaFile
+	}
+	return ""
+}
+
+// A built-in interceptor of the streams and calls of the xDS server.
+type Interceptor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Type:
+	//	*Interceptor_Recovery_
+	//	*Interceptor_RequestId
+	Type isInterceptor_Type `protobuf_oneof:"type"`
+}
+
+func (x *Interceptor) Reset() {
+	*x = Interceptor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Interceptor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Interceptor) ProtoMessage() {}
+
+func (x *Interceptor) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Interceptor.ProtoReflect.Descriptor instead.
+func (*Interceptor) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{4}
+}
+
+func (m *Interceptor) GetType() isInterceptor_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (x *Interceptor) GetRecovery() *Interceptor_Recovery {
+	if x, ok := x.GetType().(*Interceptor_Recovery_); ok {
+		return x.Recovery
+	}
+	return nil
+}
+
+func (x *Interceptor) GetRequestId() *Interceptor_RequestID {
+	if x, ok := x.GetType().(*Interceptor_RequestId); ok {
+		return x.RequestId
+	}
+	return nil
+}
+
+type isInterceptor_Type interface {
+	isInterceptor_Type()
+}
+
+type Interceptor_Recovery_ struct {
+	// Recovers from panics in the handlers of streams and calls, failing them with an INTERNAL status instead of
+	// crashing the relay.
+	Recovery *Interceptor_Recovery `protobuf:"bytes,1,opt,name=recovery,proto3,oneof"`
+}
+
+type Interceptor_RequestId struct {
+	// Identifies each stream and call by a request ID.
+	RequestId *Interceptor_RequestID `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3,oneof"`
+}
+
+func (*Interceptor_Recovery_) isInterceptor_Type() {}
+
+func (*Interceptor_RequestId) isInterceptor_Type() {}
+
+// Smooths the reconnection of every client at once, such as after a restart, by accepting new downstream xDS streams
+// at a limited rate. Streams beyond the rate are closed with a RESOURCE_EXHAUSTED status and a
+// `grpc-retry-pushback-ms` trailer hinting when to retry.
+// [#next-free-field: 5]
+type Admission struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of new streams accepted per second.
+	StreamsPerSecond float64 `protobuf:"fixed64,1,opt,name=streams_per_second,json=streamsPerSecond,proto3" json:"streams_per_second,omitempty"`
+	// Number of new streams accepted at once before the rate applies. Defaults to streams_per_second, rounded up.
+	Burst uint32 `protobuf:"varint,2,opt,name=burst,proto3" json:"burst,omitempty"`
+	// Maximum random delay added to the retry hint of each rejected stream, so that rejected clients spread their
+	// reconnects instead of retrying together. If unset, hints are not jittered.
+	MaxRetryJitter *duration.Duration `protobuf:"bytes,3,opt,name=max_retry_jitter,json=maxRetryJitter,proto3" json:"max_retry_jitter,omitempty"`
+	// Time after startup during which streams are admission controlled. If unset, streams are always admission
+	// controlled.
+	StartupDuration *duration.Duration `protobuf:"bytes,4,opt,name=startup_duration,json=startupDuration,proto3" json:"startup_duration,omitempty"`
+}
+
+func (x *Admission) Reset() {
+	*x = Admission{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Admission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Admission) ProtoMessage() {}
+
+func (x *Admission) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Admission.ProtoReflect.Descriptor instead.
+func (*Admission) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Admission) GetStreamsPerSecond() float64 {
+	if x != nil {
+		return x.StreamsPerSecond
+	}
+	return 0
+}
+
+func (x *Admission) GetBurst() uint32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+func (x *Admission) GetMaxRetryJitter() *duration.Duration {
+	if x != nil {
+		return x.MaxRetryJitter
+	}
+	return nil
+}
+
+func (x *Admission) GetStartupDuration() *duration.Duration {
+	if x != nil {
+		return x.StartupDuration
+	}
+	return nil
+}
+
+// [#next-free-field: 7]
+type Upstream struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The address for the upstream cluster.
+	Address *SocketAddress `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// The proxy that connections to the upstream cluster egress through. If unset, the upstream cluster is dialed
+	// directly. Ignored for Unix domain sockets.
+	Proxy *Proxy `protobuf:"bytes,2,opt,name=proxy,proto3" json:"proxy,omitempty"`
+	// Discovers the endpoints of the upstream cluster by resolving the hostname of the address periodically. If
+	// unset, gRPC resolves the hostname when connecting. Ignored for Unix domain sockets.
+	Discovery *Upstream_Discovery `protobuf:"bytes,3,opt,name=discovery,proto3" json:"discovery,omitempty"`
+	// gRPC metadata attached to every stream with the upstream cluster, e.g. to authenticate to managed control
+	// planes.
+	Metadata []*Upstream_Metadata `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty"`
+	// Call credentials that authenticate the relay to managed control planes, such as Traffic Director. The token of
+	// the credentials is attached to every stream in the `authorization` metadata, and is refreshed before it
+	// expires.
+	Credentials *Upstream_Credentials `protobuf:"bytes,5,opt,name=credentials,proto3" json:"credentials,omitempty"`
+	ApiVersion  Upstream_ApiVersion   `protobuf:"varint,6,opt,name=api_version,json=apiVersion,proto3,enum=bootstrap.Upstream_ApiVersion" json:"api_version,omitempty"`
+}
+
+func (x *Upstream) Reset() {
+	*x = Upstream{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upstream) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream) ProtoMessage() {}
+
+func (x *Upstream) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream.ProtoReflect.Descriptor instead.
+func (*Upstream) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Upstream) GetAddress() *SocketAddress {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Upstream) GetProxy() *Proxy {
+	if x != nil {
+		return x.Proxy
+	}
+	return nil
+}
+
+func (x *Upstream) GetDiscovery() *Upstream_Discovery {
+	if x != nil {
+		return x.Discovery
+	}
+	return nil
+}
+
+func (x *Upstream) GetMetadata() []*Upstream_Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Upstream) GetCredentials() *Upstream_Credentials {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *Upstream) GetApiVersion() Upstream_ApiVersion {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return Upstream_V2
+}
+
+// An egress proxy, for relays that reach the upstream cluster across network boundaries.
+// [#next-free-field: 5]
+type Proxy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type Proxy_Type `protobuf:"varint,1,opt,name=type,proto3,enum=bootstrap.Proxy_Type" json:"type,omitempty"`
+	// The TCP address of the proxy.
+	Address *SocketAddress `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// The username that authenticates to the proxy, with Basic authentication for HTTP proxies and username/password
+	// authentication for SOCKS5 proxies. If unset, the relay does not authenticate.
+	Username string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	// Path of the file that holds the password of the username. Trailing newlines are ignored.
+	PasswordFile string `protobuf:"bytes,4,opt,name=password_file,json=passwordFile,proto3" json:"password_file,omitempty"`
+}
+
+func (x *Proxy) Reset() {
+	*x = Proxy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Proxy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proxy) ProtoMessage() {}
+
+func (x *Proxy) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proxy.ProtoReflect.Descriptor instead.
+func (*Proxy) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Proxy) GetType() Proxy_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Proxy_HTTP_CONNECT
+}
+
+func (x *Proxy) GetAddress() *SocketAddress {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Proxy) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Proxy) GetPasswordFile() string {
+	if x != nil {
+		return x.PasswordFile
+	}
+	return ""
+}
+
+// [#next-free-field: 4]
+type Logging struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Filepath where logs are emitted. If no filepath is specified, logs will be written to `/dev/null`.
+	Path  string        `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Level Logging_Level `protobuf:"varint,2,opt,name=level,proto3,enum=bootstrap.Logging_Level" json:"level,omitempty"`
+	// The number of latest messages, debug messages included, kept for each aggregated key and served by the
+	// `/keys/<key>/events` admin endpoint, whatever the logging level. Keeping them formats the debug messages of the
+	// keys. If unset, no messages are kept.
+	KeyEventsSize uint32 `protobuf:"varint,3,opt,name=key_events_size,json=keyEventsSize,proto3" json:"key_events_size,omitempty"`
+}
+
+func (x *Logging) Reset() {
+	*x = Logging{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Logging) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Logging) ProtoMessage() {}
+
+func (x *Logging) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Logging.ProtoReflect.Descriptor instead.
+func (*Logging) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Logging) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Logging) GetLevel() Logging_Level {
+	if x != nil {
+		return x.Level
+	}
+	return Logging_INFO
+}
+
+func (x *Logging) GetKeyEventsSize() uint32 {
+	if x != nil {
+		return x.KeyEventsSize
+	}
+	return 0
+}
+
+// [#next-free-field: 5]
+type Cache struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Duration before which a key is evicted from the request/response cache. Zero means no expiration time.
+	// Expired keys are removed when they are next requested, and by a sweep that runs once every ttl.
+	Ttl *duration.Duration `protobuf:"bytes,1,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// The maximum number of keys allowed in the request/response cache. If unset, no maximum number will be enforced.
+	MaxEntries       int32                  `protobuf:"varint,2,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
+	EvictionPolicy   Cache_EvictionPolicy   `protobuf:"varint,3,opt,name=eviction_policy,json=evictionPolicy,proto3,enum=bootstrap.Cache_EvictionPolicy" json:"eviction_policy,omitempty"`
+	EvictionStrategy Cache_EvictionStrategy `protobuf:"varint,4,opt,name=eviction_strategy,json=evictionStrategy,proto3,enum=bootstrap.Cache_EvictionStrategy" json:"eviction_strategy,omitempty"`
+}
+
+func (x *Cache) Reset() {
+	*x = Cache{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cache) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cache) ProtoMessage() {}
+
+func (x *Cache) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cache.ProtoReflect.Descriptor instead.
+func (*Cache) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Cache) GetTtl() *duration.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *Cache) GetMaxEntries() int32 {
+	if x != nil {
+		return x.MaxEntries
+	}
+	return 0
+}
+
+func (x *Cache) GetEvictionPolicy() Cache_EvictionPolicy {
+	if x != nil {
+		return x.EvictionPolicy
+	}
+	return Cache_TERMINATE
+}
+
+func (x *Cache) GetEvictionStrategy() Cache_EvictionStrategy {
+	if x != nil {
+		return x.EvictionStrategy
+	}
+	return Cache_LRU
+}
+
+// [#next-free-field: 4]
+type SocketAddress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The address for this socket. Listeners will bind to the address. The xDS server and its listeners, and the
+	// origin, shadow, and parent relay servers, also accept `unix://` followed by the path of a Unix domain socket,
+	// e.g. for sidecar deployments, in which case port_value is ignored. Those addresses are validated by the relay,
+	// since the rule below only accepts hosts and IPs.
+	Address   string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	PortValue uint32 `protobuf:"varint,2,opt,name=port_value,json=portValue,proto3" json:"port_value,omitempty"`
+	// The permissions of the Unix domain socket that listeners bind to, e.g. 0660. If unset, the permissions follow
+	// the umask of the relay. Ignored for TCP addresses.
+	Mode uint32 `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+}
+
+func (x *SocketAddress) Reset() {
+	*x = SocketAddress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SocketAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SocketAddress) ProtoMessage() {}
+
+func (x *SocketAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SocketAddress.ProtoReflect.Descriptor instead.
+func (*SocketAddress) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SocketAddress) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *SocketAddress) GetPortValue() uint32 {
+	if x != nil {
+		return x.PortValue
+	}
+	return 0
+}
+
+func (x *SocketAddress) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+// [#next-free-field: 3]
+type Admin struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The TCP address that the admin server will listen on.
+	Address *SocketAddress `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Whether to serve pprof profiles at `/debug/pprof/`, expvar variables at `/debug/vars`, and runtime statistics
+	// at `/debug/runtime`. Profiles expose internals of the relay, so they are disabled by default.
+	EnableDebugEndpoints bool `protobuf:"varint,2,opt,name=enable_debug_endpoints,json=enableDebugEndpoints,proto3" json:"enable_debug_endpoints,omitempty"`
+}
+
+func (x *Admin) Reset() {
+	*x = Admin{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Admin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Admin) ProtoMessage() {}
+
+func (x *Admin) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Admin.ProtoReflect.Descriptor instead.
+func (*Admin) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Admin) GetAddress() *SocketAddress {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Admin) GetEnableDebugEndpoints() bool {
+	if x != nil {
+		return x.EnableDebugEndpoints
+	}
+	return false
+}
+
+// The type of metrics sink, i.e. statsd, prometheus, etc.
+type MetricsSink struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Type:
+	//	*MetricsSink_Statsd
+	Type isMetricsSink_Type `protobuf_oneof:"type"`
+}
+
+func (x *MetricsSink) Reset() {
+	*x = MetricsSink{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MetricsSink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricsSink) ProtoMessage() {}
+
+func (x *MetricsSink) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricsSink.ProtoReflect.Descriptor instead.
+func (*MetricsSink) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{12}
+}
+
+func (m *MetricsSink) GetType() isMetricsSink_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (x *MetricsSink) GetStatsd() *Statsd {
+	if x, ok := x.GetType().(*MetricsSink_Statsd); ok {
+		return x.Statsd
+	}
+	return nil
+}
+
+type isMetricsSink_Type interface {
+	isMetricsSink_Type()
+}
+
+type MetricsSink_Statsd struct {
+	Statsd *Statsd `protobuf:"bytes,1,opt,name=statsd,proto3,oneof"`
+}
+
+func (*MetricsSink_Statsd) isMetricsSink_Type() {}
+
+// [#next-free-field: 4]
+type Statsd struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address       *SocketAddress     `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	RootPrefix    string             `protobuf:"bytes,2,opt,name=root_prefix,json=rootPrefix,proto3" json:"root_prefix,omitempty"`
+	FlushInterval *duration.Duration `protobuf:"bytes,3,opt,name=flush_interval,json=flushInterval,proto3" json:"flush_interval,omitempty"`
+}
+
+func (x *Statsd) Reset() {
+	*x = Statsd{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Statsd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Statsd) ProtoMessage() {}
+
+func (x *Statsd) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Statsd.ProtoReflect.Descriptor instead.
+func (*Statsd) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Statsd) GetAddress() *SocketAddress {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Statsd) GetRootPrefix() string {
+	if x != nil {
+		return x.RootPrefix
+	}
+	return ""
+}
+
+func (x *Statsd) GetFlushInterval() *duration.Duration {
+	if x != nil {
+		return x.FlushInterval
+	}
+	return nil
+}
+
+// Detects upstream responses whose version moves backwards relative to the last cached response for an aggregated
+// key.
+// [#next-free-field: 3]
+type VersionGuard struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Comparator VersionGuard_Comparator `protobuf:"varint,1,opt,name=comparator,proto3,enum=bootstrap.VersionGuard_Comparator" json:"comparator,omitempty"`
+	// If true, responses that regress are dropped rather than cached and fanned out to downstream clients.
+	RejectRegressions bool `protobuf:"varint,2,opt,name=reject_regressions,json=rejectRegressions,proto3" json:"reject_regressions,omitempty"`
+}
+
+func (x *VersionGuard) Reset() {
+	*x = VersionGuard{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VersionGuard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionGuard) ProtoMessage() {}
+
+func (x *VersionGuard) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionGuard.ProtoReflect.Descriptor instead.
+func (*VersionGuard) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *VersionGuard) GetComparator() VersionGuard_Comparator {
+	if x != nil {
+		return x.Comparator
+	}
+	return VersionGuard_DISABLED
+}
+
+func (x *VersionGuard) GetRejectRegressions() bool {
+	if x != nil {
+		return x.RejectRegressions
+	}
+	return false
+}
+
+// [#next-free-field: 2]
+type Notifications struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Webhooks that receive a JSON event for every cache update.
+	Webhooks []*Webhook `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+}
+
+func (x *Notifications) Reset() {
+	*x = Notifications{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Notifications) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notifications) ProtoMessage() {}
+
+func (x *Notifications) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notifications.ProtoReflect.Descriptor instead.
+func (*Notifications) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Notifications) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+// [#next-free-field: 4]
+type Webhook struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The URL that events are POSTed to.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Timeout for each POST. Defaults to 5s if unset.
+	Timeout *duration.Duration `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// The maximum number of events queued for delivery. Events are dropped while the queue is full. Defaults to 100
+	// if unset.
+	QueueSize uint32 `protobuf:"varint,3,opt,name=queue_size,json=queueSize,proto3" json:"queue_size,omitempty"`
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetTimeout() *duration.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Webhook) GetQueueSize() uint32 {
+	if x != nil {
+		return x.QueueSize
+	}
+	return 0
+}
+
+// Elects a single leader among relay replicas sharing a lease. Only the leader opens streams to the origin server,
+// while followers serve downstream clients from the cache.
+// [#next-free-field: 5]
+type LeaderElection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique identity of this replica. Defaults to the hostname.
+	Identity string `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Time after the last observed renewal before a follower may take over the lease. Defaults to 15s.
+	LeaseDuration *duration.Duration `protobuf:"bytes,2,opt,name=lease_duration,json=leaseDuration,proto3" json:"lease_duration,omitempty"`
+	// Interval between attempts to acquire or renew the lease. Defaults to 2s. The leader steps down if the lease
+	// has not been renewed for lease_duration minus retry_period.
+	RetryPeriod *duration.Duration `protobuf:"bytes,3,opt,name=retry_period,json=retryPeriod,proto3" json:"retry_period,omitempty"`
+	// Types that are assignable to Backend:
+	//	*LeaderElection_KubernetesLease
+	Backend isLeaderElection_Backend `protobuf_oneof:"backend"`
+}
+
+func (x *LeaderElection) Reset() {
+	*x = LeaderElection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaderElection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderElection) ProtoMessage() {}
+
+func (x *LeaderElection) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderElection.ProtoReflect.Descriptor instead.
+func (*LeaderElection) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *LeaderElection) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *LeaderElection) GetLeaseDuration() *duration.Duration {
+	if x != nil {
+		return x.LeaseDuration
+	}
+	return nil
+}
+
+func (x *LeaderElection) GetRetryPeriod() *duration.Duration {
+	if x != nil {
+		return x.RetryPeriod
+	}
+	return nil
+}
+
+func (m *LeaderElection) GetBackend() isLeaderElection_Backend {
+	if m != nil {
+		return m.Backend
+	}
+	return nil
+}
+
+func (x *LeaderElection) GetKubernetesLease() *KubernetesLease {
+	if x, ok := x.GetBackend().(*LeaderElection_KubernetesLease); ok {
+		return x.KubernetesLease
+	}
+	return nil
+}
+
+type isLeaderElection_Backend interface {
+	isLeaderElection_Backend()
+}
+
+type LeaderElection_KubernetesLease struct {
+	KubernetesLease *KubernetesLease `protobuf:"bytes,4,opt,name=kubernetes_lease,json=kubernetesLease,proto3,oneof"`
+}
+
+func (*LeaderElection_KubernetesLease) isLeaderElection_Backend() {}
+
+// A coordination.k8s.io/v1 Lease object used as the election lock.
+// [#next-free-field: 6]
+type KubernetesLease struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The Kubernetes API server URL. Defaults to the in-cluster address from the KUBERNETES_SERVICE_HOST and
+	// KUBERNETES_SERVICE_PORT environment variables.
+	ApiServer string `protobuf:"bytes,3,opt,name=api_server,json=apiServer,proto3" json:"api_server,omitempty"`
+	// Path to the bearer token. Defaults to the in-cluster service account token.
+	TokenFile string `protobuf:"bytes,4,opt,name=token_file,json=tokenFile,proto3" json:"token_file,omitempty"`
+	// Path to the CA bundle used to verify the API server. Defaults to the in-cluster service account CA.
+	CaFile string `protobuf:"bytes,5,opt,name=ca_file,json=caFile,proto3" json:"ca_file,omitempty"`
+}
+
+func (x *KubernetesLease) Reset() {
+	*x = KubernetesLease{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KubernetesLease) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesLease) ProtoMessage() {}
+
+func (x *KubernetesLease) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesLease.ProtoReflect.Descriptor instead.
+func (*KubernetesLease) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *KubernetesLease) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *KubernetesLease) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *KubernetesLease) GetApiServer() string {
+	if x != nil {
+		return x.ApiServer
+	}
+	return ""
+}
+
+func (x *KubernetesLease) GetTokenFile() string {
+	if x != nil {
+		return x.TokenFile
+	}
+	return ""
+}
+
+func (x *KubernetesLease) GetCaFile() string {
+	if x != nil {
+		return x.CaFile
+	}
+	return ""
+}
+
+// Replicates cached responses between relays so that standby or regional replicas stay warm.
+//
+// A replicated response is discarded unless it is newer than the locally cached response. If a version_guard
+// comparator is configured, versions are ordered by it, and responses whose version cannot be compared with the cached
+// version are discarded. Otherwise any response whose version differs from the cached version is considered newer, so
+// the latest response received wins.
+// [#next-free-field: 5]
+type Replication struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The peer whose cache updates are replicated into this relay's cache. If unset, nothing is replicated.
+	Source *SocketAddress `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// Serves this relay's cache updates to peers. The cache of every key can be streamed from the listener, so it is
+	// kept apart from the xDS server address. If unset, cache updates are not served.
+	Listener *ReplicationListener `protobuf:"bytes,3,opt,name=listener,proto3" json:"listener,omitempty"`
+	// Connects to the source over TLS. If unset, the source is connected to in plaintext.
+	SourceTls *ClientTLS `protobuf:"bytes,4,opt,name=source_tls,json=sourceTls,proto3" json:"source_tls,omitempty"`
+}
+
+func (x *Replication) Reset() {
+	*x = Replication{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Replication) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Replication) ProtoMessage() {}
+
+func (x *Replication) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Replication.ProtoReflect.Descriptor instead.
+func (*Replication) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Replication) GetSource() *SocketAddress {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *Replication) GetListener() *ReplicationListener {
+	if x != nil {
+		return x.Listener
+	}
+	return nil
+}
+
+func (x *Replication) GetSourceTls() *ClientTLS {
+	if x != nil {
+		return x.SourceTls
+	}
+	return nil
+}
+
+// The gRPC listener that serves cache updates to peers.
+// [#next-free-field: 3]
+type ReplicationListener struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The TCP address that the listener binds to.
+	Address *SocketAddress `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Serves the listener over TLS. If the config has client CAs, only peers presenting a certificate signed by one of
+	// them may stream cache updates. If unset, the listener serves plaintext to any peer.
+	Tls *TLS `protobuf:"bytes,2,opt,name=tls,proto3" json:"tls,omitempty"`
+}
+
+func (x *ReplicationListener) Reset() {
+	*x = ReplicationListener{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplicationListener) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationListener) ProtoMessage() {}
+
+func (x *ReplicationListener) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationListener.ProtoReflect.Descriptor instead.
+func (*ReplicationListener) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReplicationListener) GetAddress() *SocketAddress {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *ReplicationListener) GetTls() *TLS {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+// [#next-free-field: 4]
+type ClientTLS struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path of the PEM-encoded certificates of the CAs that sign the server certificate. If unset, the CAs of the host
+	// are used.
+	CaFile string `protobuf:"bytes,1,opt,name=ca_file,json=caFile,proto3" json:"ca_file,omitempty"`
+	// Path of the PEM-encoded certificate chain presented to the server. If unset, no client certificate is presented.
+	CertFile string `protobuf:"bytes,2,opt,name=cert_file,json=certFile,proto3" json:"cert_file,omitempty"`
+	// Path of the PEM-encoded private key of the client certificate. Required if cert_file is set.
+	KeyFile string `protobuf:"bytes,3,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+}
+
+func (x *ClientTLS) Reset() {
+	*x = ClientTLS{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientTLS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientTLS) ProtoMessage() {}
+
+func (x *ClientTLS) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientTLS.ProtoReflect.Descriptor instead.
+func (*ClientTLS) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ClientTLS) GetCaFile() string {
+	if x != nil {
+		return x.CaFile
+	}
+	return ""
+}
+
+func (x *ClientTLS) GetCertFile() string {
+	if x != nil {
+		return x.CertFile
+	}
+	return ""
+}
+
+func (x *ClientTLS) GetKeyFile() string {
+	if x != nil {
+		return x.KeyFile
+	}
+	return ""
+}
+
+// Subscribes upstream without serving downstream, so that aggregation keys and origin server behavior can be
+// validated before traffic is cut over. Responses are cached and diffed as in serve mode.
+// [#next-free-field: 2]
+type DryRun struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subscriptions []*DryRunSubscription `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+}
+
+func (x *DryRun) Reset() {
+	*x = DryRun{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DryRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DryRun) ProtoMessage() {}
+
+func (x *DryRun) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DryRun.ProtoReflect.Descriptor instead.
+func (*DryRun) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DryRun) GetSubscriptions() []*DryRunSubscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+// A discovery request sent on behalf of a downstream client.
+// [#next-free-field: 5]
+type DryRunSubscription struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The resource type URL, e.g. `type.googleapis.com/envoy.api.v2.Cluster`.
+	TypeUrl     string `protobuf:"bytes,1,opt,name=type_url,json=typeUrl,proto3" json:"type_url,omitempty"`
+	NodeId      string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	NodeCluster string `protobuf:"bytes,3,opt,name=node_cluster,json=nodeCluster,proto3" json:"node_cluster,omitempty"`
+	// The resource names to request. If empty, all resources of the type are requested.
+	ResourceNames []string `protobuf:"bytes,4,rep,name=resource_names,json=resourceNames,proto3" json:"resource_names,omitempty"`
+}
+
+func (x *DryRunSubscription) Reset() {
+	*x = DryRunSubscription{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DryRunSubscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DryRunSubscription) ProtoMessage() {}
+
+func (x *DryRunSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DryRunSubscription.ProtoReflect.Descriptor instead.
+func (*DryRunSubscription) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DryRunSubscription) GetTypeUrl() string {
+	if x != nil {
+		return x.TypeUrl
+	}
+	return ""
+}
+
+func (x *DryRunSubscription) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *DryRunSubscription) GetNodeCluster() string {
+	if x != nil {
+		return x.NodeCluster
+	}
+	return ""
+}
+
+func (x *DryRunSubscription) GetResourceNames() []string {
+	if x != nil {
+		return x.ResourceNames
+	}
+	return nil
+}
+
+// Mutates the resources of origin server responses, e.g. to strip a deprecated field or to disable a feature.
+// [#next-free-field: 5]
+type Transformation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The type URLs of the responses to transform. If empty, responses of every type are transformed.
+	TypeUrls []string `protobuf:"bytes,1,rep,name=type_urls,json=typeUrls,proto3" json:"type_urls,omitempty"`
+	// Types that are assignable to Transformer:
+	//	*Transformation_StripFields
+	//	*Transformation_SetFields
+	//	*Transformation_GoPlugin
+	Transformer isTransformation_Transformer `protobuf_oneof:"transformer"`
+}
+
+func (x *Transformation) Reset() {
+	*x = Transformation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Transformation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transformation) ProtoMessage() {}
+
+func (x *Transformation) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transformation.ProtoReflect.Descriptor instead.
+func (*Transformation) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Transformation) GetTypeUrls() []string {
+	if x != nil {
+		return x.TypeUrls
+	}
+	return nil
+}
+
+func (m *Transformation) GetTransformer() isTransformation_Transformer {
+	if m != nil {
+		return m.Transformer
+	}
+	return nil
+}
+
+func (x *Transformation) GetStripFields() *StripFields {
+	if x, ok := x.GetTransformer().(*Transformation_StripFields); ok {
+		return x.StripFields
+	}
+	return nil
+}
+
+func (x *Transformation) GetSetFields() *SetFields {
+	if x, ok := x.GetTransformer().(*Transformation_SetFields); ok {
+		return x.SetFields
+	}
+	return nil
+}
+
+func (x *Transformation) GetGoPlugin() *GoPlugin {
+	if x, ok := x.GetTransformer().(*Transformation_GoPlugin); ok {
+		return x.GoPlugin
+	}
+	return nil
+}
+
+type isTransformation_Transformer interface {
+	isTransformation_Transformer()
+}
+
+type Transformation_StripFields struct {
+	StripFields *StripFields `protobuf:"bytes,2,opt,name=strip_fields,json=stripFields,proto3,oneof"`
+}
+
+type Transformation_SetFields struct {
+	SetFields *SetFields `protobuf:"bytes,3,opt,name=set_fields,json=setFields,proto3,oneof"`
+}
+
+type Transformation_GoPlugin struct {
+	GoPlugin *GoPlugin `protobuf:"bytes,4,opt,name=go_plugin,json=goPlugin,proto3,oneof"`
+}
+
+func (*Transformation_StripFields) isTransformation_Transformer() {}
+
+func (*Transformation_SetFields) isTransformation_Transformer() {}
+
+func (*Transformation_GoPlugin) isTransformation_Transformer() {}
+
+// Clears fields of every resource in the response.
+// [#next-free-field: 2]
+type StripFields struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Dot-separated paths of proto field names relative to the resource, e.g.
+	// `common_lb_config.healthy_panic_threshold`.
+	Paths []string `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+}
+
+func (x *StripFields) Reset() {
+	*x = StripFields{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StripFields) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StripFields) ProtoMessage() {}
+
+func (x *StripFields) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StripFields.ProtoReflect.Descriptor instead.
+func (*StripFields) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *StripFields) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+// Sets fields of every resource in the response. Intermediate messages on the path are created if unset.
+// [#next-free-field: 2]
+type SetFields struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Values keyed by dot-separated paths of proto field names relative to the resource. Scalar, enum, and wrapper
+	// type fields can be set. Enums are set by name.
+	Values map[string]*_struct.Value `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *SetFields) Reset() {
+	*x = SetFields{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetFields) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFields) ProtoMessage() {}
+
+func (x *SetFields) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFields.ProtoReflect.Descriptor instead.
+func (*SetFields) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SetFields) GetValues() map[string]*_struct.Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// A Go plugin exporting a `Transform` function of type
+// `func(*envoy_api_v2.DiscoveryResponse) (*envoy_api_v2.DiscoveryResponse, error)`, where `envoy_api_v2` is the
+// `github.com/envoyproxy/go-control-plane/envoy/api/v2` package.
+// [#next-free-field: 2]
+type GoPlugin struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path to the plugin shared object.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *GoPlugin) Reset() {
+	*x = GoPlugin{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GoPlugin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoPlugin) ProtoMessage() {}
+
+func (x *GoPlugin) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoPlugin.ProtoReflect.Descriptor instead.
+func (*GoPlugin) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GoPlugin) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+// A directory of YAML or JSON files, each holding an `override.Overrides` message. The directory is reloaded
+// periodically, and changes are applied to the cached responses immediately.
+// [#next-free-field: 3]
+type OverrideFiles struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Directory string `protobuf:"bytes,1,opt,name=directory,proto3" json:"directory,omitempty"`
+	// The interval between reloads of the directory. Defaults to 10 seconds.
+	ReloadInterval *duration.Duration `protobuf:"bytes,2,opt,name=reload_interval,json=reloadInterval,proto3" json:"reload_interval,omitempty"`
+}
+
+func (x *OverrideFiles) Reset() {
+	*x = OverrideFiles{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OverrideFiles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverrideFiles) ProtoMessage() {}
+
+func (x *OverrideFiles) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverrideFiles.ProtoReflect.Descriptor instead.
+func (*OverrideFiles) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *OverrideFiles) GetDirectory() string {
+	if x != nil {
+		return x.Directory
+	}
+	return ""
+}
+
+func (x *OverrideFiles) GetReloadInterval() *duration.Duration {
+	if x != nil {
+		return x.ReloadInterval
+	}
+	return nil
+}
+
+// A discovery response served from a file, for resources that never change or for environments without an origin
+// server. If both an aggregated key and a type URL match a request, the response for the aggregated key is served.
+// [#next-free-field: 4]
+type StaticResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Match:
+	//	*StaticResponse_Key
+	//	*StaticResponse_TypeUrl
+	Match isStaticResponse_Match `protobuf_oneof:"match"`
+	// Path to a YAML or JSON file holding an `envoy.api.v2.DiscoveryResponse`. Resources are `Any` messages whose
+	// `@type` is the resource type URL. The type URL of the response defaults to the matched type URL, and the
+	// version defaults to `static`.
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *StaticResponse) Reset() {
+	*x = StaticResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StaticResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StaticResponse) ProtoMessage() {}
+
+func (x *StaticResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StaticResponse.ProtoReflect.Descriptor instead.
+func (*StaticResponse) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{29}
+}
+
+func (m *StaticResponse) GetMatch() isStaticResponse_Match {
+	if m != nil {
+		return m.Match
+	}
+	return nil
+}
+
+func (x *StaticResponse) GetKey() string {
+	if x, ok := x.GetMatch().(*StaticResponse_Key); ok {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *StaticResponse) GetTypeUrl() string {
+	if x, ok := x.GetMatch().(*StaticResponse_TypeUrl); ok {
+		return x.TypeUrl
+	}
+	return ""
+}
+
+func (x *StaticResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type isStaticResponse_Match interface {
+	isStaticResponse_Match()
+}
+
+type StaticResponse_Key struct {
+	// The aggregated key of the requests to respond to.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3,oneof"`
+}
+
+type StaticResponse_TypeUrl struct {
+	// The type URL of the requests to respond to.
+	TypeUrl string `protobuf:"bytes,2,opt,name=type_url,json=typeUrl,proto3,oneof"`
+}
+
+func (*StaticResponse_Key) isStaticResponse_Match() {}
+
+func (*StaticResponse_TypeUrl) isStaticResponse_Match() {}
+
+// A recording of the upstream responses, and optionally the downstream requests, of each aggregated key. Each
+// aggregated key is recorded to its own file of JSON lines in the directory. Files are appended to, so recordings
+// from several runs are concatenated.
+// [#next-free-field: 3]
+type Recording struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Directory string `protobuf:"bytes,1,opt,name=directory,proto3" json:"directory,omitempty"`
+	// Whether to also record the downstream requests of each aggregated key.
+	RecordRequests bool `protobuf:"varint,2,opt,name=record_requests,json=recordRequests,proto3" json:"record_requests,omitempty"`
+}
+
+func (x *Recording) Reset() {
+	*x = Recording{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Recording) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recording) ProtoMessage() {}
+
+func (x *Recording) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recording.ProtoReflect.Descriptor instead.
+func (*Recording) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Recording) GetDirectory() string {
+	if x != nil {
+		return x.Directory
+	}
+	return ""
+}
+
+func (x *Recording) GetRecordRequests() bool {
+	if x != nil {
+		return x.RecordRequests
+	}
+	return false
+}
+
+// A recorded session, served per aggregated key in the order it was recorded.
+// [#next-free-field: 3]
+type Replay struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The directory of a recording.
+	Directory string `protobuf:"bytes,1,opt,name=directory,proto3" json:"directory,omitempty"`
+	// Whether to wait between responses for as long as elapsed between them when they were recorded. Otherwise
+	// responses are replayed immediately.
+	PreserveTiming bool `protobuf:"varint,2,opt,name=preserve_timing,json=preserveTiming,proto3" json:"preserve_timing,omitempty"`
+}
+
+func (x *Replay) Reset() {
+	*x = Replay{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Replay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Replay) ProtoMessage() {}
+
+func (x *Replay) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Replay.ProtoReflect.Descriptor instead.
+func (*Replay) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Replay) GetDirectory() string {
+	if x != nil {
+		return x.Directory
+	}
+	return ""
+}
+
+func (x *Replay) GetPreserveTiming() bool {
+	if x != nil {
+		return x.PreserveTiming
+	}
+	return false
+}
+
+// Restart policy of the worker that handles the upstream responses of an aggregated key. A worker that panics is
+// restarted on the same upstream stream after a backoff. Once it has panicked more than max_restarts times, the
+// worker fails and its upstream stream is closed.
+// [#next-free-field: 3]
+type Supervision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of times a worker is restarted. Defaults to 3.
+	MaxRestarts *wrappers.UInt32Value `protobuf:"bytes,1,opt,name=max_restarts,json=maxRestarts,proto3" json:"max_restarts,omitempty"`
+	// Time to wait before restarting a worker. Defaults to 1s.
+	RestartBackoff *duration.Duration `protobuf:"bytes,2,opt,name=restart_backoff,json=restartBackoff,proto3" json:"restart_backoff,omitempty"`
+}
+
+func (x *Supervision) Reset() {
+	*x = Supervision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Supervision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Supervision) ProtoMessage() {}
+
+func (x *Supervision) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Supervision.ProtoReflect.Descriptor instead.
+func (*Supervision) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Supervision) GetMaxRestarts() *wrappers.UInt32Value {
+	if x != nil {
+		return x.MaxRestarts
+	}
+	return nil
+}
+
+func (x *Supervision) GetRestartBackoff() *duration.Duration {
+	if x != nil {
+		return x.RestartBackoff
+	}
+	return nil
+}
+
+// Skips sending a response to a watch that subscribes to specific resources, e.g. an EDS watch, if none of those
+// resources changed since the response last sent to the watch's node, which the node acknowledged. The watch stays
+// open and is sent the next response that changes its resources. Watches of all resources are always sent.
+// [#next-free-field: 2]
+type DifferentialFanout struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type URLs of the responses that are fanned out differentially, e.g.
+	// `type.googleapis.com/envoy.api.v2.ClusterLoadAssignment`. If empty, responses of every type are.
+	TypeUrls []string `protobuf:"bytes,1,rep,name=type_urls,json=typeUrls,proto3" json:"type_urls,omitempty"`
+}
+
+func (x *DifferentialFanout) Reset() {
+	*x = DifferentialFanout{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DifferentialFanout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DifferentialFanout) ProtoMessage() {}
+
+func (x *DifferentialFanout) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DifferentialFanout.ProtoReflect.Descriptor instead.
+func (*DifferentialFanout) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DifferentialFanout) GetTypeUrls() []string {
+	if x != nil {
+		return x.TypeUrls
+	}
+	return nil
+}
+
+// Some control planes bump the version of a response even though its resources are identical. Such responses are
+// detected by hashing the resources, independently of their order. They are cached, so that new watches are served
+// the latest version, but are not fanned out to open watches, which already hold the same resources.
+// [#next-free-field: 2]
+type ContentDeduplication struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type URLs of the responses that are deduplicated by content, e.g.
+	// `type.googleapis.com/envoy.api.v2.ClusterLoadAssignment`. If empty, responses of every type are.
+	TypeUrls []string `protobuf:"bytes,1,rep,name=type_urls,json=typeUrls,proto3" json:"type_urls,omitempty"`
+}
+
+func (x *ContentDeduplication) Reset() {
+	*x = ContentDeduplication{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContentDeduplication) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentDeduplication) ProtoMessage() {}
+
+func (x *ContentDeduplication) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentDeduplication.ProtoReflect.Descriptor instead.
+func (*ContentDeduplication) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ContentDeduplication) GetTypeUrls() []string {
+	if x != nil {
+		return x.TypeUrls
+	}
+	return nil
+}
+
+// Shares a fixed number of concurrent downstream sends fairly between the aggregated keys that are fanning out, so
+// that a large fanout does not delay the fanout of other keys.
+// [#next-free-field: 4]
+type FanoutScheduling struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of downstream sends that run concurrently.
+	Concurrency uint32 `protobuf:"varint,1,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	// Keys with pending sends are served in round-robin order, and each key sends up to its weight many responses
+	// per turn. The first weight whose regex matches the key applies, and other keys have a weight of 1.
+	Weights []*KeyWeight `protobuf:"bytes,2,rep,name=weights,proto3" json:"weights,omitempty"`
+	// Priority classes of resource types. Pending sends of a higher priority class always run before those of lower
+	// priority classes, such as to fan out listeners and clusters before the routes and endpoints that depend on
+	// them. Keys share their turns with the keys of the same priority class only. Types without a priority have a
+	// priority of 0.
+	TypePriorities []*TypePriority `protobuf:"bytes,3,rep,name=type_priorities,json=typePriorities,proto3" json:"type_priorities,omitempty"`
+}
+
+func (x *FanoutScheduling) Reset() {
+	*x = FanoutScheduling{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FanoutScheduling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FanoutScheduling) ProtoMessage() {}
+
+func (x *FanoutScheduling) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FanoutScheduling.ProtoReflect.Descriptor instead.
+func (*FanoutScheduling) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *FanoutScheduling) GetConcurrency() uint32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *FanoutScheduling) GetWeights() []*KeyWeight {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+func (x *FanoutScheduling) GetTypePriorities() []*TypePriority {
+	if x != nil {
+		return x.TypePriorities
+	}
+	return nil
+}
+
+// The priority class of the responses of a resource type.
+// [#next-free-field: 3]
+type TypePriority struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Ex: "type.googleapis.com/envoy.api.v2.Listener"
+	TypeUrl  string `protobuf:"bytes,1,opt,name=type_url,json=typeUrl,proto3" json:"type_url,omitempty"`
+	Priority int32  `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+}
+
+func (x *TypePriority) Reset() {
+	*x = TypePriority{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TypePriority) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypePriority) ProtoMessage() {}
+
+func (x *TypePriority) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypePriority.ProtoReflect.Descriptor instead.
+func (*TypePriority) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TypePriority) GetTypeUrl() string {
+	if x != nil {
+		return x.TypeUrl
+	}
+	return ""
+}
+
+func (x *TypePriority) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+// The fanout weight of the aggregated keys that match a regex.
+// [#next-free-field: 3]
+type KeyWeight struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KeyRegex string `protobuf:"bytes,1,opt,name=key_regex,json=keyRegex,proto3" json:"key_regex,omitempty"`
+	Weight   uint32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (x *KeyWeight) Reset() {
+	*x = KeyWeight{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyWeight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyWeight) ProtoMessage() {}
+
+func (x *KeyWeight) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyWeight.ProtoReflect.Descriptor instead.
+func (*KeyWeight) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *KeyWeight) GetKeyRegex() string {
+	if x != nil {
+		return x.KeyRegex
+	}
+	return ""
+}
+
+func (x *KeyWeight) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+// An append-only log with an entry for every response served to a downstream client, recording the aggregated key,
+// version, and type URL of the response, the node ID of the client, and the time it was served.
+// [#next-free-field: 4]
+type AuditLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Sink:
+	//	*AuditLog_File
+	//	*AuditLog_Syslog
+	Sink isAuditLog_Sink `protobuf_oneof:"sink"`
+	// If true, entries also record the hex encoded SHA-256 hash of the resources of the response, so that the served
+	// payload can be verified later without being logged.
+	HashPayloads bool `protobuf:"varint,3,opt,name=hash_payloads,json=hashPayloads,proto3" json:"hash_payloads,omitempty"`
+}
+
+func (x *AuditLog) Reset() {
+	*x = AuditLog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLog) ProtoMessage() {}
+
+func (x *AuditLog) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLog.ProtoReflect.Descriptor instead.
+func (*AuditLog) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{38}
+}
+
+func (m *AuditLog) GetSink() isAuditLog_Sink {
+	if m != nil {
+		return m.Sink
+	}
+	return nil
+}
+
+func (x *AuditLog) GetFile() *AuditLogFile {
+	if x, ok := x.GetSink().(*AuditLog_File); ok {
+		return x.File
+	}
+	return nil
+}
+
+func (x *AuditLog) GetSyslog() *AuditLogSyslog {
+	if x, ok := x.GetSink().(*AuditLog_Syslog); ok {
+		return x.Syslog
+	}
+	return nil
+}
+
+func (x *AuditLog) GetHashPayloads() bool {
+	if x != nil {
+		return x.HashPayloads
+	}
+	return false
+}
+
+type isAuditLog_Sink interface {
+	isAuditLog_Sink()
+}
+
+type AuditLog_File struct {
+	File *AuditLogFile `protobuf:"bytes,1,opt,name=file,proto3,oneof"`
+}
+
+type AuditLog_Syslog struct {
+	Syslog *AuditLogSyslog `protobuf:"bytes,2,opt,name=syslog,proto3,oneof"`
+}
+
+func (*AuditLog_File) isAuditLog_Sink() {}
+
+func (*AuditLog_Syslog) isAuditLog_Sink() {}
+
+// Entries are appended to the file as JSON lines. Once the file reaches max_size_bytes, it is renamed with the suffix
+// ".1", previous backups are shifted up by one, and entries are appended to a new file.
+// [#next-free-field: 4]
+type AuditLogFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Size at which the file is rotated. Defaults to 100MiB.
+	MaxSizeBytes *wrappers.UInt64Value `protobuf:"bytes,2,opt,name=max_size_bytes,json=maxSizeBytes,proto3" json:"max_size_bytes,omitempty"`
+	// Number of rotated files that are kept. The oldest file is deleted when another rotation would exceed it.
+	// Defaults to 5.
+	MaxBackups *wrappers.UInt32Value `protobuf:"bytes,3,opt,name=max_backups,json=maxBackups,proto3" json:"max_backups,omitempty"`
+}
+
+func (x *AuditLogFile) Reset() {
+	*x = AuditLogFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditLogFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLogFile) ProtoMessage() {}
+
+func (x *AuditLogFile) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLogFile.ProtoReflect.Descriptor instead.
+func (*AuditLogFile) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AuditLogFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *AuditLogFile) GetMaxSizeBytes() *wrappers.UInt64Value {
+	if x != nil {
+		return x.MaxSizeBytes
+	}
+	return nil
+}
+
+func (x *AuditLogFile) GetMaxBackups() *wrappers.UInt32Value {
+	if x != nil {
+		return x.MaxBackups
+	}
+	return nil
+}
+
+// Entries are sent as JSON to a syslog daemon at the informational severity of the daemon facility.
+// [#next-free-field: 4]
+type AuditLogSyslog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The network of the syslog daemon, i.e. "tcp" or "udp". If unset, the local syslog daemon is used.
+	Network string `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	// The address of the syslog daemon. Required if the network is set.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Tag of the messages. Defaults to "xds-relay".
+	Tag string `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+}
+
+func (x *AuditLogSyslog) Reset() {
+	*x = AuditLogSyslog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditLogSyslog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLogSyslog) ProtoMessage() {}
+
+func (x *AuditLogSyslog) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLogSyslog.ProtoReflect.Descriptor instead.
+func (*AuditLogSyslog) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AuditLogSyslog) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *AuditLogSyslog) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *AuditLogSyslog) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+// Verifies that each upstream response is signed by the control plane, so that a compromised path to the origin server
+// cannot inject configuration. Responses that are unsigned or whose signature is invalid are neither cached nor
+// served.
+//
+// The control plane signs the SHA-256 digest of the type URL, version info, and the type URL and serialized value of
+// each resource, in order, with each field prefixed by its length as an 8 byte big-endian integer. The signature is
+// sent base64 encoded in the identifier of the control_plane field of the response. Ed25519 signatures sign the
+// digest itself, ECDSA signatures are ASN.1 encoded, and RSA signatures use PKCS #1 v1.5.
+// [#next-free-field: 2]
+type SignatureVerification struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path of the PEM encoded PKIX public key of the control plane. Ed25519, ECDSA, and RSA keys are supported.
+	PublicKeyPath string `protobuf:"bytes,1,opt,name=public_key_path,json=publicKeyPath,proto3" json:"public_key_path,omitempty"`
+}
+
+func (x *SignatureVerification) Reset() {
+	*x = SignatureVerification{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignatureVerification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignatureVerification) ProtoMessage() {}
+
+func (x *SignatureVerification) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignatureVerification.ProtoReflect.Descriptor instead.
+func (*SignatureVerification) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SignatureVerification) GetPublicKeyPath() string {
+	if x != nil {
+		return x.PublicKeyPath
+	}
+	return ""
+}
+
+// Guards against a runaway origin server sending responses that exhaust the memory of the relay and its clients.
+// Responses over a limit are never truncated, since partial configuration could remove resources that clients depend
+// on.
+// [#next-free-field: 5]
+type ResponseLimit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Ex: "type.googleapis.com/envoy.api.v2.Cluster". If unset, the limit applies to the types without a limit of
+	// their own.
+	TypeUrl string `protobuf:"bytes,1,opt,name=type_url,json=typeUrl,proto3" json:"type_url,omitempty"`
+	// Maximum serialized size of a response in bytes. If unset, the size is not limited.
+	MaxBytes uint64 `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	// Maximum number of resources in a response. If unset, the number of resources is not limited.
+	MaxResources uint32               `protobuf:"varint,3,opt,name=max_resources,json=maxResources,proto3" json:"max_resources,omitempty"`
+	Action       ResponseLimit_Action `protobuf:"varint,4,opt,name=action,proto3,enum=bootstrap.ResponseLimit_Action" json:"action,omitempty"`
+}
+
+func (x *ResponseLimit) Reset() {
+	*x = ResponseLimit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseLimit) ProtoMessage() {}
+
+func (x *ResponseLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseLimit.ProtoReflect.Descriptor instead.
+func (*ResponseLimit) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ResponseLimit) GetTypeUrl() string {
+	if x != nil {
+		return x.TypeUrl
+	}
+	return ""
+}
+
+func (x *ResponseLimit) GetMaxBytes() uint64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *ResponseLimit) GetMaxResources() uint32 {
+	if x != nil {
+		return x.MaxResources
+	}
+	return 0
+}
+
+func (x *ResponseLimit) GetAction() ResponseLimit_Action {
+	if x != nil {
+		return x.Action
+	}
+	return ResponseLimit_REJECT
+}
+
+// [#next-free-field: 6]
+type Memory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The garbage collection target percentage, as set by the GOGC environment variable. A negative percentage
+	// disables garbage collection. If unset, GOGC or its default of 100 applies.
+	GcPercent *wrappers.Int32Value `protobuf:"bytes,1,opt,name=gc_percent,json=gcPercent,proto3" json:"gc_percent,omitempty"`
+	// Size of a heap ballast allocated at startup. The ballast is never written, so it raises the heap size at which
+	// garbage collection is triggered without increasing the resident memory of the process.
+	BallastBytes uint64 `protobuf:"varint,2,opt,name=ballast_bytes,json=ballastBytes,proto3" json:"ballast_bytes,omitempty"`
+	// Resident memory of the process above which the least recently used aggregated keys are evicted from the cache,
+	// as if their TTL had expired, until it falls below the watermark again. If unset, keys are not evicted based on
+	// memory usage.
+	HighWatermarkBytes uint64 `protobuf:"varint,3,opt,name=high_watermark_bytes,json=highWatermarkBytes,proto3" json:"high_watermark_bytes,omitempty"`
+	// Interval at which the resident memory is checked against the high watermark. Defaults to 5s.
+	CheckInterval *duration.Duration `protobuf:"bytes,4,opt,name=check_interval,json=checkInterval,proto3" json:"check_interval,omitempty"`
+	// Number of keys evicted per check while the resident memory is above the high watermark. Defaults to 10.
+	EvictionBatchSize uint32 `protobuf:"varint,5,opt,name=eviction_batch_size,json=evictionBatchSize,proto3" json:"eviction_batch_size,omitempty"`
+}
+
+func (x *Memory) Reset() {
+	*x = Memory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Memory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Memory) ProtoMessage() {}
+
+func (x *Memory) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Memory.ProtoReflect.Descriptor instead.
+func (*Memory) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Memory) GetGcPercent() *wrappers.Int32Value {
+	if x != nil {
+		return x.GcPercent
+	}
+	return nil
+}
+
+func (x *Memory) GetBallastBytes() uint64 {
+	if x != nil {
+		return x.BallastBytes
+	}
+	return 0
+}
+
+func (x *Memory) GetHighWatermarkBytes() uint64 {
+	if x != nil {
+		return x.HighWatermarkBytes
+	}
+	return 0
+}
+
+func (x *Memory) GetCheckInterval() *duration.Duration {
+	if x != nil {
+		return x.CheckInterval
+	}
+	return nil
+}
+
+func (x *Memory) GetEvictionBatchSize() uint32 {
+	if x != nil {
+		return x.EvictionBatchSize
+	}
+	return 0
+}
+
+// Sets the control_plane.identifier of the responses served by the relay to "<prefix>/<replica>/<version>", where
+// version is the version of the response, so that the Envoy admin pages and bug reports show that configuration
+// traversed the relay and which replica served it.
+// [#next-free-field: 3]
+type ControlPlaneIdentifier struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifies the relay. Defaults to "xds-relay" if unset.
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Identifies this replica, e.g. the name of its pod. Defaults to the hostname.
+	Replica string `protobuf:"bytes,2,opt,name=replica,proto3" json:"replica,omitempty"`
+}
+
+func (x *ControlPlaneIdentifier) Reset() {
+	*x = ControlPlaneIdentifier{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControlPlaneIdentifier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControlPlaneIdentifier) ProtoMessage() {}
+
+func (x *ControlPlaneIdentifier) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControlPlaneIdentifier.ProtoReflect.Descriptor instead.
+func (*ControlPlaneIdentifier) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ControlPlaneIdentifier) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ControlPlaneIdentifier) GetReplica() string {
+	if x != nil {
+		return x.Replica
+	}
+	return ""
+}
+
+// Fans out each new version of an aggregated key to the canary nodes first. The other nodes keep being served the
+// previous version until the rollout is promoted, either after the soak duration or through the admin API at
+// `/rollouts/<key>`. A node is a canary if its node ID hashes into canary_percentage, or if its metadata matches
+// canary_node_metadata.
+// [#next-free-field: 4]
+type Rollout struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Percentage of downstream nodes, selected by a hash of their node ID, that are canaries.
+	CanaryPercentage float64 `protobuf:"fixed64,1,opt,name=canary_percentage,json=canaryPercentage,proto3" json:"canary_percentage,omitempty"`
+	// Node metadata fields whose string values select canary nodes. A node is a canary if all of the fields match.
+	CanaryNodeMetadata map[string]string `protobuf:"bytes,2,rep,name=canary_node_metadata,json=canaryNodeMetadata,proto3" json:"canary_node_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Time that a new version is served to the canary nodes only before it is promoted to every node. If unset,
+	// rollouts are only promoted through the admin API.
+	SoakDuration *duration.Duration `protobuf:"bytes,3,opt,name=soak_duration,json=soakDuration,proto3" json:"soak_duration,omitempty"`
+}
+
+func (x *Rollout) Reset() {
+	*x = Rollout{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rollout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rollout) ProtoMessage() {}
+
+func (x *Rollout) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rollout.ProtoReflect.Descriptor instead.
+func (*Rollout) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Rollout) GetCanaryPercentage() float64 {
+	if x != nil {
+		return x.CanaryPercentage
+	}
+	return 0
+}
+
+func (x *Rollout) GetCanaryNodeMetadata() map[string]string {
+	if x != nil {
+		return x.CanaryNodeMetadata
+	}
+	return nil
+}
+
+func (x *Rollout) GetSoakDuration() *duration.Duration {
+	if x != nil {
+		return x.SoakDuration
+	}
+	return nil
+}
+
+// Opens the circuit of an aggregated key once its upstream stream has failed failure_threshold times within the
+// failure window. A failure is a reset of the upstream stream, or a new upstream version rejected by a downstream
+// node. While the circuit is open, the upstream stream is closed and the cached response keeps being served. After
+// the cool down, a probe stream is opened, and the circuit is closed once it delivers a response. Failed streams of
+// closed circuits are reopened immediately.
+// [#next-free-field: 4]
+type CircuitBreaker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of failures within the failure window that opens the circuit. Defaults to 5.
+	FailureThreshold *wrappers.UInt32Value `protobuf:"bytes,1,opt,name=failure_threshold,json=failureThreshold,proto3" json:"failure_threshold,omitempty"`
+	// Time window in which failures are counted. Defaults to 1m.
+	FailureWindow *duration.Duration `protobuf:"bytes,2,opt,name=failure_window,json=failureWindow,proto3" json:"failure_window,omitempty"`
+	// Time that the circuit stays open before a probe stream is opened. Defaults to 30s.
+	CoolDown *duration.Duration `protobuf:"bytes,3,opt,name=cool_down,json=coolDown,proto3" json:"cool_down,omitempty"`
+}
+
+func (x *CircuitBreaker) Reset() {
+	*x = CircuitBreaker{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CircuitBreaker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CircuitBreaker) ProtoMessage() {}
+
+func (x *CircuitBreaker) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CircuitBreaker.ProtoReflect.Descriptor instead.
+func (*CircuitBreaker) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CircuitBreaker) GetFailureThreshold() *wrappers.UInt32Value {
+	if x != nil {
+		return x.FailureThreshold
+	}
+	return nil
+}
+
+func (x *CircuitBreaker) GetFailureWindow() *duration.Duration {
+	if x != nil {
+		return x.FailureWindow
+	}
+	return nil
+}
+
+func (x *CircuitBreaker) GetCoolDown() *duration.Duration {
+	if x != nil {
+		return x.CoolDown
+	}
+	return nil
+}
+
+// Rules that raise alerts on anomalies of the configuration of aggregated keys. Alerts are logged and counted when
+// they are raised, and listed by the `/alerts` admin endpoint until their condition clears. Each rule is disabled if
+// its threshold is unset.
+// [#next-free-field: 5]
+type Alerting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Raises a stale_key alert for an aggregated key with an upstream stream that received no response within the
+	// duration.
+	StaleAfter *duration.Duration `protobuf:"bytes,1,opt,name=stale_after,json=staleAfter,proto3" json:"stale_after,omitempty"`
+	// Raises a resource_drop alert when a response of an aggregated key has more than the percentage fewer resources
+	// than the previous response, e.g. 50. The alert clears with the next response that does not drop as many.
+	MaxResourceDropPercent float64 `protobuf:"fixed64,2,opt,name=max_resource_drop_percent,json=maxResourceDropPercent,proto3" json:"max_resource_drop_percent,omitempty"`
+	// Raises a nack_rate alert when more than the percentage of the acknowledgements and rejections of responses of
+	// an aggregated key within an evaluation interval are rejections.
+	MaxNackPercent float64 `protobuf:"fixed64,3,opt,name=max_nack_percent,json=maxNackPercent,proto3" json:"max_nack_percent,omitempty"`
+	// Interval at which the stale_key and nack_rate rules are evaluated. Defaults to 30s.
+	EvaluationInterval *duration.Duration `protobuf:"bytes,4,opt,name=evaluation_interval,json=evaluationInterval,proto3" json:"evaluation_interval,omitempty"`
+}
+
+func (x *Alerting) Reset() {
+	*x = Alerting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Alerting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alerting) ProtoMessage() {}
+
+func (x *Alerting) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alerting.ProtoReflect.Descriptor instead.
+func (*Alerting) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *Alerting) GetStaleAfter() *duration.Duration {
+	if x != nil {
+		return x.StaleAfter
+	}
+	return nil
+}
+
+func (x *Alerting) GetMaxResourceDropPercent() float64 {
+	if x != nil {
+		return x.MaxResourceDropPercent
+	}
+	return 0
+}
+
+func (x *Alerting) GetMaxNackPercent() float64 {
+	if x != nil {
+		return x.MaxNackPercent
+	}
+	return 0
+}
+
+func (x *Alerting) GetEvaluationInterval() *duration.Duration {
+	if x != nil {
+		return x.EvaluationInterval
+	}
+	return nil
+}
+
+// Replicas form a consistent hash ring of aggregated keys. A replica opens the upstream stream of an aggregated key it
+// owns with the origin server, and forwards the watches of aggregated keys owned by another replica to that replica's
+// xDS server, so that each aggregated key has a single stream with the origin server across the fleet. Upstream
+// streams move to the new owner of their aggregated key when the membership changes. Every replica must use the same
+// aggregation rules and membership.
+// [#next-free-field: 6]
+type HashRing struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identity of this replica, which must match its member ID. Defaults to the hostname, which is the name of the
+	// pod in Kubernetes.
+	Identity string `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Number of points of each member on the ring. More points spread the aggregated keys more evenly. Defaults to
+	// 100.
+	VirtualNodes *wrappers.UInt32Value `protobuf:"bytes,2,opt,name=virtual_nodes,json=virtualNodes,proto3" json:"virtual_nodes,omitempty"`
+	// Types that are assignable to Membership:
+	//	*HashRing_StaticMembers_
+	//	*HashRing_KubernetesEndpoints_
+	Membership isHashRing_Membership `protobuf_oneof:"membership"`
+	// Interval between refreshes of the membership. Defaults to 10s.
+	RefreshInterval *duration.Duration `protobuf:"bytes,5,opt,name=refresh_interval,json=refreshInterval,proto3" json:"refresh_interval,omitempty"`
+}
+
+func (x *HashRing) Reset() {
+	*x = HashRing{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HashRing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashRing) ProtoMessage() {}
+
+func (x *HashRing) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashRing.ProtoReflect.Descriptor instead.
+func (*HashRing) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *HashRing) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *HashRing) GetVirtualNodes() *wrappers.UInt32Value {
+	if x != nil {
+		return x.VirtualNodes
+	}
+	return nil
+}
+
+func (m *HashRing) GetMembership() isHashRing_Membership {
+	if m != nil {
+		return m.Membership
+	}
+	return nil
+}
+
+func (x *HashRing) GetStaticMembers() *HashRing_StaticMembers {
+	if x, ok := x.GetMembership().(*HashRing_StaticMembers_); ok {
+		return x.StaticMembers
+	}
+	return nil
+}
+
+func (x *HashRing) GetKubernetesEndpoints() *HashRing_KubernetesEndpoints {
+	if x, ok := x.GetMembership().(*HashRing_KubernetesEndpoints_); ok {
+		return x.KubernetesEndpoints
+	}
+	return nil
+}
+
+func (x *HashRing) GetRefreshInterval() *duration.Duration {
+	if x != nil {
+		return x.RefreshInterval
+	}
+	return nil
+}
+
+type isHashRing_Membership interface {
+	isHashRing_Membership()
+}
+
+type HashRing_StaticMembers_ struct {
+	StaticMembers *HashRing_StaticMembers `protobuf:"bytes,3,opt,name=static_members,json=staticMembers,proto3,oneof"`
+}
+
+type HashRing_KubernetesEndpoints_ struct {
+	// The ready addresses of a Kubernetes Endpoints object, e.g. of a headless service of the replicas.
+	KubernetesEndpoints *HashRing_KubernetesEndpoints `protobuf:"bytes,4,opt,name=kubernetes_endpoints,json=kubernetesEndpoints,proto3,oneof"`
+}
+
+func (*HashRing_StaticMembers_) isHashRing_Membership() {}
+
+func (*HashRing_KubernetesEndpoints_) isHashRing_Membership() {}
+
+// Upstream health scores the upstream stream of every aggregated key by the time since its last response, and lists
+// the scores on the `/upstream_health` admin endpoint. A stream that received no response within its timeout, while
+// a response of another aggregated key of the same type URL changed version since, is known to be missing changes and
+// is restarted with the representative request of its aggregated key.
+// [#next-free-field: 4]
+type UpstreamHealth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Time without a response after which the upstream stream of an aggregated key is unhealthy. Defaults to 5m.
+	Timeout *duration.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// Timeouts of the aggregated keys that match a regex, in place of the default timeout. The first timeout whose
+	// regex matches the key applies.
+	KeyTimeouts []*UpstreamHealth_KeyTimeout `protobuf:"bytes,2,rep,name=key_timeouts,json=keyTimeouts,proto3" json:"key_timeouts,omitempty"`
+	// Interval at which upstream streams are scored and silent streams restarted. Defaults to 30s.
+	EvaluationInterval *duration.Duration `protobuf:"bytes,3,opt,name=evaluation_interval,json=evaluationInterval,proto3" json:"evaluation_interval,omitempty"`
+}
+
+func (x *UpstreamHealth) Reset() {
+	*x = UpstreamHealth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpstreamHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpstreamHealth) ProtoMessage() {}
+
+func (x *UpstreamHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpstreamHealth.ProtoReflect.Descriptor instead.
+func (*UpstreamHealth) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *UpstreamHealth) GetTimeout() *duration.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *UpstreamHealth) GetKeyTimeouts() []*UpstreamHealth_KeyTimeout {
+	if x != nil {
+		return x.KeyTimeouts
+	}
+	return nil
+}
+
+func (x *UpstreamHealth) GetEvaluationInterval() *duration.Duration {
+	if x != nil {
+		return x.EvaluationInterval
+	}
+	return nil
+}
+
+// Negative caching remembers failures for a short window, and fails identical requests fast instead of retrying the
+// mapper or the origin server on every retry of a client. Requests that cannot be mapped fail with INVALID_ARGUMENT.
+// Watches of aggregated keys whose upstream stream failed to open fail with UNAVAILABLE, unless a response is cached
+// for the key, in which case the cached response is served without reopening the upstream stream.
+// [#next-free-field: 3]
+type NegativeCache struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Duration for which a failure is remembered.
+	Ttl *duration.Duration `protobuf:"bytes,1,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// The maximum number of failures remembered. Once reached, the oldest failures are forgotten first. Failures of
+	// requests that cannot be mapped are remembered by node, so this bounds the memory that clients with distinct node
+	// IDs take. Defaults to 10000 if unset.
+	MaxEntries uint32 `protobuf:"varint,2,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
+}
+
+func (x *NegativeCache) Reset() {
+	*x = NegativeCache{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NegativeCache) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NegativeCache) ProtoMessage() {}
+
+func (x *NegativeCache) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NegativeCache.ProtoReflect.Descriptor instead.
+func (*NegativeCache) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *NegativeCache) GetTtl() *duration.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *NegativeCache) GetMaxEntries() uint32 {
+	if x != nil {
+		return x.MaxEntries
+	}
+	return 0
+}
+
+// Relays can be chained into hierarchical deployments, e.g. edge relays whose origin server is a regional relay in
+// front of the control plane. Each relay appends its identity to the hops of the node of the requests it sends
+// upstream, in the `xds_relay_hops` list of the node metadata, so that the relays and the control plane upstream see
+// the chain of relays a request passed through. A relay rejects the requests whose hops already contain its identity,
+// which would otherwise loop through the chain forever, and the requests that passed through too many relays, with
+// FAILED_PRECONDITION.
+// [#next-free-field: 3]
+type RelayChain struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identity of this relay in the hops. Every relay of a chain must have a distinct identity. Defaults to the
+	// hostname.
+	Identity string `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Maximum number of relays that a request may have passed through before reaching this relay. Defaults to 8.
+	MaxHops *wrappers.UInt32Value `protobuf:"bytes,2,opt,name=max_hops,json=maxHops,proto3" json:"max_hops,omitempty"`
+}
+
+func (x *RelayChain) Reset() {
+	*x = RelayChain{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RelayChain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelayChain) ProtoMessage() {}
+
+func (x *RelayChain) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelayChain.ProtoReflect.Descriptor instead.
+func (*RelayChain) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RelayChain) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *RelayChain) GetMaxHops() *wrappers.UInt32Value {
+	if x != nil {
+		return x.MaxHops
+	}
+	return nil
+}
+
+// A parent relay, e.g. a regional relay in front of the origin server, shields the origin server from the relays
+// below it. The upstream stream of an aggregated key is opened with the parent relay, which serves it from its own
+// cache, and falls back to the origin server if the stream with the parent relay fails to open, or is sent no response
+// within the timeout. A key that fell back stays with the origin server until its upstream stream is reopened after
+// the retry interval. Replicas of a hash ring open the upstream streams of the keys they own with the parent relay.
+// [#next-free-field: 4]
+type ParentRelay struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The xDS server of the parent relay.
+	Upstream *Upstream `protobuf:"bytes,1,opt,name=upstream,proto3" json:"upstream,omitempty"`
+	// Time within which the parent relay must send the first response of an upstream stream. Defaults to 5s.
+	Timeout *duration.Duration `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// Time after falling back to the origin server before the upstream stream of an aggregated key is opened with the
+	// parent relay again. Defaults to 1m.
+	RetryInterval *duration.Duration `protobuf:"bytes,3,opt,name=retry_interval,json=retryInterval,proto3" json:"retry_interval,omitempty"`
+}
+
+func (x *ParentRelay) Reset() {
+	*x = ParentRelay{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParentRelay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParentRelay) ProtoMessage() {}
+
+func (x *ParentRelay) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParentRelay.ProtoReflect.Descriptor instead.
+func (*ParentRelay) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ParentRelay) GetUpstream() *Upstream {
+	if x != nil {
+		return x.Upstream
+	}
+	return nil
+}
+
+func (x *ParentRelay) GetTimeout() *duration.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *ParentRelay) GetRetryInterval() *duration.Duration {
+	if x != nil {
+		return x.RetryInterval
+	}
+	return nil
+}
+
+// Response validation unmarshals every resource of upstream responses, and checks it against the validation rules of
+// its Envoy proto schema, such as required fields and value ranges, so that configuration that every Envoy would NACK
+// is caught at the relay. Resources of an unknown type URL, or of a type URL other than the response's, are invalid.
+// [#next-free-field: 3]
+type ResponseValidation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Action ResponseValidation_Action `protobuf:"varint,1,opt,name=action,proto3,enum=bootstrap.ResponseValidation_Action" json:"action,omitempty"`
+	// Ex: "type.googleapis.com/envoy.api.v2.Cluster". If unset, the responses of every type are validated.
+	TypeUrls []string `protobuf:"bytes,2,rep,name=type_urls,json=typeUrls,proto3" json:"type_urls,omitempty"`
+}
+
+func (x *ResponseValidation) Reset() {
+	*x = ResponseValidation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseValidation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseValidation) ProtoMessage() {}
+
+func (x *ResponseValidation) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseValidation.ProtoReflect.Descriptor instead.
+func (*ResponseValidation) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ResponseValidation) GetAction() ResponseValidation_Action {
+	if x != nil {
+		return x.Action
+	}
+	return ResponseValidation_REJECT
+}
+
+func (x *ResponseValidation) GetTypeUrls() []string {
+	if x != nil {
+		return x.TypeUrls
+	}
+	return nil
+}
+
+// Linting checks that the resources referenced by the resources of an upstream response exist in the cache, e.g. that
+// the clusters routed to by a route configuration are in a cached CDS response, catching inconsistencies between
+// resources that the origin server let through. A reference is looked up in the cached responses of every aggregated
+// key of its type, and is only checked if a response of that type is cached. Findings are counted by check, and
+// listed on the `/lint` admin endpoint.
+// [#next-free-field: 3]
+type Linting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The checks to run. If unset, every check runs.
+	Checks []Linting_Check `protobuf:"varint,1,rep,packed,name=checks,proto3,enum=bootstrap.Linting_Check" json:"checks,omitempty"`
+	// Drop the responses with findings, and keep serving the previously cached response, instead of only counting
+	// the findings.
+	Block bool `protobuf:"varint,2,opt,name=block,proto3" json:"block,omitempty"`
+}
+
+func (x *Linting) Reset() {
+	*x = Linting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Linting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Linting) ProtoMessage() {}
+
+func (x *Linting) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Linting.ProtoReflect.Descriptor instead.
+func (*Linting) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *Linting) GetChecks() []Linting_Check {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+func (x *Linting) GetBlock() bool {
+	if x != nil {
+		return x.Block
+	}
+	return false
+}
+
+// Dependency ordering tracks the references between the resources of aggregated keys, such as the route
+// configurations of a listener and the clusters of a route configuration, and lists them on the `/dependencies` admin
+// endpoint. Upstream responses received within a window are cached and fanned out in waves, in the order in which
+// Envoy expects related resources: a key is fanned out after the keys pending in the same window that it references or
+// that reference it, by type, clusters before endpoints, then listeners, then route configurations. This shortens the
+// time in which Envoys hold a route to a cluster they were not sent yet.
+// [#next-free-field: 3]
+type DependencyOrdering struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Time for which upstream responses are held for the responses of related keys to arrive. Defaults to 100ms.
+	Window *duration.Duration `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	// Time between the waves of a window. Defaults to 0, which fans out the waves one after the other.
+	Pacing *duration.Duration `protobuf:"bytes,2,opt,name=pacing,proto3" json:"pacing,omitempty"`
+}
+
+func (x *DependencyOrdering) Reset() {
+	*x = DependencyOrdering{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DependencyOrdering) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyOrdering) ProtoMessage() {}
+
+func (x *DependencyOrdering) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencyOrdering.ProtoReflect.Descriptor instead.
+func (*DependencyOrdering) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DependencyOrdering) GetWindow() *duration.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *DependencyOrdering) GetPacing() *duration.Duration {
+	if x != nil {
+		return x.Pacing
+	}
+	return nil
+}
+
+// History keeps a ring buffer of the latest versions cached for every aggregated key. The versions are listed and
+// diffed on the `/history/` admin endpoint, and any of them can be served again during an incident with
+// `POST /keys/<key>/rollback?to=<version>`, which pins the version and reports on the `/rollbacks` admin endpoint how
+// the upstream diverges from it until the key is unpinned.
+// [#next-free-field: 2]
+type History struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of versions kept per aggregated key, including the cached version. Defaults to 10.
+	MaxVersions *wrappers.UInt32Value `protobuf:"bytes,1,opt,name=max_versions,json=maxVersions,proto3" json:"max_versions,omitempty"`
+}
+
+func (x *History) Reset() {
+	*x = History{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *History) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*History) ProtoMessage() {}
+
+func (x *History) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use History.ProtoReflect.Descriptor instead.
+func (*History) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *History) GetMaxVersions() *wrappers.UInt32Value {
+	if x != nil {
+		return x.MaxVersions
+	}
+	return nil
+}
+
+// Maintenance freezes the fanout of new upstream versions during scheduled windows, such as peak traffic or deploy
+// freezes. New versions are cached and served to nodes that connect during a window, but existing watches keep the
+// version they were sent until the window ends. The withheld keys are then released one at a time, so that the
+// versions held back do not land all at once.
+// [#next-free-field: 4]
+type Maintenance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Windows []*Maintenance_Window `protobuf:"bytes,1,rep,name=windows,proto3" json:"windows,omitempty"`
+	// Regular expressions of the aggregated keys that are fanned out during windows.
+	AllowedKeyRegexes []string `protobuf:"bytes,2,rep,name=allowed_key_regexes,json=allowedKeyRegexes,proto3" json:"allowed_key_regexes,omitempty"`
+	// Time between the releases of the keys withheld during a window. Defaults to 1s.
+	ReleaseInterval *duration.Duration `protobuf:"bytes,3,opt,name=release_interval,json=releaseInterval,proto3" json:"release_interval,omitempty"`
+}
+
+func (x *Maintenance) Reset() {
+	*x = Maintenance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Maintenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Maintenance) ProtoMessage() {}
+
+func (x *Maintenance) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Maintenance.ProtoReflect.Descriptor instead.
+func (*Maintenance) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *Maintenance) GetWindows() []*Maintenance_Window {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+func (x *Maintenance) GetAllowedKeyRegexes() []string {
+	if x != nil {
+		return x.AllowedKeyRegexes
+	}
+	return nil
+}
+
+func (x *Maintenance) GetReleaseInterval() *duration.Duration {
+	if x != nil {
+		return x.ReleaseInterval
+	}
+	return nil
+}
+
+// Change rate guard protects Envoys from configuration flapping caused by an unstable origin server. When more than
+// max_versions new versions of an aggregated key are received within the interval, the versions are collapsed: they
+// are cached, and only the latest is fanned out at the end of the interval. If pause is set, the fanout of the key is
+// instead paused and a `change_rate` alert is raised, until an operator resumes it with
+// `POST /keys/<key>/resume`.
+// [#next-free-field: 4]
+type ChangeRateGuard struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of new versions of an aggregated key fanned out within the interval.
+	MaxVersions uint32 `protobuf:"varint,1,opt,name=max_versions,json=maxVersions,proto3" json:"max_versions,omitempty"`
+	// Interval over which the versions of an aggregated key are counted.
+	Interval *duration.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Pause the fanout of keys that change too often until they are resumed, instead of collapsing their versions.
+	Pause bool `protobuf:"varint,3,opt,name=pause,proto3" json:"pause,omitempty"`
+}
+
+func (x *ChangeRateGuard) Reset() {
+	*x = ChangeRateGuard{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeRateGuard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeRateGuard) ProtoMessage() {}
+
+func (x *ChangeRateGuard) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeRateGuard.ProtoReflect.Descriptor instead.
+func (*ChangeRateGuard) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ChangeRateGuard) GetMaxVersions() uint32 {
+	if x != nil {
+		return x.MaxVersions
+	}
+	return 0
+}
+
+func (x *ChangeRateGuard) GetInterval() *duration.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *ChangeRateGuard) GetPause() bool {
+	if x != nil {
+		return x.Pause
+	}
+	return false
+}
+
+// Fanout pacing spreads the downstream sends of a large fanout evenly across a duration, in a random order of the
+// watches, instead of sending the response to every watch at once. This avoids synchronized configuration reloads of
+// many Envoys, and the correlated latency spikes they cause. A watch whose paced send is still pending when a newer
+// response is fanned out is only sent the newer response. Paced sends bypass the fanout scheduling, since they are
+// already spread out.
+// [#next-free-field: 3]
+type FanoutPacing struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Duration within which every send of a fanout completes.
+	Duration *duration.Duration `protobuf:"bytes,1,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Number of watches below which the sends of a fanout run at once. Defaults to 0, which paces every fanout.
+	MinWatches uint32 `protobuf:"varint,2,opt,name=min_watches,json=minWatches,proto3" json:"min_watches,omitempty"`
+}
+
+func (x *FanoutPacing) Reset() {
+	*x = FanoutPacing{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FanoutPacing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FanoutPacing) ProtoMessage() {}
+
+func (x *FanoutPacing) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FanoutPacing.ProtoReflect.Descriptor instead.
+func (*FanoutPacing) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *FanoutPacing) GetDuration() *duration.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *FanoutPacing) GetMinWatches() uint32 {
+	if x != nil {
+		return x.MinWatches
+	}
+	return 0
+}
+
+// Fetch configures the unary Fetch requests of the discovery services, which are served from the cache. By default, a
+// request for an aggregated key that is not cached opens an upstream stream for the key, which is kept open like the
+// streams of streaming watches. Single shot fetches instead close the upstream stream after its first response, so
+// that clients polling rarely do not keep upstream streams open. The responses of single shot fetches are served from
+// the cache until they are evicted, or until a streaming watch opens an upstream stream for the key.
+// [#next-free-field: 3]
+type Fetch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum time a request for a key that is not cached waits for the upstream response. Defaults to 10s.
+	Timeout *duration.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// Close the upstream stream opened for a key that is not cached after its first response.
+	SingleShot bool `protobuf:"varint,2,opt,name=single_shot,json=singleShot,proto3" json:"single_shot,omitempty"`
+}
+
+func (x *Fetch) Reset() {
+	*x = Fetch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Fetch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fetch) ProtoMessage() {}
+
+func (x *Fetch) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fetch.ProtoReflect.Descriptor instead.
+func (*Fetch) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *Fetch) GetTimeout() *duration.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Fetch) GetSingleShot() bool {
+	if x != nil {
+		return x.SingleShot
+	}
+	return false
+}
+
+// Buffers configures the response channels between the relay and its downstream watches and upstream streams. The
+// downstream channel of a watch holds the responses the watch is sent until go-control-plane passes them on to the
+// client. The upstream channel of a stream holds the responses received from the origin server until the relay
+// caches and fans them out. The configuration of a type URL replaces the defaults for the channels of the type.
+// [#next-free-field: 4]
+type Buffers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Buffer of the downstream watches.
+	Downstream *Buffers_Buffer `protobuf:"bytes,1,opt,name=downstream,proto3" json:"downstream,omitempty"`
+	// Buffer of the upstream streams.
+	Upstream *Buffers_Buffer `protobuf:"bytes,2,opt,name=upstream,proto3" json:"upstream,omitempty"`
+	// Buffers of the channels of type URLs.
+	TypeUrls []*Buffers_TypeURLBuffers `protobuf:"bytes,3,rep,name=type_urls,json=typeUrls,proto3" json:"type_urls,omitempty"`
+}
+
+func (x *Buffers) Reset() {
+	*x = Buffers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Buffers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Buffers) ProtoMessage() {}
+
+func (x *Buffers) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Buffers.ProtoReflect.Descriptor instead.
+func (*Buffers) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *Buffers) GetDownstream() *Buffers_Buffer {
+	if x != nil {
+		return x.Downstream
+	}
+	return nil
+}
+
+func (x *Buffers) GetUpstream() *Buffers_Buffer {
+	if x != nil {
+		return x.Upstream
+	}
+	return nil
+}
+
+func (x *Buffers) GetTypeUrls() []*Buffers_TypeURLBuffers {
+	if x != nil {
+		return x.TypeUrls
+	}
+	return nil
+}
+
+// Key metrics control the cardinality of the metrics of aggregated keys. The metrics of a key are tagged with `key`,
+// whose value is set by the first rule matching the key: either the key itself, or the bucket of the rule that rolls
+// up the keys it matches. Metrics of keys that match no rule are not tagged with the key.
+// [#next-free-field: 3]
+type KeyMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules []*KeyMetrics_Rule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	// Maximum number of distinct keys that tag their metrics with the key itself. The metrics of further keys are
+	// tagged with `other`. If 0, the number of keys is not capped.
+	MaxKeys uint32 `protobuf:"varint,2,opt,name=max_keys,json=maxKeys,proto3" json:"max_keys,omitempty"`
+}
+
+func (x *KeyMetrics) Reset() {
+	*x = KeyMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyMetrics) ProtoMessage() {}
+
+func (x *KeyMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyMetrics.ProtoReflect.Descriptor instead.
+func (*KeyMetrics) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *KeyMetrics) GetRules() []*KeyMetrics_Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *KeyMetrics) GetMaxKeys() uint32 {
+	if x != nil {
+		return x.MaxKeys
+	}
+	return 0
+}
+
+// Aggregation rollout stages revisions of the aggregation rules on a share of the downstream nodes before they replace
+// the aggregation rules the relay is started with, since wrong aggregated keys otherwise affect every node at once.
+// Each node is mapped with the first revision that selects it, and with the rules the relay is started with if none
+// does, so that all the streams of a node and their acknowledgements are mapped with the same rules. The aggregated
+// keys of a revision are suffixed with `@` and the name of the revision, so that they never share a cache entry with
+// the keys of other revisions.
+// [#next-free-field: 2]
+type AggregationRollout struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revisions []*AggregationRollout_Revision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+}
+
+func (x *AggregationRollout) Reset() {
+	*x = AggregationRollout{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregationRollout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregationRollout) ProtoMessage() {}
+
+func (x *AggregationRollout) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregationRollout.ProtoReflect.Descriptor instead.
+func (*AggregationRollout) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *AggregationRollout) GetRevisions() []*AggregationRollout_Revision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+// Aggregation simulation maps every downstream request with candidate aggregation rules, alongside the rules that serve
+// it, and reports how the aggregated keys would change if the candidate rules were rolled out: the number of distinct
+// keys, how often the keys of a node change, and the nodes whose key would change. The report is shown by the admin
+// API at `/simulation` and through metrics. Candidate keys never serve requests.
+// [#next-free-field: 3]
+type AggregationSimulation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path of the YAML file of the candidate aggregation rules.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Maximum number of downstream nodes and type URLs whose latest keys are tracked. Requests of further nodes and
+	// type URLs are counted, but not tracked. If 0, 10000 are tracked.
+	MaxNodes uint32 `protobuf:"varint,2,opt,name=max_nodes,json=maxNodes,proto3" json:"max_nodes,omitempty"`
+}
+
+func (x *AggregationSimulation) Reset() {
+	*x = AggregationSimulation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregationSimulation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregationSimulation) ProtoMessage() {}
+
+func (x *AggregationSimulation) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregationSimulation.ProtoReflect.Descriptor instead.
+func (*AggregationSimulation) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *AggregationSimulation) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *AggregationSimulation) GetMaxNodes() uint32 {
+	if x != nil {
+		return x.MaxNodes
+	}
+	return 0
+}
+
+// Load reporting serves the load reporting service (LRS) to downstream Envoys on the xDS server address, and reports
+// their load to the origin server on a single stream, so that the origin server does not need a connection with every
+// Envoy. The load of each cluster is summed across Envoys by locality and priority, and reported at the interval the
+// origin server asks for. The clusters and interval that the origin server asks for are relayed to every Envoy.
+// Endpoint granularity is not reported.
+// [#next-free-field: 3]
+type LoadReporting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the node that the relay reports the load as. If unset, the hostname of the relay.
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// Cluster of the node that the relay reports the load as.
+	NodeCluster string `protobuf:"bytes,2,opt,name=node_cluster,json=nodeCluster,proto3" json:"node_cluster,omitempty"`
+}
+
+func (x *LoadReporting) Reset() {
+	*x = LoadReporting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadReporting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadReporting) ProtoMessage() {}
+
+func (x *LoadReporting) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadReporting.ProtoReflect.Descriptor instead.
+func (*LoadReporting) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *LoadReporting) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *LoadReporting) GetNodeCluster() string {
+	if x != nil {
+		return x.NodeCluster
+	}
+	return ""
+}
+
+type Interceptor_Recovery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Interceptor_Recovery) Reset() {
+	*x = Interceptor_Recovery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Interceptor_Recovery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Interceptor_Recovery) ProtoMessage() {}
+
+func (x *Interceptor_Recovery) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Interceptor_Recovery.ProtoReflect.Descriptor instead.
+func (*Interceptor_Recovery) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{4, 0}
+}
+
+// Reads the request ID from a header of the request, or generates one if the header is missing. The request ID is
+// sent back in the same response header, and is logged with the messages of the stream or call.
+type Interceptor_RequestID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The header of the request ID. Defaults to `x-request-id`.
+	Header string `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+}
+
+func (x *Interceptor_RequestID) Reset() {
+	*x = Interceptor_RequestID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Interceptor_RequestID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Interceptor_RequestID) ProtoMessage() {}
+
+func (x *Interceptor_RequestID) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Interceptor_RequestID.ProtoReflect.Descriptor instead.
+func (*Interceptor_RequestID) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *Interceptor_RequestID) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+// [#next-free-field: 5]
+type Upstream_Metadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The metadata key. Keys are lowercased.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Types that are assignable to ValueSpecifier:
+	//	*Upstream_Metadata_Value
+	//	*Upstream_Metadata_Template
+	//	*Upstream_Metadata_TokenFile
+	ValueSpecifier isUpstream_Metadata_ValueSpecifier `protobuf_oneof:"value_specifier"`
+}
+
+func (x *Upstream_Metadata) Reset() {
+	*x = Upstream_Metadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upstream_Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream_Metadata) ProtoMessage() {}
+
+func (x *Upstream_Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream_Metadata.ProtoReflect.Descriptor instead.
+func (*Upstream_Metadata) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{6, 0}
+}
+
+func (x *Upstream_Metadata) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (m *Upstream_Metadata) GetValueSpecifier() isUpstream_Metadata_ValueSpecifier {
+	if m != nil {
+		return m.ValueSpecifier
+	}
+	return nil
+}
+
+func (x *Upstream_Metadata) GetValue() string {
+	if x, ok := x.GetValueSpecifier().(*Upstream_Metadata_Value); ok {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Upstream_Metadata) GetTemplate() string {
+	if x, ok := x.GetValueSpecifier().(*Upstream_Metadata_Template); ok {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *Upstream_Metadata) GetTokenFile() *Upstream_TokenFile {
+	if x, ok := x.GetValueSpecifier().(*Upstream_Metadata_TokenFile); ok {
+		return x.TokenFile
+	}
+	return nil
+}
+
+type isUpstream_Metadata_ValueSpecifier interface {
+	isUpstream_Metadata_ValueSpecifier()
+}
+
+type Upstream_Metadata_Value struct {
+	// A static value.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3,oneof"`
+}
+
+type Upstream_Metadata_Template struct {
+	// A Go text/template of the value, executed for every stream. Templates can refer to `.NodeID` and
+	// `.NodeCluster`, the node of the request that opened the stream, `.TypeURL`, `.RequestID`, and
+	// `.Hostname`, the host name of the relay.
+	Template string `protobuf:"bytes,3,opt,name=template,proto3,oneof"`
+}
+
+type Upstream_Metadata_TokenFile struct {
+	// A token read from a file, e.g. an OAuth or JWT credential that is rotated by another process.
+	TokenFile *Upstream_TokenFile `protobuf:"bytes,4,opt,name=token_file,json=tokenFile,proto3,oneof"`
+}
+
+func (*Upstream_Metadata_Value) isUpstream_Metadata_ValueSpecifier() {}
+
+func (*Upstream_Metadata_Template) isUpstream_Metadata_ValueSpecifier() {}
+
+func (*Upstream_Metadata_TokenFile) isUpstream_Metadata_ValueSpecifier() {}
+
+// [#next-free-field: 4]
+type Upstream_TokenFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path of the file that holds the token. Trailing newlines are ignored.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Prefix of the value, e.g. `Bearer `.
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Interval after which the file is read again, so that rotated tokens are picked up by new streams. Defaults
+	// to one minute.
+	RefreshInterval *duration.Duration `protobuf:"bytes,3,opt,name=refresh_interval,json=refreshInterval,proto3" json:"refresh_interval,omitempty"`
+}
+
+func (x *Upstream_TokenFile) Reset() {
+	*x = Upstream_TokenFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upstream_TokenFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream_TokenFile) ProtoMessage() {}
+
+func (x *Upstream_TokenFile) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream_TokenFile.ProtoReflect.Descriptor instead.
+func (*Upstream_TokenFile) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{6, 1}
+}
+
+func (x *Upstream_TokenFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Upstream_TokenFile) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *Upstream_TokenFile) GetRefreshInterval() *duration.Duration {
+	if x != nil {
+		return x.RefreshInterval
+	}
+	return nil
+}
+
+// [#next-free-field: 4]
+type Upstream_Credentials struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to SourceSpecifier:
+	//	*Upstream_Credentials_ServiceAccount_
+	//	*Upstream_Credentials_WorkloadIdentity_
+	//	*Upstream_Credentials_Exec
+	SourceSpecifier isUpstream_Credentials_SourceSpecifier `protobuf_oneof:"source_specifier"`
+}
+
+func (x *Upstream_Credentials) Reset() {
+	*x = Upstream_Credentials{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upstream_Credentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream_Credentials) ProtoMessage() {}
+
+func (x *Upstream_Credentials) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream_Credentials.ProtoReflect.Descriptor instead.
+func (*Upstream_Credentials) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{6, 2}
+}
+
+func (m *Upstream_Credentials) GetSourceSpecifier() isUpstream_Credentials_SourceSpecifier {
+	if m != nil {
+		return m.SourceSpecifier
+	}
+	return nil
+}
+
+func (x *Upstream_Credentials) GetServiceAccount() *Upstream_Credentials_ServiceAccount {
+	if x, ok := x.GetSourceSpecifier().(*Upstream_Credentials_ServiceAccount_); ok {
+		return x.ServiceAccount
+	}
+	return nil
+}
+
+func (x *Upstream_Credentials) GetWorkloadIdentity() *Upstream_Credentials_WorkloadIdentity {
+	if x, ok := x.GetSourceSpecifier().(*Upstream_Credentials_WorkloadIdentity_); ok {
+		return x.WorkloadIdentity
+	}
+	return nil
+}
+
+func (x *Upstream_Credentials) GetExec() *Upstream_Credentials_ExecPlugin {
+	if x, ok := x.GetSourceSpecifier().(*Upstream_Credentials_Exec); ok {
+		return x.Exec
+	}
+	return nil
+}
+
+type isUpstream_Credentials_SourceSpecifier interface {
+	isUpstream_Credentials_SourceSpecifier()
+}
+
+type Upstream_Credentials_ServiceAccount_ struct {
+	// A Google service account key file.
+	ServiceAccount *Upstream_Credentials_ServiceAccount `protobuf:"bytes,1,opt,name=service_account,json=serviceAccount,proto3,oneof"`
+}
+
+type Upstream_Credentials_WorkloadIdentity_ struct {
+	// The workload identity of the relay, obtained from the metadata server of GCE or GKE.
+	WorkloadIdentity *Upstream_Credentials_WorkloadIdentity `protobuf:"bytes,2,opt,name=workload_identity,json=workloadIdentity,proto3,oneof"`
+}
+
+type Upstream_Credentials_Exec struct {
+	// A command that prints the credentials, in the format of Kubernetes client-go credential plugins.
+	Exec *Upstream_Credentials_ExecPlugin `protobuf:"bytes,3,opt,name=exec,proto3,oneof"`
+}
+
+func (*Upstream_Credentials_ServiceAccount_) isUpstream_Credentials_SourceSpecifier() {}
+
+func (*Upstream_Credentials_WorkloadIdentity_) isUpstream_Credentials_SourceSpecifier() {}
+
+func (*Upstream_Credentials_Exec) isUpstream_Credentials_SourceSpecifier() {}
+
+// [#next-free-field: 3]
+type Upstream_Discovery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LoadBalancingPolicy Upstream_Discovery_LoadBalancingPolicy `protobuf:"varint,1,opt,name=load_balancing_policy,json=loadBalancingPolicy,proto3,enum=bootstrap.Upstream_Discovery_LoadBalancingPolicy" json:"load_balancing_policy,omitempty"`
+	// Interval between resolutions of the hostname. Streams to endpoints that are no longer resolved migrate to
+	// the remaining ones, resuming from the version of their last response. Defaults to 30 seconds.
+	RefreshInterval *duration.Duration `protobuf:"bytes,2,opt,name=refresh_interval,json=refreshInterval,proto3" json:"refresh_interval,omitempty"`
+}
+
+func (x *Upstream_Discovery) Reset() {
+	*x = Upstream_Discovery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upstream_Discovery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream_Discovery) ProtoMessage() {}
+
+func (x *Upstream_Discovery) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream_Discovery.ProtoReflect.Descriptor instead.
+func (*Upstream_Discovery) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{6, 3}
+}
+
+func (x *Upstream_Discovery) GetLoadBalancingPolicy() Upstream_Discovery_LoadBalancingPolicy {
+	if x != nil {
+		return x.LoadBalancingPolicy
+	}
+	return Upstream_Discovery_PICK_FIRST
+}
+
+func (x *Upstream_Discovery) GetRefreshInterval() *duration.Duration {
+	if x != nil {
+		return x.RefreshInterval
+	}
+	return nil
+}
+
+// [#next-free-field: 4]
+type Upstream_Credentials_ServiceAccount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path of the JSON key file of the service account.
+	KeyFile string `protobuf:"bytes,1,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	// OAuth scopes of the access token. Defaults to `https://www.googleapis.com/auth/cloud-platform`.
+	Scopes []string `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// If set, an OpenID Connect ID token for the audience is used instead of an access token.
+	Audience string `protobuf:"bytes,3,opt,name=audience,proto3" json:"audience,omitempty"`
+}
+
+func (x *Upstream_Credentials_ServiceAccount) Reset() {
+	*x = Upstream_Credentials_ServiceAccount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upstream_Credentials_ServiceAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream_Credentials_ServiceAccount) ProtoMessage() {}
+
+func (x *Upstream_Credentials_ServiceAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream_Credentials_ServiceAccount.ProtoReflect.Descriptor instead.
+func (*Upstream_Credentials_ServiceAccount) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{6, 2, 0}
+}
+
+func (x *Upstream_Credentials_ServiceAccount) GetKeyFile() string {
+	if x != nil {
+		return x.KeyFile
+	}
+	return ""
+}
+
+func (x *Upstream_Credentials_ServiceAccount) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *Upstream_Credentials_ServiceAccount) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+// [#next-free-field: 3]
+type Upstream_Credentials_WorkloadIdentity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// If set, an OpenID Connect ID token for the audience is used instead of an access token.
+	Audience string `protobuf:"bytes,1,opt,name=audience,proto3" json:"audience,omitempty"`
+	// The host of the metadata server. Defaults to `metadata.google.internal`.
+	MetadataServer string `protobuf:"bytes,2,opt,name=metadata_server,json=metadataServer,proto3" json:"metadata_server,omitempty"`
+}
+
+func (x *Upstream_Credentials_WorkloadIdentity) Reset() {
+	*x = Upstream_Credentials_WorkloadIdentity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upstream_Credentials_WorkloadIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream_Credentials_WorkloadIdentity) ProtoMessage() {}
+
+func (x *Upstream_Credentials_WorkloadIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream_Credentials_WorkloadIdentity.ProtoReflect.Descriptor instead.
+func (*Upstream_Credentials_WorkloadIdentity) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{6, 2, 1}
+}
+
+func (x *Upstream_Credentials_WorkloadIdentity) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *Upstream_Credentials_WorkloadIdentity) GetMetadataServer() string {
+	if x != nil {
+		return x.MetadataServer
+	}
+	return ""
+}
+
+// [#next-free-field: 4]
+type Upstream_Credentials_ExecPlugin struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The command, looked up in the PATH if it is not a path.
+	Command string   `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Args    []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	// Environment variables of the command, in addition to those of the relay.
+	Env map[string]string `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Upstream_Credentials_ExecPlugin) Reset() {
+	*x = Upstream_Credentials_ExecPlugin{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upstream_Credentials_ExecPlugin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream_Credentials_ExecPlugin) ProtoMessage() {}
+
+func (x *Upstream_Credentials_ExecPlugin) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream_Credentials_ExecPlugin.ProtoReflect.Descriptor instead.
+func (*Upstream_Credentials_ExecPlugin) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{6, 2, 2}
+}
+
+func (x *Upstream_Credentials_ExecPlugin) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *Upstream_Credentials_ExecPlugin) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *Upstream_Credentials_ExecPlugin) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+// [#next-free-field: 2]
+type HashRing_StaticMembers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Members []*HashRing_Member `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (x *HashRing_StaticMembers) Reset() {
+	*x = HashRing_StaticMembers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HashRing_StaticMembers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashRing_StaticMembers) ProtoMessage() {}
+
+func (x *HashRing_StaticMembers) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashRing_StaticMembers.ProtoReflect.Descriptor instead.
+func (*HashRing_StaticMembers) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{48, 0}
+}
+
+func (x *HashRing_StaticMembers) GetMembers() []*HashRing_Member {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// [#next-free-field: 3]
+type HashRing_Member struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The address of the xDS server of the member.
+	Address *SocketAddress `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *HashRing_Member) Reset() {
+	*x = HashRing_Member{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HashRing_Member) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashRing_Member) ProtoMessage() {}
+
+func (x *HashRing_Member) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashRing_Member.ProtoReflect.Descriptor instead.
+func (*HashRing_Member) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{48, 1}
+}
+
+func (x *HashRing_Member) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HashRing_Member) GetAddress() *SocketAddress {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+// Members are identified by the name of the pod of their address, or by their IP if it has none.
+// [#next-free-field: 7]
+type HashRing_KubernetesEndpoints struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Name of the port of the xDS server. Defaults to the first port of the endpoints.
+	PortName string `protobuf:"bytes,3,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	// The Kubernetes API server URL. Defaults to the in-cluster address from the KUBERNETES_SERVICE_HOST and
+	// KUBERNETES_SERVICE_PORT environment variables.
+	ApiServer string `protobuf:"bytes,4,opt,name=api_server,json=apiServer,proto3" json:"api_server,omitempty"`
+	// Path to the bearer token. Defaults to the in-cluster service account token.
+	TokenFile string `protobuf:"bytes,5,opt,name=token_file,json=tokenFile,proto3" json:"token_file,omitempty"`
+	// Path to the CA bundle used to verify the API server. Defaults to the in-cluster service account CA.
+	CaFile string `protobuf:"bytes,6,opt,name=ca_file,json=caFile,proto3" json:"ca_file,omitempty"`
+}
+
+func (x *HashRing_KubernetesEndpoints) Reset() {
+	*x = HashRing_KubernetesEndpoints{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HashRing_KubernetesEndpoints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashRing_KubernetesEndpoints) ProtoMessage() {}
+
+func (x *HashRing_KubernetesEndpoints) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashRing_KubernetesEndpoints.ProtoReflect.Descriptor instead.
+func (*HashRing_KubernetesEndpoints) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{48, 2}
+}
+
+func (x *HashRing_KubernetesEndpoints) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *HashRing_KubernetesEndpoints) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HashRing_KubernetesEndpoints) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *HashRing_KubernetesEndpoints) GetApiServer() string {
+	if x != nil {
+		return x.ApiServer
+	}
+	return ""
+}
+
+func (x *HashRing_KubernetesEndpoints) GetTokenFile() string {
+	if x != nil {
+		return x.TokenFile
+	}
+	return ""
+}
+
+func (x *HashRing_KubernetesEndpoints) GetCaFile() string {
+	if x != nil {
+		return x.CaFile
+	}
+	return ""
+}
+
+// [#next-free-field: 3]
+type UpstreamHealth_KeyTimeout struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KeyRegex string             `protobuf:"bytes,1,opt,name=key_regex,json=keyRegex,proto3" json:"key_regex,omitempty"`
+	Timeout  *duration.Duration `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *UpstreamHealth_KeyTimeout) Reset() {
+	*x = UpstreamHealth_KeyTimeout{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[81]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpstreamHealth_KeyTimeout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpstreamHealth_KeyTimeout) ProtoMessage() {}
+
+func (x *UpstreamHealth_KeyTimeout) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[81]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpstreamHealth_KeyTimeout.ProtoReflect.Descriptor instead.
+func (*UpstreamHealth_KeyTimeout) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{49, 0}
+}
+
+func (x *UpstreamHealth_KeyTimeout) GetKeyRegex() string {
+	if x != nil {
+		return x.KeyRegex
+	}
+	return ""
+}
+
+func (x *UpstreamHealth_KeyTimeout) GetTimeout() *duration.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+// A window that starts on every minute matching the schedule.
+// [#next-free-field: 3]
+type Maintenance_Window struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Cron expression of the start of the window in UTC, of five fields: minute, hour, day of month, month, and
+	// day of week. For example, `0 9 * * 1-5` starts the window at 09:00 on weekdays.
+	Schedule string `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	// Duration of the window.
+	Duration *duration.Duration `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+}
+
+func (x *Maintenance_Window) Reset() {
+	*x = Maintenance_Window{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[82]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Maintenance_Window) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Maintenance_Window) ProtoMessage() {}
+
+func (x *Maintenance_Window) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[82]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Maintenance_Window.ProtoReflect.Descriptor instead.
+func (*Maintenance_Window) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{57, 0}
+}
+
+func (x *Maintenance_Window) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *Maintenance_Window) GetDuration() *duration.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+// [#next-free-field: 3]
+type Buffers_Buffer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of responses the channel holds. Downstream channels hold at least 1 response, and default to 1.
+	// Upstream channels default to 0, which passes responses through unbuffered.
+	Size           uint32                 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	OverflowPolicy Buffers_OverflowPolicy `protobuf:"varint,2,opt,name=overflow_policy,json=overflowPolicy,proto3,enum=bootstrap.Buffers_OverflowPolicy" json:"overflow_policy,omitempty"`
+}
+
+func (x *Buffers_Buffer) Reset() {
+	*x = Buffers_Buffer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[83]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Buffers_Buffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Buffers_Buffer) ProtoMessage() {}
+
+func (x *Buffers_Buffer) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[83]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Buffers_Buffer.ProtoReflect.Descriptor instead.
+func (*Buffers_Buffer) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{61, 0}
+}
+
+func (x *Buffers_Buffer) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Buffers_Buffer) GetOverflowPolicy() Buffers_OverflowPolicy {
+	if x != nil {
+		return x.OverflowPolicy
+	}
+	return Buffers_DEFAULT
+}
+
+// [#next-free-field: 4]
+type Buffers_TypeURLBuffers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TypeUrl string `protobuf:"bytes,1,opt,name=type_url,json=typeUrl,proto3" json:"type_url,omitempty"`
+	// Buffer of the downstream watches of the type. If unset, the default downstream buffer applies.
+	Downstream *Buffers_Buffer `protobuf:"bytes,2,opt,name=downstream,proto3" json:"downstream,omitempty"`
+	// Buffer of the upstream streams of the type. If unset, the default upstream buffer applies.
+	Upstream *Buffers_Buffer `protobuf:"bytes,3,opt,name=upstream,proto3" json:"upstream,omitempty"`
+}
+
+func (x *Buffers_TypeURLBuffers) Reset() {
+	*x = Buffers_TypeURLBuffers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[84]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Buffers_TypeURLBuffers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Buffers_TypeURLBuffers) ProtoMessage() {}
+
+func (x *Buffers_TypeURLBuffers) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[84]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Buffers_TypeURLBuffers.ProtoReflect.Descriptor instead.
+func (*Buffers_TypeURLBuffers) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{61, 1}
+}
+
+func (x *Buffers_TypeURLBuffers) GetTypeUrl() string {
+	if x != nil {
+		return x.TypeUrl
+	}
+	return ""
+}
+
+func (x *Buffers_TypeURLBuffers) GetDownstream() *Buffers_Buffer {
+	if x != nil {
+		return x.Downstream
+	}
+	return nil
+}
+
+func (x *Buffers_TypeURLBuffers) GetUpstream() *Buffers_Buffer {
+	if x != nil {
+		return x.Upstream
+	}
+	return nil
+}
+
+// [#next-free-field: 3]
+type KeyMetrics_Rule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Regular expression of the aggregated keys of the rule.
+	KeyRegex string `protobuf:"bytes,1,opt,name=key_regex,json=keyRegex,proto3" json:"key_regex,omitempty"`
+	// Tag value of the metrics of the keys of the rule. If unset, the metrics are tagged with the key itself.
+	Bucket string `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+}
+
+func (x *KeyMetrics_Rule) Reset() {
+	*x = KeyMetrics_Rule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[85]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyMetrics_Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyMetrics_Rule) ProtoMessage() {}
+
+func (x *KeyMetrics_Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[85]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyMetrics_Rule.ProtoReflect.Descriptor instead.
+func (*KeyMetrics_Rule) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{62, 0}
+}
+
+func (x *KeyMetrics_Rule) GetKeyRegex() string {
+	if x != nil {
+		return x.KeyRegex
+	}
+	return ""
+}
+
+func (x *KeyMetrics_Rule) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+// [#next-free-field: 5]
+type AggregationRollout_Revision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the revision, unique among the revisions.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Path of the YAML file of the aggregation rules of the revision.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Percentage of downstream nodes, selected by a hash of their node ID, that are mapped with the revision.
+	// The percentages of the revisions select distinct nodes, and add up to at most 100.
+	Percentage float64 `protobuf:"fixed64,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	// Node metadata fields whose string values select the nodes mapped with the revision, in addition to the
+	// percentage. A node is selected if all of the fields match.
+	NodeMetadata map[string]string `protobuf:"bytes,4,rep,name=node_metadata,json=nodeMetadata,proto3" json:"node_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *AggregationRollout_Revision) Reset() {
+	*x = AggregationRollout_Revision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[86]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregationRollout_Revision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregationRollout_Revision) ProtoMessage() {}
+
+func (x *AggregationRollout_Revision) ProtoReflect() protoreflect.Message {
+	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[86]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregationRollout_Revision.ProtoReflect.Descriptor instead.
+func (*AggregationRollout_Revision) Descriptor() ([]byte, []int) {
+	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{63, 0}
+}
+
+func (x *AggregationRollout_Revision) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AggregationRollout_Revision) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *AggregationRollout_Revision) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *AggregationRollout_Revision) GetNodeMetadata() map[string]string {
+	if x != nil {
+		return x.NodeMetadata
+	}
+	return nil
+}
+
+var File_bootstrap_v1_bootstrap_proto protoreflect.FileDescriptor
+
+var file_bootstrap_v1_bootstrap_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2f, 0x76, 0x31, 0x2f, 0x62,
+	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09,
+	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xf3, 0x16, 0x0a, 0x09, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x12, 0x33,
+	0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x06, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x12, 0x42, 0x0a, 0x0d, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x42,
+	0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0c, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x07, 0x6c, 0x6f, 0x67, 0x67, 0x69,
+	0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73,
+	0x74, 0x72, 0x61, 0x70, 0x2e, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x42, 0x08, 0xfa, 0x42,
+	0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x6c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x12,
+	0x30, 0x0a, 0x05, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x05, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x12, 0x43, 0x0a, 0x0c, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x73, 0x69, 0x6e,
+	0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74,
+	0x72, 0x61, 0x70, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x53, 0x69, 0x6e, 0x6b, 0x42,
+	0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0b, 0x6d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x73, 0x53, 0x69, 0x6e, 0x6b, 0x12, 0x30, 0x0a, 0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61,
+	0x70, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10,
+	0x01, 0x52, 0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x3c, 0x0a, 0x0d, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x67, 0x75, 0x61, 0x72, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x47, 0x75, 0x61, 0x72, 0x64, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x47, 0x75, 0x61, 0x72, 0x64, 0x12, 0x3e, 0x0a, 0x0d, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0d, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x42, 0x0a, 0x0f, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x5f, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x4c, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x45, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x6c, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x45, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x0b, 0x72, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61,
+	0x70, 0x2e, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e,
+	0x12, 0x38, 0x0a, 0x0d, 0x73, 0x68, 0x61, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74,
+	0x72, 0x61, 0x70, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x0c, 0x73, 0x68,
+	0x61, 0x64, 0x6f, 0x77, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x43, 0x0a, 0x0f, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0d, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x3f, 0x0a, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74,
+	0x72, 0x61, 0x70, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x46, 0x69, 0x6c, 0x65,
+	0x73, 0x52, 0x0d, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73,
+	0x12, 0x44, 0x0a, 0x10, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
+	0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52,
+	0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x29, 0x0a, 0x06, 0x72, 0x65,
+	0x70, 0x6c, 0x61, 0x79, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x06, 0x72,
+	0x65, 0x70, 0x6c, 0x61, 0x79, 0x12, 0x38, 0x0a, 0x0b, 0x73, 0x75, 0x70, 0x65, 0x72, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53, 0x75, 0x70, 0x65, 0x72, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x0b, 0x73, 0x75, 0x70, 0x65, 0x72, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x48, 0x0a, 0x11, 0x66, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x69, 0x6e, 0x67, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x46, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x10, 0x66, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x30, 0x0a, 0x09, 0x61, 0x75, 0x64,
+	0x69, 0x74, 0x5f, 0x6c, 0x6f, 0x67, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62,
+	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f,
+	0x67, 0x52, 0x08, 0x61, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x57, 0x0a, 0x16, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62, 0x6f,
+	0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x15, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x41, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x16, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74,
+	0x72, 0x61, 0x70, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x12, 0x5b, 0x0a, 0x18, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x18,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70,
+	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x49, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x16, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x50, 0x6c, 0x61, 0x6e, 0x65, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12,
+	0x2c, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x52, 0x6f, 0x6c,
+	0x6c, 0x6f, 0x75, 0x74, 0x52, 0x07, 0x72, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x12, 0x42, 0x0a,
+	0x0f, 0x63, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72,
+	0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72,
+	0x61, 0x70, 0x2e, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65,
+	0x72, 0x52, 0x0e, 0x63, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65,
+	0x72, 0x12, 0x4e, 0x0a, 0x13, 0x64, 0x69, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x5f, 0x66, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x46, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x52, 0x12, 0x64,
+	0x69, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x46, 0x61, 0x6e, 0x6f, 0x75,
+	0x74, 0x12, 0x54, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x65, 0x64,
+	0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x64, 0x75, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x08, 0x61, 0x6c, 0x65, 0x72, 0x74,
+	0x69, 0x6e, 0x67, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
+	0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x08,
+	0x61, 0x6c, 0x65, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x3f, 0x0a, 0x0e, 0x6e, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x4e, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x0d, 0x6e, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x42, 0x0a, 0x0f, 0x75, 0x70, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0x1f, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x55,
+	0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x0e, 0x75,
+	0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x30, 0x0a,
+	0x09, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x20, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x48, 0x61, 0x73,
+	0x68, 0x52, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x68, 0x61, 0x73, 0x68, 0x52, 0x69, 0x6e, 0x67, 0x12,
+	0x36, 0x0a, 0x0b, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x21,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70,
+	0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x0a, 0x72, 0x65, 0x6c,
+	0x61, 0x79, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x39, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x6c, 0x61, 0x79, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x6c,
+	0x61, 0x79, 0x12, 0x4e, 0x0a, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x07, 0x6c, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x24, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e,
+	0x4c, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67,
+	0x12, 0x4e, 0x0a, 0x13, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x25, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64,
+	0x65, 0x6e, 0x63, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x12, 0x64, 0x65,
+	0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x69, 0x6e, 0x67,
+	0x12, 0x2c, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x26, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x38,
+	0x0a, 0x0b, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x27, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e,
+	0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x0b, 0x6d, 0x61, 0x69,
+	0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x46, 0x0a, 0x11, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x67, 0x75, 0x61, 0x72, 0x64, 0x18, 0x28, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x47, 0x75, 0x61, 0x72, 0x64, 0x52,
+	0x0f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x47, 0x75, 0x61, 0x72, 0x64,
+	0x12, 0x3c, 0x0a, 0x0d, 0x66, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x5f, 0x70, 0x61, 0x63, 0x69, 0x6e,
+	0x67, 0x18, 0x29, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74,
+	0x72, 0x61, 0x70, 0x2e, 0x46, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x50, 0x61, 0x63, 0x69, 0x6e, 0x67,
+	0x52, 0x0c, 0x66, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x50, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x26,
+	0x0a, 0x05, 0x66, 0x65, 0x74, 0x63, 0x68, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52,
+	0x05, 0x66, 0x65, 0x74, 0x63, 0x68, 0x12, 0x2c, 0x0a, 0x07, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72,
+	0x73, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74,
+	0x72, 0x61, 0x70, 0x2e, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x73, 0x52, 0x07, 0x62, 0x75, 0x66,
+	0x66, 0x65, 0x72, 0x73, 0x12, 0x36, 0x0a, 0x0b, 0x6b, 0x65, 0x79, 0x5f, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
+	0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x4b, 0x65, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x52, 0x0a, 0x6b, 0x65, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x4e, 0x0a, 0x13,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x6f, 0x6c, 0x6c,
+	0x6f, 0x75, 0x74, 0x18, 0x2d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
+	0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x52, 0x12, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x12, 0x57, 0x0a, 0x16,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x2e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62,
+	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x15,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x6d, 0x75, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x0e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x2f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x0d, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x91, 0x04, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x12, 0x3c, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x42, 0x08, 0xfa, 0x42,
+	0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x3b, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61,
+	0x70, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x0b, 0x72, 0x65, 0x73, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x51, 0x0a, 0x12,
+	0x77, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x32, 0x00, 0x52, 0x10, 0x77,
+	0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12,
+	0x25, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x32, 0x0a, 0x09, 0x61, 0x64, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
+	0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x09, 0x61, 0x64, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x0c, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x63,
+	0x65, 0x70, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x10, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x73, 0x12, 0x31, 0x0a, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72,
+	0x61, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x52, 0x09, 0x6c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x76, 0x33, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x76, 0x33, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x68, 0x64, 0x73, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x76,
+	0x68, 0x64, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x22, 0xbd, 0x02, 0x0a, 0x08, 0x4c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61,
+	0x70, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x42,
+	0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x48, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x42, 0x0f, 0xfa, 0x42, 0x0c, 0x92, 0x01, 0x09, 0x22, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01,
+	0x08, 0x01, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x03,
+	0x74, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
+	0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x54, 0x4c, 0x53, 0x52, 0x03, 0x74, 0x6c, 0x73, 0x12, 0x32,
+	0x0a, 0x09, 0x61, 0x64, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x41, 0x64,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x61, 0x64, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x36, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x07, 0x0a,
+	0x03, 0x41, 0x44, 0x53, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x44, 0x53, 0x5f, 0x45, 0x44,
+	0x53, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x44, 0x53, 0x5f, 0x52, 0x44, 0x53, 0x10, 0x02,
+	0x12, 0x08, 0x0a, 0x04, 0x52, 0x54, 0x44, 0x53, 0x10, 0x03, 0x22, 0x75, 0x0a, 0x03, 0x54, 0x4c,
+	0x53, 0x12, 0x24, 0x0a, 0x09, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x08, 0x63,
+	0x65, 0x72, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x22, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x66,
+	0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02,
+	0x10, 0x01, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x61, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x61, 0x46, 0x69, 0x6c,
+	0x65, 0x22, 0xcd, 0x01, 0x0a, 0x0b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x63, 0x65, 0x70, 0x74, 0x6f,
+	0x72, 0x12, 0x3d, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x79, 0x48, 0x00, 0x52, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x12, 0x41, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70,
+	0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x63, 0x65, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x64, 0x1a, 0x0a, 0x0a, 0x08, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x1a,
+	0x23, 0x0a, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x42, 0x0b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x03, 0xf8, 0x42,
+	0x01, 0x22, 0xfe, 0x01, 0x0a, 0x09, 0x41, 0x64, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x3c, 0x0a, 0x12, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x42, 0x0e, 0xfa, 0x42, 0x0b,
+	0x12, 0x09, 0x21, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x52, 0x10, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x62, 0x75, 0x72, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x62, 0x75,
+	0x72, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x79,
+	0x5f, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02,
+	0x32, 0x00, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x79, 0x4a, 0x69, 0x74, 0x74,
+	0x65, 0x72, 0x12, 0x4e, 0x0a, 0x10, 0x73, 0x74, 0x61, 0x72, 0x74, 0x75, 0x70, 0x5f, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x32,
+	0x00, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x75, 0x70, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x92, 0x0d, 0x0a, 0x08, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
+	0x3c, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a,
+	0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x26, 0x0a,
+	0x05, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62,
+	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x52, 0x05,
+	0x70, 0x72, 0x6f, 0x78, 0x79, 0x12, 0x3b, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73,
+	0x74, 0x72, 0x61, 0x70, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x09, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x79, 0x12, 0x38, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70,
+	0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x41, 0x0a, 0x0b,
+	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x55, 0x70,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x73, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12,
+	0x49, 0x0a, 0x0b, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70,
+	0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x41, 0x70, 0x69, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0a,
+	0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0xb3, 0x01, 0x0a, 0x08, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x19, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x16, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1c, 0x0a, 0x08, 0x74, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08,
+	0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x3e, 0x0a, 0x0a, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62,
+	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x09, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x16, 0x0a, 0x0f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x03, 0xf8, 0x42, 0x01,
+	0x1a, 0x90, 0x01, 0x0a, 0x09, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1b,
+	0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42,
+	0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x12, 0x4e, 0x0a, 0x10, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02,
+	0x2a, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x1a, 0xac, 0x05, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x61, 0x6c, 0x73, 0x12, 0x59, 0x0a, 0x0f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x62,
+	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0e,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x5f,
+	0x0a, 0x11, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
+	0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x43,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x6c,
+	0x6f, 0x61, 0x64, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x10, 0x77,
+	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12,
+	0x40, 0x0a, 0x04, 0x65, 0x78, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e,
+	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x45,
+	0x78, 0x65, 0x63, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x48, 0x00, 0x52, 0x04, 0x65, 0x78, 0x65,
+	0x63, 0x1a, 0x68, 0x0a, 0x0e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x07,
+	0x6b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x1a, 0x57, 0x0a, 0x10, 0x57,
+	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12,
+	0x1a, 0x0a, 0x08, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x1a, 0xc2, 0x01, 0x0a, 0x0a, 0x45, 0x78, 0x65, 0x63, 0x50, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x12, 0x21, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x07, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x45, 0x0a, 0x03, 0x65, 0x6e,
+	0x76, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74,
+	0x72, 0x61, 0x70, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x43, 0x72, 0x65,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x50, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x2e, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x65, 0x6e,
+	0x76, 0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x17, 0x0a, 0x10, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x03, 0xf8,
+	0x42, 0x01, 0x1a, 0x84, 0x02, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x12, 0x6f, 0x0a, 0x15, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x69,
+	0x6e, 0x67, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x31, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x55, 0x70, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c,
+	0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x13, 0x6c, 0x6f,
+	0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x12, 0x4e, 0x0a, 0x10, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00,
+	0x52, 0x0f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x22, 0x36, 0x0a, 0x13, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x69,
+	0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x49, 0x43, 0x4b,
+	0x5f, 0x46, 0x49, 0x52, 0x53, 0x54, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x4f, 0x55, 0x4e,
+	0x44, 0x5f, 0x52, 0x4f, 0x42, 0x49, 0x4e, 0x10, 0x01, 0x22, 0x1c, 0x0a, 0x0a, 0x41, 0x70, 0x69,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x06, 0x0a, 0x02, 0x56, 0x32, 0x10, 0x00, 0x12,
+	0x06, 0x0a, 0x02, 0x56, 0x33, 0x10, 0x01, 0x22, 0xe1, 0x01, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x78,
+	0x79, 0x12, 0x33, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x15, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x78,
+	0x79, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74,
+	0x72, 0x61, 0x70, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x24, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a,
+	0x0c, 0x48, 0x54, 0x54, 0x50, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x10, 0x00, 0x12,
+	0x0a, 0x0a, 0x06, 0x53, 0x4f, 0x43, 0x4b, 0x53, 0x35, 0x10, 0x01, 0x22, 0xb2, 0x01, 0x0a, 0x07,
+	0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x38, 0x0a, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x2e, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x05,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x26, 0x0a, 0x0f, 0x6b, 0x65, 0x79, 0x5f, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d,
+	0x6b, 0x65, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x31, 0x0a,
+	0x05, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x00,
+	0x12, 0x09, 0x0a, 0x05, 0x44, 0x45, 0x42, 0x55, 0x47, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x57,
+	0x41, 0x52, 0x4e, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03,
+	0x22, 0xf5, 0x02, 0x0a, 0x05, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x37, 0x0a, 0x03, 0x74, 0x74,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0xaa, 0x01, 0x04, 0x08, 0x01, 0x32, 0x00, 0x52, 0x03,
+	0x74, 0x74, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x45, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x52, 0x0a, 0x0f, 0x65, 0x76, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e,
+	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x2e,
+	0x45, 0x76, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x42, 0x08,
+	0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0e, 0x65, 0x76, 0x69, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x58, 0x0a, 0x11, 0x65, 0x76, 0x69, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x2e, 0x45, 0x76, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01,
+	0x52, 0x10, 0x65, 0x76, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x67, 0x79, 0x22, 0x30, 0x0a, 0x0e, 0x45, 0x76, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x45, 0x52, 0x4d, 0x49, 0x4e, 0x41, 0x54,
+	0x45, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x45, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49,
+	0x42, 0x45, 0x10, 0x01, 0x22, 0x32, 0x0a, 0x10, 0x45, 0x76, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x52, 0x55, 0x10,
+	0x00, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x46, 0x55, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x54,
+	0x4c, 0x5f, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x02, 0x22, 0x7b, 0x0a, 0x0d, 0x53, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x22, 0x0a, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72,
+	0x03, 0xa8, 0x01, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x28, 0x0a,
+	0x0a, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x42, 0x09, 0xfa, 0x42, 0x06, 0x2a, 0x04, 0x18, 0xff, 0xff, 0x03, 0x52, 0x09, 0x70, 0x6f,
+	0x72, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x2a, 0x03, 0x18, 0xff, 0x03, 0x52,
+	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x7b, 0x0a, 0x05, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x3c,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01,
+	0x02, 0x10, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x34, 0x0a, 0x16,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x5f, 0x65, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x44, 0x65, 0x62, 0x75, 0x67, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x73, 0x22, 0x47, 0x0a, 0x0b, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x53, 0x69, 0x6e,
+	0x6b, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x73, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x64, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x73, 0x64, 0x42, 0x0b,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x22, 0xbe, 0x01, 0x0a, 0x06,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x64, 0x12, 0x3c, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74,
+	0x72, 0x61, 0x70, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x28, 0x0a, 0x0b, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02,
+	0x20, 0x01, 0x52, 0x0a, 0x72, 0x6f, 0x6f, 0x74, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x4c,
+	0x0a, 0x0e, 0x66, 0x6c, 0x75, 0x73, 0x68, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0xaa, 0x01, 0x04, 0x08, 0x01, 0x32, 0x00, 0x52, 0x0d, 0x66,
+	0x6c, 0x75, 0x73, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0xd7, 0x01, 0x0a,
+	0x0c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x47, 0x75, 0x61, 0x72, 0x64, 0x12, 0x4c, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x22, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x47, 0x75, 0x61, 0x72, 0x64, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52,
+	0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x2d, 0x0a, 0x12, 0x72,
+	0x65, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x72, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x52,
+	0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x4a, 0x0a, 0x0a, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x49, 0x53, 0x41,
+	0x42, 0x4c, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x4e, 0x55, 0x4d, 0x45, 0x52, 0x49,
+	0x43, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x45, 0x4d, 0x56, 0x45, 0x52, 0x10, 0x02, 0x12,
+	0x15, 0x0a, 0x11, 0x4f, 0x50, 0x41, 0x51, 0x55, 0x45, 0x5f, 0x57, 0x49, 0x54, 0x48, 0x5f, 0x4e,
+	0x4f, 0x4e, 0x43, 0x45, 0x10, 0x03, 0x22, 0x3f, 0x0a, 0x0d, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2e, 0x0a, 0x08, 0x77, 0x65, 0x62, 0x68, 0x6f,
+	0x6f, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
+	0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x08, 0x77,
+	0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x07, 0x57, 0x65, 0x62, 0x68,
+	0x6f, 0x6f, 0x6b, 0x12, 0x1a, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0x88, 0x01, 0x01, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12,
+	0x3d, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05,
+	0xaa, 0x01, 0x02, 0x32, 0x00, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x71, 0x75, 0x65, 0x75, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x99, 0x02,
+	0x0a, 0x0e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x45, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x4a, 0x0a, 0x0e,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42,
+	0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00, 0x52, 0x0d, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x46, 0x0a, 0x0c, 0x72, 0x65, 0x74, 0x72,
+	0x79, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01,
+	0x02, 0x2a, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x74, 0x72, 0x79, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x12, 0x47, 0x0a, 0x10, 0x6b, 0x75, 0x62, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x65, 0x73, 0x5f, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x4b, 0x75, 0x62, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x65,
+	0x73, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x6b, 0x75, 0x62, 0x65, 0x72, 0x6e,
+	0x65, 0x74, 0x65, 0x73, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x42, 0x0e, 0x0a, 0x07, 0x62, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x22, 0xac, 0x01, 0x0a, 0x0f, 0x4b, 0x75,
+	0x62, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x65, 0x73, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x25, 0x0a,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x20, 0x01, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x20, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x70, 0x69, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x70, 0x69, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12,
+	0x17, 0x0a, 0x07, 0x63, 0x61, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x61, 0x46, 0x69, 0x6c, 0x65, 0x22, 0xbd, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73,
+	0x74, 0x72, 0x61, 0x70, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x08, 0x6c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62,
+	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x52, 0x08, 0x6c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x33, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x74, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x4c, 0x53,
+	0x52, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x6c, 0x73, 0x4a, 0x04, 0x08, 0x01, 0x10,
+	0x02, 0x52, 0x05, 0x73, 0x65, 0x72, 0x76, 0x65, 0x22, 0x75, 0x0a, 0x13, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12,
+	0x3c, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a,
+	0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x20, 0x0a,
+	0x03, 0x74, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x54, 0x4c, 0x53, 0x52, 0x03, 0x74, 0x6c, 0x73, 0x22,
+	0x5c, 0x0a, 0x09, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x4c, 0x53, 0x12, 0x17, 0x0a, 0x07,
+	0x63, 0x61, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63,
+	0x61, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x65, 0x72, 0x74, 0x46, 0x69,
+	0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x4d, 0x0a,
+	0x06, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x43, 0x0a, 0x0d, 0x73, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x44, 0x72, 0x79, 0x52, 0x75,
+	0x6e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x73,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x9b, 0x01, 0x0a,
+	0x12, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x08, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x20, 0x01, 0x52, 0x07,
+	0x74, 0x79, 0x70, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x43, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0xe9, 0x01, 0x0a, 0x0e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a,
+	0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x55, 0x72, 0x6c, 0x73, 0x12, 0x3b, 0x0a, 0x0c, 0x73, 0x74,
+	0x72, 0x69, 0x70, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53, 0x74, 0x72,
+	0x69, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x69,
+	0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x35, 0x0a, 0x0a, 0x73, 0x65, 0x74, 0x5f, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x6f,
+	0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53, 0x65, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x48, 0x00, 0x52, 0x09, 0x73, 0x65, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x32,
+	0x0a, 0x09, 0x67, 0x6f, 0x5f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x47, 0x6f,
+	0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x48, 0x00, 0x52, 0x08, 0x67, 0x6f, 0x50, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x42, 0x12, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x65,
+	0x72, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x22, 0x2d, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x69, 0x70, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x92, 0x01, 0x02, 0x08, 0x01, 0x52, 0x05,
+	0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0xa2, 0x01, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x12, 0x42, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e,
+	0x53, 0x65, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x9a, 0x01, 0x02, 0x08, 0x01, 0x52,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0x51, 0x0a, 0x0b, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x27, 0x0a, 0x08, 0x47, 0x6f,
+	0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12, 0x1b, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x20, 0x01, 0x52, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x22, 0x84, 0x01, 0x0a, 0x0d, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65,
+	0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x20,
+	0x01, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x4c, 0x0a, 0x0f,
+	0x72, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00, 0x52, 0x0e, 0x72, 0x65, 0x6c, 0x6f,
+	0x61, 0x64, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x7e, 0x0a, 0x0e, 0x53, 0x74,
+	0x61, 0x74, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02,
+	0x20, 0x01, 0x48, 0x00, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x24, 0x0a, 0x08, 0x74, 0x79, 0x70,
+	0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04,
+	0x72, 0x02, 0x20, 0x01, 0x48, 0x00, 0x52, 0x07, 0x74, 0x79, 0x70, 0x65, 0x55, 0x72, 0x6c, 0x12,
+	0x1b, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa,
+	0x42, 0x04, 0x72, 0x02, 0x20, 0x01, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x42, 0x0c, 0x0a, 0x05,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x22, 0x5b, 0x0a, 0x09, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x25, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72,
+	0x02, 0x20, 0x01, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x27,
+	0x0a, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x58, 0x0a, 0x06, 0x52, 0x65, 0x70, 0x6c, 0x61,
+	0x79, 0x12, 0x25, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x20, 0x01, 0x52, 0x09, 0x64,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0e, 0x70, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e,
+	0x67, 0x22, 0x9c, 0x01, 0x0a, 0x0b, 0x53, 0x75, 0x70, 0x65, 0x72, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x3f, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x33, 0x32,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x73, 0x12, 0x4c, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x62, 0x61,
+	0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x32, 0x00,
+	0x52, 0x0e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66,
+	0x22, 0x31, 0x0a, 0x12, 0x44, 0x69, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x46, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x75,
+	0x72, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x55,
+	0x72, 0x6c, 0x73, 0x22, 0x33, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x44, 0x65,
+	0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x79, 0x70, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
+	0x74, 0x79, 0x70, 0x65, 0x55, 0x72, 0x6c, 0x73, 0x22, 0xaf, 0x01, 0x0a, 0x10, 0x46, 0x61, 0x6e,
+	0x6f, 0x75, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x29, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x2a, 0x02, 0x20, 0x00, 0x52, 0x0b, 0x63, 0x6f, 0x6e,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2e, 0x0a, 0x07, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
+	0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x4b, 0x65, 0x79, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52,
+	0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x40, 0x0a, 0x0f, 0x74, 0x79, 0x70, 0x65,
+	0x5f, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x54, 0x79,
+	0x70, 0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x0e, 0x74, 0x79, 0x70, 0x65,
+	0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x4e, 0x0a, 0x0c, 0x54, 0x79,
+	0x70, 0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x22, 0x0a, 0x08, 0x74, 0x79,
+	0x70, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42,
+	0x04, 0x72, 0x02, 0x20, 0x01, 0x52, 0x07, 0x74, 0x79, 0x70, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x52, 0x0a, 0x09, 0x4b, 0x65,
+	0x79, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x24, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x5f, 0x72,
+	0x65, 0x67, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72,
+	0x02, 0x20, 0x01, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x1f, 0x0a,
+	0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x07, 0xfa,
+	0x42, 0x04, 0x2a, 0x02, 0x20, 0x00, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xa0,
+	0x01, 0x0a, 0x08, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x2d, 0x0a, 0x04, 0x66,
+	0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
+	0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x46, 0x69,
+	0x6c, 0x65, 0x48, 0x00, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x79,
+	0x73, 0x6c, 0x6f, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x53,
+	0x79, 0x73, 0x6c, 0x6f, 0x67, 0x48, 0x00, 0x52, 0x06, 0x73, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x12,
+	0x23, 0x0a, 0x0d, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x68, 0x61, 0x73, 0x68, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x73, 0x42, 0x0b, 0x0a, 0x04, 0x73, 0x69, 0x6e, 0x6b, 0x12, 0x03, 0xf8, 0x42,
+	0x01, 0x22, 0xb7, 0x01, 0x0a, 0x0c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x46, 0x69,
+	0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x20, 0x01, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12,
+	0x4b, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x36, 0x34,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x32, 0x02, 0x20, 0x00, 0x52, 0x0c,
+	0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x0b,
+	0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x0a, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x22, 0x69, 0x0a, 0x0e, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x53, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x12, 0x2b, 0x0a,
+	0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x11,
+	0xfa, 0x42, 0x0e, 0x72, 0x0c, 0x52, 0x00, 0x52, 0x03, 0x74, 0x63, 0x70, 0x52, 0x03, 0x75, 0x64,
+	0x70, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x48, 0x0a, 0x15, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x2f, 0x0a, 0x0f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x20,
+	0x01, 0x52, 0x0d, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x50, 0x61, 0x74, 0x68,
+	0x22, 0xcf, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x79, 0x70, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x1b, 0x0a,
+	0x09, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61,
+	0x78, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12,
+	0x41, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1f, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x1e, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x0a, 0x06,
+	0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x57, 0x41, 0x52, 0x4e,
+	0x10, 0x01, 0x22, 0x97, 0x02, 0x0a, 0x06, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x3a, 0x0a,
+	0x0a, 0x67, 0x63, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x09,
+	0x67, 0x63, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x61, 0x6c,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0c, 0x62, 0x61, 0x6c, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x30,
+	0x0a, 0x14, 0x68, 0x69, 0x67, 0x68, 0x5f, 0x77, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x68, 0x69,
+	0x67, 0x68, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x4a, 0x0a, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00, 0x52, 0x0d, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x2e, 0x0a, 0x13,
+	0x65, 0x76, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x65, 0x76, 0x69, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x4a, 0x0a, 0x16,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x18,
+	0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x22, 0xbe, 0x02, 0x0a, 0x07, 0x52, 0x6f, 0x6c,
+	0x6c, 0x6f, 0x75, 0x74, 0x12, 0x44, 0x0a, 0x11, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x42,
+	0x17, 0xfa, 0x42, 0x14, 0x12, 0x12, 0x29, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x19,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x59, 0x40, 0x52, 0x10, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x5c, 0x0a, 0x14, 0x63, 0x61,
+	0x6e, 0x61, 0x72, 0x79, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73,
+	0x74, 0x72, 0x61, 0x70, 0x2e, 0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x2e, 0x43, 0x61, 0x6e,
+	0x61, 0x72, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x12, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x4e, 0x6f, 0x64, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x48, 0x0a, 0x0d, 0x73, 0x6f, 0x61, 0x6b,
+	0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa,
+	0x01, 0x02, 0x2a, 0x00, 0x52, 0x0c, 0x73, 0x6f, 0x61, 0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x1a, 0x45, 0x0a, 0x17, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x4e, 0x6f, 0x64, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf2, 0x01, 0x0a, 0x0e, 0x43, 0x69,
+	0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x12, 0x52, 0x0a, 0x11,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x33, 0x32,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x2a, 0x02, 0x20, 0x00, 0x52, 0x10,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x12, 0x4a, 0x0a, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00, 0x52, 0x0d, 0x66,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x40, 0x0a, 0x09,
+	0x63, 0x6f, 0x6f, 0x6c, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa,
+	0x01, 0x02, 0x2a, 0x00, 0x52, 0x08, 0x63, 0x6f, 0x6f, 0x6c, 0x44, 0x6f, 0x77, 0x6e, 0x22, 0xbd,
+	0x02, 0x0a, 0x08, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x44, 0x0a, 0x0b, 0x73,
+	0x74, 0x61, 0x6c, 0x65, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05,
+	0xaa, 0x01, 0x02, 0x2a, 0x00, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x41, 0x66, 0x74, 0x65,
+	0x72, 0x12, 0x52, 0x0a, 0x19, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x5f, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x42, 0x17, 0xfa, 0x42, 0x14, 0x12, 0x12, 0x29, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x19, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x59, 0x40, 0x52, 0x16, 0x6d,
+	0x61, 0x78, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x72, 0x6f, 0x70, 0x50, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x41, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x6e, 0x61, 0x63,
+	0x6b, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x42,
+	0x17, 0xfa, 0x42, 0x14, 0x12, 0x12, 0x29, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x19,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x59, 0x40, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x4e, 0x61, 0x63,
+	0x6b, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x54, 0x0a, 0x13, 0x65, 0x76, 0x61, 0x6c,
+	0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00, 0x52, 0x12, 0x65, 0x76, 0x61, 0x6c,
+	0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x81,
+	0x06, 0x0a, 0x08, 0x48, 0x61, 0x73, 0x68, 0x52, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x69,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x4a, 0x0a, 0x0d, 0x76, 0x69, 0x72, 0x74, 0x75,
+	0x61, 0x6c, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x55, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x07, 0xfa, 0x42,
+	0x04, 0x2a, 0x02, 0x20, 0x00, 0x52, 0x0c, 0x76, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x4e, 0x6f,
+	0x64, 0x65, 0x73, 0x12, 0x4a, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x5f, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62, 0x6f,
+	0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x69, 0x6e, 0x67,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x48, 0x00,
+	0x52, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12,
+	0x5c, 0x0a, 0x14, 0x6b, 0x75, 0x62, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x65, 0x73, 0x5f, 0x65, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e,
+	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x69,
+	0x6e, 0x67, 0x2e, 0x4b, 0x75, 0x62, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x48, 0x00, 0x52, 0x13, 0x6b, 0x75, 0x62, 0x65, 0x72, 0x6e,
+	0x65, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x4e, 0x0a,
+	0x10, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00, 0x52, 0x0f, 0x72, 0x65,
+	0x66, 0x72, 0x65, 0x73, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x1a, 0x4f, 0x0a,
+	0x0d, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x3e,
+	0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x48, 0x61, 0x73, 0x68,
+	0x52, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x42, 0x08, 0xfa, 0x42, 0x05,
+	0x92, 0x01, 0x02, 0x08, 0x01, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x1a, 0x5f,
+	0x0a, 0x06, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x20, 0x01, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x3c, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x42, 0x08, 0xfa, 0x42,
+	0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x1a,
+	0xcd, 0x01, 0x0a, 0x13, 0x4b, 0x75, 0x62, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x65, 0x73, 0x45, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72,
+	0x02, 0x20, 0x01, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1b,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42,
+	0x04, 0x72, 0x02, 0x20, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70,
+	0x6f, 0x72, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x70, 0x69, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x70,
+	0x69, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x46, 0x69, 0x6c, 0x65, 0x42,
+	0x11, 0x0a, 0x0a, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x03, 0xf8,
+	0x42, 0x01, 0x22, 0xe3, 0x02, 0x0a, 0x0e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x3d, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00, 0x52, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x12, 0x47, 0x0a, 0x0c, 0x6b, 0x65, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x4b, 0x65, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x52, 0x0b, 0x6b, 0x65, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x12, 0x54, 0x0a,
+	0x13, 0x65, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00, 0x52,
+	0x12, 0x65, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x1a, 0x73, 0x0a, 0x0a, 0x4b, 0x65, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x12, 0x24, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x20, 0x01, 0x52, 0x08, 0x6b,
+	0x65, 0x79, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x3f, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0xaa, 0x01, 0x04, 0x08, 0x01, 0x2a, 0x00, 0x52,
+	0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x69, 0x0a, 0x0d, 0x4e, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x37, 0x0a, 0x03, 0x74, 0x74, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0xaa, 0x01, 0x04, 0x2a, 0x00, 0x08, 0x01, 0x52, 0x03, 0x74,
+	0x74, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x45, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x22, 0x61, 0x0a, 0x0a, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x43, 0x68, 0x61, 0x69,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x37, 0x0a,
+	0x08, 0x6d, 0x61, 0x78, 0x5f, 0x68, 0x6f, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x6d,
+	0x61, 0x78, 0x48, 0x6f, 0x70, 0x73, 0x22, 0xd3, 0x01, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x39, 0x0a, 0x08, 0x75, 0x70, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73,
+	0x74, 0x72, 0x61, 0x70, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x42, 0x08, 0xfa,
+	0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x08, 0x75, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x12, 0x3d, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa,
+	0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x12, 0x4a, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00, 0x52, 0x0d, 0x72,
+	0x65, 0x74, 0x72, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x99, 0x01, 0x0a,
+	0x12, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x46, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01,
+	0x02, 0x10, 0x01, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x79, 0x70, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
+	0x74, 0x79, 0x70, 0x65, 0x55, 0x72, 0x6c, 0x73, 0x22, 0x1e, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x10, 0x00, 0x12, 0x08,
+	0x0a, 0x04, 0x57, 0x41, 0x52, 0x4e, 0x10, 0x01, 0x22, 0xbf, 0x01, 0x0a, 0x07, 0x4c, 0x69, 0x6e,
+	0x74, 0x69, 0x6e, 0x67, 0x12, 0x3f, 0x0a, 0x06, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70,
+	0x2e, 0x4c, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x42, 0x0d,
+	0xfa, 0x42, 0x0a, 0x92, 0x01, 0x07, 0x22, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x06, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x5d, 0x0a, 0x05, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x12, 0x19, 0x0a, 0x15, 0x52, 0x4f, 0x55, 0x54, 0x45, 0x5f, 0x43, 0x4c,
+	0x55, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12,
+	0x1a, 0x0a, 0x16, 0x4c, 0x49, 0x53, 0x54, 0x45, 0x4e, 0x45, 0x52, 0x5f, 0x52, 0x4f, 0x55, 0x54,
+	0x45, 0x5f, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x43,
+	0x4c, 0x55, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x45, 0x4e, 0x44, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x53,
+	0x5f, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x22, 0x8e, 0x01, 0x0a, 0x12, 0x44,
+	0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x69, 0x6e,
+	0x67, 0x12, 0x3b, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42,
+	0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x3b,
+	0x0a, 0x06, 0x70, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01,
+	0x02, 0x32, 0x00, 0x52, 0x06, 0x70, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x22, 0x53, 0x0a, 0x07, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x48, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55,
+	0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x2a,
+	0x02, 0x20, 0x00, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0xc2, 0x02, 0x0a, 0x0b, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x41, 0x0a, 0x07, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x4d, 0x61,
+	0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0x92, 0x01, 0x02, 0x08, 0x01, 0x52, 0x07, 0x77, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x6b,
+	0x65, 0x79, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x65,
+	0x78, 0x65, 0x73, 0x12, 0x4e, 0x0a, 0x10, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02,
+	0x32, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x1a, 0x70, 0x0a, 0x06, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x23, 0x0a,
+	0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x20, 0x01, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x12, 0x41, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42,
+	0x0a, 0xfa, 0x42, 0x07, 0xaa, 0x01, 0x04, 0x08, 0x01, 0x2a, 0x00, 0x52, 0x08, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x96, 0x01, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x61, 0x74, 0x65, 0x47, 0x75, 0x61, 0x72, 0x64, 0x12, 0x2a, 0x0a, 0x0c, 0x6d, 0x61, 0x78,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x42,
+	0x07, 0xfa, 0x42, 0x04, 0x2a, 0x02, 0x20, 0x00, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x41, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0xaa, 0x01, 0x04, 0x08, 0x01, 0x2a, 0x00, 0x52, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x75, 0x73,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x70, 0x61, 0x75, 0x73, 0x65, 0x22, 0x72,
+	0x0a, 0x0c, 0x46, 0x61, 0x6e, 0x6f, 0x75, 0x74, 0x50, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x41,
+	0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0a, 0xfa, 0x42, 0x07,
+	0xaa, 0x01, 0x04, 0x08, 0x01, 0x2a, 0x00, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x22, 0x67, 0x0a, 0x05, 0x46, 0x65, 0x74, 0x63, 0x68, 0x12, 0x3d, 0x0a, 0x07, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a,
+	0x00, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x69,
+	0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0a, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x53, 0x68, 0x6f, 0x74, 0x22, 0xa8, 0x04, 0x0a, 0x07,
+	0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x64, 0x6f, 0x77, 0x6e, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6f,
+	0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e,
+	0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x52, 0x0a, 0x64, 0x6f, 0x77, 0x6e, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x12, 0x35, 0x0a, 0x08, 0x75, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70,
+	0x2e, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x52,
+	0x08, 0x75, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x3e, 0x0a, 0x09, 0x74, 0x79, 0x70,
+	0x65, 0x5f, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62,
+	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x73,
+	0x2e, 0x54, 0x79, 0x70, 0x65, 0x55, 0x52, 0x4c, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x73, 0x52,
+	0x08, 0x74, 0x79, 0x70, 0x65, 0x55, 0x72, 0x6c, 0x73, 0x1a, 0x72, 0x0a, 0x06, 0x42, 0x75, 0x66,
+	0x66, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x54, 0x0a, 0x0f, 0x6f, 0x76, 0x65, 0x72, 0x66,
+	0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x21, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x42, 0x75, 0x66,
+	0x66, 0x65, 0x72, 0x73, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0e, 0x6f,
+	0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x1a, 0xa6, 0x01,
+	0x0a, 0x0e, 0x54, 0x79, 0x70, 0x65, 0x55, 0x52, 0x4c, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x73,
+	0x12, 0x22, 0x0a, 0x08, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x20, 0x01, 0x52, 0x07, 0x74, 0x79, 0x70,
+	0x65, 0x55, 0x72, 0x6c, 0x12, 0x39, 0x0a, 0x0a, 0x64, 0x6f, 0x77, 0x6e, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73,
+	0x74, 0x72, 0x61, 0x70, 0x2e, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x42, 0x75, 0x66,
+	0x66, 0x65, 0x72, 0x52, 0x0a, 0x64, 0x6f, 0x77, 0x6e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
+	0x35, 0x0a, 0x08, 0x75, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x42, 0x75,
+	0x66, 0x66, 0x65, 0x72, 0x73, 0x2e, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x52, 0x08, 0x75, 0x70,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x22, 0x4e, 0x0a, 0x0e, 0x4f, 0x76, 0x65, 0x72, 0x66, 0x6c,
+	0x6f, 0x77, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41,
+	0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x01,
+	0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x52, 0x4f, 0x50, 0x5f, 0x4f, 0x4c, 0x44, 0x45, 0x53, 0x54, 0x10,
+	0x02, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4f, 0x41, 0x4c, 0x45, 0x53, 0x43, 0x45, 0x5f, 0x4c, 0x41,
+	0x54, 0x45, 0x53, 0x54, 0x10, 0x03, 0x22, 0xa9, 0x01, 0x0a, 0x0a, 0x4b, 0x65, 0x79, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x3a, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70,
+	0x2e, 0x4b, 0x65, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2e, 0x52, 0x75, 0x6c, 0x65,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0x92, 0x01, 0x02, 0x08, 0x01, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65,
+	0x73, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x4b, 0x65, 0x79, 0x73, 0x1a, 0x44, 0x0a, 0x04,
+	0x52, 0x75, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x5f, 0x72, 0x65, 0x67, 0x65,
+	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x20, 0x01,
+	0x52, 0x08, 0x6b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x22, 0x97, 0x03, 0x0a, 0x12, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x12, 0x4e, 0x0a, 0x09, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x62,
+	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x2e, 0x52, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x92, 0x01, 0x02, 0x08, 0x01, 0x52, 0x09,
+	0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0xb0, 0x02, 0x0a, 0x08, 0x52, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x1a, 0xfa, 0x42, 0x17, 0x72, 0x15, 0x32, 0x11, 0x5e, 0x5b, 0x41,
+	0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x5f, 0x2e, 0x2d, 0x5d, 0x2b, 0x24, 0x10, 0x01,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x12, 0x37, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x42, 0x17, 0xfa, 0x42, 0x14, 0x12, 0x12, 0x19, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x59, 0x40, 0x29, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x52, 0x0a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x5d, 0x0a, 0x0d,
+	0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x6f, 0x6c, 0x6c, 0x6f,
+	0x75, 0x74, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x6e,
+	0x6f, 0x64, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3f, 0x0a, 0x11, 0x4e,
+	0x6f, 0x64, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x51, 0x0a, 0x15,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x6d, 0x75, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22,
+	0x4b, 0x0a, 0x0d, 0x4c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67,
+	0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x6f, 0x64,
+	0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x42, 0x1a, 0x5a, 0x18,
+	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2f, 0x76, 0x31, 0x3b, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_bootstrap_v1_bootstrap_proto_rawDescOnce sync.Once
+	file_bootstrap_v1_bootstrap_proto_rawDescData = file_bootstrap_v1_bootstrap_proto_rawDesc
+)
+
+func file_bootstrap_v1_bootstrap_proto_rawDescGZIP() []byte {
+	file_bootstrap_v1_bootstrap_proto_rawDescOnce.Do(func() {
+		file_bootstrap_v1_bootstrap_proto_rawDescData = protoimpl.X.CompressGZIP(file_bootstrap_v1_bootstrap_proto_rawDescData)
+	})
+	return file_bootstrap_v1_bootstrap_proto_rawDescData
+}
+
+var file_bootstrap_v1_bootstrap_proto_enumTypes = make([]protoimpl.EnumInfo, 12)
+var file_bootstrap_v1_bootstrap_proto_msgTypes = make([]protoimpl.MessageInfo, 88)
+var file_bootstrap_v1_bootstrap_proto_goTypes = []interface{}{
+	(Listener_Service)(0),                         // 0: bootstrap.Listener.Service
+	(Upstream_ApiVersion)(0),                      // 1: bootstrap.Upstream.ApiVersion
+	(Upstream_Discovery_LoadBalancingPolicy)(0),   // 2: bootstrap.Upstream.Discovery.LoadBalancingPolicy
+	(Proxy_Type)(0),                               // 3: bootstrap.Proxy.Type
+	(Logging_Level)(0),                            // 4: bootstrap.Logging.Level
+	(Cache_EvictionPolicy)(0),                     // 5: bootstrap.Cache.EvictionPolicy
+	(Cache_EvictionStrategy)(0),                   // 6: bootstrap.Cache.EvictionStrategy
+	(VersionGuard_Comparator)(0),                  // 7: bootstrap.VersionGuard.Comparator
+	(ResponseLimit_Action)(0),                     // 8: bootstrap.ResponseLimit.Action
+	(ResponseValidation_Action)(0),                // 9: bootstrap.ResponseValidation.Action
+	(Linting_Check)(0),                            // 10: bootstrap.Linting.Check
+	(Buffers_OverflowPolicy)(0),                   // 11: bootstrap.Buffers.OverflowPolicy
+	(*Bootstrap)(nil),                             // 12: bootstrap.Bootstrap
+	(*Server)(nil),                                // 13: bootstrap.Server
+	(*Listener)(nil),                              // 14: bootstrap.Listener
+	(*TLS)(nil),                                   // 15: bootstrap.TLS
+	(*Interceptor)(nil),                           // 16: bootstrap.Interceptor
+	(*Admission)(nil),                             // 17: bootstrap.Admission
+	(*Upstream)(nil),                              // 18: bootstrap.Upstream
+	(*Proxy)(nil),                                 // 19: bootstrap.Proxy
+	(*Logging)(nil),                               // 20: bootstrap.Logging
+	(*Cache)(nil),                                 // 21: bootstrap.Cache
+	(*SocketAddress)(nil),                         // 22: bootstrap.SocketAddress
+	(*Admin)(nil),                                 // 23: bootstrap.Admin
+	(*MetricsSink)(nil),                           // 24: bootstrap.MetricsSink
+	(*Statsd)(nil),                                // 25: bootstrap.Statsd
+	(*VersionGuard)(nil),                          // 26: bootstrap.VersionGuard
+	(*Notifications)(nil),                         // 27: bootstrap.Notifications
+	(*Webhook)(nil),                               // 28: bootstrap.Webhook
+	(*LeaderElection)(nil),                        // 29: bootstrap.LeaderElection
+	(*KubernetesLease)(nil),                       // 30: bootstrap.KubernetesLease
+	(*Replication)(nil),                           // 31: bootstrap.Replication
+	(*ReplicationListener)(nil),                   // 32: bootstrap.ReplicationListener
+	(*ClientTLS)(nil),                             // 33: bootstrap.ClientTLS
+	(*DryRun)(nil),                                // 34: bootstrap.DryRun
+	(*DryRunSubscription)(nil),                    // 35: bootstrap.DryRunSubscription
+	(*Transformation)(nil),                        // 36: bootstrap.Transformation
+	(*StripFields)(nil),                           // 37: bootstrap.StripFields
+	(*SetFields)(nil),                             // 38: bootstrap.SetFields
+	(*GoPlugin)(nil),                              // 39: bootstrap.GoPlugin
+	(*OverrideFiles)(nil),                         // 40: bootstrap.OverrideFiles
+	(*StaticResponse)(nil),                        // 41: bootstrap.StaticResponse
+	(*Recording)(nil),                             // 42: bootstrap.Recording
+	(*Replay)(nil),                                // 43: bootstrap.Replay
+	(*Supervision)(nil),                           // 44: bootstrap.Supervision
+	(*DifferentialFanout)(nil),                    // 45: bootstrap.DifferentialFanout
+	(*ContentDeduplication)(nil),                  // 46: bootstrap.ContentDeduplication
+	(*FanoutScheduling)(nil),                      // 47: bootstrap.FanoutScheduling
+	(*TypePriority)(nil),                          // 48: bootstrap.TypePriority
+	(*KeyWeight)(nil),                             // 49: bootstrap.KeyWeight
+	(*AuditLog)(nil),                              // 50: bootstrap.AuditLog
+	(*AuditLogFile)(nil),                          // 51: bootstrap.AuditLogFile
+	(*AuditLogSyslog)(nil),                        // 52: bootstrap.AuditLogSyslog
+	(*SignatureVerification)(nil),                 // 53: bootstrap.SignatureVerification
+	(*ResponseLimit)(nil),                         // 54: bootstrap.ResponseLimit
+	(*Memory)(nil),                                // 55: bootstrap.Memory
+	(*ControlPlaneIdentifier)(nil),                // 56: bootstrap.ControlPlaneIdentifier
+	(*Rollout)(nil),                               // 57: bootstrap.Rollout
+	(*CircuitBreaker)(nil),                        // 58: bootstrap.CircuitBreaker
+	(*Alerting)(nil),                              // 59: bootstrap.Alerting
+	(*HashRing)(nil),                              // 60: bootstrap.HashRing
+	(*UpstreamHealth)(nil),                        // 61: bootstrap.UpstreamHealth
+	(*NegativeCache)(nil),                         // 62: bootstrap.NegativeCache
+	(*RelayChain)(nil),                            // 63: bootstrap.RelayChain
+	(*ParentRelay)(nil),                           // 64: bootstrap.ParentRelay
+	(*ResponseValidation)(nil),                    // 65: bootstrap.ResponseValidation
+	(*Linting)(nil),                               // 66: bootstrap.Linting
+	(*DependencyOrdering)(nil),                    // 67: bootstrap.DependencyOrdering
+	(*History)(nil),                               // 68: bootstrap.History
+	(*Maintenance)(nil),                           // 69: bootstrap.Maintenance
+	(*ChangeRateGuard)(nil),                       // 70: bootstrap.ChangeRateGuard
+	(*FanoutPacing)(nil),                          // 71: bootstrap.FanoutPacing
+	(*Fetch)(nil),                                 // 72: bootstrap.Fetch
+	(*Buffers)(nil),                               // 73: bootstrap.Buffers
+	(*KeyMetrics)(nil),                            // 74: bootstrap.KeyMetrics
+	(*AggregationRollout)(nil),                    // 75: bootstrap.AggregationRollout
+	(*AggregationSimulation)(nil),                 // 76: bootstrap.AggregationSimulation
+	(*LoadReporting)(nil),                         // 77: bootstrap.LoadReporting
+	(*Interceptor_Recovery)(nil),                  // 78: bootstrap.Interceptor.Recovery
+	(*Interceptor_RequestID)(nil),                 // 79: bootstrap.Interceptor.RequestID
+	(*Upstream_Metadata)(nil),                     // 80: bootstrap.Upstream.Metadata
+	(*Upstream_TokenFile)(nil),                    // 81: bootstrap.Upstream.TokenFile
+	(*Upstream_Credentials)(nil),                  // 82: bootstrap.Upstream.Credentials
+	(*Upstream_Discovery)(nil),                    // 83: bootstrap.Upstream.Discovery
+	(*Upstream_Credentials_ServiceAccount)(nil),   // 84: bootstrap.Upstream.Credentials.ServiceAccount
+	(*Upstream_Credentials_WorkloadIdentity)(nil), // 85: bootstrap.Upstream.Credentials.WorkloadIdentity
+	(*Upstream_Credentials_ExecPlugin)(nil),       // 86: bootstrap.Upstream.Credentials.ExecPlugin
+	nil,                                           // 87: bootstrap.Upstream.Credentials.ExecPlugin.EnvEntry
+	nil,                                           // 88: bootstrap.SetFields.ValuesEntry
+	nil,                                           // 89: bootstrap.Rollout.CanaryNodeMetadataEntry
+	(*HashRing_StaticMembers)(nil),                // 90: bootstrap.HashRing.StaticMembers
+	(*HashRing_Member)(nil),                       // 91: bootstrap.HashRing.Member
+	(*HashRing_KubernetesEndpoints)(nil),          // 92: bootstrap.HashRing.KubernetesEndpoints
+	(*UpstreamHealth_KeyTimeout)(nil),             // 93: bootstrap.UpstreamHealth.KeyTimeout
+	(*Maintenance_Window)(nil),                    // 94: bootstrap.Maintenance.Window
+	(*Buffers_Buffer)(nil),                        // 95: bootstrap.Buffers.Buffer
+	(*Buffers_TypeURLBuffers)(nil),                // 96: bootstrap.Buffers.TypeURLBuffers
+	(*KeyMetrics_Rule)(nil),                       // 97: bootstrap.KeyMetrics.Rule
+	(*AggregationRollout_Revision)(nil),           // 98: bootstrap.AggregationRollout.Revision
+	nil,                                           // 99: bootstrap.AggregationRollout.Revision.NodeMetadataEntry
+	(*duration.Duration)(nil),                     // 100: google.protobuf.Duration
+	(*wrappers.UInt32Value)(nil),                  // 101: google.protobuf.UInt32Value
+	(*wrappers.UInt64Value)(nil),                  // 102: google.protobuf.UInt64Value
+	(*wrappers.Int32Value)(nil),                   // 103: google.protobuf.Int32Value
+	(*_struct.Value)(nil),                         // 104: google.protobuf.Value
+}
+var file_bootstrap_v1_bootstrap_proto_depIdxs = []int32{
+	13,  // 0: bootstrap.Bootstrap.server:type_name -> bootstrap.Server
+	18,  // 1: bootstrap.Bootstrap.origin_server:type_name -> bootstrap.Upstream
+	20,  // 2: bootstrap.Bootstrap.logging:type_name -> bootstrap.Logging
+	21,  // 3: bootstrap.Bootstrap.cache:type_name -> bootstrap.Cache
+	24,  // 4: bootstrap.Bootstrap.metrics_sink:type_name -> bootstrap.MetricsSink
+	23,  // 5: bootstrap.Bootstrap.admin:type_name -> bootstrap.Admin
+	26,  // 6: bootstrap.Bootstrap.version_guard:type_name -> bootstrap.VersionGuard
+	27,  // 7: bootstrap.Bootstrap.notifications:type_name -> bootstrap.Notifications
+	29,  // 8: bootstrap.Bootstrap.leader_election:type_name -> bootstrap.LeaderElection
+	31,  // 9: bootstrap.Bootstrap.replication:type_name -> bootstrap.Replication
+	34,  // 10: bootstrap.Bootstrap.dry_run:type_name -> bootstrap.DryRun
+	18,  // 11: bootstrap.Bootstrap.shadow_server:type_name -> bootstrap.Upstream
+	36,  // 12: bootstrap.Bootstrap.transformations:type_name -> bootstrap.Transformation
+	40,  // 13: bootstrap.Bootstrap.override_files:type_name -> bootstrap.OverrideFiles
+	41,  // 14: bootstrap.Bootstrap.static_responses:type_name -> bootstrap.StaticResponse
+	42,  // 15: bootstrap.Bootstrap.recording:type_name -> bootstrap.Recording
+	43,  // 16: bootstrap.Bootstrap.replay:type_name -> bootstrap.Replay
+	44,  // 17: bootstrap.Bootstrap.supervision:type_name -> bootstrap.Supervision
+	47,  // 18: bootstrap.Bootstrap.fanout_scheduling:type_name -> bootstrap.FanoutScheduling
+	50,  // 19: bootstrap.Bootstrap.audit_log:type_name -> bootstrap.AuditLog
+	53,  // 20: bootstrap.Bootstrap.signature_verification:type_name -> bootstrap.SignatureVerification
+	54,  // 21: bootstrap.Bootstrap.response_limits:type_name -> bootstrap.ResponseLimit
+	55,  // 22: bootstrap.Bootstrap.memory:type_name -> bootstrap.Memory
+	56,  // 23: bootstrap.Bootstrap.control_plane_identifier:type_name -> bootstrap.ControlPlaneIdentifier
+	57,  // 24: bootstrap.Bootstrap.rollout:type_name -> bootstrap.Rollout
+	58,  // 25: bootstrap.Bootstrap.circuit_breaker:type_name -> bootstrap.CircuitBreaker
+	45,  // 26: bootstrap.Bootstrap.differential_fanout:type_name -> bootstrap.DifferentialFanout
+	46,  // 27: bootstrap.Bootstrap.content_deduplication:type_name -> bootstrap.ContentDeduplication
+	59,  // 28: bootstrap.Bootstrap.alerting:type_name -> bootstrap.Alerting
+	62,  // 29: bootstrap.Bootstrap.negative_cache:type_name -> bootstrap.NegativeCache
+	61,  // 30: bootstrap.Bootstrap.upstream_health:type_name -> bootstrap.UpstreamHealth
+	60,  // 31: bootstrap.Bootstrap.hash_ring:type_name -> bootstrap.HashRing
+	63,  // 32: bootstrap.Bootstrap.relay_chain:type_name -> bootstrap.RelayChain
+	64,  // 33: bootstrap.Bootstrap.parent_relay:type_name -> bootstrap.ParentRelay
+	65,  // 34: bootstrap.Bootstrap.response_validation:type_name -> bootstrap.ResponseValidation
+	66,  // 35: bootstrap.Bootstrap.linting:type_name -> bootstrap.Linting
+	67,  // 36: bootstrap.Bootstrap.dependency_ordering:type_name -> bootstrap.DependencyOrdering
+	68,  // 37: bootstrap.Bootstrap.history:type_name -> bootstrap.History
+	69,  // 38: bootstrap.Bootstrap.maintenance:type_name -> bootstrap.Maintenance
+	70,  // 39: bootstrap.Bootstrap.change_rate_guard:type_name -> bootstrap.ChangeRateGuard
+	71,  // 40: bootstrap.Bootstrap.fanout_pacing:type_name -> bootstrap.FanoutPacing
+	72,  // 41: bootstrap.Bootstrap.fetch:type_name -> bootstrap.Fetch
+	73,  // 42: bootstrap.Bootstrap.buffers:type_name -> bootstrap.Buffers
+	74,  // 43: bootstrap.Bootstrap.key_metrics:type_name -> bootstrap.KeyMetrics
+	75,  // 44: bootstrap.Bootstrap.aggregation_rollout:type_name -> bootstrap.AggregationRollout
+	76,  // 45: bootstrap.Bootstrap.aggregation_simulation:type_name -> bootstrap.AggregationSimulation
+	77,  // 46: bootstrap.Bootstrap.load_reporting:type_name -> bootstrap.LoadReporting
+	22,  // 47: bootstrap.Server.address:type_name -> bootstrap.SocketAddress
+	22,  // 48: bootstrap.Server.rest_address:type_name -> bootstrap.SocketAddress
+	100, // 49: bootstrap.Server.watch_idle_timeout:type_name -> google.protobuf.Duration
+	17,  // 50: bootstrap.Server.admission:type_name -> bootstrap.Admission
+	16,  // 51: bootstrap.Server.interceptors:type_name -> bootstrap.Interceptor
+	14,  // 52: bootstrap.Server.listeners:type_name -> bootstrap.Listener
+	22,  // 53: bootstrap.Listener.address:type_name -> bootstrap.SocketAddress
+	0,   // 54: bootstrap.Listener.services:type_name -> bootstrap.Listener.Service
+	15,  // 55: bootstrap.Listener.tls:type_name -> bootstrap.TLS
+	17,  // 56: bootstrap.Listener.admission:type_name -> bootstrap.Admission
+	78,  // 57: bootstrap.Interceptor.recovery:type_name -> bootstrap.Interceptor.Recovery
+	79,  // 58: bootstrap.Interceptor.request_id:type_name -> bootstrap.Interceptor.RequestID
+	100, // 59: bootstrap.Admission.max_retry_jitter:type_name -> google.protobuf.Duration
+	100, // 60: bootstrap.Admission.startup_duration:type_name -> google.protobuf.Duration
+	22,  // 61: bootstrap.Upstream.address:type_name -> bootstrap.SocketAddress
+	19,  // 62: bootstrap.Upstream.proxy:type_name -> bootstrap.Proxy
+	83,  // 63: bootstrap.Upstream.discovery:type_name -> bootstrap.Upstream.Discovery
+	80,  // 64: bootstrap.Upstream.metadata:type_name -> bootstrap.Upstream.Metadata
+	82,  // 65: bootstrap.Upstream.credentials:type_name -> bootstrap.Upstream.Credentials
+	1,   // 66: bootstrap.Upstream.api_version:type_name -> bootstrap.Upstream.ApiVersion
+	3,   // 67: bootstrap.Proxy.type:type_name -> bootstrap.Proxy.Type
+	22,  // 68: bootstrap.Proxy.address:type_name -> bootstrap.SocketAddress
+	4,   // 69: bootstrap.Logging.level:type_name -> bootstrap.Logging.Level
+	100, // 70: bootstrap.Cache.ttl:type_name -> google.protobuf.Duration
+	5,   // 71: bootstrap.Cache.eviction_policy:type_name -> bootstrap.Cache.EvictionPolicy
+	6,   // 72: bootstrap.Cache.eviction_strategy:type_name -> bootstrap.Cache.EvictionStrategy
+	22,  // 73: bootstrap.Admin.address:type_name -> bootstrap.SocketAddress
+	25,  // 74: bootstrap.MetricsSink.statsd:type_name -> bootstrap.Statsd
+	22,  // 75: bootstrap.Statsd.address:type_name -> bootstrap.SocketAddress
+	100, // 76: bootstrap.Statsd.flush_interval:type_name -> google.protobuf.Duration
+	7,   // 77: bootstrap.VersionGuard.comparator:type_name -> bootstrap.VersionGuard.Comparator
+	28,  // 78: bootstrap.Notifications.webhooks:type_name -> bootstrap.Webhook
+	100, // 79: bootstrap.Webhook.timeout:type_name -> google.protobuf.Duration
+	100, // 80: bootstrap.LeaderElection.lease_duration:type_name -> google.protobuf.Duration
+	100, // 81: bootstrap.LeaderElection.retry_period:type_name -> google.protobuf.Duration
+	30,  // 82: bootstrap.LeaderElection.kubernetes_lease:type_name -> bootstrap.KubernetesLease
+	22,  // 83: bootstrap.Replication.source:type_name -> bootstrap.SocketAddress
+	32,  // 84: bootstrap.Replication.listener:type_name -> bootstrap.ReplicationListener
+	33,  // 85: bootstrap.Replication.source_tls:type_name -> bootstrap.ClientTLS
+	22,  // 86: bootstrap.ReplicationListener.address:type_name -> bootstrap.SocketAddress
+	15,  // 87: bootstrap.ReplicationListener.tls:type_name -> bootstrap.TLS
+	35,  // 88: bootstrap.DryRun.subscriptions:type_name -> bootstrap.DryRunSubscription
+	37,  // 89: bootstrap.Transformation.strip_fields:type_name -> bootstrap.StripFields
+	38,  // 90: bootstrap.Transformation.set_fields:type_name -> bootstrap.SetFields
+	39,  // 91: bootstrap.Transformation.go_plugin:type_name -> bootstrap.GoPlugin
+	88,  // 92: bootstrap.SetFields.values:type_name -> bootstrap.SetFields.ValuesEntry
+	100, // 93: bootstrap.OverrideFiles.reload_interval:type_name -> google.protobuf.Duration
+	101, // 94: bootstrap.Supervision.max_restarts:type_name -> google.protobuf.UInt32Value
+	100, // 95: bootstrap.Supervision.restart_backoff:type_name -> google.protobuf.Duration
+	49,  // 96: bootstrap.FanoutScheduling.weights:type_name -> bootstrap.KeyWeight
+	48,  // 97: bootstrap.FanoutScheduling.type_priorities:type_name -> bootstrap.TypePriority
+	51,  // 98: bootstrap.AuditLog.file:type_name -> bootstrap.AuditLogFile
+	52,  // 99: bootstrap.AuditLog.syslog:type_name -> bootstrap.AuditLogSyslog
+	102, // 100: bootstrap.AuditLogFile.max_size_bytes:type_name -> google.protobuf.UInt64Value
+	101, // 101: bootstrap.AuditLogFile.max_backups:type_name -> google.protobuf.UInt32Value
+	8,   // 102: bootstrap.ResponseLimit.action:type_name -> bootstrap.ResponseLimit.Action
+	103, // 103: bootstrap.Memory.gc_percent:type_name -> google.protobuf.Int32Value
+	100, // 104: bootstrap.Memory.check_interval:type_name -> google.protobuf.Duration
+	89,  // 105: bootstrap.Rollout.canary_node_metadata:type_name -> bootstrap.Rollout.CanaryNodeMetadataEntry
+	100, // 106: bootstrap.Rollout.soak_duration:type_name -> google.protobuf.Duration
+	101, // 107: bootstrap.CircuitBreaker.failure_threshold:type_name -> google.protobuf.UInt32Value
+	100, // 108: bootstrap.CircuitBreaker.failure_window:type_name -> google.protobuf.Duration
+	100, // 109: bootstrap.CircuitBreaker.cool_down:type_name -> google.protobuf.Duration
+	100, // 110: bootstrap.Alerting.stale_after:type_name -> google.protobuf.Duration
+	100, // 111: bootstrap.Alerting.evaluation_interval:type_name -> google.protobuf.Duration
+	101, // 112: bootstrap.HashRing.virtual_nodes:type_name -> google.protobuf.UInt32Value
+	90,  // 113: bootstrap.HashRing.static_members:type_name -> bootstrap.HashRing.StaticMembers
+	92,  // 114: bootstrap.HashRing.kubernetes_endpoints:type_name -> bootstrap.HashRing.KubernetesEndpoints
+	100, // 115: bootstrap.HashRing.refresh_interval:type_name -> google.protobuf.Duration
+	100, // 116: bootstrap.UpstreamHealth.timeout:type_name -> google.protobuf.Duration
+	93,  // 117: bootstrap.UpstreamHealth.key_timeouts:type_name -> bootstrap.UpstreamHealth.KeyTimeout
+	100, // 118: bootstrap.UpstreamHealth.evaluation_interval:type_name -> google.protobuf.Duration
+	100, // 119: bootstrap.NegativeCache.ttl:type_name -> google.protobuf.Duration
+	101, // 120: bootstrap.RelayChain.max_hops:type_name -> google.protobuf.UInt32Value
+	18,  // 121: bootstrap.ParentRelay.upstream:type_name -> bootstrap.Upstream
+	100, // 122: bootstrap.ParentRelay.timeout:type_name -> google.protobuf.Duration
+	100, // 123: bootstrap.ParentRelay.retry_interval:type_name -> google.protobuf.Duration
+	9,   // 124: bootstrap.ResponseValidation.action:type_name -> bootstrap.ResponseValidation.Action
+	10,  // 125: bootstrap.Linting.checks:type_name -> bootstrap.Linting.Check
+	100, // 126: bootstrap.DependencyOrdering.window:type_name -> google.protobuf.Duration
+	100, // 127: bootstrap.DependencyOrdering.pacing:type_name -> google.protobuf.Duration
+	101, // 128: bootstrap.History.max_versions:type_name -> google.protobuf.UInt32Value
+	94,  // 129: bootstrap.Maintenance.windows:type_name -> bootstrap.Maintenance.Window
+	100, // 130: bootstrap.Maintenance.release_interval:type_name -> google.protobuf.Duration
+	100, // 131: bootstrap.ChangeRateGuard.interval:type_name -> google.protobuf.Duration
+	100, // 132: bootstrap.FanoutPacing.duration:type_name -> google.protobuf.Duration
+	100, // 133: bootstrap.Fetch.timeout:type_name -> google.protobuf.Duration
+	95,  // 134: bootstrap.Buffers.downstream:type_name -> bootstrap.Buffers.Buffer
+	95,  // 135: bootstrap.Buffers.upstream:type_name -> bootstrap.Buffers.Buffer
+	96,  // 136: bootstrap.Buffers.type_urls:type_name -> bootstrap.Buffers.TypeURLBuffers
+	97,  // 137: bootstrap.KeyMetrics.rules:type_name -> bootstrap.KeyMetrics.Rule
+	98,  // 138: bootstrap.AggregationRollout.revisions:type_name -> bootstrap.AggregationRollout.Revision
+	81,  // 139: bootstrap.Upstream.Metadata.token_file:type_name -> bootstrap.Upstream.TokenFile
+	100, // 140: bootstrap.Upstream.TokenFile.refresh_interval:type_name -> google.protobuf.Duration
+	84,  // 141: bootstrap.Upstream.Credentials.service_account:type_name -> bootstrap.Upstream.Credentials.ServiceAccount
+	85,  // 142: bootstrap.Upstream.Credentials.workload_identity:type_name -> bootstrap.Upstream.Credentials.WorkloadIdentity
+	86,  // 143: bootstrap.Upstream.Credentials.exec:type_name -> bootstrap.Upstream.Credentials.ExecPlugin
+	2,   // 144: bootstrap.Upstream.Discovery.load_balancing_policy:type_name -> bootstrap.Upstream.Discovery.LoadBalancingPolicy
+	100, // 145: bootstrap.Upstream.Discovery.refresh_interval:type_name -> google.protobuf.Duration
+	87,  // 146: bootstrap.Upstream.Credentials.ExecPlugin.env:type_name -> bootstrap.Upstream.Credentials.ExecPlugin.EnvEntry
+	104, // 147: bootstrap.SetFields.ValuesEntry.value:type_name -> google.protobuf.Value
+	91,  // 148: bootstrap.HashRing.StaticMembers.members:type_name -> bootstrap.HashRing.Member
+	22,  // 149: bootstrap.HashRing.Member.address:type_name -> bootstrap.SocketAddress
+	100, // 150: bootstrap.UpstreamHealth.KeyTimeout.timeout:type_name -> google.protobuf.Duration
+	100, // 151: bootstrap.Maintenance.Window.duration:type_name -> google.protobuf.Duration
+	11,  // 152: bootstrap.Buffers.Buffer.overflow_policy:type_name -> bootstrap.Buffers.OverflowPolicy
+	95,  // 153: bootstrap.Buffers.TypeURLBuffers.downstream:type_name -> bootstrap.Buffers.Buffer
+	95,  // 154: bootstrap.Buffers.TypeURLBuffers.upstream:type_name -> bootstrap.Buffers.Buffer
+	99,  // 155: bootstrap.AggregationRollout.Revision.node_metadata:type_name -> bootstrap.AggregationRollout.Revision.NodeMetadataEntry
+	156, // [156:156] is the sub-list for method output_type
+	156, // [156:156] is the sub-list for method input_type
+	156, // [156:156] is the sub-list for extension type_name
+	156, // [156:156] is the sub-list for extension extendee
+	0,   // [0:156] is the sub-list for field type_name
+}
+
+func init() { file_bootstrap_v1_bootstrap_proto_init() }
+func file_bootstrap_v1_bootstrap_proto_init() {
+	if File_bootstrap_v1_bootstrap_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_bootstrap_v1_bootstrap_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Bootstrap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Server); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Listener); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TLS); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Interceptor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Admission); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upstream); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Proxy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Logging); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cache); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SocketAddress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Admin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MetricsSink); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Statsd); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionGuard); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Notifications); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Webhook); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaderElection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KubernetesLease); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Replication); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicationListener); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientTLS); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DryRun); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DryRunSubscription); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Transformation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StripFields); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFields); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GoPlugin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OverrideFiles); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StaticResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Recording); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Replay); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Supervision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DifferentialFanout); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContentDeduplication); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FanoutScheduling); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TypePriority); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyWeight); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditLogFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditLogSyslog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignatureVerification); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseLimit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Memory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ControlPlaneIdentifier); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rollout); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CircuitBreaker); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Alerting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HashRing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpstreamHealth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NegativeCache); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RelayChain); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParentRelay); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseValidation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Linting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DependencyOrdering); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*History); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Maintenance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeRateGuard); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FanoutPacing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Fetch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Buffers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregationRollout); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregationSimulation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadReporting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Interceptor_Recovery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Interceptor_RequestID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upstream_Metadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upstream_TokenFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upstream_Credentials); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upstream_Discovery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upstream_Credentials_ServiceAccount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bootstrap_v1_bootstrap_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upstream_Credentials_WorkloadIdentity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use MetricsSink.ProtoReflect.Descriptor instead.
-func (*MetricsSink) Descriptor() ([]byte, []int) {
-	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{7}
-}
-
-func (m *MetricsSink) GetType() isMetricsSink_Type {
-	if m != nil {
-		return m.Type
-	}
-	return nil
-}
-
-func (x *MetricsSink) GetStatsd() *Statsd {
-	if x, ok := x.GetType().(*MetricsSink_Statsd); ok {
-		return x.Statsd
-	}
-	return nil
-}
-
-type isMetricsSink_Type interface {
-	isMetricsSink_Type()
-}
-
-type MetricsSink_Statsd struct {
-	Statsd *Statsd `protobuf:"bytes,1,opt,name=statsd,proto3,oneof"`
-}
-
-func (*MetricsSink_Statsd) isMetricsSink_Type() {}
-
-// [#next-free-field: 4]
-type Statsd struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Address       *SocketAddress     `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	RootPrefix    string             `protobuf:"bytes,2,opt,name=root_prefix,json=rootPrefix,proto3" json:"root_prefix,omitempty"`
-	FlushInterval *duration.Duration `protobuf:"bytes,3,opt,name=flush_interval,json=flushInterval,proto3" json:"flush_interval,omitempty"`
-}
-
-func (x *Statsd) Reset() {
-	*x = Statsd{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[8]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *Statsd) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Statsd) ProtoMessage() {}
-
-func (x *Statsd) ProtoReflect() protoreflect.Message {
-	mi := &file_bootstrap_v1_bootstrap_proto_msgTypes[8]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
+		file_bootstrap_v1_bootstrap_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upstream_Credentials_ExecPlugin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Statsd.ProtoReflect.Descriptor instead.
-func (*Statsd) Descriptor() ([]byte, []int) {
-	return file_bootstrap_v1_bootstrap_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *Statsd) GetAddress() *SocketAddress {
-	if x != nil {
-		return x.Address
-	}
-	return nil
-}
-
-func (x *Statsd) GetRootPrefix() string {
-	if x != nil {
-		return x.RootPrefix
-	}
-	return ""
-}
-
-func (x *Statsd) GetFlushInterval() *duration.Duration {
-	if x != nil {
-		return x.FlushInterval
-	}
-	return nil
-}
-
-var File_bootstrap_v1_bootstrap_proto protoreflect.FileDescriptor
-
-var file_bootstrap_v1_bootstrap_proto_rawDesc = []byte{
-	0x0a, 0x1c, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2f, 0x76, 0x31, 0x2f, 0x62,
-	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09,
-	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70,
-	0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64,
-	0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x22, 0xe5, 0x02, 0x0a, 0x09, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70,
-	0x12, 0x33, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x11, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x06, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x42, 0x0a, 0x0d, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62,
-	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0c, 0x6f, 0x72, 0x69,
-	0x67, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x07, 0x6c, 0x6f, 0x67,
-	0x67, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62, 0x6f, 0x6f,
-	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x42, 0x08,
-	0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x6c, 0x6f, 0x67, 0x67, 0x69, 0x6e,
-	0x67, 0x12, 0x30, 0x0a, 0x05, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x10, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x43, 0x61, 0x63,
-	0x68, 0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x05, 0x63, 0x61,
-	0x63, 0x68, 0x65, 0x12, 0x43, 0x0a, 0x0c, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x73,
-	0x69, 0x6e, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
-	0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x53, 0x69, 0x6e,
-	0x6b, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0b, 0x6d, 0x65, 0x74,
-	0x72, 0x69, 0x63, 0x73, 0x53, 0x69, 0x6e, 0x6b, 0x12, 0x30, 0x0a, 0x05, 0x61, 0x64, 0x6d, 0x69,
-	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74,
-	0x72, 0x61, 0x70, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01,
-	0x02, 0x10, 0x01, 0x52, 0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x22, 0x46, 0x0a, 0x06, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x12, 0x3c, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61,
-	0x70, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x42,
-	0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x22, 0x48, 0x0a, 0x08, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x3c,
-	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53, 0x6f, 0x63, 0x6b,
-	0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01,
-	0x02, 0x10, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x8a, 0x01, 0x0a,
-	0x07, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x38, 0x0a, 0x05,
-	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x62, 0x6f,
-	0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x2e,
-	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52,
-	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x31, 0x0a, 0x05, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12,
-	0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x45, 0x42,
-	0x55, 0x47, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x57, 0x41, 0x52, 0x4e, 0x10, 0x02, 0x12, 0x09,
-	0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x22, 0x61, 0x0a, 0x05, 0x43, 0x61, 0x63,
-	0x68, 0x65, 0x12, 0x37, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0xaa,
-	0x01, 0x04, 0x08, 0x01, 0x32, 0x00, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x6d,
-	0x61, 0x78, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0a, 0x6d, 0x61, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x5d, 0x0a, 0x0d,
-	0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x22, 0x0a,
-	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08,
-	0xfa, 0x42, 0x05, 0x72, 0x03, 0xa8, 0x01, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x12, 0x28, 0x0a, 0x0a, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x09, 0xfa, 0x42, 0x06, 0x2a, 0x04, 0x18, 0xff, 0xff, 0x03,
-	0x52, 0x09, 0x70, 0x6f, 0x72, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x45, 0x0a, 0x05, 0x41,
-	0x64, 0x6d, 0x69, 0x6e, 0x12, 0x3c, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61,
-	0x70, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x42,
-	0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x22, 0x47, 0x0a, 0x0b, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x53, 0x69, 0x6e,
-	0x6b, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x73, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x11, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x53, 0x74,
-	0x61, 0x74, 0x73, 0x64, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x73, 0x64, 0x42, 0x0b,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x22, 0xbe, 0x01, 0x0a, 0x06,
-	0x53, 0x74, 0x61, 0x74, 0x73, 0x64, 0x12, 0x3c, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74,
-	0x72, 0x61, 0x70, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x12, 0x28, 0x0a, 0x0b, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x65,
-	0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02,
-	0x20, 0x01, 0x52, 0x0a, 0x72, 0x6f, 0x6f, 0x74, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x4c,
-	0x0a, 0x0e, 0x66, 0x6c, 0x75, 0x73, 0x68, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0xaa, 0x01, 0x04, 0x08, 0x01, 0x32, 0x00, 0x52, 0x0d, 0x66,
-	0x6c, 0x75, 0x73, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x42, 0x1a, 0x5a, 0x18,
-	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x2f, 0x76, 0x31, 0x3b, 0x62, 0x6f, 0x6f,
-	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_bootstrap_v1_bootstrap_proto_rawDescOnce sync.Once
-	file_bootstrap_v1_bootstrap_proto_rawDescData = file_bootstrap_v1_bootstrap_proto_rawDesc
-)
-
-func file_bootstrap_v1_bootstrap_proto_rawDescGZIP() []byte {
-	file_bootstrap_v1_bootstrap_proto_rawDescOnce.Do(func() {
-		file_bootstrap_v1_bootstrap_proto_rawDescData = protoimpl.X.CompressGZIP(file_bootstrap_v1_bootstrap_proto_rawDescData)
-	})
-	return file_bootstrap_v1_bootstrap_proto_rawDescData
-}
-
-var file_bootstrap_v1_bootstrap_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_bootstrap_v1_bootstrap_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
-var file_bootstrap_v1_bootstrap_proto_goTypes = []interface{}{
-	(Logging_Level)(0),        // 0: bootstrap.Logging.Level
-	(*Bootstrap)(nil),         // 1: bootstrap.Bootstrap
-	(*Server)(nil),            // 2: bootstrap.Server
-	(*Upstream)(nil),          // 3: bootstrap.Upstream
-	(*Logging)(nil),           // 4: bootstrap.Logging
-	(*Cache)(nil),             // 5: bootstrap.Cache
-	(*SocketAddress)(nil),     // 6: bootstrap.SocketAddress
-	(*Admin)(nil),             // 7: bootstrap.Admin
-	(*MetricsSink)(nil),       // 8: bootstrap.MetricsSink
-	(*Statsd)(nil),            // 9: bootstrap.Statsd
-	(*duration.Duration)(nil), // 10: google.protobuf.Duration
-}
-var file_bootstrap_v1_bootstrap_proto_depIdxs = []int32{
-	2,  // 0: bootstrap.Bootstrap.server:type_name -> bootstrap.Server
-	3,  // 1: bootstrap.Bootstrap.origin_server:type_name -> bootstrap.Upstream
-	4,  // 2: bootstrap.Bootstrap.logging:type_name -> bootstrap.Logging
-	5,  // 3: bootstrap.Bootstrap.cache:type_name -> bootstrap.Cache
-	8,  // 4: bootstrap.Bootstrap.metrics_sink:type_name -> bootstrap.MetricsSink
-	7,  // 5: bootstrap.Bootstrap.admin:type_name -> bootstrap.Admin
-	6,  // 6: bootstrap.Server.address:type_name -> bootstrap.SocketAddress
-	6,  // 7: bootstrap.Upstream.address:type_name -> bootstrap.SocketAddress
-	0,  // 8: bootstrap.Logging.level:type_name -> bootstrap.Logging.Level
-	10, // 9: bootstrap.Cache.ttl:type_name -> google.protobuf.Duration
-	6,  // 10: bootstrap.Admin.address:type_name -> bootstrap.SocketAddress
-	9,  // 11: bootstrap.MetricsSink.statsd:type_name -> bootstrap.Statsd
-	6,  // 12: bootstrap.Statsd.address:type_name -> bootstrap.SocketAddress
-	10, // 13: bootstrap.Statsd.flush_interval:type_name -> google.protobuf.Duration
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
-}
-
-func init() { file_bootstrap_v1_bootstrap_proto_init() }
-func file_bootstrap_v1_bootstrap_proto_init() {
-	if File_bootstrap_v1_bootstrap_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_bootstrap_v1_bootstrap_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Bootstrap); i {
+		file_bootstrap_v1_bootstrap_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HashRing_StaticMembers); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -781,8 +9158,8 @@ func file_bootstrap_v1_bootstrap_proto_init() {
 				return nil
 			}
 		}
-		file_bootstrap_v1_bootstrap_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Server); i {
+		file_bootstrap_v1_bootstrap_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HashRing_Member); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -793,8 +9170,8 @@ func file_bootstrap_v1_bootstrap_proto_init() {
 				return nil
 			}
 		}
-		file_bootstrap_v1_bootstrap_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Upstream); i {
+		file_bootstrap_v1_bootstrap_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HashRing_KubernetesEndpoints); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -805,8 +9182,8 @@ func file_bootstrap_v1_bootstrap_proto_init() {
 				return nil
 			}
 		}
-		file_bootstrap_v1_bootstrap_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Logging); i {
+		file_bootstrap_v1_bootstrap_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpstreamHealth_KeyTimeout); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -817,8 +9194,8 @@ func file_bootstrap_v1_bootstrap_proto_init() {
 				return nil
 			}
 		}
-		file_bootstrap_v1_bootstrap_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Cache); i {
+		file_bootstrap_v1_bootstrap_proto_msgTypes[82].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Maintenance_Window); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -829,8 +9206,8 @@ func file_bootstrap_v1_bootstrap_proto_init() {
 				return nil
 			}
 		}
-		file_bootstrap_v1_bootstrap_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SocketAddress); i {
+		file_bootstrap_v1_bootstrap_proto_msgTypes[83].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Buffers_Buffer); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -841,8 +9218,8 @@ func file_bootstrap_v1_bootstrap_proto_init() {
 				return nil
 			}
 		}
-		file_bootstrap_v1_bootstrap_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Admin); i {
+		file_bootstrap_v1_bootstrap_proto_msgTypes[84].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Buffers_TypeURLBuffers); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -853,8 +9230,8 @@ func file_bootstrap_v1_bootstrap_proto_init() {
 				return nil
 			}
 		}
-		file_bootstrap_v1_bootstrap_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MetricsSink); i {
+		file_bootstrap_v1_bootstrap_proto_msgTypes[85].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyMetrics_Rule); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -865,8 +9242,8 @@ func file_bootstrap_v1_bootstrap_proto_init() {
 				return nil
 			}
 		}
-		file_bootstrap_v1_bootstrap_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Statsd); i {
+		file_bootstrap_v1_bootstrap_proto_msgTypes[86].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregationRollout_Revision); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -878,16 +9255,50 @@ func file_bootstrap_v1_bootstrap_proto_init() {
 			}
 		}
 	}
-	file_bootstrap_v1_bootstrap_proto_msgTypes[7].OneofWrappers = []interface{}{
+	file_bootstrap_v1_bootstrap_proto_msgTypes[4].OneofWrappers = []interface{}{
+		(*Interceptor_Recovery_)(nil),
+		(*Interceptor_RequestId)(nil),
+	}
+	file_bootstrap_v1_bootstrap_proto_msgTypes[12].OneofWrappers = []interface{}{
 		(*MetricsSink_Statsd)(nil),
 	}
+	file_bootstrap_v1_bootstrap_proto_msgTypes[17].OneofWrappers = []interface{}{
+		(*LeaderElection_KubernetesLease)(nil),
+	}
+	file_bootstrap_v1_bootstrap_proto_msgTypes[24].OneofWrappers = []interface{}{
+		(*Transformation_StripFields)(nil),
+		(*Transformation_SetFields)(nil),
+		(*Transformation_GoPlugin)(nil),
+	}
+	file_bootstrap_v1_bootstrap_proto_msgTypes[29].OneofWrappers = []interface{}{
+		(*StaticResponse_Key)(nil),
+		(*StaticResponse_TypeUrl)(nil),
+	}
+	file_bootstrap_v1_bootstrap_proto_msgTypes[38].OneofWrappers = []interface{}{
+		(*AuditLog_File)(nil),
+		(*AuditLog_Syslog)(nil),
+	}
+	file_bootstrap_v1_bootstrap_proto_msgTypes[48].OneofWrappers = []interface{}{
+		(*HashRing_StaticMembers_)(nil),
+		(*HashRing_KubernetesEndpoints_)(nil),
+	}
+	file_bootstrap_v1_bootstrap_proto_msgTypes[68].OneofWrappers = []interface{}{
+		(*Upstream_Metadata_Value)(nil),
+		(*Upstream_Metadata_Template)(nil),
+		(*Upstream_Metadata_TokenFile)(nil),
+	}
+	file_bootstrap_v1_bootstrap_proto_msgTypes[70].OneofWrappers = []interface{}{
+		(*Upstream_Credentials_ServiceAccount_)(nil),
+		(*Upstream_Credentials_WorkloadIdentity_)(nil),
+		(*Upstream_Credentials_Exec)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_bootstrap_v1_bootstrap_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   9,
+			NumEnums:      12,
+			NumMessages:   88,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
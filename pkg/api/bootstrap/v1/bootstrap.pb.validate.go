@@ -145,12 +145,7128 @@ func (m *Bootstrap) Validate() error {
 		}
 	}
 
+	if v, ok := interface{}(m.GetVersionGuard()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "VersionGuard",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetNotifications()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Notifications",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetLeaderElection()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "LeaderElection",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetReplication()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Replication",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetDryRun()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "DryRun",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetShadowServer()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "ShadowServer",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetTransformations() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BootstrapValidationError{
+					field:  fmt.Sprintf("Transformations[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if v, ok := interface{}(m.GetOverrideFiles()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "OverrideFiles",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetStaticResponses() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BootstrapValidationError{
+					field:  fmt.Sprintf("StaticResponses[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if v, ok := interface{}(m.GetRecording()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Recording",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetReplay()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Replay",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetSupervision()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Supervision",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetFanoutScheduling()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "FanoutScheduling",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetAuditLog()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "AuditLog",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetSignatureVerification()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "SignatureVerification",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetResponseLimits() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BootstrapValidationError{
+					field:  fmt.Sprintf("ResponseLimits[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if v, ok := interface{}(m.GetMemory()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Memory",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetControlPlaneIdentifier()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "ControlPlaneIdentifier",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetRollout()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Rollout",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetCircuitBreaker()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "CircuitBreaker",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetDifferentialFanout()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "DifferentialFanout",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetContentDeduplication()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "ContentDeduplication",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetAlerting()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Alerting",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetNegativeCache()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "NegativeCache",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetUpstreamHealth()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "UpstreamHealth",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetHashRing()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "HashRing",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetRelayChain()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "RelayChain",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetParentRelay()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "ParentRelay",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetResponseValidation()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "ResponseValidation",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetLinting()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Linting",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetDependencyOrdering()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "DependencyOrdering",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetHistory()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "History",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetMaintenance()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Maintenance",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetChangeRateGuard()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "ChangeRateGuard",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetFanoutPacing()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "FanoutPacing",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetFetch()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Fetch",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetBuffers()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Buffers",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetKeyMetrics()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "KeyMetrics",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetAggregationRollout()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "AggregationRollout",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetAggregationSimulation()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "AggregationSimulation",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetLoadReporting()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "LoadReporting",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// BootstrapValidationError is the validation error returned by
+// Bootstrap.Validate if the designated constraints aren't met.
+type BootstrapValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BootstrapValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BootstrapValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BootstrapValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BootstrapValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BootstrapValidationError) ErrorName() string { return "BootstrapValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BootstrapValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBootstrap.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BootstrapValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BootstrapValidationError{}
+
+// Validate checks the field values on Server with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Server) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetAddress() == nil {
+		return ServerValidationError{
+			field:  "Address",
+			reason: "value is required",
+		}
+	}
+
+	if v, ok := interface{}(m.GetAddress()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServerValidationError{
+				field:  "Address",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetRestAddress()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServerValidationError{
+				field:  "RestAddress",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if d := m.GetWatchIdleTimeout(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return ServerValidationError{
+				field:  "WatchIdleTimeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur < gte {
+			return ServerValidationError{
+				field:  "WatchIdleTimeout",
+				reason: "value must be greater than or equal to 0s",
+			}
+		}
+
+	}
+
+	// no validation rules for StatusService
+
+	if v, ok := interface{}(m.GetAdmission()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServerValidationError{
+				field:  "Admission",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetInterceptors() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ServerValidationError{
+					field:  fmt.Sprintf("Interceptors[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for ResumptionTokens
+
+	for idx, item := range m.GetListeners() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ServerValidationError{
+					field:  fmt.Sprintf("Listeners[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for V3Services
+
+	// no validation rules for VhdsService
+
+	return nil
+}
+
+// ServerValidationError is the validation error returned by Server.Validate if
+// the designated constraints aren't met.
+type ServerValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ServerValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ServerValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ServerValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ServerValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ServerValidationError) ErrorName() string { return "ServerValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ServerValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ServerValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ServerValidationError{}
+
+// Validate checks the field values on Listener with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Listener) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetName()) < 1 {
+		return ListenerValidationError{
+			field:  "Name",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	if m.GetAddress() == nil {
+		return ListenerValidationError{
+			field:  "Address",
+			reason: "value is required",
+		}
+	}
+
+	if v, ok := interface{}(m.GetAddress()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListenerValidationError{
+				field:  "Address",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(m.GetServices()) < 1 {
+		return ListenerValidationError{
+			field:  "Services",
+			reason: "value must contain at least 1 item(s)",
+		}
+	}
+
+	for idx, item := range m.GetServices() {
+		_, _ = idx, item
+
+		if _, ok := Listener_Service_name[int32(item)]; !ok {
+			return ListenerValidationError{
+				field:  fmt.Sprintf("Services[%v]", idx),
+				reason: "value must be one of the defined enum values",
+			}
+		}
+
+	}
+
+	if v, ok := interface{}(m.GetTls()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListenerValidationError{
+				field:  "Tls",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetAdmission()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListenerValidationError{
+				field:  "Admission",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// ListenerValidationError is the validation error returned by
+// Listener.Validate if the designated constraints aren't met.
+type ListenerValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListenerValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListenerValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListenerValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListenerValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListenerValidationError) ErrorName() string { return "ListenerValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListenerValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListener.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListenerValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListenerValidationError{}
+
+// Validate checks the field values on TLS with the rules defined in the proto
+// definition for this message. If any rules are violated, an error is returned.
+func (m *TLS) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetCertFile()) < 1 {
+		return TLSValidationError{
+			field:  "CertFile",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	if utf8.RuneCountInString(m.GetKeyFile()) < 1 {
+		return TLSValidationError{
+			field:  "KeyFile",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	// no validation rules for ClientCaFile
+
+	return nil
+}
+
+// TLSValidationError is the validation error returned by TLS.Validate if the
+// designated constraints aren't met.
+type TLSValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TLSValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TLSValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TLSValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TLSValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TLSValidationError) ErrorName() string { return "TLSValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TLSValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTLS.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TLSValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TLSValidationError{}
+
+// Validate checks the field values on Interceptor with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *Interceptor) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	switch m.Type.(type) {
+
+	case *Interceptor_Recovery_:
+
+		if v, ok := interface{}(m.GetRecovery()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return InterceptorValidationError{
+					field:  "Recovery",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Interceptor_RequestId:
+
+		if v, ok := interface{}(m.GetRequestId()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return InterceptorValidationError{
+					field:  "RequestId",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		return InterceptorValidationError{
+			field:  "Type",
+			reason: "value is required",
+		}
+
+	}
+
+	return nil
+}
+
+// InterceptorValidationError is the validation error returned by
+// Interceptor.Validate if the designated constraints aren't met.
+type InterceptorValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e InterceptorValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e InterceptorValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e InterceptorValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e InterceptorValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e InterceptorValidationError) ErrorName() string { return "InterceptorValidationError" }
+
+// Error satisfies the builtin error interface
+func (e InterceptorValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sInterceptor.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = InterceptorValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = InterceptorValidationError{}
+
+// Validate checks the field values on Admission with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Admission) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetStreamsPerSecond() <= 0 {
+		return AdmissionValidationError{
+			field:  "StreamsPerSecond",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	// no validation rules for Burst
+
+	if d := m.GetMaxRetryJitter(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return AdmissionValidationError{
+				field:  "MaxRetryJitter",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur < gte {
+			return AdmissionValidationError{
+				field:  "MaxRetryJitter",
+				reason: "value must be greater than or equal to 0s",
+			}
+		}
+
+	}
+
+	if d := m.GetStartupDuration(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return AdmissionValidationError{
+				field:  "StartupDuration",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur < gte {
+			return AdmissionValidationError{
+				field:  "StartupDuration",
+				reason: "value must be greater than or equal to 0s",
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// AdmissionValidationError is the validation error returned by
+// Admission.Validate if the designated constraints aren't met.
+type AdmissionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AdmissionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AdmissionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AdmissionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AdmissionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AdmissionValidationError) ErrorName() string { return "AdmissionValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AdmissionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAdmission.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AdmissionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AdmissionValidationError{}
+
+// Validate checks the field values on Upstream with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Upstream) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetAddress() == nil {
+		return UpstreamValidationError{
+			field:  "Address",
+			reason: "value is required",
+		}
+	}
+
+	if v, ok := interface{}(m.GetAddress()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpstreamValidationError{
+				field:  "Address",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetProxy()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpstreamValidationError{
+				field:  "Proxy",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetDiscovery()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpstreamValidationError{
+				field:  "Discovery",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetMetadata() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UpstreamValidationError{
+					field:  fmt.Sprintf("Metadata[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if v, ok := interface{}(m.GetCredentials()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpstreamValidationError{
+				field:  "Credentials",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if _, ok := Upstream_ApiVersion_name[int32(m.GetApiVersion())]; !ok {
+		return UpstreamValidationError{
+			field:  "ApiVersion",
+			reason: "value must be one of the defined enum values",
+		}
+	}
+
+	return nil
+}
+
+// UpstreamValidationError is the validation error returned by
+// Upstream.Validate if the designated constraints aren't met.
+type UpstreamValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpstreamValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpstreamValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpstreamValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpstreamValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpstreamValidationError) ErrorName() string { return "UpstreamValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UpstreamValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpstream.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpstreamValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpstreamValidationError{}
+
+// Validate checks the field values on Proxy with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Proxy) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if _, ok := Proxy_Type_name[int32(m.GetType())]; !ok {
+		return ProxyValidationError{
+			field:  "Type",
+			reason: "value must be one of the defined enum values",
+		}
+	}
+
+	if m.GetAddress() == nil {
+		return ProxyValidationError{
+			field:  "Address",
+			reason: "value is required",
+		}
+	}
+
+	if v, ok := interface{}(m.GetAddress()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ProxyValidationError{
+				field:  "Address",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Username
+
+	// no validation rules for PasswordFile
+
+	return nil
+}
+
+// ProxyValidationError is the validation error returned by Proxy.Validate if
+// the designated constraints aren't met.
+type ProxyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProxyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProxyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProxyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProxyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProxyValidationError) ErrorName() string { return "ProxyValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ProxyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProxy.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProxyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProxyValidationError{}
+
+// Validate checks the field values on Logging with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Logging) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Path
+
+	if _, ok := Logging_Level_name[int32(m.GetLevel())]; !ok {
+		return LoggingValidationError{
+			field:  "Level",
+			reason: "value must be one of the defined enum values",
+		}
+	}
+
+	// no validation rules for KeyEventsSize
+
+	return nil
+}
+
+// LoggingValidationError is the validation error returned by Logging.Validate
+// if the designated constraints aren't met.
+type LoggingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LoggingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LoggingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LoggingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LoggingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LoggingValidationError) ErrorName() string { return "LoggingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LoggingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogging.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LoggingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LoggingValidationError{}
+
+// Validate checks the field values on Cache with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Cache) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetTtl() == nil {
+		return CacheValidationError{
+			field:  "Ttl",
+			reason: "value is required",
+		}
+	}
+
+	if d := m.GetTtl(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return CacheValidationError{
+				field:  "Ttl",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur < gte {
+			return CacheValidationError{
+				field:  "Ttl",
+				reason: "value must be greater than or equal to 0s",
+			}
+		}
+
+	}
+
+	// no validation rules for MaxEntries
+
+	if _, ok := Cache_EvictionPolicy_name[int32(m.GetEvictionPolicy())]; !ok {
+		return CacheValidationError{
+			field:  "EvictionPolicy",
+			reason: "value must be one of the defined enum values",
+		}
+	}
+
+	if _, ok := Cache_EvictionStrategy_name[int32(m.GetEvictionStrategy())]; !ok {
+		return CacheValidationError{
+			field:  "EvictionStrategy",
+			reason: "value must be one of the defined enum values",
+		}
+	}
+
+	return nil
+}
+
+// CacheValidationError is the validation error returned by Cache.Validate if
+// the designated constraints aren't met.
+type CacheValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CacheValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CacheValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CacheValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CacheValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CacheValidationError) ErrorName() string { return "CacheValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CacheValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCache.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CacheValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CacheValidationError{}
+
+// Validate checks the field values on SocketAddress with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *SocketAddress) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if err := m._validateHostname(m.GetAddress()); err != nil {
+		if ip := net.ParseIP(m.GetAddress()); ip == nil {
+			return SocketAddressValidationError{
+				field:  "Address",
+				reason: "value must be a valid hostname, or ip address",
+			}
+		}
+	}
+
+	if m.GetPortValue() > 65535 {
+		return SocketAddressValidationError{
+			field:  "PortValue",
+			reason: "value must be less than or equal to 65535",
+		}
+	}
+
+	if m.GetMode() > 511 {
+		return SocketAddressValidationError{
+			field:  "Mode",
+			reason: "value must be less than or equal to 511",
+		}
+	}
+
+	return nil
+}
+
+func (m *SocketAddress) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+// SocketAddressValidationError is the validation error returned by
+// SocketAddress.Validate if the designated constraints aren't met.
+type SocketAddressValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SocketAddressValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SocketAddressValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SocketAddressValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SocketAddressValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SocketAddressValidationError) ErrorName() string { return "SocketAddressValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SocketAddressValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSocketAddress.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SocketAddressValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SocketAddressValidationError{}
+
+// Validate checks the field values on Admin with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Admin) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetAddress() == nil {
+		return AdminValidationError{
+			field:  "Address",
+			reason: "value is required",
+		}
+	}
+
+	if v, ok := interface{}(m.GetAddress()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AdminValidationError{
+				field:  "Address",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for EnableDebugEndpoints
+
+	return nil
+}
+
+// AdminValidationError is the validation error returned by Admin.Validate if
+// the designated constraints aren't met.
+type AdminValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AdminValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AdminValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AdminValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AdminValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AdminValidationError) ErrorName() string { return "AdminValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AdminValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAdmin.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AdminValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AdminValidationError{}
+
+// Validate checks the field values on MetricsSink with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *MetricsSink) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	switch m.Type.(type) {
+
+	case *MetricsSink_Statsd:
+
+		if v, ok := interface{}(m.GetStatsd()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MetricsSinkValidationError{
+					field:  "Statsd",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		return MetricsSinkValidationError{
+			field:  "Type",
+			reason: "value is required",
+		}
+
+	}
+
+	return nil
+}
+
+// MetricsSinkValidationError is the validation error returned by
+// MetricsSink.Validate if the designated constraints aren't met.
+type MetricsSinkValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MetricsSinkValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MetricsSinkValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MetricsSinkValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MetricsSinkValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MetricsSinkValidationError) ErrorName() string { return "MetricsSinkValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MetricsSinkValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMetricsSink.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MetricsSinkValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MetricsSinkValidationError{}
+
+// Validate checks the field values on Statsd with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Statsd) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetAddress() == nil {
+		return StatsdValidationError{
+			field:  "Address",
+			reason: "value is required",
+		}
+	}
+
+	if v, ok := interface{}(m.GetAddress()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return StatsdValidationError{
+				field:  "Address",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(m.GetRootPrefix()) < 1 {
+		return StatsdValidationError{
+			field:  "RootPrefix",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	if m.GetFlushInterval() == nil {
+		return StatsdValidationError{
+			field:  "FlushInterval",
+			reason: "value is required",
+		}
+	}
+
+	if d := m.GetFlushInterval(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return StatsdValidationError{
+				field:  "FlushInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur < gte {
+			return StatsdValidationError{
+				field:  "FlushInterval",
+				reason: "value must be greater than or equal to 0s",
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// StatsdValidationError is the validation error returned by Statsd.Validate if
+// the designated constraints aren't met.
+type StatsdValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StatsdValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StatsdValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StatsdValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StatsdValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StatsdValidationError) ErrorName() string { return "StatsdValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StatsdValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStatsd.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StatsdValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StatsdValidationError{}
+
+// Validate checks the field values on VersionGuard with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *VersionGuard) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if _, ok := VersionGuard_Comparator_name[int32(m.GetComparator())]; !ok {
+		return VersionGuardValidationError{
+			field:  "Comparator",
+			reason: "value must be one of the defined enum values",
+		}
+	}
+
+	// no validation rules for RejectRegressions
+
+	return nil
+}
+
+// VersionGuardValidationError is the validation error returned by
+// VersionGuard.Validate if the designated constraints aren't met.
+type VersionGuardValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VersionGuardValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VersionGuardValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VersionGuardValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VersionGuardValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VersionGuardValidationError) ErrorName() string { return "VersionGuardValidationError" }
+
+// Error satisfies the builtin error interface
+func (e VersionGuardValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVersionGuard.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VersionGuardValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VersionGuardValidationError{}
+
+// Validate checks the field values on Notifications with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *Notifications) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	for idx, item := range m.GetWebhooks() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return NotificationsValidationError{
+					field:  fmt.Sprintf("Webhooks[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// NotificationsValidationError is the validation error returned by
+// Notifications.Validate if the designated constraints aren't met.
+type NotificationsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e NotificationsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e NotificationsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e NotificationsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e NotificationsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e NotificationsValidationError) ErrorName() string { return "NotificationsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e NotificationsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sNotifications.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = NotificationsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = NotificationsValidationError{}
+
+// Validate checks the field values on Webhook with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Webhook) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if uri, err := url.Parse(m.GetUrl()); err != nil {
+		return WebhookValidationError{
+			field:  "Url",
+			reason: "value must be a valid URI",
+			cause:  err,
+		}
+	} else if !uri.IsAbs() {
+		return WebhookValidationError{
+			field:  "Url",
+			reason: "value must be absolute",
+		}
+	}
+
+	if d := m.GetTimeout(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return WebhookValidationError{
+				field:  "Timeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur < gte {
+			return WebhookValidationError{
+				field:  "Timeout",
+				reason: "value must be greater than or equal to 0s",
+			}
+		}
+
+	}
+
+	// no validation rules for QueueSize
+
+	return nil
+}
+
+// WebhookValidationError is the validation error returned by Webhook.Validate
+// if the designated constraints aren't met.
+type WebhookValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WebhookValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WebhookValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WebhookValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WebhookValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WebhookValidationError) ErrorName() string { return "WebhookValidationError" }
+
+// Error satisfies the builtin error interface
+func (e WebhookValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWebhook.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WebhookValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WebhookValidationError{}
+
+// Validate checks the field values on LeaderElection with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *LeaderElection) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Identity
+
+	if d := m.GetLeaseDuration(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return LeaderElectionValidationError{
+				field:  "LeaseDuration",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return LeaderElectionValidationError{
+				field:  "LeaseDuration",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	if d := m.GetRetryPeriod(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return LeaderElectionValidationError{
+				field:  "RetryPeriod",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return LeaderElectionValidationError{
+				field:  "RetryPeriod",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	switch m.Backend.(type) {
+
+	case *LeaderElection_KubernetesLease:
+
+		if v, ok := interface{}(m.GetKubernetesLease()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return LeaderElectionValidationError{
+					field:  "KubernetesLease",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		return LeaderElectionValidationError{
+			field:  "Backend",
+			reason: "value is required",
+		}
+
+	}
+
+	return nil
+}
+
+// LeaderElectionValidationError is the validation error returned by
+// LeaderElection.Validate if the designated constraints aren't met.
+type LeaderElectionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LeaderElectionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LeaderElectionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LeaderElectionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LeaderElectionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LeaderElectionValidationError) ErrorName() string { return "LeaderElectionValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LeaderElectionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLeaderElection.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LeaderElectionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LeaderElectionValidationError{}
+
+// Validate checks the field values on KubernetesLease with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *KubernetesLease) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetNamespace()) < 1 {
+		return KubernetesLeaseValidationError{
+			field:  "Namespace",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	if len(m.GetName()) < 1 {
+		return KubernetesLeaseValidationError{
+			field:  "Name",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	// no validation rules for ApiServer
+
+	// no validation rules for TokenFile
+
+	// no validation rules for CaFile
+
+	return nil
+}
+
+// KubernetesLeaseValidationError is the validation error returned by
+// KubernetesLease.Validate if the designated constraints aren't met.
+type KubernetesLeaseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KubernetesLeaseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KubernetesLeaseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesLeaseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesLeaseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesLeaseValidationError) ErrorName() string { return "KubernetesLeaseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e KubernetesLeaseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesLease.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesLeaseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesLeaseValidationError{}
+
+// Validate checks the field values on Replication with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *Replication) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if v, ok := interface{}(m.GetSource()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ReplicationValidationError{
+				field:  "Source",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetListener()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ReplicationValidationError{
+				field:  "Listener",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetSourceTls()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ReplicationValidationError{
+				field:  "SourceTls",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// ReplicationValidationError is the validation error returned by
+// Replication.Validate if the designated constraints aren't met.
+type ReplicationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReplicationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReplicationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReplicationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReplicationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReplicationValidationError) ErrorName() string { return "ReplicationValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ReplicationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReplication.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReplicationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReplicationValidationError{}
+
+// Validate checks the field values on ReplicationListener with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *ReplicationListener) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetAddress() == nil {
+		return ReplicationListenerValidationError{
+			field:  "Address",
+			reason: "value is required",
+		}
+	}
+
+	if v, ok := interface{}(m.GetAddress()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ReplicationListenerValidationError{
+				field:  "Address",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetTls()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ReplicationListenerValidationError{
+				field:  "Tls",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// ReplicationListenerValidationError is the validation error returned by
+// ReplicationListener.Validate if the designated constraints aren't met.
+type ReplicationListenerValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReplicationListenerValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReplicationListenerValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReplicationListenerValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReplicationListenerValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReplicationListenerValidationError) ErrorName() string {
+	return "ReplicationListenerValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReplicationListenerValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReplicationListener.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReplicationListenerValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReplicationListenerValidationError{}
+
+// Validate checks the field values on ClientTLS with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *ClientTLS) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for CaFile
+
+	// no validation rules for CertFile
+
+	// no validation rules for KeyFile
+
+	return nil
+}
+
+// ClientTLSValidationError is the validation error returned by
+// ClientTLS.Validate if the designated constraints aren't met.
+type ClientTLSValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ClientTLSValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ClientTLSValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ClientTLSValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ClientTLSValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ClientTLSValidationError) ErrorName() string { return "ClientTLSValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ClientTLSValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sClientTLS.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ClientTLSValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ClientTLSValidationError{}
+
+// Validate checks the field values on DryRun with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *DryRun) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	for idx, item := range m.GetSubscriptions() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DryRunValidationError{
+					field:  fmt.Sprintf("Subscriptions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// DryRunValidationError is the validation error returned by DryRun.Validate if
+// the designated constraints aren't met.
+type DryRunValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DryRunValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DryRunValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DryRunValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DryRunValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DryRunValidationError) ErrorName() string { return "DryRunValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DryRunValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDryRun.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DryRunValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DryRunValidationError{}
+
+// Validate checks the field values on DryRunSubscription with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *DryRunSubscription) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetTypeUrl()) < 1 {
+		return DryRunSubscriptionValidationError{
+			field:  "TypeUrl",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	// no validation rules for NodeId
+
+	// no validation rules for NodeCluster
+
+	return nil
+}
+
+// DryRunSubscriptionValidationError is the validation error returned by
+// DryRunSubscription.Validate if the designated constraints aren't met.
+type DryRunSubscriptionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DryRunSubscriptionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DryRunSubscriptionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DryRunSubscriptionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DryRunSubscriptionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DryRunSubscriptionValidationError) ErrorName() string {
+	return "DryRunSubscriptionValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DryRunSubscriptionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDryRunSubscription.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DryRunSubscriptionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DryRunSubscriptionValidationError{}
+
+// Validate checks the field values on Transformation with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *Transformation) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	switch m.Transformer.(type) {
+
+	case *Transformation_StripFields:
+
+		if v, ok := interface{}(m.GetStripFields()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return TransformationValidationError{
+					field:  "StripFields",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Transformation_SetFields:
+
+		if v, ok := interface{}(m.GetSetFields()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return TransformationValidationError{
+					field:  "SetFields",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Transformation_GoPlugin:
+
+		if v, ok := interface{}(m.GetGoPlugin()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return TransformationValidationError{
+					field:  "GoPlugin",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		return TransformationValidationError{
+			field:  "Transformer",
+			reason: "value is required",
+		}
+
+	}
+
+	return nil
+}
+
+// TransformationValidationError is the validation error returned by
+// Transformation.Validate if the designated constraints aren't met.
+type TransformationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TransformationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TransformationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TransformationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TransformationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TransformationValidationError) ErrorName() string { return "TransformationValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TransformationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTransformation.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TransformationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TransformationValidationError{}
+
+// Validate checks the field values on StripFields with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *StripFields) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetPaths()) < 1 {
+		return StripFieldsValidationError{
+			field:  "Paths",
+			reason: "value must contain at least 1 item(s)",
+		}
+	}
+
+	return nil
+}
+
+// StripFieldsValidationError is the validation error returned by
+// StripFields.Validate if the designated constraints aren't met.
+type StripFieldsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StripFieldsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StripFieldsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StripFieldsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StripFieldsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StripFieldsValidationError) ErrorName() string { return "StripFieldsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StripFieldsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStripFields.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StripFieldsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StripFieldsValidationError{}
+
+// Validate checks the field values on SetFields with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *SetFields) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetValues()) < 1 {
+		return SetFieldsValidationError{
+			field:  "Values",
+			reason: "value must contain at least 1 pair(s)",
+		}
+	}
+
+	for key, val := range m.GetValues() {
+		_ = val
+
+		// no validation rules for Values[key]
+
+		if v, ok := interface{}(val).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SetFieldsValidationError{
+					field:  fmt.Sprintf("Values[%v]", key),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// SetFieldsValidationError is the validation error returned by
+// SetFields.Validate if the designated constraints aren't met.
+type SetFieldsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SetFieldsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SetFieldsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SetFieldsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SetFieldsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SetFieldsValidationError) ErrorName() string { return "SetFieldsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SetFieldsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSetFields.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SetFieldsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SetFieldsValidationError{}
+
+// Validate checks the field values on GoPlugin with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *GoPlugin) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetPath()) < 1 {
+		return GoPluginValidationError{
+			field:  "Path",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	return nil
+}
+
+// GoPluginValidationError is the validation error returned by
+// GoPlugin.Validate if the designated constraints aren't met.
+type GoPluginValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GoPluginValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GoPluginValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GoPluginValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GoPluginValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GoPluginValidationError) ErrorName() string { return "GoPluginValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GoPluginValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGoPlugin.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GoPluginValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GoPluginValidationError{}
+
+// Validate checks the field values on OverrideFiles with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *OverrideFiles) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetDirectory()) < 1 {
+		return OverrideFilesValidationError{
+			field:  "Directory",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	if d := m.GetReloadInterval(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return OverrideFilesValidationError{
+				field:  "ReloadInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return OverrideFilesValidationError{
+				field:  "ReloadInterval",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// OverrideFilesValidationError is the validation error returned by
+// OverrideFiles.Validate if the designated constraints aren't met.
+type OverrideFilesValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e OverrideFilesValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e OverrideFilesValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e OverrideFilesValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e OverrideFilesValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e OverrideFilesValidationError) ErrorName() string { return "OverrideFilesValidationError" }
+
+// Error satisfies the builtin error interface
+func (e OverrideFilesValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sOverrideFiles.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = OverrideFilesValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = OverrideFilesValidationError{}
+
+// Validate checks the field values on StaticResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *StaticResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetPath()) < 1 {
+		return StaticResponseValidationError{
+			field:  "Path",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	switch m.Match.(type) {
+
+	case *StaticResponse_Key:
+
+		if len(m.GetKey()) < 1 {
+			return StaticResponseValidationError{
+				field:  "Key",
+				reason: "value length must be at least 1 bytes",
+			}
+		}
+
+	case *StaticResponse_TypeUrl:
+
+		if len(m.GetTypeUrl()) < 1 {
+			return StaticResponseValidationError{
+				field:  "TypeUrl",
+				reason: "value length must be at least 1 bytes",
+			}
+		}
+
+	default:
+		return StaticResponseValidationError{
+			field:  "Match",
+			reason: "value is required",
+		}
+
+	}
+
+	return nil
+}
+
+// StaticResponseValidationError is the validation error returned by
+// StaticResponse.Validate if the designated constraints aren't met.
+type StaticResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StaticResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StaticResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StaticResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StaticResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StaticResponseValidationError) ErrorName() string { return "StaticResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StaticResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStaticResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StaticResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StaticResponseValidationError{}
+
+// Validate checks the field values on Recording with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Recording) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetDirectory()) < 1 {
+		return RecordingValidationError{
+			field:  "Directory",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	// no validation rules for RecordRequests
+
+	return nil
+}
+
+// RecordingValidationError is the validation error returned by
+// Recording.Validate if the designated constraints aren't met.
+type RecordingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RecordingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RecordingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RecordingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RecordingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RecordingValidationError) ErrorName() string { return "RecordingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RecordingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRecording.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RecordingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RecordingValidationError{}
+
+// Validate checks the field values on Replay with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Replay) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetDirectory()) < 1 {
+		return ReplayValidationError{
+			field:  "Directory",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	// no validation rules for PreserveTiming
+
+	return nil
+}
+
+// ReplayValidationError is the validation error returned by Replay.Validate if
+// the designated constraints aren't met.
+type ReplayValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReplayValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReplayValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReplayValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReplayValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReplayValidationError) ErrorName() string { return "ReplayValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ReplayValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReplay.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReplayValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReplayValidationError{}
+
+// Validate checks the field values on Supervision with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *Supervision) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if v, ok := interface{}(m.GetMaxRestarts()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SupervisionValidationError{
+				field:  "MaxRestarts",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if d := m.GetRestartBackoff(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return SupervisionValidationError{
+				field:  "RestartBackoff",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur < gte {
+			return SupervisionValidationError{
+				field:  "RestartBackoff",
+				reason: "value must be greater than or equal to 0s",
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// SupervisionValidationError is the validation error returned by
+// Supervision.Validate if the designated constraints aren't met.
+type SupervisionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SupervisionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SupervisionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SupervisionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SupervisionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SupervisionValidationError) ErrorName() string { return "SupervisionValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SupervisionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSupervision.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SupervisionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SupervisionValidationError{}
+
+// Validate checks the field values on DifferentialFanout with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *DifferentialFanout) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	return nil
+}
+
+// DifferentialFanoutValidationError is the validation error returned by
+// DifferentialFanout.Validate if the designated constraints aren't met.
+type DifferentialFanoutValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DifferentialFanoutValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DifferentialFanoutValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DifferentialFanoutValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DifferentialFanoutValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DifferentialFanoutValidationError) ErrorName() string {
+	return "DifferentialFanoutValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DifferentialFanoutValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDifferentialFanout.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DifferentialFanoutValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DifferentialFanoutValidationError{}
+
+// Validate checks the field values on ContentDeduplication with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *ContentDeduplication) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	return nil
+}
+
+// ContentDeduplicationValidationError is the validation error returned by
+// ContentDeduplication.Validate if the designated constraints aren't met.
+type ContentDeduplicationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ContentDeduplicationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ContentDeduplicationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ContentDeduplicationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ContentDeduplicationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ContentDeduplicationValidationError) ErrorName() string {
+	return "ContentDeduplicationValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ContentDeduplicationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sContentDeduplication.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ContentDeduplicationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ContentDeduplicationValidationError{}
+
+// Validate checks the field values on FanoutScheduling with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *FanoutScheduling) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetConcurrency() <= 0 {
+		return FanoutSchedulingValidationError{
+			field:  "Concurrency",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	for idx, item := range m.GetWeights() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FanoutSchedulingValidationError{
+					field:  fmt.Sprintf("Weights[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	for idx, item := range m.GetTypePriorities() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FanoutSchedulingValidationError{
+					field:  fmt.Sprintf("TypePriorities[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// FanoutSchedulingValidationError is the validation error returned by
+// FanoutScheduling.Validate if the designated constraints aren't met.
+type FanoutSchedulingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FanoutSchedulingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FanoutSchedulingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FanoutSchedulingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FanoutSchedulingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FanoutSchedulingValidationError) ErrorName() string { return "FanoutSchedulingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FanoutSchedulingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFanoutScheduling.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FanoutSchedulingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FanoutSchedulingValidationError{}
+
+// Validate checks the field values on TypePriority with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *TypePriority) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetTypeUrl()) < 1 {
+		return TypePriorityValidationError{
+			field:  "TypeUrl",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	// no validation rules for Priority
+
+	return nil
+}
+
+// TypePriorityValidationError is the validation error returned by
+// TypePriority.Validate if the designated constraints aren't met.
+type TypePriorityValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TypePriorityValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TypePriorityValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TypePriorityValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TypePriorityValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TypePriorityValidationError) ErrorName() string { return "TypePriorityValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TypePriorityValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTypePriority.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TypePriorityValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TypePriorityValidationError{}
+
+// Validate checks the field values on KeyWeight with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *KeyWeight) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetKeyRegex()) < 1 {
+		return KeyWeightValidationError{
+			field:  "KeyRegex",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	if m.GetWeight() <= 0 {
+		return KeyWeightValidationError{
+			field:  "Weight",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	return nil
+}
+
+// KeyWeightValidationError is the validation error returned by
+// KeyWeight.Validate if the designated constraints aren't met.
+type KeyWeightValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KeyWeightValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KeyWeightValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KeyWeightValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KeyWeightValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KeyWeightValidationError) ErrorName() string { return "KeyWeightValidationError" }
+
+// Error satisfies the builtin error interface
+func (e KeyWeightValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKeyWeight.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KeyWeightValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KeyWeightValidationError{}
+
+// Validate checks the field values on AuditLog with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *AuditLog) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for HashPayloads
+
+	switch m.Sink.(type) {
+
+	case *AuditLog_File:
+
+		if v, ok := interface{}(m.GetFile()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return AuditLogValidationError{
+					field:  "File",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *AuditLog_Syslog:
+
+		if v, ok := interface{}(m.GetSyslog()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return AuditLogValidationError{
+					field:  "Syslog",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		return AuditLogValidationError{
+			field:  "Sink",
+			reason: "value is required",
+		}
+
+	}
+
+	return nil
+}
+
+// AuditLogValidationError is the validation error returned by
+// AuditLog.Validate if the designated constraints aren't met.
+type AuditLogValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AuditLogValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AuditLogValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AuditLogValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AuditLogValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AuditLogValidationError) ErrorName() string { return "AuditLogValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AuditLogValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAuditLog.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AuditLogValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AuditLogValidationError{}
+
+// Validate checks the field values on AuditLogFile with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *AuditLogFile) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetPath()) < 1 {
+		return AuditLogFileValidationError{
+			field:  "Path",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	if wrapper := m.GetMaxSizeBytes(); wrapper != nil {
+
+		if wrapper.GetValue() <= 0 {
+			return AuditLogFileValidationError{
+				field:  "MaxSizeBytes",
+				reason: "value must be greater than 0",
+			}
+		}
+
+	}
+
+	if v, ok := interface{}(m.GetMaxBackups()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AuditLogFileValidationError{
+				field:  "MaxBackups",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// AuditLogFileValidationError is the validation error returned by
+// AuditLogFile.Validate if the designated constraints aren't met.
+type AuditLogFileValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AuditLogFileValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AuditLogFileValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AuditLogFileValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AuditLogFileValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AuditLogFileValidationError) ErrorName() string { return "AuditLogFileValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AuditLogFileValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAuditLogFile.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AuditLogFileValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AuditLogFileValidationError{}
+
+// Validate checks the field values on AuditLogSyslog with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *AuditLogSyslog) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if _, ok := _AuditLogSyslog_Network_InLookup[m.GetNetwork()]; !ok {
+		return AuditLogSyslogValidationError{
+			field:  "Network",
+			reason: "value must be in list [ tcp udp]",
+		}
+	}
+
+	// no validation rules for Address
+
+	// no validation rules for Tag
+
+	return nil
+}
+
+// AuditLogSyslogValidationError is the validation error returned by
+// AuditLogSyslog.Validate if the designated constraints aren't met.
+type AuditLogSyslogValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AuditLogSyslogValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AuditLogSyslogValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AuditLogSyslogValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AuditLogSyslogValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AuditLogSyslogValidationError) ErrorName() string { return "AuditLogSyslogValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AuditLogSyslogValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAuditLogSyslog.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AuditLogSyslogValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AuditLogSyslogValidationError{}
+
+var _AuditLogSyslog_Network_InLookup = map[string]struct{}{
+	"":    {},
+	"tcp": {},
+	"udp": {},
+}
+
+// Validate checks the field values on SignatureVerification with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *SignatureVerification) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetPublicKeyPath()) < 1 {
+		return SignatureVerificationValidationError{
+			field:  "PublicKeyPath",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	return nil
+}
+
+// SignatureVerificationValidationError is the validation error returned by
+// SignatureVerification.Validate if the designated constraints aren't met.
+type SignatureVerificationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SignatureVerificationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SignatureVerificationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SignatureVerificationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SignatureVerificationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SignatureVerificationValidationError) ErrorName() string {
+	return "SignatureVerificationValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SignatureVerificationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSignatureVerification.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SignatureVerificationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SignatureVerificationValidationError{}
+
+// Validate checks the field values on ResponseLimit with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *ResponseLimit) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for TypeUrl
+
+	// no validation rules for MaxBytes
+
+	// no validation rules for MaxResources
+
+	if _, ok := ResponseLimit_Action_name[int32(m.GetAction())]; !ok {
+		return ResponseLimitValidationError{
+			field:  "Action",
+			reason: "value must be one of the defined enum values",
+		}
+	}
+
+	return nil
+}
+
+// ResponseLimitValidationError is the validation error returned by
+// ResponseLimit.Validate if the designated constraints aren't met.
+type ResponseLimitValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResponseLimitValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResponseLimitValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResponseLimitValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResponseLimitValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResponseLimitValidationError) ErrorName() string { return "ResponseLimitValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ResponseLimitValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResponseLimit.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResponseLimitValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResponseLimitValidationError{}
+
+// Validate checks the field values on Memory with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Memory) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if v, ok := interface{}(m.GetGcPercent()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MemoryValidationError{
+				field:  "GcPercent",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for BallastBytes
+
+	// no validation rules for HighWatermarkBytes
+
+	if d := m.GetCheckInterval(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return MemoryValidationError{
+				field:  "CheckInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return MemoryValidationError{
+				field:  "CheckInterval",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	// no validation rules for EvictionBatchSize
+
+	return nil
+}
+
+// MemoryValidationError is the validation error returned by Memory.Validate if
+// the designated constraints aren't met.
+type MemoryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MemoryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MemoryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MemoryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MemoryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MemoryValidationError) ErrorName() string { return "MemoryValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MemoryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMemory.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MemoryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MemoryValidationError{}
+
+// Validate checks the field values on ControlPlaneIdentifier with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *ControlPlaneIdentifier) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Prefix
+
+	// no validation rules for Replica
+
+	return nil
+}
+
+// ControlPlaneIdentifierValidationError is the validation error returned by
+// ControlPlaneIdentifier.Validate if the designated constraints aren't met.
+type ControlPlaneIdentifierValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ControlPlaneIdentifierValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ControlPlaneIdentifierValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ControlPlaneIdentifierValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ControlPlaneIdentifierValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ControlPlaneIdentifierValidationError) ErrorName() string {
+	return "ControlPlaneIdentifierValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ControlPlaneIdentifierValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sControlPlaneIdentifier.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ControlPlaneIdentifierValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ControlPlaneIdentifierValidationError{}
+
+// Validate checks the field values on Rollout with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Rollout) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if val := m.GetCanaryPercentage(); val < 0 || val > 100 {
+		return RolloutValidationError{
+			field:  "CanaryPercentage",
+			reason: "value must be inside range [0, 100]",
+		}
+	}
+
+	// no validation rules for CanaryNodeMetadata
+
+	if d := m.GetSoakDuration(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return RolloutValidationError{
+				field:  "SoakDuration",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return RolloutValidationError{
+				field:  "SoakDuration",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// RolloutValidationError is the validation error returned by Rollout.Validate
+// if the designated constraints aren't met.
+type RolloutValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RolloutValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RolloutValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RolloutValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RolloutValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RolloutValidationError) ErrorName() string { return "RolloutValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RolloutValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRollout.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RolloutValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RolloutValidationError{}
+
+// Validate checks the field values on CircuitBreaker with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *CircuitBreaker) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if wrapper := m.GetFailureThreshold(); wrapper != nil {
+
+		if wrapper.GetValue() <= 0 {
+			return CircuitBreakerValidationError{
+				field:  "FailureThreshold",
+				reason: "value must be greater than 0",
+			}
+		}
+
+	}
+
+	if d := m.GetFailureWindow(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return CircuitBreakerValidationError{
+				field:  "FailureWindow",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return CircuitBreakerValidationError{
+				field:  "FailureWindow",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	if d := m.GetCoolDown(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return CircuitBreakerValidationError{
+				field:  "CoolDown",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return CircuitBreakerValidationError{
+				field:  "CoolDown",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// CircuitBreakerValidationError is the validation error returned by
+// CircuitBreaker.Validate if the designated constraints aren't met.
+type CircuitBreakerValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CircuitBreakerValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CircuitBreakerValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CircuitBreakerValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CircuitBreakerValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CircuitBreakerValidationError) ErrorName() string { return "CircuitBreakerValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CircuitBreakerValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCircuitBreaker.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CircuitBreakerValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CircuitBreakerValidationError{}
+
+// Validate checks the field values on Alerting with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Alerting) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if d := m.GetStaleAfter(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return AlertingValidationError{
+				field:  "StaleAfter",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return AlertingValidationError{
+				field:  "StaleAfter",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	if val := m.GetMaxResourceDropPercent(); val < 0 || val > 100 {
+		return AlertingValidationError{
+			field:  "MaxResourceDropPercent",
+			reason: "value must be inside range [0, 100]",
+		}
+	}
+
+	if val := m.GetMaxNackPercent(); val < 0 || val > 100 {
+		return AlertingValidationError{
+			field:  "MaxNackPercent",
+			reason: "value must be inside range [0, 100]",
+		}
+	}
+
+	if d := m.GetEvaluationInterval(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return AlertingValidationError{
+				field:  "EvaluationInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return AlertingValidationError{
+				field:  "EvaluationInterval",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// AlertingValidationError is the validation error returned by
+// Alerting.Validate if the designated constraints aren't met.
+type AlertingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AlertingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AlertingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AlertingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AlertingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AlertingValidationError) ErrorName() string { return "AlertingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AlertingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAlerting.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AlertingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AlertingValidationError{}
+
+// Validate checks the field values on HashRing with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *HashRing) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Identity
+
+	if wrapper := m.GetVirtualNodes(); wrapper != nil {
+
+		if wrapper.GetValue() <= 0 {
+			return HashRingValidationError{
+				field:  "VirtualNodes",
+				reason: "value must be greater than 0",
+			}
+		}
+
+	}
+
+	if d := m.GetRefreshInterval(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return HashRingValidationError{
+				field:  "RefreshInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return HashRingValidationError{
+				field:  "RefreshInterval",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	switch m.Membership.(type) {
+
+	case *HashRing_StaticMembers_:
+
+		if v, ok := interface{}(m.GetStaticMembers()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return HashRingValidationError{
+					field:  "StaticMembers",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *HashRing_KubernetesEndpoints_:
+
+		if v, ok := interface{}(m.GetKubernetesEndpoints()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return HashRingValidationError{
+					field:  "KubernetesEndpoints",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		return HashRingValidationError{
+			field:  "Membership",
+			reason: "value is required",
+		}
+
+	}
+
+	return nil
+}
+
+// HashRingValidationError is the validation error returned by
+// HashRing.Validate if the designated constraints aren't met.
+type HashRingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e HashRingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e HashRingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e HashRingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e HashRingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e HashRingValidationError) ErrorName() string { return "HashRingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e HashRingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sHashRing.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = HashRingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = HashRingValidationError{}
+
+// Validate checks the field values on UpstreamHealth with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *UpstreamHealth) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if d := m.GetTimeout(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return UpstreamHealthValidationError{
+				field:  "Timeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return UpstreamHealthValidationError{
+				field:  "Timeout",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	for idx, item := range m.GetKeyTimeouts() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UpstreamHealthValidationError{
+					field:  fmt.Sprintf("KeyTimeouts[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if d := m.GetEvaluationInterval(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return UpstreamHealthValidationError{
+				field:  "EvaluationInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return UpstreamHealthValidationError{
+				field:  "EvaluationInterval",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// UpstreamHealthValidationError is the validation error returned by
+// UpstreamHealth.Validate if the designated constraints aren't met.
+type UpstreamHealthValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpstreamHealthValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpstreamHealthValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpstreamHealthValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpstreamHealthValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpstreamHealthValidationError) ErrorName() string { return "UpstreamHealthValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UpstreamHealthValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpstreamHealth.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpstreamHealthValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpstreamHealthValidationError{}
+
+// Validate checks the field values on NegativeCache with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *NegativeCache) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetTtl() == nil {
+		return NegativeCacheValidationError{
+			field:  "Ttl",
+			reason: "value is required",
+		}
+	}
+
+	if d := m.GetTtl(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return NegativeCacheValidationError{
+				field:  "Ttl",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return NegativeCacheValidationError{
+				field:  "Ttl",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	// no validation rules for MaxEntries
+
+	return nil
+}
+
+// NegativeCacheValidationError is the validation error returned by
+// NegativeCache.Validate if the designated constraints aren't met.
+type NegativeCacheValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e NegativeCacheValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e NegativeCacheValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e NegativeCacheValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e NegativeCacheValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e NegativeCacheValidationError) ErrorName() string { return "NegativeCacheValidationError" }
+
+// Error satisfies the builtin error interface
+func (e NegativeCacheValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sNegativeCache.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = NegativeCacheValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = NegativeCacheValidationError{}
+
+// Validate checks the field values on RelayChain with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *RelayChain) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Identity
+
+	if v, ok := interface{}(m.GetMaxHops()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RelayChainValidationError{
+				field:  "MaxHops",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// RelayChainValidationError is the validation error returned by
+// RelayChain.Validate if the designated constraints aren't met.
+type RelayChainValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RelayChainValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RelayChainValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RelayChainValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RelayChainValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RelayChainValidationError) ErrorName() string { return "RelayChainValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RelayChainValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRelayChain.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RelayChainValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RelayChainValidationError{}
+
+// Validate checks the field values on ParentRelay with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *ParentRelay) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetUpstream() == nil {
+		return ParentRelayValidationError{
+			field:  "Upstream",
+			reason: "value is required",
+		}
+	}
+
+	if v, ok := interface{}(m.GetUpstream()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ParentRelayValidationError{
+				field:  "Upstream",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if d := m.GetTimeout(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return ParentRelayValidationError{
+				field:  "Timeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return ParentRelayValidationError{
+				field:  "Timeout",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	if d := m.GetRetryInterval(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return ParentRelayValidationError{
+				field:  "RetryInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return ParentRelayValidationError{
+				field:  "RetryInterval",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ParentRelayValidationError is the validation error returned by
+// ParentRelay.Validate if the designated constraints aren't met.
+type ParentRelayValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ParentRelayValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ParentRelayValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ParentRelayValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ParentRelayValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ParentRelayValidationError) ErrorName() string { return "ParentRelayValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ParentRelayValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sParentRelay.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ParentRelayValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ParentRelayValidationError{}
+
+// Validate checks the field values on ResponseValidation with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *ResponseValidation) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if _, ok := ResponseValidation_Action_name[int32(m.GetAction())]; !ok {
+		return ResponseValidationValidationError{
+			field:  "Action",
+			reason: "value must be one of the defined enum values",
+		}
+	}
+
+	return nil
+}
+
+// ResponseValidationValidationError is the validation error returned by
+// ResponseValidation.Validate if the designated constraints aren't met.
+type ResponseValidationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResponseValidationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResponseValidationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResponseValidationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResponseValidationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResponseValidationValidationError) ErrorName() string {
+	return "ResponseValidationValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ResponseValidationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResponseValidation.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResponseValidationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResponseValidationValidationError{}
+
+// Validate checks the field values on Linting with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Linting) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	for idx, item := range m.GetChecks() {
+		_, _ = idx, item
+
+		if _, ok := Linting_Check_name[int32(item)]; !ok {
+			return LintingValidationError{
+				field:  fmt.Sprintf("Checks[%v]", idx),
+				reason: "value must be one of the defined enum values",
+			}
+		}
+
+	}
+
+	// no validation rules for Block
+
+	return nil
+}
+
+// LintingValidationError is the validation error returned by Linting.Validate
+// if the designated constraints aren't met.
+type LintingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LintingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LintingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LintingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LintingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LintingValidationError) ErrorName() string { return "LintingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LintingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLinting.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LintingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LintingValidationError{}
+
+// Validate checks the field values on DependencyOrdering with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *DependencyOrdering) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if d := m.GetWindow(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return DependencyOrderingValidationError{
+				field:  "Window",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return DependencyOrderingValidationError{
+				field:  "Window",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	if d := m.GetPacing(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return DependencyOrderingValidationError{
+				field:  "Pacing",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur < gte {
+			return DependencyOrderingValidationError{
+				field:  "Pacing",
+				reason: "value must be greater than or equal to 0s",
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// DependencyOrderingValidationError is the validation error returned by
+// DependencyOrdering.Validate if the designated constraints aren't met.
+type DependencyOrderingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DependencyOrderingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DependencyOrderingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DependencyOrderingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DependencyOrderingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DependencyOrderingValidationError) ErrorName() string {
+	return "DependencyOrderingValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DependencyOrderingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDependencyOrdering.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DependencyOrderingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DependencyOrderingValidationError{}
+
+// Validate checks the field values on History with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *History) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if wrapper := m.GetMaxVersions(); wrapper != nil {
+
+		if wrapper.GetValue() <= 0 {
+			return HistoryValidationError{
+				field:  "MaxVersions",
+				reason: "value must be greater than 0",
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// HistoryValidationError is the validation error returned by History.Validate
+// if the designated constraints aren't met.
+type HistoryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e HistoryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e HistoryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e HistoryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e HistoryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e HistoryValidationError) ErrorName() string { return "HistoryValidationError" }
+
+// Error satisfies the builtin error interface
+func (e HistoryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sHistory.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = HistoryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = HistoryValidationError{}
+
+// Validate checks the field values on Maintenance with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *Maintenance) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetWindows()) < 1 {
+		return MaintenanceValidationError{
+			field:  "Windows",
+			reason: "value must contain at least 1 item(s)",
+		}
+	}
+
+	for idx, item := range m.GetWindows() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MaintenanceValidationError{
+					field:  fmt.Sprintf("Windows[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if d := m.GetReleaseInterval(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return MaintenanceValidationError{
+				field:  "ReleaseInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur < gte {
+			return MaintenanceValidationError{
+				field:  "ReleaseInterval",
+				reason: "value must be greater than or equal to 0s",
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MaintenanceValidationError is the validation error returned by
+// Maintenance.Validate if the designated constraints aren't met.
+type MaintenanceValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MaintenanceValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MaintenanceValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MaintenanceValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MaintenanceValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MaintenanceValidationError) ErrorName() string { return "MaintenanceValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MaintenanceValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMaintenance.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MaintenanceValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MaintenanceValidationError{}
+
+// Validate checks the field values on ChangeRateGuard with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *ChangeRateGuard) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetMaxVersions() <= 0 {
+		return ChangeRateGuardValidationError{
+			field:  "MaxVersions",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	if m.GetInterval() == nil {
+		return ChangeRateGuardValidationError{
+			field:  "Interval",
+			reason: "value is required",
+		}
+	}
+
+	if d := m.GetInterval(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return ChangeRateGuardValidationError{
+				field:  "Interval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return ChangeRateGuardValidationError{
+				field:  "Interval",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	// no validation rules for Pause
+
+	return nil
+}
+
+// ChangeRateGuardValidationError is the validation error returned by
+// ChangeRateGuard.Validate if the designated constraints aren't met.
+type ChangeRateGuardValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ChangeRateGuardValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ChangeRateGuardValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ChangeRateGuardValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ChangeRateGuardValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ChangeRateGuardValidationError) ErrorName() string { return "ChangeRateGuardValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ChangeRateGuardValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sChangeRateGuard.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ChangeRateGuardValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ChangeRateGuardValidationError{}
+
+// Validate checks the field values on FanoutPacing with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *FanoutPacing) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetDuration() == nil {
+		return FanoutPacingValidationError{
+			field:  "Duration",
+			reason: "value is required",
+		}
+	}
+
+	if d := m.GetDuration(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return FanoutPacingValidationError{
+				field:  "Duration",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return FanoutPacingValidationError{
+				field:  "Duration",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	// no validation rules for MinWatches
+
+	return nil
+}
+
+// FanoutPacingValidationError is the validation error returned by
+// FanoutPacing.Validate if the designated constraints aren't met.
+type FanoutPacingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FanoutPacingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FanoutPacingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FanoutPacingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FanoutPacingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FanoutPacingValidationError) ErrorName() string { return "FanoutPacingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FanoutPacingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFanoutPacing.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FanoutPacingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FanoutPacingValidationError{}
+
+// Validate checks the field values on Fetch with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Fetch) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if d := m.GetTimeout(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return FetchValidationError{
+				field:  "Timeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return FetchValidationError{
+				field:  "Timeout",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	// no validation rules for SingleShot
+
+	return nil
+}
+
+// FetchValidationError is the validation error returned by Fetch.Validate if
+// the designated constraints aren't met.
+type FetchValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FetchValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FetchValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FetchValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FetchValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FetchValidationError) ErrorName() string { return "FetchValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FetchValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFetch.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FetchValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FetchValidationError{}
+
+// Validate checks the field values on Buffers with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Buffers) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if v, ok := interface{}(m.GetDownstream()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BuffersValidationError{
+				field:  "Downstream",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetUpstream()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BuffersValidationError{
+				field:  "Upstream",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetTypeUrls() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BuffersValidationError{
+					field:  fmt.Sprintf("TypeUrls[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// BuffersValidationError is the validation error returned by Buffers.Validate
+// if the designated constraints aren't met.
+type BuffersValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BuffersValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BuffersValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BuffersValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BuffersValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BuffersValidationError) ErrorName() string { return "BuffersValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BuffersValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBuffers.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BuffersValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BuffersValidationError{}
+
+// Validate checks the field values on KeyMetrics with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *KeyMetrics) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetRules()) < 1 {
+		return KeyMetricsValidationError{
+			field:  "Rules",
+			reason: "value must contain at least 1 item(s)",
+		}
+	}
+
+	for idx, item := range m.GetRules() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return KeyMetricsValidationError{
+					field:  fmt.Sprintf("Rules[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for MaxKeys
+
+	return nil
+}
+
+// KeyMetricsValidationError is the validation error returned by
+// KeyMetrics.Validate if the designated constraints aren't met.
+type KeyMetricsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KeyMetricsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KeyMetricsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KeyMetricsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KeyMetricsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KeyMetricsValidationError) ErrorName() string { return "KeyMetricsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e KeyMetricsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKeyMetrics.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KeyMetricsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KeyMetricsValidationError{}
+
+// Validate checks the field values on AggregationRollout with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *AggregationRollout) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetRevisions()) < 1 {
+		return AggregationRolloutValidationError{
+			field:  "Revisions",
+			reason: "value must contain at least 1 item(s)",
+		}
+	}
+
+	for idx, item := range m.GetRevisions() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return AggregationRolloutValidationError{
+					field:  fmt.Sprintf("Revisions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// AggregationRolloutValidationError is the validation error returned by
+// AggregationRollout.Validate if the designated constraints aren't met.
+type AggregationRolloutValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AggregationRolloutValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AggregationRolloutValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AggregationRolloutValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AggregationRolloutValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AggregationRolloutValidationError) ErrorName() string {
+	return "AggregationRolloutValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AggregationRolloutValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAggregationRollout.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AggregationRolloutValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AggregationRolloutValidationError{}
+
+// Validate checks the field values on AggregationSimulation with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *AggregationSimulation) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetPath()) < 1 {
+		return AggregationSimulationValidationError{
+			field:  "Path",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	// no validation rules for MaxNodes
+
+	return nil
+}
+
+// AggregationSimulationValidationError is the validation error returned by
+// AggregationSimulation.Validate if the designated constraints aren't met.
+type AggregationSimulationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AggregationSimulationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AggregationSimulationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AggregationSimulationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AggregationSimulationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AggregationSimulationValidationError) ErrorName() string {
+	return "AggregationSimulationValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AggregationSimulationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAggregationSimulation.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AggregationSimulationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AggregationSimulationValidationError{}
+
+// Validate checks the field values on LoadReporting with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *LoadReporting) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for NodeId
+
+	// no validation rules for NodeCluster
+
+	return nil
+}
+
+// LoadReportingValidationError is the validation error returned by
+// LoadReporting.Validate if the designated constraints aren't met.
+type LoadReportingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LoadReportingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LoadReportingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LoadReportingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LoadReportingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LoadReportingValidationError) ErrorName() string { return "LoadReportingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LoadReportingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLoadReporting.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LoadReportingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LoadReportingValidationError{}
+
+// Validate checks the field values on Interceptor_Recovery with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *Interceptor_Recovery) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	return nil
+}
+
+// Interceptor_RecoveryValidationError is the validation error returned by
+// Interceptor_Recovery.Validate if the designated constraints aren't met.
+type Interceptor_RecoveryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Interceptor_RecoveryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Interceptor_RecoveryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Interceptor_RecoveryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Interceptor_RecoveryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Interceptor_RecoveryValidationError) ErrorName() string {
+	return "Interceptor_RecoveryValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e Interceptor_RecoveryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sInterceptor_Recovery.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Interceptor_RecoveryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Interceptor_RecoveryValidationError{}
+
+// Validate checks the field values on Interceptor_RequestID with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *Interceptor_RequestID) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Header
+
+	return nil
+}
+
+// Interceptor_RequestIDValidationError is the validation error returned by
+// Interceptor_RequestID.Validate if the designated constraints aren't met.
+type Interceptor_RequestIDValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Interceptor_RequestIDValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Interceptor_RequestIDValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Interceptor_RequestIDValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Interceptor_RequestIDValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Interceptor_RequestIDValidationError) ErrorName() string {
+	return "Interceptor_RequestIDValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e Interceptor_RequestIDValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sInterceptor_RequestID.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Interceptor_RequestIDValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Interceptor_RequestIDValidationError{}
+
+// Validate checks the field values on Upstream_Metadata with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *Upstream_Metadata) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetKey()) < 1 {
+		return Upstream_MetadataValidationError{
+			field:  "Key",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	switch m.ValueSpecifier.(type) {
+
+	case *Upstream_Metadata_Value:
+		// no validation rules for Value
+
+	case *Upstream_Metadata_Template:
+		// no validation rules for Template
+
+	case *Upstream_Metadata_TokenFile:
+
+		if v, ok := interface{}(m.GetTokenFile()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return Upstream_MetadataValidationError{
+					field:  "TokenFile",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		return Upstream_MetadataValidationError{
+			field:  "ValueSpecifier",
+			reason: "value is required",
+		}
+
+	}
+
+	return nil
+}
+
+// Upstream_MetadataValidationError is the validation error returned by
+// Upstream_Metadata.Validate if the designated constraints aren't met.
+type Upstream_MetadataValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Upstream_MetadataValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Upstream_MetadataValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Upstream_MetadataValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Upstream_MetadataValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Upstream_MetadataValidationError) ErrorName() string {
+	return "Upstream_MetadataValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e Upstream_MetadataValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpstream_Metadata.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Upstream_MetadataValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Upstream_MetadataValidationError{}
+
+// Validate checks the field values on Upstream_TokenFile with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *Upstream_TokenFile) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetPath()) < 1 {
+		return Upstream_TokenFileValidationError{
+			field:  "Path",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	// no validation rules for Prefix
+
+	if d := m.GetRefreshInterval(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return Upstream_TokenFileValidationError{
+				field:  "RefreshInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return Upstream_TokenFileValidationError{
+				field:  "RefreshInterval",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// Upstream_TokenFileValidationError is the validation error returned by
+// Upstream_TokenFile.Validate if the designated constraints aren't met.
+type Upstream_TokenFileValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Upstream_TokenFileValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Upstream_TokenFileValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Upstream_TokenFileValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Upstream_TokenFileValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Upstream_TokenFileValidationError) ErrorName() string {
+	return "Upstream_TokenFileValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e Upstream_TokenFileValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpstream_TokenFile.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Upstream_TokenFileValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Upstream_TokenFileValidationError{}
+
+// Validate checks the field values on Upstream_Credentials with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *Upstream_Credentials) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	switch m.SourceSpecifier.(type) {
+
+	case *Upstream_Credentials_ServiceAccount_:
+
+		if v, ok := interface{}(m.GetServiceAccount()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return Upstream_CredentialsValidationError{
+					field:  "ServiceAccount",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Upstream_Credentials_WorkloadIdentity_:
+
+		if v, ok := interface{}(m.GetWorkloadIdentity()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return Upstream_CredentialsValidationError{
+					field:  "WorkloadIdentity",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Upstream_Credentials_Exec:
+
+		if v, ok := interface{}(m.GetExec()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return Upstream_CredentialsValidationError{
+					field:  "Exec",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		return Upstream_CredentialsValidationError{
+			field:  "SourceSpecifier",
+			reason: "value is required",
+		}
+
+	}
+
+	return nil
+}
+
+// Upstream_CredentialsValidationError is the validation error returned by
+// Upstream_Credentials.Validate if the designated constraints aren't met.
+type Upstream_CredentialsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Upstream_CredentialsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Upstream_CredentialsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Upstream_CredentialsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Upstream_CredentialsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Upstream_CredentialsValidationError) ErrorName() string {
+	return "Upstream_CredentialsValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e Upstream_CredentialsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpstream_Credentials.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Upstream_CredentialsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Upstream_CredentialsValidationError{}
+
+// Validate checks the field values on Upstream_Discovery with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *Upstream_Discovery) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if _, ok := Upstream_Discovery_LoadBalancingPolicy_name[int32(m.GetLoadBalancingPolicy())]; !ok {
+		return Upstream_DiscoveryValidationError{
+			field:  "LoadBalancingPolicy",
+			reason: "value must be one of the defined enum values",
+		}
+	}
+
+	if d := m.GetRefreshInterval(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return Upstream_DiscoveryValidationError{
+				field:  "RefreshInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return Upstream_DiscoveryValidationError{
+				field:  "RefreshInterval",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// Upstream_DiscoveryValidationError is the validation error returned by
+// Upstream_Discovery.Validate if the designated constraints aren't met.
+type Upstream_DiscoveryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Upstream_DiscoveryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Upstream_DiscoveryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Upstream_DiscoveryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Upstream_DiscoveryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Upstream_DiscoveryValidationError) ErrorName() string {
+	return "Upstream_DiscoveryValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e Upstream_DiscoveryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpstream_Discovery.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Upstream_DiscoveryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Upstream_DiscoveryValidationError{}
+
+// Validate checks the field values on Upstream_Credentials_ServiceAccount with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, an error is returned.
+func (m *Upstream_Credentials_ServiceAccount) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetKeyFile()) < 1 {
+		return Upstream_Credentials_ServiceAccountValidationError{
+			field:  "KeyFile",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	// no validation rules for Audience
+
 	return nil
 }
 
-// BootstrapValidationError is the validation error returned by
-// Bootstrap.Validate if the designated constraints aren't met.
-type BootstrapValidationError struct {
+// Upstream_Credentials_ServiceAccountValidationError is the validation error
+// returned by Upstream_Credentials_ServiceAccount.Validate if the designated
+// constraints aren't met.
+type Upstream_Credentials_ServiceAccountValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -158,22 +7274,24 @@ type BootstrapValidationError struct {
 }
 
 // Field function returns field value.
-func (e BootstrapValidationError) Field() string { return e.field }
+func (e Upstream_Credentials_ServiceAccountValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e BootstrapValidationError) Reason() string { return e.reason }
+func (e Upstream_Credentials_ServiceAccountValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e BootstrapValidationError) Cause() error { return e.cause }
+func (e Upstream_Credentials_ServiceAccountValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e BootstrapValidationError) Key() bool { return e.key }
+func (e Upstream_Credentials_ServiceAccountValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e BootstrapValidationError) ErrorName() string { return "BootstrapValidationError" }
+func (e Upstream_Credentials_ServiceAccountValidationError) ErrorName() string {
+	return "Upstream_Credentials_ServiceAccountValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e BootstrapValidationError) Error() string {
+func (e Upstream_Credentials_ServiceAccountValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -185,14 +7303,14 @@ func (e BootstrapValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sBootstrap.%s: %s%s",
+		"invalid %sUpstream_Credentials_ServiceAccount.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = BootstrapValidationError{}
+var _ error = Upstream_Credentials_ServiceAccountValidationError{}
 
 var _ interface {
 	Field() string
@@ -200,38 +7318,193 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = BootstrapValidationError{}
+} = Upstream_Credentials_ServiceAccountValidationError{}
 
-// Validate checks the field values on Server with the rules defined in the
-// proto definition for this message. If any rules are violated, an error is returned.
-func (m *Server) Validate() error {
+// Validate checks the field values on Upstream_Credentials_WorkloadIdentity
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, an error is returned.
+func (m *Upstream_Credentials_WorkloadIdentity) Validate() error {
 	if m == nil {
 		return nil
 	}
 
-	if m.GetAddress() == nil {
-		return ServerValidationError{
-			field:  "Address",
-			reason: "value is required",
+	// no validation rules for Audience
+
+	// no validation rules for MetadataServer
+
+	return nil
+}
+
+// Upstream_Credentials_WorkloadIdentityValidationError is the validation error
+// returned by Upstream_Credentials_WorkloadIdentity.Validate if the
+// designated constraints aren't met.
+type Upstream_Credentials_WorkloadIdentityValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Upstream_Credentials_WorkloadIdentityValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Upstream_Credentials_WorkloadIdentityValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Upstream_Credentials_WorkloadIdentityValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Upstream_Credentials_WorkloadIdentityValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Upstream_Credentials_WorkloadIdentityValidationError) ErrorName() string {
+	return "Upstream_Credentials_WorkloadIdentityValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e Upstream_Credentials_WorkloadIdentityValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpstream_Credentials_WorkloadIdentity.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Upstream_Credentials_WorkloadIdentityValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Upstream_Credentials_WorkloadIdentityValidationError{}
+
+// Validate checks the field values on Upstream_Credentials_ExecPlugin with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *Upstream_Credentials_ExecPlugin) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetCommand()) < 1 {
+		return Upstream_Credentials_ExecPluginValidationError{
+			field:  "Command",
+			reason: "value length must be at least 1 runes",
 		}
 	}
 
-	if v, ok := interface{}(m.GetAddress()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return ServerValidationError{
-				field:  "Address",
-				reason: "embedded message failed validation",
-				cause:  err,
+	// no validation rules for Env
+
+	return nil
+}
+
+// Upstream_Credentials_ExecPluginValidationError is the validation error
+// returned by Upstream_Credentials_ExecPlugin.Validate if the designated
+// constraints aren't met.
+type Upstream_Credentials_ExecPluginValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Upstream_Credentials_ExecPluginValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Upstream_Credentials_ExecPluginValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Upstream_Credentials_ExecPluginValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Upstream_Credentials_ExecPluginValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Upstream_Credentials_ExecPluginValidationError) ErrorName() string {
+	return "Upstream_Credentials_ExecPluginValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e Upstream_Credentials_ExecPluginValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpstream_Credentials_ExecPlugin.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Upstream_Credentials_ExecPluginValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Upstream_Credentials_ExecPluginValidationError{}
+
+// Validate checks the field values on HashRing_StaticMembers with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *HashRing_StaticMembers) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetMembers()) < 1 {
+		return HashRing_StaticMembersValidationError{
+			field:  "Members",
+			reason: "value must contain at least 1 item(s)",
+		}
+	}
+
+	for idx, item := range m.GetMembers() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return HashRing_StaticMembersValidationError{
+					field:  fmt.Sprintf("Members[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
 			}
 		}
+
 	}
 
 	return nil
 }
 
-// ServerValidationError is the validation error returned by Server.Validate if
-// the designated constraints aren't met.
-type ServerValidationError struct {
+// HashRing_StaticMembersValidationError is the validation error returned by
+// HashRing_StaticMembers.Validate if the designated constraints aren't met.
+type HashRing_StaticMembersValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -239,22 +7512,24 @@ type ServerValidationError struct {
 }
 
 // Field function returns field value.
-func (e ServerValidationError) Field() string { return e.field }
+func (e HashRing_StaticMembersValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ServerValidationError) Reason() string { return e.reason }
+func (e HashRing_StaticMembersValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ServerValidationError) Cause() error { return e.cause }
+func (e HashRing_StaticMembersValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ServerValidationError) Key() bool { return e.key }
+func (e HashRing_StaticMembersValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ServerValidationError) ErrorName() string { return "ServerValidationError" }
+func (e HashRing_StaticMembersValidationError) ErrorName() string {
+	return "HashRing_StaticMembersValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e ServerValidationError) Error() string {
+func (e HashRing_StaticMembersValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -266,14 +7541,14 @@ func (e ServerValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sServer.%s: %s%s",
+		"invalid %sHashRing_StaticMembers.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ServerValidationError{}
+var _ error = HashRing_StaticMembersValidationError{}
 
 var _ interface {
 	Field() string
@@ -281,17 +7556,25 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ServerValidationError{}
+} = HashRing_StaticMembersValidationError{}
 
-// Validate checks the field values on Upstream with the rules defined in the
-// proto definition for this message. If any rules are violated, an error is returned.
-func (m *Upstream) Validate() error {
+// Validate checks the field values on HashRing_Member with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *HashRing_Member) Validate() error {
 	if m == nil {
 		return nil
 	}
 
+	if len(m.GetId()) < 1 {
+		return HashRing_MemberValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
 	if m.GetAddress() == nil {
-		return UpstreamValidationError{
+		return HashRing_MemberValidationError{
 			field:  "Address",
 			reason: "value is required",
 		}
@@ -299,7 +7582,7 @@ func (m *Upstream) Validate() error {
 
 	if v, ok := interface{}(m.GetAddress()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return UpstreamValidationError{
+			return HashRing_MemberValidationError{
 				field:  "Address",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -310,9 +7593,9 @@ func (m *Upstream) Validate() error {
 	return nil
 }
 
-// UpstreamValidationError is the validation error returned by
-// Upstream.Validate if the designated constraints aren't met.
-type UpstreamValidationError struct {
+// HashRing_MemberValidationError is the validation error returned by
+// HashRing_Member.Validate if the designated constraints aren't met.
+type HashRing_MemberValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -320,22 +7603,112 @@ type UpstreamValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpstreamValidationError) Field() string { return e.field }
+func (e HashRing_MemberValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpstreamValidationError) Reason() string { return e.reason }
+func (e HashRing_MemberValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpstreamValidationError) Cause() error { return e.cause }
+func (e HashRing_MemberValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpstreamValidationError) Key() bool { return e.key }
+func (e HashRing_MemberValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpstreamValidationError) ErrorName() string { return "UpstreamValidationError" }
+func (e HashRing_MemberValidationError) ErrorName() string { return "HashRing_MemberValidationError" }
+
+// Error satisfies the builtin error interface
+func (e HashRing_MemberValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sHashRing_Member.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = HashRing_MemberValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = HashRing_MemberValidationError{}
+
+// Validate checks the field values on HashRing_KubernetesEndpoints with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *HashRing_KubernetesEndpoints) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetNamespace()) < 1 {
+		return HashRing_KubernetesEndpointsValidationError{
+			field:  "Namespace",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	if len(m.GetName()) < 1 {
+		return HashRing_KubernetesEndpointsValidationError{
+			field:  "Name",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	// no validation rules for PortName
+
+	// no validation rules for ApiServer
+
+	// no validation rules for TokenFile
+
+	// no validation rules for CaFile
+
+	return nil
+}
+
+// HashRing_KubernetesEndpointsValidationError is the validation error returned
+// by HashRing_KubernetesEndpoints.Validate if the designated constraints
+// aren't met.
+type HashRing_KubernetesEndpointsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e HashRing_KubernetesEndpointsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e HashRing_KubernetesEndpointsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e HashRing_KubernetesEndpointsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e HashRing_KubernetesEndpointsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e HashRing_KubernetesEndpointsValidationError) ErrorName() string {
+	return "HashRing_KubernetesEndpointsValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e UpstreamValidationError) Error() string {
+func (e HashRing_KubernetesEndpointsValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -347,14 +7720,14 @@ func (e UpstreamValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpstream.%s: %s%s",
+		"invalid %sHashRing_KubernetesEndpoints.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpstreamValidationError{}
+var _ error = HashRing_KubernetesEndpointsValidationError{}
 
 var _ interface {
 	Field() string
@@ -362,30 +7735,57 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpstreamValidationError{}
+} = HashRing_KubernetesEndpointsValidationError{}
 
-// Validate checks the field values on Logging with the rules defined in the
-// proto definition for this message. If any rules are violated, an error is returned.
-func (m *Logging) Validate() error {
+// Validate checks the field values on UpstreamHealth_KeyTimeout with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *UpstreamHealth_KeyTimeout) Validate() error {
 	if m == nil {
 		return nil
 	}
 
-	// no validation rules for Path
+	if len(m.GetKeyRegex()) < 1 {
+		return UpstreamHealth_KeyTimeoutValidationError{
+			field:  "KeyRegex",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
 
-	if _, ok := Logging_Level_name[int32(m.GetLevel())]; !ok {
-		return LoggingValidationError{
-			field:  "Level",
-			reason: "value must be one of the defined enum values",
+	if m.GetTimeout() == nil {
+		return UpstreamHealth_KeyTimeoutValidationError{
+			field:  "Timeout",
+			reason: "value is required",
 		}
 	}
 
+	if d := m.GetTimeout(); d != nil {
+		dur, err := ptypes.Duration(d)
+		if err != nil {
+			return UpstreamHealth_KeyTimeoutValidationError{
+				field:  "Timeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+		}
+
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+		if dur <= gt {
+			return UpstreamHealth_KeyTimeoutValidationError{
+				field:  "Timeout",
+				reason: "value must be greater than 0s",
+			}
+		}
+
+	}
+
 	return nil
 }
 
-// LoggingValidationError is the validation error returned by Logging.Validate
-// if the designated constraints aren't met.
-type LoggingValidationError struct {
+// UpstreamHealth_KeyTimeoutValidationError is the validation error returned by
+// UpstreamHealth_KeyTimeout.Validate if the designated constraints aren't met.
+type UpstreamHealth_KeyTimeoutValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -393,22 +7793,24 @@ type LoggingValidationError struct {
 }
 
 // Field function returns field value.
-func (e LoggingValidationError) Field() string { return e.field }
+func (e UpstreamHealth_KeyTimeoutValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e LoggingValidationError) Reason() string { return e.reason }
+func (e UpstreamHealth_KeyTimeoutValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e LoggingValidationError) Cause() error { return e.cause }
+func (e UpstreamHealth_KeyTimeoutValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e LoggingValidationError) Key() bool { return e.key }
+func (e UpstreamHealth_KeyTimeoutValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e LoggingValidationError) ErrorName() string { return "LoggingValidationError" }
+func (e UpstreamHealth_KeyTimeoutValidationError) ErrorName() string {
+	return "UpstreamHealth_KeyTimeoutValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e LoggingValidationError) Error() string {
+func (e UpstreamHealth_KeyTimeoutValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -420,14 +7822,14 @@ func (e LoggingValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sLogging.%s: %s%s",
+		"invalid %sUpstreamHealth_KeyTimeout.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = LoggingValidationError{}
+var _ error = UpstreamHealth_KeyTimeoutValidationError{}
 
 var _ interface {
 	Field() string
@@ -435,51 +7837,57 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = LoggingValidationError{}
+} = UpstreamHealth_KeyTimeoutValidationError{}
 
-// Validate checks the field values on Cache with the rules defined in the
-// proto definition for this message. If any rules are violated, an error is returned.
-func (m *Cache) Validate() error {
+// Validate checks the field values on Maintenance_Window with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *Maintenance_Window) Validate() error {
 	if m == nil {
 		return nil
 	}
 
-	if m.GetTtl() == nil {
-		return CacheValidationError{
-			field:  "Ttl",
+	if len(m.GetSchedule()) < 1 {
+		return Maintenance_WindowValidationError{
+			field:  "Schedule",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	if m.GetDuration() == nil {
+		return Maintenance_WindowValidationError{
+			field:  "Duration",
 			reason: "value is required",
 		}
 	}
 
-	if d := m.GetTtl(); d != nil {
+	if d := m.GetDuration(); d != nil {
 		dur, err := ptypes.Duration(d)
 		if err != nil {
-			return CacheValidationError{
-				field:  "Ttl",
+			return Maintenance_WindowValidationError{
+				field:  "Duration",
 				reason: "value is not a valid duration",
 				cause:  err,
 			}
 		}
 
-		gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+		gt := time.Duration(0*time.Second + 0*time.Nanosecond)
 
-		if dur < gte {
-			return CacheValidationError{
-				field:  "Ttl",
-				reason: "value must be greater than or equal to 0s",
+		if dur <= gt {
+			return Maintenance_WindowValidationError{
+				field:  "Duration",
+				reason: "value must be greater than 0s",
 			}
 		}
 
 	}
 
-	// no validation rules for MaxEntries
-
 	return nil
 }
 
-// CacheValidationError is the validation error returned by Cache.Validate if
-// the designated constraints aren't met.
-type CacheValidationError struct {
+// Maintenance_WindowValidationError is the validation error returned by
+// Maintenance_Window.Validate if the designated constraints aren't met.
+type Maintenance_WindowValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -487,22 +7895,24 @@ type CacheValidationError struct {
 }
 
 // Field function returns field value.
-func (e CacheValidationError) Field() string { return e.field }
+func (e Maintenance_WindowValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e CacheValidationError) Reason() string { return e.reason }
+func (e Maintenance_WindowValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e CacheValidationError) Cause() error { return e.cause }
+func (e Maintenance_WindowValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e CacheValidationError) Key() bool { return e.key }
+func (e Maintenance_WindowValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e CacheValidationError) ErrorName() string { return "CacheValidationError" }
+func (e Maintenance_WindowValidationError) ErrorName() string {
+	return "Maintenance_WindowValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e CacheValidationError) Error() string {
+func (e Maintenance_WindowValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -514,14 +7924,14 @@ func (e CacheValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sCache.%s: %s%s",
+		"invalid %sMaintenance_Window.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = CacheValidationError{}
+var _ error = Maintenance_WindowValidationError{}
 
 var _ interface {
 	Field() string
@@ -529,68 +7939,31 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = CacheValidationError{}
+} = Maintenance_WindowValidationError{}
 
-// Validate checks the field values on SocketAddress with the rules defined in
+// Validate checks the field values on Buffers_Buffer with the rules defined in
 // the proto definition for this message. If any rules are violated, an error
 // is returned.
-func (m *SocketAddress) Validate() error {
+func (m *Buffers_Buffer) Validate() error {
 	if m == nil {
 		return nil
 	}
 
-	if err := m._validateHostname(m.GetAddress()); err != nil {
-		if ip := net.ParseIP(m.GetAddress()); ip == nil {
-			return SocketAddressValidationError{
-				field:  "Address",
-				reason: "value must be a valid hostname, or ip address",
-			}
-		}
-	}
-
-	if m.GetPortValue() > 65535 {
-		return SocketAddressValidationError{
-			field:  "PortValue",
-			reason: "value must be less than or equal to 65535",
-		}
-	}
-
-	return nil
-}
-
-func (m *SocketAddress) _validateHostname(host string) error {
-	s := strings.ToLower(strings.TrimSuffix(host, "."))
-
-	if len(host) > 253 {
-		return errors.New("hostname cannot exceed 253 characters")
-	}
-
-	for _, part := range strings.Split(s, ".") {
-		if l := len(part); l == 0 || l > 63 {
-			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
-		}
-
-		if part[0] == '-' {
-			return errors.New("hostname parts cannot begin with hyphens")
-		}
-
-		if part[len(part)-1] == '-' {
-			return errors.New("hostname parts cannot end with hyphens")
-		}
+	// no validation rules for Size
 
-		for _, r := range part {
-			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
-				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
-			}
+	if _, ok := Buffers_OverflowPolicy_name[int32(m.GetOverflowPolicy())]; !ok {
+		return Buffers_BufferValidationError{
+			field:  "OverflowPolicy",
+			reason: "value must be one of the defined enum values",
 		}
 	}
 
 	return nil
 }
 
-// SocketAddressValidationError is the validation error returned by
-// SocketAddress.Validate if the designated constraints aren't met.
-type SocketAddressValidationError struct {
+// Buffers_BufferValidationError is the validation error returned by
+// Buffers_Buffer.Validate if the designated constraints aren't met.
+type Buffers_BufferValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -598,22 +7971,22 @@ type SocketAddressValidationError struct {
 }
 
 // Field function returns field value.
-func (e SocketAddressValidationError) Field() string { return e.field }
+func (e Buffers_BufferValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e SocketAddressValidationError) Reason() string { return e.reason }
+func (e Buffers_BufferValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e SocketAddressValidationError) Cause() error { return e.cause }
+func (e Buffers_BufferValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e SocketAddressValidationError) Key() bool { return e.key }
+func (e Buffers_BufferValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e SocketAddressValidationError) ErrorName() string { return "SocketAddressValidationError" }
+func (e Buffers_BufferValidationError) ErrorName() string { return "Buffers_BufferValidationError" }
 
 // Error satisfies the builtin error interface
-func (e SocketAddressValidationError) Error() string {
+func (e Buffers_BufferValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -625,14 +7998,14 @@ func (e SocketAddressValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sSocketAddress.%s: %s%s",
+		"invalid %sBuffers_Buffer.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = SocketAddressValidationError{}
+var _ error = Buffers_BufferValidationError{}
 
 var _ interface {
 	Field() string
@@ -640,26 +8013,37 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = SocketAddressValidationError{}
+} = Buffers_BufferValidationError{}
 
-// Validate checks the field values on Admin with the rules defined in the
-// proto definition for this message. If any rules are violated, an error is returned.
-func (m *Admin) Validate() error {
+// Validate checks the field values on Buffers_TypeURLBuffers with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *Buffers_TypeURLBuffers) Validate() error {
 	if m == nil {
 		return nil
 	}
 
-	if m.GetAddress() == nil {
-		return AdminValidationError{
-			field:  "Address",
-			reason: "value is required",
+	if len(m.GetTypeUrl()) < 1 {
+		return Buffers_TypeURLBuffersValidationError{
+			field:  "TypeUrl",
+			reason: "value length must be at least 1 bytes",
 		}
 	}
 
-	if v, ok := interface{}(m.GetAddress()).(interface{ Validate() error }); ok {
+	if v, ok := interface{}(m.GetDownstream()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return AdminValidationError{
-				field:  "Address",
+			return Buffers_TypeURLBuffersValidationError{
+				field:  "Downstream",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetUpstream()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Buffers_TypeURLBuffersValidationError{
+				field:  "Upstream",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
@@ -669,9 +8053,9 @@ func (m *Admin) Validate() error {
 	return nil
 }
 
-// AdminValidationError is the validation error returned by Admin.Validate if
-// the designated constraints aren't met.
-type AdminValidationError struct {
+// Buffers_TypeURLBuffersValidationError is the validation error returned by
+// Buffers_TypeURLBuffers.Validate if the designated constraints aren't met.
+type Buffers_TypeURLBuffersValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -679,22 +8063,24 @@ type AdminValidationError struct {
 }
 
 // Field function returns field value.
-func (e AdminValidationError) Field() string { return e.field }
+func (e Buffers_TypeURLBuffersValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e AdminValidationError) Reason() string { return e.reason }
+func (e Buffers_TypeURLBuffersValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e AdminValidationError) Cause() error { return e.cause }
+func (e Buffers_TypeURLBuffersValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e AdminValidationError) Key() bool { return e.key }
+func (e Buffers_TypeURLBuffersValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e AdminValidationError) ErrorName() string { return "AdminValidationError" }
+func (e Buffers_TypeURLBuffersValidationError) ErrorName() string {
+	return "Buffers_TypeURLBuffersValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e AdminValidationError) Error() string {
+func (e Buffers_TypeURLBuffersValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -706,14 +8092,14 @@ func (e AdminValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sAdmin.%s: %s%s",
+		"invalid %sBuffers_TypeURLBuffers.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = AdminValidationError{}
+var _ error = Buffers_TypeURLBuffersValidationError{}
 
 var _ interface {
 	Field() string
@@ -721,44 +8107,31 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = AdminValidationError{}
+} = Buffers_TypeURLBuffersValidationError{}
 
-// Validate checks the field values on MetricsSink with the rules defined in
-// the proto definition for this message. If any rules are violated, an error
-// is returned.
-func (m *MetricsSink) Validate() error {
+// Validate checks the field values on KeyMetrics_Rule with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *KeyMetrics_Rule) Validate() error {
 	if m == nil {
 		return nil
 	}
 
-	switch m.Type.(type) {
-
-	case *MetricsSink_Statsd:
-
-		if v, ok := interface{}(m.GetStatsd()).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return MetricsSinkValidationError{
-					field:  "Statsd",
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
-
-	default:
-		return MetricsSinkValidationError{
-			field:  "Type",
-			reason: "value is required",
+	if len(m.GetKeyRegex()) < 1 {
+		return KeyMetrics_RuleValidationError{
+			field:  "KeyRegex",
+			reason: "value length must be at least 1 bytes",
 		}
-
 	}
 
+	// no validation rules for Bucket
+
 	return nil
 }
 
-// MetricsSinkValidationError is the validation error returned by
-// MetricsSink.Validate if the designated constraints aren't met.
-type MetricsSinkValidationError struct {
+// KeyMetrics_RuleValidationError is the validation error returned by
+// KeyMetrics_Rule.Validate if the designated constraints aren't met.
+type KeyMetrics_RuleValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -766,22 +8139,22 @@ type MetricsSinkValidationError struct {
 }
 
 // Field function returns field value.
-func (e MetricsSinkValidationError) Field() string { return e.field }
+func (e KeyMetrics_RuleValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e MetricsSinkValidationError) Reason() string { return e.reason }
+func (e KeyMetrics_RuleValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e MetricsSinkValidationError) Cause() error { return e.cause }
+func (e KeyMetrics_RuleValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e MetricsSinkValidationError) Key() bool { return e.key }
+func (e KeyMetrics_RuleValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e MetricsSinkValidationError) ErrorName() string { return "MetricsSinkValidationError" }
+func (e KeyMetrics_RuleValidationError) ErrorName() string { return "KeyMetrics_RuleValidationError" }
 
 // Error satisfies the builtin error interface
-func (e MetricsSinkValidationError) Error() string {
+func (e KeyMetrics_RuleValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -793,14 +8166,14 @@ func (e MetricsSinkValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sMetricsSink.%s: %s%s",
+		"invalid %sKeyMetrics_Rule.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = MetricsSinkValidationError{}
+var _ error = KeyMetrics_RuleValidationError{}
 
 var _ interface {
 	Field() string
@@ -808,73 +8181,53 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = MetricsSinkValidationError{}
+} = KeyMetrics_RuleValidationError{}
 
-// Validate checks the field values on Statsd with the rules defined in the
-// proto definition for this message. If any rules are violated, an error is returned.
-func (m *Statsd) Validate() error {
+// Validate checks the field values on AggregationRollout_Revision with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *AggregationRollout_Revision) Validate() error {
 	if m == nil {
 		return nil
 	}
 
-	if m.GetAddress() == nil {
-		return StatsdValidationError{
-			field:  "Address",
-			reason: "value is required",
+	if utf8.RuneCountInString(m.GetName()) < 1 {
+		return AggregationRollout_RevisionValidationError{
+			field:  "Name",
+			reason: "value length must be at least 1 runes",
 		}
 	}
 
-	if v, ok := interface{}(m.GetAddress()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return StatsdValidationError{
-				field:  "Address",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
+	if !_AggregationRollout_Revision_Name_Pattern.MatchString(m.GetName()) {
+		return AggregationRollout_RevisionValidationError{
+			field:  "Name",
+			reason: "value does not match regex pattern \"^[A-Za-z0-9_.-]+$\"",
 		}
 	}
 
-	if len(m.GetRootPrefix()) < 1 {
-		return StatsdValidationError{
-			field:  "RootPrefix",
-			reason: "value length must be at least 1 bytes",
+	if utf8.RuneCountInString(m.GetPath()) < 1 {
+		return AggregationRollout_RevisionValidationError{
+			field:  "Path",
+			reason: "value length must be at least 1 runes",
 		}
 	}
 
-	if m.GetFlushInterval() == nil {
-		return StatsdValidationError{
-			field:  "FlushInterval",
-			reason: "value is required",
+	if val := m.GetPercentage(); val < 0 || val > 100 {
+		return AggregationRollout_RevisionValidationError{
+			field:  "Percentage",
+			reason: "value must be inside range [0, 100]",
 		}
 	}
 
-	if d := m.GetFlushInterval(); d != nil {
-		dur, err := ptypes.Duration(d)
-		if err != nil {
-			return StatsdValidationError{
-				field:  "FlushInterval",
-				reason: "value is not a valid duration",
-				cause:  err,
-			}
-		}
-
-		gte := time.Duration(0*time.Second + 0*time.Nanosecond)
-
-		if dur < gte {
-			return StatsdValidationError{
-				field:  "FlushInterval",
-				reason: "value must be greater than or equal to 0s",
-			}
-		}
-
-	}
+	// no validation rules for NodeMetadata
 
 	return nil
 }
 
-// StatsdValidationError is the validation error returned by Statsd.Validate if
-// the designated constraints aren't met.
-type StatsdValidationError struct {
+// AggregationRollout_RevisionValidationError is the validation error returned
+// by AggregationRollout_Revision.Validate if the designated constraints
+// aren't met.
+type AggregationRollout_RevisionValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -882,22 +8235,24 @@ type StatsdValidationError struct {
 }
 
 // Field function returns field value.
-func (e StatsdValidationError) Field() string { return e.field }
+func (e AggregationRollout_RevisionValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e StatsdValidationError) Reason() string { return e.reason }
+func (e AggregationRollout_RevisionValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e StatsdValidationError) Cause() error { return e.cause }
+func (e AggregationRollout_RevisionValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e StatsdValidationError) Key() bool { return e.key }
+func (e AggregationRollout_RevisionValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e StatsdValidationError) ErrorName() string { return "StatsdValidationError" }
+func (e AggregationRollout_RevisionValidationError) ErrorName() string {
+	return "AggregationRollout_RevisionValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e StatsdValidationError) Error() string {
+func (e AggregationRollout_RevisionValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -909,14 +8264,14 @@ func (e StatsdValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sStatsd.%s: %s%s",
+		"invalid %sAggregationRollout_Revision.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = StatsdValidationError{}
+var _ error = AggregationRollout_RevisionValidationError{}
 
 var _ interface {
 	Field() string
@@ -924,4 +8279,6 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = StatsdValidationError{}
+} = AggregationRollout_RevisionValidationError{}
+
+var _AggregationRollout_Revision_Name_Pattern = regexp.MustCompile("^[A-Za-z0-9_.-]+$")
@@ -0,0 +1,403 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.20.1
+// 	protoc        v3.11.4
+// source: override/v1/override.proto
+
+package overridev1
+
+import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	proto "github.com/golang/protobuf/proto"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// The contents of an override file. Overrides patch upstream resources before they are cached and served, so that
+// emergency configuration fixes can be applied at the relay.
+// [#next-free-field: 2]
+type Overrides struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Overrides []*Override `protobuf:"bytes,1,rep,name=overrides,proto3" json:"overrides,omitempty"`
+}
+
+func (x *Overrides) Reset() {
+	*x = Overrides{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_override_v1_override_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Overrides) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Overrides) ProtoMessage() {}
+
+func (x *Overrides) ProtoReflect() protoreflect.Message {
+	mi := &file_override_v1_override_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Overrides.ProtoReflect.Descriptor instead.
+func (*Overrides) Descriptor() ([]byte, []int) {
+	return file_override_v1_override_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Overrides) GetOverrides() []*Override {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+// A patch of a single resource. Patches of the same resource are applied in file name order, and then in the order
+// they appear in the file.
+// [#next-free-field: 6]
+type Override struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The aggregated key of the responses containing the resource.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The name of the resource, e.g. `Cluster.name`, `ClusterLoadAssignment.cluster_name` or `Runtime.name`.
+	ResourceName string `protobuf:"bytes,2,opt,name=resource_name,json=resourceName,proto3" json:"resource_name,omitempty"`
+	// Types that are assignable to Patch:
+	//	*Override_MergePatch
+	//	*Override_FieldMaskPatch
+	//	*Override_RuntimeLayer
+	Patch isOverride_Patch `protobuf_oneof:"patch"`
+}
+
+func (x *Override) Reset() {
+	*x = Override{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_override_v1_override_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Override) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Override) ProtoMessage() {}
+
+func (x *Override) ProtoReflect() protoreflect.Message {
+	mi := &file_override_v1_override_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Override.ProtoReflect.Descriptor instead.
+func (*Override) Descriptor() ([]byte, []int) {
+	return file_override_v1_override_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Override) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Override) GetResourceName() string {
+	if x != nil {
+		return x.ResourceName
+	}
+	return ""
+}
+
+func (m *Override) GetPatch() isOverride_Patch {
+	if m != nil {
+		return m.Patch
+	}
+	return nil
+}
+
+func (x *Override) GetMergePatch() *_struct.Struct {
+	if x, ok := x.GetPatch().(*Override_MergePatch); ok {
+		return x.MergePatch
+	}
+	return nil
+}
+
+func (x *Override) GetFieldMaskPatch() *FieldMaskPatch {
+	if x, ok := x.GetPatch().(*Override_FieldMaskPatch); ok {
+		return x.FieldMaskPatch
+	}
+	return nil
+}
+
+func (x *Override) GetRuntimeLayer() *_struct.Struct {
+	if x, ok := x.GetPatch().(*Override_RuntimeLayer); ok {
+		return x.RuntimeLayer
+	}
+	return nil
+}
+
+type isOverride_Patch interface {
+	isOverride_Patch()
+}
+
+type Override_MergePatch struct {
+	// A JSON merge patch (RFC 7386) of the JSON representation of the resource. Object keys are proto field names,
+	// and null removes the field. Resources holding `Any` fields of types unknown to the relay cannot be converted
+	// to JSON, and must be patched with a field mask patch instead.
+	MergePatch *_struct.Struct `protobuf:"bytes,3,opt,name=merge_patch,json=mergePatch,proto3,oneof"`
+}
+
+type Override_FieldMaskPatch struct {
+	FieldMaskPatch *FieldMaskPatch `protobuf:"bytes,4,opt,name=field_mask_patch,json=fieldMaskPatch,proto3,oneof"`
+}
+
+type Override_RuntimeLayer struct {
+	// Runtime values merged into the layer of an RTDS `Runtime` resource, so that runtime flags can be flipped at
+	// the relay when the origin server is slow to update. Nested objects are flattened into dot-separated runtime
+	// keys, as Envoy does, so `{"envoy.feature": true}` and `{"envoy": {"feature": true}}` set the same key. The
+	// values replace those of the origin server, and null removes the key and the keys nested under it.
+	RuntimeLayer *_struct.Struct `protobuf:"bytes,5,opt,name=runtime_layer,json=runtimeLayer,proto3,oneof"`
+}
+
+func (*Override_MergePatch) isOverride_Patch() {}
+
+func (*Override_FieldMaskPatch) isOverride_Patch() {}
+
+func (*Override_RuntimeLayer) isOverride_Patch() {}
+
+// Replaces the fields of the resource at the paths with those of the value. Fields at the paths that are unset in the
+// value are cleared.
+// [#next-free-field: 3]
+type FieldMaskPatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Dot-separated paths of proto field names relative to the resource, e.g.
+	// `common_lb_config.healthy_panic_threshold`.
+	Paths []string `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+	// The JSON representation of a resource of the patched type.
+	Value *_struct.Struct `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *FieldMaskPatch) Reset() {
+	*x = FieldMaskPatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_override_v1_override_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FieldMaskPatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldMaskPatch) ProtoMessage() {}
+
+func (x *FieldMaskPatch) ProtoReflect() protoreflect.Message {
+	mi := &file_override_v1_override_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldMaskPatch.ProtoReflect.Descriptor instead.
+func (*FieldMaskPatch) Descriptor() ([]byte, []int) {
+	return file_override_v1_override_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FieldMaskPatch) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *FieldMaskPatch) GetValue() *_struct.Struct {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+var File_override_v1_override_proto protoreflect.FileDescriptor
+
+var file_override_v1_override_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x76,
+	0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x6f, 0x76,
+	0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3d, 0x0a,
+	0x09, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x09, 0x6f, 0x76,
+	0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
+	0x65, 0x52, 0x09, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x22, 0xa3, 0x02, 0x0a,
+	0x08, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12, 0x19, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x20, 0x01, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04,
+	0x72, 0x02, 0x20, 0x01, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x63,
+	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x48, 0x00, 0x52, 0x0a, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x50, 0x61, 0x74, 0x63, 0x68, 0x12, 0x44,
+	0x0a, 0x10, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x5f, 0x70, 0x61, 0x74,
+	0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x76, 0x65, 0x72, 0x72,
+	0x69, 0x64, 0x65, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x50, 0x61, 0x74,
+	0x63, 0x68, 0x48, 0x00, 0x52, 0x0e, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x50,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x3e, 0x0a, 0x0d, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4c,
+	0x61, 0x79, 0x65, 0x72, 0x42, 0x0c, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x12, 0x03, 0xf8,
+	0x42, 0x01, 0x22, 0x69, 0x0a, 0x0e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x50,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x1e, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x92, 0x01, 0x02, 0x08, 0x01, 0x52, 0x05, 0x70,
+	0x61, 0x74, 0x68, 0x73, 0x12, 0x37, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x42, 0x08, 0xfa, 0x42,
+	0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x5a,
+	0x16, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x6f, 0x76, 0x65,
+	0x72, 0x72, 0x69, 0x64, 0x65, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_override_v1_override_proto_rawDescOnce sync.Once
+	file_override_v1_override_proto_rawDescData = file_override_v1_override_proto_rawDesc
+)
+
+func file_override_v1_override_proto_rawDescGZIP() []byte {
+	file_override_v1_override_proto_rawDescOnce.Do(func() {
+		file_override_v1_override_proto_rawDescData = protoimpl.X.CompressGZIP(file_override_v1_override_proto_rawDescData)
+	})
+	return file_override_v1_override_proto_rawDescData
+}
+
+var file_override_v1_override_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_override_v1_override_proto_goTypes = []interface{}{
+	(*Overrides)(nil),      // 0: override.Overrides
+	(*Override)(nil),       // 1: override.Override
+	(*FieldMaskPatch)(nil), // 2: override.FieldMaskPatch
+	(*_struct.Struct)(nil), // 3: google.protobuf.Struct
+}
+var file_override_v1_override_proto_depIdxs = []int32{
+	1, // 0: override.Overrides.overrides:type_name -> override.Override
+	3, // 1: override.Override.merge_patch:type_name -> google.protobuf.Struct
+	2, // 2: override.Override.field_mask_patch:type_name -> override.FieldMaskPatch
+	3, // 3: override.Override.runtime_layer:type_name -> google.protobuf.Struct
+	3, // 4: override.FieldMaskPatch.value:type_name -> google.protobuf.Struct
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_override_v1_override_proto_init() }
+func file_override_v1_override_proto_init() {
+	if File_override_v1_override_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_override_v1_override_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Overrides); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_override_v1_override_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Override); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_override_v1_override_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FieldMaskPatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_override_v1_override_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*Override_MergePatch)(nil),
+		(*Override_FieldMaskPatch)(nil),
+		(*Override_RuntimeLayer)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_override_v1_override_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_override_v1_override_proto_goTypes,
+		DependencyIndexes: file_override_v1_override_proto_depIdxs,
+		MessageInfos:      file_override_v1_override_proto_msgTypes,
+	}.Build()
+	File_override_v1_override_proto = out.File
+	file_override_v1_override_proto_rawDesc = nil
+	file_override_v1_override_proto_goTypes = nil
+	file_override_v1_override_proto_depIdxs = nil
+}
@@ -0,0 +1,329 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: override/v1/override.proto
+
+package overridev1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = ptypes.DynamicAny{}
+)
+
+// define the regex for a UUID once up-front
+var _override_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
+// Validate checks the field values on Overrides with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Overrides) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	for idx, item := range m.GetOverrides() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return OverridesValidationError{
+					field:  fmt.Sprintf("Overrides[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// OverridesValidationError is the validation error returned by
+// Overrides.Validate if the designated constraints aren't met.
+type OverridesValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e OverridesValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e OverridesValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e OverridesValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e OverridesValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e OverridesValidationError) ErrorName() string { return "OverridesValidationError" }
+
+// Error satisfies the builtin error interface
+func (e OverridesValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sOverrides.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = OverridesValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = OverridesValidationError{}
+
+// Validate checks the field values on Override with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Override) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetKey()) < 1 {
+		return OverrideValidationError{
+			field:  "Key",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	if len(m.GetResourceName()) < 1 {
+		return OverrideValidationError{
+			field:  "ResourceName",
+			reason: "value length must be at least 1 bytes",
+		}
+	}
+
+	switch m.Patch.(type) {
+
+	case *Override_MergePatch:
+
+		if v, ok := interface{}(m.GetMergePatch()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return OverrideValidationError{
+					field:  "MergePatch",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Override_FieldMaskPatch:
+
+		if v, ok := interface{}(m.GetFieldMaskPatch()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return OverrideValidationError{
+					field:  "FieldMaskPatch",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Override_RuntimeLayer:
+
+		if v, ok := interface{}(m.GetRuntimeLayer()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return OverrideValidationError{
+					field:  "RuntimeLayer",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		return OverrideValidationError{
+			field:  "Patch",
+			reason: "value is required",
+		}
+
+	}
+
+	return nil
+}
+
+// OverrideValidationError is the validation error returned by
+// Override.Validate if the designated constraints aren't met.
+type OverrideValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e OverrideValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e OverrideValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e OverrideValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e OverrideValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e OverrideValidationError) ErrorName() string { return "OverrideValidationError" }
+
+// Error satisfies the builtin error interface
+func (e OverrideValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sOverride.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = OverrideValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = OverrideValidationError{}
+
+// Validate checks the field values on FieldMaskPatch with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *FieldMaskPatch) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetPaths()) < 1 {
+		return FieldMaskPatchValidationError{
+			field:  "Paths",
+			reason: "value must contain at least 1 item(s)",
+		}
+	}
+
+	if m.GetValue() == nil {
+		return FieldMaskPatchValidationError{
+			field:  "Value",
+			reason: "value is required",
+		}
+	}
+
+	if v, ok := interface{}(m.GetValue()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return FieldMaskPatchValidationError{
+				field:  "Value",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// FieldMaskPatchValidationError is the validation error returned by
+// FieldMaskPatch.Validate if the designated constraints aren't met.
+type FieldMaskPatchValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FieldMaskPatchValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FieldMaskPatchValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FieldMaskPatchValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FieldMaskPatchValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FieldMaskPatchValidationError) ErrorName() string { return "FieldMaskPatchValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FieldMaskPatchValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFieldMaskPatch.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FieldMaskPatchValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FieldMaskPatchValidationError{}
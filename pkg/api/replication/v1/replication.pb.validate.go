@@ -0,0 +1,195 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: replication/v1/replication.proto
+
+package replicationv1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = ptypes.DynamicAny{}
+)
+
+// define the regex for a UUID once up-front
+var _replication_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
+// Validate checks the field values on StreamUpdatesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *StreamUpdatesRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for PeerId
+
+	return nil
+}
+
+// StreamUpdatesRequestValidationError is the validation error returned by
+// StreamUpdatesRequest.Validate if the designated constraints aren't met.
+type StreamUpdatesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StreamUpdatesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StreamUpdatesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StreamUpdatesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StreamUpdatesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StreamUpdatesRequestValidationError) ErrorName() string {
+	return "StreamUpdatesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e StreamUpdatesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStreamUpdatesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StreamUpdatesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StreamUpdatesRequestValidationError{}
+
+// Validate checks the field values on CacheUpdate with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *CacheUpdate) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Key
+
+	// no validation rules for Version
+
+	if v, ok := interface{}(m.GetResponse()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CacheUpdateValidationError{
+				field:  "Response",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetUpdateTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CacheUpdateValidationError{
+				field:  "UpdateTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// CacheUpdateValidationError is the validation error returned by
+// CacheUpdate.Validate if the designated constraints aren't met.
+type CacheUpdateValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CacheUpdateValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CacheUpdateValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CacheUpdateValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CacheUpdateValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CacheUpdateValidationError) ErrorName() string { return "CacheUpdateValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CacheUpdateValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCacheUpdate.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CacheUpdateValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CacheUpdateValidationError{}
@@ -0,0 +1,534 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.20.1
+// 	protoc        v3.11.4
+// source: status/v1/status.proto
+
+package statusv1
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type KeyStatusEvent_Type int32
+
+const (
+	// The cached response of the key changed to the version.
+	KeyStatusEvent_VERSION_CHANGED KeyStatusEvent_Type = 0
+	// The node acknowledged the version.
+	KeyStatusEvent_ACKED KeyStatusEvent_Type = 1
+	// The node rejected a response. The version is the last version the node acknowledged.
+	KeyStatusEvent_NACKED KeyStatusEvent_Type = 2
+)
+
+// Enum value maps for KeyStatusEvent_Type.
+var (
+	KeyStatusEvent_Type_name = map[int32]string{
+		0: "VERSION_CHANGED",
+		1: "ACKED",
+		2: "NACKED",
+	}
+	KeyStatusEvent_Type_value = map[string]int32{
+		"VERSION_CHANGED": 0,
+		"ACKED":           1,
+		"NACKED":          2,
+	}
+)
+
+func (x KeyStatusEvent_Type) Enum() *KeyStatusEvent_Type {
+	p := new(KeyStatusEvent_Type)
+	*p = x
+	return p
+}
+
+func (x KeyStatusEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KeyStatusEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_status_v1_status_proto_enumTypes[0].Descriptor()
+}
+
+func (KeyStatusEvent_Type) Type() protoreflect.EnumType {
+	return &file_status_v1_status_proto_enumTypes[0]
+}
+
+func (x KeyStatusEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KeyStatusEvent_Type.Descriptor instead.
+func (KeyStatusEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_status_v1_status_proto_rawDescGZIP(), []int{1, 0}
+}
+
+// [#next-free-field: 2]
+type StreamKeyStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The aggregated keys to stream events of. Events of all keys are streamed if empty.
+	Keys []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (x *StreamKeyStatusRequest) Reset() {
+	*x = StreamKeyStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_status_v1_status_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamKeyStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamKeyStatusRequest) ProtoMessage() {}
+
+func (x *StreamKeyStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_status_v1_status_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamKeyStatusRequest.ProtoReflect.Descriptor instead.
+func (*StreamKeyStatusRequest) Descriptor() ([]byte, []int) {
+	return file_status_v1_status_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StreamKeyStatusRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// [#next-free-field: 7]
+type KeyStatusEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type KeyStatusEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=status.KeyStatusEvent_Type" json:"type,omitempty"`
+	// The aggregated key of the event.
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// The version_info of the response.
+	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// The ID of the node that acknowledged or rejected the response. Empty for VERSION_CHANGED events.
+	NodeId string `protobuf:"bytes,4,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// The error message of the node that rejected the response. Empty unless the event is NACKED.
+	ErrorDetail string `protobuf:"bytes,5,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
+	// When the relay observed the event.
+	Time *timestamp.Timestamp `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *KeyStatusEvent) Reset() {
+	*x = KeyStatusEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_status_v1_status_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyStatusEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyStatusEvent) ProtoMessage() {}
+
+func (x *KeyStatusEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_status_v1_status_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyStatusEvent.ProtoReflect.Descriptor instead.
+func (*KeyStatusEvent) Descriptor() ([]byte, []int) {
+	return file_status_v1_status_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KeyStatusEvent) GetType() KeyStatusEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return KeyStatusEvent_VERSION_CHANGED
+}
+
+func (x *KeyStatusEvent) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KeyStatusEvent) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *KeyStatusEvent) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *KeyStatusEvent) GetErrorDetail() string {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return ""
+}
+
+func (x *KeyStatusEvent) GetTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+// Detail of the status of downstream streams and fetches that xds-relay fails, which identifies the cause of the
+// failure.
+// [#next-free-field: 3]
+type ErrorInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The cause of the failure, e.g. KEY_NOT_MAPPED or UPSTREAM_UNAVAILABLE.
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The aggregated key of the failed request. Empty if the request was not mapped to a key.
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *ErrorInfo) Reset() {
+	*x = ErrorInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_status_v1_status_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorInfo) ProtoMessage() {}
+
+func (x *ErrorInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_status_v1_status_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorInfo.ProtoReflect.Descriptor instead.
+func (*ErrorInfo) Descriptor() ([]byte, []int) {
+	return file_status_v1_status_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ErrorInfo) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ErrorInfo) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+var File_status_v1_status_proto protoreflect.FileDescriptor
+
+var file_status_v1_status_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x2c, 0x0a, 0x16, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4b, 0x65, 0x79, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x22,
+	0x8d, 0x02, 0x0a, 0x0e, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1b, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x2e, 0x0a, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x32, 0x0a, 0x04, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x43,
+	0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x43, 0x4b, 0x45,
+	0x44, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x41, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x02, 0x22,
+	0x35, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x32, 0x5e, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4d, 0x0a, 0x0f, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x2e, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x42, 0x14, 0x5a, 0x12, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x2f, 0x76, 0x31, 0x3b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_status_v1_status_proto_rawDescOnce sync.Once
+	file_status_v1_status_proto_rawDescData = file_status_v1_status_proto_rawDesc
+)
+
+func file_status_v1_status_proto_rawDescGZIP() []byte {
+	file_status_v1_status_proto_rawDescOnce.Do(func() {
+		file_status_v1_status_proto_rawDescData = protoimpl.X.CompressGZIP(file_status_v1_status_proto_rawDescData)
+	})
+	return file_status_v1_status_proto_rawDescData
+}
+
+var file_status_v1_status_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_status_v1_status_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_status_v1_status_proto_goTypes = []interface{}{
+	(KeyStatusEvent_Type)(0),       // 0: status.KeyStatusEvent.Type
+	(*StreamKeyStatusRequest)(nil), // 1: status.StreamKeyStatusRequest
+	(*KeyStatusEvent)(nil),         // 2: status.KeyStatusEvent
+	(*ErrorInfo)(nil),              // 3: status.ErrorInfo
+	(*timestamp.Timestamp)(nil),    // 4: google.protobuf.Timestamp
+}
+var file_status_v1_status_proto_depIdxs = []int32{
+	0, // 0: status.KeyStatusEvent.type:type_name -> status.KeyStatusEvent.Type
+	4, // 1: status.KeyStatusEvent.time:type_name -> google.protobuf.Timestamp
+	1, // 2: status.StatusService.StreamKeyStatus:input_type -> status.StreamKeyStatusRequest
+	2, // 3: status.StatusService.StreamKeyStatus:output_type -> status.KeyStatusEvent
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_status_v1_status_proto_init() }
+func file_status_v1_status_proto_init() {
+	if File_status_v1_status_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_status_v1_status_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamKeyStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_status_v1_status_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyStatusEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_status_v1_status_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_status_v1_status_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_status_v1_status_proto_goTypes,
+		DependencyIndexes: file_status_v1_status_proto_depIdxs,
+		EnumInfos:         file_status_v1_status_proto_enumTypes,
+		MessageInfos:      file_status_v1_status_proto_msgTypes,
+	}.Build()
+	File_status_v1_status_proto = out.File
+	file_status_v1_status_proto_rawDesc = nil
+	file_status_v1_status_proto_goTypes = nil
+	file_status_v1_status_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// StatusServiceClient is the client API for StatusService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type StatusServiceClient interface {
+	// Sends an event whenever the cached version of an aggregated key changes, or a node acknowledges or rejects a
+	// response, until the client disconnects or falls too far behind.
+	StreamKeyStatus(ctx context.Context, in *StreamKeyStatusRequest, opts ...grpc.CallOption) (StatusService_StreamKeyStatusClient, error)
+}
+
+type statusServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStatusServiceClient(cc grpc.ClientConnInterface) StatusServiceClient {
+	return &statusServiceClient{cc}
+}
+
+func (c *statusServiceClient) StreamKeyStatus(ctx context.Context, in *StreamKeyStatusRequest, opts ...grpc.CallOption) (StatusService_StreamKeyStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StatusService_serviceDesc.Streams[0], "/status.StatusService/StreamKeyStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &statusServiceStreamKeyStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StatusService_StreamKeyStatusClient interface {
+	Recv() (*KeyStatusEvent, error)
+	grpc.ClientStream
+}
+
+type statusServiceStreamKeyStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *statusServiceStreamKeyStatusClient) Recv() (*KeyStatusEvent, error) {
+	m := new(KeyStatusEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StatusServiceServer is the server API for StatusService service.
+type StatusServiceServer interface {
+	// Sends an event whenever the cached version of an aggregated key changes, or a node acknowledges or rejects a
+	// response, until the client disconnects or falls too far behind.
+	StreamKeyStatus(*StreamKeyStatusRequest, StatusService_StreamKeyStatusServer) error
+}
+
+// UnimplementedStatusServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedStatusServiceServer struct {
+}
+
+func (*UnimplementedStatusServiceServer) StreamKeyStatus(*StreamKeyStatusRequest, StatusService_StreamKeyStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamKeyStatus not implemented")
+}
+
+func RegisterStatusServiceServer(s *grpc.Server, srv StatusServiceServer) {
+	s.RegisterService(&_StatusService_serviceDesc, srv)
+}
+
+func _StatusService_StreamKeyStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamKeyStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StatusServiceServer).StreamKeyStatus(m, &statusServiceStreamKeyStatusServer{stream})
+}
+
+type StatusService_StreamKeyStatusServer interface {
+	Send(*KeyStatusEvent) error
+	grpc.ServerStream
+}
+
+type statusServiceStreamKeyStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *statusServiceStreamKeyStatusServer) Send(m *KeyStatusEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _StatusService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "status.StatusService",
+	HandlerType: (*StatusServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamKeyStatus",
+			Handler:       _StatusService_StreamKeyStatus_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "status/v1/status.proto",
+}
@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: status/v1/status.proto
+
+package statusv1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = ptypes.DynamicAny{}
+)
+
+// define the regex for a UUID once up-front
+var _status_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
+// Validate checks the field values on StreamKeyStatusRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *StreamKeyStatusRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	return nil
+}
+
+// StreamKeyStatusRequestValidationError is the validation error returned by
+// StreamKeyStatusRequest.Validate if the designated constraints aren't met.
+type StreamKeyStatusRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StreamKeyStatusRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StreamKeyStatusRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StreamKeyStatusRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StreamKeyStatusRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StreamKeyStatusRequestValidationError) ErrorName() string {
+	return "StreamKeyStatusRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e StreamKeyStatusRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStreamKeyStatusRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StreamKeyStatusRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StreamKeyStatusRequestValidationError{}
+
+// Validate checks the field values on KeyStatusEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *KeyStatusEvent) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Type
+
+	// no validation rules for Key
+
+	// no validation rules for Version
+
+	// no validation rules for NodeId
+
+	// no validation rules for ErrorDetail
+
+	if v, ok := interface{}(m.GetTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KeyStatusEventValidationError{
+				field:  "Time",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// KeyStatusEventValidationError is the validation error returned by
+// KeyStatusEvent.Validate if the designated constraints aren't met.
+type KeyStatusEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KeyStatusEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KeyStatusEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KeyStatusEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KeyStatusEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KeyStatusEventValidationError) ErrorName() string { return "KeyStatusEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e KeyStatusEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKeyStatusEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KeyStatusEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KeyStatusEventValidationError{}
+
+// Validate checks the field values on ErrorInfo with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *ErrorInfo) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Reason
+
+	// no validation rules for Key
+
+	return nil
+}
+
+// ErrorInfoValidationError is the validation error returned by
+// ErrorInfo.Validate if the designated constraints aren't met.
+type ErrorInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ErrorInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ErrorInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ErrorInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ErrorInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ErrorInfoValidationError) ErrorName() string { return "ErrorInfoValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ErrorInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sErrorInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ErrorInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ErrorInfoValidationError{}
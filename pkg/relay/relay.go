@@ -0,0 +1,166 @@
+// Package relay runs xds-relay embedded in another Go program. The components of the relay that are configured from
+// the bootstrap by the standalone binary, i.e. the logger, metrics, aggregation mapper, cache, and upstream client,
+// can be replaced by the program's own implementations.
+package relay
+
+import (
+	"context"
+
+	gcp "github.com/envoyproxy/go-control-plane/pkg/server/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/cache"
+	"github.com/envoyproxy/xds-relay/internal/app/interceptor"
+	"github.com/envoyproxy/xds-relay/internal/app/mapper"
+	"github.com/envoyproxy/xds-relay/internal/app/server"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/log"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/yamlproto"
+	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/uber-go/tally"
+)
+
+type (
+	// Logger is the logger of the relay. See NewLogger, NewZap and NewLogr for implementations.
+	Logger = log.Logger
+	// LogrSink is the subset of a logr.Logger that NewLogr adapts.
+	LogrSink = log.LogrSink
+
+	// Mapper maps discovery requests to their aggregated keys.
+	Mapper = mapper.Mapper
+
+	// Cache keeps the latest response and the open watches of each aggregated key.
+	Cache = cache.Cache
+	// ReadOnlyCache is the read-only view of a Cache.
+	ReadOnlyCache = cache.ReadOnlyCache
+	// CacheResource is the cache entry of an aggregated key.
+	CacheResource = cache.Resource
+	// WatchID identifies a downstream watch in a Cache.
+	WatchID = cache.WatchID
+	// OnEvictFunc must be called by a Cache for each key that it evicts.
+	OnEvictFunc = cache.OnEvictFunc
+	// CacheFactory creates the cache of the relay.
+	CacheFactory = cache.Factory
+
+	// UpstreamClient opens streams to the origin server.
+	UpstreamClient = upstream.Client
+	// UpstreamDialer creates the clients of the origin server and of the shadow origin server.
+	UpstreamDialer = upstream.Dialer
+	// CallOptions are the options of the calls of an UpstreamClient.
+	CallOptions = upstream.CallOptions
+
+	// Interceptors are interceptors of the streams and calls of the xDS server, in the order in which they run.
+	Interceptors = interceptor.Interceptors
+
+	// Callbacks are called by the xDS server when streams open and close, and before and after each request and
+	// response of streams and fetches.
+	Callbacks = gcp.Callbacks
+	// CallbackFuncs implements Callbacks with functions. Callbacks whose function is nil do nothing.
+	CallbackFuncs = server.CallbackFuncs
+)
+
+var (
+	// NewLogger creates the default logger at the log level, e.g. "info".
+	NewLogger = log.New
+	// NewZap adapts a zap logger.
+	NewZap = log.NewZap
+	// NewLogr adapts a logr logger, logging debug messages to the debug sink.
+	NewLogr = log.NewLogr
+	// NewMapper creates the default mapper from the aggregation rules.
+	NewMapper = mapper.New
+	// NewCache creates the default cache, which evicts the least recently used keys over max entries and the keys
+	// that were not updated within the TTL.
+	NewCache = cache.NewCache
+	// DialUpstream is the default upstream dialer, which connects to the origin server over gRPC.
+	DialUpstream = upstream.New
+
+	// RecoveryInterceptors recover from panics in handlers, failing the stream or call with an INTERNAL status.
+	RecoveryInterceptors = interceptor.Recovery
+	// RequestIDInterceptors identify each stream and call by the request ID in a header, or by a generated ID.
+	RequestIDInterceptors = interceptor.RequestID
+	// RequestIDFromContext returns the request ID of the context of a handler.
+	RequestIDFromContext = interceptor.RequestIDFromContext
+)
+
+// Option replaces a component of the relay that is otherwise configured from the bootstrap.
+type Option func(*server.Components)
+
+// WithLogger logs to the logger instead of the logger configured by the logging section of the bootstrap.
+func WithLogger(logger Logger) Option {
+	return func(components *server.Components) {
+		components.Logger = logger
+	}
+}
+
+// WithMetricsScope records metrics to the scope instead of the statsd sink of the bootstrap. The relay does not
+// close the scope.
+func WithMetricsScope(scope tally.Scope) Option {
+	return func(components *server.Components) {
+		components.Scope = scope
+	}
+}
+
+// WithMapper aggregates requests with the mapper instead of the aggregation rules.
+func WithMapper(mapper Mapper) Option {
+	return func(components *server.Components) {
+		components.Mapper = mapper
+	}
+}
+
+// WithCacheFactory creates the cache with the factory instead of the cache section of the bootstrap.
+func WithCacheFactory(factory CacheFactory) Option {
+	return func(components *server.Components) {
+		components.CacheFactory = factory
+	}
+}
+
+// WithUpstreamDialer creates the clients of the origin server and of the shadow origin server with the dialer.
+func WithUpstreamDialer(dialer UpstreamDialer) Option {
+	return func(components *server.Components) {
+		components.UpstreamDialer = dialer
+	}
+}
+
+// WithInterceptors runs the interceptors on the streams and calls of the xDS server, after the built-in interceptors
+// configured in the bootstrap and admission control. Interceptors of multiple options run in the order of the options.
+func WithInterceptors(interceptors Interceptors) Option {
+	return func(components *server.Components) {
+		components.Interceptors.Append(interceptors)
+	}
+}
+
+// WithCallbacks calls the callbacks at the hook points of the xDS protocol, e.g. to audit requests or enforce quotas.
+// The callbacks run after the relay's own, so responses carry the nonces the relay sends, and an error returned by
+// OnStreamOpen, OnStreamRequest, or OnFetchRequest fails the stream or call. Callbacks of multiple options run in the
+// order of the options.
+func WithCallbacks(callbacks Callbacks) Option {
+	return func(components *server.Components) {
+		components.Callbacks = append(components.Callbacks, callbacks)
+	}
+}
+
+// Run validates the configurations and serves xDS requests, as well as the admin API, at the addresses of the
+// bootstrap until ctx is done. The aggregation rules are only used when no mapper is provided.
+func Run(
+	ctx context.Context,
+	bootstrapConfig *bootstrapv1.Bootstrap,
+	aggregationRulesConfig *aggregationv1.KeyerConfiguration,
+	opts ...Option,
+) error {
+	var components server.Components
+	for _, opt := range opts {
+		opt(&components)
+	}
+	if err := yamlproto.ValidateBootstrap(bootstrapConfig); err != nil {
+		return err
+	}
+	if components.Mapper == nil {
+		if err := aggregationRulesConfig.Validate(); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	server.RunWithComponents(ctx, cancel, bootstrapConfig, aggregationRulesConfig, components, "serve")
+	return nil
+}
@@ -0,0 +1,139 @@
+package relay
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	"github.com/envoyproxy/xds-relay/internal/app/client"
+	"github.com/envoyproxy/xds-relay/internal/app/upstream"
+	"github.com/envoyproxy/xds-relay/internal/pkg/util/testutils"
+	aggregationv1 "github.com/envoyproxy/xds-relay/pkg/api/aggregation/v1"
+	bootstrapv1 "github.com/envoyproxy/xds-relay/pkg/api/bootstrap/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+)
+
+type typeMapper struct{}
+
+func (typeMapper) GetKey(request v2.DiscoveryRequest) (string, error) {
+	return request.GetTypeUrl(), nil
+}
+
+type staticClient struct {
+	resp *v2.DiscoveryResponse
+}
+
+func (c staticClient) OpenStream(v2.DiscoveryRequest) (<-chan *v2.DiscoveryResponse, func(), error) {
+	ch := make(chan *v2.DiscoveryResponse, 1)
+	ch <- c.resp
+	return ch, func() {}, nil
+}
+
+func freeAddress(t *testing.T) *bootstrapv1.SocketAddress {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	return &bootstrapv1.SocketAddress{
+		Address:   "127.0.0.1",
+		PortValue: uint32(listener.Addr().(*net.TCPAddr).Port),
+	}
+}
+
+func newBootstrap(t *testing.T) *bootstrapv1.Bootstrap {
+	return &bootstrapv1.Bootstrap{
+		Server:       &bootstrapv1.Server{Address: freeAddress(t)},
+		OriginServer: &bootstrapv1.Upstream{Address: &bootstrapv1.SocketAddress{Address: "origin", PortValue: 18000}},
+		Logging:      &bootstrapv1.Logging{Level: bootstrapv1.Logging_ERROR},
+		Cache:        &bootstrapv1.Cache{Ttl: ptypes.DurationProto(time.Minute), MaxEntries: 10},
+		Admin:        &bootstrapv1.Admin{Address: freeAddress(t)},
+		MetricsSink: &bootstrapv1.MetricsSink{
+			Type: &bootstrapv1.MetricsSink_Statsd{
+				Statsd: &bootstrapv1.Statsd{
+					Address:       &bootstrapv1.SocketAddress{Address: "127.0.0.1", PortValue: 8125},
+					RootPrefix:    "xdsrelay",
+					FlushInterval: ptypes.DurationProto(time.Second),
+				},
+			},
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	bootstrap := newBootstrap(t)
+	scope := tally.NewTestScope("embedded", nil)
+	dialedAddresses := make(chan string, 1)
+	cacheCreated := make(chan bool, 1)
+	interceptedMethods := make(chan string, 10)
+	responseNonces := make(chan string, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Run(ctx, bootstrap, nil,
+			WithLogger(NewLogger("error")),
+			WithMetricsScope(scope),
+			WithMapper(typeMapper{}),
+			WithCacheFactory(func(onEvicted OnEvictFunc) (Cache, error) {
+				cacheCreated <- true
+				return NewCache(10, onEvicted, time.Minute)
+			}),
+			WithUpstreamDialer(func(
+				ctx context.Context, address string, callOptions CallOptions, logger Logger) (UpstreamClient, error) {
+				dialedAddresses <- address
+				return staticClient{resp: &v2.DiscoveryResponse{VersionInfo: "1", TypeUrl: upstream.ClusterTypeURL}}, nil
+			}),
+			WithInterceptors(Interceptors{Stream: []grpc.StreamServerInterceptor{func(srv interface{},
+				ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				interceptedMethods <- info.FullMethod
+				return handler(srv, ss)
+			}}}),
+			WithCallbacks(CallbackFuncs{StreamResponseFunc: func(streamID int64, req *v2.DiscoveryRequest,
+				resp *v2.DiscoveryResponse) {
+				responseNonces <- resp.GetNonce()
+			}}),
+		)
+	}()
+
+	var resp *v2.DiscoveryResponse
+	var err error
+	for i := 0; i < 50; i++ {
+		resp, err = client.Fetch(ctx, NewLogger("error"), client.FetchOptions{
+			ServerAddress: net.JoinHostPort(bootstrap.Server.Address.Address,
+				strconv.FormatUint(uint64(bootstrap.Server.Address.PortValue), 10)),
+			NodeID:       "node",
+			ResourceType: "cluster",
+			Timeout:      time.Second,
+		})
+		if err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, "1", resp.GetVersionInfo())
+	assert.Equal(t, "origin:18000", <-dialedAddresses)
+	assert.True(t, <-cacheCreated)
+	assert.Equal(t, "/envoy.api.v2.ClusterDiscoveryService/StreamClusters", <-interceptedMethods)
+	// The callbacks observe the nonce set by the relay.
+	assert.Equal(t, resp.GetNonce(), <-responseNonces)
+	testutils.AssertCounterValue(t, scope.Snapshot().Counters(), "embedded.server.alive", 1)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("relay did not stop once the context was done")
+	}
+}
+
+func TestRun_InvalidConfiguration(t *testing.T) {
+	err := Run(context.Background(), &bootstrapv1.Bootstrap{}, &aggregationv1.KeyerConfiguration{})
+	assert.Error(t, err)
+}
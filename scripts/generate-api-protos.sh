@@ -31,7 +31,7 @@ modpath() {
 protoc_command() {
   echo "${1}"
   "${PROTOC_BIN}" "${IPATHS[@]}" \
-    --go_out=paths=source_relative:./pkg/api \
+    --go_out=plugins=grpc,paths=source_relative:./pkg/api \
     --plugin=protoc-gen-validate="${GOBIN}/protoc-gen-validate" \
     --validate_out="lang=go:pkg/api" \
     --plugin=protoc-gen-go="${GOBIN}/protoc-gen-go" \
@@ -39,10 +39,11 @@ protoc_command() {
 }
 
 main() {
-  # Install required plugins for generation. These deps are pinned in go.mod.
+  # Install required plugins for generation. These deps are pinned in go.mod. The github.com/golang/protobuf
+  # protoc-gen-go wraps the google.golang.org/protobuf generator and additionally generates gRPC services.
   go install \
     github.com/envoyproxy/protoc-gen-validate \
-    google.golang.org/protobuf/cmd/protoc-gen-go
+    github.com/golang/protobuf/protoc-gen-go
 
   # Include .proto deps from go modules.
   IPATHS=(
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/loadtest"
+	relaylog "github.com/envoyproxy/xds-relay/internal/pkg/log"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	options  loadtest.Options
+	logLevel string
+
+	loadtestCmd = &cobra.Command{
+		Use:   "loadtest",
+		Short: "A tool to load test xds-relay with many simulated Envoy clients",
+		Long: `loadtest opens a stream from each of many simulated Envoy clients to an xDS server, such as xds-relay,
+keeps the clients connected for the duration of the run, and reports the time each client took to receive its first
+response, and the time new versions took to fan out to all clients.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			report, err := loadtest.Run(context.Background(), relaylog.New(logLevel), options)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Print(report)
+		},
+	}
+)
+
+func main() {
+	loadtestCmd.Flags().StringVarP(&options.ServerAddress, "server", "s", "localhost:9991",
+		"address of the xDS server")
+	loadtestCmd.Flags().IntVarP(&options.Clients, "clients", "c", 100, "number of simulated clients")
+	loadtestCmd.Flags().StringVar(&options.NodeIDPrefix, "node-id-prefix", "loadtest-",
+		"prefix of the node IDs of the clients, which is followed by the index of the client")
+	loadtestCmd.Flags().IntVar(&options.DistinctNodes, "distinct-nodes", 0,
+		"number of distinct node IDs shared by the clients. Every client has its own node ID if unset")
+	loadtestCmd.Flags().StringVar(&options.NodeCluster, "node-cluster", "",
+		"node cluster sent in the discovery requests")
+	loadtestCmd.Flags().StringVarP(&options.ResourceType, "type", "t", "",
+		"resource type: listener, cluster, route, endpoint, runtime, or a type URL")
+	loadtestCmd.Flags().StringSliceVarP(&options.ResourceNames, "resource-names", "r", nil,
+		"resource names to request. All resources are requested if unset")
+	loadtestCmd.Flags().Float64Var(&options.ConnectRate, "connect-rate", 0,
+		"number of clients that connect per second. All clients connect at once if unset")
+	loadtestCmd.Flags().StringVar(&options.AckMode, "ack-mode", loadtest.AckModeAck,
+		"how clients respond to responses: ack, nack, or none")
+	loadtestCmd.Flags().DurationVarP(&options.Duration, "duration", "d", time.Minute, "duration of the run")
+	loadtestCmd.Flags().StringVarP(&logLevel, "log-level", "l", "error", "the logging level")
+	if err := loadtestCmd.MarkFlagRequired("type"); err != nil {
+		log.Fatal("Could not mark the type flag as required")
+	}
+
+	if err := loadtestCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
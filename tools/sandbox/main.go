@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/sandbox"
+	relaylog "github.com/envoyproxy/xds-relay/internal/pkg/log"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	configOptions    = sandbox.DefaultConfigOptions
+	outputDirectory  string
+	smokeTestOptions sandbox.SmokeTestOptions
+	logLevel         string
+
+	sandboxCmd = &cobra.Command{
+		Use:   "sandbox",
+		Short: "A tool to configure and smoke test the local sandbox",
+		Long: `sandbox generates the configuration of the docker-compose sandbox, in which an Envoy is served by
+xds-relay, which is in turn served by the management server simulator, and smoke tests the running sandbox.
+`,
+	}
+
+	generateCmd = &cobra.Command{
+		Use:   "generate",
+		Short: "Generate the configuration of xds-relay and Envoy",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := sandbox.Generate(configOptions, outputDirectory); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+
+	smokeTestCmd = &cobra.Command{
+		Use:   "smoke-test",
+		Short: "Validate that resources flow from the simulator through xds-relay to Envoy",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := sandbox.SmokeTest(context.Background(), relaylog.New(logLevel), smokeTestOptions); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+)
+
+func main() {
+	generateCmd.Flags().StringVarP(&outputDirectory, "output-directory", "o", "sandbox/config",
+		"directory that the configuration files are written to")
+	generateCmd.Flags().StringVar(&configOptions.RelayHost, "relay-host", configOptions.RelayHost,
+		"host of xds-relay, as addressed by Envoy")
+	generateCmd.Flags().Uint32Var(&configOptions.RelayPort, "relay-port", configOptions.RelayPort,
+		"port of the xDS server of xds-relay")
+	generateCmd.Flags().Uint32Var(&configOptions.RelayAdminPort, "relay-admin-port", configOptions.RelayAdminPort,
+		"port of the admin server of xds-relay")
+	generateCmd.Flags().StringVar(&configOptions.SimulatorHost, "simulator-host", configOptions.SimulatorHost,
+		"host of the simulator, as addressed by xds-relay")
+	generateCmd.Flags().Uint32Var(&configOptions.SimulatorPort, "simulator-port", configOptions.SimulatorPort,
+		"port of the simulator")
+	generateCmd.Flags().Uint32Var(&configOptions.EnvoyAdminPort, "envoy-admin-port", configOptions.EnvoyAdminPort,
+		"port of the admin server of Envoy")
+	sandboxCmd.AddCommand(generateCmd)
+
+	smokeTestCmd.Flags().StringVar(&smokeTestOptions.RelayAddress, "relay", "localhost:9991",
+		"address of the xDS server of xds-relay")
+	smokeTestCmd.Flags().StringVar(&smokeTestOptions.RelayAdminAddress, "relay-admin", "localhost:6070",
+		"address of the admin server of xds-relay")
+	smokeTestCmd.Flags().StringVar(&smokeTestOptions.EnvoyAdminAddress, "envoy-admin", "localhost:9901",
+		"address of the admin server of Envoy")
+	smokeTestCmd.Flags().StringVar(&smokeTestOptions.ListenerAddress, "listener", "",
+		"address of an HTTP listener of Envoy to send traffic through. Traffic is not sent if unset")
+	smokeTestCmd.Flags().DurationVarP(&smokeTestOptions.Timeout, "timeout", "t", time.Minute,
+		"time that the sandbox has to become healthy")
+	smokeTestCmd.Flags().DurationVar(&smokeTestOptions.RetryInterval, "retry-interval", time.Second,
+		"time between attempts of a failing check")
+	smokeTestCmd.Flags().StringVarP(&logLevel, "log-level", "l", "info", "the logging level")
+	sandboxCmd.AddCommand(smokeTestCmd)
+
+	if err := sandboxCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
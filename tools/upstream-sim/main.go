@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/envoyproxy/xds-relay/internal/app/simulator"
+	relaylog "github.com/envoyproxy/xds-relay/internal/pkg/log"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	options      simulator.Options
+	upstreamPort uint
+	basePort     uint
+	logLevel     string
+
+	simulatorCmd = &cobra.Command{
+		Use:   "upstream-sim",
+		Short: "A tool that simulates a management server for running xds-relay locally",
+		Long: `upstream-sim serves synthetic listeners, clusters, routes, and endpoints to every node over xDS, in
+place of a real management server. A new version of the resources is served every update interval, so that the
+caching and fanout of xds-relay can be observed.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.UpstreamPort, options.BasePort = uint32(upstreamPort), uint32(basePort)
+			ctx, cancel := context.WithCancel(context.Background())
+			signals := make(chan os.Signal, 1)
+			signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+			go func() {
+				<-signals
+				cancel()
+			}()
+			if err := simulator.Run(ctx, relaylog.New(logLevel), options); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+)
+
+func main() {
+	simulatorCmd.Flags().StringVarP(&options.Address, "address", "a", "127.0.0.1:18000",
+		"address that the simulator listens on")
+	simulatorCmd.Flags().UintVar(&upstreamPort, "upstream-port", 18080,
+		"port of the single endpoint of every cluster, on localhost")
+	simulatorCmd.Flags().UintVar(&basePort, "base-port", 10000,
+		"port of the first listener. Listeners are assigned consecutive ports")
+	simulatorCmd.Flags().IntVar(&options.Clusters, "clusters", 4, "number of clusters")
+	simulatorCmd.Flags().IntVar(&options.HTTPListeners, "http-listeners", 2, "number of HTTP listeners")
+	simulatorCmd.Flags().IntVar(&options.TCPListeners, "tcp-listeners", 0, "number of TCP listeners")
+	simulatorCmd.Flags().DurationVarP(&options.UpdateInterval, "update-interval", "u", 10*time.Second,
+		"time between versions of the resources. Resources never change if zero")
+	simulatorCmd.Flags().StringVarP(&logLevel, "log-level", "l", "info", "the logging level")
+
+	if err := simulatorCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}